@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
@@ -19,6 +20,7 @@ import (
 	errs "github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	"github.com/bytebase/bytebase/backend/store"
@@ -34,6 +36,8 @@ const (
 	// MFATempTokenAudienceFmt is the format of the MFA temp token audience.
 	MFATempTokenAudienceFmt = "bb.user.mfa-temp.%s"
 	apiTokenDuration        = 1 * time.Hour
+	// accessTokenLastUsedInterval is the minimum interval in seconds to update the last used time of access tokens.
+	accessTokenLastUsedInterval = 60
 	// DefaultTokenDuration is the default token expiration duration.
 	DefaultTokenDuration = 7 * 24 * time.Hour
 
@@ -81,7 +85,7 @@ func (in *APIAuthInterceptor) AuthenticationInterceptor(ctx context.Context, req
 		return nil, status.Errorf(codes.Unauthenticated, err.Error())
 	}
 
-	principalID, err := in.authenticate(ctx, accessTokenStr)
+	principalID, accessToken, err := in.authenticate(ctx, accessTokenStr, serverInfo.FullMethod, request)
 	if err != nil {
 		if IsAuthenticationAllowed(serverInfo.FullMethod) {
 			return handler(ctx, request)
@@ -91,6 +95,9 @@ func (in *APIAuthInterceptor) AuthenticationInterceptor(ctx context.Context, req
 
 	// Stores principalID into context.
 	childCtx := context.WithValue(ctx, common.PrincipalIDContextKey, principalID)
+	if accessToken != nil {
+		childCtx = context.WithValue(childCtx, common.AccessTokenContextKey, accessToken)
+	}
 	return handler(childCtx, request)
 }
 
@@ -106,7 +113,8 @@ func (in *APIAuthInterceptor) AuthenticationStreamInterceptor(request any, ss gr
 		return status.Errorf(codes.Unauthenticated, err.Error())
 	}
 
-	principalID, err := in.authenticate(ctx, accessTokenStr)
+	// The stream request is not received yet, so the project scope of access tokens can't be checked.
+	principalID, accessToken, err := in.authenticate(ctx, accessTokenStr, serverInfo.FullMethod, nil /* request */)
 	if err != nil {
 		if IsAuthenticationAllowed(serverInfo.FullMethod) {
 			return handler(request, ss)
@@ -116,6 +124,9 @@ func (in *APIAuthInterceptor) AuthenticationStreamInterceptor(request any, ss gr
 
 	// Stores principalID into context.
 	childCtx := context.WithValue(ctx, common.PrincipalIDContextKey, principalID)
+	if accessToken != nil {
+		childCtx = context.WithValue(childCtx, common.AccessTokenContextKey, accessToken)
+	}
 	sss := overrideStream{ServerStream: ss, childCtx: childCtx}
 	return handler(request, sss)
 }
@@ -129,12 +140,13 @@ func (s overrideStream) Context() context.Context {
 	return s.childCtx
 }
 
-func (in *APIAuthInterceptor) authenticate(ctx context.Context, accessTokenStr string) (int, error) {
+// authenticate returns the principal ID, and the personal access token if the request is authenticated by one.
+func (in *APIAuthInterceptor) authenticate(ctx context.Context, accessTokenStr string, fullMethod string, request any) (int, *store.AccessTokenMessage, error) {
 	if accessTokenStr == "" {
-		return 0, status.Errorf(codes.Unauthenticated, "access token not found")
+		return 0, nil, status.Errorf(codes.Unauthenticated, "access token not found")
 	}
 	if _, ok := in.stateCfg.ExpireCache.Get(accessTokenStr); ok {
-		return 0, status.Errorf(codes.Unauthenticated, "access token expired")
+		return 0, nil, status.Errorf(codes.Unauthenticated, "access token expired")
 	}
 	claims := &claimsMessage{}
 	if _, err := jwt.ParseWithClaims(accessTokenStr, claims, func(t *jwt.Token) (any, error) {
@@ -150,12 +162,12 @@ func (in *APIAuthInterceptor) authenticate(ctx context.Context, accessTokenStr s
 	}); err != nil {
 		var ve *jwt.ValidationError
 		if errors.As(err, &ve) && ve.Errors == jwt.ValidationErrorExpired {
-			return 0, status.Errorf(codes.Unauthenticated, "access token expired")
+			return 0, nil, status.Errorf(codes.Unauthenticated, "access token expired")
 		}
-		return 0, status.Errorf(codes.Unauthenticated, "failed to parse claim")
+		return 0, nil, status.Errorf(codes.Unauthenticated, "failed to parse claim")
 	}
 	if !audienceContains(claims.Audience, fmt.Sprintf(AccessTokenAudienceFmt, in.mode)) {
-		return 0, status.Errorf(codes.Unauthenticated,
+		return 0, nil, status.Errorf(codes.Unauthenticated,
 			"invalid access token, audience mismatch, got %q, expected %q. you may send request to the wrong environment",
 			claims.Audience,
 			fmt.Sprintf(AccessTokenAudienceFmt, in.mode),
@@ -164,20 +176,60 @@ func (in *APIAuthInterceptor) authenticate(ctx context.Context, accessTokenStr s
 
 	principalID, err := strconv.Atoi(claims.Subject)
 	if err != nil {
-		return 0, status.Errorf(codes.Unauthenticated, "malformed ID %q in the access token", claims.Subject)
+		return 0, nil, status.Errorf(codes.Unauthenticated, "malformed ID %q in the access token", claims.Subject)
 	}
 	user, err := in.store.GetUserByID(ctx, principalID)
 	if err != nil {
-		return 0, status.Errorf(codes.Unauthenticated, "failed to find user ID %q in the access token", principalID)
+		return 0, nil, status.Errorf(codes.Unauthenticated, "failed to find user ID %q in the access token", principalID)
 	}
 	if user == nil {
-		return 0, status.Errorf(codes.Unauthenticated, "user ID %q not exists in the access token", principalID)
+		return 0, nil, status.Errorf(codes.Unauthenticated, "user ID %q not exists in the access token", principalID)
 	}
 	if user.MemberDeleted {
-		return 0, status.Errorf(codes.Unauthenticated, "user ID %q has been deactivated by administrators", principalID)
+		return 0, nil, status.Errorf(codes.Unauthenticated, "user ID %q has been deactivated by administrators", principalID)
+	}
+
+	// Personal access tokens carry their IDs and are restricted by their scopes.
+	if claims.ID != "" {
+		accessToken, err := in.authenticateAccessToken(ctx, claims.ID, principalID, fullMethod, request)
+		if err != nil {
+			return 0, nil, err
+		}
+		return principalID, accessToken, nil
 	}
 
-	return principalID, nil
+	return principalID, nil, nil
+}
+
+func (in *APIAuthInterceptor) authenticateAccessToken(ctx context.Context, accessTokenID string, principalID int, fullMethod string, request any) (*store.AccessTokenMessage, error) {
+	accessTokenUID, err := strconv.Atoi(accessTokenID)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "malformed access token ID %q", accessTokenID)
+	}
+	accessToken, err := in.store.GetAccessToken(ctx, &store.FindAccessTokenMessage{UID: &accessTokenUID, ShowRevoked: true})
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to find access token %d", accessTokenUID)
+	}
+	if accessToken == nil || accessToken.PrincipalUID != principalID {
+		return nil, status.Errorf(codes.Unauthenticated, "access token %d not found", accessTokenUID)
+	}
+	if accessToken.Revoked {
+		return nil, status.Errorf(codes.Unauthenticated, "access token %d has been revoked", accessTokenUID)
+	}
+	now := time.Now()
+	if now.Unix() >= accessToken.ExpireTs {
+		return nil, status.Errorf(codes.Unauthenticated, "access token expired")
+	}
+	if err := in.checkAccessTokenScope(ctx, accessToken, fullMethod, request); err != nil {
+		return nil, err
+	}
+	// Avoid writing the metadata database on every request.
+	if now.Unix()-accessToken.LastUsedTs >= accessTokenLastUsedInterval {
+		if err := in.store.UpdateAccessTokenLastUsedTs(ctx, accessToken.UID, now.Unix()); err != nil {
+			slog.Warn("failed to update the last used time of access token", slog.Int("id", accessToken.UID), log.BBError(err))
+		}
+	}
+	return accessToken, nil
 }
 
 // GetUserIDFromMFATempToken returns the user ID from the MFA temp token.
@@ -246,23 +298,28 @@ type claimsMessage struct {
 // GenerateAPIToken generates an API token.
 func GenerateAPIToken(userName string, userID int, mode common.ReleaseMode, secret string) (string, error) {
 	expirationTime := time.Now().Add(apiTokenDuration)
-	return generateToken(userName, userID, fmt.Sprintf(AccessTokenAudienceFmt, mode), expirationTime, []byte(secret))
+	return generateToken(userName, userID, "" /* tokenID */, fmt.Sprintf(AccessTokenAudienceFmt, mode), expirationTime, []byte(secret))
 }
 
 // GenerateAccessToken generates an access token for web.
 func GenerateAccessToken(userName string, userID int, mode common.ReleaseMode, secret string, tokenDuration time.Duration) (string, error) {
 	expirationTime := time.Now().Add(tokenDuration)
-	return generateToken(userName, userID, fmt.Sprintf(AccessTokenAudienceFmt, mode), expirationTime, []byte(secret))
+	return generateToken(userName, userID, "" /* tokenID */, fmt.Sprintf(AccessTokenAudienceFmt, mode), expirationTime, []byte(secret))
+}
+
+// GeneratePersonalAccessToken generates a personal access token restricted by the scopes of the stored access token.
+func GeneratePersonalAccessToken(userName string, userID int, accessTokenUID int, mode common.ReleaseMode, secret string, expirationTime time.Time) (string, error) {
+	return generateToken(userName, userID, strconv.Itoa(accessTokenUID), fmt.Sprintf(AccessTokenAudienceFmt, mode), expirationTime, []byte(secret))
 }
 
 // GenerateMFATempToken generates a temporary token for MFA.
 func GenerateMFATempToken(userName string, userID int, mode common.ReleaseMode, secret string, tokenDuration time.Duration) (string, error) {
 	expirationTime := time.Now().Add(tokenDuration)
-	return generateToken(userName, userID, fmt.Sprintf(MFATempTokenAudienceFmt, mode), expirationTime, []byte(secret))
+	return generateToken(userName, userID, "" /* tokenID */, fmt.Sprintf(MFATempTokenAudienceFmt, mode), expirationTime, []byte(secret))
 }
 
 // Pay attention to this function. It holds the main JWT token generation logic.
-func generateToken(userName string, userID int, aud string, expirationTime time.Time, secret []byte) (string, error) {
+func generateToken(userName string, userID int, tokenID string, aud string, expirationTime time.Time, secret []byte) (string, error) {
 	// Create the JWT claims, which includes the username and expiry time.
 	claims := &claimsMessage{
 		Name: userName,
@@ -273,6 +330,7 @@ func generateToken(userName string, userID int, aud string, expirationTime time.
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    issuer,
 			Subject:   strconv.Itoa(userID),
+			ID:        tokenID,
 		},
 	}
 
@@ -0,0 +1,319 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const apiPackagePrefix = "/bytebase.v1."
+
+// scopeFieldNames are the request fields referring to the resources accessed by the request.
+var scopeFieldNames = map[protoreflect.Name]bool{
+	"name":     true,
+	"parent":   true,
+	"project":  true,
+	"database": true,
+}
+
+// getMethodPermission returns the permission required by the method in the format of {resource}:{action}.
+// The resource is the service name without the "Service" suffix and the action is the first word of the method name, skipping "Batch".
+// For example, "/bytebase.v1.RolloutService/BatchRunTasks" requires "rollout:run".
+func getMethodPermission(fullMethod string) string {
+	if !strings.HasPrefix(fullMethod, apiPackagePrefix) {
+		return ""
+	}
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, apiPackagePrefix), "/")
+	if !ok {
+		return ""
+	}
+	resource := strings.ToLower(strings.TrimSuffix(serviceName, "Service"))
+
+	var words []string
+	start := 0
+	for i, r := range methodName {
+		if i > 0 && unicode.IsUpper(r) {
+			words = append(words, methodName[start:i])
+			start = i
+		}
+	}
+	words = append(words, methodName[start:])
+	action := words[0]
+	if action == "Batch" && len(words) > 1 {
+		action = words[1]
+	}
+	return fmt.Sprintf("%s:%s", resource, strings.ToLower(action))
+}
+
+// isPermissionGranted returns whether the permission is granted by the permission list.
+// An empty permission list grants all permissions.
+func isPermissionGranted(permissionList []string, permission string) bool {
+	if len(permissionList) == 0 {
+		return true
+	}
+	resource, _, _ := strings.Cut(permission, ":")
+	for _, p := range permissionList {
+		if p == permission || p == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAccessTokenScope checks the method and the request against the scopes of the access token.
+func (in *APIAuthInterceptor) checkAccessTokenScope(ctx context.Context, accessToken *store.AccessTokenMessage, fullMethod string, request any) error {
+	permission := getMethodPermission(fullMethod)
+	if !isPermissionGranted(accessToken.PermissionList, permission) {
+		return status.Errorf(codes.PermissionDenied, "access token does not have permission %q for method %q", permission, fullMethod)
+	}
+	if len(accessToken.ProjectList) == 0 {
+		return nil
+	}
+
+	projectIDs, err := in.getRequestProjectIDs(ctx, request)
+	if err != nil {
+		return status.Errorf(codes.PermissionDenied, "failed to get the projects of the request, error: %v", err)
+	}
+	if len(projectIDs) == 0 {
+		return status.Errorf(codes.PermissionDenied, "access token is restricted to projects, but method %q doesn't access a project", fullMethod)
+	}
+	allowed := make(map[string]bool)
+	for _, projectID := range accessToken.ProjectList {
+		allowed[projectID] = true
+	}
+	for _, projectID := range projectIDs {
+		if !allowed[projectID] {
+			return status.Errorf(codes.PermissionDenied, "access token is not allowed to access project %q", projectID)
+		}
+	}
+	return nil
+}
+
+// getRequestProjectIDs returns the projects of the resources referred by the request.
+// It looks into the resource name fields of the request and its top-level messages, and all the strings in lists and maps,
+// e.g. the task names of BatchRunTasks. It fails on the messages in lists or maps which cannot be looked into.
+func (in *APIAuthInterceptor) getRequestProjectIDs(ctx context.Context, request any) ([]string, error) {
+	message, ok := request.(proto.Message)
+	if !ok {
+		return nil, nil
+	}
+	names, err := collectRequestResourceNames(message.ProtoReflect(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	projectIDMap := make(map[string]bool)
+	for _, name := range names {
+		projectID, err := in.getResourceProjectID(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if projectID != "" {
+			projectIDMap[projectID] = true
+		}
+	}
+	var projectIDs []string
+	for projectID := range projectIDMap {
+		projectIDs = append(projectIDs, projectID)
+	}
+	return projectIDs, nil
+}
+
+func collectRequestResourceNames(m protoreflect.Message, depth int) ([]string, error) {
+	var names []string
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				switch fd.Kind() {
+				case protoreflect.StringKind:
+					names = append(names, list.Get(i).String())
+				case protoreflect.MessageKind:
+					if depth > 0 {
+						err = errors.Errorf("unsupported list field %q", fd.FullName())
+						return false
+					}
+					elementNames, elementErr := collectRequestResourceNames(list.Get(i).Message(), depth+1)
+					if elementErr != nil {
+						err = elementErr
+						return false
+					}
+					names = append(names, elementNames...)
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				err = errors.Errorf("unsupported map field %q", fd.FullName())
+				return false
+			}
+			if fd.MapValue().Kind() == protoreflect.StringKind {
+				v.Map().Range(func(_ protoreflect.MapKey, value protoreflect.Value) bool {
+					names = append(names, value.String())
+					return true
+				})
+			}
+		case fd.Kind() == protoreflect.StringKind && scopeFieldNames[fd.Name()]:
+			names = append(names, v.String())
+		case fd.Kind() == protoreflect.MessageKind && depth == 0:
+			fieldNames, fieldErr := collectRequestResourceNames(v.Message(), depth+1)
+			if fieldErr != nil {
+				err = fieldErr
+				return false
+			}
+			names = append(names, fieldNames...)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// CheckAccessTokenScopeSubset checks that the projects and permissions are within the scopes of the access token,
+// so that an access token cannot create another token with broader scopes.
+// Empty projects or permissions mean all of them.
+func CheckAccessTokenScopeSubset(accessToken *store.AccessTokenMessage, projectList []string, permissionList []string, expireTs int64) error {
+	if len(accessToken.ProjectList) > 0 {
+		if len(projectList) == 0 {
+			return errors.Errorf("access token is restricted to projects, the new token must be restricted to them too")
+		}
+		allowed := make(map[string]bool)
+		for _, projectID := range accessToken.ProjectList {
+			allowed[projectID] = true
+		}
+		for _, projectID := range projectList {
+			if !allowed[projectID] {
+				return errors.Errorf("access token is not allowed to access project %q", projectID)
+			}
+		}
+	}
+	if len(accessToken.PermissionList) > 0 {
+		if len(permissionList) == 0 {
+			return errors.Errorf("access token is restricted to permissions, the new token must be restricted to them too")
+		}
+		for _, permission := range permissionList {
+			if !isPermissionGranted(accessToken.PermissionList, permission) {
+				return errors.Errorf("access token does not have permission %q", permission)
+			}
+		}
+	}
+	if expireTs > accessToken.ExpireTs {
+		return errors.Errorf("the new token cannot expire later than the access token")
+	}
+	return nil
+}
+
+// getResourceProjectID returns the project of the project or database resource, or empty for other resources.
+// The resources in projects referred by UIDs, e.g. issues, are loaded to check that they belong to the project in the name,
+// so that a token restricted to a project cannot access the resources of other projects by rewriting the project in the name.
+func (in *APIAuthInterceptor) getResourceProjectID(ctx context.Context, name string) (string, error) {
+	tokens := strings.Split(name, "/")
+	if len(tokens) < 2 {
+		return "", nil
+	}
+	switch tokens[0] + "/" {
+	case common.ProjectNamePrefix:
+		return checkProjectResource(ctx, tokens, in.getStoredResourceProjectID)
+	case common.InstanceNamePrefix:
+		if len(tokens) < 4 || tokens[2]+"/" != common.DatabaseIDPrefix {
+			return "", nil
+		}
+		database, err := in.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+			InstanceID:   &tokens[1],
+			DatabaseName: &tokens[3],
+		})
+		if err != nil {
+			return "", err
+		}
+		if database == nil {
+			return "", errors.Errorf("database %q not found", name)
+		}
+		return database.ProjectID, nil
+	}
+	return "", nil
+}
+
+// resourceProjectGetter returns the project of the stored resource of the collection in a project by its UID, or empty if not found.
+type resourceProjectGetter func(ctx context.Context, collection string, uid int) (string, error)
+
+// checkProjectResource returns the project of the resource name tokens starting with the project,
+// and checks that the resource referred by UID belongs to the project. The project "-" is resolved from the resource.
+func checkProjectResource(ctx context.Context, tokens []string, getResourceProjectID resourceProjectGetter) (string, error) {
+	projectID := tokens[1]
+	if len(tokens) < 4 {
+		return projectID, nil
+	}
+	collection := tokens[2] + "/"
+	switch collection {
+	case common.IssuePrefix, common.PlanPrefix, common.RolloutPrefix, common.SheetIDPrefix, common.SchemaDesignPrefix:
+	default:
+		return projectID, nil
+	}
+	uid, err := strconv.Atoi(tokens[3])
+	if err != nil {
+		// Let the method report the malformed name.
+		return projectID, nil
+	}
+	resourceProjectID, err := getResourceProjectID(ctx, collection, uid)
+	if err != nil {
+		return "", err
+	}
+	if resourceProjectID == "" {
+		return projectID, nil
+	}
+	if projectID != "-" && projectID != resourceProjectID {
+		return "", errors.Errorf("%s%d does not belong to project %q", collection, uid, projectID)
+	}
+	return resourceProjectID, nil
+}
+
+// getStoredResourceProjectID returns the project of the stored resource of the collection by its UID, or empty if not found.
+func (in *APIAuthInterceptor) getStoredResourceProjectID(ctx context.Context, collection string, uid int) (string, error) {
+	switch collection {
+	case common.IssuePrefix:
+		issue, err := in.store.GetIssueV2(ctx, &store.FindIssueMessage{UID: &uid})
+		if err != nil || issue == nil {
+			return "", err
+		}
+		return issue.Project.ResourceID, nil
+	case common.PlanPrefix:
+		planUID := int64(uid)
+		plan, err := in.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planUID})
+		if err != nil || plan == nil {
+			return "", err
+		}
+		return plan.ProjectID, nil
+	case common.RolloutPrefix:
+		pipeline, err := in.store.GetPipelineV2ByID(ctx, uid)
+		if err != nil || pipeline == nil {
+			return "", err
+		}
+		return pipeline.ProjectID, nil
+	case common.SheetIDPrefix, common.SchemaDesignPrefix:
+		sheet, err := in.store.GetSheet(ctx, &store.FindSheetMessage{UID: &uid}, store.SystemBotID)
+		if err != nil || sheet == nil {
+			return "", err
+		}
+		project, err := in.store.GetProjectV2(ctx, &store.FindProjectMessage{UID: &sheet.ProjectUID})
+		if err != nil || project == nil {
+			return "", err
+		}
+		return project.ResourceID, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestGetMethodPermission(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		want       string
+	}{
+		{"/bytebase.v1.RolloutService/BatchRunTasks", "rollout:run"},
+		{"/bytebase.v1.SQLService/Query", "sql:query"},
+		{"/bytebase.v1.DatabaseService/ListDatabases", "database:list"},
+		{"/bytebase.v1.OrgPolicyService/GetPolicy", "orgpolicy:get"},
+		{"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", ""},
+	}
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, getMethodPermission(test.fullMethod), test.fullMethod)
+	}
+}
+
+func TestIsPermissionGranted(t *testing.T) {
+	tests := []struct {
+		permissionList []string
+		permission     string
+		want           bool
+	}{
+		{nil, "sql:query", true},
+		{[]string{"rollout:run"}, "rollout:run", true},
+		{[]string{"rollout:run"}, "sql:query", false},
+		{[]string{"rollout:*"}, "rollout:get", true},
+		{[]string{"rollout:*"}, "sql:query", false},
+	}
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, isPermissionGranted(test.permissionList, test.permission), "%v %s", test.permissionList, test.permission)
+	}
+}
+
+func TestCheckAccessTokenScopeSubset(t *testing.T) {
+	accessToken := &store.AccessTokenMessage{
+		ProjectList:    []string{"p1", "p2"},
+		PermissionList: []string{"rollout:run", "sql:*"},
+		ExpireTs:       100,
+	}
+	tests := []struct {
+		projectList    []string
+		permissionList []string
+		expireTs       int64
+		wantErr        bool
+	}{
+		{[]string{"p1"}, []string{"rollout:run", "sql:query"}, 100, false},
+		{[]string{"p1"}, []string{"sql:*"}, 50, false},
+		{nil, []string{"rollout:run"}, 100, true},
+		{[]string{"p3"}, []string{"rollout:run"}, 100, true},
+		{[]string{"p1"}, nil, 100, true},
+		{[]string{"p1"}, []string{"rollout:*"}, 100, true},
+		{[]string{"p1"}, []string{"rollout:run"}, 101, true},
+	}
+	a := require.New(t)
+	for _, test := range tests {
+		err := CheckAccessTokenScopeSubset(accessToken, test.projectList, test.permissionList, test.expireTs)
+		if test.wantErr {
+			a.Error(err, "%v %v %d", test.projectList, test.permissionList, test.expireTs)
+		} else {
+			a.NoError(err, "%v %v %d", test.projectList, test.permissionList, test.expireTs)
+		}
+	}
+}
+
+func TestCollectRequestResourceNames(t *testing.T) {
+	a := require.New(t)
+	names, err := collectRequestResourceNames((&v1pb.BatchRunTasksRequest{
+		Parent: "projects/p1/rollouts/1",
+		Tasks:  []string{"projects/p2/rollouts/2/stages/3/tasks/4"},
+	}).ProtoReflect(), 0)
+	a.NoError(err)
+	a.ElementsMatch([]string{"projects/p1/rollouts/1", "projects/p2/rollouts/2/stages/3/tasks/4"}, names)
+}
+
+func TestCheckProjectResource(t *testing.T) {
+	projects := map[string]string{
+		"issues/1":        "p1",
+		"plans/2":         "p1",
+		"sheets/3":        "p1",
+		"issues/11":       "p2",
+		"plans/12":        "p2",
+		"sheets/13":       "p2",
+		"schemaDesigns/3": "p1",
+	}
+	getResourceProjectID := func(_ context.Context, collection string, uid int) (string, error) {
+		return projects[fmt.Sprintf("%s%d", collection, uid)], nil
+	}
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"projects/p1", "p1", false},
+		{"projects/p1/databaseGroups/g1", "p1", false},
+		{"projects/p1/issues/1", "p1", false},
+		{"projects/p1/plans/2", "p1", false},
+		{"projects/p1/sheets/3", "p1", false},
+		{"projects/p1/sheets/3/revisions/4", "p1", false},
+		{"projects/p1/schemaDesigns/3", "p1", false},
+		// The resources of other projects.
+		{"projects/p1/issues/11", "", true},
+		{"projects/p1/plans/12", "", true},
+		{"projects/p1/plans/12/planCheckRuns/5", "", true},
+		{"projects/p1/sheets/13", "", true},
+		// The project is resolved from the resource.
+		{"projects/-/issues/11", "p2", false},
+		{"projects/-/sheets/13", "p2", false},
+		// The missing resources are reported by the methods.
+		{"projects/p1/issues/100", "p1", false},
+	}
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := checkProjectResource(context.Background(), strings.Split(test.name, "/"), getResourceProjectID)
+		if test.wantErr {
+			a.Error(err, test.name)
+			continue
+		}
+		a.NoError(err, test.name)
+		a.Equal(test.want, got, test.name)
+	}
+}
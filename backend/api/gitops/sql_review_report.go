@@ -0,0 +1,284 @@
+package gitops
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	// sqlReviewToolName is the tool name shown in the code scanning and test reports.
+	sqlReviewToolName = "Bytebase SQL Review"
+	sqlReviewToolURI  = "https://www.bytebase.com/docs/sql-review/overview"
+)
+
+// sarifLog is the SARIF 2.1.0 log, only the properties used by the SQL review are defined.
+// Spec: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string              `json:"id"`
+	ShortDescription sarifMessage        `json:"shortDescription"`
+	HelpURI          string              `json:"helpUri"`
+	Properties       sarifRuleProperties `json:"properties"`
+}
+
+type sarifRuleProperties struct {
+	Code advisor.Code `json:"code"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// convertSQLAdviceToSARIFResult will convert SQL advice map to SARIF 2.1.0 format.
+// GitHub code scanning: https://docs.github.com/en/code-security/code-scanning/integrating-with-code-scanning/sarif-support-for-code-scanning
+func convertSQLAdviceToSARIFResult(adviceMap map[string][]advisor.Advice) (*api.VCSSQLReviewResult, error) {
+	status := advisor.Success
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           sqlReviewToolName,
+				InformationURI: sqlReviewToolURI,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+	ruleIndex := map[string]int{}
+
+	for _, filePath := range getSQLAdviceFileList(adviceMap) {
+		for _, advice := range adviceMap[filePath] {
+			if advice.Code == 0 || advice.Status == advisor.Success {
+				continue
+			}
+			status = mergeSQLReviewStatus(status, advice.Status)
+
+			ruleID := getSQLReviewRuleID(advice)
+			index, ok := ruleIndex[ruleID]
+			if !ok {
+				index = len(run.Tool.Driver.Rules)
+				ruleIndex[ruleID] = index
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:               ruleID,
+					ShortDescription: sarifMessage{Text: advice.Title},
+					HelpURI:          getSQLReviewHelpURI(advice.Code),
+					Properties:       sarifRuleProperties{Code: advice.Code},
+				})
+			}
+
+			level := "warning"
+			if advice.Status == advisor.Error {
+				level = "error"
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    ruleID,
+				RuleIndex: index,
+				Level:     level,
+				Message:   sarifMessage{Text: advice.Content},
+				Locations: []sarifLocation{
+					{
+						PhysicalLocation: sarifPhysicalLocation{
+							ArtifactLocation: sarifArtifactLocation{URI: filePath},
+							Region:           getSARIFRegion(advice),
+						},
+					},
+				},
+			})
+		}
+	}
+
+	content, err := json.MarshalIndent(&sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &api.VCSSQLReviewResult{
+		Status:  status,
+		Content: []string{string(content)},
+	}, nil
+}
+
+func getSARIFRegion(advice advisor.Advice) sarifRegion {
+	region := sarifRegion{
+		StartLine: advice.Line,
+	}
+	if region.StartLine <= 0 {
+		region.StartLine = 1
+	}
+	// The column is optional in SARIF, and it's 1-based.
+	if advice.Column > 0 {
+		region.StartColumn = advice.Column
+	}
+	return region
+}
+
+// junitTestSuites is the JUnit XML report.
+// Schema: https://llg.cubic.org/docs/junit/
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// convertSQLAdviceToJUnitResult will convert SQL advice map to JUnit XML format.
+// Each file is a test suite, and each advice is a failed test case. The file without advice has a passed test case.
+// GitLab test report: https://docs.gitlab.com/ee/ci/testing/unit_test_reports.html
+// Azure DevOps test report: https://learn.microsoft.com/en-us/azure/devops/pipelines/tasks/reference/publish-test-results-v2
+func convertSQLAdviceToJUnitResult(adviceMap map[string][]advisor.Advice) (*api.VCSSQLReviewResult, error) {
+	status := advisor.Success
+	testSuites := junitTestSuites{
+		Name: sqlReviewToolName,
+	}
+
+	for _, filePath := range getSQLAdviceFileList(adviceMap) {
+		testSuite := junitTestSuite{
+			Name: filePath,
+		}
+		for _, advice := range adviceMap[filePath] {
+			if advice.Code == 0 || advice.Status == advisor.Success {
+				continue
+			}
+			status = mergeSQLReviewStatus(status, advice.Status)
+
+			line := advice.Line
+			if line <= 0 {
+				line = 1
+			}
+			ruleID := getSQLReviewRuleID(advice)
+			testSuite.TestCases = append(testSuite.TestCases, junitTestCase{
+				Name:      fmt.Sprintf("[%s] %s#L%d: %s", advice.Status, filePath, line, ruleID),
+				ClassName: filePath,
+				File:      filePath,
+				Line:      line,
+				Failure: &junitFailure{
+					Message: advice.Content,
+					Type:    ruleID,
+					Text:    fmt.Sprintf("%s\nPlease check the docs at %s", advice.Content, getSQLReviewHelpURI(advice.Code)),
+				},
+			})
+			testSuite.Failures++
+		}
+		if len(testSuite.TestCases) == 0 {
+			testSuite.TestCases = append(testSuite.TestCases, junitTestCase{
+				Name:      filePath,
+				ClassName: filePath,
+				File:      filePath,
+			})
+		}
+		testSuite.Tests = len(testSuite.TestCases)
+
+		testSuites.Tests += testSuite.Tests
+		testSuites.Failures += testSuite.Failures
+		testSuites.TestSuites = append(testSuites.TestSuites, testSuite)
+	}
+
+	content, err := xml.MarshalIndent(&testSuites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &api.VCSSQLReviewResult{
+		Status:  status,
+		Content: []string{xml.Header + string(content)},
+	}, nil
+}
+
+// getSQLReviewRuleID returns the rule ID of the advice.
+// The advice title is the SQL review rule type, such as "naming.table", for the advices from the SQL review rules.
+func getSQLReviewRuleID(advice advisor.Advice) string {
+	if advice.Title == "" {
+		return fmt.Sprintf("%d", advice.Code)
+	}
+	return advice.Title
+}
+
+func getSQLReviewHelpURI(code advisor.Code) string {
+	return fmt.Sprintf("%s#%d", sqlReviewDocs, code)
+}
+
+func mergeSQLReviewStatus(status advisor.Status, adviceStatus advisor.Status) advisor.Status {
+	switch {
+	case adviceStatus == advisor.Error:
+		return advisor.Error
+	case adviceStatus == advisor.Warn && status != advisor.Error:
+		return advisor.Warn
+	default:
+		return status
+	}
+}
@@ -570,6 +570,11 @@ func (s *Service) RegisterWebhookRoutes(g *echo.Group) {
 		if err := json.Unmarshal(body, &request); err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, "Malformed SQL review request").SetInternal(err)
 		}
+		switch request.Format {
+		case api.VCSSQLReviewResultFormatDefault, api.VCSSQLReviewResultFormatSARIF, api.VCSSQLReviewResultFormatJUnit:
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Unsupported SQL review result format %q", request.Format))
+		}
 
 		workspaceID, err := s.store.GetWorkspaceID(ctx)
 		if err != nil {
@@ -694,17 +699,31 @@ func (s *Service) RegisterWebhookRoutes(g *echo.Group) {
 		}
 
 		response := &api.VCSSQLReviewResult{}
-		switch repo.vcs.Type {
-		case vcs.GitHub:
-			response = convertSQLAdviceToGitHubActionResult(sqlFileName2Advice)
-		case vcs.GitLab:
-			response = convertSQLAdviceToGitLabCIResult(sqlFileName2Advice)
-		case vcs.AzureDevOps:
-			response = convertSQLAdviceToGitLabCIResult(sqlFileName2Advice)
+		switch request.Format {
+		case api.VCSSQLReviewResultFormatSARIF:
+			response, err = convertSQLAdviceToSARIFResult(sqlFileName2Advice)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to convert SQL review result to SARIF").SetInternal(err)
+			}
+		case api.VCSSQLReviewResultFormatJUnit:
+			response, err = convertSQLAdviceToJUnitResult(sqlFileName2Advice)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to convert SQL review result to JUnit").SetInternal(err)
+			}
+		default:
+			switch repo.vcs.Type {
+			case vcs.GitHub:
+				response = convertSQLAdviceToGitHubActionResult(sqlFileName2Advice)
+			case vcs.GitLab:
+				response = convertSQLAdviceToGitLabCIResult(sqlFileName2Advice)
+			case vcs.AzureDevOps:
+				response = convertSQLAdviceToGitLabCIResult(sqlFileName2Advice)
+			}
 		}
 
 		slog.Debug("SQL review finished",
 			slog.String("pull_request", request.PullRequestID),
+			slog.String("format", string(request.Format)),
 			slog.String("status", string(response.Status)),
 			slog.String("content", strings.Join(response.Content, "\n")),
 			slog.String("repository_id", request.RepositoryID),
@@ -740,7 +759,7 @@ func (s *Service) sqlAdviceForMybatisMapperFiles(ctx context.Context, mybatisMap
 		wg.Add(1)
 		go func(datum *mybatisMapperXMLFileDatum) {
 			defer wg.Done()
-			adviceList, err := s.sqlAdviceForMybatisMapperFile(ctx, datum)
+			adviceList, err := s.sqlAdviceForMybatisMapperFile(ctx, datum, repoInfo.project.ResourceID)
 			if err != nil {
 				slog.Error(
 					"Failed to take SQL review for file",
@@ -767,7 +786,7 @@ func (s *Service) sqlAdviceForMybatisMapperFiles(ctx context.Context, mybatisMap
 	return sqlCheckAdvices, nil
 }
 
-func (s *Service) sqlAdviceForMybatisMapperFile(ctx context.Context, datum *mybatisMapperXMLFileDatum) ([]advisor.Advice, error) {
+func (s *Service) sqlAdviceForMybatisMapperFile(ctx context.Context, datum *mybatisMapperXMLFileDatum, projectID string) ([]advisor.Advice, error) {
 	var result []advisor.Advice
 	var environmentIDs []string
 	// If the configuration file is found, we extract the environment from the configuration file.
@@ -819,8 +838,10 @@ func (s *Service) sqlAdviceForMybatisMapperFile(ctx context.Context, datum *myba
 					return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to convert to advisor db type").SetInternal(err)
 				}
 				adviceList, err := advisor.SQLReviewCheck(mybatisSQLs, policy.RuleList, advisor.SQLReviewCheckContext{
-					Catalog: emptyCatalog,
-					DbType:  dbType,
+					Catalog:       emptyCatalog,
+					DbType:        dbType,
+					EnvironmentID: env.ResourceID,
+					ProjectID:     projectID,
 				})
 				if err != nil {
 					return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to check sql review").SetInternal(err)
@@ -1026,12 +1047,14 @@ func (s *Service) sqlAdviceForFile(
 			return nil, errors.Errorf("database schema %v not found", database.UID)
 		}
 		adviceList, err := advisor.SQLReviewCheck(fileContent, policy.RuleList, advisor.SQLReviewCheckContext{
-			Charset:   dbSchema.Metadata.CharacterSet,
-			Collation: dbSchema.Metadata.Collation,
-			DbType:    dbType,
-			Catalog:   catalog,
-			Driver:    connection,
-			Context:   ctx,
+			Charset:       dbSchema.Metadata.CharacterSet,
+			Collation:     dbSchema.Metadata.Collation,
+			DbType:        dbType,
+			Catalog:       catalog,
+			Driver:        connection,
+			Context:       ctx,
+			EnvironmentID: environment.ResourceID,
+			ProjectID:     database.ProjectID,
 		})
 		driver.Close(ctx)
 		if err != nil {
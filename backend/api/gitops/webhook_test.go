@@ -1,6 +1,8 @@
 package gitops
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -185,6 +187,79 @@ func TestVCSSQLReview_ConvertSQLAdviceToGitHubActionResult(t *testing.T) {
 	assert.Equal(t, expect, res.Content)
 }
 
+func TestVCSSQLReview_ConvertSQLAdviceToSARIFResult(t *testing.T) {
+	res, err := convertSQLAdviceToSARIFResult(mockSQLAdviceMap)
+	require.NoError(t, err)
+	assert.Equal(t, advisor.Error, res.Status)
+	assert.Equal(t, 1, len(res.Content))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal([]byte(res.Content[0]), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Equal(t, 1, len(log.Runs))
+	run := log.Runs[0]
+	assert.Equal(t, 4, len(run.Tool.Driver.Rules))
+	assert.Equal(t, "naming.index.idx", run.Tool.Driver.Rules[1].ID)
+	assert.Equal(t, "https://www.bytebase.com/docs/reference/error-code/advisor#303", run.Tool.Driver.Rules[1].HelpURI)
+	require.Equal(t, 4, len(run.Results))
+	assert.Equal(t, sarifResult{
+		RuleID:    "naming.index.uk",
+		RuleIndex: 3,
+		Level:     "error",
+		Message:   sarifMessage{Text: "Unique key in table \"tech_book\" mismatches the naming convention, expect \"^$|^uk_tech_book_id_name$\" but found \"tech_book_id_name\""},
+		Locations: []sarifLocation{
+			{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: "file2.sql"},
+					Region:           sarifRegion{StartLine: 4},
+				},
+			},
+		},
+	}, run.Results[3])
+	assert.Equal(t, "warning", run.Results[0].Level)
+}
+
+func TestVCSSQLReview_ConvertSQLAdviceToJUnitResult(t *testing.T) {
+	adviceMap := map[string][]advisor.Advice{
+		"file3.sql": {
+			{
+				Status:  advisor.Success,
+				Code:    advisor.Ok,
+				Title:   "OK",
+				Content: "",
+			},
+		},
+	}
+	for filePath, adviceList := range mockSQLAdviceMap {
+		adviceMap[filePath] = adviceList
+	}
+	res, err := convertSQLAdviceToJUnitResult(adviceMap)
+	require.NoError(t, err)
+	assert.Equal(t, advisor.Error, res.Status)
+	assert.Equal(t, 1, len(res.Content))
+
+	var testSuites junitTestSuites
+	require.NoError(t, xml.Unmarshal([]byte(res.Content[0]), &testSuites))
+	assert.Equal(t, 5, testSuites.Tests)
+	assert.Equal(t, 4, testSuites.Failures)
+	require.Equal(t, 3, len(testSuites.TestSuites))
+	assert.Equal(t, junitTestCase{
+		Name:      "[WARN] file1.sql#L1: column.no-null",
+		ClassName: "file1.sql",
+		File:      "file1.sql",
+		Line:      1,
+		Failure: &junitFailure{
+			Message: `Column "id" in "public"."book" cannot have NULL value`,
+			Type:    "column.no-null",
+			Text:    "Column \"id\" in \"public\".\"book\" cannot have NULL value\nPlease check the docs at https://www.bytebase.com/docs/reference/error-code/advisor#402",
+		},
+	}, testSuites.TestSuites[0].TestCases[0])
+	// The file without advice has a passed test case.
+	assert.Equal(t, "file3.sql", testSuites.TestSuites[2].Name)
+	assert.Equal(t, 0, testSuites.TestSuites[2].Failures)
+	assert.Nil(t, testSuites.TestSuites[2].TestCases[0].Failure)
+}
+
 func TestGetFileInfo(t *testing.T) {
 	t.Run("a SQL format DDL", func(t *testing.T) {
 		mi, fileType, repoInfo, err := getFileInfo(
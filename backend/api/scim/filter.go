@@ -0,0 +1,520 @@
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// filterExpr is a parsed SCIM filter evaluated against the JSON object of a resource.
+// Spec: https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2.2
+type filterExpr interface {
+	match(object map[string]any) bool
+}
+
+type logicalExpr struct {
+	or          bool
+	left, right filterExpr
+}
+
+func (e *logicalExpr) match(object map[string]any) bool {
+	if e.or {
+		return e.left.match(object) || e.right.match(object)
+	}
+	return e.left.match(object) && e.right.match(object)
+}
+
+type notExpr struct {
+	expr filterExpr
+}
+
+func (e *notExpr) match(object map[string]any) bool {
+	return !e.expr.match(object)
+}
+
+// attrExpr is the attribute expression such as `userName eq "alice@example.com"`.
+type attrExpr struct {
+	attr     string
+	subAttr  string
+	operator string
+	value    any
+}
+
+func (e *attrExpr) match(object map[string]any) bool {
+	for _, v := range getAttributeValues(object, e.attr, e.subAttr) {
+		if compareValue(e.operator, v, e.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// valuePathExpr is the filter on the elements of a multi-valued attribute such as `emails[type eq "work"]`.
+type valuePathExpr struct {
+	attr   string
+	filter filterExpr
+}
+
+func (e *valuePathExpr) match(object map[string]any) bool {
+	for _, element := range getElements(object, e.attr) {
+		if e.filter.match(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// getAttributeValues returns the values of the attribute, flattening the multi-valued attributes.
+func getAttributeValues(object map[string]any, attr, subAttr string) []any {
+	value, ok := getValue(object, attr)
+	if !ok || value == nil {
+		return nil
+	}
+	var values []any
+	if list, ok := value.([]any); ok {
+		values = list
+	} else {
+		values = []any{value}
+	}
+	if subAttr == "" {
+		return values
+	}
+	var result []any
+	for _, v := range values {
+		if m, ok := v.(map[string]any); ok {
+			if subValue, ok := getValue(m, subAttr); ok && subValue != nil {
+				result = append(result, subValue)
+			}
+		}
+	}
+	return result
+}
+
+// getElements returns the complex elements of the multi-valued attribute.
+func getElements(object map[string]any, attr string) []map[string]any {
+	value, ok := getValue(object, attr)
+	if !ok {
+		return nil
+	}
+	var result []map[string]any
+	switch value := value.(type) {
+	case []any:
+		for _, v := range value {
+			if m, ok := v.(map[string]any); ok {
+				result = append(result, m)
+			}
+		}
+	case map[string]any:
+		result = append(result, value)
+	}
+	return result
+}
+
+// getKey returns the key of the attribute in the object. The attribute names are case-insensitive.
+func getKey(object map[string]any, attr string) (string, bool) {
+	if _, ok := object[attr]; ok {
+		return attr, true
+	}
+	for key := range object {
+		if strings.EqualFold(key, attr) {
+			return key, true
+		}
+	}
+	return attr, false
+}
+
+func getValue(object map[string]any, attr string) (any, bool) {
+	key, ok := getKey(object, attr)
+	if !ok {
+		return nil, false
+	}
+	return object[key], true
+}
+
+func compareValue(operator string, value, expected any) bool {
+	if operator == "pr" {
+		switch value := value.(type) {
+		case string:
+			return value != ""
+		case []any:
+			return len(value) > 0
+		case map[string]any:
+			return len(value) > 0
+		default:
+			return value != nil
+		}
+	}
+	switch expected := expected.(type) {
+	case nil:
+		// Comparing with null matches the unassigned attributes, which are skipped before the comparison.
+		return operator == "ne"
+	case bool:
+		v, ok := value.(bool)
+		if !ok {
+			return false
+		}
+		switch operator {
+		case "eq":
+			return v == expected
+		case "ne":
+			return v != expected
+		}
+	case float64:
+		v, ok := value.(float64)
+		if !ok {
+			return false
+		}
+		switch operator {
+		case "eq":
+			return v == expected
+		case "ne":
+			return v != expected
+		case "gt":
+			return v > expected
+		case "ge":
+			return v >= expected
+		case "lt":
+			return v < expected
+		case "le":
+			return v <= expected
+		}
+	case string:
+		v, ok := value.(string)
+		if !ok {
+			return false
+		}
+		// The string attributes we expose are not case exact.
+		v, expected = strings.ToLower(v), strings.ToLower(expected)
+		switch operator {
+		case "eq":
+			return v == expected
+		case "ne":
+			return v != expected
+		case "co":
+			return strings.Contains(v, expected)
+		case "sw":
+			return strings.HasPrefix(v, expected)
+		case "ew":
+			return strings.HasSuffix(v, expected)
+		case "gt":
+			return v > expected
+		case "ge":
+			return v >= expected
+		case "lt":
+			return v < expected
+		case "le":
+			return v <= expected
+		}
+	}
+	return false
+}
+
+var compareOperators = map[string]bool{
+	"eq": true,
+	"ne": true,
+	"co": true,
+	"sw": true,
+	"ew": true,
+	"gt": true,
+	"ge": true,
+	"lt": true,
+	"le": true,
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBracket
+	tokenRightBracket
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "("})
+			i++
+		case ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")"})
+			i++
+		case '[':
+			tokens = append(tokens, token{kind: tokenLeftBracket, value: "["})
+			i++
+		case ']':
+			tokens = append(tokens, token{kind: tokenRightBracket, value: "]"})
+			i++
+		case '"':
+			end := i + 1
+			for ; end < len(s); end++ {
+				if s[end] == '\\' {
+					end++
+					continue
+				}
+				if s[end] == '"' {
+					break
+				}
+			}
+			if end >= len(s) {
+				return nil, errors.Errorf("unterminated string at position %d", i)
+			}
+			var value string
+			if err := json.Unmarshal([]byte(s[i:end+1]), &value); err != nil {
+				return nil, errors.Wrapf(err, "invalid string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value})
+			i = end + 1
+		default:
+			end := i
+			for end < len(s) && !strings.ContainsRune(" \t\n\r()[]\"", rune(s[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: s[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+// parseFilter parses the SCIM filter.
+func parseFilter(filter string) (filterExpr, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errors.Errorf("unexpected %q in filter", p.tokens[p.pos].value)
+	}
+	return expr, nil
+}
+
+func (p *filterParser) peek() *token {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *filterParser) peekKeyword(keyword string) bool {
+	t := p.peek()
+	return t != nil && t.kind == tokenWord && strings.EqualFold(t.value, keyword)
+}
+
+func (p *filterParser) expect(kind tokenKind, value string) error {
+	t := p.peek()
+	if t == nil {
+		return errors.Errorf("expect %q but got end of filter", value)
+	}
+	if t.kind != kind {
+		return errors.Errorf("expect %q but got %q", value, t.value)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{or: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterExpr, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, errors.Errorf("unexpected end of filter")
+	}
+	if p.peekKeyword("not") {
+		p.pos++
+		if err := p.expect(tokenLeftParen, "("); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRightParen, ")"); err != nil {
+			return nil, err
+		}
+		return &notExpr{expr: expr}, nil
+	}
+	if t.kind == tokenLeftParen {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRightParen, ")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	if t.kind != tokenWord {
+		return nil, errors.Errorf("expect attribute but got %q", t.value)
+	}
+	p.pos++
+	attr, subAttr := splitAttributePath(t.value)
+
+	if next := p.peek(); next != nil && next.kind == tokenLeftBracket {
+		if subAttr != "" {
+			return nil, errors.Errorf("invalid value path %q", t.value)
+		}
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRightBracket, "]"); err != nil {
+			return nil, err
+		}
+		return &valuePathExpr{attr: attr, filter: expr}, nil
+	}
+
+	operatorToken := p.peek()
+	if operatorToken == nil || operatorToken.kind != tokenWord {
+		return nil, errors.Errorf("expect operator after %q", t.value)
+	}
+	p.pos++
+	operator := strings.ToLower(operatorToken.value)
+	if operator == "pr" {
+		return &attrExpr{attr: attr, subAttr: subAttr, operator: operator}, nil
+	}
+	if !compareOperators[operator] {
+		return nil, errors.Errorf("unsupported operator %q", operatorToken.value)
+	}
+
+	valueToken := p.peek()
+	if valueToken == nil {
+		return nil, errors.Errorf("expect value after %q", operatorToken.value)
+	}
+	p.pos++
+	var value any
+	switch valueToken.kind {
+	case tokenString:
+		value = valueToken.value
+	case tokenWord:
+		switch strings.ToLower(valueToken.value) {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			number, err := strconv.ParseFloat(valueToken.value, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid value %q", valueToken.value)
+			}
+			value = number
+		}
+	default:
+		return nil, errors.Errorf("expect value but got %q", valueToken.value)
+	}
+	return &attrExpr{attr: attr, subAttr: subAttr, operator: operator, value: value}, nil
+}
+
+// splitAttributePath splits the attribute path such as "name.givenName" into the attribute and the sub-attribute.
+// The schema URI prefix of the core resources is trimmed.
+func splitAttributePath(path string) (string, string) {
+	for _, schema := range []string{userSchema, groupSchema} {
+		if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+			path = path[len(schema)+1:]
+			break
+		}
+	}
+	attr, subAttr, _ := strings.Cut(path, ".")
+	return attr, subAttr
+}
+
+// patchPath is the path of a PATCH operation such as `members[value eq "2"]` or `name.givenName`.
+type patchPath struct {
+	attr    string
+	filter  filterExpr
+	subAttr string
+}
+
+func parsePatchPath(path string) (*patchPath, error) {
+	tokens, err := tokenize(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 || tokens[0].kind != tokenWord {
+		return nil, errors.Errorf("invalid path %q", path)
+	}
+	attr, subAttr := splitAttributePath(tokens[0].value)
+	result := &patchPath{attr: attr, subAttr: subAttr}
+	if len(tokens) == 1 {
+		return result, nil
+	}
+	if subAttr != "" || tokens[1].kind != tokenLeftBracket {
+		return nil, errors.Errorf("invalid path %q", path)
+	}
+
+	// Find the matching bracket of the value filter.
+	end := len(tokens) - 1
+	if last := tokens[end]; last.kind == tokenWord && strings.HasPrefix(last.value, ".") {
+		result.subAttr = strings.TrimPrefix(last.value, ".")
+		end--
+	}
+	if tokens[end].kind != tokenRightBracket {
+		return nil, errors.Errorf("invalid path %q", path)
+	}
+	p := &filterParser{tokens: tokens[2:end]}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid path %q", path)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errors.Errorf("invalid path %q", path)
+	}
+	result.filter = filter
+	return result, nil
+}
@@ -0,0 +1,158 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testUser = `{
+	"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+	"id": "101",
+	"userName": "alice@example.com",
+	"name": {"formatted": "Alice"},
+	"displayName": "Alice",
+	"emails": [{"value": "alice@example.com", "type": "work", "primary": true}],
+	"active": true,
+	"groups": [{"value": "102", "display": "Engineering"}]
+}`
+
+func TestFilter(t *testing.T) {
+	object := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(testUser), &object))
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{filter: `userName eq "alice@example.com"`, want: true},
+		{filter: `UserName Eq "ALICE@example.com"`, want: true},
+		{filter: `userName eq "bob@example.com"`, want: false},
+		{filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName sw "alice"`, want: true},
+		{filter: `emails.value ew "@example.com"`, want: true},
+		{filter: `emails[type eq "work" and value co "alice"]`, want: true},
+		{filter: `emails[type eq "home"]`, want: false},
+		{filter: `active eq true and groups.display eq "Engineering"`, want: true},
+		{filter: `active eq false or id eq "101"`, want: true},
+		{filter: `not (active eq true)`, want: false},
+		{filter: `externalId pr`, want: false},
+		{filter: `(name.formatted pr) and not (displayName ne "Alice")`, want: true},
+	}
+	for _, test := range tests {
+		expr, err := parseFilter(test.filter)
+		require.NoError(t, err, test.filter)
+		require.Equal(t, test.want, expr.match(object), test.filter)
+	}
+
+	for _, filter := range []string{
+		`userName`,
+		`userName eq`,
+		`userName unknown "alice"`,
+		`(userName eq "alice"`,
+		`userName eq "alice`,
+		`emails[type eq "work"`,
+	} {
+		_, err := parseFilter(filter)
+		require.Error(t, err, filter)
+	}
+}
+
+func TestApplyPatchOperations(t *testing.T) {
+	tests := []struct {
+		description string
+		object      string
+		operations  string
+		want        string
+		wantErr     string
+	}{
+		{
+			description: "Okta deactivates the user without path",
+			object:      `{"userName": "alice@example.com", "active": true}`,
+			operations:  `[{"op": "replace", "value": {"active": false}}]`,
+			want:        `{"userName": "alice@example.com", "active": false}`,
+		},
+		{
+			description: "Azure AD deactivates the user with the string value",
+			object:      `{"userName": "alice@example.com", "active": true}`,
+			operations:  `[{"op": "Replace", "path": "active", "value": "False"}]`,
+			want:        `{"userName": "alice@example.com", "active": "False"}`,
+		},
+		{
+			description: "replace the sub-attribute",
+			object:      `{"name": {"formatted": "Alice"}}`,
+			operations:  `[{"op": "replace", "path": "name.givenName", "value": "Alice"}, {"op": "add", "path": "name.familyName", "value": "Smith"}]`,
+			want:        `{"name": {"formatted": "Alice", "givenName": "Alice", "familyName": "Smith"}}`,
+		},
+		{
+			description: "add and remove the group members",
+			object:      `{"displayName": "Engineering", "members": [{"value": "101"}, {"value": "102"}]}`,
+			operations:  `[{"op": "add", "path": "members", "value": [{"value": "103"}]}, {"op": "remove", "path": "members[value eq \"101\"]"}]`,
+			want:        `{"displayName": "Engineering", "members": [{"value": "102"}, {"value": "103"}]}`,
+		},
+		{
+			description: "remove all the group members",
+			object:      `{"displayName": "Engineering", "members": [{"value": "101"}]}`,
+			operations:  `[{"op": "remove", "path": "members"}]`,
+			want:        `{"displayName": "Engineering"}`,
+		},
+		{
+			description: "replace the value of the filtered element",
+			object:      `{"emails": [{"value": "alice@example.com", "type": "work"}, {"value": "alice@home.com", "type": "home"}]}`,
+			operations:  `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice@corp.com"}]`,
+			want:        `{"emails": [{"value": "alice@corp.com", "type": "work"}, {"value": "alice@home.com", "type": "home"}]}`,
+		},
+		{
+			description: "no target for the filter",
+			object:      `{"emails": [{"value": "alice@example.com", "type": "work"}]}`,
+			operations:  `[{"op": "replace", "path": "emails[type eq \"home\"].value", "value": "alice@home.com"}]`,
+			wantErr:     "no value matches",
+		},
+		{
+			description: "remove without path",
+			object:      `{"active": true}`,
+			operations:  `[{"op": "remove"}]`,
+			wantErr:     "path is required",
+		},
+		{
+			description: "unsupported operation",
+			object:      `{"active": true}`,
+			operations:  `[{"op": "move", "path": "active"}]`,
+			wantErr:     "unsupported operation",
+		},
+	}
+
+	for _, test := range tests {
+		object := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(test.object), &object), test.description)
+		var operations []patchOperation
+		require.NoError(t, json.Unmarshal([]byte(test.operations), &operations), test.description)
+
+		err := applyPatchOperations(object, operations)
+		if test.wantErr != "" {
+			require.ErrorContains(t, err, test.wantErr, test.description)
+			continue
+		}
+		require.NoError(t, err, test.description)
+		want := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(test.want), &want), test.description)
+		require.Equal(t, want, object, test.description)
+	}
+}
+
+func TestPatchUserResource(t *testing.T) {
+	object := map[string]any{}
+	require.NoError(t, json.Unmarshal([]byte(testUser), &object))
+	var operations []patchOperation
+	require.NoError(t, json.Unmarshal([]byte(`[{"op": "replace", "path": "active", "value": "False"}]`), &operations))
+	require.NoError(t, applyPatchOperations(object, operations))
+
+	resource := &userResource{}
+	require.NoError(t, fromObject(object, resource))
+	require.NotNil(t, resource.Active)
+	require.False(t, bool(*resource.Active))
+	email, err := getUserEmail(resource)
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", email)
+	require.Equal(t, "Alice", getUserDisplayName(resource, email))
+}
@@ -0,0 +1,354 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const groupResourceType = "Groups"
+
+// groupResource is the SCIM group resource. The members are the users.
+// Spec: https://datatracker.ietf.org/doc/html/rfc7643#section-4.2
+type groupResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []reference `json:"members"`
+	Meta        *meta       `json:"meta,omitempty"`
+}
+
+func (s *Service) listGroups(c echo.Context) error {
+	ctx := c.Request().Context()
+	groups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{})
+	if err != nil {
+		return newInternalError(err, "Failed to list groups")
+	}
+	excludeMembers := isMembersExcluded(c)
+	scimUserUIDs, err := s.getSCIMUserUIDs(ctx)
+	if err != nil {
+		return err
+	}
+	baseURL := s.getBaseURL(c)
+	var resources []*groupResource
+	for _, group := range groups {
+		resource, err := s.convertToGroupResource(ctx, baseURL, group, scimUserUIDs, !excludeMembers)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, resource)
+	}
+	response, err := listResources(c, resources)
+	if err != nil {
+		return err
+	}
+	return responseJSON(c, http.StatusOK, response)
+}
+
+func (s *Service) getGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	group, err := s.getUserGroup(ctx, c)
+	if err != nil {
+		return err
+	}
+	return s.responseGroup(c, http.StatusOK, group)
+}
+
+func (s *Service) createGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	resource := &groupResource{}
+	if err := readJSON(c, resource); err != nil {
+		return err
+	}
+	name := strings.TrimSpace(resource.DisplayName)
+	if name == "" {
+		return newError(errorInvalidValue, "displayName is required")
+	}
+	if err := s.checkGroupNameUnique(ctx, name, 0); err != nil {
+		return err
+	}
+	email, err := s.getGroupEmail(ctx, name, 0)
+	if err != nil {
+		return err
+	}
+	scimUserUIDs, err := s.getSCIMUserUIDs(ctx)
+	if err != nil {
+		return err
+	}
+	memberUIDs, err := s.getMemberUIDs(resource.Members, scimUserUIDs)
+	if err != nil {
+		return err
+	}
+	group, err := s.store.CreateUserGroup(ctx, &store.UserGroupMessage{
+		Name:       name,
+		ExternalID: resource.ExternalID,
+		Email:      email,
+		MemberUIDs: memberUIDs,
+	}, api.SystemBotID)
+	if err != nil {
+		return newInternalError(err, "Failed to create group")
+	}
+	return s.responseGroup(c, http.StatusCreated, group)
+}
+
+func (s *Service) replaceGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	group, err := s.getUserGroup(ctx, c)
+	if err != nil {
+		return err
+	}
+	resource := &groupResource{}
+	if err := readJSON(c, resource); err != nil {
+		return err
+	}
+	scimUserUIDs, err := s.getSCIMUserUIDs(ctx)
+	if err != nil {
+		return err
+	}
+	if group, err = s.updateGroup(ctx, group, resource, scimUserUIDs); err != nil {
+		return err
+	}
+	return s.responseGroup(c, http.StatusOK, group)
+}
+
+// patchGroup applies the patch operations to the group. The members are added and removed one by one rather than
+// replaced, so that the concurrent patches of different members, e.g. by the replicas, don't overwrite each other.
+func (s *Service) patchGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	group, err := s.getUserGroup(ctx, c)
+	if err != nil {
+		return err
+	}
+	request, err := readPatchRequest(c)
+	if err != nil {
+		return err
+	}
+	scimUserUIDs, err := s.getSCIMUserUIDs(ctx)
+	if err != nil {
+		return err
+	}
+	resource, err := s.convertToGroupResource(ctx, s.getBaseURL(c), group, scimUserUIDs, true)
+	if err != nil {
+		return err
+	}
+	object, err := toObject(resource)
+	if err != nil {
+		return newInternalError(err, "Failed to convert group")
+	}
+	if err := applyPatchOperations(object, request.Operations); err != nil {
+		return err
+	}
+	resource = &groupResource{}
+	if err := fromObject(object, resource); err != nil {
+		return newError(errorInvalidValue, "%v", err)
+	}
+	if group, err = s.updateGroup(ctx, group, resource, scimUserUIDs); err != nil {
+		return err
+	}
+	return s.responseGroup(c, http.StatusOK, group)
+}
+
+func (s *Service) deleteGroup(c echo.Context) error {
+	ctx := c.Request().Context()
+	group, err := s.getUserGroup(ctx, c)
+	if err != nil {
+		return err
+	}
+	if err := s.store.DeleteUserGroup(ctx, group.UID); err != nil {
+		return newInternalError(err, "Failed to delete group")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// updateGroup updates the group to the resource. The members managed by the SCIM clients are replaced
+// by adding and removing the differences, and the other members are kept.
+func (s *Service) updateGroup(ctx context.Context, group *store.UserGroupMessage, resource *groupResource, scimUserUIDs map[int]bool) (*store.UserGroupMessage, error) {
+	patch := &store.UpdateUserGroupMessage{}
+	name := strings.TrimSpace(resource.DisplayName)
+	if name == "" {
+		return nil, newError(errorInvalidValue, "displayName is required")
+	}
+	if name != group.Name {
+		if err := s.checkGroupNameUnique(ctx, name, group.UID); err != nil {
+			return nil, err
+		}
+		patch.Name = &name
+		if group.Email == "" {
+			email, err := s.getGroupEmail(ctx, name, group.UID)
+			if err != nil {
+				return nil, err
+			}
+			if email != "" {
+				patch.Email = &email
+			}
+		}
+	}
+	if resource.ExternalID != group.ExternalID {
+		patch.ExternalID = &resource.ExternalID
+	}
+	memberUIDs, err := s.getMemberUIDs(resource.Members, scimUserUIDs)
+	if err != nil {
+		return nil, err
+	}
+	wantMembers := make(map[int]bool)
+	for _, memberUID := range memberUIDs {
+		wantMembers[memberUID] = true
+		if !slices.Contains(group.MemberUIDs, memberUID) {
+			patch.AddMemberUIDs = append(patch.AddMemberUIDs, memberUID)
+		}
+	}
+	for _, memberUID := range group.MemberUIDs {
+		if scimUserUIDs[memberUID] && !wantMembers[memberUID] {
+			patch.RemoveMemberUIDs = append(patch.RemoveMemberUIDs, memberUID)
+		}
+	}
+	updatedGroup, err := s.store.UpdateUserGroup(ctx, group.UID, patch, api.SystemBotID)
+	if err != nil {
+		return nil, newInternalError(err, "Failed to update group")
+	}
+	return updatedGroup, nil
+}
+
+func (s *Service) checkGroupNameUnique(ctx context.Context, name string, uid int) error {
+	existingGroup, err := s.store.GetUserGroup(ctx, &store.FindUserGroupMessage{Name: &name})
+	if err != nil {
+		return newInternalError(err, "Failed to find group by name")
+	}
+	if existingGroup != nil && existingGroup.UID != uid {
+		return &scimError{status: http.StatusConflict, scimType: errorUniqueness, detail: "Group " + name + " already exists"}
+	}
+	return nil
+}
+
+// getGroupEmail returns the group email if the display name is an email address, so that the group can be
+// referenced in the IAM policies as group:{email}. The email is left empty if it's used by another group.
+func (s *Service) getGroupEmail(ctx context.Context, name string, uid int) (string, error) {
+	email := strings.ToLower(name)
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return "", nil
+	}
+	existingGroup, err := s.store.GetUserGroup(ctx, &store.FindUserGroupMessage{Email: &email})
+	if err != nil {
+		return "", newInternalError(err, "Failed to find group by email")
+	}
+	if existingGroup != nil && existingGroup.UID != uid {
+		return "", nil
+	}
+	return email, nil
+}
+
+// getMemberUIDs validates the members are the users managed by the SCIM clients and returns their IDs.
+func (*Service) getMemberUIDs(members []reference, scimUserUIDs map[int]bool) ([]int, error) {
+	var memberUIDs []int
+	for _, member := range members {
+		uid, err := strconv.Atoi(member.Value)
+		if err != nil {
+			return nil, newError(errorInvalidValue, "invalid member %q", member.Value)
+		}
+		if !scimUserUIDs[uid] {
+			return nil, newError(errorInvalidValue, "member %q not found", member.Value)
+		}
+		memberUIDs = append(memberUIDs, uid)
+	}
+	return memberUIDs, nil
+}
+
+// getSCIMUserUIDs returns the IDs of the users managed by the SCIM clients.
+func (s *Service) getSCIMUserUIDs(ctx context.Context) (map[int]bool, error) {
+	scimUsers, err := s.store.ListSCIMUsers(ctx, &store.FindSCIMUserMessage{})
+	if err != nil {
+		return nil, newInternalError(err, "Failed to list SCIM users")
+	}
+	uids := make(map[int]bool)
+	for _, scimUser := range scimUsers {
+		uids[scimUser.PrincipalUID] = true
+	}
+	return uids, nil
+}
+
+func (s *Service) getUserGroup(ctx context.Context, c echo.Context) (*store.UserGroupMessage, error) {
+	id, err := parseResourceID(c, "Group")
+	if err != nil {
+		return nil, err
+	}
+	group, err := s.store.GetUserGroup(ctx, &store.FindUserGroupMessage{UID: &id})
+	if err != nil {
+		return nil, newInternalError(err, "Failed to get group")
+	}
+	if group == nil {
+		return nil, newNotFoundError("Group %q not found", c.Param("id"))
+	}
+	return group, nil
+}
+
+func (s *Service) responseGroup(c echo.Context, status int, group *store.UserGroupMessage) error {
+	scimUserUIDs, err := s.getSCIMUserUIDs(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	resource, err := s.convertToGroupResource(c.Request().Context(), s.getBaseURL(c), group, scimUserUIDs, !isMembersExcluded(c))
+	if err != nil {
+		return err
+	}
+	if status == http.StatusCreated {
+		c.Response().Header().Set(echo.HeaderLocation, resource.Meta.Location)
+	}
+	return responseJSON(c, status, resource)
+}
+
+// convertToGroupResource converts the group to the resource. Only the members managed by the SCIM clients are listed.
+func (s *Service) convertToGroupResource(ctx context.Context, baseURL string, group *store.UserGroupMessage, scimUserUIDs map[int]bool, withMembers bool) (*groupResource, error) {
+	resource := &groupResource{
+		Schemas:     []string{groupSchema},
+		ID:          strconv.Itoa(group.UID),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.Name,
+		Members:     []reference{},
+		Meta: &meta{
+			ResourceType: "Group",
+			Created:      formatTimestamp(group.CreatedTs),
+			LastModified: formatTimestamp(group.UpdatedTs),
+			Location:     getResourceLocation(baseURL, groupResourceType, group.UID),
+		},
+	}
+	if !withMembers {
+		return resource, nil
+	}
+	for _, memberUID := range group.MemberUIDs {
+		if !scimUserUIDs[memberUID] {
+			continue
+		}
+		user, err := s.store.GetUserByID(ctx, memberUID)
+		if err != nil {
+			return nil, newInternalError(err, "Failed to get user")
+		}
+		member := reference{
+			Value: strconv.Itoa(memberUID),
+			Ref:   getResourceLocation(baseURL, userResourceType, memberUID),
+		}
+		if user != nil {
+			member.Display = user.Name
+		}
+		resource.Members = append(resource.Members, member)
+	}
+	return resource, nil
+}
+
+// isMembersExcluded returns true if the client excludes the members, which is used to skip the large member lists.
+func isMembersExcluded(c echo.Context) bool {
+	for _, attr := range strings.Split(c.QueryParam("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package scim
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
+)
+
+// patchRequest is the SCIM PATCH request.
+// Spec: https://datatracker.ietf.org/doc/html/rfc7644#section-3.5.2
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// readPatchRequest reads the PATCH request body.
+func readPatchRequest(c echo.Context) (*patchRequest, error) {
+	request := &patchRequest{}
+	if err := readJSON(c, request); err != nil {
+		return nil, err
+	}
+	if len(request.Schemas) > 0 && !slices.Contains(request.Schemas, patchOpSchema) {
+		return nil, newError(errorInvalidSyntax, "schemas must contain %q", patchOpSchema)
+	}
+	return request, nil
+}
+
+// applyPatchOperations applies the PATCH operations on the JSON object of a resource.
+// The returned errors are SCIM errors with the scimType set.
+func applyPatchOperations(object map[string]any, operations []patchOperation) error {
+	for _, operation := range operations {
+		var value any
+		if len(operation.Value) > 0 {
+			if err := json.Unmarshal(operation.Value, &value); err != nil {
+				return newError(errorInvalidSyntax, "invalid value of the %q operation: %v", operation.Op, err)
+			}
+		}
+		op := strings.ToLower(operation.Op)
+		switch op {
+		case "add", "replace", "remove":
+		default:
+			return newError(errorInvalidSyntax, "unsupported operation %q", operation.Op)
+		}
+		if err := applyPatchOperation(object, op, operation.Path, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyPatchOperation(object map[string]any, op string, path string, value any) error {
+	if path == "" {
+		if op == "remove" {
+			return newError(errorNoTarget, "path is required for the remove operation")
+		}
+		attributes, ok := value.(map[string]any)
+		if !ok {
+			return newError(errorInvalidValue, "value must be an object if path is not set")
+		}
+		// Each of the attributes is applied as if it's the path of the operation.
+		for attr, v := range attributes {
+			if err := applyPatchOperation(object, op, attr, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p, err := parsePatchPath(path)
+	if err != nil {
+		return newError(errorInvalidPath, "%v", err)
+	}
+	if p.filter == nil {
+		applyAttributePatch(object, op, p.attr, p.subAttr, value)
+		return nil
+	}
+
+	key, ok := getKey(object, p.attr)
+	if !ok {
+		if op == "remove" {
+			return nil
+		}
+		return newError(errorNoTarget, "no value matches the path %q", path)
+	}
+	elements, ok := object[key].([]any)
+	if !ok {
+		return newError(errorInvalidPath, "attribute %q is not multi-valued", p.attr)
+	}
+	var result []any
+	matched := false
+	for _, element := range elements {
+		m, ok := element.(map[string]any)
+		if !ok || !p.filter.match(m) {
+			result = append(result, element)
+			continue
+		}
+		matched = true
+		switch {
+		case op == "remove" && p.subAttr == "":
+			// Drop the element.
+		case p.subAttr == "":
+			if v, ok := value.(map[string]any); ok && op == "add" {
+				for attr, subValue := range v {
+					setValue(m, attr, subValue)
+				}
+				result = append(result, m)
+			} else {
+				result = append(result, value)
+			}
+		default:
+			applyAttributePatch(m, op, p.subAttr, "", value)
+			result = append(result, m)
+		}
+	}
+	if !matched && op != "remove" {
+		return newError(errorNoTarget, "no value matches the path %q", path)
+	}
+	if result == nil {
+		result = []any{}
+	}
+	object[key] = result
+	return nil
+}
+
+// applyAttributePatch applies the operation on the attribute or the sub-attribute.
+func applyAttributePatch(object map[string]any, op, attr, subAttr string, value any) {
+	if subAttr != "" {
+		key, ok := getKey(object, attr)
+		if !ok {
+			if op == "remove" {
+				return
+			}
+			object[key] = map[string]any{}
+		}
+		switch target := object[key].(type) {
+		case map[string]any:
+			applyAttributePatch(target, op, subAttr, "", value)
+		case []any:
+			// Apply on the sub-attribute of all the elements.
+			for _, element := range target {
+				if m, ok := element.(map[string]any); ok {
+					applyAttributePatch(m, op, subAttr, "", value)
+				}
+			}
+		default:
+			if op != "remove" {
+				m := map[string]any{}
+				applyAttributePatch(m, op, subAttr, "", value)
+				object[key] = m
+			}
+		}
+		return
+	}
+
+	key, _ := getKey(object, attr)
+	switch op {
+	case "remove":
+		delete(object, key)
+	case "add":
+		existing, ok := object[key]
+		if !ok || existing == nil {
+			object[key] = value
+			return
+		}
+		switch existing := existing.(type) {
+		case []any:
+			// Add the values to the multi-valued attribute.
+			if values, ok := value.([]any); ok {
+				object[key] = append(existing, values...)
+			} else {
+				object[key] = append(existing, value)
+			}
+		case map[string]any:
+			if v, ok := value.(map[string]any); ok {
+				for subAttr, subValue := range v {
+					setValue(existing, subAttr, subValue)
+				}
+				return
+			}
+			object[key] = value
+		default:
+			object[key] = value
+		}
+	case "replace":
+		object[key] = value
+	}
+}
+
+func setValue(object map[string]any, attr string, value any) {
+	key, _ := getKey(object, attr)
+	object[key] = value
+}
+
+// toObject converts the resource to its JSON object.
+func toObject(resource any) (map[string]any, error) {
+	bytes, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	object := map[string]any{}
+	if err := json.Unmarshal(bytes, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// fromObject converts the JSON object to the resource.
+func fromObject(object map[string]any, resource any) error {
+	bytes, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bytes, resource); err != nil {
+		return errors.Wrapf(err, "invalid resource")
+	}
+	return nil
+}
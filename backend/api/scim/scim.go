@@ -0,0 +1,375 @@
+// Package scim is the package for the SCIM 2.0 provisioning APIs.
+// Spec: https://datatracker.ietf.org/doc/html/rfc7644
+package scim
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	userSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	groupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	listResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	patchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	errorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	serviceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	contentType = "application/scim+json"
+	// maxResults is the maximum number of resources returned in a list response.
+	maxResults = 1000
+	// maxRequestBodySize is the maximum size of the request body.
+	maxRequestBodySize = 1 << 20
+)
+
+// The scimType of the SCIM errors.
+// Spec: https://datatracker.ietf.org/doc/html/rfc7644#section-3.12
+const (
+	errorInvalidFilter = "invalidFilter"
+	errorUniqueness    = "uniqueness"
+	errorInvalidSyntax = "invalidSyntax"
+	errorInvalidPath   = "invalidPath"
+	errorNoTarget      = "noTarget"
+	errorInvalidValue  = "invalidValue"
+)
+
+// Service is the API endpoint for handling SCIM requests.
+type Service struct {
+	store          *store.Store
+	licenseService enterpriseAPI.LicenseService
+}
+
+// NewService creates a SCIM service.
+func NewService(store *store.Store, licenseService enterpriseAPI.LicenseService) *Service {
+	return &Service{
+		store:          store,
+		licenseService: licenseService,
+	}
+}
+
+// RegisterRoutes registers the SCIM routes.
+func (s *Service) RegisterRoutes(g *echo.Group) {
+	g.Use(s.errorMiddleware, s.authMiddleware)
+
+	g.GET("/ServiceProviderConfig", s.getServiceProviderConfig)
+
+	g.GET("/Users", s.listUsers)
+	g.POST("/Users", s.createUser)
+	g.GET("/Users/:id", s.getUser)
+	g.PUT("/Users/:id", s.replaceUser)
+	g.PATCH("/Users/:id", s.patchUser)
+	g.DELETE("/Users/:id", s.deleteUser)
+
+	g.GET("/Groups", s.listGroups)
+	g.POST("/Groups", s.createGroup)
+	g.GET("/Groups/:id", s.getGroup)
+	g.PUT("/Groups/:id", s.replaceGroup)
+	g.PATCH("/Groups/:id", s.patchGroup)
+	g.DELETE("/Groups/:id", s.deleteGroup)
+}
+
+// scimError is the error responded in the SCIM error format.
+type scimError struct {
+	status   int
+	scimType string
+	detail   string
+	internal error
+}
+
+func (e *scimError) Error() string {
+	if e.internal != nil {
+		return fmt.Sprintf("%s: %v", e.detail, e.internal)
+	}
+	return e.detail
+}
+
+// newError returns the bad request error with the scimType.
+func newError(scimType string, format string, args ...any) *scimError {
+	return &scimError{status: http.StatusBadRequest, scimType: scimType, detail: fmt.Sprintf(format, args...)}
+}
+
+func newNotFoundError(format string, args ...any) *scimError {
+	return &scimError{status: http.StatusNotFound, detail: fmt.Sprintf(format, args...)}
+}
+
+func newInternalError(err error, format string, args ...any) *scimError {
+	return &scimError{status: http.StatusInternalServerError, detail: fmt.Sprintf(format, args...), internal: err}
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+	Status   string   `json:"status"`
+}
+
+// errorMiddleware responds the errors in the SCIM error format.
+func (*Service) errorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := next(c)
+		if err == nil {
+			return nil
+		}
+		var e *scimError
+		if !errors.As(err, &e) {
+			return err
+		}
+		if e.internal != nil {
+			slog.Error("Failed to handle SCIM request", slog.String("path", c.Request().URL.Path), log.BBError(e.internal))
+		}
+		return responseJSON(c, e.status, &errorResponse{
+			Schemas:  []string{errorSchema},
+			ScimType: e.scimType,
+			Detail:   e.detail,
+			Status:   strconv.Itoa(e.status),
+		})
+	}
+}
+
+// HashToken returns the hash of the SCIM token, which is stored in the workspace setting instead of the token.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// authMiddleware authenticates the SCIM clients with the bearer token whose hash is in the workspace setting.
+func (s *Service) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		if err := s.licenseService.IsFeatureEnabled(api.FeatureSSO); err != nil {
+			return &scimError{status: http.StatusForbidden, detail: err.Error()}
+		}
+		settingName := api.SettingSCIMToken
+		setting, err := s.store.GetSettingV2(ctx, &store.FindSettingMessage{Name: &settingName})
+		if err != nil {
+			return newInternalError(err, "Failed to get SCIM token")
+		}
+		if setting == nil || setting.Value == "" {
+			return &scimError{status: http.StatusUnauthorized, detail: "SCIM provisioning is not enabled"}
+		}
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(HashToken(token)), []byte(setting.Value)) != 1 {
+			return &scimError{status: http.StatusUnauthorized, detail: "Invalid bearer token"}
+		}
+		return next(c)
+	}
+}
+
+// listResponse is the SCIM list response.
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// listResources filters and paginates the resources with the query parameters.
+// Spec: https://datatracker.ietf.org/doc/html/rfc7644#section-3.4.2
+func listResources[T any](c echo.Context, resources []T) (*listResponse, error) {
+	var filter filterExpr
+	if v := c.QueryParam("filter"); v != "" {
+		expr, err := parseFilter(v)
+		if err != nil {
+			return nil, newError(errorInvalidFilter, "invalid filter %q: %v", v, err)
+		}
+		filter = expr
+	}
+	startIndex := 1
+	if v := c.QueryParam("startIndex"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, newError(errorInvalidValue, "invalid startIndex %q", v)
+		}
+		// A value less than 1 is interpreted as 1.
+		startIndex = max(n, 1)
+	}
+	count := maxResults
+	if v := c.QueryParam("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, newError(errorInvalidValue, "invalid count %q", v)
+		}
+		// A negative value is interpreted as 0.
+		count = min(max(n, 0), maxResults)
+	}
+
+	var matched []map[string]any
+	for _, resource := range resources {
+		object, err := toObject(resource)
+		if err != nil {
+			return nil, newInternalError(err, "Failed to convert resource")
+		}
+		if filter != nil && !filter.match(object) {
+			continue
+		}
+		matched = append(matched, object)
+	}
+
+	response := &listResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: len(matched),
+		StartIndex:   startIndex,
+		Resources:    []any{},
+	}
+	for i := startIndex - 1; i < len(matched) && len(response.Resources) < count; i++ {
+		response.Resources = append(response.Resources, matched[i])
+	}
+	response.ItemsPerPage = len(response.Resources)
+	return response, nil
+}
+
+func (*Service) getServiceProviderConfig(c echo.Context) error {
+	type supported struct {
+		Supported bool `json:"supported"`
+	}
+	type filterSupported struct {
+		Supported  bool `json:"supported"`
+		MaxResults int  `json:"maxResults"`
+	}
+	type bulkSupported struct {
+		Supported      bool `json:"supported"`
+		MaxOperations  int  `json:"maxOperations"`
+		MaxPayloadSize int  `json:"maxPayloadSize"`
+	}
+	type authenticationScheme struct {
+		Type        string `json:"type"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	return responseJSON(c, http.StatusOK, &struct {
+		Schemas               []string               `json:"schemas"`
+		Patch                 supported              `json:"patch"`
+		Bulk                  bulkSupported          `json:"bulk"`
+		Filter                filterSupported        `json:"filter"`
+		ChangePassword        supported              `json:"changePassword"`
+		Sort                  supported              `json:"sort"`
+		Etag                  supported              `json:"etag"`
+		AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	}{
+		Schemas: []string{serviceProviderConfigSchema},
+		Patch:   supported{Supported: true},
+		Filter:  filterSupported{Supported: true, MaxResults: maxResults},
+		AuthenticationSchemes: []authenticationScheme{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "OAuth Bearer Token",
+				Description: "Authentication with the SCIM token in the workspace setting",
+			},
+		},
+	})
+}
+
+// readJSON reads the JSON request body, which is at most maxRequestBodySize bytes.
+func readJSON(c echo.Context, v any) error {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxRequestBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return &scimError{status: http.StatusRequestEntityTooLarge, detail: fmt.Sprintf("request body exceeds %d bytes", maxRequestBodySize)}
+		}
+		return newError(errorInvalidSyntax, "failed to read request body: %v", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return newError(errorInvalidSyntax, "malformed request body: %v", err)
+	}
+	return nil
+}
+
+func responseJSON(c echo.Context, status int, v any) error {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Blob(status, contentType, bytes)
+}
+
+// getBaseURL returns the base URL of the SCIM APIs, which is the request path before the resource type.
+func (s *Service) getBaseURL(c echo.Context) string {
+	basePath := c.Request().URL.Path
+	for _, resourceType := range []string{userResourceType, groupResourceType} {
+		if i := strings.LastIndex(basePath, "/"+resourceType); i >= 0 {
+			basePath = basePath[:i]
+			break
+		}
+	}
+	setting, err := s.store.GetWorkspaceGeneralSetting(c.Request().Context())
+	if err != nil || setting.ExternalUrl == "" {
+		return basePath
+	}
+	return strings.TrimSuffix(setting.ExternalUrl, "/") + basePath
+}
+
+func getResourceLocation(baseURL, resourceType string, id int) string {
+	return fmt.Sprintf("%s/%s/%d", baseURL, resourceType, id)
+}
+
+func formatTimestamp(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+}
+
+// meta is the resource metadata.
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+// reference is the reference to the other resources such as the members of a group.
+type reference struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// flexBool is the boolean accepting the strings "true" and "false", which are sent by some clients such as Azure AD.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = flexBool(v)
+	case string:
+		parsed, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return errors.Errorf("invalid boolean %q", v)
+		}
+		*b = flexBool(parsed)
+	case nil:
+		*b = false
+	default:
+		return errors.Errorf("invalid boolean %v", v)
+	}
+	return nil
+}
+
+// parseResourceID parses the ID of the resource in the path.
+func parseResourceID(c echo.Context, resourceType string) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, newNotFoundError("%s %q not found", resourceType, c.Param("id"))
+	}
+	return id, nil
+}
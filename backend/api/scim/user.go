@@ -0,0 +1,424 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/bytebase/bytebase/backend/common"
+	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const userResourceType = "Users"
+
+// userResource is the SCIM user resource. The userName is the email of the principal.
+// Spec: https://datatracker.ietf.org/doc/html/rfc7643#section-4.1
+type userResource struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	ExternalID  string       `json:"externalId,omitempty"`
+	UserName    string       `json:"userName"`
+	Name        *userName    `json:"name,omitempty"`
+	DisplayName string       `json:"displayName,omitempty"`
+	Emails      []multiValue `json:"emails,omitempty"`
+	Active      *flexBool    `json:"active,omitempty"`
+	Groups      []reference  `json:"groups,omitempty"`
+	Meta        *meta        `json:"meta,omitempty"`
+}
+
+type userName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type multiValue struct {
+	Value   string   `json:"value"`
+	Type    string   `json:"type,omitempty"`
+	Primary flexBool `json:"primary,omitempty"`
+}
+
+func (s *Service) listUsers(c echo.Context) error {
+	ctx := c.Request().Context()
+	principalType := api.EndUser
+	users, err := s.store.ListUsers(ctx, &store.FindUserMessage{Type: &principalType, ShowDeleted: true})
+	if err != nil {
+		return newInternalError(err, "Failed to list users")
+	}
+	scimUsers, err := s.store.ListSCIMUsers(ctx, &store.FindSCIMUserMessage{})
+	if err != nil {
+		return newInternalError(err, "Failed to list SCIM users")
+	}
+	scimUserMap := make(map[int]*store.SCIMUserMessage)
+	for _, scimUser := range scimUsers {
+		scimUserMap[scimUser.PrincipalUID] = scimUser
+	}
+	groups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{})
+	if err != nil {
+		return newInternalError(err, "Failed to list groups")
+	}
+	baseURL := s.getBaseURL(c)
+	var resources []*userResource
+	for _, user := range users {
+		// Only the users managed by the SCIM clients are exposed.
+		scimUser, ok := scimUserMap[user.ID]
+		if !ok {
+			continue
+		}
+		resources = append(resources, convertToUserResource(baseURL, user, scimUser, groups))
+	}
+	response, err := listResources(c, resources)
+	if err != nil {
+		return err
+	}
+	return responseJSON(c, http.StatusOK, response)
+}
+
+func (s *Service) getUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, scimUser, err := s.getSCIMUser(ctx, c)
+	if err != nil {
+		return err
+	}
+	return s.responseUser(c, http.StatusOK, user, scimUser)
+}
+
+func (s *Service) createUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	resource := &userResource{}
+	if err := readJSON(c, resource); err != nil {
+		return err
+	}
+	email, err := getUserEmail(resource)
+	if err != nil {
+		return err
+	}
+	if err := s.checkExternalIDUnique(ctx, resource.ExternalID, 0); err != nil {
+		return err
+	}
+	existingUser, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &email, ShowDeleted: true})
+	if err != nil {
+		return newInternalError(err, "Failed to find user by email")
+	}
+	if existingUser != nil {
+		return s.linkUser(c, existingUser, resource)
+	}
+	active := resource.Active == nil || bool(*resource.Active)
+	if active {
+		if err := s.userCountGuard(ctx); err != nil {
+			return err
+		}
+	}
+
+	// The provisioned users sign in with the identity provider, so a random password is generated.
+	password, err := common.RandomString(20)
+	if err != nil {
+		return newInternalError(err, "Failed to generate random password")
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return newInternalError(err, "Failed to generate password hash")
+	}
+	user, err := s.store.CreateUser(ctx, &store.UserMessage{
+		Email:        email,
+		Name:         getUserDisplayName(resource, email),
+		Type:         api.EndUser,
+		PasswordHash: string(passwordHash),
+	}, api.SystemBotID)
+	if err != nil {
+		return newInternalError(err, "Failed to create user")
+	}
+	scimUser := &store.SCIMUserMessage{PrincipalUID: user.ID, ExternalID: resource.ExternalID}
+	if err := s.store.UpsertSCIMUser(ctx, scimUser); err != nil {
+		return newInternalError(err, "Failed to create SCIM user")
+	}
+	if !active {
+		deleted := true
+		if user, err = s.store.UpdateUser(ctx, user.ID, &store.UpdateUserMessage{Delete: &deleted}, api.SystemBotID); err != nil {
+			return newInternalError(err, "Failed to deactivate user")
+		}
+	}
+	return s.responseUser(c, http.StatusCreated, user, scimUser)
+}
+
+// linkUser links the existing user to the SCIM clients by the external ID, so that the users signed up before
+// the provisioning is enabled can be managed. The workspace owners are never linked to avoid locking out the workspace.
+func (s *Service) linkUser(c echo.Context, user *store.UserMessage, resource *userResource) error {
+	ctx := c.Request().Context()
+	scimUser, err := s.store.GetSCIMUser(ctx, &store.FindSCIMUserMessage{PrincipalUID: &user.ID})
+	if err != nil {
+		return newInternalError(err, "Failed to get SCIM user")
+	}
+	if scimUser != nil || resource.ExternalID == "" || user.Type != api.EndUser || user.Role == api.Owner {
+		return &scimError{status: http.StatusConflict, scimType: errorUniqueness, detail: "User " + user.Email + " already exists"}
+	}
+	scimUser = &store.SCIMUserMessage{PrincipalUID: user.ID, ExternalID: resource.ExternalID}
+	if err := s.store.UpsertSCIMUser(ctx, scimUser); err != nil {
+		return newInternalError(err, "Failed to link SCIM user")
+	}
+	if user, err = s.updateUser(ctx, user, scimUser, resource); err != nil {
+		return err
+	}
+	return s.responseUser(c, http.StatusCreated, user, scimUser)
+}
+
+func (s *Service) replaceUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, scimUser, err := s.getSCIMUser(ctx, c)
+	if err != nil {
+		return err
+	}
+	resource := &userResource{}
+	if err := readJSON(c, resource); err != nil {
+		return err
+	}
+	if user, err = s.updateUser(ctx, user, scimUser, resource); err != nil {
+		return err
+	}
+	return s.responseUser(c, http.StatusOK, user, scimUser)
+}
+
+func (s *Service) patchUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, scimUser, err := s.getSCIMUser(ctx, c)
+	if err != nil {
+		return err
+	}
+	request, err := readPatchRequest(c)
+	if err != nil {
+		return err
+	}
+	groups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{MemberUID: &user.ID})
+	if err != nil {
+		return newInternalError(err, "Failed to list groups")
+	}
+	object, err := toObject(convertToUserResource(s.getBaseURL(c), user, scimUser, groups))
+	if err != nil {
+		return newInternalError(err, "Failed to convert user")
+	}
+	if err := applyPatchOperations(object, request.Operations); err != nil {
+		return err
+	}
+	resource := &userResource{}
+	if err := fromObject(object, resource); err != nil {
+		return newError(errorInvalidValue, "%v", err)
+	}
+	if user, err = s.updateUser(ctx, user, scimUser, resource); err != nil {
+		return err
+	}
+	return s.responseUser(c, http.StatusOK, user, scimUser)
+}
+
+// deleteUser deactivates the user and removes it from the groups.
+// The principals are kept for the audit history, so the deleted users can be activated again.
+func (s *Service) deleteUser(c echo.Context) error {
+	ctx := c.Request().Context()
+	user, _, err := s.getSCIMUser(ctx, c)
+	if err != nil {
+		return err
+	}
+	// The user is deactivated even if it looks deactivated, which is idempotent.
+	deleted := true
+	if _, err := s.store.UpdateUser(ctx, user.ID, &store.UpdateUserMessage{Delete: &deleted}, api.SystemBotID); err != nil {
+		return newInternalError(err, "Failed to deactivate user")
+	}
+	groups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{MemberUID: &user.ID})
+	if err != nil {
+		return newInternalError(err, "Failed to list groups")
+	}
+	for _, group := range groups {
+		if _, err := s.store.UpdateUserGroup(ctx, group.UID, &store.UpdateUserGroupMessage{RemoveMemberUIDs: []int{user.ID}}, api.SystemBotID); err != nil {
+			return newInternalError(err, "Failed to remove user from group %q", group.Name)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// updateUser updates the user to the resource. The absent active attribute keeps the user state.
+func (s *Service) updateUser(ctx context.Context, user *store.UserMessage, scimUser *store.SCIMUserMessage, resource *userResource) (*store.UserMessage, error) {
+	email, err := getUserEmail(resource)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkExternalIDUnique(ctx, resource.ExternalID, user.ID); err != nil {
+		return nil, err
+	}
+	patch := &store.UpdateUserMessage{}
+	if email != user.Email {
+		existingUser, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &email, ShowDeleted: true})
+		if err != nil {
+			return nil, newInternalError(err, "Failed to find user by email")
+		}
+		if existingUser != nil {
+			return nil, &scimError{status: http.StatusConflict, scimType: errorUniqueness, detail: "User " + email + " already exists"}
+		}
+		patch.Email = &email
+	}
+	if name := getUserDisplayName(resource, email); name != user.Name {
+		patch.Name = &name
+	}
+	if resource.Active != nil {
+		deleted := !bool(*resource.Active)
+		if deleted != user.MemberDeleted {
+			if !deleted {
+				if err := s.userCountGuard(ctx); err != nil {
+					return nil, err
+				}
+			}
+			patch.Delete = &deleted
+		}
+	}
+	if resource.ExternalID != scimUser.ExternalID {
+		scimUser.ExternalID = resource.ExternalID
+		if err := s.store.UpsertSCIMUser(ctx, scimUser); err != nil {
+			return nil, newInternalError(err, "Failed to update external ID")
+		}
+	}
+	if patch.Email == nil && patch.Name == nil && patch.Delete == nil {
+		return user, nil
+	}
+	updatedUser, err := s.store.UpdateUser(ctx, user.ID, patch, api.SystemBotID)
+	if err != nil {
+		return nil, newInternalError(err, "Failed to update user")
+	}
+	return updatedUser, nil
+}
+
+// getSCIMUser gets the user in the path. Only the end users managed by the SCIM clients are exposed.
+// The user is read from the database rather than the cache, which may be stale if another replica has updated it.
+func (s *Service) getSCIMUser(ctx context.Context, c echo.Context) (*store.UserMessage, *store.SCIMUserMessage, error) {
+	id, err := parseResourceID(c, "User")
+	if err != nil {
+		return nil, nil, err
+	}
+	scimUser, err := s.store.GetSCIMUser(ctx, &store.FindSCIMUserMessage{PrincipalUID: &id})
+	if err != nil {
+		return nil, nil, newInternalError(err, "Failed to get SCIM user")
+	}
+	if scimUser == nil {
+		return nil, nil, newNotFoundError("User %q not found", c.Param("id"))
+	}
+	user, err := s.store.GetUser(ctx, &store.FindUserMessage{ID: &id, ShowDeleted: true})
+	if err != nil {
+		return nil, nil, newInternalError(err, "Failed to get user")
+	}
+	if user == nil || user.Type != api.EndUser {
+		return nil, nil, newNotFoundError("User %q not found", c.Param("id"))
+	}
+	return user, scimUser, nil
+}
+
+// checkExternalIDUnique checks the external ID is not used by the other users.
+func (s *Service) checkExternalIDUnique(ctx context.Context, externalID string, uid int) error {
+	if externalID == "" {
+		return nil
+	}
+	existingUser, err := s.store.GetSCIMUser(ctx, &store.FindSCIMUserMessage{ExternalID: &externalID})
+	if err != nil {
+		return newInternalError(err, "Failed to find user by external ID")
+	}
+	if existingUser != nil && existingUser.PrincipalUID != uid {
+		return &scimError{status: http.StatusConflict, scimType: errorUniqueness, detail: "User with externalId " + externalID + " already exists"}
+	}
+	return nil
+}
+
+func (s *Service) userCountGuard(ctx context.Context) error {
+	userLimit := s.licenseService.GetPlanLimitValue(ctx, enterpriseAPI.PlanLimitMaximumUser)
+	count, err := s.store.CountActiveUsers(ctx)
+	if err != nil {
+		return newInternalError(err, "Failed to count active users")
+	}
+	if int64(count) >= userLimit {
+		return &scimError{status: http.StatusForbidden, detail: "Reached the maximum user count " + strconv.FormatInt(userLimit, 10)}
+	}
+	return nil
+}
+
+func (s *Service) responseUser(c echo.Context, status int, user *store.UserMessage, scimUser *store.SCIMUserMessage) error {
+	groups, err := s.store.ListUserGroups(c.Request().Context(), &store.FindUserGroupMessage{MemberUID: &user.ID})
+	if err != nil {
+		return newInternalError(err, "Failed to list groups")
+	}
+	resource := convertToUserResource(s.getBaseURL(c), user, scimUser, groups)
+	if status == http.StatusCreated {
+		c.Response().Header().Set(echo.HeaderLocation, resource.Meta.Location)
+	}
+	return responseJSON(c, status, resource)
+}
+
+func convertToUserResource(baseURL string, user *store.UserMessage, scimUser *store.SCIMUserMessage, groups []*store.UserGroupMessage) *userResource {
+	active := flexBool(!user.MemberDeleted)
+	resource := &userResource{
+		Schemas:     []string{userSchema},
+		ID:          strconv.Itoa(user.ID),
+		ExternalID:  scimUser.ExternalID,
+		UserName:    user.Email,
+		Name:        &userName{Formatted: user.Name},
+		DisplayName: user.Name,
+		Emails: []multiValue{
+			{Value: user.Email, Type: "work", Primary: true},
+		},
+		Active: &active,
+		Meta: &meta{
+			ResourceType: "User",
+			Location:     getResourceLocation(baseURL, userResourceType, user.ID),
+		},
+	}
+	for _, group := range groups {
+		for _, memberUID := range group.MemberUIDs {
+			if memberUID == user.ID {
+				resource.Groups = append(resource.Groups, reference{
+					Value:   strconv.Itoa(group.UID),
+					Display: group.Name,
+				})
+				break
+			}
+		}
+	}
+	return resource
+}
+
+// getUserEmail returns the email of the user, which is the userName or the primary email.
+func getUserEmail(resource *userResource) (string, error) {
+	email := resource.UserName
+	if !strings.Contains(email, "@") && len(resource.Emails) > 0 {
+		email = resource.Emails[0].Value
+		for _, e := range resource.Emails {
+			if e.Primary {
+				email = e.Value
+				break
+			}
+		}
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", newError(errorInvalidValue, "userName is required")
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", newError(errorInvalidValue, "invalid email %q: %v", email, err)
+	}
+	return email, nil
+}
+
+func getUserDisplayName(resource *userResource, email string) string {
+	if resource.DisplayName != "" {
+		return resource.DisplayName
+	}
+	if name := resource.Name; name != nil {
+		if name.Formatted != "" {
+			return name.Formatted
+		}
+		if fullName := strings.TrimSpace(name.GivenName + " " + name.FamilyName); fullName != "" {
+			return fullName
+		}
+	}
+	return email
+}
@@ -175,11 +175,8 @@ func (in *ACLInterceptor) getProjectRoles(ctx context.Context, user *store.UserM
 	}
 	roles := map[api.Role]bool{}
 	for _, binding := range projectPolicy.Bindings {
-		for _, member := range binding.Members {
-			if member.ID == user.ID {
-				roles[binding.Role] = true
-				break
-			}
+		if binding.HasMember(user.ID) {
+			roles[binding.Role] = true
 		}
 	}
 	return roles, nil
@@ -31,6 +31,13 @@ var ownerAndDBAMethods = map[string]bool{
 	"RoleService/CreateRole":                 true,
 	"RoleService/UpdateRole":                 true,
 	"RoleService/DeleteRole":                 true,
+	"GroupService/CreateGroup":               true,
+	"GroupService/UpdateGroup":               true,
+	"GroupService/DeleteGroup":               true,
+	"SQLService/CreateSession":               true,
+	"SQLService/BeginTransaction":            true,
+	"SQLService/CommitTransaction":           true,
+	"SQLService/RollbackTransaction":         true,
 	"ActuatorService/UpdateActuatorInfo":     true,
 	"ActuatorService/ListDebugLog":           true,
 }
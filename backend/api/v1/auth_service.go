@@ -19,6 +19,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/bytebase/bytebase/backend/api/auth"
 	"github.com/bytebase/bytebase/backend/common"
@@ -41,6 +42,7 @@ type CreateUserFunc func(ctx context.Context, user *store.UserMessage, firstEndU
 
 var (
 	invalidUserOrPasswordError = status.Errorf(codes.Unauthenticated, "The email or password is not valid.")
+	accessTokenPermissionRegex = regexp.MustCompile(`^[a-z]+:([a-z]+|\*)$`)
 )
 
 // AuthService implements the auth service.
@@ -474,6 +476,169 @@ func (s *AuthService) UndeleteUser(ctx context.Context, request *v1pb.UndeleteUs
 	return convertToUser(user), nil
 }
 
+// CreateAccessToken creates a personal access token for the user.
+func (s *AuthService) CreateAccessToken(ctx context.Context, request *v1pb.CreateAccessTokenRequest) (*v1pb.AccessToken, error) {
+	user, err := s.getAccessTokenUser(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if request.AccessToken == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "access token must be set")
+	}
+	if request.AccessToken.Title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "access token title must be set")
+	}
+	if request.AccessToken.ExpireTime == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "access token expire time must be set")
+	}
+	expireTime := request.AccessToken.ExpireTime.AsTime()
+	if !expireTime.After(time.Now()) {
+		return nil, status.Errorf(codes.InvalidArgument, "access token expire time must be in the future")
+	}
+
+	var projectList []string
+	for _, name := range request.AccessToken.Projects {
+		projectID, err := common.GetProjectID(name)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &projectID})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get project %q, error: %v", projectID, err)
+		}
+		if project == nil {
+			return nil, status.Errorf(codes.NotFound, "project %q not found", projectID)
+		}
+		projectList = append(projectList, projectID)
+	}
+	for _, permission := range request.AccessToken.Permissions {
+		if !accessTokenPermissionRegex.MatchString(permission) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid permission %q, should be in the format of {resource}:{action}", permission)
+		}
+	}
+	// A personal access token can only create tokens within its own scopes.
+	if callerAccessToken, ok := ctx.Value(common.AccessTokenContextKey).(*store.AccessTokenMessage); ok {
+		if err := auth.CheckAccessTokenScopeSubset(callerAccessToken, projectList, request.AccessToken.Permissions, expireTime.Unix()); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+	}
+
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	accessToken, err := s.store.CreateAccessToken(ctx, &store.AccessTokenMessage{
+		PrincipalUID:   user.ID,
+		Title:          request.AccessToken.Title,
+		ProjectList:    projectList,
+		PermissionList: request.AccessToken.Permissions,
+		ExpireTs:       expireTime.Unix(),
+	}, principalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create access token, error: %v", err)
+	}
+	token, err := auth.GeneratePersonalAccessToken(user.Name, user.ID, accessToken.UID, s.profile.Mode, s.secret, expireTime)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate access token, error: %v", err)
+	}
+
+	v1AccessToken := convertToAccessToken(accessToken)
+	v1AccessToken.Token = token
+	return v1AccessToken, nil
+}
+
+// ListAccessTokens lists the personal access tokens of the user.
+func (s *AuthService) ListAccessTokens(ctx context.Context, request *v1pb.ListAccessTokensRequest) (*v1pb.ListAccessTokensResponse, error) {
+	user, err := s.getAccessTokenUser(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	accessTokens, err := s.store.ListAccessTokens(ctx, &store.FindAccessTokenMessage{
+		PrincipalUID: &user.ID,
+		ShowRevoked:  request.ShowRevoked,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list access tokens, error: %v", err)
+	}
+	response := &v1pb.ListAccessTokensResponse{}
+	for _, accessToken := range accessTokens {
+		response.AccessTokens = append(response.AccessTokens, convertToAccessToken(accessToken))
+	}
+	return response, nil
+}
+
+// RevokeAccessToken revokes a personal access token of the user.
+func (s *AuthService) RevokeAccessToken(ctx context.Context, request *v1pb.RevokeAccessTokenRequest) (*v1pb.AccessToken, error) {
+	userID, accessTokenID, err := common.GetUserIDAccessTokenID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	user, err := s.getAccessTokenUser(ctx, fmt.Sprintf("%s%d", common.UserNamePrefix, userID))
+	if err != nil {
+		return nil, err
+	}
+	accessToken, err := s.store.GetAccessToken(ctx, &store.FindAccessTokenMessage{
+		UID:          &accessTokenID,
+		PrincipalUID: &user.ID,
+		ShowRevoked:  true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get access token, error: %v", err)
+	}
+	if accessToken == nil {
+		return nil, status.Errorf(codes.NotFound, "access token %q not found", request.Name)
+	}
+	if accessToken.Revoked {
+		return nil, status.Errorf(codes.FailedPrecondition, "access token %q has been revoked", request.Name)
+	}
+
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	if err := s.store.RevokeAccessToken(ctx, accessToken.UID, principalID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke access token, error: %v", err)
+	}
+	accessToken.Revoked = true
+	return convertToAccessToken(accessToken), nil
+}
+
+// getAccessTokenUser returns the user owning the access tokens. Only the workspace owner or the user itself can manage the access tokens.
+func (s *AuthService) getAccessTokenUser(ctx context.Context, name string) (*store.UserMessage, error) {
+	userID, err := common.GetUserID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	role := ctx.Value(common.RoleContextKey).(api.Role)
+	if principalID != userID && role != api.Owner {
+		return nil, status.Errorf(codes.PermissionDenied, "only workspace owner or user itself can manage the access tokens of user %d", userID)
+	}
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user, error: %v", err)
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", userID)
+	}
+	if user.MemberDeleted {
+		return nil, status.Errorf(codes.NotFound, "user %d has been deleted", userID)
+	}
+	return user, nil
+}
+
+func convertToAccessToken(accessToken *store.AccessTokenMessage) *v1pb.AccessToken {
+	v1AccessToken := &v1pb.AccessToken{
+		Name:        fmt.Sprintf("%s%d/%s%d", common.UserNamePrefix, accessToken.PrincipalUID, common.AccessTokenPrefix, accessToken.UID),
+		Title:       accessToken.Title,
+		Permissions: accessToken.PermissionList,
+		ExpireTime:  timestamppb.New(time.Unix(accessToken.ExpireTs, 0)),
+		CreateTime:  timestamppb.New(time.Unix(accessToken.CreatedTs, 0)),
+		Revoked:     accessToken.Revoked,
+	}
+	for _, projectID := range accessToken.ProjectList {
+		v1AccessToken.Projects = append(v1AccessToken.Projects, fmt.Sprintf("%s%s", common.ProjectNamePrefix, projectID))
+	}
+	if accessToken.LastUsedTs != 0 {
+		v1AccessToken.LastUsedTime = timestamppb.New(time.Unix(accessToken.LastUsedTs, 0))
+	}
+	return v1AccessToken
+}
+
 func convertToUser(user *store.UserMessage) *v1pb.User {
 	role := v1pb.UserRole_USER_ROLE_UNSPECIFIED
 	switch user.Role {
@@ -774,6 +939,22 @@ func (s *AuthService) getOrCreateUserWithIDP(ctx context.Context, request *v1pb.
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to get user info: %v", err)
 		}
+	} else if idp.Type == storepb.IdentityProviderType_SAML {
+		samlContext := request.IdpContext.GetSamlContext()
+		if samlContext == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "missing SAML context")
+		}
+		samlIDP, err := NewSAMLIdentityProvider(setting.ExternalUrl, idp.ResourceID, idp.Config.GetSamlConfig())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create new SAML identity provider: %v", err)
+		}
+		// Each authentication request can only be used to sign in once.
+		userInfo, err = samlIDP.UserInfo(samlContext.SamlResponse, func(requestID string) (bool, error) {
+			return s.store.ConsumeSAMLRequest(ctx, requestID, idp.ResourceID)
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "failed to get user info: %v", err)
+		}
 	} else {
 		return nil, status.Errorf(codes.InvalidArgument, "identity provider type %s not supported", idp.Type.String())
 	}
@@ -831,9 +1012,51 @@ func (s *AuthService) getOrCreateUserWithIDP(ctx context.Context, request *v1pb.
 		user = users[0]
 	}
 
+	if fieldMapping := getIdentityProviderFieldMapping(idp.Config); fieldMapping != nil && fieldMapping.Groups != "" {
+		if err := s.syncUserGroups(ctx, idp, user, userInfo.Groups); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to sync user groups: %v", err)
+		}
+	}
+
 	return user, nil
 }
 
+// syncUserGroups syncs the group membership of the user from the identity provider.
+// Only the user groups linked to the identity provider are synced, which are matched by the identity provider groups.
+// The user is added to the matched groups, and removed from the other groups that the identity provider has added the user to.
+func (s *AuthService) syncUserGroups(ctx context.Context, idp *store.IdentityProviderMessage, user *store.UserMessage, idpGroups []string) error {
+	idpGroupMap := make(map[string]bool)
+	for _, group := range idpGroups {
+		idpGroupMap[strings.ToLower(group)] = true
+	}
+	groups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{IdentityProviderID: &idp.ResourceID})
+	if err != nil {
+		return err
+	}
+	groupUIDs := []int{}
+	for _, group := range groups {
+		if group.IdentityProviderGroup != "" && idpGroupMap[strings.ToLower(group.IdentityProviderGroup)] {
+			groupUIDs = append(groupUIDs, group.UID)
+		}
+	}
+	return s.store.SyncUserGroupMemberships(ctx, idp.ResourceID, user.ID, groupUIDs)
+}
+
+func getIdentityProviderFieldMapping(config *storepb.IdentityProviderConfig) *storepb.FieldMapping {
+	switch v := config.GetConfig().(type) {
+	case *storepb.IdentityProviderConfig_Oauth2Config:
+		return v.Oauth2Config.GetFieldMapping()
+	case *storepb.IdentityProviderConfig_OidcConfig:
+		return v.OidcConfig.GetFieldMapping()
+	case *storepb.IdentityProviderConfig_LdapConfig:
+		return v.LdapConfig.GetFieldMapping()
+	case *storepb.IdentityProviderConfig_SamlConfig:
+		return v.SamlConfig.GetFieldMapping()
+	default:
+		return nil
+	}
+}
+
 func challengeMFACode(user *store.UserMessage, mfaCode string) error {
 	if !validateWithCodeAndSecret(mfaCode, user.MFAConfig.OtpSecret) {
 		return status.Errorf(codes.Unauthenticated, "invalid MFA code")
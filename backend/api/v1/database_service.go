@@ -1027,6 +1027,10 @@ func (s *DatabaseService) getParserEngine(ctx context.Context, request *v1pb.Dif
 		engine = parser.TiDB
 	case db.Oracle:
 		engine = parser.Oracle
+	case db.MSSQL:
+		engine = parser.MSSQL
+	case db.Snowflake:
+		engine = parser.Snowflake
 	default:
 		return engine, status.Errorf(codes.InvalidArgument, fmt.Sprintf("invalid engine type %v", instance.Engine))
 	}
@@ -1076,6 +1080,9 @@ func convertToChangeHistory(h *store.InstanceChangeHistoryMessage) (*v1pb.Change
 	if h.Payload != nil && h.Payload.ChangedResources != nil {
 		v1pbHistory.ChangedResources = convertToChangedResources(h.Payload.ChangedResources)
 	}
+	if h.SheetID != nil && h.IssueUID != nil && h.Payload != nil && h.Payload.SheetRevision > 0 {
+		v1pbHistory.SheetRevision = formatSheetRevisionName(h.IssueProjectID, *h.SheetID, int(h.Payload.SheetRevision))
+	}
 	return v1pbHistory, nil
 }
 
@@ -2154,10 +2161,8 @@ func isProjectOwnerOrDeveloper(principalID int, projectPolicy *store.IAMPolicyMe
 		if binding.Role != api.Owner && binding.Role != api.Developer {
 			continue
 		}
-		for _, member := range binding.Members {
-			if member.ID == principalID {
-				return true
-			}
+		if binding.HasMember(principalID) {
+			return true
 		}
 	}
 	return false
@@ -0,0 +1,343 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/idp/ldap"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// GroupService implements the group service.
+type GroupService struct {
+	v1pb.UnimplementedGroupServiceServer
+	store          *store.Store
+	licenseService enterpriseAPI.LicenseService
+}
+
+// NewGroupService creates a new GroupService.
+func NewGroupService(store *store.Store, licenseService enterpriseAPI.LicenseService) *GroupService {
+	return &GroupService{
+		store:          store,
+		licenseService: licenseService,
+	}
+}
+
+// GetGroup gets a group.
+func (s *GroupService) GetGroup(ctx context.Context, request *v1pb.GetGroupRequest) (*v1pb.Group, error) {
+	group, err := s.getGroupByName(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToGroup(ctx, group)
+}
+
+// ListGroups lists the groups.
+// The groups provisioned without email cannot be referenced in the IAM policies, so they are not listed.
+func (s *GroupService) ListGroups(ctx context.Context, _ *v1pb.ListGroupsRequest) (*v1pb.ListGroupsResponse, error) {
+	groups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list groups, error: %v", err)
+	}
+	response := &v1pb.ListGroupsResponse{}
+	for _, group := range groups {
+		if group.Email == "" {
+			continue
+		}
+		v1Group, err := s.convertToGroup(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+		response.Groups = append(response.Groups, v1Group)
+	}
+	return response, nil
+}
+
+// CreateGroup creates a group.
+func (s *GroupService) CreateGroup(ctx context.Context, request *v1pb.CreateGroupRequest) (*v1pb.Group, error) {
+	if err := s.licenseService.IsFeatureEnabled(api.FeatureRBAC); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, err.Error())
+	}
+	if request.Group == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "group must be set")
+	}
+	email, err := validateGroupEmail(request.GroupEmail)
+	if err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(request.Group.Title)
+	if title == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "group title must be set")
+	}
+	existingGroup, err := s.store.GetUserGroup(ctx, &store.FindUserGroupMessage{Email: &email})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find group by email, error: %v", err)
+	}
+	if existingGroup != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "group %q already exists", email)
+	}
+	if err := s.checkGroupTitleUnique(ctx, title, 0); err != nil {
+		return nil, err
+	}
+	memberUIDs, err := s.getMemberUIDs(ctx, request.Group.Members)
+	if err != nil {
+		return nil, err
+	}
+	identityProviderID, identityProviderGroup, err := s.getIdentityProviderGroup(ctx, request.Group.IdentityProvider, request.Group.IdentityProviderGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	group, err := s.store.CreateUserGroup(ctx, &store.UserGroupMessage{
+		Name:        title,
+		Email:       email,
+		Description: request.Group.Description,
+		MemberUIDs:  memberUIDs,
+
+		IdentityProviderID:    identityProviderID,
+		IdentityProviderGroup: identityProviderGroup,
+	}, principalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create group, error: %v", err)
+	}
+	return s.convertToGroup(ctx, group)
+}
+
+// UpdateGroup updates a group.
+func (s *GroupService) UpdateGroup(ctx context.Context, request *v1pb.UpdateGroupRequest) (*v1pb.Group, error) {
+	if err := s.licenseService.IsFeatureEnabled(api.FeatureRBAC); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, err.Error())
+	}
+	if request.Group == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "group must be set")
+	}
+	if request.UpdateMask == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "update_mask must be set")
+	}
+	group, err := s.getGroupByName(ctx, request.Group.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	patch := &store.UpdateUserGroupMessage{}
+	for _, path := range request.UpdateMask.Paths {
+		switch path {
+		case "title":
+			title := strings.TrimSpace(request.Group.Title)
+			if title == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "group title must be set")
+			}
+			if err := s.checkGroupTitleUnique(ctx, title, group.UID); err != nil {
+				return nil, err
+			}
+			patch.Name = &title
+		case "description":
+			patch.Description = &request.Group.Description
+		case "members":
+			memberUIDs, err := s.getMemberUIDs(ctx, request.Group.Members)
+			if err != nil {
+				return nil, err
+			}
+			patch.MemberUIDs = &memberUIDs
+		case "identity_provider", "identity_provider_group":
+			// The identity provider and the group are validated together below.
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update mask path: %s", path)
+		}
+	}
+	if slices.Contains(request.UpdateMask.Paths, "identity_provider") || slices.Contains(request.UpdateMask.Paths, "identity_provider_group") {
+		identityProvider := ""
+		if group.IdentityProviderID != "" {
+			identityProvider = fmt.Sprintf("%s%s", common.IdentityProviderNamePrefix, group.IdentityProviderID)
+		}
+		if slices.Contains(request.UpdateMask.Paths, "identity_provider") {
+			identityProvider = request.Group.IdentityProvider
+		}
+		identityProviderGroup := group.IdentityProviderGroup
+		if slices.Contains(request.UpdateMask.Paths, "identity_provider_group") {
+			identityProviderGroup = request.Group.IdentityProviderGroup
+		}
+		identityProviderID, identityProviderGroup, err := s.getIdentityProviderGroup(ctx, identityProvider, identityProviderGroup)
+		if err != nil {
+			return nil, err
+		}
+		patch.IdentityProviderID = &identityProviderID
+		patch.IdentityProviderGroup = &identityProviderGroup
+	}
+
+	principalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	group, err = s.store.UpdateUserGroup(ctx, group.UID, patch, principalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update group, error: %v", err)
+	}
+	return s.convertToGroup(ctx, group)
+}
+
+// DeleteGroup deletes a group.
+func (s *GroupService) DeleteGroup(ctx context.Context, request *v1pb.DeleteGroupRequest) (*emptypb.Empty, error) {
+	group, err := s.getGroupByName(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.DeleteUserGroup(ctx, group.UID); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete group, error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *GroupService) getGroupByName(ctx context.Context, name string) (*store.UserGroupMessage, error) {
+	email, err := common.GetGroupEmail(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	group, err := s.store.GetUserGroup(ctx, &store.FindUserGroupMessage{Email: &email})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get group, error: %v", err)
+	}
+	if group == nil {
+		return nil, status.Errorf(codes.NotFound, "group %q not found", name)
+	}
+	return group, nil
+}
+
+func (s *GroupService) checkGroupTitleUnique(ctx context.Context, title string, uid int) error {
+	existingGroup, err := s.store.GetUserGroup(ctx, &store.FindUserGroupMessage{Name: &title})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to find group by title, error: %v", err)
+	}
+	if existingGroup != nil && existingGroup.UID != uid {
+		return status.Errorf(codes.AlreadyExists, "group with title %q already exists", title)
+	}
+	return nil
+}
+
+// getIdentityProviderGroup validates the identity provider and the group in it that the group is linked to,
+// and returns the identity provider resource ID and the normalized group identifier.
+func (s *GroupService) getIdentityProviderGroup(ctx context.Context, identityProvider, identityProviderGroup string) (string, string, error) {
+	identityProviderGroup = strings.TrimSpace(identityProviderGroup)
+	if identityProvider == "" {
+		if identityProviderGroup != "" {
+			return "", "", status.Errorf(codes.InvalidArgument, "identity provider must be set with the identity provider group")
+		}
+		return "", "", nil
+	}
+	identityProviderID, err := common.GetIdentityProviderID(identityProvider)
+	if err != nil {
+		return "", "", status.Errorf(codes.InvalidArgument, "invalid identity provider %q, error: %v", identityProvider, err)
+	}
+	idp, err := s.store.GetIdentityProvider(ctx, &store.FindIdentityProviderMessage{ResourceID: &identityProviderID})
+	if err != nil {
+		return "", "", status.Errorf(codes.Internal, "failed to get identity provider, error: %v", err)
+	}
+	if idp == nil || idp.Deleted {
+		return "", "", status.Errorf(codes.NotFound, "identity provider %q not found", identityProvider)
+	}
+	if identityProviderGroup == "" {
+		return "", "", status.Errorf(codes.InvalidArgument, "identity provider group must be set with the identity provider")
+	}
+	// The LDAP groups are matched by the normalized DNs.
+	if idp.Type == storepb.IdentityProviderType_LDAP {
+		identityProviderGroup = ldap.NormalizeDN(identityProviderGroup)
+	}
+	return identityProviderID, identityProviderGroup, nil
+}
+
+// getMemberUIDs converts the members in users/{email} format to the user IDs.
+func (s *GroupService) getMemberUIDs(ctx context.Context, members []string) ([]int, error) {
+	var memberUIDs []int
+	for _, member := range members {
+		email, err := common.GetUserEmail(member)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid member %q, error: %v", member, err)
+		}
+		user, err := s.store.GetUser(ctx, &store.FindUserMessage{Email: &email})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user %q, error: %v", email, err)
+		}
+		if user == nil {
+			return nil, status.Errorf(codes.NotFound, "user %q not found", member)
+		}
+		memberUIDs = append(memberUIDs, user.ID)
+	}
+	return memberUIDs, nil
+}
+
+func (s *GroupService) convertToGroup(ctx context.Context, group *store.UserGroupMessage) (*v1pb.Group, error) {
+	v1Group := &v1pb.Group{
+		Name:        common.FormatGroupEmail(group.Email),
+		Title:       group.Name,
+		Description: group.Description,
+		CreateTime:  timestamppb.New(time.Unix(group.CreatedTs, 0)),
+
+		IdentityProviderGroup: group.IdentityProviderGroup,
+	}
+	if group.IdentityProviderID != "" {
+		v1Group.IdentityProvider = fmt.Sprintf("%s%s", common.IdentityProviderNamePrefix, group.IdentityProviderID)
+	}
+	creator, err := s.store.GetUserByID(ctx, group.CreatorUID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get creator, error: %v", err)
+	}
+	if creator != nil {
+		v1Group.Creator = common.FormatUserEmail(creator.Email)
+	}
+	for _, memberUID := range group.MemberUIDs {
+		user, err := s.store.GetUserByID(ctx, memberUID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get member, error: %v", err)
+		}
+		if user == nil {
+			continue
+		}
+		v1Group.Members = append(v1Group.Members, common.FormatUserEmail(user.Email))
+	}
+	return v1Group, nil
+}
+
+func validateGroupEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", status.Errorf(codes.InvalidArgument, "group email must be set")
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return "", status.Errorf(codes.InvalidArgument, "invalid group email %q", email)
+	}
+	return email, nil
+}
+
+// getGroupByMember gets the group of the IAM member in group:{email} format.
+func getGroupByMember(ctx context.Context, s *store.Store, member string) (*store.UserGroupMessage, error) {
+	email := strings.TrimPrefix(member, groupMemberPrefix)
+	group, err := s.GetUserGroup(ctx, &store.FindUserGroupMessage{Email: &email})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get group %q, error: %v", email, err)
+	}
+	if group == nil {
+		return nil, status.Errorf(codes.NotFound, "group %q not found", member)
+	}
+	return group, nil
+}
+
+const (
+	userMemberPrefix  = "user:"
+	groupMemberPrefix = "group:"
+)
+
+func formatGroupMember(group *store.UserGroupMessage) string {
+	return fmt.Sprintf("%s%s", groupMemberPrefix, group.Email)
+}
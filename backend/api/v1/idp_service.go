@@ -15,6 +15,7 @@ import (
 	"github.com/bytebase/bytebase/backend/plugin/idp/ldap"
 	"github.com/bytebase/bytebase/backend/plugin/idp/oauth2"
 	"github.com/bytebase/bytebase/backend/plugin/idp/oidc"
+	"github.com/bytebase/bytebase/backend/plugin/idp/saml"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
@@ -144,6 +145,10 @@ func (s *IdentityProviderService) UpdateIdentityProvider(ctx context.Context, re
 			if request.IdentityProvider.Config.GetLdapConfig().BindPassword == "" {
 				patch.Config.GetLdapConfig().BindPassword = identityProvider.Config.GetLdapConfig().BindPassword
 			}
+		} else if identityProvider.Type == storepb.IdentityProviderType_SAML {
+			if request.IdentityProvider.Config.GetSamlConfig().SpPrivateKey == "" {
+				patch.Config.GetSamlConfig().SpPrivateKey = identityProvider.Config.GetSamlConfig().SpPrivateKey
+			}
 		}
 	}
 
@@ -314,12 +319,61 @@ func (s *IdentityProviderService) TestIdentityProvider(ctx context.Context, requ
 			return nil, status.Errorf(codes.InvalidArgument, "failed to test connection, error: %s", err.Error())
 		}
 		_ = conn.Close()
+	} else if identityProvider.Type == v1pb.IdentityProviderType_SAML {
+		// Retrieve service provider private key from stored identity provider if not provided.
+		if request.IdentityProvider.Config.GetSamlConfig().SpPrivateKey == "" {
+			storedIdentityProvider, err := s.getIdentityProviderMessage(ctx, request.IdentityProvider.Name)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to find identity provider, error: %s", err.Error())
+			}
+			if storedIdentityProvider == nil {
+				return nil, status.Errorf(codes.Internal, "identity provider %s not found", request.IdentityProvider.Name)
+			}
+			request.IdentityProvider.Config.GetSamlConfig().SpPrivateKey = storedIdentityProvider.Config.GetSamlConfig().SpPrivateKey
+		}
+		identityProviderID, err := common.GetIdentityProviderID(request.IdentityProvider.Name)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		identityProviderConfig := convertIdentityProviderConfigToStore(identityProvider.Config).GetSamlConfig()
+		samlIdentityProvider, err := NewSAMLIdentityProvider(setting.ExternalUrl, identityProviderID, identityProviderConfig)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to create new SAML identity provider: %v", err)
+		}
+		if _, err := samlIdentityProvider.Metadata(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to generate service provider metadata, error: %s", err.Error())
+		}
+		// The SAML response is validated without the request ID as the test doesn't sign in.
+		if samlContext := request.GetSamlContext(); samlContext != nil {
+			if _, err := samlIdentityProvider.UserInfo(samlContext.SamlResponse, nil); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "failed to get user info, error: %s", err.Error())
+			}
+		}
 	} else {
 		return nil, status.Errorf(codes.InvalidArgument, "identity provider type %s not supported", identityProvider.Type.String())
 	}
 	return &v1pb.TestIdentityProviderResponse{}, nil
 }
 
+// NewSAMLIdentityProvider creates the SAML identity provider with the service
+// provider entity ID and the assertion consumer service URL of Bytebase.
+func NewSAMLIdentityProvider(externalURL, identityProviderID string, config *storepb.SAMLIdentityProviderConfig) (*saml.IdentityProvider, error) {
+	if config == nil {
+		return nil, errors.Errorf("SAML identity provider config must be set")
+	}
+	return saml.NewIdentityProvider(saml.IdentityProviderConfig{
+		EntityID:      config.EntityId,
+		SSOURL:        config.SsoUrl,
+		Certificate:   config.Certificate,
+		SPCertificate: config.SpCertificate,
+		SPPrivateKey:  config.SpPrivateKey,
+		NameIDFormat:  config.NameIdFormat,
+		FieldMapping:  config.FieldMapping,
+		SPEntityID:    fmt.Sprintf("%s/saml/%s/metadata", externalURL, identityProviderID),
+		ACSURL:        fmt.Sprintf("%s/saml/%s/acs", externalURL, identityProviderID),
+	})
+}
+
 func (s *IdentityProviderService) getIdentityProviderMessage(ctx context.Context, name string) (*store.IdentityProviderMessage, error) {
 	identityProviderID, err := common.GetIdentityProviderID(name)
 	if err != nil {
@@ -360,6 +414,7 @@ func convertIdentityProviderConfigFromStore(identityProviderConfig *storepb.Iden
 			DisplayName: v.FieldMapping.DisplayName,
 			Email:       v.FieldMapping.Email,
 			Phone:       v.FieldMapping.Phone,
+			Groups:      v.FieldMapping.Groups,
 		}
 		return &v1pb.IdentityProviderConfig{
 			Config: &v1pb.IdentityProviderConfig_Oauth2Config{
@@ -382,6 +437,7 @@ func convertIdentityProviderConfigFromStore(identityProviderConfig *storepb.Iden
 			DisplayName: v.FieldMapping.DisplayName,
 			Email:       v.FieldMapping.Email,
 			Phone:       v.FieldMapping.Phone,
+			Groups:      v.FieldMapping.Groups,
 		}
 		return &v1pb.IdentityProviderConfig{
 			Config: &v1pb.IdentityProviderConfig_OidcConfig{
@@ -402,6 +458,7 @@ func convertIdentityProviderConfigFromStore(identityProviderConfig *storepb.Iden
 			DisplayName: v.FieldMapping.DisplayName,
 			Email:       v.FieldMapping.Email,
 			Phone:       v.FieldMapping.Phone,
+			Groups:      v.FieldMapping.Groups,
 		}
 		return &v1pb.IdentityProviderConfig{
 			Config: &v1pb.IdentityProviderConfig_LdapConfig{
@@ -418,6 +475,27 @@ func convertIdentityProviderConfigFromStore(identityProviderConfig *storepb.Iden
 				},
 			},
 		}
+	} else if v := identityProviderConfig.GetSamlConfig(); v != nil {
+		fieldMapping := v1pb.FieldMapping{
+			Identifier:  v.FieldMapping.Identifier,
+			DisplayName: v.FieldMapping.DisplayName,
+			Email:       v.FieldMapping.Email,
+			Phone:       v.FieldMapping.Phone,
+			Groups:      v.FieldMapping.Groups,
+		}
+		return &v1pb.IdentityProviderConfig{
+			Config: &v1pb.IdentityProviderConfig_SamlConfig{
+				SamlConfig: &v1pb.SAMLIdentityProviderConfig{
+					EntityId:      v.EntityId,
+					SsoUrl:        v.SsoUrl,
+					Certificate:   v.Certificate,
+					SpCertificate: v.SpCertificate,
+					SpPrivateKey:  "", // SECURITY: We do not expose the service provider private key
+					NameIdFormat:  v.NameIdFormat,
+					FieldMapping:  &fieldMapping,
+				},
+			},
+		}
 	}
 	return nil
 }
@@ -429,6 +507,7 @@ func convertIdentityProviderConfigToStore(identityProviderConfig *v1pb.IdentityP
 			DisplayName: v.FieldMapping.DisplayName,
 			Email:       v.FieldMapping.Email,
 			Phone:       v.FieldMapping.Phone,
+			Groups:      v.FieldMapping.Groups,
 		}
 		return &storepb.IdentityProviderConfig{
 			Config: &storepb.IdentityProviderConfig_Oauth2Config{
@@ -451,6 +530,7 @@ func convertIdentityProviderConfigToStore(identityProviderConfig *v1pb.IdentityP
 			DisplayName: v.FieldMapping.DisplayName,
 			Email:       v.FieldMapping.Email,
 			Phone:       v.FieldMapping.Phone,
+			Groups:      v.FieldMapping.Groups,
 		}
 		return &storepb.IdentityProviderConfig{
 			Config: &storepb.IdentityProviderConfig_OidcConfig{
@@ -470,6 +550,7 @@ func convertIdentityProviderConfigToStore(identityProviderConfig *v1pb.IdentityP
 			DisplayName: v.FieldMapping.DisplayName,
 			Email:       v.FieldMapping.Email,
 			Phone:       v.FieldMapping.Phone,
+			Groups:      v.FieldMapping.Groups,
 		}
 		return &storepb.IdentityProviderConfig{
 			Config: &storepb.IdentityProviderConfig_LdapConfig{
@@ -486,6 +567,27 @@ func convertIdentityProviderConfigToStore(identityProviderConfig *v1pb.IdentityP
 				},
 			},
 		}
+	} else if v := identityProviderConfig.GetSamlConfig(); v != nil {
+		fieldMapping := storepb.FieldMapping{
+			Identifier:  v.FieldMapping.Identifier,
+			DisplayName: v.FieldMapping.DisplayName,
+			Email:       v.FieldMapping.Email,
+			Phone:       v.FieldMapping.Phone,
+			Groups:      v.FieldMapping.Groups,
+		}
+		return &storepb.IdentityProviderConfig{
+			Config: &storepb.IdentityProviderConfig_SamlConfig{
+				SamlConfig: &storepb.SAMLIdentityProviderConfig{
+					EntityId:      v.EntityId,
+					SsoUrl:        v.SsoUrl,
+					Certificate:   v.Certificate,
+					SpCertificate: v.SpCertificate,
+					SpPrivateKey:  v.SpPrivateKey,
+					NameIdFormat:  v.NameIdFormat,
+					FieldMapping:  &fieldMapping,
+				},
+			},
+		}
 	} else {
 		return nil
 	}
@@ -505,6 +607,10 @@ func validIdentityProviderConfig(identityProviderType v1pb.IdentityProviderType,
 		if identityProviderConfig.GetLdapConfig() == nil {
 			return errors.Errorf("unexpected provider config value")
 		}
+	} else if identityProviderType == v1pb.IdentityProviderType_SAML {
+		if identityProviderConfig.GetSamlConfig() == nil {
+			return errors.Errorf("unexpected provider config value")
+		}
 	} else {
 		return errors.Errorf("unexpected provider type %s", identityProviderType)
 	}
@@ -270,7 +270,7 @@ func (s *InstanceService) syncSlowQueriesForInstance(ctx context.Context, instan
 
 func (s *InstanceService) syncSlowQueriesImpl(ctx context.Context, project *store.ProjectMessage, instance *store.InstanceMessage) error {
 	switch instance.Engine {
-	case db.MySQL:
+	case db.MySQL, db.MSSQL, db.Oracle, db.MongoDB, db.ClickHouse:
 		driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, nil /* database */)
 		if err != nil {
 			return err
@@ -364,7 +364,7 @@ func (s *InstanceService) syncSlowQueriesForProject(ctx context.Context, project
 		}
 
 		switch instance.Engine {
-		case db.MySQL, db.Postgres:
+		case db.MySQL, db.Postgres, db.MSSQL, db.Oracle, db.MongoDB, db.ClickHouse:
 			if instance.Deleted {
 				continue
 			}
@@ -663,6 +663,27 @@ func (s *InstanceService) UpdateDataSource(ctx context.Context, request *v1pb.Up
 			obfuscated := common.Obfuscate(request.DataSource.SshPrivateKey, s.secret)
 			patch.SSHObfuscatedPrivateKey = &obfuscated
 			dataSource.SSHObfuscatedPrivateKey = obfuscated
+		case "external_secret":
+			externalSecret := s.convertDataSourceExternalSecret(request.DataSource.ExternalSecret)
+			// The token and secret ID are input only, so the stored ones are kept if they are not set.
+			// They are only kept for the same Vault server and auth, so that they are never sent to another server.
+			if stored := dataSource.ExternalSecret; externalSecret != nil && stored != nil &&
+				externalSecret.Url == stored.Url && externalSecret.AuthType == stored.AuthType && externalSecret.AppRoleId == stored.AppRoleId {
+				if request.DataSource.ExternalSecret.Token == "" {
+					externalSecret.ObfuscatedToken = stored.ObfuscatedToken
+				}
+				if request.DataSource.ExternalSecret.AppRoleSecretId == "" {
+					externalSecret.ObfuscatedAppRoleSecretId = stored.ObfuscatedAppRoleSecretId
+				}
+			}
+			patch.ExternalSecret = externalSecret
+			patch.RemoveExternalSecret = externalSecret == nil
+			dataSource.ExternalSecret = externalSecret
+		case "iam_authentication":
+			iamAuthentication := convertDataSourceIAMAuthentication(request.DataSource.IamAuthentication)
+			patch.IAMAuthentication = iamAuthentication
+			patch.RemoveIAMAuthentication = iamAuthentication == nil
+			dataSource.IAMAuthentication = iamAuthentication
 		default:
 			return nil, status.Errorf(codes.InvalidArgument, `unsupport update_mask "%s"`, path)
 		}
@@ -838,6 +859,8 @@ func convertToInstance(instance *store.InstanceMessage) *v1pb.Instance {
 			AuthenticationDatabase: ds.AuthenticationDatabase,
 			Sid:                    ds.SID,
 			ServiceName:            ds.ServiceName,
+			ExternalSecret:         convertToDataSourceExternalSecret(ds.ExternalSecret),
+			IamAuthentication:      convertToDataSourceIAMAuthentication(ds.IAMAuthentication),
 		})
 	}
 	return &v1pb.Instance{
@@ -916,9 +939,70 @@ func (s *InstanceService) convertToDataSourceMessage(dataSource *v1pb.DataSource
 		SSHUser:                 dataSource.SshUser,
 		SSHObfuscatedPassword:   common.Obfuscate(dataSource.SshPassword, s.secret),
 		SSHObfuscatedPrivateKey: common.Obfuscate(dataSource.SshPrivateKey, s.secret),
+		ExternalSecret:          s.convertDataSourceExternalSecret(dataSource.ExternalSecret),
+		IAMAuthentication:       convertDataSourceIAMAuthentication(dataSource.IamAuthentication),
 	}, nil
 }
 
+// convertToDataSourceExternalSecret converts the external secret without the token and secret ID, which are input only.
+func convertToDataSourceExternalSecret(externalSecret *storepb.DataSourceExternalSecret) *v1pb.DataSourceExternalSecret {
+	if externalSecret == nil {
+		return nil
+	}
+	return &v1pb.DataSourceExternalSecret{
+		SecretType:      v1pb.DataSourceExternalSecret_SecretType(externalSecret.SecretType),
+		AuthType:        v1pb.DataSourceExternalSecret_AuthType(externalSecret.AuthType),
+		Url:             externalSecret.Url,
+		AppRoleId:       externalSecret.AppRoleId,
+		EngineName:      externalSecret.EngineName,
+		SecretName:      externalSecret.SecretName,
+		PasswordKeyName: externalSecret.PasswordKeyName,
+		UsernameKeyName: externalSecret.UsernameKeyName,
+		DatabaseRole:    externalSecret.DatabaseRole,
+		Region:          externalSecret.Region,
+	}
+}
+
+func (s *InstanceService) convertDataSourceExternalSecret(externalSecret *v1pb.DataSourceExternalSecret) *storepb.DataSourceExternalSecret {
+	if externalSecret == nil {
+		return nil
+	}
+	return &storepb.DataSourceExternalSecret{
+		SecretType:                storepb.DataSourceExternalSecret_SecretType(externalSecret.SecretType),
+		AuthType:                  storepb.DataSourceExternalSecret_AuthType(externalSecret.AuthType),
+		Url:                       externalSecret.Url,
+		ObfuscatedToken:           common.Obfuscate(externalSecret.Token, s.secret),
+		AppRoleId:                 externalSecret.AppRoleId,
+		ObfuscatedAppRoleSecretId: common.Obfuscate(externalSecret.AppRoleSecretId, s.secret),
+		EngineName:                externalSecret.EngineName,
+		SecretName:                externalSecret.SecretName,
+		PasswordKeyName:           externalSecret.PasswordKeyName,
+		UsernameKeyName:           externalSecret.UsernameKeyName,
+		DatabaseRole:              externalSecret.DatabaseRole,
+		Region:                    externalSecret.Region,
+	}
+}
+
+func convertToDataSourceIAMAuthentication(iamAuthentication *storepb.DataSourceIAMAuthentication) *v1pb.DataSourceIAMAuthentication {
+	if iamAuthentication == nil {
+		return nil
+	}
+	return &v1pb.DataSourceIAMAuthentication{
+		Type:   v1pb.DataSourceIAMAuthentication_Type(iamAuthentication.Type),
+		Region: iamAuthentication.Region,
+	}
+}
+
+func convertDataSourceIAMAuthentication(iamAuthentication *v1pb.DataSourceIAMAuthentication) *storepb.DataSourceIAMAuthentication {
+	if iamAuthentication == nil {
+		return nil
+	}
+	return &storepb.DataSourceIAMAuthentication{
+		Type:   storepb.DataSourceIAMAuthentication_Type(iamAuthentication.Type),
+		Region: iamAuthentication.Region,
+	}
+}
+
 func (s *InstanceService) instanceCountGuard(ctx context.Context) error {
 	instanceLimit := s.licenseService.GetPlanLimitValue(ctx, enterpriseAPI.PlanLimitMaximumInstance)
 
@@ -487,16 +487,9 @@ func (s *IssueService) createIssueDatabaseChange(ctx context.Context, request *v
 	}
 
 	var planUID *int64
-	planID, err := common.GetPlanID(request.Issue.Plan)
+	plan, err := getPlanMessage(ctx, s.store, request.Issue.Plan)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
-	}
-	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planID})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get plan, error: %v", err)
-	}
-	if plan == nil {
-		return nil, status.Errorf(codes.NotFound, "plan not found for id: %d", planID)
+		return nil, err
 	}
 	planUID = &plan.UID
 	var rolloutUID *int
@@ -577,6 +570,12 @@ func (s *IssueService) createIssueDatabaseChange(ctx context.Context, request *v
 		return nil, errors.Wrapf(err, "failed to create ActivityIssueCreate activity after creating the issue: %v", issue.Title)
 	}
 
+	if rolloutUID != nil {
+		if err := s.createRolloutWindowWarningActivity(ctx, issue, *rolloutUID); err != nil {
+			slog.Error("failed to create rollout window warning activity", log.BBError(err))
+		}
+	}
+
 	converted, err := convertToIssue(ctx, s.store, issue)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to convert to issue, error: %v", err)
@@ -585,6 +584,53 @@ func (s *IssueService) createIssueDatabaseChange(ctx context.Context, request *v
 	return converted, nil
 }
 
+// createRolloutWindowWarningActivity comments on the issue if the stages of the rollout are not allowed to roll out now
+// by the rollout window policies, so that the users know it before running the tasks.
+func (s *IssueService) createRolloutWindowWarningActivity(ctx context.Context, issue *store.IssueMessage, rolloutUID int) error {
+	stages, err := s.store.ListStageV2(ctx, rolloutUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list stages")
+	}
+	var warnings []string
+	for _, stage := range stages {
+		policy, err := s.store.GetRolloutWindowPolicy(ctx, stage.EnvironmentID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get rollout window policy for environment %d", stage.EnvironmentID)
+		}
+		reason, err := utils.CheckRolloutWindow(policy, time.Now())
+		if err != nil {
+			return errors.Wrapf(err, "failed to check rollout window for environment %d", stage.EnvironmentID)
+		}
+		if reason != "" {
+			warnings = append(warnings, fmt.Sprintf("Stage %q: %s.", stage.Name, reason))
+		}
+	}
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(api.ActivityIssueCommentCreatePayload{
+		IssueName: issue.Title,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal payload")
+	}
+	activityCreate := &store.ActivityMessage{
+		CreatorUID:   api.SystemBotID,
+		ContainerUID: issue.UID,
+		Type:         api.ActivityIssueCommentCreate,
+		Level:        api.ActivityWarn,
+		Comment:      strings.Join(warnings, "\n"),
+		Payload:      string(payload),
+	}
+	if _, err := s.activityManager.CreateActivity(ctx, activityCreate, &activity.Metadata{
+		Issue: issue,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to create activity")
+	}
+	return nil
+}
+
 func (s *IssueService) createIssueGrantRequest(ctx context.Context, request *v1pb.CreateIssueRequest) (*v1pb.Issue, error) {
 	creatorID := ctx.Value(common.PrincipalIDContextKey).(int)
 	projectID, err := common.GetProjectID(request.Parent)
@@ -762,8 +808,12 @@ func (s *IssueService) ApproveIssue(ctx context.Context, request *v1pb.ApproveIs
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get project policy, error: %v", err)
 	}
+	userGroups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{MemberUID: &user.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list user groups, error: %v", err)
+	}
 
-	canApprove, err := isUserReviewer(step, user, policy)
+	canApprove, err := isUserReviewer(step, user, userGroups, policy)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to check if principal can approve step, error: %v", err)
 	}
@@ -787,6 +837,13 @@ func (s *IssueService) ApproveIssue(ctx context.Context, request *v1pb.ApproveIs
 	}
 	payload.Approval.Approvers = append(payload.Approval.Approvers, newApprovers...)
 
+	// Pin the sheet revisions before the approval is saved, after which the tasks can run.
+	if approved && issue.PipelineUID != nil {
+		if err := s.store.PinTaskSheetRevisions(ctx, *issue.PipelineUID); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to pin sheet revisions, error: %v", err)
+		}
+	}
+
 	issue, err = s.store.UpdateIssueV2(ctx, issue.UID, &store.UpdateIssueMessage{
 		PayloadUpsert: &storepb.IssuePayload{
 			Approval: payload.Approval,
@@ -947,8 +1004,12 @@ func (s *IssueService) RejectIssue(ctx context.Context, request *v1pb.RejectIssu
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get project policy, error: %v", err)
 	}
+	userGroups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{MemberUID: &user.ID})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list user groups, error: %v", err)
+	}
 
-	canApprove, err := isUserReviewer(step, user, policy)
+	canApprove, err := isUserReviewer(step, user, userGroups, policy)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to check if principal can reject step, error: %v", err)
 	}
@@ -1407,8 +1468,9 @@ func (s *IssueService) onIssueApproved(ctx context.Context, issue *store.IssueMe
 	}
 }
 
+// getIssueMessage gets the issue by name, the issue must belong to the project in the name unless the project is "-".
 func (s *IssueService) getIssueMessage(ctx context.Context, name string) (*store.IssueMessage, error) {
-	issueID, err := common.GetIssueID(name)
+	projectID, issueID, err := common.GetProjectIDIssueID(name)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
@@ -1416,8 +1478,8 @@ func (s *IssueService) getIssueMessage(ctx context.Context, name string) (*store
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get issue, error: %v", err)
 	}
-	if issue == nil {
-		return nil, status.Errorf(codes.NotFound, "issue %d not found", issueID)
+	if issue == nil || (projectID != "-" && issue.Project.ResourceID != projectID) {
+		return nil, status.Errorf(codes.NotFound, "issue %d not found in project %s", issueID, projectID)
 	}
 	return issue, nil
 }
@@ -1426,7 +1488,8 @@ func canRequestIssue(issueCreator *store.UserMessage, user *store.UserMessage) b
 	return issueCreator.ID == user.ID
 }
 
-func isUserReviewer(step *storepb.ApprovalStep, user *store.UserMessage, policy *store.IAMPolicyMessage) (bool, error) {
+// isUserReviewer returns whether the user can approve the step, the userGroups are the groups which the user belongs to.
+func isUserReviewer(step *storepb.ApprovalStep, user *store.UserMessage, userGroups []*store.UserGroupMessage, policy *store.IAMPolicyMessage) (bool, error) {
 	if len(step.Nodes) != 1 {
 		return false, errors.Errorf("expecting one node but got %v", len(step.Nodes))
 	}
@@ -1440,11 +1503,8 @@ func isUserReviewer(step *storepb.ApprovalStep, user *store.UserMessage, policy
 
 	userHasProjectRole := map[string]bool{}
 	for _, binding := range policy.Bindings {
-		for _, member := range binding.Members {
-			if member.ID == user.ID {
-				userHasProjectRole[convertToRoleName(string(binding.Role))] = true
-				break
-			}
+		if binding.HasMember(user.ID) {
+			userHasProjectRole[convertToRoleName(string(binding.Role))] = true
 		}
 	}
 	switch val := node.Payload.(type) {
@@ -1467,6 +1527,12 @@ func isUserReviewer(step *storepb.ApprovalStep, user *store.UserMessage, policy
 		if userHasProjectRole[val.Role] {
 			return true, nil
 		}
+	case *storepb.ApprovalNode_Group:
+		for _, group := range userGroups {
+			if group.Email != "" && common.FormatGroupEmail(group.Email) == val.Group {
+				return true, nil
+			}
+		}
 	case *storepb.ApprovalNode_ExternalNodeId:
 		return true, nil
 	default:
@@ -1630,6 +1696,10 @@ func convertToApprovalNode(node *storepb.ApprovalNode) *v1pb.ApprovalNode {
 		v1node.Payload = &v1pb.ApprovalNode_ExternalNodeId{
 			ExternalNodeId: payload.ExternalNodeId,
 		}
+	case *storepb.ApprovalNode_Group:
+		v1node.Payload = &v1pb.ApprovalNode_Group{
+			Group: payload.Group,
+		}
 	}
 	return v1node
 }
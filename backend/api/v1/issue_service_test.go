@@ -41,10 +41,11 @@ func TestConvertToApprovalNode(t *testing.T) {
 
 func TestCanUserApproveStep(t *testing.T) {
 	tests := []struct {
-		step   *storepb.ApprovalStep
-		user   *store.UserMessage
-		policy *store.IAMPolicyMessage
-		want   bool
+		step       *storepb.ApprovalStep
+		user       *store.UserMessage
+		userGroups []*store.UserGroupMessage
+		policy     *store.IAMPolicyMessage
+		want       bool
 	}{
 		{
 			step: &storepb.ApprovalStep{
@@ -179,11 +180,95 @@ func TestCanUserApproveStep(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			step: &storepb.ApprovalStep{
+				Type: storepb.ApprovalStep_ANY,
+				Nodes: []*storepb.ApprovalNode{
+					{
+						Type: storepb.ApprovalNode_ANY_IN_GROUP,
+						Payload: &storepb.ApprovalNode_Role{
+							Role: "roles/ProjectDBA",
+						},
+					},
+				},
+			},
+			user: &store.UserMessage{
+				ID:   1,
+				Role: api.Developer,
+			},
+			policy: &store.IAMPolicyMessage{
+				Bindings: []*store.PolicyBinding{
+					{
+						Role: "ProjectDBA",
+						Groups: []*store.UserGroupMessage{
+							{
+								UID:        101,
+								Email:      "dba@example.com",
+								MemberUIDs: []int{1, 2},
+							},
+						},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			step: &storepb.ApprovalStep{
+				Type: storepb.ApprovalStep_ANY,
+				Nodes: []*storepb.ApprovalNode{
+					{
+						Type: storepb.ApprovalNode_ANY_IN_GROUP,
+						Payload: &storepb.ApprovalNode_Group{
+							Group: "groups/dba@example.com",
+						},
+					},
+				},
+			},
+			user: &store.UserMessage{
+				ID:   1,
+				Role: api.Developer,
+			},
+			userGroups: []*store.UserGroupMessage{
+				{
+					UID:        101,
+					Email:      "dba@example.com",
+					MemberUIDs: []int{1},
+				},
+			},
+			policy: &store.IAMPolicyMessage{},
+			want:   true,
+		},
+		{
+			step: &storepb.ApprovalStep{
+				Type: storepb.ApprovalStep_ANY,
+				Nodes: []*storepb.ApprovalNode{
+					{
+						Type: storepb.ApprovalNode_ANY_IN_GROUP,
+						Payload: &storepb.ApprovalNode_Group{
+							Group: "groups/dba@example.com",
+						},
+					},
+				},
+			},
+			user: &store.UserMessage{
+				ID:   1,
+				Role: api.Developer,
+			},
+			userGroups: []*store.UserGroupMessage{
+				{
+					UID:        102,
+					Email:      "developer@example.com",
+					MemberUIDs: []int{1},
+				},
+			},
+			policy: &store.IAMPolicyMessage{},
+			want:   false,
+		},
 	}
 
 	a := require.New(t)
 	for _, test := range tests {
-		got, err := isUserReviewer(test.step, test.user, test.policy)
+		got, err := isUserReviewer(test.step, test.user, test.userGroups, test.policy)
 		a.NoError(err)
 		a.Equal(test.want, got)
 	}
@@ -17,12 +17,14 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/storagefactory"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	advisorDB "github.com/bytebase/bytebase/backend/plugin/advisor/db"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
@@ -523,8 +525,32 @@ func validatePolicyPayload(policyType api.PolicyType, policy *v1pb.Policy) error
 			if _, err := common.ValidateMaskingExceptionCELExpr(exception.Condition.Expression); err != nil {
 				return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid masking exception expression: %v", err))
 			}
-			if !strings.HasPrefix(exception.Member, "user:") {
-				return status.Errorf(codes.InvalidArgument, "masking exception member must start with user:")
+			if !strings.HasPrefix(exception.Member, userMemberPrefix) && !strings.HasPrefix(exception.Member, groupMemberPrefix) {
+				return status.Errorf(codes.InvalidArgument, "masking exception member must start with user: or group:")
+			}
+		}
+	case api.PolicyTypeRolloutWindow:
+		rolloutWindowPolicy, ok := policy.Policy.(*v1pb.Policy_RolloutWindowPolicy)
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "unmatched policy type %v and policy %v", policyType, policy.Policy)
+		}
+		if rolloutWindowPolicy.RolloutWindowPolicy == nil {
+			return status.Errorf(codes.InvalidArgument, "rollout window policy must be set")
+		}
+		if err := utils.ValidateRolloutWindowPolicy(convertToStorePBRolloutWindowPolicy(rolloutWindowPolicy.RolloutWindowPolicy)); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid rollout window policy: %v", err)
+		}
+	case api.PolicyTypeRedisKeyAccess:
+		redisKeyAccessPolicy, ok := policy.Policy.(*v1pb.Policy_RedisKeyAccessPolicy)
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "unmatched policy type %v and policy %v", policyType, policy.Policy)
+		}
+		if redisKeyAccessPolicy.RedisKeyAccessPolicy == nil {
+			return status.Errorf(codes.InvalidArgument, "redis key access policy must be set")
+		}
+		for _, keyPattern := range redisKeyAccessPolicy.RedisKeyAccessPolicy.KeyPatterns {
+			if keyPattern == "" {
+				return status.Errorf(codes.InvalidArgument, "redis key pattern cannot be empty")
 			}
 		}
 	default:
@@ -648,6 +674,25 @@ func (s *OrgPolicyService) convertPolicyPayloadToString(policy *v1pb.Policy) (st
 			return "", errors.Wrap(err, "failed to marshal masking exception policy")
 		}
 		return string(payloadBytes), nil
+	case v1pb.PolicyType_ROLLOUT_WINDOW:
+		payload := convertToStorePBRolloutWindowPolicy(policy.GetRolloutWindowPolicy())
+		payloadBytes, err := protojson.Marshal(payload)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal rollout window policy")
+		}
+		return string(payloadBytes), nil
+	case v1pb.PolicyType_REDIS_KEY_ACCESS:
+		if err := s.licenseService.IsFeatureEnabled(api.FeatureAccessControl); err != nil {
+			return "", status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		payload := &storepb.RedisKeyAccessPolicy{
+			KeyPatterns: policy.GetRedisKeyAccessPolicy().GetKeyPatterns(),
+		}
+		payloadBytes, err := protojson.Marshal(payload)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal redis key access policy")
+		}
+		return string(payloadBytes), nil
 	}
 
 	return "", status.Errorf(codes.InvalidArgument, "invalid policy %v", policy.Type)
@@ -757,6 +802,26 @@ func convertToPolicy(parentPath string, policyMessage *store.PolicyMessage) (*v1
 		policy.Policy = &v1pb.Policy_MaskingExceptionPolicy{
 			MaskingExceptionPolicy: payload,
 		}
+	case api.PolicyTypeRolloutWindow:
+		pType = v1pb.PolicyType_ROLLOUT_WINDOW
+		rolloutWindowPolicy := &storepb.RolloutWindowPolicy{}
+		if err := protojson.Unmarshal([]byte(policyMessage.Payload), rolloutWindowPolicy); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal rollout window policy")
+		}
+		policy.Policy = &v1pb.Policy_RolloutWindowPolicy{
+			RolloutWindowPolicy: convertToV1PBRolloutWindowPolicy(rolloutWindowPolicy),
+		}
+	case api.PolicyTypeRedisKeyAccess:
+		pType = v1pb.PolicyType_REDIS_KEY_ACCESS
+		redisKeyAccessPolicy := &storepb.RedisKeyAccessPolicy{}
+		if err := protojson.Unmarshal([]byte(policyMessage.Payload), redisKeyAccessPolicy); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal redis key access policy")
+		}
+		policy.Policy = &v1pb.Policy_RedisKeyAccessPolicy{
+			RedisKeyAccessPolicy: &v1pb.RedisKeyAccessPolicy{
+				KeyPatterns: redisKeyAccessPolicy.KeyPatterns,
+			},
+		}
 	}
 
 	policy.Type = pType
@@ -995,10 +1060,78 @@ func convertToV1PBBackupPlanPolicy(payloadStr string) (*v1pb.Policy_BackupPlanPo
 		BackupPlanPolicy: &v1pb.BackupPlanPolicy{
 			Schedule:          schedule,
 			RetentionDuration: &durationpb.Duration{Seconds: int64(payload.RetentionPeriodTs)},
+			Storage:           convertToV1PBBackupStorage(payload.Storage),
 		},
 	}, nil
 }
 
+func convertToV1PBBackupStorage(backupStorage *api.BackupStorage) *v1pb.BackupStorage {
+	if backupStorage == nil {
+		return nil
+	}
+	storageType := v1pb.BackupStorage_TYPE_UNSPECIFIED
+	switch backupStorage.Backend {
+	case api.BackupStorageBackendLocal:
+		storageType = v1pb.BackupStorage_LOCAL
+	case api.BackupStorageBackendS3:
+		storageType = v1pb.BackupStorage_S3
+	case api.BackupStorageBackendGCS:
+		storageType = v1pb.BackupStorage_GCS
+	case api.BackupStorageBackendAzureBlob:
+		storageType = v1pb.BackupStorage_AZURE_BLOB
+	}
+	return &v1pb.BackupStorage{
+		Type:              storageType,
+		Bucket:            backupStorage.Bucket,
+		Prefix:            backupStorage.Prefix,
+		Region:            backupStorage.Region,
+		Endpoint:          backupStorage.Endpoint,
+		CredentialFile:    backupStorage.CredentialFile,
+		EncryptionKeyFile: backupStorage.EncryptionKeyFile,
+	}
+}
+
+func convertToBackupStorage(backupStorage *v1pb.BackupStorage) (*api.BackupStorage, error) {
+	if backupStorage == nil {
+		return nil, nil
+	}
+	var backend api.BackupStorageBackend
+	switch backupStorage.Type {
+	case v1pb.BackupStorage_LOCAL:
+		backend = api.BackupStorageBackendLocal
+		// The backups in the local data directory are not encrypted because they are restored from the files directly.
+		if backupStorage.EncryptionKeyFile != "" {
+			return nil, errors.Errorf("encryption is not supported for local backup storage")
+		}
+	case v1pb.BackupStorage_S3:
+		backend = api.BackupStorageBackendS3
+	case v1pb.BackupStorage_GCS:
+		backend = api.BackupStorageBackendGCS
+	case v1pb.BackupStorage_AZURE_BLOB:
+		backend = api.BackupStorageBackendAzureBlob
+	default:
+		return nil, errors.Errorf("invalid backup storage type %v", backupStorage.Type)
+	}
+	if backend != api.BackupStorageBackendLocal && backupStorage.Bucket == "" {
+		return nil, errors.Errorf("bucket is required for backup storage type %v", backupStorage.Type)
+	}
+	if err := storagefactory.ValidateCredentialFileName(backupStorage.CredentialFile); err != nil {
+		return nil, errors.Wrap(err, "invalid credential file")
+	}
+	if err := storagefactory.ValidateCredentialFileName(backupStorage.EncryptionKeyFile); err != nil {
+		return nil, errors.Wrap(err, "invalid encryption key file")
+	}
+	return &api.BackupStorage{
+		Backend:           backend,
+		Bucket:            backupStorage.Bucket,
+		Prefix:            backupStorage.Prefix,
+		Region:            backupStorage.Region,
+		Endpoint:          backupStorage.Endpoint,
+		CredentialFile:    backupStorage.CredentialFile,
+		EncryptionKeyFile: backupStorage.EncryptionKeyFile,
+	}, nil
+}
+
 func convertToBackupPlanPolicyPayload(policy *v1pb.BackupPlanPolicy) (*api.BackupPlanPolicy, error) {
 	var schedule api.BackupPlanPolicySchedule
 	switch policy.Schedule {
@@ -1017,9 +1150,15 @@ func convertToBackupPlanPolicyPayload(policy *v1pb.BackupPlanPolicy) (*api.Backu
 		retentionPeriodTs = int(policy.RetentionDuration.Seconds)
 	}
 
+	backupStorage, err := convertToBackupStorage(policy.Storage)
+	if err != nil {
+		return nil, err
+	}
+
 	return &api.BackupPlanPolicy{
 		Schedule:          schedule,
 		RetentionPeriodTs: retentionPeriodTs,
+		Storage:           backupStorage,
 	}, nil
 }
 
@@ -1172,6 +1311,50 @@ func convertToDisableCopyDataPolicyPayload(policy *v1pb.DisableCopyDataPolicy) (
 	}, nil
 }
 
+func convertToStorePBRolloutWindowPolicy(policy *v1pb.RolloutWindowPolicy) *storepb.RolloutWindowPolicy {
+	result := &storepb.RolloutWindowPolicy{}
+	for _, window := range policy.AllowedWindows {
+		result.AllowedWindows = append(result.AllowedWindows, &storepb.RolloutWindowPolicy_Window{
+			Cron:     window.Cron,
+			Duration: window.Duration,
+			TimeZone: window.TimeZone,
+		})
+	}
+	for _, period := range policy.FreezePeriods {
+		result.FreezePeriods = append(result.FreezePeriods, &storepb.RolloutWindowPolicy_FreezePeriod{
+			Title:     period.Title,
+			StartTime: period.StartTime,
+			EndTime:   period.EndTime,
+		})
+	}
+	for _, role := range policy.BypassRoles {
+		result.BypassRoles = append(result.BypassRoles, string(convertUserRole(role)))
+	}
+	return result
+}
+
+func convertToV1PBRolloutWindowPolicy(policy *storepb.RolloutWindowPolicy) *v1pb.RolloutWindowPolicy {
+	result := &v1pb.RolloutWindowPolicy{}
+	for _, window := range policy.AllowedWindows {
+		result.AllowedWindows = append(result.AllowedWindows, &v1pb.RolloutWindowPolicy_Window{
+			Cron:     window.Cron,
+			Duration: window.Duration,
+			TimeZone: window.TimeZone,
+		})
+	}
+	for _, period := range policy.FreezePeriods {
+		result.FreezePeriods = append(result.FreezePeriods, &v1pb.RolloutWindowPolicy_FreezePeriod{
+			Title:     period.Title,
+			StartTime: period.StartTime,
+			EndTime:   period.EndTime,
+		})
+	}
+	for _, role := range policy.BypassRoles {
+		result.BypassRoles = append(result.BypassRoles, v1pb.UserRole(v1pb.UserRole_value[role]))
+	}
+	return result
+}
+
 func convertToStorePBMskingRulePolicy(policy *v1pb.MaskingRulePolicy) (*storepb.MaskingRulePolicy, error) {
 	var rules []*storepb.MaskingRulePolicy_MaskingRule
 	for _, rule := range policy.Rules {
@@ -1218,7 +1401,7 @@ func convertToStorePBMaskingExceptionPolicyPayload(policy *v1pb.MaskingException
 		exceptions = append(exceptions, &storepb.MaskingExceptionPolicy_MaskingException{
 			Action:       convertToStorePBAction(exception.Action),
 			MaskingLevel: convertToStorePBMaskingLevel(exception.MaskingLevel),
-			Member:       strings.TrimPrefix(exception.Member, "user:"),
+			Member:       convertToStorePBMaskingExceptionMember(exception.Member),
 			Condition: &expr.Expr{
 				Title:       exception.Condition.Title,
 				Expression:  exception.Condition.Expression,
@@ -1239,7 +1422,7 @@ func convertToV1PBMaskingExceptionPolicyPayload(policy *storepb.MaskingException
 		exceptions = append(exceptions, &v1pb.MaskingExceptionPolicy_MaskingException{
 			Action:       convertToV1PBAction(exception.Action),
 			MaskingLevel: convertToV1PBMaskingLevel(exception.MaskingLevel),
-			Member:       fmt.Sprintf("user:%s", exception.Member),
+			Member:       convertToV1PBMaskingExceptionMember(exception.Member),
 			Condition: &expr.Expr{
 				Title:       exception.Condition.Title,
 				Expression:  exception.Condition.Expression,
@@ -1254,6 +1437,22 @@ func convertToV1PBMaskingExceptionPolicyPayload(policy *storepb.MaskingException
 	}, nil
 }
 
+// convertToStorePBMaskingExceptionMember converts the member in the masking exception.
+// The user is stored as the email for compatibility, and the group keeps the group: prefix.
+func convertToStorePBMaskingExceptionMember(member string) string {
+	if strings.HasPrefix(member, groupMemberPrefix) {
+		return member
+	}
+	return strings.TrimPrefix(member, userMemberPrefix)
+}
+
+func convertToV1PBMaskingExceptionMember(member string) string {
+	if strings.HasPrefix(member, groupMemberPrefix) {
+		return member
+	}
+	return fmt.Sprintf("%s%s", userMemberPrefix, member)
+}
+
 // This is to be deprecated.
 const (
 	// IssueDatabaseCreate is the issue type for creating databases.
@@ -1308,6 +1507,10 @@ func convertPolicyType(pType string) (api.PolicyType, error) {
 		return api.PolicyTypeSlowQuery, nil
 	case v1pb.PolicyType_DISABLE_COPY_DATA.String():
 		return api.PolicyTypeDisableCopyData, nil
+	case v1pb.PolicyType_ROLLOUT_WINDOW.String():
+		return api.PolicyTypeRolloutWindow, nil
+	case v1pb.PolicyType_REDIS_KEY_ACCESS.String():
+		return api.PolicyTypeRedisKeyAccess, nil
 	}
 	return policyType, errors.Errorf("invalid policy type %v", pType)
 }
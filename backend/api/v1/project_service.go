@@ -16,7 +16,9 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"gopkg.in/yaml.v3"
 
 	"github.com/bytebase/bytebase/backend/common"
@@ -32,6 +34,7 @@ import (
 	webhookPlugin "github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
@@ -660,6 +663,15 @@ func (s *ProjectService) CreateIAMPolicyUpdateActivity(ctx context.Context, remo
 				Comment:      fmt.Sprintf("Revoked %s from %s (%s).", binding.Role, member.Name, member.Email),
 			})
 		}
+		for _, group := range binding.Groups {
+			activities = append(activities, &store.ActivityMessage{
+				CreatorUID:   creatorUID,
+				ContainerUID: project.UID,
+				Type:         api.ActivityProjectMemberDelete,
+				Level:        api.ActivityInfo,
+				Comment:      fmt.Sprintf("Revoked %s from group %s (%s).", binding.Role, group.Name, group.Email),
+			})
+		}
 	}
 	for _, binding := range add.Bindings {
 		for _, member := range binding.Members {
@@ -671,6 +683,15 @@ func (s *ProjectService) CreateIAMPolicyUpdateActivity(ctx context.Context, remo
 				Comment:      fmt.Sprintf("Granted %s to %s (%s).", member.Name, member.Email, binding.Role),
 			})
 		}
+		for _, group := range binding.Groups {
+			activities = append(activities, &store.ActivityMessage{
+				CreatorUID:   creatorUID,
+				ContainerUID: project.UID,
+				Type:         api.ActivityProjectMemberCreate,
+				Level:        api.ActivityInfo,
+				Comment:      fmt.Sprintf("Granted %s to group %s (%s).", binding.Role, group.Name, group.Email),
+			})
+		}
 	}
 
 	for _, a := range activities {
@@ -839,6 +860,8 @@ func (s *ProjectService) UpdateWebhook(ctx context.Context, request *v1pb.Update
 				return nil, status.Errorf(codes.InvalidArgument, "notification types should not be empty")
 			}
 			update.ActivityList = types
+		case "signing_secret":
+			update.SigningSecret = &request.Webhook.SigningSecret
 		default:
 			return nil, status.Errorf(codes.InvalidArgument, "invalid field %q", path)
 		}
@@ -952,6 +975,8 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 			CreatorEmail: api.SystemBotEmail,
 			CreatedTs:    time.Now().Unix(),
 			Project:      &webhookPlugin.Project{Name: project.Title},
+
+			SigningSecret: webhook.SigningSecret,
 		},
 	)
 	if err != nil {
@@ -961,6 +986,126 @@ func (s *ProjectService) TestWebhook(ctx context.Context, request *v1pb.TestWebh
 	return resp, nil
 }
 
+// ListWebhookDeliveries lists the deliveries of a webhook.
+func (s *ProjectService) ListWebhookDeliveries(ctx context.Context, request *v1pb.ListWebhookDeliveriesRequest) (*v1pb.ListWebhookDeliveriesResponse, error) {
+	projectID, webhookID, err := common.GetProjectIDWebhookID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	webhookIDInt, err := strconv.Atoi(webhookID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook id %q", webhookID)
+	}
+	webhook, err := s.getProjectWebhookMessage(ctx, projectID, webhookIDInt)
+	if err != nil {
+		return nil, err
+	}
+
+	var limit, offset int
+	if request.PageToken != "" {
+		var pageToken storepb.PageToken
+		if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		if pageToken.Limit < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "page size cannot be negative")
+		}
+		limit = int(pageToken.Limit)
+		offset = int(pageToken.Offset)
+	} else {
+		limit = int(request.PageSize)
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	limitPlusOne := limit + 1
+
+	deliveries, err := s.store.ListWebhookDeliveries(ctx, &store.FindWebhookDeliveryMessage{
+		ProjectWebhookUID: &webhook.ID,
+		Limit:             &limitPlusOne,
+		Offset:            &offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list webhook deliveries, error: %v", err)
+	}
+
+	response := &v1pb.ListWebhookDeliveriesResponse{}
+	if len(deliveries) == limitPlusOne {
+		nextPageToken, err := getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+		response.NextPageToken = nextPageToken
+		deliveries = deliveries[:limit]
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, convertToWebhookDelivery(projectID, delivery))
+	}
+	return response, nil
+}
+
+// RedeliverWebhookDelivery delivers a webhook delivery again immediately.
+// The retries of the delivery restart from scratch if the redelivery fails.
+func (s *ProjectService) RedeliverWebhookDelivery(ctx context.Context, request *v1pb.RedeliverWebhookDeliveryRequest) (*v1pb.WebhookDelivery, error) {
+	projectID, webhookID, deliveryID, err := common.GetProjectIDWebhookIDDeliveryID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	webhook, err := s.getProjectWebhookMessage(ctx, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := s.store.GetWebhookDelivery(ctx, &store.FindWebhookDeliveryMessage{
+		UID:               &deliveryID,
+		ProjectWebhookUID: &webhook.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if delivery == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook delivery %q not found", request.Name)
+	}
+
+	delivery, err = s.activityManager.RedeliverWebhook(ctx, delivery.UID)
+	if err != nil {
+		if errors.Is(err, activity.ErrWebhookDeliveryInProgress) {
+			return nil, status.Errorf(codes.FailedPrecondition, "webhook delivery %q is being delivered, try again later", request.Name)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to redeliver webhook delivery, error: %v", err)
+	}
+	return convertToWebhookDelivery(projectID, delivery), nil
+}
+
+func (s *ProjectService) getProjectWebhookMessage(ctx context.Context, projectID string, webhookID int) (*store.ProjectWebhookMessage, error) {
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{
+		ResourceID: &projectID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, "project %q not found", projectID)
+	}
+	if project.Deleted {
+		return nil, status.Errorf(codes.NotFound, "project %q has been deleted", projectID)
+	}
+
+	webhook, err := s.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{
+		ProjectID: &project.UID,
+		ID:        &webhookID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+	if webhook == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook %d not found", webhookID)
+	}
+	return webhook, nil
+}
+
 func (s *ProjectService) findProjectRepository(ctx context.Context, projectName string) (*store.RepositoryMessage, error) {
 	project, err := s.getProjectMessage(ctx, projectName)
 	if err != nil {
@@ -2226,13 +2371,43 @@ func convertToStoreProjectWebhookMessage(webhook *v1pb.Webhook) (*store.ProjectW
 		return nil, err
 	}
 	return &store.ProjectWebhookMessage{
-		Type:         tp,
-		URL:          webhook.Url,
-		Title:        webhook.Title,
-		ActivityList: activityTypes,
+		Type:          tp,
+		URL:           webhook.Url,
+		Title:         webhook.Title,
+		ActivityList:  activityTypes,
+		SigningSecret: webhook.SigningSecret,
 	}, nil
 }
 
+func convertToWebhookDelivery(projectID string, delivery *store.WebhookDeliveryMessage) *v1pb.WebhookDelivery {
+	v1Delivery := &v1pb.WebhookDelivery{
+		Name:         fmt.Sprintf("%s%s/%s%d/%s%d", common.ProjectNamePrefix, projectID, common.WebhookIDPrefix, delivery.ProjectWebhookUID, common.WebhookDeliveryPrefix, delivery.UID),
+		AttemptCount: int32(delivery.AttemptCount),
+		LastError:    delivery.LastError,
+		CreateTime:   timestamppb.New(time.Unix(delivery.CreatedTs, 0)),
+	}
+	if types := convertNotificationTypeStrings([]string{delivery.ActivityType}); len(types) > 0 {
+		v1Delivery.ActivityType = types[0]
+	}
+	var payload webhookPlugin.Context
+	if err := json.Unmarshal([]byte(delivery.Payload), &payload); err == nil {
+		v1Delivery.Title = payload.Title
+	}
+	switch delivery.Status {
+	case store.WebhookDeliveryPending:
+		v1Delivery.Status = v1pb.WebhookDelivery_PENDING
+		v1Delivery.NextAttemptTime = timestamppb.New(time.Unix(delivery.NextAttemptTs, 0))
+	case store.WebhookDeliverySucceeded:
+		v1Delivery.Status = v1pb.WebhookDelivery_SUCCEEDED
+	case store.WebhookDeliveryDeadLetter:
+		v1Delivery.Status = v1pb.WebhookDelivery_DEAD_LETTER
+	}
+	if delivery.LastAttemptTs != 0 {
+		v1Delivery.LastAttemptTime = timestamppb.New(time.Unix(delivery.LastAttemptTs, 0))
+	}
+	return v1Delivery
+}
+
 func convertToActivityTypeStrings(types []v1pb.Activity_Type) ([]string, error) {
 	var result []string
 	for _, tp := range types {
@@ -2403,11 +2578,11 @@ func validateAndConvertToStoreDeploymentSchedule(deployment *v1pb.DeploymentConf
 				return nil, common.Errorf(common.Invalid, "label selector expression must not be empty")
 			}
 			switch e.Operator {
-			case v1pb.OperatorType_OPERATOR_TYPE_IN:
+			case v1pb.OperatorType_OPERATOR_TYPE_IN, v1pb.OperatorType_OPERATOR_TYPE_NOT_IN:
 				if len(e.Values) == 0 {
 					return nil, common.Errorf(common.Invalid, "expression key %q with %q operator should have at least one value", e.Key, e.Operator)
 				}
-			case v1pb.OperatorType_OPERATOR_TYPE_EXISTS:
+			case v1pb.OperatorType_OPERATOR_TYPE_EXISTS, v1pb.OperatorType_OPERATOR_TYPE_DOES_NOT_EXIST:
 				if len(e.Values) > 0 {
 					return nil, common.Errorf(common.Invalid, "expression key %q with %q operator shouldn't have values", e.Key, e.Operator)
 				}
@@ -2424,6 +2599,15 @@ func validateAndConvertToStoreDeploymentSchedule(deployment *v1pb.DeploymentConf
 		if !hasEnv {
 			return nil, common.Errorf(common.Invalid, "deployment should contain %q label", api.EnvironmentLabelKey)
 		}
+		if w := d.Spec.Waves; w != nil {
+			var percentages []int
+			for _, p := range w.Percentages {
+				percentages = append(percentages, int(p))
+			}
+			if err := api.ValidateDeploymentWaves(percentages, int(w.MaxFailedTasks), int64(w.SoakDuration.AsDuration().Seconds())); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return convertToStoreDeploymentConfig(deployment)
 }
@@ -2464,6 +2648,9 @@ func convertToIamPolicy(iamPolicy *store.IAMPolicyMessage) (*v1pb.IamPolicy, err
 		for _, member := range binding.Members {
 			members = append(members, fmt.Sprintf("user:%s", member.Email))
 		}
+		for _, group := range binding.Groups {
+			members = append(members, formatGroupMember(group))
+		}
 		v1pbBinding := &v1pb.Binding{
 			Role:      convertToProjectRole(binding.Role),
 			Members:   members,
@@ -2496,11 +2683,20 @@ func (s *ProjectService) convertToIAMPolicyMessage(ctx context.Context, iamPolic
 	var bindings []*store.PolicyBinding
 	for _, binding := range iamPolicy.Bindings {
 		var users []*store.UserMessage
+		var groups []*store.UserGroupMessage
 		role, err := convertProjectRole(binding.Role)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, err.Error())
 		}
 		for _, member := range binding.Members {
+			if strings.HasPrefix(member, groupMemberPrefix) {
+				group, err := getGroupByMember(ctx, s.store, member)
+				if err != nil {
+					return nil, err
+				}
+				groups = append(groups, group)
+				continue
+			}
 			email := strings.TrimPrefix(member, "user:")
 			user, err := s.store.GetUser(ctx, &store.FindUserMessage{
 				Email:       &email,
@@ -2518,6 +2714,7 @@ func (s *ProjectService) convertToIAMPolicyMessage(ctx context.Context, iamPolic
 		bindings = append(bindings, &store.PolicyBinding{
 			Role:      role,
 			Members:   users,
+			Groups:    groups,
 			Condition: binding.Condition,
 		})
 	}
@@ -2729,6 +2926,37 @@ func convertToStoreDeployment(deployment *v1pb.ScheduleDeployment) (*store.Deplo
 func convertToSpec(spec *store.DeploymentSpec) *v1pb.DeploymentSpec {
 	return &v1pb.DeploymentSpec{
 		LabelSelector: convertToLabelSelector(spec.Selector),
+		Waves:         convertToDeploymentWaves(spec.Waves),
+	}
+}
+
+func convertToDeploymentWaves(waves *store.DeploymentWaves) *v1pb.DeploymentWaves {
+	if waves == nil {
+		return nil
+	}
+	var percentages []int32
+	for _, p := range waves.Percentages {
+		percentages = append(percentages, int32(p))
+	}
+	return &v1pb.DeploymentWaves{
+		Percentages:    percentages,
+		MaxFailedTasks: int32(waves.MaxFailedTasks),
+		SoakDuration:   durationpb.New(time.Duration(waves.SoakSeconds) * time.Second),
+	}
+}
+
+func convertToStoreDeploymentWaves(waves *v1pb.DeploymentWaves) *store.DeploymentWaves {
+	if waves == nil {
+		return nil
+	}
+	var percentages []int
+	for _, p := range waves.Percentages {
+		percentages = append(percentages, int(p))
+	}
+	return &store.DeploymentWaves{
+		Percentages:    percentages,
+		MaxFailedTasks: int(waves.MaxFailedTasks),
+		SoakSeconds:    int64(waves.SoakDuration.AsDuration().Seconds()),
 	}
 }
 
@@ -2739,6 +2967,7 @@ func convertToStoreSpec(spec *v1pb.DeploymentSpec) (*store.DeploymentSpec, error
 	}
 	return &store.DeploymentSpec{
 		Selector: selector,
+		Waves:    convertToStoreDeploymentWaves(spec.Waves),
 	}, nil
 }
 
@@ -2793,6 +3022,10 @@ func convertToLabelSelectorOperator(operator store.OperatorType) v1pb.OperatorTy
 		return v1pb.OperatorType_OPERATOR_TYPE_IN
 	case store.ExistsOperatorType:
 		return v1pb.OperatorType_OPERATOR_TYPE_EXISTS
+	case store.NotInOperatorType:
+		return v1pb.OperatorType_OPERATOR_TYPE_NOT_IN
+	case store.DoesNotExistOperatorType:
+		return v1pb.OperatorType_OPERATOR_TYPE_DOES_NOT_EXIST
 	}
 	return v1pb.OperatorType_OPERATOR_TYPE_UNSPECIFIED
 }
@@ -2803,6 +3036,10 @@ func convertToStoreLabelSelectorOperator(operator v1pb.OperatorType) (store.Oper
 		return store.InOperatorType, nil
 	case v1pb.OperatorType_OPERATOR_TYPE_EXISTS:
 		return store.ExistsOperatorType, nil
+	case v1pb.OperatorType_OPERATOR_TYPE_NOT_IN:
+		return store.NotInOperatorType, nil
+	case v1pb.OperatorType_OPERATOR_TYPE_DOES_NOT_EXIST:
+		return store.DoesNotExistOperatorType, nil
 	}
 	return store.OperatorType(""), errors.Errorf("invalid operator type: %v", operator)
 }
@@ -2861,7 +3098,8 @@ func validateBindings(bindings []*v1pb.Binding, roles []*v1pb.Role) error {
 
 func validateMember(member string) error {
 	userIdentifierMap := map[string]bool{
-		"user:": true,
+		userMemberPrefix:  true,
+		groupMemberPrefix: true,
 	}
 	for prefix := range userIdentifierMap {
 		if strings.HasPrefix(member, prefix) && len(member[len(prefix):]) > 0 {
@@ -2873,10 +3111,8 @@ func validateMember(member string) error {
 
 func isProjectMember(policy *store.IAMPolicyMessage, userID int) bool {
 	for _, binding := range policy.Bindings {
-		for _, member := range binding.Members {
-			if member.ID == userID {
-				return true
-			}
+		if binding.HasMember(userID) {
+			return true
 		}
 	}
 	return false
@@ -30,6 +30,14 @@ func TestValidateMembers(t *testing.T) {
 			member:  "user:foo",
 			wantErr: false,
 		},
+		{
+			member:  "group:",
+			wantErr: true,
+		},
+		{
+			member:  "group:dba@example.com",
+			wantErr: false,
+		},
 	}
 
 	a := require.New(t)
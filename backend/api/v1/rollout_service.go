@@ -53,18 +53,27 @@ func NewRolloutService(store *store.Store, licenseService enterpriseAPI.LicenseS
 
 // GetPlan gets a plan.
 func (s *RolloutService) GetPlan(ctx context.Context, request *v1pb.GetPlanRequest) (*v1pb.Plan, error) {
-	planID, err := common.GetPlanID(request.Name)
+	plan, err := getPlanMessage(ctx, s.store, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return convertToPlan(plan), nil
+}
+
+// getPlanMessage gets the plan by name, the plan must belong to the project in the name unless the project is "-".
+func getPlanMessage(ctx context.Context, stores *store.Store, name string) (*store.PlanMessage, error) {
+	projectID, planID, err := common.GetProjectIDPlanID(name)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
-	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planID})
+	plan, err := stores.GetPlan(ctx, &store.FindPlanMessage{UID: &planID})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get plan, error: %v", err)
 	}
-	if plan == nil {
-		return nil, status.Errorf(codes.NotFound, "plan not found for id: %d", planID)
+	if plan == nil || (projectID != "-" && plan.ProjectID != projectID) {
+		return nil, status.Errorf(codes.NotFound, "plan %d not found in project %s", planID, projectID)
 	}
-	return convertToPlan(plan), nil
+	return plan, nil
 }
 
 // ListPlans lists plans.
@@ -255,16 +264,9 @@ func (s *RolloutService) CreateRollout(ctx context.Context, request *v1pb.Create
 		return nil, status.Errorf(codes.NotFound, "project not found for id: %v", projectID)
 	}
 
-	planID, err := common.GetPlanID(request.Plan)
+	plan, err := getPlanMessage(ctx, s.store, request.Plan)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
-	}
-	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planID})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get plan, error: %v", err)
-	}
-	if plan == nil {
-		return nil, status.Errorf(codes.NotFound, "plan not found for id: %d", planID)
+		return nil, err
 	}
 
 	pipelineCreate, err := GetPipelineCreate(ctx, s.store, s.licenseService, s.dbFactory, plan.Config.Steps, project)
@@ -281,22 +283,22 @@ func (s *RolloutService) CreateRollout(ctx context.Context, request *v1pb.Create
 
 	// Update pipeline ID in the plan.
 	if err := s.store.UpdatePlan(ctx, &store.UpdatePlanMessage{
-		UID:         planID,
+		UID:         plan.UID,
 		UpdaterID:   creatorID,
 		PipelineUID: &pipeline.ID,
 	}); err != nil {
 		return nil, err
 	}
 
-	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PlanUID: &planID})
+	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PlanUID: &plan.UID})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get issue by plan id %v, error: %v", planID, err)
+		return nil, status.Errorf(codes.Internal, "failed to get issue by plan id %v, error: %v", plan.UID, err)
 	}
 	if issue != nil {
 		if _, err := s.store.UpdateIssueV2(ctx, issue.UID, &store.UpdateIssueMessage{
 			PipelineUID: &pipeline.ID,
 		}, creatorID); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to update issue by plan id %v, error: %v", planID, err)
+			return nil, status.Errorf(codes.Internal, "failed to update issue by plan id %v, error: %v", plan.UID, err)
 		}
 	}
 
@@ -318,12 +320,12 @@ func (s *RolloutService) CreateRollout(ctx context.Context, request *v1pb.Create
 
 // ListPlanCheckRuns lists plan check runs for the plan.
 func (s *RolloutService) ListPlanCheckRuns(ctx context.Context, request *v1pb.ListPlanCheckRunsRequest) (*v1pb.ListPlanCheckRunsResponse, error) {
-	planUID, err := common.GetPlanID(request.Parent)
+	plan, err := getPlanMessage(ctx, s.store, request.Parent)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, err
 	}
 	planCheckRuns, err := s.store.ListPlanCheckRuns(ctx, &store.FindPlanCheckRunMessage{
-		PlanUID: &planUID,
+		PlanUID: &plan.UID,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list plan check runs, error: %v", err)
@@ -341,16 +343,9 @@ func (s *RolloutService) ListPlanCheckRuns(ctx context.Context, request *v1pb.Li
 
 // RunPlanChecks runs plan checks for a plan.
 func (s *RolloutService) RunPlanChecks(ctx context.Context, request *v1pb.RunPlanChecksRequest) (*v1pb.RunPlanChecksResponse, error) {
-	planUID, err := common.GetPlanID(request.Name)
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
-	}
-	plan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planUID})
+	plan, err := getPlanMessage(ctx, s.store, request.Name)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get plan, error: %v", err)
-	}
-	if plan == nil {
-		return nil, status.Errorf(codes.NotFound, "plan not found")
+		return nil, err
 	}
 
 	planCheckRuns, err := getPlanCheckRunsFromPlan(ctx, s.store, plan)
@@ -422,8 +417,8 @@ func (s *RolloutService) BatchRunTasks(ctx context.Context, request *v1pb.BatchR
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to find rollout, error: %v", err)
 	}
-	if rollout == nil {
-		return nil, status.Errorf(codes.NotFound, "rollout %v not found", rolloutID)
+	if rollout == nil || rollout.ProjectID != project.ResourceID {
+		return nil, status.Errorf(codes.NotFound, "rollout %v not found in project %v", rolloutID, projectID)
 	}
 
 	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &rolloutID})
@@ -445,10 +440,13 @@ func (s *RolloutService) BatchRunTasks(ctx context.Context, request *v1pb.BatchR
 	stageTasks := map[int][]int{}
 	taskIDsToRunMap := map[int]bool{}
 	for _, task := range request.Tasks {
-		_, _, stageID, taskID, err := common.GetProjectIDRolloutIDStageIDTaskID(task)
+		taskProjectID, taskRolloutID, stageID, taskID, err := common.GetProjectIDRolloutIDStageIDTaskID(task)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, err.Error())
 		}
+		if taskProjectID != projectID || taskRolloutID != rolloutID {
+			return nil, status.Errorf(codes.InvalidArgument, "task %q is not in rollout %q", task, request.Parent)
+		}
 		stageTasks[stageID] = append(stageTasks[stageID], taskID)
 		taskIDsToRunMap[taskID] = true
 	}
@@ -555,8 +553,8 @@ func (s *RolloutService) BatchSkipTasks(ctx context.Context, request *v1pb.Batch
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to find rollout, error: %v", err)
 	}
-	if rollout == nil {
-		return nil, status.Errorf(codes.NotFound, "rollout %v not found", rolloutID)
+	if rollout == nil || rollout.ProjectID != project.ResourceID {
+		return nil, status.Errorf(codes.NotFound, "rollout %v not found in project %v", rolloutID, projectID)
 	}
 
 	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &rolloutID})
@@ -581,10 +579,13 @@ func (s *RolloutService) BatchSkipTasks(ctx context.Context, request *v1pb.Batch
 	var taskUIDs []int
 	var tasksToSkip []*store.TaskMessage
 	for _, task := range request.Tasks {
-		_, _, _, taskID, err := common.GetProjectIDRolloutIDStageIDTaskID(task)
+		taskProjectID, taskRolloutID, _, taskID, err := common.GetProjectIDRolloutIDStageIDTaskID(task)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, err.Error())
 		}
+		if taskProjectID != projectID || taskRolloutID != rolloutID {
+			return nil, status.Errorf(codes.InvalidArgument, "task %q is not in rollout %q", task, request.Parent)
+		}
 		if _, ok := taskByID[taskID]; !ok {
 			return nil, status.Errorf(codes.NotFound, "task %v not found in the rollout", taskID)
 		}
@@ -633,8 +634,8 @@ func (s *RolloutService) BatchCancelTaskRuns(ctx context.Context, request *v1pb.
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to find rollout, error: %v", err)
 	}
-	if rollout == nil {
-		return nil, status.Errorf(codes.NotFound, "rollout %v not found", rolloutID)
+	if rollout == nil || rollout.ProjectID != project.ResourceID {
+		return nil, status.Errorf(codes.NotFound, "rollout %v not found in project %v", rolloutID, projectID)
 	}
 
 	issue, err := s.store.GetIssueV2(ctx, &store.FindIssueMessage{PipelineID: &rolloutID})
@@ -684,10 +685,13 @@ func (s *RolloutService) BatchCancelTaskRuns(ctx context.Context, request *v1pb.
 	var taskRunIDs []int
 	var taskIDs []int
 	for _, taskRun := range request.TaskRuns {
-		_, _, _, taskID, taskRunID, err := common.GetProjectIDRolloutIDStageIDTaskIDTaskRunID(taskRun)
+		taskRunProjectID, taskRunRolloutID, taskRunStageID, taskID, taskRunID, err := common.GetProjectIDRolloutIDStageIDTaskIDTaskRunID(taskRun)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, err.Error())
 		}
+		if taskRunProjectID != projectID || taskRunRolloutID != rolloutID || taskRunStageID != stageID {
+			return nil, status.Errorf(codes.InvalidArgument, "task run %q is not in stage %q", taskRun, request.Parent)
+		}
 		taskIDs = append(taskIDs, taskID)
 		taskRunIDs = append(taskRunIDs, taskRunID)
 	}
@@ -704,6 +708,19 @@ func (s *RolloutService) BatchCancelTaskRuns(ctx context.Context, request *v1pb.
 		return nil, status.Errorf(codes.Internal, "failed to list task runs, error: %v", err)
 	}
 
+	taskByID := make(map[int]*store.TaskMessage)
+	for _, task := range tasks {
+		if task.PipelineID != rolloutID || task.StageID != stageID {
+			return nil, status.Errorf(codes.InvalidArgument, "task %v is not in stage %v", task.ID, stageID)
+		}
+		taskByID[task.ID] = task
+	}
+	for _, taskRun := range taskRuns {
+		if _, ok := taskByID[taskRun.TaskUID]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "task run %v is not in stage %v", taskRun.ID, stageID)
+		}
+	}
+
 	for _, taskRun := range taskRuns {
 		switch taskRun.Status {
 		case api.TaskRunPending:
@@ -744,16 +761,9 @@ func (s *RolloutService) UpdatePlan(ctx context.Context, request *v1pb.UpdatePla
 			return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask path %q", path)
 		}
 	}
-	planID, err := common.GetPlanID(request.Plan.Name)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
-	}
-	oldPlan, err := s.store.GetPlan(ctx, &store.FindPlanMessage{UID: &planID})
+	oldPlan, err := getPlanMessage(ctx, s.store, request.Plan.Name)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get plan %q: %v", request.Plan.Name, err)
-	}
-	if oldPlan == nil {
-		return nil, status.Errorf(codes.NotFound, "plan %q not found", request.Plan.Name)
+		return nil, err
 	}
 	oldSteps := convertToPlanSteps(oldPlan.Config.Steps)
 
@@ -1053,21 +1063,29 @@ func GetPipelineCreate(ctx context.Context, s *store.Store, licenseService enter
 	}
 
 	transformedSteps := steps
+	// stagePayloads is aligned with transformedSteps if the steps come from the deployment config.
+	var stagePayloads []*storepb.StagePayload
 	if len(steps) == 1 && len(steps[0].Specs) == 1 {
 		spec := steps[0].Specs[0]
 		if config := spec.GetChangeDatabaseConfig(); config != nil {
 			if _, _, err := common.GetProjectIDDeploymentConfigID(config.Target); err == nil {
-				stepsFromDeploymentConfig, err := transformDeploymentConfigTargetToSteps(ctx, s, spec, config, project)
+				stepsFromDeploymentConfig, payloadsFromDeploymentConfig, err := transformDeploymentConfigTargetToSteps(ctx, s, spec, config, project)
 				if err != nil {
 					return nil, errors.Wrap(err, "failed to transform deploymentConfig target to steps")
 				}
 				transformedSteps = stepsFromDeploymentConfig
+				stagePayloads = payloadsFromDeploymentConfig
 			}
 		}
 	}
 
-	for _, step := range transformedSteps {
-		stageCreate := &store.StageMessage{}
+	for i, step := range transformedSteps {
+		stageCreate := &store.StageMessage{
+			Payload: &storepb.StagePayload{},
+		}
+		if i < len(stagePayloads) {
+			stageCreate.Payload = stagePayloads[i]
+		}
 
 		var stageEnvironmentID string
 		registerEnvironmentID := func(environmentID string) error {
@@ -1104,6 +1122,9 @@ func GetPipelineCreate(ctx context.Context, s *store.Store, licenseService enter
 		}
 		stageCreate.EnvironmentID = environment.UID
 		stageCreate.Name = fmt.Sprintf("%s Stage", environment.Title)
+		if wave := stageCreate.Payload.GetCanaryWave(); wave != nil {
+			stageCreate.Name = fmt.Sprintf("%s Stage (Wave %d)", environment.Title, wave.Index+1)
+		}
 
 		pipelineCreate.Stages = append(pipelineCreate.Stages, stageCreate)
 	}
@@ -1125,6 +1146,7 @@ func (s *RolloutService) createPipeline(ctx context.Context, project *store.Proj
 			Name:          stage.Name,
 			EnvironmentID: stage.EnvironmentID,
 			PipelineID:    pipelineCreated.ID,
+			Payload:       stage.Payload,
 		})
 	}
 	createdStages, err := s.store.CreateStageV2(ctx, stageCreates, creatorID)
@@ -1227,10 +1249,8 @@ func canUserRunStageTasks(ctx context.Context, s *store.Store, user *store.UserM
 			if binding.Role != api.Owner {
 				continue
 			}
-			for _, member := range binding.Members {
-				if member.ID == user.ID {
-					return true, nil
-				}
+			if binding.HasMember(user.ID) {
+				return true, nil
 			}
 		}
 	}
@@ -1261,10 +1281,8 @@ func canUserCancelStageTaskRun(ctx context.Context, s *store.Store, user *store.
 			if binding.Role != api.Owner {
 				continue
 			}
-			for _, member := range binding.Members {
-				if member.ID == user.ID {
-					return true, nil
-				}
+			if binding.HasMember(user.ID) {
+				return true, nil
 			}
 		}
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
@@ -351,6 +352,8 @@ func convertToPlanCheckRunType(t store.PlanCheckRunType) v1pb.PlanCheckRun_Type
 		return v1pb.PlanCheckRun_DATABASE_STATEMENT_TYPE
 	case store.PlanCheckDatabaseStatementSummaryReport:
 		return v1pb.PlanCheckRun_DATABASE_STATEMENT_SUMMARY_REPORT
+	case store.PlanCheckDatabaseStatementLockImpact:
+		return v1pb.PlanCheckRun_DATABASE_STATEMENT_LOCK_IMPACT
 	case store.PlanCheckDatabaseConnect:
 		return v1pb.PlanCheckRun_DATABASE_CONNECT
 	case store.PlanCheckDatabaseGhostSync:
@@ -410,6 +413,20 @@ func convertToPlanCheckRunResult(result *storepb.PlanCheckRunResult_Result) *v1p
 				Code:   report.SqlReviewReport.Code,
 			},
 		}
+	case *storepb.PlanCheckRunResult_Result_LockImpactReport_:
+		resultV1.Report = &v1pb.PlanCheckRun_Result_LockImpactReport_{
+			LockImpactReport: &v1pb.PlanCheckRun_Result_LockImpactReport{
+				Line:              report.LockImpactReport.Line,
+				StatementType:     report.LockImpactReport.StatementType,
+				Table:             report.LockImpactReport.Table,
+				LockLevel:         v1pb.PlanCheckRun_Result_LockImpactReport_LockLevel(report.LockImpactReport.LockLevel),
+				TableRewrite:      report.LockImpactReport.TableRewrite,
+				ValidationScan:    report.LockImpactReport.ValidationScan,
+				RowCount:          report.LockImpactReport.RowCount,
+				DataSize:          report.LockImpactReport.DataSize,
+				EstimatedDuration: report.LockImpactReport.EstimatedDuration,
+			},
+		}
 	}
 	return resultV1
 }
@@ -497,6 +514,15 @@ func convertToRollout(ctx context.Context, s *store.Store, project *store.Projec
 			Environment: fmt.Sprintf("%s%s", common.EnvironmentNamePrefix, environment.ResourceID),
 			Title:       stage.Name,
 		}
+		rolloutWindowPolicy, err := s.GetRolloutWindowPolicy(ctx, environment.UID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get rollout window policy for environment %d", environment.UID)
+		}
+		rolloutWindowWarning, err := utils.CheckRolloutWindow(rolloutWindowPolicy, time.Now())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check rollout window for environment %d", environment.UID)
+		}
+		rolloutStage.RolloutWindowWarning = rolloutWindowWarning
 		for _, task := range stage.TaskList {
 			rolloutTask, err := convertToTask(ctx, s, project, task)
 			if err != nil {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -370,6 +371,22 @@ func getPlanCheckRunsFromChangeDatabaseConfigForDatabase(ctx context.Context, s
 			},
 		})
 	}
+	if databaseGroupUID == nil && instance.Engine == db.Postgres && config.Type == storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE {
+		planCheckRuns = append(planCheckRuns, &store.PlanCheckRunMessage{
+			CreatorUID: api.SystemBotID,
+			UpdaterUID: api.SystemBotID,
+			PlanUID:    plan.UID,
+			Status:     store.PlanCheckRunStatusRunning,
+			Type:       store.PlanCheckDatabaseStatementLockImpact,
+			Config: &storepb.PlanCheckRunConfig{
+				SheetUid:           int32(sheetUID),
+				ChangeDatabaseType: convertToChangeDatabaseType(config.Type),
+				InstanceUid:        int32(instance.UID),
+				DatabaseName:       database.DatabaseName,
+				DatabaseGroupUid:   databaseGroupUID,
+			},
+		})
+	}
 
 	return planCheckRuns, nil
 }
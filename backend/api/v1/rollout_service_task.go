@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"log/slog"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
@@ -21,13 +23,15 @@ import (
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
-func transformDeploymentConfigTargetToSteps(ctx context.Context, s *store.Store, spec *storepb.PlanConfig_Spec, c *storepb.PlanConfig_ChangeDatabaseConfig, project *store.ProjectMessage) ([]*storepb.PlanConfig_Step, error) {
+// transformDeploymentConfigTargetToSteps transforms the deployment config target to steps.
+// It also returns the stage payload of each step, which records the canary wave if the deployment has waves.
+func transformDeploymentConfigTargetToSteps(ctx context.Context, s *store.Store, spec *storepb.PlanConfig_Spec, c *storepb.PlanConfig_ChangeDatabaseConfig, project *store.ProjectMessage) ([]*storepb.PlanConfig_Step, []*storepb.StagePayload, error) {
 	projectID, _, err := common.GetProjectIDDeploymentConfigID(c.Target)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get project and deployment id from target %q", c.Target)
+		return nil, nil, errors.Wrapf(err, "failed to get project and deployment id from target %q", c.Target)
 	}
 	if project.ResourceID != projectID {
-		return nil, errors.Errorf("project id %q in target %q does not match project id %q in plan config", projectID, c.Target, project.ResourceID)
+		return nil, nil, errors.Errorf("project id %q in target %q does not match project id %q in plan config", projectID, c.Target, project.ResourceID)
 	}
 
 	switch c.Type {
@@ -37,56 +41,78 @@ func transformDeploymentConfigTargetToSteps(ctx context.Context, s *store.Store,
 	case storepb.PlanConfig_ChangeDatabaseConfig_MIGRATE_SDL:
 	case storepb.PlanConfig_ChangeDatabaseConfig_DATA:
 	default:
-		return nil, errors.Errorf("unsupported change database config type: %v", c.Type)
+		return nil, nil, errors.Errorf("unsupported change database config type: %v", c.Type)
 	}
 
 	deploymentConfig, err := s.GetDeploymentConfigV2(ctx, project.UID)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get deployment config")
+		return nil, nil, errors.Wrapf(err, "failed to get deployment config")
 	}
 	apiDeploymentConfig, err := deploymentConfig.ToAPIDeploymentConfig()
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to convert deployment config to api deployment config")
+		return nil, nil, errors.Wrapf(err, "failed to convert deployment config to api deployment config")
 	}
 	deploySchedule, err := api.ValidateAndGetDeploymentSchedule(apiDeploymentConfig.Payload)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to validate and get deployment schedule")
+		return nil, nil, errors.Wrapf(err, "failed to validate and get deployment schedule")
 	}
 	allDatabases, err := s.ListDatabases(ctx, &store.FindDatabaseMessage{ProjectID: &project.ResourceID})
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list databases")
+		return nil, nil, errors.Wrapf(err, "failed to list databases")
 	}
 	matrix, err := utils.GetDatabaseMatrixFromDeploymentSchedule(deploySchedule, allDatabases)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get database matrix from deployment schedule")
+		return nil, nil, errors.Wrapf(err, "failed to get database matrix from deployment schedule")
 	}
 
 	var steps []*storepb.PlanConfig_Step
-	for _, databases := range matrix {
+	var stagePayloads []*storepb.StagePayload
+	for i, databases := range matrix {
 		if len(databases) == 0 {
 			continue
 		}
 
-		step := &storepb.PlanConfig_Step{}
-		for _, database := range databases {
-			step.Specs = append(step.Specs, &storepb.PlanConfig_Spec{
-				EarliestAllowedTime: spec.EarliestAllowedTime,
-				Id:                  spec.Id,
-				Config: &storepb.PlanConfig_Spec_ChangeDatabaseConfig{
-					ChangeDatabaseConfig: &storepb.PlanConfig_ChangeDatabaseConfig{
-						Type:            c.Type,
-						Target:          fmt.Sprintf("instances/%s/databases/%s", database.InstanceID, database.DatabaseName),
-						Sheet:           c.Sheet,
-						SchemaVersion:   c.SchemaVersion,
-						RollbackEnabled: c.RollbackEnabled,
-						RollbackDetail:  c.RollbackDetail,
-					},
-				},
-			})
+		waves := [][]*store.DatabaseMessage{databases}
+		var wavesSpec *api.DeploymentWaves
+		if deploySchedule.Deployments[i].Spec != nil && deploySchedule.Deployments[i].Spec.Waves != nil {
+			wavesSpec = deploySchedule.Deployments[i].Spec.Waves
+			waves = utils.SplitDatabasesIntoWaves(databases, wavesSpec.Percentages)
+		}
+		for waveIndex, wave := range waves {
+			steps = append(steps, getStepFromDatabases(spec, c, wave))
+			stagePayload := &storepb.StagePayload{}
+			if wavesSpec != nil && len(waves) > 1 {
+				stagePayload.CanaryWave = &storepb.CanaryWave{
+					Index:          int32(waveIndex),
+					MaxFailedTasks: int32(wavesSpec.MaxFailedTasks),
+					SoakDuration:   durationpb.New(time.Duration(wavesSpec.SoakSeconds) * time.Second),
+				}
+			}
+			stagePayloads = append(stagePayloads, stagePayload)
 		}
-		steps = append(steps, step)
 	}
-	return steps, nil
+	return steps, stagePayloads, nil
+}
+
+func getStepFromDatabases(spec *storepb.PlanConfig_Spec, c *storepb.PlanConfig_ChangeDatabaseConfig, databases []*store.DatabaseMessage) *storepb.PlanConfig_Step {
+	step := &storepb.PlanConfig_Step{}
+	for _, database := range databases {
+		step.Specs = append(step.Specs, &storepb.PlanConfig_Spec{
+			EarliestAllowedTime: spec.EarliestAllowedTime,
+			Id:                  spec.Id,
+			Config: &storepb.PlanConfig_Spec_ChangeDatabaseConfig{
+				ChangeDatabaseConfig: &storepb.PlanConfig_ChangeDatabaseConfig{
+					Type:            c.Type,
+					Target:          fmt.Sprintf("instances/%s/databases/%s", database.InstanceID, database.DatabaseName),
+					Sheet:           c.Sheet,
+					SchemaVersion:   c.SchemaVersion,
+					RollbackEnabled: c.RollbackEnabled,
+					RollbackDetail:  c.RollbackDetail,
+				},
+			},
+		})
+	}
+	return step
 }
 
 func getTaskCreatesFromSpec(ctx context.Context, s *store.Store, licenseService enterpriseAPI.LicenseService, dbFactory *dbfactory.DBFactory, spec *storepb.PlanConfig_Spec, project *store.ProjectMessage, registerEnvironmentID func(string) error) ([]*store.TaskMessage, []store.TaskIndexDAG, error) {
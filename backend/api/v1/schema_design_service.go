@@ -483,6 +483,10 @@ func convertEngineToParserType(engine v1pb.Engine) parser.EngineType {
 		return parser.TiDB
 	case v1pb.Engine_ORACLE:
 		return parser.Oracle
+	case v1pb.Engine_MSSQL:
+		return parser.MSSQL
+	case v1pb.Engine_SNOWFLAKE:
+		return parser.Snowflake
 	default:
 		return parser.Standard
 	}
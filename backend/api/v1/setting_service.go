@@ -17,12 +17,14 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 
+	"github.com/bytebase/bytebase/backend/api/scim"
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/mail"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/edit"
 	"github.com/bytebase/bytebase/backend/store"
@@ -54,6 +56,9 @@ func NewSettingService(
 	}
 }
 
+// minSCIMTokenLength is the minimum length of the bearer token for the SCIM provisioning clients.
+const minSCIMTokenLength = 32
+
 // Some settings contain secret info so we only return settings that are needed by the client.
 var whitelistSettings = []api.SettingName{
 	api.SettingBrandingLogo,
@@ -142,11 +147,6 @@ func (s *SettingService) SetSetting(ctx context.Context, request *v1pb.SetSettin
 		return nil, status.Errorf(codes.InvalidArgument, "feature %s is unavailable in current mode", settingName)
 	}
 	apiSettingName := api.SettingName(settingName)
-	// TODO(zp): remove the following hard code when we persist the algorithm setting.
-	if apiSettingName == api.SettingMaskingAlgorithms {
-		return nil, status.Errorf(codes.InvalidArgument, "setting masking algorithm is not available")
-	}
-
 	var storeSettingValue string
 	switch apiSettingName {
 	case api.SettingWorkspaceProfile:
@@ -421,6 +421,14 @@ func (s *SettingService) SetSetting(ctx context.Context, request *v1pb.SetSettin
 		if err := convertV1PbToStorePb(request.Setting.Value.GetSemanticTypesSettingValue(), storeSemanticTypesSetting); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to unmarshal setting value for %s with error: %v", apiSettingName, err)
 		}
+		maskingAlgorithmSetting, err := s.store.GetMaskingAlgorithmSetting(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get masking algorithm setting: %v", err)
+		}
+		algorithmIDMap := make(map[string]any)
+		for _, algorithm := range maskingAlgorithmSetting.Algorithms {
+			algorithmIDMap[algorithm.Id] = any(nil)
+		}
 		idMap := make(map[string]any)
 		for _, tp := range storeSemanticTypesSetting.Types {
 			if !isValidUUID(tp.Id) {
@@ -432,6 +440,14 @@ func (s *SettingService) SetSetting(ctx context.Context, request *v1pb.SetSettin
 			if _, ok := idMap[tp.Id]; ok {
 				return nil, status.Errorf(codes.InvalidArgument, "duplicate semantic type id: %s", tp.Id)
 			}
+			for _, algorithmID := range []string{tp.PartialMaskAlgorithmId, tp.FullMaskAlgorithmId} {
+				if algorithmID == "" {
+					continue
+				}
+				if _, ok := algorithmIDMap[algorithmID]; !ok {
+					return nil, status.Errorf(codes.InvalidArgument, "masking algorithm %s not found for semantic type %s", algorithmID, tp.Id)
+				}
+			}
 			idMap[tp.Id] = any(nil)
 		}
 		bytes, err := protojson.Marshal(storeSemanticTypesSetting)
@@ -439,6 +455,61 @@ func (s *SettingService) SetSetting(ctx context.Context, request *v1pb.SetSettin
 			return nil, status.Errorf(codes.Internal, "failed to marshal setting for %s with error: %v", apiSettingName, err)
 		}
 		storeSettingValue = string(bytes)
+	case api.SettingMaskingAlgorithms:
+		storeMaskingAlgorithmSetting := new(storepb.MaskingAlgorithmSetting)
+		if err := convertV1PbToStorePb(request.Setting.Value.GetMaskingAlgorithmSettingValue(), storeMaskingAlgorithmSetting); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to unmarshal setting value for %s with error: %v", apiSettingName, err)
+		}
+		idMap := make(map[string]any)
+		for _, algorithm := range storeMaskingAlgorithmSetting.Algorithms {
+			if !isValidUUID(algorithm.Id) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid masking algorithm id format: %s", algorithm.Id)
+			}
+			if algorithm.Title == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "masking algorithm title cannot be empty: %s", algorithm.Id)
+			}
+			if _, ok := idMap[algorithm.Id]; ok {
+				return nil, status.Errorf(codes.InvalidArgument, "duplicate masking algorithm id: %s", algorithm.Id)
+			}
+			if err := masker.ValidateAlgorithm(algorithm); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid masking algorithm %s: %v", algorithm.Id, err)
+			}
+			idMap[algorithm.Id] = any(nil)
+		}
+		// The semantic types refer to the masking algorithms, so the algorithms in use cannot be deleted.
+		semanticTypesSetting, err := s.store.GetSemanticTypesSetting(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get semantic types setting: %v", err)
+		}
+		for _, tp := range semanticTypesSetting.Types {
+			for _, algorithmID := range []string{tp.PartialMaskAlgorithmId, tp.FullMaskAlgorithmId} {
+				if algorithmID == "" {
+					continue
+				}
+				if _, ok := idMap[algorithmID]; !ok {
+					return nil, status.Errorf(codes.FailedPrecondition, "masking algorithm %s is used by semantic type %s", algorithmID, tp.Id)
+				}
+			}
+		}
+		bytes, err := protojson.Marshal(storeMaskingAlgorithmSetting)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to marshal setting for %s with error: %v", apiSettingName, err)
+		}
+		storeSettingValue = string(bytes)
+	case api.SettingSCIMToken:
+		if err := s.licenseService.IsFeatureEnabled(api.FeatureSSO); err != nil {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		// An empty token disables the SCIM provisioning.
+		token := request.Setting.Value.GetStringValue()
+		if token != "" && len(token) < minSCIMTokenLength {
+			return nil, status.Errorf(codes.InvalidArgument, "SCIM token should be at least %d characters", minSCIMTokenLength)
+		}
+		// Only the hash of the token is stored, so the token cannot be read back.
+		if token != "" {
+			token = scim.HashToken(token)
+		}
+		storeSettingValue = token
 	default:
 		storeSettingValue = request.Setting.Value.GetStringValue()
 	}
@@ -648,6 +719,14 @@ func (s *SettingService) convertToSettingMessage(ctx context.Context, setting *s
 				},
 			},
 		}, nil
+	case api.SettingSCIMToken:
+		// The stored value is the hash of the token, which is useless to the clients.
+		return &v1pb.Setting{
+			Name: settingName,
+			Value: &v1pb.Value{
+				Value: &v1pb.Value_StringValue{},
+			},
+		}, nil
 	case api.SettingMaskingAlgorithms:
 		v1Value := new(v1pb.MaskingAlgorithmSetting)
 		if err := protojson.Unmarshal([]byte(setting.Value), v1Value); err != nil {
@@ -821,6 +900,11 @@ func validateApprovalTemplate(template *v1pb.ApprovalTemplate) error {
 		if len(step.Nodes) != 1 {
 			return errors.Errorf("expect 1 node in approval step, got: %v", len(step.Nodes))
 		}
+		if v, ok := step.Nodes[0].Payload.(*v1pb.ApprovalNode_Group); ok {
+			if _, err := common.GetGroupEmail(v.Group); err != nil {
+				return errors.Wrapf(err, "invalid approval node group %q", v.Group)
+			}
+		}
 	}
 	return nil
 }
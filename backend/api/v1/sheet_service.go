@@ -801,11 +801,8 @@ func (s *SheetService) findProjectRoles(ctx context.Context, projectUID int, pri
 	}
 	projectRoles := make(map[common.ProjectRole]bool)
 	for _, binding := range policy.Bindings {
-		for _, member := range binding.Members {
-			if member.ID == principalUID {
-				projectRoles[common.ProjectRole(binding.Role)] = true
-				break
-			}
+		if binding.HasMember(principalUID) {
+			projectRoles[common.ProjectRole(binding.Role)] = true
 		}
 	}
 	return projectRoles, nil
@@ -0,0 +1,288 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// sheetRevisionDiffContext is the number of unchanged lines around the changes in the unified diff.
+const sheetRevisionDiffContext = 3
+
+// ListSheetRevisions lists the revisions of a sheet, the latest first.
+func (s *SheetService) ListSheetRevisions(ctx context.Context, request *v1pb.ListSheetRevisionsRequest) (*v1pb.ListSheetRevisionsResponse, error) {
+	projectResourceID, sheetUID, err := common.GetProjectResourceIDSheetUID(request.Parent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	sheet, err := s.getProjectSheet(ctx, projectResourceID, sheetUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReadSheet(ctx, sheet); err != nil {
+		return nil, err
+	}
+
+	var limit, offset int
+	if request.PageToken != "" {
+		var pageToken storepb.PageToken
+		if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+		}
+		if pageToken.Limit < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "page size cannot be negative")
+		}
+		limit = int(pageToken.Limit)
+		offset = int(pageToken.Offset)
+	} else {
+		limit = int(request.PageSize)
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+	limitPlusOne := limit + 1
+
+	revisions, err := s.store.ListSheetRevisions(ctx, &store.FindSheetRevisionMessage{
+		SheetUID: sheet.UID,
+		Limit:    &limitPlusOne,
+		Offset:   &offset,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list sheet revisions, error: %v", err)
+	}
+
+	response := &v1pb.ListSheetRevisionsResponse{}
+	if len(revisions) == limitPlusOne {
+		nextPageToken, err := getPageToken(limit, offset+limit)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+		}
+		response.NextPageToken = nextPageToken
+		revisions = revisions[:limit]
+	}
+	for _, revision := range revisions {
+		v1pbRevision, err := s.convertToSheetRevision(ctx, projectResourceID, revision)
+		if err != nil {
+			return nil, err
+		}
+		response.Revisions = append(response.Revisions, v1pbRevision)
+	}
+	return response, nil
+}
+
+// GetSheetRevision returns the revision of a sheet with the full content.
+func (s *SheetService) GetSheetRevision(ctx context.Context, request *v1pb.GetSheetRevisionRequest) (*v1pb.SheetRevision, error) {
+	projectResourceID, sheetUID, revisionNumber, err := common.GetProjectResourceIDSheetUIDRevision(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	sheet, err := s.getProjectSheet(ctx, projectResourceID, sheetUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReadSheet(ctx, sheet); err != nil {
+		return nil, err
+	}
+	revision, err := s.getSheetRevision(ctx, sheet.UID, revisionNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToSheetRevision(ctx, projectResourceID, revision)
+}
+
+// DiffSheetRevisions returns the unified diff between two revisions of a sheet.
+func (s *SheetService) DiffSheetRevisions(ctx context.Context, request *v1pb.DiffSheetRevisionsRequest) (*v1pb.DiffSheetRevisionsResponse, error) {
+	projectResourceID, sheetUID, err := common.GetProjectResourceIDSheetUID(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	baseProjectResourceID, baseSheetUID, baseRevisionNumber, err := common.GetProjectResourceIDSheetUIDRevision(request.BaseRevision)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if baseProjectResourceID != projectResourceID || baseSheetUID != sheetUID {
+		return nil, status.Errorf(codes.InvalidArgument, "base revision %q is not a revision of sheet %q", request.BaseRevision, request.Name)
+	}
+	sheet, err := s.getProjectSheet(ctx, projectResourceID, sheetUID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReadSheet(ctx, sheet); err != nil {
+		return nil, err
+	}
+
+	base, err := s.getSheetRevision(ctx, sheet.UID, baseRevisionNumber)
+	if err != nil {
+		return nil, err
+	}
+	var target *store.SheetRevisionMessage
+	if request.TargetRevision == "" {
+		target, err = s.store.GetLatestSheetRevision(ctx, sheet.UID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get the latest revision of sheet %q, error: %v", request.Name, err)
+		}
+		if target == nil {
+			return nil, status.Errorf(codes.NotFound, "sheet %q has no revision", request.Name)
+		}
+	} else {
+		targetProjectResourceID, targetSheetUID, targetRevisionNumber, err := common.GetProjectResourceIDSheetUIDRevision(request.TargetRevision)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if targetProjectResourceID != projectResourceID || targetSheetUID != sheetUID {
+			return nil, status.Errorf(codes.InvalidArgument, "target revision %q is not a revision of sheet %q", request.TargetRevision, request.Name)
+		}
+		target, err = s.getSheetRevision(ctx, sheet.UID, targetRevisionNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diff, err := diffSheetRevisions(base, target)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to diff sheet revisions, error: %v", err)
+	}
+	return &v1pb.DiffSheetRevisionsResponse{Diff: diff}, nil
+}
+
+// RestoreSheetRevision restores the content of the sheet to a revision, which is appended as a new revision.
+func (s *SheetService) RestoreSheetRevision(ctx context.Context, request *v1pb.RestoreSheetRevisionRequest) (*v1pb.Sheet, error) {
+	projectResourceID, sheetUID, revisionNumber, err := common.GetProjectResourceIDSheetUIDRevision(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	sheet, err := s.getProjectSheet(ctx, projectResourceID, sheetUID)
+	if err != nil {
+		return nil, err
+	}
+	canAccess, err := s.canWriteSheet(ctx, sheet)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, fmt.Sprintf("failed to check access with error: %v", err))
+	}
+	if !canAccess {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot write sheet %s", sheet.Name)
+	}
+	revision, err := s.getSheetRevision(ctx, sheet.UID, revisionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	currentPrincipalID := ctx.Value(common.PrincipalIDContextKey).(int)
+	storeSheet, err := s.store.PatchSheet(ctx, &store.PatchSheetMessage{
+		UID:       sheet.UID,
+		UpdaterID: currentPrincipalID,
+		Statement: &revision.Statement,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, fmt.Sprintf("failed to restore sheet: %v", err))
+	}
+	return s.convertToAPISheetMessage(ctx, storeSheet)
+}
+
+// getProjectSheet gets the sheet in the project.
+func (s *SheetService) getProjectSheet(ctx context.Context, projectResourceID string, sheetUID int) (*store.SheetMessage, error) {
+	if sheetUID <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, fmt.Sprintf("invalid sheet id %d, must be positive integer", sheetUID))
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{
+		ResourceID: &projectResourceID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, fmt.Sprintf("failed to get project with resource id %q, err: %s", projectResourceID, err.Error()))
+	}
+	if project == nil {
+		return nil, status.Errorf(codes.NotFound, fmt.Sprintf("project with resource id %q not found", projectResourceID))
+	}
+	if project.Deleted {
+		return nil, status.Errorf(codes.NotFound, fmt.Sprintf("project with resource id %q had deleted", projectResourceID))
+	}
+	return s.findSheet(ctx, &store.FindSheetMessage{
+		UID:        &sheetUID,
+		ProjectUID: &project.UID,
+	})
+}
+
+func (s *SheetService) checkReadSheet(ctx context.Context, sheet *store.SheetMessage) error {
+	canAccess, err := s.canReadSheet(ctx, sheet)
+	if err != nil {
+		return status.Errorf(codes.Internal, fmt.Sprintf("failed to check access with error: %v", err))
+	}
+	if !canAccess {
+		return status.Errorf(codes.PermissionDenied, "cannot access sheet %s", sheet.Name)
+	}
+	return nil
+}
+
+// getSheetRevision gets the revision of the sheet with the full statement.
+func (s *SheetService) getSheetRevision(ctx context.Context, sheetUID, revisionNumber int) (*store.SheetRevisionMessage, error) {
+	revision, err := s.store.GetSheetRevision(ctx, &store.FindSheetRevisionMessage{
+		SheetUID: sheetUID,
+		Revision: &revisionNumber,
+		LoadFull: true,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get revision %d of sheet %d, error: %v", revisionNumber, sheetUID, err)
+	}
+	if revision == nil {
+		return nil, status.Errorf(codes.NotFound, "revision %d of sheet %d not found", revisionNumber, sheetUID)
+	}
+	return revision, nil
+}
+
+func (s *SheetService) convertToSheetRevision(ctx context.Context, projectResourceID string, revision *store.SheetRevisionMessage) (*v1pb.SheetRevision, error) {
+	creator, err := s.store.GetUserByID(ctx, revision.CreatorID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, fmt.Sprintf("failed to get creator: %v", err))
+	}
+	if creator == nil {
+		return nil, status.Errorf(codes.NotFound, fmt.Sprintf("creator with id %d not found", revision.CreatorID))
+	}
+	return &v1pb.SheetRevision{
+		Name:        formatSheetRevisionName(projectResourceID, revision.SheetUID, revision.Revision),
+		Creator:     common.FormatUserEmail(creator.Email),
+		CreateTime:  timestamppb.New(revision.CreatedTime),
+		Content:     []byte(revision.Statement),
+		ContentSize: revision.Size,
+	}, nil
+}
+
+func formatSheetRevisionName(projectResourceID string, sheetUID, revision int) string {
+	return fmt.Sprintf("%s%s/%s%d/%s%d", common.ProjectNamePrefix, projectResourceID, common.SheetIDPrefix, sheetUID, common.SheetRevisionPrefix, revision)
+}
+
+// diffSheetRevisions returns the unified diff from the base revision to the target revision.
+func diffSheetRevisions(base, target *store.SheetRevisionMessage) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitSheetRevisionLines(base.Statement),
+		B:        splitSheetRevisionLines(target.Statement),
+		FromFile: fmt.Sprintf("%s%d", common.SheetRevisionPrefix, base.Revision),
+		ToFile:   fmt.Sprintf("%s%d", common.SheetRevisionPrefix, target.Revision),
+		Context:  sheetRevisionDiffContext,
+	})
+}
+
+// splitSheetRevisionLines splits the statement into lines that keep their line endings.
+// Unlike difflib.SplitLines, it does not add an empty line after the trailing newline.
+func splitSheetRevisionLines(statement string) []string {
+	if statement == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(statement, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+func TestDiffSheetRevisions(t *testing.T) {
+	a := require.New(t)
+	base := &store.SheetRevisionMessage{Revision: 1, Statement: "CREATE TABLE t1(id INT);\nCREATE TABLE t2(id INT);\n"}
+	target := &store.SheetRevisionMessage{Revision: 2, Statement: "CREATE TABLE t1(id INT);\nCREATE TABLE t3(id INT);\n"}
+
+	diff, err := diffSheetRevisions(base, target)
+	a.NoError(err)
+	want := `--- revisions/1
++++ revisions/2
+@@ -1,2 +1,2 @@
+ CREATE TABLE t1(id INT);
+-CREATE TABLE t2(id INT);
++CREATE TABLE t3(id INT);
+`
+	a.Equal(want, diff)
+
+	diff, err = diffSheetRevisions(base, base)
+	a.NoError(err)
+	a.Equal("", diff)
+}
+
+func TestFormatSheetRevisionName(t *testing.T) {
+	require.Equal(t, "projects/p1/sheets/12/revisions/3", formatSheetRevisionName("p1", 12, 3))
+}
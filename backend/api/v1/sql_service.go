@@ -31,6 +31,8 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/exportjob"
+	"github.com/bytebase/bytebase/backend/component/sqlsession"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/advisor"
@@ -38,6 +40,8 @@ import (
 	advisorDB "github.com/bytebase/bytebase/backend/plugin/advisor/db"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/pg"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
@@ -54,16 +58,22 @@ const (
 	maximumSQLResultSize = 10 * 1024 * 1024
 	// defaultTimeout is the default timeout for query and admin execution.
 	defaultTimeout = 1 * time.Minute
+	// indexHitCheckTimeout is the timeout for explaining the query statement to check index hit.
+	indexHitCheckTimeout = 10 * time.Second
+	// fullTableScanRowCountThreshold is the estimated row count of a table, full table scan on which is reported.
+	fullTableScanRowCountThreshold = 100000
 )
 
 // SQLService is the service for SQL.
 type SQLService struct {
 	v1pb.UnimplementedSQLServiceServer
-	store           *store.Store
-	schemaSyncer    *schemasync.Syncer
-	dbFactory       *dbfactory.DBFactory
-	activityManager *activity.Manager
-	licenseService  enterpriseAPI.LicenseService
+	store            *store.Store
+	schemaSyncer     *schemasync.Syncer
+	dbFactory        *dbfactory.DBFactory
+	activityManager  *activity.Manager
+	licenseService   enterpriseAPI.LicenseService
+	sessionManager   *sqlsession.Manager
+	exportJobManager *exportjob.Manager
 }
 
 // NewSQLService creates a SQLService.
@@ -73,13 +83,17 @@ func NewSQLService(
 	dbFactory *dbfactory.DBFactory,
 	activityManager *activity.Manager,
 	licenseService enterpriseAPI.LicenseService,
+	sessionManager *sqlsession.Manager,
+	exportJobManager *exportjob.Manager,
 ) *SQLService {
 	return &SQLService{
-		store:           store,
-		schemaSyncer:    schemaSyncer,
-		dbFactory:       dbFactory,
-		activityManager: activityManager,
-		licenseService:  licenseService,
+		store:            store,
+		schemaSyncer:     schemaSyncer,
+		dbFactory:        dbFactory,
+		activityManager:  activityManager,
+		licenseService:   licenseService,
+		sessionManager:   sessionManager,
+		exportJobManager: exportJobManager,
 	}
 }
 
@@ -114,10 +128,13 @@ func (*SQLService) Pretty(_ context.Context, request *v1pb.PrettyRequest) (*v1pb
 }
 
 // AdminExecute executes the SQL statement.
+// The statement runs in the session if specified, and the sessions used by the stream are closed if the client disconnects.
 func (s *SQLService) AdminExecute(server v1pb.SQLService_AdminExecuteServer) error {
 	ctx := server.Context()
 	var driver db.Driver
 	var conn *sql.Conn
+	usedSessionIDs := make(map[string]bool)
+	disconnected := false
 	defer func() {
 		if conn != nil {
 			if err := conn.Close(); err != nil {
@@ -127,6 +144,11 @@ func (s *SQLService) AdminExecute(server v1pb.SQLService_AdminExecuteServer) err
 		if driver != nil {
 			driver.Close(ctx)
 		}
+		if disconnected {
+			for sessionID := range usedSessionIDs {
+				s.sessionManager.Delete(context.Background(), sessionID, "the client disconnected")
+			}
+		}
 	}()
 	for {
 		request, err := server.Recv()
@@ -134,16 +156,30 @@ func (s *SQLService) AdminExecute(server v1pb.SQLService_AdminExecuteServer) err
 			if err == io.EOF {
 				return nil
 			}
+			disconnected = ctx.Err() != nil
 			return status.Errorf(codes.Internal, "failed to receive request: %v", err)
 		}
 
-		instance, database, activity, err := s.preAdminExecute(ctx, request)
+		var session *sqlsession.Session
+		if request.Session != "" {
+			session, err = s.getSession(ctx, request.Session)
+			if err != nil {
+				return err
+			}
+			if request.Name != common.InstanceNamePrefix+session.InstanceID || request.ConnectionDatabase != session.ConnectionDatabase {
+				return status.Errorf(codes.InvalidArgument, "instance %q and connection database %q don't match session %q", request.Name, request.ConnectionDatabase, request.Session)
+			}
+			usedSessionIDs[session.ID] = true
+		}
+
+		instance, database, activity, err := s.preAdminExecute(ctx, request, session)
 		if err != nil {
 			return err
 		}
 
 		// We only need to get the driver and connection once.
-		if driver == nil {
+		// The statements in a session run on the connection pinned by the session instead.
+		if session == nil && driver == nil {
 			driver, err = s.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
 			if err != nil {
 				return status.Errorf(codes.Internal, "failed to get database driver: %v", err)
@@ -158,7 +194,24 @@ func (s *SQLService) AdminExecute(server v1pb.SQLService_AdminExecuteServer) err
 			}
 		}
 
-		result, durationNs, queryErr := s.doAdminExecute(ctx, driver, conn, request)
+		var result []*v1pb.QueryResult
+		var durationNs int64
+		var queryErr error
+		if session != nil {
+			result, durationNs, queryErr = s.doAdminExecuteInSession(ctx, session, request)
+		} else {
+			result, durationNs, queryErr = s.doAdminExecute(ctx, driver, conn, request)
+		}
+		if queryErr == nil {
+			sensitiveSchemaInfo, err := s.getSensitiveSchemaInfoForStatement(ctx, instance, request.ConnectionDatabase, request.Statement, storepb.MaskingExceptionPolicy_MaskingException_QUERY)
+			if err != nil {
+				// Fail closed if we cannot tell which columns are sensitive.
+				slog.Warn("failed to get sensitive schema info for admin execute, mask all columns", log.BBError(err))
+				util.MaskAllResults(request.Statement, result)
+			} else {
+				util.MaskResults(instance.Engine, request.Statement, request.ConnectionDatabase, sensitiveSchemaInfo, result)
+			}
+		}
 		sanitizeResults(result)
 
 		if err := s.postAdminExecute(ctx, activity, durationNs, queryErr); err != nil {
@@ -247,21 +300,47 @@ func (*SQLService) doAdminExecute(ctx context.Context, driver db.Driver, conn *s
 	return result, time.Now().UnixNano() - start, err
 }
 
-func (s *SQLService) preAdminExecute(ctx context.Context, request *v1pb.AdminExecuteRequest) (*store.InstanceMessage, *store.DatabaseMessage, *store.ActivityMessage, error) {
+// doAdminExecuteInSession runs the statement on the connection pinned by the session.
+func (*SQLService) doAdminExecuteInSession(ctx context.Context, session *sqlsession.Session, request *v1pb.AdminExecuteRequest) ([]*v1pb.QueryResult, int64, error) {
+	start := time.Now().UnixNano()
+	timeout := defaultTimeout
+	if request.Timeout != nil {
+		timeout = request.Timeout.AsDuration()
+	}
+	ctx, cancelCtx := context.WithTimeout(ctx, timeout)
+	defer cancelCtx()
+	result, err := session.Execute(ctx, request.Statement)
+	return result, time.Now().UnixNano() - start, err
+}
+
+func (s *SQLService) preAdminExecute(ctx context.Context, request *v1pb.AdminExecuteRequest, session *sqlsession.Session) (*store.InstanceMessage, *store.DatabaseMessage, *store.ActivityMessage, error) {
 	user, _, instance, database, err := s.prepareRelatedMessage(ctx, request.Name, request.ConnectionDatabase)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if instance.Engine == db.Redis {
+		if err := validateRedisCommands(request.Statement, false /* readOnly */); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := s.checkRedisKeyAccess(ctx, instance, request.ConnectionDatabase, request.Statement); err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	databaseID := 0
 	if database != nil {
 		databaseID = database.UID
 	}
+	sessionID := ""
+	if session != nil {
+		sessionID = session.ID
+	}
 	activity, err := s.createQueryActivity(ctx, user, api.ActivityInfo, instance.UID, api.ActivitySQLEditorQueryPayload{
 		Statement:              request.Statement,
 		InstanceID:             instance.UID,
 		DeprecatedInstanceName: instance.Title,
 		DatabaseID:             databaseID,
 		DatabaseName:           request.ConnectionDatabase,
+		SessionID:              sessionID,
 	})
 	if err != nil {
 		return nil, nil, nil, err
@@ -780,6 +859,11 @@ func (s *SQLService) preExport(ctx context.Context, request *v1pb.ExportRequest)
 	if err := validateQueryRequest(instance, request.ConnectionDatabase, request.Statement); err != nil {
 		return nil, nil, nil, nil, err
 	}
+	if instance.Engine == db.Redis {
+		if err := s.checkRedisKeyAccess(ctx, instance, request.ConnectionDatabase, request.Statement); err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
 
 	// dataShare must be false when connecting to instance (not database) in sql editor
 	// dataShare must be false when engine is not redshift
@@ -809,87 +893,98 @@ func (s *SQLService) preExport(ctx context.Context, request *v1pb.ExportRequest)
 	}
 
 	// Get sensitive schema info.
+	sensitiveSchemaInfo, err := s.getSensitiveSchemaInfoForStatement(ctx, instance, request.ConnectionDatabase, request.Statement, storepb.MaskingExceptionPolicy_MaskingException_EXPORT)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	databaseID := 0
+	if database != nil {
+		databaseID = database.UID
+	}
+	// Create export activity.
+	level := api.ActivityInfo
+	activity, err := s.createExportActivity(ctx, user, level, instance.UID, api.ActivitySQLExportPayload{
+		Statement:    request.Statement,
+		InstanceID:   instance.UID,
+		DatabaseID:   databaseID,
+		DatabaseName: request.ConnectionDatabase,
+	})
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return instance, database, sensitiveSchemaInfo, activity, nil
+}
+
+// getSensitiveSchemaInfoForStatement gets the sensitive schema info of the databases that the statement accesses.
+func (s *SQLService) getSensitiveSchemaInfoForStatement(ctx context.Context, instance *store.InstanceMessage, connectionDatabase string, statement string, action storepb.MaskingExceptionPolicy_MaskingException_Action) (*db.SensitiveSchemaInfo, error) {
 	var sensitiveSchemaInfo *db.SensitiveSchemaInfo
+	var err error
 	switch instance.Engine {
 	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
-		databaseList, err := parser.ExtractDatabaseList(parser.MySQL, request.Statement, "")
+		databaseList, err := parser.ExtractDatabaseList(parser.MySQL, statement, "")
 		if err != nil {
-			return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get database list: %s with error %v", request.Statement, err)
+			return nil, status.Errorf(codes.Internal, "Failed to get database list: %s with error %v", statement, err)
 		}
 
-		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, request.ConnectionDatabase, storepb.MaskingExceptionPolicy_MaskingException_EXPORT)
+		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, connectionDatabase, action)
 		if err != nil {
-			return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info: %s", request.Statement)
+			return nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info: %s", statement)
 		}
-	case db.Postgres, db.RisingWave:
-		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{request.ConnectionDatabase}, request.ConnectionDatabase, storepb.MaskingExceptionPolicy_MaskingException_EXPORT)
+	case db.Postgres, db.RisingWave, db.MongoDB:
+		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{connectionDatabase}, connectionDatabase, action)
 		if err != nil {
-			return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info: %s", request.Statement)
+			return nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info: %s", statement)
 		}
 	case db.Oracle, db.DM:
 		if instance.Options == nil || !instance.Options.SchemaTenantMode {
-			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{request.ConnectionDatabase}, request.ConnectionDatabase, storepb.MaskingExceptionPolicy_MaskingException_EXPORT)
+			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{connectionDatabase}, connectionDatabase, action)
 			if err != nil {
-				return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info for statement: %s, error: %v", request.Statement, err.Error())
+				return nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info for statement: %s, error: %v", statement, err.Error())
 			}
 		} else {
-			list, err := parser.ExtractResourceList(parser.Oracle, request.ConnectionDatabase, request.ConnectionDatabase, request.Statement)
+			list, err := parser.ExtractResourceList(parser.Oracle, connectionDatabase, connectionDatabase, statement)
 			if err != nil {
-				return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get resource list: %s", request.Statement)
+				return nil, status.Errorf(codes.Internal, "Failed to get resource list: %s", statement)
 			}
 			databaseMap := make(map[string]bool)
 			for _, resource := range list {
 				databaseMap[resource.Database] = true
 			}
 			var databaseList []string
-			databaseList = append(databaseList, request.ConnectionDatabase)
+			databaseList = append(databaseList, connectionDatabase)
 			for database := range databaseMap {
 				databaseList = append(databaseList, database)
 			}
-			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, request.ConnectionDatabase, storepb.MaskingExceptionPolicy_MaskingException_EXPORT)
+			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, connectionDatabase, action)
 			if err != nil {
-				return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info for statement: %s, error: %v", request.Statement, err.Error())
+				return nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info for statement: %s, error: %v", statement, err.Error())
 			}
 		}
 	case db.Snowflake:
-		databaseList, err := parser.ExtractDatabaseList(parser.Snowflake, request.Statement, request.ConnectionDatabase)
+		databaseList, err := parser.ExtractDatabaseList(parser.Snowflake, statement, connectionDatabase)
 		if err != nil {
-			return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get database list: %s with error %v", request.Statement, err)
+			return nil, status.Errorf(codes.Internal, "Failed to get database list: %s with error %v", statement, err)
 		}
 
-		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, request.ConnectionDatabase, storepb.MaskingExceptionPolicy_MaskingException_EXPORT)
+		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, connectionDatabase, action)
 		if err != nil {
-			return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info: %s", request.Statement)
+			return nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info: %s", statement)
 		}
 	case db.MSSQL:
-		databaseList, err := parser.ExtractDatabaseList(parser.MSSQL, request.Statement, request.ConnectionDatabase)
+		databaseList, err := parser.ExtractDatabaseList(parser.MSSQL, statement, connectionDatabase)
 		if err != nil {
-			return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get database list: %s with error %v", request.Statement, err)
+			return nil, status.Errorf(codes.Internal, "Failed to get database list: %s with error %v", statement, err)
 		}
 
-		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, request.ConnectionDatabase, storepb.MaskingExceptionPolicy_MaskingException_EXPORT)
+		sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, databaseList, connectionDatabase, action)
 		if err != nil {
-			return nil, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info: %s", request.Statement)
+			return nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info: %s", statement)
 		}
 	}
 
-	databaseID := 0
-	if database != nil {
-		databaseID = database.UID
-	}
-	// Create export activity.
-	level := api.ActivityInfo
-	activity, err := s.createExportActivity(ctx, user, level, instance.UID, api.ActivitySQLExportPayload{
-		Statement:    request.Statement,
-		InstanceID:   instance.UID,
-		DatabaseID:   databaseID,
-		DatabaseName: request.ConnectionDatabase,
-	})
-	if err != nil {
-		return nil, nil, nil, nil, err
-	}
-
-	return instance, database, sensitiveSchemaInfo, activity, nil
+	return sensitiveSchemaInfo, nil
 }
 
 func (s *SQLService) createExportActivity(ctx context.Context, user *store.UserMessage, level api.ActivityLevel, containerID int, payload api.ActivitySQLExportPayload) (*store.ActivityMessage, error) {
@@ -978,6 +1073,18 @@ func (s *SQLService) Check(ctx context.Context, request *v1pb.CheckRequest) (*v1
 //  2. do query
 //  3. post-query
 func (s *SQLService) Query(ctx context.Context, request *v1pb.QueryRequest) (*v1pb.QueryResponse, error) {
+	var session *sqlsession.Session
+	if request.Session != "" {
+		var err error
+		session, err = s.getSession(ctx, request.Session)
+		if err != nil {
+			return nil, err
+		}
+		if request.Name != common.InstanceNamePrefix+session.InstanceID || request.ConnectionDatabase != session.ConnectionDatabase {
+			return nil, status.Errorf(codes.InvalidArgument, "instance %q and connection database %q don't match session %q", request.Name, request.ConnectionDatabase, request.Session)
+		}
+	}
+
 	user, instance, database, adviceStatus, adviceList, sensitiveSchemaInfo, activity, err := s.preQuery(ctx, request)
 	if err != nil {
 		return nil, err
@@ -987,10 +1094,10 @@ func (s *SQLService) Query(ctx context.Context, request *v1pb.QueryRequest) (*v1
 	var queryErr error
 	var durationNs int64
 	if adviceStatus != advisor.Error {
-		results, durationNs, queryErr = s.doQuery(ctx, request, instance, database, sensitiveSchemaInfo)
+		results, durationNs, queryErr = s.doQuery(ctx, request, instance, database, sensitiveSchemaInfo, session)
 	}
 
-	adviceList, err = s.postQuery(ctx, request, adviceStatus, adviceList, instance, activity, durationNs, queryErr)
+	adviceList, err = s.postQuery(ctx, request, adviceStatus, adviceList, instance, database, activity, durationNs, queryErr)
 	if err != nil {
 		return nil, err
 	}
@@ -1052,10 +1159,11 @@ func (s *SQLService) Query(ctx context.Context, request *v1pb.QueryRequest) (*v1
 // postQuery does the following:
 //  1. Check index hit Explain statements
 //  2. Update SQL query activity
-func (s *SQLService) postQuery(ctx context.Context, _ *v1pb.QueryRequest, adviceStatus advisor.Status, adviceList []*v1pb.Advice, _ *store.InstanceMessage, activity *store.ActivityMessage, durationNs int64, queryErr error) ([]*v1pb.Advice, error) {
-	indexHitAdvices, err := s.checkIndexHit()
-	if err != nil {
-		return nil, err
+func (s *SQLService) postQuery(ctx context.Context, request *v1pb.QueryRequest, adviceStatus advisor.Status, adviceList []*v1pb.Advice, instance *store.InstanceMessage, database *store.DatabaseMessage, activity *store.ActivityMessage, durationNs int64, queryErr error) ([]*v1pb.Advice, error) {
+	var indexHitAdvices []*v1pb.Advice
+	// We only check the index hit for the successful queries.
+	if adviceStatus != advisor.Error && queryErr == nil {
+		indexHitAdvices = s.checkIndexHit(ctx, request, instance, database)
 	}
 
 	var finalAdviceList []*v1pb.Advice
@@ -1107,12 +1215,288 @@ func (s *SQLService) postQuery(ctx context.Context, _ *v1pb.QueryRequest, advice
 	return finalAdviceList, nil
 }
 
-func (*SQLService) checkIndexHit() ([]*v1pb.Advice, error) {
-	// TODO(rebelice): implement checkIndexHit
-	return nil, nil
+// fullTableScan is a table scanned fully in the query plan.
+type fullTableScan struct {
+	schema string
+	table  string
+	// estimatedRows is the estimated row count in the query plan, it is used if the table is not found in the database metadata.
+	estimatedRows int64
 }
 
-func (s *SQLService) doQuery(ctx context.Context, request *v1pb.QueryRequest, instance *store.InstanceMessage, database *store.DatabaseMessage, sensitiveSchemaInfo *db.SensitiveSchemaInfo) ([]*v1pb.QueryResult, int64, error) {
+// checkIndexHit explains the query statement and reports the full table scans on large tables.
+// The check is best-effort, failing to get the schema or explain the statement does not fail the query that has run already, so we only log the error.
+func (s *SQLService) checkIndexHit(ctx context.Context, request *v1pb.QueryRequest, instance *store.InstanceMessage, database *store.DatabaseMessage) []*v1pb.Advice {
+	if database == nil {
+		return nil
+	}
+	switch instance.Engine {
+	case db.MySQL, db.TiDB, db.MariaDB, db.Postgres:
+	default:
+		return nil
+	}
+	statement, ok := getSingleSelectStatement(instance.Engine, request.Statement)
+	if !ok {
+		return nil
+	}
+
+	dbSchema, err := s.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		slog.Warn("failed to get database schema to check index hit", slog.String("database", database.DatabaseName), log.BBError(err))
+		return nil
+	}
+	if dbSchema == nil {
+		return nil
+	}
+
+	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		slog.Warn("failed to get driver to check index hit", slog.String("database", database.DatabaseName), log.BBError(err))
+		return nil
+	}
+	defer driver.Close(ctx)
+	sqlDB := driver.GetDB()
+	if sqlDB == nil {
+		return nil
+	}
+
+	ctx, cancelCtx := context.WithTimeout(ctx, indexHitCheckTimeout)
+	defer cancelCtx()
+	var scans []fullTableScan
+	if instance.Engine == db.Postgres {
+		var explainJSON string
+		if err := sqlDB.QueryRowContext(ctx, fmt.Sprintf("EXPLAIN (FORMAT JSON, VERBOSE) %s", statement)).Scan(&explainJSON); err != nil {
+			slog.Warn("failed to explain statement to check index hit", slog.String("statement", statement), log.BBError(err))
+			return nil
+		}
+		scans, err = extractPostgresFullTableScans(explainJSON)
+	} else {
+		var explainRows []map[string]string
+		explainRows, err = queryExplainRows(ctx, sqlDB, fmt.Sprintf("EXPLAIN %s", statement))
+		if err != nil {
+			slog.Warn("failed to explain statement to check index hit", slog.String("statement", statement), log.BBError(err))
+			return nil
+		}
+		scans = resolveMySQLTableAliases(statement, database.DatabaseName, extractMySQLFullTableScans(explainRows))
+	}
+	if err != nil {
+		slog.Warn("failed to parse the query plan to check index hit", slog.String("statement", statement), log.BBError(err))
+		return nil
+	}
+
+	return buildIndexHitAdvices(scans, instance.Engine, dbSchema.Metadata)
+}
+
+// getSingleSelectStatement returns the statement without the trailing semicolon if it is a single SELECT statement.
+func getSingleSelectStatement(engine db.Type, statement string) (string, bool) {
+	list, err := parser.SplitMultiSQL(convertToParserEngine(engine), statement)
+	if err != nil {
+		return "", false
+	}
+	var result string
+	for _, item := range list {
+		if item.Empty {
+			continue
+		}
+		if result != "" {
+			return "", false
+		}
+		result = strings.TrimLeft(strings.TrimRight(item.Text, " \n\t;"), " \n\t")
+	}
+	upper := strings.ToUpper(result)
+	if !strings.HasPrefix(upper, "SELECT") && !strings.HasPrefix(upper, "WITH") {
+		return "", false
+	}
+	return result, true
+}
+
+// queryExplainRows runs the EXPLAIN statement and returns the rows as the maps from the lower case column name to the value.
+func queryExplainRows(ctx context.Context, sqlDB *sql.DB, statement string) ([]map[string]string, error) {
+	rows, err := sqlDB.QueryContext(ctx, statement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var result []map[string]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		scanArgs := make([]any, len(columns))
+		for i := range values {
+			scanArgs[i] = &values[i]
+		}
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]string)
+		for i, column := range columns {
+			row[strings.ToLower(column)] = values[i].String
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// extractMySQLFullTableScans extracts the full table scans from the EXPLAIN result of MySQL, MariaDB and TiDB.
+func extractMySQLFullTableScans(explainRows []map[string]string) []fullTableScan {
+	var result []fullTableScan
+	for _, row := range explainRows {
+		if accessObject, ok := row["access object"]; ok {
+			// TiDB uses the operator id such as "TableFullScan_5" and the access object such as "table:t".
+			if !strings.HasPrefix(row["id"], "TableFullScan") {
+				continue
+			}
+			table := strings.TrimPrefix(strings.SplitN(accessObject, ",", 2)[0], "table:")
+			estimatedRows, _ := strconv.ParseFloat(row["estrows"], 64)
+			result = append(result, fullTableScan{table: table, estimatedRows: int64(estimatedRows)})
+			continue
+		}
+		// MySQL and MariaDB use the access type "ALL" for the full table scan.
+		if row["type"] != "ALL" || row["key"] != "" || row["table"] == "" {
+			continue
+		}
+		// Derived tables and unions such as "<derived2>" are not real tables.
+		if strings.HasPrefix(row["table"], "<") {
+			continue
+		}
+		estimatedRows, _ := strconv.ParseInt(row["rows"], 10, 64)
+		result = append(result, fullTableScan{table: row["table"], estimatedRows: estimatedRows})
+	}
+	return result
+}
+
+// resolveMySQLTableAliases resolves the tables of the full table scans from the names in the EXPLAIN result,
+// which are the aliases if the tables are aliased in the statement.
+// The tables in the other databases than the connected database are qualified by the database as the schema.
+// The scans are returned as is if the statement cannot be parsed.
+func resolveMySQLTableAliases(statement string, databaseName string, scans []fullTableScan) []fullTableScan {
+	aliases, err := parser.ExtractMySQLTableAliases(statement)
+	if err != nil {
+		slog.Debug("failed to extract the table aliases to check index hit", slog.String("statement", statement), log.BBError(err))
+		return scans
+	}
+	var result []fullTableScan
+	for _, scan := range scans {
+		if resource, ok := aliases[scan.table]; ok {
+			scan.table = resource.Table
+			if resource.Database != "" && resource.Database != databaseName {
+				scan.schema = resource.Database
+			}
+		}
+		result = append(result, scan)
+	}
+	return result
+}
+
+// extractPostgresFullTableScans extracts the sequential scans from the EXPLAIN (FORMAT JSON) result of PostgreSQL.
+func extractPostgresFullTableScans(explainJSON string) ([]fullTableScan, error) {
+	type planNode struct {
+		NodeType     string      `json:"Node Type"`
+		RelationName string      `json:"Relation Name"`
+		Schema       string      `json:"Schema"`
+		PlanRows     float64     `json:"Plan Rows"`
+		Plans        []*planNode `json:"Plans"`
+	}
+	var explain []struct {
+		Plan *planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(explainJSON), &explain); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal query plan")
+	}
+
+	var result []fullTableScan
+	var walk func(node *planNode)
+	walk = func(node *planNode) {
+		if node == nil {
+			return
+		}
+		if node.NodeType == "Seq Scan" && node.RelationName != "" {
+			result = append(result, fullTableScan{schema: node.Schema, table: node.RelationName, estimatedRows: int64(node.PlanRows)})
+		}
+		for _, child := range node.Plans {
+			walk(child)
+		}
+	}
+	for _, item := range explain {
+		walk(item.Plan)
+	}
+	return result, nil
+}
+
+// buildIndexHitAdvices builds the advices for the full table scans on the tables whose row count exceeds the threshold.
+// The row count comes from the synced database metadata, and falls back to the estimation in the query plan.
+func buildIndexHitAdvices(scans []fullTableScan, engine db.Type, metadata *storepb.DatabaseSchemaMetadata) []*v1pb.Advice {
+	type tableKey struct {
+		schema string
+		table  string
+	}
+	rowCountMap := make(map[tableKey]int64)
+	for _, schema := range metadata.GetSchemas() {
+		for _, table := range schema.Tables {
+			rowCountMap[tableKey{schema: schema.Name, table: table.Name}] = table.RowCount
+		}
+	}
+
+	var result []*v1pb.Advice
+	reported := make(map[tableKey]bool)
+	for _, scan := range scans {
+		key := tableKey{schema: scan.schema, table: scan.table}
+		if engine == db.Postgres && key.schema == "" {
+			key.schema = "public"
+		}
+		if reported[key] {
+			continue
+		}
+		rowCount, ok := rowCountMap[key]
+		if !ok {
+			rowCount = scan.estimatedRows
+		}
+		if rowCount < fullTableScanRowCountThreshold {
+			continue
+		}
+		reported[key] = true
+		tableName := key.table
+		if key.schema != "" {
+			tableName = fmt.Sprintf("%s.%s", key.schema, key.table)
+		}
+		result = append(result, &v1pb.Advice{
+			Status:  v1pb.Advice_WARNING,
+			Code:    int32(advisor.StatementFullTableScan),
+			Title:   "Full table scan",
+			Content: fmt.Sprintf("The query scans the whole table %q with about %d rows, consider using an index or a more selective filter", tableName, rowCount),
+		})
+	}
+	return result
+}
+
+// doQuery runs the query on a new read-only connection, or on the connection pinned by the session if specified.
+func (s *SQLService) doQuery(ctx context.Context, request *v1pb.QueryRequest, instance *store.InstanceMessage, database *store.DatabaseMessage, sensitiveSchemaInfo *db.SensitiveSchemaInfo, session *sqlsession.Session) ([]*v1pb.QueryResult, int64, error) {
+	queryContext := &db.QueryContext{
+		Limit:               int(request.Limit),
+		ReadOnly:            true,
+		CurrentDatabase:     request.ConnectionDatabase,
+		SensitiveSchemaInfo: sensitiveSchemaInfo,
+		EnableSensitive:     s.licenseService.IsFeatureEnabledForInstance(api.FeatureSensitiveData, instance) == nil,
+	}
+	timeout := defaultTimeout
+	if request.Timeout != nil {
+		timeout = request.Timeout.AsDuration()
+	}
+
+	if session != nil {
+		ctx, cancelCtx := context.WithTimeout(ctx, timeout)
+		defer cancelCtx()
+		start := time.Now().UnixNano()
+		results, err := session.Query(ctx, request.Statement, queryContext)
+		sanitizeResults(results)
+		return results, time.Now().UnixNano() - start, err
+	}
+
 	driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database)
 	if err != nil {
 		return nil, 0, err
@@ -1129,21 +1513,11 @@ func (s *SQLService) doQuery(ctx context.Context, request *v1pb.QueryRequest, in
 		defer conn.Close()
 	}
 
-	timeout := defaultTimeout
-	if request.Timeout != nil {
-		timeout = request.Timeout.AsDuration()
-	}
 	ctx, cancelCtx := context.WithTimeout(ctx, timeout)
 	defer cancelCtx()
 
 	start := time.Now().UnixNano()
-	results, err := driver.QueryConn(ctx, conn, request.Statement, &db.QueryContext{
-		Limit:               int(request.Limit),
-		ReadOnly:            true,
-		CurrentDatabase:     request.ConnectionDatabase,
-		SensitiveSchemaInfo: sensitiveSchemaInfo,
-		EnableSensitive:     s.licenseService.IsFeatureEnabledForInstance(api.FeatureSensitiveData, instance) == nil,
-	})
+	results, err := driver.QueryConn(ctx, conn, request.Statement, queryContext)
 	select {
 	case <-ctx.Done():
 		// canceled or timed out
@@ -1192,6 +1566,11 @@ func (s *SQLService) preQuery(ctx context.Context, request *v1pb.QueryRequest) (
 	if err := validateQueryRequest(instance, request.ConnectionDatabase, request.Statement); err != nil {
 		return nil, nil, nil, advisor.Success, nil, nil, nil, err
 	}
+	if instance.Engine == db.Redis {
+		if err := s.checkRedisKeyAccess(ctx, instance, request.ConnectionDatabase, request.Statement); err != nil {
+			return nil, nil, nil, advisor.Success, nil, nil, nil, err
+		}
+	}
 
 	// dataShare must be false when connecting to instance (not database) in sql editor
 	// dataShare must be false when engine is not redshift
@@ -1235,7 +1614,7 @@ func (s *SQLService) preQuery(ctx context.Context, request *v1pb.QueryRequest) (
 			if err != nil {
 				return nil, nil, nil, advisor.Success, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info for statement: %s, error: %v", request.Statement, err.Error())
 			}
-		case db.Redshift, db.RisingWave:
+		case db.Redshift, db.RisingWave, db.MongoDB:
 			sensitiveSchemaInfo, err = s.getSensitiveSchemaInfo(ctx, instance, []string{request.ConnectionDatabase}, request.ConnectionDatabase, storepb.MaskingExceptionPolicy_MaskingException_QUERY)
 			if err != nil {
 				return nil, nil, nil, advisor.Success, nil, nil, nil, status.Errorf(codes.Internal, "Failed to get sensitive schema info for statement: %s, error: %v", request.Statement, err.Error())
@@ -1384,6 +1763,17 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find current principal")
 	}
+	// The masking exceptions can be granted to the user groups of the current principal.
+	currentPrincipalGroups, err := s.store.ListUserGroups(ctx, &store.FindUserGroupMessage{MemberUID: &currentPrincipalUID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find groups of current principal")
+	}
+	currentPrincipalMembers := map[string]bool{currentPrincipal.Email: true}
+	for _, group := range currentPrincipalGroups {
+		if group.Email != "" {
+			currentPrincipalMembers[formatGroupMember(group)] = true
+		}
+	}
 
 	type sensitiveDataMap map[api.SensitiveData]api.SensitiveDataMaskType
 	isEmpty := true
@@ -1406,9 +1796,21 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 	// we store the projectResourceID - maskingExceptionPolicy in a map.
 	maskingExceptionPolicyMap := make(map[string]*storepb.MaskingExceptionPolicy)
 
+	semanticTypesSetting, err := s.store.GetSemanticTypesSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find semantic types setting")
+	}
+
+	maskingAlgorithmSetting, err := s.store.GetMaskingAlgorithmSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find masking algorithm setting")
+	}
+
 	m := newEmptyMaskingLevelEvaluator().
 		withMaskingRulePolicy(maskingRulePolicy).
-		withDataClassificationSetting(classificationSetting)
+		withDataClassificationSetting(classificationSetting).
+		withSemanticTypesSetting(semanticTypesSetting).
+		withMaskingAlgorithmSetting(maskingAlgorithmSetting)
 
 	for _, name := range databaseList {
 		databaseName := name
@@ -1464,7 +1866,7 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 				if maskingException.Action != action {
 					continue
 				}
-				if maskingException.Member == currentPrincipal.Email {
+				if currentPrincipalMembers[maskingException.Member] {
 					slog.Debug("hit masking exception for current principal", slog.String("database", databaseName), slog.String("project", database.ProjectID), slog.Any("masking exception", maskingException))
 					maskingExceptionContainsCurrentPrincipal = append(maskingExceptionContainsCurrentPrincipal, maskingException)
 					break
@@ -1514,6 +1916,7 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "Failed to find schema for database %q in instance %q: %v", databaseName, instance.Title, err)
 		}
+		semanticTypeIDMap := buildColumnSemanticTypeIDMap(dbSchema.Config)
 
 		if instance.Engine == db.Oracle || instance.Engine == db.DM {
 			for _, schema := range dbSchema.Metadata.Schemas {
@@ -1539,9 +1942,14 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 						if sensitive {
 							isEmpty = false
 						}
+						columnMasker, err := m.getMaskerOfColumn(semanticTypeIDMap[maskingPolicyKey{schema: schema.Name, table: table.Name, column: column.Name}], maskingLevel)
+						if err != nil {
+							return nil, errors.Wrapf(err, "failed to get masker of database %q, schema %q, table %q, column %q", databaseName, schema.Name, table.Name, column.Name)
+						}
 						tableSchema.ColumnList = append(tableSchema.ColumnList, db.ColumnInfo{
 							Name:         column.Name,
 							MaskingLevel: maskingLevel,
+							Masker:       columnMasker,
 						})
 					}
 					schemaSchema.TableList = append(schemaSchema.TableList, tableSchema)
@@ -1576,9 +1984,14 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 					if sensitive {
 						isEmpty = false
 					}
+					columnMasker, err := m.getMaskerOfColumn(semanticTypeIDMap[maskingPolicyKey{schema: schema.Name, table: table.Name, column: column.Name}], maskingLevel)
+					if err != nil {
+						return nil, errors.Wrapf(err, "failed to get masker of database %q, schema %q, table %q, column %q", databaseName, schema.Name, table.Name, column.Name)
+					}
 					tableSchema.ColumnList = append(tableSchema.ColumnList, db.ColumnInfo{
 						Name:         column.Name,
 						MaskingLevel: maskingLevel,
+						Masker:       columnMasker,
 					})
 				}
 				schemaSchema.TableList = append(schemaSchema.TableList, tableSchema)
@@ -1603,15 +2016,94 @@ func (s *SQLService) getSensitiveSchemaInfo(ctx context.Context, instance *store
 	return result, nil
 }
 
+// buildColumnSemanticTypeIDMap builds the map from the column to its semantic type id.
+func buildColumnSemanticTypeIDMap(config *storepb.DatabaseConfig) map[maskingPolicyKey]string {
+	result := make(map[maskingPolicyKey]string)
+	if config == nil {
+		return result
+	}
+	for _, schemaConfig := range config.SchemaConfigs {
+		for _, tableConfig := range schemaConfig.TableConfigs {
+			for _, columnConfig := range tableConfig.ColumnConfigs {
+				if columnConfig.SemanticTypeId == "" {
+					continue
+				}
+				result[maskingPolicyKey{
+					schema: schemaConfig.Name,
+					table:  tableConfig.Name,
+					column: columnConfig.Name,
+				}] = columnConfig.SemanticTypeId
+			}
+		}
+	}
+	return result
+}
+
 type maskingLevelEvaluator struct {
 	maskingRules            []*storepb.MaskingRulePolicy_MaskingRule
 	dataClassificationIDMap map[string]*storepb.DataClassificationSetting_DataClassificationConfig
+	semanticTypeIDMap       map[string]*storepb.SemanticTypesSetting_SemanticType
+	maskingAlgorithmIDMap   map[string]*storepb.MaskingAlgorithmSetting_Algorithm
 }
 
 func newEmptyMaskingLevelEvaluator() *maskingLevelEvaluator {
 	return &maskingLevelEvaluator{
 		dataClassificationIDMap: make(map[string]*storepb.DataClassificationSetting_DataClassificationConfig),
+		semanticTypeIDMap:       make(map[string]*storepb.SemanticTypesSetting_SemanticType),
+		maskingAlgorithmIDMap:   make(map[string]*storepb.MaskingAlgorithmSetting_Algorithm),
+	}
+}
+
+func (m *maskingLevelEvaluator) withSemanticTypesSetting(semanticTypesSetting *storepb.SemanticTypesSetting) *maskingLevelEvaluator {
+	if semanticTypesSetting == nil {
+		return m
+	}
+	for _, semanticType := range semanticTypesSetting.Types {
+		m.semanticTypeIDMap[semanticType.Id] = semanticType
 	}
+	return m
+}
+
+func (m *maskingLevelEvaluator) withMaskingAlgorithmSetting(maskingAlgorithmSetting *storepb.MaskingAlgorithmSetting) *maskingLevelEvaluator {
+	if maskingAlgorithmSetting == nil {
+		return m
+	}
+	for _, algorithm := range maskingAlgorithmSetting.Algorithms {
+		m.maskingAlgorithmIDMap[algorithm.Id] = algorithm
+	}
+	return m
+}
+
+// getMaskerOfColumn returns the masker of the column with the given semantic type and masking level.
+// It returns nil if the semantic type does not specify the masking algorithm for the masking level,
+// and the default masker of the masking level will be used.
+func (m *maskingLevelEvaluator) getMaskerOfColumn(semanticTypeID string, maskingLevel storepb.MaskingLevel) (masker.Masker, error) {
+	if semanticTypeID == "" {
+		return nil, nil
+	}
+	semanticType, ok := m.semanticTypeIDMap[semanticTypeID]
+	if !ok {
+		return nil, nil
+	}
+	var algorithmID string
+	switch maskingLevel {
+	case storepb.MaskingLevel_FULL:
+		algorithmID = semanticType.FullMaskAlgorithmId
+	case storepb.MaskingLevel_PARTIAL:
+		algorithmID = semanticType.PartialMaskAlgorithmId
+	default:
+		return nil, nil
+	}
+	if algorithmID == "" {
+		return nil, nil
+	}
+	algorithm, ok := m.maskingAlgorithmIDMap[algorithmID]
+	if !ok {
+		// The algorithms in use cannot be deleted, but the settings may be changed concurrently, fallback to the default masker of the masking level.
+		slog.Warn("masking algorithm of semantic type not found", slog.String("semantic type", semanticTypeID), slog.String("algorithm", algorithmID))
+		return nil, nil
+	}
+	return masker.NewMasker(algorithm)
 }
 
 func (m *maskingLevelEvaluator) withMaskingRulePolicy(maskingRulePolicy *storepb.MaskingRulePolicy) *maskingLevelEvaluator {
@@ -1845,12 +2337,13 @@ func (s *SQLService) sqlReviewCheck(ctx context.Context, statement string, envir
 		dbType,
 		dbSchema.Metadata.CharacterSet,
 		dbSchema.Metadata.Collation,
-		environment.UID,
+		environment,
 		statement,
 		catalog,
 		connection,
 		currentSchema,
 		database.DatabaseName,
+		database.ProjectID,
 	)
 	if err != nil {
 		return advisor.Error, nil, status.Errorf(codes.Internal, "Failed to check SQL review policy: %v", err)
@@ -1893,15 +2386,16 @@ func (s *SQLService) sqlCheck(
 	dbType advisorDB.Type,
 	dbCharacterSet string,
 	dbCollation string,
-	environmentID int,
+	environment *store.EnvironmentMessage,
 	statement string,
 	catalog catalog.Catalog,
 	driver *sql.DB,
 	currentSchema string,
 	currentDatabase string,
+	projectID string,
 ) (advisor.Status, []advisor.Advice, error) {
 	var adviceList []advisor.Advice
-	policy, err := s.store.GetSQLReviewPolicy(ctx, environmentID)
+	policy, err := s.store.GetSQLReviewPolicy(ctx, environment.UID)
 	if err != nil {
 		if e, ok := err.(*common.Error); ok && e.Code == common.NotFound {
 			return advisor.Success, nil, nil
@@ -1918,6 +2412,8 @@ func (s *SQLService) sqlCheck(
 		Context:         ctx,
 		CurrentSchema:   currentSchema,
 		CurrentDatabase: currentDatabase,
+		EnvironmentID:   environment.ResourceID,
+		ProjectID:       projectID,
 	})
 	if err != nil {
 		return advisor.Error, nil, err
@@ -2048,7 +2544,11 @@ func validateQueryRequest(instance *store.InstanceMessage, databaseName string,
 		if err := parser.PLSQLValidateForEditor(tree); err != nil {
 			return nonSelectSQLError.Err()
 		}
-	case db.MongoDB, db.Redis:
+	case db.Redis:
+		if err := validateRedisCommands(statement, true /* readOnly */); err != nil {
+			return err
+		}
+	case db.MongoDB:
 		// Do nothing.
 	default:
 		// TODO(rebelice): support multiple statements here.
@@ -2060,6 +2560,72 @@ func validateQueryRequest(instance *store.InstanceMessage, databaseName string,
 	return nil
 }
 
+// validateRedisCommands checks the Redis commands are allowed to run in the SQL Editor.
+// The dangerous commands must run by the data change issues, and only the read commands are allowed for the read-only queries.
+// SELECT is rejected because the key access policy is checked against the connection database.
+func validateRedisCommands(statement string, readOnly bool) error {
+	commands, err := parser.ParseRedisCommands(statement)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to parse query: %s", err.Error())
+	}
+	for _, command := range commands {
+		var message string
+		switch {
+		case command.Category == parser.RedisCommandDangerous:
+			message = fmt.Sprintf("%s is a dangerous command and must run by a data change issue", command.Name)
+		case command.Name == "SELECT":
+			message = "SELECT is not allowed, connect to the database to run the commands on it instead"
+		case readOnly && command.Category != parser.RedisCommandRead:
+			message = fmt.Sprintf("%s is not a read-only command", command.Name)
+		default:
+			continue
+		}
+		if suggestion := command.Suggestion(); suggestion != "" {
+			message += ", " + suggestion
+		}
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return nil
+}
+
+// checkRedisKeyAccess checks the keys accessed by the Redis commands are allowed by the Redis key access policy of the project.
+func (s *SQLService) checkRedisKeyAccess(ctx context.Context, instance *store.InstanceMessage, connectionDatabase string, statement string) error {
+	if connectionDatabase == "" {
+		// The Redis driver connects to the database 0 by default.
+		connectionDatabase = "0"
+	}
+	database, err := s.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{
+		InstanceID:   &instance.ResourceID,
+		DatabaseName: &connectionDatabase,
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch database: %v", err)
+	}
+	if database == nil {
+		// The key access policy is configured on the project of the database, so deny the databases not synced yet.
+		return status.Errorf(codes.NotFound, "database %q not found", connectionDatabase)
+	}
+	project, err := s.store.GetProjectV2(ctx, &store.FindProjectMessage{ResourceID: &database.ProjectID})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch project: %v", err)
+	}
+	if project == nil {
+		return status.Errorf(codes.NotFound, "project %q not found", database.ProjectID)
+	}
+	policy, err := s.store.GetRedisKeyAccessPolicyByProjectUID(ctx, project.UID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch redis key access policy: %v", err)
+	}
+	commands, err := parser.ParseRedisCommands(statement)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to parse query: %s", err.Error())
+	}
+	if err := parser.CheckRedisKeyPatterns(commands, policy.KeyPatterns); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
 func (s *SQLService) extractResourceList(ctx context.Context, engine parser.EngineType, databaseName string, statement string, instance *store.InstanceMessage) ([]parser.SchemaResource, error) {
 	switch engine {
 	case parser.MySQL, parser.MariaDB, parser.OceanBase:
@@ -2544,29 +3110,18 @@ func hasDatabaseAccessRights(principalID int, projectPolicy *store.IAMPolicyMess
 	pass := false
 	for _, binding := range projectPolicy.Bindings {
 		// Project owner has all permissions.
-		if binding.Role == api.Role(common.ProjectOwner) {
-			for _, member := range binding.Members {
-				if member.ID == principalID {
-					pass = true
-					break
-				}
-			}
+		if binding.Role == api.Role(common.ProjectOwner) && binding.HasMember(principalID) {
+			pass = true
 		}
 		if !((isExport && binding.Role == api.Role(common.ProjectExporter)) || (!isExport && binding.Role == api.Role(common.ProjectQuerier))) {
 			continue
 		}
-		for _, member := range binding.Members {
-			if member.ID != principalID {
-				continue
-			}
+		if binding.HasMember(principalID) {
 			ok, err := evaluateQueryExportPolicyCondition(binding.Condition.Expression, attributes)
 			if err != nil {
 				slog.Error("failed to evaluate condition", log.BBError(err), slog.String("condition", binding.Condition.Expression))
-				break
-			}
-			if ok {
+			} else if ok {
 				pass = true
-				break
 			}
 		}
 		if pass {
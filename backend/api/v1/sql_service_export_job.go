@@ -0,0 +1,461 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/xuri/excelize/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/exportjob"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// CreateExportJob creates an export job running in the background.
+// The rows are streamed from the database into the export file, and the export activity is updated once the job finishes.
+func (s *SQLService) CreateExportJob(ctx context.Context, request *v1pb.CreateExportJobRequest) (*v1pb.ExportJob, error) {
+	exportRequest := request.Export
+	if exportRequest == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "export must be set")
+	}
+	if _, ok := exportFormatWriters[exportRequest.Format]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported export format: %s", exportRequest.Format.String())
+	}
+	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "principal ID not found")
+	}
+
+	instance, database, sensitiveSchemaInfo, activity, err := s.preExport(ctx, exportRequest)
+	if err != nil {
+		return nil, err
+	}
+	// Don't anonymize data for exporting data using admin mode.
+	if exportRequest.Admin {
+		sensitiveSchemaInfo = nil
+	}
+	var resourceList []parser.SchemaResource
+	if exportRequest.Format == v1pb.ExportFormat_SQL {
+		if resourceList, err = s.extractResourceList(ctx, convertToParserEngine(instance.Engine), exportRequest.ConnectionDatabase, exportRequest.Statement, instance); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to extract resource list: %v", err)
+		}
+		// Check the engine before running the job, the columns are only known once the query runs.
+		if _, err := getSQLStatementPrefix(instance.Engine, resourceList, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	run := func(ctx context.Context, job *exportjob.Job, w io.Writer) error {
+		driver, err := s.dbFactory.GetReadOnlyDatabaseDriver(ctx, instance, database)
+		if err != nil {
+			return err
+		}
+		defer driver.Close(ctx)
+
+		sqlDB := driver.GetDB()
+		var conn *sql.Conn
+		if sqlDB != nil {
+			conn, err = sqlDB.Conn(ctx)
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+		}
+
+		streamer := &exportRowStreamer{
+			job: job,
+			newWriter: func(header *v1pb.QueryResult) (exportFormatWriter, error) {
+				return exportFormatWriters[exportRequest.Format](w, instance.Engine, resourceList, header)
+			},
+		}
+		defer streamer.release()
+		results, err := driver.QueryConn(ctx, conn, exportRequest.Statement, &db.QueryContext{
+			Limit:               int(exportRequest.Limit),
+			ReadOnly:            true,
+			CurrentDatabase:     exportRequest.ConnectionDatabase,
+			SensitiveSchemaInfo: sensitiveSchemaInfo,
+			EnableSensitive:     s.licenseService.IsFeatureEnabledForInstance(api.FeatureSensitiveData, instance) == nil,
+			RowStreamer:         streamer,
+		})
+		if err != nil {
+			return err
+		}
+		if len(results) != 1 {
+			return errors.Errorf("expecting 1 result, but got %d", len(results))
+		}
+		return streamer.finish(results[0])
+	}
+	start := time.Now().UnixNano()
+	finish := func(job *exportjob.Job, exportErr error) {
+		if err := s.postExport(context.Background(), activity, time.Now().UnixNano()-start, exportErr); err != nil {
+			slog.Error("failed to update the activity of the export job", slog.String("job", job.ID), log.BBError(err))
+		}
+	}
+	job, err := s.exportJobManager.Create(ctx, &exportjob.CreateJobMessage{
+		PrincipalID:        principalID,
+		InstanceID:         instance.ResourceID,
+		ConnectionDatabase: exportRequest.ConnectionDatabase,
+		Statement:          exportRequest.Statement,
+		Format:             exportRequest.Format,
+		Compression:        request.Compression,
+	}, run, finish)
+	if err != nil {
+		if err := s.postExport(ctx, activity, 0, err); err != nil {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to create export job: %v", err)
+	}
+	return s.convertToExportJob(ctx, job)
+}
+
+// GetExportJob gets an export job with its progress.
+func (s *SQLService) GetExportJob(ctx context.Context, request *v1pb.GetExportJobRequest) (*v1pb.ExportJob, error) {
+	job, err := s.getExportJob(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return s.convertToExportJob(ctx, job)
+}
+
+// CancelExportJob cancels a running export job.
+func (s *SQLService) CancelExportJob(ctx context.Context, request *v1pb.CancelExportJobRequest) (*v1pb.ExportJob, error) {
+	job, err := s.getExportJob(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	canceled, err := s.exportJobManager.Cancel(ctx, job)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cancel export job, error: %v", err)
+	}
+	if canceled == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "export job %q is not running", request.Name)
+	}
+	return s.convertToExportJob(ctx, canceled)
+}
+
+// getExportJob gets the export job of the current user by name.
+func (s *SQLService) getExportJob(ctx context.Context, name string) (*exportjob.Job, error) {
+	jobID, err := common.GetExportJobID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "principal ID not found")
+	}
+	job, err := s.exportJobManager.Get(ctx, jobID, principalID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get export job, error: %v", err)
+	}
+	if job == nil {
+		return nil, status.Errorf(codes.NotFound, "export job %q not found", name)
+	}
+	return job, nil
+}
+
+func (s *SQLService) convertToExportJob(ctx context.Context, job *exportjob.Job) (*v1pb.ExportJob, error) {
+	state, jobErr, finishedTs := job.Status()
+	exportJob := &v1pb.ExportJob{
+		Name:               common.ExportJobNamePrefix + job.ID,
+		Instance:           common.InstanceNamePrefix + job.InstanceID,
+		ConnectionDatabase: job.ConnectionDatabase,
+		Statement:          job.Statement,
+		Format:             job.Format,
+		Compression:        job.Compression,
+		State:              state,
+		RowCount:           job.RowCount(),
+		ByteCount:          job.ByteCount(),
+		Error:              jobErr,
+		CreateTime:         timestamppb.New(time.Unix(job.CreatedTs, 0)),
+	}
+	if state == v1pb.ExportJob_RUNNING {
+		return exportJob, nil
+	}
+	exportJob.FinishTime = timestamppb.New(time.Unix(finishedTs, 0))
+	if state == v1pb.ExportJob_DONE {
+		setting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to find workspace setting, error: %v", err)
+		}
+		// The link is relative to the server if the external URL isn't configured.
+		exportJob.DownloadUrl = fmt.Sprintf("%s%s/%s?token=%s", setting.ExternalUrl, exportjob.DownloadPath, job.ID, url.QueryEscape(job.Token()))
+		exportJob.ExpireTime = timestamppb.New(time.Unix(finishedTs, 0).Add(exportjob.DownloadTTL))
+	}
+	return exportJob, nil
+}
+
+// exportRowStreamer streams the rows of the query result into the export format writer and reports the progress to the job.
+type exportRowStreamer struct {
+	job       *exportjob.Job
+	newWriter func(header *v1pb.QueryResult) (exportFormatWriter, error)
+	writer    exportFormatWriter
+}
+
+func (e *exportRowStreamer) Begin(header *v1pb.QueryResult) error {
+	writer, err := e.newWriter(header)
+	if err != nil {
+		return err
+	}
+	e.writer = writer
+	return nil
+}
+
+func (e *exportRowStreamer) Row(row *v1pb.QueryRow) error {
+	if err := e.writer.writeRow(row); err != nil {
+		return err
+	}
+	e.job.AddRow()
+	return nil
+}
+
+// finish flushes the export content.
+// The drivers not supporting the row streamer return the rows in the result instead, which are written here.
+func (e *exportRowStreamer) finish(result *v1pb.QueryResult) error {
+	if e.writer == nil {
+		if result.Error != "" {
+			return errors.New(result.Error)
+		}
+		if err := e.Begin(result); err != nil {
+			return err
+		}
+		for _, row := range result.Rows {
+			if err := e.Row(row); err != nil {
+				return err
+			}
+		}
+	}
+	return e.writer.close()
+}
+
+func (e *exportRowStreamer) release() {
+	if e.writer != nil {
+		e.writer.release()
+	}
+}
+
+// exportFormatWriter writes the rows in an export format.
+// The content is the same as the one of the Export API.
+type exportFormatWriter interface {
+	writeRow(row *v1pb.QueryRow) error
+	// close writes the rest of the content after the rows.
+	close() error
+	// release releases the resources held by the writer, it is called even if the export fails.
+	release()
+}
+
+var exportFormatWriters = map[v1pb.ExportFormat]func(w io.Writer, engine db.Type, resourceList []parser.SchemaResource, header *v1pb.QueryResult) (exportFormatWriter, error){
+	v1pb.ExportFormat_CSV:  newCSVExportWriter,
+	v1pb.ExportFormat_JSON: newJSONExportWriter,
+	v1pb.ExportFormat_SQL:  newSQLExportWriter,
+	v1pb.ExportFormat_XLSX: newXLSXExportWriter,
+}
+
+type csvExportWriter struct {
+	w        *bufio.Writer
+	rowCount int
+}
+
+func newCSVExportWriter(w io.Writer, _ db.Type, _ []parser.SchemaResource, header *v1pb.QueryResult) (exportFormatWriter, error) {
+	writer := &csvExportWriter{w: bufio.NewWriter(w)}
+	if _, err := writer.w.WriteString(strings.Join(header.ColumnNames, ",")); err != nil {
+		return nil, err
+	}
+	if err := writer.w.WriteByte('\n'); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (c *csvExportWriter) writeRow(row *v1pb.QueryRow) error {
+	if c.rowCount != 0 {
+		if err := c.w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	c.rowCount++
+	for i, value := range row.Values {
+		if i != 0 {
+			if err := c.w.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		if _, err := c.w.Write(convertValueToBytesInCSV(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*csvExportWriter) release() {}
+
+func (c *csvExportWriter) close() error {
+	return c.w.Flush()
+}
+
+type sqlExportWriter struct {
+	w               *bufio.Writer
+	engine          db.Type
+	statementPrefix string
+	rowCount        int
+}
+
+func newSQLExportWriter(w io.Writer, engine db.Type, resourceList []parser.SchemaResource, header *v1pb.QueryResult) (exportFormatWriter, error) {
+	statementPrefix, err := getSQLStatementPrefix(engine, resourceList, header.ColumnNames)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlExportWriter{w: bufio.NewWriter(w), engine: engine, statementPrefix: statementPrefix}, nil
+}
+
+func (e *sqlExportWriter) writeRow(row *v1pb.QueryRow) error {
+	if e.rowCount != 0 {
+		if _, err := e.w.WriteString(");\n"); err != nil {
+			return err
+		}
+	}
+	e.rowCount++
+	if _, err := e.w.WriteString(e.statementPrefix); err != nil {
+		return err
+	}
+	for i, value := range row.Values {
+		if i != 0 {
+			if err := e.w.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		if _, err := e.w.Write(convertValueToBytesInSQL(e.engine, value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*sqlExportWriter) release() {}
+
+func (e *sqlExportWriter) close() error {
+	if e.rowCount != 0 {
+		if _, err := e.w.WriteString(");"); err != nil {
+			return err
+		}
+	}
+	return e.w.Flush()
+}
+
+type jsonExportWriter struct {
+	w           *bufio.Writer
+	columnNames []string
+	rowCount    int
+}
+
+func newJSONExportWriter(w io.Writer, _ db.Type, _ []parser.SchemaResource, header *v1pb.QueryResult) (exportFormatWriter, error) {
+	writer := &jsonExportWriter{w: bufio.NewWriter(w), columnNames: header.ColumnNames}
+	if err := writer.w.WriteByte('['); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (j *jsonExportWriter) writeRow(row *v1pb.QueryRow) error {
+	separator := ",\n  "
+	if j.rowCount == 0 {
+		separator = "\n  "
+	}
+	j.rowCount++
+	m := make(map[string]any)
+	for i, value := range row.Values {
+		m[j.columnNames[i]] = convertValueToStringInJSON(value)
+	}
+	content, err := json.MarshalIndent(m, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := j.w.WriteString(separator); err != nil {
+		return err
+	}
+	if _, err := j.w.Write(content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (*jsonExportWriter) release() {}
+
+func (j *jsonExportWriter) close() error {
+	if j.rowCount != 0 {
+		if err := j.w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	if err := j.w.WriteByte(']'); err != nil {
+		return err
+	}
+	return j.w.Flush()
+}
+
+// xlsxExportWriter writes the rows with the stream writer of excelize, which keeps the rows in a temporary file instead of the memory.
+// The workbook is written to w once all rows are written because the XLSX file is a zip archive.
+type xlsxExportWriter struct {
+	w        io.Writer
+	f        *excelize.File
+	sw       *excelize.StreamWriter
+	rowCount int
+}
+
+func newXLSXExportWriter(w io.Writer, _ db.Type, _ []parser.SchemaResource, header *v1pb.QueryResult) (exportFormatWriter, error) {
+	if len(header.ColumnNames) > excelMaxColumn {
+		return nil, errors.Errorf("index cannot be greater than %v (column ZZZ)", excelMaxColumn)
+	}
+	f := excelize.NewFile()
+	sw, err := f.NewStreamWriter(sheet1Name)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	var values []any
+	for _, columnName := range header.ColumnNames {
+		values = append(values, columnName)
+	}
+	if err := sw.SetRow("A1", values); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &xlsxExportWriter{w: w, f: f, sw: sw}, nil
+}
+
+func (x *xlsxExportWriter) writeRow(row *v1pb.QueryRow) error {
+	x.rowCount++
+	var values []any
+	for _, value := range row.Values {
+		values = append(values, convertValueToStringInXLSX(value))
+	}
+	return x.sw.SetRow(fmt.Sprintf("A%d", x.rowCount+1), values)
+}
+
+func (x *xlsxExportWriter) release() {
+	if err := x.f.Close(); err != nil {
+		slog.Warn("failed to close the XLSX file of the export", log.BBError(err))
+	}
+}
+
+func (x *xlsxExportWriter) close() error {
+	if err := x.sw.Flush(); err != nil {
+		return err
+	}
+	return x.f.Write(x.w)
+}
@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestExportFormatWriters(t *testing.T) {
+	a := require.New(t)
+	result := &v1pb.QueryResult{
+		ColumnNames: []string{"a", "b", "c"},
+		Rows: []*v1pb.QueryRow{
+			{
+				Values: []*v1pb.RowValue{
+					{Kind: &v1pb.RowValue_Int64Value{Int64Value: 1}},
+					{Kind: &v1pb.RowValue_StringValue{StringValue: "a\"b,c"}},
+					{Kind: &v1pb.RowValue_NullValue{}},
+				},
+			},
+			{
+				Values: []*v1pb.RowValue{
+					{Kind: &v1pb.RowValue_Int64Value{Int64Value: 2}},
+					{Kind: &v1pb.RowValue_StringValue{StringValue: "a\nbc"}},
+					{Kind: &v1pb.RowValue_BoolValue{BoolValue: true}},
+				},
+			},
+		},
+	}
+	resourceList := []parser.SchemaResource{{Database: "db", Table: "t"}}
+	statementPrefix, err := getSQLStatementPrefix(db.MySQL, resourceList, result.ColumnNames)
+	a.NoError(err)
+
+	tests := []struct {
+		format v1pb.ExportFormat
+		export func(result *v1pb.QueryResult) ([]byte, error)
+	}{
+		{
+			format: v1pb.ExportFormat_CSV,
+			export: exportCSV,
+		},
+		{
+			format: v1pb.ExportFormat_JSON,
+			export: exportJSON,
+		},
+		{
+			format: v1pb.ExportFormat_SQL,
+			export: func(result *v1pb.QueryResult) ([]byte, error) {
+				return exportSQL(db.MySQL, statementPrefix, result)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		// The streamed content is the same as the one of the Export API.
+		want, err := test.export(result)
+		a.NoError(err)
+		var buf bytes.Buffer
+		writer, err := exportFormatWriters[test.format](&buf, db.MySQL, resourceList, result)
+		a.NoError(err)
+		for _, row := range result.Rows {
+			a.NoError(writer.writeRow(row))
+		}
+		a.NoError(writer.close())
+		writer.release()
+		a.Equal(string(want), buf.String(), test.format.String())
+	}
+}
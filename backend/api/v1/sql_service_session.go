@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/sqlsession"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+// CreateSession creates a SQL Editor session pinning a connection of the admin data source.
+// Only the workspace owners and DBAs can create sessions, see ownerAndDBAMethods.
+func (s *SQLService) CreateSession(ctx context.Context, request *v1pb.CreateSessionRequest) (*v1pb.Session, error) {
+	if request.Session == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "session must be set")
+	}
+	user, _, instance, database, err := s.prepareRelatedMessage(ctx, request.Session.Instance, request.Session.ConnectionDatabase)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not found")
+	}
+	if !sqlsession.IsEngineSupported(instance.Engine) {
+		return nil, status.Errorf(codes.InvalidArgument, "session is not supported for engine %s", instance.Engine)
+	}
+	if instance.Engine == db.Postgres && request.Session.ConnectionDatabase == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "connection_database is required for postgres instance")
+	}
+	var idleTimeout time.Duration
+	if request.Session.IdleTimeout != nil {
+		idleTimeout = request.Session.IdleTimeout.AsDuration()
+		if idleTimeout <= 0 || idleTimeout > sqlsession.MaxIdleTimeout {
+			return nil, status.Errorf(codes.InvalidArgument, "idle_timeout must be positive and at most %v", sqlsession.MaxIdleTimeout)
+		}
+	}
+
+	driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get database driver: %v", err)
+	}
+	session, err := s.sessionManager.Create(ctx, &sqlsession.CreateSessionMessage{
+		PrincipalID:        user.ID,
+		Instance:           instance,
+		Database:           database,
+		ConnectionDatabase: request.Session.ConnectionDatabase,
+		IdleTimeout:        idleTimeout,
+	}, driver)
+	if err != nil {
+		driver.Close(ctx)
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to create session: %v", err)
+	}
+	return convertToSession(session), nil
+}
+
+// GetSession gets a SQL Editor session.
+func (s *SQLService) GetSession(ctx context.Context, request *v1pb.GetSessionRequest) (*v1pb.Session, error) {
+	session, err := s.getSession(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	return convertToSession(session), nil
+}
+
+// DeleteSession closes a SQL Editor session, and rolls back its open transaction.
+func (s *SQLService) DeleteSession(ctx context.Context, request *v1pb.DeleteSessionRequest) (*emptypb.Empty, error) {
+	session, err := s.getSession(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	s.sessionManager.Delete(ctx, session.ID, "the session is closed")
+	return &emptypb.Empty{}, nil
+}
+
+// BeginTransaction opens a transaction in a SQL Editor session.
+func (s *SQLService) BeginTransaction(ctx context.Context, request *v1pb.BeginTransactionRequest) (*v1pb.Session, error) {
+	session, err := s.getSession(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	begin, _, _ := session.TransactionStatements()
+	if err := s.runSessionTransactionStatement(ctx, session, begin, session.Begin); err != nil {
+		return nil, err
+	}
+	return convertToSession(session), nil
+}
+
+// CommitTransaction commits the open transaction of a SQL Editor session.
+func (s *SQLService) CommitTransaction(ctx context.Context, request *v1pb.CommitTransactionRequest) (*v1pb.Session, error) {
+	session, err := s.getSession(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	_, commit, _ := session.TransactionStatements()
+	if err := s.runSessionTransactionStatement(ctx, session, commit, session.Commit); err != nil {
+		return nil, err
+	}
+	return convertToSession(session), nil
+}
+
+// RollbackTransaction rolls back the open transaction of a SQL Editor session.
+func (s *SQLService) RollbackTransaction(ctx context.Context, request *v1pb.RollbackTransactionRequest) (*v1pb.Session, error) {
+	session, err := s.getSession(ctx, request.Name)
+	if err != nil {
+		return nil, err
+	}
+	_, _, rollback := session.TransactionStatements()
+	if err := s.runSessionTransactionStatement(ctx, session, rollback, session.Rollback); err != nil {
+		return nil, err
+	}
+	return convertToSession(session), nil
+}
+
+// runSessionTransactionStatement runs the transaction control statement of the session, and records it in the SQL Editor query activity.
+func (s *SQLService) runSessionTransactionStatement(ctx context.Context, session *sqlsession.Session, statement string, run func(context.Context) error) error {
+	user, err := s.getUser(ctx)
+	if err != nil {
+		return err
+	}
+	activity, err := s.createQueryActivity(ctx, user, api.ActivityInfo, session.InstanceUID, api.ActivitySQLEditorQueryPayload{
+		Statement:              statement,
+		InstanceID:             session.InstanceUID,
+		DeprecatedInstanceName: session.InstanceTitle,
+		DatabaseID:             session.DatabaseUID,
+		DatabaseName:           session.ConnectionDatabase,
+		SessionID:              session.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	start := time.Now().UnixNano()
+	runErr := run(ctx)
+	if err := s.postAdminExecute(ctx, activity, time.Now().UnixNano()-start, runErr); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to run %s in session %q: %v", statement, session.ID, runErr)
+	}
+	return nil
+}
+
+// getSession gets the SQL Editor session of the current user by name.
+// The sessions pin the connections of the admin data source, so only the workspace owners and DBAs can use them,
+// the role is checked on every use in case the user is demoted after creating the session.
+func (s *SQLService) getSession(ctx context.Context, name string) (*sqlsession.Session, error) {
+	sessionID, err := common.GetSessionID(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	principalID, ok := ctx.Value(common.PrincipalIDContextKey).(int)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "principal ID not found")
+	}
+	role, ok := ctx.Value(common.RoleContextKey).(api.Role)
+	if !ok || !isOwnerOrDBA(role) {
+		return nil, status.Errorf(codes.PermissionDenied, "only workspace owners and DBAs can use sessions")
+	}
+	session := s.sessionManager.Get(sessionID, principalID)
+	if session == nil {
+		return nil, status.Errorf(codes.NotFound, "session %q not found", name)
+	}
+	return session, nil
+}
+
+func convertToSession(session *sqlsession.Session) *v1pb.Session {
+	transactionState := v1pb.Session_AUTOCOMMIT
+	if session.InTransaction() {
+		transactionState = v1pb.Session_IN_TRANSACTION
+	}
+	return &v1pb.Session{
+		Name:               common.SessionNamePrefix + session.ID,
+		Instance:           common.InstanceNamePrefix + session.InstanceID,
+		ConnectionDatabase: session.ConnectionDatabase,
+		IdleTimeout:        durationpb.New(session.IdleTimeout),
+		TransactionState:   transactionState,
+		CreateTime:         timestamppb.New(time.Unix(session.CreatedTs, 0)),
+		LastActiveTime:     timestamppb.New(time.Unix(session.LastActiveTs(), 0)),
+	}
+}
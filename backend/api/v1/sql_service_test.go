@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/googleapis/type/expr"
 
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	"github.com/bytebase/bytebase/backend/store"
@@ -450,3 +451,218 @@ func TestEvalMaskingLevelOfColumn(t *testing.T) {
 		a.Equal(tc.want, result, tc.description)
 	}
 }
+
+func TestGetMaskerOfColumn(t *testing.T) {
+	semanticTypesSetting := &storepb.SemanticTypesSetting{
+		Types: []*storepb.SemanticTypesSetting_SemanticType{
+			{
+				Id:                     "email",
+				Title:                  "Email",
+				FullMaskAlgorithmId:    "md5",
+				PartialMaskAlgorithmId: "keep-domain",
+			},
+			{
+				Id:    "default",
+				Title: "Default",
+			},
+			{
+				Id:                  "dangling",
+				Title:               "Dangling",
+				FullMaskAlgorithmId: "not-exist",
+			},
+		},
+	}
+	maskingAlgorithmSetting := &storepb.MaskingAlgorithmSetting{
+		Algorithms: []*storepb.MaskingAlgorithmSetting_Algorithm{
+			{
+				Id:    "md5",
+				Title: "MD5",
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_HashMask_{
+					HashMask: &storepb.MaskingAlgorithmSetting_Algorithm_HashMask{Type: storepb.MaskingAlgorithmSetting_Algorithm_HashMask_MD5},
+				},
+			},
+			{
+				Id:    "keep-domain",
+				Title: "Keep domain",
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_{
+					InnerOuterMask: &storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask{
+						Type:      storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_INNER,
+						SuffixLen: 8,
+					},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		description    string
+		semanticTypeID string
+		maskingLevel   storepb.MaskingLevel
+		input          string
+		// want is empty if no masker is expected.
+		want string
+	}{
+		{
+			description:    "full masking level uses the full mask algorithm",
+			semanticTypeID: "email",
+			maskingLevel:   storepb.MaskingLevel_FULL,
+			input:          "bytebase",
+			want:           "91f044180a1552deeed3606d693b4ea7",
+		},
+		{
+			description:    "partial masking level uses the partial mask algorithm",
+			semanticTypeID: "email",
+			maskingLevel:   storepb.MaskingLevel_PARTIAL,
+			input:          "zp@bytebase.com",
+			want:           "*******base.com",
+		},
+		{
+			description:    "none masking level does not need masker",
+			semanticTypeID: "email",
+			maskingLevel:   storepb.MaskingLevel_NONE,
+		},
+		{
+			description:    "semantic type without algorithm falls back to the default masker",
+			semanticTypeID: "default",
+			maskingLevel:   storepb.MaskingLevel_FULL,
+		},
+		{
+			description:    "missing algorithm falls back to the default masker",
+			semanticTypeID: "dangling",
+			maskingLevel:   storepb.MaskingLevel_FULL,
+		},
+		{
+			description:  "column without semantic type uses the default masker",
+			maskingLevel: storepb.MaskingLevel_FULL,
+		},
+	}
+
+	a := require.New(t)
+	m := newEmptyMaskingLevelEvaluator().withSemanticTypesSetting(semanticTypesSetting).withMaskingAlgorithmSetting(maskingAlgorithmSetting)
+	for _, tc := range testCases {
+		columnMasker, err := m.getMaskerOfColumn(tc.semanticTypeID, tc.maskingLevel)
+		a.NoError(err, tc.description)
+		if tc.want == "" {
+			a.Nil(columnMasker, tc.description)
+			continue
+		}
+		a.NotNil(columnMasker, tc.description)
+		got := columnMasker.Mask(&v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: tc.input}})
+		a.Equal(tc.want, got.GetStringValue(), tc.description)
+	}
+}
+
+func TestGetSingleSelectStatement(t *testing.T) {
+	testCases := []struct {
+		engine    db.Type
+		statement string
+		want      string
+		wantOK    bool
+	}{
+		{engine: db.MySQL, statement: "SELECT * FROM t;", want: "SELECT * FROM t", wantOK: true},
+		{engine: db.Postgres, statement: " with a as (select 1) select * from a", want: "with a as (select 1) select * from a", wantOK: true},
+		{engine: db.MySQL, statement: "SELECT 1; SELECT 2;"},
+		{engine: db.Postgres, statement: "UPDATE t SET a = 1"},
+	}
+
+	a := require.New(t)
+	for _, tc := range testCases {
+		got, ok := getSingleSelectStatement(tc.engine, tc.statement)
+		a.Equal(tc.wantOK, ok, tc.statement)
+		a.Equal(tc.want, got, tc.statement)
+	}
+}
+
+func TestExtractFullTableScans(t *testing.T) {
+	a := require.New(t)
+
+	mysqlRows := []map[string]string{
+		{"id": "1", "table": "t1", "type": "ALL", "key": "", "rows": "200000"},
+		{"id": "1", "table": "t2", "type": "ref", "key": "idx_a", "rows": "1"},
+		{"id": "1", "table": "<derived2>", "type": "ALL", "key": "", "rows": "10"},
+	}
+	a.Equal([]fullTableScan{{table: "t1", estimatedRows: 200000}}, extractMySQLFullTableScans(mysqlRows))
+
+	tidbRows := []map[string]string{
+		{"id": "TableReader_5", "estrows": "10000.00", "task": "root", "access object": "", "operator info": "data:TableFullScan_4"},
+		{"id": "TableFullScan_4", "estrows": "10000.00", "task": "cop[tikv]", "access object": "table:t", "operator info": "keep order:false"},
+	}
+	a.Equal([]fullTableScan{{table: "t", estimatedRows: 10000}}, extractMySQLFullTableScans(tidbRows))
+
+	aliasRows := []map[string]string{
+		{"id": "1", "table": "o", "type": "ALL", "key": "", "rows": "200000"},
+		{"id": "1", "table": "u", "type": "ALL", "key": "", "rows": "300000"},
+		{"id": "1", "table": "t3", "type": "ALL", "key": "", "rows": "400000"},
+	}
+	a.Equal([]fullTableScan{
+		{table: "orders", estimatedRows: 200000},
+		{schema: "other", table: "users", estimatedRows: 300000},
+		{table: "t3", estimatedRows: 400000},
+	}, resolveMySQLTableAliases("SELECT * FROM orders AS o JOIN other.users u ON o.user_id = u.id JOIN db.t3 ON t3.id = o.id", "db", extractMySQLFullTableScans(aliasRows)))
+
+	pgPlan := `[{"Plan": {"Node Type": "Hash Join", "Plan Rows": 100, "Plans": [
+		{"Node Type": "Seq Scan", "Relation Name": "orders", "Schema": "public", "Plan Rows": 5000000},
+		{"Node Type": "Index Scan", "Relation Name": "users", "Schema": "public", "Plan Rows": 1}
+	]}}]`
+	scans, err := extractPostgresFullTableScans(pgPlan)
+	a.NoError(err)
+	a.Equal([]fullTableScan{{schema: "public", table: "orders", estimatedRows: 5000000}}, scans)
+
+	_, err = extractPostgresFullTableScans("not json")
+	a.Error(err)
+}
+
+func TestBuildIndexHitAdvices(t *testing.T) {
+	metadata := &storepb.DatabaseSchemaMetadata{
+		Schemas: []*storepb.SchemaMetadata{
+			{
+				Name: "public",
+				Tables: []*storepb.TableMetadata{
+					{Name: "orders", RowCount: 5000000},
+					{Name: "users", RowCount: 100},
+				},
+			},
+		},
+	}
+	scans := []fullTableScan{
+		// The row count in metadata wins over the estimation.
+		{schema: "public", table: "orders", estimatedRows: 10},
+		{schema: "public", table: "orders", estimatedRows: 10},
+		{schema: "public", table: "users", estimatedRows: 10000000},
+		// Fall back to the estimation for tables not in metadata.
+		{schema: "", table: "events", estimatedRows: 300000},
+	}
+
+	a := require.New(t)
+	advices := buildIndexHitAdvices(scans, db.Postgres, metadata)
+	a.Len(advices, 2)
+	a.Equal(v1pb.Advice_WARNING, advices[0].Status)
+	a.Equal(int32(advisor.StatementFullTableScan), advices[0].Code)
+	a.Contains(advices[0].Content, `"public.orders" with about 5000000 rows`)
+	a.Contains(advices[1].Content, `"public.events" with about 300000 rows`)
+}
+
+func TestValidateRedisCommands(t *testing.T) {
+	tests := []struct {
+		statement   string
+		readOnly    bool
+		wantMessage string
+	}{
+		{statement: "GET user:1\nSCAN 0 MATCH user:*", readOnly: true},
+		{statement: "SET user:1 a", readOnly: true, wantMessage: "SET is not a read-only command"},
+		{statement: "SET user:1 a", readOnly: false},
+		{statement: "KEYS user:*", readOnly: true, wantMessage: "KEYS is a dangerous command and must run by a data change issue, use SCAN with MATCH"},
+		{statement: "flushall", readOnly: false, wantMessage: "FLUSHALL is a dangerous command and must run by a data change issue"},
+		{statement: `GET "user`, readOnly: true, wantMessage: "failed to parse query"},
+		{statement: "SELECT 1\nGET user:1", readOnly: false, wantMessage: "SELECT is not allowed"},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		err := validateRedisCommands(test.statement, test.readOnly)
+		if test.wantMessage == "" {
+			a.NoError(err, test.statement)
+		} else {
+			a.ErrorContains(err, test.wantMessage, test.statement)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/component/config"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/kms"
 )
 
 func getBaseProfile(dataDir string) config.Profile {
@@ -33,10 +34,20 @@ func getBaseProfile(dataDir string) config.Profile {
 		Version:              version,
 		GitCommit:            gitcommit,
 		PgURL:                flags.pgURL,
+		HA:                   flags.ha,
 		BackupStorageBackend: backupStorageBackend,
 		BackupRegion:         flags.backupRegion,
 		BackupBucket:         flags.backupBucket,
 		BackupCredentialFile: flags.backupCredential,
 		LastActiveTs:         time.Now().Unix(),
+		KMS: kms.Config{
+			Provider:       kms.Provider(flags.kmsProvider),
+			KeyFile:        flags.kmsKeyFile,
+			Address:        flags.kmsAddress,
+			MountPath:      flags.kmsMountPath,
+			KeyName:        flags.kmsKeyName,
+			Region:         flags.kmsRegion,
+			CredentialFile: flags.kmsCredential,
+		},
 	}
 }
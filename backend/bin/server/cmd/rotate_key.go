@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+	"github.com/bytebase/bytebase/backend/server"
+)
+
+// rotateKeyFlags are the flags of the key provider the data keys are wrapped by before switching to the one specified by the --kms-* flags.
+var rotateKeyFlags struct {
+	previousKMSProvider   string
+	previousKMSKeyFile    string
+	previousKMSAddress    string
+	previousKMSMountPath  string
+	previousKMSKeyName    string
+	previousKMSRegion     string
+	previousKMSCredential string
+}
+
+func init() {
+	rotateKeyCmd.Flags().StringVar(&rotateKeyFlags.previousKMSProvider, "previous-kms-provider", "", "key provider the data keys are wrapped by, to switch to the key provider specified by the --kms-* flags. The data keys are re-wrapped before rotating.")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyFlags.previousKMSKeyFile, "previous-kms-key-file", "", "key file of the previous LOCAL key provider.")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyFlags.previousKMSAddress, "previous-kms-address", "", "address of the previous VAULT key provider, or the custom endpoint of the previous AWS_KMS and GCP_KMS key provider.")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyFlags.previousKMSMountPath, "previous-kms-mount-path", "", "mount path of the Vault Transit secrets engine of the previous VAULT key provider.")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyFlags.previousKMSKeyName, "previous-kms-key-name", "", "name of the key of the previous key provider.")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyFlags.previousKMSRegion, "previous-kms-region", "", "region of the previous AWS_KMS key provider.")
+	rotateKeyCmd.Flags().StringVar(&rotateKeyFlags.previousKMSCredential, "previous-kms-credential", "", "credentials file of the previous AWS_KMS and GCP_KMS key provider.")
+	rootCmd.AddCommand(rotateKeyCmd)
+}
+
+var rotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "Re-encrypt the secrets in the metadata database with a new data key",
+	Long: `Re-encrypt the data source credentials, VCS OAuth tokens and other secrets stored in the metadata database
+with a new data key wrapped by the key provider specified by the --kms-* flags. The secrets stored before the
+key provider was configured are encrypted as well.
+
+The Bytebase servers sharing the metadata database keep running during the rotation. They switch to the new data key
+within a minute, and re-encrypt the secrets written with the old data key in the meantime in the background.
+
+To switch the key provider, specify the key provider the data keys are wrapped by with the --previous-kms-* flags,
+then restart the servers with the new --kms-* flags.`,
+	Run: func(_ *cobra.Command, _ []string) {
+		if err := checkDataDir(); err != nil {
+			slog.Error(err.Error())
+			return
+		}
+		if err := checkKMSFlags(); err != nil {
+			slog.Error("invalid flags for key provider", log.BBError(err))
+			return
+		}
+		previousKMS := &kms.Config{
+			Provider:       kms.Provider(rotateKeyFlags.previousKMSProvider),
+			KeyFile:        rotateKeyFlags.previousKMSKeyFile,
+			Address:        rotateKeyFlags.previousKMSAddress,
+			MountPath:      rotateKeyFlags.previousKMSMountPath,
+			KeyName:        rotateKeyFlags.previousKMSKeyName,
+			Region:         rotateKeyFlags.previousKMSRegion,
+			CredentialFile: rotateKeyFlags.previousKMSCredential,
+		}
+		count, err := server.RotateDataKey(context.Background(), activeProfile(flags.dataDir), previousKMS)
+		if err != nil {
+			slog.Error("failed to rotate data key", log.BBError(err))
+			return
+		}
+		fmt.Printf("Re-encrypted %d secrets with the new data key.\n", count)
+	},
+}
@@ -8,4 +8,6 @@ const (
 	PrincipalIDContextKey ContextKey = iota
 	// RoleContextKey is the key name used to store principal role in the context.
 	RoleContextKey
+	// AccessTokenContextKey is the key name used to store the personal access token authenticating the request in the context.
+	AccessTokenContextKey
 )
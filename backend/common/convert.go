@@ -23,11 +23,19 @@ var RiskFactors = []cel.EnvOption{
 	cel.Variable("database_name", cel.StringType),
 	cel.Variable("db_engine", cel.StringType),
 	cel.Variable("sql_type", cel.StringType),
+	// PostgreSQL lock impact, the statement type taking the lock such as ALTER_TABLE, and the lock level such as ACCESS_EXCLUSIVE.
+	cel.Variable("lock_statement_type", cel.StringType),
+	cel.Variable("lock_level", cel.StringType),
+
+	// bool factors
+	cel.Variable("table_rewrite", cel.BoolType),
+	cel.Variable("validation_scan", cel.BoolType),
 
 	// number factors
 	cel.Variable("affected_rows", cel.IntType),
 	cel.Variable("expiration_days", cel.IntType),
 	cel.Variable("export_rows", cel.IntType),
+	cel.Variable("estimated_duration_seconds", cel.IntType),
 }
 
 // ApprovalFactors are the variables when finding the approval template.
@@ -62,6 +70,23 @@ var MaskingRulePolicyCELAttributes = []cel.EnvOption{
 	cel.ParserExpressionSizeLimit(celLimit),
 }
 
+// SQLReviewCustomRuleCELAttributes are the variables when evaluating the custom SQL review rules.
+// The table variables are the state of the statement table after walking through the statements.
+var SQLReviewCustomRuleCELAttributes = []cel.EnvOption{
+	cel.Variable("environment_id", cel.StringType),
+	cel.Variable("project_id", cel.StringType),
+	cel.Variable("statement.type", cel.StringType),
+	cel.Variable("statement.text", cel.StringType),
+	cel.Variable("statement.schema", cel.StringType),
+	cel.Variable("statement.table", cel.StringType),
+	cel.Variable("statement.columns", cel.ListType(cel.StringType)),
+	cel.Variable("statement.indexes", cel.ListType(cel.StringType)),
+	cel.Variable("table.exists", cel.BoolType),
+	cel.Variable("table.columns", cel.ListType(cel.StringType)),
+	cel.Variable("table.indexes", cel.ListType(cel.StringType)),
+	cel.ParserExpressionSizeLimit(celLimit),
+}
+
 // MaskingExceptionPolicyCELAttributes are the variables when evaluating masking exception.
 var MaskingExceptionPolicyCELAttributes = []cel.EnvOption{
 	cel.Variable("resource.instance_id", cel.StringType),
@@ -48,6 +48,12 @@ const (
 	TaskTypeDropPrimaryKey Code = 408
 	TaskTypeDropForeignKey Code = 409
 	TaskTypeDropCheck      Code = 410
+
+	// 501 task lock impact.
+	TaskLockTableRewrite   Code = 501
+	TaskLockValidationScan Code = 502
+	TaskLockIndexBuild     Code = 503
+	TaskLockAnalysisFailed Code = 504
 )
 
 // Int returns the int type of code.
@@ -17,6 +17,7 @@ const (
 	DatabaseIDPrefix             = "databases/"
 	InstanceRolePrefix           = "roles/"
 	UserNamePrefix               = "users/"
+	GroupNamePrefix              = "groups/"
 	IdentityProviderNamePrefix   = "idps/"
 	SettingNamePrefix            = "settings/"
 	BackupPrefix                 = "backups/"
@@ -34,6 +35,7 @@ const (
 	SecretNamePrefix             = "secrets/"
 	WebhookIDPrefix              = "webhooks/"
 	SheetIDPrefix                = "sheets/"
+	SheetRevisionPrefix          = "revisions/"
 	DatabaseGroupNamePrefix      = "databaseGroups/"
 	SchemaGroupNamePrefix        = "schemaGroups/"
 	ChangeHistoryPrefix          = "changeHistories/"
@@ -44,6 +46,10 @@ const (
 	SchemaDesignPrefix           = "schemaDesigns/"
 	DeploymentConfigPrefix       = "deploymentConfigs/"
 	ChangelistsPrefix            = "changelists/"
+	AccessTokenPrefix            = "accessTokens/"
+	WebhookDeliveryPrefix        = "deliveries/"
+	SessionNamePrefix            = "sessions/"
+	ExportJobNamePrefix          = "exportJobs/"
 
 	BackupSettingSuffix = "/backupSetting"
 	SchemaSuffix        = "/schema"
@@ -87,6 +93,23 @@ func GetProjectIDWebhookID(name string) (string, string, error) {
 	return tokens[0], tokens[1], nil
 }
 
+// GetProjectIDWebhookIDDeliveryID returns the project ID, webhook ID and delivery ID from a resource name.
+func GetProjectIDWebhookIDDeliveryID(name string) (string, int, int, error) {
+	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, WebhookIDPrefix, WebhookDeliveryPrefix)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	webhookID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return "", 0, 0, errors.Errorf("invalid webhook ID %q", tokens[1])
+	}
+	deliveryID, err := strconv.Atoi(tokens[2])
+	if err != nil {
+		return "", 0, 0, errors.Errorf("invalid webhook delivery ID %q", tokens[2])
+	}
+	return tokens[0], webhookID, deliveryID, nil
+}
+
 func GetProjectIDDeploymentConfigID(name string) (string, string, error) {
 	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, DeploymentConfigPrefix)
 	if err != nil {
@@ -207,6 +230,23 @@ func GetUserID(name string) (int, error) {
 	return GetUIDFromName(name, UserNamePrefix)
 }
 
+// GetUserIDAccessTokenID returns the user ID and access token ID from a resource name.
+func GetUserIDAccessTokenID(name string) (int, int, error) {
+	tokens, err := GetNameParentTokens(name, UserNamePrefix, AccessTokenPrefix)
+	if err != nil {
+		return 0, 0, err
+	}
+	userID, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid user ID %q", tokens[0])
+	}
+	accessTokenID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid access token ID %q", tokens[1])
+	}
+	return userID, accessTokenID, nil
+}
+
 // GetUserEmail returns the user email from a resource name.
 func GetUserEmail(name string) (string, error) {
 	tokens, err := GetNameParentTokens(name, UserNamePrefix)
@@ -216,6 +256,15 @@ func GetUserEmail(name string) (string, error) {
 	return tokens[0], nil
 }
 
+// GetGroupEmail returns the group email from a resource name.
+func GetGroupEmail(name string) (string, error) {
+	tokens, err := GetNameParentTokens(name, GroupNamePrefix)
+	if err != nil {
+		return "", err
+	}
+	return tokens[0], nil
+}
+
 // GetSettingName returns the setting name from a resource name.
 func GetSettingName(name string) (string, error) {
 	token, err := GetNameParentTokens(name, SettingNamePrefix)
@@ -234,6 +283,24 @@ func GetIdentityProviderID(name string) (string, error) {
 	return tokens[0], nil
 }
 
+// GetSessionID returns the SQL Editor session ID from a resource name.
+func GetSessionID(name string) (string, error) {
+	tokens, err := GetNameParentTokens(name, SessionNamePrefix)
+	if err != nil {
+		return "", err
+	}
+	return tokens[0], nil
+}
+
+// GetExportJobID returns the export job ID from a resource name.
+func GetExportJobID(name string) (string, error) {
+	tokens, err := GetNameParentTokens(name, ExportJobNamePrefix)
+	if err != nil {
+		return "", err
+	}
+	return tokens[0], nil
+}
+
 // GetBookmarkID returns the bookmark ID from a resource name.
 func GetBookmarkID(name string) (int, error) {
 	return GetUIDFromName(name, BookmarkPrefix)
@@ -283,6 +350,32 @@ func GetTaskID(name string) (int, error) {
 	return taskID, nil
 }
 
+// GetProjectIDIssueID returns the project ID and issue ID from a resource name.
+func GetProjectIDIssueID(name string) (string, int, error) {
+	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, IssuePrefix)
+	if err != nil {
+		return "", 0, err
+	}
+	issueID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return "", 0, errors.Errorf("invalid issue ID %q", tokens[1])
+	}
+	return tokens[0], issueID, nil
+}
+
+// GetProjectIDPlanID returns the project ID and plan ID from a resource name.
+func GetProjectIDPlanID(name string) (string, int64, error) {
+	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, PlanPrefix)
+	if err != nil {
+		return "", 0, err
+	}
+	planID, err := strconv.ParseInt(tokens[1], 10, 64)
+	if err != nil {
+		return "", 0, errors.Errorf("invalid plan ID %q", tokens[1])
+	}
+	return tokens[0], planID, nil
+}
+
 // GetPlanID returns the plan ID from a resource name.
 func GetPlanID(name string) (int64, error) {
 	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, PlanPrefix)
@@ -453,6 +546,23 @@ func GetProjectResourceIDSheetUID(name string) (string, int, error) {
 	return tokens[0], sheetUID, nil
 }
 
+// GetProjectResourceIDSheetUIDRevision returns the project ID, sheet UID and revision from a sheet revision resource name.
+func GetProjectResourceIDSheetUIDRevision(name string) (string, int, int, error) {
+	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, SheetIDPrefix, SheetRevisionPrefix)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	sheetUID, err := strconv.Atoi(tokens[1])
+	if err != nil {
+		return "", 0, 0, errors.Wrapf(err, "failed to convert sheet uid %q to int", tokens[1])
+	}
+	revision, err := strconv.Atoi(tokens[2])
+	if err != nil {
+		return "", 0, 0, errors.Wrapf(err, "failed to convert sheet revision %q to int", tokens[2])
+	}
+	return tokens[0], sheetUID, revision, nil
+}
+
 // GetProjectResourceIDAndSchemaDesignSheetID returns the project ID and schema design sheet ID from a resource name.
 func GetProjectResourceIDAndSchemaDesignSheetID(name string) (string, string, error) {
 	tokens, err := GetNameParentTokens(name, ProjectNamePrefix, SchemaDesignPrefix)
@@ -502,6 +612,10 @@ func FormatUserEmail(email string) string {
 	return fmt.Sprintf("%s%s", UserNamePrefix, email)
 }
 
+func FormatGroupEmail(email string) string {
+	return fmt.Sprintf("%s%s", GroupNamePrefix, email)
+}
+
 func FormatUserUID(uid int) string {
 	return fmt.Sprintf("%s%d", UserNamePrefix, uid)
 }
@@ -37,3 +37,17 @@ func TestGetRiskID(t *testing.T) {
 		a.Equal(test.want, got)
 	}
 }
+
+func TestGetProjectResourceIDSheetUIDRevision(t *testing.T) {
+	a := require.New(t)
+	projectID, sheetUID, revision, err := GetProjectResourceIDSheetUIDRevision("projects/p1/sheets/12/revisions/3")
+	a.NoError(err)
+	a.Equal("p1", projectID)
+	a.Equal(12, sheetUID)
+	a.Equal(3, revision)
+
+	_, _, _, err = GetProjectResourceIDSheetUIDRevision("projects/p1/sheets/12")
+	a.Error(err)
+	_, _, _, err = GetProjectResourceIDSheetUIDRevision("projects/p1/sheets/12/revisions/latest")
+	a.Error(err)
+}
@@ -9,7 +9,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/gosimple/slug"
 	"github.com/nyaruka/phonenumbers"
@@ -19,6 +18,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/state"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/webhook"
 	"github.com/bytebase/bytebase/backend/store"
@@ -29,7 +29,8 @@ import (
 
 // Manager is the activity manager.
 type Manager struct {
-	store *store.Store
+	store    *store.Store
+	stateCfg *state.State
 }
 
 // Metadata is the activity metadata.
@@ -38,9 +39,10 @@ type Metadata struct {
 }
 
 // NewManager creates an activity manager.
-func NewManager(store *store.Store) *Manager {
+func NewManager(store *store.Store, stateCfg *state.State) *Manager {
 	return &Manager{
-		store: store,
+		store:    store,
+		stateCfg: stateCfg,
 	}
 }
 
@@ -124,7 +126,7 @@ func (m *Manager) BatchCreateActivitiesForRunTasks(ctx context.Context, tasks []
 		CreatorEmail: user.Email,
 	}
 	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
+	go m.postWebhookList(ctx, &webhookCtx, webhookList)
 
 	return nil
 }
@@ -208,7 +210,7 @@ func (m *Manager) BatchCreateActivitiesForSkipTasks(ctx context.Context, tasks [
 		CreatorEmail: user.Email,
 	}
 	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
+	go m.postWebhookList(ctx, &webhookCtx, webhookList)
 
 	return nil
 }
@@ -293,7 +295,7 @@ func (m *Manager) BatchCreateActivitiesForCancelTaskRuns(ctx context.Context, ta
 		CreatorEmail: user.Email,
 	}
 	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
+	go m.postWebhookList(ctx, &webhookCtx, webhookList)
 
 	return nil
 }
@@ -377,7 +379,7 @@ func (m *Manager) BatchCreateTaskStatusUpdateApprovalActivity(ctx context.Contex
 		CreatorEmail: user.Email,
 	}
 	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, &webhookCtx, webhookList)
+	go m.postWebhookList(ctx, &webhookCtx, webhookList)
 
 	return nil
 }
@@ -429,33 +431,11 @@ func (m *Manager) CreateActivity(ctx context.Context, create *store.ActivityMess
 		return activity, nil
 	}
 	// Call external webhook endpoint in Go routine to avoid blocking web serving thread.
-	go postWebhookList(ctx, webhookCtx, webhookList)
+	go m.postWebhookList(ctx, webhookCtx, webhookList)
 
 	return activity, nil
 }
 
-func postWebhookList(ctx context.Context, webhookCtx *webhook.Context, webhookList []*store.ProjectWebhookMessage) {
-	for _, hook := range webhookList {
-		webhookCtx := *webhookCtx
-		webhookCtx.URL = hook.URL
-		webhookCtx.CreatedTs = time.Now().Unix()
-		go func(webhookCtx *webhook.Context, hook *store.ProjectWebhookMessage) {
-			if err := common.Retry(ctx, func() error {
-				return webhook.Post(hook.Type, *webhookCtx)
-			}); err != nil {
-				// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning
-				slog.Warn("Failed to post webhook event on activity",
-					slog.String("webhook type", hook.Type),
-					slog.String("webhook name", hook.Title),
-					slog.String("activity type", webhookCtx.ActivityType),
-					slog.String("title", webhookCtx.Title),
-					log.BBError(err))
-				return
-			}
-		}(&webhookCtx, hook)
-	}
-}
-
 func (m *Manager) getWebhookContext(ctx context.Context, activity *store.ActivityMessage, meta *Metadata, updater *store.UserMessage) (*webhook.Context, error) {
 	var webhookCtx webhook.Context
 	var webhookTaskResult *webhook.TaskResult
@@ -798,6 +778,8 @@ func (m *Manager) getWebhookContext(ctx context.Context, activity *store.Activit
 		case *storepb.ApprovalNode_Role:
 			role := api.Role(strings.TrimPrefix(val.Role, "roles/"))
 			usersGetter = getUsersFromProjectRole(m.store, role, meta.Issue.Project.ResourceID)
+		case *storepb.ApprovalNode_Group:
+			usersGetter = getUsersFromGroup(m.store, strings.TrimPrefix(val.Group, common.GroupNamePrefix))
 		case *storepb.ApprovalNode_ExternalNodeId:
 			usersGetter = func(ctx context.Context) ([]*store.UserMessage, error) {
 				return nil, nil
@@ -933,8 +915,36 @@ func getUsersFromProjectRole(s *store.Store, role api.Role, projectID string) fu
 		var users []*store.UserMessage
 		for _, binding := range projectIAM.Bindings {
 			if binding.Role == role {
-				users = append(users, binding.Members...)
+				bindingUsers, err := s.GetPolicyBindingUsers(ctx, binding)
+				if err != nil {
+					return nil, err
+				}
+				users = append(users, bindingUsers...)
+			}
+		}
+		return users, nil
+	}
+}
+
+func getUsersFromGroup(s *store.Store, email string) func(context.Context) ([]*store.UserMessage, error) {
+	return func(ctx context.Context) ([]*store.UserMessage, error) {
+		group, err := s.GetUserGroup(ctx, &store.FindUserGroupMessage{Email: &email})
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			return nil, nil
+		}
+		var users []*store.UserMessage
+		for _, memberUID := range group.MemberUIDs {
+			user, err := s.GetUserByID(ctx, memberUID)
+			if err != nil {
+				return nil, err
+			}
+			if user == nil || user.MemberDeleted {
+				continue
 			}
+			users = append(users, user)
 		}
 		return users, nil
 	}
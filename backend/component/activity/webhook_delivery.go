@@ -0,0 +1,174 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/webhook"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	// WebhookDeliveryMaxAttempts is the number of failed attempts after which a delivery is dead-lettered.
+	WebhookDeliveryMaxAttempts = 8
+	// webhookDeliveryInitialBackoff is the delay before the first retry, doubled for each following retry.
+	webhookDeliveryInitialBackoff = 30 * time.Second
+	webhookDeliveryMaxBackoff     = time.Hour
+)
+
+// ErrWebhookDeliveryInProgress is returned if the webhook delivery is being delivered by another replica.
+var ErrWebhookDeliveryInProgress = errors.New("webhook delivery is in progress")
+
+// getWebhookDeliveryBackoff returns the delay before retrying a delivery which has failed attemptCount times.
+func getWebhookDeliveryBackoff(attemptCount int) time.Duration {
+	backoff := webhookDeliveryInitialBackoff
+	for i := 1; i < attemptCount; i++ {
+		backoff *= 2
+		if backoff >= webhookDeliveryMaxBackoff {
+			return webhookDeliveryMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// postWebhookList records a delivery for each webhook and makes the first attempt.
+// Failed deliveries are retried by the webhook runner.
+func (m *Manager) postWebhookList(ctx context.Context, webhookCtx *webhook.Context, webhookList []*store.ProjectWebhookMessage) {
+	// The deliveries must not be canceled along with the request creating the activity.
+	ctx = context.WithoutCancel(ctx)
+	for _, hook := range webhookList {
+		webhookCtx := *webhookCtx
+		webhookCtx.URL = ""
+		webhookCtx.SigningSecret = ""
+		webhookCtx.CreatedTs = time.Now().Unix()
+		payload, err := json.Marshal(webhookCtx)
+		if err != nil {
+			slog.Error("Failed to marshal webhook context", slog.String("webhook name", hook.Title), log.BBError(err))
+			continue
+		}
+		delivery, err := m.store.CreateWebhookDelivery(ctx, &store.WebhookDeliveryMessage{
+			ProjectWebhookUID: hook.ID,
+			ActivityType:      webhookCtx.ActivityType,
+			Payload:           string(payload),
+			// Let the runner pick up the delivery in case the first attempt is interrupted.
+			NextAttemptTs: time.Now().Add(webhookDeliveryInitialBackoff).Unix(),
+		})
+		if err != nil {
+			slog.Error("Failed to create webhook delivery", slog.String("webhook name", hook.Title), log.BBError(err))
+			continue
+		}
+		go func(delivery *store.WebhookDeliveryMessage) {
+			// The runner may have picked up the delivery if the first attempt is delayed.
+			if _, err := m.deliverWebhookWithLease(ctx, delivery.UID, false /* redeliver */, func(delivery *store.WebhookDeliveryMessage) bool {
+				return delivery.Status == store.WebhookDeliveryPending && delivery.AttemptCount == 0
+			}); err != nil {
+				slog.Error("Failed to deliver webhook", slog.Int("delivery", delivery.UID), log.BBError(err))
+			}
+		}(delivery)
+	}
+}
+
+// RetryWebhookDelivery makes an attempt of the pending webhook delivery if it is due and records the result.
+// A failed delivery is scheduled for a retry with exponential backoff, or dead-lettered after the maximum attempts.
+// It returns nil if the delivery is not due, or is being delivered by another replica.
+func (m *Manager) RetryWebhookDelivery(ctx context.Context, deliveryUID int) (*store.WebhookDeliveryMessage, error) {
+	delivery, err := m.deliverWebhookWithLease(ctx, deliveryUID, false /* redeliver */, func(delivery *store.WebhookDeliveryMessage) bool {
+		return delivery.Status == store.WebhookDeliveryPending && delivery.NextAttemptTs <= time.Now().Unix()
+	})
+	if errors.Is(err, ErrWebhookDeliveryInProgress) {
+		return nil, nil
+	}
+	return delivery, err
+}
+
+// RedeliverWebhook makes an attempt of the webhook delivery regardless of its status, restarting the retries from scratch.
+// It returns ErrWebhookDeliveryInProgress if the delivery is being delivered by another replica.
+func (m *Manager) RedeliverWebhook(ctx context.Context, deliveryUID int) (*store.WebhookDeliveryMessage, error) {
+	return m.deliverWebhookWithLease(ctx, deliveryUID, true /* redeliver */, func(*store.WebhookDeliveryMessage) bool {
+		return true
+	})
+}
+
+// deliverWebhookWithLease makes an attempt of the webhook delivery holding its lease, so that the replicas never deliver it concurrently.
+// The delivery is read after acquiring the lease, and is skipped if it no longer meets the condition.
+func (m *Manager) deliverWebhookWithLease(ctx context.Context, deliveryUID int, redeliver bool, condition func(*store.WebhookDeliveryMessage) bool) (*store.WebhookDeliveryMessage, error) {
+	resource := store.WebhookDeliveryLeaseResource(deliveryUID)
+	acquired, err := m.stateCfg.TryAcquireLease(ctx, resource, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to acquire lease of webhook delivery %d", deliveryUID)
+	}
+	if !acquired {
+		return nil, ErrWebhookDeliveryInProgress
+	}
+	defer m.stateCfg.ReleaseLease(ctx, resource)
+
+	delivery, err := m.store.GetWebhookDelivery(ctx, &store.FindWebhookDeliveryMessage{UID: &deliveryUID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get webhook delivery %d", deliveryUID)
+	}
+	if delivery == nil {
+		return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("webhook delivery %d not found", deliveryUID)}
+	}
+	if !condition(delivery) {
+		return nil, nil
+	}
+	if redeliver {
+		delivery.AttemptCount = 0
+	}
+	hook, err := m.store.GetProjectWebhookV2(ctx, &store.FindProjectWebhookMessage{ID: &delivery.ProjectWebhookUID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get project webhook %d", delivery.ProjectWebhookUID)
+	}
+	if hook == nil {
+		return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("project webhook %d not found", delivery.ProjectWebhookUID)}
+	}
+	return m.attemptWebhookDelivery(ctx, hook, delivery)
+}
+
+func (m *Manager) attemptWebhookDelivery(ctx context.Context, hook *store.ProjectWebhookMessage, delivery *store.WebhookDeliveryMessage) (*store.WebhookDeliveryMessage, error) {
+	var webhookCtx webhook.Context
+	if err := json.Unmarshal([]byte(delivery.Payload), &webhookCtx); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal payload of webhook delivery %d", delivery.UID)
+	}
+	webhookCtx.URL = hook.URL
+	webhookCtx.SigningSecret = hook.SigningSecret
+
+	now := time.Now()
+	attemptCount := delivery.AttemptCount + 1
+	lastAttemptTs := now.Unix()
+	deliveryStatus := store.WebhookDeliverySucceeded
+	var nextAttemptTs int64
+	var lastError string
+	if err := webhook.Post(hook.Type, webhookCtx); err != nil {
+		// The external webhook endpoint might be invalid which is out of our code control, so we just emit a warning
+		slog.Warn("Failed to post webhook event on activity",
+			slog.String("webhook type", hook.Type),
+			slog.String("webhook name", hook.Title),
+			slog.String("activity type", webhookCtx.ActivityType),
+			slog.String("title", webhookCtx.Title),
+			slog.Int("attempt", attemptCount),
+			log.BBError(err))
+		lastError = err.Error()
+		if attemptCount >= WebhookDeliveryMaxAttempts {
+			deliveryStatus = store.WebhookDeliveryDeadLetter
+		} else {
+			deliveryStatus = store.WebhookDeliveryPending
+			nextAttemptTs = now.Add(getWebhookDeliveryBackoff(attemptCount)).Unix()
+		}
+	}
+
+	return m.store.UpdateWebhookDelivery(ctx, &store.UpdateWebhookDeliveryMessage{
+		UID:           delivery.UID,
+		Status:        &deliveryStatus,
+		AttemptCount:  &attemptCount,
+		NextAttemptTs: &nextAttemptTs,
+		LastAttemptTs: &lastAttemptTs,
+		LastError:     &lastError,
+	})
+}
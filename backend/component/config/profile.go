@@ -6,6 +6,7 @@ import (
 
 	"github.com/bytebase/bytebase/backend/common"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/kms"
 )
 
 // Profile is the configuration to start main server.
@@ -50,12 +51,18 @@ type Profile struct {
 	BackupBucket         string
 	BackupCredentialFile string
 
+	// KMS is the configuration of the key provider wrapping the data keys, which encrypt the secrets stored in the metadata database.
+	// The secrets are not encrypted if the provider is empty.
+	KMS kms.Config
+
 	// Version is the bytebase's server version
 	Version string
 	// Git commit hash of the build
 	GitCommit string
 	// PgURL is the optional external PostgreSQL instance connection url
 	PgURL string
+	// HA is whether to run in high-availability mode, in which multiple replicas share the same external PostgreSQL instance.
+	HA bool
 	// MetricConnectionKey is the connection key for metric.
 	MetricConnectionKey string
 	// EnableMetric will enable the metric collector.
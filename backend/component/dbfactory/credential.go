@@ -0,0 +1,139 @@
+package dbfactory
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/secret"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// staticCredentialTTL is how long the credential without lease is cached, after which it's fetched again to pick up the rotated one.
+const staticCredentialTTL = 5 * time.Minute
+
+// credentialCache caches the credentials fetched from the external secret managers.
+// The entries are keyed by the external secret config, so that updating the config of the data source fetches the credential again.
+type credentialCache struct {
+	cache *expiringCache[*secret.Credential]
+}
+
+// credentialSource fetches the credential by the secret manager of the external secret.
+type credentialSource struct {
+	manager    secret.Manager
+	credential *secret.Credential
+	// expireTime is the time when the lease expires. It's zero for the credential without lease.
+	expireTime time.Time
+}
+
+func newCredentialCache() *credentialCache {
+	return &credentialCache{cache: newExpiringCache[*secret.Credential]()}
+}
+
+func getCredentialCacheKey(externalSecret *storepb.DataSourceExternalSecret) (string, error) {
+	protoBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(externalSecret)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal external secret")
+	}
+	return string(protoBytes), nil
+}
+
+// invalidate drops the cached credential, e.g. the credential is rejected by the database after rotation.
+func (c *credentialCache) invalidate(ctx context.Context, externalSecret *storepb.DataSourceExternalSecret) {
+	key, err := getCredentialCacheKey(externalSecret)
+	if err != nil {
+		return
+	}
+	c.cache.invalidate(ctx, key)
+}
+
+// getCredential returns the cached credential of the external secret.
+func (c *credentialCache) getCredential(ctx context.Context, externalSecret *storepb.DataSourceExternalSecret, secretKey string) (*secret.Credential, error) {
+	key, err := getCredentialCacheKey(externalSecret)
+	if err != nil {
+		return nil, err
+	}
+	return c.cache.get(ctx, key, func(ctx context.Context) (expiringSource[*secret.Credential], error) {
+		token, err := common.Unobfuscate(externalSecret.ObfuscatedToken, secretKey)
+		if err != nil {
+			return nil, err
+		}
+		appRoleSecretID, err := common.Unobfuscate(externalSecret.ObfuscatedAppRoleSecretId, secretKey)
+		if err != nil {
+			return nil, err
+		}
+		manager, err := secret.New(ctx, &secret.Config{
+			DataSourceExternalSecret: externalSecret,
+			Token:                    token,
+			AppRoleSecretID:          appRoleSecretID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &credentialSource{manager: manager}, nil
+	})
+}
+
+// fetch renews the lease of the dynamic credential when two thirds of it have passed, and fetches the credential again if the renewal fails.
+func (s *credentialSource) fetch(ctx context.Context) (*secret.Credential, time.Time, error) {
+	now := time.Now()
+	if s.credential != nil && s.credential.Renewable && now.Before(s.expireTime) {
+		leaseDuration, err := s.manager.RenewLease(ctx, s.credential.LeaseID)
+		if err == nil {
+			s.expireTime = now.Add(leaseDuration)
+			return s.credential, now.Add(leaseDuration * 2 / 3), nil
+		}
+		slog.Warn("failed to renew the lease of the data source credential", slog.String("lease", s.credential.LeaseID), log.BBError(err))
+	}
+
+	credential, err := s.manager.GetCredential(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	s.credential = credential
+	if credential.LeaseID != "" && credential.LeaseDuration > 0 {
+		s.expireTime = now.Add(credential.LeaseDuration)
+		return credential, now.Add(credential.LeaseDuration * 2 / 3), nil
+	}
+	s.expireTime = time.Time{}
+	return credential, now.Add(staticCredentialTTL), nil
+}
+
+func (s *credentialSource) close(ctx context.Context) {
+	if err := s.manager.Close(ctx); err != nil {
+		slog.Warn("failed to close the secret manager of the data source credential", log.BBError(err))
+	}
+}
+
+// isAuthenticationError returns whether the database rejects the credential,
+// which means the cached credential may have been rotated or revoked.
+func isAuthenticationError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		// ER_ACCESS_DENIED_ERROR.
+		return mysqlErr.Number == 1045
+	}
+	message := strings.ToLower(err.Error())
+	for _, s := range []string{
+		// PostgreSQL invalid_password and invalid_authorization_specification.
+		"sqlstate 28p01",
+		"sqlstate 28000",
+		"password authentication failed",
+		"authentication failed",
+		"access denied",
+		"login failed",
+		"invalid username/password",
+	} {
+		if strings.Contains(message, s) {
+			return true
+		}
+	}
+	return false
+}
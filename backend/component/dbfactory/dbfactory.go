@@ -4,9 +4,12 @@ package dbfactory
 import (
 	"context"
 
+	"github.com/pkg/errors"
+
 	"github.com/bytebase/bytebase/backend/common"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/iamauth"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 )
@@ -18,6 +21,8 @@ type DBFactory struct {
 	mongoBinDir string
 	dataDir     string
 	secret      string
+	credentials *credentialCache
+	iamTokens   *iamTokenCache
 }
 
 // New creates a new database driver factory.
@@ -28,6 +33,8 @@ func New(mysqlBinDir, mongoBinDir, pgBinDir, dataDir, secret string) *DBFactory
 		pgBinDir:    pgBinDir,
 		dataDir:     dataDir,
 		secret:      secret,
+		credentials: newCredentialCache(),
+		iamTokens:   newIAMTokenCache(),
 	}
 }
 
@@ -122,10 +129,45 @@ func (d *DBFactory) GetDataSourceDriver(ctx context.Context, engine db.Type, dat
 	if datashare {
 		connectionDatabase = dataSource.Database
 	}
+	username := dataSource.Username
 	password, err := common.Unobfuscate(dataSource.ObfuscatedPassword, d.secret)
 	if err != nil {
 		return nil, err
 	}
+	if dataSource.ExternalSecret != nil {
+		credential, err := d.credentials.getCredential(ctx, dataSource.ExternalSecret, d.secret)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the credential of data source %q from %s", dataSource.ID, dataSource.ExternalSecret.SecretType)
+		}
+		if credential.Username != "" {
+			username = credential.Username
+		}
+		password = credential.Password
+	}
+	var iamConfig *iamauth.Config
+	var refreshPassword func(context.Context) (string, error)
+	if dataSource.IAMAuthentication != nil {
+		switch engine {
+		case db.MySQL, db.MariaDB, db.Postgres:
+		default:
+			return nil, errors.Errorf("IAM authentication is not supported for %s", engine)
+		}
+		iamConfig = &iamauth.Config{
+			DataSourceIAMAuthentication: dataSource.IAMAuthentication,
+			Host:                        dataSource.Host,
+			Port:                        dataSource.Port,
+			Username:                    username,
+		}
+		// The token is short-lived, so the drivers get the token before opening each connection of the pool.
+		refreshPassword = func(ctx context.Context) (string, error) {
+			return d.iamTokens.getToken(ctx, iamConfig)
+		}
+		token, err := refreshPassword(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate the %s IAM authentication token of data source %q", dataSource.IAMAuthentication.Type, dataSource.ID)
+		}
+		password = token
+	}
 	sslCA, err := common.Unobfuscate(dataSource.ObfuscatedSslCa, d.secret)
 	if err != nil {
 		return nil, err
@@ -161,8 +203,9 @@ func (d *DBFactory) GetDataSourceDriver(ctx context.Context, engine db.Type, dat
 			BinlogDir: common.GetBinlogAbsDir(d.dataDir, instanceUID),
 		},
 		db.ConnectionConfig{
-			Username: dataSource.Username,
-			Password: password,
+			Username:        username,
+			Password:        password,
+			RefreshPassword: refreshPassword,
 			TLSConfig: db.TLSConfig{
 				SslCA:   sslCA,
 				SslCert: sslCert,
@@ -185,6 +228,16 @@ func (d *DBFactory) GetDataSourceDriver(ctx context.Context, engine db.Type, dat
 		},
 	)
 	if err != nil {
+		// The cached credential may have been rotated or revoked, so fetch it again for the next connection.
+		// The other failures, e.g. the database is unreachable, keep the cached credential.
+		if isAuthenticationError(err) {
+			if dataSource.ExternalSecret != nil {
+				d.credentials.invalidate(ctx, dataSource.ExternalSecret)
+			}
+			if iamConfig != nil {
+				d.iamTokens.invalidate(ctx, iamConfig)
+			}
+		}
 		return nil, err
 	}
 
@@ -0,0 +1,112 @@
+package dbfactory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleTimeout is how long the entry is kept without being used, e.g. after the config of the data source is updated.
+const idleTimeout = 1 * time.Hour
+
+// expiringSource fetches the short-lived value of an expiringCache entry.
+// It's created once for the entry and kept until the entry is dropped, so that it can reuse its state, e.g. renew the lease.
+type expiringSource[V any] interface {
+	// fetch returns the value and the time after which it's fetched again.
+	fetch(ctx context.Context) (V, time.Time, error)
+	// close releases the source of the dropped entry, e.g. revokes the Vault token.
+	close(ctx context.Context)
+}
+
+// expiringCache caches the short-lived values, such as the credentials fetched from the external secret managers and the IAM authentication tokens.
+// The connections of the pools share the value until it's refreshed, and the entries unused for idleTimeout are dropped.
+type expiringCache[V any] struct {
+	sync.Mutex
+	entries map[string]*expiringEntry[V]
+}
+
+type expiringEntry[V any] struct {
+	// mu serializes fetching the value of the entry without blocking the other entries.
+	mu     sync.Mutex
+	source expiringSource[V]
+	value  V
+	// refreshTime is the time after which the value is fetched again. It's zero if the value hasn't been fetched.
+	refreshTime time.Time
+	// lastUsedTime is protected by the cache mutex.
+	lastUsedTime time.Time
+}
+
+func newExpiringCache[V any]() *expiringCache[V] {
+	return &expiringCache[V]{entries: make(map[string]*expiringEntry[V])}
+}
+
+// getEntry returns the entry of the key, and evicts the idle entries.
+func (c *expiringCache[V]) getEntry(ctx context.Context, key string) *expiringEntry[V] {
+	c.Lock()
+	now := time.Now()
+	var evicted []*expiringEntry[V]
+	for k, entry := range c.entries {
+		if k != key && now.Sub(entry.lastUsedTime) > idleTimeout {
+			evicted = append(evicted, entry)
+			delete(c.entries, k)
+		}
+	}
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &expiringEntry[V]{}
+		c.entries[key] = entry
+	}
+	entry.lastUsedTime = now
+	c.Unlock()
+
+	for _, entry := range evicted {
+		entry.close(ctx)
+	}
+	return entry
+}
+
+// get returns the cached value of the key, newSource creates the source of the entry on the first fetch.
+func (c *expiringCache[V]) get(ctx context.Context, key string, newSource func(ctx context.Context) (expiringSource[V], error)) (V, error) {
+	entry := c.getEntry(ctx, key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if !entry.refreshTime.IsZero() && time.Now().Before(entry.refreshTime) {
+		return entry.value, nil
+	}
+	if entry.source == nil {
+		source, err := newSource(ctx)
+		if err != nil {
+			var zero V
+			return zero, err
+		}
+		entry.source = source
+	}
+	value, refreshTime, err := entry.source.fetch(ctx)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	entry.value, entry.refreshTime = value, refreshTime
+	return value, nil
+}
+
+// invalidate drops the cached value of the key, e.g. the value is rejected by the database.
+func (c *expiringCache[V]) invalidate(ctx context.Context, key string) {
+	c.Lock()
+	entry, ok := c.entries[key]
+	delete(c.entries, key)
+	c.Unlock()
+	if ok {
+		entry.close(ctx)
+	}
+}
+
+func (e *expiringEntry[V]) close(ctx context.Context) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.source == nil {
+		return
+	}
+	e.source.close(ctx)
+}
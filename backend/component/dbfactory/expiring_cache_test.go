@@ -0,0 +1,62 @@
+package dbfactory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	fetches int
+	ttl     time.Duration
+	closed  bool
+}
+
+func (s *fakeSource) fetch(context.Context) (int, time.Time, error) {
+	s.fetches++
+	return s.fetches, time.Now().Add(s.ttl), nil
+}
+
+func (s *fakeSource) close(context.Context) {
+	s.closed = true
+}
+
+func TestExpiringCache(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	cache := newExpiringCache[int]()
+	sources := map[string]*fakeSource{}
+	get := func(key string, ttl time.Duration) int {
+		value, err := cache.get(ctx, key, func(context.Context) (expiringSource[int], error) {
+			source := &fakeSource{ttl: ttl}
+			sources[key] = source
+			return source, nil
+		})
+		a.NoError(err)
+		return value
+	}
+
+	// The value is reused until the refresh time, and the source is kept to fetch it again.
+	a.Equal(1, get("a", time.Hour))
+	a.Equal(1, get("a", time.Hour))
+	a.Equal(1, get("b", -time.Second))
+	a.Equal(2, get("b", -time.Second))
+	a.Equal(2, sources["b"].fetches)
+
+	// Invalidating the entry closes its source, and a new source is created on the next get.
+	cache.invalidate(ctx, "a")
+	a.True(sources["a"].closed)
+	a.Equal(1, get("a", time.Hour))
+	a.False(sources["a"].closed)
+
+	// The idle entries are evicted and closed by the access of the other entries.
+	b := sources["b"]
+	cache.Lock()
+	cache.entries["b"].lastUsedTime = time.Now().Add(-idleTimeout - time.Minute)
+	cache.Unlock()
+	a.Equal(1, get("a", time.Hour))
+	a.True(b.closed)
+	a.NotContains(cache.entries, "b")
+}
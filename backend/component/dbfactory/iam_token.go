@@ -0,0 +1,71 @@
+package dbfactory
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bytebase/bytebase/backend/plugin/iamauth"
+)
+
+// iamTokenCache caches the IAM authentication tokens, so that the connections of the pools share the token until it's about to expire.
+// The entries are keyed by the IAM authentication config and the endpoint and user of the data source.
+type iamTokenCache struct {
+	cache *expiringCache[string]
+}
+
+// iamTokenSource generates the tokens by the token generator of the config.
+type iamTokenSource struct {
+	generator iamauth.TokenGenerator
+}
+
+func newIAMTokenCache() *iamTokenCache {
+	return &iamTokenCache{cache: newExpiringCache[string]()}
+}
+
+func getIAMTokenCacheKey(config *iamauth.Config) (string, error) {
+	protoBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(config.DataSourceIAMAuthentication)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal IAM authentication")
+	}
+	return string(protoBytes) + "\x00" + config.Host + "\x00" + config.Port + "\x00" + config.Username, nil
+}
+
+// invalidate drops the cached token, e.g. the token is rejected by the database.
+func (c *iamTokenCache) invalidate(ctx context.Context, config *iamauth.Config) {
+	key, err := getIAMTokenCacheKey(config)
+	if err != nil {
+		return
+	}
+	c.cache.invalidate(ctx, key)
+}
+
+// getToken returns the cached token of the config.
+func (c *iamTokenCache) getToken(ctx context.Context, config *iamauth.Config) (string, error) {
+	key, err := getIAMTokenCacheKey(config)
+	if err != nil {
+		return "", err
+	}
+	return c.cache.get(ctx, key, func(ctx context.Context) (expiringSource[string], error) {
+		generator, err := iamauth.New(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		return &iamTokenSource{generator: generator}, nil
+	})
+}
+
+// fetch generates a new token, which is generated again when two thirds of its lifetime have passed.
+func (s *iamTokenSource) fetch(ctx context.Context) (string, time.Time, error) {
+	now := time.Now()
+	token, err := s.generator.GenerateToken(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token.Value, now.Add(token.ExpireTime.Sub(now) * 2 / 3), nil
+}
+
+// close is a no-op as the token generators don't hold any resources.
+func (*iamTokenSource) close(context.Context) {}
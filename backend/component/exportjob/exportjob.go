@@ -0,0 +1,563 @@
+// Package exportjob manages the export jobs which stream large query results into the export files in the storage in the background,
+// so that an export isn't bound to the request timeout or the server memory.
+package exportjob
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/storagefactory"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	// DownloadPath is the path of the HTTP endpoint downloading the export files, followed by the job ID.
+	DownloadPath = "/export"
+	// DownloadTTL is the duration the export file of a job can be downloaded after the job finishes.
+	// The export file and the job are deleted afterwards.
+	DownloadTTL = 24 * time.Hour
+	// maxRunningJobsPerPrincipal is the maximum number of running jobs of a principal, each running job holds a database connection.
+	maxRunningJobsPerPrincipal = 3
+	// expireInterval is the interval to delete the expired jobs and export files.
+	expireInterval = 5 * time.Minute
+	// progressInterval is the interval for the running job to report its progress to the store,
+	// and to find out whether it's canceled by another replica.
+	progressInterval = 5 * time.Second
+	// interruptedTimeout is the duration without progress reports after which a running job is considered interrupted,
+	// e.g. its replica is stopped.
+	interruptedTimeout = 1 * time.Minute
+	// exportDir is the directory of the export files in the storage.
+	exportDir = "exports/"
+)
+
+var (
+	// ErrJobNotFound is returned when the job doesn't exist or has expired.
+	ErrJobNotFound = errors.New("export job not found")
+	// ErrInvalidToken is returned when the download token doesn't match.
+	ErrInvalidToken = errors.New("invalid download token")
+
+	errUploadAborted = errors.New("export file upload is aborted")
+)
+
+// RunFunc writes the exported content of the job to w.
+type RunFunc func(ctx context.Context, job *Job, w io.Writer) error
+
+// FinishFunc is called once the job finishes, err is nil if the job is done.
+type FinishFunc func(job *Job, err error)
+
+// Job is an export job.
+type Job struct {
+	// ID is the unique ID of the job.
+	ID string
+	// PrincipalID is the ID of the principal who creates the job, only the principal can get or cancel the job.
+	PrincipalID int
+	// InstanceID is the resource ID of the instance.
+	InstanceID string
+	// ConnectionDatabase is the name of the connection database.
+	ConnectionDatabase string
+	// Statement is the exported statement.
+	Statement string
+	// Format is the format of the exported content.
+	Format v1pb.ExportFormat
+	// Compression is the compression of the export file.
+	Compression v1pb.ExportCompression
+	// CreatedTs is the creation timestamp of the job.
+	CreatedTs int64
+
+	// path is the path of the export file in the storage.
+	path string
+	// token authorizes downloading the export file without the credentials of the principal.
+	token string
+
+	rowCount  atomic.Int64
+	byteCount atomic.Int64
+
+	// mu guards the fields below.
+	mu         sync.Mutex
+	state      v1pb.ExportJob_State
+	err        string
+	finishedTs int64
+}
+
+// Status returns the state, the error and the finish timestamp of the job.
+func (j *Job) Status() (v1pb.ExportJob_State, string, int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.state, j.err, j.finishedTs
+}
+
+// AddRow increases the number of exported rows, it reports the progress of the job.
+func (j *Job) AddRow() {
+	j.rowCount.Add(1)
+}
+
+// RowCount returns the number of exported rows.
+func (j *Job) RowCount() int64 {
+	return j.rowCount.Load()
+}
+
+// ByteCount returns the number of bytes written to the export file.
+func (j *Job) ByteCount() int64 {
+	return j.byteCount.Load()
+}
+
+// Token returns the download token of the job.
+func (j *Job) Token() string {
+	return j.token
+}
+
+// FileName returns the file name of the export file.
+func (j *Job) FileName() string {
+	name := fmt.Sprintf("export-%s.%s", j.ID, contentExtension(j.Format))
+	switch j.Compression {
+	case v1pb.ExportCompression_GZIP:
+		name += ".gz"
+	case v1pb.ExportCompression_ZIP:
+		name = strings.TrimSuffix(name, "."+contentExtension(j.Format)) + ".zip"
+	}
+	return name
+}
+
+func (j *Job) setStatus(job *store.ExportJobMessage) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.state = v1pb.ExportJob_State(v1pb.ExportJob_State_value[string(job.State)])
+	j.err = job.Error
+	j.finishedTs = job.FinishedTs
+}
+
+func contentExtension(format v1pb.ExportFormat) string {
+	switch format {
+	case v1pb.ExportFormat_CSV:
+		return "csv"
+	case v1pb.ExportFormat_JSON:
+		return "json"
+	case v1pb.ExportFormat_SQL:
+		return "sql"
+	case v1pb.ExportFormat_XLSX:
+		return "xlsx"
+	default:
+		return "txt"
+	}
+}
+
+// CreateJobMessage is the message to create a job.
+type CreateJobMessage struct {
+	PrincipalID        int
+	InstanceID         string
+	ConnectionDatabase string
+	Statement          string
+	Format             v1pb.ExportFormat
+	Compression        v1pb.ExportCompression
+}
+
+// Manager manages the export jobs of the server.
+// The jobs are stored in the metadata database, so that any replica can get, cancel and download the jobs run by the others,
+// while the export files are written to the default backup storage.
+// The download token is derived from the job ID with the secret shared by the replicas, so it isn't stored.
+type Manager struct {
+	store          *store.Store
+	storageFactory *storagefactory.StorageFactory
+	secret         string
+
+	// jobWG tracks the running jobs so that the shutdown waits for them to be canceled.
+	jobWG sync.WaitGroup
+
+	// mu guards the running jobs of the replica, which report the live progress and are canceled on shutdown.
+	mu      sync.Mutex
+	running map[string]*runningJob
+}
+
+type runningJob struct {
+	job    *Job
+	cancel context.CancelFunc
+}
+
+// NewManager creates a new export job manager.
+func NewManager(store *store.Store, storageFactory *storagefactory.StorageFactory, secret string) *Manager {
+	return &Manager{
+		store:          store,
+		storageFactory: storageFactory,
+		secret:         secret,
+		running:        make(map[string]*runningJob),
+	}
+}
+
+// Create creates a job and runs it in the background.
+// The job isn't bound to ctx, it runs until it finishes, is canceled or the server shuts down.
+func (m *Manager) Create(ctx context.Context, create *CreateJobMessage, run RunFunc, finish FinishFunc) (*Job, error) {
+	running := store.ExportJobRunning
+	runningJobs, err := m.store.ListExportJobs(ctx, &store.FindExportJobMessage{CreatorUID: &create.PrincipalID, State: &running})
+	if err != nil {
+		return nil, err
+	}
+	if len(runningJobs) >= maxRunningJobsPerPrincipal {
+		return nil, errors.Errorf("a user can run at most %d export jobs at the same time", maxRunningJobsPerPrincipal)
+	}
+
+	s, err := m.getStorage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{
+		ID:                 uuid.NewString(),
+		PrincipalID:        create.PrincipalID,
+		InstanceID:         create.InstanceID,
+		ConnectionDatabase: create.ConnectionDatabase,
+		Statement:          create.Statement,
+		Format:             create.Format,
+		Compression:        create.Compression,
+	}
+	job.path = exportDir + job.FileName()
+	job.token = m.getToken(job.ID)
+	stored, err := m.store.CreateExportJob(ctx, &store.ExportJobMessage{
+		ID:                 job.ID,
+		CreatorUID:         job.PrincipalID,
+		InstanceID:         job.InstanceID,
+		ConnectionDatabase: job.ConnectionDatabase,
+		Statement:          job.Statement,
+		Format:             job.Format.String(),
+		Compression:        job.Compression.String(),
+		Path:               job.path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	job.CreatedTs = stored.CreatedTs
+	job.setStatus(stored)
+
+	jobCtx, cancel := context.WithCancel(context.Background())
+	m.mu.Lock()
+	m.running[job.ID] = &runningJob{job: job, cancel: cancel}
+	m.mu.Unlock()
+
+	m.jobWG.Add(1)
+	go func() {
+		defer m.jobWG.Done()
+		defer cancel()
+		progressDone := make(chan struct{})
+		go func() {
+			defer close(progressDone)
+			m.reportProgress(jobCtx, job, cancel)
+		}()
+		err := m.runJob(jobCtx, s, job, run)
+		cancelErr := jobCtx.Err()
+		cancel()
+		<-progressDone
+
+		state, message := store.ExportJobDone, ""
+		switch {
+		case err == nil:
+		case cancelErr != nil:
+			err = errors.Wrap(cancelErr, "export job is canceled")
+			state, message = store.ExportJobCanceled, err.Error()
+		default:
+			state, message = store.ExportJobFailed, err.Error()
+		}
+		m.finishJob(job, state, message)
+		if state, _, _ := job.Status(); state != v1pb.ExportJob_DONE {
+			if err == nil {
+				// The job is canceled by another replica right before it's done.
+				err = errors.New("export job is canceled")
+			}
+			if err := s.Delete(context.Background(), job.path); err != nil {
+				slog.Warn("failed to delete the export file of the export job", slog.String("job", job.ID), log.BBError(err))
+			}
+		}
+
+		m.mu.Lock()
+		delete(m.running, job.ID)
+		m.mu.Unlock()
+		finish(job, err)
+	}()
+	return job, nil
+}
+
+// reportProgress reports the progress of the running job periodically until ctx is done.
+// The job is canceled if it's no longer running in the store, i.e. it's canceled by another replica.
+func (m *Manager) reportProgress(ctx context.Context, job *Job, cancel context.CancelFunc) {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			rowCount, byteCount := job.RowCount(), job.ByteCount()
+			running := store.ExportJobRunning
+			stored, err := m.store.UpdateExportJob(ctx, &store.UpdateExportJobMessage{
+				ID:            job.ID,
+				ExpectedState: &running,
+				RowCount:      &rowCount,
+				ByteCount:     &byteCount,
+			})
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Warn("failed to report the progress of the export job", slog.String("job", job.ID), log.BBError(err))
+				}
+				continue
+			}
+			if stored == nil {
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// finishJob records the final state of the job unless it has been canceled by another replica.
+func (m *Manager) finishJob(job *Job, state store.ExportJobState, message string) {
+	ctx := context.Background()
+	rowCount, byteCount := job.RowCount(), job.ByteCount()
+	finishedTs := time.Now().Unix()
+	running := store.ExportJobRunning
+	stored, err := m.store.UpdateExportJob(ctx, &store.UpdateExportJobMessage{
+		ID:            job.ID,
+		ExpectedState: &running,
+		State:         &state,
+		Error:         &message,
+		RowCount:      &rowCount,
+		ByteCount:     &byteCount,
+		FinishedTs:    &finishedTs,
+	})
+	if err == nil && stored == nil {
+		stored, err = m.store.GetExportJob(ctx, &store.FindExportJobMessage{ID: &job.ID})
+	}
+	if err != nil || stored == nil {
+		slog.Error("failed to record the state of the export job", slog.String("job", job.ID), log.BBError(err))
+		// The job is failed as interrupted by the other replicas eventually.
+		job.setStatus(&store.ExportJobMessage{State: store.ExportJobFailed, Error: message, FinishedTs: finishedTs})
+		return
+	}
+	job.setStatus(stored)
+}
+
+// runJob streams the content written by run through the compressor into the export file.
+func (*Manager) runJob(ctx context.Context, s storage.Storage, job *Job, run RunFunc) error {
+	pr, pw := io.Pipe()
+	uploadErr := make(chan error, 1)
+	go func() {
+		err := s.Upload(ctx, job.path, pr)
+		// Unblock the writer if the upload fails.
+		pr.CloseWithError(errUploadAborted)
+		uploadErr <- err
+	}()
+
+	err := writeContent(ctx, job, &countingWriter{w: pw, count: &job.byteCount}, run)
+	pw.CloseWithError(err)
+	if uploadErr := <-uploadErr; uploadErr != nil && (err == nil || errors.Is(err, errUploadAborted)) {
+		return errors.Wrap(uploadErr, "failed to upload export file")
+	}
+	return err
+}
+
+func writeContent(ctx context.Context, job *Job, w io.Writer, run RunFunc) error {
+	switch job.Compression {
+	case v1pb.ExportCompression_GZIP:
+		gw := gzip.NewWriter(w)
+		if err := run(ctx, job, gw); err != nil {
+			return err
+		}
+		return gw.Close()
+	case v1pb.ExportCompression_ZIP:
+		zw := zip.NewWriter(w)
+		fw, err := zw.Create(fmt.Sprintf("export-%s.%s", job.ID, contentExtension(job.Format)))
+		if err != nil {
+			return errors.Wrap(err, "failed to create zip entry")
+		}
+		if err := run(ctx, job, fw); err != nil {
+			return err
+		}
+		return zw.Close()
+	default:
+		return run(ctx, job, w)
+	}
+}
+
+// Get gets the job of the principal, it returns nil if not found.
+func (m *Manager) Get(ctx context.Context, id string, principalID int) (*Job, error) {
+	stored, err := m.store.GetExportJob(ctx, &store.FindExportJobMessage{ID: &id, CreatorUID: &principalID})
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, nil
+	}
+	return m.convertJob(stored), nil
+}
+
+// Cancel cancels the running job, it returns the canceled job or nil if the job isn't running.
+// The job run by another replica is canceled once the replica reports its progress.
+func (m *Manager) Cancel(ctx context.Context, job *Job) (*Job, error) {
+	running, canceled := store.ExportJobRunning, store.ExportJobCanceled
+	message := "export job is canceled"
+	finishedTs := time.Now().Unix()
+	stored, err := m.store.UpdateExportJob(ctx, &store.UpdateExportJobMessage{
+		ID:            job.ID,
+		ExpectedState: &running,
+		State:         &canceled,
+		Error:         &message,
+		FinishedTs:    &finishedTs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		return nil, nil
+	}
+	m.mu.Lock()
+	if r, ok := m.running[job.ID]; ok {
+		r.cancel()
+	}
+	m.mu.Unlock()
+	return m.convertJob(stored), nil
+}
+
+// Download writes the export file of the job to w after validating the download token.
+// The job is returned so that the caller can set the response headers before the content is written.
+func (m *Manager) Download(ctx context.Context, id string, token string, w func(job *Job) io.Writer) error {
+	if !hmac.Equal([]byte(m.getToken(id)), []byte(token)) {
+		return ErrInvalidToken
+	}
+	stored, err := m.store.GetExportJob(ctx, &store.FindExportJobMessage{ID: &id})
+	if err != nil {
+		return err
+	}
+	if stored == nil || stored.State != store.ExportJobDone || time.Now().After(time.Unix(stored.FinishedTs, 0).Add(DownloadTTL)) {
+		return ErrJobNotFound
+	}
+	job := m.convertJob(stored)
+	s, err := m.getStorage(ctx)
+	if err != nil {
+		return err
+	}
+	return s.Download(ctx, job.path, w(job))
+}
+
+// Run deletes the expired jobs and export files periodically, and cancels the running jobs when the server shuts down.
+func (m *Manager) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	slog.Debug(fmt.Sprintf("Export job manager started and will run every %v", expireInterval))
+	for {
+		select {
+		case <-ticker.C:
+			m.deleteExpired(ctx)
+		case <-ctx.Done():
+			m.mu.Lock()
+			for _, r := range m.running {
+				r.cancel()
+			}
+			m.mu.Unlock()
+			m.jobWG.Wait()
+			return
+		}
+	}
+}
+
+func (m *Manager) deleteExpired(ctx context.Context) {
+	now := time.Now()
+	if _, err := m.store.FailInterruptedExportJobs(ctx, now.Add(-interruptedTimeout).Unix(), "export job is interrupted"); err != nil {
+		slog.Error("failed to fail the interrupted export jobs", log.BBError(err))
+	}
+	if _, err := m.store.DeleteFinishedExportJobs(ctx, now.Add(-DownloadTTL).Unix()); err != nil {
+		slog.Error("failed to delete the expired export jobs", log.BBError(err))
+	}
+
+	// Delete the export files by their modification time instead of the jobs,
+	// so that the files left by the interrupted jobs are deleted as well.
+	s, err := m.getStorage(ctx)
+	if err != nil {
+		slog.Error("failed to get the storage of the export files", log.BBError(err))
+		return
+	}
+	objects, err := s.List(ctx, exportDir)
+	if err != nil {
+		slog.Error("failed to list the export files", log.BBError(err))
+		return
+	}
+	var paths []string
+	for _, object := range objects {
+		if now.After(object.LastModified.Add(DownloadTTL)) {
+			paths = append(paths, object.Path)
+		}
+	}
+	if len(paths) == 0 {
+		return
+	}
+	if err := s.Delete(ctx, paths...); err != nil {
+		slog.Error("failed to delete the expired export files", log.BBError(err))
+	}
+}
+
+// convertJob converts the stored job, the live progress is used if the job is running on the replica.
+func (m *Manager) convertJob(stored *store.ExportJobMessage) *Job {
+	m.mu.Lock()
+	r, ok := m.running[stored.ID]
+	m.mu.Unlock()
+	if ok {
+		r.job.setStatus(stored)
+		return r.job
+	}
+	job := &Job{
+		ID:                 stored.ID,
+		PrincipalID:        stored.CreatorUID,
+		InstanceID:         stored.InstanceID,
+		ConnectionDatabase: stored.ConnectionDatabase,
+		Statement:          stored.Statement,
+		Format:             v1pb.ExportFormat(v1pb.ExportFormat_value[stored.Format]),
+		Compression:        v1pb.ExportCompression(v1pb.ExportCompression_value[stored.Compression]),
+		CreatedTs:          stored.CreatedTs,
+		path:               stored.Path,
+		token:              m.getToken(stored.ID),
+	}
+	job.rowCount.Store(stored.RowCount)
+	job.byteCount.Store(stored.ByteCount)
+	job.setStatus(stored)
+	return job
+}
+
+func (m *Manager) getStorage(ctx context.Context) (storage.Storage, error) {
+	s, err := m.storageFactory.GetStorage(ctx, m.storageFactory.GetDefaultBackupStorage())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the storage of the export files")
+	}
+	return s, nil
+}
+
+// getToken returns the download token of the job, which is the HMAC of the job ID with the secret.
+func (m *Manager) getToken(id string) string {
+	mac := hmac.New(sha256.New, []byte(m.secret))
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w     io.Writer
+	count *atomic.Int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.count.Add(int64(n))
+	return n, err
+}
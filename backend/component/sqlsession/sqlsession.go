@@ -0,0 +1,455 @@
+// Package sqlsession manages the SQL Editor sessions which pin database connections across requests,
+// so that a transaction can be opened in one request and committed or rolled back in another.
+package sqlsession
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	// DefaultIdleTimeout is the idle timeout of a session if not specified.
+	DefaultIdleTimeout = 10 * time.Minute
+	// MaxIdleTimeout is the maximum idle timeout of a session.
+	MaxIdleTimeout = 1 * time.Hour
+	// maxSessionsPerPrincipal is the maximum number of sessions a principal can open, each session holds a database connection.
+	maxSessionsPerPrincipal = 10
+	// expireInterval is the interval to close the idle sessions.
+	expireInterval = 30 * time.Second
+	// rollbackTimeout is the timeout to roll back the open transaction of an interrupted session.
+	rollbackTimeout = 10 * time.Second
+)
+
+var (
+	// ErrSessionClosed is returned when operating on a closed session.
+	ErrSessionClosed = errors.New("session is closed")
+)
+
+// Session is a SQL Editor session pinning a database connection.
+type Session struct {
+	// ID is the unique ID of the session.
+	ID string
+	// PrincipalID is the ID of the principal who creates the session, only the principal can use the session.
+	PrincipalID int
+	// InstanceUID is the UID of the instance.
+	InstanceUID int
+	// InstanceID is the resource ID of the instance.
+	InstanceID string
+	// InstanceTitle is the title of the instance.
+	InstanceTitle string
+	// Engine is the engine of the instance.
+	Engine db.Type
+	// DatabaseUID is the UID of the connection database, 0 if the session doesn't connect to a database.
+	DatabaseUID int
+	// ConnectionDatabase is the name of the connection database.
+	ConnectionDatabase string
+	// IdleTimeout is the duration after which the idle session is closed.
+	IdleTimeout time.Duration
+	// CreatedTs is the creation timestamp of the session.
+	CreatedTs int64
+
+	// closed is set once the session is closed, it is read without holding mu so that the manager doesn't wait for the running statements.
+	closed atomic.Bool
+
+	// manager is the manager of the session, it records the rollbacks of the interrupted sessions.
+	manager *Manager
+
+	// mu guards the fields below and serializes the statements on the connection.
+	mu            sync.Mutex
+	driver        db.Driver
+	conn          *sql.Conn
+	inTransaction bool
+	lastActiveTs  int64
+}
+
+// InTransaction returns whether the session has an open transaction.
+func (s *Session) InTransaction() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inTransaction
+}
+
+// TransactionStatements returns the statements to begin, commit and roll back the transaction of the session.
+func (s *Session) TransactionStatements() (string, string, string) {
+	statements := getTransactionStatements(s.Engine)
+	return statements.begin, statements.commit, statements.rollback
+}
+
+// LastActiveTs returns the timestamp of the last statement executed in the session.
+func (s *Session) LastActiveTs() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastActiveTs
+}
+
+// Execute runs the statement on the pinned connection.
+func (s *Session) Execute(ctx context.Context, statement string) ([]*v1pb.QueryResult, error) {
+	return s.run(ctx, func(ctx context.Context) ([]*v1pb.QueryResult, error) {
+		return s.driver.RunStatement(ctx, s.conn, statement)
+	})
+}
+
+// Query runs the query on the pinned connection, in the open transaction of the session if any.
+func (s *Session) Query(ctx context.Context, statement string, queryContext *db.QueryContext) ([]*v1pb.QueryResult, error) {
+	return s.run(ctx, func(ctx context.Context) ([]*v1pb.QueryResult, error) {
+		queryContext.InTransaction = s.inTransaction
+		return s.driver.QueryConn(ctx, s.conn, statement, queryContext)
+	})
+}
+
+// run runs the statements on the pinned connection, and refreshes the transaction state of the session from the database,
+// so that the transactions started or ended by the statements are tracked.
+// If the statements are canceled or time out, the connection can't be trusted anymore,
+// so the session is closed and the open transaction is rolled back.
+func (s *Session) run(ctx context.Context, f func(context.Context) ([]*v1pb.QueryResult, error)) ([]*v1pb.QueryResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed.Load() {
+		return nil, ErrSessionClosed
+	}
+	s.lastActiveTs = time.Now().Unix()
+	results, err := f(ctx)
+	if ctx.Err() != nil {
+		return nil, s.abortLocked(ctx.Err(), "statement")
+	}
+	if err := s.refreshTransactionStateLocked(ctx); err != nil {
+		return nil, err
+	}
+	return results, err
+}
+
+// refreshTransactionStateLocked reads the transaction state of the session from the database.
+// The session is closed if the state is unknown, the open transaction is rolled back by the database once the connection is closed.
+// The caller must hold s.mu.
+func (s *Session) refreshTransactionStateLocked(ctx context.Context) error {
+	inTransaction, err := getTransactionStatus(ctx, s.Engine, s.conn)
+	if err != nil {
+		s.closeLocked(context.Background(), false /* rollback */)
+		return errors.Wrap(err, "the session is closed")
+	}
+	s.inTransaction = inTransaction
+	return nil
+}
+
+// Begin opens a transaction in the session.
+func (s *Session) Begin(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed.Load() {
+		return ErrSessionClosed
+	}
+	if s.inTransaction {
+		return errors.New("session already has an open transaction")
+	}
+	statements := getTransactionStatements(s.Engine)
+	s.lastActiveTs = time.Now().Unix()
+	if _, err := s.conn.ExecContext(ctx, statements.begin); err != nil {
+		if ctx.Err() != nil {
+			return s.abortLocked(ctx.Err(), statements.begin)
+		}
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	return s.refreshTransactionStateLocked(ctx)
+}
+
+// Commit commits the open transaction of the session.
+func (s *Session) Commit(ctx context.Context) error {
+	return s.endTransaction(ctx, true /* commit */)
+}
+
+// Rollback rolls back the open transaction of the session.
+func (s *Session) Rollback(ctx context.Context) error {
+	return s.endTransaction(ctx, false /* commit */)
+}
+
+func (s *Session) endTransaction(ctx context.Context, commit bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed.Load() {
+		return ErrSessionClosed
+	}
+	if !s.inTransaction {
+		return errors.New("session has no open transaction")
+	}
+	statements := getTransactionStatements(s.Engine)
+	statement := statements.rollback
+	if commit {
+		statement = statements.commit
+	}
+	s.lastActiveTs = time.Now().Unix()
+	_, execErr := s.conn.ExecContext(ctx, statement)
+	if execErr != nil && ctx.Err() != nil {
+		return s.abortLocked(ctx.Err(), statement)
+	}
+	// The transaction may have ended even if the statement fails, e.g. a failed commit rolls back the transaction.
+	if err := s.refreshTransactionStateLocked(ctx); err != nil {
+		return err
+	}
+	if execErr != nil {
+		return errors.Wrapf(execErr, "failed to %s", statement)
+	}
+	return nil
+}
+
+// abortLocked closes the session whose statement is interrupted because the client disconnects or the statement times out,
+// rolls back the open transaction and records the rollback in the SQL Editor query activities.
+// The caller must hold s.mu.
+func (s *Session) abortLocked(cause error, statement string) error {
+	reason := "the client disconnected"
+	if errors.Is(cause, context.DeadlineExceeded) {
+		reason = "the statement timed out"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), rollbackTimeout)
+	defer cancel()
+	if !s.closeLocked(ctx, true /* rollback */) {
+		return errors.Wrapf(cause, "%s is interrupted, the session is closed", statement)
+	}
+	if err := s.manager.createRollbackActivity(ctx, s, reason); err != nil {
+		slog.Error("failed to create activity for the rolled back transaction of the SQL Editor session", slog.String("session", s.ID), log.BBError(err))
+	}
+	return errors.Wrapf(cause, "%s is interrupted, the session is closed and the transaction is rolled back", statement)
+}
+
+// closeLocked closes the session and returns whether an open transaction is rolled back.
+// The caller must hold s.mu.
+func (s *Session) closeLocked(ctx context.Context, rollback bool) bool {
+	if !s.closed.CompareAndSwap(false, true) {
+		return false
+	}
+	rolledBack := s.inTransaction
+	if rollback && s.inTransaction {
+		if _, err := s.conn.ExecContext(ctx, getTransactionStatements(s.Engine).rollback); err != nil {
+			// Closing the connection rolls back the transaction anyway.
+			slog.Warn("failed to roll back the transaction of the SQL Editor session", slog.String("session", s.ID), log.BBError(err))
+		}
+	}
+	s.inTransaction = false
+	if err := s.conn.Close(); err != nil {
+		slog.Warn("failed to close the connection of the SQL Editor session", slog.String("session", s.ID), log.BBError(err))
+	}
+	if err := s.driver.Close(ctx); err != nil {
+		slog.Warn("failed to close the driver of the SQL Editor session", slog.String("session", s.ID), log.BBError(err))
+	}
+	return rolledBack
+}
+
+// CreateSessionMessage is the message to create a session.
+type CreateSessionMessage struct {
+	PrincipalID        int
+	Instance           *store.InstanceMessage
+	Database           *store.DatabaseMessage
+	ConnectionDatabase string
+	IdleTimeout        time.Duration
+}
+
+// Manager manages the SQL Editor sessions of the server.
+// The sessions are kept in memory, so a session is bound to the replica creating it in high-availability mode.
+type Manager struct {
+	store *store.Store
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewManager creates a new session manager.
+func NewManager(store *store.Store) *Manager {
+	return &Manager{
+		store:    store,
+		sessions: make(map[string]*Session),
+	}
+}
+
+// IsEngineSupported returns whether the sessions are supported for the engine.
+func IsEngineSupported(engine db.Type) bool {
+	return getTransactionStatements(engine) != nil
+}
+
+// Create creates a session pinning a connection of the driver.
+// The session takes the ownership of the driver and closes it when the session is closed.
+func (m *Manager) Create(ctx context.Context, create *CreateSessionMessage, driver db.Driver) (*Session, error) {
+	if !IsEngineSupported(create.Instance.Engine) {
+		return nil, errors.Errorf("session is not supported for engine %s", create.Instance.Engine)
+	}
+	idleTimeout := create.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+	if idleTimeout < 0 || idleTimeout > MaxIdleTimeout {
+		return nil, errors.Errorf("idle timeout must be positive and at most %v", MaxIdleTimeout)
+	}
+
+	sqlDB := driver.GetDB()
+	if sqlDB == nil {
+		return nil, errors.Errorf("session is not supported for engine %s", create.Instance.Engine)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get database connection")
+	}
+	now := time.Now().Unix()
+	session := &Session{
+		ID:                 uuid.NewString(),
+		PrincipalID:        create.PrincipalID,
+		InstanceUID:        create.Instance.UID,
+		InstanceID:         create.Instance.ResourceID,
+		InstanceTitle:      create.Instance.Title,
+		Engine:             create.Instance.Engine,
+		ConnectionDatabase: create.ConnectionDatabase,
+		IdleTimeout:        idleTimeout,
+		CreatedTs:          now,
+		manager:            m,
+		driver:             driver,
+		conn:               conn,
+		lastActiveTs:       now,
+	}
+	if create.Database != nil {
+		session.DatabaseUID = create.Database.UID
+	}
+
+	// Count the sessions and add the new one while holding the lock, so that the concurrent requests can't exceed the limit.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, s := range m.sessions {
+		if s.PrincipalID == create.PrincipalID && !s.closed.Load() {
+			count++
+		}
+	}
+	if count >= maxSessionsPerPrincipal {
+		if err := conn.Close(); err != nil {
+			slog.Warn("failed to close the database connection", log.BBError(err))
+		}
+		return nil, errors.Errorf("a user can open at most %d sessions, close the unused sessions first", maxSessionsPerPrincipal)
+	}
+	m.sessions[session.ID] = session
+	return session, nil
+}
+
+// Get gets the session of the principal, it returns nil if not found or closed.
+func (m *Manager) Get(id string, principalID int) *Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	if !ok || session.PrincipalID != principalID {
+		return nil
+	}
+	if session.closed.Load() {
+		// The session is closed because its statement was interrupted.
+		delete(m.sessions, id)
+		return nil
+	}
+	return session
+}
+
+// Delete closes the session and rolls back its open transaction.
+// The rollback is recorded in the SQL Editor query activities with the reason.
+func (m *Manager) Delete(ctx context.Context, id string, reason string) {
+	m.mu.Lock()
+	session, ok := m.sessions[id]
+	delete(m.sessions, id)
+	m.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	session.mu.Lock()
+	rolledBack := session.closeLocked(ctx, true /* rollback */)
+	session.mu.Unlock()
+	if rolledBack {
+		if err := m.createRollbackActivity(ctx, session, reason); err != nil {
+			slog.Error("failed to create activity for the rolled back transaction of the SQL Editor session", slog.String("session", session.ID), log.BBError(err))
+		}
+	}
+}
+
+// Run closes the idle sessions periodically, and closes all sessions when the server shuts down.
+func (m *Manager) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(expireInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	slog.Debug(fmt.Sprintf("SQL Editor session manager started and will run every %v", expireInterval))
+	for {
+		select {
+		case <-ticker.C:
+			m.deleteIdleSessions(ctx)
+		case <-ctx.Done():
+			m.mu.Lock()
+			var ids []string
+			for id := range m.sessions {
+				ids = append(ids, id)
+			}
+			m.mu.Unlock()
+			for _, id := range ids {
+				m.Delete(context.Background(), id, "the server is shutting down")
+			}
+			return
+		}
+	}
+}
+
+func (m *Manager) deleteIdleSessions(ctx context.Context) {
+	now := time.Now()
+	var idleSessions []*Session
+	m.mu.Lock()
+	for id, session := range m.sessions {
+		if session.closed.Load() {
+			delete(m.sessions, id)
+			continue
+		}
+		// Skip the sessions running statements.
+		if !session.mu.TryLock() {
+			continue
+		}
+		if now.After(time.Unix(session.lastActiveTs, 0).Add(session.IdleTimeout)) {
+			idleSessions = append(idleSessions, session)
+		}
+		session.mu.Unlock()
+	}
+	m.mu.Unlock()
+	for _, session := range idleSessions {
+		m.Delete(ctx, session.ID, fmt.Sprintf("the session has been idle for more than %v", session.IdleTimeout))
+	}
+}
+
+func (m *Manager) createRollbackActivity(ctx context.Context, session *Session, reason string) error {
+	statement := getTransactionStatements(session.Engine).rollback
+	payload, err := json.Marshal(api.ActivitySQLEditorQueryPayload{
+		Statement:              statement,
+		InstanceID:             session.InstanceUID,
+		DeprecatedInstanceName: session.InstanceTitle,
+		DatabaseID:             session.DatabaseUID,
+		DatabaseName:           session.ConnectionDatabase,
+		SessionID:              session.ID,
+		Error:                  fmt.Sprintf("transaction is rolled back automatically because %s", reason),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal activity payload")
+	}
+	if _, err := m.store.CreateActivityV2(ctx, &store.ActivityMessage{
+		CreatorUID:   session.PrincipalID,
+		Type:         api.ActivitySQLEditorQuery,
+		ContainerUID: session.InstanceUID,
+		Level:        api.ActivityWarn,
+		Comment: fmt.Sprintf("Rolled back the transaction of session %q in database %q of instance %d because %s.",
+			session.ID, session.ConnectionDatabase, session.InstanceUID, reason),
+		Payload: string(payload),
+	}); err != nil {
+		return errors.Wrap(err, "failed to create activity")
+	}
+	return nil
+}
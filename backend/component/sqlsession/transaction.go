@@ -0,0 +1,108 @@
+package sqlsession
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+// transactionStatements are the statements to control the transactions of an engine.
+type transactionStatements struct {
+	begin    string
+	commit   string
+	rollback string
+	// statusQuery returns whether the connection has an open transaction.
+	// It is empty if the status is read from the driver of the connection instead.
+	statusQuery string
+}
+
+var (
+	mysqlTransactionStatements = &transactionStatements{
+		// The InnoDB transaction is started immediately with the consistent snapshot,
+		// otherwise it is not listed in INNODB_TRX before the first statement accessing the tables.
+		begin:       "START TRANSACTION WITH CONSISTENT SNAPSHOT",
+		commit:      "COMMIT",
+		rollback:    "ROLLBACK",
+		statusQuery: "SELECT COUNT(*) > 0 FROM information_schema.INNODB_TRX WHERE trx_mysql_thread_id = CONNECTION_ID()",
+	}
+	mariadbTransactionStatements = &transactionStatements{
+		begin:       "BEGIN",
+		commit:      "COMMIT",
+		rollback:    "ROLLBACK",
+		statusQuery: "SELECT @@in_transaction = 1",
+	}
+	postgresTransactionStatements = &transactionStatements{
+		begin:    "BEGIN",
+		commit:   "COMMIT",
+		rollback: "ROLLBACK",
+	}
+	mssqlTransactionStatements = &transactionStatements{
+		begin:       "BEGIN TRANSACTION",
+		commit:      "COMMIT TRANSACTION",
+		rollback:    "ROLLBACK TRANSACTION",
+		statusQuery: "SELECT CAST(CASE WHEN @@TRANCOUNT > 0 THEN 1 ELSE 0 END AS BIT)",
+	}
+)
+
+// getTransactionStatements returns the transaction statements of the engine, or nil if the sessions are not supported.
+// The sessions are only supported for the engines reporting whether the connection has an open transaction.
+func getTransactionStatements(engine db.Type) *transactionStatements {
+	switch engine {
+	case db.MySQL:
+		return mysqlTransactionStatements
+	case db.MariaDB:
+		return mariadbTransactionStatements
+	case db.Postgres:
+		return postgresTransactionStatements
+	case db.MSSQL:
+		return mssqlTransactionStatements
+	default:
+		return nil
+	}
+}
+
+// getTransactionStatus returns whether the connection has an open transaction.
+// The status is read from the database instead of the statements, so that the transactions started or ended by the statements,
+// e.g. the implicit commit of MySQL DDL or the rollback of a deadlock, are tracked as well.
+func getTransactionStatus(ctx context.Context, engine db.Type, conn *sql.Conn) (bool, error) {
+	statements := getTransactionStatements(engine)
+	if statements == nil {
+		return false, errors.Errorf("session is not supported for engine %s", engine)
+	}
+	if statements.statusQuery == "" {
+		return getPostgresTransactionStatus(conn)
+	}
+	var inTransaction bool
+	if err := conn.QueryRowContext(ctx, statements.statusQuery).Scan(&inTransaction); err != nil {
+		return false, errors.Wrap(err, "failed to get transaction status")
+	}
+	return inTransaction, nil
+}
+
+// getPostgresTransactionStatus returns the transaction status reported by the server in the last ReadyForQuery message.
+// A failed transaction is still open until it is rolled back.
+func getPostgresTransactionStatus(conn *sql.Conn) (bool, error) {
+	var txStatus byte
+	if err := conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.Errorf("unexpected postgres driver connection %T", driverConn)
+		}
+		txStatus = c.Conn().PgConn().TxStatus()
+		return nil
+	}); err != nil {
+		return false, errors.Wrap(err, "failed to get transaction status")
+	}
+	switch txStatus {
+	case 'I':
+		return false, nil
+	case 'T', 'E':
+		return true, nil
+	default:
+		return false, errors.Errorf("unknown postgres transaction status %q", txStatus)
+	}
+}
@@ -0,0 +1,29 @@
+package sqlsession
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+)
+
+func TestIsEngineSupported(t *testing.T) {
+	tests := []struct {
+		engine db.Type
+		want   bool
+	}{
+		{engine: db.MySQL, want: true},
+		{engine: db.MariaDB, want: true},
+		{engine: db.Postgres, want: true},
+		{engine: db.MSSQL, want: true},
+		// The transaction status is not reported reliably.
+		{engine: db.TiDB, want: false},
+		{engine: db.OceanBase, want: false},
+		{engine: db.Snowflake, want: false},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, IsEngineSupported(test.engine), test.engine)
+	}
+}
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	// HeartbeatInterval is the interval for the replica to send heartbeats in high-availability mode.
+	HeartbeatInterval = 10 * time.Second
+	// ReplicaTimeout is the duration without heartbeats after which a replica is considered dead,
+	// and the leases held by it can be reclaimed by other replicas.
+	ReplicaTimeout = 1 * time.Minute
+)
+
+// AliveSinceTs returns the timestamp since which a replica must have sent heartbeats to be considered alive.
+func AliveSinceTs() int64 {
+	return time.Now().Add(-ReplicaTimeout).Unix()
+}
+
+// TryAcquireLease tries to acquire the lease of the resource for the replica.
+// If instanceUID is not zero, the lease also counts towards the outstanding connections of the instance across replicas.
+// It always succeeds if the high-availability mode is off.
+func (s *State) TryAcquireLease(ctx context.Context, resource string, instanceUID int) (bool, error) {
+	if !s.HA {
+		return true, nil
+	}
+	return s.store.TryAcquireLease(ctx, &store.LeaseMessage{
+		Resource:    resource,
+		Holder:      s.ReplicaID,
+		InstanceUID: instanceUID,
+	}, AliveSinceTs(), InstanceMaximumConnectionNumber)
+}
+
+// ReleaseLease releases the lease of the resource held by the replica.
+// Failures are only logged because the lease will be reclaimed once the replica is dead anyway.
+func (s *State) ReleaseLease(ctx context.Context, resource string) {
+	if !s.HA {
+		return
+	}
+	if err := s.store.ReleaseLease(ctx, resource, s.ReplicaID); err != nil {
+		slog.Error("failed to release lease", slog.String("resource", resource), log.BBError(err))
+	}
+}
+
+// ListHeldLeases returns the set of resources whose leases are held by the replica.
+// It returns nil if the high-availability mode is off.
+func (s *State) ListHeldLeases(ctx context.Context) (map[string]bool, error) {
+	if !s.HA {
+		return nil, nil
+	}
+	leases, err := s.store.ListLeases(ctx, &store.FindLeaseMessage{Holder: &s.ReplicaID})
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[string]bool)
+	for _, lease := range leases {
+		held[lease.Resource] = true
+	}
+	return held, nil
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/dgraph-io/ristretto"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/store"
@@ -64,10 +65,22 @@ type State struct {
 
 	ExpireCache *ristretto.Cache
 
+	// HA is whether the server runs in high-availability mode.
+	// In high-availability mode, replicas coordinate the background jobs through leases in the metadata database,
+	// and the in-memory states above only deduplicate the jobs within the replica.
+	// The channels and the approval finding set above only notify the runners of the same replica, the runners of the other replicas
+	// find the jobs by polling the database, so the jobs are picked up by them with the polling latency, which is accepted,
+	// e.g. 5 seconds for the plan check and task run schedulers and 30 seconds for the issues pending approval template finding.
+	HA bool
+	// ReplicaID is the unique ID of the replica, which is regenerated every time the server starts.
+	ReplicaID string
+
+	store *store.Store
+
 	sync.Mutex
 }
 
-func New() (*State, error) {
+func New(storeInstance *store.Store, ha bool) (*State, error) {
 	expireCache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1_000,
 		MaxCost:     1_000, // ~1KB
@@ -85,6 +98,9 @@ func New() (*State, error) {
 		PlanCheckTickleChan:                  make(chan int, 1000),
 		TaskRunTickleChan:                    make(chan int, 1000),
 		ExpireCache:                          expireCache,
+		HA:                                   ha,
+		ReplicaID:                            uuid.NewString(),
+		store:                                storeInstance,
 	}, nil
 }
 
@@ -0,0 +1,171 @@
+// Package storagefactory includes the factory of the storages keeping the backup and binlog files.
+package storagefactory
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/component/config"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// CredentialDir is the directory in the data directory holding the credential and encryption key files
+// referenced by the backup storages of the backup plan policies.
+// The policies only reference the files by name so that they cannot read arbitrary files on the server.
+const CredentialDir = "backup-credential"
+
+// ValidateCredentialFileName validates the name of a credential or encryption key file referenced by a backup storage.
+func ValidateCredentialFileName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if name == "." || name == ".." || filepath.Base(name) != name || filepath.IsAbs(name) {
+		return errors.Errorf("invalid file name %q, it must be the name of a file in the %q directory of the data directory", name, CredentialDir)
+	}
+	return nil
+}
+
+// StorageFactory is the factory for building the storages of the backup storage settings.
+type StorageFactory struct {
+	store   *store.Store
+	profile *config.Profile
+
+	mu       sync.Mutex
+	storages map[api.BackupStorage]storage.Storage
+}
+
+// New creates a new storage factory.
+func New(store *store.Store, profile *config.Profile) *StorageFactory {
+	return &StorageFactory{
+		store:    store,
+		profile:  profile,
+		storages: make(map[api.BackupStorage]storage.Storage),
+	}
+}
+
+// GetDefaultBackupStorage returns the default backup storage of the server specified by the command-line flags.
+func (f *StorageFactory) GetDefaultBackupStorage() *api.BackupStorage {
+	if f.profile.BackupStorageBackend == api.BackupStorageBackendS3 {
+		return &api.BackupStorage{
+			Backend:        api.BackupStorageBackendS3,
+			Bucket:         f.profile.BackupBucket,
+			Region:         f.profile.BackupRegion,
+			CredentialFile: f.profile.BackupCredentialFile,
+		}
+	}
+	return &api.BackupStorage{Backend: api.BackupStorageBackendLocal}
+}
+
+// GetEnvironmentBackupStorage returns the backup storage of the environment.
+// It is the storage in the backup plan policy of the environment, or the default backup storage if not set.
+func (f *StorageFactory) GetEnvironmentBackupStorage(ctx context.Context, environmentUID int) (*api.BackupStorage, error) {
+	policy, err := f.store.GetBackupPlanPolicyByEnvID(ctx, environmentUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get backup plan policy of environment %d", environmentUID)
+	}
+	if policy.Storage != nil {
+		return policy.Storage, nil
+	}
+	return f.GetDefaultBackupStorage(), nil
+}
+
+// GetBackupStorage returns the storage the backup is written to.
+// The backups taken before the storage is recorded in the payload are in the default backup storage of their backends.
+func (f *StorageFactory) GetBackupStorage(ctx context.Context, backup *store.BackupMessage) (storage.Storage, error) {
+	backupStorage := backup.Payload.Storage
+	if backupStorage == nil {
+		backupStorage = &api.BackupStorage{Backend: backup.StorageBackend}
+		if defaultStorage := f.GetDefaultBackupStorage(); defaultStorage.Backend == backup.StorageBackend {
+			backupStorage = defaultStorage
+		}
+	}
+	return f.GetStorage(ctx, backupStorage)
+}
+
+// GetBinlogStorage returns the storage the binlog files of the instance are uploaded to, which is the backup storage of the instance environment.
+// It returns nil if the binlog files are only kept in the local data directory.
+func (f *StorageFactory) GetBinlogStorage(ctx context.Context, instance *store.InstanceMessage) (storage.Storage, error) {
+	environment, err := f.store.GetEnvironmentV2(ctx, &store.FindEnvironmentMessage{ResourceID: &instance.EnvironmentID})
+	if err != nil {
+		return nil, err
+	}
+	if environment == nil {
+		return nil, errors.Errorf("environment %q not found", instance.EnvironmentID)
+	}
+	backupStorage, err := f.GetEnvironmentBackupStorage(ctx, environment.UID)
+	if err != nil {
+		return nil, err
+	}
+	if backupStorage.Backend == api.BackupStorageBackendLocal {
+		return nil, nil
+	}
+	return f.GetStorage(ctx, backupStorage)
+}
+
+// GetStorage returns the storage of the backup storage setting.
+// The local storage is rooted at the data directory.
+func (f *StorageFactory) GetStorage(ctx context.Context, backupStorage *api.BackupStorage) (storage.Storage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.storages[*backupStorage]; ok {
+		return s, nil
+	}
+
+	cfg := &storage.Config{Backend: storage.Backend(backupStorage.Backend)}
+	switch backupStorage.Backend {
+	case api.BackupStorageBackendLocal:
+		// The paths of the local backup files are relative to the data directory, and the files are restored directly.
+		if backupStorage.EncryptionKeyFile != "" {
+			return nil, errors.Errorf("encryption is not supported for local backup storage")
+		}
+		cfg.Dir = f.profile.DataDir
+	default:
+		cfg.Bucket = backupStorage.Bucket
+		cfg.Prefix = backupStorage.Prefix
+		cfg.Region = backupStorage.Region
+		cfg.Endpoint = backupStorage.Endpoint
+		credentialFile, err := f.getCredentialFilePath(backupStorage.CredentialFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.CredentialFile = credentialFile
+		if backupStorage.EncryptionKeyFile != "" {
+			encryptionKeyFile, err := f.getCredentialFilePath(backupStorage.EncryptionKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			key, err := storage.ReadEncryptionKeyFile(encryptionKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			cfg.EncryptionKey = key
+		}
+	}
+	// The storages are cached, so they must outlive the context of the caller, e.g. for refreshing the credentials.
+	s, err := storage.New(context.WithoutCancel(ctx), cfg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create %s backup storage", backupStorage.Backend)
+	}
+	f.storages[*backupStorage] = s
+	return s, nil
+}
+
+// getCredentialFilePath returns the path of the credential or encryption key file referenced by the backup storage.
+// The credential file of the default backup storage is specified by the command-line flag, and the others are in the credential directory.
+func (f *StorageFactory) getCredentialFilePath(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	if name == f.profile.BackupCredentialFile {
+		return name, nil
+	}
+	if err := ValidateCredentialFileName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(f.profile.DataDir, CredentialDir, name), nil
+}
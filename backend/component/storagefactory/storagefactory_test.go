@@ -0,0 +1,29 @@
+package storagefactory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCredentialFileName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "", wantErr: false},
+		{name: "gcs.json", wantErr: false},
+		{name: "/etc/passwd", wantErr: true},
+		{name: "../bytebase.key", wantErr: true},
+		{name: "a/b.json", wantErr: true},
+		{name: "..", wantErr: true},
+	}
+	for _, test := range tests {
+		err := ValidateCredentialFileName(test.name)
+		if test.wantErr {
+			require.Error(t, err, test.name)
+		} else {
+			require.NoError(t, err, test.name)
+		}
+	}
+}
@@ -258,6 +258,8 @@ type ActivitySQLEditorQueryPayload struct {
 	DatabaseName           string           `json:"databaseName"`
 	Error                  string           `json:"error"`
 	AdviceList             []advisor.Advice `json:"adviceList"`
+	// SessionID is the ID of the SQL Editor session running the statement, empty if the statement runs outside sessions.
+	SessionID string `json:"sessionId,omitempty"`
 }
 
 // ActivitySQLExportPayload is the API message payloads for the exported SQL info.
@@ -35,14 +35,34 @@ type BackupStorageBackend string
 const (
 	// BackupStorageBackendLocal is the local storage backend for a backup.
 	BackupStorageBackendLocal BackupStorageBackend = "LOCAL"
-	// BackupStorageBackendS3 is the AWS S3 storage backend for a backup. Not used yet.
+	// BackupStorageBackendS3 is the AWS S3 or S3-compatible storage backend for a backup.
 	BackupStorageBackendS3 BackupStorageBackend = "S3"
-	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup. Not used yet.
+	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup.
 	BackupStorageBackendGCS BackupStorageBackend = "GCS"
+	// BackupStorageBackendAzureBlob is the Azure Blob Storage storage backend for a backup.
+	BackupStorageBackendAzureBlob BackupStorageBackend = "AZURE_BLOB"
 	// BackupStorageBackendOSS is the AliCloud Object Storage Service (OSS) storage backend for a backup. Not used yet.
 	BackupStorageBackendOSS BackupStorageBackend = "OSS"
 )
 
+// BackupStorage is the storage the backups are written to.
+type BackupStorage struct {
+	Backend BackupStorageBackend `json:"backend"`
+	// Bucket is the bucket of S3 and GCS, or the container of Azure Blob Storage.
+	Bucket string `json:"bucket,omitempty"`
+	// Prefix is the path prefix of the backup files in the bucket.
+	Prefix string `json:"prefix,omitempty"`
+	Region string `json:"region,omitempty"`
+	// Endpoint is the custom endpoint URL of an S3-compatible service such as MinIO, or the service URL of Azure Blob Storage.
+	Endpoint string `json:"endpoint,omitempty"`
+	// CredentialFile is the name of the credential file in the backup-credential directory of the data directory,
+	// or the path of the credential file specified by the command-line flag for the default backup storage.
+	CredentialFile string `json:"credentialFile,omitempty"`
+	// EncryptionKeyFile is the name of the file in the backup-credential directory of the data directory containing the base64 encoded 256-bit key
+	// to encrypt the backup files on the client side. The backup files are not encrypted if empty.
+	EncryptionKeyFile string `json:"encryptionKeyFile,omitempty"`
+}
+
 // BinlogInfo is the binlog coordination for MySQL.
 type BinlogInfo struct {
 	FileName string `json:"fileName"`
@@ -62,4 +82,7 @@ type BackupPayload struct {
 	// It is recorded within the same transaction as the dump so that the binlog position is consistent with the dump.
 	// Please refer to https://github.com/bytebase/bytebase/blob/main/docs/design/pitr-mysql.md#full-backup for details.
 	BinlogInfo BinlogInfo `json:"binlogInfo"`
+
+	// Storage is the storage the backup is written to, nil for the backups in the local data directory.
+	Storage *BackupStorage `json:"storage,omitempty"`
 }
@@ -30,6 +30,18 @@ type Deployment struct {
 // DeploymentSpec is the API message for deployment specification.
 type DeploymentSpec struct {
 	Selector *LabelSelector `json:"selector"`
+	// Waves splits the matched databases into canary waves. The matched databases roll out in a single stage if nil.
+	Waves *DeploymentWaves `json:"waves,omitempty"`
+}
+
+// DeploymentWaves is the API message for the canary waves of a deployment.
+type DeploymentWaves struct {
+	// Percentages is the cumulative percentages of the matched databases rolled out in each wave, such as [1, 10, 100].
+	Percentages []int `json:"percentages"`
+	// MaxFailedTasks is the maximum number of failed tasks in a wave before the following waves are halted.
+	MaxFailedTasks int `json:"maxFailedTasks"`
+	// SoakSeconds is the time to wait after the tasks of a wave finish before the next wave starts.
+	SoakSeconds int64 `json:"soakSeconds"`
 }
 
 // LabelSelector is the API message for label selector.
@@ -39,8 +51,7 @@ type LabelSelector struct {
 }
 
 // OperatorType is the type of label selector requirement operator.
-// Valid operators are In, Exists.
-// Note: NotIn and DoesNotExist are not supported initially.
+// Valid operators are In, Exists, NotIn and DoesNotExist.
 type OperatorType string
 
 const (
@@ -48,6 +59,10 @@ const (
 	InOperatorType OperatorType = "In"
 	// ExistsOperatorType is the operator type for Exists.
 	ExistsOperatorType OperatorType = "Exists"
+	// NotInOperatorType is the operator type for NotIn.
+	NotInOperatorType OperatorType = "NotIn"
+	// DoesNotExistOperatorType is the operator type for DoesNotExist.
+	DoesNotExistOperatorType OperatorType = "DoesNotExist"
 )
 
 // LabelSelectorRequirement is the API message for label selector.
@@ -102,11 +117,11 @@ func ValidateAndGetDeploymentSchedule(payload string) (*DeploymentSchedule, erro
 		hasEnv := false
 		for _, e := range d.Spec.Selector.MatchExpressions {
 			switch e.Operator {
-			case InOperatorType:
+			case InOperatorType, NotInOperatorType:
 				if len(e.Values) == 0 {
 					return nil, common.Errorf(common.Invalid, "expression key %q with %q operator should have at least one value", e.Key, e.Operator)
 				}
-			case ExistsOperatorType:
+			case ExistsOperatorType, DoesNotExistOperatorType:
 				if len(e.Values) > 0 {
 					return nil, common.Errorf(common.Invalid, "expression key %q with %q operator shouldn't have values", e.Key, e.Operator)
 				}
@@ -123,6 +138,35 @@ func ValidateAndGetDeploymentSchedule(payload string) (*DeploymentSchedule, erro
 		if !hasEnv {
 			return nil, common.Errorf(common.Invalid, "deployment should contain %q label", EnvironmentLabelKey)
 		}
+		if w := d.Spec.Waves; w != nil {
+			if err := ValidateDeploymentWaves(w.Percentages, w.MaxFailedTasks, w.SoakSeconds); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return schedule, nil
 }
+
+// ValidateDeploymentWaves validates the canary waves of a deployment.
+func ValidateDeploymentWaves(percentages []int, maxFailedTasks int, soakSeconds int64) error {
+	if len(percentages) == 0 {
+		return common.Errorf(common.Invalid, "waves should have at least one percentage")
+	}
+	prev := 0
+	for _, p := range percentages {
+		if p <= prev || p > 100 {
+			return common.Errorf(common.Invalid, "wave percentages should be increasing in (0, 100], got %v", percentages)
+		}
+		prev = p
+	}
+	if prev != 100 {
+		return common.Errorf(common.Invalid, "the last wave percentage should be 100, got %d", prev)
+	}
+	if maxFailedTasks < 0 {
+		return common.Errorf(common.Invalid, "max failed tasks should not be negative, got %d", maxFailedTasks)
+	}
+	if soakSeconds < 0 {
+		return common.Errorf(common.Invalid, "soak time should not be negative, got %ds", soakSeconds)
+	}
+	return nil
+}
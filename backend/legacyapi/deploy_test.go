@@ -97,6 +97,26 @@ func TestGetDeploymentSchedule(t *testing.T) {
 			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod", "dev"]},{"key":"location","operator":"In","values":["us-central1","europe-west1"]}]}}}]}`,
 			nil,
 			"should must use operator",
+		}, {
+			"notInOperatorWithNoValue",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]},{"key":"location","operator":"NotIn"}]}}}]}`,
+			nil,
+			"operator should have at least one value",
+		}, {
+			"doesNotExistOperatorWithValues",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]},{"key":"location","operator":"DoesNotExist","values":["us-central1"]}]}}}]}`,
+			nil,
+			"operator shouldn't have values",
+		}, {
+			"wavesNotEndingAt100",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"waves":{"percentages":[1,10]}}}]}`,
+			nil,
+			"the last wave percentage should be 100",
+		}, {
+			"wavesNotIncreasing",
+			`{"deployments":[{"name":"deployment1","spec":{"selector":{"matchExpressions":[{"key":"bb.environment","operator":"In","values":["prod"]}]},"waves":{"percentages":[10,1,100]}}}]}`,
+			nil,
+			"wave percentages should be increasing",
 		},
 	}
 
@@ -50,6 +50,10 @@ const (
 	PolicyTypeDisableCopyData PolicyType = "bb.policy.disable-copy-data"
 	// PolicyTypeMaskingRule is the masking rule policy type.
 	PolicyTypeMaskingRule PolicyType = "bb.policy.masking-rule"
+	// PolicyTypeRolloutWindow is the rollout window policy type.
+	PolicyTypeRolloutWindow PolicyType = "bb.policy.rollout-window"
+	// PolicyTypeRedisKeyAccess is the Redis key access policy type.
+	PolicyTypeRedisKeyAccess PolicyType = "bb.policy.redis-key-access"
 
 	// PipelineApprovalValueManualNever means the pipeline will automatically be approved without user intervention.
 	PipelineApprovalValueManualNever PipelineApprovalValue = "MANUAL_APPROVAL_NEVER"
@@ -100,6 +104,8 @@ var (
 		PolicyTypeDisableCopyData:  {PolicyResourceTypeEnvironment},
 		PolicyTypeMaskingRule:      {PolicyResourceTypeWorkspace},
 		PolicyTypeMaskingException: {PolicyResourceTypeProject},
+		PolicyTypeRolloutWindow:    {PolicyResourceTypeEnvironment},
+		PolicyTypeRedisKeyAccess:   {PolicyResourceTypeProject},
 	}
 )
 
@@ -148,6 +154,9 @@ type BackupPlanPolicy struct {
 	Schedule BackupPlanPolicySchedule `json:"schedule"`
 	// RetentionPeriodTs is the minimum allowed period that backup data is kept for databases in an environment.
 	RetentionPeriodTs int `json:"retentionPeriodTs"`
+	// Storage is the storage the backups are written to.
+	// The backups are written to the default storage of the server if nil.
+	Storage *BackupStorage `json:"storage,omitempty"`
 }
 
 func (bp *BackupPlanPolicy) String() (string, error) {
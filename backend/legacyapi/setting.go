@@ -46,6 +46,8 @@ const (
 	SettingSemanticTypes SettingName = "bb.workspace.semantic-types"
 	// SettingMaskingAlgorithms is the setting name for masking algorithms.
 	SettingMaskingAlgorithms SettingName = "bb.workspace.masking-algorithms"
+	// SettingSCIMToken is the setting name for the bearer token of the SCIM provisioning clients.
+	SettingSCIMToken SettingName = "bb.workspace.scim-token"
 )
 
 // IMType is the type of IM.
@@ -131,6 +131,8 @@ type TaskDatabaseSchemaUpdatePayload struct {
 	SheetID         int    `json:"sheetId,omitempty"`
 	SchemaVersion   string `json:"schemaVersion,omitempty"`
 	SchemaGroupName string `json:"schemaGroupName,omitempty"`
+	// SheetRevision is the revision of the sheet pinned when the issue is approved.
+	SheetRevision int `json:"sheetRevision,omitempty"`
 }
 
 // TaskDatabaseSchemaUpdateSDLPayload is the task payload for database schema update (SDL).
@@ -142,6 +144,8 @@ type TaskDatabaseSchemaUpdateSDLPayload struct {
 
 	SheetID       int    `json:"sheetId,omitempty"`
 	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// SheetRevision is the revision of the sheet pinned when the issue is approved.
+	SheetRevision int `json:"sheetRevision,omitempty"`
 }
 
 // TaskDatabaseSchemaUpdateGhostSyncPayload is the task payload for gh-ost syncing ghost table.
@@ -153,6 +157,8 @@ type TaskDatabaseSchemaUpdateGhostSyncPayload struct {
 
 	SheetID       int    `json:"sheetId,omitempty"`
 	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// SheetRevision is the revision of the sheet pinned when the issue is approved.
+	SheetRevision int `json:"sheetRevision,omitempty"`
 	// SocketFileName is the socket file that gh-ost listens on.
 	// The name follows this template,
 	// `./tmp/gh-ost.{{ISSUE_ID}}.{{TASK_ID}}.{{DATABASE_ID}}.{{DATABASE_NAME}}.{{TABLE_NAME}}.sock`
@@ -188,6 +194,8 @@ type TaskDatabaseDataUpdatePayload struct {
 
 	SheetID       int    `json:"sheetId,omitempty"`
 	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// SheetRevision is the revision of the sheet pinned when the issue is approved.
+	SheetRevision int `json:"sheetRevision,omitempty"`
 
 	// MySQL rollback SQL related.
 
@@ -210,7 +218,10 @@ type TaskDatabaseDataUpdatePayload struct {
 	BinlogFileEnd   string `json:"binlogFileEnd,omitempty"`
 	BinlogPosStart  int64  `json:"binlogPosStart,omitempty"`
 	BinlogPosEnd    int64  `json:"binlogPosEnd,omitempty"`
-	RollbackError   string `json:"rollbackError,omitempty"`
+	// RollbackBackupList is the list of the backups of the rows affected by UPDATE and DELETE statements in execution order.
+	// It is only used for PostgreSQL to generate the rollback SQL statement now.
+	RollbackBackupList []*RollbackBackup `json:"rollbackBackupList,omitempty"`
+	RollbackError      string            `json:"rollbackError,omitempty"`
 	// RollbackSheetID is the generated rollback SQL statement for the DML task.
 	RollbackSheetID int `json:"rollbackSheetId,omitempty"`
 	// RollbackFromIssueID is the issue ID containing the original task from which the rollback SQL statement is generated for this task.
@@ -221,6 +232,19 @@ type TaskDatabaseDataUpdatePayload struct {
 	SchemaGroupName string `json:"schemaGroupName,omitempty"`
 }
 
+// RollbackBackup is the backup of the rows affected by an UPDATE or DELETE statement.
+type RollbackBackup struct {
+	// Type is the statement type, either UPDATE or DELETE.
+	Type string `json:"type,omitempty"`
+	// Schema and Table are the table changed by the statement.
+	Schema string `json:"schema,omitempty"`
+	Table  string `json:"table,omitempty"`
+	// BackupTable is the table holding the rows before the change.
+	BackupTable string `json:"backupTable,omitempty"`
+	// UpdatedColumns are the columns set by the UPDATE statement.
+	UpdatedColumns []string `json:"updatedColumns,omitempty"`
+}
+
 // TaskDatabaseBackupPayload is the task payload for database backup.
 type TaskDatabaseBackupPayload struct {
 	// Common fields
@@ -21,6 +21,18 @@ type VCSSQLReviewResult struct {
 	Content []string       `json:"content"`
 }
 
+// VCSSQLReviewResultFormat is the format of the SQL review result content.
+type VCSSQLReviewResultFormat string
+
+const (
+	// VCSSQLReviewResultFormatDefault is the format consumed by the SQL review CI template of the VCS.
+	VCSSQLReviewResultFormatDefault VCSSQLReviewResultFormat = ""
+	// VCSSQLReviewResultFormatSARIF is the SARIF 2.1.0 format for code scanning.
+	VCSSQLReviewResultFormatSARIF VCSSQLReviewResultFormat = "SARIF"
+	// VCSSQLReviewResultFormatJUnit is the JUnit XML format for test reports.
+	VCSSQLReviewResultFormatJUnit VCSSQLReviewResultFormat = "JUNIT"
+)
+
 // VCSSQLReviewRequest is the request from SQL review CI in VCS workflow.
 // In the VCS SQL review workflow, the CI will generate the request body then POST /hook/sql-review/:webhook_endpoint_id.
 type VCSSQLReviewRequest struct {
@@ -30,4 +42,7 @@ type VCSSQLReviewRequest struct {
 	// In GitHub, the URL should be "https://github.com". Docs: https://docs.github.com/en/actions/learn-github-actions/environment-variables
 	// In GitLab, the URL should be the base URL of the GitLab instance like "https://gitlab.bytebase.com". Docs: https://docs.gitlab.com/ee/ci/variables/predefined_variables.html
 	WebURL string `json:"webURL"`
+	// Format is the format of the result content, the default format depends on the VCS.
+	// The SARIF and JUnit content is a single document.
+	Format VCSSQLReviewResultFormat `json:"format"`
 }
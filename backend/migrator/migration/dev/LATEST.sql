@@ -19,7 +19,7 @@ CREATE TABLE idp (
   resource_id TEXT NOT NULL,
   name TEXT NOT NULL,
   domain TEXT NOT NULL,
-  type TEXT NOT NULL CONSTRAINT idp_type_check CHECK (type IN ('OAUTH2', 'OIDC', 'LDAP')),
+  type TEXT NOT NULL CONSTRAINT idp_type_check CHECK (type IN ('OAUTH2', 'OIDC', 'LDAP', 'SAML')),
   -- config stores the corresponding configuration of the IdP, which may vary depending on the type of the IdP.
   config JSONB NOT NULL DEFAULT '{}'
 );
@@ -304,7 +304,9 @@ CREATE TABLE project_webhook (
     type TEXT NOT NULL CHECK (type LIKE 'bb.plugin.webhook.%'),
     name TEXT NOT NULL,
     url TEXT NOT NULL,
-    activity_list TEXT ARRAY NOT NULL
+    activity_list TEXT ARRAY NOT NULL,
+    -- signing_secret is the secret to sign the requests of the custom webhook with HMAC-SHA256.
+    signing_secret TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_project_webhook_project_id ON project_webhook(project_id);
@@ -479,7 +481,7 @@ CREATE TABLE backup (
     name TEXT NOT NULL,
     status TEXT NOT NULL CHECK (status IN ('PENDING_CREATE', 'DONE', 'FAILED')),
     type TEXT NOT NULL CHECK (type IN ('MANUAL', 'AUTOMATIC', 'PITR')),
-    storage_backend TEXT NOT NULL CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS')),
+    storage_backend TEXT NOT NULL CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS', 'AZURE_BLOB')),
     migration_history_version TEXT NOT NULL,
     path TEXT NOT NULL,
     comment TEXT NOT NULL DEFAULT '',
@@ -561,7 +563,9 @@ CREATE TABLE stage (
     updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
     pipeline_id INTEGER NOT NULL REFERENCES pipeline (id),
     environment_id INTEGER NOT NULL REFERENCES environment (id),
-    name TEXT NOT NULL
+    name TEXT NOT NULL,
+    -- Stored as StagePayload (proto/store/stage.proto)
+    payload JSONB NOT NULL DEFAULT '{}'
 );
 
 CREATE INDEX idx_stage_pipeline_id ON stage(pipeline_id);
@@ -1150,6 +1154,21 @@ CREATE UNIQUE INDEX idx_sheet_organizer_unique_sheet_id_principal_id ON sheet_or
 
 CREATE INDEX idx_sheet_organizer_principal_id ON sheet_organizer(principal_id);
 
+-- sheet_revision table stores the immutable revisions of the sheet statement.
+CREATE TABLE sheet_revision (
+    id SERIAL PRIMARY KEY,
+    sheet_id INTEGER NOT NULL REFERENCES sheet (id) ON DELETE CASCADE,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    -- revision is the sequence number of the revision in the sheet, starting from 1.
+    revision INTEGER NOT NULL,
+    statement TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_sheet_revision_unique_sheet_id_revision ON sheet_revision(sheet_id, revision);
+
+ALTER SEQUENCE sheet_revision_id_seq RESTART WITH 101;
+
 -- external_approval stores approval instances of third party applications.
 CREATE TABLE external_approval ( 
     id SERIAL PRIMARY KEY,
@@ -1230,6 +1249,18 @@ UPDATE
     ON slow_query FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- slow_query_snapshot stores the cumulative query statistics of an instance for the engines which only expose the statistics since the statement is cached,
+-- e.g. sys.dm_exec_query_stats of SQL Server and V$SQL of Oracle. The slow query statistics of a day are the deltas from the baseline of the day.
+CREATE TABLE slow_query_snapshot (
+    instance_id INTEGER PRIMARY KEY REFERENCES instance (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    log_date_ts INTEGER NOT NULL,
+    -- baseline is the cumulative statistics at the beginning of the log date, keyed by the database name.
+    baseline JSONB NOT NULL DEFAULT '{}',
+    -- latest is the cumulative statistics at the last sync, keyed by the database name.
+    latest JSONB NOT NULL DEFAULT '{}'
+);
+
 CREATE TABLE db_group (
     id BIGSERIAL PRIMARY KEY,
     row_status row_status NOT NULL DEFAULT 'NORMAL',
@@ -1301,4 +1332,211 @@ CREATE TRIGGER update_changelist_updated_ts
 BEFORE
 UPDATE
     ON changelist FOR EACH ROW
-EXECUTE FUNCTION trigger_update_updated_ts();
\ No newline at end of file
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- replica_heartbeat table stores the heartbeats of Bytebase replicas running in high-availability mode.
+CREATE TABLE replica_heartbeat (
+    replica_id TEXT PRIMARY KEY,
+    heartbeat_ts BIGINT NOT NULL DEFAULT extract(epoch from now())
+);
+
+-- lease table stores the leases of background jobs held by Bytebase replicas running in high-availability mode.
+CREATE TABLE lease (
+    resource TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    instance_id INTEGER,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now())
+);
+
+CREATE INDEX idx_lease_holder ON lease(holder);
+
+CREATE INDEX idx_lease_instance_id ON lease(instance_id);
+
+-- access_token table stores the scoped personal access tokens of the users.
+CREATE TABLE access_token (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    title TEXT NOT NULL,
+    -- project_list is the list of project resource IDs the token is restricted to. Empty means all projects.
+    project_list TEXT ARRAY NOT NULL,
+    -- permission_list is the list of permissions the token is restricted to, such as "rollout:run". Empty means all permissions.
+    permission_list TEXT ARRAY NOT NULL,
+    expire_ts BIGINT NOT NULL,
+    last_used_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_access_token_principal_id ON access_token(principal_id);
+
+ALTER SEQUENCE access_token_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_access_token_updated_ts
+BEFORE
+UPDATE
+    ON access_token FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- webhook_delivery table stores the delivery attempts of the project webhooks.
+CREATE TABLE webhook_delivery (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    project_webhook_id INTEGER NOT NULL REFERENCES project_webhook (id) ON DELETE CASCADE,
+    activity_type TEXT NOT NULL,
+    -- status is PENDING until the delivery succeeds, or is moved to DEAD_LETTER after the maximum attempts.
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'SUCCEEDED', 'DEAD_LETTER')),
+    -- payload is the webhook context to deliver, excluding the URL and the signing secret.
+    payload JSONB NOT NULL DEFAULT '{}',
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+    last_attempt_ts BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_webhook_delivery_project_webhook_id ON webhook_delivery(project_webhook_id);
+
+CREATE INDEX idx_webhook_delivery_status_next_attempt_ts ON webhook_delivery(status, next_attempt_ts);
+
+ALTER SEQUENCE webhook_delivery_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_webhook_delivery_updated_ts
+BEFORE
+UPDATE
+    ON webhook_delivery FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- user_group table stores the user groups, which are created in the workspace or provisioned by the SCIM clients.
+CREATE TABLE user_group (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    name TEXT NOT NULL,
+    -- external_id is the identifier of the group in the provisioning client.
+    external_id TEXT NOT NULL DEFAULT '',
+    -- email is the identifier of the group in the IAM bindings, e.g. group:dba@example.com.
+    email TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    -- idp_id is the identity provider whose users sync the group membership on login.
+    idp_id TEXT NOT NULL DEFAULT '',
+    -- idp_group is the identifier of the group in the identity provider, e.g. the DN of the LDAP group.
+    idp_group TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_user_group_unique_name ON user_group(name);
+
+CREATE UNIQUE INDEX idx_user_group_unique_email ON user_group(email) WHERE email <> '';
+
+ALTER SEQUENCE user_group_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_user_group_updated_ts
+BEFORE
+UPDATE
+    ON user_group FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- user_group_member table stores the members of the user groups.
+CREATE TABLE user_group_member (
+    group_id INTEGER NOT NULL REFERENCES user_group (id) ON DELETE CASCADE,
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    -- idp_id is the identity provider that added the member, which is the only one that may remove the member on login.
+    idp_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (group_id, principal_id)
+);
+
+CREATE INDEX idx_user_group_member_principal_id ON user_group_member(principal_id);
+
+-- project_group_member table stores the user groups bound to the project roles.
+CREATE TABLE project_group_member (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    project_id INTEGER NOT NULL REFERENCES project (id),
+    role TEXT NOT NULL,
+    group_id INTEGER NOT NULL REFERENCES user_group (id) ON DELETE CASCADE,
+    condition JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_project_group_member_project_id ON project_group_member(project_id);
+
+CREATE INDEX idx_project_group_member_group_id ON project_group_member(group_id);
+
+ALTER SEQUENCE project_group_member_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_project_group_member_updated_ts
+BEFORE
+UPDATE
+    ON project_group_member FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- data_key table stores the data keys of the envelope encryption of the secrets, wrapped by the key provider.
+CREATE TABLE data_key (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    provider TEXT NOT NULL,
+    wrapped_key TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    -- deactivated_ts is when the data key was replaced by another active data key.
+    deactivated_ts BIGINT NOT NULL DEFAULT 0
+);
+
+-- The secrets are encrypted with the only active data key.
+CREATE UNIQUE INDEX idx_data_key_unique_active ON data_key(active) WHERE active;
+
+ALTER SEQUENCE data_key_id_seq RESTART WITH 101;
+
+-- saml_request table stores the pending SAML authentication requests, so that any replica can validate the response.
+-- A request is deleted once the user signs in with the response to it.
+CREATE TABLE saml_request (
+    id TEXT PRIMARY KEY,
+    idp_id TEXT NOT NULL,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    expires_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_saml_request_expires_ts ON saml_request(expires_ts);
+
+-- scim_user table stores the users managed by the SCIM provisioning clients, which are either provisioned by the clients
+-- or linked to the existing users by the external IDs. The SCIM clients cannot see or change the other users.
+CREATE TABLE scim_user (
+    principal_id INTEGER PRIMARY KEY REFERENCES principal (id),
+    external_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_scim_user_unique_external_id ON scim_user(external_id) WHERE external_id != '';
+
+-- export_job table stores the export jobs, so that any replica can get, cancel and download the jobs run by the others.
+CREATE TABLE export_job (
+    id TEXT PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    -- updated_ts is refreshed by the progress reports of the running job, a running job without reports is interrupted.
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id TEXT NOT NULL,
+    connection_database TEXT NOT NULL DEFAULT '',
+    statement TEXT NOT NULL,
+    format TEXT NOT NULL,
+    compression TEXT NOT NULL,
+    -- path is the path of the export file in the default backup storage.
+    path TEXT NOT NULL,
+    state TEXT NOT NULL CHECK (state IN ('RUNNING', 'DONE', 'FAILED', 'CANCELED')),
+    error TEXT NOT NULL DEFAULT '',
+    row_count BIGINT NOT NULL DEFAULT 0,
+    byte_count BIGINT NOT NULL DEFAULT 0,
+    finished_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_export_job_creator_id_state ON export_job(creator_id, state);
+
+CREATE TRIGGER update_export_job_updated_ts
+BEFORE
+UPDATE
+    ON export_job FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,17 @@
+-- replica_heartbeat table stores the heartbeats of Bytebase replicas running in high-availability mode.
+CREATE TABLE replica_heartbeat (
+    replica_id TEXT PRIMARY KEY,
+    heartbeat_ts BIGINT NOT NULL DEFAULT extract(epoch from now())
+);
+
+-- lease table stores the leases of background jobs held by Bytebase replicas running in high-availability mode.
+CREATE TABLE lease (
+    resource TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    instance_id INTEGER,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now())
+);
+
+CREATE INDEX idx_lease_holder ON lease(holder);
+
+CREATE INDEX idx_lease_instance_id ON lease(instance_id);
@@ -0,0 +1,27 @@
+-- access_token table stores the scoped personal access tokens of the users.
+CREATE TABLE access_token (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    title TEXT NOT NULL,
+    -- project_list is the list of project resource IDs the token is restricted to. Empty means all projects.
+    project_list TEXT ARRAY NOT NULL,
+    -- permission_list is the list of permissions the token is restricted to, such as "rollout:run". Empty means all permissions.
+    permission_list TEXT ARRAY NOT NULL,
+    expire_ts BIGINT NOT NULL,
+    last_used_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_access_token_principal_id ON access_token(principal_id);
+
+ALTER SEQUENCE access_token_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_access_token_updated_ts
+BEFORE
+UPDATE
+    ON access_token FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
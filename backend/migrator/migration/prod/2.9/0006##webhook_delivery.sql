@@ -0,0 +1,31 @@
+-- signing_secret is the secret to sign the requests of the custom webhook with HMAC-SHA256.
+ALTER TABLE project_webhook ADD COLUMN signing_secret TEXT NOT NULL DEFAULT '';
+
+-- webhook_delivery table stores the delivery attempts of the project webhooks.
+CREATE TABLE webhook_delivery (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    project_webhook_id INTEGER NOT NULL REFERENCES project_webhook (id) ON DELETE CASCADE,
+    activity_type TEXT NOT NULL,
+    -- status is PENDING until the delivery succeeds, or is moved to DEAD_LETTER after the maximum attempts.
+    status TEXT NOT NULL CHECK (status IN ('PENDING', 'SUCCEEDED', 'DEAD_LETTER')),
+    -- payload is the webhook context to deliver, excluding the URL and the signing secret.
+    payload JSONB NOT NULL DEFAULT '{}',
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    next_attempt_ts BIGINT NOT NULL DEFAULT 0,
+    last_attempt_ts BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_webhook_delivery_project_webhook_id ON webhook_delivery(project_webhook_id);
+
+CREATE INDEX idx_webhook_delivery_status_next_attempt_ts ON webhook_delivery(status, next_attempt_ts);
+
+ALTER SEQUENCE webhook_delivery_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_webhook_delivery_updated_ts
+BEFORE
+UPDATE
+    ON webhook_delivery FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,30 @@
+-- user_group table stores the user groups provisioned by the SCIM clients.
+CREATE TABLE user_group (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    name TEXT NOT NULL,
+    -- external_id is the identifier of the group in the provisioning client.
+    external_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_user_group_unique_name ON user_group(name);
+
+ALTER SEQUENCE user_group_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_user_group_updated_ts
+BEFORE
+UPDATE
+    ON user_group FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- user_group_member table stores the members of the user groups.
+CREATE TABLE user_group_member (
+    group_id INTEGER NOT NULL REFERENCES user_group (id) ON DELETE CASCADE,
+    principal_id INTEGER NOT NULL REFERENCES principal (id),
+    PRIMARY KEY (group_id, principal_id)
+);
+
+CREATE INDEX idx_user_group_member_principal_id ON user_group_member(principal_id);
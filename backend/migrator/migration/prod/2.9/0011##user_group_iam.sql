@@ -0,0 +1,29 @@
+ALTER TABLE user_group ADD COLUMN email TEXT NOT NULL DEFAULT '';
+ALTER TABLE user_group ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_user_group_unique_email ON user_group(email) WHERE email <> '';
+
+-- project_group_member table stores the user groups bound to the project roles.
+CREATE TABLE project_group_member (
+    id SERIAL PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    project_id INTEGER NOT NULL REFERENCES project (id),
+    role TEXT NOT NULL,
+    group_id INTEGER NOT NULL REFERENCES user_group (id) ON DELETE CASCADE,
+    condition JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_project_group_member_project_id ON project_group_member(project_id);
+
+CREATE INDEX idx_project_group_member_group_id ON project_group_member(group_id);
+
+ALTER SEQUENCE project_group_member_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_project_group_member_updated_ts
+BEFORE
+UPDATE
+    ON project_group_member FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
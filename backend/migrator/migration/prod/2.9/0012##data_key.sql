@@ -0,0 +1,13 @@
+-- data_key table stores the data keys of the envelope encryption of the secrets, wrapped by the key provider.
+CREATE TABLE data_key (
+    id SERIAL PRIMARY KEY,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    provider TEXT NOT NULL,
+    wrapped_key TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- The secrets are encrypted with the only active data key.
+CREATE UNIQUE INDEX idx_data_key_unique_active ON data_key(active) WHERE active;
+
+ALTER SEQUENCE data_key_id_seq RESTART WITH 101;
@@ -0,0 +1,18 @@
+-- sheet_revision table stores the immutable revisions of the sheet statement.
+CREATE TABLE sheet_revision (
+    id SERIAL PRIMARY KEY,
+    sheet_id INTEGER NOT NULL REFERENCES sheet (id) ON DELETE CASCADE,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    -- revision is the sequence number of the revision in the sheet, starting from 1.
+    revision INTEGER NOT NULL,
+    statement TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_sheet_revision_unique_sheet_id_revision ON sheet_revision(sheet_id, revision);
+
+ALTER SEQUENCE sheet_revision_id_seq RESTART WITH 101;
+
+-- The current statement of the existing sheets becomes their first revision.
+INSERT INTO sheet_revision (sheet_id, creator_id, created_ts, revision, statement)
+SELECT id, updater_id, updated_ts, 1, statement FROM sheet;
@@ -0,0 +1,11 @@
+-- slow_query_snapshot stores the cumulative query statistics of an instance for the engines which only expose the statistics since the statement is cached,
+-- e.g. sys.dm_exec_query_stats of SQL Server and V$SQL of Oracle. The slow query statistics of a day are the deltas from the baseline of the day.
+CREATE TABLE slow_query_snapshot (
+    instance_id INTEGER PRIMARY KEY REFERENCES instance (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    log_date_ts INTEGER NOT NULL,
+    -- baseline is the cumulative statistics at the beginning of the log date, keyed by the database name.
+    baseline JSONB NOT NULL DEFAULT '{}',
+    -- latest is the cumulative statistics at the last sync, keyed by the database name.
+    latest JSONB NOT NULL DEFAULT '{}'
+);
@@ -0,0 +1,10 @@
+-- saml_request table stores the pending SAML authentication requests, so that any replica can validate the response.
+-- A request is deleted once the user signs in with the response to it.
+CREATE TABLE saml_request (
+    id TEXT PRIMARY KEY,
+    idp_id TEXT NOT NULL,
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    expires_ts BIGINT NOT NULL
+);
+
+CREATE INDEX idx_saml_request_expires_ts ON saml_request(expires_ts);
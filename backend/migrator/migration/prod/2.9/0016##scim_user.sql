@@ -0,0 +1,11 @@
+-- scim_user table stores the users managed by the SCIM provisioning clients, which are either provisioned by the clients
+-- or linked to the existing users by the external IDs. The SCIM clients cannot see or change the other users.
+CREATE TABLE scim_user (
+    principal_id INTEGER PRIMARY KEY REFERENCES principal (id),
+    external_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_scim_user_unique_external_id ON scim_user(external_id) WHERE external_id != '';
+
+-- The SCIM token is stored as its SHA-256 hash.
+UPDATE setting SET value = encode(sha256(convert_to(value, 'UTF8')), 'hex') WHERE name = 'bb.workspace.scim-token' AND value != '';
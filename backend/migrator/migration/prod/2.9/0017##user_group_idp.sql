@@ -0,0 +1,7 @@
+-- The user groups linked to an identity provider sync the group membership of the users from the identity provider on login.
+ALTER TABLE user_group ADD COLUMN idp_id TEXT NOT NULL DEFAULT '';
+-- idp_group is the identifier of the group in the identity provider, e.g. the DN of the LDAP group.
+ALTER TABLE user_group ADD COLUMN idp_group TEXT NOT NULL DEFAULT '';
+
+-- idp_id is the identity provider that added the member, which is the only one that may remove the member on login.
+ALTER TABLE user_group_member ADD COLUMN idp_id TEXT NOT NULL DEFAULT '';
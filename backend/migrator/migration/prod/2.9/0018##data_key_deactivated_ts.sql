@@ -0,0 +1,3 @@
+-- deactivated_ts is when the data key was replaced by another active data key.
+-- The inactive data keys are deleted once no secrets are encrypted with them.
+ALTER TABLE data_key ADD COLUMN deactivated_ts BIGINT NOT NULL DEFAULT 0;
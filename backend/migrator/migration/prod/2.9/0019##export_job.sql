@@ -0,0 +1,28 @@
+-- export_job table stores the export jobs, so that any replica can get, cancel and download the jobs run by the others.
+CREATE TABLE export_job (
+    id TEXT PRIMARY KEY,
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    -- updated_ts is refreshed by the progress reports of the running job, a running job without reports is interrupted.
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id TEXT NOT NULL,
+    connection_database TEXT NOT NULL DEFAULT '',
+    statement TEXT NOT NULL,
+    format TEXT NOT NULL,
+    compression TEXT NOT NULL,
+    -- path is the path of the export file in the default backup storage.
+    path TEXT NOT NULL,
+    state TEXT NOT NULL CHECK (state IN ('RUNNING', 'DONE', 'FAILED', 'CANCELED')),
+    error TEXT NOT NULL DEFAULT '',
+    row_count BIGINT NOT NULL DEFAULT 0,
+    byte_count BIGINT NOT NULL DEFAULT 0,
+    finished_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_export_job_creator_id_state ON export_job(creator_id, state);
+
+CREATE TRIGGER update_export_job_updated_ts
+BEFORE
+UPDATE
+    ON export_job FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -238,5 +238,5 @@ func TestMigrationCompatibility(t *testing.T) {
 func TestGetCutoffVersion(t *testing.T) {
 	releaseVersion, err := getProdCutoffVersion()
 	require.NoError(t, err)
-	require.Equal(t, semver.MustParse("2.9.3"), releaseVersion)
+	require.Equal(t, semver.MustParse("2.9.19"), releaseVersion)
 }
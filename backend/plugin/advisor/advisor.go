@@ -216,6 +216,9 @@ const (
 	// MySQLStatementDMLDryRun is an advisor type for MySQL DML dry run.
 	MySQLStatementDMLDryRun Type = "bb.plugin.advisor.mysql.statement.dml-dry-run"
 
+	// MySQLCustomRule is an advisor type for MySQL user-defined rules written in CEL.
+	MySQLCustomRule Type = "bb.plugin.advisor.mysql.custom"
+
 	// PostgreSQL Advisor.
 
 	// PostgreSQLSyntax is an advisor type for PostgreSQL syntax.
@@ -335,6 +338,9 @@ const (
 	// PostgreSQLCollationAllowlist is an advisor type for PostgreSQL collation allowlist.
 	PostgreSQLCollationAllowlist Type = "bb.plugin.advisor.postgresql.collation.allowlist"
 
+	// PostgreSQLCustomRule is an advisor type for PostgreSQL user-defined rules written in CEL.
+	PostgreSQLCustomRule Type = "bb.plugin.advisor.postgresql.custom"
+
 	// Oracle Advisor.
 
 	// OracleSyntax is an advisor type for Oracle syntax.
@@ -537,6 +543,10 @@ type Context struct {
 	CurrentDatabase string
 	// CurrentSchema is the current schema. Special for Oracle.
 	CurrentSchema string
+	// EnvironmentID is the resource ID of the environment. Special for the custom rules.
+	EnvironmentID string
+	// ProjectID is the resource ID of the project. Special for the custom rules.
+	ProjectID string
 }
 
 // Advisor is the interface for advisor.
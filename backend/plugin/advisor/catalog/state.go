@@ -6,6 +6,7 @@ package catalog
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
@@ -350,6 +351,35 @@ func (table *TableState) CountIndex() int {
 	return len(table.indexSet)
 }
 
+// ColumnNameList returns the column names of the table in the order of positions.
+func (table *TableState) ColumnNameList() []string {
+	var columnList []*ColumnState
+	for _, column := range table.columnSet {
+		columnList = append(columnList, column)
+	}
+	sort.Slice(columnList, func(i, j int) bool {
+		if columnList[i].Position() != columnList[j].Position() {
+			return columnList[i].Position() < columnList[j].Position()
+		}
+		return columnList[i].name < columnList[j].name
+	})
+	var result []string
+	for _, column := range columnList {
+		result = append(result, column.name)
+	}
+	return result
+}
+
+// IndexNameList returns the index names of the table in alphabetical order.
+func (table *TableState) IndexNameList() []string {
+	var result []string
+	for name := range table.indexSet {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func (table *TableState) copy() *TableState {
 	return &TableState{
 		name:      table.name,
@@ -460,6 +490,14 @@ func (col *ColumnState) copy() *ColumnState {
 	}
 }
 
+// Position returns position for the column, or 0 if unknown.
+func (col *ColumnState) Position() int {
+	if col.position != nil {
+		return *col.position
+	}
+	return 0
+}
+
 // Nullable returns nullable for the column.
 func (col *ColumnState) Nullable() bool {
 	return col.nullable != nil && *col.nullable
@@ -37,6 +37,7 @@ const (
 	StatementAddColumnWithDefault    Code = 210
 	StatementAddCheckWithValidation  Code = 211
 	StatementAddNotNull              Code = 212
+	StatementFullTableScan           Code = 213
 
 	// 301 ～ 399 naming error code
 	// 301 table naming advisor error code.
@@ -136,6 +137,10 @@ const (
 
 	// 1301 ~ 1399 comment error code.
 	CommentTooLong Code = 1301
+
+	// 1401 ~ 1499 custom rule error code.
+	CustomRuleViolation       Code = 1401
+	CustomRuleEvaluationError Code = 1402
 )
 
 // Int returns the int type of code.
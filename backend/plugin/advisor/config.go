@@ -53,7 +53,13 @@ func MergeSQLReviewRules(override *SQLReviewConfigOverride) ([]*SQLReviewRule, e
 	}
 
 	ruleUpdateMap := make(map[SQLReviewRuleType]*SQLReviewRuleData)
+	var customRuleList []*SQLReviewRuleData
 	for _, rule := range override.RuleList {
+		// The custom rules aren't in the template, and there could be multiple custom rules.
+		if rule.Type == SchemaRuleCustom {
+			customRuleList = append(customRuleList, rule)
+			continue
+		}
 		ruleUpdateMap[rule.Type] = rule
 	}
 
@@ -68,6 +74,20 @@ func MergeSQLReviewRules(override *SQLReviewConfigOverride) ([]*SQLReviewRule, e
 		res = append(res, rule)
 	}
 
+	for _, customRule := range customRuleList {
+		if _, err := NewStatusBySQLReviewRuleLevel(customRule.Level); err != nil && customRule.Level != SchemaRuleLevelDisabled {
+			return nil, errors.Wrapf(err, "invalid level for custom rule %v", customRule.Payload["title"])
+		}
+		rule, err := mergeRule(customRule, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		res = append(res, rule)
+	}
+
 	return res, nil
 }
 
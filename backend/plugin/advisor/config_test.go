@@ -29,6 +29,11 @@ ruleList:
     payload:
       list:
         - name
+  - type: custom
+    level: WARNING
+    payload:
+      title: No truncate
+      expression: statement.type == "TRUNCATE" && environment_id != "maintenance"
 `
 
 func TestConfigOverride(t *testing.T) {
@@ -74,6 +79,14 @@ func TestConfigOverride(t *testing.T) {
 
 			assert.Equal(t, 1, len(payload.List))
 			assert.Equal(t, "name", payload.List[0])
+		case "custom":
+			assert.Equal(t, SchemaRuleLevelWarning, rule.Level)
+
+			payload, err := UnmarshalCustomRulePayload(rule.Payload)
+			require.NoError(t, err)
+
+			assert.Equal(t, "No truncate", payload.Title)
 		}
 	}
+	assert.Equal(t, SchemaRuleCustom, ruleList[len(ruleList)-1].Type)
 }
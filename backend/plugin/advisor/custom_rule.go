@@ -0,0 +1,160 @@
+package advisor
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+)
+
+// CustomRuleStatement is the typed view of a statement evaluated by the custom rules.
+type CustomRuleStatement struct {
+	// Type is the statement type, such as CREATE_TABLE, ALTER_TABLE and TRUNCATE.
+	Type string
+	Text string
+	Line int
+	// Schema is the schema of the statement table. It's the database for MySQL.
+	Schema string
+	Table  string
+	// ColumnList is the list of the columns defined, changed or referenced by the statement.
+	ColumnList []string
+	// IndexList is the list of the indexes and constraints created or dropped by the statement.
+	IndexList []string
+	// TableState is the state of the statement table after walking through the statements, nil if the table doesn't exist.
+	TableState *catalog.TableState
+}
+
+// CheckCustomRule evaluates the custom rule expression against each statement.
+// The failure to evaluate the expression against a statement is reported as an advice of the rule,
+// so that the other rules and statements are still reviewed.
+func CheckCustomRule(ctx Context, statementList []*CustomRuleStatement) ([]Advice, error) {
+	payload, err := UnmarshalCustomRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	level, err := NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	prg, err := compileCustomRuleExpression(payload.Expression)
+	if err != nil {
+		return []Advice{
+			{
+				Status:  level,
+				Code:    CustomRuleEvaluationError,
+				Title:   payload.Title,
+				Content: fmt.Sprintf("Failed to compile the custom rule %q: %v", payload.Title, err),
+			},
+		}, nil
+	}
+
+	var adviceList []Advice
+	for _, statement := range statementList {
+		violated, err := evalCustomRule(prg, getCustomRuleArgs(ctx.EnvironmentID, ctx.ProjectID, statement))
+		if err != nil {
+			adviceList = append(adviceList, Advice{
+				Status:  level,
+				Code:    CustomRuleEvaluationError,
+				Title:   payload.Title,
+				Content: fmt.Sprintf("Failed to evaluate the custom rule %q: %v, related statement: %q", payload.Title, err, statement.Text),
+				Line:    statement.Line,
+			})
+			continue
+		}
+		if violated {
+			adviceList = append(adviceList, Advice{
+				Status:  level,
+				Code:    CustomRuleViolation,
+				Title:   payload.Title,
+				Content: formatCustomRuleMessage(payload, statement),
+				Line:    statement.Line,
+			})
+		}
+	}
+
+	if len(adviceList) == 0 {
+		adviceList = append(adviceList, Advice{
+			Status:  Success,
+			Code:    Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return adviceList, nil
+}
+
+func evalCustomRule(prg cel.Program, args map[string]any) (bool, error) {
+	res, _, err := prg.Eval(args)
+	if err != nil {
+		return false, err
+	}
+	val, err := res.ConvertToNative(reflect.TypeOf(false))
+	if err != nil {
+		return false, errors.Wrap(err, "expect bool result")
+	}
+	boolVal, ok := val.(bool)
+	return ok && boolVal, nil
+}
+
+func compileCustomRuleExpression(expression string) (cel.Program, error) {
+	e, err := cel.NewEnv(common.SQLReviewCustomRuleCELAttributes...)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := e.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, errors.Wrapf(issues.Err(), "failed to compile custom rule expression %q", expression)
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, errors.Errorf("custom rule expression %q should return bool, but got %s", expression, ast.OutputType())
+	}
+	prg, err := e.Program(ast)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile custom rule expression %q", expression)
+	}
+	return prg, nil
+}
+
+func getCustomRuleArgs(environmentID string, projectID string, statement *CustomRuleStatement) map[string]any {
+	args := map[string]any{
+		"environment_id":    environmentID,
+		"project_id":        projectID,
+		"statement.type":    statement.Type,
+		"statement.text":    statement.Text,
+		"statement.schema":  statement.Schema,
+		"statement.table":   statement.Table,
+		"statement.columns": nonNilStringList(statement.ColumnList),
+		"statement.indexes": nonNilStringList(statement.IndexList),
+		"table.exists":      statement.TableState != nil,
+		"table.columns":     []string{},
+		"table.indexes":     []string{},
+	}
+	if statement.TableState != nil {
+		args["table.columns"] = nonNilStringList(statement.TableState.ColumnNameList())
+		args["table.indexes"] = nonNilStringList(statement.TableState.IndexNameList())
+	}
+	return args
+}
+
+func formatCustomRuleMessage(payload *CustomRulePayload, statement *CustomRuleStatement) string {
+	if payload.Message == "" {
+		return fmt.Sprintf("The statement violates the custom rule %q, related statement: %q", payload.Title, statement.Text)
+	}
+	return strings.NewReplacer(
+		StatementTypeTemplateToken, statement.Type,
+		SchemaNameTemplateToken, statement.Schema,
+		TableNameTemplateToken, statement.Table,
+	).Replace(payload.Message)
+}
+
+func nonNilStringList(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
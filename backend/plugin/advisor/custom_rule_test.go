@@ -0,0 +1,59 @@
+package advisor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCustomRule(t *testing.T) {
+	tests := []struct {
+		expression string
+		projectID  string
+		want       []Code
+	}{
+		{
+			expression: `project_id == "billing" && statement.type == "DELETE"`,
+			projectID:  "billing",
+			want:       []Code{CustomRuleViolation},
+		},
+		{
+			expression: `project_id == "billing" && statement.type == "DELETE"`,
+			projectID:  "inventory",
+			want:       []Code{Ok},
+		},
+		{
+			// Indexing out of range fails at runtime, only for the statements without columns.
+			expression: `statement.columns[0] == "tenant_id"`,
+			projectID:  "billing",
+			want:       []Code{CustomRuleViolation, CustomRuleEvaluationError},
+		},
+	}
+
+	statementList := []*CustomRuleStatement{
+		{Type: "INSERT", Text: "INSERT INTO t(tenant_id) VALUES (1)", Line: 1, Table: "t", ColumnList: []string{"tenant_id"}},
+		{Type: "DELETE", Text: "DELETE FROM t", Line: 2, Table: "t"},
+	}
+	for _, test := range tests {
+		payload, err := json.Marshal(CustomRulePayload{
+			Title:      "Custom",
+			Expression: test.expression,
+		})
+		require.NoError(t, err)
+		adviceList, err := CheckCustomRule(Context{
+			Rule: &SQLReviewRule{
+				Type:    SchemaRuleCustom,
+				Level:   SchemaRuleLevelWarning,
+				Payload: string(payload),
+			},
+			ProjectID: test.projectID,
+		}, statementList)
+		require.NoError(t, err)
+		var codes []Code
+		for _, advice := range adviceList {
+			codes = append(codes, advice.Code)
+		}
+		require.Equal(t, test.want, codes, test.expression)
+	}
+}
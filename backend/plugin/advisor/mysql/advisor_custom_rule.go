@@ -0,0 +1,180 @@
+package mysql
+
+import (
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+)
+
+var (
+	_ advisor.Advisor = (*CustomRuleAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.MySQL, advisor.MySQLCustomRule, &CustomRuleAdvisor{})
+	advisor.Register(db.TiDB, advisor.MySQLCustomRule, &CustomRuleAdvisor{})
+	advisor.Register(db.MariaDB, advisor.MySQLCustomRule, &CustomRuleAdvisor{})
+	advisor.Register(db.OceanBase, advisor.MySQLCustomRule, &CustomRuleAdvisor{})
+}
+
+// CustomRuleAdvisor is the advisor evaluating the user-defined rules written in CEL.
+type CustomRuleAdvisor struct {
+}
+
+// Check evaluates the user-defined rule against each statement.
+func (*CustomRuleAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmtList, ok := ctx.AST.([]ast.StmtNode)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to StmtNode")
+	}
+
+	var statementList []*advisor.CustomRuleStatement
+	for _, stmt := range stmtList {
+		for _, statement := range convertToCustomRuleStatements(ctx.Catalog, stmt) {
+			statement.Text = stmt.Text()
+			statement.Line = stmt.OriginTextPosition()
+			statementList = append(statementList, statement)
+		}
+	}
+	return advisor.CheckCustomRule(ctx, statementList)
+}
+
+// convertToCustomRuleStatements converts the statement to the typed views, one for each statement table.
+func convertToCustomRuleStatements(finder *catalog.Finder, node ast.StmtNode) []*advisor.CustomRuleStatement {
+	newStatement := func(tp string, table *ast.TableName) *advisor.CustomRuleStatement {
+		statement := &advisor.CustomRuleStatement{
+			Type:   tp,
+			Schema: finder.Final.DatabaseName(),
+		}
+		if table == nil {
+			return statement
+		}
+		if table.Schema.O != "" {
+			statement.Schema = table.Schema.O
+		}
+		statement.Table = table.Name.O
+		// The catalog only contains the current database.
+		if statement.Schema == finder.Final.DatabaseName() {
+			statement.TableState = finder.Final.FindTable(&catalog.TableFind{TableName: table.Name.O})
+		}
+		return statement
+	}
+
+	switch n := node.(type) {
+	case *ast.CreateDatabaseStmt:
+		return []*advisor.CustomRuleStatement{{Type: "CREATE_DATABASE", Schema: n.Name.O}}
+	case *ast.DropDatabaseStmt:
+		return []*advisor.CustomRuleStatement{{Type: "DROP_DATABASE", Schema: n.Name.O}}
+	case *ast.CreateTableStmt:
+		statement := newStatement("CREATE_TABLE", n.Table)
+		for _, column := range n.Cols {
+			statement.ColumnList = append(statement.ColumnList, column.Name.Name.O)
+		}
+		for _, constraint := range n.Constraints {
+			if constraint.Name != "" {
+				statement.IndexList = append(statement.IndexList, constraint.Name)
+			}
+		}
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.CreateViewStmt:
+		return []*advisor.CustomRuleStatement{newStatement("CREATE_VIEW", n.ViewName)}
+	case *ast.AlterTableStmt:
+		statement := newStatement("ALTER_TABLE", n.Table)
+		for _, spec := range n.Specs {
+			switch spec.Tp {
+			case ast.AlterTableAddColumns, ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+				for _, column := range spec.NewColumns {
+					statement.ColumnList = append(statement.ColumnList, column.Name.Name.O)
+				}
+			case ast.AlterTableDropColumn:
+				statement.ColumnList = append(statement.ColumnList, spec.OldColumnName.Name.O)
+			case ast.AlterTableRenameColumn:
+				statement.ColumnList = append(statement.ColumnList, spec.NewColumnName.Name.O)
+			case ast.AlterTableAddConstraint:
+				if spec.Constraint.Name != "" {
+					statement.IndexList = append(statement.IndexList, spec.Constraint.Name)
+				}
+			case ast.AlterTableDropIndex, ast.AlterTableDropForeignKey:
+				statement.IndexList = append(statement.IndexList, spec.Name)
+			case ast.AlterTableRenameIndex:
+				statement.IndexList = append(statement.IndexList, spec.ToKey.O)
+			}
+		}
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.DropTableStmt:
+		tp := "DROP_TABLE"
+		if n.IsView {
+			tp = "DROP_VIEW"
+		}
+		var result []*advisor.CustomRuleStatement
+		for _, table := range n.Tables {
+			result = append(result, newStatement(tp, table))
+		}
+		return result
+	case *ast.RenameTableStmt:
+		var result []*advisor.CustomRuleStatement
+		for _, pair := range n.TableToTables {
+			result = append(result, newStatement("RENAME_TABLE", pair.NewTable))
+		}
+		return result
+	case *ast.TruncateTableStmt:
+		return []*advisor.CustomRuleStatement{newStatement("TRUNCATE", n.Table)}
+	case *ast.CreateIndexStmt:
+		statement := newStatement("CREATE_INDEX", n.Table)
+		statement.IndexList = []string{n.IndexName}
+		for _, spec := range n.IndexPartSpecifications {
+			if spec.Column != nil {
+				statement.ColumnList = append(statement.ColumnList, spec.Column.Name.O)
+			}
+		}
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.DropIndexStmt:
+		statement := newStatement("DROP_INDEX", n.Table)
+		statement.IndexList = []string{n.IndexName}
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.InsertStmt:
+		tp := "INSERT"
+		if n.IsReplace {
+			tp = "REPLACE"
+		}
+		statement := newStatement(tp, getFirstTableName(n.Table))
+		for _, column := range n.Columns {
+			statement.ColumnList = append(statement.ColumnList, column.Name.O)
+		}
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.UpdateStmt:
+		statement := newStatement("UPDATE", getFirstTableName(n.TableRefs))
+		for _, assignment := range n.List {
+			statement.ColumnList = append(statement.ColumnList, assignment.Column.Name.O)
+		}
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.DeleteStmt:
+		return []*advisor.CustomRuleStatement{newStatement("DELETE", getFirstTableName(n.TableRefs))}
+	case *ast.SelectStmt:
+		return []*advisor.CustomRuleStatement{newStatement("SELECT", getFirstTableName(n.From))}
+	}
+	return []*advisor.CustomRuleStatement{newStatement("UNKNOWN", nil)}
+}
+
+// getFirstTableName returns the leftmost table in the table references, or nil if it's not a table.
+func getFirstTableName(refs *ast.TableRefsClause) *ast.TableName {
+	if refs == nil || refs.TableRefs == nil {
+		return nil
+	}
+	var node ast.ResultSetNode = refs.TableRefs
+	for {
+		switch n := node.(type) {
+		case *ast.Join:
+			node = n.Left
+		case *ast.TableSource:
+			node = n.Source
+		case *ast.TableName:
+			return n
+		default:
+			return nil
+		}
+	}
+}
@@ -116,6 +116,9 @@ func TestMySQLRules(t *testing.T) {
 
 		// advisor.SchemaRuleCollationAllowlist enforce the collation allowlist.
 		advisor.SchemaRuleCollationAllowlist,
+
+		// advisor.SchemaRuleCustom enforce the user-defined rule written in CEL.
+		advisor.SchemaRuleCustom,
 	}
 
 	for _, rule := range mysqlRules {
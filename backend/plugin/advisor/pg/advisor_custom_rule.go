@@ -0,0 +1,171 @@
+package pg
+
+import (
+	pgquery "github.com/pganalyze/pg_query_go/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/advisor"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/catalog"
+	"github.com/bytebase/bytebase/backend/plugin/advisor/db"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CustomRuleAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLCustomRule, &CustomRuleAdvisor{})
+}
+
+// CustomRuleAdvisor is the advisor evaluating the user-defined rules written in CEL.
+type CustomRuleAdvisor struct {
+}
+
+// Check evaluates the user-defined rule against each statement.
+func (*CustomRuleAdvisor) Check(ctx advisor.Context, _ string) ([]advisor.Advice, error) {
+	stmts, ok := ctx.AST.([]ast.Node)
+	if !ok {
+		return nil, errors.Errorf("failed to convert to Node")
+	}
+
+	var statementList []*advisor.CustomRuleStatement
+	for _, stmt := range stmts {
+		for _, statement := range convertToCustomRuleStatements(ctx.Catalog, stmt) {
+			statement.Text = stmt.Text()
+			statement.Line = stmt.LastLine()
+			statementList = append(statementList, statement)
+		}
+	}
+	return advisor.CheckCustomRule(ctx, statementList)
+}
+
+// convertToCustomRuleStatements converts the statement to the typed views, one for each statement table.
+func convertToCustomRuleStatements(finder *catalog.Finder, node ast.Node) []*advisor.CustomRuleStatement {
+	newStatement := func(tp string, schema string, table string) *advisor.CustomRuleStatement {
+		statement := &advisor.CustomRuleStatement{
+			Type: tp,
+		}
+		if table == "" {
+			statement.Schema = schema
+			return statement
+		}
+		statement.Schema = normalizeSchemaName(schema)
+		statement.Table = table
+		statement.TableState = finder.Final.FindTable(&catalog.TableFind{
+			SchemaName: statement.Schema,
+			TableName:  table,
+		})
+		return statement
+	}
+	newTableStatement := func(tp string, table *ast.TableDef) *advisor.CustomRuleStatement {
+		if table == nil {
+			return newStatement(tp, "", "")
+		}
+		return newStatement(tp, table.Schema, table.Name)
+	}
+
+	switch n := node.(type) {
+	case *ast.CreateSchemaStmt:
+		return []*advisor.CustomRuleStatement{newStatement("CREATE_SCHEMA", n.Name, "")}
+	case *ast.DropSchemaStmt:
+		var result []*advisor.CustomRuleStatement
+		for _, schema := range n.SchemaList {
+			result = append(result, newStatement("DROP_SCHEMA", schema, ""))
+		}
+		return result
+	case *ast.CreateTableStmt:
+		tp := "CREATE_TABLE"
+		if n.Name.Type == ast.TableTypeView {
+			tp = "CREATE_VIEW"
+		}
+		statement := newTableStatement(tp, n.Name)
+		for _, column := range n.ColumnList {
+			statement.ColumnList = append(statement.ColumnList, column.ColumnName)
+			statement.IndexList = append(statement.IndexList, getConstraintNameList(column.ConstraintList)...)
+		}
+		statement.IndexList = append(statement.IndexList, getConstraintNameList(n.ConstraintList)...)
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.AlterTableStmt:
+		statement := newTableStatement("ALTER_TABLE", n.Table)
+		for _, item := range n.AlterItemList {
+			switch item := item.(type) {
+			case *ast.AddColumnListStmt:
+				for _, column := range item.ColumnList {
+					statement.ColumnList = append(statement.ColumnList, column.ColumnName)
+					statement.IndexList = append(statement.IndexList, getConstraintNameList(column.ConstraintList)...)
+				}
+			case *ast.DropColumnStmt:
+				statement.ColumnList = append(statement.ColumnList, item.ColumnName)
+			case *ast.AlterColumnTypeStmt:
+				statement.ColumnList = append(statement.ColumnList, item.ColumnName)
+			case *ast.RenameColumnStmt:
+				statement.ColumnList = append(statement.ColumnList, item.NewName)
+			case *ast.AddConstraintStmt:
+				statement.IndexList = append(statement.IndexList, getConstraintNameList([]*ast.ConstraintDef{item.Constraint})...)
+			case *ast.DropConstraintStmt:
+				statement.IndexList = append(statement.IndexList, item.ConstraintName)
+			case *ast.RenameTableStmt:
+				statement = newStatement("RENAME_TABLE", n.Table.Schema, item.NewName)
+			}
+		}
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.DropTableStmt:
+		var result []*advisor.CustomRuleStatement
+		for _, table := range n.TableList {
+			result = append(result, newTableStatement("DROP_TABLE", table))
+		}
+		return result
+	case *ast.CreateIndexStmt:
+		statement := newTableStatement("CREATE_INDEX", n.Index.Table)
+		statement.IndexList = []string{n.Index.Name}
+		for _, key := range n.Index.KeyList {
+			if key.Type == ast.IndexKeyTypeColumn {
+				statement.ColumnList = append(statement.ColumnList, key.Key)
+			}
+		}
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.DropIndexStmt:
+		var result []*advisor.CustomRuleStatement
+		for _, index := range n.IndexList {
+			statement := newTableStatement("DROP_INDEX", index.Table)
+			statement.IndexList = []string{index.Name}
+			result = append(result, statement)
+		}
+		return result
+	case *ast.InsertStmt:
+		statement := newTableStatement("INSERT", n.Table)
+		statement.ColumnList = n.ColumnList
+		return []*advisor.CustomRuleStatement{statement}
+	case *ast.UpdateStmt:
+		return []*advisor.CustomRuleStatement{newTableStatement("UPDATE", n.Table)}
+	case *ast.DeleteStmt:
+		return []*advisor.CustomRuleStatement{newTableStatement("DELETE", n.Table)}
+	case *ast.SelectStmt:
+		return []*advisor.CustomRuleStatement{newStatement("SELECT", "", "")}
+	case *ast.UnconvertedStmt:
+		// TRUNCATE isn't converted to our AST, so we use pg_query_go to parse it.
+		res, err := pgquery.Parse(n.Text())
+		if err != nil || len(res.Stmts) != 1 || res.Stmts[0].Stmt.GetTruncateStmt() == nil {
+			break
+		}
+		var result []*advisor.CustomRuleStatement
+		for _, relation := range res.Stmts[0].Stmt.GetTruncateStmt().Relations {
+			if rangeVar := relation.GetRangeVar(); rangeVar != nil {
+				result = append(result, newStatement("TRUNCATE", rangeVar.Schemaname, rangeVar.Relname))
+			}
+		}
+		return result
+	}
+	return []*advisor.CustomRuleStatement{newStatement("UNKNOWN", "", "")}
+}
+
+func getConstraintNameList(constraintList []*ast.ConstraintDef) []string {
+	var result []string
+	for _, constraint := range constraintList {
+		if constraint.Name != "" {
+			result = append(result, constraint.Name)
+		}
+	}
+	return result
+}
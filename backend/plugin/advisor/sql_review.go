@@ -177,6 +177,9 @@ const (
 	// SchemaRuleCommentLength limit comment length.
 	SchemaRuleCommentLength SQLReviewRuleType = "system.comment.length"
 
+	// SchemaRuleCustom enforces the user-defined rule written in CEL.
+	SchemaRuleCustom SQLReviewRuleType = "custom"
+
 	// TableNameTemplateToken is the token for table name.
 	TableNameTemplateToken = "{{table}}"
 	// ColumnListTemplateToken is the token for column name list.
@@ -189,6 +192,10 @@ const (
 	ReferencedTableNameTemplateToken = "{{referenced_table}}"
 	// ReferencedColumnNameTemplateToken is the token for referenced column name.
 	ReferencedColumnNameTemplateToken = "{{referenced_column}}"
+	// StatementTypeTemplateToken is the token for statement type.
+	StatementTypeTemplateToken = "{{statement_type}}"
+	// SchemaNameTemplateToken is the token for schema name.
+	SchemaNameTemplateToken = "{{schema}}"
 
 	// defaultNameLengthLimit is the default length limit for naming rules.
 	// PostgreSQL has it's own naming length limit, will auto slice the name to make sure its length <= 63
@@ -293,6 +300,14 @@ func (rule *SQLReviewRule) Validate() error {
 		if _, err := UnmarshalNamingCaseRulePayload(rule.Payload); err != nil {
 			return err
 		}
+	case SchemaRuleCustom:
+		payload, err := UnmarshalCustomRulePayload(rule.Payload)
+		if err != nil {
+			return err
+		}
+		if _, err := compileCustomRuleExpression(payload.Expression); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -330,6 +345,15 @@ type NamingCaseRulePayload struct {
 	Upper bool `json:"upper"`
 }
 
+// CustomRulePayload is the payload for custom rule.
+type CustomRulePayload struct {
+	Title string `json:"title"`
+	// Expression is the CEL expression evaluated per statement, the statement violates the rule if it's true.
+	Expression string `json:"expression"`
+	// Message is the advice content template, the template tokens are {{statement_type}}, {{schema}} and {{table}}.
+	Message string `json:"message"`
+}
+
 // UnmarshalNamingRulePayloadAsRegexp will unmarshal payload to NamingRulePayload and compile it as regular expression.
 func UnmarshalNamingRulePayloadAsRegexp(payload string) (*regexp.Regexp, int, error) {
 	var nr NamingRulePayload
@@ -467,6 +491,21 @@ func UnmarshalNamingCaseRulePayload(payload string) (*NamingCaseRulePayload, err
 	return &ncr, nil
 }
 
+// UnmarshalCustomRulePayload will unmarshal payload to CustomRulePayload.
+func UnmarshalCustomRulePayload(payload string) (*CustomRulePayload, error) {
+	var cr CustomRulePayload
+	if err := json.Unmarshal([]byte(payload), &cr); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal custom rule payload %q", payload)
+	}
+	if cr.Title == "" {
+		return nil, errors.Errorf("invalid custom rule payload %q, title cannot be empty", payload)
+	}
+	if cr.Expression == "" {
+		return nil, errors.Errorf("invalid custom rule payload %q, expression cannot be empty", payload)
+	}
+	return &cr, nil
+}
+
 // SQLReviewCheckContext is the context for SQL review check.
 type SQLReviewCheckContext struct {
 	Charset   string
@@ -475,6 +514,10 @@ type SQLReviewCheckContext struct {
 	Catalog   catalog.Catalog
 	Driver    *sql.DB
 	Context   context.Context
+	// EnvironmentID is the resource ID of the environment, used by the custom rules.
+	EnvironmentID string
+	// ProjectID is the resource ID of the project, used by the custom rules.
+	ProjectID string
 
 	// Snowflake specific fields
 	CurrentDatabase string
@@ -769,6 +812,8 @@ func SQLReviewCheck(statements string, ruleList []*SQLReviewRule, checkContext S
 				Context:         checkContext.Context,
 				CurrentSchema:   checkContext.CurrentSchema,
 				CurrentDatabase: checkContext.CurrentDatabase,
+				EnvironmentID:   checkContext.EnvironmentID,
+				ProjectID:       checkContext.ProjectID,
 			},
 			statements,
 		)
@@ -1490,6 +1535,13 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 		if engine == db.Postgres {
 			return PostgreSQLCommentConvention, nil
 		}
+	case SchemaRuleCustom:
+		switch engine {
+		case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+			return MySQLCustomRule, nil
+		case db.Postgres:
+			return PostgreSQLCustomRule, nil
+		}
 	}
 	return Fake, errors.Errorf("unknown SQL review rule type %v for %v", ruleType, engine)
 }
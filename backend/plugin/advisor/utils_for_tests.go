@@ -453,6 +453,12 @@ func SetDefaultSQLReviewRulePayload(ruleTp SQLReviewRuleType, dbType db.Type) (s
 		payload, err = json.Marshal(NamingCaseRulePayload{
 			Upper: true,
 		})
+	case SchemaRuleCustom:
+		payload, err = json.Marshal(CustomRulePayload{
+			Title:      "Tenant convention",
+			Expression: `(statement.type in ["CREATE_TABLE", "ALTER_TABLE"] && !("tenant_id" in table.columns)) || (statement.type == "TRUNCATE" && environment_id != "maintenance")`,
+			Message:    "{{statement_type}} on table {{table}} in {{schema}} violates the tenant convention",
+		})
 	default:
 		return "", errors.Errorf("unknown SQL review type for default payload: %s", ruleTp)
 	}
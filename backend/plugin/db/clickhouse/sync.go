@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/pkg/errors"
@@ -178,12 +180,82 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 	}, nil
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// SyncSlowQuery syncs the slow query from system.query_log.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	query := `
+		SELECT
+			event_time,
+			query_duration_ms,
+			read_rows,
+			result_rows,
+			current_database,
+			query,
+			normalizeQuery(query)
+		FROM system.query_log
+		WHERE
+			type = 'QueryFinish'
+			AND is_initial_query
+			AND event_date = toDate($1)
+			AND query_duration_ms >= $2`
+	rows, err := driver.db.QueryContext(ctx, query, logDateTs.Format("2006-01-02"), db.SlowQueryMinimumQueryTime.Milliseconds())
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var logs []*util.SlowQueryLog
+	for rows.Next() {
+		var eventTime time.Time
+		var queryDuration, readRows, resultRows uint64
+		log := &util.SlowQueryLog{
+			Details: &storepb.SlowQueryDetails{},
+		}
+		if err := rows.Scan(
+			&eventTime,
+			&queryDuration,
+			&readRows,
+			&resultRows,
+			&log.Database,
+			&log.Details.SqlText,
+			&log.Fingerprint,
+		); err != nil {
+			return nil, err
+		}
+		queryTime := time.Duration(queryDuration) * time.Millisecond
+		// event_time is the time when the query finished.
+		log.Details.StartTime = timestamppb.New(eventTime.Add(-queryTime))
+		log.Details.QueryTime = durationpb.New(queryTime)
+		log.Details.LockTime = durationpb.New(0)
+		log.Details.RowsSent = int64(resultRows)
+		log.Details.RowsExamined = int64(readRows)
+		logs = util.SampleSlowQueryLog(logs, log)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+
+	return util.AnalyzeSlowQueryLogs(logs), nil
 }
 
 // CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	logQueriesQuery := `SELECT value FROM system.settings WHERE name = 'log_queries'`
+	var logQueries string
+	if err := driver.db.QueryRowContext(ctx, logQueriesQuery).Scan(&logQueries); err != nil {
+		return util.FormatErrorWithQuery(err, logQueriesQuery)
+	}
+	if logQueries != "1" {
+		return errors.New("query log is not enabled: log_queries = " + logQueries)
+	}
+
+	// system.query_log is created when the query log is flushed for the first time.
+	queryLogQuery := `SELECT count() FROM system.tables WHERE database = 'system' AND name = 'query_log'`
+	var count uint64
+	if err := driver.db.QueryRowContext(ctx, queryLogQuery).Scan(&count); err != nil {
+		return util.FormatErrorWithQuery(err, queryLogQuery)
+	}
+	if count == 0 {
+		return errors.New("system.query_log does not exist, please check the query_log section in the server configuration")
+	}
+	return nil
 }
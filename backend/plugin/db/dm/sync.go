@@ -359,11 +359,13 @@ func getViews(txn *sql.Tx, schemaName string) (map[string][]*storepb.ViewMetadat
 }
 
 // SyncSlowQuery syncs the slow query.
+// It is not supported: the SQL monitoring views of DM, e.g. V$LONG_EXEC_SQLS, don't record the schema of the statements,
+// so the slow queries cannot be attributed to the databases (i.e. schemas) synced by Bytebase.
 func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+	return nil, errors.Errorf("slow query is not supported for DM because the statements cannot be attributed to schemas")
 }
 
 // CheckSlowQueryLogEnabled checks if slow query log is enabled.
 func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+	return errors.Errorf("slow query is not supported for DM")
 }
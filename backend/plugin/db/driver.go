@@ -15,6 +15,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
@@ -65,6 +66,8 @@ const (
 	SlowQueryMaxSamplePerFingerprint = 100
 	// SlowQueryMaxSamplePerDay is the max number of slow query samples per day.
 	SlowQueryMaxSamplePerDay = 10000
+	// SlowQueryMinimumQueryTime is the minimum query time of slow queries for the engines without slow query thresholds.
+	SlowQueryMinimumQueryTime = time.Second
 )
 
 // User is the database user.
@@ -366,7 +369,10 @@ type ConnectionConfig struct {
 	Port     string
 	Username string
 	Password string
-	Database string
+	// RefreshPassword returns the password for each new connection of the pool if it's set, such as the short-lived IAM authentication token.
+	// It's only supported for MySQL and Postgres now. For MySQL, the password is sent in cleartext over TLS verifying the server by the SSL CA.
+	RefreshPassword func(ctx context.Context) (string, error)
+	Database        string
 	// The database used to connect.
 	// It's only set for Redshift datashare database.
 	ConnectionDatabase string
@@ -417,6 +423,21 @@ type QueryContext struct {
 	CurrentDatabase string
 	// ShareDB is for Redshift.
 	ShareDB bool
+	// InTransaction is set if the connection has an open transaction, e.g. the connection pinned by a SQL Editor session.
+	// The query runs in the open transaction instead of a new one, so that it sees the uncommitted changes.
+	InTransaction bool
+	// RowStreamer receives the rows one by one instead of collecting them in the result if set,
+	// so that a large result doesn't have to be kept in memory.
+	// It is only supported by the drivers querying through database/sql, the others still return the rows in the result.
+	RowStreamer RowStreamer
+}
+
+// RowStreamer receives a query result row by row.
+type RowStreamer interface {
+	// Begin is called with the columns of the result before the rows.
+	Begin(header *v1pb.QueryResult) error
+	// Row is called with each row of the result after masking.
+	Row(row *v1pb.QueryRow) error
 }
 
 // DatabaseRoleMessage is the API message for database role.
@@ -534,6 +555,9 @@ func Open(ctx context.Context, dbType Type, driverConfig DriverConfig, connectio
 type ExecuteOptions struct {
 	BeginFunc          func(ctx context.Context, conn *sql.Conn) error
 	EndTransactionFunc func(tx *sql.Tx) error
+	// BeforeStatementFunc returns the statement to execute right before the given statement in the same transaction.
+	// It is only supported by PostgreSQL to back up the rows affected by data changes now.
+	BeforeStatementFunc func(stmt string) (string, error)
 }
 
 // FormatParamNameInQuestionMark formats the param name in question mark.
@@ -601,10 +625,16 @@ type TableSchema struct {
 type ColumnInfo struct {
 	Name         string
 	MaskingLevel storepb.MaskingLevel
+	// Masker is the masker resolved from the semantic type of the column for the masking level.
+	// Nil means using the default masker of the masking level.
+	Masker masker.Masker
 }
 
 // SensitiveField is the struct about SELECT fields.
 type SensitiveField struct {
 	Name         string
 	MaskingLevel storepb.MaskingLevel
+	// Masker is only set if the field is selected from a column directly.
+	// Nil means using the default masker of the masking level.
+	Masker masker.Masker
 }
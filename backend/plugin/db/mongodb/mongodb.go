@@ -171,9 +171,29 @@ func getMongoDBConnectionURI(connConfig db.ConnectionConfig) string {
 }
 
 // QueryConn queries a SQL statement in a given connection.
+// The find, findOne, aggregate, countDocuments and distinct queries run by the driver with the masking applied,
+// the other statements are evaluated by mongosh unless the query is read-only.
 func (driver *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, queryContext *db.QueryContext) ([]*v1pb.QueryResult, error) {
-	simpleStatement := isMongoStatement(statement)
+	if queryContext == nil {
+		queryContext = &db.QueryContext{}
+	}
 	startTime := time.Now()
+	query, parseErr := parser.ParseMongoQuery(statement)
+	if parseErr == nil {
+		result, err := driver.runQuery(ctx, query, queryContext)
+		if err != nil {
+			return nil, err
+		}
+		result.Latency = durationpb.New(time.Since(startTime))
+		result.Statement = statement
+		return []*v1pb.QueryResult{result}, nil
+	}
+	if queryContext.ReadOnly {
+		// The arbitrary JavaScript evaluated by mongosh can't be guaranteed to be read-only, and its result can't be masked.
+		return nil, parseErr
+	}
+
+	simpleStatement := isMongoStatement(statement)
 	connectionURI := getMongoDBConnectionURI(driver.connCfg)
 	// For MongoDB query, we execute the statement in mongosh with flag --eval for the following reasons:
 	// 1. Query always short, so it's safe to execute in the command line.
@@ -2,9 +2,11 @@ package mongodb
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/durationpb"
 
@@ -211,3 +213,50 @@ func TestGetOrderedColumns(t *testing.T) {
 		a.Equal(tt.wantColumnIndexMap, gotMap)
 	}
 }
+
+func TestConvertProfileEntry(t *testing.T) {
+	tests := []struct {
+		entry           *profileEntry
+		wantFingerprint string
+		wantText        string
+	}{
+		{
+			entry: &profileEntry{
+				Op: "query",
+				NS: "sample.users",
+				Command: bson.D{
+					{Key: "find", Value: "users"},
+					{Key: "filter", Value: bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}}},
+					{Key: "lsid", Value: bson.D{{Key: "id", Value: "session"}}},
+					{Key: "$db", Value: "sample"},
+				},
+				Millis: 1500,
+			},
+			wantFingerprint: `query sample.users {"find":"?","filter":{"age":{"$gt":"?"}}}`,
+			wantText:        `{"find":"users","filter":{"age":{"$gt":18}}}`,
+		},
+		{
+			entry: &profileEntry{
+				Op: "command",
+				NS: "sample.users",
+				Command: bson.D{
+					{Key: "aggregate", Value: "users"},
+					{Key: "pipeline", Value: bson.A{bson.D{{Key: "$match", Value: bson.D{{Key: "name", Value: "alice"}}}}, bson.D{{Key: "$limit", Value: 10}}}},
+				},
+				Millis: 2000,
+			},
+			wantFingerprint: `command sample.users {"aggregate":"?","pipeline":[{"$match":{"name":"?"}}]}`,
+			wantText:        `{"aggregate":"users","pipeline":[{"$match":{"name":"alice"}},{"$limit":10}]}`,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		log, err := convertProfileEntry("sample", test.entry)
+		a.NoError(err)
+		a.Equal("sample", log.Database)
+		a.Equal(test.wantFingerprint, log.Fingerprint)
+		a.Equal(test.wantText, log.Details.SqlText)
+		a.Equal(time.Duration(test.entry.Millis)*time.Millisecond, log.Details.QueryTime.AsDuration())
+	}
+}
@@ -0,0 +1,596 @@
+package mongodb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+var (
+	// writeStages are the aggregation stages writing to collections, which are rejected in the queries.
+	writeStages = map[string]bool{"$out": true, "$merge": true}
+	// maskPreservingStages are the aggregation stages keeping the field paths of the documents,
+	// only these stages are allowed on the collections with sensitive fields so that the masking can't be bypassed by renaming the fields.
+	maskPreservingStages = map[string]bool{"$match": true, "$sort": true, "$limit": true, "$skip": true, "$sample": true, "$count": true}
+	// lookupStages are the aggregation stages reading the documents of the other collections,
+	// the pipeline is rejected if any collection they read has sensitive fields, which the masking of the queried collection doesn't cover.
+	lookupStages = map[string]bool{"$lookup": true, "$unionWith": true, "$graphLookup": true}
+)
+
+// runQuery runs the parsed query by the driver and converts the documents to the query result,
+// the nested documents are flattened into the columns of the dotted field paths.
+func (driver *Driver) runQuery(ctx context.Context, query *parser.MongoQuery, queryContext *db.QueryContext) (*v1pb.QueryResult, error) {
+	databaseName := driver.databaseName
+	if queryContext.CurrentDatabase != "" {
+		databaseName = queryContext.CurrentDatabase
+	}
+	collection := driver.client.Database(databaseName).Collection(query.Collection)
+	fieldMasker := newFieldMasker(queryContext, databaseName, query.Collection)
+
+	switch query.Method {
+	case parser.MongoFind, parser.MongoFindOne:
+		filter, projection, err := getFindArguments(query.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		opts := options.Find()
+		var limit int64
+		if query.Method == parser.MongoFindOne {
+			limit = 1
+		}
+		for _, method := range query.CursorMethods {
+			if len(method.Arguments) != 1 {
+				return nil, errors.Errorf("%s expects 1 argument", method.Name)
+			}
+			switch method.Name {
+			case "sort":
+				var sort bson.D
+				if err := decodeArgument(method.Arguments[0], &sort); err != nil {
+					return nil, errors.Wrap(err, "invalid sort")
+				}
+				opts.SetSort(sort)
+			case "skip":
+				var skip int64
+				if err := decodeArgument(method.Arguments[0], &skip); err != nil {
+					return nil, errors.Wrap(err, "invalid skip")
+				}
+				opts.SetSkip(skip)
+			case "limit":
+				if err := decodeArgument(method.Arguments[0], &limit); err != nil {
+					return nil, errors.Wrap(err, "invalid limit")
+				}
+				// A negative limit returns a single batch in mongosh.
+				if limit < 0 {
+					limit = -limit
+				}
+			case "projection":
+				if err := decodeArgument(method.Arguments[0], &projection); err != nil {
+					return nil, errors.Wrap(err, "invalid projection")
+				}
+			}
+		}
+		if projection != nil {
+			if fieldMasker.hasSensitiveFields() {
+				if err := checkMaskPreservingProjection(projection); err != nil {
+					return nil, err
+				}
+			}
+			opts.SetProjection(projection)
+		}
+		if queryContext.Limit > 0 && (limit == 0 || limit > int64(queryContext.Limit)) {
+			limit = int64(queryContext.Limit)
+		}
+		if limit > 0 {
+			opts.SetLimit(limit)
+		}
+		cursor, err := collection.Find(ctx, filter, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to run find")
+		}
+		return convertCursor(ctx, cursor, fieldMasker)
+	case parser.MongoAggregate:
+		if len(query.Arguments) != 1 {
+			return nil, errors.New("aggregate expects the pipeline")
+		}
+		var pipeline []bson.D
+		if err := decodeArgument(query.Arguments[0], &pipeline); err != nil {
+			return nil, errors.Wrap(err, "invalid pipeline")
+		}
+		for _, stage := range pipeline {
+			if len(stage) != 1 {
+				return nil, errors.New("each stage of the pipeline must have exactly one field")
+			}
+			if writeStages[stage[0].Key] {
+				return nil, errors.Errorf("stage %s is not allowed in the query", stage[0].Key)
+			}
+			if fieldMasker.hasSensitiveFields() && !maskPreservingStages[stage[0].Key] {
+				return nil, errors.Errorf("stage %s is not allowed on collection %q with sensitive fields", stage[0].Key, query.Collection)
+			}
+		}
+		if err := checkPipelineReferences(queryContext, databaseName, pipeline); err != nil {
+			return nil, err
+		}
+		if queryContext.Limit > 0 {
+			pipeline = append(pipeline, bson.D{{Key: "$limit", Value: queryContext.Limit}})
+		}
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to run aggregate")
+		}
+		return convertCursor(ctx, cursor, fieldMasker)
+	case parser.MongoCountDocuments:
+		if len(query.Arguments) > 1 {
+			return nil, errors.New("countDocuments expects the optional filter")
+		}
+		filter := bson.D{}
+		if len(query.Arguments) > 0 {
+			if err := decodeArgument(query.Arguments[0], &filter); err != nil {
+				return nil, errors.Wrap(err, "invalid filter")
+			}
+		}
+		count, err := collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to run countDocuments")
+		}
+		return &v1pb.QueryResult{
+			ColumnNames:     []string{"count"},
+			ColumnTypeNames: []string{"INT64"},
+			Rows:            []*v1pb.QueryRow{{Values: []*v1pb.RowValue{{Kind: &v1pb.RowValue_Int64Value{Int64Value: count}}}}},
+			Masked:          []bool{false},
+			Sensitive:       []bool{false},
+		}, nil
+	case parser.MongoDistinct:
+		if len(query.Arguments) == 0 || len(query.Arguments) > 2 {
+			return nil, errors.New("distinct expects the field and the optional filter")
+		}
+		var field string
+		if err := decodeArgument(query.Arguments[0], &field); err != nil {
+			return nil, errors.Wrap(err, "invalid field")
+		}
+		filter := bson.D{}
+		if len(query.Arguments) > 1 {
+			if err := decodeArgument(query.Arguments[1], &filter); err != nil {
+				return nil, errors.Wrap(err, "invalid filter")
+			}
+		}
+		values, err := collection.Distinct(ctx, field, filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to run distinct")
+		}
+		if queryContext.Limit > 0 && len(values) > queryContext.Limit {
+			values = values[:queryContext.Limit]
+		}
+		result := &v1pb.QueryResult{ColumnNames: []string{field}}
+		columnType := ""
+		fieldValueMasker, sensitive := fieldMasker.get(field)
+		for _, value := range values {
+			t, data, err := bson.MarshalValue(value)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal distinct value")
+			}
+			rawValue := bson.RawValue{Type: t, Value: data}
+			columnType = mergeColumnType(columnType, rawValue)
+			rowValue := convertRawValue(rawValue)
+			if fieldValueMasker != nil {
+				rowValue = fieldValueMasker.Mask(rowValue)
+			}
+			result.Rows = append(result.Rows, &v1pb.QueryRow{Values: []*v1pb.RowValue{rowValue}})
+		}
+		result.ColumnTypeNames = []string{columnType}
+		result.Masked = []bool{fieldValueMasker != nil}
+		result.Sensitive = []bool{sensitive}
+		return result, nil
+	default:
+		return nil, errors.Errorf("unsupported method %q", query.Method)
+	}
+}
+
+func getFindArguments(arguments []string) (bson.D, bson.D, error) {
+	if len(arguments) > 2 {
+		return nil, nil, errors.New("find expects the filter and the optional projection")
+	}
+	filter := bson.D{}
+	var projection bson.D
+	if len(arguments) > 0 {
+		if err := decodeArgument(arguments[0], &filter); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid filter")
+		}
+	}
+	if len(arguments) > 1 {
+		if err := decodeArgument(arguments[1], &projection); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid projection")
+		}
+	}
+	return filter, projection, nil
+}
+
+// checkMaskPreservingProjection checks the projection only includes or excludes the fields,
+// the projection with expressions can rename the fields and bypass the masking.
+func checkMaskPreservingProjection(projection bson.D) error {
+	for _, e := range projection {
+		switch v := e.Value.(type) {
+		case int32, int64, float64, bool:
+		case bson.D:
+			if len(v) != 1 || (v[0].Key != "$slice" && v[0].Key != "$elemMatch") {
+				return errors.Errorf("projection of field %q must be a boolean on the collection with sensitive fields", e.Key)
+			}
+		default:
+			return errors.Errorf("projection of field %q must be a boolean on the collection with sensitive fields", e.Key)
+		}
+	}
+	return nil
+}
+
+// collectionReference is a collection read by a lookup stage of the aggregation pipeline.
+type collectionReference struct {
+	database   string
+	collection string
+}
+
+// checkPipelineReferences checks none of the collections read by the lookup stages of the pipeline has sensitive fields.
+// The collections of the other databases are rejected as their sensitive fields are unknown.
+func checkPipelineReferences(queryContext *db.QueryContext, databaseName string, pipeline []bson.D) error {
+	references, err := getPipelineReferences(databaseName, pipeline)
+	if err != nil {
+		return err
+	}
+	for _, reference := range references {
+		if reference.database != databaseName {
+			return errors.Errorf("reading collection %q of another database %q is not allowed in the query", reference.collection, reference.database)
+		}
+		if newFieldMasker(queryContext, reference.database, reference.collection).hasSensitiveFields() {
+			return errors.Errorf("collection %q with sensitive fields is not allowed in $lookup, $unionWith or $graphLookup", reference.collection)
+		}
+	}
+	return nil
+}
+
+// getPipelineReferences returns the collections read by the lookup stages of the pipeline, including the nested pipelines.
+func getPipelineReferences(databaseName string, pipeline []bson.D) ([]collectionReference, error) {
+	var references []collectionReference
+	for _, stage := range pipeline {
+		for _, e := range stage {
+			stageReferences, err := getStageReferences(databaseName, e.Key, e.Value)
+			if err != nil {
+				return nil, err
+			}
+			references = append(references, stageReferences...)
+		}
+	}
+	return references, nil
+}
+
+func getStageReferences(databaseName string, key string, value any) ([]collectionReference, error) {
+	if key == "$facet" {
+		facet, ok := value.(bson.D)
+		if !ok {
+			return nil, errors.Errorf("invalid stage %s", key)
+		}
+		var references []collectionReference
+		for _, e := range facet {
+			pipeline, err := getNestedPipeline(key, e.Value)
+			if err != nil {
+				return nil, err
+			}
+			nestedReferences, err := getPipelineReferences(databaseName, pipeline)
+			if err != nil {
+				return nil, err
+			}
+			references = append(references, nestedReferences...)
+		}
+		return references, nil
+	}
+	if !lookupStages[key] {
+		return nil, nil
+	}
+	// {$unionWith: "collection"} is the short form of {$unionWith: {coll: "collection"}}.
+	if collection, ok := value.(string); ok && key == "$unionWith" {
+		return []collectionReference{{database: databaseName, collection: collection}}, nil
+	}
+	spec, ok := value.(bson.D)
+	if !ok {
+		return nil, errors.Errorf("invalid stage %s", key)
+	}
+	var references []collectionReference
+	for _, e := range spec {
+		switch {
+		case e.Key == "from" || (key == "$unionWith" && e.Key == "coll"):
+			switch from := e.Value.(type) {
+			case string:
+				references = append(references, collectionReference{database: databaseName, collection: from})
+			case bson.D:
+				// The collection of another database, e.g. {from: {db: "db", coll: "collection"}}.
+				var reference collectionReference
+				for _, f := range from {
+					switch f.Key {
+					case "db":
+						reference.database, _ = f.Value.(string)
+					case "coll":
+						reference.collection, _ = f.Value.(string)
+					}
+				}
+				if reference.database == "" || reference.collection == "" {
+					return nil, errors.Errorf("invalid %s of stage %s", e.Key, key)
+				}
+				references = append(references, reference)
+			default:
+				return nil, errors.Errorf("invalid %s of stage %s", e.Key, key)
+			}
+		case e.Key == "pipeline":
+			pipeline, err := getNestedPipeline(key, e.Value)
+			if err != nil {
+				return nil, err
+			}
+			nestedReferences, err := getPipelineReferences(databaseName, pipeline)
+			if err != nil {
+				return nil, err
+			}
+			references = append(references, nestedReferences...)
+		}
+	}
+	return references, nil
+}
+
+func getNestedPipeline(key string, value any) ([]bson.D, error) {
+	stages, ok := value.(bson.A)
+	if !ok {
+		return nil, errors.Errorf("invalid pipeline of stage %s", key)
+	}
+	var pipeline []bson.D
+	for _, stage := range stages {
+		d, ok := stage.(bson.D)
+		if !ok {
+			return nil, errors.Errorf("invalid pipeline of stage %s", key)
+		}
+		pipeline = append(pipeline, d)
+	}
+	return pipeline, nil
+}
+
+// decodeArgument decodes the argument in Extended JSON into v.
+func decodeArgument(argument string, v any) error {
+	var wrapper struct {
+		V bson.RawValue `bson:"v"`
+	}
+	if err := bson.UnmarshalExtJSON([]byte(`{"v":`+argument+`}`), false /* canonical */, &wrapper); err != nil {
+		return err
+	}
+	return wrapper.V.Unmarshal(v)
+}
+
+// column is a flattened field of the documents.
+type column struct {
+	typeName  string
+	masker    masker.Masker
+	sensitive bool
+}
+
+func convertCursor(ctx context.Context, cursor *mongo.Cursor, fieldMasker *fieldMasker) (*v1pb.QueryResult, error) {
+	defer cursor.Close(ctx)
+	var documents []map[string]bson.RawValue
+	columns := make(map[string]*column)
+	for cursor.Next(ctx) {
+		document := make(map[string]bson.RawValue)
+		if err := flattenDocument(cursor.Current, "", document); err != nil {
+			return nil, errors.Wrap(err, "failed to read document")
+		}
+		for path, value := range document {
+			c, ok := columns[path]
+			if !ok {
+				c = &column{}
+				c.masker, c.sensitive = fieldMasker.get(path)
+				columns[path] = c
+			}
+			c.typeName = mergeColumnType(c.typeName, value)
+		}
+		documents = append(documents, document)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate documents")
+	}
+
+	columnSet := make(map[string]bool)
+	for path := range columns {
+		columnSet[path] = true
+	}
+	columnNames, _ := getOrderedColumns(columnSet)
+	result := &v1pb.QueryResult{ColumnNames: columnNames}
+	for _, name := range columnNames {
+		c := columns[name]
+		result.ColumnTypeNames = append(result.ColumnTypeNames, c.typeName)
+		result.Masked = append(result.Masked, c.masker != nil)
+		result.Sensitive = append(result.Sensitive, c.sensitive)
+	}
+	for _, document := range documents {
+		row := &v1pb.QueryRow{}
+		for _, name := range columnNames {
+			value, ok := document[name]
+			if !ok {
+				row.Values = append(row.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{}})
+				continue
+			}
+			rowValue := convertRawValue(value)
+			if m := columns[name].masker; m != nil {
+				rowValue = m.Mask(rowValue)
+			}
+			row.Values = append(row.Values, rowValue)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+// flattenDocument flattens the nested documents into the dotted field paths, the arrays are kept as values.
+func flattenDocument(document bson.Raw, prefix string, result map[string]bson.RawValue) error {
+	elements, err := document.Elements()
+	if err != nil {
+		return err
+	}
+	for _, element := range elements {
+		path := prefix + element.Key()
+		value := element.Value()
+		if value.Type == bsontype.EmbeddedDocument {
+			// The empty documents of 5 bytes are kept as values so that they are not lost.
+			if nested := value.Document(); len(nested) > 5 {
+				if err := flattenDocument(nested, path+".", result); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		result[path] = value
+	}
+	return nil
+}
+
+var columnTypeNames = map[bsontype.Type]string{
+	bsontype.Double:           "DOUBLE",
+	bsontype.String:           "STRING",
+	bsontype.EmbeddedDocument: "OBJECT",
+	bsontype.Array:            "ARRAY",
+	bsontype.Binary:           "BINARY",
+	bsontype.ObjectID:         "OBJECTID",
+	bsontype.Boolean:          "BOOL",
+	bsontype.DateTime:         "DATE",
+	bsontype.Regex:            "REGEX",
+	bsontype.Int32:            "INT32",
+	bsontype.Timestamp:        "TIMESTAMP",
+	bsontype.Int64:            "INT64",
+	bsontype.Decimal128:       "DECIMAL128",
+}
+
+// mergeColumnType returns the type of the column with the value, the column type is MIXED if the values are of different types.
+func mergeColumnType(columnType string, value bson.RawValue) string {
+	if value.Type == bsontype.Null || value.Type == bsontype.Undefined {
+		return columnType
+	}
+	typeName, ok := columnTypeNames[value.Type]
+	if !ok {
+		typeName = strings.ToUpper(value.Type.String())
+	}
+	if columnType == "" || columnType == typeName {
+		return typeName
+	}
+	return "MIXED"
+}
+
+func convertRawValue(value bson.RawValue) *v1pb.RowValue {
+	switch value.Type {
+	case bsontype.String:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: value.StringValue()}}
+	case bsontype.Int32:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_Int32Value{Int32Value: value.Int32()}}
+	case bsontype.Int64:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: value.Int64()}}
+	case bsontype.Double:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_DoubleValue{DoubleValue: value.Double()}}
+	case bsontype.Boolean:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_BoolValue{BoolValue: value.Boolean()}}
+	case bsontype.ObjectID:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: value.ObjectID().Hex()}}
+	case bsontype.DateTime:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: time.UnixMilli(value.DateTime()).UTC().Format(time.RFC3339Nano)}}
+	case bsontype.Decimal128:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: value.Decimal128().String()}}
+	case bsontype.Binary:
+		_, data := value.Binary()
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_BytesValue{BytesValue: data}}
+	case bsontype.Null, bsontype.Undefined:
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{}}
+	default:
+		// The arrays, empty documents and other types are shown in Extended JSON.
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: value.String()}}
+	}
+}
+
+// fieldMasker resolves the maskers of the field paths from the sensitive schema info of the collection.
+type fieldMasker struct {
+	enableSensitive bool
+	// levels are the masking levels of the sensitive field paths.
+	levels  map[string]storepb.MaskingLevel
+	maskers map[string]masker.Masker
+}
+
+func newFieldMasker(queryContext *db.QueryContext, databaseName string, collectionName string) *fieldMasker {
+	m := &fieldMasker{
+		enableSensitive: queryContext.EnableSensitive,
+		levels:          make(map[string]storepb.MaskingLevel),
+		maskers:         make(map[string]masker.Masker),
+	}
+	if queryContext.SensitiveSchemaInfo == nil {
+		return m
+	}
+	for _, database := range queryContext.SensitiveSchemaInfo.DatabaseList {
+		if database.Name != databaseName {
+			continue
+		}
+		for _, schema := range database.SchemaList {
+			for _, table := range schema.TableList {
+				if table.Name != collectionName {
+					continue
+				}
+				for _, column := range table.ColumnList {
+					if column.MaskingLevel != storepb.MaskingLevel_FULL && column.MaskingLevel != storepb.MaskingLevel_PARTIAL {
+						continue
+					}
+					m.levels[column.Name] = column.MaskingLevel
+					columnMasker := column.Masker
+					if columnMasker == nil {
+						columnMasker = masker.NewDefaultMasker(column.MaskingLevel)
+					}
+					m.maskers[column.Name] = columnMasker
+				}
+			}
+		}
+	}
+	return m
+}
+
+func (m *fieldMasker) hasSensitiveFields() bool {
+	return len(m.levels) != 0
+}
+
+// get returns the masker of the field path and whether the field is sensitive, the masker is nil if the field isn't masked.
+// The field is masked by the masker of itself or its closest sensitive ancestor,
+// and it is fully masked if it contains sensitive fields, e.g. an array of documents.
+func (m *fieldMasker) get(path string) (masker.Masker, bool) {
+	var result masker.Masker
+	for ancestor := path; ; {
+		if ancestorMasker, ok := m.maskers[ancestor]; ok {
+			result = ancestorMasker
+			break
+		}
+		i := strings.LastIndex(ancestor, ".")
+		if i < 0 {
+			break
+		}
+		ancestor = ancestor[:i]
+	}
+	if result == nil {
+		for sensitivePath := range m.levels {
+			if strings.HasPrefix(sensitivePath, path+".") {
+				result = masker.NewDefaultMasker(storepb.MaskingLevel_FULL)
+				break
+			}
+		}
+	}
+	if result == nil {
+		return nil, false
+	}
+	if !m.enableSensitive {
+		return nil, true
+	}
+	return result, true
+}
@@ -0,0 +1,180 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestFlattenDocument(t *testing.T) {
+	a := require.New(t)
+	var document bson.D
+	a.NoError(decodeArgument(`{"_id": {"$oid": "5f1d7a9b2c8e4a1b3c5d7e9f"}, "name": "alice", "age": 18, "address": {"city": "NYC", "geo": {"lat": 1.5}}, "tags": ["a", "b"], "extra": {}, "created": {"$date": "2023-01-01T00:00:00Z"}}`, &document))
+	raw, err := bson.Marshal(document)
+	a.NoError(err)
+
+	result := make(map[string]bson.RawValue)
+	a.NoError(flattenDocument(raw, "", result))
+	got := make(map[string]*v1pb.RowValue)
+	types := make(map[string]string)
+	for path, value := range result {
+		got[path] = convertRawValue(value)
+		types[path] = mergeColumnType("", value)
+	}
+	a.Equal(map[string]*v1pb.RowValue{
+		"_id":             {Kind: &v1pb.RowValue_StringValue{StringValue: "5f1d7a9b2c8e4a1b3c5d7e9f"}},
+		"name":            {Kind: &v1pb.RowValue_StringValue{StringValue: "alice"}},
+		"age":             {Kind: &v1pb.RowValue_Int32Value{Int32Value: 18}},
+		"address.city":    {Kind: &v1pb.RowValue_StringValue{StringValue: "NYC"}},
+		"address.geo.lat": {Kind: &v1pb.RowValue_DoubleValue{DoubleValue: 1.5}},
+		"tags":            {Kind: &v1pb.RowValue_StringValue{StringValue: `["a","b"]`}},
+		"extra":           {Kind: &v1pb.RowValue_StringValue{StringValue: `{}`}},
+		"created":         {Kind: &v1pb.RowValue_StringValue{StringValue: "2023-01-01T00:00:00Z"}},
+	}, got)
+	a.Equal(map[string]string{
+		"_id":             "OBJECTID",
+		"name":            "STRING",
+		"age":             "INT32",
+		"address.city":    "STRING",
+		"address.geo.lat": "DOUBLE",
+		"tags":            "ARRAY",
+		"extra":           "OBJECT",
+		"created":         "DATE",
+	}, types)
+
+	a.Equal("MIXED", mergeColumnType("STRING", result["age"]))
+	a.Equal("INT32", mergeColumnType("INT32", bson.RawValue{Type: bson.TypeNull}))
+}
+
+func TestFieldMasker(t *testing.T) {
+	queryContext := &db.QueryContext{
+		EnableSensitive: true,
+		SensitiveSchemaInfo: &db.SensitiveSchemaInfo{
+			DatabaseList: []db.DatabaseSchema{
+				{
+					Name: "sample",
+					SchemaList: []db.SchemaSchema{
+						{
+							TableList: []db.TableSchema{
+								{
+									Name: "users",
+									ColumnList: []db.ColumnInfo{
+										{Name: "name", MaskingLevel: storepb.MaskingLevel_NONE},
+										{Name: "address", MaskingLevel: storepb.MaskingLevel_PARTIAL},
+										{Name: "profile.ssn", MaskingLevel: storepb.MaskingLevel_FULL},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		path          string
+		wantMasked    bool
+		wantSensitive bool
+	}{
+		{path: "_id"},
+		{path: "name"},
+		{path: "address", wantMasked: true, wantSensitive: true},
+		{path: "address.city", wantMasked: true, wantSensitive: true},
+		{path: "profile", wantMasked: true, wantSensitive: true},
+		{path: "profile.ssn", wantMasked: true, wantSensitive: true},
+		{path: "profile.age"},
+		{path: "profiles"},
+	}
+
+	a := require.New(t)
+	m := newFieldMasker(queryContext, "sample", "users")
+	a.True(m.hasSensitiveFields())
+	for _, test := range tests {
+		gotMasker, gotSensitive := m.get(test.path)
+		a.Equal(test.wantMasked, gotMasker != nil, test.path)
+		a.Equal(test.wantSensitive, gotSensitive, test.path)
+	}
+
+	a.False(newFieldMasker(queryContext, "sample", "orders").hasSensitiveFields())
+	queryContext.EnableSensitive = false
+	gotMasker, gotSensitive := newFieldMasker(queryContext, "sample", "users").get("address")
+	a.Nil(gotMasker)
+	a.True(gotSensitive)
+}
+
+func TestCheckMaskPreservingProjection(t *testing.T) {
+	tests := []struct {
+		projection string
+		wantErr    bool
+	}{
+		{projection: `{"name": 1, "_id": false}`},
+		{projection: `{"tags": {"$slice": 2}}`},
+		{projection: `{"name": "$address"}`, wantErr: true},
+		{projection: `{"city": {"$concat": ["$address", ""]}}`, wantErr: true},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		var projection bson.D
+		a.NoError(decodeArgument(test.projection, &projection))
+		err := checkMaskPreservingProjection(projection)
+		if test.wantErr {
+			a.Error(err, test.projection)
+		} else {
+			a.NoError(err, test.projection)
+		}
+	}
+}
+
+func TestCheckPipelineReferences(t *testing.T) {
+	queryContext := &db.QueryContext{
+		SensitiveSchemaInfo: &db.SensitiveSchemaInfo{
+			DatabaseList: []db.DatabaseSchema{
+				{
+					Name: "sample",
+					SchemaList: []db.SchemaSchema{
+						{
+							TableList: []db.TableSchema{
+								{
+									Name: "users",
+									ColumnList: []db.ColumnInfo{
+										{Name: "address", MaskingLevel: storepb.MaskingLevel_PARTIAL},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		pipeline string
+		wantErr  bool
+	}{
+		{pipeline: `[{"$match": {"status": "A"}}, {"$lookup": {"from": "items", "localField": "item", "foreignField": "_id", "as": "items"}}]`},
+		{pipeline: `[{"$unionWith": "items"}]`},
+		{pipeline: `[{"$lookup": {"from": "users", "localField": "user", "foreignField": "_id", "as": "users"}}]`, wantErr: true},
+		{pipeline: `[{"$lookup": {"from": "items", "pipeline": [{"$unionWith": {"coll": "users"}}], "as": "items"}}]`, wantErr: true},
+		{pipeline: `[{"$facet": {"users": [{"$graphLookup": {"from": "users", "startWith": "$user", "connectFromField": "manager", "connectToField": "_id", "as": "users"}}]}}]`, wantErr: true},
+		{pipeline: `[{"$unionWith": "users"}]`, wantErr: true},
+		{pipeline: `[{"$lookup": {"from": {"db": "other", "coll": "items"}, "localField": "item", "foreignField": "_id", "as": "items"}}]`, wantErr: true},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		var pipeline []bson.D
+		a.NoError(decodeArgument(test.pipeline, &pipeline))
+		err := checkPipelineReferences(queryContext, "sample", pipeline)
+		if test.wantErr {
+			a.Error(err, test.pipeline)
+		} else {
+			a.NoError(err, test.pipeline)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"sort"
 	"strings"
@@ -10,10 +11,13 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
@@ -141,8 +145,14 @@ func (driver *Driver) SyncDBSchema(ctx context.Context) (*storepb.DatabaseSchema
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get index schema of collection %s", collectionName)
 		}
+		// Get collection field paths from the sampled documents.
+		columns, err := getCollectionFields(ctx, collection)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get field paths of collection %s", collectionName)
+		}
 		schemaMetadata.Tables = append(schemaMetadata.Tables, &storepb.TableMetadata{
 			Name:      collectionName,
+			Columns:   columns,
 			RowCount:  count,
 			DataSize:  dataSize64,
 			IndexSize: totalIndexSize64,
@@ -217,6 +227,52 @@ func getIndexes(ctx context.Context, collection *mongo.Collection) ([]*storepb.I
 	return indexes, nil
 }
 
+// fieldSampleSize is the number of the sampled documents to infer the field paths of a collection.
+const fieldSampleSize = 100
+
+// getCollectionFields infers the flattened field paths of the collection from the sampled documents,
+// so that the field paths can be classified and masked like the columns.
+func getCollectionFields(ctx context.Context, collection *mongo.Collection) ([]*storepb.ColumnMetadata, error) {
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{{{Key: "$sample", Value: bson.D{{Key: "size", Value: fieldSampleSize}}}}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sample documents")
+	}
+	defer cursor.Close(ctx)
+	documentCount := 0
+	fieldCount := make(map[string]int)
+	fieldType := make(map[string]string)
+	for cursor.Next(ctx) {
+		document := make(map[string]bson.RawValue)
+		if err := flattenDocument(cursor.Current, "", document); err != nil {
+			return nil, errors.Wrap(err, "failed to read document")
+		}
+		documentCount++
+		for path, value := range document {
+			fieldCount[path]++
+			fieldType[path] = mergeColumnType(fieldType[path], value)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate documents")
+	}
+
+	fieldSet := make(map[string]bool)
+	for path := range fieldCount {
+		fieldSet[path] = true
+	}
+	paths, _ := getOrderedColumns(fieldSet)
+	var columns []*storepb.ColumnMetadata
+	for i, path := range paths {
+		columns = append(columns, &storepb.ColumnMetadata{
+			Name:     path,
+			Position: int32(i + 1),
+			Type:     fieldType[path],
+			Nullable: fieldCount[path] < documentCount || fieldType[path] == "",
+		})
+	}
+	return columns, nil
+}
+
 // getVersion returns the version of mongod or mongos instance.
 func (driver *Driver) getVersion(ctx context.Context) (string, error) {
 	database := driver.client.Database(bytebaseDefaultDatabase)
@@ -304,12 +360,155 @@ func convertEmptyInterfaceToInt64(value any) (int64, error) {
 	}
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// profileStatus is the subset of the mongodb command result of "profile".
+type profileStatus struct {
+	Was    int `bson:"was"`
+	SlowMS int `bson:"slowms"`
+}
+
+// profileEntry is the subset of the document in the system.profile collection.
+type profileEntry struct {
+	Op           string    `bson:"op"`
+	NS           string    `bson:"ns"`
+	Command      bson.D    `bson:"command"`
+	Millis       int64     `bson:"millis"`
+	Ts           time.Time `bson:"ts"`
+	NReturned    int64     `bson:"nreturned"`
+	DocsExamined int64     `bson:"docsExamined"`
+}
+
+// SyncSlowQuery syncs the slow query from the system.profile collections of the databases with profiling enabled.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	databaseNames, err := driver.getNonSystemDatabaseList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*util.SlowQueryLog
+	for _, databaseName := range databaseNames {
+		database := driver.client.Database(databaseName)
+		status, err := getProfileStatus(ctx, database)
+		if err != nil {
+			return nil, err
+		}
+		if status.Was == 0 {
+			continue
+		}
+		filter := bson.M{
+			"ts": bson.M{
+				"$gte": logDateTs,
+				"$lt":  logDateTs.AddDate(0, 0, 1),
+			},
+			// Profiling level 2 records all operations.
+			"millis": bson.M{"$gte": status.SlowMS},
+		}
+		cursor, err := database.Collection("system.profile").Find(ctx, filter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find slow queries in database %q", databaseName)
+		}
+		for cursor.Next(ctx) {
+			var entry profileEntry
+			if err := cursor.Decode(&entry); err != nil {
+				cursor.Close(ctx)
+				return nil, errors.Wrapf(err, "failed to decode profile entry in database %q", databaseName)
+			}
+			log, err := convertProfileEntry(databaseName, &entry)
+			if err != nil {
+				cursor.Close(ctx)
+				return nil, err
+			}
+			logs = util.SampleSlowQueryLog(logs, log)
+		}
+		if err := cursor.Err(); err != nil {
+			cursor.Close(ctx)
+			return nil, errors.Wrapf(err, "failed to iterate profile entries in database %q", databaseName)
+		}
+		cursor.Close(ctx)
+	}
+
+	return util.AnalyzeSlowQueryLogs(logs), nil
+}
+
+func convertProfileEntry(databaseName string, entry *profileEntry) (*util.SlowQueryLog, error) {
+	command := getProfileCommand(entry.Command)
+	text, err := bson.MarshalExtJSON(command, false, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal profile command")
+	}
+	shape, err := bson.MarshalExtJSON(getQueryShape(command), false, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal profile command shape")
+	}
+	queryTime := time.Duration(entry.Millis) * time.Millisecond
+	return &util.SlowQueryLog{
+		Database:    databaseName,
+		Fingerprint: fmt.Sprintf("%s %s %s", entry.Op, entry.NS, shape),
+		Details: &storepb.SlowQueryDetails{
+			// ts is the time when the operation finished.
+			StartTime:    timestamppb.New(entry.Ts.Add(-queryTime)),
+			QueryTime:    durationpb.New(queryTime),
+			LockTime:     durationpb.New(0),
+			RowsSent:     entry.NReturned,
+			RowsExamined: entry.DocsExamined,
+			SqlText:      string(text),
+		},
+	}, nil
+}
+
+// getProfileCommand removes the session and cluster fields from the command.
+func getProfileCommand(command bson.D) bson.D {
+	var result bson.D
+	for _, e := range command {
+		if e.Key == "lsid" || strings.HasPrefix(e.Key, "$") {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// getQueryShape replaces the values in the query with "?", so that queries only differing in values have the same shape.
+func getQueryShape(value any) any {
+	switch v := value.(type) {
+	case bson.D:
+		shape := bson.D{}
+		for _, e := range v {
+			shape = append(shape, bson.E{Key: e.Key, Value: getQueryShape(e.Value)})
+		}
+		return shape
+	case bson.A:
+		if len(v) == 0 {
+			return bson.A{}
+		}
+		// The elements of an array usually share the same shape.
+		return bson.A{getQueryShape(v[0])}
+	default:
+		return "?"
+	}
+}
+
+func getProfileStatus(ctx context.Context, database *mongo.Database) (*profileStatus, error) {
+	var status profileStatus
+	if err := database.RunCommand(ctx, bson.D{{Key: "profile", Value: -1}}).Decode(&status); err != nil {
+		return nil, errors.Wrapf(err, "failed to get profiling level of database %q", database.Name())
+	}
+	return &status, nil
 }
 
 // CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	databaseNames, err := driver.getNonSystemDatabaseList(ctx)
+	if err != nil {
+		return err
+	}
+	for _, databaseName := range databaseNames {
+		status, err := getProfileStatus(ctx, driver.client.Database(databaseName))
+		if err != nil {
+			return err
+		}
+		if status.Was > 0 {
+			return nil
+		}
+	}
+	return errors.New("profiler is not enabled in any database, use db.setProfilingLevel(1) to enable it")
 }
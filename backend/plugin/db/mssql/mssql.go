@@ -112,7 +112,15 @@ func (driver *Driver) Execute(ctx context.Context, statement string, createDatab
 		return nil
 	}
 
-	if _, err := parser.SplitMultiSQLStream(parser.MSSQL, strings.NewReader(statement), f); err != nil {
+	if parser.HasTSQLBatchSeparator(statement) {
+		// The batches separated by GO are sent as a whole, because the CREATE PROCEDURE, FUNCTION, VIEW and TRIGGER
+		// statements must be the only statement in the batch, and their bodies may contain semicolons.
+		for _, batch := range parser.SplitTSQLBatches(statement) {
+			if err := f(batch); err != nil {
+				return 0, err
+			}
+		}
+	} else if _, err := parser.SplitMultiSQLStream(parser.MSSQL, strings.NewReader(statement), f); err != nil {
 		return 0, err
 	}
 
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
@@ -325,12 +327,83 @@ func getViews(txn *sql.Tx) (map[string][]*storepb.ViewMetadata, error) {
 	return viewMap, nil
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// SyncSlowQuery syncs the query statistics from sys.dm_exec_query_stats.
+// The statistics are cumulative since the plan is cached, so the caller computes the statistics of a day from the deltas between the snapshots.
+// Only the statements executed since logDateTs are returned, or all the cached statements if logDateTs is zero.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	var args []any
+	filter := ""
+	if !logDateTs.IsZero() {
+		// The last execution time is in the local time of the server, so we look back one more day to cover any time zone.
+		// Use the unambiguous date format YYYYMMDD regardless of the language settings.
+		filter = "WHERE qs.last_execution_time >= @p1"
+		args = append(args, logDateTs.AddDate(0, 0, -1).Format("20060102"))
+	}
+	query := `
+		SELECT
+			COALESCE(DB_NAME(st.dbid), DB_NAME(CAST(pa.value AS INT)), ''),
+			SUBSTRING(st.text, (qs.statement_start_offset / 2) + 1,
+				((CASE qs.statement_end_offset WHEN -1 THEN DATALENGTH(st.text) ELSE qs.statement_end_offset END - qs.statement_start_offset) / 2) + 1),
+			qs.execution_count,
+			qs.total_elapsed_time,
+			qs.max_elapsed_time,
+			qs.total_rows,
+			qs.max_rows,
+			qs.last_execution_time
+		FROM sys.dm_exec_query_stats AS qs
+		CROSS APPLY sys.dm_exec_sql_text(qs.sql_handle) AS st
+		OUTER APPLY (SELECT value FROM sys.dm_exec_plan_attributes(qs.plan_handle) WHERE attribute = N'dbid') AS pa
+		` + filter
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for rows.Next() {
+		var database, statement string
+		var executionCount, totalElapsedTime, maxElapsedTime, totalRows, maxRows int64
+		var lastExecutionTime time.Time
+		if err := rows.Scan(
+			&database,
+			&statement,
+			&executionCount,
+			&totalElapsedTime,
+			&maxElapsedTime,
+			&totalRows,
+			&maxRows,
+			&lastExecutionTime,
+		); err != nil {
+			return nil, err
+		}
+		// The elapsed time is in microseconds.
+		util.MergeSlowQueryStatisticsItem(result, database, &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   strings.TrimSpace(statement),
+			Count:            executionCount,
+			LatestLogTime:    timestamppb.New(lastExecutionTime),
+			TotalQueryTime:   durationpb.New(time.Duration(totalElapsedTime) * time.Microsecond),
+			MaximumQueryTime: durationpb.New(time.Duration(maxElapsedTime) * time.Microsecond),
+			TotalRowsSent:    totalRows,
+			MaximumRowsSent:  maxRows,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return result, nil
 }
 
 // CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+// The query statistics are always collected, so we only check the permission to read them.
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	query := `SELECT HAS_PERMS_BY_NAME(NULL, NULL, 'VIEW SERVER STATE')`
+	var hasPerms int
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&hasPerms); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	if hasPerms != 1 {
+		return errors.New("VIEW SERVER STATE permission is required to read sys.dm_exec_query_stats")
+	}
+	return nil
 }
@@ -845,8 +845,14 @@ func (driver *Driver) restoreImpl(ctx context.Context, backup io.Reader, databas
 	if driver.connCfg.Port != "" {
 		mysqlArgs = append(mysqlArgs, "--port", driver.connCfg.Port)
 	}
-	if driver.connCfg.Password != "" {
-		mysqlArgs = append(mysqlArgs, fmt.Sprintf("--password=%s", driver.connCfg.Password))
+	authentication, err := driver.getCLIAuthentication(ctx)
+	if err != nil {
+		return err
+	}
+	defer authentication.close()
+	mysqlArgs = append(mysqlArgs, authentication.args...)
+	if authentication.password != "" {
+		mysqlArgs = append(mysqlArgs, fmt.Sprintf("--password=%s", authentication.password))
 	}
 	mysqlCmd := exec.CommandContext(ctx, mysqlutil.GetPath(mysqlutil.MySQL, driver.dbBinDir), mysqlArgs...)
 
@@ -4,9 +4,11 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log/slog"
 	"net"
+	"os"
 	"strings"
 	"time"
 
@@ -98,10 +100,26 @@ func (driver *Driver) Open(_ context.Context, dbType db.Type, connCfg db.Connect
 		params = append(params, fmt.Sprintf("tls=%s", tlsKey))
 	}
 
-	dsn := fmt.Sprintf("%s:%s@%s(%s:%s)/%s?%s", connCfg.Username, connCfg.Password, protocol, connCfg.Host, connCfg.Port, connCfg.Database, strings.Join(params, "&"))
-	db, err := sql.Open("mysql", dsn)
-	if err != nil {
-		return nil, err
+	var db *sql.DB
+	if connCfg.RefreshPassword != nil {
+		// The IAM authentication token is sent by the cleartext plugin, which must be protected by TLS verifying the server.
+		if tlsConfig == nil {
+			return nil, errors.New("IAM authentication requires the SSL CA of the data source, e.g. the AWS RDS global bundle or the Cloud SQL server CA, to verify the server")
+		}
+		params = append(params, "allowCleartextPasswords=true")
+		dsn := fmt.Sprintf("%s@%s(%s:%s)/%s?%s", connCfg.Username, protocol, connCfg.Host, connCfg.Port, connCfg.Database, strings.Join(params, "&"))
+		cfg, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		db = sql.OpenDB(&refreshPasswordConnector{cfg: cfg, refreshPassword: connCfg.RefreshPassword})
+	} else {
+		dsn := fmt.Sprintf("%s:%s@%s(%s:%s)/%s?%s", connCfg.Username, connCfg.Password, protocol, connCfg.Host, connCfg.Port, connCfg.Database, strings.Join(params, "&"))
+		var err error
+		db, err = sql.Open("mysql", dsn)
+		if err != nil {
+			return nil, err
+		}
 	}
 	driver.dbType = dbType
 	driver.db = db
@@ -126,6 +144,84 @@ func (driver *Driver) Close(context.Context) error {
 	return err
 }
 
+// refreshPasswordConnector sets a fresh password before opening each connection of the pool.
+type refreshPasswordConnector struct {
+	cfg             *mysql.Config
+	refreshPassword func(ctx context.Context) (string, error)
+}
+
+// Connect implements driver.Connector.
+func (c *refreshPasswordConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	password, err := c.refreshPassword(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cfg := c.cfg.Clone()
+	cfg.Passwd = password
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+// Driver implements driver.Connector.
+func (*refreshPasswordConnector) Driver() driver.Driver {
+	return &mysql.MySQLDriver{}
+}
+
+// cliAuthentication is the authentication of the mysql and mysqlbinlog CLI tools.
+type cliAuthentication struct {
+	password string
+	// args are the extra arguments of the CLI tools, such as enabling the cleartext plugin for the IAM authentication token.
+	args []string
+	// caFile is the temporary file of the SSL CA verifying the server, which is removed by close.
+	caFile string
+}
+
+// getCLIAuthentication returns the authentication of the CLI tools, which must be closed after the tools exit.
+// The CLI tools open their own connections, so a fresh IAM authentication token is used,
+// which is sent by the cleartext plugin over TLS verifying the server by the SSL CA of the data source.
+func (driver *Driver) getCLIAuthentication(ctx context.Context) (*cliAuthentication, error) {
+	if driver.connCfg.RefreshPassword == nil {
+		return &cliAuthentication{password: driver.connCfg.Password}, nil
+	}
+	if driver.connCfg.TLSConfig.SslCA == "" {
+		return nil, errors.New("IAM authentication requires the SSL CA of the data source to verify the server")
+	}
+	password, err := driver.connCfg.RefreshPassword(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to refresh the IAM authentication token")
+	}
+	f, err := os.CreateTemp("", "mysql-ssl-ca-*.pem")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the SSL CA file")
+	}
+	if _, err := f.WriteString(driver.connCfg.TLSConfig.SslCA); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, errors.Wrap(err, "failed to write the SSL CA file")
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return nil, errors.Wrap(err, "failed to write the SSL CA file")
+	}
+	return &cliAuthentication{
+		password: password,
+		args:     []string{"--enable-cleartext-plugin", "--ssl-mode=VERIFY_CA", fmt.Sprintf("--ssl-ca=%s", f.Name())},
+		caFile:   f.Name(),
+	}, nil
+}
+
+func (a *cliAuthentication) close() {
+	if a.caFile == "" {
+		return
+	}
+	if err := os.Remove(a.caFile); err != nil {
+		slog.Warn("failed to remove the SSL CA file", slog.String("path", a.caFile), log.BBError(err))
+	}
+}
+
 // Ping pings the database.
 func (driver *Driver) Ping(ctx context.Context) error {
 	return driver.db.PingContext(ctx)
@@ -31,7 +31,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
-	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 	"github.com/bytebase/bytebase/backend/resources/mysqlutil"
 	"github.com/bytebase/bytebase/backend/store"
 
@@ -183,10 +183,16 @@ func (driver *Driver) replayBinlogFromDir(ctx context.Context, originalDatabase,
 	if driver.connCfg.Port != "" {
 		mysqlArgs = append(mysqlArgs, "--port", driver.connCfg.Port)
 	}
-	if driver.connCfg.Password != "" {
+	authentication, err := driver.getCLIAuthentication(ctx)
+	if err != nil {
+		return err
+	}
+	defer authentication.close()
+	mysqlArgs = append(mysqlArgs, authentication.args...)
+	if authentication.password != "" {
 		// The --password parameter of mysql/mysqlbinlog does not support the "--password PASSWORD" format (split by space).
 		// If provided like that, the program will hang.
-		mysqlArgs = append(mysqlArgs, fmt.Sprintf("--password=%s", driver.connCfg.Password))
+		mysqlArgs = append(mysqlArgs, fmt.Sprintf("--password=%s", authentication.password))
 	}
 
 	mysqlbinlogCmd := exec.CommandContext(ctx, mysqlutil.GetPath(mysqlutil.MySQLBinlog, driver.dbBinDir), mysqlbinlogArgs...)
@@ -347,7 +353,7 @@ func sortBinlogFiles(binlogFiles []BinlogFile) []BinlogFile {
 
 // GetLatestBackupBeforeOrEqualTs finds the latest logical backup and corresponding binlog info whose time is before or equal to `targetTs`.
 // The backupList should only contain DONE backups.
-func (driver *Driver) GetLatestBackupBeforeOrEqualTs(ctx context.Context, backupList []*store.BackupMessage, targetTs int64, client *bbs3.Client) (*store.BackupMessage, *api.BinlogInfo, error) {
+func (driver *Driver) GetLatestBackupBeforeOrEqualTs(ctx context.Context, backupList []*store.BackupMessage, targetTs int64, client storage.Storage) (*store.BackupMessage, *api.BinlogInfo, error) {
 	if len(backupList) == 0 {
 		return nil, nil, errors.Errorf("no valid backup")
 	}
@@ -574,7 +580,7 @@ func binlogMetaAreContinuous(files []binlogFileMeta) bool {
 }
 
 // Download binlog files on server.
-func (driver *Driver) downloadBinlogFilesOnServer(ctx context.Context, metaList []binlogFileMeta, binlogFilesOnServerSorted []BinlogFile, downloadLatestBinlogFile bool, uploader *bbs3.Client) error {
+func (driver *Driver) downloadBinlogFilesOnServer(ctx context.Context, metaList []binlogFileMeta, binlogFilesOnServerSorted []BinlogFile, downloadLatestBinlogFile bool, uploader storage.Storage) error {
 	if len(binlogFilesOnServerSorted) == 0 {
 		slog.Debug("No binlog file found on server to download")
 		return nil
@@ -616,7 +622,8 @@ func (driver *Driver) GetBinlogDir() string {
 }
 
 // FetchAllBinlogFiles downloads all binlog files on server to `binlogDir`.
-func (driver *Driver) FetchAllBinlogFiles(ctx context.Context, downloadLatestBinlogFile bool, client *bbs3.Client) error {
+// If client is not nil, the binlog files are uploaded to it and removed from `binlogDir`.
+func (driver *Driver) FetchAllBinlogFiles(ctx context.Context, downloadLatestBinlogFile bool, client storage.Storage) error {
 	if err := os.MkdirAll(driver.binlogDir, os.ModePerm); err != nil {
 		return errors.Wrapf(err, "failed to create binlog directory %q", driver.binlogDir)
 	}
@@ -648,7 +655,7 @@ func (driver *Driver) FetchAllBinlogFiles(ctx context.Context, downloadLatestBin
 	return nil
 }
 
-func (driver *Driver) syncBinlogMetaFileFromCloud(ctx context.Context, client *bbs3.Client) error {
+func (driver *Driver) syncBinlogMetaFileFromCloud(ctx context.Context, client storage.Storage) error {
 	metaListToDownload, err := driver.getBinlogMetaFileListToDownload(ctx, client)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get binlog metadata file list on cloud in directory %q", driver.binlogDir)
@@ -663,7 +670,7 @@ func (driver *Driver) syncBinlogMetaFileFromCloud(ctx context.Context, client *b
 		filePathLocal := filepath.Join(driver.binlogDir, metaFileName)
 		// Use path.Join to compose a path on cloud which always uses / as the separator.
 		filePathOnCloud := path.Join(common.GetBinlogRelativeDir(driver.binlogDir), metaFileName)
-		if err := client.DownloadFileFromCloud(ctx, filePathLocal, filePathOnCloud); err != nil {
+		if err := storage.DownloadFile(ctx, client, filePathOnCloud, filePathLocal); err != nil {
 			return errors.Wrapf(err, "failed to download binlog metadata file %s from the cloud storage", metaFileName)
 		}
 	}
@@ -671,14 +678,15 @@ func (driver *Driver) syncBinlogMetaFileFromCloud(ctx context.Context, client *b
 	return nil
 }
 
-func (driver *Driver) getBinlogMetaFileListToDownload(ctx context.Context, client *bbs3.Client) ([]string, error) {
-	listOutput, err := client.ListObjects(ctx, driver.binlogDir)
+func (driver *Driver) getBinlogMetaFileListToDownload(ctx context.Context, client storage.Storage) ([]string, error) {
+	binlogDirOnCloud := common.GetBinlogRelativeDir(driver.binlogDir)
+	listOutput, err := client.List(ctx, binlogDirOnCloud+"/")
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to list binlog dir %q in the cloud storage", driver.binlogDir)
+		return nil, errors.Wrapf(err, "failed to list binlog dir %q in the cloud storage", binlogDirOnCloud)
 	}
 	var downloadList []string
 	for _, item := range listOutput {
-		binlogPathOnCloud := *item.Key
+		binlogPathOnCloud := item.Path
 		if !strings.HasSuffix(binlogPathOnCloud, binlogMetaSuffix) {
 			continue
 		}
@@ -716,12 +724,18 @@ func (driver *Driver) downloadBinlogFile(ctx context.Context, binlogFileToDownlo
 	if driver.connCfg.Port != "" {
 		args = append(args, "--port", driver.connCfg.Port)
 	}
+	authentication, err := driver.getCLIAuthentication(ctx)
+	if err != nil {
+		return err
+	}
+	defer authentication.close()
+	args = append(args, authentication.args...)
 
 	cmd := exec.CommandContext(ctx, mysqlutil.GetPath(mysqlutil.MySQLBinlog, driver.dbBinDir), args...)
 	// We cannot set password as a flag. Otherwise, there is warning message
 	// "mysqlbinlog: [Warning] Using a password on the command line interface can be insecure."
-	if driver.connCfg.Password != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("MYSQL_PWD=%s", driver.connCfg.Password))
+	if authentication.password != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("MYSQL_PWD=%s", authentication.password))
 	}
 	cmd.Stdout = os.Stderr
 	cmd.Stderr = os.Stderr
@@ -760,7 +774,7 @@ func (driver *Driver) downloadBinlogFile(ctx context.Context, binlogFileToDownlo
 	return nil
 }
 
-func (driver *Driver) uploadBinlogFileToCloud(ctx context.Context, uploader *bbs3.Client, binlogFileName string) error {
+func (driver *Driver) uploadBinlogFileToCloud(ctx context.Context, uploader storage.Storage, binlogFileName string) error {
 	binlogFilePath := filepath.Join(driver.binlogDir, binlogFileName)
 	metaFileName := binlogFileName + binlogMetaSuffix
 	metaFilePath := filepath.Join(driver.binlogDir, metaFileName)
@@ -771,7 +785,7 @@ func (driver *Driver) uploadBinlogFileToCloud(ctx context.Context, uploader *bbs
 	defer binlogFile.Close()
 	defer os.Remove(binlogFilePath)
 	relativeDir := common.GetBinlogRelativeDir(driver.binlogDir)
-	if _, err := uploader.UploadObject(ctx, path.Join(relativeDir, binlogFileName), binlogFile); err != nil {
+	if err := uploader.Upload(ctx, path.Join(relativeDir, binlogFileName), binlogFile); err != nil {
 		// Remove the local metadata file so that it can be re-uploaded later.
 		if err := os.Remove(metaFilePath); err != nil {
 			slog.Warn("Failed to remove binlog metadata file %q when error occurs in uploading binlog file", slog.String("binlogFile", binlogFilePath), log.BBError(err))
@@ -785,7 +799,7 @@ func (driver *Driver) uploadBinlogFileToCloud(ctx context.Context, uploader *bbs
 	}
 	defer metaFile.Close()
 	// We leave the local metadata file to indicate that the binlog file has been uploaded successfully.
-	if _, err := uploader.UploadObject(ctx, path.Join(relativeDir, metaFileName), metaFile); err != nil {
+	if err := uploader.Upload(ctx, path.Join(relativeDir, metaFileName), metaFile); err != nil {
 		return errors.Wrapf(err, "failed to upload binlog metadata file %q to cloud storage", metaFileName)
 	}
 	slog.Debug("Successfully uploaded binlog file to cloud storage", slog.String("path", binlogFilePath))
@@ -880,7 +894,7 @@ func (driver *Driver) GetSortedBinlogFilesOnServer(ctx context.Context) ([]Binlo
 }
 
 // getBinlogCoordinateByTs converts a timestamp to binlog coordinate using local binlog files.
-func (driver *Driver) getBinlogCoordinateByTs(ctx context.Context, targetTs int64, client *bbs3.Client) (*binlogCoordinate, error) {
+func (driver *Driver) getBinlogCoordinateByTs(ctx context.Context, targetTs int64, client storage.Storage) (*binlogCoordinate, error) {
 	metaList, err := getSortedLocalBinlogFilesMeta(driver.binlogDir)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read local binlog metadata files")
@@ -918,7 +932,7 @@ func (driver *Driver) getBinlogCoordinateByTs(ctx context.Context, targetTs int6
 		filePathLocal := filepath.Join(driver.binlogDir, targetMeta.binlogName)
 		// Use path.Join to compose a path on cloud which always uses / as the separator.
 		filePathOnCloud := path.Join(common.GetBinlogRelativeDir(driver.binlogDir), targetMeta.binlogName)
-		if err := client.DownloadFileFromCloud(ctx, filePathLocal, filePathOnCloud); err != nil {
+		if err := storage.DownloadFile(ctx, client, filePathOnCloud, filePathLocal); err != nil {
 			return nil, errors.Wrapf(err, "failed to download binlog file %s from the cloud storage", targetMeta.binlogName)
 		}
 	}
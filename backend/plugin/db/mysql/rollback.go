@@ -81,10 +81,16 @@ func (driver *Driver) GenerateRollbackSQL(ctx context.Context, binlogSizeLimit i
 	if driver.connCfg.Port != "" {
 		args = append(args, "--port", driver.connCfg.Port)
 	}
+	authentication, err := driver.getCLIAuthentication(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer authentication.close()
+	args = append(args, authentication.args...)
 	cmd := exec.CommandContext(ctx, mysqlutil.GetPath(mysqlutil.MySQLBinlog, driver.dbBinDir), args...)
 	slog.Debug("mysqlbinlog", slog.String("command", cmd.String()))
-	if driver.connCfg.Password != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("MYSQL_PWD=%s", driver.connCfg.Password))
+	if authentication.password != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("MYSQL_PWD=%s", authentication.password))
 	}
 	pr, err := cmd.StdoutPipe()
 	if err != nil {
@@ -639,7 +639,7 @@ func analyzeSlowLog(logs []*slowLog) (map[string]*storepb.SlowQueryStatistics, e
 				dbLog = make(map[string]*storepb.SlowQueryStatisticsItem)
 				logMap[db] = dbLog
 			}
-			dbLog[fingerprint] = mergeSlowLog(fingerprint, dbLog[fingerprint], log.details)
+			dbLog[fingerprint] = util.MergeSlowQueryDetails(fingerprint, dbLog[fingerprint], log.details)
 		}
 	}
 
@@ -656,47 +656,6 @@ func analyzeSlowLog(logs []*slowLog) (map[string]*storepb.SlowQueryStatistics, e
 	return result, nil
 }
 
-func mergeSlowLog(fingerprint string, statistics *storepb.SlowQueryStatisticsItem, details *storepb.SlowQueryDetails) *storepb.SlowQueryStatisticsItem {
-	if statistics == nil {
-		return &storepb.SlowQueryStatisticsItem{
-			SqlFingerprint:      fingerprint,
-			Count:               1,
-			LatestLogTime:       details.StartTime,
-			TotalQueryTime:      details.QueryTime,
-			MaximumQueryTime:    details.QueryTime,
-			TotalRowsSent:       details.RowsSent,
-			MaximumRowsSent:     details.RowsSent,
-			TotalRowsExamined:   details.RowsExamined,
-			MaximumRowsExamined: details.RowsExamined,
-			Samples:             []*storepb.SlowQueryDetails{details},
-		}
-	}
-	statistics.Count++
-	if statistics.LatestLogTime.AsTime().Before(details.StartTime.AsTime()) {
-		statistics.LatestLogTime = details.StartTime
-	}
-	statistics.TotalQueryTime = durationpb.New(statistics.TotalQueryTime.AsDuration() + details.QueryTime.AsDuration())
-	if statistics.MaximumQueryTime.AsDuration() < details.QueryTime.AsDuration() {
-		statistics.MaximumQueryTime = details.QueryTime
-	}
-	statistics.TotalRowsSent += details.RowsSent
-	if statistics.MaximumRowsSent < details.RowsSent {
-		statistics.MaximumRowsSent = details.RowsSent
-	}
-	statistics.TotalRowsExamined += details.RowsExamined
-	if statistics.MaximumRowsExamined < details.RowsExamined {
-		statistics.MaximumRowsExamined = details.RowsExamined
-	}
-	if len(statistics.Samples) < db.SlowQueryMaxSamplePerFingerprint {
-		statistics.Samples = append(statistics.Samples, details)
-	} else {
-		// Use Reservoir Sampling to sample slow logs.
-		pos := rand.Intn(len(statistics.Samples))
-		statistics.Samples[pos] = details
-	}
-	return statistics
-}
-
 func extractDatabase(defaultDB string, sql string) []string {
 	list, err := parser.ExtractDatabaseList(parser.MySQL, sql, "")
 	if err != nil {
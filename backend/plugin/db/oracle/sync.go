@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
@@ -510,12 +512,98 @@ func getViews(txn *sql.Tx, schemaName string) (map[string][]*storepb.ViewMetadat
 	return viewMap, nil
 }
 
-// SyncSlowQuery syncs the slow query.
-func (*Driver) SyncSlowQuery(_ context.Context, _ time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
-	return nil, errors.Errorf("not implemented")
+// SyncSlowQuery syncs the query statistics from V$SQL.
+// The statistics are cumulative since the statement is loaded into the shared pool, so the caller computes the statistics of a day from the deltas between the snapshots.
+// Only the statements active since logDateTs are returned, or all the cached statements if logDateTs is zero.
+func (driver *Driver) SyncSlowQuery(ctx context.Context, logDateTs time.Time) (map[string]*storepb.SlowQueryStatistics, error) {
+	if driver.schemaTenantMode {
+		return driver.syncSlowQuery(ctx, logDateTs, "s.PARSING_SCHEMA_NAME", "", fmt.Sprintf("AND s.PARSING_SCHEMA_NAME NOT IN (%s)", systemSchema))
+	}
+	// The statements in the CDB root belong to the database named by V$DATABASE, and the others belong to the PDBs.
+	result, err := driver.syncSlowQuery(ctx, logDateTs, "CASE WHEN s.CON_ID <= 1 THEN d.NAME ELSE c.NAME END", "CROSS JOIN V$DATABASE d LEFT JOIN V$CONTAINERS c ON s.CON_ID = c.CON_ID", "")
+	if err != nil {
+		// Fall back for non-CDB databases without V$CONTAINERS.
+		return driver.syncSlowQuery(ctx, logDateTs, "d.NAME", "CROSS JOIN V$DATABASE d", "")
+	}
+	return result, nil
+}
+
+func (driver *Driver) syncSlowQuery(ctx context.Context, logDateTs time.Time, databaseExpr, join, filter string) (map[string]*storepb.SlowQueryStatistics, error) {
+	var args []any
+	if !logDateTs.IsZero() {
+		// The last active time is in the local time of the server, so we look back one more day to cover any time zone.
+		filter += " AND s.LAST_ACTIVE_TIME >= TO_DATE(:1, 'YYYY-MM-DD')"
+		args = append(args, logDateTs.AddDate(0, 0, -1).Format("2006-01-02"))
+	}
+	// Statements sharing the same FORCE_MATCHING_SIGNATURE only differ in literals.
+	// V$SQL doesn't record the maximum elapsed time of a single execution, so the maximum average elapsed time of the cursors is used instead.
+	query := fmt.Sprintf(`
+		SELECT
+			%s,
+			MIN(s.SQL_TEXT),
+			SUM(s.EXECUTIONS),
+			SUM(s.ELAPSED_TIME),
+			MAX(TRUNC(s.ELAPSED_TIME / s.EXECUTIONS)),
+			SUM(s.ROWS_PROCESSED),
+			MAX(s.LAST_ACTIVE_TIME)
+		FROM V$SQL s %s
+		WHERE s.EXECUTIONS > 0 %s
+		GROUP BY %s, DECODE(s.FORCE_MATCHING_SIGNATURE, 0, s.SQL_ID, TO_CHAR(s.FORCE_MATCHING_SIGNATURE))`,
+		databaseExpr, join, filter, databaseExpr)
+	rows, err := driver.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for rows.Next() {
+		var database, sqlText sql.NullString
+		var executions, elapsedTime, maxElapsedTime, rowsProcessed int64
+		var lastActiveTime time.Time
+		if err := rows.Scan(
+			&database,
+			&sqlText,
+			&executions,
+			&elapsedTime,
+			&maxElapsedTime,
+			&rowsProcessed,
+			&lastActiveTime,
+		); err != nil {
+			return nil, err
+		}
+		// The elapsed time is in microseconds.
+		util.MergeSlowQueryStatisticsItem(result, database.String, &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   strings.TrimSpace(sqlText.String),
+			Count:            executions,
+			LatestLogTime:    timestamppb.New(lastActiveTime),
+			TotalQueryTime:   durationpb.New(time.Duration(elapsedTime) * time.Microsecond),
+			MaximumQueryTime: durationpb.New(time.Duration(maxElapsedTime) * time.Microsecond),
+			TotalRowsSent:    rowsProcessed,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return result, nil
 }
 
 // CheckSlowQueryLogEnabled checks if slow query log is enabled.
-func (*Driver) CheckSlowQueryLogEnabled(_ context.Context) error {
-	return errors.Errorf("not implemented")
+func (driver *Driver) CheckSlowQueryLogEnabled(ctx context.Context) error {
+	// The elapsed time in V$SQL is only collected if timed statistics are enabled.
+	timedStatisticsQuery := `SELECT VALUE FROM V$PARAMETER WHERE NAME = 'timed_statistics'`
+	var timedStatistics string
+	if err := driver.db.QueryRowContext(ctx, timedStatisticsQuery).Scan(&timedStatistics); err != nil {
+		return errors.Wrapf(util.FormatErrorWithQuery(err, timedStatisticsQuery), "SELECT privilege on V$PARAMETER is required")
+	}
+	if !strings.EqualFold(timedStatistics, "TRUE") {
+		return errors.New("timed statistics is not enabled: timed_statistics = " + timedStatistics)
+	}
+
+	sqlQuery := `SELECT COUNT(*) FROM V$SQL WHERE ROWNUM = 1`
+	var count int
+	if err := driver.db.QueryRowContext(ctx, sqlQuery).Scan(&count); err != nil {
+		return errors.Wrapf(util.FormatErrorWithQuery(err, sqlQuery), "SELECT privilege on V$SQL is required")
+	}
+	return nil
 }
@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"net"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -65,10 +66,68 @@ func (driver *Driver) Dump(ctx context.Context, out io.Writer, schemaOnly bool)
 	return "", nil
 }
 
+// pgDumpAuthentication is the authentication of the pg_dump CLI tool.
+type pgDumpAuthentication struct {
+	password string
+	// env is the extra environment of pg_dump, such as requiring TLS verifying the server for the IAM authentication token.
+	env []string
+	// caFile is the temporary file of the SSL CA verifying the server, which is removed by close.
+	caFile string
+}
+
+// getPgDumpAuthentication returns the authentication of pg_dump, which must be closed after pg_dump exits.
+// pg_dump opens its own connection, so a fresh IAM authentication token is used,
+// which is sent over TLS verifying the server and its host name by the SSL CA of the data source.
+func (driver *Driver) getPgDumpAuthentication(ctx context.Context) (*pgDumpAuthentication, error) {
+	if driver.config.RefreshPassword == nil {
+		return &pgDumpAuthentication{password: driver.config.Password}, nil
+	}
+	if driver.config.TLSConfig.SslCA == "" {
+		return nil, errors.New("IAM authentication requires the SSL CA of the data source to verify the server")
+	}
+	password, err := driver.config.RefreshPassword(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to refresh the IAM authentication token")
+	}
+	f, err := os.CreateTemp("", "pg-ssl-ca-*.pem")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the SSL CA file")
+	}
+	if _, err := f.WriteString(driver.config.TLSConfig.SslCA); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, errors.Wrap(err, "failed to write the SSL CA file")
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return nil, errors.Wrap(err, "failed to write the SSL CA file")
+	}
+	return &pgDumpAuthentication{
+		password: password,
+		env:      []string{"PGSSLMODE=verify-full", fmt.Sprintf("PGSSLROOTCERT=%s", f.Name())},
+		caFile:   f.Name(),
+	}, nil
+}
+
+func (a *pgDumpAuthentication) close() {
+	if a.caFile == "" {
+		return
+	}
+	if err := os.Remove(a.caFile); err != nil {
+		slog.Warn("failed to remove the SSL CA file", slog.String("path", a.caFile), log.BBError(err))
+	}
+}
+
 func (driver *Driver) dumpOneDatabaseWithPgDump(ctx context.Context, database string, out io.Writer, schemaOnly bool) error {
+	authentication, err := driver.getPgDumpAuthentication(ctx)
+	if err != nil {
+		return err
+	}
+	defer authentication.close()
+
 	var args []string
 	args = append(args, fmt.Sprintf("--username=%s", driver.config.Username))
-	if driver.config.Password == "" {
+	if authentication.password == "" {
 		args = append(args, "--no-password")
 	}
 	if driver.sshClient == nil {
@@ -100,10 +159,14 @@ func (driver *Driver) dumpOneDatabaseWithPgDump(ctx context.Context, database st
 	args = append(args, "--no-privileges")
 	args = append(args, database)
 
-	sslCAs := splitSslCA(driver.config.TLSConfig.SslCA)
+	// The SSL CA of the IAM authentication is passed by the file, so it is not split.
+	sslCAs := []string{""}
+	if authentication.caFile == "" {
+		sslCAs = splitSslCA(driver.config.TLSConfig.SslCA)
+	}
 	dumpSuccess := false
 	for _, sslCA := range sslCAs {
-		if err := driver.execPgDump(ctx, args, out, sslCA); err != nil {
+		if err := driver.execPgDump(ctx, args, out, sslCA, authentication); err != nil {
 			slog.Warn("Failed to exec pg_dump", log.BBError(err))
 		} else {
 			dumpSuccess = true
@@ -117,13 +180,14 @@ func (driver *Driver) dumpOneDatabaseWithPgDump(ctx context.Context, database st
 	return nil
 }
 
-func (driver *Driver) execPgDump(ctx context.Context, args []string, out io.Writer, sslCA string) error {
+func (driver *Driver) execPgDump(ctx context.Context, args []string, out io.Writer, sslCA string, authentication *pgDumpAuthentication) error {
 	pgDumpPath := filepath.Join(driver.dbBinDir, "pg_dump")
 	cmd := exec.CommandContext(ctx, pgDumpPath, args...)
 	// Unlike MySQL, PostgreSQL does not support specifying commands in commands, we can do this by means of environment variables.
-	if driver.config.Password != "" {
-		cmd.Env = append(cmd.Env, fmt.Sprintf("PGPASSWORD=%s", driver.config.Password))
+	if authentication.password != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("PGPASSWORD=%s", authentication.password))
 	}
+	cmd.Env = append(cmd.Env, authentication.env...)
 	if driver.config.TLSConfig.SslCert != "" {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("PGSSLCERT=%s", driver.config.TLSConfig.SslCert))
 	}
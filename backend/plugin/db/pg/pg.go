@@ -3,6 +3,8 @@ package pg
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"fmt"
 	"log/slog"
@@ -87,6 +89,9 @@ func (driver *Driver) Open(_ context.Context, _ db.Type, config db.ConnectionCon
 	// https://neon.tech/docs/connect/connectivity-issues#c-set-verify-full-for-golang-based-clients
 	if strings.HasSuffix(config.Host, ".neon.tech") {
 		connStr += " sslmode=verify-full"
+	} else if config.RefreshPassword != nil {
+		// The IAM authentication token is sent as the password, so the server and its host name must be verified.
+		connStr += " sslmode=verify-full"
 	}
 	connConfig, err := pgx.ParseConfig(connStr)
 	if err != nil {
@@ -95,7 +100,11 @@ func (driver *Driver) Open(_ context.Context, _ db.Type, config db.ConnectionCon
 	connConfig.Config.User = config.Username
 	connConfig.Config.Password = config.Password
 	connConfig.Config.Database = config.Database
-	if config.TLSConfig.SslCert != "" {
+	if config.RefreshPassword != nil {
+		if err := setIAMTLSConfig(connConfig.TLSConfig, config.TLSConfig); err != nil {
+			return nil, err
+		}
+	} else if config.TLSConfig.SslCert != "" {
 		cfg, err := config.TLSConfig.GetSslConfig()
 		if err != nil {
 			return nil, err
@@ -132,6 +141,18 @@ func (driver *Driver) Open(_ context.Context, _ db.Type, config db.ConnectionCon
 	}
 	driver.config = config
 
+	if config.RefreshPassword != nil {
+		// The password expires soon, so get a fresh one before opening each connection of the pool.
+		driver.db = stdlib.OpenDB(*connConfig, stdlib.OptionBeforeConnect(func(ctx context.Context, cfg *pgx.ConnConfig) error {
+			password, err := config.RefreshPassword(ctx)
+			if err != nil {
+				return err
+			}
+			cfg.Password = password
+			return nil
+		}))
+		return driver, nil
+	}
 	driver.connectionString = stdlib.RegisterConnConfig(connConfig)
 	db, err := sql.Open(driverName, driver.connectionString)
 	if err != nil {
@@ -141,6 +162,30 @@ func (driver *Driver) Open(_ context.Context, _ db.Type, config db.ConnectionCon
 	return driver, nil
 }
 
+// setIAMTLSConfig sets the TLS config of the verify-full SSL mode to verify the server by the SSL CA of the data source
+// instead of the system roots, and to present the client certificate if it's set.
+func setIAMTLSConfig(tlsConfig *tls.Config, tc db.TLSConfig) error {
+	if tc.SslCA == "" {
+		return errors.New("IAM authentication requires the SSL CA of the data source to verify the server")
+	}
+	if tlsConfig == nil || tlsConfig.InsecureSkipVerify || tlsConfig.ServerName == "" {
+		return errors.New("IAM authentication requires the verify-full SSL mode")
+	}
+	rootCertPool := x509.NewCertPool()
+	if ok := rootCertPool.AppendCertsFromPEM([]byte(tc.SslCA)); !ok {
+		return errors.Errorf("failed to append the SSL CA of the data source")
+	}
+	tlsConfig.RootCAs = rootCertPool
+	if tc.SslCert != "" {
+		cert, err := tls.X509KeyPair([]byte(tc.SslCert), []byte(tc.SslKey))
+		if err != nil {
+			return err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return nil
+}
+
 type noDeadlineConn struct{ net.Conn }
 
 func (*noDeadlineConn) SetDeadline(time.Time) error      { return nil }
@@ -260,7 +305,7 @@ func (driver *Driver) getPGStatStatementsVersion(ctx context.Context) (string, e
 
 // Execute will execute the statement. For CREATE DATABASE statement, some types of databases such as Postgres
 // will not use transactions to execute the statement but will still use transactions to execute the rest of statements.
-func (driver *Driver) Execute(ctx context.Context, statement string, createDatabase bool, _ db.ExecuteOptions) (int64, error) {
+func (driver *Driver) Execute(ctx context.Context, statement string, createDatabase bool, opts db.ExecuteOptions) (int64, error) {
 	if createDatabase {
 		databases, err := driver.getDatabases(ctx)
 		if err != nil {
@@ -319,6 +364,15 @@ func (driver *Driver) Execute(ctx context.Context, statement string, createDatab
 		} else if isNonTransactionStatement(stmt) {
 			nonTransactionStmts = append(nonTransactionStmts, stmt)
 		} else if !isIgnoredStatement(stmt) {
+			if opts.BeforeStatementFunc != nil {
+				beforeStmt, err := opts.BeforeStatementFunc(stmt)
+				if err != nil {
+					return err
+				}
+				if beforeStmt != "" {
+					remainingStmts = append(remainingStmts, beforeStmt)
+				}
+			}
 			remainingStmts = append(remainingStmts, stmt)
 		}
 		return nil
@@ -1,9 +1,20 @@
 package pg
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
 )
 
 func TestGetDatabaseInCreateDatabaseStatement(t *testing.T) {
@@ -49,3 +60,35 @@ func TestGetDatabaseInCreateDatabaseStatement(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestSetIAMTLSConfig(t *testing.T) {
+	a := require.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	a.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	a.NoError(err)
+	sslCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	connConfig, err := pgx.ParseConfig("host=db.example.com port=5432 sslmode=verify-full")
+	a.NoError(err)
+	a.NoError(setIAMTLSConfig(connConfig.TLSConfig, db.TLSConfig{SslCA: sslCA}))
+	a.False(connConfig.TLSConfig.InsecureSkipVerify)
+	a.Equal("db.example.com", connConfig.TLSConfig.ServerName)
+	a.NotNil(connConfig.TLSConfig.RootCAs)
+	a.Empty(connConfig.Fallbacks)
+
+	// The SSL CA is required.
+	a.Error(setIAMTLSConfig(connConfig.TLSConfig, db.TLSConfig{}))
+	// The server must be verified.
+	connConfig, err = pgx.ParseConfig("host=db.example.com port=5432 sslmode=require")
+	a.NoError(err)
+	a.Error(setIAMTLSConfig(connConfig.TLSConfig, db.TLSConfig{SslCA: sslCA}))
+}
@@ -0,0 +1,408 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RollbackBackupSchema is the schema holding the rows backed up before executing UPDATE and DELETE statements.
+	RollbackBackupSchema = "bbdataarchive"
+	defaultSchema        = "public"
+)
+
+// RollbackBackupType is the type of the statement whose affected rows are backed up.
+type RollbackBackupType string
+
+const (
+	// RollbackBackupUpdate is the backup for UPDATE statements.
+	RollbackBackupUpdate RollbackBackupType = "UPDATE"
+	// RollbackBackupDelete is the backup for DELETE statements.
+	RollbackBackupDelete RollbackBackupType = "DELETE"
+)
+
+// RollbackBackup is the backup of the rows affected by an UPDATE or DELETE statement.
+type RollbackBackup struct {
+	Type RollbackBackupType
+	// Schema and Table are the table changed by the statement.
+	Schema string
+	Table  string
+	// BackupTable is the table in RollbackBackupSchema holding the rows before the change.
+	BackupTable string
+	// UpdatedColumns are the columns set by the UPDATE statement.
+	UpdatedColumns []string
+}
+
+// GetRollbackBackupStatement returns the statement copying the rows affected by the UPDATE or DELETE statement into backupTable in RollbackBackupSchema.
+// The backup statement must be executed in the same transaction right before the statement.
+// It returns nil and an empty statement if the statement doesn't change rows.
+// It returns an error for the statements changing rows which cannot be rolled back, e.g. INSERT, upsert and TRUNCATE,
+// the rollback SQL of a task containing them cannot be generated.
+func GetRollbackBackupStatement(statement string, backupTable string) (*RollbackBackup, string, error) {
+	res, err := pgquery.Parse(statement)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to parse statement %q", statement)
+	}
+	if len(res.Stmts) != 1 {
+		return nil, "", errors.Errorf("expecting one statement, but got %d", len(res.Stmts))
+	}
+
+	if hasDataModifyingCTE(res.Stmts[0].Stmt) {
+		return nil, "", errors.Errorf("data-modifying statements in WITH are not supported for rollback in statement %q", statement)
+	}
+
+	var backupType RollbackBackupType
+	var updatedColumns []string
+	var relation *pgquery.RangeVar
+	var fromClause []*pgquery.Node
+	var whereClause *pgquery.Node
+	var withClause *pgquery.WithClause
+	switch node := res.Stmts[0].Stmt.Node.(type) {
+	case *pgquery.Node_UpdateStmt:
+		backupType = RollbackBackupUpdate
+		relation = node.UpdateStmt.Relation
+		fromClause = node.UpdateStmt.FromClause
+		whereClause = node.UpdateStmt.WhereClause
+		withClause = node.UpdateStmt.WithClause
+		for _, target := range node.UpdateStmt.TargetList {
+			if resTarget, ok := target.Node.(*pgquery.Node_ResTarget); ok {
+				updatedColumns = append(updatedColumns, resTarget.ResTarget.Name)
+			}
+		}
+	case *pgquery.Node_DeleteStmt:
+		backupType = RollbackBackupDelete
+		relation = node.DeleteStmt.Relation
+		fromClause = node.DeleteStmt.UsingClause
+		whereClause = node.DeleteStmt.WhereClause
+		withClause = node.DeleteStmt.WithClause
+	case *pgquery.Node_InsertStmt:
+		// The inserted rows, and the rows updated by INSERT ... ON CONFLICT DO UPDATE, cannot be identified before the statement runs.
+		return nil, "", errors.Errorf("INSERT statements are not supported for rollback in statement %q", statement)
+	case *pgquery.Node_MergeStmt:
+		return nil, "", errors.Errorf("MERGE statements are not supported for rollback in statement %q", statement)
+	case *pgquery.Node_TruncateStmt:
+		return nil, "", errors.Errorf("TRUNCATE statements are not supported for rollback in statement %q", statement)
+	case *pgquery.Node_CopyStmt:
+		if node.CopyStmt.IsFrom {
+			return nil, "", errors.Errorf("COPY FROM statements are not supported for rollback in statement %q", statement)
+		}
+		return nil, "", nil
+	default:
+		return nil, "", nil
+	}
+	if relation == nil {
+		return nil, "", errors.Errorf("missing target table in statement %q", statement)
+	}
+	if whereClause != nil {
+		if _, ok := whereClause.Node.(*pgquery.Node_CurrentOfExpr); ok {
+			return nil, "", errors.Errorf("WHERE CURRENT OF is not supported for rollback in statement %q", statement)
+		}
+	}
+
+	// The rows of the target table are referred by the alias if the statement has one.
+	refName := relation.Relname
+	if relation.Alias != nil && relation.Alias.Aliasname != "" {
+		refName = relation.Alias.Aliasname
+	}
+	selectStmt := &pgquery.SelectStmt{
+		TargetList: []*pgquery.Node{
+			pgquery.MakeResTargetNodeWithVal(pgquery.MakeColumnRefNode([]*pgquery.Node{pgquery.MakeStrNode(refName), pgquery.MakeAStarNode()}, 0), 0),
+		},
+		FromClause:  append([]*pgquery.Node{{Node: &pgquery.Node_RangeVar{RangeVar: relation}}}, fromClause...),
+		WhereClause: whereClause,
+		WithClause:  withClause,
+	}
+	if len(fromClause) > 0 {
+		// A row of the target table can be joined with multiple rows from the FROM or USING clause, so we deduplicate them by ctid.
+		selectStmt.DistinctClause = []*pgquery.Node{
+			pgquery.MakeColumnRefNode([]*pgquery.Node{pgquery.MakeStrNode(refName), pgquery.MakeStrNode("ctid")}, 0),
+		}
+	}
+	query, err := pgquery.Deparse(&pgquery.ParseResult{
+		Stmts: []*pgquery.RawStmt{{Stmt: &pgquery.Node{Node: &pgquery.Node_SelectStmt{SelectStmt: selectStmt}}}},
+	})
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to deparse the backup query for statement %q", statement)
+	}
+
+	schema := relation.Schemaname
+	if schema == "" {
+		schema = defaultSchema
+	}
+	backup := &RollbackBackup{
+		Type:           backupType,
+		Schema:         schema,
+		Table:          relation.Relname,
+		BackupTable:    backupTable,
+		UpdatedColumns: updatedColumns,
+	}
+	backupStatement := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s;\nCREATE TABLE %s.%s AS %s;", quoteIdentifier(RollbackBackupSchema), quoteIdentifier(RollbackBackupSchema), quoteIdentifier(backupTable), query)
+	return backup, backupStatement, nil
+}
+
+// hasDataModifyingCTE returns true if the statement has INSERT, UPDATE or DELETE in its WITH clause.
+// The rows changed by such statements are not backed up, and the backup query cannot contain them either,
+// because PostgreSQL would execute them again.
+func hasDataModifyingCTE(node *pgquery.Node) bool {
+	var withClause *pgquery.WithClause
+	switch n := node.Node.(type) {
+	case *pgquery.Node_SelectStmt:
+		withClause = n.SelectStmt.WithClause
+		if n.SelectStmt.Larg != nil && hasDataModifyingCTE(&pgquery.Node{Node: &pgquery.Node_SelectStmt{SelectStmt: n.SelectStmt.Larg}}) {
+			return true
+		}
+		if n.SelectStmt.Rarg != nil && hasDataModifyingCTE(&pgquery.Node{Node: &pgquery.Node_SelectStmt{SelectStmt: n.SelectStmt.Rarg}}) {
+			return true
+		}
+	case *pgquery.Node_InsertStmt:
+		withClause = n.InsertStmt.WithClause
+		if n.InsertStmt.SelectStmt != nil && hasDataModifyingCTE(n.InsertStmt.SelectStmt) {
+			return true
+		}
+	case *pgquery.Node_UpdateStmt:
+		withClause = n.UpdateStmt.WithClause
+	case *pgquery.Node_DeleteStmt:
+		withClause = n.DeleteStmt.WithClause
+	case *pgquery.Node_CreateTableAsStmt:
+		return n.CreateTableAsStmt.Query != nil && hasDataModifyingCTE(n.CreateTableAsStmt.Query)
+	}
+	if withClause == nil {
+		return false
+	}
+	for _, cte := range withClause.Ctes {
+		commonTableExpr, ok := cte.Node.(*pgquery.Node_CommonTableExpr)
+		if !ok || commonTableExpr.CommonTableExpr.Ctequery == nil {
+			continue
+		}
+		switch commonTableExpr.CommonTableExpr.Ctequery.Node.(type) {
+		case *pgquery.Node_InsertStmt, *pgquery.Node_UpdateStmt, *pgquery.Node_DeleteStmt:
+			return true
+		}
+		if hasDataModifyingCTE(commonTableExpr.CommonTableExpr.Ctequery) {
+			return true
+		}
+	}
+	return false
+}
+
+// DropRollbackBackupTables drops the backup tables in RollbackBackupSchema.
+func (driver *Driver) DropRollbackBackupTables(ctx context.Context, backupList []*RollbackBackup) error {
+	for _, backup := range backupList {
+		if _, err := driver.db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s.%s", quoteIdentifier(RollbackBackupSchema), quoteIdentifier(backup.BackupTable))); err != nil {
+			return errors.Wrapf(err, "failed to drop backup table %q", backup.BackupTable)
+		}
+	}
+	return nil
+}
+
+// GenerateRollbackSQL generates the rollback SQL statements from the backup tables in the reversed order.
+// The rows backed up for DELETE statements are inserted back, and the rows backed up for UPDATE statements are updated back by the primary key.
+// Generation fails if the statements exceed rollbackSizeLimit bytes.
+func (driver *Driver) GenerateRollbackSQL(ctx context.Context, rollbackSizeLimit int, backupList []*RollbackBackup) (string, error) {
+	var sqlList []string
+	size := 0
+	for i := len(backupList) - 1; i >= 0; i-- {
+		backup := backupList[i]
+		statements, err := driver.getRollbackSQL(ctx, backup)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to generate rollback SQL for backup table %q", backup.BackupTable)
+		}
+		for _, statement := range statements {
+			size += len(statement)
+			if size > rollbackSizeLimit {
+				return "", errors.Errorf("rollback SQL exceeds the size limit of %d bytes", rollbackSizeLimit)
+			}
+		}
+		sqlList = append(sqlList, statements...)
+	}
+	return strings.Join(sqlList, "\n"), nil
+}
+
+type rollbackColumn struct {
+	name string
+	// generated is true for the generated columns, which cannot be written.
+	generated bool
+	// identityAlways is true for GENERATED ALWAYS AS IDENTITY columns, which require OVERRIDING SYSTEM VALUE on insertion.
+	identityAlways bool
+	primary        bool
+}
+
+func (driver *Driver) getRollbackSQL(ctx context.Context, backup *RollbackBackup) ([]string, error) {
+	columns, err := driver.getRollbackColumns(ctx, backup.Schema, backup.Table)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, errors.Errorf("table %q.%q not found", backup.Schema, backup.Table)
+	}
+
+	var writableColumns []*rollbackColumn
+	var selectList []string
+	for _, column := range columns {
+		if column.generated {
+			continue
+		}
+		writableColumns = append(writableColumns, column)
+		selectList = append(selectList, fmt.Sprintf("%s::text", quoteIdentifier(column.name)))
+	}
+	table := fmt.Sprintf("%s.%s", quoteIdentifier(backup.Schema), quoteIdentifier(backup.Table))
+
+	var rowList [][]string
+	rows, err := driver.db.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s.%s", strings.Join(selectList, ", "), quoteIdentifier(RollbackBackupSchema), quoteIdentifier(backup.BackupTable)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		values := make([]sql.NullString, len(writableColumns))
+		dest := make([]any, len(writableColumns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		var row []string
+		for _, value := range values {
+			row = append(row, quoteLiteral(value))
+		}
+		rowList = append(rowList, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	switch backup.Type {
+	case RollbackBackupDelete:
+		return getInsertRollbackSQL(table, writableColumns, rowList), nil
+	case RollbackBackupUpdate:
+		return getUpdateRollbackSQL(table, writableColumns, rowList, backup.UpdatedColumns)
+	default:
+		return nil, errors.Errorf("unsupported backup type %q", backup.Type)
+	}
+}
+
+func (driver *Driver) getRollbackColumns(ctx context.Context, schema, table string) ([]*rollbackColumn, error) {
+	primaryKeys := make(map[string]bool)
+	pkRows, err := driver.db.QueryContext(ctx, `
+		SELECT a.attname
+		FROM pg_index i
+		JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = $1::regclass AND i.indisprimary`,
+		fmt.Sprintf("%s.%s", quoteIdentifier(schema), quoteIdentifier(table)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer pkRows.Close()
+	for pkRows.Next() {
+		var name string
+		if err := pkRows.Scan(&name); err != nil {
+			return nil, err
+		}
+		primaryKeys[name] = true
+	}
+	if err := pkRows.Err(); err != nil {
+		return nil, err
+	}
+
+	var columns []*rollbackColumn
+	rows, err := driver.db.QueryContext(ctx, `
+		SELECT column_name, is_generated, identity_generation
+		FROM information_schema.columns
+		WHERE table_schema = $1 AND table_name = $2
+		ORDER BY ordinal_position`,
+		schema, table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		var isGenerated, identityGeneration sql.NullString
+		if err := rows.Scan(&name, &isGenerated, &identityGeneration); err != nil {
+			return nil, err
+		}
+		columns = append(columns, &rollbackColumn{
+			name:           name,
+			generated:      isGenerated.String == "ALWAYS",
+			identityAlways: identityGeneration.String == "ALWAYS",
+			primary:        primaryKeys[name],
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func getInsertRollbackSQL(table string, columns []*rollbackColumn, rowList [][]string) []string {
+	var columnNames []string
+	overriding := ""
+	for _, column := range columns {
+		columnNames = append(columnNames, quoteIdentifier(column.name))
+		if column.identityAlways {
+			overriding = " OVERRIDING SYSTEM VALUE"
+		}
+	}
+	var result []string
+	for _, row := range rowList {
+		result = append(result, fmt.Sprintf("INSERT INTO %s (%s)%s VALUES (%s);", table, strings.Join(columnNames, ", "), overriding, strings.Join(row, ", ")))
+	}
+	return result
+}
+
+func getUpdateRollbackSQL(table string, columns []*rollbackColumn, rowList [][]string, updatedColumns []string) ([]string, error) {
+	primaryKeys := make(map[string]bool)
+	for _, column := range columns {
+		if column.primary {
+			primaryKeys[column.name] = true
+		}
+	}
+	if len(primaryKeys) == 0 {
+		return nil, errors.Errorf("table %s has no primary key", table)
+	}
+	for _, column := range updatedColumns {
+		if primaryKeys[column] {
+			// The rows are located by the primary key before the change, which no longer exists after the UPDATE.
+			return nil, errors.Errorf("cannot roll back the UPDATE statement changing the primary key column %q of table %s", column, table)
+		}
+	}
+
+	var result []string
+	for _, row := range rowList {
+		var setList, whereList []string
+		for i, column := range columns {
+			switch {
+			case column.primary:
+				whereList = append(whereList, fmt.Sprintf("%s = %s", quoteIdentifier(column.name), row[i]))
+			case column.identityAlways:
+				// GENERATED ALWAYS AS IDENTITY columns can only be updated to DEFAULT.
+			default:
+				setList = append(setList, fmt.Sprintf("%s = %s", quoteIdentifier(column.name), row[i]))
+			}
+		}
+		if len(setList) == 0 {
+			continue
+		}
+		result = append(result, fmt.Sprintf("UPDATE %s SET %s WHERE %s;", table, strings.Join(setList, ", "), strings.Join(whereList, " AND ")))
+	}
+	return result, nil
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+func quoteLiteral(value sql.NullString) string {
+	if !value.Valid {
+		return "NULL"
+	}
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value.String, "'", "''"))
+}
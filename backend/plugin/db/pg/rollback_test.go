@@ -0,0 +1,113 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRollbackBackupStatement(t *testing.T) {
+	tests := []struct {
+		statement       string
+		backup          *RollbackBackup
+		backupStatement string
+	}{
+		{
+			statement: "SELECT * FROM t",
+		},
+		{
+			statement: "UPDATE t SET a = 1 WHERE b > 2",
+			backup: &RollbackBackup{
+				Type:           RollbackBackupUpdate,
+				Schema:         "public",
+				Table:          "t",
+				BackupTable:    "task1_0",
+				UpdatedColumns: []string{"a"},
+			},
+			backupStatement: "CREATE SCHEMA IF NOT EXISTS \"bbdataarchive\";\nCREATE TABLE \"bbdataarchive\".\"task1_0\" AS SELECT t.* FROM t WHERE b > 2;",
+		},
+		{
+			statement: "DELETE FROM s.t AS x WHERE x.a IN (1, 2)",
+			backup: &RollbackBackup{
+				Type:        RollbackBackupDelete,
+				Schema:      "s",
+				Table:       "t",
+				BackupTable: "task1_0",
+			},
+			backupStatement: "CREATE SCHEMA IF NOT EXISTS \"bbdataarchive\";\nCREATE TABLE \"bbdataarchive\".\"task1_0\" AS SELECT x.* FROM s.t x WHERE x.a IN (1, 2);",
+		},
+		{
+			statement: "UPDATE t SET a = u.a FROM u WHERE t.id = u.id",
+			backup: &RollbackBackup{
+				Type:           RollbackBackupUpdate,
+				Schema:         "public",
+				Table:          "t",
+				BackupTable:    "task1_0",
+				UpdatedColumns: []string{"a"},
+			},
+			backupStatement: "CREATE SCHEMA IF NOT EXISTS \"bbdataarchive\";\nCREATE TABLE \"bbdataarchive\".\"task1_0\" AS SELECT DISTINCT ON (t.ctid) t.* FROM t, u WHERE t.id = u.id;",
+		},
+		{
+			statement: "DELETE FROM t",
+			backup: &RollbackBackup{
+				Type:        RollbackBackupDelete,
+				Schema:      "public",
+				Table:       "t",
+				BackupTable: "task1_0",
+			},
+			backupStatement: "CREATE SCHEMA IF NOT EXISTS \"bbdataarchive\";\nCREATE TABLE \"bbdataarchive\".\"task1_0\" AS SELECT t.* FROM t;",
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		backup, backupStatement, err := GetRollbackBackupStatement(test.statement, "task1_0")
+		a.NoError(err)
+		a.Equal(test.backup, backup, test.statement)
+		a.Equal(test.backupStatement, backupStatement, test.statement)
+	}
+}
+
+func TestGetRollbackBackupStatementDataModifyingCTE(t *testing.T) {
+	a := require.New(t)
+	for _, statement := range []string{
+		"WITH d AS (DELETE FROM t WHERE a = 1 RETURNING *) SELECT * FROM d",
+		"WITH d AS (DELETE FROM u RETURNING id) UPDATE t SET a = 1 WHERE id IN (SELECT id FROM d)",
+		"WITH x AS (WITH d AS (UPDATE u SET a = 1 RETURNING id) SELECT id FROM d) DELETE FROM t WHERE id IN (SELECT id FROM x)",
+		"INSERT INTO t WITH d AS (DELETE FROM u RETURNING *) SELECT * FROM d",
+	} {
+		_, _, err := GetRollbackBackupStatement(statement, "task1_0")
+		a.ErrorContains(err, "data-modifying statements in WITH are not supported", statement)
+	}
+}
+
+func TestGetRollbackBackupStatementUnsupported(t *testing.T) {
+	a := require.New(t)
+	for _, statement := range []string{
+		"INSERT INTO t VALUES (1)",
+		"INSERT INTO t VALUES (1) ON CONFLICT (id) DO UPDATE SET a = excluded.a",
+		"INSERT INTO t SELECT * FROM u",
+		"MERGE INTO t USING u ON t.id = u.id WHEN MATCHED THEN UPDATE SET a = u.a",
+		"TRUNCATE t",
+		"COPY t FROM '/tmp/t.csv'",
+	} {
+		_, _, err := GetRollbackBackupStatement(statement, "task1_0")
+		a.ErrorContains(err, "not supported for rollback", statement)
+	}
+}
+
+func TestGetUpdateRollbackSQL(t *testing.T) {
+	a := require.New(t)
+	columns := []*rollbackColumn{
+		{name: "id", primary: true},
+		{name: "a"},
+	}
+	rowList := [][]string{{"'1'", "'x'"}}
+
+	result, err := getUpdateRollbackSQL(`"public"."t"`, columns, rowList, []string{"a"})
+	a.NoError(err)
+	a.Equal([]string{`UPDATE "public"."t" SET "a" = 'x' WHERE "id" = '1';`}, result)
+
+	_, err = getUpdateRollbackSQL(`"public"."t"`, columns, rowList, []string{"a", "id"})
+	a.ErrorContains(err, `changing the primary key column "id"`)
+}
@@ -9,7 +9,6 @@ import (
 	"log/slog"
 	"net"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -21,6 +20,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
@@ -147,21 +147,14 @@ func (d *Driver) Execute(ctx context.Context, statement string, createDatabase b
 		return 0, errors.New("redis: cannot create database")
 	}
 
-	lines := strings.Split(statement, "\n")
-	for i := range lines {
-		lines[i] = strings.Trim(lines[i], " \n\t\r")
+	commands, err := parser.ParseRedisCommands(statement)
+	if err != nil {
+		return 0, err
 	}
 
 	if _, err := d.rdb.Pipelined(ctx, func(p redis.Pipeliner) error {
-		for _, line := range lines {
-			if line == "" {
-				continue
-			}
-			var input []any
-			for _, s := range strings.Split(line, " ") {
-				input = append(input, s)
-			}
-			_ = p.Do(ctx, input...)
+		for _, command := range commands {
+			_ = p.Do(ctx, getCommandInput(command)...)
 		}
 		return nil
 	}); err != nil && err != redis.Nil {
@@ -187,26 +180,27 @@ func (*Driver) Restore(context.Context, io.Reader) error {
 }
 
 // QueryConn queries a SQL statement in a given connection.
-func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, _ *db.QueryContext) ([]*v1pb.QueryResult, error) {
+// Only the read commands are allowed if the query is read-only.
+func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, queryContext *db.QueryContext) ([]*v1pb.QueryResult, error) {
 	startTime := time.Now()
-	lines := strings.Split(statement, "\n")
-	for i := range lines {
-		lines[i] = strings.Trim(lines[i], " \n\t\r")
+	commands, err := parser.ParseRedisCommands(statement)
+	if err != nil {
+		return nil, err
+	}
+	if queryContext != nil && queryContext.ReadOnly {
+		for _, command := range commands {
+			if command.Category != parser.RedisCommandRead {
+				return nil, errors.Errorf("redis: %s is not allowed in read-only mode", command.Name)
+			}
+		}
 	}
 
 	var data []*v1pb.QueryRow
 	var cmds []*redis.Cmd
 
 	if _, err := d.rdb.Pipelined(ctx, func(p redis.Pipeliner) error {
-		for _, line := range lines {
-			if line == "" {
-				continue
-			}
-			var input []any
-			for _, s := range strings.Split(line, " ") {
-				input = append(input, s)
-			}
-			cmd := p.Do(ctx, input...)
+		for _, command := range commands {
+			cmd := p.Do(ctx, getCommandInput(command)...)
 			cmds = append(cmds, cmd)
 		}
 		return nil
@@ -234,6 +228,14 @@ func (d *Driver) QueryConn(ctx context.Context, _ *sql.Conn, statement string, _
 	}}, nil
 }
 
+func getCommandInput(command *parser.RedisCommand) []any {
+	var input []any
+	for _, argument := range command.Arguments {
+		input = append(input, argument)
+	}
+	return input
+}
+
 // RunStatement runs a SQL statement in a given connection.
 func (d *Driver) RunStatement(ctx context.Context, _ *sql.Conn, statement string) ([]*v1pb.QueryResult, error) {
 	return d.QueryConn(ctx, nil, statement, nil)
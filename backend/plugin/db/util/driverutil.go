@@ -21,6 +21,7 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
@@ -121,15 +122,26 @@ func ApplyMultiStatements(sc io.Reader, f func(string) error) error {
 
 // Query will execute a readonly / SELECT query.
 func Query(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string, queryContext *db.QueryContext) (*v1pb.QueryResult, error) {
-	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: queryContext.ReadOnly})
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
+	var rows *sql.Rows
+	if queryContext.InTransaction {
+		// Beginning another transaction would end the open transaction of the connection.
+		r, err := conn.QueryContext(ctx, statement)
+		if err != nil {
+			return nil, FormatErrorWithQuery(err, statement)
+		}
+		rows = r
+	} else {
+		tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: queryContext.ReadOnly})
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
 
-	rows, err := tx.QueryContext(ctx, statement)
-	if err != nil {
-		return nil, FormatErrorWithQuery(err, statement)
+		r, err := tx.QueryContext(ctx, statement)
+		if err != nil {
+			return nil, FormatErrorWithQuery(err, statement)
+		}
+		rows = r
 	}
 	defer rows.Close()
 
@@ -150,7 +162,7 @@ func Query(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string
 		return nil, errors.Errorf("failed to extract sensitive fields: %q", statement)
 	}
 
-	var fieldMaskingLevels []storepb.MaskingLevel
+	var fieldMaskers []masker.Masker
 	var fieldMaskInfo []bool
 	var fieldSensitiveInfo []bool
 	for i := range columnNames {
@@ -158,8 +170,15 @@ func Query(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string
 		if len(fieldList) > i && queryContext.EnableSensitive {
 			maskingLevel = fieldList[i].MaskingLevel
 		}
-		fieldMaskingLevels = append(fieldMaskingLevels, maskingLevel)
 		sensitive := len(fieldList) > i && (maskingLevel == storepb.MaskingLevel_FULL || maskingLevel == storepb.MaskingLevel_PARTIAL)
+		var fieldMasker masker.Masker
+		if sensitive {
+			fieldMasker = fieldList[i].Masker
+			if fieldMasker == nil {
+				fieldMasker = masker.NewDefaultMasker(maskingLevel)
+			}
+		}
+		fieldMaskers = append(fieldMaskers, fieldMasker)
 		fieldMaskInfo = append(fieldMaskInfo, sensitive && queryContext.EnableSensitive)
 		fieldSensitiveInfo = append(fieldSensitiveInfo, sensitive)
 	}
@@ -176,8 +195,24 @@ func Query(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string
 		columnTypeNames = append(columnTypeNames, strings.ToUpper(v.DatabaseTypeName()))
 	}
 
-	data, err := readRows(rows, columnTypeNames, fieldMaskingLevels)
-	if err != nil {
+	result := &v1pb.QueryResult{
+		ColumnNames:     columnNames,
+		ColumnTypeNames: columnTypeNames,
+		Masked:          fieldMaskInfo,
+		Sensitive:       fieldSensitiveInfo,
+	}
+	// The rows are collected in the result, or passed to the row streamer without being kept in memory.
+	handleRow := func(row *v1pb.QueryRow) error {
+		result.Rows = append(result.Rows, row)
+		return nil
+	}
+	if streamer := queryContext.RowStreamer; streamer != nil {
+		if err := streamer.Begin(result); err != nil {
+			return nil, err
+		}
+		handleRow = streamer.Row
+	}
+	if err := readRows(rows, columnTypeNames, fieldMaskers, handleRow); err != nil {
 		return nil, err
 	}
 
@@ -185,13 +220,7 @@ func Query(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string
 		return nil, err
 	}
 
-	return &v1pb.QueryResult{
-		ColumnNames:     columnNames,
-		ColumnTypeNames: columnTypeNames,
-		Rows:            data,
-		Masked:          fieldMaskInfo,
-		Sensitive:       fieldSensitiveInfo,
-	}, nil
+	return result, nil
 }
 
 // RunStatement runs a SQL statement in a given connection.
@@ -248,6 +277,138 @@ func RunStatement(ctx context.Context, engineType parser.EngineType, conn *sql.C
 	return results, nil
 }
 
+// MaskResults masks the sensitive columns of the query results in place.
+// The sensitive fields are extracted from the statement of each result, the whole statement is used if the result does not carry one.
+// Masking fails closed for the results of SELECT statements: all columns of such a result are fully masked if its sensitive fields cannot be determined.
+// The results of other statements, e.g. the affected rows of DML, SHOW and DDL, don't return table data and are left unchanged.
+func MaskResults(dbType db.Type, statement string, currentDatabase string, schemaInfo *db.SensitiveSchemaInfo, results []*v1pb.QueryResult) {
+	if schemaInfo == nil {
+		return
+	}
+	for _, result := range results {
+		if result.Error != "" || len(result.ColumnNames) == 0 {
+			continue
+		}
+		resultStatement, ok := getResultStatement(statement, result, len(results))
+		if !ok {
+			maskAllColumns(result)
+			continue
+		}
+		if !IsSelectStatement(resultStatement) {
+			continue
+		}
+		fieldList, err := extractSensitiveField(dbType, resultStatement, currentDatabase, schemaInfo)
+		if err != nil {
+			slog.Debug("failed to extract sensitive fields, mask all columns", slog.String("statement", resultStatement), log.BBError(err))
+			maskAllColumns(result)
+			continue
+		}
+		if len(fieldList) != len(result.ColumnNames) {
+			maskAllColumns(result)
+			continue
+		}
+
+		fieldMaskers := make([]masker.Masker, len(fieldList))
+		result.Masked = make([]bool, len(fieldList))
+		result.Sensitive = make([]bool, len(fieldList))
+		for i, field := range fieldList {
+			if field.MaskingLevel != storepb.MaskingLevel_FULL && field.MaskingLevel != storepb.MaskingLevel_PARTIAL {
+				continue
+			}
+			fieldMaskers[i] = field.Masker
+			if fieldMaskers[i] == nil {
+				fieldMaskers[i] = masker.NewDefaultMasker(field.MaskingLevel)
+			}
+			result.Masked[i] = true
+			result.Sensitive[i] = true
+		}
+		maskRows(result, fieldMaskers)
+	}
+}
+
+// MaskAllResults fully masks all columns of the results of SELECT statements in place.
+// It is used if the sensitive schema info of the statement cannot be determined.
+func MaskAllResults(statement string, results []*v1pb.QueryResult) {
+	for _, result := range results {
+		if result.Error != "" || len(result.ColumnNames) == 0 {
+			continue
+		}
+		if resultStatement, ok := getResultStatement(statement, result, len(results)); ok && !IsSelectStatement(resultStatement) {
+			continue
+		}
+		maskAllColumns(result)
+	}
+}
+
+// getResultStatement returns the statement of the result, or the whole statement if it is the only result.
+// It returns false if the statement of the result is unknown.
+func getResultStatement(statement string, result *v1pb.QueryResult, resultCount int) (string, bool) {
+	if result.Statement != "" {
+		return result.Statement, true
+	}
+	if resultCount != 1 {
+		return "", false
+	}
+	return statement, true
+}
+
+// IsSelectStatement returns true if the statement is a SELECT statement or a CTE, which returns table data.
+// The leading comments and parentheses are skipped.
+func IsSelectStatement(stmt string) bool {
+	for {
+		stmt = strings.TrimLeft(stmt, " \t\r\n(")
+		switch {
+		case strings.HasPrefix(stmt, "--"):
+			_, rest, found := strings.Cut(stmt, "\n")
+			if !found {
+				return false
+			}
+			stmt = rest
+		case strings.HasPrefix(stmt, "/*"):
+			_, rest, found := strings.Cut(stmt, "*/")
+			if !found {
+				return false
+			}
+			stmt = rest
+		default:
+			upperStatement := strings.ToUpper(stmt)
+			for _, prefix := range []string{"SELECT", "WITH", "TABLE", "VALUES"} {
+				if strings.HasPrefix(upperStatement, prefix) && (len(upperStatement) == len(prefix) || !isIdentifierChar(upperStatement[len(prefix)])) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || ('0' <= c && c <= '9') || ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z')
+}
+
+func maskAllColumns(result *v1pb.QueryResult) {
+	fullMasker := masker.NewDefaultMasker(storepb.MaskingLevel_FULL)
+	fieldMaskers := make([]masker.Masker, len(result.ColumnNames))
+	result.Masked = make([]bool, len(result.ColumnNames))
+	result.Sensitive = make([]bool, len(result.ColumnNames))
+	for i := range result.ColumnNames {
+		fieldMaskers[i] = fullMasker
+		result.Masked[i] = true
+		result.Sensitive[i] = true
+	}
+	maskRows(result, fieldMaskers)
+}
+
+func maskRows(result *v1pb.QueryResult, fieldMaskers []masker.Masker) {
+	for _, row := range result.Rows {
+		for i, value := range row.Values {
+			if i < len(fieldMaskers) && fieldMaskers[i] != nil {
+				row.Values[i] = fieldMaskers[i].Mask(value)
+			}
+		}
+	}
+}
+
 func adminQuery(ctx context.Context, conn *sql.Conn, statement string) *v1pb.QueryResult {
 	startTime := time.Now()
 	rows, err := conn.QueryContext(ctx, statement)
@@ -287,8 +448,11 @@ func rowsToQueryResult(rows *sql.Rows) (*v1pb.QueryResult, error) {
 		columnTypeNames = append(columnTypeNames, strings.ToUpper(v.DatabaseTypeName()))
 	}
 
-	data, err := readRows(rows, columnTypeNames, nil)
-	if err != nil {
+	var data []*v1pb.QueryRow
+	if err := readRows(rows, columnTypeNames, nil, func(row *v1pb.QueryRow) error {
+		data = append(data, row)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
@@ -303,12 +467,12 @@ func rowsToQueryResult(rows *sql.Rows) (*v1pb.QueryResult, error) {
 	}, nil
 }
 
-func readRows(rows *sql.Rows, columnTypeNames []string, fieldMaskingLevels []storepb.MaskingLevel) ([]*v1pb.QueryRow, error) {
-	var data []*v1pb.QueryRow
+// readRows reads the rows and calls handleRow with each row after masking.
+func readRows(rows *sql.Rows, columnTypeNames []string, fieldMaskers []masker.Masker, handleRow func(*v1pb.QueryRow) error) error {
 	if len(columnTypeNames) == 0 {
 		// No rows.
 		// The oracle driver will panic if there is no rows such as EXPLAIN PLAN FOR statement.
-		return data, nil
+		return nil
 	}
 	for rows.Next() {
 		// wantBytesValue want to convert StringValue to BytesValue when columnTypeName is BIT or VARBIT
@@ -334,109 +498,47 @@ func readRows(rows *sql.Rows, columnTypeNames []string, fieldMaskingLevels []sto
 		}
 
 		if err := rows.Scan(scanArgs...); err != nil {
-			return nil, err
+			return err
 		}
 
 		var rowData v1pb.QueryRow
 		for i := range columnTypeNames {
-			if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_FULL {
-				rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: "******"}})
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullBool); ok && v.Valid {
-				if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-					s := fmt.Sprintf("%t", v.Bool)
-					result := getMiddlePartOfString(s)
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-				} else {
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_BoolValue{BoolValue: v.Bool}})
-				}
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullString); ok && v.Valid {
-				if wantBytesValue[i] {
-					if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-						result := getMiddlePartOfString(v.String)
-						rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-					} else {
-						rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_BytesValue{BytesValue: []byte(v.String)}})
-					}
-				} else {
-					if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-						result := getMiddlePartOfString(v.String)
-						rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-					} else {
-						rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: v.String}})
-					}
-				}
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt64); ok && v.Valid {
-				if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-					s := strconv.FormatInt(v.Int64, 10)
-					result := getMiddlePartOfString(s)
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-				} else {
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: v.Int64}})
-				}
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt32); ok && v.Valid {
-				if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-					s := strconv.FormatInt(int64(v.Int32), 10)
-					result := getMiddlePartOfString(s)
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-				} else {
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_Int32Value{Int32Value: v.Int32}})
-				}
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullFloat64); ok && v.Valid {
-				if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-					s := strconv.FormatFloat(v.Float64, 'f', -1, 64)
-					result := getMiddlePartOfString(s)
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: paddingAsterisk(result)}})
-				} else {
-					rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_DoubleValue{DoubleValue: v.Float64}})
-				}
-				continue
-			}
-			// If none of them match, set nil to its value.
-			if len(fieldMaskingLevels) > i && fieldMaskingLevels[i] == storepb.MaskingLevel_PARTIAL {
-				rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: "**UL**"}})
-			} else {
-				rowData.Values = append(rowData.Values, &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}})
+			value := convertScanArgToRowValue(scanArgs[i], wantBytesValue[i])
+			if len(fieldMaskers) > i && fieldMaskers[i] != nil {
+				value = fieldMaskers[i].Mask(value)
 			}
+			rowData.Values = append(rowData.Values, value)
 		}
 
-		data = append(data, &rowData)
+		if err := handleRow(&rowData); err != nil {
+			return err
+		}
 	}
 
-	return data, nil
-}
-
-func paddingAsterisk(s string) string {
-	return fmt.Sprintf("**%s**", s)
+	return nil
 }
 
-// getMiddlePartOfString will get the middle part of the string.
-func getMiddlePartOfString(stmt string) string {
-	if len(stmt) == 0 || len(stmt) == 1 {
-		return ""
+func convertScanArgToRowValue(scanArg any, wantBytesValue bool) *v1pb.RowValue {
+	if v, ok := scanArg.(*sql.NullBool); ok && v.Valid {
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_BoolValue{BoolValue: v.Bool}}
 	}
-	if len(stmt) == 2 || len(stmt) == 3 {
-		return string(stmt[1])
+	if v, ok := scanArg.(*sql.NullString); ok && v.Valid {
+		if wantBytesValue {
+			return &v1pb.RowValue{Kind: &v1pb.RowValue_BytesValue{BytesValue: []byte(v.String)}}
+		}
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: v.String}}
 	}
-
-	s := []rune(stmt)
-	if len(s)%4 != 0 {
-		s = s[:len(s)/4*4]
+	if v, ok := scanArg.(*sql.NullInt64); ok && v.Valid {
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: v.Int64}}
 	}
-
-	var ret []rune
-	ret = append(ret, s[len(s)/4:len(s)/2]...)
-	ret = append(ret, s[len(s)/2:len(s)/4*3]...)
-	return string(ret)
+	if v, ok := scanArg.(*sql.NullInt32); ok && v.Valid {
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_Int32Value{Int32Value: v.Int32}}
+	}
+	if v, ok := scanArg.(*sql.NullFloat64); ok && v.Valid {
+		return &v1pb.RowValue{Kind: &v1pb.RowValue_DoubleValue{DoubleValue: v.Float64}}
+	}
+	// If none of them match, set nil to its value.
+	return &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}}
 }
 
 func getStatementWithResultLimit(stmt string, limit int) string {
@@ -10,7 +10,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 
 	// Register pingcap parser driver.
 	_ "github.com/pingcap/tidb/types/parser_driver"
@@ -670,6 +672,177 @@ func TestMySQLExtractSensitiveField(t *testing.T) {
 	}
 }
 
+func TestExtractSensitiveFieldMasker(t *testing.T) {
+	const (
+		defaultDatabase = "db"
+	)
+	phoneMasker := masker.NewRangeMasker([]*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice{
+		{Start: 3, End: 7, Substitution: "****"},
+	})
+	schemaInfo := &db.SensitiveSchemaInfo{
+		DatabaseList: []db.DatabaseSchema{
+			{
+				Name: defaultDatabase,
+				SchemaList: []db.SchemaSchema{
+					{
+						Name: "",
+						TableList: []db.TableSchema{
+							{
+								Name: "t",
+								ColumnList: []db.ColumnInfo{
+									{
+										Name:         "a",
+										MaskingLevel: storepb.MaskingLevel_PARTIAL,
+										Masker:       phoneMasker,
+									},
+									{
+										Name:         "b",
+										MaskingLevel: storepb.MaskingLevel_NONE,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		statement string
+		fieldList []db.SensitiveField
+	}{
+		{
+			statement: "SELECT a, b FROM t",
+			fieldList: []db.SensitiveField{
+				{Name: "a", MaskingLevel: storepb.MaskingLevel_PARTIAL, Masker: phoneMasker},
+				{Name: "b", MaskingLevel: storepb.MaskingLevel_NONE},
+			},
+		},
+		{
+			// The column masker is kept through the subquery and the alias.
+			statement: "SELECT x.phone FROM (SELECT a AS phone FROM t) x",
+			fieldList: []db.SensitiveField{
+				{Name: "phone", MaskingLevel: storepb.MaskingLevel_PARTIAL, Masker: phoneMasker},
+			},
+		},
+		{
+			// Derived expressions fall back to the default masker of the masking level.
+			statement: "SELECT CONCAT(a, b) AS c FROM t",
+			fieldList: []db.SensitiveField{
+				{Name: "c", MaskingLevel: storepb.MaskingLevel_PARTIAL},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		res, err := extractSensitiveField(db.MySQL, test.statement, defaultDatabase, schemaInfo)
+		require.NoError(t, err, test.statement)
+		require.Equal(t, test.fieldList, res, test.statement)
+
+		res, err = extractSensitiveField(db.TiDB, test.statement, defaultDatabase, schemaInfo)
+		require.NoError(t, err, test.statement)
+		require.Equal(t, test.fieldList, res, test.statement)
+	}
+}
+
+func TestMaskResultsFailClosed(t *testing.T) {
+	a := require.New(t)
+	schemaInfo := &db.SensitiveSchemaInfo{
+		DatabaseList: []db.DatabaseSchema{
+			{
+				Name: "db",
+				SchemaList: []db.SchemaSchema{
+					{
+						TableList: []db.TableSchema{
+							{
+								Name: "t",
+								ColumnList: []db.ColumnInfo{
+									{Name: "a", MaskingLevel: storepb.MaskingLevel_FULL},
+									{Name: "b", MaskingLevel: storepb.MaskingLevel_NONE},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	newResult := func(statement string) *v1pb.QueryResult {
+		return &v1pb.QueryResult{
+			ColumnNames: []string{"a", "b"},
+			Rows: []*v1pb.QueryRow{
+				{Values: []*v1pb.RowValue{
+					{Kind: &v1pb.RowValue_StringValue{StringValue: "secret"}},
+					{Kind: &v1pb.RowValue_StringValue{StringValue: "public"}},
+				}},
+			},
+			Statement: statement,
+		}
+	}
+	masked := masker.NewDefaultMasker(storepb.MaskingLevel_FULL).Mask(nil)
+
+	// The sensitive fields are extracted.
+	results := []*v1pb.QueryResult{newResult("SELECT a, b FROM t")}
+	MaskResults(db.MySQL, "", "db", schemaInfo, results)
+	a.Equal([]bool{true, false}, results[0].Masked)
+	a.Equal(masked.String(), results[0].Rows[0].Values[0].String())
+	a.Equal("public", results[0].Rows[0].Values[1].GetStringValue())
+
+	for _, results := range [][]*v1pb.QueryResult{
+		// The statement cannot be parsed.
+		{newResult("SELECT a, b FROM")},
+		// The column count does not match.
+		{newResult("SELECT a FROM t")},
+		// The result does not carry its statement.
+		{newResult(""), newResult("")},
+	} {
+		MaskResults(db.MySQL, "SELECT a, b FROM t", "db", schemaInfo, results)
+		for _, result := range results {
+			a.Equal([]bool{true, true}, result.Masked)
+			a.Equal(masked.String(), result.Rows[0].Values[0].String())
+			a.Equal(masked.String(), result.Rows[0].Values[1].String())
+		}
+	}
+
+	// The results of non-SELECT statements are left unchanged.
+	for _, statement := range []string{"SHOW TABLES", "INSERT INTO t VALUES ('secret', 'public')", "CREATE TABLE t1 (a INT)"} {
+		results = []*v1pb.QueryResult{newResult(statement)}
+		MaskResults(db.MySQL, "", "db", schemaInfo, results)
+		a.Nil(results[0].Masked, statement)
+		a.Equal("secret", results[0].Rows[0].Values[0].GetStringValue(), statement)
+	}
+
+	results = []*v1pb.QueryResult{newResult("")}
+	MaskAllResults("SELECT a, b FROM t", results)
+	a.Equal([]bool{true, true}, results[0].Masked)
+	results = []*v1pb.QueryResult{newResult("SHOW TABLES")}
+	MaskAllResults("SHOW TABLES", results)
+	a.Nil(results[0].Masked)
+}
+
+func TestIsSelectStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{"SELECT * FROM t", true},
+		{"  select 1", true},
+		{"(SELECT a FROM t) UNION (SELECT a FROM t1)", true},
+		{"WITH c AS (SELECT 1) SELECT * FROM c", true},
+		{"-- comment\nSELECT 1", true},
+		{"/* comment */ SELECT 1", true},
+		{"TABLE t", true},
+		{"SELECTED", false},
+		{"SHOW TABLES", false},
+		{"UPDATE t SET a = 1", false},
+		{"EXPLAIN SELECT 1", false},
+		{"-- SELECT 1", false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, IsSelectStatement(test.statement), test.statement)
+	}
+}
+
 func TestPostgreSQLExtractSensitiveField(t *testing.T) {
 	const (
 		defaultDatabase = "db"
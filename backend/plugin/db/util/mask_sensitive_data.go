@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
@@ -20,6 +21,8 @@ type fieldInfo struct {
 	schema       string
 	database     string
 	maskingLevel storepb.MaskingLevel
+	// masker is the masker of the column the field derives from directly, nil means the default masker of the masking level.
+	masker masker.Masker
 }
 
 type sensitiveFieldExtractor struct {
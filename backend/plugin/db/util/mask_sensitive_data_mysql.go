@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -61,6 +62,7 @@ func (l *mysqlSensitiveFieldListener) EnterSelectStatement(ctx *mysql.SelectStat
 		l.result = append(l.result, db.SensitiveField{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 }
@@ -77,6 +79,7 @@ func (l *mysqlSensitiveFieldListener) EnterCreateView(ctx *mysql.CreateViewConte
 		l.result = append(l.result, db.SensitiveField{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 
@@ -331,6 +334,7 @@ func (l *recursiveCTEListener) EnterQueryExpressionBody(ctx *mysql.QueryExpressi
 					for i := range initialPart {
 						if cmp.Less[storepb.MaskingLevel](initialPart[i].maskingLevel, fieldList[i].maskingLevel) {
 							initialPart[i].maskingLevel = fieldList[i].maskingLevel
+							initialPart[i].masker = fieldList[i].masker
 						}
 					}
 				}
@@ -376,6 +380,7 @@ func (l *recursiveCTEListener) EnterQueryExpressionBody(ctx *mysql.QueryExpressi
 					for i := range initialPart {
 						if cmp.Less[storepb.MaskingLevel](initialPart[i].maskingLevel, fieldList[i].maskingLevel) {
 							initialPart[i].maskingLevel = fieldList[i].maskingLevel
+							initialPart[i].masker = fieldList[i].masker
 						}
 					}
 				}
@@ -398,6 +403,7 @@ func (l *recursiveCTEListener) EnterQueryExpressionBody(ctx *mysql.QueryExpressi
 			l.cteInfo.ColumnList = append(l.cteInfo.ColumnList, db.ColumnInfo{
 				Name:         item.name,
 				MaskingLevel: item.maskingLevel,
+				Masker:       item.masker,
 			})
 		}
 	} else {
@@ -408,6 +414,7 @@ func (l *recursiveCTEListener) EnterQueryExpressionBody(ctx *mysql.QueryExpressi
 		for i := range initialPart {
 			if cmp.Less[storepb.MaskingLevel](l.cteInfo.ColumnList[i].MaskingLevel, initialPart[i].maskingLevel) {
 				l.cteInfo.ColumnList[i].MaskingLevel = initialPart[i].maskingLevel
+				l.cteInfo.ColumnList[i].Masker = initialPart[i].masker
 			}
 		}
 	}
@@ -450,6 +457,7 @@ func (l *recursiveCTEListener) EnterQueryExpressionBody(ctx *mysql.QueryExpressi
 				for i := range fieldList {
 					if cmp.Less[storepb.MaskingLevel](fieldList[i].maskingLevel, itemFields[i].maskingLevel) {
 						fieldList[i].maskingLevel = itemFields[i].maskingLevel
+						fieldList[i].masker = itemFields[i].masker
 					}
 				}
 			}
@@ -465,6 +473,7 @@ func (l *recursiveCTEListener) EnterQueryExpressionBody(ctx *mysql.QueryExpressi
 		for i, field := range fieldList {
 			if cmp.Less[storepb.MaskingLevel](l.cteInfo.ColumnList[i].MaskingLevel, field.maskingLevel) {
 				l.cteInfo.ColumnList[i].MaskingLevel = field.maskingLevel
+				l.cteInfo.ColumnList[i].Masker = field.masker
 				changed = true
 			}
 		}
@@ -514,6 +523,7 @@ func (extractor *sensitiveFieldExtractor) mysqlExtractNonRecursiveCTE(ctx mysql.
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 	return result, nil
@@ -542,6 +552,7 @@ func (extractor *sensitiveFieldExtractor) mysqlExtractQueryExpressionBody(ctx my
 				for i := range result {
 					if cmp.Less[storepb.MaskingLevel](result[i].maskingLevel, fieldList[i].maskingLevel) {
 						result[i].maskingLevel = fieldList[i].maskingLevel
+						result[i].masker = fieldList[i].masker
 					}
 				}
 			}
@@ -559,6 +570,7 @@ func (extractor *sensitiveFieldExtractor) mysqlExtractQueryExpressionBody(ctx my
 				for i := range result {
 					if cmp.Less[storepb.MaskingLevel](result[i].maskingLevel, fieldList[i].maskingLevel) {
 						result[i].maskingLevel = fieldList[i].maskingLevel
+						result[i].masker = fieldList[i].masker
 					}
 				}
 			}
@@ -646,6 +658,7 @@ func (extractor *sensitiveFieldExtractor) mysqlExtractExplicitTable(ctx mysql.IE
 			table:        tableSchema.Name,
 			database:     databaseName,
 			maskingLevel: column.MaskingLevel,
+			masker:       column.Masker,
 		})
 	}
 
@@ -778,6 +791,7 @@ func (extractor *sensitiveFieldExtractor) mysqlBuildTableSchemaForView(viewName
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:         field.Name,
 			MaskingLevel: field.MaskingLevel,
+			Masker:       field.Masker,
 		})
 	}
 	return result, nil
@@ -848,6 +862,7 @@ func (extractor *sensitiveFieldExtractor) mysqlExtractSelectItem(ctx mysql.ISele
 			{
 				name:         fieldName,
 				maskingLevel: sensitiveLevel,
+				masker:       extractor.mysqlFindColumnRefMasker(ctx.Expr()),
 			},
 		}, nil
 	}
@@ -986,6 +1001,7 @@ func (extractor *sensitiveFieldExtractor) mysqlMergeJoin(leftField []fieldInfo,
 			// Merge the sensitive attribute for the column in USING.
 			if existsInUsingMap && existsInRightField && cmp.Less[storepb.MaskingLevel](rField.maskingLevel, left.maskingLevel) {
 				left.maskingLevel = rField.maskingLevel
+				left.masker = rField.masker
 			}
 			result = append(result, left)
 		}
@@ -1029,6 +1045,7 @@ func (extractor *sensitiveFieldExtractor) mysqlMergeJoin(leftField []fieldInfo,
 			// Merge the sensitive attribute for the column in USING.
 			if existsInUsingMap && existsInRightField && cmp.Less[storepb.MaskingLevel](rField.maskingLevel, left.maskingLevel) {
 				left.maskingLevel = rField.maskingLevel
+				left.masker = rField.masker
 			}
 			result = append(result, left)
 		}
@@ -1058,6 +1075,7 @@ func (extractor *sensitiveFieldExtractor) mysqlMergeJoin(leftField []fieldInfo,
 		for _, left := range leftField {
 			if rField, exists := rightFieldMap[strings.ToLower(left.name)]; exists && cmp.Less[storepb.MaskingLevel](left.maskingLevel, rField.maskingLevel) {
 				left.maskingLevel = rField.maskingLevel
+				left.masker = rField.masker
 			}
 			result = append(result, left)
 		}
@@ -1119,6 +1137,7 @@ func (extractor *sensitiveFieldExtractor) mysqlExtractSingleTable(ctx mysql.ISin
 			table:        tableName,
 			database:     databaseName,
 			maskingLevel: column.MaskingLevel,
+			masker:       column.Masker,
 		})
 	}
 
@@ -1358,6 +1377,13 @@ func (extractor *sensitiveFieldExtractor) mysqlEvalMaskingLevelInExprList(list [
 }
 
 func (extractor *sensitiveFieldExtractor) mysqlCheckFieldMaskingLevel(databaseName string, tableName string, columnName string) storepb.MaskingLevel {
+	if field, ok := extractor.mysqlFindField(databaseName, tableName, columnName); ok {
+		return field.maskingLevel
+	}
+	return defaultMaskingLevel
+}
+
+func (extractor *sensitiveFieldExtractor) mysqlFindField(databaseName string, tableName string, columnName string) (fieldInfo, bool) {
 	// One sub-query may have multi-outer schemas and the multi-outer schemas can use the same name, such as:
 	//
 	//  select (
@@ -1388,7 +1414,7 @@ func (extractor *sensitiveFieldExtractor) mysqlCheckFieldMaskingLevel(databaseNa
 		// Column name in MySQL is NOT case sensitive.
 		sameField = strings.EqualFold(columnName, field.name)
 		if sameDatabase && sameTable && sameField {
-			return field.maskingLevel
+			return field, true
 		}
 	}
 
@@ -1408,8 +1434,31 @@ func (extractor *sensitiveFieldExtractor) mysqlCheckFieldMaskingLevel(databaseNa
 		// Column name in MySQL is NOT case sensitive.
 		sameField = strings.EqualFold(columnName, field.name)
 		if sameDatabase && sameTable && sameField {
-			return field.maskingLevel
+			return field, true
 		}
 	}
-	return defaultMaskingLevel
+	return fieldInfo{}, false
+}
+
+// mysqlFindColumnRefMasker returns the masker of the column if the expression is a plain column reference.
+// Otherwise, it returns nil, and the default masker of the masking level will be used.
+func (extractor *sensitiveFieldExtractor) mysqlFindColumnRefMasker(ctx antlr.ParserRuleContext) masker.Masker {
+	for ctx != nil {
+		if columnRef, ok := ctx.(mysql.IColumnRefContext); ok {
+			databaseName, tableName, fieldName := parser.NormalizeMySQLFieldIdentifier(columnRef.FieldIdentifier())
+			if field, ok := extractor.mysqlFindField(databaseName, tableName, fieldName); ok {
+				return field.masker
+			}
+			return nil
+		}
+		if ctx.GetChildCount() != 1 {
+			return nil
+		}
+		child, ok := ctx.GetChild(0).(antlr.ParserRuleContext)
+		if !ok {
+			return nil
+		}
+		ctx = child
+	}
+	return nil
 }
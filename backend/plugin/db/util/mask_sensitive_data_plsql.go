@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -56,6 +57,7 @@ func (l *selectStatementListener) EnterSelect_statement(ctx *plsql.Select_statem
 				l.result = append(l.result, db.SensitiveField{
 					Name:         field.name,
 					MaskingLevel: field.maskingLevel,
+					Masker:       field.masker,
 				})
 			}
 		}
@@ -111,6 +113,7 @@ func (extractor *sensitiveFieldExtractor) plsqlExtractFactoringElement(ctx plsql
 			cteInfo.ColumnList = append(cteInfo.ColumnList, db.ColumnInfo{
 				Name:         field.name,
 				MaskingLevel: field.maskingLevel,
+				Masker:       field.masker,
 			})
 		}
 
@@ -142,6 +145,7 @@ func (extractor *sensitiveFieldExtractor) plsqlExtractFactoringElement(ctx plsql
 				if cmp.Less[storepb.MaskingLevel](cteInfo.ColumnList[i].MaskingLevel, field.maskingLevel) {
 					changed = true
 					cteInfo.ColumnList[i].MaskingLevel = field.maskingLevel
+					cteInfo.ColumnList[i].Masker = field.masker
 				}
 			}
 
@@ -221,6 +225,7 @@ func (extractor *sensitiveFieldExtractor) plsqlExtractNonRecursiveCTE(ctx plsql.
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 	return result, nil
@@ -367,6 +372,7 @@ func (extractor *sensitiveFieldExtractor) plsqlExtractQueryBlock(ctx plsql.IQuer
 					database:     extractor.currentDatabase,
 					name:         fieldName,
 					maskingLevel: maskingLevel,
+					masker:       extractor.plsqlFindColumnNameMasker(element.Expression()),
 				})
 			}
 		}
@@ -376,6 +382,13 @@ func (extractor *sensitiveFieldExtractor) plsqlExtractQueryBlock(ctx plsql.IQuer
 }
 
 func (extractor *sensitiveFieldExtractor) plsqlCheckFieldMaskingLevel(schemaName string, tableName string, columnName string) storepb.MaskingLevel {
+	if field, ok := extractor.plsqlFindField(schemaName, tableName, columnName); ok {
+		return field.maskingLevel
+	}
+	return defaultMaskingLevel
+}
+
+func (extractor *sensitiveFieldExtractor) plsqlFindField(schemaName string, tableName string, columnName string) (fieldInfo, bool) {
 	// One sub-query may have multi-outer schemas and the multi-outer schemas can use the same name, such as:
 	//
 	//  select (
@@ -395,7 +408,7 @@ func (extractor *sensitiveFieldExtractor) plsqlCheckFieldMaskingLevel(schemaName
 		sameTable := (tableName == field.table || tableName == "")
 		sameColumn := (columnName == field.name)
 		if sameSchema && sameTable && sameColumn {
-			return field.maskingLevel
+			return field, true
 		}
 	}
 
@@ -404,11 +417,43 @@ func (extractor *sensitiveFieldExtractor) plsqlCheckFieldMaskingLevel(schemaName
 		sameTable := (tableName == field.table || tableName == "")
 		sameColumn := (columnName == field.name)
 		if sameSchema && sameTable && sameColumn {
-			return field.maskingLevel
+			return field, true
 		}
 	}
 
-	return defaultMaskingLevel
+	return fieldInfo{}, false
+}
+
+// plsqlFindColumnNameMasker returns the masker of the column if the expression is a plain column name.
+// Otherwise, it returns nil, and the default masker of the masking level will be used.
+func (extractor *sensitiveFieldExtractor) plsqlFindColumnNameMasker(ctx antlr.ParserRuleContext) masker.Masker {
+	for ctx != nil {
+		switch rule := ctx.(type) {
+		case plsql.IColumn_nameContext:
+			schemaName, tableName, columnName, err := plsqlNormalizeColumnName(extractor.currentDatabase, rule)
+			if err != nil {
+				return nil
+			}
+			if field, ok := extractor.plsqlFindField(schemaName, tableName, columnName); ok {
+				return field.masker
+			}
+			return nil
+		case plsql.IIdentifierContext:
+			if field, ok := extractor.plsqlFindField("", "", parser.PLSQLNormalizeIdentifierContext(rule)); ok {
+				return field.masker
+			}
+			return nil
+		}
+		if ctx.GetChildCount() != 1 {
+			return nil
+		}
+		child, ok := ctx.GetChild(0).(antlr.ParserRuleContext)
+		if !ok {
+			return nil
+		}
+		ctx = child
+	}
+	return nil
 }
 
 func (extractor *sensitiveFieldExtractor) plsqlEvalMaskingLevelInExpression(ctx antlr.ParserRuleContext) (string, storepb.MaskingLevel, error) {
@@ -1131,6 +1176,7 @@ func (extractor *sensitiveFieldExtractor) plsqlExtractTableRefAux(ctx plsql.ITab
 			table:        alias,
 			name:         field.name,
 			maskingLevel: field.maskingLevel,
+			masker:       field.masker,
 		})
 	}
 
@@ -1167,6 +1213,7 @@ func (extractor *sensitiveFieldExtractor) plsqlExtractDmlTableExpressionClause(c
 				table:        table,
 				name:         column.Name,
 				maskingLevel: column.MaskingLevel,
+				masker:       column.Masker,
 			})
 		}
 		return result, nil
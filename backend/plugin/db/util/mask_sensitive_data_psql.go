@@ -7,6 +7,7 @@ import (
 	pgquery "github.com/pganalyze/pg_query_go/v4"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/ast"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/engine/pg"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -63,6 +64,7 @@ func (extractor *sensitiveFieldExtractor) extractPostgreSQLSensitiveField(statem
 		result = append(result, db.SensitiveField{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 	return result, nil
@@ -114,6 +116,7 @@ func pgMergeJoinField(node *pgquery.Node_JoinExpr, leftField []fieldInfo, rightF
 			// Merge the sensitive attribute for the same column name field.
 			if rField, exists := rightFieldMap[field.name]; exists && cmp.Less[storepb.MaskingLevel](field.maskingLevel, rField.maskingLevel) {
 				field.maskingLevel = rField.maskingLevel
+				field.masker = rField.masker
 			}
 			result = append(result, field)
 		}
@@ -145,6 +148,7 @@ func pgMergeJoinField(node *pgquery.Node_JoinExpr, leftField []fieldInfo, rightF
 				// Merge the sensitive attribute for the column name field in USING.
 				if existsInUsingMap && existsInRightField && cmp.Less[storepb.MaskingLevel](field.maskingLevel, rField.maskingLevel) {
 					field.maskingLevel = rField.maskingLevel
+					field.masker = rField.masker
 				}
 				result = append(result, field)
 			}
@@ -190,6 +194,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeSubselect(node *pgquery.
 				table:        aliasName,
 				name:         columnName,
 				maskingLevel: item.maskingLevel,
+				masker:       item.masker,
 			})
 		}
 		return result, nil
@@ -225,6 +230,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeVar(node *pgquery.Node_R
 				name:         column.Name,
 				table:        tableSchema.Name,
 				maskingLevel: column.MaskingLevel,
+				masker:       column.Masker,
 			})
 		}
 	} else {
@@ -246,6 +252,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRangeVar(node *pgquery.Node_R
 				name:         columnName,
 				table:        aliasName,
 				maskingLevel: column.MaskingLevel,
+				masker:       column.Masker,
 			})
 		}
 	}
@@ -314,6 +321,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRecursiveCTE(node *pgquery.No
 			cteInfo.ColumnList = append(cteInfo.ColumnList, db.ColumnInfo{
 				Name:         field.name,
 				MaskingLevel: field.maskingLevel,
+				Masker:       field.masker,
 			})
 		}
 
@@ -345,6 +353,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractRecursiveCTE(node *pgquery.No
 				if cmp.Less[storepb.MaskingLevel](cteInfo.ColumnList[i].MaskingLevel, field.maskingLevel) {
 					changed = true
 					cteInfo.ColumnList[i].MaskingLevel = field.maskingLevel
+					cteInfo.ColumnList[i].Masker = field.masker
 				}
 			}
 
@@ -389,6 +398,7 @@ func (extractor *sensitiveFieldExtractor) pgExtractNonRecursiveCTE(node *pgquery
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 
@@ -533,9 +543,14 @@ func (extractor *sensitiveFieldExtractor) pgExtractSelect(node *pgquery.Node_Sel
 				if resTarget.ResTarget.Name != "" {
 					columnName = resTarget.ResTarget.Name
 				}
+				var fieldMasker masker.Masker
+				if field, ok := extractor.pgFindField(extractSchemaTableColumnName(columnRef)); ok {
+					fieldMasker = field.masker
+				}
 				result = append(result, fieldInfo{
 					name:         columnName,
 					maskingLevel: maskingLevel,
+					masker:       fieldMasker,
 				})
 			}
 		default:
@@ -715,6 +730,13 @@ func extractSchemaTableColumnName(columnName *ast.ColumnNameDef) (string, string
 }
 
 func (extractor *sensitiveFieldExtractor) pgCheckFieldMaskingLevel(schemaName string, tableName string, fieldName string) storepb.MaskingLevel {
+	if field, ok := extractor.pgFindField(schemaName, tableName, fieldName); ok {
+		return field.maskingLevel
+	}
+	return defaultMaskingLevel
+}
+
+func (extractor *sensitiveFieldExtractor) pgFindField(schemaName string, tableName string, fieldName string) (fieldInfo, bool) {
 	// One sub-query may have multi-outer schemas and the multi-outer schemas can use the same name, such as:
 	//
 	//  select (
@@ -734,7 +756,7 @@ func (extractor *sensitiveFieldExtractor) pgCheckFieldMaskingLevel(schemaName st
 			sameTable := (tableName == field.table || tableName == "")
 			sameField := (fieldName == field.name)
 			if sameTable && sameField {
-				return field.maskingLevel
+				return field, true
 			}
 		}
 	}
@@ -743,11 +765,11 @@ func (extractor *sensitiveFieldExtractor) pgCheckFieldMaskingLevel(schemaName st
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameTable && sameField {
-			return field.maskingLevel
+			return field, true
 		}
 	}
 
-	return defaultMaskingLevel
+	return fieldInfo{}, false
 }
 
 func (extractor *sensitiveFieldExtractor) pgExtractColumnRefFromExpressionNode(in *pgquery.Node) (storepb.MaskingLevel, error) {
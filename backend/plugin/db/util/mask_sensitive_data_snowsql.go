@@ -11,6 +11,7 @@ import (
 	snowparser "github.com/bytebase/snowsql-parser"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -54,6 +55,7 @@ func (l *snowsqlSensitiveFieldExtractorListener) EnterDml_command(ctx *snowparse
 		l.result = append(l.result, db.SensitiveField{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 }
@@ -81,6 +83,7 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsQueryStat
 					tempCTEOuterSchemaInfo.ColumnList = append(tempCTEOuterSchemaInfo.ColumnList, db.ColumnInfo{
 						Name:         fieldsInAnchorClause[i].name,
 						MaskingLevel: fieldsInAnchorClause[i].maskingLevel,
+						Masker:       fieldsInAnchorClause[i].masker,
 					})
 					result = append(result, fieldsInAnchorClause[i])
 				}
@@ -100,7 +103,9 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsQueryStat
 						if cmp.Less[storepb.MaskingLevel](tempCTEOuterSchemaInfo.ColumnList[i].MaskingLevel, fieldsInRecursiveClause[i].maskingLevel) {
 							change = true
 							tempCTEOuterSchemaInfo.ColumnList[i].MaskingLevel = fieldsInRecursiveClause[i].maskingLevel
+							tempCTEOuterSchemaInfo.ColumnList[i].Masker = fieldsInRecursiveClause[i].masker
 							result[i].maskingLevel = fieldsInRecursiveClause[i].maskingLevel
+							result[i].masker = fieldsInRecursiveClause[i].masker
 						}
 					}
 					if !change {
@@ -132,6 +137,7 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsQueryStat
 				columnList = append(columnList, db.ColumnInfo{
 					Name:         field.name,
 					MaskingLevel: field.maskingLevel,
+					Masker:       field.masker,
 				})
 			}
 			extractor.cteOuterSchemaInfo = append(extractor.cteOuterSchemaInfo, db.TableSchema{
@@ -159,11 +165,10 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsQueryStat
 			return nil, errors.Wrapf(err, "the number of columns in the query statement nearly line %d returns %d fields, but %d set operator near line %d returns %d fields", selectStatement.GetStart().GetLine(), len(result), i+1, setOperator.GetStart().GetLine(), len(right))
 		}
 		for i := range right {
-			finalLevel := result[i].maskingLevel
-			if cmp.Less[storepb.MaskingLevel](finalLevel, right[i].maskingLevel) {
-				finalLevel = right[i].maskingLevel
+			if cmp.Less[storepb.MaskingLevel](result[i].maskingLevel, right[i].maskingLevel) {
+				result[i].maskingLevel = right[i].maskingLevel
+				result[i].masker = right[i].masker
 			}
-			result[i].maskingLevel = finalLevel
 		}
 	}
 	return result, nil
@@ -248,6 +253,7 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsSelectSta
 				result = append(result, fieldInfo{
 					name:         columnName,
 					maskingLevel: maskingLevel,
+					masker:       extractor.snowflakeFindFullColumnNameMasker(v),
 				})
 			} else if v := expressionElem.Predicate(); v != nil {
 				columnName, maskingLevel, err := extractor.evalSnowSQLExprMaskingLevel(v)
@@ -257,6 +263,7 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsSelectSta
 				result = append(result, fieldInfo{
 					name:         columnName,
 					maskingLevel: maskingLevel,
+					masker:       extractor.snowflakeFindFullColumnNameMasker(v),
 				})
 			}
 
@@ -904,6 +911,7 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsJoinClaus
 				// If the field is in the left part and the right part, we should keep the field in the left part,
 				// and set the sensitive flag to true if the field in the right part is sensitive.
 				result[idx].maskingLevel = field.maskingLevel
+				result[idx].masker = field.masker
 			}
 		}
 		return result, nil
@@ -934,6 +942,7 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsObjectRef
 				table:        tableSchema.Name,
 				name:         column.Name,
 				maskingLevel: column.MaskingLevel,
+				masker:       column.Masker,
 			})
 		}
 	}
@@ -998,6 +1007,7 @@ func (extractor *sensitiveFieldExtractor) extractSnowsqlSensitiveFieldsObjectRef
 				result = append(result, fieldInfo{
 					name:         literal.GetText(),
 					maskingLevel: pivotColumnInOriginalResult.maskingLevel,
+					masker:       pivotColumnInOriginalResult.masker,
 				})
 			}
 		} else if v := ctx.Pivot_unpivot(); v.UNPIVOT() != nil {
@@ -1202,6 +1212,29 @@ func (extractor *sensitiveFieldExtractor) snowflakeGetField(normalizedDatabaseNa
 	return fieldInfo{}, errors.Errorf(`no matching column %q.%q.%q.%q`, normalizedDatabaseName, normalizedSchemaName, normalizedTableName, normalizedColumnName)
 }
 
+// snowflakeFindFullColumnNameMasker returns the masker of the column if the expression is a plain column name.
+// Otherwise, it returns nil, and the default masker of the masking level will be used.
+func (extractor *sensitiveFieldExtractor) snowflakeFindFullColumnNameMasker(ctx antlr.ParserRuleContext) masker.Masker {
+	for ctx != nil {
+		if fullColumnName, ok := ctx.(snowparser.IFull_column_nameContext); ok {
+			field, err := extractor.snowflakeGetField(normalizedFullColumnName(fullColumnName))
+			if err != nil {
+				return nil
+			}
+			return field.masker
+		}
+		if ctx.GetChildCount() != 1 {
+			return nil
+		}
+		child, ok := ctx.GetChild(0).(antlr.ParserRuleContext)
+		if !ok {
+			return nil
+		}
+		ctx = child
+	}
+	return nil
+}
+
 func normalizedFullColumnName(ctx snowparser.IFull_column_nameContext) (normalizedDatabaseName, normalizedSchemaName, normalizedTableName, normalizedColumnName string) {
 	if ctx.GetDb_name() != nil {
 		normalizedDatabaseName = parser.NormalizeSnowSQLObjectNamePart(ctx.GetDb_name())
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 
@@ -49,6 +50,7 @@ func (extractor *sensitiveFieldExtractor) extractTiDBSensitiveField(statement st
 		result = append(result, db.SensitiveField{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 	return result, nil
@@ -128,6 +130,7 @@ func (extractor *sensitiveFieldExtractor) extractSetOpr(node *tidbast.SetOprStmt
 			for i := 0; i < len(result); i++ {
 				if cmp.Less[storepb.MaskingLevel](result[i].maskingLevel, fieldList[i].maskingLevel) {
 					result[i].maskingLevel = fieldList[i].maskingLevel
+					result[i].masker = fieldList[i].masker
 				}
 			}
 		}
@@ -206,6 +209,7 @@ func (extractor *sensitiveFieldExtractor) extractRecursiveCTE(node *tidbast.Comm
 			cteInfo.ColumnList = append(cteInfo.ColumnList, db.ColumnInfo{
 				Name:         field.name,
 				MaskingLevel: field.maskingLevel,
+				Masker:       field.masker,
 			})
 		}
 
@@ -242,6 +246,7 @@ func (extractor *sensitiveFieldExtractor) extractRecursiveCTE(node *tidbast.Comm
 				if cmp.Less[storepb.MaskingLevel](cteInfo.ColumnList[i].MaskingLevel, field.maskingLevel) {
 					changed = true
 					cteInfo.ColumnList[i].MaskingLevel = field.maskingLevel
+					cteInfo.ColumnList[i].Masker = field.masker
 				}
 			}
 
@@ -278,6 +283,7 @@ func (extractor *sensitiveFieldExtractor) extractNonRecursiveCTE(node *tidbast.C
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 	return result, nil
@@ -345,6 +351,7 @@ func (extractor *sensitiveFieldExtractor) extractSelect(node *tidbast.SelectStmt
 					table:        "",
 					name:         fieldName,
 					maskingLevel: maskingLevel,
+					masker:       extractor.findColumnNameMasker(field.Expr),
 				})
 			}
 		}
@@ -368,6 +375,13 @@ func extractFieldName(in *tidbast.SelectField) string {
 }
 
 func (extractor *sensitiveFieldExtractor) checkFieldMaskingLevel(databaseName string, tableName string, fieldName string) storepb.MaskingLevel {
+	if field, ok := extractor.findField(databaseName, tableName, fieldName); ok {
+		return field.maskingLevel
+	}
+	return defaultMaskingLevel
+}
+
+func (extractor *sensitiveFieldExtractor) findField(databaseName string, tableName string, fieldName string) (fieldInfo, bool) {
 	// One sub-query may have multi-outer schemas and the multi-outer schemas can use the same name, such as:
 	//
 	//  select (
@@ -387,7 +401,7 @@ func (extractor *sensitiveFieldExtractor) checkFieldMaskingLevel(databaseName st
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameDatabase && sameTable && sameField {
-			return field.maskingLevel
+			return field, true
 		}
 	}
 
@@ -396,11 +410,24 @@ func (extractor *sensitiveFieldExtractor) checkFieldMaskingLevel(databaseName st
 		sameTable := (tableName == field.table || tableName == "")
 		sameField := (fieldName == field.name)
 		if sameDatabase && sameTable && sameField {
-			return field.maskingLevel
+			return field, true
 		}
 	}
 
-	return defaultMaskingLevel
+	return fieldInfo{}, false
+}
+
+// findColumnNameMasker returns the masker of the column if the expression is a plain column name.
+// Otherwise, it returns nil, and the default masker of the masking level will be used.
+func (extractor *sensitiveFieldExtractor) findColumnNameMasker(in tidbast.ExprNode) masker.Masker {
+	columnName, ok := in.(*tidbast.ColumnNameExpr)
+	if !ok {
+		return nil
+	}
+	if field, ok := extractor.findField(columnName.Name.Schema.O, columnName.Name.Table.O, columnName.Name.Name.O); ok {
+		return field.masker
+	}
+	return nil
 }
 
 func (extractor *sensitiveFieldExtractor) extractColumnFromExprNode(in tidbast.ExprNode) (maskingLevel storepb.MaskingLevel, err error) {
@@ -530,6 +557,7 @@ func (extractor *sensitiveFieldExtractor) extractTableSource(node *tidbast.Table
 				table:        node.AsName.O,
 				database:     field.database,
 				maskingLevel: field.maskingLevel,
+				masker:       field.masker,
 			})
 		}
 	} else {
@@ -614,6 +642,7 @@ func (extractor *sensitiveFieldExtractor) buildTableSchemaForView(viewName strin
 		result.ColumnList = append(result.ColumnList, db.ColumnInfo{
 			Name:         field.Name,
 			MaskingLevel: field.MaskingLevel,
+			Masker:       field.Masker,
 		})
 	}
 	return result, nil
@@ -672,6 +701,7 @@ func (extractor *sensitiveFieldExtractor) extractTableName(node *tidbast.TableNa
 			table:        tableSchema.Name,
 			database:     databaseName,
 			maskingLevel: column.MaskingLevel,
+			masker:       column.Masker,
 		})
 	}
 	return res, nil
@@ -711,6 +741,7 @@ func mergeJoinField(node *tidbast.Join, leftField []fieldInfo, rightField []fiel
 			// Merge the sensitive attribute for the same column name field.
 			if rField, exists := rightFieldMap[strings.ToLower(field.name)]; exists && cmp.Less[storepb.MaskingLevel](field.maskingLevel, rField.maskingLevel) {
 				field.maskingLevel = rField.maskingLevel
+				field.masker = rField.masker
 			}
 			result = append(result, field)
 		}
@@ -735,6 +766,7 @@ func mergeJoinField(node *tidbast.Join, leftField []fieldInfo, rightField []fiel
 				// Merge the sensitive attribute for the column name field in USING.
 				if existsInUsingMap && existsInRightField && cmp.Less[storepb.MaskingLevel](field.maskingLevel, rField.maskingLevel) {
 					field.maskingLevel = rField.maskingLevel
+					field.masker = rField.masker
 				}
 				result = append(result, field)
 			}
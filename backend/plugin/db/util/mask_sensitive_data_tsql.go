@@ -11,6 +11,7 @@ import (
 	tsqlparser "github.com/bytebase/tsql-parser"
 
 	"github.com/bytebase/bytebase/backend/plugin/db"
+	"github.com/bytebase/bytebase/backend/plugin/masker"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
@@ -56,6 +57,7 @@ func (l *tsqlSensitiveFieldExtractorListener) EnterDml_clause(ctx *tsqlparser.Dm
 		l.result = append(l.result, db.SensitiveField{
 			Name:         field.name,
 			MaskingLevel: field.maskingLevel,
+			Masker:       field.masker,
 		})
 	}
 }
@@ -99,6 +101,7 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromSelectSt
 						for j := range right {
 							if cmp.Less[storepb.MaskingLevel](fieldsInAnchorClause[j].maskingLevel, right[j].maskingLevel) {
 								fieldsInAnchorClause[j].maskingLevel = right[j].maskingLevel
+								fieldsInAnchorClause[j].masker = right[j].masker
 							}
 						}
 					}
@@ -122,6 +125,7 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromSelectSt
 					tempCTEOuterSchemaInfo.ColumnList = append(tempCTEOuterSchemaInfo.ColumnList, db.ColumnInfo{
 						Name:         fieldsInAnchorClause[i].name,
 						MaskingLevel: fieldsInAnchorClause[i].maskingLevel,
+						Masker:       fieldsInAnchorClause[i].masker,
 					})
 					result = append(result, fieldsInAnchorClause[i])
 				}
@@ -142,7 +146,9 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromSelectSt
 							if cmp.Less[storepb.MaskingLevel](tempCTEOuterSchemaInfo.ColumnList[i].MaskingLevel, fieldsInRecursiveClause[i].maskingLevel) {
 								change = true
 								tempCTEOuterSchemaInfo.ColumnList[i].MaskingLevel = fieldsInRecursiveClause[i].maskingLevel
+								tempCTEOuterSchemaInfo.ColumnList[i].Masker = fieldsInRecursiveClause[i].masker
 								result[i].maskingLevel = fieldsInRecursiveClause[i].maskingLevel
+								result[i].masker = fieldsInRecursiveClause[i].masker
 							}
 						}
 					} else if allQueryExpression := queryExpression.AllQuery_expression(); len(allQueryExpression) > 1 {
@@ -158,7 +164,9 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromSelectSt
 							if cmp.Less[storepb.MaskingLevel](tempCTEOuterSchemaInfo.ColumnList[i].MaskingLevel, fieldsInRecursiveClause[i].maskingLevel) {
 								change = true
 								tempCTEOuterSchemaInfo.ColumnList[i].MaskingLevel = fieldsInRecursiveClause[i].maskingLevel
+								tempCTEOuterSchemaInfo.ColumnList[i].Masker = fieldsInRecursiveClause[i].masker
 								result[i].maskingLevel = fieldsInRecursiveClause[i].maskingLevel
+								result[i].masker = fieldsInRecursiveClause[i].masker
 							}
 						}
 					}
@@ -185,6 +193,7 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromSelectSt
 				columnList = append(columnList, db.ColumnInfo{
 					Name:         field.name,
 					MaskingLevel: field.maskingLevel,
+					Masker:       field.masker,
 				})
 			}
 			extractor.cteOuterSchemaInfo = append(extractor.cteOuterSchemaInfo, db.TableSchema{
@@ -234,6 +243,7 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromQueryExp
 				for i := range right {
 					if cmp.Less[storepb.MaskingLevel](left[i].maskingLevel, right[i].maskingLevel) {
 						left[i].maskingLevel = right[i].maskingLevel
+						left[i].masker = right[i].masker
 					}
 				}
 			}
@@ -259,6 +269,7 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromQueryExp
 			for i := range right {
 				if cmp.Less[storepb.MaskingLevel](left[i].maskingLevel, right[i].maskingLevel) {
 					left[i].maskingLevel = right[i].maskingLevel
+					left[i].masker = right[i].masker
 				}
 			}
 		}
@@ -319,6 +330,7 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromQuerySpe
 			result = append(result, fieldInfo{
 				name:         columnName,
 				maskingLevel: maskingLevel,
+				masker:       extractor.tsqlFindFullColumnNameMasker(expressionElem),
 			})
 		}
 	}
@@ -326,6 +338,29 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromQuerySpe
 	return result, nil
 }
 
+// tsqlFindFullColumnNameMasker returns the masker of the column if the expression is a plain column name.
+// Otherwise, it returns nil, and the default masker of the masking level will be used.
+func (extractor *sensitiveFieldExtractor) tsqlFindFullColumnNameMasker(ctx antlr.ParserRuleContext) masker.Masker {
+	for ctx != nil {
+		if fullColumnName, ok := ctx.(tsqlparser.IFull_column_nameContext); ok {
+			field, err := extractor.tsqlIsFullColumnNameSensitive(fullColumnName)
+			if err != nil {
+				return nil
+			}
+			return field.masker
+		}
+		if ctx.GetChildCount() != 1 {
+			return nil
+		}
+		child, ok := ctx.GetChild(0).(antlr.ParserRuleContext)
+		if !ok {
+			return nil
+		}
+		ctx = child
+	}
+	return nil
+}
+
 func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromTableSources(ctx tsqlparser.ITable_sourcesContext) ([]fieldInfo, error) {
 	if ctx == nil {
 		return nil, nil
@@ -418,6 +453,7 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromTableSou
 				table:        tableSchema.Name,
 				name:         column.Name,
 				maskingLevel: column.MaskingLevel,
+				masker:       column.Masker,
 			})
 		}
 	}
@@ -490,6 +526,7 @@ func (extractor *sensitiveFieldExtractor) extractTSqlSensitiveFieldsFromDerivedT
 			for i := range right {
 				if cmp.Less[storepb.MaskingLevel](left[i].maskingLevel, right[i].maskingLevel) {
 					left[i].maskingLevel = right[i].maskingLevel
+					left[i].masker = right[i].masker
 				}
 			}
 		}
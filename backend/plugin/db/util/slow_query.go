@@ -0,0 +1,246 @@
+package util
+
+import (
+	"math/rand"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// SlowQueryLog is a slow query log sample of a database.
+type SlowQueryLog struct {
+	Database    string
+	Fingerprint string
+	Details     *storepb.SlowQueryDetails
+}
+
+// SampleSlowQueryLog adds the log to the samples with reservoir sampling, keeping at most db.SlowQueryMaxSamplePerDay samples.
+// See https://en.wikipedia.org/wiki/Reservoir_sampling
+func SampleSlowQueryLog(logs []*SlowQueryLog, log *SlowQueryLog) []*SlowQueryLog {
+	if len(logs) < db.SlowQueryMaxSamplePerDay {
+		return append(logs, log)
+	}
+	logs[rand.Intn(len(logs))] = log
+	return logs
+}
+
+// AnalyzeSlowQueryLogs groups the slow query log samples by database and fingerprint.
+func AnalyzeSlowQueryLogs(logs []*SlowQueryLog) map[string]*storepb.SlowQueryStatistics {
+	logMap := make(map[string]map[string]*storepb.SlowQueryStatisticsItem)
+	for _, log := range logs {
+		fingerprint := log.Fingerprint
+		if len(fingerprint) > db.SlowQueryMaxLen {
+			fingerprint = fingerprint[:db.SlowQueryMaxLen]
+		}
+		if len(log.Details.SqlText) > db.SlowQueryMaxLen {
+			log.Details.SqlText = log.Details.SqlText[:db.SlowQueryMaxLen]
+		}
+		dbLog, ok := logMap[log.Database]
+		if !ok {
+			dbLog = make(map[string]*storepb.SlowQueryStatisticsItem)
+			logMap[log.Database] = dbLog
+		}
+		dbLog[fingerprint] = MergeSlowQueryDetails(fingerprint, dbLog[fingerprint], log.Details)
+	}
+
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for database, dbLog := range logMap {
+		statistics := &storepb.SlowQueryStatistics{}
+		for _, item := range dbLog {
+			statistics.Items = append(statistics.Items, item)
+		}
+		result[database] = statistics
+	}
+	return result
+}
+
+// MergeSlowQueryDetails merges the slow query details into the statistics of the fingerprint.
+func MergeSlowQueryDetails(fingerprint string, statistics *storepb.SlowQueryStatisticsItem, details *storepb.SlowQueryDetails) *storepb.SlowQueryStatisticsItem {
+	if statistics == nil {
+		return &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:      fingerprint,
+			Count:               1,
+			LatestLogTime:       details.StartTime,
+			TotalQueryTime:      details.QueryTime,
+			MaximumQueryTime:    details.QueryTime,
+			TotalRowsSent:       details.RowsSent,
+			MaximumRowsSent:     details.RowsSent,
+			TotalRowsExamined:   details.RowsExamined,
+			MaximumRowsExamined: details.RowsExamined,
+			Samples:             []*storepb.SlowQueryDetails{details},
+		}
+	}
+	statistics.Count++
+	if statistics.LatestLogTime.AsTime().Before(details.StartTime.AsTime()) {
+		statistics.LatestLogTime = details.StartTime
+	}
+	statistics.TotalQueryTime = durationpb.New(statistics.TotalQueryTime.AsDuration() + details.QueryTime.AsDuration())
+	if statistics.MaximumQueryTime.AsDuration() < details.QueryTime.AsDuration() {
+		statistics.MaximumQueryTime = details.QueryTime
+	}
+	statistics.TotalRowsSent += details.RowsSent
+	if statistics.MaximumRowsSent < details.RowsSent {
+		statistics.MaximumRowsSent = details.RowsSent
+	}
+	statistics.TotalRowsExamined += details.RowsExamined
+	if statistics.MaximumRowsExamined < details.RowsExamined {
+		statistics.MaximumRowsExamined = details.RowsExamined
+	}
+	if len(statistics.Samples) < db.SlowQueryMaxSamplePerFingerprint {
+		statistics.Samples = append(statistics.Samples, details)
+	} else {
+		// Use Reservoir Sampling to sample slow logs.
+		pos := rand.Intn(len(statistics.Samples))
+		statistics.Samples[pos] = details
+	}
+	return statistics
+}
+
+// MergeSlowQueryStatisticsItem merges the aggregated statistics item into the statistics of the same fingerprint in the database.
+// It is used by the engines collecting the aggregated statistics instead of the slow query log samples.
+func MergeSlowQueryStatisticsItem(result map[string]*storepb.SlowQueryStatistics, database string, item *storepb.SlowQueryStatisticsItem) {
+	if len(item.SqlFingerprint) > db.SlowQueryMaxLen {
+		item.SqlFingerprint = item.SqlFingerprint[:db.SlowQueryMaxLen]
+	}
+	statistics, ok := result[database]
+	if !ok {
+		result[database] = &storepb.SlowQueryStatistics{Items: []*storepb.SlowQueryStatisticsItem{item}}
+		return
+	}
+	for _, existing := range statistics.Items {
+		if existing.SqlFingerprint != item.SqlFingerprint {
+			continue
+		}
+		existing.Count += item.Count
+		if existing.LatestLogTime.AsTime().Before(item.LatestLogTime.AsTime()) {
+			existing.LatestLogTime = item.LatestLogTime
+		}
+		existing.TotalQueryTime = durationpb.New(existing.TotalQueryTime.AsDuration() + item.TotalQueryTime.AsDuration())
+		if existing.MaximumQueryTime.AsDuration() < item.MaximumQueryTime.AsDuration() {
+			existing.MaximumQueryTime = item.MaximumQueryTime
+		}
+		existing.TotalRowsSent += item.TotalRowsSent
+		if existing.MaximumRowsSent < item.MaximumRowsSent {
+			existing.MaximumRowsSent = item.MaximumRowsSent
+		}
+		existing.TotalRowsExamined += item.TotalRowsExamined
+		if existing.MaximumRowsExamined < item.MaximumRowsExamined {
+			existing.MaximumRowsExamined = item.MaximumRowsExamined
+		}
+		return
+	}
+	statistics.Items = append(statistics.Items, item)
+}
+
+// MergeSlowQuerySnapshot merges the current cumulative statistics into the previous snapshot and returns the new snapshot and baseline.
+// The statements missing in the current statistics keep their previous statistics, unless they are not executed since earliest.
+// The baseline only keeps the statements executed on the log date, the baseline of a statement is recorded when it's executed for the first time on the log date,
+// which is its previous statistics, or the empty statistics if it's newly cached.
+// It is used by the engines which only expose the cumulative statistics since the statement is cached.
+func MergeSlowQuerySnapshot(previous, baseline, current map[string]*storepb.SlowQueryStatistics, earliest time.Time) (map[string]*storepb.SlowQueryStatistics, map[string]*storepb.SlowQueryStatistics) {
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for database, statistics := range previous {
+		for _, item := range statistics.Items {
+			if item.LatestLogTime.AsTime().Before(earliest) {
+				continue
+			}
+			result[database] = appendSlowQueryStatisticsItem(result[database], item)
+		}
+	}
+	resultBaseline := make(map[string]*storepb.SlowQueryStatistics)
+	for database, statistics := range baseline {
+		resultBaseline[database] = &storepb.SlowQueryStatistics{Items: append([]*storepb.SlowQueryStatisticsItem{}, statistics.Items...)}
+	}
+	for database, statistics := range current {
+		if _, ok := result[database]; !ok {
+			result[database] = &storepb.SlowQueryStatistics{}
+		}
+		baselineItems := make(map[string]bool)
+		for _, item := range resultBaseline[database].GetItems() {
+			baselineItems[item.SqlFingerprint] = true
+		}
+		for _, item := range statistics.Items {
+			var previousItem *storepb.SlowQueryStatisticsItem
+			for i, existing := range result[database].Items {
+				if existing.SqlFingerprint == item.SqlFingerprint {
+					previousItem = existing
+					result[database].Items[i] = item
+					break
+				}
+			}
+			if previousItem == nil {
+				result[database].Items = append(result[database].Items, item)
+				previousItem = &storepb.SlowQueryStatisticsItem{
+					SqlFingerprint:   item.SqlFingerprint,
+					LatestLogTime:    item.LatestLogTime,
+					TotalQueryTime:   durationpb.New(0),
+					MaximumQueryTime: durationpb.New(0),
+				}
+			} else if previousItem.Count == item.Count && previousItem.TotalQueryTime.AsDuration() == item.TotalQueryTime.AsDuration() {
+				// The statement is not executed since the previous snapshot.
+				continue
+			}
+			if !baselineItems[item.SqlFingerprint] {
+				baselineItems[item.SqlFingerprint] = true
+				resultBaseline[database] = appendSlowQueryStatisticsItem(resultBaseline[database], previousItem)
+			}
+		}
+	}
+	return result, resultBaseline
+}
+
+// DiffSlowQueryStatistics returns the statistics of the executions between the baseline and the latest cumulative statistics.
+// Only the statements in the baseline are executed since the baseline.
+// If the statistics of a statement decrease, the statement has been evicted from the cache and cached again, so the latest statistics are the deltas.
+// The maximum values cannot be subtracted, the latest ones are used instead.
+// Only the executed statements whose maximum query time reaches minimumQueryTime are returned.
+func DiffSlowQueryStatistics(latest, baseline map[string]*storepb.SlowQueryStatistics, minimumQueryTime time.Duration) map[string]*storepb.SlowQueryStatistics {
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	for database, statistics := range latest {
+		baselineItems := make(map[string]*storepb.SlowQueryStatisticsItem)
+		if v, ok := baseline[database]; ok {
+			for _, item := range v.Items {
+				baselineItems[item.SqlFingerprint] = item
+			}
+		}
+		for _, item := range statistics.Items {
+			base, ok := baselineItems[item.SqlFingerprint]
+			if !ok || item.MaximumQueryTime.AsDuration() < minimumQueryTime {
+				continue
+			}
+			delta := &storepb.SlowQueryStatisticsItem{
+				SqlFingerprint:      item.SqlFingerprint,
+				Count:               item.Count,
+				LatestLogTime:       item.LatestLogTime,
+				TotalQueryTime:      item.TotalQueryTime,
+				MaximumQueryTime:    item.MaximumQueryTime,
+				TotalRowsSent:       item.TotalRowsSent,
+				MaximumRowsSent:     item.MaximumRowsSent,
+				TotalRowsExamined:   item.TotalRowsExamined,
+				MaximumRowsExamined: item.MaximumRowsExamined,
+			}
+			if base.Count <= item.Count && base.TotalQueryTime.AsDuration() <= item.TotalQueryTime.AsDuration() {
+				delta.Count -= base.Count
+				delta.TotalQueryTime = durationpb.New(item.TotalQueryTime.AsDuration() - base.TotalQueryTime.AsDuration())
+				delta.TotalRowsSent -= base.TotalRowsSent
+				delta.TotalRowsExamined -= base.TotalRowsExamined
+			}
+			if delta.Count <= 0 {
+				continue
+			}
+			result[database] = appendSlowQueryStatisticsItem(result[database], delta)
+		}
+	}
+	return result
+}
+
+func appendSlowQueryStatisticsItem(statistics *storepb.SlowQueryStatistics, item *storepb.SlowQueryStatisticsItem) *storepb.SlowQueryStatistics {
+	if statistics == nil {
+		statistics = &storepb.SlowQueryStatistics{}
+	}
+	statistics.Items = append(statistics.Items, item)
+	return statistics
+}
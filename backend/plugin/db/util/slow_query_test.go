@@ -0,0 +1,159 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestAnalyzeSlowQueryLogs(t *testing.T) {
+	start := time.Date(2023, 10, 18, 0, 0, 0, 0, time.UTC)
+	newLog := func(database, fingerprint string, offset, queryTime time.Duration, rows int64) *SlowQueryLog {
+		return &SlowQueryLog{
+			Database:    database,
+			Fingerprint: fingerprint,
+			Details: &storepb.SlowQueryDetails{
+				StartTime:    timestamppb.New(start.Add(offset)),
+				QueryTime:    durationpb.New(queryTime),
+				RowsSent:     rows,
+				RowsExamined: rows * 10,
+				SqlText:      fingerprint,
+			},
+		}
+	}
+	logs := []*SlowQueryLog{
+		newLog("db1", "SELECT ?", time.Minute, 2*time.Second, 1),
+		newLog("db1", "SELECT ?", time.Hour, 3*time.Second, 5),
+		newLog("db1", "UPDATE t SET a = ?", time.Minute, time.Second, 0),
+		newLog("db2", "SELECT ?", time.Minute, time.Second, 2),
+	}
+
+	a := require.New(t)
+	result := AnalyzeSlowQueryLogs(logs)
+	a.Len(result, 2)
+	a.Len(result["db1"].Items, 2)
+	a.Len(result["db2"].Items, 1)
+	for _, item := range result["db1"].Items {
+		if item.SqlFingerprint != "SELECT ?" {
+			continue
+		}
+		a.Equal(int64(2), item.Count)
+		a.Equal(start.Add(time.Hour), item.LatestLogTime.AsTime())
+		a.Equal(5*time.Second, item.TotalQueryTime.AsDuration())
+		a.Equal(3*time.Second, item.MaximumQueryTime.AsDuration())
+		a.Equal(int64(6), item.TotalRowsSent)
+		a.Equal(int64(5), item.MaximumRowsSent)
+		a.Equal(int64(60), item.TotalRowsExamined)
+		a.Equal(int64(50), item.MaximumRowsExamined)
+		a.Len(item.Samples, 2)
+	}
+}
+
+func TestMergeSlowQueryStatisticsItem(t *testing.T) {
+	now := time.Date(2023, 10, 18, 12, 0, 0, 0, time.UTC)
+	newItem := func(fingerprint string, count int64, latest time.Time, total, maximum time.Duration, rows int64) *storepb.SlowQueryStatisticsItem {
+		return &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   fingerprint,
+			Count:            count,
+			LatestLogTime:    timestamppb.New(latest),
+			TotalQueryTime:   durationpb.New(total),
+			MaximumQueryTime: durationpb.New(maximum),
+			TotalRowsSent:    rows,
+			MaximumRowsSent:  rows,
+		}
+	}
+
+	a := require.New(t)
+	result := make(map[string]*storepb.SlowQueryStatistics)
+	MergeSlowQueryStatisticsItem(result, "db", newItem("SELECT 1", 2, now, 4*time.Second, 3*time.Second, 10))
+	MergeSlowQueryStatisticsItem(result, "db", newItem("SELECT 1", 3, now.Add(time.Hour), 6*time.Second, 2*time.Second, 20))
+	MergeSlowQueryStatisticsItem(result, "db", newItem("SELECT 2", 1, now, time.Second, time.Second, 1))
+	MergeSlowQueryStatisticsItem(result, "other", newItem("SELECT 1", 1, now, time.Second, time.Second, 1))
+
+	a.Len(result, 2)
+	a.Len(result["db"].Items, 2)
+	item := result["db"].Items[0]
+	a.Equal("SELECT 1", item.SqlFingerprint)
+	a.Equal(int64(5), item.Count)
+	a.Equal(now.Add(time.Hour), item.LatestLogTime.AsTime())
+	a.Equal(10*time.Second, item.TotalQueryTime.AsDuration())
+	a.Equal(3*time.Second, item.MaximumQueryTime.AsDuration())
+	a.Equal(int64(30), item.TotalRowsSent)
+	a.Equal(int64(20), item.MaximumRowsSent)
+	a.Len(result["other"].Items, 1)
+}
+
+func TestDiffSlowQueryStatistics(t *testing.T) {
+	day := time.Date(2023, 10, 18, 0, 0, 0, 0, time.UTC)
+	newItem := func(fingerprint string, count int64, totalQueryTime, maximumQueryTime time.Duration, latest time.Time) *storepb.SlowQueryStatisticsItem {
+		return &storepb.SlowQueryStatisticsItem{
+			SqlFingerprint:   fingerprint,
+			Count:            count,
+			LatestLogTime:    timestamppb.New(latest),
+			TotalQueryTime:   durationpb.New(totalQueryTime),
+			MaximumQueryTime: durationpb.New(maximumQueryTime),
+			TotalRowsSent:    count * 10,
+		}
+	}
+	previous := map[string]*storepb.SlowQueryStatistics{
+		"db1": {Items: []*storepb.SlowQueryStatisticsItem{
+			newItem("SELECT 1", 100, 200*time.Second, 3*time.Second, day.Add(-time.Hour)),
+			newItem("SELECT 2", 50, 100*time.Second, 2*time.Second, day.Add(-time.Hour)),
+			newItem("SELECT 3", 10, 20*time.Second, 2*time.Second, day.AddDate(0, 0, -40)),
+		}},
+	}
+	current := map[string]*storepb.SlowQueryStatistics{
+		"db1": {Items: []*storepb.SlowQueryStatisticsItem{
+			// Executed 5 more times.
+			newItem("SELECT 1", 105, 215*time.Second, 4*time.Second, day.Add(time.Hour)),
+			// Evicted and cached again.
+			newItem("SELECT 4", 2, 3*time.Second, 2*time.Second, day.Add(time.Hour)),
+			// Fast statement.
+			newItem("SELECT 5", 1000, time.Second, time.Millisecond, day.Add(time.Hour)),
+		}},
+		"db2": {Items: []*storepb.SlowQueryStatisticsItem{
+			newItem("SELECT 1", 3, 6*time.Second, 2*time.Second, day.Add(time.Hour)),
+		}},
+	}
+	previous["db1"].Items = append(previous["db1"].Items, newItem("SELECT 4", 20, 40*time.Second, 2*time.Second, day.Add(-time.Hour)))
+
+	a := require.New(t)
+	latest, baseline := MergeSlowQuerySnapshot(previous, nil, current, day.AddDate(0, 0, -30))
+	// SELECT 3 is not executed in the retention cycle.
+	a.Len(latest["db1"].Items, 4)
+	a.Len(previous["db1"].Items, 4)
+	a.Len(latest["db2"].Items, 1)
+	// Only the statements executed since the previous snapshot are in the baseline, SELECT 5 is newly cached.
+	var baselineFingerprints []string
+	for _, item := range baseline["db1"].Items {
+		baselineFingerprints = append(baselineFingerprints, item.SqlFingerprint)
+	}
+	a.ElementsMatch([]string{"SELECT 1", "SELECT 4", "SELECT 5"}, baselineFingerprints)
+	a.Equal(int64(100), baseline["db1"].Items[0].Count)
+	a.Len(baseline["db2"].Items, 1)
+
+	// Merging the same statistics again doesn't change the baseline recorded on the log date.
+	latest, baseline = MergeSlowQuerySnapshot(latest, baseline, current, day.AddDate(0, 0, -30))
+	a.Len(baseline["db1"].Items, 3)
+	a.Equal(int64(100), baseline["db1"].Items[0].Count)
+
+	deltas := DiffSlowQueryStatistics(latest, baseline, time.Second)
+	a.Len(deltas, 2)
+	items := make(map[string]*storepb.SlowQueryStatisticsItem)
+	for _, item := range deltas["db1"].Items {
+		items[item.SqlFingerprint] = item
+	}
+	// SELECT 2 is not executed since the baseline, and SELECT 5 is not slow.
+	a.Len(items, 2)
+	a.Equal(int64(5), items["SELECT 1"].Count)
+	a.Equal(15*time.Second, items["SELECT 1"].TotalQueryTime.AsDuration())
+	a.Equal(4*time.Second, items["SELECT 1"].MaximumQueryTime.AsDuration())
+	a.Equal(int64(50), items["SELECT 1"].TotalRowsSent)
+	a.Equal(int64(2), items["SELECT 4"].Count)
+	a.Equal(int64(3), deltas["db2"].Items[0].Count)
+}
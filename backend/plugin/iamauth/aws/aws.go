@@ -0,0 +1,108 @@
+// Package aws provides the token generator of the AWS RDS IAM database authentication.
+package aws
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/iamauth"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	serviceName = "rds-db"
+	// tokenLifetime is the maximum lifetime of the RDS authentication token.
+	tokenLifetime = 15 * time.Minute
+	// emptyPayloadHash is the SHA-256 hash of the empty request body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+func init() {
+	iamauth.Register(storepb.DataSourceIAMAuthentication_AWS_RDS, newGenerator)
+}
+
+// Generator generates the RDS authentication tokens with the AWS credentials of the Bytebase server.
+type Generator struct {
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	region      string
+	endpoint    string
+	username    string
+}
+
+// newGenerator returns the token generator of the config.
+// The AWS credentials of the Bytebase server are loaded from the environment, e.g. the IAM role of the EC2 instance or the EKS service account.
+func newGenerator(ctx context.Context, config *iamauth.Config) (iamauth.TokenGenerator, error) {
+	if config.Host == "" || config.Port == "" {
+		return nil, errors.New("host and port are required for AWS RDS IAM authentication")
+	}
+	var optFns []func(*awsconfig.LoadOptions) error
+	if config.Region != "" {
+		optFns = append(optFns, awsconfig.WithRegion(config.Region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load AWS config")
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("region of AWS RDS is required")
+	}
+	return &Generator{
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		region:      cfg.Region,
+		endpoint:    net.JoinHostPort(config.Host, config.Port),
+		username:    config.Username,
+	}, nil
+}
+
+// GenerateToken retrieves the AWS credentials and signs a new token.
+func (g *Generator) GenerateToken(ctx context.Context) (*iamauth.Token, error) {
+	credentials, err := g.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve AWS credentials")
+	}
+	return BuildAuthToken(ctx, g.signer, credentials, g.endpoint, g.region, g.username, time.Now())
+}
+
+// BuildAuthToken builds the RDS authentication token of the database user, which is the presigned URL of the connect action without the scheme.
+// The token is signed locally with the credentials, so it doesn't call any AWS API.
+// It expires in 15 minutes, or earlier if the temporary credentials expire before that.
+func BuildAuthToken(ctx context.Context, signer *v4.Signer, credentials aws.Credentials, endpoint, region, username string, signTime time.Time) (*iamauth.Token, error) {
+	lifetime := tokenLifetime
+	if credentials.CanExpire {
+		if remaining := credentials.Expires.Sub(signTime); remaining < lifetime {
+			lifetime = remaining
+		}
+	}
+	if lifetime <= 0 {
+		return nil, errors.New("AWS credentials have expired")
+	}
+
+	values := url.Values{}
+	values.Set("Action", "connect")
+	values.Set("DBUser", username)
+	values.Set("X-Amz-Expires", strconv.FormatInt(int64(lifetime/time.Second), 10))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+endpoint+"/?"+values.Encode(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the connect request")
+	}
+	signedURL, _, err := signer.PresignHTTP(ctx, credentials, req, emptyPayloadHash, serviceName, region, signTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to presign the connect request")
+	}
+	return &iamauth.Token{
+		Value:      strings.TrimPrefix(signedURL, "https://"),
+		ExpireTime: signTime.Add(lifetime),
+	}, nil
+}
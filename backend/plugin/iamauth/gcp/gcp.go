@@ -0,0 +1,50 @@
+// Package gcp provides the token generator of the Google Cloud SQL IAM database authentication.
+package gcp
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/bytebase/bytebase/backend/plugin/iamauth"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// sqlLoginScope is the OAuth 2.0 scope for logging in to the Cloud SQL instances.
+const sqlLoginScope = "https://www.googleapis.com/auth/sqlservice.login"
+
+func init() {
+	iamauth.Register(storepb.DataSourceIAMAuthentication_GCP_CLOUD_SQL, newGenerator)
+}
+
+// Generator uses the OAuth 2.0 access tokens of the Bytebase server's service account as the Cloud SQL IAM authentication tokens.
+type Generator struct {
+	tokenSource oauth2.TokenSource
+}
+
+// newGenerator returns the token generator with the application default credentials.
+// The database user is the service account email, trimming the ".gserviceaccount.com" suffix for Postgres.
+func newGenerator(ctx context.Context, _ *iamauth.Config) (iamauth.TokenGenerator, error) {
+	credentials, err := google.FindDefaultCredentials(ctx, sqlLoginScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find GCP default credentials")
+	}
+	return &Generator{tokenSource: credentials.TokenSource}, nil
+}
+
+// GenerateToken returns the current access token, which is refreshed by the token source before it expires.
+func (g *Generator) GenerateToken(context.Context) (*iamauth.Token, error) {
+	token, err := g.tokenSource.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get GCP access token")
+	}
+	if token.Expiry.IsZero() {
+		return nil, errors.New("GCP access token without expiry is not supported for Cloud SQL IAM authentication")
+	}
+	return &iamauth.Token{
+		Value:      token.AccessToken,
+		ExpireTime: token.Expiry,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestGenerateToken(t *testing.T) {
+	a := require.New(t)
+	expiry := time.Date(2023, 10, 1, 13, 0, 0, 0, time.UTC)
+	generator := &Generator{tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "ya29.token", Expiry: expiry})}
+	token, err := generator.GenerateToken(context.Background())
+	a.NoError(err)
+	a.Equal("ya29.token", token.Value)
+	a.Equal(expiry, token.ExpireTime)
+
+	// The token without expiry can't be refreshed before it's rejected.
+	generator = &Generator{tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "ya29.token"})}
+	_, err = generator.GenerateToken(context.Background())
+	a.Error(err)
+}
@@ -0,0 +1,67 @@
+// Package iamauth provides the IAM database authentication generating the short-lived tokens used as the passwords of the data sources.
+package iamauth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	generatorsMu sync.RWMutex
+	generators   = make(map[storepb.DataSourceIAMAuthentication_Type]newFunc)
+)
+
+type newFunc func(ctx context.Context, config *Config) (TokenGenerator, error)
+
+// Token is the short-lived authentication token used as the password.
+type Token struct {
+	Value string
+	// ExpireTime is the time after which the token is rejected by the database.
+	ExpireTime time.Time
+}
+
+// TokenGenerator is the interface of an IAM authentication token generator.
+type TokenGenerator interface {
+	// GenerateToken generates a new authentication token of the database user.
+	GenerateToken(ctx context.Context) (*Token, error)
+}
+
+// Config is the configuration of an IAM authentication token generator.
+type Config struct {
+	*storepb.DataSourceIAMAuthentication
+	// Host and Port are the endpoint of the database instance.
+	Host string
+	Port string
+	// Username is the database user authenticated by the token.
+	Username string
+}
+
+// Register makes a token generator available for the IAM authentication type.
+// If Register is called twice with the same type or if f is nil, it panics.
+func Register(authType storepb.DataSourceIAMAuthentication_Type, f newFunc) {
+	generatorsMu.Lock()
+	defer generatorsMu.Unlock()
+	if f == nil {
+		panic("iamauth: Register token generator is nil")
+	}
+	if _, dup := generators[authType]; dup {
+		panic("iamauth: Register called twice for type " + authType.String())
+	}
+	generators[authType] = f
+}
+
+// New creates the token generator specified by the config.
+func New(ctx context.Context, config *Config) (TokenGenerator, error) {
+	generatorsMu.RLock()
+	f, ok := generators[config.Type]
+	generatorsMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("iamauth: unknown IAM authentication type %q", config.Type)
+	}
+	return f(ctx, config)
+}
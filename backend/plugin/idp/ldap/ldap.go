@@ -150,7 +150,7 @@ func (p *IdentityProvider) Authenticate(username, password string) (*storepb.Ide
 			0,
 			false,
 			strings.ReplaceAll(p.config.UserFilter, "%s", username),
-			[]string{"dn", p.config.FieldMapping.Identifier, p.config.FieldMapping.DisplayName, p.config.FieldMapping.Email},
+			[]string{"dn", p.config.FieldMapping.Identifier, p.config.FieldMapping.DisplayName, p.config.FieldMapping.Email, p.config.FieldMapping.Groups},
 			nil,
 		),
 	)
@@ -171,9 +171,25 @@ func (p *IdentityProvider) Authenticate(username, password string) (*storepb.Ide
 	if identifier == "" {
 		return nil, errors.Errorf("the attribute %q is not found or has empty value", p.config.FieldMapping.Identifier)
 	}
-	return &storepb.IdentityProviderUserInfo{
+	userInfo := &storepb.IdentityProviderUserInfo{
 		Identifier:  identifier,
 		DisplayName: entry.GetAttributeValue(p.config.FieldMapping.DisplayName),
 		Email:       entry.GetAttributeValue(p.config.FieldMapping.Email),
-	}, nil
+	}
+	if p.config.FieldMapping.Groups != "" {
+		for _, group := range entry.GetAttributeValues(p.config.FieldMapping.Groups) {
+			userInfo.Groups = append(userInfo.Groups, NormalizeDN(group))
+		}
+	}
+	return userInfo, nil
+}
+
+// NormalizeDN returns the normalized DN so that the DNs of the same group are equal regardless of the case of the
+// attribute types and the spaces, e.g. the values of the memberOf attribute. The value is returned as is if it's not a DN.
+func NormalizeDN(value string) string {
+	dn, err := ldap.ParseDN(value)
+	if err != nil || len(dn.RDNs) == 0 {
+		return value
+	}
+	return dn.String()
 }
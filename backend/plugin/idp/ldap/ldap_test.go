@@ -272,3 +272,18 @@ func TestIdentityProvider(t *testing.T) {
 	}
 	assert.Equal(t, wantUserInfo, userInfo)
 }
+
+func TestNormalizeDN(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "cn=dba,ou=groups,dc=example,dc=com", want: "cn=dba,ou=groups,dc=example,dc=com"},
+		{value: "CN=Developers, OU=Groups, DC=example, DC=com", want: "cn=Developers,ou=Groups,dc=example,dc=com"},
+		{value: `cn=R\2CD,ou=groups,dc=example,dc=com`, want: `cn=R\,D,ou=groups,dc=example,dc=com`},
+		{value: "dba@example.com", want: "dba@example.com"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, NormalizeDN(test.value), test.value)
+	}
+}
@@ -144,5 +144,8 @@ func (p *IdentityProvider) UserInfo(token string) (*storepb.IdentityProviderUser
 			}
 		}
 	}
+	if p.config.FieldMapping.Groups != "" {
+		userInfo.Groups = idp.GetStringListWithKey(claims, p.config.FieldMapping.Groups)
+	}
 	return userInfo, nil
 }
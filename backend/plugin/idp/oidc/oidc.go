@@ -166,5 +166,8 @@ func (p *IdentityProvider) UserInfo(ctx context.Context, token *oauth2.Token, no
 			}
 		}
 	}
+	if p.config.FieldMapping.Groups != "" {
+		userInfo.Groups = idp.GetStringListWithKey(claims, p.config.FieldMapping.Groups)
+	}
 	return userInfo, nil
 }
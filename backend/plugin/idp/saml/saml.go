@@ -0,0 +1,586 @@
+// Package saml is the plugin for SAML 2.0 Identity Provider.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/idp"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	namespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	namespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	namespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	bindingHTTPPost   = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	statusSuccess     = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	confirmBearer     = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	nameIDUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+	signatureRSA256   = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+
+	// NameIDKey is the key of the subject name identifier in the user attributes
+	// that the field mapping refers to.
+	NameIDKey = "NameID"
+
+	// maxClockSkew is the tolerated clock difference between Bytebase and the
+	// identity provider when validating the time conditions.
+	maxClockSkew = 3 * time.Minute
+)
+
+// IdentityProvider represents a SAML 2.0 Identity Provider.
+type IdentityProvider struct {
+	config        IdentityProviderConfig
+	certificate   *x509.Certificate
+	spCertificate *x509.Certificate
+	spPrivateKey  *rsa.PrivateKey
+}
+
+// IdentityProviderConfig is the configuration to be consumed by the SAML
+// Identity Provider.
+type IdentityProviderConfig struct {
+	// EntityID is the entity ID of the identity provider, which is expected to
+	// be the issuer of the SAML responses.
+	EntityID string `json:"entityId"`
+	// SSOURL is the single sign-on URL of the identity provider that receives
+	// the authentication requests with the HTTP-Redirect binding.
+	SSOURL string `json:"ssoUrl"`
+	// Certificate is the PEM-encoded X.509 certificate that the identity
+	// provider signs the SAML responses with.
+	Certificate string `json:"certificate"`
+	// SPCertificate is the PEM-encoded X.509 certificate of Bytebase as the
+	// service provider.
+	SPCertificate string `json:"spCertificate"`
+	// SPPrivateKey is the PEM-encoded private key of the service provider
+	// certificate to sign the authentication requests.
+	SPPrivateKey string `json:"spPrivateKey"`
+	// NameIDFormat is the name identifier format requested from the identity
+	// provider. The unspecified format is used if empty.
+	NameIDFormat string `json:"nameIdFormat"`
+	// FieldMapping is the mapping of the assertion attributes.
+	FieldMapping *storepb.FieldMapping `json:"fieldMapping"`
+	// SPEntityID is the entity ID of Bytebase as the service provider, which
+	// is also the audience of the assertions.
+	SPEntityID string `json:"spEntityId"`
+	// ACSURL is the URL of the assertion consumer service of Bytebase that the
+	// identity provider posts the SAML responses to.
+	ACSURL string `json:"acsUrl"`
+}
+
+// NewIdentityProvider initializes a new SAML Identity Provider with the given
+// configuration.
+func NewIdentityProvider(config IdentityProviderConfig) (*IdentityProvider, error) {
+	if config.FieldMapping == nil {
+		return nil, errors.Errorf("the field %q is empty but required", "fieldMapping")
+	}
+	for v, field := range map[string]string{
+		config.EntityID:                "entityId",
+		config.SSOURL:                  "ssoUrl",
+		config.Certificate:             "certificate",
+		config.SPCertificate:           "spCertificate",
+		config.SPPrivateKey:            "spPrivateKey",
+		config.FieldMapping.Identifier: "fieldMapping.identifier",
+		config.SPEntityID:              "spEntityId",
+		config.ACSURL:                  "acsUrl",
+	} {
+		if v == "" {
+			return nil, errors.Errorf("the field %q is empty but required", field)
+		}
+	}
+	if _, err := url.Parse(config.SSOURL); err != nil {
+		return nil, errors.Wrapf(err, "invalid SSO URL %q", config.SSOURL)
+	}
+
+	certificate, err := parseCertificate(config.Certificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid identity provider certificate")
+	}
+	if _, ok := certificate.PublicKey.(*rsa.PublicKey); !ok {
+		return nil, errors.New("the identity provider certificate must have an RSA public key")
+	}
+	spCertificate, err := parseCertificate(config.SPCertificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid service provider certificate")
+	}
+	spPrivateKey, err := parsePrivateKey(config.SPPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid service provider private key")
+	}
+	if !spPrivateKey.PublicKey.Equal(spCertificate.PublicKey) {
+		return nil, errors.New("the service provider private key doesn't match the certificate")
+	}
+	return &IdentityProvider{
+		config:        config,
+		certificate:   certificate,
+		spCertificate: spCertificate,
+		spPrivateKey:  spPrivateKey,
+	}, nil
+}
+
+func parseCertificate(s string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("expect a PEM-encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parsePrivateKey(s string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.New("expect a PEM-encoded private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("only the RSA private key is supported")
+	}
+	return rsaKey, nil
+}
+
+// NewRequestID returns a random ID for the authentication request.
+func NewRequestID() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// The ID must not start with a digit as it's an xs:ID.
+	return "id-" + hex.EncodeToString(b), nil
+}
+
+type metadataKeyDescriptor struct {
+	Use         string `xml:"use,attr"`
+	Certificate string `xml:"ds:KeyInfo>ds:X509Data>ds:X509Certificate"`
+}
+
+type metadataEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+	Index    int    `xml:"index,attr"`
+}
+
+type metadataEntityDescriptor struct {
+	XMLName         xml.Name `xml:"md:EntityDescriptor"`
+	NamespaceMD     string   `xml:"xmlns:md,attr"`
+	NamespaceDSig   string   `xml:"xmlns:ds,attr"`
+	EntityID        string   `xml:"entityID,attr"`
+	SPSSODescriptor struct {
+		AuthnRequestsSigned        bool                    `xml:"AuthnRequestsSigned,attr"`
+		WantAssertionsSigned       bool                    `xml:"WantAssertionsSigned,attr"`
+		ProtocolSupportEnumeration string                  `xml:"protocolSupportEnumeration,attr"`
+		KeyDescriptors             []metadataKeyDescriptor `xml:"md:KeyDescriptor"`
+		NameIDFormat               string                  `xml:"md:NameIDFormat"`
+		AssertionConsumerService   metadataEndpoint        `xml:"md:AssertionConsumerService"`
+	} `xml:"md:SPSSODescriptor"`
+}
+
+// Metadata returns the service provider metadata for the identity provider to
+// register Bytebase.
+func (p *IdentityProvider) Metadata() ([]byte, error) {
+	descriptor := metadataEntityDescriptor{
+		NamespaceMD:   namespaceMetadata,
+		NamespaceDSig: namespaceDSig,
+		EntityID:      p.config.SPEntityID,
+	}
+	descriptor.SPSSODescriptor.AuthnRequestsSigned = true
+	descriptor.SPSSODescriptor.WantAssertionsSigned = true
+	descriptor.SPSSODescriptor.ProtocolSupportEnumeration = namespaceProtocol
+	certificate := base64.StdEncoding.EncodeToString(p.spCertificate.Raw)
+	descriptor.SPSSODescriptor.KeyDescriptors = []metadataKeyDescriptor{
+		{Use: "signing", Certificate: certificate},
+	}
+	descriptor.SPSSODescriptor.NameIDFormat = p.nameIDFormat()
+	descriptor.SPSSODescriptor.AssertionConsumerService = metadataEndpoint{
+		Binding:  bindingHTTPPost,
+		Location: p.config.ACSURL,
+		Index:    0,
+	}
+	data, err := xml.MarshalIndent(descriptor, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal metadata")
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func (p *IdentityProvider) nameIDFormat() string {
+	if p.config.NameIDFormat != "" {
+		return p.config.NameIDFormat
+	}
+	return nameIDUnspecified
+}
+
+type authnRequest struct {
+	XMLName                     xml.Name `xml:"samlp:AuthnRequest"`
+	NamespaceProtocol           string   `xml:"xmlns:samlp,attr"`
+	NamespaceAssertion          string   `xml:"xmlns:saml,attr"`
+	ID                          string   `xml:"ID,attr"`
+	Version                     string   `xml:"Version,attr"`
+	IssueInstant                string   `xml:"IssueInstant,attr"`
+	Destination                 string   `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string   `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string   `xml:"ProtocolBinding,attr"`
+	Issuer                      string   `xml:"saml:Issuer"`
+	NameIDPolicy                struct {
+		Format      string `xml:"Format,attr"`
+		AllowCreate bool   `xml:"AllowCreate,attr"`
+	} `xml:"samlp:NameIDPolicy"`
+}
+
+// AuthnRequestURL returns the URL to redirect the user to the identity provider
+// with the authentication request signed by the HTTP-Redirect binding. The
+// relay state is returned by the identity provider along with the response.
+func (p *IdentityProvider) AuthnRequestURL(requestID, relayState string, now time.Time) (string, error) {
+	request := authnRequest{
+		NamespaceProtocol:           namespaceProtocol,
+		NamespaceAssertion:          namespaceAssertion,
+		ID:                          requestID,
+		Version:                     "2.0",
+		IssueInstant:                now.UTC().Format(time.RFC3339),
+		Destination:                 p.config.SSOURL,
+		AssertionConsumerServiceURL: p.config.ACSURL,
+		ProtocolBinding:             bindingHTTPPost,
+		Issuer:                      p.config.SPEntityID,
+	}
+	request.NameIDPolicy.Format = p.nameIDFormat()
+	request.NameIDPolicy.AllowCreate = true
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal authentication request")
+	}
+
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return "", errors.Wrap(err, "failed to deflate authentication request")
+	}
+	if err := writer.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to deflate authentication request")
+	}
+
+	// The signature covers the query string in the order of SAMLRequest,
+	// RelayState and SigAlg, see section 3.4.4.1 of the SAML bindings.
+	query := "SAMLRequest=" + url.QueryEscape(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	query += "&SigAlg=" + url.QueryEscape(signatureRSA256)
+	digest := crypto.SHA256.New()
+	digest.Write([]byte(query))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.spPrivateKey, crypto.SHA256, digest.Sum(nil))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign authentication request")
+	}
+	query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+
+	separator := "?"
+	if strings.Contains(p.config.SSOURL, "?") {
+		separator = "&"
+	}
+	return p.config.SSOURL + separator + query, nil
+}
+
+// UserInfo validates the base64-encoded SAML response posted to the assertion
+// consumer service, and returns the user information mapped from the assertion.
+// The validRequestID checks whether the response is in response to an
+// authentication request sent by Bytebase. The check is skipped if it's nil.
+func (p *IdentityProvider) UserInfo(samlResponse string, validRequestID func(requestID string) (bool, error)) (*storepb.IdentityProviderUserInfo, error) {
+	return p.userInfo(samlResponse, validRequestID, time.Now())
+}
+
+func (p *IdentityProvider) userInfo(samlResponse string, validRequestID func(requestID string) (bool, error), now time.Time) (*storepb.IdentityProviderUserInfo, error) {
+	assertion, err := p.validateResponse(samlResponse, validRequestID, now)
+	if err != nil {
+		return nil, err
+	}
+	attributes := getAttributes(assertion)
+
+	userInfo := &storepb.IdentityProviderUserInfo{}
+	if v, ok := getAttribute(attributes, p.config.FieldMapping.Identifier).(string); ok {
+		userInfo.Identifier = v
+	}
+	if userInfo.Identifier == "" {
+		return nil, errors.Errorf("the field %q is not found in assertion or has empty value", p.config.FieldMapping.Identifier)
+	}
+
+	// Best effort to map optional fields
+	if p.config.FieldMapping.DisplayName != "" {
+		if v, ok := getAttribute(attributes, p.config.FieldMapping.DisplayName).(string); ok {
+			userInfo.DisplayName = v
+		}
+	}
+	if userInfo.DisplayName == "" {
+		userInfo.DisplayName = userInfo.Identifier
+	}
+	if p.config.FieldMapping.Email != "" {
+		if v, ok := getAttribute(attributes, p.config.FieldMapping.Email).(string); ok {
+			userInfo.Email = v
+		}
+	}
+	if p.config.FieldMapping.Phone != "" {
+		if v, ok := getAttribute(attributes, p.config.FieldMapping.Phone).(string); ok {
+			userInfo.Phone = v
+		}
+	}
+	if p.config.FieldMapping.Groups != "" {
+		userInfo.Groups = getAttributeValues(assertion, p.config.FieldMapping.Groups)
+	}
+	return userInfo, nil
+}
+
+// validateResponse validates the signature, issuer, audience, recipient and time
+// conditions of the SAML response, and returns the validated assertion.
+func (p *IdentityProvider) validateResponse(samlResponse string, validRequestID func(requestID string) (bool, error), now time.Time) (*element, error) {
+	data, err := decodeBase64(samlResponse)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode SAML response")
+	}
+	response, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+	if !response.is(namespaceProtocol, "Response") {
+		return nil, errors.Errorf("expect SAML Response but got %q", response.local)
+	}
+	// The duplicated IDs are rejected to prevent the signature wrapping attacks.
+	ids := map[string]bool{}
+	var duplicatedID string
+	response.walk(func(e *element) {
+		if id := e.attr("ID"); id != "" {
+			if ids[id] {
+				duplicatedID = id
+			}
+			ids[id] = true
+		}
+	})
+	if duplicatedID != "" {
+		return nil, errors.Errorf("duplicated ID %q in SAML response", duplicatedID)
+	}
+
+	if destination := response.attr("Destination"); destination != "" && destination != p.config.ACSURL {
+		return nil, errors.Errorf("mismatched destination, want %q but got %q", p.config.ACSURL, destination)
+	}
+	if issuer := response.child(namespaceAssertion, "Issuer"); issuer != nil && strings.TrimSpace(issuer.text()) != p.config.EntityID {
+		return nil, errors.Errorf("mismatched issuer, want %q but got %q", p.config.EntityID, strings.TrimSpace(issuer.text()))
+	}
+	statusCode := response.child(namespaceProtocol, "Status")
+	if statusCode != nil {
+		statusCode = statusCode.child(namespaceProtocol, "StatusCode")
+	}
+	if statusCode == nil {
+		return nil, errors.New("SAML response has no status code")
+	}
+	if statusCode.attr("Value") != statusSuccess {
+		return nil, errors.Errorf("the identity provider responded with status %q", statusCode.attr("Value"))
+	}
+
+	if len(response.childElements(namespaceAssertion, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("encrypted assertion is not supported")
+	}
+	assertions := response.childElements(namespaceAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, errors.Errorf("expect exactly one assertion but got %d", len(assertions))
+	}
+	assertion := assertions[0]
+
+	// Either the response or the assertion must be signed by the identity provider.
+	responseErr := verifySignature(response, p.certificate)
+	if responseErr != nil && responseErr != errNotSigned {
+		return nil, errors.Wrap(responseErr, "invalid response signature")
+	}
+	assertionErr := verifySignature(assertion, p.certificate)
+	if assertionErr != nil && assertionErr != errNotSigned {
+		return nil, errors.Wrap(assertionErr, "invalid assertion signature")
+	}
+	if responseErr == errNotSigned && assertionErr == errNotSigned {
+		return nil, errors.New("neither the response nor the assertion is signed")
+	}
+
+	if issuer := assertion.child(namespaceAssertion, "Issuer"); issuer == nil || strings.TrimSpace(issuer.text()) != p.config.EntityID {
+		return nil, errors.Errorf("the assertion is not issued by %q", p.config.EntityID)
+	}
+	if err := p.validateConditions(assertion, now); err != nil {
+		return nil, err
+	}
+	if err := p.validateSubject(assertion, response.attr("InResponseTo"), validRequestID, now); err != nil {
+		return nil, err
+	}
+	return assertion, nil
+}
+
+func (p *IdentityProvider) validateConditions(assertion *element, now time.Time) error {
+	conditions := assertion.child(namespaceAssertion, "Conditions")
+	if conditions == nil {
+		return errors.New("the assertion has no conditions")
+	}
+	if err := validateTime(conditions, now); err != nil {
+		return errors.Wrap(err, "the assertion conditions are not met")
+	}
+	audienceRestrictions := conditions.childElements(namespaceAssertion, "AudienceRestriction")
+	if len(audienceRestrictions) == 0 {
+		return errors.New("the assertion has no audience restriction")
+	}
+	for _, restriction := range audienceRestrictions {
+		matched := false
+		for _, audience := range restriction.childElements(namespaceAssertion, "Audience") {
+			if strings.TrimSpace(audience.text()) == p.config.SPEntityID {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return errors.Errorf("the assertion audience doesn't include %q", p.config.SPEntityID)
+		}
+	}
+	return nil
+}
+
+func (p *IdentityProvider) validateSubject(assertion *element, inResponseTo string, validRequestID func(requestID string) (bool, error), now time.Time) error {
+	subject := assertion.child(namespaceAssertion, "Subject")
+	if subject == nil {
+		return errors.New("the assertion has no subject")
+	}
+	for _, confirmation := range subject.childElements(namespaceAssertion, "SubjectConfirmation") {
+		if confirmation.attr("Method") != confirmBearer {
+			continue
+		}
+		data := confirmation.child(namespaceAssertion, "SubjectConfirmationData")
+		if data == nil {
+			continue
+		}
+		if recipient := data.attr("Recipient"); recipient != p.config.ACSURL {
+			return errors.Errorf("mismatched recipient, want %q but got %q", p.config.ACSURL, recipient)
+		}
+		if data.attr("NotOnOrAfter") == "" {
+			return errors.New("the subject confirmation has no expiration")
+		}
+		if err := validateTime(data, now); err != nil {
+			return errors.Wrap(err, "the subject confirmation is expired")
+		}
+		if validRequestID != nil {
+			requestID := data.attr("InResponseTo")
+			if inResponseTo != "" && requestID != "" && requestID != inResponseTo {
+				return errors.New("mismatched InResponseTo in response and assertion")
+			}
+			if requestID == "" {
+				requestID = inResponseTo
+			}
+			// The identity provider initiated responses are rejected.
+			if requestID == "" {
+				return errors.New("the response is not in response to a valid authentication request")
+			}
+			valid, err := validRequestID(requestID)
+			if err != nil {
+				return errors.Wrap(err, "failed to check the authentication request")
+			}
+			if !valid {
+				return errors.New("the response is not in response to a valid authentication request")
+			}
+		}
+		return nil
+	}
+	return errors.New("the assertion has no bearer subject confirmation")
+}
+
+// validateTime validates the NotBefore and NotOnOrAfter attributes of the element if present.
+func validateTime(e *element, now time.Time) error {
+	if v := e.attr("NotBefore"); v != "" {
+		notBefore, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid NotBefore %q", v)
+		}
+		if now.Add(maxClockSkew).Before(notBefore) {
+			return errors.Errorf("not valid before %s", v)
+		}
+	}
+	if v := e.attr("NotOnOrAfter"); v != "" {
+		notOnOrAfter, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return errors.Wrapf(err, "invalid NotOnOrAfter %q", v)
+		}
+		if !now.Add(-maxClockSkew).Before(notOnOrAfter) {
+			return errors.Errorf("not valid on or after %s", v)
+		}
+	}
+	return nil
+}
+
+// getAttribute returns the attribute value of the key. The attribute names are
+// often URIs with dots, so the key is looked up as a whole before as a path.
+func getAttribute(attributes map[string]any, key string) any {
+	if v, ok := attributes[key]; ok {
+		return v
+	}
+	return idp.GetValueWithKey(attributes, key)
+}
+
+// getAttributes returns the subject name identifier and the first values of the
+// assertion attributes keyed by both the names and the friendly names.
+func getAttributes(assertion *element) map[string]any {
+	attributes := map[string]any{}
+	if subject := assertion.child(namespaceAssertion, "Subject"); subject != nil {
+		if nameID := subject.child(namespaceAssertion, "NameID"); nameID != nil {
+			attributes[NameIDKey] = strings.TrimSpace(nameID.text())
+		}
+	}
+	for _, statement := range assertion.childElements(namespaceAssertion, "AttributeStatement") {
+		for _, attribute := range statement.childElements(namespaceAssertion, "Attribute") {
+			value := attribute.child(namespaceAssertion, "AttributeValue")
+			if value == nil {
+				continue
+			}
+			v := strings.TrimSpace(value.text())
+			if name := attribute.attr("Name"); name != "" {
+				attributes[name] = v
+			}
+			if friendlyName := attribute.attr("FriendlyName"); friendlyName != "" {
+				if _, ok := attributes[friendlyName]; !ok {
+					attributes[friendlyName] = v
+				}
+			}
+		}
+	}
+	return attributes
+}
+
+// getAttributeValues returns all values of the multi-valued assertion attribute
+// whose name or friendly name is the key, e.g. the group list.
+func getAttributeValues(assertion *element, key string) []string {
+	var values []string
+	for _, statement := range assertion.childElements(namespaceAssertion, "AttributeStatement") {
+		for _, attribute := range statement.childElements(namespaceAssertion, "Attribute") {
+			if attribute.attr("Name") != key && attribute.attr("FriendlyName") != key {
+				continue
+			}
+			for _, value := range attribute.childElements(namespaceAssertion, "AttributeValue") {
+				if v := strings.TrimSpace(value.text()); v != "" {
+					values = append(values, v)
+				}
+			}
+			return values
+		}
+	}
+	return values
+}
@@ -0,0 +1,329 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	testIdPEntityID = "https://idp.example.com/adfs/services/trust"
+	testSSOURL      = "https://idp.example.com/adfs/ls/"
+	testSPEntityID  = "https://bytebase.example.com/saml/adfs/metadata"
+	testACSURL      = "https://bytebase.example.com/saml/adfs/acs"
+	testEmailClaim  = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"
+)
+
+func newTestKeyPair(t *testing.T, commonName string) (*rsa.PrivateKey, string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(certificate), string(privateKey)
+}
+
+// idpStub is a local SAML identity provider that signs the responses.
+type idpStub struct {
+	t             *testing.T
+	key           *rsa.PrivateKey
+	spCertificate *x509.Certificate
+}
+
+// parseAuthnRequest verifies the signature of the authentication request URL and returns the request ID.
+func (s *idpStub) parseAuthnRequest(requestURL string) string {
+	u, err := url.Parse(requestURL)
+	require.NoError(s.t, err)
+	require.Equal(s.t, testSSOURL, fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path))
+
+	rawQuery := u.RawQuery
+	i := strings.Index(rawQuery, "&Signature=")
+	require.Greater(s.t, i, 0)
+	signed := rawQuery[:i]
+	query, err := url.ParseQuery(rawQuery)
+	require.NoError(s.t, err)
+	require.Equal(s.t, signatureRSA256, query.Get("SigAlg"))
+	signature, err := base64.StdEncoding.DecodeString(query.Get("Signature"))
+	require.NoError(s.t, err)
+	digest := sha256.Sum256([]byte(signed))
+	require.NoError(s.t, rsa.VerifyPKCS1v15(s.spCertificate.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], signature))
+
+	deflated, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+	require.NoError(s.t, err)
+	data, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	require.NoError(s.t, err)
+	var request struct {
+		ID                          string `xml:"ID,attr"`
+		AssertionConsumerServiceURL string `xml:"AssertionConsumerServiceURL,attr"`
+		Issuer                      string `xml:"Issuer"`
+	}
+	require.NoError(s.t, xml.Unmarshal(data, &request))
+	require.Equal(s.t, testACSURL, request.AssertionConsumerServiceURL)
+	require.Equal(s.t, testSPEntityID, request.Issuer)
+	return request.ID
+}
+
+type responseOptions struct {
+	requestID    string
+	audience     string
+	recipient    string
+	notOnOrAfter time.Time
+	signResponse bool
+	signNothing  bool
+}
+
+// response returns the base64-encoded SAML response.
+func (s *idpStub) response(opts responseOptions) string {
+	issueInstant := time.Now().UTC().Format(time.RFC3339)
+	notOnOrAfter := opts.notOnOrAfter.UTC().Format(time.RFC3339)
+	assertion := fmt.Sprintf(`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_assertion" IssueInstant="%s" Version="2.0">
+    <saml:Issuer>%s</saml:Issuer>
+    <saml:Subject>
+      <saml:NameID Format="urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified">jdoe</saml:NameID>
+      <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+        <saml:SubjectConfirmationData InResponseTo="%s" NotOnOrAfter="%s" Recipient="%s"/>
+      </saml:SubjectConfirmation>
+    </saml:Subject>
+    <saml:Conditions NotBefore="%s" NotOnOrAfter="%s">
+      <saml:AudienceRestriction>
+        <saml:Audience>%s</saml:Audience>
+      </saml:AudienceRestriction>
+    </saml:Conditions>
+    <saml:AttributeStatement>
+      <saml:Attribute Name="%s"><saml:AttributeValue xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="xs:string">jdoe@example.com</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="urn:oid:2.16.840.1.113730.3.1.241" FriendlyName="displayName"><saml:AttributeValue>John &amp; Doe</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="groups"><saml:AttributeValue>dba</saml:AttributeValue><saml:AttributeValue>developer</saml:AttributeValue></saml:Attribute>
+    </saml:AttributeStatement>
+  </saml:Assertion>`,
+		issueInstant, testIdPEntityID, opts.requestID, notOnOrAfter, opts.recipient,
+		time.Now().Add(-time.Minute).UTC().Format(time.RFC3339), notOnOrAfter, opts.audience, testEmailClaim)
+	if !opts.signResponse && !opts.signNothing {
+		assertion = s.sign(assertion, "_assertion", "</saml:Issuer>")
+	}
+	response := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" Destination="%s" ID="_response" InResponseTo="%s" IssueInstant="%s" Version="2.0">
+  <saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">%s</saml:Issuer>
+  <samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>
+  %s
+</samlp:Response>`, testACSURL, opts.requestID, issueInstant, testIdPEntityID, assertion)
+	if opts.signResponse {
+		response = s.sign(response, "_response", "</saml:Issuer>")
+	}
+	return base64.StdEncoding.EncodeToString([]byte(response))
+}
+
+// sign inserts the enveloped signature of the element with the ID after the first marker.
+func (s *idpStub) sign(doc, id, marker string) string {
+	root, err := parseXML([]byte(doc))
+	require.NoError(s.t, err)
+	var signed *element
+	root.walk(func(e *element) {
+		if e.attr("ID") == id {
+			signed = e
+		}
+	})
+	require.NotNil(s.t, signed)
+	digest := sha256.Sum256(canonicalize(signed, nil, nil))
+	signedInfo := fmt.Sprintf(`<ds:SignedInfo xmlns:ds="http://www.w3.org/2000/09/xmldsig#">
+  <ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/>
+  <ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"/>
+  <ds:Reference URI="#%s">
+    <ds:Transforms>
+      <ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"/>
+      <ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/>
+    </ds:Transforms>
+    <ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/>
+    <ds:DigestValue>%s</ds:DigestValue>
+  </ds:Reference>
+</ds:SignedInfo>`, id, base64.StdEncoding.EncodeToString(digest[:]))
+	signedInfoElement, err := parseXML([]byte(signedInfo))
+	require.NoError(s.t, err)
+	hashed := sha256.Sum256(canonicalize(signedInfoElement, nil, nil))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hashed[:])
+	require.NoError(s.t, err)
+
+	signatureXML := fmt.Sprintf(`<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#">%s<ds:SignatureValue>%s</ds:SignatureValue></ds:Signature>`,
+		signedInfo, base64.StdEncoding.EncodeToString(signature))
+	i := strings.Index(doc, marker) + len(marker)
+	return doc[:i] + signatureXML + doc[i:]
+}
+
+func newTestIdentityProvider(t *testing.T) (*IdentityProvider, *idpStub) {
+	idpKey, idpCertificate, _ := newTestKeyPair(t, "idp")
+	_, spCertificate, spPrivateKey := newTestKeyPair(t, "bytebase")
+	p, err := NewIdentityProvider(IdentityProviderConfig{
+		EntityID:      testIdPEntityID,
+		SSOURL:        testSSOURL,
+		Certificate:   idpCertificate,
+		SPCertificate: spCertificate,
+		SPPrivateKey:  spPrivateKey,
+		FieldMapping: &storepb.FieldMapping{
+			Identifier:  testEmailClaim,
+			DisplayName: "displayName",
+			Groups:      "groups",
+		},
+		SPEntityID: testSPEntityID,
+		ACSURL:     testACSURL,
+	})
+	require.NoError(t, err)
+	return p, &idpStub{t: t, key: idpKey, spCertificate: p.spCertificate}
+}
+
+func TestIdentityProvider(t *testing.T) {
+	p, stub := newTestIdentityProvider(t)
+
+	metadata, err := p.Metadata()
+	require.NoError(t, err)
+	assert.Contains(t, string(metadata), `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://bytebase.example.com/saml/adfs/metadata">`)
+	assert.Contains(t, string(metadata), `<md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://bytebase.example.com/saml/adfs/acs" index="0"></md:AssertionConsumerService>`)
+
+	requestID, err := NewRequestID()
+	require.NoError(t, err)
+	requestURL, err := p.AuthnRequestURL(requestID, "state", time.Now())
+	require.NoError(t, err)
+	require.Equal(t, requestID, stub.parseAuthnRequest(requestURL))
+
+	validRequestID := func(id string) (bool, error) {
+		return id == requestID, nil
+	}
+	valid := responseOptions{
+		requestID:    requestID,
+		audience:     testSPEntityID,
+		recipient:    testACSURL,
+		notOnOrAfter: time.Now().Add(5 * time.Minute),
+	}
+	want := &storepb.IdentityProviderUserInfo{
+		Identifier:  "jdoe@example.com",
+		DisplayName: "John & Doe",
+		Groups:      []string{"dba", "developer"},
+	}
+
+	userInfo, err := p.UserInfo(stub.response(valid), validRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, want, userInfo)
+
+	signResponse := valid
+	signResponse.signResponse = true
+	userInfo, err = p.UserInfo(stub.response(signResponse), validRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, want, userInfo)
+
+	tests := []struct {
+		name    string
+		opts    func(opts *responseOptions)
+		tamper  func(response string) string
+		wantErr string
+	}{
+		{
+			name:    "unsigned",
+			opts:    func(opts *responseOptions) { opts.signNothing = true },
+			wantErr: "neither the response nor the assertion is signed",
+		},
+		{
+			name:    "wrong audience",
+			opts:    func(opts *responseOptions) { opts.audience = "https://other.example.com" },
+			wantErr: "the assertion audience doesn't include",
+		},
+		{
+			name:    "wrong recipient",
+			opts:    func(opts *responseOptions) { opts.recipient = "https://other.example.com/acs" },
+			wantErr: "mismatched recipient",
+		},
+		{
+			name:    "expired",
+			opts:    func(opts *responseOptions) { opts.notOnOrAfter = time.Now().Add(-10 * time.Minute) },
+			wantErr: "not valid on or after",
+		},
+		{
+			name:    "unknown request",
+			opts:    func(opts *responseOptions) { opts.requestID = "id-unknown" },
+			wantErr: "not in response to a valid authentication request",
+		},
+		{
+			name: "tampered assertion",
+			tamper: func(response string) string {
+				return strings.Replace(response, "jdoe@example.com", "admin@example.com", 1)
+			},
+			wantErr: "the digest of the signed element doesn't match",
+		},
+		{
+			name: "signature wrapping",
+			tamper: func(response string) string {
+				// Move the signed assertion into an extension and add an unsigned one.
+				start := strings.Index(response, "<saml:Assertion")
+				end := strings.Index(response, "</saml:Assertion>") + len("</saml:Assertion>")
+				signed := response[start:end]
+				unsigned := strings.Replace(strings.Replace(signed, "jdoe@example.com", "admin@example.com", 1), `ID="_assertion"`, `ID="_evil"`, 1)
+				return response[:start] + unsigned + "<samlp:Extensions>" + signed + "</samlp:Extensions>" + response[end:]
+			},
+			wantErr: "doesn't refer to the signed element",
+		},
+	}
+	for _, test := range tests {
+		opts := valid
+		if test.opts != nil {
+			test.opts(&opts)
+		}
+		response := stub.response(opts)
+		if test.tamper != nil {
+			data, err := base64.StdEncoding.DecodeString(response)
+			require.NoError(t, err)
+			response = base64.StdEncoding.EncodeToString([]byte(test.tamper(string(data))))
+		}
+		_, err := p.UserInfo(response, validRequestID)
+		require.Error(t, err, test.name)
+		require.Contains(t, err.Error(), test.wantErr, test.name)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	doc := `<?xml version="1.0"?>
+<a:root xmlns:a="urn:a" xmlns:b="urn:b" xmlns="urn:default" xmlns:unused="urn:unused">
+  <a:child b:attr="1" z="2" a:attr="3" y='quote"&amp;'/>
+  <plain>text &lt; &gt; &amp;</plain>
+  <b:other xmlns:a="urn:a"><!-- comment --><inner xmlns=""/></b:other>
+</a:root>`
+	root, err := parseXML([]byte(doc))
+	require.NoError(t, err)
+	want := `<a:root xmlns:a="urn:a">
+  <a:child xmlns:b="urn:b" y="quote&quot;&amp;" z="2" a:attr="3" b:attr="1"></a:child>
+  <plain xmlns="urn:default">text &lt; &gt; &amp;</plain>
+  <b:other xmlns:b="urn:b"><inner></inner></b:other>
+</a:root>`
+	require.Equal(t, want, string(canonicalize(root, nil, nil)))
+
+	// The inclusive namespaces are rendered even if not visibly utilized.
+	child := root.childElements("urn:a", "child")[0]
+	require.Equal(t, `<a:child xmlns="urn:default" xmlns:a="urn:a" xmlns:b="urn:b" xmlns:unused="urn:unused" y="quote&quot;&amp;" z="2" a:attr="3" b:attr="1"></a:child>`,
+		string(canonicalize(child, []string{"#default", "unused"}, nil)))
+
+	_, err = parseXML([]byte(`<!DOCTYPE root [<!ENTITY a "aaaa">]><root>&a;</root>`))
+	require.Error(t, err)
+}
@@ -0,0 +1,418 @@
+package saml
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	// Register the hash functions used by the digest and signature algorithms.
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	namespaceXML                = "http://www.w3.org/XML/1998/namespace"
+	namespaceDSig               = "http://www.w3.org/2000/09/xmldsig#"
+	algorithmC14N               = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	algorithmEnvelopedSignature = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+)
+
+var (
+	errNotSigned = errors.New("the element is not signed")
+
+	digestAlgorithms = map[string]crypto.Hash{
+		"http://www.w3.org/2000/09/xmldsig#sha1":        crypto.SHA1,
+		"http://www.w3.org/2001/04/xmlenc#sha256":       crypto.SHA256,
+		"http://www.w3.org/2001/04/xmlenc#sha512":       crypto.SHA512,
+		"http://www.w3.org/2001/04/xmldsig-more#sha384": crypto.SHA384,
+	}
+	signatureAlgorithms = map[string]crypto.Hash{
+		"http://www.w3.org/2000/09/xmldsig#rsa-sha1":        crypto.SHA1,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha256": crypto.SHA256,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha384": crypto.SHA384,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha512": crypto.SHA512,
+	}
+)
+
+// element is an XML element that keeps the namespace prefixes as they are in
+// the document, which is required to canonicalize the signed elements.
+type element struct {
+	prefix string
+	local  string
+	// attrs are the attributes except the namespace declarations, and the
+	// Name.Space of an attribute is its prefix.
+	attrs []xml.Attr
+	// namespaces maps the prefixes declared on the element to the namespace
+	// URIs, and the default namespace has the empty prefix.
+	namespaces map[string]string
+	// children are either *element or string for the character data.
+	children []any
+	parent   *element
+}
+
+// parseXML parses the document into the element tree. The comments and
+// processing instructions are dropped, and the document type declarations are
+// rejected to prevent the entity expansion attacks.
+func parseXML(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse XML")
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			e := &element{
+				prefix:     t.Name.Space,
+				local:      t.Name.Local,
+				namespaces: map[string]string{},
+				parent:     current,
+			}
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					e.namespaces[""] = attr.Value
+				case attr.Name.Space == "xmlns":
+					e.namespaces[attr.Name.Local] = attr.Value
+				default:
+					e.attrs = append(e.attrs, attr)
+				}
+			}
+			if current == nil {
+				if root != nil {
+					return nil, errors.New("XML has multiple root elements")
+				}
+				root = e
+			} else {
+				current.children = append(current.children, e)
+			}
+			current = e
+		case xml.EndElement:
+			if current == nil {
+				return nil, errors.New("XML has unexpected end element")
+			}
+			current = current.parent
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, string(t))
+			}
+		case xml.Directive:
+			return nil, errors.New("XML with document type declaration is not supported")
+		}
+	}
+	if root == nil || current != nil {
+		return nil, errors.New("XML is incomplete")
+	}
+	return root, nil
+}
+
+// lookupNamespace returns the namespace URI bound to the prefix in the scope of the element.
+func (e *element) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return namespaceXML, true
+	}
+	for current := e; current != nil; current = current.parent {
+		if uri, ok := current.namespaces[prefix]; ok {
+			return uri, true
+		}
+	}
+	return "", prefix == ""
+}
+
+func (e *element) namespace() string {
+	uri, _ := e.lookupNamespace(e.prefix)
+	return uri
+}
+
+func (e *element) is(namespace, local string) bool {
+	return e.local == local && e.namespace() == namespace
+}
+
+// attr returns the value of the unprefixed attribute.
+func (e *element) attr(local string) string {
+	for _, attr := range e.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func (e *element) childElements(namespace, local string) []*element {
+	var elements []*element
+	for _, child := range e.children {
+		if c, ok := child.(*element); ok && c.is(namespace, local) {
+			elements = append(elements, c)
+		}
+	}
+	return elements
+}
+
+func (e *element) child(namespace, local string) *element {
+	if elements := e.childElements(namespace, local); len(elements) > 0 {
+		return elements[0]
+	}
+	return nil
+}
+
+// text returns the concatenated character data of the element and its descendants.
+func (e *element) text() string {
+	var b strings.Builder
+	for _, child := range e.children {
+		switch c := child.(type) {
+		case string:
+			b.WriteString(c)
+		case *element:
+			b.WriteString(c.text())
+		}
+	}
+	return b.String()
+}
+
+// walk calls fn for the element and its descendants in document order.
+func (e *element) walk(fn func(*element)) {
+	fn(e)
+	for _, child := range e.children {
+		if c, ok := child.(*element); ok {
+			c.walk(fn)
+		}
+	}
+}
+
+// canonicalize serializes the element with the exclusive XML canonicalization
+// without comments, see https://www.w3.org/TR/xml-exc-c14n/. The excluded
+// element is omitted from the output, which implements the enveloped
+// signature transform.
+func canonicalize(e *element, inclusivePrefixes []string, excluded *element) []byte {
+	var buf bytes.Buffer
+	c := &canonicalizer{buf: &buf, inclusivePrefixes: inclusivePrefixes, excluded: excluded}
+	c.element(e, map[string]string{})
+	return buf.Bytes()
+}
+
+type canonicalizer struct {
+	buf               *bytes.Buffer
+	inclusivePrefixes []string
+	excluded          *element
+}
+
+func (c *canonicalizer) element(e *element, rendered map[string]string) {
+	// The namespaces are visibly utilized by the element name and the attribute names.
+	utilized := map[string]bool{e.prefix: true}
+	for _, attr := range e.attrs {
+		if attr.Name.Space != "" && attr.Name.Space != "xml" {
+			utilized[attr.Name.Space] = true
+		}
+	}
+	for _, prefix := range c.inclusivePrefixes {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		if _, ok := e.lookupNamespace(prefix); ok {
+			utilized[prefix] = true
+		}
+	}
+
+	var prefixes []string
+	scope := map[string]string{}
+	for prefix, uri := range rendered {
+		scope[prefix] = uri
+	}
+	for prefix := range utilized {
+		uri, _ := e.lookupNamespace(prefix)
+		if renderedURI, ok := rendered[prefix]; ok && renderedURI == uri {
+			continue
+		}
+		// The empty default namespace is only rendered to undeclare a rendered one.
+		if prefix == "" && uri == "" && rendered[""] == "" {
+			continue
+		}
+		scope[prefix] = uri
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	attrs := append([]xml.Attr{}, e.attrs...)
+	attrNamespace := func(attr xml.Attr) string {
+		if attr.Name.Space == "" {
+			return ""
+		}
+		uri, _ := e.lookupNamespace(attr.Name.Space)
+		return uri
+	}
+	sort.SliceStable(attrs, func(i, j int) bool {
+		ni, nj := attrNamespace(attrs[i]), attrNamespace(attrs[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return attrs[i].Name.Local < attrs[j].Name.Local
+	})
+
+	c.buf.WriteByte('<')
+	c.buf.WriteString(qualifiedName(e.prefix, e.local))
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			c.buf.WriteString(` xmlns="`)
+		} else {
+			c.buf.WriteString(` xmlns:` + prefix + `="`)
+		}
+		c.buf.WriteString(escapeAttr(scope[prefix]))
+		c.buf.WriteByte('"')
+	}
+	for _, attr := range attrs {
+		c.buf.WriteString(" " + qualifiedName(attr.Name.Space, attr.Name.Local) + `="`)
+		c.buf.WriteString(escapeAttr(attr.Value))
+		c.buf.WriteByte('"')
+	}
+	c.buf.WriteByte('>')
+	for _, child := range e.children {
+		switch ch := child.(type) {
+		case string:
+			c.buf.WriteString(escapeText(ch))
+		case *element:
+			if ch == c.excluded {
+				continue
+			}
+			c.element(ch, scope)
+		}
+	}
+	c.buf.WriteString("</" + qualifiedName(e.prefix, e.local) + ">")
+}
+
+func qualifiedName(prefix, local string) string {
+	if prefix == "" {
+		return local
+	}
+	return prefix + ":" + local
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
+
+// verifySignature verifies the enveloped XML signature of the element with the
+// certificate. It returns errNotSigned if the element has no signature.
+func verifySignature(e *element, certificate *x509.Certificate) error {
+	signatures := e.childElements(namespaceDSig, "Signature")
+	if len(signatures) == 0 {
+		return errNotSigned
+	}
+	if len(signatures) > 1 {
+		return errors.New("the element has multiple signatures")
+	}
+	signature := signatures[0]
+	signedInfo := signature.child(namespaceDSig, "SignedInfo")
+	if signedInfo == nil {
+		return errors.New("the signature has no SignedInfo")
+	}
+
+	c14nMethod := signedInfo.child(namespaceDSig, "CanonicalizationMethod")
+	if c14nMethod == nil || c14nMethod.attr("Algorithm") != algorithmC14N {
+		return errors.New("the signature must use the exclusive canonicalization")
+	}
+	signatureMethod := signedInfo.child(namespaceDSig, "SignatureMethod")
+	if signatureMethod == nil {
+		return errors.New("the signature has no SignatureMethod")
+	}
+	signatureHash, ok := signatureAlgorithms[signatureMethod.attr("Algorithm")]
+	if !ok {
+		return errors.Errorf("unsupported signature algorithm %q", signatureMethod.attr("Algorithm"))
+	}
+
+	references := signedInfo.childElements(namespaceDSig, "Reference")
+	if len(references) != 1 {
+		return errors.Errorf("the signature must have exactly one reference, got %d", len(references))
+	}
+	reference := references[0]
+	id := e.attr("ID")
+	if id == "" || reference.attr("URI") != "#"+id {
+		return errors.Errorf("the signature reference %q doesn't refer to the signed element", reference.attr("URI"))
+	}
+	var inclusivePrefixes []string
+	if transforms := reference.child(namespaceDSig, "Transforms"); transforms != nil {
+		for _, transform := range transforms.childElements(namespaceDSig, "Transform") {
+			switch transform.attr("Algorithm") {
+			case algorithmEnvelopedSignature:
+			case algorithmC14N:
+				inclusivePrefixes = getInclusivePrefixes(transform)
+			default:
+				return errors.Errorf("unsupported transform %q", transform.attr("Algorithm"))
+			}
+		}
+	}
+	digestMethod := reference.child(namespaceDSig, "DigestMethod")
+	if digestMethod == nil {
+		return errors.New("the reference has no DigestMethod")
+	}
+	digestHash, ok := digestAlgorithms[digestMethod.attr("Algorithm")]
+	if !ok {
+		return errors.Errorf("unsupported digest algorithm %q", digestMethod.attr("Algorithm"))
+	}
+	digestValue := reference.child(namespaceDSig, "DigestValue")
+	if digestValue == nil {
+		return errors.New("the reference has no DigestValue")
+	}
+	expectedDigest, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return errors.Wrap(err, "failed to decode digest value")
+	}
+	h := digestHash.New()
+	h.Write(canonicalize(e, inclusivePrefixes, signature))
+	if subtle.ConstantTimeCompare(h.Sum(nil), expectedDigest) != 1 {
+		return errors.New("the digest of the signed element doesn't match")
+	}
+
+	signatureValue := signature.child(namespaceDSig, "SignatureValue")
+	if signatureValue == nil {
+		return errors.New("the signature has no SignatureValue")
+	}
+	signatureBytes, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signature value")
+	}
+	publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("only the RSA certificate is supported")
+	}
+	h = signatureHash.New()
+	h.Write(canonicalize(signedInfo, getInclusivePrefixes(c14nMethod), nil))
+	if err := rsa.VerifyPKCS1v15(publicKey, signatureHash, h.Sum(nil), signatureBytes); err != nil {
+		return errors.Wrap(err, "failed to verify signature")
+	}
+	return nil
+}
+
+func getInclusivePrefixes(e *element) []string {
+	inclusiveNamespaces := e.child(algorithmC14N, "InclusiveNamespaces")
+	if inclusiveNamespaces == nil {
+		return nil
+	}
+	return strings.Fields(inclusiveNamespaces.attr("PrefixList"))
+}
+
+// decodeBase64 decodes the base64 content that may be wrapped with white spaces.
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
@@ -17,3 +17,27 @@ func GetValueWithKey(data map[string]any, key string) any {
 
 	return value
 }
+
+// GetStringListWithKey returns the string list of the key in the data.
+// A single string value is treated as a list with one element.
+func GetStringListWithKey(data map[string]any, key string) []string {
+	switch value := GetValueWithKey(data, key).(type) {
+	case string:
+		if value == "" {
+			return nil
+		}
+		return []string{value}
+	case []any:
+		var list []string
+		for _, v := range value {
+			if s, ok := v.(string); ok && s != "" {
+				list = append(list, s)
+			}
+		}
+		return list
+	case []string:
+		return value
+	default:
+		return nil
+	}
+}
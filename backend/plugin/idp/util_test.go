@@ -67,3 +67,38 @@ func TestGetValueWithKeyFromJSONString(t *testing.T) {
 		}
 	}
 }
+
+func TestGetStringListWithKey(t *testing.T) {
+	tests := []struct {
+		data string
+		key  string
+		want []string
+	}{
+		{
+			data: `{"groups": ["dba@example.com", "developer@example.com"]}`,
+			key:  "groups",
+			want: []string{"dba@example.com", "developer@example.com"},
+		},
+		{
+			data: `{"user": {"groups": "dba@example.com"}}`,
+			key:  "user.groups",
+			want: []string{"dba@example.com"},
+		},
+		{
+			data: `{"groups": [1, "dba@example.com", ""]}`,
+			key:  "groups",
+			want: []string{"dba@example.com"},
+		},
+		{
+			data: `{"groups": {"name": "dba"}}`,
+			key:  "groups",
+			want: nil,
+		},
+	}
+
+	for _, test := range tests {
+		data := map[string]any{}
+		require.NoError(t, json.Unmarshal([]byte(test.data), &data))
+		require.Equal(t, test.want, GetStringListWithKey(data, test.key))
+	}
+}
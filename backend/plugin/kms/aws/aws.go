@@ -0,0 +1,142 @@
+// Package aws provides the key provider using AWS Key Management Service.
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+)
+
+const serviceName = "kms"
+
+func init() {
+	kms.Register(kms.AWS, newKeyProvider)
+}
+
+// KeyProvider wraps the data keys with the symmetric KMS key through the AWS KMS JSON API.
+type KeyProvider struct {
+	client      *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	region      string
+	endpoint    string
+	keyID       string
+}
+
+// newKeyProvider returns the key provider of the config.
+// The credentials are loaded from the credential file if specified, otherwise from the environment.
+func newKeyProvider(ctx context.Context, config *kms.Config) (kms.KeyProvider, error) {
+	var optFns []func(*awsconfig.LoadOptions) error
+	if config.Region != "" {
+		optFns = append(optFns, awsconfig.WithRegion(config.Region))
+	}
+	if config.CredentialFile != "" {
+		optFns = append(optFns, awsconfig.WithSharedCredentialsFiles([]string{config.CredentialFile}))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load AWS config")
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("region of AWS KMS is required")
+	}
+	endpoint := strings.TrimSuffix(config.Address, "/")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com", cfg.Region)
+	}
+	return &KeyProvider{
+		client:      &http.Client{Timeout: 30 * time.Second},
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		region:      cfg.Region,
+		endpoint:    endpoint,
+		keyID:       config.KeyName,
+	}, nil
+}
+
+type kmsRequest struct {
+	KeyID          string `json:"KeyId"`
+	Plaintext      []byte `json:"Plaintext,omitempty"`
+	CiphertextBlob []byte `json:"CiphertextBlob,omitempty"`
+}
+
+type kmsResponse struct {
+	Plaintext      []byte `json:"Plaintext"`
+	CiphertextBlob []byte `json:"CiphertextBlob"`
+	Type           string `json:"__type"`
+	Message        string `json:"message"`
+}
+
+// Wrap encrypts the data key with the KMS key.
+func (p *KeyProvider) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	resp, err := p.call(ctx, "Encrypt", &kmsRequest{KeyID: p.keyID, Plaintext: dataKey})
+	if err != nil {
+		return nil, err
+	}
+	return resp.CiphertextBlob, nil
+}
+
+// Unwrap decrypts the ciphertext blob.
+// The key ID is specified so that the ciphertext blob cannot be decrypted with another KMS key.
+func (p *KeyProvider) Unwrap(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	resp, err := p.call(ctx, "Decrypt", &kmsRequest{KeyID: p.keyID, CiphertextBlob: wrappedKey})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+func (p *KeyProvider) call(ctx context.Context, operation string, request *kmsRequest) (*kmsResponse, error) {
+	// The binary fields are base64 encoded by encoding/json as required by the AWS JSON protocol.
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+operation)
+
+	credentials, err := p.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve AWS credentials")
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := p.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), serviceName, p.region, time.Now()); err != nil {
+		return nil, errors.Wrap(err, "failed to sign request")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to call AWS KMS %s", operation)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response from AWS KMS")
+	}
+	var response kmsResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal response from AWS KMS, status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to call AWS KMS %s, status %d, %s: %s", operation, resp.StatusCode, response.Type, response.Message)
+	}
+	return &response, nil
+}
@@ -0,0 +1,187 @@
+package kms
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// The encrypted secret is "bbenc:v1:{data key ID}:{base64 encoded nonce and AES-256-GCM sealed secret}".
+// The prefix up to the data key ID is used as the additional data,
+// so that the sealed secret cannot be moved to another data key or format version.
+const (
+	encryptedPrefix = "bbenc:v1:"
+	dataKeySize     = 32
+)
+
+// ErrUnknownDataKey is returned when the secret is encrypted with a data key not added to the envelope.
+var ErrUnknownDataKey = errors.New("unknown data key")
+
+// Envelope encrypts the secrets with the data keys, which are wrapped by the key provider.
+type Envelope struct {
+	provider KeyProvider
+
+	mu       sync.RWMutex
+	keys     map[int]cipher.AEAD
+	activeID int
+}
+
+// NewEnvelope creates an envelope with the key provider.
+func NewEnvelope(provider KeyProvider) *Envelope {
+	return &Envelope{
+		provider: provider,
+		keys:     make(map[int]cipher.AEAD),
+	}
+}
+
+// GenerateDataKey generates a random 256-bit data key and returns it wrapped by the key provider.
+func (e *Envelope) GenerateDataKey(ctx context.Context) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, errors.Wrap(err, "failed to generate data key")
+	}
+	wrappedKey, err := e.provider.Wrap(ctx, dataKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to wrap data key")
+	}
+	return wrappedKey, nil
+}
+
+// AddDataKey unwraps the data key and adds it to the envelope.
+// The secrets are encrypted with the active data key.
+func (e *Envelope) AddDataKey(ctx context.Context, id int, wrappedKey []byte, active bool) error {
+	dataKey, err := e.provider.Unwrap(ctx, wrappedKey)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unwrap data key %d", id)
+	}
+	if len(dataKey) != dataKeySize {
+		return errors.Errorf("data key %d must be %d bytes, got %d bytes", id, dataKeySize, len(dataKey))
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return errors.Wrap(err, "failed to create GCM")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.keys[id] = aead
+	if active {
+		e.activeID = id
+	}
+	return nil
+}
+
+// HasDataKey returns whether the data key has been added to the envelope.
+func (e *Envelope) HasDataKey(id int) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	_, ok := e.keys[id]
+	return ok
+}
+
+// SetActiveDataKey makes the data key added before the active one.
+func (e *Envelope) SetActiveDataKey(id int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.keys[id]; !ok {
+		return errors.Wrapf(ErrUnknownDataKey, "data key %d", id)
+	}
+	e.activeID = id
+	return nil
+}
+
+// ActiveDataKeyID returns the ID of the active data key, or 0 if there is no active data key.
+func (e *Envelope) ActiveDataKeyID() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.activeID
+}
+
+// Encrypt encrypts the secret with the active data key.
+// The empty secret is not encrypted.
+func (e *Envelope) Encrypt(secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	e.mu.RLock()
+	id, aead := e.activeID, e.keys[e.activeID]
+	e.mu.RUnlock()
+	if aead == nil {
+		return "", errors.New("no active data key")
+	}
+
+	prefix := EncryptedPrefix(id)
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(secret)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), []byte(prefix))
+	return prefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the encrypted secret.
+// It returns ErrUnknownDataKey if the data key of the secret has not been added to the envelope.
+func (e *Envelope) Decrypt(encrypted string) (string, error) {
+	id, err := GetDataKeyID(encrypted)
+	if err != nil {
+		return "", err
+	}
+	e.mu.RLock()
+	aead := e.keys[id]
+	e.mu.RUnlock()
+	if aead == nil {
+		return "", errors.Wrapf(ErrUnknownDataKey, "data key %d", id)
+	}
+
+	prefix := EncryptedPrefix(id)
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encrypted, prefix))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode encrypted secret")
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("encrypted secret is too short")
+	}
+	secret, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(prefix))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt secret")
+	}
+	return string(secret), nil
+}
+
+// IsEncrypted returns whether the secret is encrypted by the envelope.
+func IsEncrypted(secret string) bool {
+	return strings.HasPrefix(secret, encryptedPrefix)
+}
+
+// EncryptedPrefix returns the prefix of the secrets encrypted with the data key.
+func EncryptedPrefix(id int) string {
+	return fmt.Sprintf("%s%d:", encryptedPrefix, id)
+}
+
+// GetDataKeyID returns the ID of the data key encrypting the secret.
+func GetDataKeyID(encrypted string) (int, error) {
+	if !IsEncrypted(encrypted) {
+		return 0, errors.New("secret is not encrypted")
+	}
+	idStr, _, ok := strings.Cut(strings.TrimPrefix(encrypted, encryptedPrefix), ":")
+	if !ok {
+		return 0, errors.New("malformed encrypted secret")
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, errors.Wrap(err, "malformed data key ID of encrypted secret")
+	}
+	return id, nil
+}
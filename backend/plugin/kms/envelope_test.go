@@ -0,0 +1,80 @@
+package kms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// xorKeyProvider is a toy key provider for testing.
+type xorKeyProvider struct{}
+
+func (xorKeyProvider) Wrap(_ context.Context, dataKey []byte) ([]byte, error) {
+	wrapped := make([]byte, len(dataKey))
+	for i, b := range dataKey {
+		wrapped[i] = b ^ 0x5a
+	}
+	return wrapped, nil
+}
+
+func (p xorKeyProvider) Unwrap(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	return p.Wrap(ctx, wrappedKey)
+}
+
+func TestEnvelope(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	envelope := NewEnvelope(xorKeyProvider{})
+
+	_, err := envelope.Encrypt("secret")
+	a.Error(err)
+
+	wrappedKey1, err := envelope.GenerateDataKey(ctx)
+	a.NoError(err)
+	a.NoError(envelope.AddDataKey(ctx, 101, wrappedKey1, true))
+	a.Equal(101, envelope.ActiveDataKeyID())
+
+	encrypted1, err := envelope.Encrypt("secret")
+	a.NoError(err)
+	a.True(IsEncrypted(encrypted1))
+	a.NotContains(encrypted1, "secret")
+	id, err := GetDataKeyID(encrypted1)
+	a.NoError(err)
+	a.Equal(101, id)
+
+	// The empty secret is kept as is.
+	empty, err := envelope.Encrypt("")
+	a.NoError(err)
+	a.Equal("", empty)
+
+	// The secrets encrypted with the old data key are still decryptable after another data key becomes active.
+	wrappedKey2, err := envelope.GenerateDataKey(ctx)
+	a.NoError(err)
+	a.NoError(envelope.AddDataKey(ctx, 102, wrappedKey2, true))
+	encrypted2, err := envelope.Encrypt("secret")
+	a.NoError(err)
+	a.NotEqual(encrypted1, encrypted2)
+	for _, encrypted := range []string{encrypted1, encrypted2} {
+		secret, err := envelope.Decrypt(encrypted)
+		a.NoError(err)
+		a.Equal("secret", secret)
+	}
+
+	// The data key added before can be made active again.
+	a.NoError(envelope.SetActiveDataKey(101))
+	a.Equal(101, envelope.ActiveDataKeyID())
+	a.True(errors.Is(envelope.SetActiveDataKey(103), ErrUnknownDataKey))
+
+	// The sealed secret cannot be moved to another data key.
+	_, err = envelope.Decrypt("bbenc:v1:102:" + encrypted1[len("bbenc:v1:101:"):])
+	a.Error(err)
+
+	_, err = NewEnvelope(xorKeyProvider{}).Decrypt(encrypted1)
+	a.True(errors.Is(err, ErrUnknownDataKey))
+
+	a.False(IsEncrypted("c2VjcmV0"))
+	_, err = GetDataKeyID("bbenc:v1:abc")
+	a.Error(err)
+}
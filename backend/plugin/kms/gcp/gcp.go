@@ -0,0 +1,132 @@
+// Package gcp provides the key provider using Google Cloud Key Management Service.
+package gcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+)
+
+const (
+	defaultEndpoint = "https://cloudkms.googleapis.com"
+	cloudKMSScope   = "https://www.googleapis.com/auth/cloudkms"
+)
+
+func init() {
+	kms.Register(kms.GCP, newKeyProvider)
+}
+
+// KeyProvider wraps the data keys with the symmetric crypto key through the Cloud KMS REST API.
+type KeyProvider struct {
+	client   *http.Client
+	endpoint string
+	keyName  string
+}
+
+// newKeyProvider returns the key provider of the config.
+// The service account key is loaded from the credential file if specified, otherwise the application default credentials are used.
+func newKeyProvider(ctx context.Context, config *kms.Config) (kms.KeyProvider, error) {
+	var client *http.Client
+	if config.CredentialFile != "" {
+		content, err := os.ReadFile(config.CredentialFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read credential file %q", config.CredentialFile)
+		}
+		credentials, err := google.CredentialsFromJSON(ctx, content, cloudKMSScope)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse GCP credentials")
+		}
+		client = oauth2.NewClient(ctx, credentials.TokenSource)
+	} else {
+		var err error
+		client, err = google.DefaultClient(ctx, cloudKMSScope)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find GCP default credentials")
+		}
+	}
+	endpoint := strings.TrimSuffix(config.Address, "/")
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	return &KeyProvider{
+		client:   client,
+		endpoint: endpoint,
+		keyName:  config.KeyName,
+	}, nil
+}
+
+type cryptoRequest struct {
+	Plaintext  []byte `json:"plaintext,omitempty"`
+	Ciphertext []byte `json:"ciphertext,omitempty"`
+}
+
+type cryptoResponse struct {
+	Plaintext  []byte `json:"plaintext"`
+	Ciphertext []byte `json:"ciphertext"`
+	Error      *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Wrap encrypts the data key with the primary version of the crypto key.
+func (p *KeyProvider) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	resp, err := p.call(ctx, "encrypt", &cryptoRequest{Plaintext: dataKey})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ciphertext, nil
+}
+
+// Unwrap decrypts the ciphertext, Cloud KMS finds the crypto key version from the ciphertext.
+func (p *KeyProvider) Unwrap(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	resp, err := p.call(ctx, "decrypt", &cryptoRequest{Ciphertext: wrappedKey})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
+
+func (p *KeyProvider) call(ctx context.Context, operation string, request *cryptoRequest) (*cryptoResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+	url := fmt.Sprintf("%s/v1/%s:%s", p.endpoint, p.keyName, operation)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to %s with Cloud KMS", operation)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response from Cloud KMS")
+	}
+	var response cryptoResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal response from Cloud KMS, status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		message := ""
+		if response.Error != nil {
+			message = response.Error.Message
+		}
+		return nil, errors.Errorf("failed to %s with Cloud KMS, status %d: %s", operation, resp.StatusCode, message)
+	}
+	return &response, nil
+}
@@ -0,0 +1,91 @@
+// Package kms provides the key providers wrapping the data keys and the envelope encryption of the secrets stored in the metadata database.
+package kms
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Provider is the type of a key provider.
+type Provider string
+
+const (
+	// Local is the key provider with the key encryption key in a local keyfile.
+	Local Provider = "LOCAL"
+	// Vault is the key provider using the Transit secrets engine of HashiCorp Vault.
+	Vault Provider = "VAULT"
+	// AWS is the key provider using AWS Key Management Service.
+	AWS Provider = "AWS_KMS"
+	// GCP is the key provider using Google Cloud Key Management Service.
+	GCP Provider = "GCP_KMS"
+)
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[Provider]newFunc)
+)
+
+type newFunc func(ctx context.Context, config *Config) (KeyProvider, error)
+
+// KeyProvider is the interface of a key provider.
+// The key provider holds the key encryption key, which never leaves the provider, and wraps the data keys with it.
+type KeyProvider interface {
+	// Wrap encrypts the data key with the key encryption key.
+	Wrap(ctx context.Context, dataKey []byte) ([]byte, error)
+	// Unwrap decrypts the wrapped data key with the key encryption key.
+	Unwrap(ctx context.Context, wrappedKey []byte) ([]byte, error)
+}
+
+// Config is the configuration of a key provider.
+type Config struct {
+	Provider Provider
+	// KeyFile is the path of the file containing the base64 encoded 256-bit key encryption key of the local key provider.
+	KeyFile string
+	// Address is the address of the Vault server, e.g. https://vault.example.com:8200.
+	// For AWS and GCP, it is the optional custom endpoint URL.
+	Address string
+	// Token is the Vault token.
+	Token string
+	// MountPath is the mount path of the Vault Transit secrets engine. Default to "transit".
+	MountPath string
+	// KeyName is the name of the key encryption key.
+	// It is the key name in Vault Transit, the key ID or ARN in AWS KMS,
+	// and the resource name in GCP KMS, e.g. projects/p/locations/global/keyRings/r/cryptoKeys/k.
+	KeyName string
+	// Region is the region of AWS KMS.
+	Region string
+	// CredentialFile is the path of the credential file.
+	// It is the shared credentials file for AWS and the service account key file for GCP.
+	// If empty, the default credentials of the environment are used.
+	CredentialFile string
+}
+
+// Register makes a key provider available.
+// If Register is called twice with the same provider or if f is nil, it panics.
+func Register(provider Provider, f newFunc) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	if f == nil {
+		panic("kms: Register key provider is nil")
+	}
+	if _, dup := providers[provider]; dup {
+		panic("kms: Register called twice for provider " + provider)
+	}
+	providers[provider] = f
+}
+
+// New creates the key provider specified by the config.
+func New(ctx context.Context, config *Config) (KeyProvider, error) {
+	providersMu.RLock()
+	f, ok := providers[config.Provider]
+	providersMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("kms: unknown provider %q", config.Provider)
+	}
+	if config.KeyName == "" && config.Provider != Local {
+		return nil, errors.Errorf("kms: key name of provider %q is required", config.Provider)
+	}
+	return f(ctx, config)
+}
@@ -0,0 +1,76 @@
+// Package local provides the key provider with the key encryption key in a local keyfile.
+package local
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+)
+
+const keySize = 32
+
+func init() {
+	kms.Register(kms.Local, newKeyProvider)
+}
+
+// KeyProvider wraps the data keys with AES-256-GCM using the key in the keyfile.
+type KeyProvider struct {
+	aead cipher.AEAD
+}
+
+// newKeyProvider returns the key provider with the key in the keyfile of the config.
+// The keyfile contains the base64 encoded 256-bit key, which can be generated by `openssl rand -base64 32`.
+func newKeyProvider(_ context.Context, config *kms.Config) (kms.KeyProvider, error) {
+	if config.KeyFile == "" {
+		return nil, errors.New("keyfile of local key provider is required")
+	}
+	content, err := os.ReadFile(config.KeyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read keyfile %q", config.KeyFile)
+	}
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(content)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode keyfile %q", config.KeyFile)
+	}
+	if len(key) != keySize {
+		return nil, errors.Errorf("key in %q must be %d bytes, got %d bytes", config.KeyFile, keySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCM")
+	}
+	return &KeyProvider{aead: aead}, nil
+}
+
+// Wrap seals the data key, and prepends the random nonce to the result.
+func (p *KeyProvider) Wrap(_ context.Context, dataKey []byte) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize(), p.aead.NonceSize()+len(dataKey)+p.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return p.aead.Seal(nonce, nonce, dataKey, nil), nil
+}
+
+// Unwrap opens the wrapped data key.
+func (p *KeyProvider) Unwrap(_ context.Context, wrappedKey []byte) ([]byte, error) {
+	if len(wrappedKey) < p.aead.NonceSize() {
+		return nil, errors.New("wrapped key is too short")
+	}
+	dataKey, err := p.aead.Open(nil, wrappedKey[:p.aead.NonceSize()], wrappedKey[p.aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unwrap data key, the keyfile may not be the one wrapping it")
+	}
+	return dataKey, nil
+}
@@ -0,0 +1,72 @@
+package local
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+)
+
+func writeKeyFile(t *testing.T) string {
+	key := make([]byte, keySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+	return keyFile
+}
+
+func TestKeyProvider(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	keyFile := writeKeyFile(t)
+	p, err := kms.New(ctx, &kms.Config{Provider: kms.Local, KeyFile: keyFile})
+	a.NoError(err)
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	wrappedKey, err := p.Wrap(ctx, dataKey)
+	a.NoError(err)
+	a.NotContains(string(wrappedKey), string(dataKey))
+	unwrappedKey, err := p.Unwrap(ctx, wrappedKey)
+	a.NoError(err)
+	a.Equal(dataKey, unwrappedKey)
+
+	// The data key cannot be unwrapped with another keyfile.
+	other, err := kms.New(ctx, &kms.Config{Provider: kms.Local, KeyFile: writeKeyFile(t)})
+	a.NoError(err)
+	_, err = other.Unwrap(ctx, wrappedKey)
+	a.Error(err)
+
+	// The envelope encrypts the secrets with the data key wrapped by the keyfile.
+	envelope := kms.NewEnvelope(p)
+	wrappedKey, err = envelope.GenerateDataKey(ctx)
+	a.NoError(err)
+	a.NoError(envelope.AddDataKey(ctx, 101, wrappedKey, true))
+	encrypted, err := envelope.Encrypt("password")
+	a.NoError(err)
+	reloaded, err := kms.New(ctx, &kms.Config{Provider: kms.Local, KeyFile: keyFile})
+	a.NoError(err)
+	envelope = kms.NewEnvelope(reloaded)
+	a.NoError(envelope.AddDataKey(ctx, 101, wrappedKey, true))
+	secret, err := envelope.Decrypt(encrypted)
+	a.NoError(err)
+	a.Equal("password", secret)
+}
+
+func TestInvalidKeyFile(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	_, err := kms.New(ctx, &kms.Config{Provider: kms.Local})
+	a.Error(err)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	a.NoError(os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0600))
+	_, err = kms.New(ctx, &kms.Config{Provider: kms.Local, KeyFile: keyFile})
+	a.ErrorContains(err, "must be 32 bytes")
+}
@@ -0,0 +1,134 @@
+// Package vault provides the key provider using the Transit secrets engine of HashiCorp Vault.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+)
+
+const defaultMountPath = "transit"
+
+func init() {
+	kms.Register(kms.Vault, newKeyProvider)
+}
+
+// KeyProvider wraps the data keys with the named key of Vault Transit.
+// The key is rotated in Vault, and the data keys are always wrapped with the latest version of the key.
+type KeyProvider struct {
+	client    *http.Client
+	address   string
+	token     string
+	mountPath string
+	keyName   string
+}
+
+// newKeyProvider returns the key provider of the config.
+// The address and token fall back to the VAULT_ADDR and VAULT_TOKEN environment variables.
+func newKeyProvider(_ context.Context, config *kms.Config) (kms.KeyProvider, error) {
+	address := config.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return nil, errors.New("address of Vault is required")
+	}
+	token := config.Token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if token == "" {
+		return nil, errors.New("token of Vault is required")
+	}
+	mountPath := strings.Trim(config.MountPath, "/")
+	if mountPath == "" {
+		mountPath = defaultMountPath
+	}
+	return &KeyProvider{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		address:   strings.TrimSuffix(address, "/"),
+		token:     token,
+		mountPath: mountPath,
+		keyName:   config.KeyName,
+	}, nil
+}
+
+type transitRequest struct {
+	Plaintext  string `json:"plaintext,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+}
+
+type transitResponse struct {
+	Data struct {
+		Plaintext  string `json:"plaintext"`
+		Ciphertext string `json:"ciphertext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+// Wrap encrypts the data key, and returns the Vault ciphertext such as "vault:v1:...".
+func (p *KeyProvider) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	resp, err := p.call(ctx, "encrypt", &transitRequest{Plaintext: base64.StdEncoding.EncodeToString(dataKey)})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data.Ciphertext == "" {
+		return nil, errors.New("empty ciphertext from Vault")
+	}
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+// Unwrap decrypts the Vault ciphertext.
+func (p *KeyProvider) Unwrap(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	resp, err := p.call(ctx, "decrypt", &transitRequest{Ciphertext: string(wrappedKey)})
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode plaintext from Vault")
+	}
+	return dataKey, nil
+}
+
+func (p *KeyProvider) call(ctx context.Context, operation string, request *transitRequest) (*transitResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+	url := fmt.Sprintf("%s/v1/%s/%s/%s", p.address, p.mountPath, operation, p.keyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to %s with Vault", operation)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response from Vault")
+	}
+	var response transitResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal response from Vault, status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to %s with Vault, status %d, errors: %s", operation, resp.StatusCode, strings.Join(response.Errors, "; "))
+	}
+	return &response, nil
+}
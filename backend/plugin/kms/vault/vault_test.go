@@ -0,0 +1,104 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+)
+
+const testToken = "root"
+
+// transitServer is a stand-in of the Vault dev server with the Transit secrets engine.
+// The ciphertext is "vault:v{version}:{id}", where id refers to the plaintext kept in memory.
+type transitServer struct {
+	mu         sync.Mutex
+	version    int
+	plaintexts map[string]string
+}
+
+func (s *transitServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeError := func(status int, message string) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{message}})
+	}
+	if r.Header.Get("X-Vault-Token") != testToken {
+		writeError(http.StatusForbidden, "permission denied")
+		return
+	}
+	var request transitRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var response transitResponse
+	switch r.URL.Path {
+	case "/v1/transit/encrypt/bytebase":
+		response.Data.Ciphertext = fmt.Sprintf("vault:v%d:%d", s.version, len(s.plaintexts))
+		s.plaintexts[response.Data.Ciphertext] = request.Plaintext
+	case "/v1/transit/decrypt/bytebase":
+		plaintext, ok := s.plaintexts[request.Ciphertext]
+		if !ok {
+			writeError(http.StatusBadRequest, "cipher: message authentication failed")
+			return
+		}
+		response.Data.Plaintext = plaintext
+	case "/v1/transit/keys/bytebase/rotate":
+		s.version++
+	default:
+		writeError(http.StatusNotFound, "no handler for route")
+		return
+	}
+	_ = json.NewEncoder(w).Encode(&response)
+}
+
+func TestKeyProvider(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	transit := &transitServer{version: 1, plaintexts: make(map[string]string)}
+	server := httptest.NewServer(transit)
+	defer server.Close()
+
+	p, err := kms.New(ctx, &kms.Config{Provider: kms.Vault, Address: server.URL, Token: testToken, KeyName: "bytebase"})
+	a.NoError(err)
+
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	wrappedKey, err := p.Wrap(ctx, dataKey)
+	a.NoError(err)
+	a.True(strings.HasPrefix(string(wrappedKey), "vault:v1:"))
+
+	// The data keys wrapped with the old version of the key are still unwrappable after the key is rotated in Vault.
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/v1/transit/keys/bytebase/rotate", strings.NewReader("{}"))
+	a.NoError(err)
+	req.Header.Set("X-Vault-Token", testToken)
+	resp, err := http.DefaultClient.Do(req)
+	a.NoError(err)
+	a.NoError(resp.Body.Close())
+	rewrappedKey, err := p.Wrap(ctx, dataKey)
+	a.NoError(err)
+	a.True(strings.HasPrefix(string(rewrappedKey), "vault:v2:"))
+	for _, wrapped := range [][]byte{wrappedKey, rewrappedKey} {
+		unwrappedKey, err := p.Unwrap(ctx, wrapped)
+		a.NoError(err)
+		a.Equal(dataKey, unwrappedKey)
+	}
+
+	_, err = p.Unwrap(ctx, []byte("vault:v1:unknown"))
+	a.ErrorContains(err, "message authentication failed")
+
+	denied, err := kms.New(ctx, &kms.Config{Provider: kms.Vault, Address: server.URL, Token: "invalid", KeyName: "bytebase"})
+	a.NoError(err)
+	_, err = denied.Wrap(ctx, dataKey)
+	a.ErrorContains(err, "permission denied")
+}
@@ -0,0 +1,381 @@
+// Package masker provides the algorithms to mask the sensitive data in query results.
+package masker
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const (
+	// defaultFullMaskSubstitution is the substitution of the full mask if the algorithm doesn't specify one.
+	defaultFullMaskSubstitution = "******"
+	// defaultCharacterSubstitution is the substitution of each masked character if the algorithm doesn't specify one.
+	defaultCharacterSubstitution = "*"
+	// maxFullMaskSubstitutionLength is the maximum length of the substitution of the full mask.
+	maxFullMaskSubstitutionLength = 16
+)
+
+// Masker is the interface that masks a value in the query result.
+type Masker interface {
+	// Mask returns the masked value, the input value would not be modified.
+	Mask(value *v1pb.RowValue) *v1pb.RowValue
+}
+
+// NewMasker creates a masker by the masking algorithm.
+func NewMasker(algorithm *storepb.MaskingAlgorithmSetting_Algorithm) (Masker, error) {
+	switch mask := algorithm.Mask.(type) {
+	case *storepb.MaskingAlgorithmSetting_Algorithm_FullMask_:
+		return NewFullMasker(mask.FullMask.Substitution), nil
+	case *storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_:
+		return NewRangeMasker(mask.RangeMask.Slices), nil
+	case *storepb.MaskingAlgorithmSetting_Algorithm_HashMask_:
+		return NewHashMasker(mask.HashMask.Type, mask.HashMask.Salt), nil
+	case *storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_:
+		return NewInnerOuterMasker(mask.InnerOuterMask.Type, mask.InnerOuterMask.PrefixLen, mask.InnerOuterMask.SuffixLen, mask.InnerOuterMask.Substitution), nil
+	case *storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_:
+		return NewGeneralizationMasker(mask.GeneralizationMask.BucketSize, mask.GeneralizationMask.TimeUnit), nil
+	default:
+		return nil, errors.Errorf("masking algorithm %q has no mask", algorithm.Id)
+	}
+}
+
+// ValidateAlgorithm validates the masking algorithm.
+func ValidateAlgorithm(algorithm *storepb.MaskingAlgorithmSetting_Algorithm) error {
+	switch mask := algorithm.Mask.(type) {
+	case *storepb.MaskingAlgorithmSetting_Algorithm_FullMask_:
+		if len(mask.FullMask.Substitution) > maxFullMaskSubstitutionLength {
+			return errors.Errorf("the substitution of full mask should not be longer than %d bytes", maxFullMaskSubstitutionLength)
+		}
+	case *storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_:
+		if len(mask.RangeMask.Slices) == 0 {
+			return errors.Errorf("range mask should have at least one slice")
+		}
+		for _, slice := range mask.RangeMask.Slices {
+			if slice.Start < 0 || slice.Start >= slice.End {
+				return errors.Errorf("invalid range mask slice [%d, %d)", slice.Start, slice.End)
+			}
+		}
+	case *storepb.MaskingAlgorithmSetting_Algorithm_HashMask_:
+		if mask.HashMask.Type == storepb.MaskingAlgorithmSetting_Algorithm_HashMask_HASH_TYPE_UNSPECIFIED {
+			return errors.Errorf("hash mask should specify the hash type")
+		}
+	case *storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_:
+		if mask.InnerOuterMask.Type == storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MASK_TYPE_UNSPECIFIED {
+			return errors.Errorf("inner outer mask should specify the mask type")
+		}
+		if mask.InnerOuterMask.PrefixLen < 0 || mask.InnerOuterMask.SuffixLen < 0 {
+			return errors.Errorf("the prefix and suffix length of inner outer mask should not be negative")
+		}
+	case *storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_:
+		if mask.GeneralizationMask.BucketSize < 0 {
+			return errors.Errorf("the bucket size of generalization mask should not be negative")
+		}
+		if mask.GeneralizationMask.BucketSize == 0 && mask.GeneralizationMask.TimeUnit == storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TIME_UNIT_UNSPECIFIED {
+			return errors.Errorf("generalization mask should specify either the bucket size or the time unit")
+		}
+	default:
+		return errors.Errorf("masking algorithm should have a mask")
+	}
+	return nil
+}
+
+// NewDefaultMasker returns the masker used if the semantic type of the column doesn't specify the algorithm for the masking level.
+// It returns nil if the masking level doesn't need to mask.
+func NewDefaultMasker(level storepb.MaskingLevel) Masker {
+	switch level {
+	case storepb.MaskingLevel_FULL:
+		return NewFullMasker("")
+	case storepb.MaskingLevel_PARTIAL:
+		return NewDefaultRangeMasker()
+	default:
+		return nil
+	}
+}
+
+// FullMasker replaces the whole value with the substitution, including NULL.
+type FullMasker struct {
+	substitution string
+}
+
+// NewFullMasker returns a new FullMasker.
+func NewFullMasker(substitution string) *FullMasker {
+	if substitution == "" {
+		substitution = defaultFullMaskSubstitution
+	}
+	return &FullMasker{substitution: substitution}
+}
+
+// Mask implements Masker.
+func (m *FullMasker) Mask(*v1pb.RowValue) *v1pb.RowValue {
+	return newStringValue(m.substitution)
+}
+
+// DefaultRangeMasker keeps the middle part of the value and pads it with asterisks.
+type DefaultRangeMasker struct{}
+
+// NewDefaultRangeMasker returns a new DefaultRangeMasker.
+func NewDefaultRangeMasker() *DefaultRangeMasker {
+	return &DefaultRangeMasker{}
+}
+
+// Mask implements Masker.
+func (*DefaultRangeMasker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	s, ok := rowValueToString(value)
+	if !ok {
+		return newStringValue("**UL**")
+	}
+	return newStringValue(fmt.Sprintf("**%s**", getMiddlePartOfString(s)))
+}
+
+// getMiddlePartOfString will get the middle part of the string.
+func getMiddlePartOfString(stmt string) string {
+	if len(stmt) == 0 || len(stmt) == 1 {
+		return ""
+	}
+	if len(stmt) == 2 || len(stmt) == 3 {
+		return string(stmt[1])
+	}
+
+	s := []rune(stmt)
+	if len(s)%4 != 0 {
+		s = s[:len(s)/4*4]
+	}
+
+	var ret []rune
+	ret = append(ret, s[len(s)/4:len(s)/2]...)
+	ret = append(ret, s[len(s)/2:len(s)/4*3]...)
+	return string(ret)
+}
+
+// RangeMasker replaces the characters in the slices with the substitution of each slice.
+type RangeMasker struct {
+	slices []*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice
+}
+
+// NewRangeMasker returns a new RangeMasker.
+func NewRangeMasker(slices []*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice) *RangeMasker {
+	return &RangeMasker{slices: slices}
+}
+
+// Mask implements Masker.
+func (m *RangeMasker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	s, ok := rowValueToString(value)
+	if !ok {
+		return value
+	}
+	runes := []rune(s)
+	masked := make([]bool, len(runes))
+	// substitutions records the substitution of the first masked character of each slice.
+	substitutions := make(map[int]string)
+	for _, slice := range m.slices {
+		start, end := int(slice.Start), int(slice.End)
+		if end > len(runes) {
+			end = len(runes)
+		}
+		if start >= end {
+			continue
+		}
+		for i := start; i < end; i++ {
+			masked[i] = true
+		}
+		substitutions[start] = slice.Substitution
+	}
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if !masked[i] {
+			_, _ = sb.WriteRune(r)
+			continue
+		}
+		if substitution, ok := substitutions[i]; ok {
+			_, _ = sb.WriteString(substitution)
+		}
+	}
+	return newStringValue(sb.String())
+}
+
+// HashMasker replaces the value with the hex encoded hash of the value and the salt.
+// The result is deterministic, so the masked columns can still be used to join or group by.
+type HashMasker struct {
+	hashType storepb.MaskingAlgorithmSetting_Algorithm_HashMask_HashType
+	salt     string
+}
+
+// NewHashMasker returns a new HashMasker.
+func NewHashMasker(hashType storepb.MaskingAlgorithmSetting_Algorithm_HashMask_HashType, salt string) *HashMasker {
+	return &HashMasker{hashType: hashType, salt: salt}
+}
+
+// Mask implements Masker.
+func (m *HashMasker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	s, ok := rowValueToString(value)
+	if !ok {
+		return value
+	}
+	data := []byte(s + m.salt)
+	switch m.hashType {
+	case storepb.MaskingAlgorithmSetting_Algorithm_HashMask_SHA256:
+		sum := sha256.Sum256(data)
+		return newStringValue(hex.EncodeToString(sum[:]))
+	default:
+		sum := md5.Sum(data)
+		return newStringValue(hex.EncodeToString(sum[:]))
+	}
+}
+
+// InnerOuterMasker masks the inner or the outer part of the value split by the prefix and suffix length.
+type InnerOuterMasker struct {
+	maskType     storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType
+	prefixLen    int
+	suffixLen    int
+	substitution string
+}
+
+// NewInnerOuterMasker returns a new InnerOuterMasker.
+func NewInnerOuterMasker(maskType storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType, prefixLen, suffixLen int32, substitution string) *InnerOuterMasker {
+	if substitution == "" {
+		substitution = defaultCharacterSubstitution
+	}
+	return &InnerOuterMasker{
+		maskType:     maskType,
+		prefixLen:    int(prefixLen),
+		suffixLen:    int(suffixLen),
+		substitution: substitution,
+	}
+}
+
+// Mask implements Masker.
+func (m *InnerOuterMasker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	s, ok := rowValueToString(value)
+	if !ok {
+		return value
+	}
+	runes := []rune(s)
+	if m.prefixLen+m.suffixLen >= len(runes) {
+		// The prefix and suffix cover the whole value, mask all of them to avoid leaking the value.
+		return newStringValue(strings.Repeat(m.substitution, len(runes)))
+	}
+	prefix, inner, suffix := runes[:m.prefixLen], runes[m.prefixLen:len(runes)-m.suffixLen], runes[len(runes)-m.suffixLen:]
+	var sb strings.Builder
+	switch m.maskType {
+	case storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_OUTER:
+		_, _ = sb.WriteString(strings.Repeat(m.substitution, len(prefix)))
+		_, _ = sb.WriteString(string(inner))
+		_, _ = sb.WriteString(strings.Repeat(m.substitution, len(suffix)))
+	default:
+		_, _ = sb.WriteString(string(prefix))
+		_, _ = sb.WriteString(strings.Repeat(m.substitution, len(inner)))
+		_, _ = sb.WriteString(string(suffix))
+	}
+	return newStringValue(sb.String())
+}
+
+// GeneralizationMasker replaces a number with the range it falls into, and truncates a date or timestamp to the time unit.
+// Values that are neither numbers nor times are fully masked.
+type GeneralizationMasker struct {
+	bucketSize float64
+	timeUnit   storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit
+}
+
+// NewGeneralizationMasker returns a new GeneralizationMasker.
+func NewGeneralizationMasker(bucketSize float64, timeUnit storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit) *GeneralizationMasker {
+	return &GeneralizationMasker{bucketSize: bucketSize, timeUnit: timeUnit}
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// Mask implements Masker.
+func (m *GeneralizationMasker) Mask(value *v1pb.RowValue) *v1pb.RowValue {
+	s, ok := rowValueToString(value)
+	if !ok {
+		return value
+	}
+	if m.bucketSize > 0 {
+		if f, err := strconv.ParseFloat(strings.TrimSpace(s), 64); err == nil {
+			lower := math.Floor(f/m.bucketSize) * m.bucketSize
+			upper := lower + m.bucketSize
+			return newStringValue(fmt.Sprintf("[%s, %s)", strconv.FormatFloat(lower, 'f', -1, 64), strconv.FormatFloat(upper, 'f', -1, 64)))
+		}
+	}
+	if m.timeUnit != storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TIME_UNIT_UNSPECIFIED {
+		for _, layout := range timeLayouts {
+			t, err := time.Parse(layout, strings.TrimSpace(s))
+			if err != nil {
+				continue
+			}
+			switch m.timeUnit {
+			case storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_YEAR:
+				return newStringValue(t.Format("2006"))
+			case storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_MONTH:
+				return newStringValue(t.Format("2006-01"))
+			case storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_DAY:
+				return newStringValue(t.Format("2006-01-02"))
+			default:
+				return newStringValue(t.Format("2006-01-02 15"))
+			}
+		}
+	}
+	return newStringValue(defaultFullMaskSubstitution)
+}
+
+// rowValueToString converts the row value to string, returns false if the value is NULL.
+func rowValueToString(value *v1pb.RowValue) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+	switch v := value.Kind.(type) {
+	case *v1pb.RowValue_NullValue:
+		return "", false
+	case *v1pb.RowValue_BoolValue:
+		return fmt.Sprintf("%t", v.BoolValue), true
+	case *v1pb.RowValue_BytesValue:
+		return string(v.BytesValue), true
+	case *v1pb.RowValue_DoubleValue:
+		return strconv.FormatFloat(v.DoubleValue, 'f', -1, 64), true
+	case *v1pb.RowValue_FloatValue:
+		return strconv.FormatFloat(float64(v.FloatValue), 'f', -1, 32), true
+	case *v1pb.RowValue_Int32Value:
+		return strconv.FormatInt(int64(v.Int32Value), 10), true
+	case *v1pb.RowValue_Int64Value:
+		return strconv.FormatInt(v.Int64Value, 10), true
+	case *v1pb.RowValue_StringValue:
+		return v.StringValue, true
+	case *v1pb.RowValue_Uint32Value:
+		return strconv.FormatUint(uint64(v.Uint32Value), 10), true
+	case *v1pb.RowValue_Uint64Value:
+		return strconv.FormatUint(v.Uint64Value, 10), true
+	case *v1pb.RowValue_ValueValue:
+		if _, ok := v.ValueValue.GetKind().(*structpb.Value_NullValue); ok {
+			return "", false
+		}
+		b, err := v.ValueValue.MarshalJSON()
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	default:
+		return "", false
+	}
+}
+
+func newStringValue(s string) *v1pb.RowValue {
+	return &v1pb.RowValue{Kind: &v1pb.RowValue_StringValue{StringValue: s}}
+}
@@ -0,0 +1,156 @@
+package masker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestMasker(t *testing.T) {
+	tests := []struct {
+		masker Masker
+		input  *v1pb.RowValue
+		want   *v1pb.RowValue
+	}{
+		{
+			masker: NewFullMasker(""),
+			input:  newStringValue("bytebase"),
+			want:   newStringValue("******"),
+		},
+		{
+			masker: NewFullMasker("<hidden>"),
+			input:  &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}},
+			want:   newStringValue("<hidden>"),
+		},
+		{
+			masker: NewDefaultRangeMasker(),
+			input:  newStringValue("bytebase"),
+			want:   newStringValue("**teba**"),
+		},
+		{
+			masker: NewDefaultRangeMasker(),
+			input:  &v1pb.RowValue{Kind: &v1pb.RowValue_BoolValue{BoolValue: true}},
+			want:   newStringValue("**ru**"),
+		},
+		{
+			masker: NewDefaultRangeMasker(),
+			input:  &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}},
+			want:   newStringValue("**UL**"),
+		},
+		{
+			masker: NewRangeMasker([]*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice{
+				{Start: 0, End: 4, Substitution: "####"},
+				{Start: 6, End: 100, Substitution: "*"},
+			}),
+			input: newStringValue("13912345678"),
+			want:  newStringValue("####23*"),
+		},
+		{
+			masker: NewHashMasker(storepb.MaskingAlgorithmSetting_Algorithm_HashMask_MD5, ""),
+			input:  newStringValue("bytebase"),
+			want:   newStringValue("91f044180a1552deeed3606d693b4ea7"),
+		},
+		{
+			masker: NewHashMasker(storepb.MaskingAlgorithmSetting_Algorithm_HashMask_SHA256, "salt"),
+			input:  &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: 42}},
+			want:   newStringValue("ce743db8f02c8a96915606a4aa6a4e0347fe2990c4f29bcaa5a782479d218f96"),
+		},
+		{
+			masker: NewHashMasker(storepb.MaskingAlgorithmSetting_Algorithm_HashMask_SHA256, "salt"),
+			input:  &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}},
+			want:   &v1pb.RowValue{Kind: &v1pb.RowValue_NullValue{NullValue: structpb.NullValue_NULL_VALUE}},
+		},
+		{
+			masker: NewInnerOuterMasker(storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_INNER, 1, 4, ""),
+			input:  newStringValue("alice@corp.com"),
+			want:   newStringValue("a*********.com"),
+		},
+		{
+			masker: NewInnerOuterMasker(storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_OUTER, 2, 2, "#"),
+			input:  newStringValue("123456"),
+			want:   newStringValue("##34##"),
+		},
+		{
+			masker: NewInnerOuterMasker(storepb.MaskingAlgorithmSetting_Algorithm_InnerOuterMask_INNER, 3, 3, ""),
+			input:  newStringValue("12345"),
+			want:   newStringValue("*****"),
+		},
+		{
+			masker: NewGeneralizationMasker(10, storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TIME_UNIT_UNSPECIFIED),
+			input:  &v1pb.RowValue{Kind: &v1pb.RowValue_Int64Value{Int64Value: 27}},
+			want:   newStringValue("[20, 30)"),
+		},
+		{
+			masker: NewGeneralizationMasker(0.5, storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TIME_UNIT_UNSPECIFIED),
+			input:  newStringValue("-1.2"),
+			want:   newStringValue("[-1.5, -1)"),
+		},
+		{
+			masker: NewGeneralizationMasker(0, storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_MONTH),
+			input:  newStringValue("2023-10-11 12:13:14"),
+			want:   newStringValue("2023-10"),
+		},
+		{
+			masker: NewGeneralizationMasker(0, storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_YEAR),
+			input:  newStringValue("not a date"),
+			want:   newStringValue("******"),
+		},
+	}
+
+	a := require.New(t)
+	for i, test := range tests {
+		got := test.masker.Mask(test.input)
+		a.Equal(test.want.String(), got.String(), "test case %d", i)
+	}
+}
+
+func TestValidateAlgorithm(t *testing.T) {
+	tests := []struct {
+		algorithm *storepb.MaskingAlgorithmSetting_Algorithm
+		wantErr   bool
+	}{
+		{
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_FullMask_{FullMask: &storepb.MaskingAlgorithmSetting_Algorithm_FullMask{Substitution: "***"}},
+			},
+		},
+		{
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{},
+			wantErr:   true,
+		},
+		{
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_{RangeMask: &storepb.MaskingAlgorithmSetting_Algorithm_RangeMask{
+					Slices: []*storepb.MaskingAlgorithmSetting_Algorithm_RangeMask_Slice{{Start: 3, End: 1}},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_HashMask_{HashMask: &storepb.MaskingAlgorithmSetting_Algorithm_HashMask{}},
+			},
+			wantErr: true,
+		},
+		{
+			algorithm: &storepb.MaskingAlgorithmSetting_Algorithm{
+				Mask: &storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask_{GeneralizationMask: &storepb.MaskingAlgorithmSetting_Algorithm_GeneralizationMask{}},
+			},
+			wantErr: true,
+		},
+	}
+
+	a := require.New(t)
+	for i, test := range tests {
+		err := ValidateAlgorithm(test.algorithm)
+		if test.wantErr {
+			a.Error(err, "test case %d", i)
+		} else {
+			a.NoError(err, "test case %d", i)
+		}
+	}
+}
@@ -0,0 +1,680 @@
+// Package snowflake provides the Snowflake differ plugin.
+package snowflake
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	snowparser "github.com/bytebase/snowsql-parser"
+
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/differ"
+)
+
+const (
+	defaultSchemaName = "PUBLIC"
+)
+
+var (
+	_ differ.SchemaDiffer = (*SchemaDiffer)(nil)
+)
+
+func init() {
+	differ.Register(parser.Snowflake, &SchemaDiffer{})
+}
+
+// SchemaDiffer is the schema differ for Snowflake.
+// Snowflake does not support indexes on the standard tables, so there is no index diff.
+type SchemaDiffer struct {
+}
+
+// diffNode defines different modification types as the safe change order.
+// The safe change order means we can change them with no dependency conflicts as this order.
+type diffNode struct {
+	// Drop nodes
+	dropViewList           []string
+	dropForeignKeyList     []string
+	dropConstraintExceptFk []string
+	dropColumnList         []string
+	dropTableList          []string
+	dropProcedureList      []string
+	dropFunctionList       []string
+	dropSchemaList         []string
+
+	// Create nodes
+	createSchemaList      []string
+	createTableList       []string
+	addColumnList         []string
+	alterColumnList       []string
+	addConstraintExceptFk []string
+	addForeignKeyList     []string
+	createFunctionList    []string
+	createProcedureList   []string
+	createViewList        []string
+}
+
+// SchemaDiff implements the differ.SchemaDiffer interface.
+func (*SchemaDiffer) SchemaDiff(oldStmt, newStmt string, _ bool) (string, error) {
+	oldSchema, err := buildSchemaDefinition(oldStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema definition for old statement")
+	}
+	newSchema, err := buildSchemaDefinition(newStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema definition for new statement")
+	}
+
+	diff := &diffNode{}
+	diff.diffSchema(oldSchema, newSchema)
+	droppedTables := diff.diffTable(oldSchema, newSchema)
+	diff.diffConstraint(oldSchema, newSchema, droppedTables)
+	diff.diffRoutine(oldSchema.viewMap, newSchema.viewMap, &diff.dropViewList, &diff.createViewList)
+	diff.diffRoutine(oldSchema.procedureMap, newSchema.procedureMap, &diff.dropProcedureList, &diff.createProcedureList)
+	diff.diffRoutine(oldSchema.functionMap, newSchema.functionMap, &diff.dropFunctionList, &diff.createFunctionList)
+
+	return diff.deparse()
+}
+
+func (diff *diffNode) diffSchema(oldSchema, newSchema *schemaDefinition) {
+	for _, schema := range sortedSchemas(newSchema.schemaMap) {
+		if _, ok := oldSchema.schemaMap[schema.name]; !ok {
+			diff.createSchemaList = append(diff.createSchemaList, schema.definition+";")
+		}
+	}
+	for _, schema := range sortedSchemas(oldSchema.schemaMap) {
+		if schema.name == defaultSchemaName {
+			// The dump always contains the PUBLIC schema, never drop it.
+			continue
+		}
+		if _, ok := newSchema.schemaMap[schema.name]; !ok {
+			diff.dropSchemaList = append(diff.dropSchemaList, fmt.Sprintf("DROP SCHEMA %s;", quoteIdentifier(schema.name)))
+		}
+	}
+}
+
+// diffTable diffs the tables and their columns, it returns the keys of the dropped tables.
+func (diff *diffNode) diffTable(oldSchema, newSchema *schemaDefinition) map[string]bool {
+	droppedTables := make(map[string]bool)
+	for _, newTable := range sortedTables(newSchema.tableMap) {
+		oldTable, ok := oldSchema.tableMap[newTable.key]
+		if !ok {
+			diff.createTableList = append(diff.createTableList, newTable.definition+";")
+			continue
+		}
+		diff.diffColumn(oldTable, newTable)
+	}
+	for _, oldTable := range sortedTables(oldSchema.tableMap) {
+		if _, ok := newSchema.tableMap[oldTable.key]; !ok {
+			droppedTables[oldTable.key] = true
+			diff.dropTableList = append(diff.dropTableList, fmt.Sprintf("DROP TABLE %s;", oldTable.key))
+		}
+	}
+	return droppedTables
+}
+
+func (diff *diffNode) diffColumn(oldTable, newTable *tableInfo) {
+	oldColumnMap := make(map[string]*columnInfo)
+	for _, column := range oldTable.columnList {
+		oldColumnMap[column.name] = column
+	}
+	newColumnMap := make(map[string]*columnInfo)
+	for _, column := range newTable.columnList {
+		newColumnMap[column.name] = column
+	}
+
+	var dropColumns []string
+	for _, oldColumn := range oldTable.columnList {
+		if _, ok := newColumnMap[oldColumn.name]; !ok {
+			dropColumns = append(dropColumns, quoteIdentifier(oldColumn.name))
+		}
+	}
+	if len(dropColumns) > 0 {
+		diff.dropColumnList = append(diff.dropColumnList, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", oldTable.key, strings.Join(dropColumns, ", ")))
+	}
+
+	for _, newColumn := range newTable.columnList {
+		oldColumn, ok := oldColumnMap[newColumn.name]
+		if !ok {
+			diff.addColumnList = append(diff.addColumnList, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", newTable.key, newColumn.definition))
+			continue
+		}
+		// TODO: Snowflake cannot change the collation of an existing column, we may recreate the column for it.
+		var actions []string
+		if oldColumn.dataType != newColumn.dataType {
+			actions = append(actions, fmt.Sprintf("SET DATA TYPE %s", newColumn.dataType))
+		}
+		if oldColumn.notNull != newColumn.notNull {
+			if newColumn.notNull {
+				actions = append(actions, "SET NOT NULL")
+			} else {
+				actions = append(actions, "DROP NOT NULL")
+			}
+		}
+		if oldColumn.defaultValue != newColumn.defaultValue {
+			if newColumn.defaultValue == "" {
+				actions = append(actions, "DROP DEFAULT")
+			} else {
+				// Snowflake only supports setting the sequence as the default value of an existing column.
+				actions = append(actions, fmt.Sprintf("SET %s", newColumn.defaultValue))
+			}
+		}
+		if oldColumn.comment != newColumn.comment {
+			if newColumn.comment == "" {
+				actions = append(actions, "UNSET COMMENT")
+			} else {
+				actions = append(actions, fmt.Sprintf("COMMENT %s", newColumn.comment))
+			}
+		}
+		for _, action := range actions {
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s;", newTable.key, quoteIdentifier(newColumn.name), action))
+		}
+	}
+}
+
+func (diff *diffNode) diffConstraint(oldSchema, newSchema *schemaDefinition, droppedTables map[string]bool) {
+	for _, oldConstraint := range sortedConstraints(oldSchema.constraintMap) {
+		if droppedTables[oldConstraint.tableKey] {
+			// The constraint is dropped with the table.
+			continue
+		}
+		if newConstraint, ok := newSchema.constraintMap[oldConstraint.key()]; ok && newConstraint.definition == oldConstraint.definition {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s DROP %s;", oldConstraint.tableKey, oldConstraint.dropDefinition())
+		if oldConstraint.constraintType == constraintTypeForeignKey {
+			diff.dropForeignKeyList = append(diff.dropForeignKeyList, stmt)
+		} else {
+			diff.dropConstraintExceptFk = append(diff.dropConstraintExceptFk, stmt)
+		}
+	}
+
+	for _, newConstraint := range sortedConstraints(newSchema.constraintMap) {
+		if _, ok := oldSchema.tableMap[newConstraint.tableKey]; !ok {
+			// The constraint is created with the table.
+			continue
+		}
+		if oldConstraint, ok := oldSchema.constraintMap[newConstraint.key()]; ok && oldConstraint.definition == newConstraint.definition {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD %s;", newConstraint.tableKey, newConstraint.definition)
+		if newConstraint.constraintType == constraintTypeForeignKey {
+			diff.addForeignKeyList = append(diff.addForeignKeyList, stmt)
+		} else {
+			diff.addConstraintExceptFk = append(diff.addConstraintExceptFk, stmt)
+		}
+	}
+}
+
+// diffRoutine diffs the views, procedures or functions. The changed ones are replaced by CREATE OR REPLACE statements.
+func (*diffNode) diffRoutine(oldMap, newMap routineMap, dropList *[]string, createList *[]string) {
+	oldRoutines := sortedRoutines(oldMap)
+	// Drop in the reverse order of the definition, so that the dependent ones are dropped first.
+	for i := len(oldRoutines) - 1; i >= 0; i-- {
+		if _, ok := newMap[oldRoutines[i].key]; !ok {
+			*dropList = append(*dropList, fmt.Sprintf("DROP %s %s;", oldRoutines[i].routineType, oldRoutines[i].key))
+		}
+	}
+	for _, newRoutine := range sortedRoutines(newMap) {
+		oldRoutine, ok := oldMap[newRoutine.key]
+		if !ok {
+			*createList = append(*createList, newRoutine.definition+";")
+			continue
+		}
+		if oldRoutine.definition != newRoutine.definition {
+			*createList = append(*createList, newRoutine.replaceDefinition+";")
+		}
+	}
+}
+
+// deparse statements as the safe change order.
+func (diff *diffNode) deparse() (string, error) {
+	var buf bytes.Buffer
+	for _, list := range [][]string{
+		// drop
+		diff.dropViewList,
+		diff.dropForeignKeyList,
+		diff.dropConstraintExceptFk,
+		diff.dropColumnList,
+		diff.dropTableList,
+		diff.dropProcedureList,
+		diff.dropFunctionList,
+		diff.dropSchemaList,
+		// create
+		diff.createSchemaList,
+		diff.createTableList,
+		diff.addColumnList,
+		diff.alterColumnList,
+		diff.addConstraintExceptFk,
+		diff.addForeignKeyList,
+		diff.createFunctionList,
+		diff.createProcedureList,
+		diff.createViewList,
+	} {
+		if err := printStmtSlice(&buf, list); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+func printStmtSlice(buf io.Writer, stmtList []string) error {
+	for _, stmt := range stmtList {
+		if _, err := buf.Write([]byte(stmt)); err != nil {
+			return err
+		}
+		if _, err := buf.Write([]byte("\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type schemaMap map[string]*schemaInfo
+type tableMap map[string]*tableInfo
+type constraintMap map[string]*constraintInfo
+type routineMap map[string]*routineInfo
+
+// schemaDefinition is the definition of the objects in the statements.
+// The keys of the tables and the routines are the quoted full names, such as "PUBLIC"."T".
+type schemaDefinition struct {
+	schemaMap     schemaMap
+	tableMap      tableMap
+	constraintMap constraintMap
+	viewMap       routineMap
+	procedureMap  routineMap
+	functionMap   routineMap
+}
+
+type schemaInfo struct {
+	id         int
+	name       string
+	definition string
+}
+
+type tableInfo struct {
+	id         int
+	key        string
+	definition string
+	columnList []*columnInfo
+}
+
+type columnInfo struct {
+	name string
+	// definition is the column definition without the inline constraints, which are diffed as the constraints.
+	definition   string
+	dataType     string
+	notNull      bool
+	defaultValue string
+	comment      string
+}
+
+type constraintType string
+
+const (
+	constraintTypePrimaryKey constraintType = "PRIMARY KEY"
+	constraintTypeUnique     constraintType = "UNIQUE"
+	constraintTypeForeignKey constraintType = "FOREIGN KEY"
+)
+
+type constraintInfo struct {
+	id       int
+	tableKey string
+	// name is empty for the unnamed constraints.
+	name           string
+	constraintType constraintType
+	// columns is the column list in parentheses.
+	columns string
+	// definition is the constraint definition used in ALTER TABLE ADD statement.
+	definition string
+}
+
+func (c *constraintInfo) key() string {
+	if c.name == "" {
+		// Snowflake identifies the unnamed constraints by the type and the columns.
+		return fmt.Sprintf("%s.%s %s", c.tableKey, c.constraintType, c.columns)
+	}
+	return fmt.Sprintf("%s.%s", c.tableKey, c.name)
+}
+
+func (c *constraintInfo) dropDefinition() string {
+	switch {
+	case c.name != "":
+		return fmt.Sprintf("CONSTRAINT %s", quoteIdentifier(c.name))
+	case c.constraintType == constraintTypePrimaryKey:
+		return string(constraintTypePrimaryKey)
+	default:
+		return fmt.Sprintf("%s %s", c.constraintType, c.columns)
+	}
+}
+
+type routineInfo struct {
+	id int
+	// key is the full name for views, and the full name with the argument types for functions and procedures,
+	// because Snowflake supports overloading them.
+	key         string
+	routineType string
+	definition  string
+	// replaceDefinition is the CREATE OR REPLACE statement with the same body as the definition.
+	replaceDefinition string
+}
+
+func buildSchemaDefinition(statement string) (*schemaDefinition, error) {
+	schema := &schemaDefinition{
+		schemaMap:     make(schemaMap),
+		tableMap:      make(tableMap),
+		constraintMap: make(constraintMap),
+		viewMap:       make(routineMap),
+		procedureMap:  make(routineMap),
+		functionMap:   make(routineMap),
+	}
+	if strings.TrimSpace(statement) == "" {
+		return schema, nil
+	}
+	tree, err := parser.ParseSnowSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &buildSchemaDefinitionListener{
+		schema: schema,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	if listener.err != nil {
+		return nil, listener.err
+	}
+	return schema, nil
+}
+
+type buildSchemaDefinitionListener struct {
+	*snowparser.BaseSnowflakeParserListener
+
+	schema *schemaDefinition
+	// id is the definition order of the objects.
+	id  int
+	err error
+}
+
+func (l *buildSchemaDefinitionListener) nextID() int {
+	l.id++
+	return l.id
+}
+
+// EnterCreate_schema is called when production create_schema is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_schema(ctx *snowparser.Create_schemaContext) {
+	if l.err != nil {
+		return
+	}
+	ids := ctx.Schema_name().AllId_()
+	name := parser.NormalizeSnowSQLObjectNamePart(ids[len(ids)-1])
+	if _, ok := l.schema.schemaMap[name]; ok {
+		l.err = errors.Errorf("duplicate schema %q", name)
+		return
+	}
+	l.schema.schemaMap[name] = &schemaInfo{
+		id:         l.nextID(),
+		name:       name,
+		definition: getTextWithoutSemicolon(ctx),
+	}
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_table(ctx *snowparser.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+	table := &tableInfo{
+		id:         l.nextID(),
+		key:        fullName(ctx.Object_name()),
+		definition: getTextWithoutSemicolon(ctx),
+	}
+	if _, ok := l.schema.tableMap[table.key]; ok {
+		l.err = errors.Errorf("duplicate table %s", table.key)
+		return
+	}
+	l.schema.tableMap[table.key] = table
+
+	for _, item := range ctx.Column_decl_item_list().AllColumn_decl_item() {
+		switch {
+		case item.Full_col_decl() != nil:
+			l.addColumn(table, item.Full_col_decl())
+		case item.Out_of_line_constraint() != nil:
+			l.addOutOfLineConstraint(table, item.Out_of_line_constraint())
+		}
+	}
+}
+
+func (l *buildSchemaDefinitionListener) addColumn(table *tableInfo, ctx snowparser.IFull_col_declContext) {
+	column := &columnInfo{
+		name:     parser.NormalizeSnowSQLObjectNamePart(ctx.Col_decl().Column_name().Id_()),
+		dataType: getText(ctx.Col_decl().Data_type()),
+	}
+	table.columnList = append(table.columnList, column)
+
+	// Build the definition without the inline constraints, and keep the order of the other parts.
+	var parts []string
+	for _, child := range ctx.GetChildren() {
+		switch child := child.(type) {
+		case *snowparser.Inline_constraintContext:
+			if child.Null_not_null() != nil {
+				column.notNull = child.Null_not_null().NOT() != nil
+				parts = append(parts, getText(child.Null_not_null()))
+			}
+			l.addInlineConstraint(table, column, child)
+		case *snowparser.Null_not_nullContext:
+			column.notNull = child.NOT() != nil
+			parts = append(parts, getText(child))
+		case *snowparser.Default_valueContext:
+			column.defaultValue = getText(child)
+			parts = append(parts, column.defaultValue)
+		case *snowparser.StringContext:
+			column.comment = getText(child)
+			parts = append(parts, column.comment)
+		case antlr.ParserRuleContext:
+			parts = append(parts, getText(child))
+		case antlr.TerminalNode:
+			parts = append(parts, child.GetText())
+		}
+	}
+	column.definition = strings.Join(parts, " ")
+}
+
+func (l *buildSchemaDefinitionListener) addInlineConstraint(table *tableInfo, column *columnInfo, ctx snowparser.IInline_constraintContext) {
+	constraint := &constraintInfo{
+		tableKey: table.key,
+		columns:  fmt.Sprintf("(%s)", quoteIdentifier(column.name)),
+	}
+	if ctx.CONSTRAINT() != nil {
+		constraint.name = parser.NormalizeSnowSQLObjectNamePart(ctx.Id_())
+	}
+
+	var buf strings.Builder
+	if constraint.name != "" {
+		_, _ = fmt.Fprintf(&buf, "CONSTRAINT %s ", quoteIdentifier(constraint.name))
+	}
+	switch {
+	case ctx.UNIQUE() != nil:
+		constraint.constraintType = constraintTypeUnique
+		_, _ = fmt.Fprintf(&buf, "%s %s", constraint.constraintType, constraint.columns)
+	case ctx.PRIMARY() != nil:
+		constraint.constraintType = constraintTypePrimaryKey
+		_, _ = fmt.Fprintf(&buf, "%s %s", constraint.constraintType, constraint.columns)
+	default:
+		constraint.constraintType = constraintTypeForeignKey
+		_, _ = fmt.Fprintf(&buf, "%s %s REFERENCES %s", constraint.constraintType, constraint.columns, fullName(ctx.Object_name()))
+		if ctx.Column_name() != nil {
+			_, _ = fmt.Fprintf(&buf, " (%s)", quoteIdentifier(parser.NormalizeSnowSQLObjectNamePart(ctx.Column_name().Id_())))
+		}
+	}
+	if ctx.Constraint_properties() != nil {
+		_, _ = buf.WriteString(" ")
+		_, _ = buf.WriteString(getText(ctx.Constraint_properties()))
+	}
+	constraint.definition = buf.String()
+	l.addConstraint(constraint)
+}
+
+func (l *buildSchemaDefinitionListener) addOutOfLineConstraint(table *tableInfo, ctx snowparser.IOut_of_line_constraintContext) {
+	constraint := &constraintInfo{
+		tableKey:   table.key,
+		definition: getText(ctx),
+	}
+	if ctx.CONSTRAINT() != nil {
+		constraint.name = parser.NormalizeSnowSQLObjectNamePart(ctx.Id_())
+	}
+	switch {
+	case ctx.UNIQUE() != nil:
+		constraint.constraintType = constraintTypeUnique
+	case ctx.PRIMARY() != nil:
+		constraint.constraintType = constraintTypePrimaryKey
+	default:
+		constraint.constraintType = constraintTypeForeignKey
+	}
+	if columns := ctx.AllColumn_list_in_parentheses(); len(columns) > 0 {
+		var columnNames []string
+		for _, column := range columns[0].Column_list().AllColumn_name() {
+			columnNames = append(columnNames, quoteIdentifier(parser.NormalizeSnowSQLObjectNamePart(column.Id_())))
+		}
+		constraint.columns = fmt.Sprintf("(%s)", strings.Join(columnNames, ", "))
+	}
+	l.addConstraint(constraint)
+}
+
+func (l *buildSchemaDefinitionListener) addConstraint(constraint *constraintInfo) {
+	if _, ok := l.schema.constraintMap[constraint.key()]; ok {
+		l.err = errors.Errorf("duplicate constraint %q on table %s", constraint.key(), constraint.tableKey)
+		return
+	}
+	constraint.id = l.nextID()
+	l.schema.constraintMap[constraint.key()] = constraint
+}
+
+// EnterCreate_view is called when production create_view is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_view(ctx *snowparser.Create_viewContext) {
+	if l.err != nil {
+		return
+	}
+	l.addRoutine(l.schema.viewMap, "VIEW", fullName(ctx.Object_name()), ctx, ctx.Or_replace() != nil)
+}
+
+// EnterCreate_procedure is called when production create_procedure is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_procedure(ctx *snowparser.Create_procedureContext) {
+	if l.err != nil {
+		return
+	}
+	l.addRoutine(l.schema.procedureMap, "PROCEDURE", signature(ctx.Object_name(), ctx.AllArg_decl()), ctx, ctx.Or_replace() != nil)
+}
+
+// EnterCreate_function is called when production create_function is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_function(ctx *snowparser.Create_functionContext) {
+	if l.err != nil {
+		return
+	}
+	l.addRoutine(l.schema.functionMap, "FUNCTION", signature(ctx.Object_name(), ctx.AllArg_decl()), ctx, ctx.Or_replace() != nil)
+}
+
+func (l *buildSchemaDefinitionListener) addRoutine(m routineMap, routineType string, key string, ctx antlr.ParserRuleContext, orReplace bool) {
+	if _, ok := m[key]; ok {
+		l.err = errors.Errorf("duplicate %s %s", strings.ToLower(routineType), key)
+		return
+	}
+	routine := &routineInfo{
+		id:                l.nextID(),
+		key:               key,
+		routineType:       routineType,
+		definition:        getTextWithoutSemicolon(ctx),
+		replaceDefinition: getTextWithoutSemicolon(ctx),
+	}
+	if !orReplace {
+		// The definition starts with the CREATE keyword, insert OR REPLACE after it.
+		routine.replaceDefinition = "CREATE OR REPLACE" + routine.definition[len("CREATE"):]
+	}
+	m[key] = routine
+}
+
+func sortedSchemas(m schemaMap) []*schemaInfo {
+	var result []*schemaInfo
+	for _, schema := range m {
+		result = append(result, schema)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func sortedTables(m tableMap) []*tableInfo {
+	var result []*tableInfo
+	for _, table := range m {
+		result = append(result, table)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func sortedConstraints(m constraintMap) []*constraintInfo {
+	var result []*constraintInfo
+	for _, constraint := range m {
+		result = append(result, constraint)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func sortedRoutines(m routineMap) []*routineInfo {
+	var result []*routineInfo
+	for _, routine := range m {
+		result = append(result, routine)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+// fullName returns the quoted schema and object name, the database name is ignored.
+func fullName(objectName snowparser.IObject_nameContext) string {
+	schemaName := defaultSchemaName
+	if s := objectName.GetS(); s != nil {
+		schemaName = parser.NormalizeSnowSQLObjectNamePart(s)
+	}
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(parser.NormalizeSnowSQLObjectNamePart(objectName.GetO())))
+}
+
+// signature returns the full name with the argument types, such as "PUBLIC"."F"(NUMBER, VARCHAR).
+func signature(objectName snowparser.IObject_nameContext, args []snowparser.IArg_declContext) string {
+	var argTypes []string
+	for _, arg := range args {
+		argTypes = append(argTypes, strings.ToUpper(getText(arg.Arg_data_type())))
+	}
+	return fmt.Sprintf("%s(%s)", fullName(objectName), strings.Join(argTypes, ", "))
+}
+
+func getText(ctx antlr.ParserRuleContext) string {
+	if ctx == nil || ctx.GetStart() == nil || ctx.GetStop() == nil || ctx.GetStop().GetTokenIndex() < ctx.GetStart().GetTokenIndex() {
+		return ""
+	}
+	return getTextFromTokens(ctx.GetStart(), ctx.GetStop())
+}
+
+// getTextFromTokens returns the original text between the tokens, including the whitespaces skipped by the lexer.
+func getTextFromTokens(start, stop antlr.Token) string {
+	return start.GetInputStream().GetText(start.GetStart(), stop.GetStop())
+}
+
+func getTextWithoutSemicolon(ctx antlr.ParserRuleContext) string {
+	return strings.TrimRight(getText(ctx), "; \t\r\n")
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
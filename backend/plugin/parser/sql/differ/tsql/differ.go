@@ -0,0 +1,1101 @@
+// Package tsql provides the T-SQL differ plugin.
+package tsql
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antlr4-go/antlr/v4"
+	"github.com/pkg/errors"
+
+	tsqlparser "github.com/bytebase/tsql-parser"
+
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/plugin/parser/sql/differ"
+)
+
+const (
+	defaultSchemaName = "dbo"
+	batchSeparator    = "GO\n"
+)
+
+var (
+	_ differ.SchemaDiffer = (*SchemaDiffer)(nil)
+)
+
+func init() {
+	differ.Register(parser.MSSQL, &SchemaDiffer{})
+}
+
+// SchemaDiffer is the schema differ for T-SQL.
+type SchemaDiffer struct {
+}
+
+// diffNode defines different modification types as the safe change order.
+// The safe change order means we can change them with no dependency conflicts as this order.
+type diffNode struct {
+	// Drop nodes
+	dropTriggerList               []string
+	dropViewList                  []string
+	dropForeignKeyList            []string
+	dropUnnamedForeignKeyList     []string
+	dropConstraintExceptFk        []string
+	dropUnnamedConstraintExceptFk []string
+	dropIndexList                 []string
+	dropColumnList                []string
+	dropTableList                 []string
+	dropProcedureList             []string
+	dropFunctionList              []string
+	dropSchemaList                []string
+
+	// Create nodes
+	createSchemaList        []string
+	createFunctionList      []string
+	createProcedureList     []string
+	createTableList         []string
+	addColumnList           []string
+	alterColumnList         []string
+	addConstraintExceptFk   []string
+	createIndexList         []string
+	addForeignKeyList       []string
+	createViewList          []string
+	createTriggerList       []string
+	alterRoutineAndViewList []string
+}
+
+// SchemaDiff implements the differ.SchemaDiffer interface.
+func (*SchemaDiffer) SchemaDiff(oldStmt, newStmt string, _ bool) (string, error) {
+	oldSchema, err := buildSchemaDefinition(oldStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema definition for old statement")
+	}
+	newSchema, err := buildSchemaDefinition(newStmt)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to build schema definition for new statement")
+	}
+
+	diff := &diffNode{}
+	diff.diffSchema(oldSchema, newSchema)
+	droppedTables, addedColumns, recreatedColumns := diff.diffTable(oldSchema, newSchema)
+	diff.diffConstraint(oldSchema, newSchema, droppedTables, addedColumns, recreatedColumns)
+	diff.diffIndex(oldSchema, newSchema, droppedTables, addedColumns, recreatedColumns)
+	diff.diffRoutine(oldSchema.viewMap, newSchema.viewMap, &diff.dropViewList, &diff.createViewList)
+	diff.diffRoutine(oldSchema.procedureMap, newSchema.procedureMap, &diff.dropProcedureList, &diff.createProcedureList)
+	diff.diffRoutine(oldSchema.functionMap, newSchema.functionMap, &diff.dropFunctionList, &diff.createFunctionList)
+	diff.diffRoutine(oldSchema.triggerMap, newSchema.triggerMap, &diff.dropTriggerList, &diff.createTriggerList)
+
+	return diff.deparse()
+}
+
+func (diff *diffNode) diffSchema(oldSchema, newSchema *schemaDefinition) {
+	for _, schema := range sortedSchemas(newSchema.schemaMap) {
+		if _, ok := oldSchema.schemaMap[schema.key]; !ok {
+			diff.createSchemaList = append(diff.createSchemaList, schema.definition+";")
+		}
+	}
+	for _, schema := range sortedSchemas(oldSchema.schemaMap) {
+		if _, ok := newSchema.schemaMap[schema.key]; !ok {
+			diff.dropSchemaList = append(diff.dropSchemaList, fmt.Sprintf("DROP SCHEMA %s;", quoteIdentifier(schema.name)))
+		}
+	}
+}
+
+// diffTable diffs the tables and their columns, it returns the keys of the dropped tables, and the keys
+// of the added and recreated columns in format "schema.table.column", so that the following constraint and
+// index diffs could skip the objects that are already handled by the table and column statements.
+func (diff *diffNode) diffTable(oldSchema, newSchema *schemaDefinition) (map[string]bool, map[string]bool, map[string]bool) {
+	droppedTables := make(map[string]bool)
+	addedColumns := make(map[string]bool)
+	recreatedColumns := make(map[string]bool)
+
+	for _, newTable := range sortedTables(newSchema.tableMap) {
+		oldTable, ok := oldSchema.tableMap[newTable.key]
+		if !ok {
+			diff.createTableList = append(diff.createTableList, newTable.definition+";")
+			continue
+		}
+		diff.diffColumn(oldTable, newTable, addedColumns, recreatedColumns)
+	}
+	for _, oldTable := range sortedTables(oldSchema.tableMap) {
+		if _, ok := newSchema.tableMap[oldTable.key]; !ok {
+			droppedTables[oldTable.key] = true
+			diff.dropTableList = append(diff.dropTableList, fmt.Sprintf("DROP TABLE %s;", oldTable.fullName()))
+		}
+	}
+	return droppedTables, addedColumns, recreatedColumns
+}
+
+func (diff *diffNode) diffColumn(oldTable, newTable *tableInfo, addedColumns, recreatedColumns map[string]bool) {
+	oldColumnMap := make(map[string]*columnInfo)
+	for _, column := range oldTable.columnList {
+		oldColumnMap[column.key] = column
+	}
+	newColumnMap := make(map[string]*columnInfo)
+	for _, column := range newTable.columnList {
+		newColumnMap[column.key] = column
+	}
+
+	var dropColumns []string
+	for _, oldColumn := range oldTable.columnList {
+		newColumn, ok := newColumnMap[oldColumn.key]
+		if !ok || isColumnRecreated(oldColumn, newColumn) {
+			dropColumns = append(dropColumns, quoteIdentifier(oldColumn.name))
+		}
+	}
+	if len(dropColumns) > 0 {
+		diff.dropColumnList = append(diff.dropColumnList, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", oldTable.fullName(), strings.Join(dropColumns, ", ")))
+	}
+
+	for _, newColumn := range newTable.columnList {
+		columnKey := fmt.Sprintf("%s.%s", newTable.key, newColumn.key)
+		oldColumn, ok := oldColumnMap[newColumn.key]
+		if !ok || isColumnRecreated(oldColumn, newColumn) {
+			if ok {
+				recreatedColumns[columnKey] = true
+			} else {
+				addedColumns[columnKey] = true
+			}
+			diff.addColumnList = append(diff.addColumnList, fmt.Sprintf("ALTER TABLE %s ADD %s;", newTable.fullName(), newColumn.definition))
+			continue
+		}
+		if oldColumn.dataType != newColumn.dataType || oldColumn.collation != newColumn.collation || oldColumn.nullable != newColumn.nullable {
+			diff.alterColumnList = append(diff.alterColumnList, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s;", newTable.fullName(), newColumn.alterDefinition()))
+		}
+	}
+}
+
+// isColumnRecreated returns true if the column must be dropped and added again,
+// because SQL Server cannot alter the identity property and the computed column expression.
+func isColumnRecreated(oldColumn, newColumn *columnInfo) bool {
+	return oldColumn.computed != newColumn.computed || oldColumn.identity != newColumn.identity
+}
+
+func (diff *diffNode) diffConstraint(oldSchema, newSchema *schemaDefinition, droppedTables, addedColumns, recreatedColumns map[string]bool) {
+	for _, oldConstraint := range sortedConstraints(oldSchema.constraintMap) {
+		if droppedTables[oldConstraint.tableKey] {
+			// The constraint is dropped with the table.
+			continue
+		}
+		newConstraint, ok := newSchema.constraintMap[oldConstraint.key()]
+		if ok && newConstraint.definition == oldConstraint.definition && !recreatedColumns[oldConstraint.columnKey()] {
+			continue
+		}
+		if oldConstraint.name == "" {
+			// The unnamed constraint gets a system generated name, so we look it up in the catalog by its type and columns.
+			stmt := oldConstraint.dropUnnamedStatement()
+			if oldConstraint.foreignKey {
+				diff.dropUnnamedForeignKeyList = append(diff.dropUnnamedForeignKeyList, stmt)
+			} else {
+				diff.dropUnnamedConstraintExceptFk = append(diff.dropUnnamedConstraintExceptFk, stmt)
+			}
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", oldConstraint.tableName, quoteIdentifier(oldConstraint.name))
+		if oldConstraint.foreignKey {
+			diff.dropForeignKeyList = append(diff.dropForeignKeyList, stmt)
+		} else {
+			diff.dropConstraintExceptFk = append(diff.dropConstraintExceptFk, stmt)
+		}
+	}
+
+	for _, newConstraint := range sortedConstraints(newSchema.constraintMap) {
+		if _, ok := oldSchema.tableMap[newConstraint.tableKey]; !ok && newConstraint.inline {
+			// The constraint is created with the table.
+			continue
+		}
+		if newConstraint.inline && newConstraint.column != "" && (addedColumns[newConstraint.columnKey()] || recreatedColumns[newConstraint.columnKey()]) {
+			// The constraint is created with the column.
+			continue
+		}
+		oldConstraint, ok := oldSchema.constraintMap[newConstraint.key()]
+		if ok && oldConstraint.definition == newConstraint.definition && !recreatedColumns[newConstraint.columnKey()] {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD %s;", newConstraint.tableName, newConstraint.definition)
+		if newConstraint.foreignKey {
+			diff.addForeignKeyList = append(diff.addForeignKeyList, stmt)
+		} else {
+			diff.addConstraintExceptFk = append(diff.addConstraintExceptFk, stmt)
+		}
+	}
+}
+
+func (diff *diffNode) diffIndex(oldSchema, newSchema *schemaDefinition, droppedTables, addedColumns, recreatedColumns map[string]bool) {
+	for _, oldIndex := range sortedIndexes(oldSchema.indexMap) {
+		if droppedTables[oldIndex.tableKey] {
+			// The index is dropped with the table.
+			continue
+		}
+		newIndex, ok := newSchema.indexMap[oldIndex.key()]
+		if ok && newIndex.definition == oldIndex.definition && !recreatedColumns[oldIndex.columnKey()] {
+			continue
+		}
+		diff.dropIndexList = append(diff.dropIndexList, fmt.Sprintf("DROP INDEX %s ON %s;", quoteIdentifier(oldIndex.name), oldIndex.tableName))
+	}
+
+	for _, newIndex := range sortedIndexes(newSchema.indexMap) {
+		if _, ok := oldSchema.tableMap[newIndex.tableKey]; !ok && newIndex.inline {
+			// The index is created with the table.
+			continue
+		}
+		if newIndex.inline && newIndex.column != "" && (addedColumns[newIndex.columnKey()] || recreatedColumns[newIndex.columnKey()]) {
+			// The index is created with the column.
+			continue
+		}
+		oldIndex, ok := oldSchema.indexMap[newIndex.key()]
+		if ok && oldIndex.definition == newIndex.definition && !recreatedColumns[newIndex.columnKey()] {
+			continue
+		}
+		diff.createIndexList = append(diff.createIndexList, newIndex.definition+";")
+	}
+}
+
+// diffRoutine diffs the views, procedures, functions or triggers. The changed ones are altered in place to keep the permissions.
+func (diff *diffNode) diffRoutine(oldMap, newMap routineMap, dropList *[]string, createList *[]string) {
+	oldRoutines := sortedRoutines(oldMap)
+	// Drop in the reverse order of the definition, so that the dependent ones are dropped first.
+	for i := len(oldRoutines) - 1; i >= 0; i-- {
+		if _, ok := newMap[oldRoutines[i].key]; !ok {
+			*dropList = append(*dropList, fmt.Sprintf("DROP %s %s;", oldRoutines[i].routineType, oldRoutines[i].fullName()))
+		}
+	}
+	for _, newRoutine := range sortedRoutines(newMap) {
+		oldRoutine, ok := oldMap[newRoutine.key]
+		if !ok {
+			*createList = append(*createList, newRoutine.definition+";")
+			continue
+		}
+		if oldRoutine.definition != newRoutine.definition {
+			diff.alterRoutineAndViewList = append(diff.alterRoutineAndViewList, newRoutine.alterDefinition+";")
+		}
+	}
+}
+
+// deparse statements as the safe change order.
+func (diff *diffNode) deparse() (string, error) {
+	var buf bytes.Buffer
+	for _, list := range []struct {
+		stmtList []string
+		// batch is true if each statement must be the only statement in its batch.
+		batch bool
+	}{
+		// drop
+		{stmtList: diff.dropTriggerList},
+		{stmtList: diff.dropViewList},
+		{stmtList: diff.dropForeignKeyList},
+		{stmtList: diff.dropUnnamedForeignKeyList, batch: true},
+		{stmtList: diff.dropConstraintExceptFk},
+		{stmtList: diff.dropUnnamedConstraintExceptFk, batch: true},
+		{stmtList: diff.dropIndexList},
+		{stmtList: diff.dropColumnList},
+		{stmtList: diff.dropTableList},
+		{stmtList: diff.dropProcedureList},
+		{stmtList: diff.dropFunctionList},
+		{stmtList: diff.dropSchemaList},
+		// create
+		{stmtList: diff.createSchemaList, batch: true},
+		// T-SQL resolves the names in function and procedure bodies when executing them,
+		// so create them before the tables which may reference them in computed columns or constraints.
+		{stmtList: diff.createFunctionList, batch: true},
+		{stmtList: diff.createProcedureList, batch: true},
+		{stmtList: diff.createTableList},
+		{stmtList: diff.addColumnList},
+		{stmtList: diff.alterColumnList},
+		{stmtList: diff.addConstraintExceptFk},
+		{stmtList: diff.createIndexList},
+		{stmtList: diff.addForeignKeyList},
+		{stmtList: diff.createViewList, batch: true},
+		{stmtList: diff.createTriggerList, batch: true},
+		{stmtList: diff.alterRoutineAndViewList, batch: true},
+	} {
+		if err := printStmtSlice(&buf, list.stmtList, list.batch); err != nil {
+			return "", err
+		}
+	}
+	return buf.String(), nil
+}
+
+// printStmtSlice prints the statements, the statements are separated by GO into their own batches if batch is true,
+// because CREATE SCHEMA, CREATE VIEW, CREATE PROCEDURE and so on must be the only statement in the batch.
+func printStmtSlice(buf *bytes.Buffer, stmtList []string, batch bool) error {
+	for _, stmt := range stmtList {
+		if batch && buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte(batchSeparator)) {
+			if _, err := buf.WriteString(batchSeparator); err != nil {
+				return err
+			}
+		}
+		if _, err := buf.WriteString(stmt); err != nil {
+			return err
+		}
+		if _, err := buf.WriteString("\n"); err != nil {
+			return err
+		}
+		if batch {
+			if _, err := buf.WriteString(batchSeparator); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type schemaMap map[string]*schemaInfo
+type tableMap map[string]*tableInfo
+type constraintMap map[string]*constraintInfo
+type indexMap map[string]*indexInfo
+type routineMap map[string]*routineInfo
+
+// schemaDefinition is the definition of the objects in the statements.
+// All the map keys are the normalized names.
+type schemaDefinition struct {
+	schemaMap     schemaMap
+	tableMap      tableMap
+	constraintMap constraintMap
+	indexMap      indexMap
+	viewMap       routineMap
+	procedureMap  routineMap
+	functionMap   routineMap
+	triggerMap    routineMap
+}
+
+type schemaInfo struct {
+	id         int
+	key        string
+	name       string
+	definition string
+}
+
+type tableInfo struct {
+	id         int
+	key        string
+	schemaName string
+	name       string
+	definition string
+	columnList []*columnInfo
+}
+
+func (t *tableInfo) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(t.schemaName), quoteIdentifier(t.name))
+}
+
+type columnInfo struct {
+	key        string
+	name       string
+	definition string
+	dataType   string
+	collation  string
+	nullable   string
+	identity   string
+	computed   string
+}
+
+func (c *columnInfo) alterDefinition() string {
+	var buf strings.Builder
+	_, _ = buf.WriteString(quoteIdentifier(c.name))
+	_, _ = buf.WriteString(" ")
+	_, _ = buf.WriteString(c.dataType)
+	if c.collation != "" {
+		_, _ = buf.WriteString(" COLLATE ")
+		_, _ = buf.WriteString(c.collation)
+	}
+	if c.nullable != "" {
+		_, _ = buf.WriteString(" ")
+		_, _ = buf.WriteString(c.nullable)
+	} else {
+		// The column is nullable by default, we should specify it explicitly to drop the NOT NULL.
+		_, _ = buf.WriteString(" NULL")
+	}
+	return buf.String()
+}
+
+type constraintInfo struct {
+	id        int
+	tableKey  string
+	tableName string
+	// name is empty for the unnamed constraints.
+	name string
+	// column is the normalized column name for the constraints declared in the column definition.
+	column     string
+	inline     bool
+	foreignKey bool
+	// definition is the constraint definition used in ALTER TABLE ADD statement.
+	definition string
+	// constraintType is the object type in sys.objects, i.e. PK, UQ, F, C or D.
+	// constraintType and columns are used to look up the system generated name of the unnamed constraints.
+	constraintType string
+	// columns are the constrained columns, empty for the table level CHECK constraints.
+	columns []string
+}
+
+func (c *constraintInfo) key() string {
+	if c.name == "" {
+		// Use the definition as the identity of the unnamed constraints.
+		return fmt.Sprintf("%s.%s", c.tableKey, c.definition)
+	}
+	return fmt.Sprintf("%s.%s", c.tableKey, strings.ToLower(c.name))
+}
+
+func (c *constraintInfo) columnKey() string {
+	return fmt.Sprintf("%s.%s", c.tableKey, c.column)
+}
+
+// dropUnnamedStatement returns the batch to drop the unnamed constraint.
+// It looks up the system generated name in the catalog and fails if the constraint cannot be determined uniquely.
+func (c *constraintInfo) dropUnnamedStatement() string {
+	var catalog, condition string
+	switch c.constraintType {
+	case "PK", "UQ":
+		catalog = "sys.key_constraints"
+		condition = fmt.Sprintf("o.type = '%s'", c.constraintType)
+		if c.constraintType == "UQ" {
+			condition += " AND " + columnsCondition(
+				"SELECT c.name FROM sys.index_columns ic JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id WHERE ic.object_id = o.parent_object_id AND ic.index_id = o.unique_index_id AND ic.is_included_column = 0",
+				c.columns,
+			)
+		}
+	case "F":
+		catalog = "sys.foreign_keys"
+		condition = columnsCondition(
+			"SELECT c.name FROM sys.foreign_key_columns fkc JOIN sys.columns c ON c.object_id = fkc.parent_object_id AND c.column_id = fkc.parent_column_id WHERE fkc.constraint_object_id = o.object_id",
+			c.columns,
+		)
+	case "C":
+		catalog = "sys.check_constraints"
+		condition = "o.parent_column_id = 0"
+		if len(c.columns) == 1 {
+			condition = fmt.Sprintf("o.parent_column_id = COLUMNPROPERTY(o.parent_object_id, %s, 'ColumnId')", quoteString(c.columns[0]))
+		}
+	default:
+		catalog = "sys.default_constraints"
+		condition = fmt.Sprintf("o.parent_column_id = COLUMNPROPERTY(o.parent_object_id, %s, 'ColumnId')", quoteString(strings.Join(c.columns, "")))
+	}
+	var buf strings.Builder
+	_, _ = buf.WriteString("DECLARE @name sysname, @count INT, @sql NVARCHAR(MAX);\n")
+	_, _ = fmt.Fprintf(&buf, "SELECT @name = MIN(o.name), @count = COUNT(*) FROM %s o WHERE o.parent_object_id = OBJECT_ID(%s) AND o.is_system_named = 1 AND %s;\n", catalog, quoteString(c.tableName), condition)
+	_, _ = fmt.Fprintf(&buf, "IF @count <> 1 THROW 50000, %s, 1;\n", quoteString(fmt.Sprintf("cannot determine the system generated name of the constraint %q on table %s", c.definition, c.tableName)))
+	_, _ = fmt.Fprintf(&buf, "SET @sql = %s + QUOTENAME(@name);\n", quoteString(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT ", c.tableName)))
+	_, _ = buf.WriteString("EXEC sp_executesql @sql;")
+	return buf.String()
+}
+
+// columnsCondition returns the condition that the column names returned by the query are exactly the columns.
+func columnsCondition(query string, columns []string) string {
+	var quoted []string
+	for _, column := range columns {
+		quoted = append(quoted, quoteString(column))
+	}
+	return fmt.Sprintf("(SELECT COUNT(*) FROM (%s) AS t) = %d AND NOT EXISTS (SELECT 1 FROM (%s) AS t WHERE t.name NOT IN (%s))", query, len(columns), query, strings.Join(quoted, ", "))
+}
+
+type indexInfo struct {
+	id        int
+	tableKey  string
+	tableName string
+	name      string
+	// column is the normalized column name for the indexes declared in the column definition.
+	column string
+	inline bool
+	// definition is the CREATE INDEX statement.
+	definition string
+}
+
+func (i *indexInfo) key() string {
+	return fmt.Sprintf("%s.%s", i.tableKey, strings.ToLower(i.name))
+}
+
+func (i *indexInfo) columnKey() string {
+	return fmt.Sprintf("%s.%s", i.tableKey, i.column)
+}
+
+type routineInfo struct {
+	id          int
+	key         string
+	schemaName  string
+	name        string
+	routineType string
+	definition  string
+	// alterDefinition is the ALTER statement with the same body as the definition.
+	alterDefinition string
+}
+
+func (r *routineInfo) fullName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(r.schemaName), quoteIdentifier(r.name))
+}
+
+func buildSchemaDefinition(statement string) (*schemaDefinition, error) {
+	schema := &schemaDefinition{
+		schemaMap:     make(schemaMap),
+		tableMap:      make(tableMap),
+		constraintMap: make(constraintMap),
+		indexMap:      make(indexMap),
+		viewMap:       make(routineMap),
+		procedureMap:  make(routineMap),
+		functionMap:   make(routineMap),
+		triggerMap:    make(routineMap),
+	}
+	if strings.TrimSpace(statement) == "" {
+		return schema, nil
+	}
+	tree, err := parser.ParseTSQL(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := &buildSchemaDefinitionListener{
+		schema: schema,
+	}
+	antlr.ParseTreeWalkerDefault.Walk(listener, tree)
+	if listener.err != nil {
+		return nil, listener.err
+	}
+	return schema, nil
+}
+
+type buildSchemaDefinitionListener struct {
+	*tsqlparser.BaseTSqlParserListener
+
+	schema *schemaDefinition
+	// id is the definition order of the objects.
+	id  int
+	err error
+}
+
+func (l *buildSchemaDefinitionListener) nextID() int {
+	l.id++
+	return l.id
+}
+
+// EnterCreate_schema is called when production create_schema is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_schema(ctx *tsqlparser.Create_schemaContext) {
+	if l.err != nil {
+		return
+	}
+	ids := ctx.AllId_()
+	// CREATE SCHEMA AUTHORIZATION owner uses the owner name as the schema name.
+	schemaName := ids[0]
+	// Only take the CREATE SCHEMA part, the nested statements are handled by their own listeners.
+	stop := ids[0].GetStop()
+	if ctx.GetSchema_name() != nil && ctx.AUTHORIZATION() != nil {
+		stop = ids[1].GetStop()
+	}
+	key := normalizeIdentifier(schemaName)
+	if _, ok := l.schema.schemaMap[key]; ok {
+		l.err = errors.Errorf("duplicate schema %q", key)
+		return
+	}
+	l.schema.schemaMap[key] = &schemaInfo{
+		id:         l.nextID(),
+		key:        key,
+		name:       identifierText(schemaName),
+		definition: getTextFromTokens(ctx.GetStart(), stop),
+	}
+}
+
+// EnterCreate_table is called when production create_table is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_table(ctx *tsqlparser.Create_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName := defaultSchemaName
+	if schema := ctx.Table_name().GetSchema(); schema != nil {
+		schemaName = identifierText(schema)
+	} else if createSchema, ok := ctx.GetParent().(*tsqlparser.Create_schemaContext); ok && createSchema.GetSchema_name() != nil {
+		schemaName = identifierText(createSchema.GetSchema_name())
+	}
+	table := &tableInfo{
+		id:         l.nextID(),
+		schemaName: schemaName,
+		name:       identifierText(ctx.Table_name().GetTable()),
+		definition: getTextWithoutSemicolon(ctx),
+	}
+	table.key = fmt.Sprintf("%s.%s", strings.ToLower(table.schemaName), strings.ToLower(table.name))
+	if _, ok := l.schema.tableMap[table.key]; ok {
+		l.err = errors.Errorf("duplicate table %q", table.key)
+		return
+	}
+	l.schema.tableMap[table.key] = table
+
+	for _, item := range ctx.Column_def_table_constraints().AllColumn_def_table_constraint() {
+		switch {
+		case item.Column_definition() != nil:
+			l.addColumn(table, item.Column_definition())
+		case item.Materialized_column_definition() != nil:
+			column := item.Materialized_column_definition()
+			table.columnList = append(table.columnList, &columnInfo{
+				key:        normalizeIdentifier(column.Id_()),
+				name:       identifierText(column.Id_()),
+				definition: getText(column),
+				computed:   getText(column.Expression()),
+			})
+		case item.Table_constraint() != nil:
+			l.addTableConstraint(table.key, table.fullName(), true /* inline */, item.Table_constraint())
+		}
+	}
+	for _, index := range ctx.AllTable_indices() {
+		l.addTableIndex(table, index)
+	}
+}
+
+func (l *buildSchemaDefinitionListener) addColumn(table *tableInfo, ctx tsqlparser.IColumn_definitionContext) {
+	column := &columnInfo{
+		key:        normalizeIdentifier(ctx.Id_()),
+		name:       identifierText(ctx.Id_()),
+		definition: getText(ctx),
+	}
+	table.columnList = append(table.columnList, column)
+	quotedColumnName := quoteIdentifier(column.name)
+
+	if dataType := ctx.Data_type(); dataType != nil {
+		if identity := dataType.IDENTITY(); identity != nil {
+			// The data type is in the form of "INT IDENTITY(1, 1)".
+			column.dataType = getText(dataType.GetExt_type())
+			column.identity = getTextFromTokens(identity.GetSymbol(), dataType.GetStop())
+		} else {
+			column.dataType = getText(dataType)
+		}
+	} else {
+		column.computed = getText(ctx.Expression())
+	}
+
+	for _, element := range ctx.AllColumn_definition_element() {
+		switch {
+		case element.COLLATE() != nil:
+			column.collation = getText(element.GetCollation_name())
+		case element.DEFAULT() != nil:
+			var buf strings.Builder
+			name := ""
+			if element.GetConstraint() != nil {
+				name = identifierText(element.GetConstraint())
+				_, _ = fmt.Fprintf(&buf, "CONSTRAINT %s ", quoteIdentifier(name))
+			}
+			_, _ = fmt.Fprintf(&buf, "DEFAULT %s FOR %s", getText(element.GetConstant_expr()), quotedColumnName)
+			l.addConstraint(&constraintInfo{
+				tableKey:       table.key,
+				tableName:      table.fullName(),
+				name:           name,
+				column:         column.key,
+				inline:         true,
+				definition:     buf.String(),
+				constraintType: "D",
+				columns:        []string{column.name},
+			})
+		case element.IDENTITY() != nil:
+			column.identity = getText(element)
+		case element.Column_constraint() != nil:
+			constraint := element.Column_constraint()
+			if constraint.Null_notnull() != nil {
+				column.nullable = strings.ToUpper(getText(constraint.Null_notnull()))
+				if constraint.Null_notnull().NOT() != nil {
+					column.nullable = "NOT NULL"
+				}
+				continue
+			}
+			l.addColumnConstraint(table, column, constraint)
+		}
+	}
+
+	if columnIndex := ctx.Column_index(); columnIndex != nil {
+		var buf strings.Builder
+		_, _ = buf.WriteString("CREATE ")
+		if columnIndex.Clustered() != nil {
+			_, _ = buf.WriteString(strings.ToUpper(getText(columnIndex.Clustered())))
+			_, _ = buf.WriteString(" ")
+		}
+		name := identifierText(columnIndex.GetIndex_name())
+		_, _ = fmt.Fprintf(&buf, "INDEX %s ON %s (%s)", quoteIdentifier(name), table.fullName(), quotedColumnName)
+		l.addIndex(&indexInfo{
+			tableKey:   table.key,
+			tableName:  table.fullName(),
+			name:       name,
+			column:     column.key,
+			inline:     true,
+			definition: buf.String(),
+		})
+	}
+}
+
+func (l *buildSchemaDefinitionListener) addColumnConstraint(table *tableInfo, column *columnInfo, ctx tsqlparser.IColumn_constraintContext) {
+	var buf strings.Builder
+	name := ""
+	if ctx.GetConstraint() != nil {
+		name = identifierText(ctx.GetConstraint())
+		_, _ = fmt.Fprintf(&buf, "CONSTRAINT %s ", quoteIdentifier(name))
+	}
+	foreignKey := false
+	constraintType := ""
+	switch {
+	case ctx.PRIMARY() != nil || ctx.UNIQUE() != nil:
+		if ctx.PRIMARY() != nil {
+			constraintType = "PK"
+			_, _ = buf.WriteString("PRIMARY KEY ")
+		} else {
+			constraintType = "UQ"
+			_, _ = buf.WriteString("UNIQUE ")
+		}
+		if ctx.Clustered() != nil {
+			_, _ = buf.WriteString(strings.ToUpper(getText(ctx.Clustered())))
+			_, _ = buf.WriteString(" ")
+		}
+		_, _ = fmt.Fprintf(&buf, "(%s)", quoteIdentifier(column.name))
+		if options := getText(ctx.Primary_key_options()); options != "" {
+			_, _ = buf.WriteString(" ")
+			_, _ = buf.WriteString(options)
+		}
+	case ctx.Foreign_key_options() != nil:
+		foreignKey = true
+		constraintType = "F"
+		_, _ = fmt.Fprintf(&buf, "FOREIGN KEY (%s) %s", quoteIdentifier(column.name), getText(ctx.Foreign_key_options()))
+	case ctx.Check_constraint() != nil:
+		constraintType = "C"
+		_, _ = buf.WriteString(getText(ctx.Check_constraint()))
+	default:
+		return
+	}
+	l.addConstraint(&constraintInfo{
+		tableKey:       table.key,
+		tableName:      table.fullName(),
+		name:           name,
+		column:         column.key,
+		inline:         true,
+		foreignKey:     foreignKey,
+		definition:     buf.String(),
+		constraintType: constraintType,
+		columns:        []string{column.name},
+	})
+}
+
+func (l *buildSchemaDefinitionListener) addTableConstraint(tableKey string, tableName string, inline bool, ctx tsqlparser.ITable_constraintContext) {
+	if ctx.CONNECTION() != nil {
+		// Skip the graph edge constraints.
+		return
+	}
+	constraint := &constraintInfo{
+		tableKey:   tableKey,
+		tableName:  tableName,
+		inline:     inline,
+		foreignKey: ctx.FOREIGN() != nil,
+		definition: getText(ctx),
+	}
+	if ctx.GetConstraint() != nil {
+		constraint.name = identifierText(ctx.GetConstraint())
+	}
+	switch {
+	case ctx.PRIMARY() != nil || ctx.UNIQUE() != nil:
+		constraint.constraintType = "UQ"
+		if ctx.PRIMARY() != nil {
+			constraint.constraintType = "PK"
+		}
+		if columnList := ctx.Column_name_list_with_order(); columnList != nil {
+			for _, column := range columnList.AllId_() {
+				constraint.columns = append(constraint.columns, identifierText(column))
+			}
+		}
+	case ctx.FOREIGN() != nil:
+		constraint.constraintType = "F"
+		if fk := ctx.GetFk(); fk != nil {
+			for _, column := range fk.AllId_() {
+				constraint.columns = append(constraint.columns, identifierText(column))
+			}
+		}
+	case ctx.DEFAULT() != nil:
+		constraint.constraintType = "D"
+		constraint.column = normalizeIdentifier(ctx.GetColumn())
+		constraint.columns = []string{identifierText(ctx.GetColumn())}
+	default:
+		constraint.constraintType = "C"
+	}
+	l.addConstraint(constraint)
+}
+
+func (l *buildSchemaDefinitionListener) addConstraint(constraint *constraintInfo) {
+	if _, ok := l.schema.constraintMap[constraint.key()]; ok {
+		if constraint.name == "" {
+			// The same unnamed constraints are treated as one.
+			return
+		}
+		l.err = errors.Errorf("duplicate constraint %q on table %q", constraint.name, constraint.tableKey)
+		return
+	}
+	constraint.id = l.nextID()
+	l.schema.constraintMap[constraint.key()] = constraint
+}
+
+func (l *buildSchemaDefinitionListener) addTableIndex(table *tableInfo, ctx tsqlparser.ITable_indicesContext) {
+	var buf strings.Builder
+	_, _ = buf.WriteString("CREATE ")
+	if ctx.UNIQUE() != nil {
+		_, _ = buf.WriteString("UNIQUE ")
+	}
+	switch {
+	case ctx.Clustered() != nil:
+		_, _ = buf.WriteString(strings.ToUpper(getText(ctx.Clustered())))
+		_, _ = buf.WriteString(" ")
+	case ctx.CLUSTERED() != nil:
+		_, _ = buf.WriteString("CLUSTERED ")
+	case ctx.NONCLUSTERED() != nil:
+		_, _ = buf.WriteString("NONCLUSTERED ")
+	}
+	if ctx.COLUMNSTORE() != nil {
+		_, _ = buf.WriteString("COLUMNSTORE ")
+	}
+	name := identifierText(ctx.Id_(0))
+	_, _ = fmt.Fprintf(&buf, "INDEX %s ON %s", quoteIdentifier(name), table.fullName())
+	switch {
+	case ctx.Column_name_list_with_order() != nil:
+		_, _ = fmt.Fprintf(&buf, " (%s)", getText(ctx.Column_name_list_with_order()))
+	case ctx.Column_name_list() != nil:
+		_, _ = fmt.Fprintf(&buf, " (%s)", getText(ctx.Column_name_list()))
+	}
+	l.addIndex(&indexInfo{
+		tableKey:   table.key,
+		tableName:  table.fullName(),
+		name:       name,
+		inline:     true,
+		definition: buf.String(),
+	})
+}
+
+func (l *buildSchemaDefinitionListener) addIndex(index *indexInfo) {
+	if _, ok := l.schema.indexMap[index.key()]; ok {
+		l.err = errors.Errorf("duplicate index %q on table %q", index.name, index.tableKey)
+		return
+	}
+	index.id = l.nextID()
+	l.schema.indexMap[index.key()] = index
+}
+
+// EnterAlter_table is called when production alter_table is entered.
+// The schema dump may add the constraints by ALTER TABLE statements, so we collect them as the table constraints.
+func (l *buildSchemaDefinitionListener) EnterAlter_table(ctx *tsqlparser.Alter_tableContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName, tableName := defaultSchemaName, identifierText(ctx.Table_name(0).GetTable())
+	if schema := ctx.Table_name(0).GetSchema(); schema != nil {
+		schemaName = identifierText(schema)
+	}
+	tableKey := fmt.Sprintf("%s.%s", strings.ToLower(schemaName), strings.ToLower(tableName))
+	fullName := fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(tableName))
+	if _, ok := l.schema.tableMap[tableKey]; !ok {
+		l.err = errors.Errorf("table %q not found", tableKey)
+		return
+	}
+
+	switch {
+	case ctx.WITH() != nil && ctx.ADD() != nil:
+		// ALTER TABLE ... WITH CHECK ADD CONSTRAINT ...
+		constraint := &constraintInfo{
+			tableKey:   tableKey,
+			tableName:  fullName,
+			foreignKey: ctx.FOREIGN() != nil,
+		}
+		start := ctx.GetParser().GetTokenStream().Get(ctx.ADD().GetSymbol().GetTokenIndex() + 1)
+		constraint.definition = strings.TrimRight(getTextFromTokens(start, ctx.GetStop()), "; \t\r\n")
+		if ctx.GetConstraint() != nil {
+			constraint.name = identifierText(ctx.GetConstraint())
+		}
+		constraint.constraintType = "C"
+		if ctx.FOREIGN() != nil {
+			constraint.constraintType = "F"
+			for _, column := range ctx.GetFk().AllId_() {
+				constraint.columns = append(constraint.columns, identifierText(column))
+			}
+		}
+		l.addConstraint(constraint)
+	case ctx.ADD() != nil && ctx.Column_def_table_constraints() != nil:
+		for _, item := range ctx.Column_def_table_constraints().AllColumn_def_table_constraint() {
+			if item.Table_constraint() == nil {
+				l.err = errors.Errorf("only support adding constraints by ALTER TABLE statement, but got %q", getText(item))
+				return
+			}
+			l.addTableConstraint(tableKey, fullName, false /* inline */, item.Table_constraint())
+		}
+	}
+}
+
+// EnterCreate_index is called when production create_index is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_index(ctx *tsqlparser.Create_indexContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName := defaultSchemaName
+	if schema := ctx.Table_name().GetSchema(); schema != nil {
+		schemaName = identifierText(schema)
+	}
+	tableName := identifierText(ctx.Table_name().GetTable())
+	l.addIndex(&indexInfo{
+		tableKey:   fmt.Sprintf("%s.%s", strings.ToLower(schemaName), strings.ToLower(tableName)),
+		tableName:  fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(tableName)),
+		name:       identifierText(ctx.Id_(0)),
+		definition: getTextWithoutSemicolon(ctx),
+	})
+}
+
+// EnterCreate_view is called when production create_view is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_view(ctx *tsqlparser.Create_viewContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName := defaultSchemaName
+	if schema := ctx.Simple_name().GetSchema(); schema != nil {
+		schemaName = identifierText(schema)
+	} else if createSchema, ok := ctx.GetParent().(*tsqlparser.Create_schemaContext); ok && createSchema.GetSchema_name() != nil {
+		schemaName = identifierText(createSchema.GetSchema_name())
+	}
+	l.addRoutine(l.schema.viewMap, "VIEW", schemaName, ctx.Simple_name().GetName(), ctx, ctx.VIEW().GetSymbol())
+}
+
+// EnterCreate_or_alter_procedure is called when production create_or_alter_procedure is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_or_alter_procedure(ctx *tsqlparser.Create_or_alter_procedureContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName := defaultSchemaName
+	if schema := ctx.Func_proc_name_schema().GetSchema(); schema != nil {
+		schemaName = identifierText(schema)
+	}
+	l.addRoutine(l.schema.procedureMap, "PROCEDURE", schemaName, ctx.Func_proc_name_schema().GetProcedure(), ctx, ctx.GetProc())
+}
+
+// EnterCreate_or_alter_function is called when production create_or_alter_function is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_or_alter_function(ctx *tsqlparser.Create_or_alter_functionContext) {
+	if l.err != nil {
+		return
+	}
+	schemaName := defaultSchemaName
+	if schema := ctx.Func_proc_name_schema().GetSchema(); schema != nil {
+		schemaName = identifierText(schema)
+	}
+	l.addRoutine(l.schema.functionMap, "FUNCTION", schemaName, ctx.Func_proc_name_schema().GetProcedure(), ctx, ctx.FUNCTION().GetSymbol())
+}
+
+// EnterCreate_or_alter_dml_trigger is called when production create_or_alter_dml_trigger is entered.
+func (l *buildSchemaDefinitionListener) EnterCreate_or_alter_dml_trigger(ctx *tsqlparser.Create_or_alter_dml_triggerContext) {
+	if l.err != nil {
+		return
+	}
+	// The DML trigger is in the schema of its table.
+	schemaName := defaultSchemaName
+	if schema := ctx.Simple_name().GetSchema(); schema != nil {
+		schemaName = identifierText(schema)
+	} else if schema := ctx.Table_name().GetSchema(); schema != nil {
+		schemaName = identifierText(schema)
+	}
+	l.addRoutine(l.schema.triggerMap, "TRIGGER", schemaName, ctx.Simple_name().GetName(), ctx, ctx.TRIGGER().GetSymbol())
+}
+
+// addRoutine adds the view, procedure, function or trigger, the typeToken is the VIEW, PROCEDURE, FUNCTION or TRIGGER keyword.
+func (l *buildSchemaDefinitionListener) addRoutine(m routineMap, routineType string, schemaName string, name tsqlparser.IId_Context, ctx antlr.ParserRuleContext, typeToken antlr.Token) {
+	routine := &routineInfo{
+		id:          l.nextID(),
+		schemaName:  schemaName,
+		name:        identifierText(name),
+		routineType: routineType,
+		definition:  getTextWithoutSemicolon(ctx),
+	}
+	routine.key = fmt.Sprintf("%s.%s", strings.ToLower(routine.schemaName), strings.ToLower(routine.name))
+	if _, ok := m[routine.key]; ok {
+		l.err = errors.Errorf("duplicate %s %q", strings.ToLower(routineType), routine.key)
+		return
+	}
+	routine.alterDefinition = "ALTER " + strings.TrimRight(getTextFromTokens(typeToken, ctx.GetStop()), "; \t\r\n")
+	m[routine.key] = routine
+}
+
+func sortedSchemas(m schemaMap) []*schemaInfo {
+	var result []*schemaInfo
+	for _, schema := range m {
+		result = append(result, schema)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func sortedTables(m tableMap) []*tableInfo {
+	var result []*tableInfo
+	for _, table := range m {
+		result = append(result, table)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func sortedConstraints(m constraintMap) []*constraintInfo {
+	var result []*constraintInfo
+	for _, constraint := range m {
+		result = append(result, constraint)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func sortedIndexes(m indexMap) []*indexInfo {
+	var result []*indexInfo
+	for _, index := range m {
+		result = append(result, index)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func sortedRoutines(m routineMap) []*routineInfo {
+	var result []*routineInfo
+	for _, routine := range m {
+		result = append(result, routine)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].id < result[j].id
+	})
+	return result
+}
+
+func getText(ctx antlr.ParserRuleContext) string {
+	if ctx == nil || ctx.GetStart() == nil || ctx.GetStop() == nil || ctx.GetStop().GetTokenIndex() < ctx.GetStart().GetTokenIndex() {
+		return ""
+	}
+	return getTextFromTokens(ctx.GetStart(), ctx.GetStop())
+}
+
+// getTextFromTokens returns the original text between the tokens, including the whitespaces skipped by the lexer.
+func getTextFromTokens(start, stop antlr.Token) string {
+	return start.GetInputStream().GetText(start.GetStart(), stop.GetStop())
+}
+
+func getTextWithoutSemicolon(ctx antlr.ParserRuleContext) string {
+	return strings.TrimRight(getText(ctx), "; \t\r\n")
+}
+
+// identifierText returns the identifier without the brackets or double quotes, and keeps the case.
+func identifierText(id tsqlparser.IId_Context) string {
+	if id == nil {
+		return ""
+	}
+	text := id.GetText()
+	if len(text) >= 2 && ((text[0] == '[' && text[len(text)-1] == ']') || (text[0] == '"' && text[len(text)-1] == '"')) {
+		text = text[1 : len(text)-1]
+	}
+	return text
+}
+
+func normalizeIdentifier(id tsqlparser.IId_Context) string {
+	return strings.ToLower(identifierText(id))
+}
+
+// quoteString returns the N” string literal.
+func quoteString(s string) string {
+	return fmt.Sprintf("N'%s'", strings.ReplaceAll(s, "'", "''"))
+}
+
+func quoteIdentifier(s string) string {
+	return fmt.Sprintf("[%s]", strings.ReplaceAll(s, "]", "]]"))
+}
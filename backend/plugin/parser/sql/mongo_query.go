@@ -0,0 +1,650 @@
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// MongoQueryMethod is the collection method of a MongoDB query.
+type MongoQueryMethod string
+
+const (
+	// MongoFind is db.collection.find(filter, projection).
+	MongoFind MongoQueryMethod = "find"
+	// MongoFindOne is db.collection.findOne(filter, projection).
+	MongoFindOne MongoQueryMethod = "findOne"
+	// MongoAggregate is db.collection.aggregate(pipeline).
+	MongoAggregate MongoQueryMethod = "aggregate"
+	// MongoCountDocuments is db.collection.countDocuments(filter).
+	MongoCountDocuments MongoQueryMethod = "countDocuments"
+	// MongoDistinct is db.collection.distinct(field, filter).
+	MongoDistinct MongoQueryMethod = "distinct"
+)
+
+// mongoCursorMethods are the cursor methods allowed to be chained after the collection methods.
+// toArray and pretty only change how mongosh prints the result, so they are accepted and ignored.
+var mongoCursorMethods = map[MongoQueryMethod]map[string]bool{
+	MongoFind:           {"sort": true, "skip": true, "limit": true, "projection": true, "toArray": true, "pretty": true},
+	MongoFindOne:        {},
+	MongoAggregate:      {"toArray": true, "pretty": true},
+	MongoCountDocuments: {},
+	MongoDistinct:       {},
+}
+
+// MongoQuery is a read-only query on a collection in the mongosh syntax, such as db.users.find({age: {$gt: 18}}).sort({name: 1}).limit(10).
+type MongoQuery struct {
+	// Collection is the name of the queried collection.
+	Collection string
+	// Method is the collection method.
+	Method MongoQueryMethod
+	// Arguments are the arguments of the method in MongoDB Extended JSON,
+	// the shell helpers such as ObjectId("...") and ISODate("...") are converted to {"$oid": "..."} and {"$date": ...}.
+	Arguments []string
+	// CursorMethods are the cursor methods chained after the collection method in order.
+	CursorMethods []*MongoCursorMethod
+}
+
+// MongoCursorMethod is a cursor method such as sort, skip and limit.
+type MongoCursorMethod struct {
+	Name string
+	// Arguments are the arguments of the cursor method in MongoDB Extended JSON.
+	Arguments []string
+}
+
+// ParseMongoQuery parses the read-only MongoDB query in the mongosh syntax.
+// Only the find, findOne, aggregate, countDocuments and distinct methods on a collection are supported,
+// and the arguments must be literals, so that the query can run by the driver without evaluating JavaScript.
+func ParseMongoQuery(statement string) (*MongoQuery, error) {
+	p := &mongoQueryParser{s: []rune(statement)}
+	query, err := p.query()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse MongoDB query at position %d", p.pos)
+	}
+	return query, nil
+}
+
+type mongoQueryParser struct {
+	s   []rune
+	pos int
+}
+
+func (p *mongoQueryParser) query() (*MongoQuery, error) {
+	p.skipSpace()
+	if p.identifier() != "db" {
+		return nil, errors.New("query must start with db")
+	}
+	query := &MongoQuery{}
+	var segments []string
+	for {
+		p.skipSpace()
+		switch {
+		case p.consume('.'):
+			p.skipSpace()
+			name := p.identifier()
+			if name == "" {
+				return nil, errors.New("expecting a collection or method name")
+			}
+			p.skipSpace()
+			if p.peek() != '(' {
+				segments = append(segments, name)
+				continue
+			}
+			arguments, err := p.arguments()
+			if err != nil {
+				return nil, err
+			}
+			if name == "getCollection" && len(segments) == 0 && query.Collection == "" {
+				if len(arguments) != 1 {
+					return nil, errors.New("getCollection expects the collection name")
+				}
+				if err := json.Unmarshal([]byte(arguments[0]), &query.Collection); err != nil {
+					return nil, errors.New("getCollection expects the collection name")
+				}
+				continue
+			}
+			if query.Collection == "" {
+				query.Collection = strings.Join(segments, ".")
+			} else if len(segments) != 0 {
+				query.Collection += "." + strings.Join(segments, ".")
+			}
+			if query.Collection == "" {
+				return nil, errors.Errorf("unsupported method db.%s()", name)
+			}
+			query.Method = MongoQueryMethod(name)
+			if _, ok := mongoCursorMethods[query.Method]; !ok {
+				return nil, errors.Errorf("unsupported method %q, only find, findOne, aggregate, countDocuments and distinct are supported", name)
+			}
+			query.Arguments = arguments
+			return query, p.cursorMethods(query)
+		case p.consume('['):
+			p.skipSpace()
+			var name string
+			if err := p.stringValue(&name); err != nil {
+				return nil, err
+			}
+			p.skipSpace()
+			if !p.consume(']') {
+				return nil, errors.New("expecting ]")
+			}
+			segments = append(segments, name)
+		default:
+			return nil, errors.New("expecting a collection method call such as db.collection.find()")
+		}
+	}
+}
+
+func (p *mongoQueryParser) cursorMethods(query *MongoQuery) error {
+	for {
+		p.skipSpace()
+		if !p.consume('.') {
+			break
+		}
+		p.skipSpace()
+		name := p.identifier()
+		if !mongoCursorMethods[query.Method][name] {
+			return errors.Errorf("unsupported cursor method %q after %s", name, query.Method)
+		}
+		p.skipSpace()
+		arguments, err := p.arguments()
+		if err != nil {
+			return err
+		}
+		if name == "toArray" || name == "pretty" {
+			continue
+		}
+		query.CursorMethods = append(query.CursorMethods, &MongoCursorMethod{Name: name, Arguments: arguments})
+	}
+	p.consume(';')
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return errors.New("only one query is supported")
+	}
+	return nil
+}
+
+// arguments parses the parenthesized arguments and returns them in Extended JSON.
+func (p *mongoQueryParser) arguments() ([]string, error) {
+	if !p.consume('(') {
+		return nil, errors.New("expecting (")
+	}
+	var arguments []string
+	for {
+		p.skipSpace()
+		if p.consume(')') {
+			return arguments, nil
+		}
+		if len(arguments) != 0 {
+			if !p.consume(',') {
+				return nil, errors.New("expecting , or )")
+			}
+			p.skipSpace()
+			// Trailing comma.
+			if p.consume(')') {
+				return arguments, nil
+			}
+		}
+		var b strings.Builder
+		if err := p.value(&b); err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, b.String())
+	}
+}
+
+// value converts the JavaScript literal to Extended JSON.
+func (p *mongoQueryParser) value(b *strings.Builder) error {
+	p.skipSpace()
+	switch c := p.peek(); {
+	case c == '{':
+		return p.object(b)
+	case c == '[':
+		return p.array(b)
+	case c == '"' || c == '\'':
+		var s string
+		if err := p.stringValue(&s); err != nil {
+			return err
+		}
+		writeJSONString(b, s)
+		return nil
+	case c == '/':
+		return p.regex(b)
+	case c == '-' || c == '+' || c == '.' || unicode.IsDigit(c):
+		return p.number(b)
+	case isIdentifierStart(c):
+		return p.identifierValue(b)
+	default:
+		return errors.Errorf("unexpected character %q", string(c))
+	}
+}
+
+func (p *mongoQueryParser) object(b *strings.Builder) error {
+	p.consume('{')
+	b.WriteByte('{')
+	first := true
+	for {
+		p.skipSpace()
+		if p.consume('}') {
+			b.WriteByte('}')
+			return nil
+		}
+		if !first {
+			if !p.consume(',') {
+				return errors.New("expecting , or }")
+			}
+			p.skipSpace()
+			if p.consume('}') {
+				b.WriteByte('}')
+				return nil
+			}
+			b.WriteByte(',')
+		}
+		first = false
+
+		var key string
+		switch c := p.peek(); {
+		case c == '"' || c == '\'':
+			if err := p.stringValue(&key); err != nil {
+				return err
+			}
+		case isIdentifierStart(c) || unicode.IsDigit(c):
+			key = p.identifier()
+		default:
+			return errors.New("expecting a field name")
+		}
+		writeJSONString(b, key)
+		p.skipSpace()
+		if !p.consume(':') {
+			return errors.New("expecting :")
+		}
+		b.WriteByte(':')
+		if err := p.value(b); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *mongoQueryParser) array(b *strings.Builder) error {
+	p.consume('[')
+	b.WriteByte('[')
+	first := true
+	for {
+		p.skipSpace()
+		if p.consume(']') {
+			b.WriteByte(']')
+			return nil
+		}
+		if !first {
+			if !p.consume(',') {
+				return errors.New("expecting , or ]")
+			}
+			p.skipSpace()
+			if p.consume(']') {
+				b.WriteByte(']')
+				return nil
+			}
+			b.WriteByte(',')
+		}
+		first = false
+		if err := p.value(b); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *mongoQueryParser) stringValue(s *string) error {
+	quote := p.peek()
+	if quote != '"' && quote != '\'' {
+		return errors.New("expecting a string")
+	}
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.s) {
+			return errors.New("unterminated string")
+		}
+		c := p.s[p.pos]
+		p.pos++
+		switch c {
+		case quote:
+			*s = b.String()
+			return nil
+		case '\\':
+			if p.pos >= len(p.s) {
+				return errors.New("unterminated string")
+			}
+			escaped := p.s[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				b.WriteRune('\n')
+			case 't':
+				b.WriteRune('\t')
+			case 'r':
+				b.WriteRune('\r')
+			case 'b':
+				b.WriteRune('\b')
+			case 'f':
+				b.WriteRune('\f')
+			case 'v':
+				b.WriteRune('\v')
+			case '0':
+				b.WriteRune(0)
+			case 'u', 'x':
+				size := 4
+				if escaped == 'x' {
+					size = 2
+				}
+				if p.pos+size > len(p.s) {
+					return errors.New("invalid escape sequence")
+				}
+				code, err := strconv.ParseUint(string(p.s[p.pos:p.pos+size]), 16, 32)
+				if err != nil {
+					return errors.New("invalid escape sequence")
+				}
+				p.pos += size
+				b.WriteRune(rune(code))
+			default:
+				b.WriteRune(escaped)
+			}
+		default:
+			b.WriteRune(c)
+		}
+	}
+}
+
+func (p *mongoQueryParser) regex(b *strings.Builder) error {
+	p.consume('/')
+	var pattern strings.Builder
+	inClass := false
+	for {
+		if p.pos >= len(p.s) {
+			return errors.New("unterminated regular expression")
+		}
+		c := p.s[p.pos]
+		p.pos++
+		if c == '\\' && p.pos < len(p.s) {
+			pattern.WriteRune(c)
+			pattern.WriteRune(p.s[p.pos])
+			p.pos++
+			continue
+		}
+		if c == '/' && !inClass {
+			break
+		}
+		switch c {
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		}
+		pattern.WriteRune(c)
+	}
+	var options []rune
+	for p.pos < len(p.s) && unicode.IsLetter(p.s[p.pos]) {
+		options = append(options, p.s[p.pos])
+		p.pos++
+	}
+	// The options of the regular expression must be in alphabetical order in Extended JSON.
+	sort.Slice(options, func(i, j int) bool { return options[i] < options[j] })
+	b.WriteString(`{"$regularExpression":{"pattern":`)
+	writeJSONString(b, pattern.String())
+	b.WriteString(`,"options":`)
+	writeJSONString(b, string(options))
+	b.WriteString(`}}`)
+	return nil
+}
+
+func (p *mongoQueryParser) number(b *strings.Builder) error {
+	start := p.pos
+	negative := p.peek() == '-'
+	if c := p.peek(); c == '-' || c == '+' {
+		p.pos++
+	}
+	if p.identifierAhead("Infinity") {
+		p.pos += len("Infinity")
+		if negative {
+			b.WriteString(`{"$numberDouble":"-Infinity"}`)
+		} else {
+			b.WriteString(`{"$numberDouble":"Infinity"}`)
+		}
+		return nil
+	}
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if unicode.IsDigit(c) || c == '.' || c == 'e' || c == 'E' || ((c == '-' || c == '+') && (p.s[p.pos-1] == 'e' || p.s[p.pos-1] == 'E')) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	text := strings.TrimPrefix(string(p.s[start:p.pos]), "+")
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		b.WriteString(strconv.FormatInt(i, 10))
+		return nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return errors.Errorf("invalid number %q", text)
+	}
+	b.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	return nil
+}
+
+func (p *mongoQueryParser) identifierValue(b *strings.Builder) error {
+	name := p.identifier()
+	switch name {
+	case "true", "false", "null":
+		b.WriteString(name)
+		return nil
+	case "undefined":
+		b.WriteString("null")
+		return nil
+	case "Infinity", "NaN":
+		b.WriteString(`{"$numberDouble":"` + name + `"}`)
+		return nil
+	case "new":
+		p.skipSpace()
+		name = p.identifier()
+	}
+	p.skipSpace()
+	if p.peek() != '(' {
+		return errors.Errorf("unsupported expression %q, only literals are supported", name)
+	}
+	arguments, err := p.arguments()
+	if err != nil {
+		return err
+	}
+	return writeShellHelper(b, name, arguments)
+}
+
+// writeShellHelper converts the mongosh helper such as ObjectId("...") to Extended JSON.
+func writeShellHelper(b *strings.Builder, name string, arguments []string) error {
+	var values []any
+	for _, argument := range arguments {
+		decoder := json.NewDecoder(strings.NewReader(argument))
+		decoder.UseNumber()
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+	stringArgument := func() (string, error) {
+		if len(values) != 1 {
+			return "", errors.Errorf("%s expects 1 argument", name)
+		}
+		switch v := values[0].(type) {
+		case string:
+			return v, nil
+		case json.Number:
+			return v.String(), nil
+		default:
+			return "", errors.Errorf("%s expects a string or number", name)
+		}
+	}
+
+	switch name {
+	case "ObjectId":
+		id, err := stringArgument()
+		if err != nil {
+			return err
+		}
+		if _, err := hex.DecodeString(id); err != nil || len(id) != 24 {
+			return errors.Errorf("invalid ObjectId %q", id)
+		}
+		b.WriteString(`{"$oid":`)
+		writeJSONString(b, id)
+		b.WriteString(`}`)
+	case "ISODate", "Date":
+		t := time.Now()
+		if len(values) != 0 {
+			switch v := values[0].(type) {
+			case string:
+				parsed, err := parseMongoDate(v)
+				if err != nil {
+					return err
+				}
+				t = parsed
+			case json.Number:
+				ms, err := v.Int64()
+				if err != nil {
+					return errors.Errorf("invalid date %q", v)
+				}
+				t = time.UnixMilli(ms)
+			default:
+				return errors.Errorf("%s expects a string or number", name)
+			}
+		}
+		b.WriteString(`{"$date":{"$numberLong":"` + strconv.FormatInt(t.UnixMilli(), 10) + `"}}`)
+	case "NumberLong", "NumberInt":
+		n, err := stringArgument()
+		if err != nil {
+			return err
+		}
+		bitSize, key := 64, "$numberLong"
+		if name == "NumberInt" {
+			bitSize, key = 32, "$numberInt"
+		}
+		if _, err := strconv.ParseInt(n, 10, bitSize); err != nil {
+			return errors.Errorf("invalid %s %q", name, n)
+		}
+		b.WriteString(`{"` + key + `":"` + n + `"}`)
+	case "NumberDecimal", "Decimal128":
+		n, err := stringArgument()
+		if err != nil {
+			return err
+		}
+		b.WriteString(`{"$numberDecimal":`)
+		writeJSONString(b, n)
+		b.WriteString(`}`)
+	case "UUID":
+		id, err := stringArgument()
+		if err != nil {
+			return err
+		}
+		data, err := hex.DecodeString(strings.ReplaceAll(id, "-", ""))
+		if err != nil || len(data) != 16 {
+			return errors.Errorf("invalid UUID %q", id)
+		}
+		b.WriteString(`{"$binary":{"base64":"` + base64.StdEncoding.EncodeToString(data) + `","subType":"04"}}`)
+	case "Timestamp":
+		var t, i any
+		switch len(values) {
+		case 1:
+			m, ok := values[0].(map[string]any)
+			if !ok {
+				return errors.New("Timestamp expects {t: <seconds>, i: <increment>}")
+			}
+			t, i = m["t"], m["i"]
+		case 2:
+			t, i = values[0], values[1]
+		default:
+			return errors.New("Timestamp expects the seconds and the increment")
+		}
+		tn, ok1 := t.(json.Number)
+		in, ok2 := i.(json.Number)
+		if !ok1 || !ok2 {
+			return errors.New("Timestamp expects the seconds and the increment")
+		}
+		b.WriteString(`{"$timestamp":{"t":` + tn.String() + `,"i":` + in.String() + `}}`)
+	default:
+		return errors.Errorf("unsupported function %s()", name)
+	}
+	return nil
+}
+
+func parseMongoDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid date %q", s)
+}
+
+func writeJSONString(b *strings.Builder, s string) {
+	// Marshaling a string never fails.
+	data, _ := json.Marshal(s)
+	b.Write(data)
+}
+
+func (p *mongoQueryParser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.s) && (isIdentifierStart(p.s[p.pos]) || unicode.IsDigit(p.s[p.pos])) {
+		p.pos++
+	}
+	return string(p.s[start:p.pos])
+}
+
+func (p *mongoQueryParser) identifierAhead(name string) bool {
+	return strings.HasPrefix(string(p.s[p.pos:]), name)
+}
+
+func isIdentifierStart(c rune) bool {
+	return c == '_' || c == '$' || unicode.IsLetter(c)
+}
+
+func (p *mongoQueryParser) peek() rune {
+	if p.pos >= len(p.s) {
+		return eofRune
+	}
+	return p.s[p.pos]
+}
+
+func (p *mongoQueryParser) consume(c rune) bool {
+	if p.peek() != c {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+// skipSpace skips the spaces and comments.
+func (p *mongoQueryParser) skipSpace() {
+	for p.pos < len(p.s) {
+		switch {
+		case unicode.IsSpace(p.s[p.pos]):
+			p.pos++
+		case p.identifierAhead("//"):
+			for p.pos < len(p.s) && p.s[p.pos] != '\n' {
+				p.pos++
+			}
+		case p.identifierAhead("/*"):
+			p.pos += 2
+			for p.pos < len(p.s) && !p.identifierAhead("*/") {
+				p.pos++
+			}
+			p.pos = min(p.pos+2, len(p.s))
+		default:
+			return
+		}
+	}
+}
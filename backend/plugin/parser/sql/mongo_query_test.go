@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMongoQuery(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      *MongoQuery
+	}{
+		{
+			statement: `db.users.find()`,
+			want:      &MongoQuery{Collection: "users", Method: MongoFind},
+		},
+		{
+			statement: `db.users.find({age: {$gt: 18}, name: /^a/i}, {_id: 0}).sort({name: -1}).limit(10);`,
+			want: &MongoQuery{
+				Collection: "users",
+				Method:     MongoFind,
+				Arguments: []string{
+					`{"age":{"$gt":18},"name":{"$regularExpression":{"pattern":"^a","options":"i"}}}`,
+					`{"_id":0}`,
+				},
+				CursorMethods: []*MongoCursorMethod{
+					{Name: "sort", Arguments: []string{`{"name":-1}`}},
+					{Name: "limit", Arguments: []string{`10`}},
+				},
+			},
+		},
+		{
+			statement: `db.getCollection("order.items").aggregate([{$match: {_id: ObjectId("5f1d7a9b2c8e4a1b3c5d7e9f")}}])`,
+			want: &MongoQuery{
+				Collection: "order.items",
+				Method:     MongoAggregate,
+				Arguments:  []string{`[{"$match":{"_id":{"$oid":"5f1d7a9b2c8e4a1b3c5d7e9f"}}}]`},
+			},
+		},
+		{
+			statement: `// Count the recent orders.
+db["orders"].countDocuments({created: {$gte: ISODate("2023-01-01T00:00:00Z")}, total: NumberLong(5)})`,
+			want: &MongoQuery{
+				Collection: "orders",
+				Method:     MongoCountDocuments,
+				Arguments:  []string{`{"created":{"$gte":{"$date":{"$numberLong":"1672531200000"}}},"total":{"$numberLong":"5"}}`},
+			},
+		},
+		{
+			statement: `db.orders.distinct('status', {"total": {'$gt': -1.5}})`,
+			want: &MongoQuery{
+				Collection: "orders",
+				Method:     MongoDistinct,
+				Arguments:  []string{`"status"`, `{"total":{"$gt":-1.5}}`},
+			},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := ParseMongoQuery(test.statement)
+		a.NoError(err, test.statement)
+		a.Equal(test.want, got, test.statement)
+	}
+}
+
+func TestParseMongoQueryError(t *testing.T) {
+	tests := []string{
+		`show dbs`,
+		`db.users.insertOne({name: "a"})`,
+		`db.users.find({name: "a"}).forEach(printjson)`,
+		`db.users.find({$where: function() { return true; }})`,
+		`db.users.find({name: x})`,
+		`db.users.find(); db.orders.find()`,
+		`db.users.countDocuments().limit(1)`,
+		`db.users.find({name: "a"`,
+	}
+
+	a := require.New(t)
+	for _, statement := range tests {
+		_, err := ParseMongoQuery(statement)
+		a.Error(err, statement)
+	}
+}
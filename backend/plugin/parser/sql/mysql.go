@@ -588,6 +588,43 @@ func extractMySQLResourceList(currentDatabase string, statement string) ([]Schem
 	return result, nil
 }
 
+// ExtractMySQLTableAliases returns the tables referenced by the statement keyed by the names used in the statement,
+// which are the aliases if specified, otherwise the table names.
+// The database of the table is empty if it's not qualified.
+func ExtractMySQLTableAliases(statement string) (map[string]SchemaResource, error) {
+	treeList, err := ParseMySQL(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &mysqlTableAliasListener{
+		aliases: make(map[string]SchemaResource),
+	}
+	for _, tree := range treeList {
+		if tree == nil {
+			continue
+		}
+		antlr.ParseTreeWalkerDefault.Walk(l, tree.Tree)
+	}
+	return l.aliases, nil
+}
+
+type mysqlTableAliasListener struct {
+	*parser.BaseMySQLParserListener
+
+	aliases map[string]SchemaResource
+}
+
+// EnterSingleTable is called when production singleTable is entered.
+func (l *mysqlTableAliasListener) EnterSingleTable(ctx *parser.SingleTableContext) {
+	database, table := NormalizeMySQLTableRef(ctx.TableRef())
+	alias := table
+	if ctx.TableAlias() != nil {
+		alias = NormalizeMySQLIdentifier(ctx.TableAlias().Identifier())
+	}
+	l.aliases[alias] = SchemaResource{Database: database, Table: table}
+}
+
 type mysqlResourceExtractListener struct {
 	*parser.BaseMySQLParserListener
 
@@ -184,6 +184,16 @@ func TestExtractMySQLResourceList(t *testing.T) {
 	}
 }
 
+func TestExtractMySQLTableAliases(t *testing.T) {
+	aliases, err := ExtractMySQLTableAliases("SELECT * FROM t1 AS a JOIN db2.t2 b ON a.c1 = b.c1 WHERE a.c2 IN (SELECT c2 FROM t3);")
+	require.NoError(t, err)
+	require.Equal(t, map[string]SchemaResource{
+		"a":  {Table: "t1"},
+		"b":  {Database: "db2", Table: "t2"},
+		"t3": {Table: "t3"},
+	}, aliases)
+}
+
 func TestSplitMySQLStatements(t *testing.T) {
 	tests := []struct {
 		statement string
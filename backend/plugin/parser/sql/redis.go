@@ -0,0 +1,831 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// RedisCommandCategory is the category of a Redis command.
+type RedisCommandCategory string
+
+const (
+	// RedisCommandRead is the category of the commands only reading the data, such as GET and SCAN.
+	RedisCommandRead RedisCommandCategory = "READ"
+	// RedisCommandWrite is the category of the commands changing the data, such as SET and DEL.
+	RedisCommandWrite RedisCommandCategory = "WRITE"
+	// RedisCommandAdmin is the category of the commands inspecting or managing the server and the connection, such as INFO and SELECT.
+	RedisCommandAdmin RedisCommandCategory = "ADMIN"
+	// RedisCommandDangerous is the category of the commands that can destroy the data or block the server, such as FLUSHALL and KEYS.
+	RedisCommandDangerous RedisCommandCategory = "DANGEROUS"
+)
+
+// RedisCommand is a Redis command in the redis-cli syntax.
+type RedisCommand struct {
+	// Name is the upper-case command name, the subcommand is included for the container commands, such as "CONFIG SET".
+	Name string
+	// Arguments are the arguments of the command including the command name.
+	Arguments []string
+	Category  RedisCommandCategory
+	// Keys are the keys accessed by the command.
+	Keys []string
+	// Patterns are the key patterns matched by the command, such as the pattern of SCAN ... MATCH pattern.
+	Patterns []string
+	// UnknownKeys is true if the keys accessed by the command can't be determined, such as the commands of the modules.
+	UnknownKeys bool
+}
+
+// Suggestion returns the suggested replacement of the command, it is empty if there is no suggestion.
+func (c *RedisCommand) Suggestion() string {
+	return redisCommandSuggestions[c.Name]
+}
+
+var redisCommandSuggestions = map[string]string{
+	"KEYS": "use SCAN with MATCH to iterate the keys incrementally instead of KEYS, which blocks the server until all keys are scanned",
+}
+
+// redisKeySpec describes the positions of the keys in the arguments like the key specs of the COMMAND command.
+type redisKeySpec struct {
+	category RedisCommandCategory
+	// keyless is true if the command doesn't access any key.
+	keyless bool
+	// firstKey, lastKey and step are the positions of the keys, the negative lastKey counts from the end.
+	firstKey int
+	lastKey  int
+	step     int
+	// numKeys is the position of the number of the following keys, such as ZUNION numkeys key [key ...].
+	numKeys int
+	// streams is true if the keys are the first half of the arguments after STREAMS, such as XREAD.
+	streams bool
+	// matchPattern is true if the command filters the keys by the MATCH option, such as SCAN.
+	matchPattern bool
+	// sort is true if the command reads the keys by the BY and GET patterns and writes the STORE destination, such as SORT.
+	sort bool
+}
+
+func readKey() redisKeySpec {
+	return redisKeySpec{category: RedisCommandRead, firstKey: 1, lastKey: 1, step: 1}
+}
+
+func writeKey() redisKeySpec {
+	return redisKeySpec{category: RedisCommandWrite, firstKey: 1, lastKey: 1, step: 1}
+}
+
+func readKeys() redisKeySpec {
+	return redisKeySpec{category: RedisCommandRead, firstKey: 1, lastKey: -1, step: 1}
+}
+
+func writeKeys() redisKeySpec {
+	return redisKeySpec{category: RedisCommandWrite, firstKey: 1, lastKey: -1, step: 1}
+}
+
+func writeTwoKeys() redisKeySpec {
+	return redisKeySpec{category: RedisCommandWrite, firstKey: 1, lastKey: 2, step: 1}
+}
+
+func keyless(category RedisCommandCategory) redisKeySpec {
+	return redisKeySpec{category: category, keyless: true}
+}
+
+// redisCommands are the specs of the known commands, the container commands are looked up by the name with the subcommand first.
+var redisCommands = map[string]redisKeySpec{
+	// Generic.
+	"EXISTS":          readKeys(),
+	"TYPE":            readKey(),
+	"TTL":             readKey(),
+	"PTTL":            readKey(),
+	"EXPIRETIME":      readKey(),
+	"PEXPIRETIME":     readKey(),
+	"DUMP":            readKey(),
+	"TOUCH":           readKeys(),
+	"SORT_RO":         {category: RedisCommandRead, firstKey: 1, lastKey: 1, step: 1, sort: true},
+	"SCAN":            {category: RedisCommandRead, keyless: true, matchPattern: true},
+	"RANDOMKEY":       keyless(RedisCommandRead),
+	"DBSIZE":          keyless(RedisCommandRead),
+	"DEL":             writeKeys(),
+	"UNLINK":          writeKeys(),
+	"EXPIRE":          writeKey(),
+	"PEXPIRE":         writeKey(),
+	"EXPIREAT":        writeKey(),
+	"PEXPIREAT":       writeKey(),
+	"PERSIST":         writeKey(),
+	"RENAME":          writeTwoKeys(),
+	"RENAMENX":        writeTwoKeys(),
+	"COPY":            writeTwoKeys(),
+	"MOVE":            writeKey(),
+	"RESTORE":         writeKey(),
+	"SORT":            {category: RedisCommandWrite, firstKey: 1, lastKey: 1, step: 1, sort: true},
+	"OBJECT ENCODING": {category: RedisCommandRead, firstKey: 2, lastKey: 2, step: 1},
+	"OBJECT FREQ":     {category: RedisCommandRead, firstKey: 2, lastKey: 2, step: 1},
+	"OBJECT IDLETIME": {category: RedisCommandRead, firstKey: 2, lastKey: 2, step: 1},
+	"OBJECT REFCOUNT": {category: RedisCommandRead, firstKey: 2, lastKey: 2, step: 1},
+	"KEYS":            {category: RedisCommandDangerous, keyless: true, matchPattern: true},
+	"FLUSHALL":        keyless(RedisCommandDangerous),
+	"FLUSHDB":         keyless(RedisCommandDangerous),
+	"SWAPDB":          keyless(RedisCommandDangerous),
+	"MIGRATE":         keyless(RedisCommandDangerous),
+	// Strings.
+	"GET":         readKey(),
+	"MGET":        readKeys(),
+	"GETRANGE":    readKey(),
+	"SUBSTR":      readKey(),
+	"STRLEN":      readKey(),
+	"GETBIT":      readKey(),
+	"BITCOUNT":    readKey(),
+	"BITPOS":      readKey(),
+	"BITFIELD_RO": readKey(),
+	"LCS":         {category: RedisCommandRead, firstKey: 1, lastKey: 2, step: 1},
+	"SET":         writeKey(),
+	"SETNX":       writeKey(),
+	"SETEX":       writeKey(),
+	"PSETEX":      writeKey(),
+	"GETSET":      writeKey(),
+	"GETDEL":      writeKey(),
+	"GETEX":       writeKey(),
+	"APPEND":      writeKey(),
+	"INCR":        writeKey(),
+	"DECR":        writeKey(),
+	"INCRBY":      writeKey(),
+	"DECRBY":      writeKey(),
+	"INCRBYFLOAT": writeKey(),
+	"SETRANGE":    writeKey(),
+	"SETBIT":      writeKey(),
+	"BITFIELD":    writeKey(),
+	"BITOP":       {category: RedisCommandWrite, firstKey: 2, lastKey: -1, step: 1},
+	"MSET":        {category: RedisCommandWrite, firstKey: 1, lastKey: -1, step: 2},
+	"MSETNX":      {category: RedisCommandWrite, firstKey: 1, lastKey: -1, step: 2},
+	// Hashes.
+	"HGET":         readKey(),
+	"HMGET":        readKey(),
+	"HGETALL":      readKey(),
+	"HKEYS":        readKey(),
+	"HVALS":        readKey(),
+	"HLEN":         readKey(),
+	"HEXISTS":      readKey(),
+	"HSTRLEN":      readKey(),
+	"HRANDFIELD":   readKey(),
+	"HSCAN":        readKey(),
+	"HSET":         writeKey(),
+	"HSETNX":       writeKey(),
+	"HMSET":        writeKey(),
+	"HDEL":         writeKey(),
+	"HINCRBY":      writeKey(),
+	"HINCRBYFLOAT": writeKey(),
+	// Lists.
+	"LRANGE":     readKey(),
+	"LLEN":       readKey(),
+	"LINDEX":     readKey(),
+	"LPOS":       readKey(),
+	"LPUSH":      writeKey(),
+	"RPUSH":      writeKey(),
+	"LPUSHX":     writeKey(),
+	"RPUSHX":     writeKey(),
+	"LPOP":       writeKey(),
+	"RPOP":       writeKey(),
+	"LSET":       writeKey(),
+	"LINSERT":    writeKey(),
+	"LREM":       writeKey(),
+	"LTRIM":      writeKey(),
+	"RPOPLPUSH":  writeTwoKeys(),
+	"LMOVE":      writeTwoKeys(),
+	"BLMOVE":     writeTwoKeys(),
+	"BRPOPLPUSH": writeTwoKeys(),
+	"BLPOP":      {category: RedisCommandWrite, firstKey: 1, lastKey: -2, step: 1},
+	"BRPOP":      {category: RedisCommandWrite, firstKey: 1, lastKey: -2, step: 1},
+	"LMPOP":      {category: RedisCommandWrite, numKeys: 1},
+	"BLMPOP":     {category: RedisCommandWrite, numKeys: 2},
+	// Sets.
+	"SMEMBERS":    readKey(),
+	"SISMEMBER":   readKey(),
+	"SMISMEMBER":  readKey(),
+	"SCARD":       readKey(),
+	"SRANDMEMBER": readKey(),
+	"SSCAN":       readKey(),
+	"SINTER":      readKeys(),
+	"SUNION":      readKeys(),
+	"SDIFF":       readKeys(),
+	"SINTERCARD":  {category: RedisCommandRead, numKeys: 1},
+	"SADD":        writeKey(),
+	"SREM":        writeKey(),
+	"SPOP":        writeKey(),
+	"SMOVE":       writeTwoKeys(),
+	"SINTERSTORE": writeKeys(),
+	"SUNIONSTORE": writeKeys(),
+	"SDIFFSTORE":  writeKeys(),
+	// Sorted sets.
+	"ZRANGE":           readKey(),
+	"ZREVRANGE":        readKey(),
+	"ZRANGEBYSCORE":    readKey(),
+	"ZREVRANGEBYSCORE": readKey(),
+	"ZRANGEBYLEX":      readKey(),
+	"ZREVRANGEBYLEX":   readKey(),
+	"ZRANK":            readKey(),
+	"ZREVRANK":         readKey(),
+	"ZSCORE":           readKey(),
+	"ZMSCORE":          readKey(),
+	"ZCARD":            readKey(),
+	"ZCOUNT":           readKey(),
+	"ZLEXCOUNT":        readKey(),
+	"ZRANDMEMBER":      readKey(),
+	"ZSCAN":            readKey(),
+	"ZINTER":           {category: RedisCommandRead, numKeys: 1},
+	"ZUNION":           {category: RedisCommandRead, numKeys: 1},
+	"ZDIFF":            {category: RedisCommandRead, numKeys: 1},
+	"ZINTERCARD":       {category: RedisCommandRead, numKeys: 1},
+	"ZADD":             writeKey(),
+	"ZINCRBY":          writeKey(),
+	"ZREM":             writeKey(),
+	"ZREMRANGEBYRANK":  writeKey(),
+	"ZREMRANGEBYSCORE": writeKey(),
+	"ZREMRANGEBYLEX":   writeKey(),
+	"ZPOPMIN":          writeKey(),
+	"ZPOPMAX":          writeKey(),
+	"BZPOPMIN":         {category: RedisCommandWrite, firstKey: 1, lastKey: -2, step: 1},
+	"BZPOPMAX":         {category: RedisCommandWrite, firstKey: 1, lastKey: -2, step: 1},
+	"ZRANGESTORE":      writeTwoKeys(),
+	"ZINTERSTORE":      {category: RedisCommandWrite, firstKey: 1, lastKey: 1, step: 1, numKeys: 2},
+	"ZUNIONSTORE":      {category: RedisCommandWrite, firstKey: 1, lastKey: 1, step: 1, numKeys: 2},
+	"ZDIFFSTORE":       {category: RedisCommandWrite, firstKey: 1, lastKey: 1, step: 1, numKeys: 2},
+	"ZMPOP":            {category: RedisCommandWrite, numKeys: 1},
+	"BZMPOP":           {category: RedisCommandWrite, numKeys: 2},
+	// Streams.
+	"XRANGE":                readKey(),
+	"XREVRANGE":             readKey(),
+	"XLEN":                  readKey(),
+	"XPENDING":              readKey(),
+	"XREAD":                 {category: RedisCommandRead, streams: true},
+	"XINFO STREAM":          {category: RedisCommandRead, firstKey: 2, lastKey: 2, step: 1},
+	"XINFO GROUPS":          {category: RedisCommandRead, firstKey: 2, lastKey: 2, step: 1},
+	"XINFO CONSUMERS":       {category: RedisCommandRead, firstKey: 2, lastKey: 2, step: 1},
+	"XADD":                  writeKey(),
+	"XDEL":                  writeKey(),
+	"XTRIM":                 writeKey(),
+	"XACK":                  writeKey(),
+	"XCLAIM":                writeKey(),
+	"XAUTOCLAIM":            writeKey(),
+	"XSETID":                writeKey(),
+	"XREADGROUP":            {category: RedisCommandWrite, streams: true},
+	"XGROUP CREATE":         {category: RedisCommandWrite, firstKey: 2, lastKey: 2, step: 1},
+	"XGROUP SETID":          {category: RedisCommandWrite, firstKey: 2, lastKey: 2, step: 1},
+	"XGROUP DESTROY":        {category: RedisCommandWrite, firstKey: 2, lastKey: 2, step: 1},
+	"XGROUP DELCONSUMER":    {category: RedisCommandWrite, firstKey: 2, lastKey: 2, step: 1},
+	"XGROUP CREATECONSUMER": {category: RedisCommandWrite, firstKey: 2, lastKey: 2, step: 1},
+	// HyperLogLog and geospatial indexes.
+	"PFCOUNT":              readKeys(),
+	"PFADD":                writeKey(),
+	"PFMERGE":              writeKeys(),
+	"GEOPOS":               readKey(),
+	"GEODIST":              readKey(),
+	"GEOHASH":              readKey(),
+	"GEOSEARCH":            readKey(),
+	"GEORADIUS_RO":         readKey(),
+	"GEORADIUSBYMEMBER_RO": readKey(),
+	"GEOADD":               writeKey(),
+	"GEOSEARCHSTORE":       writeTwoKeys(),
+	// Connection and server.
+	"PING":               keyless(RedisCommandRead),
+	"ECHO":               keyless(RedisCommandRead),
+	"TIME":               keyless(RedisCommandRead),
+	"LASTSAVE":           keyless(RedisCommandRead),
+	"MEMORY USAGE":       {category: RedisCommandRead, firstKey: 2, lastKey: 2, step: 1},
+	"PUBLISH":            keyless(RedisCommandWrite),
+	"SELECT":             {category: RedisCommandAdmin},
+	"INFO":               keyless(RedisCommandAdmin),
+	"ROLE":               keyless(RedisCommandAdmin),
+	"COMMAND":            keyless(RedisCommandAdmin),
+	"CONFIG":             keyless(RedisCommandAdmin),
+	"CLIENT":             keyless(RedisCommandAdmin),
+	"SLOWLOG":            keyless(RedisCommandAdmin),
+	"LATENCY":            keyless(RedisCommandAdmin),
+	"MEMORY":             keyless(RedisCommandAdmin),
+	"PUBSUB":             keyless(RedisCommandAdmin),
+	"CLUSTER":            keyless(RedisCommandAdmin),
+	"ACL":                keyless(RedisCommandAdmin),
+	"SCRIPT":             keyless(RedisCommandAdmin),
+	"FUNCTION":           keyless(RedisCommandAdmin),
+	"MODULE LIST":        keyless(RedisCommandAdmin),
+	"SAVE":               keyless(RedisCommandAdmin),
+	"BGSAVE":             keyless(RedisCommandAdmin),
+	"BGREWRITEAOF":       keyless(RedisCommandAdmin),
+	"WAIT":               keyless(RedisCommandAdmin),
+	"CONFIG SET":         keyless(RedisCommandDangerous),
+	"CONFIG REWRITE":     keyless(RedisCommandDangerous),
+	"CONFIG RESETSTAT":   keyless(RedisCommandDangerous),
+	"CLIENT KILL":        keyless(RedisCommandDangerous),
+	"CLIENT PAUSE":       keyless(RedisCommandDangerous),
+	"CLUSTER RESET":      keyless(RedisCommandDangerous),
+	"CLUSTER FAILOVER":   keyless(RedisCommandDangerous),
+	"CLUSTER FORGET":     keyless(RedisCommandDangerous),
+	"CLUSTER FLUSHSLOTS": keyless(RedisCommandDangerous),
+	"ACL SETUSER":        keyless(RedisCommandDangerous),
+	"ACL DELUSER":        keyless(RedisCommandDangerous),
+	"ACL LOAD":           keyless(RedisCommandDangerous),
+	"SCRIPT FLUSH":       keyless(RedisCommandDangerous),
+	"SCRIPT KILL":        keyless(RedisCommandDangerous),
+	"FUNCTION FLUSH":     keyless(RedisCommandDangerous),
+	"FUNCTION DELETE":    keyless(RedisCommandDangerous),
+	"FUNCTION RESTORE":   keyless(RedisCommandDangerous),
+	"FUNCTION KILL":      keyless(RedisCommandDangerous),
+	"MODULE":             keyless(RedisCommandDangerous),
+	"SHUTDOWN":           keyless(RedisCommandDangerous),
+	"DEBUG":              keyless(RedisCommandDangerous),
+	"MONITOR":            keyless(RedisCommandDangerous),
+	"SYNC":               keyless(RedisCommandDangerous),
+	"PSYNC":              keyless(RedisCommandDangerous),
+	"REPLICAOF":          keyless(RedisCommandDangerous),
+	"SLAVEOF":            keyless(RedisCommandDangerous),
+	"FAILOVER":           keyless(RedisCommandDangerous),
+	// The scripts can access any key regardless of the declared keys.
+	"EVAL":       {category: RedisCommandDangerous},
+	"EVALSHA":    {category: RedisCommandDangerous},
+	"EVAL_RO":    {category: RedisCommandDangerous},
+	"EVALSHA_RO": {category: RedisCommandDangerous},
+	"FCALL":      {category: RedisCommandDangerous},
+	"FCALL_RO":   {category: RedisCommandDangerous},
+}
+
+// redisContainerCommands are the commands whose first argument is a subcommand.
+var redisContainerCommands = map[string]bool{
+	"ACL":      true,
+	"CLIENT":   true,
+	"CLUSTER":  true,
+	"COMMAND":  true,
+	"CONFIG":   true,
+	"FUNCTION": true,
+	"LATENCY":  true,
+	"MEMORY":   true,
+	"MODULE":   true,
+	"OBJECT":   true,
+	"PUBSUB":   true,
+	"SCRIPT":   true,
+	"SLOWLOG":  true,
+	"XGROUP":   true,
+	"XINFO":    true,
+}
+
+// ParseRedisCommands parses the Redis commands in the redis-cli syntax, one command per line.
+// The arguments are separated by spaces and can be quoted by double quotes with escapes or single quotes.
+func ParseRedisCommands(statement string) ([]*RedisCommand, error) {
+	var commands []*RedisCommand
+	for i, line := range strings.Split(statement, "\n") {
+		arguments, err := splitRedisArguments(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse line %d", i+1)
+		}
+		if len(arguments) == 0 {
+			continue
+		}
+		commands = append(commands, newRedisCommand(arguments))
+	}
+	return commands, nil
+}
+
+func newRedisCommand(arguments []string) *RedisCommand {
+	name := strings.ToUpper(arguments[0])
+	if redisContainerCommands[name] && len(arguments) > 1 {
+		name = name + " " + strings.ToUpper(arguments[1])
+	}
+	command := &RedisCommand{
+		Name:      name,
+		Arguments: arguments,
+	}
+	spec, ok := redisCommands[name]
+	if !ok {
+		spec, ok = redisCommands[strings.ToUpper(arguments[0])]
+	}
+	if !ok {
+		// The unknown commands may change the data.
+		command.Category = RedisCommandWrite
+		command.UnknownKeys = true
+		return command
+	}
+	command.Category = spec.category
+	command.Keys, command.Patterns, command.UnknownKeys = spec.extract(arguments)
+	return command
+}
+
+// extract returns the keys and the key patterns in the arguments, and whether the keys can't be determined.
+func (spec redisKeySpec) extract(arguments []string) ([]string, []string, bool) {
+	if spec.keyless {
+		if !spec.matchPattern {
+			return nil, nil, false
+		}
+		var patterns []string
+		if strings.ToUpper(arguments[0]) == "KEYS" {
+			patterns = append(patterns, arguments[1:]...)
+		}
+		for i := 1; i+1 < len(arguments); i++ {
+			if strings.ToUpper(arguments[i]) == "MATCH" {
+				patterns = append(patterns, arguments[i+1])
+			}
+		}
+		if len(patterns) == 0 {
+			// All keys are matched without a pattern.
+			patterns = append(patterns, "*")
+		}
+		return nil, patterns, false
+	}
+
+	var keys []string
+	if spec.streams {
+		for i := 1; i < len(arguments); i++ {
+			if strings.ToUpper(arguments[i]) == "STREAMS" {
+				rest := arguments[i+1:]
+				return rest[:len(rest)/2], nil, false
+			}
+		}
+		return nil, nil, true
+	}
+	if spec.firstKey > 0 {
+		last := spec.lastKey
+		if last < 0 {
+			last += len(arguments)
+		}
+		for i := spec.firstKey; i <= last && i < len(arguments); i += spec.step {
+			keys = append(keys, arguments[i])
+		}
+	}
+	if spec.numKeys > 0 {
+		if spec.numKeys >= len(arguments) {
+			return nil, nil, true
+		}
+		n, err := strconv.Atoi(arguments[spec.numKeys])
+		if err != nil || n < 0 || spec.numKeys+n >= len(arguments) {
+			return nil, nil, true
+		}
+		keys = append(keys, arguments[spec.numKeys+1:spec.numKeys+1+n]...)
+	}
+	if spec.firstKey == 0 && spec.numKeys == 0 {
+		return nil, nil, true
+	}
+	if spec.sort {
+		for i := 2; i+1 < len(arguments); i++ {
+			switch strings.ToUpper(arguments[i]) {
+			case "BY", "GET":
+				// The pattern with * reads the keys substituting * with the elements, "GET #" returns the elements themselves.
+				if strings.Contains(arguments[i+1], "*") {
+					return nil, nil, true
+				}
+				i++
+			case "STORE":
+				keys = append(keys, arguments[i+1])
+				i++
+			case "LIMIT":
+				i += 2
+			}
+		}
+	}
+	return keys, nil, false
+}
+
+// CheckRedisKeyPatterns checks the keys accessed by the commands match one of the glob-style key patterns.
+// The key patterns matched by the commands such as SCAN must be covered by one of the key patterns.
+// The keys are not restricted if the key patterns are empty.
+func CheckRedisKeyPatterns(commands []*RedisCommand, keyPatterns []string) error {
+	if len(keyPatterns) == 0 {
+		return nil
+	}
+	allowed := func(key string) bool {
+		for _, keyPattern := range keyPatterns {
+			if MatchRedisPattern(keyPattern, key) {
+				return true
+			}
+		}
+		return false
+	}
+	covered := func(pattern string) bool {
+		for _, keyPattern := range keyPatterns {
+			if CoverRedisPattern(keyPattern, pattern) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, command := range commands {
+		if command.UnknownKeys {
+			return errors.Errorf("cannot determine the keys accessed by %s, only the commands accessing the keys matching %s are allowed", command.Name, strings.Join(keyPatterns, ", "))
+		}
+		for _, key := range command.Keys {
+			if !allowed(key) {
+				return errors.Errorf("key %q accessed by %s doesn't match any of the allowed key patterns %s", key, command.Name, strings.Join(keyPatterns, ", "))
+			}
+		}
+		for _, pattern := range command.Patterns {
+			if !covered(pattern) {
+				return errors.Errorf("pattern %q of %s isn't covered by any of the allowed key patterns %s", pattern, command.Name, strings.Join(keyPatterns, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// MatchRedisPattern reports whether the key matches the glob-style pattern in the same way as the KEYS command.
+// The pattern supports *, ?, [abc], [^abc], [a-z] and escaping the special characters with \.
+func MatchRedisPattern(pattern string, key string) bool {
+	p, k := []rune(pattern), []rune(key)
+	for len(p) > 0 {
+		switch p[0] {
+		case '*':
+			for len(p) > 1 && p[1] == '*' {
+				p = p[1:]
+			}
+			if len(p) == 1 {
+				return true
+			}
+			for i := 0; i <= len(k); i++ {
+				if MatchRedisPattern(string(p[1:]), string(k[i:])) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(k) == 0 {
+				return false
+			}
+			k = k[1:]
+		case '[':
+			if len(k) == 0 {
+				return false
+			}
+			p = p[1:]
+			not := len(p) > 0 && p[0] == '^'
+			if not {
+				p = p[1:]
+			}
+			match := false
+			for len(p) > 0 && p[0] != ']' {
+				switch {
+				case p[0] == '\\' && len(p) > 1:
+					p = p[1:]
+					if p[0] == k[0] {
+						match = true
+					}
+				case len(p) > 2 && p[1] == '-' && p[2] != ']':
+					start, end := p[0], p[2]
+					if start > end {
+						start, end = end, start
+					}
+					if k[0] >= start && k[0] <= end {
+						match = true
+					}
+					p = p[2:]
+				default:
+					if p[0] == k[0] {
+						match = true
+					}
+				}
+				p = p[1:]
+			}
+			if match == not {
+				return false
+			}
+			k = k[1:]
+			if len(p) == 0 {
+				// The unterminated bracket is treated as the end of the pattern.
+				return len(k) == 0
+			}
+		case '\\':
+			if len(p) > 1 {
+				p = p[1:]
+			}
+			fallthrough
+		default:
+			if len(k) == 0 || p[0] != k[0] {
+				return false
+			}
+			k = k[1:]
+		}
+		p = p[1:]
+	}
+	return len(k) == 0
+}
+
+// CoverRedisPattern reports whether all keys matching the glob-style pattern also match the allowed pattern.
+// It is conservative: false may be returned for a covered pattern if the coverage can't be determined cheaply,
+// such as a * in the pattern that is not matched by a * in the allowed pattern.
+func CoverRedisPattern(allowed string, pattern string) bool {
+	return coverRedisPatternTokens(parseRedisPattern(allowed), parseRedisPattern(pattern))
+}
+
+func coverRedisPatternTokens(allowed, pattern []redisPatternToken) bool {
+	if len(allowed) == 0 {
+		return len(pattern) == 0
+	}
+	if allowed[0].star {
+		// The * of the allowed pattern matches the empty string, or absorbs the first token of the pattern including a *.
+		if coverRedisPatternTokens(allowed[1:], pattern) {
+			return true
+		}
+		return len(pattern) > 0 && coverRedisPatternTokens(allowed, pattern[1:])
+	}
+	if len(pattern) == 0 || pattern[0].star {
+		return false
+	}
+	return allowed[0].cover(pattern[0]) && coverRedisPatternTokens(allowed[1:], pattern[1:])
+}
+
+// maxRedisPatternRange is the maximum number of characters of a range compared character by character.
+const maxRedisPatternRange = 1 << 16
+
+// redisPatternToken is a token of a glob-style pattern: a *, or a character matched by ?, a literal or a [...] class.
+type redisPatternToken struct {
+	star bool
+	// any is true for ?.
+	any bool
+	// not is true for the negated class [^...].
+	not bool
+	// ranges are the characters of the literal or the class, a literal c is the range {c, c}.
+	ranges [][2]rune
+}
+
+func (t redisPatternToken) inRanges(r rune) bool {
+	for _, rg := range t.ranges {
+		if r >= rg[0] && r <= rg[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// cover reports whether all characters matched by the token p are matched by the token t, neither is a *.
+func (t redisPatternToken) cover(p redisPatternToken) bool {
+	if t.any {
+		return true
+	}
+	if p.any {
+		return false
+	}
+	switch {
+	case !p.not && !t.not:
+		for _, rg := range p.ranges {
+			if rg[1]-rg[0] > maxRedisPatternRange {
+				return false
+			}
+			for r := rg[0]; r <= rg[1]; r++ {
+				if !t.inRanges(r) {
+					return false
+				}
+			}
+		}
+		return true
+	case !p.not && t.not:
+		for _, rg := range p.ranges {
+			for _, excluded := range t.ranges {
+				if rg[0] <= excluded[1] && excluded[0] <= rg[1] {
+					return false
+				}
+			}
+		}
+		return true
+	case p.not && t.not:
+		// The characters excluded by t must be excluded by p.
+		for _, rg := range t.ranges {
+			if rg[1]-rg[0] > maxRedisPatternRange {
+				return false
+			}
+			for r := rg[0]; r <= rg[1]; r++ {
+				if !p.inRanges(r) {
+					return false
+				}
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRedisPattern parses the glob-style pattern into the tokens in the same way as MatchRedisPattern.
+func parseRedisPattern(pattern string) []redisPatternToken {
+	var tokens []redisPatternToken
+	p := []rune(pattern)
+	for len(p) > 0 {
+		switch p[0] {
+		case '*':
+			if len(tokens) == 0 || !tokens[len(tokens)-1].star {
+				tokens = append(tokens, redisPatternToken{star: true})
+			}
+		case '?':
+			tokens = append(tokens, redisPatternToken{any: true})
+		case '[':
+			p = p[1:]
+			token := redisPatternToken{}
+			if len(p) > 0 && p[0] == '^' {
+				token.not = true
+				p = p[1:]
+			}
+			for len(p) > 0 && p[0] != ']' {
+				switch {
+				case p[0] == '\\' && len(p) > 1:
+					p = p[1:]
+					token.ranges = append(token.ranges, [2]rune{p[0], p[0]})
+				case len(p) > 2 && p[1] == '-' && p[2] != ']':
+					start, end := p[0], p[2]
+					if start > end {
+						start, end = end, start
+					}
+					token.ranges = append(token.ranges, [2]rune{start, end})
+					p = p[2:]
+				default:
+					token.ranges = append(token.ranges, [2]rune{p[0], p[0]})
+				}
+				p = p[1:]
+			}
+			tokens = append(tokens, token)
+			if len(p) == 0 {
+				// The unterminated bracket is treated as the end of the pattern.
+				return tokens
+			}
+		case '\\':
+			if len(p) > 1 {
+				p = p[1:]
+			}
+			tokens = append(tokens, redisPatternToken{ranges: [][2]rune{{p[0], p[0]}}})
+		default:
+			tokens = append(tokens, redisPatternToken{ranges: [][2]rune{{p[0], p[0]}}})
+		}
+		p = p[1:]
+	}
+	return tokens
+}
+
+// splitRedisArguments splits the line into the arguments in the same way as redis-cli.
+func splitRedisArguments(line string) ([]string, error) {
+	var arguments []string
+	s := []rune(line)
+	for i := 0; ; {
+		for i < len(s) && unicode.IsSpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return arguments, nil
+		}
+		var b strings.Builder
+		switch s[i] {
+		case '"':
+			i++
+			for ; ; i++ {
+				if i == len(s) {
+					return nil, errors.New("unbalanced double quotes")
+				}
+				if s[i] == '"' {
+					break
+				}
+				if s[i] != '\\' || i+1 == len(s) {
+					b.WriteRune(s[i])
+					continue
+				}
+				i++
+				switch s[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				case 'b':
+					b.WriteByte('\b')
+				case 'a':
+					b.WriteByte('\a')
+				case 'x':
+					if i+2 < len(s) {
+						if v, err := strconv.ParseUint(string(s[i+1:i+3]), 16, 8); err == nil {
+							b.WriteByte(byte(v))
+							i += 2
+							continue
+						}
+					}
+					b.WriteRune(s[i])
+				default:
+					b.WriteRune(s[i])
+				}
+			}
+			i++
+		case '\'':
+			i++
+			for ; ; i++ {
+				if i == len(s) {
+					return nil, errors.New("unbalanced single quotes")
+				}
+				if s[i] == '\'' {
+					break
+				}
+				if s[i] == '\\' && i+1 < len(s) && s[i+1] == '\'' {
+					i++
+				}
+				b.WriteRune(s[i])
+			}
+			i++
+		default:
+			for ; i < len(s) && !unicode.IsSpace(s[i]); i++ {
+				b.WriteRune(s[i])
+			}
+		}
+		if i < len(s) && !unicode.IsSpace(s[i]) {
+			return nil, errors.New("closing quote must be followed by a space")
+		}
+		arguments = append(arguments, b.String())
+	}
+}
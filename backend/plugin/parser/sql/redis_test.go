@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRedisCommands(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []*RedisCommand
+	}{
+		{
+			statement: "get user:1\n\n  set 'user:2' \"a \\\"b\\\"\\n\"",
+			want: []*RedisCommand{
+				{Name: "GET", Arguments: []string{"get", "user:1"}, Category: RedisCommandRead, Keys: []string{"user:1"}},
+				{Name: "SET", Arguments: []string{"set", "user:2", "a \"b\"\n"}, Category: RedisCommandWrite, Keys: []string{"user:2"}},
+			},
+		},
+		{
+			statement: "MSET a 1 b 2\nZUNIONSTORE out 2 x y WEIGHTS 1 2\nXREAD COUNT 1 STREAMS s1 s2 0 0",
+			want: []*RedisCommand{
+				{Name: "MSET", Arguments: []string{"MSET", "a", "1", "b", "2"}, Category: RedisCommandWrite, Keys: []string{"a", "b"}},
+				{Name: "ZUNIONSTORE", Arguments: []string{"ZUNIONSTORE", "out", "2", "x", "y", "WEIGHTS", "1", "2"}, Category: RedisCommandWrite, Keys: []string{"out", "x", "y"}},
+				{Name: "XREAD", Arguments: []string{"XREAD", "COUNT", "1", "STREAMS", "s1", "s2", "0", "0"}, Category: RedisCommandRead, Keys: []string{"s1", "s2"}},
+			},
+		},
+		{
+			statement: "scan 0 MATCH user:* COUNT 100\nKEYS *\nconfig set maxmemory 0\nconfig get maxmemory\nFLUSHALL\nSELECT 1\nJSON.GET doc",
+			want: []*RedisCommand{
+				{Name: "SCAN", Arguments: []string{"scan", "0", "MATCH", "user:*", "COUNT", "100"}, Category: RedisCommandRead, Patterns: []string{"user:*"}},
+				{Name: "KEYS", Arguments: []string{"KEYS", "*"}, Category: RedisCommandDangerous, Patterns: []string{"*"}},
+				{Name: "CONFIG SET", Arguments: []string{"config", "set", "maxmemory", "0"}, Category: RedisCommandDangerous},
+				{Name: "CONFIG GET", Arguments: []string{"config", "get", "maxmemory"}, Category: RedisCommandAdmin},
+				{Name: "FLUSHALL", Arguments: []string{"FLUSHALL"}, Category: RedisCommandDangerous},
+				{Name: "SELECT", Arguments: []string{"SELECT", "1"}, Category: RedisCommandAdmin, UnknownKeys: true},
+				{Name: "JSON.GET", Arguments: []string{"JSON.GET", "doc"}, Category: RedisCommandWrite, UnknownKeys: true},
+			},
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		got, err := ParseRedisCommands(test.statement)
+		a.NoError(err, test.statement)
+		a.Equal(test.want, got, test.statement)
+	}
+
+	for _, statement := range []string{`GET "user`, `GET 'user`, `GET "user"1`} {
+		_, err := ParseRedisCommands(statement)
+		a.Error(err, statement)
+	}
+}
+
+func TestCheckRedisKeyPatterns(t *testing.T) {
+	tests := []struct {
+		statement string
+		wantErr   bool
+	}{
+		{statement: "GET user:1\nHGETALL session:abc\nDBSIZE"},
+		{statement: "MGET user:1 order:1", wantErr: true},
+		{statement: "SCAN 0 MATCH user:1*"},
+		{statement: "SCAN 0 MATCH user*", wantErr: true},
+		{statement: "SCAN 0 MATCH u[s]er:*"},
+		{statement: "SCAN 0 MATCH [us]ser:*", wantErr: true},
+		{statement: "SCAN 0", wantErr: true},
+		{statement: "SELECT 1", wantErr: true},
+		{statement: "SORT_RO user:ids LIMIT 0 10 ALPHA"},
+		{statement: "SORT user:ids BY nosort STORE user:sorted"},
+		{statement: "SORT user:ids STORE order:sorted", wantErr: true},
+		{statement: "SORT_RO user:ids BY user:*:age", wantErr: true},
+		{statement: "SORT_RO user:ids GET # GET order:*->total", wantErr: true},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		commands, err := ParseRedisCommands(test.statement)
+		a.NoError(err)
+		err = CheckRedisKeyPatterns(commands, []string{"user:*", "session:*"})
+		if test.wantErr {
+			a.Error(err, test.statement)
+		} else {
+			a.NoError(err, test.statement)
+		}
+		a.NoError(CheckRedisKeyPatterns(commands, nil))
+	}
+}
+
+func TestCoverRedisPattern(t *testing.T) {
+	tests := []struct {
+		allowed string
+		pattern string
+		want    bool
+	}{
+		{allowed: "a*", pattern: "a*", want: true},
+		{allowed: "a*", pattern: "ab*", want: true},
+		{allowed: "a*", pattern: "a?c", want: true},
+		{allowed: "a*", pattern: "a[*x]", want: true},
+		{allowed: "*", pattern: "*", want: true},
+		{allowed: "a*b", pattern: "a*xb", want: true},
+		{allowed: "a?", pattern: "ab", want: true},
+		{allowed: "a?", pattern: "a[bc]", want: true},
+		{allowed: "a[a-z]", pattern: "a[b-d]", want: true},
+		{allowed: "a[^0-9]", pattern: "a[a-z]", want: true},
+		{allowed: "a[^0-9]", pattern: "a[^0-9x]", want: true},
+		{allowed: "a\\*", pattern: "a\\*", want: true},
+		{allowed: "a?", pattern: "a*", want: false},
+		{allowed: "a?", pattern: "a", want: false},
+		{allowed: "a\\*", pattern: "a[*x]", want: false},
+		{allowed: "a\\*", pattern: "a*", want: false},
+		{allowed: "a[a-z]", pattern: "a?", want: false},
+		{allowed: "a[a-z]", pattern: "a[^0-9]", want: false},
+		{allowed: "a[^0-9]", pattern: "a[0-z]", want: false},
+		{allowed: "a[^0-9]", pattern: "a[^0-8]", want: false},
+		{allowed: "a*b", pattern: "a*", want: false},
+		{allowed: "ab*", pattern: "a*", want: false},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, CoverRedisPattern(test.allowed, test.pattern), "%s %s", test.allowed, test.pattern)
+	}
+}
+
+func TestMatchRedisPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		want    bool
+	}{
+		{pattern: "*", key: "", want: true},
+		{pattern: "user:*", key: "user:1", want: true},
+		{pattern: "user:*", key: "order:1", want: false},
+		{pattern: "h?llo", key: "hello", want: true},
+		{pattern: "h?llo", key: "hllo", want: false},
+		{pattern: "h[ae]llo", key: "hallo", want: true},
+		{pattern: "h[^e]llo", key: "hello", want: false},
+		{pattern: "h[a-b]llo", key: "hbllo", want: true},
+		{pattern: "user\\*", key: "user*", want: true},
+		{pattern: "user\\*", key: "user1", want: false},
+		{pattern: "a*b*c", key: "axxbyyc", want: true},
+		{pattern: "a*b*c", key: "axxbyy", want: false},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, MatchRedisPattern(test.pattern, test.key), "%s %s", test.pattern, test.key)
+	}
+}
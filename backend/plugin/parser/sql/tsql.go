@@ -540,6 +540,39 @@ func ParseTSQL(statement string) (antlr.Tree, error) {
 	return tree, nil
 }
 
+// SplitTSQLBatches splits the statement into batches by the GO separator lines, like sqlcmd does.
+// It returns the whole statement as the only batch if there is no GO separator.
+// Empty batches are skipped.
+func SplitTSQLBatches(statement string) []string {
+	var batches []string
+	var buf strings.Builder
+	flush := func() {
+		if batch := strings.TrimSpace(buf.String()); batch != "" {
+			batches = append(batches, batch)
+		}
+		buf.Reset()
+	}
+	for _, line := range strings.SplitAfter(statement, "\n") {
+		if strings.EqualFold(strings.TrimRight(strings.TrimSpace(line), ";"), "GO") {
+			flush()
+			continue
+		}
+		_, _ = buf.WriteString(line)
+	}
+	flush()
+	return batches
+}
+
+// HasTSQLBatchSeparator returns true if the statement contains any GO separator line.
+func HasTSQLBatchSeparator(statement string) bool {
+	for _, line := range strings.Split(statement, "\n") {
+		if strings.EqualFold(strings.TrimRight(strings.TrimSpace(line), ";"), "GO") {
+			return true
+		}
+	}
+	return false
+}
+
 // NormalizeTSQLTableName returns the normalized table name.
 func NormalizeTSQLTableName(ctx tsqlparser.ITable_nameContext, fallbackDatabaseName, fallbackSchemaName string, _ bool) string {
 	database := fallbackDatabaseName
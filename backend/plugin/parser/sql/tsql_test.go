@@ -84,3 +84,35 @@ func TestExtractMSSQLNormalizedResourceListFromSelectStatement(t *testing.T) {
 		require.Equal(t, test.want, res, "for statement: %v", test.statement)
 	}
 }
+
+func TestSplitTSQLBatches(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []string
+		separated bool
+	}{
+		{
+			statement: "CREATE TABLE t(a INT);\nINSERT INTO t VALUES (1);",
+			want:      []string{"CREATE TABLE t(a INT);\nINSERT INTO t VALUES (1);"},
+		},
+		{
+			statement: "CREATE TABLE t(a INT);\nGO\nCREATE PROCEDURE p AS\nBEGIN\n  SELECT 1;\n  SELECT 2;\nEND;\n  go  \nGO\n",
+			want: []string{
+				"CREATE TABLE t(a INT);",
+				"CREATE PROCEDURE p AS\nBEGIN\n  SELECT 1;\n  SELECT 2;\nEND;",
+			},
+			separated: true,
+		},
+		{
+			statement: "SELECT 'GO' AS GOAL;\r\nGO\r\nSELECT 1;",
+			want:      []string{"SELECT 'GO' AS GOAL;", "SELECT 1;"},
+			separated: true,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, SplitTSQLBatches(test.statement), test.statement)
+		a.Equal(test.separated, HasTSQLBatchSeparator(test.statement), test.statement)
+	}
+}
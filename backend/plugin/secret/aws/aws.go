@@ -0,0 +1,162 @@
+// Package aws provides the secret manager using AWS Secrets Manager.
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/secret"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const serviceName = "secretsmanager"
+
+func init() {
+	secret.Register(storepb.DataSourceExternalSecret_AWS_SECRETS_MANAGER, newManager)
+}
+
+// Manager fetches the secret value through the AWS Secrets Manager JSON API.
+type Manager struct {
+	client      *http.Client
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	region      string
+	endpoint    string
+	config      *secret.Config
+}
+
+// newManager returns the AWS Secrets Manager of the config.
+// The AWS credentials of the Bytebase server are loaded from the environment.
+func newManager(ctx context.Context, config *secret.Config) (secret.Manager, error) {
+	if config.SecretName == "" {
+		return nil, errors.New("secret name of AWS Secrets Manager is required")
+	}
+	var optFns []func(*awsconfig.LoadOptions) error
+	if config.Region != "" {
+		optFns = append(optFns, awsconfig.WithRegion(config.Region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load AWS config")
+	}
+	if cfg.Region == "" {
+		return nil, errors.New("region of AWS Secrets Manager is required")
+	}
+	// The URL is the optional custom endpoint, e.g. the VPC endpoint.
+	endpoint := config.Url
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com", cfg.Region)
+	}
+	return &Manager{
+		client:      &http.Client{Timeout: 30 * time.Second},
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		region:      cfg.Region,
+		endpoint:    endpoint,
+		config:      config,
+	}, nil
+}
+
+type getSecretValueRequest struct {
+	SecretID string `json:"SecretId"`
+}
+
+type getSecretValueResponse struct {
+	SecretString string `json:"SecretString"`
+	Type         string `json:"__type"`
+	Message      string `json:"message"`
+}
+
+// GetCredential fetches the current version of the secret.
+// If the password key is specified, the secret string is parsed as the JSON object, such as the secrets managed by Amazon RDS.
+func (m *Manager) GetCredential(ctx context.Context) (*secret.Credential, error) {
+	resp, err := m.getSecretValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if m.config.PasswordKeyName == "" && m.config.UsernameKeyName == "" {
+		return &secret.Credential{Password: resp.SecretString}, nil
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(resp.SecretString), &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal secret %q of AWS Secrets Manager", m.config.SecretName)
+	}
+	credential := &secret.Credential{Password: resp.SecretString}
+	if m.config.PasswordKeyName != "" {
+		password, ok := data[m.config.PasswordKeyName].(string)
+		if !ok {
+			return nil, errors.Errorf("key %q not found in secret %q of AWS Secrets Manager", m.config.PasswordKeyName, m.config.SecretName)
+		}
+		credential.Password = password
+	}
+	if m.config.UsernameKeyName != "" {
+		username, ok := data[m.config.UsernameKeyName].(string)
+		if !ok {
+			return nil, errors.Errorf("key %q not found in secret %q of AWS Secrets Manager", m.config.UsernameKeyName, m.config.SecretName)
+		}
+		credential.Username = username
+	}
+	return credential, nil
+}
+
+// RenewLease is not supported because the secrets of AWS Secrets Manager are not leased.
+func (*Manager) RenewLease(context.Context, string) (time.Duration, error) {
+	return 0, errors.New("AWS Secrets Manager does not support lease")
+}
+
+// Close is a no-op because the requests are signed with the credentials of the environment.
+func (*Manager) Close(context.Context) error {
+	return nil
+}
+
+func (m *Manager) getSecretValue(ctx context.Context) (*getSecretValueResponse, error) {
+	body, err := json.Marshal(&getSecretValueRequest{SecretID: m.config.SecretName})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	credentials, err := m.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve AWS credentials")
+	}
+	payloadHash := sha256.Sum256(body)
+	if err := m.signer.SignHTTP(ctx, credentials, req, hex.EncodeToString(payloadHash[:]), serviceName, m.region, time.Now()); err != nil {
+		return nil, errors.Wrap(err, "failed to sign request")
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call AWS Secrets Manager GetSecretValue")
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response from AWS Secrets Manager")
+	}
+	var response getSecretValueResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal response from AWS Secrets Manager, status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to get secret %q from AWS Secrets Manager, status %d, %s: %s", m.config.SecretName, resp.StatusCode, response.Type, response.Message)
+	}
+	return &response, nil
+}
@@ -0,0 +1,77 @@
+// Package secret provides the external secret managers storing the credentials of the data sources.
+package secret
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+var (
+	managersMu sync.RWMutex
+	managers   = make(map[storepb.DataSourceExternalSecret_SecretType]newFunc)
+)
+
+type newFunc func(ctx context.Context, config *Config) (Manager, error)
+
+// Credential is the credential of a data source fetched from the secret manager.
+type Credential struct {
+	// Username is empty if the username is not stored in the secret, and the username of the data source is used.
+	Username string
+	Password string
+	// LeaseID is the ID of the lease of the dynamic credential. It's empty if the credential is not leased.
+	LeaseID string
+	// LeaseDuration is the remaining duration of the lease.
+	LeaseDuration time.Duration
+	// Renewable is true if the lease can be renewed.
+	Renewable bool
+}
+
+// Manager is the interface of a secret manager.
+type Manager interface {
+	// GetCredential fetches the credential from the secret manager.
+	GetCredential(ctx context.Context) (*Credential, error)
+	// RenewLease extends the lease of the credential and returns the remaining duration of the lease.
+	RenewLease(ctx context.Context, leaseID string) (time.Duration, error)
+	// Close releases the resources of the manager, e.g. revokes the token it logged in with.
+	// The manager logs in again if it's used after closing.
+	Close(ctx context.Context) error
+}
+
+// Config is the configuration of a secret manager.
+type Config struct {
+	*storepb.DataSourceExternalSecret
+	// Token is the unobfuscated Vault token.
+	Token string
+	// AppRoleSecretID is the unobfuscated secret ID of the Vault AppRole.
+	AppRoleSecretID string
+}
+
+// Register makes a secret manager available for the secret type.
+// If Register is called twice with the same secret type or if f is nil, it panics.
+func Register(secretType storepb.DataSourceExternalSecret_SecretType, f newFunc) {
+	managersMu.Lock()
+	defer managersMu.Unlock()
+	if f == nil {
+		panic("secret: Register secret manager is nil")
+	}
+	if _, dup := managers[secretType]; dup {
+		panic("secret: Register called twice for secret type " + secretType.String())
+	}
+	managers[secretType] = f
+}
+
+// New creates the secret manager specified by the config.
+func New(ctx context.Context, config *Config) (Manager, error) {
+	managersMu.RLock()
+	f, ok := managers[config.SecretType]
+	managersMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("secret: unknown secret type %q", config.SecretType)
+	}
+	return f(ctx, config)
+}
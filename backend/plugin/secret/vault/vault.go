@@ -0,0 +1,263 @@
+// Package vault provides the secret manager using the KV version 2 and database secrets engines of HashiCorp Vault.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/plugin/secret"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	defaultKVEngineName       = "secret"
+	defaultDatabaseEngineName = "database"
+	defaultPasswordKeyName    = "password"
+)
+
+func init() {
+	secret.Register(storepb.DataSourceExternalSecret_VAULT_KV_V2, newManager)
+	secret.Register(storepb.DataSourceExternalSecret_VAULT_DATABASE, newManager)
+}
+
+// Manager fetches the static secrets from the KV secrets engine, or the dynamic credentials from the database secrets engine.
+type Manager struct {
+	client *http.Client
+	config *secret.Config
+	url    string
+	engine string
+
+	// mu protects the token logged in with AppRole, which is reused until two thirds of its TTL have passed.
+	mu    sync.Mutex
+	token string
+	// tokenRefreshTime is zero if the token doesn't expire.
+	tokenRefreshTime time.Time
+}
+
+// newManager returns the Vault secret manager of the config.
+func newManager(_ context.Context, config *secret.Config) (secret.Manager, error) {
+	if config.Url == "" {
+		return nil, errors.New("URL of Vault is required")
+	}
+	switch config.AuthType {
+	case storepb.DataSourceExternalSecret_TOKEN:
+		if config.Token == "" {
+			return nil, errors.New("token of Vault is required")
+		}
+	case storepb.DataSourceExternalSecret_APP_ROLE:
+		if config.AppRoleId == "" || config.AppRoleSecretID == "" {
+			return nil, errors.New("role ID and secret ID of Vault AppRole are required")
+		}
+	default:
+		return nil, errors.Errorf("unsupported Vault auth type %q", config.AuthType)
+	}
+	engine := strings.Trim(config.EngineName, "/")
+	switch config.SecretType {
+	case storepb.DataSourceExternalSecret_VAULT_KV_V2:
+		if config.SecretName == "" {
+			return nil, errors.New("secret path of Vault KV is required")
+		}
+		if engine == "" {
+			engine = defaultKVEngineName
+		}
+	case storepb.DataSourceExternalSecret_VAULT_DATABASE:
+		if config.DatabaseRole == "" {
+			return nil, errors.New("role of Vault database secrets engine is required")
+		}
+		if engine == "" {
+			engine = defaultDatabaseEngineName
+		}
+	}
+	return &Manager{
+		client: &http.Client{Timeout: 30 * time.Second},
+		config: config,
+		url:    strings.TrimSuffix(config.Url, "/"),
+		engine: engine,
+	}, nil
+}
+
+type vaultResponse struct {
+	LeaseID       string          `json:"lease_id"`
+	LeaseDuration int64           `json:"lease_duration"`
+	Renewable     bool            `json:"renewable"`
+	Data          json.RawMessage `json:"data"`
+	Auth          *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+// GetCredential fetches the credential.
+// The KV secret is read from the latest version, and the dynamic credential is generated with a new lease.
+func (m *Manager) GetCredential(ctx context.Context) (*secret.Credential, error) {
+	token, err := m.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if m.config.SecretType == storepb.DataSourceExternalSecret_VAULT_DATABASE {
+		resp, err := m.call(ctx, http.MethodGet, fmt.Sprintf("%s/creds/%s", m.engine, m.config.DatabaseRole), token, nil)
+		if err != nil {
+			return nil, err
+		}
+		var data struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		if err := json.Unmarshal(resp.Data, &data); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal database credential from Vault")
+		}
+		return &secret.Credential{
+			Username:      data.Username,
+			Password:      data.Password,
+			LeaseID:       resp.LeaseID,
+			LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+			Renewable:     resp.Renewable,
+		}, nil
+	}
+
+	resp, err := m.call(ctx, http.MethodGet, fmt.Sprintf("%s/data/%s", m.engine, strings.Trim(m.config.SecretName, "/")), token, nil)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal KV secret from Vault")
+	}
+	passwordKeyName := m.config.PasswordKeyName
+	if passwordKeyName == "" {
+		passwordKeyName = defaultPasswordKeyName
+	}
+	password, ok := data.Data[passwordKeyName].(string)
+	if !ok {
+		return nil, errors.Errorf("key %q not found in Vault secret %q", passwordKeyName, m.config.SecretName)
+	}
+	credential := &secret.Credential{Password: password}
+	if m.config.UsernameKeyName != "" {
+		username, ok := data.Data[m.config.UsernameKeyName].(string)
+		if !ok {
+			return nil, errors.Errorf("key %q not found in Vault secret %q", m.config.UsernameKeyName, m.config.SecretName)
+		}
+		credential.Username = username
+	}
+	return credential, nil
+}
+
+// RenewLease renews the lease of the dynamic credential.
+func (m *Manager) RenewLease(ctx context.Context, leaseID string) (time.Duration, error) {
+	token, err := m.login(ctx)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := m.call(ctx, http.MethodPut, "sys/leases/renew", token, map[string]string{"lease_id": leaseID})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(resp.LeaseDuration) * time.Second, nil
+}
+
+// Close revokes the token logged in with AppRole.
+func (m *Manager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	token := m.token
+	m.token, m.tokenRefreshTime = "", time.Time{}
+	m.mu.Unlock()
+	if token == "" {
+		return nil
+	}
+	return m.revoke(ctx, token)
+}
+
+// login returns the token to access Vault, logging in with AppRole if configured.
+// The token logged in with AppRole is reused, and revoked once it's replaced by a new one.
+func (m *Manager) login(ctx context.Context) (string, error) {
+	if m.config.AuthType == storepb.DataSourceExternalSecret_TOKEN {
+		return m.config.Token, nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if m.token != "" && (m.tokenRefreshTime.IsZero() || now.Before(m.tokenRefreshTime)) {
+		return m.token, nil
+	}
+
+	resp, err := m.call(ctx, http.MethodPost, "auth/approle/login", "", map[string]string{
+		"role_id":   m.config.AppRoleId,
+		"secret_id": m.config.AppRoleSecretID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return "", errors.New("empty client token from Vault AppRole login")
+	}
+	if m.token != "" {
+		if err := m.revoke(ctx, m.token); err != nil {
+			slog.Warn("failed to revoke the Vault token", log.BBError(err))
+		}
+	}
+	m.token, m.tokenRefreshTime = resp.Auth.ClientToken, time.Time{}
+	if resp.Auth.LeaseDuration > 0 {
+		m.tokenRefreshTime = now.Add(time.Duration(resp.Auth.LeaseDuration) * time.Second * 2 / 3)
+	}
+	return m.token, nil
+}
+
+func (m *Manager) revoke(ctx context.Context, token string) error {
+	_, err := m.call(ctx, http.MethodPost, "auth/token/revoke-self", token, nil)
+	return err
+}
+
+func (m *Manager) call(ctx context.Context, method, path, token string, request any) (*vaultResponse, error) {
+	var body io.Reader
+	if request != nil {
+		content, err := json.Marshal(request)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal request")
+		}
+		body = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/%s", m.url, path), body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to request Vault %s", path)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNoContent {
+		return &vaultResponse{}, nil
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response from Vault")
+	}
+	var response vaultResponse
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal response from Vault, status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to request Vault %s, status %d, errors: %s", path, resp.StatusCode, strings.Join(response.Errors, "; "))
+	}
+	return &response, nil
+}
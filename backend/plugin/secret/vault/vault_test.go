@@ -0,0 +1,222 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/secret"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+const (
+	testToken           = "root"
+	testAppRoleID       = "bytebase-role"
+	testAppRoleSecretID = "bytebase-secret"
+	testAppRoleToken    = "approle-token"
+)
+
+// vaultServer is a stand-in of the Vault dev server with the KV version 2 and database secrets engines.
+type vaultServer struct {
+	mu     sync.Mutex
+	leases map[string]bool
+	// logins is the number of the AppRole logins, and revoked is whether the AppRole token is revoked.
+	logins  int
+	revoked bool
+}
+
+func (s *vaultServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	writeError := func(status int, message string) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{message}})
+	}
+	if r.URL.Path == "/v1/auth/approle/login" {
+		var request map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(http.StatusBadRequest, err.Error())
+			return
+		}
+		if request["role_id"] != testAppRoleID || request["secret_id"] != testAppRoleSecretID {
+			writeError(http.StatusBadRequest, "invalid role or secret ID")
+			return
+		}
+		s.mu.Lock()
+		s.logins++
+		s.revoked = false
+		s.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": testAppRoleToken, "lease_duration": 3600}})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token := r.Header.Get("X-Vault-Token"); token != testToken && (token != testAppRoleToken || s.revoked) {
+		writeError(http.StatusForbidden, "permission denied")
+		return
+	}
+	switch r.URL.Path {
+	case "/v1/auth/token/revoke-self":
+		if r.Header.Get("X-Vault-Token") == testAppRoleToken {
+			s.revoked = true
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "/v1/secret/data/prod/mysql":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{
+				"data":     map[string]any{"user": "bytebase", "password": "s3cret"},
+				"metadata": map[string]any{"version": 3},
+			},
+		})
+	case "/v1/database/creds/readonly":
+		leaseID := fmt.Sprintf("database/creds/readonly/%d", len(s.leases))
+		s.leases[leaseID] = true
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"lease_id":       leaseID,
+			"lease_duration": 3600,
+			"renewable":      true,
+			"data":           map[string]any{"username": "v-readonly-1", "password": "dynamic"},
+		})
+	case "/v1/sys/leases/renew":
+		var request map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(http.StatusBadRequest, err.Error())
+			return
+		}
+		if !s.leases[request["lease_id"]] {
+			writeError(http.StatusBadRequest, "lease not found")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"lease_id": request["lease_id"], "lease_duration": 1800, "renewable": true})
+	default:
+		writeError(http.StatusNotFound, "no handler for route")
+	}
+}
+
+func newTestManager(t *testing.T, url string, externalSecret *storepb.DataSourceExternalSecret, token, appRoleSecretID string) secret.Manager {
+	externalSecret.Url = url
+	manager, err := newManager(context.Background(), &secret.Config{
+		DataSourceExternalSecret: externalSecret,
+		Token:                    token,
+		AppRoleSecretID:          appRoleSecretID,
+	})
+	require.NoError(t, err)
+	return manager
+}
+
+func TestKVSecret(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	server := httptest.NewServer(&vaultServer{leases: make(map[string]bool)})
+	defer server.Close()
+
+	manager := newTestManager(t, server.URL, &storepb.DataSourceExternalSecret{
+		SecretType:      storepb.DataSourceExternalSecret_VAULT_KV_V2,
+		AuthType:        storepb.DataSourceExternalSecret_TOKEN,
+		SecretName:      "prod/mysql",
+		UsernameKeyName: "user",
+	}, testToken, "")
+	credential, err := manager.GetCredential(ctx)
+	a.NoError(err)
+	a.Equal(&secret.Credential{Username: "bytebase", Password: "s3cret"}, credential)
+
+	manager = newTestManager(t, server.URL, &storepb.DataSourceExternalSecret{
+		SecretType:      storepb.DataSourceExternalSecret_VAULT_KV_V2,
+		AuthType:        storepb.DataSourceExternalSecret_TOKEN,
+		SecretName:      "prod/mysql",
+		PasswordKeyName: "pass",
+	}, testToken, "")
+	_, err = manager.GetCredential(ctx)
+	a.ErrorContains(err, `key "pass" not found`)
+
+	manager = newTestManager(t, server.URL, &storepb.DataSourceExternalSecret{
+		SecretType: storepb.DataSourceExternalSecret_VAULT_KV_V2,
+		AuthType:   storepb.DataSourceExternalSecret_TOKEN,
+		SecretName: "prod/mysql",
+	}, "wrong", "")
+	_, err = manager.GetCredential(ctx)
+	a.ErrorContains(err, "permission denied")
+}
+
+func TestDatabaseCredential(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	server := httptest.NewServer(&vaultServer{leases: make(map[string]bool)})
+	defer server.Close()
+
+	manager := newTestManager(t, server.URL, &storepb.DataSourceExternalSecret{
+		SecretType:   storepb.DataSourceExternalSecret_VAULT_DATABASE,
+		AuthType:     storepb.DataSourceExternalSecret_APP_ROLE,
+		AppRoleId:    testAppRoleID,
+		DatabaseRole: "readonly",
+	}, "", testAppRoleSecretID)
+	credential, err := manager.GetCredential(ctx)
+	a.NoError(err)
+	a.Equal(&secret.Credential{
+		Username:      "v-readonly-1",
+		Password:      "dynamic",
+		LeaseID:       "database/creds/readonly/0",
+		LeaseDuration: time.Hour,
+		Renewable:     true,
+	}, credential)
+
+	leaseDuration, err := manager.RenewLease(ctx, credential.LeaseID)
+	a.NoError(err)
+	a.Equal(30*time.Minute, leaseDuration)
+	_, err = manager.RenewLease(ctx, "database/creds/readonly/1")
+	a.ErrorContains(err, "lease not found")
+}
+
+func TestAppRoleToken(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	vault := &vaultServer{leases: make(map[string]bool)}
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	manager := newTestManager(t, server.URL, &storepb.DataSourceExternalSecret{
+		SecretType: storepb.DataSourceExternalSecret_VAULT_KV_V2,
+		AuthType:   storepb.DataSourceExternalSecret_APP_ROLE,
+		AppRoleId:  testAppRoleID,
+		SecretName: "prod/mysql",
+	}, "", testAppRoleSecretID)
+	// The token is reused by the fetches until it's about to expire.
+	for i := 0; i < 3; i++ {
+		_, err := manager.GetCredential(ctx)
+		a.NoError(err)
+	}
+	a.Equal(1, vault.logins)
+
+	// The token is revoked on close, and the manager logs in again if it's used afterwards.
+	a.NoError(manager.Close(ctx))
+	a.True(vault.revoked)
+	_, err := manager.GetCredential(ctx)
+	a.NoError(err)
+	a.Equal(2, vault.logins)
+	a.False(vault.revoked)
+}
+
+func TestNewManagerValidation(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+
+	_, err := newManager(ctx, &secret.Config{DataSourceExternalSecret: &storepb.DataSourceExternalSecret{
+		SecretType: storepb.DataSourceExternalSecret_VAULT_KV_V2,
+		AuthType:   storepb.DataSourceExternalSecret_TOKEN,
+		SecretName: "prod/mysql",
+	}, Token: testToken})
+	a.ErrorContains(err, "URL of Vault is required")
+
+	_, err = newManager(ctx, &secret.Config{DataSourceExternalSecret: &storepb.DataSourceExternalSecret{
+		SecretType: storepb.DataSourceExternalSecret_VAULT_DATABASE,
+		AuthType:   storepb.DataSourceExternalSecret_APP_ROLE,
+		Url:        "http://127.0.0.1:8200",
+	}, AppRoleSecretID: testAppRoleSecretID})
+	a.ErrorContains(err, "role ID and secret ID")
+}
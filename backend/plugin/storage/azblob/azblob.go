@@ -0,0 +1,118 @@
+// Package azblob provides the storage of Azure Blob Storage.
+package azblob
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+func init() {
+	storage.Register(storage.AzureBlob, newStorage)
+}
+
+// Storage is the storage of an Azure Blob Storage container.
+type Storage struct {
+	client    *azblob.Client
+	container string
+}
+
+// newStorage returns the storage of the config.
+// The credential file contains the connection string of the storage account.
+// If it is not specified, the endpoint must be the service URL with the SAS token.
+func newStorage(_ context.Context, config *storage.Config) (storage.Storage, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("container of Azure Blob storage is required")
+	}
+	var client *azblob.Client
+	switch {
+	case config.CredentialFile != "":
+		content, err := os.ReadFile(config.CredentialFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read Azure Blob credential file %q", config.CredentialFile)
+		}
+		c, err := azblob.NewClientFromConnectionString(strings.TrimSpace(string(content)), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create Azure Blob client")
+		}
+		client = c
+	case config.Endpoint != "":
+		c, err := azblob.NewClientWithNoCredential(config.Endpoint, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create Azure Blob client")
+		}
+		client = c
+	default:
+		return nil, errors.New("either credential file or endpoint of Azure Blob storage is required")
+	}
+	return &Storage{client: client, container: config.Bucket}, nil
+}
+
+// Upload uploads the body to the path in blocks.
+func (s *Storage) Upload(ctx context.Context, path string, body io.Reader) error {
+	if _, err := s.client.UploadStream(ctx, s.container, path, body, nil); err != nil {
+		return errors.Wrapf(err, "failed to upload %q to Azure Blob", path)
+	}
+	return nil
+}
+
+// Download writes the content of the blob at the path to w.
+func (s *Storage) Download(ctx context.Context, path string, w io.Writer) error {
+	resp, err := s.client.DownloadStream(ctx, s.container, path, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %q from Azure Blob", path)
+	}
+	body := resp.NewRetryReader(ctx, nil)
+	defer body.Close()
+	if _, err := io.Copy(w, body); err != nil {
+		return errors.Wrapf(err, "failed to download %q from Azure Blob", path)
+	}
+	return nil
+}
+
+// List lists the blobs whose paths start with the prefix.
+func (s *Storage) List(ctx context.Context, prefix string) ([]*storage.Object, error) {
+	var objects []*storage.Object
+	pager := s.client.NewListBlobsFlatPager(s.container, &azblob.ListBlobsFlatOptions{Prefix: &prefix})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list blobs with prefix %q from Azure Blob", prefix)
+		}
+		for _, item := range page.Segment.BlobItems {
+			object := &storage.Object{Path: *item.Name}
+			if properties := item.Properties; properties != nil {
+				if properties.ContentLength != nil {
+					object.Size = *properties.ContentLength
+				}
+				if properties.LastModified != nil {
+					object.LastModified = *properties.LastModified
+				}
+			}
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+// Delete deletes the blobs at the paths.
+func (s *Storage) Delete(ctx context.Context, paths ...string) error {
+	for _, path := range paths {
+		if _, err := s.client.DeleteBlob(ctx, s.container, path, nil); err != nil {
+			var respErr *azcore.ResponseError
+			if errors.As(err, &respErr) && respErr.ErrorCode == string(bloberror.BlobNotFound) {
+				continue
+			}
+			return errors.Wrapf(err, "failed to delete %q from Azure Blob", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// The encrypted object consists of a header and a sequence of AES-256-GCM sealed chunks.
+// The header is the format version followed by the random nonce prefix of the object.
+// The nonce of each chunk is the nonce prefix, the big-endian chunk index and a flag marking the last chunk,
+// so that the chunks cannot be reordered or truncated without being detected.
+const (
+	encryptionVersion        = 1
+	encryptionKeySize        = 32
+	encryptionNoncePrefixLen = 7
+	encryptionHeaderLen      = 1 + encryptionNoncePrefixLen
+	encryptionChunkSize      = 64 * 1024
+)
+
+// ReadEncryptionKeyFile reads the base64 encoded 256-bit encryption key from the file.
+func ReadEncryptionKeyFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read encryption key file %q", path)
+	}
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(content)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode encryption key file %q", path)
+	}
+	if len(key) != encryptionKeySize {
+		return nil, errors.Errorf("encryption key in %q must be %d bytes, got %d bytes", path, encryptionKeySize, len(key))
+	}
+	return key, nil
+}
+
+// NewEncryptedStorage returns the storage encrypting the objects on the client side with the 256-bit key.
+func NewEncryptedStorage(s Storage, key []byte) (Storage, error) {
+	if len(key) != encryptionKeySize {
+		return nil, errors.Errorf("encryption key must be %d bytes, got %d bytes", encryptionKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCM")
+	}
+	return &encryptedStorage{s: s, aead: aead}, nil
+}
+
+type encryptedStorage struct {
+	s    Storage
+	aead cipher.AEAD
+}
+
+func (e *encryptedStorage) Upload(ctx context.Context, path string, body io.Reader) error {
+	header := make([]byte, encryptionHeaderLen)
+	header[0] = encryptionVersion
+	if _, err := rand.Read(header[1:]); err != nil {
+		return errors.Wrap(err, "failed to generate nonce")
+	}
+	return e.s.Upload(ctx, path, &encryptReader{
+		aead:        e.aead,
+		noncePrefix: header[1:],
+		r:           bufio.NewReaderSize(body, encryptionChunkSize),
+		plain:       make([]byte, encryptionChunkSize),
+		out:         header,
+	})
+}
+
+func (e *encryptedStorage) Download(ctx context.Context, path string, w io.Writer) error {
+	dw := &decryptWriter{aead: e.aead, w: w}
+	if err := e.s.Download(ctx, path, dw); err != nil {
+		return err
+	}
+	return dw.finish()
+}
+
+func (e *encryptedStorage) List(ctx context.Context, prefix string) ([]*Object, error) {
+	return e.s.List(ctx, prefix)
+}
+
+func (e *encryptedStorage) Delete(ctx context.Context, paths ...string) error {
+	return e.s.Delete(ctx, paths...)
+}
+
+func encryptionNonce(noncePrefix []byte, index uint32, last bool) []byte {
+	nonce := make([]byte, 0, encryptionNoncePrefixLen+5)
+	nonce = append(nonce, noncePrefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, index)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// encryptReader reads the plaintext from r and returns the encrypted object.
+type encryptReader struct {
+	aead        cipher.AEAD
+	noncePrefix []byte
+	r           *bufio.Reader
+	plain       []byte
+	index       uint32
+	done        bool
+	// out is the encrypted bytes not returned yet.
+	out []byte
+}
+
+func (e *encryptReader) Read(p []byte) (int, error) {
+	for len(e.out) == 0 {
+		if e.done {
+			return 0, io.EOF
+		}
+		if err := e.sealChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, e.out)
+	e.out = e.out[n:]
+	return n, nil
+}
+
+func (e *encryptReader) sealChunk() error {
+	n, err := io.ReadFull(e.r, e.plain)
+	last := false
+	switch err {
+	case nil:
+		// Peek to tell whether the full chunk is the last one.
+		if _, err := e.r.Peek(1); err != nil {
+			if err != io.EOF {
+				return err
+			}
+			last = true
+		}
+	case io.EOF, io.ErrUnexpectedEOF:
+		last = true
+	default:
+		return err
+	}
+	if !last && e.index == math.MaxUint32 {
+		return errors.New("object is too large to encrypt")
+	}
+	e.out = e.aead.Seal(e.out[:0], encryptionNonce(e.noncePrefix, e.index, last), e.plain[:n], nil)
+	e.index++
+	e.done = last
+	return nil
+}
+
+// decryptWriter decrypts the object written to it and writes the plaintext to w.
+type decryptWriter struct {
+	aead        cipher.AEAD
+	w           io.Writer
+	noncePrefix []byte
+	index       uint32
+	buf         []byte
+}
+
+func (d *decryptWriter) Write(p []byte) (int, error) {
+	d.buf = append(d.buf, p...)
+	if d.noncePrefix == nil {
+		if len(d.buf) < encryptionHeaderLen {
+			return len(p), nil
+		}
+		if d.buf[0] != encryptionVersion {
+			return 0, errors.Errorf("unsupported encryption version %d", d.buf[0])
+		}
+		d.noncePrefix = append([]byte{}, d.buf[1:encryptionHeaderLen]...)
+		d.buf = d.buf[encryptionHeaderLen:]
+	}
+	sealedChunkSize := encryptionChunkSize + d.aead.Overhead()
+	// Only open the chunks followed by more bytes, the last chunk is opened in finish.
+	for len(d.buf) > sealedChunkSize {
+		if err := d.openChunk(d.buf[:sealedChunkSize], false); err != nil {
+			return 0, err
+		}
+		d.buf = append(d.buf[:0], d.buf[sealedChunkSize:]...)
+	}
+	return len(p), nil
+}
+
+func (d *decryptWriter) finish() error {
+	if d.noncePrefix == nil {
+		return errors.New("encrypted object is truncated")
+	}
+	return d.openChunk(d.buf, true)
+}
+
+func (d *decryptWriter) openChunk(sealed []byte, last bool) error {
+	plain, err := d.aead.Open(nil, encryptionNonce(d.noncePrefix, d.index, last), sealed, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt chunk %d", d.index)
+	}
+	d.index++
+	_, err = d.w.Write(plain)
+	return err
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStorage struct {
+	objects map[string][]byte
+}
+
+func (m *memoryStorage) Upload(_ context.Context, path string, body io.Reader) error {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.objects[path] = content
+	return nil
+}
+
+func (m *memoryStorage) Download(_ context.Context, path string, w io.Writer) error {
+	// Write in small pieces to exercise the chunk reassembly.
+	content := m.objects[path]
+	for len(content) > 0 {
+		n := min(len(content), 1000)
+		if _, err := w.Write(content[:n]); err != nil {
+			return err
+		}
+		content = content[n:]
+	}
+	return nil
+}
+
+func (m *memoryStorage) List(_ context.Context, prefix string) ([]*Object, error) {
+	var objects []*Object
+	for path, content := range m.objects {
+		if strings.HasPrefix(path, prefix) {
+			objects = append(objects, &Object{Path: path, Size: int64(len(content))})
+		}
+	}
+	return objects, nil
+}
+
+func (m *memoryStorage) Delete(_ context.Context, paths ...string) error {
+	for _, path := range paths {
+		delete(m.objects, path)
+	}
+	return nil
+}
+
+func TestEncryptedStorage(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	key := make([]byte, encryptionKeySize)
+	_, err := rand.Read(key)
+	a.NoError(err)
+
+	memory := &memoryStorage{objects: map[string][]byte{}}
+	s, err := NewEncryptedStorage(memory, key)
+	a.NoError(err)
+
+	for _, size := range []int{0, 1, encryptionChunkSize - 1, encryptionChunkSize, encryptionChunkSize + 1, 3*encryptionChunkSize + 17} {
+		plain := make([]byte, size)
+		_, err := rand.Read(plain)
+		a.NoError(err)
+
+		a.NoError(s.Upload(ctx, "object", bytes.NewReader(plain)))
+		if size > encryptionChunkSize {
+			a.NotContains(string(memory.objects["object"]), string(plain))
+		}
+
+		var buf bytes.Buffer
+		a.NoError(s.Download(ctx, "object", &buf))
+		a.True(bytes.Equal(plain, buf.Bytes()), "size %d", size)
+	}
+
+	// The truncated object is rejected.
+	sealed := memory.objects["object"]
+	memory.objects["object"] = sealed[:len(sealed)-encryptionChunkSize]
+	a.Error(s.Download(ctx, "object", io.Discard))
+
+	// The object encrypted with another key is rejected.
+	memory.objects["object"] = sealed
+	otherKey := make([]byte, encryptionKeySize)
+	other, err := NewEncryptedStorage(memory, otherKey)
+	a.NoError(err)
+	a.Error(other.Download(ctx, "object", io.Discard))
+}
@@ -0,0 +1,102 @@
+// Package gcs provides the storage of Google Cloud Storage.
+package gcs
+
+import (
+	"context"
+	"io"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	bbstorage "github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+func init() {
+	bbstorage.Register(bbstorage.GCS, newStorage)
+}
+
+// Storage is the storage of a Google Cloud Storage bucket.
+type Storage struct {
+	bucket *storage.BucketHandle
+}
+
+// newStorage returns the storage of the config.
+// The service account key is loaded from the credential file if specified, otherwise the application default credentials are used.
+func newStorage(ctx context.Context, config *bbstorage.Config) (bbstorage.Storage, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("bucket of GCS storage is required")
+	}
+	var opts []option.ClientOption
+	if config.CredentialFile != "" {
+		opts = append(opts, option.WithCredentialsFile(config.CredentialFile))
+	}
+	if config.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(config.Endpoint))
+	}
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCS client")
+	}
+	return &Storage{bucket: client.Bucket(config.Bucket)}, nil
+}
+
+// Upload uploads the body to the path with resumable upload.
+func (s *Storage) Upload(ctx context.Context, path string, body io.Reader) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Canceling the context before closing the writer aborts the upload.
+	w := s.bucket.Object(path).NewWriter(ctx)
+	if _, err := io.Copy(w, body); err != nil {
+		return errors.Wrapf(err, "failed to upload %q to GCS", path)
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrapf(err, "failed to upload %q to GCS", path)
+	}
+	return nil
+}
+
+// Download writes the content of the object at the path to w.
+func (s *Storage) Download(ctx context.Context, path string, w io.Writer) error {
+	r, err := s.bucket.Object(path).NewReader(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %q from GCS", path)
+	}
+	defer r.Close()
+	if _, err := io.Copy(w, r); err != nil {
+		return errors.Wrapf(err, "failed to download %q from GCS", path)
+	}
+	return nil
+}
+
+// List lists the objects whose paths start with the prefix.
+func (s *Storage) List(ctx context.Context, prefix string) ([]*bbstorage.Object, error) {
+	var objects []*bbstorage.Object
+	it := s.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list objects with prefix %q from GCS", prefix)
+		}
+		objects = append(objects, &bbstorage.Object{
+			Path:         attrs.Name,
+			Size:         attrs.Size,
+			LastModified: attrs.Updated,
+		})
+	}
+	return objects, nil
+}
+
+// Delete deletes the objects at the paths.
+func (s *Storage) Delete(ctx context.Context, paths ...string) error {
+	for _, path := range paths {
+		if err := s.bucket.Object(path).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+			return errors.Wrapf(err, "failed to delete %q from GCS", path)
+		}
+	}
+	return nil
+}
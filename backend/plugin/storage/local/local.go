@@ -0,0 +1,132 @@
+// Package local provides the storage on the local file system.
+package local
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+func init() {
+	storage.Register(storage.Local, newStorage)
+}
+
+// Storage is the storage keeping the objects as files under the directory.
+type Storage struct {
+	dir string
+}
+
+func newStorage(_ context.Context, config *storage.Config) (storage.Storage, error) {
+	return NewStorage(config.Dir)
+}
+
+// NewStorage returns the storage rooted at the directory.
+func NewStorage(dir string) (*Storage, error) {
+	if dir == "" {
+		return nil, errors.New("directory of local storage is required")
+	}
+	return &Storage{dir: dir}, nil
+}
+
+func (s *Storage) filePath(path string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(path))
+}
+
+// Upload writes the body to the file at the path, creating the parent directories if needed.
+// The body is written to a temporary file first so that a failed upload doesn't leave a partial file.
+func (s *Storage) Upload(_ context.Context, path string, body io.Reader) error {
+	filePath := s.filePath(path)
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %q", path)
+	}
+	filePathTemp := filePath + ".tmp"
+	f, err := os.Create(filePathTemp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file %q", filePathTemp)
+	}
+	defer os.Remove(filePathTemp)
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write file %q", filePathTemp)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close file %q", filePathTemp)
+	}
+	if err := os.Rename(filePathTemp, filePath); err != nil {
+		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePath)
+	}
+	return nil
+}
+
+// Download writes the content of the file at the path to w.
+func (s *Storage) Download(_ context.Context, path string, w io.Writer) error {
+	f, err := os.Open(s.filePath(path))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open file %q", path)
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return errors.Wrapf(err, "failed to read file %q", path)
+	}
+	return nil
+}
+
+// List lists the files whose paths start with the prefix.
+func (s *Storage) List(_ context.Context, prefix string) ([]*storage.Object, error) {
+	// Only walk the deepest directory containing all the matched files.
+	walkDir := s.dir
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		walkDir = s.filePath(prefix[:i])
+	}
+	var objects []*storage.Object
+	err := filepath.WalkDir(walkDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+		path := filepath.ToSlash(rel)
+		if !strings.HasPrefix(path, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, &storage.Object{
+			Path:         path,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list files with prefix %q", prefix)
+	}
+	return objects, nil
+}
+
+// Delete deletes the files at the paths.
+func (s *Storage) Delete(_ context.Context, paths ...string) error {
+	for _, path := range paths {
+		if err := os.Remove(s.filePath(path)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return errors.Wrapf(err, "failed to delete file %q", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
+)
+
+func TestStorage(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	s, err := storage.New(ctx, &storage.Config{Backend: storage.Local, Dir: t.TempDir()})
+	a.NoError(err)
+
+	for _, path := range []string{"backup/db/1/a.sql", "backup/db/1/b.sql", "backup/db/2/c.sql", "backup/instance/1/binlog.000001"} {
+		a.NoError(s.Upload(ctx, path, bytes.NewBufferString(path)))
+	}
+
+	objects, err := s.List(ctx, "backup/db/")
+	a.NoError(err)
+	var paths []string
+	for _, object := range objects {
+		paths = append(paths, object.Path)
+	}
+	sort.Strings(paths)
+	a.Equal([]string{"backup/db/1/a.sql", "backup/db/1/b.sql", "backup/db/2/c.sql"}, paths)
+
+	objects, err = s.List(ctx, "backup/nonexistent/")
+	a.NoError(err)
+	a.Empty(objects)
+
+	var buf bytes.Buffer
+	a.NoError(s.Download(ctx, "backup/db/1/b.sql", &buf))
+	a.Equal("backup/db/1/b.sql", buf.String())
+
+	a.NoError(s.Delete(ctx, "backup/db/1/a.sql", "backup/db/1/nonexistent.sql"))
+	objects, err = s.List(ctx, "backup/db/1/")
+	a.NoError(err)
+	a.Len(objects, 1)
+}
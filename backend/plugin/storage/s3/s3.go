@@ -1,10 +1,11 @@
-// Package s3 provides the client for AWS S3 storage.
+// Package s3 provides the client for AWS S3 and S3-compatible storages.
 package s3
 
 import (
 	"context"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -13,8 +14,17 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 )
 
+// deleteObjectsBatchSize is the maximum number of objects deleted by one DeleteObjects request.
+const deleteObjectsBatchSize = 1000
+
+func init() {
+	storage.Register(storage.S3, newStorage)
+}
+
 // Client wraps the AWS S3 client.
 type Client struct {
 	c      *s3.Client
@@ -38,15 +48,51 @@ func GetCredentialsFromFile(ctx context.Context, credentialsFileName string) (aw
 
 // NewClient returns a new AWS S3 client.
 func NewClient(ctx context.Context, region, bucket string, credentials aws.Credentials) (*Client, error) {
-	cfg, err := awsconfig.LoadDefaultConfig(ctx,
-		awsconfig.WithRegion(region),
-		awsconfig.WithCredentialsProvider(awscredentials.NewStaticCredentialsProvider(credentials.AccessKeyID, credentials.SecretAccessKey, "")),
-	)
+	return newClient(ctx, region, bucket, "", awscredentials.NewStaticCredentialsProvider(credentials.AccessKeyID, credentials.SecretAccessKey, ""))
+}
+
+// newStorage returns the client of the storage config.
+// The credentials are loaded from the credential file if specified, otherwise from the environment.
+func newStorage(ctx context.Context, config *storage.Config) (storage.Storage, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("bucket of S3 storage is required")
+	}
+	var provider aws.CredentialsProvider
+	if config.CredentialFile != "" {
+		credentials, err := GetCredentialsFromFile(ctx, config.CredentialFile)
+		if err != nil {
+			return nil, err
+		}
+		provider = awscredentials.NewStaticCredentialsProvider(credentials.AccessKeyID, credentials.SecretAccessKey, credentials.SessionToken)
+	}
+	return newClient(ctx, config.Region, config.Bucket, config.Endpoint, provider)
+}
+
+// newClient returns a new S3 client.
+// If endpoint is not empty, the client connects to the S3-compatible service at the endpoint, such as MinIO, with path-style addressing.
+func newClient(ctx context.Context, region, bucket, endpoint string, provider aws.CredentialsProvider) (*Client, error) {
+	var optFns []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		optFns = append(optFns, awsconfig.WithRegion(region))
+	}
+	if provider != nil {
+		optFns = append(optFns, awsconfig.WithCredentialsProvider(provider))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, optFns...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load AWS S3 config")
 	}
+	if endpoint != "" && cfg.Region == "" {
+		// S3-compatible services usually ignore the region, but the SDK requires one to sign the requests.
+		cfg.Region = "us-east-1"
+	}
 	return &Client{
-		c:      s3.NewFromConfig(cfg),
+		c: s3.NewFromConfig(cfg, func(o *s3.Options) {
+			if endpoint != "" {
+				o.BaseEndpoint = aws.String(endpoint)
+				o.UsePathStyle = true
+			}
+		}),
 		bucket: bucket,
 	}, nil
 }
@@ -125,3 +171,64 @@ func (c *Client) DownloadFileFromCloud(ctx context.Context, filePathLocal, fileP
 	}
 	return nil
 }
+
+// Upload uploads the body to the path with multipart upload.
+func (c *Client) Upload(ctx context.Context, path string, body io.Reader) error {
+	if _, err := c.UploadObject(ctx, path, body); err != nil {
+		return errors.Wrapf(err, "failed to upload %q to S3", path)
+	}
+	return nil
+}
+
+// Download writes the content of the object at the path to w.
+func (c *Client) Download(ctx context.Context, path string, w io.Writer) error {
+	output, err := c.c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &c.bucket,
+		Key:    &path,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %q from S3", path)
+	}
+	defer output.Body.Close()
+	if _, err := io.Copy(w, output.Body); err != nil {
+		return errors.Wrapf(err, "failed to download %q from S3", path)
+	}
+	return nil
+}
+
+// List lists the objects whose paths start with the prefix.
+func (c *Client) List(ctx context.Context, prefix string) ([]*storage.Object, error) {
+	list, err := c.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	var objects []*storage.Object
+	for _, object := range list {
+		o := &storage.Object{Path: aws.ToString(object.Key), Size: object.Size}
+		if object.LastModified != nil {
+			o.LastModified = *object.LastModified
+		}
+		objects = append(objects, o)
+	}
+	return objects, nil
+}
+
+// Delete deletes the objects at the paths in batches.
+func (c *Client) Delete(ctx context.Context, paths ...string) error {
+	for len(paths) > 0 {
+		batch := paths[:min(len(paths), deleteObjectsBatchSize)]
+		paths = paths[len(batch):]
+		output, err := c.DeleteObjects(ctx, batch...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete objects from S3")
+		}
+		if len(output.Errors) > 0 {
+			var messages []string
+			for _, e := range output.Errors {
+				messages = append(messages, aws.ToString(e.Key)+": "+aws.ToString(e.Message))
+			}
+			return errors.Errorf("failed to delete objects from S3: %s", strings.Join(messages, "; "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Package storage provides the interface of the object storages keeping the backup files.
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Backend is the type of a storage backend.
+type Backend string
+
+const (
+	// Local is the storage backend on the local file system.
+	Local Backend = "LOCAL"
+	// S3 is the storage backend for AWS S3 and S3-compatible services such as MinIO.
+	S3 Backend = "S3"
+	// GCS is the storage backend for Google Cloud Storage.
+	GCS Backend = "GCS"
+	// AzureBlob is the storage backend for Azure Blob Storage.
+	AzureBlob Backend = "AZURE_BLOB"
+)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[Backend]newFunc)
+)
+
+type newFunc func(ctx context.Context, config *Config) (Storage, error)
+
+// Object is an object in the storage.
+type Object struct {
+	// Path is the path of the object relative to the storage root, always separated by "/".
+	Path         string
+	Size         int64
+	LastModified time.Time
+}
+
+// Storage is the interface of an object storage.
+type Storage interface {
+	// Upload uploads the content of body to the path.
+	// The body is streamed, so the size of the content doesn't need to be known in advance.
+	Upload(ctx context.Context, path string, body io.Reader) error
+	// Download writes the content of the object at the path to w.
+	Download(ctx context.Context, path string, w io.Writer) error
+	// List lists the objects whose paths start with the prefix.
+	List(ctx context.Context, prefix string) ([]*Object, error)
+	// Delete deletes the objects at the paths. Nonexistent objects are ignored.
+	Delete(ctx context.Context, paths ...string) error
+}
+
+// Config is the configuration of a storage.
+type Config struct {
+	Backend Backend
+	// Dir is the root directory of the local storage.
+	Dir string
+	// Bucket is the bucket of S3 and GCS, or the container of Azure Blob Storage.
+	Bucket string
+	// Prefix is the path prefix prepended to the paths of all objects in the bucket.
+	Prefix string
+	// Region is the region of S3.
+	Region string
+	// Endpoint is the custom endpoint URL of an S3-compatible service such as MinIO,
+	// or the service URL of Azure Blob Storage.
+	Endpoint string
+	// CredentialFile is the path of the credential file.
+	// It is the shared credentials file for S3, the service account key file for GCS,
+	// and the file containing the connection string for Azure Blob Storage.
+	// If empty, the default credentials of the environment are used for S3 and GCS.
+	CredentialFile string
+	// EncryptionKey is the 256-bit key to encrypt the objects on the client side.
+	// The objects are stored as is if empty.
+	EncryptionKey []byte
+}
+
+// Register makes a storage backend available.
+// If Register is called twice with the same backend or if f is nil, it panics.
+func Register(backend Backend, f newFunc) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	if f == nil {
+		panic("storage: Register storage is nil")
+	}
+	if _, dup := backends[backend]; dup {
+		panic("storage: Register called twice for backend " + backend)
+	}
+	backends[backend] = f
+}
+
+// New creates the storage specified by the config.
+func New(ctx context.Context, config *Config) (Storage, error) {
+	backendsMu.RLock()
+	f, ok := backends[config.Backend]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("storage: unknown backend %q", config.Backend)
+	}
+	s, err := f(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Prefix != "" {
+		s = &prefixStorage{s: s, prefix: strings.Trim(config.Prefix, "/") + "/"}
+	}
+	if len(config.EncryptionKey) > 0 {
+		s, err = NewEncryptedStorage(s, config.EncryptionKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// DownloadFile downloads the object at the path to the local file.
+// In case of network errors which will get partially downloaded files, we first download to a temporary file.
+// After that, we then rename it to the target file path.
+func DownloadFile(ctx context.Context, s Storage, objectPath, filePath string) error {
+	filePathTemp := filePath + ".tmp"
+	fileTemp, err := os.Create(filePathTemp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the local temporary file %s", filePathTemp)
+	}
+	defer os.Remove(filePathTemp)
+	if err := s.Download(ctx, objectPath, fileTemp); err != nil {
+		fileTemp.Close()
+		return errors.Wrapf(err, "failed to download file %q from the storage", objectPath)
+	}
+	if err := fileTemp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close the local temporary file %s", filePathTemp)
+	}
+	if err := os.Rename(filePathTemp, filePath); err != nil {
+		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePath)
+	}
+	return nil
+}
+
+// UploadFile uploads the local file to the path.
+func UploadFile(ctx context.Context, s Storage, filePath, objectPath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open local file %q for uploading", filePath)
+	}
+	defer file.Close()
+	return s.Upload(ctx, objectPath, file)
+}
+
+// prefixStorage prepends the prefix to the object paths.
+type prefixStorage struct {
+	s      Storage
+	prefix string
+}
+
+func (p *prefixStorage) Upload(ctx context.Context, objectPath string, body io.Reader) error {
+	return p.s.Upload(ctx, path.Join(p.prefix, objectPath), body)
+}
+
+func (p *prefixStorage) Download(ctx context.Context, objectPath string, w io.Writer) error {
+	return p.s.Download(ctx, path.Join(p.prefix, objectPath), w)
+}
+
+func (p *prefixStorage) List(ctx context.Context, prefix string) ([]*Object, error) {
+	objects, err := p.s.List(ctx, p.prefix+prefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range objects {
+		object.Path = strings.TrimPrefix(object.Path, p.prefix)
+	}
+	return objects, nil
+}
+
+func (p *prefixStorage) Delete(ctx context.Context, objectPaths ...string) error {
+	var prefixed []string
+	for _, objectPath := range objectPaths {
+		prefixed = append(prefixed, path.Join(p.prefix, objectPath))
+	}
+	return p.s.Delete(ctx, prefixed...)
+}
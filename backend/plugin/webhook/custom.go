@@ -2,13 +2,27 @@ package webhook
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
+const (
+	// SignatureHeader is the header carrying the HMAC-SHA256 signature of the custom webhook request.
+	SignatureHeader = "X-Bytebase-Signature-256"
+	// TimestampHeader is the header carrying the Unix timestamp in seconds when the custom webhook request is signed.
+	TimestampHeader = "X-Bytebase-Timestamp"
+	// SignatureTolerance is the maximum age of the signed timestamp accepted by Verify.
+	SignatureTolerance = 5 * time.Minute
+)
+
 // CustomWebhookResponse is the API message for Custom webhook response.
 type CustomWebhookResponse struct {
 	Code    int    `json:"code"`
@@ -62,6 +76,11 @@ func (*CustomReceiver) post(context Context) error {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if context.SigningSecret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(SignatureHeader, Sign(context.SigningSecret, timestamp, body))
+	}
 	client := &http.Client{
 		Timeout: timeout,
 	}
@@ -91,3 +110,29 @@ func (*CustomReceiver) post(context Context) error {
 
 	return nil
 }
+
+// Sign returns the HMAC-SHA256 signature of "{timestamp}.{body}" in the format of "sha256={hex digest}".
+// Signing the timestamp together with the body prevents the signed requests from being replayed later.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	_, _ = mac.Write([]byte("."))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify verifies the signature and the timestamp headers of a custom webhook request.
+// The request is rejected if the signature does not match, or the timestamp is more than tolerance away from now.
+func Verify(secret, signature, timestamp string, body []byte, now time.Time, tolerance time.Duration) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "invalid timestamp %q", timestamp)
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d > tolerance || d < -tolerance {
+		return errors.Errorf("timestamp %d is out of the tolerance %v", ts, tolerance)
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, ts, body))) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
@@ -83,6 +83,9 @@ type Context struct {
 	Project      *Project
 	TaskResult   *TaskResult
 	Approval     *Approval
+
+	// SigningSecret is the secret to sign the request body, only used by the custom receiver.
+	SigningSecret string
 }
 
 // Receiver is the webhook receiver.
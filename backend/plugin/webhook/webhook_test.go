@@ -3,6 +3,7 @@ package webhook
 import (
 	"crypto/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -122,3 +123,95 @@ func TestContext_getMetaList(t *testing.T) {
 		a.Equal(want, context.getMetaList())
 	})
 }
+
+func TestSign(t *testing.T) {
+	a := require.New(t)
+	// The expected digest is computed by `echo -n '1700000000.{"level":"INFO"}' | openssl dgst -sha256 -hmac secret`.
+	a.Equal("sha256=aecfa97f362b80e19002482573c9251b1e6db9691ee24a119049055a96fa3456", Sign("secret", 1700000000, []byte(`{"level":"INFO"}`)))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"level":"INFO"}`)
+	now := time.Unix(1700000000, 0)
+	signature := Sign("secret", now.Unix(), body)
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		timestamp string
+		body      []byte
+		now       time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "valid",
+			secret:    "secret",
+			signature: signature,
+			timestamp: "1700000000",
+			body:      body,
+			now:       now.Add(SignatureTolerance),
+		},
+		{
+			name:      "tampered body",
+			secret:    "secret",
+			signature: signature,
+			timestamp: "1700000000",
+			body:      []byte(`{"level":"ERROR"}`),
+			now:       now,
+			wantErr:   true,
+		},
+		{
+			name:      "tampered timestamp",
+			secret:    "secret",
+			signature: signature,
+			timestamp: "1700000060",
+			body:      body,
+			now:       now,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid timestamp",
+			secret:    "secret",
+			signature: signature,
+			timestamp: "now",
+			body:      body,
+			now:       now,
+			wantErr:   true,
+		},
+		{
+			name:      "stale",
+			secret:    "secret",
+			signature: signature,
+			timestamp: "1700000000",
+			body:      body,
+			now:       now.Add(SignatureTolerance + time.Second),
+			wantErr:   true,
+		},
+		{
+			name:      "future",
+			secret:    "secret",
+			signature: signature,
+			timestamp: "1700000000",
+			body:      body,
+			now:       now.Add(-SignatureTolerance - time.Second),
+			wantErr:   true,
+		},
+		{
+			name:      "wrong key",
+			secret:    "another-secret",
+			signature: signature,
+			timestamp: "1700000000",
+			body:      body,
+			now:       now,
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		err := Verify(test.secret, test.signature, test.timestamp, test.body, test.now, SignatureTolerance)
+		if test.wantErr {
+			require.Error(t, err, test.name)
+		} else {
+			require.NoError(t, err, test.name)
+		}
+	}
+}
@@ -58,7 +58,11 @@ func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *sta
 	}
 }
 
-const approvalRunnerInterval = 1 * time.Second
+const (
+	approvalRunnerInterval = 1 * time.Second
+	// approvalFindingReloadInterval is the interval of reloading the issues pending approval template finding from the database in high-availability mode.
+	approvalFindingReloadInterval = 30 * time.Second
+)
 
 // Run runs the runner.
 func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
@@ -67,10 +71,20 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 	slog.Debug(fmt.Sprintf("Approval runner started and will run every %v", approvalRunnerInterval))
 	r.retryFindApprovalTemplate(ctx)
+	// The issues are queued in the memory of the replica handling the request, so in high-availability mode,
+	// the other replicas reload the pending issues from the database in case the replica dies before finding the approval template.
+	var reloadTicker <-chan time.Time
+	if r.stateCfg.HA {
+		t := time.NewTicker(approvalFindingReloadInterval)
+		defer t.Stop()
+		reloadTicker = t.C
+	}
 	for {
 		select {
 		case <-ticker.C:
 			r.runOnce(ctx)
+		case <-reloadTicker:
+			r.retryFindApprovalTemplate(ctx)
 		case <-ctx.Done():
 			return
 		}
@@ -101,6 +115,28 @@ func (r *Runner) runOnce(ctx context.Context) {
 		var errs error
 		r.stateCfg.ApprovalFinding.Range(func(key, value any) bool {
 			issue := value.(*store.IssueMessage)
+			// In high-availability mode, skip the issue if another replica is finding the approval template for it.
+			acquired, err := r.stateCfg.TryAcquireLease(ctx, store.ApprovalFindingLeaseResource(issue.UID), 0)
+			if err != nil {
+				errs = multierr.Append(errs, errors.Wrapf(err, "failed to acquire lease of finding approval template for issue %v", issue.UID))
+				return true
+			}
+			if !acquired {
+				return true
+			}
+			defer r.stateCfg.ReleaseLease(ctx, store.ApprovalFindingLeaseResource(issue.UID))
+			if r.stateCfg.HA {
+				// Reload the issue because another replica may have found the approval template since the issue was queued.
+				issue, err = r.store.GetIssueV2(ctx, &store.FindIssueMessage{UID: &issue.UID})
+				if err != nil {
+					errs = multierr.Append(errs, errors.Wrapf(err, "failed to get issue %v", key))
+					return true
+				}
+				if issue == nil {
+					r.stateCfg.ApprovalFinding.Delete(key)
+					return true
+				}
+			}
 			done, err := r.findApprovalTemplateForIssue(ctx, issue, risks, approvalSetting)
 			if err != nil {
 				errs = multierr.Append(errs, errors.Wrapf(err, "failed to find approval template for issue %v", issue.UID))
@@ -234,6 +270,17 @@ func (r *Runner) findApprovalTemplateForIssue(ctx context.Context, issue *store.
 	}
 	payload.Approval.Approvers = append(payload.Approval.Approvers, newApprovers...)
 
+	// Pin the sheet revisions before the approval is saved, after which the tasks can run.
+	approved, err := utils.CheckApprovalApproved(payload.Approval)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check if the approval is approved")
+	}
+	if approved && issue.PipelineUID != nil {
+		if err := r.store.PinTaskSheetRevisions(ctx, *issue.PipelineUID); err != nil {
+			return false, errors.Wrap(err, "failed to pin sheet revisions")
+		}
+	}
+
 	if err := updateIssueApprovalPayload(ctx, r.store, issue, payload.Approval); err != nil {
 		return false, errors.Wrap(err, "failed to update issue payload")
 	}
@@ -355,18 +402,20 @@ func getDatabaseGeneralIssueRisk(ctx context.Context, s *store.Store, licenseSer
 
 	planCheckRuns, err := s.ListPlanCheckRuns(ctx, &store.FindPlanCheckRunMessage{
 		PlanUID: &plan.UID,
-		Type:    &[]store.PlanCheckRunType{store.PlanCheckDatabaseStatementSummaryReport},
+		Type:    &[]store.PlanCheckRunType{store.PlanCheckDatabaseStatementSummaryReport, store.PlanCheckDatabaseStatementLockImpact},
 	})
 	if err != nil {
 		return 0, store.RiskSourceUnknown, false, errors.Wrapf(err, "failed to list plan check runs for plan %v", plan.UID)
 	}
 	type Key struct {
+		Type         store.PlanCheckRunType
 		InstanceUID  int
 		DatabaseName string
 	}
 	latestPlanCheckRun := map[Key]*store.PlanCheckRunMessage{}
 	for _, run := range planCheckRuns {
 		key := Key{
+			Type:         run.Type,
 			InstanceUID:  int(run.Config.InstanceUid),
 			DatabaseName: run.Config.DatabaseName,
 		}
@@ -479,6 +528,12 @@ func getDatabaseGeneralIssueRisk(ctx context.Context, s *store.Store, licenseSer
 						"db_engine":     string(instance.Engine),
 						"sql_type":      "UNKNOWN",
 						"affected_rows": math.MaxInt32,
+						// the lock impact is only reported for PostgreSQL DDL.
+						"lock_statement_type":        "",
+						"lock_level":                 "",
+						"table_rewrite":              false,
+						"validation_scan":            false,
+						"estimated_duration_seconds": 0,
 					}
 
 					res, _, err := prg.Eval(args)
@@ -494,6 +549,7 @@ func getDatabaseGeneralIssueRisk(ctx context.Context, s *store.Store, licenseSer
 					}
 
 					if run, ok := latestPlanCheckRun[Key{
+						Type:         store.PlanCheckDatabaseStatementSummaryReport,
 						InstanceUID:  instance.UID,
 						DatabaseName: databaseName,
 					}]; ok {
@@ -519,6 +575,35 @@ func getDatabaseGeneralIssueRisk(ctx context.Context, s *store.Store, licenseSer
 							}
 						}
 					}
+
+					if run, ok := latestPlanCheckRun[Key{
+						Type:         store.PlanCheckDatabaseStatementLockImpact,
+						InstanceUID:  instance.UID,
+						DatabaseName: databaseName,
+					}]; ok {
+						for _, result := range run.Result.Results {
+							report := result.GetLockImpactReport()
+							if report == nil {
+								continue
+							}
+							args["lock_statement_type"] = report.StatementType
+							args["lock_level"] = report.LockLevel.String()
+							args["table_rewrite"] = report.TableRewrite
+							args["validation_scan"] = report.ValidationScan
+							args["estimated_duration_seconds"] = int64(report.EstimatedDuration.AsDuration().Seconds())
+							res, _, err := prg.Eval(args)
+							if err != nil {
+								return 0, err
+							}
+							val, err := res.ConvertToNative(reflect.TypeOf(false))
+							if err != nil {
+								return 0, errors.Wrap(err, "expect bool result")
+							}
+							if boolVal, ok := val.(bool); ok && boolVal {
+								return risk.Level, nil
+							}
+						}
+					}
 				}
 				return 0, nil
 			}()
@@ -22,20 +22,21 @@ import (
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/state"
+	"github.com/bytebase/bytebase/backend/component/storagefactory"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
-	"github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 	"github.com/bytebase/bytebase/backend/store"
 	"github.com/bytebase/bytebase/backend/utils"
 )
 
 // NewRunner creates a new backup runner.
-func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *s3.Client, stateCfg *state.State, profile *config.Profile) *Runner {
+func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, storageFactory *storagefactory.StorageFactory, stateCfg *state.State, profile *config.Profile) *Runner {
 	return &Runner{
 		store:                     store,
 		dbFactory:                 dbFactory,
-		s3Client:                  s3Client,
+		storageFactory:            storageFactory,
 		stateCfg:                  stateCfg,
 		profile:                   profile,
 		downloadBinlogInstanceIDs: make(map[int]bool),
@@ -46,7 +47,7 @@ func NewRunner(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *s3.
 type Runner struct {
 	store                     *store.Store
 	dbFactory                 *dbfactory.DBFactory
-	s3Client                  *s3.Client
+	storageFactory            *storagefactory.StorageFactory
 	stateCfg                  *state.State
 	profile                   *config.Profile
 	downloadBinlogInstanceIDs map[int]bool
@@ -138,7 +139,7 @@ func (r *Runner) purgeExpiredBackupData(ctx context.Context) {
 		if maxRetentionPeriodTs == math.MaxInt {
 			continue
 		}
-		if err := r.purgeBinlogFiles(ctx, instance.UID, maxRetentionPeriodTs); err != nil {
+		if err := r.purgeBinlogFiles(ctx, instance, maxRetentionPeriodTs); err != nil {
 			slog.Error("Failed to purge binlog files for instance", slog.String("instance", instance.Title), slog.Int("retentionPeriodTs", maxRetentionPeriodTs), log.BBError(err))
 		}
 	}
@@ -159,21 +160,21 @@ func (r *Runner) getMaxRetentionPeriodTsForMySQLInstance(ctx context.Context, in
 	return maxRetentionPeriodTs, nil
 }
 
-func (r *Runner) purgeBinlogFiles(ctx context.Context, instanceID, retentionPeriodTs int) error {
-	binlogDir := common.GetBinlogAbsDir(r.profile.DataDir, instanceID)
-	switch r.profile.BackupStorageBackend {
-	case api.BackupStorageBackendLocal:
+func (r *Runner) purgeBinlogFiles(ctx context.Context, instance *store.InstanceMessage, retentionPeriodTs int) error {
+	binlogDir := common.GetBinlogAbsDir(r.profile.DataDir, instance.UID)
+	binlogStorage, err := r.storageFactory.GetBinlogStorage(ctx, instance)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get binlog storage for instance %q", instance.Title)
+	}
+	if binlogStorage == nil {
 		return r.purgeBinlogFilesLocal(binlogDir, retentionPeriodTs)
-	case api.BackupStorageBackendS3:
-		return r.purgeBinlogFilesOnCloud(ctx, binlogDir, retentionPeriodTs)
-	default:
-		return errors.Errorf("purge binlog files not implemented for storage backend %s", r.profile.BackupStorageBackend)
 	}
+	return r.purgeBinlogFilesOnCloud(ctx, binlogStorage, binlogDir, retentionPeriodTs)
 }
 
-func (r *Runner) purgeBinlogFilesOnCloud(ctx context.Context, binlogDir string, retentionPeriodTs int) error {
+func (*Runner) purgeBinlogFilesOnCloud(ctx context.Context, binlogStorage storage.Storage, binlogDir string, retentionPeriodTs int) error {
 	binlogDirOnCloud := common.GetBinlogRelativeDir(binlogDir)
-	listOutput, err := r.s3Client.ListObjects(ctx, binlogDirOnCloud)
+	listOutput, err := binlogStorage.List(ctx, binlogDirOnCloud+"/")
 	if err != nil {
 		return errors.Wrapf(err, "failed to list binlog dir %q in the cloud storage", binlogDirOnCloud)
 	}
@@ -181,12 +182,12 @@ func (r *Runner) purgeBinlogFilesOnCloud(ctx context.Context, binlogDir string,
 	for _, item := range listOutput {
 		expireTime := item.LastModified.Add(time.Duration(retentionPeriodTs) * time.Second)
 		if time.Now().After(expireTime) {
-			purgeBinlogPathList = append(purgeBinlogPathList, *item.Key)
+			purgeBinlogPathList = append(purgeBinlogPathList, item.Path)
 		}
 	}
 	if len(purgeBinlogPathList) > 0 {
 		slog.Debug(fmt.Sprintf("Deleting %d expired binlog files from the cloud storage.", len(purgeBinlogPathList)))
-		if _, err := r.s3Client.DeleteObjects(ctx, purgeBinlogPathList...); err != nil {
+		if err := binlogStorage.Delete(ctx, purgeBinlogPathList...); err != nil {
 			return errors.Wrapf(err, "failed to delete %d expired binlog files from the cloud storage", len(purgeBinlogPathList))
 		}
 	}
@@ -239,20 +240,15 @@ func (r *Runner) purgeBackup(ctx context.Context, backup *store.BackupMessage) e
 	}
 	slog.Debug("Archived expired backup record", slog.String("name", backup.Name), slog.Int("id", backup.UID))
 
-	switch backup.StorageBackend {
-	case api.BackupStorageBackendLocal:
-		backupFilePath := GetBackupAbsFilePath(r.profile.DataDir, backup.DatabaseUID, backup.Name)
-		if err := os.Remove(backupFilePath); err != nil {
-			return errors.Wrapf(err, "failed to delete an expired backup file %q", backupFilePath)
-		}
-		slog.Debug(fmt.Sprintf("Deleted expired local backup file %s", backupFilePath))
-	case api.BackupStorageBackendS3:
-		backupFilePath := getBackupRelativeFilePath(backup.DatabaseUID, backup.Name)
-		if _, err := r.s3Client.DeleteObjects(ctx, backupFilePath); err != nil {
-			return errors.Wrapf(err, "failed to delete backup file %s in the cloud storage", backupFilePath)
-		}
-		slog.Debug(fmt.Sprintf("Deleted expired backup file %s in the cloud storage", backupFilePath))
+	backupStorage, err := r.storageFactory.GetBackupStorage(ctx, backup)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get storage of backup %q", backup.Name)
 	}
+	backupFilePath := getBackupRelativeFilePath(backup.DatabaseUID, backup.Name)
+	if err := backupStorage.Delete(ctx, backupFilePath); err != nil {
+		return errors.Wrapf(err, "failed to delete an expired backup file %q in the %s storage", backupFilePath, backup.StorageBackend)
+	}
+	slog.Debug(fmt.Sprintf("Deleted expired backup file %s in the %s storage", backupFilePath, backup.StorageBackend))
 
 	return nil
 }
@@ -301,7 +297,12 @@ func (r *Runner) downloadBinlogFilesForInstance(ctx context.Context, instance *s
 		slog.Error("Failed to cast driver to mysql.Driver", slog.String("instance", instance.ResourceID))
 		return
 	}
-	if err := mysqlDriver.FetchAllBinlogFiles(ctx, false /* downloadLatestBinlogFile */, r.s3Client); err != nil {
+	binlogStorage, err := r.storageFactory.GetBinlogStorage(ctx, instance)
+	if err != nil {
+		slog.Error("Failed to get binlog storage for instance", slog.String("instance", instance.ResourceID), log.BBError(err))
+		return
+	}
+	if err := mysqlDriver.FetchAllBinlogFiles(ctx, false /* downloadLatestBinlogFile */, binlogStorage); err != nil {
 		slog.Error("Failed to download all binlog files for instance", slog.String("instance", instance.ResourceID), log.BBError(err))
 		return
 	}
@@ -353,6 +354,16 @@ func (r *Runner) startAutoBackups(ctx context.Context) {
 		if environment.Deleted {
 			continue
 		}
+		// In high-availability mode, skip the database if another replica is backing it up.
+		// The lease is acquired before checking the existing backup so that the backup is not created twice.
+		acquired, err := r.stateCfg.TryAcquireLease(ctx, store.BackupDatabaseLeaseResource(database.UID), 0)
+		if err != nil {
+			slog.Error("Failed to acquire lease of backing up database", slog.String("database", database.DatabaseName), log.BBError(err))
+			continue
+		}
+		if !acquired {
+			continue
+		}
 		backupName := fmt.Sprintf("%s-%s-%s-autobackup", slug.Make(project.Title), slug.Make(environment.Title), t.Format("20060102T030405"))
 		backupList, err := r.store.ListBackupV2(ctx, &store.FindBackupMessage{
 			DatabaseUID: &database.UID,
@@ -360,15 +371,18 @@ func (r *Runner) startAutoBackups(ctx context.Context) {
 		})
 		if err != nil {
 			slog.Error("Failed to find backup", log.BBError(err))
+			r.stateCfg.ReleaseLease(ctx, store.BackupDatabaseLeaseResource(database.UID))
 			continue
 		}
 		if len(backupList) > 0 {
+			r.stateCfg.ReleaseLease(ctx, store.BackupDatabaseLeaseResource(database.UID))
 			continue
 		}
 
 		r.stateCfg.RunningBackupDatabases.Store(backupSetting.DatabaseUID, true)
 		go func(database *store.DatabaseMessage, backupName string, hookURL string) {
 			defer func() {
+				r.stateCfg.ReleaseLease(ctx, store.BackupDatabaseLeaseResource(database.UID))
 				r.stateCfg.RunningBackupDatabases.Delete(database.UID)
 				r.backupWg.Done()
 			}()
@@ -430,8 +444,18 @@ func (r *Runner) ScheduleBackupTask(ctx context.Context, database *store.Databas
 		return nil, errors.Wrapf(err, "failed to get migration history for database %q", database.DatabaseName)
 	}
 	path := getBackupRelativeFilePath(database.UID, backupName)
-	if err := createBackupDirectory(r.profile.DataDir, database.UID); err != nil {
-		return nil, errors.Wrap(err, "failed to create backup directory")
+	backupStorage, err := r.storageFactory.GetEnvironmentBackupStorage(ctx, environment.UID)
+	if err != nil {
+		return nil, err
+	}
+	var backupPayload api.BackupPayload
+	if backupStorage.Backend == api.BackupStorageBackendLocal {
+		if err := createBackupDirectory(r.profile.DataDir, database.UID); err != nil {
+			return nil, errors.Wrap(err, "failed to create backup directory")
+		}
+	} else {
+		// Record the storage so that the backup can be restored and purged after the backup setting of the environment changes.
+		backupPayload.Storage = backupStorage
 	}
 
 	backupNew, err := r.store.CreateBackupV2(ctx, &store.BackupMessage{
@@ -439,9 +463,10 @@ func (r *Runner) ScheduleBackupTask(ctx context.Context, database *store.Databas
 		Status:                  api.BackupStatusPendingCreate,
 		BackupType:              backupType,
 		Comment:                 "",
-		StorageBackend:          r.profile.BackupStorageBackend,
+		StorageBackend:          backupStorage.Backend,
 		MigrationHistoryVersion: migrationHistoryVersion,
 		Path:                    path,
+		Payload:                 backupPayload,
 	}, database.UID, creatorID)
 	if err != nil {
 		if common.ErrorCode(err) == common.Conflict {
@@ -0,0 +1,99 @@
+// Package datakeyrun is the runner keeping the secrets encrypted with the active data key.
+package datakeyrun
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/state"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	// dataKeyRefreshInterval is the interval to switch to the data key activated by the rotate-key command.
+	dataKeyRefreshInterval   = 1 * time.Minute
+	dataKeyReencryptInterval = 1 * time.Hour
+	// dataKeyRetention is how long the deactivated data keys are kept at least,
+	// so that all the replicas have switched to the active data key before they are deleted.
+	dataKeyRetention = 1 * time.Hour
+)
+
+// NewRunner creates a new runner instance.
+func NewRunner(store *store.Store, stateCfg *state.State) *Runner {
+	return &Runner{
+		store:    store,
+		stateCfg: stateCfg,
+	}
+}
+
+// Runner is the runner refreshing the data keys, and re-encrypting the secrets not encrypted with the active data key,
+// including the ones stored before the key provider was configured.
+type Runner struct {
+	store    *store.Store
+	stateCfg *state.State
+}
+
+// Run runs the runner.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if !r.store.HasSecretEnvelope() {
+		return
+	}
+	ticker := time.NewTicker(dataKeyRefreshInterval)
+	defer ticker.Stop()
+	reencryptTicker := time.NewTicker(dataKeyReencryptInterval)
+	defer reencryptTicker.Stop()
+	slog.Debug(fmt.Sprintf("Data key runner started and will run every %v", dataKeyRefreshInterval))
+
+	r.reencryptSecrets(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.store.RefreshDataKeys(ctx); err != nil {
+				slog.Error("Failed to refresh data keys", log.BBError(err))
+			}
+		case <-reencryptTicker.C:
+			r.reencryptSecrets(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reencryptSecrets re-encrypts the secrets and deletes the unused data keys holding the lease, so that only one replica does it at a time.
+func (r *Runner) reencryptSecrets(ctx context.Context) {
+	acquired, err := r.stateCfg.TryAcquireLease(ctx, store.DataKeyLeaseResource, 0)
+	if err != nil {
+		slog.Error("Failed to acquire lease of data key", log.BBError(err))
+		return
+	}
+	if !acquired {
+		return
+	}
+	defer r.stateCfg.ReleaseLease(ctx, store.DataKeyLeaseResource)
+
+	if err := r.store.RefreshDataKeys(ctx); err != nil {
+		slog.Error("Failed to refresh data keys", log.BBError(err))
+		return
+	}
+	count, err := r.store.ReencryptSecrets(ctx)
+	if err != nil {
+		slog.Error("Failed to re-encrypt secrets", log.BBError(err))
+		return
+	}
+	if count > 0 {
+		slog.Info(fmt.Sprintf("Re-encrypted %d secrets with the active data key", count))
+	}
+	deleted, err := r.store.DeleteUnusedDataKeys(ctx, time.Now().Add(-dataKeyRetention).Unix())
+	if err != nil {
+		slog.Error("Failed to delete unused data keys", log.BBError(err))
+		return
+	}
+	if deleted > 0 {
+		slog.Info(fmt.Sprintf("Deleted %d unused data keys", deleted))
+	}
+}
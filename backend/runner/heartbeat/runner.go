@@ -0,0 +1,69 @@
+// Package heartbeat is the runner keeping the replica alive in high-availability mode.
+package heartbeat
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/state"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// NewRunner creates a new runner instance.
+func NewRunner(store *store.Store, stateCfg *state.State) *Runner {
+	return &Runner{
+		store:    store,
+		stateCfg: stateCfg,
+	}
+}
+
+// Runner is the runner sending heartbeats of the replica and reclaiming the leases held by dead replicas.
+type Runner struct {
+	store    *store.Store
+	stateCfg *state.State
+}
+
+// Run runs the runner.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(state.HeartbeatInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	slog.Debug(fmt.Sprintf("Heartbeat runner started and will run every %v", state.HeartbeatInterval))
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Beat(ctx); err != nil {
+				slog.Error("failed to send heartbeat", log.BBError(err))
+				continue
+			}
+			r.reclaimLeases(ctx)
+		case <-ctx.Done():
+			// Release the leases immediately so that other replicas don't need to wait for the replica timeout.
+			// Use a new context because ctx is already canceled.
+			if err := r.store.DeleteReplica(context.Background(), r.stateCfg.ReplicaID); err != nil {
+				slog.Error("failed to delete replica", slog.String("replica", r.stateCfg.ReplicaID), log.BBError(err))
+			}
+			return
+		}
+	}
+}
+
+// Beat sends a heartbeat of the replica.
+func (r *Runner) Beat(ctx context.Context) error {
+	return r.store.UpsertReplicaHeartbeat(ctx, r.stateCfg.ReplicaID)
+}
+
+func (r *Runner) reclaimLeases(ctx context.Context) {
+	leases, err := r.store.DeleteDeadReplicas(ctx, state.AliveSinceTs())
+	if err != nil {
+		slog.Error("failed to reclaim leases of dead replicas", log.BBError(err))
+		return
+	}
+	for _, lease := range leases {
+		slog.Info("Reclaimed lease of dead replica", slog.String("resource", lease.Resource), slog.String("replica", lease.Holder))
+	}
+}
@@ -211,7 +211,12 @@ func (s *SlowQueryWeeklyMailSender) sendEmail(ctx context.Context, now time.Time
 
 		for _, binding := range projectPolicy.Bindings {
 			if binding.Role == api.Owner {
-				for _, member := range binding.Members {
+				members, err := s.store.GetPolicyBindingUsers(ctx, binding)
+				if err != nil {
+					slog.Error("Failed to get project owners", log.BBError(err))
+					continue
+				}
+				for _, member := range members {
 					apiValue.SMTPTo = member.Email
 					subject := fmt.Sprintf("%s database slow query weekly report %s", project.Title, generateDateRange(now))
 					if err := send(apiValue, subject, body); err != nil {
@@ -417,6 +422,14 @@ func engineTypeString(engine db.Type) string {
 		return "MySQL"
 	case db.Postgres:
 		return "Postgres"
+	case db.MSSQL:
+		return "SQL Server"
+	case db.Oracle:
+		return "Oracle"
+	case db.MongoDB:
+		return "MongoDB"
+	case db.ClickHouse:
+		return "ClickHouse"
 	}
 	return ""
 }
@@ -699,6 +712,14 @@ func engineOrder(engine db.Type) int {
 		return 1
 	case db.Postgres:
 		return 2
+	case db.MSSQL:
+		return 3
+	case db.Oracle:
+		return 4
+	case db.MongoDB:
+		return 5
+	case db.ClickHouse:
+		return 6
 	default:
 		return 100
 	}
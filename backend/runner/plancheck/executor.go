@@ -52,7 +52,7 @@ func isStatementAdviseSupported(dbType db.Type) bool {
 
 func isStatementReportSupported(dbType db.Type) bool {
 	switch dbType {
-	case db.Postgres, db.MySQL, db.OceanBase, db.Oracle:
+	case db.Postgres, db.MySQL, db.OceanBase, db.Oracle, db.Redis:
 		return true
 	default:
 		return false
@@ -115,9 +115,37 @@ func (s *Scheduler) runPlanCheckRun(ctx context.Context, planCheckRun *store.Pla
 	s.stateCfg.InstanceOutstandingConnections[instanceUID]++
 	s.stateCfg.Unlock()
 
+	// In high-availability mode, the plan check run is executed by the replica holding the lease.
+	// The plan check run is re-executed by another replica if the holder is dead.
+	acquired, err := s.stateCfg.TryAcquireLease(ctx, store.PlanCheckRunLeaseResource(planCheckRun.UID), instanceUID)
+	if err != nil || !acquired {
+		if err != nil {
+			slog.Error("failed to acquire lease of plan check run", slog.Int("uid", planCheckRun.UID), log.BBError(err))
+		}
+		s.stateCfg.Lock()
+		s.stateCfg.InstanceOutstandingConnections[instanceUID]--
+		s.stateCfg.Unlock()
+		return
+	}
+	// The lease is released once a plan check run finishes, so another replica may have finished it since we listed it.
+	if s.stateCfg.HA {
+		running, err := s.isPlanCheckRunRunning(ctx, planCheckRun.UID)
+		if err != nil || !running {
+			if err != nil {
+				slog.Error("failed to get plan check run", slog.Int("uid", planCheckRun.UID), log.BBError(err))
+			}
+			s.stateCfg.ReleaseLease(ctx, store.PlanCheckRunLeaseResource(planCheckRun.UID))
+			s.stateCfg.Lock()
+			s.stateCfg.InstanceOutstandingConnections[instanceUID]--
+			s.stateCfg.Unlock()
+			return
+		}
+	}
+
 	s.stateCfg.RunningPlanChecks.Store(planCheckRun.UID, true)
 	go func() {
 		defer func() {
+			s.stateCfg.ReleaseLease(ctx, store.PlanCheckRunLeaseResource(planCheckRun.UID))
 			s.stateCfg.RunningPlanChecks.Delete(planCheckRun.UID)
 			s.stateCfg.Lock()
 			s.stateCfg.InstanceOutstandingConnections[instanceUID]--
@@ -132,6 +160,17 @@ func (s *Scheduler) runPlanCheckRun(ctx context.Context, planCheckRun *store.Pla
 	}()
 }
 
+func (s *Scheduler) isPlanCheckRunRunning(ctx context.Context, uid int) (bool, error) {
+	planCheckRuns, err := s.store.ListPlanCheckRuns(ctx, &store.FindPlanCheckRunMessage{
+		UID:    &uid,
+		Status: &[]store.PlanCheckRunStatus{store.PlanCheckRunStatusRunning},
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(planCheckRuns) > 0, nil
+}
+
 func (s *Scheduler) markPlanCheckRunDone(ctx context.Context, planCheckRun *store.PlanCheckRunMessage, results []*storepb.PlanCheckRunResult_Result) {
 	result := &storepb.PlanCheckRunResult{
 		Results: results,
@@ -168,12 +168,14 @@ func (e *StatementAdviseExecutor) runForDatabaseTarget(ctx context.Context, conf
 	// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
 	renderedStatement := utils.RenderStatement(statement, materials)
 	adviceList, err := advisor.SQLReviewCheck(renderedStatement, policy.RuleList, advisor.SQLReviewCheckContext{
-		Charset:   dbSchema.Metadata.CharacterSet,
-		Collation: dbSchema.Metadata.Collation,
-		DbType:    dbType,
-		Catalog:   catalog,
-		Driver:    connection,
-		Context:   ctx,
+		Charset:       dbSchema.Metadata.CharacterSet,
+		Collation:     dbSchema.Metadata.Collation,
+		DbType:        dbType,
+		Catalog:       catalog,
+		Driver:        connection,
+		Context:       ctx,
+		EnvironmentID: environment.ResourceID,
+		ProjectID:     database.ProjectID,
 	})
 	if err != nil {
 		return nil, err
@@ -386,12 +388,14 @@ func (e *StatementAdviseExecutor) runForDatabaseGroupTarget(ctx context.Context,
 				// To avoid leaking the rendered statement, the error message should use the original statement and not the rendered statement.
 				renderedStatement := utils.RenderStatement(statement, materials)
 				adviceList, err := advisor.SQLReviewCheck(renderedStatement, policy.RuleList, advisor.SQLReviewCheckContext{
-					Charset:   dbSchema.Metadata.CharacterSet,
-					Collation: dbSchema.Metadata.Collation,
-					DbType:    dbType,
-					Catalog:   catalog,
-					Driver:    connection,
-					Context:   ctx,
+					Charset:       dbSchema.Metadata.CharacterSet,
+					Collation:     dbSchema.Metadata.Collation,
+					DbType:        dbType,
+					Catalog:       catalog,
+					Driver:        connection,
+					Context:       ctx,
+					EnvironmentID: environment.ResourceID,
+					ProjectID:     db.ProjectID,
 				})
 				if err != nil {
 					return nil, err
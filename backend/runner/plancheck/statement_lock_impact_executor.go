@@ -0,0 +1,843 @@
+package plancheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pgquery "github.com/pganalyze/pg_query_go/v4"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/db"
+	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
+	"github.com/bytebase/bytebase/backend/store"
+	"github.com/bytebase/bytebase/backend/utils"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// The throughputs are conservative estimations on the commodity SSD.
+// They are only used to give the reviewers a sense of the magnitude of the lock duration.
+const (
+	tableRewriteBytesPerSecond   = 50 * 1024 * 1024
+	validationScanBytesPerSecond = 200 * 1024 * 1024
+	indexBuildBytesPerSecond     = 100 * 1024 * 1024
+)
+
+// volatileFunctions are the commonly used volatile functions.
+// Adding a column with a volatile default rewrites the table, while a stable or immutable default is stored in the catalog since PostgreSQL 11.
+var volatileFunctions = map[string]bool{
+	"random":             true,
+	"gen_random_uuid":    true,
+	"uuid_generate_v1":   true,
+	"uuid_generate_v1mc": true,
+	"uuid_generate_v4":   true,
+	"clock_timestamp":    true,
+	"timeofday":          true,
+	"nextval":            true,
+}
+
+var serialTypes = map[string]bool{
+	"smallserial": true,
+	"serial2":     true,
+	"serial":      true,
+	"serial4":     true,
+	"bigserial":   true,
+	"serial8":     true,
+}
+
+var _ Executor = (*StatementLockImpactExecutor)(nil)
+
+// NewStatementLockImpactExecutor creates a statement lock impact executor.
+func NewStatementLockImpactExecutor(store *store.Store, dbFactory *dbfactory.DBFactory) Executor {
+	return &StatementLockImpactExecutor{
+		store:     store,
+		dbFactory: dbFactory,
+	}
+}
+
+// StatementLockImpactExecutor is the statement lock impact executor.
+// It reports the table lock taken by each PostgreSQL DDL statement, and whether the statement rewrites or scans the whole table while holding the lock.
+type StatementLockImpactExecutor struct {
+	store     *store.Store
+	dbFactory *dbfactory.DBFactory
+}
+
+// Run runs the statement lock impact executor.
+func (e *StatementLockImpactExecutor) Run(ctx context.Context, config *storepb.PlanCheckRunConfig) ([]*storepb.PlanCheckRunResult_Result, error) {
+	if config.DatabaseGroupUid != nil {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+				Code:    common.Ok.Int64(),
+				Title:   "Lock impact analysis is not supported for database group",
+				Content: "",
+			},
+		}, nil
+	}
+
+	instanceUID := int(config.InstanceUid)
+	instance, err := e.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &instanceUID})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get instance UID %v", instanceUID)
+	}
+	if instance == nil {
+		return nil, errors.Errorf("instance not found UID %v", instanceUID)
+	}
+	if instance.Engine != db.Postgres {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+				Code:    common.Ok.Int64(),
+				Title:   fmt.Sprintf("Lock impact analysis is not supported for %s", instance.Engine),
+				Content: "",
+			},
+		}, nil
+	}
+
+	database, err := e.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{InstanceID: &instance.ResourceID, DatabaseName: &config.DatabaseName})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get database %q", config.DatabaseName)
+	}
+	if database == nil {
+		return nil, errors.Errorf("database not found %q", config.DatabaseName)
+	}
+	dbSchema, err := e.store.GetDBSchema(ctx, database.UID)
+	if err != nil {
+		return nil, err
+	}
+	if dbSchema == nil {
+		return nil, errors.Errorf("database schema not found: %d", database.UID)
+	}
+
+	sheetUID := int(config.SheetUid)
+	sheet, err := e.store.GetSheet(ctx, &store.FindSheetMessage{UID: &sheetUID}, api.SystemBotID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sheet %d", sheetUID)
+	}
+	if sheet == nil {
+		return nil, errors.Errorf("sheet %d not found", sheetUID)
+	}
+	if sheet.Size > common.MaxSheetSizeForTaskCheck {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+				Code:    common.Ok.Int64(),
+				Title:   "Large SQL review policy is disabled",
+				Content: "",
+			},
+		}, nil
+	}
+	statement, err := e.store.GetSheetStatementByID(ctx, sheetUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sheet statement %d", sheetUID)
+	}
+	materials := utils.GetSecretMapFromDatabaseMessage(database)
+	renderedStatement := utils.RenderStatement(statement, materials)
+
+	// The statements are executed with the admin data source, so its search path resolves the unqualified names.
+	// The lock impact is advisory, so the analysis failures are reported as warnings instead of failing the check, which would block the approval.
+	driver, err := e.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return []*storepb.PlanCheckRunResult_Result{newLockImpactAnalysisFailedResult(errors.Wrap(err, "failed to connect to the database"))}, nil
+	}
+	defer driver.Close(ctx)
+	analyzer, err := newLockImpactAnalyzer(ctx, driver.GetDB(), dbSchema)
+	if err != nil {
+		return []*storepb.PlanCheckRunResult_Result{newLockImpactAnalysisFailedResult(err)}, nil
+	}
+	return analyzeLockImpact(analyzer, renderedStatement, dbSchema), nil
+}
+
+// analyzeLockImpact returns the lock impact results of the statement, or a warning result if the analysis fails.
+func analyzeLockImpact(analyzer *lockImpactAnalyzer, statement string, dbSchema *store.DBSchema) []*storepb.PlanCheckRunResult_Result {
+	impacts, err := analyzer.analyze(statement)
+	if err != nil {
+		return []*storepb.PlanCheckRunResult_Result{newLockImpactAnalysisFailedResult(err)}
+	}
+
+	var results []*storepb.PlanCheckRunResult_Result
+	for _, impact := range impacts {
+		results = append(results, convertLockImpactToResult(impact, dbSchema.FindTable(impact.schema, impact.table)))
+	}
+	if len(results) == 0 {
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_SUCCESS,
+				Title:   "OK",
+				Content: "",
+				Code:    common.Ok.Int64(),
+				Report:  nil,
+			},
+		}
+	}
+	return results
+}
+
+func newLockImpactAnalysisFailedResult(err error) *storepb.PlanCheckRunResult_Result {
+	return &storepb.PlanCheckRunResult_Result{
+		Status:  storepb.PlanCheckRunResult_Result_WARNING,
+		Code:    common.TaskLockAnalysisFailed.Int64(),
+		Title:   "Failed to analyze the lock impact",
+		Content: err.Error(),
+	}
+}
+
+// lockImpact is the lock impact of a statement on a table.
+type lockImpact struct {
+	line          int
+	statementType string
+	schema        string
+	table         string
+	lockLevel     storepb.PlanCheckRunResult_Result_LockImpactReport_LockLevel
+	// tableRewrite, validationScan and indexBuild are the operations reading the whole table while holding the lock.
+	tableRewrite   bool
+	validationScan bool
+	indexBuild     bool
+	// reasons are the human-readable explanations of the operations.
+	reasons []string
+}
+
+// lockImpactAnalyzer analyzes the lock impact of the statements on a PostgreSQL database.
+type lockImpactAnalyzer struct {
+	dbSchema *store.DBSchema
+	// defaultSearchPath is the effective search path of the connection executing the statements.
+	defaultSearchPath []string
+	// searchPath resolves the unqualified names, it is changed by the SET search_path statements.
+	searchPath []string
+	// serverVersionNum is the server_version_num of the database, such as 150002.
+	serverVersionNum int
+	// getColumnType returns the type of the column with the type modifiers, such as character varying(20).
+	// It returns an empty string if the column is not found.
+	getColumnType func(schema string, table string, column string) (string, error)
+}
+
+// newLockImpactAnalyzer creates the analyzer reading the search path, server version and column types from the database.
+func newLockImpactAnalyzer(ctx context.Context, sqlDB *sql.DB, dbSchema *store.DBSchema) (*lockImpactAnalyzer, error) {
+	var serverVersionNum int
+	var searchPath string
+	if err := sqlDB.QueryRowContext(ctx, "SELECT current_setting('server_version_num')::int, array_to_string(current_schemas(false), ',')").Scan(&serverVersionNum, &searchPath); err != nil {
+		return nil, errors.Wrap(err, "failed to get the search path and server version")
+	}
+	var defaultSearchPath []string
+	if searchPath != "" {
+		defaultSearchPath = strings.Split(searchPath, ",")
+	}
+	return &lockImpactAnalyzer{
+		dbSchema:          dbSchema,
+		defaultSearchPath: defaultSearchPath,
+		serverVersionNum:  serverVersionNum,
+		getColumnType: func(schema string, table string, column string) (string, error) {
+			var columnType string
+			if err := sqlDB.QueryRowContext(ctx, `
+				SELECT format_type(atttypid, atttypmod)
+				FROM pg_catalog.pg_attribute
+				WHERE attrelid = to_regclass(format('%I.%I', $1::text, $2::text)) AND attname = $3 AND NOT attisdropped`,
+				schema, table, column,
+			).Scan(&columnType); err != nil {
+				if err == sql.ErrNoRows {
+					return "", nil
+				}
+				return "", errors.Wrapf(err, "failed to get the type of column %q.%q.%q", schema, table, column)
+			}
+			return columnType, nil
+		},
+	}, nil
+}
+
+// resolveSchema returns the schema of the table, the unqualified table is resolved by the search path.
+// The table not found in the search path, e.g. created by the same statements, is in the first schema of the search path.
+func (a *lockImpactAnalyzer) resolveSchema(schema string, table string) string {
+	if schema != "" {
+		return schema
+	}
+	for _, searchSchema := range a.searchPath {
+		if a.dbSchema.FindTable(searchSchema, table) != nil {
+			return searchSchema
+		}
+	}
+	if len(a.searchPath) > 0 {
+		return a.searchPath[0]
+	}
+	return "public"
+}
+
+func (a *lockImpactAnalyzer) newLockImpact(statementType string, schema string, table string) *lockImpact {
+	return &lockImpact{
+		statementType: statementType,
+		schema:        a.resolveSchema(schema, table),
+		table:         table,
+	}
+}
+
+// acquire upgrades the lock level, a statement holds the strongest lock taken by its sub-commands.
+func (l *lockImpact) acquire(level storepb.PlanCheckRunResult_Result_LockImpactReport_LockLevel) {
+	if level > l.lockLevel {
+		l.lockLevel = level
+	}
+}
+
+func (l *lockImpact) rewrite(reason string) {
+	l.tableRewrite = true
+	l.reasons = append(l.reasons, reason)
+}
+
+func (l *lockImpact) scan(reason string) {
+	l.validationScan = true
+	l.reasons = append(l.reasons, reason)
+}
+
+func (l *lockImpact) buildIndex(reason string) {
+	l.indexBuild = true
+	l.reasons = append(l.reasons, reason)
+}
+
+// blocksWrites returns true if the lock conflicts with INSERT, UPDATE and DELETE.
+func (l *lockImpact) blocksWrites() bool {
+	return l.lockLevel >= storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE
+}
+
+func (l *lockImpact) tableName() string {
+	return fmt.Sprintf("%q.%q", l.schema, l.table)
+}
+
+// estimateDuration estimates the duration of the table rewrite, validation scan and index build from the synced table size.
+func (l *lockImpact) estimateDuration(table *storepb.TableMetadata) time.Duration {
+	if table == nil {
+		return 0
+	}
+	var seconds float64
+	if l.tableRewrite {
+		seconds += float64(table.DataSize+table.IndexSize) / tableRewriteBytesPerSecond
+	}
+	if l.validationScan {
+		seconds += float64(table.DataSize) / validationScanBytesPerSecond
+	}
+	if l.indexBuild {
+		seconds += float64(table.DataSize) / indexBuildBytesPerSecond
+	}
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
+
+func convertLockImpactToResult(impact *lockImpact, table *storepb.TableMetadata) *storepb.PlanCheckRunResult_Result {
+	lockLevel := strings.ReplaceAll(impact.lockLevel.String(), "_", " ")
+	duration := impact.estimateDuration(table)
+
+	status := storepb.PlanCheckRunResult_Result_SUCCESS
+	code := common.Ok
+	if impact.blocksWrites() {
+		switch {
+		case impact.tableRewrite:
+			status, code = storepb.PlanCheckRunResult_Result_WARNING, common.TaskLockTableRewrite
+		case impact.validationScan:
+			status, code = storepb.PlanCheckRunResult_Result_WARNING, common.TaskLockValidationScan
+		case impact.indexBuild:
+			status, code = storepb.PlanCheckRunResult_Result_WARNING, common.TaskLockIndexBuild
+		}
+	}
+
+	var content []string
+	content = append(content, impact.reasons...)
+	if table != nil && duration > 0 {
+		content = append(content, fmt.Sprintf("Estimated to hold the %s lock for %s based on %d rows and %d bytes of data", lockLevel, duration, table.RowCount, table.DataSize))
+	}
+	report := &storepb.PlanCheckRunResult_Result_LockImpactReport{
+		Line:              int64(impact.line),
+		StatementType:     impact.statementType,
+		Table:             impact.tableName(),
+		LockLevel:         impact.lockLevel,
+		TableRewrite:      impact.tableRewrite,
+		ValidationScan:    impact.validationScan,
+		RowCount:          0,
+		DataSize:          0,
+		EstimatedDuration: durationpb.New(duration),
+	}
+	if table != nil {
+		report.RowCount = table.RowCount
+		report.DataSize = table.DataSize
+	}
+	return &storepb.PlanCheckRunResult_Result{
+		Status:  status,
+		Code:    code.Int64(),
+		Title:   fmt.Sprintf("%s lock on %s", lockLevel, impact.tableName()),
+		Content: strings.Join(content, ". "),
+		Report: &storepb.PlanCheckRunResult_Result_LockImpactReport_{
+			LockImpactReport: report,
+		},
+	}
+}
+
+// analyze returns the lock impact of the statements locking the existing tables.
+// See https://www.postgresql.org/docs/current/sql-altertable.html for the lock taken by each ALTER TABLE sub-command.
+func (a *lockImpactAnalyzer) analyze(statement string) ([]*lockImpact, error) {
+	a.searchPath = a.defaultSearchPath
+	singleSQLs, err := parser.SplitMultiSQL(parser.Postgres, statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split SQL")
+	}
+
+	var impacts []*lockImpact
+	for _, singleSQL := range singleSQLs {
+		if singleSQL.Empty {
+			continue
+		}
+		res, err := pgquery.Parse(singleSQL.Text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse statement %q", singleSQL.Text)
+		}
+		for _, stmt := range res.Stmts {
+			stmtImpacts, err := a.getLockImpact(stmt.Stmt)
+			if err != nil {
+				return nil, err
+			}
+			for _, impact := range stmtImpacts {
+				impact.line = singleSQL.LastLine
+				impacts = append(impacts, impact)
+			}
+		}
+	}
+	return impacts, nil
+}
+
+func (a *lockImpactAnalyzer) getLockImpact(node *pgquery.Node) ([]*lockImpact, error) {
+	switch n := node.Node.(type) {
+	case *pgquery.Node_VariableSetStmt:
+		a.setSearchPath(n.VariableSetStmt)
+		return nil, nil
+	case *pgquery.Node_AlterTableStmt:
+		if n.AlterTableStmt.Objtype != pgquery.ObjectType_OBJECT_TABLE {
+			return nil, nil
+		}
+		impact, err := a.getAlterTableLockImpact(n.AlterTableStmt)
+		if err != nil {
+			return nil, err
+		}
+		return []*lockImpact{impact}, nil
+	case *pgquery.Node_IndexStmt:
+		impact := a.newLockImpact("CREATE_INDEX", n.IndexStmt.Relation.Schemaname, n.IndexStmt.Relation.Relname)
+		if n.IndexStmt.Concurrent {
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_UPDATE_EXCLUSIVE)
+			impact.buildIndex("Builds the index concurrently without blocking writes")
+		} else {
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE)
+			impact.buildIndex("Builds the index while blocking writes, consider CREATE INDEX CONCURRENTLY")
+		}
+		return []*lockImpact{impact}, nil
+	case *pgquery.Node_DropStmt:
+		var impacts []*lockImpact
+		for _, object := range n.DropStmt.Objects {
+			list, ok := object.Node.(*pgquery.Node_List)
+			if !ok {
+				continue
+			}
+			schema, name, err := convertTableName(list)
+			if err != nil {
+				return nil, err
+			}
+			switch n.DropStmt.RemoveType {
+			case pgquery.ObjectType_OBJECT_TABLE:
+				impact := a.newLockImpact("DROP_TABLE", schema, name)
+				impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+				impacts = append(impacts, impact)
+			case pgquery.ObjectType_OBJECT_INDEX:
+				impact := a.newIndexLockImpact("DROP_INDEX", schema, name)
+				if impact == nil {
+					continue
+				}
+				if n.DropStmt.Concurrent {
+					impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_UPDATE_EXCLUSIVE)
+				} else {
+					impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+				}
+				impacts = append(impacts, impact)
+			}
+		}
+		return impacts, nil
+	case *pgquery.Node_TruncateStmt:
+		var impacts []*lockImpact
+		for _, relation := range n.TruncateStmt.Relations {
+			if rangeVar := relation.GetRangeVar(); rangeVar != nil {
+				impact := a.newLockImpact("TRUNCATE", rangeVar.Schemaname, rangeVar.Relname)
+				impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+				impacts = append(impacts, impact)
+			}
+		}
+		return impacts, nil
+	case *pgquery.Node_RenameStmt:
+		if n.RenameStmt.Relation == nil || n.RenameStmt.RelationType != pgquery.ObjectType_OBJECT_TABLE {
+			return nil, nil
+		}
+		var statementType string
+		switch n.RenameStmt.RenameType {
+		case pgquery.ObjectType_OBJECT_TABLE:
+			statementType = "RENAME_TABLE"
+		case pgquery.ObjectType_OBJECT_COLUMN:
+			statementType = "RENAME_COLUMN"
+		case pgquery.ObjectType_OBJECT_TABCONSTRAINT:
+			statementType = "RENAME_CONSTRAINT"
+		default:
+			return nil, nil
+		}
+		impact := a.newLockImpact(statementType, n.RenameStmt.Relation.Schemaname, n.RenameStmt.Relation.Relname)
+		impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+		return []*lockImpact{impact}, nil
+	case *pgquery.Node_VacuumStmt:
+		if !n.VacuumStmt.IsVacuumcmd || !hasOption(n.VacuumStmt.Options, "full") {
+			return nil, nil
+		}
+		var impacts []*lockImpact
+		for _, rel := range n.VacuumStmt.Rels {
+			if relation := rel.GetVacuumRelation().GetRelation(); relation != nil {
+				impact := a.newLockImpact("VACUUM", relation.Schemaname, relation.Relname)
+				impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+				impact.rewrite("VACUUM FULL rewrites the table and its indexes")
+				impacts = append(impacts, impact)
+			}
+		}
+		return impacts, nil
+	case *pgquery.Node_ClusterStmt:
+		if n.ClusterStmt.Relation == nil {
+			return nil, nil
+		}
+		impact := a.newLockImpact("CLUSTER", n.ClusterStmt.Relation.Schemaname, n.ClusterStmt.Relation.Relname)
+		impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+		impact.rewrite("CLUSTER rewrites the table and its indexes")
+		return []*lockImpact{impact}, nil
+	case *pgquery.Node_ReindexStmt:
+		var impact *lockImpact
+		switch n.ReindexStmt.Kind {
+		case pgquery.ReindexObjectType_REINDEX_OBJECT_TABLE:
+			impact = a.newLockImpact("REINDEX", n.ReindexStmt.Relation.Schemaname, n.ReindexStmt.Relation.Relname)
+		case pgquery.ReindexObjectType_REINDEX_OBJECT_INDEX:
+			impact = a.newIndexLockImpact("REINDEX", n.ReindexStmt.Relation.Schemaname, n.ReindexStmt.Relation.Relname)
+			if impact == nil {
+				return nil, nil
+			}
+		default:
+			return nil, nil
+		}
+		if hasOption(n.ReindexStmt.Params, "concurrently") {
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_UPDATE_EXCLUSIVE)
+			impact.buildIndex("Rebuilds the indexes concurrently without blocking writes")
+		} else {
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE)
+			impact.buildIndex("Rebuilds the indexes while blocking writes, consider REINDEX CONCURRENTLY")
+		}
+		return []*lockImpact{impact}, nil
+	}
+	return nil, nil
+}
+
+// setSearchPath follows the SET search_path statements, RESET and SET TO DEFAULT restore the search path of the connection.
+func (a *lockImpactAnalyzer) setSearchPath(stmt *pgquery.VariableSetStmt) {
+	if stmt.Name != "search_path" {
+		return
+	}
+	switch stmt.Kind {
+	case pgquery.VariableSetKind_VAR_SET_VALUE:
+		var searchPath []string
+		for _, arg := range stmt.Args {
+			schema := arg.GetAConst().GetSval().GetSval()
+			// The schema of the user executing the statements is already in the search path of the connection.
+			if schema == "" || schema == "$user" {
+				continue
+			}
+			searchPath = append(searchPath, schema)
+		}
+		a.searchPath = searchPath
+	case pgquery.VariableSetKind_VAR_SET_DEFAULT, pgquery.VariableSetKind_VAR_RESET:
+		a.searchPath = a.defaultSearchPath
+	}
+}
+
+// newIndexLockImpact returns the lock impact on the table of the index, or nil if the index is not found.
+// The unqualified index is resolved by the search path.
+func (a *lockImpactAnalyzer) newIndexLockImpact(statementType string, schema string, index string) *lockImpact {
+	schemaList := a.searchPath
+	if schema != "" {
+		schemaList = []string{schema}
+	}
+	for _, schema := range schemaList {
+		if table := findIndexTable(a.dbSchema, schema, index); table != "" {
+			return &lockImpact{
+				statementType: statementType,
+				schema:        schema,
+				table:         table,
+			}
+		}
+	}
+	return nil
+}
+
+func (a *lockImpactAnalyzer) getAlterTableLockImpact(node *pgquery.AlterTableStmt) (*lockImpact, error) {
+	impact := a.newLockImpact("ALTER_TABLE", node.Relation.Schemaname, node.Relation.Relname)
+	for _, cmd := range node.Cmds {
+		alterCmd := cmd.GetAlterTableCmd()
+		if alterCmd == nil {
+			continue
+		}
+		switch alterCmd.Subtype {
+		case pgquery.AlterTableType_AT_AddColumn:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+			if column := alterCmd.Def.GetColumnDef(); column != nil {
+				checkAddColumn(impact, column)
+			}
+		case pgquery.AlterTableType_AT_AlterColumnType:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+			column := alterCmd.Def.GetColumnDef()
+			binaryCoercible := false
+			if column != nil {
+				var err error
+				if binaryCoercible, err = a.isBinaryCoercibleTypeChange(impact.schema, impact.table, alterCmd.Name, column); err != nil {
+					return nil, err
+				}
+			}
+			if !binaryCoercible {
+				impact.rewrite(fmt.Sprintf("Changing the type of column %q rewrites the table and its indexes", alterCmd.Name))
+			}
+		case pgquery.AlterTableType_AT_SetNotNull:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+			impact.scan(fmt.Sprintf("Setting column %q NOT NULL scans the table, consider adding a NOT VALID check constraint and validating it first", alterCmd.Name))
+		case pgquery.AlterTableType_AT_AddConstraint:
+			if constraint := alterCmd.Def.GetConstraint(); constraint != nil {
+				checkAddConstraint(impact, constraint)
+			} else {
+				impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+			}
+		case pgquery.AlterTableType_AT_ValidateConstraint:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_UPDATE_EXCLUSIVE)
+			impact.scan(fmt.Sprintf("Validating constraint %q scans the table without blocking writes", alterCmd.Name))
+		case
+			pgquery.AlterTableType_AT_SetStatistics,
+			pgquery.AlterTableType_AT_ClusterOn,
+			pgquery.AlterTableType_AT_DropCluster,
+			pgquery.AlterTableType_AT_SetRelOptions,
+			pgquery.AlterTableType_AT_ResetRelOptions,
+			pgquery.AlterTableType_AT_AttachPartition:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_UPDATE_EXCLUSIVE)
+		case
+			pgquery.AlterTableType_AT_EnableTrig,
+			pgquery.AlterTableType_AT_EnableAlwaysTrig,
+			pgquery.AlterTableType_AT_EnableReplicaTrig,
+			pgquery.AlterTableType_AT_DisableTrig,
+			pgquery.AlterTableType_AT_EnableTrigAll,
+			pgquery.AlterTableType_AT_DisableTrigAll,
+			pgquery.AlterTableType_AT_EnableTrigUser,
+			pgquery.AlterTableType_AT_DisableTrigUser:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_ROW_EXCLUSIVE)
+		case pgquery.AlterTableType_AT_SetTableSpace:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+			impact.rewrite("Moving the table to another tablespace copies the whole table")
+		case
+			pgquery.AlterTableType_AT_SetLogged,
+			pgquery.AlterTableType_AT_SetUnLogged,
+			pgquery.AlterTableType_AT_SetAccessMethod:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+			impact.rewrite("Changing the persistence or access method rewrites the table")
+		default:
+			impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+		}
+	}
+	return impact, nil
+}
+
+func checkAddColumn(impact *lockImpact, column *pgquery.ColumnDef) {
+	if serialTypes[getTypeName(column.TypeName)] {
+		impact.rewrite(fmt.Sprintf("Adding serial column %q rewrites the table", column.Colname))
+	}
+	for _, node := range column.Constraints {
+		constraint := node.GetConstraint()
+		if constraint == nil {
+			continue
+		}
+		switch constraint.Contype {
+		case pgquery.ConstrType_CONSTR_DEFAULT:
+			if hasVolatileFunction(constraint.RawExpr) {
+				impact.rewrite(fmt.Sprintf("Adding column %q with a volatile default rewrites the table", column.Colname))
+			}
+		case pgquery.ConstrType_CONSTR_GENERATED:
+			impact.rewrite(fmt.Sprintf("Adding stored generated column %q rewrites the table", column.Colname))
+		case pgquery.ConstrType_CONSTR_IDENTITY:
+			impact.rewrite(fmt.Sprintf("Adding identity column %q rewrites the table", column.Colname))
+		case pgquery.ConstrType_CONSTR_CHECK:
+			impact.scan(fmt.Sprintf("Adding column %q with a check constraint scans the table", column.Colname))
+		case pgquery.ConstrType_CONSTR_PRIMARY, pgquery.ConstrType_CONSTR_UNIQUE:
+			impact.buildIndex(fmt.Sprintf("Adding column %q with a primary key or unique constraint builds the index", column.Colname))
+		}
+	}
+}
+
+func checkAddConstraint(impact *lockImpact, constraint *pgquery.Constraint) {
+	switch constraint.Contype {
+	case pgquery.ConstrType_CONSTR_FOREIGN:
+		// The referenced table is locked in the same level.
+		impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_ROW_EXCLUSIVE)
+		if !constraint.SkipValidation {
+			impact.scan(fmt.Sprintf("Adding foreign key %q scans the table, consider adding it NOT VALID and validating it later", constraint.Conname))
+		}
+	case pgquery.ConstrType_CONSTR_CHECK:
+		impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+		if !constraint.SkipValidation {
+			impact.scan(fmt.Sprintf("Adding check constraint %q scans the table, consider adding it NOT VALID and validating it later", constraint.Conname))
+		}
+	case pgquery.ConstrType_CONSTR_PRIMARY, pgquery.ConstrType_CONSTR_UNIQUE, pgquery.ConstrType_CONSTR_EXCLUSION:
+		impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+		if constraint.Indexname == "" {
+			impact.buildIndex(fmt.Sprintf("Adding constraint %q builds the index, consider creating the index concurrently and adding the constraint USING INDEX", constraint.Conname))
+		}
+	default:
+		impact.acquire(storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE)
+	}
+}
+
+// isBinaryCoercibleTypeChange returns true if the type change doesn't rewrite the table.
+// Changing varchar or text to text or unbounded varchar, and increasing the length of varchar since PostgreSQL 9.2 don't rewrite the table.
+func (a *lockImpactAnalyzer) isBinaryCoercibleTypeChange(schemaName string, tableName string, columnName string, column *pgquery.ColumnDef) (bool, error) {
+	// USING clause.
+	if column.RawDefault != nil {
+		return false, nil
+	}
+	if len(column.TypeName.ArrayBounds) > 0 {
+		return false, nil
+	}
+	newType := getTypeName(column.TypeName)
+	if newType != "text" && newType != "varchar" {
+		return false, nil
+	}
+	oldType, err := a.getOldColumnType(schemaName, tableName, columnName)
+	if err != nil {
+		return false, err
+	}
+	oldLength, oldIsVarchar := getVarcharLength(oldType)
+	if oldType != "text" && !oldIsVarchar {
+		return false, nil
+	}
+	if len(column.TypeName.Typmods) == 0 {
+		return true, nil
+	}
+	// The type modifier of varchar is the maximum length.
+	if newType != "varchar" || len(column.TypeName.Typmods) != 1 || !oldIsVarchar || oldLength == 0 {
+		return false, nil
+	}
+	newLength := column.TypeName.Typmods[0].GetAConst().GetIval().GetIval()
+	return a.serverVersionNum >= 90200 && int64(newLength) >= oldLength, nil
+}
+
+// getOldColumnType returns the type of the column with the type modifiers.
+// The synced column type doesn't contain the type modifiers, so it's only used if the type can't be read from the database.
+func (a *lockImpactAnalyzer) getOldColumnType(schemaName string, tableName string, columnName string) (string, error) {
+	if a.getColumnType != nil {
+		columnType, err := a.getColumnType(schemaName, tableName, columnName)
+		if err != nil {
+			return "", err
+		}
+		if columnType != "" {
+			return columnType, nil
+		}
+	}
+	if a.dbSchema == nil || a.dbSchema.Metadata == nil {
+		return "", nil
+	}
+	table := a.dbSchema.FindTable(schemaName, tableName)
+	if table == nil {
+		return "", nil
+	}
+	for _, oldColumn := range table.Columns {
+		if oldColumn.Name == columnName {
+			return oldColumn.Type, nil
+		}
+	}
+	return "", nil
+}
+
+// getVarcharLength returns the maximum length of the varchar type, 0 if unbounded.
+// The second return value is false if the type is not varchar.
+func getVarcharLength(columnType string) (int64, bool) {
+	if columnType == "character varying" {
+		return 0, true
+	}
+	lengthStr, ok := strings.CutPrefix(columnType, "character varying(")
+	if !ok {
+		return 0, false
+	}
+	length, err := strconv.ParseInt(strings.TrimSuffix(lengthStr, ")"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return length, true
+}
+
+func getTypeName(typeName *pgquery.TypeName) string {
+	if typeName == nil || len(typeName.Names) == 0 {
+		return ""
+	}
+	return typeName.Names[len(typeName.Names)-1].GetString_().GetSval()
+}
+
+// hasVolatileFunction returns true if the expression calls any known volatile function.
+func hasVolatileFunction(node *pgquery.Node) bool {
+	if node == nil {
+		return false
+	}
+	found := false
+	var walk func(message protoreflect.Message)
+	walk = func(message protoreflect.Message) {
+		if funcCall, ok := message.Interface().(*pgquery.FuncCall); ok && len(funcCall.Funcname) > 0 {
+			if volatileFunctions[funcCall.Funcname[len(funcCall.Funcname)-1].GetString_().GetSval()] {
+				found = true
+				return
+			}
+		}
+		message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+			if field.Kind() != protoreflect.MessageKind || field.IsMap() {
+				return true
+			}
+			if field.IsList() {
+				list := value.List()
+				for i := 0; i < list.Len() && !found; i++ {
+					walk(list.Get(i).Message())
+				}
+			} else {
+				walk(value.Message())
+			}
+			return !found
+		})
+	}
+	walk(node.ProtoReflect())
+	return found
+}
+
+func hasOption(options []*pgquery.Node, name string) bool {
+	for _, option := range options {
+		if option.GetDefElem().GetDefname() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func findIndexTable(dbSchema *store.DBSchema, schemaName string, indexName string) string {
+	if dbSchema == nil || dbSchema.Metadata == nil {
+		return ""
+	}
+	for _, schema := range dbSchema.Metadata.Schemas {
+		if schema.Name != schemaName {
+			continue
+		}
+		for _, table := range schema.Tables {
+			for _, index := range table.Indexes {
+				if index.Name == indexName {
+					return table.Name
+				}
+			}
+		}
+	}
+	return ""
+}
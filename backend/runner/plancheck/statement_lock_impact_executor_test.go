@@ -0,0 +1,208 @@
+package plancheck
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestAnalyzePostgreSQLLockImpact(t *testing.T) {
+	dbSchema := &store.DBSchema{
+		Metadata: &storepb.DatabaseSchemaMetadata{
+			Schemas: []*storepb.SchemaMetadata{
+				{
+					Name: "app",
+					Tables: []*storepb.TableMetadata{
+						{
+							Name: "u",
+							Indexes: []*storepb.IndexMetadata{
+								{Name: "idx_u_id"},
+							},
+						},
+					},
+				},
+				{
+					Name: "public",
+					Tables: []*storepb.TableMetadata{
+						{
+							Name: "t",
+							Columns: []*storepb.ColumnMetadata{
+								{Name: "name", Type: "character varying"},
+								{Name: "code", Type: "character varying"},
+								{Name: "age", Type: "integer"},
+							},
+							Indexes: []*storepb.IndexMetadata{
+								{Name: "idx_t_age"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The column types with the type modifiers read from the database.
+	columnTypes := map[string]string{
+		"public.t.code": "character varying(20)",
+	}
+
+	type lockImpactResult struct {
+		statementType  string
+		schema         string
+		table          string
+		lockLevel      storepb.PlanCheckRunResult_Result_LockImpactReport_LockLevel
+		tableRewrite   bool
+		validationScan bool
+		indexBuild     bool
+	}
+	tests := []struct {
+		statement string
+		want      []lockImpactResult
+	}{
+		{
+			statement: "ALTER TABLE t ADD COLUMN c int DEFAULT 0;",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE}},
+		},
+		{
+			statement: "ALTER TABLE t ADD COLUMN c uuid DEFAULT gen_random_uuid();",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE, tableRewrite: true}},
+		},
+		{
+			statement: "ALTER TABLE t ADD COLUMN id bigserial;",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE, tableRewrite: true}},
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN age TYPE bigint;",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE, tableRewrite: true}},
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN name TYPE text;",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE}},
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN code TYPE varchar(50);",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE}},
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN code TYPE varchar(10);",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE, tableRewrite: true}},
+		},
+		{
+			statement: "ALTER TABLE t ALTER COLUMN name SET NOT NULL;",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE, validationScan: true}},
+		},
+		{
+			statement: "ALTER TABLE t ADD CONSTRAINT fk_t FOREIGN KEY (age) REFERENCES p (id) NOT VALID;",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_ROW_EXCLUSIVE}},
+		},
+		{
+			statement: "ALTER TABLE t ADD CONSTRAINT chk_age CHECK (age > 0);",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE, validationScan: true}},
+		},
+		{
+			statement: "ALTER TABLE t VALIDATE CONSTRAINT chk_age;",
+			want:      []lockImpactResult{{statementType: "ALTER_TABLE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_UPDATE_EXCLUSIVE, validationScan: true}},
+		},
+		{
+			statement: "CREATE INDEX CONCURRENTLY idx_t_name ON t (name);",
+			want:      []lockImpactResult{{statementType: "CREATE_INDEX", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_SHARE_UPDATE_EXCLUSIVE, indexBuild: true}},
+		},
+		{
+			statement: "DROP INDEX idx_t_age; TRUNCATE t;",
+			want: []lockImpactResult{
+				{statementType: "DROP_INDEX", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE},
+				{statementType: "TRUNCATE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE},
+			},
+		},
+		{
+			statement: "VACUUM FULL t;",
+			want:      []lockImpactResult{{statementType: "VACUUM", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE, tableRewrite: true}},
+		},
+		{
+			statement: "SET search_path TO app, public; TRUNCATE u, t; DROP INDEX idx_u_id; RESET search_path; TRUNCATE u;",
+			want: []lockImpactResult{
+				{statementType: "TRUNCATE", schema: "app", table: "u", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE},
+				{statementType: "TRUNCATE", schema: "public", table: "t", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE},
+				{statementType: "DROP_INDEX", schema: "app", table: "u", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE},
+				{statementType: "TRUNCATE", schema: "public", table: "u", lockLevel: storepb.PlanCheckRunResult_Result_LockImpactReport_ACCESS_EXCLUSIVE},
+			},
+		},
+		{
+			statement: "CREATE TABLE p (id int); INSERT INTO t (age) VALUES (1);",
+			want:      nil,
+		},
+	}
+
+	analyzer := &lockImpactAnalyzer{
+		dbSchema:          dbSchema,
+		defaultSearchPath: []string{"public"},
+		serverVersionNum:  150002,
+		getColumnType: func(schema string, table string, column string) (string, error) {
+			return columnTypes[fmt.Sprintf("%s.%s.%s", schema, table, column)], nil
+		},
+	}
+	for _, test := range tests {
+		impacts, err := analyzer.analyze(test.statement)
+		require.NoError(t, err, test.statement)
+		var got []lockImpactResult
+		for _, impact := range impacts {
+			got = append(got, lockImpactResult{
+				statementType:  impact.statementType,
+				schema:         impact.schema,
+				table:          impact.table,
+				lockLevel:      impact.lockLevel,
+				tableRewrite:   impact.tableRewrite,
+				validationScan: impact.validationScan,
+				indexBuild:     impact.indexBuild,
+			})
+		}
+		require.Equal(t, test.want, got, test.statement)
+	}
+}
+
+func TestAnalyzePostgreSQLLockImpactFailure(t *testing.T) {
+	a := require.New(t)
+	dbSchema := &store.DBSchema{
+		Metadata: &storepb.DatabaseSchemaMetadata{
+			Schemas: []*storepb.SchemaMetadata{
+				{
+					Name: "public",
+					Tables: []*storepb.TableMetadata{
+						{
+							Name: "t",
+							Columns: []*storepb.ColumnMetadata{
+								{Name: "code", Type: "character varying"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	analyzer := &lockImpactAnalyzer{
+		dbSchema:          dbSchema,
+		defaultSearchPath: []string{"public"},
+		serverVersionNum:  150002,
+		getColumnType: func(string, string, string) (string, error) {
+			return "", errors.New("connection reset")
+		},
+	}
+
+	for _, statement := range []string{
+		// The statement cannot be parsed.
+		"ALTER TABLE t ADD COLUMN",
+		// The column type cannot be read from the database.
+		"ALTER TABLE t ALTER COLUMN code TYPE varchar(30)",
+	} {
+		results := analyzeLockImpact(analyzer, statement, dbSchema)
+		a.Len(results, 1, statement)
+		a.Equal(storepb.PlanCheckRunResult_Result_WARNING, results[0].Status, statement)
+		a.Equal(common.TaskLockAnalysisFailed.Int64(), results[0].Code, statement)
+	}
+}
@@ -144,6 +144,8 @@ func (e *StatementReportExecutor) runForDatabaseTarget(ctx context.Context, conf
 			schema = database.DatabaseName
 		}
 		return reportForOracle(database.DatabaseName, schema, renderedStatement)
+	case db.Redis:
+		return reportForRedis(renderedStatement)
 	default:
 		return []*storepb.PlanCheckRunResult_Result{
 			{
@@ -383,6 +385,58 @@ func reportForOracle(databaseName string, schemaName string, statement string) (
 	}, nil
 }
 
+// reportForRedis reports the names of the commands changing the data or the server as the statement types,
+// so that the risk rules can require the approval for the dangerous commands such as FLUSHALL.
+func reportForRedis(statement string) ([]*storepb.PlanCheckRunResult_Result, error) {
+	commands, err := parser.ParseRedisCommands(statement)
+	if err != nil {
+		// nolint:nilerr
+		return []*storepb.PlanCheckRunResult_Result{
+			{
+				Status:  storepb.PlanCheckRunResult_Result_ERROR,
+				Title:   "Syntax error",
+				Content: err.Error(),
+				Code:    0,
+				Report: &storepb.PlanCheckRunResult_Result_SqlSummaryReport_{
+					SqlSummaryReport: &storepb.PlanCheckRunResult_Result_SqlSummaryReport{
+						Code: advisor.StatementSyntaxError.Int64(),
+					},
+				},
+			},
+		}, nil
+	}
+
+	var statementTypes, dangerousCommands []string
+	seen := make(map[string]bool)
+	for _, command := range commands {
+		if command.Category == parser.RedisCommandRead || seen[command.Name] {
+			continue
+		}
+		seen[command.Name] = true
+		statementTypes = append(statementTypes, command.Name)
+		if command.Category == parser.RedisCommandDangerous {
+			dangerousCommands = append(dangerousCommands, command.Name)
+		}
+	}
+
+	result := &storepb.PlanCheckRunResult_Result{
+		Status: storepb.PlanCheckRunResult_Result_SUCCESS,
+		Code:   common.Ok.Int64(),
+		Title:  "OK",
+		Report: &storepb.PlanCheckRunResult_Result_SqlSummaryReport_{
+			SqlSummaryReport: &storepb.PlanCheckRunResult_Result_SqlSummaryReport{
+				StatementTypes: statementTypes,
+			},
+		},
+	}
+	if len(dangerousCommands) > 0 {
+		result.Status = storepb.PlanCheckRunResult_Result_WARNING
+		result.Title = "Dangerous commands"
+		result.Content = fmt.Sprintf("The dangerous commands %s can destroy the data or block the server", strings.Join(dangerousCommands, ", "))
+	}
+	return []*storepb.PlanCheckRunResult_Result{result}, nil
+}
+
 func getChangedResourcesForOracle(databaseName string, schemaName string, statement string) ([]parser.SchemaResource, error) {
 	return parser.ExtractChangedResources(parser.Oracle, databaseName, schemaName, statement)
 }
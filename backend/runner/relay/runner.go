@@ -270,6 +270,13 @@ func (r *Runner) approveExternalApprovalNode(ctx context.Context, issueUID int)
 	}
 	payload.Approval.Approvers = append(payload.Approval.Approvers, newApprovers...)
 
+	// Pin the sheet revisions before the approval is saved, after which the tasks can run.
+	if approved && issue.PipelineUID != nil {
+		if err := r.store.PinTaskSheetRevisions(ctx, *issue.PipelineUID); err != nil {
+			return errors.Wrapf(err, "failed to pin sheet revisions")
+		}
+	}
+
 	issue, err = r.store.UpdateIssueV2(ctx, issue.UID, &store.UpdateIssueMessage{
 		PayloadUpsert: &storepb.IssuePayload{
 			Approval: payload.Approval,
@@ -21,9 +21,17 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	"github.com/bytebase/bytebase/backend/store"
 )
 
+const (
+	// rollbackBackupRetention is how long the PostgreSQL backup tables are kept after generating the rollback SQL.
+	// The backups are kept for a while for manually recovering the data if the generation fails.
+	rollbackBackupRetention     = 7 * 24 * time.Hour
+	rollbackBackupPurgeInterval = 1 * time.Hour
+)
+
 // NewRunner creates a new rollback runner.
 func NewRunner(profile *config.Profile, store *store.Store, dbFactory *dbfactory.DBFactory, stateCfg *state.State) *Runner {
 	return &Runner{
@@ -48,8 +56,12 @@ func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer ticker.Stop()
 	defer wg.Done()
 	r.retryGenerateRollbackSQL(ctx)
+	purgeTicker := time.NewTicker(rollbackBackupPurgeInterval)
+	defer purgeTicker.Stop()
 	for {
 		select {
+		case <-purgeTicker.C:
+			r.purgeExpiredRollbackBackups(ctx)
 		case <-ticker.C:
 			r.stateCfg.RollbackGenerate.Range(func(key, value any) bool {
 				task := value.(*store.TaskMessage)
@@ -74,7 +86,7 @@ func (r *Runner) retryGenerateRollbackSQL(ctx context.Context) {
 	taskList, err := r.store.ListTasks(ctx, &api.TaskFind{
 		LatestTaskRunStatusList: &[]api.TaskRunStatus{api.TaskRunDone},
 		TypeList:                &[]api.TaskType{api.TaskDatabaseDataUpdate},
-		Payload:                 "(task.payload->>'rollbackEnabled')::BOOLEAN IS TRUE AND (task.payload->>'threadId'!='' OR task.payload->>'transactionId' != '') AND task.payload->>'rollbackSqlStatus'='PENDING'",
+		Payload:                 "(task.payload->>'rollbackEnabled')::BOOLEAN IS TRUE AND (task.payload->>'threadId'!='' OR task.payload->>'transactionId' != '' OR task.payload->>'migrationId' != '') AND task.payload->>'rollbackSqlStatus'='PENDING'",
 	})
 	if err != nil {
 		slog.Error("Failed to get running DML tasks", log.BBError(err))
@@ -124,7 +136,160 @@ func (r *Runner) generateRollbackSQL(ctx context.Context, task *store.TaskMessag
 		r.generateMySQLRollbackSQL(ctx, task, payload, instance, project)
 	case db.Oracle:
 		r.generateOracleRollbackSQL(ctx, task, payload, instance, project)
+	case db.Postgres:
+		r.generatePostgresRollbackSQL(ctx, task, payload, instance, database, project)
+	}
+}
+
+func (r *Runner) generatePostgresRollbackSQL(ctx context.Context, task *store.TaskMessage, payload *api.TaskDatabaseDataUpdatePayload, instance *store.InstanceMessage, database *store.DatabaseMessage, project *store.ProjectMessage) {
+	var rollbackSQLStatus api.RollbackSQLStatus
+	var rollbackStatement, rollbackError string
+
+	const rollbackSizeLimit = 8 * 1024 * 1024
+	rollbackSQL, err := r.generatePostgresRollbackSQLImpl(ctx, payload, rollbackSizeLimit, instance, database)
+	if err != nil {
+		slog.Error("Failed to generate rollback SQL statement", log.BBError(err))
+		rollbackSQLStatus = api.RollbackSQLStatusFailed
+		rollbackError = err.Error()
+	} else {
+		rollbackSQLStatus = api.RollbackSQLStatusDone
+		rollbackStatement = rollbackSQL
+	}
+
+	sheet, err := r.store.CreateSheet(ctx, &store.SheetMessage{
+		CreatorID:  api.SystemBotID,
+		ProjectUID: project.UID,
+		Name:       fmt.Sprintf("Sheet for rolling back task %d", task.ID),
+		Statement:  rollbackStatement,
+		Visibility: store.ProjectSheet,
+		Source:     store.SheetFromBytebaseArtifact,
+		Type:       store.SheetForSQL,
+	})
+	if err != nil {
+		slog.Error("failed to create database creation sheet", log.BBError(err))
+		return
+	}
+	patch := &api.TaskPatch{
+		ID:                task.ID,
+		UpdaterID:         api.SystemBotID,
+		RollbackSQLStatus: &rollbackSQLStatus,
+		RollbackSheetID:   &sheet.UID,
+		RollbackError:     &rollbackError,
+	}
+	if _, err := r.store.UpdateTaskV2(ctx, patch); err != nil {
+		slog.Error("Failed to patch task with the PostgreSQL rollback sheet", slog.Int("taskID", task.ID))
+		return
+	}
+	slog.Debug("Rollback SQL generation success", slog.Int("taskID", task.ID))
+}
+
+func (r *Runner) generatePostgresRollbackSQLImpl(ctx context.Context, payload *api.TaskDatabaseDataUpdatePayload, rollbackSizeLimit int, instance *store.InstanceMessage, database *store.DatabaseMessage) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if payload.MigrationID == "" {
+		return "", errors.New("missing backups of the changed rows, rollback must be enabled before running the task")
+	}
+	if len(payload.RollbackBackupList) == 0 {
+		// There is no UPDATE or DELETE statement in the task.
+		return "", nil
+	}
+	backupList := convertToPGRollbackBackupList(payload.RollbackBackupList)
+
+	driver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to get admin database driver")
+	}
+	defer driver.Close(ctx)
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		return "", errors.Errorf("failed to cast driver to pg.Driver")
+	}
+	rollbackSQL, err := pgDriver.GenerateRollbackSQL(ctx, rollbackSizeLimit, backupList)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to generate rollback SQL statement")
+	}
+	return rollbackSQL, nil
+}
+
+func convertToPGRollbackBackupList(list []*api.RollbackBackup) []*pg.RollbackBackup {
+	var backupList []*pg.RollbackBackup
+	for _, backup := range list {
+		backupList = append(backupList, &pg.RollbackBackup{
+			Type:           pg.RollbackBackupType(backup.Type),
+			Schema:         backup.Schema,
+			Table:          backup.Table,
+			BackupTable:    backup.BackupTable,
+			UpdatedColumns: backup.UpdatedColumns,
+		})
+	}
+	return backupList
+}
+
+// purgeExpiredRollbackBackups drops the PostgreSQL backup tables of the tasks whose rollback SQL generation
+// finished longer than rollbackBackupRetention ago, and removes the backups from the task payload.
+func (r *Runner) purgeExpiredRollbackBackups(ctx context.Context) {
+	taskList, err := r.store.ListTasks(ctx, &api.TaskFind{
+		TypeList: &[]api.TaskType{api.TaskDatabaseDataUpdate},
+		Payload:  "jsonb_array_length(COALESCE(task.payload->'rollbackBackupList', '[]'::jsonb)) > 0 AND task.payload->>'rollbackSqlStatus' IN ('DONE', 'FAILED')",
+	})
+	if err != nil {
+		slog.Error("Failed to list tasks with rollback backups", log.BBError(err))
+		return
+	}
+	for _, task := range taskList {
+		if time.Since(time.Unix(task.UpdatedTs, 0)) < rollbackBackupRetention {
+			continue
+		}
+		if err := r.purgeRollbackBackups(ctx, task); err != nil {
+			slog.Error("Failed to purge rollback backups", slog.Int("taskID", task.ID), log.BBError(err))
+		}
+	}
+}
+
+func (r *Runner) purgeRollbackBackups(ctx context.Context, task *store.TaskMessage) error {
+	payload := &api.TaskDatabaseDataUpdatePayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return errors.Wrap(err, "invalid database data update payload")
+	}
+	instance, err := r.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
+	if err != nil {
+		return errors.Wrap(err, "failed to find instance")
+	}
+	database, err := r.store.GetDatabaseV2(ctx, &store.FindDatabaseMessage{UID: task.DatabaseID})
+	if err != nil {
+		return errors.Wrap(err, "failed to find database")
+	}
+	// The backup tables are gone with the deleted instance or database.
+	if instance != nil && database != nil && database.SyncState == api.OK {
+		driver, err := r.dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
+		if err != nil {
+			return errors.Wrap(err, "failed to get admin database driver")
+		}
+		defer driver.Close(ctx)
+		pgDriver, ok := driver.(*pg.Driver)
+		if !ok {
+			return errors.Errorf("failed to cast driver to pg.Driver")
+		}
+		if err := pgDriver.DropRollbackBackupTables(ctx, convertToPGRollbackBackupList(payload.RollbackBackupList)); err != nil {
+			return err
+		}
+	}
+
+	payload.RollbackBackupList = nil
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal task payload")
+	}
+	payloadString := string(payloadBytes)
+	if _, err := r.store.UpdateTaskV2(ctx, &api.TaskPatch{
+		ID:        task.ID,
+		UpdaterID: api.SystemBotID,
+		Payload:   &payloadString,
+	}); err != nil {
+		return errors.Wrap(err, "failed to patch task payload")
 	}
+	return nil
 }
 
 func (r *Runner) generateOracleRollbackSQL(ctx context.Context, task *store.TaskMessage, payload *api.TaskDatabaseDataUpdatePayload, instance *store.InstanceMessage, project *store.ProjectMessage) {
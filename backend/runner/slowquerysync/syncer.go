@@ -18,6 +18,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/pg"
+	"github.com/bytebase/bytebase/backend/plugin/db/util"
 	"github.com/bytebase/bytebase/backend/store"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
@@ -84,6 +85,12 @@ func (s *Syncer) syncSlowQuery(ctx context.Context, message *state.InstanceSlowQ
 	if message != nil {
 		find.ResourceID = &message.InstanceID
 		project = message.ProjectID
+	} else {
+		// The snapshots of the instances not synced in the retention cycle are outdated, e.g. the instance is deleted or the slow query policy is disabled.
+		earliestDate := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -retentionCycle)
+		if err := s.store.DeleteOutdatedSlowQuerySnapshot(ctx, earliestDate); err != nil {
+			slog.Error("Failed to delete outdated slow query snapshots", log.BBError(err))
+		}
 	}
 	instances, err := s.store.ListInstancesV2(ctx, find)
 	if err != nil {
@@ -119,8 +126,10 @@ func (s *Syncer) syncInstanceSlowQuery(ctx context.Context, instance *store.Inst
 	}
 
 	switch instance.Engine {
-	case db.MySQL:
-		return s.syncMySQLSlowQuery(ctx, instance)
+	case db.MySQL, db.MongoDB, db.ClickHouse:
+		return s.syncSlowQueryByDate(ctx, instance)
+	case db.MSSQL, db.Oracle:
+		return s.syncCumulativeSlowQuery(ctx, instance)
 	case db.Postgres:
 		return s.syncPostgreSQLSlowQuery(ctx, instance, project)
 	default:
@@ -288,7 +297,8 @@ func getLatestLogTime(logMap map[string]*storepb.SlowQueryStatistics) time.Time
 	return time.Time{}
 }
 
-func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.InstanceMessage) error {
+// syncSlowQueryByDate syncs the slow query logs day by day since the latest synced date.
+func (s *Syncer) syncSlowQueryByDate(ctx context.Context, instance *store.InstanceMessage) error {
 	today := time.Now().UTC().Truncate(24 * time.Hour)
 
 	earliestDate := today.AddDate(0, 0, -retentionCycle)
@@ -338,3 +348,72 @@ func (s *Syncer) syncMySQLSlowQuery(ctx context.Context, instance *store.Instanc
 
 	return nil
 }
+
+// syncCumulativeSlowQuery syncs the slow query statistics for the engines which only expose the cumulative statistics since the statement is cached,
+// e.g. sys.dm_exec_query_stats of SQL Server and V$SQL of Oracle.
+// The statistics of the day are the deltas from the baseline of the day, which only keeps the statements executed on the day,
+// so the snapshot keeps one copy of the cumulative statistics instead of two.
+func (s *Syncer) syncCumulativeSlowQuery(ctx context.Context, instance *store.InstanceMessage) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	earliestDate := today.AddDate(0, 0, -retentionCycle)
+
+	if err := s.store.DeleteOutdatedSlowLog(ctx, instance.UID, earliestDate); err != nil {
+		return err
+	}
+
+	snapshot, err := s.store.GetSlowQuerySnapshot(ctx, instance.UID)
+	if err != nil {
+		return err
+	}
+
+	driver, err := s.dbFactory.GetAdminDatabaseDriver(ctx, instance, nil /* database */)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	if err := driver.CheckSlowQueryLogEnabled(ctx); err != nil {
+		return err
+	}
+
+	// The statements not executed since the last sync have the same statistics as the snapshot.
+	var since time.Time
+	if snapshot != nil {
+		since = snapshot.UpdatedTime
+	}
+	current, err := driver.SyncSlowQuery(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	if snapshot == nil {
+		// The statistics before the first snapshot cannot be attributed to any day, so the first snapshot is only the baseline.
+		return s.store.UpsertSlowQuerySnapshot(ctx, &store.SlowQuerySnapshotMessage{
+			InstanceUID: instance.UID,
+			LogDate:     today,
+			Baseline:    map[string]*storepb.SlowQueryStatistics{},
+			Latest:      current,
+		})
+	}
+	if !snapshot.LogDate.Equal(today) {
+		snapshot.LogDate = today
+		snapshot.Baseline = nil
+	}
+	snapshot.Latest, snapshot.Baseline = util.MergeSlowQuerySnapshot(snapshot.Latest, snapshot.Baseline, current, earliestDate)
+
+	logs := util.DiffSlowQueryStatistics(snapshot.Latest, snapshot.Baseline, db.SlowQueryMinimumQueryTime)
+	for dbName, slowLog := range logs {
+		if err := s.store.UpsertSlowLog(ctx, &store.UpsertSlowLogMessage{
+			EnvironmentID: &instance.EnvironmentID,
+			InstanceID:    &instance.ResourceID,
+			DatabaseName:  dbName,
+			InstanceUID:   instance.UID,
+			LogDate:       today,
+			SlowLog:       slowLog,
+			UpdaterID:     api.SystemBotID,
+		}); err != nil {
+			return err
+		}
+	}
+	return s.store.UpsertSlowQuerySnapshot(ctx, snapshot)
+}
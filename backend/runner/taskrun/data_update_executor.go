@@ -45,9 +45,9 @@ func (exec *DataUpdateExecutor) RunOnce(ctx context.Context, driverCtx context.C
 		return true, nil, errors.Wrap(err, "invalid database data update payload")
 	}
 
-	statement, err := exec.store.GetSheetStatementByID(ctx, payload.SheetID)
+	sheetRevision, err := getSheetRevision(ctx, exec.store, payload.SheetID, payload.SheetRevision)
 	if err != nil {
 		return true, nil, err
 	}
-	return runMigration(ctx, driverCtx, exec.store, exec.dbFactory, exec.activityManager, exec.license, exec.stateCfg, exec.profile, task, db.Data, statement, payload.SchemaVersion, &payload.SheetID)
+	return runMigration(ctx, driverCtx, exec.store, exec.dbFactory, exec.activityManager, exec.license, exec.stateCfg, exec.profile, task, db.Data, sheetRevision.Statement, payload.SchemaVersion, sheetRevision)
 }
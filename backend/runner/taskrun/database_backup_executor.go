@@ -4,19 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	"golang.org/x/sys/unix"
 
-	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/storagefactory"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
-	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
 	"github.com/bytebase/bytebase/backend/store"
 )
@@ -27,21 +29,21 @@ const (
 )
 
 // NewDatabaseBackupExecutor creates a new database backup task executor.
-func NewDatabaseBackupExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile) Executor {
+func NewDatabaseBackupExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, storageFactory *storagefactory.StorageFactory, profile config.Profile) Executor {
 	return &DatabaseBackupExecutor{
-		store:     store,
-		dbFactory: dbFactory,
-		s3Client:  s3Client,
-		profile:   profile,
+		store:          store,
+		dbFactory:      dbFactory,
+		storageFactory: storageFactory,
+		profile:        profile,
 	}
 }
 
 // DatabaseBackupExecutor is the task executor for database backup.
 type DatabaseBackupExecutor struct {
-	store     *store.Store
-	dbFactory *dbfactory.DBFactory
-	s3Client  *bbs3.Client
-	profile   config.Profile
+	store          *store.Store
+	dbFactory      *dbfactory.DBFactory
+	storageFactory *storagefactory.StorageFactory
+	profile        config.Profile
 }
 
 // RunOnce will run database backup once.
@@ -81,7 +83,13 @@ func (exec *DatabaseBackupExecutor) RunOnce(ctx context.Context, _ context.Conte
 		}
 	}
 	slog.Debug("Start database backup.", slog.String("instance", instance.Title), slog.String("database", database.DatabaseName), slog.String("backup", backup.Name))
-	backupPayload, backupErr := exec.backupDatabase(ctx, exec.dbFactory, exec.s3Client, exec.profile, instance, database, backup)
+	backupPayload, backupErr := exec.backupDatabase(ctx, exec.dbFactory, exec.storageFactory, exec.profile, instance, database, backup)
+	if mergedPayload, err := mergeBackupPayload(backupPayload, backup.Payload.Storage); err != nil {
+		// Keep the backup error so that the failure of the dump or upload is not hidden.
+		backupErr = multierr.Append(backupErr, err)
+	} else {
+		backupPayload = mergedPayload
+	}
 	backupStatus := string(api.BackupStatusDone)
 	comment := ""
 	if backupErr != nil {
@@ -112,6 +120,25 @@ func (exec *DatabaseBackupExecutor) RunOnce(ctx context.Context, _ context.Conte
 	}, nil
 }
 
+// mergeBackupPayload adds the storage recorded when scheduling the backup to the payload returned by the dump.
+func mergeBackupPayload(dumpPayload string, backupStorage *api.BackupStorage) (string, error) {
+	if backupStorage == nil {
+		return dumpPayload, nil
+	}
+	var payload api.BackupPayload
+	if dumpPayload != "" {
+		if err := json.Unmarshal([]byte(dumpPayload), &payload); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal backup payload %q", dumpPayload)
+		}
+	}
+	payload.Storage = backupStorage
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal backup payload")
+	}
+	return string(bytes), nil
+}
+
 func removeLocalBackupFile(dataDir string, backup *store.BackupMessage) error {
 	if backup.StorageBackend != api.BackupStorageBackendLocal {
 		return nil
@@ -151,43 +178,61 @@ func dumpBackupFile(ctx context.Context, driver db.Driver, backupFilePath string
 	return payload, nil
 }
 
+// dumpBackupToStorage streams the dump of the database to the storage.
+func dumpBackupToStorage(ctx context.Context, driver db.Driver, backupStorage storage.Storage, backupPath string) (string, error) {
+	pr, pw := io.Pipe()
+	var payload string
+	dumpErr := make(chan error, 1)
+	go func() {
+		var err error
+		payload, err = driver.Dump(ctx, pw, false /* schemaOnly */)
+		// Closing with a nil error ends the upload with EOF.
+		pw.CloseWithError(err)
+		dumpErr <- err
+	}()
+	uploadErr := backupStorage.Upload(ctx, backupPath, pr)
+	// Unblock the dump if the upload returns before reading all of it.
+	pr.CloseWithError(errors.New("backup upload aborted"))
+	var errs error
+	if err := <-dumpErr; err != nil {
+		errs = multierr.Append(errs, errors.Wrap(err, "failed to dump database"))
+	}
+	if uploadErr != nil {
+		errs = multierr.Append(errs, errors.Wrapf(uploadErr, "failed to upload backup file %q", backupPath))
+	}
+	if errs != nil {
+		return "", errs
+	}
+	return payload, nil
+}
+
 // backupDatabase will take a backup of a database.
-func (*DatabaseBackupExecutor) backupDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile, instance *store.InstanceMessage, database *store.DatabaseMessage, backup *store.BackupMessage) (string, error) {
+// The backup in the local data directory is dumped to the file, otherwise it is streamed to the storage.
+func (*DatabaseBackupExecutor) backupDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, storageFactory *storagefactory.StorageFactory, profile config.Profile, instance *store.InstanceMessage, database *store.DatabaseMessage, backup *store.BackupMessage) (string, error) {
 	driver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
 	if err != nil {
 		return "", err
 	}
 	defer driver.Close(ctx)
 
-	backupFilePathLocal := filepath.Join(profile.DataDir, backup.Path)
-	payload, err := dumpBackupFile(ctx, driver, backupFilePathLocal)
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to dump backup file %q", backupFilePathLocal)
-	}
-
-	switch backup.StorageBackend {
-	case api.BackupStorageBackendLocal:
-		return payload, nil
-	case api.BackupStorageBackendS3:
-		slog.Debug("Uploading backup to s3 bucket.", slog.String("bucket", s3Client.GetBucket()), slog.String("path", backupFilePathLocal))
-		bucketFileToUpload, err := os.Open(backupFilePathLocal)
+	if backup.StorageBackend == api.BackupStorageBackendLocal {
+		backupFilePathLocal := filepath.Join(profile.DataDir, backup.Path)
+		payload, err := dumpBackupFile(ctx, driver, backupFilePathLocal)
 		if err != nil {
-			return "", errors.Wrapf(err, "failed to open backup file %q for uploading to s3 bucket", backupFilePathLocal)
-		}
-		defer bucketFileToUpload.Close()
-
-		if _, err := s3Client.UploadObject(ctx, backup.Path, bucketFileToUpload); err != nil {
-			return "", errors.Wrapf(err, "failed to upload backup to AWS S3")
-		}
-		slog.Debug("Successfully uploaded backup to s3 bucket.")
-
-		if err := os.Remove(backupFilePathLocal); err != nil {
-			slog.Warn("Failed to remove the local backup file after uploading to s3 bucket.", slog.String("path", backupFilePathLocal), log.BBError(err))
-		} else {
-			slog.Debug("Successfully removed the local backup file after uploading to s3 bucket.", slog.String("path", backupFilePathLocal))
+			return "", errors.Wrapf(err, "failed to dump backup file %q", backupFilePathLocal)
 		}
 		return payload, nil
-	default:
-		return "", errors.Errorf("backup to %s not implemented yet", backup.StorageBackend)
 	}
+
+	backupStorage, err := storageFactory.GetBackupStorage(ctx, backup)
+	if err != nil {
+		return "", err
+	}
+	slog.Debug("Uploading backup to the storage.", slog.String("storageBackend", string(backup.StorageBackend)), slog.String("path", backup.Path))
+	payload, err := dumpBackupToStorage(ctx, driver, backupStorage, backup.Path)
+	if err != nil {
+		return "", err
+	}
+	slog.Debug("Successfully uploaded backup to the storage.", slog.String("storageBackend", string(backup.StorageBackend)), slog.String("path", backup.Path))
+	return payload, nil
 }
@@ -24,6 +24,7 @@ import (
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
+	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	parser "github.com/bytebase/bytebase/backend/plugin/parser/sql"
 	"github.com/bytebase/bytebase/backend/plugin/parser/sql/transform"
 	vcsPlugin "github.com/bytebase/bytebase/backend/plugin/vcs"
@@ -223,6 +224,41 @@ func executeMigration(ctx context.Context, driverCtx context.Context, stores *st
 		// getSetOracleTransactionIdFunc will update the task payload to set the Oracle transaction id, we need to re-retrieve the task to store to the RollbackGenerate.
 		opts.EndTransactionFunc = getSetOracleTransactionIDFunc(ctx, task, stores)
 	}
+	var rollbackBackupList []*api.RollbackBackup
+	// rollbackBackupErr is the error backing up the rows of a statement, the rollback SQL cannot be generated then but the migration still runs.
+	var rollbackBackupErr error
+	if task.Type == api.TaskDatabaseDataUpdate && instance.Engine == db.Postgres {
+		payload := &api.TaskDatabaseDataUpdatePayload{}
+		if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+			return "", "", errors.Wrap(err, "invalid database data update payload")
+		}
+		if payload.RollbackEnabled {
+			// Back up the rows affected by each UPDATE and DELETE statement in the migration transaction for generating the rollback SQL.
+			opts.BeforeStatementFunc = func(stmt string) (string, error) {
+				// The rollback SQL would be incomplete without the backup of any statement, so stop backing up the rows.
+				if rollbackBackupErr != nil {
+					return "", nil
+				}
+				backupTable := fmt.Sprintf("task%d_%d", task.ID, len(rollbackBackupList))
+				backup, backupStatement, err := pg.GetRollbackBackupStatement(stmt, backupTable)
+				if err != nil {
+					rollbackBackupErr = err
+					slog.Warn("failed to back up the rows for PostgreSQL rollback SQL", slog.Int("taskID", task.ID), log.BBError(err))
+					return "", nil
+				}
+				if backup != nil {
+					rollbackBackupList = append(rollbackBackupList, &api.RollbackBackup{
+						Type:           string(backup.Type),
+						Schema:         backup.Schema,
+						Table:          backup.Table,
+						BackupTable:    backup.BackupTable,
+						UpdatedColumns: backup.UpdatedColumns,
+					})
+				}
+				return backupStatement, nil
+			}
+		}
+	}
 
 	migrationID, schema, err := utils.ExecuteMigrationDefault(ctx, driverCtx, stores, driver, mi, statement, sheetID, opts)
 	if err != nil {
@@ -266,9 +302,52 @@ func executeMigration(ctx context.Context, driverCtx context.Context, stores *st
 		}
 	}
 
+	if task.Type == api.TaskDatabaseDataUpdate && instance.Engine == db.Postgres && opts.BeforeStatementFunc != nil {
+		updatedTask, err := setRollbackBackupList(ctx, task, stores, migrationID, rollbackBackupList, rollbackBackupErr)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to update the task payload for PostgreSQL rollback SQL")
+		}
+		if rollbackBackupErr == nil {
+			// The runner will periodically scan the map to generate rollback SQL asynchronously.
+			stateCfg.RollbackGenerate.Store(task.ID, updatedTask)
+		}
+	}
+
 	return migrationID, schema, nil
 }
 
+// setRollbackBackupList records the backups of the changed rows in the task payload.
+// The rollback SQL generation fails with the backup error if any statement cannot be backed up,
+// the backup tables created before are kept in the payload so that they are purged.
+func setRollbackBackupList(ctx context.Context, task *store.TaskMessage, store *store.Store, migrationID string, rollbackBackupList []*api.RollbackBackup, backupErr error) (*store.TaskMessage, error) {
+	payload := &api.TaskDatabaseDataUpdatePayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return nil, errors.Wrap(err, "invalid database data update payload")
+	}
+	payload.MigrationID = migrationID
+	payload.RollbackBackupList = rollbackBackupList
+	if backupErr != nil {
+		payload.RollbackSQLStatus = api.RollbackSQLStatusFailed
+		payload.RollbackError = backupErr.Error()
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal task payload")
+	}
+	payloadString := string(payloadBytes)
+	patch := &api.TaskPatch{
+		ID:        task.ID,
+		UpdaterID: api.SystemBotID,
+		Payload:   &payloadString,
+	}
+	updatedTask, err := store.UpdateTaskV2(ctx, patch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to patch task %d with the PostgreSQL rollback backups", task.ID)
+	}
+	return updatedTask, nil
+}
+
 func getSetOracleTransactionIDFunc(ctx context.Context, task *store.TaskMessage, store *store.Store) func(tx *sql.Tx) error {
 	return func(tx *sql.Tx) error {
 		payload := &api.TaskDatabaseDataUpdatePayload{}
@@ -606,11 +685,16 @@ func isWriteBack(ctx context.Context, stores *store.Store, license enterpriseAPI
 	return branch, nil
 }
 
-func runMigration(ctx context.Context, driverCtx context.Context, store *store.Store, dbFactory *dbfactory.DBFactory, activityManager *activity.Manager, license enterpriseAPI.LicenseService, stateCfg *state.State, profile config.Profile, task *store.TaskMessage, migrationType db.MigrationType, statement, schemaVersion string, sheetID *int) (terminated bool, result *api.TaskRunResultPayload, err error) {
+func runMigration(ctx context.Context, driverCtx context.Context, store *store.Store, dbFactory *dbfactory.DBFactory, activityManager *activity.Manager, license enterpriseAPI.LicenseService, stateCfg *state.State, profile config.Profile, task *store.TaskMessage, migrationType db.MigrationType, statement, schemaVersion string, sheetRevision *store.SheetRevisionMessage) (terminated bool, result *api.TaskRunResultPayload, err error) {
 	mi, err := getMigrationInfo(ctx, store, profile, task, migrationType, statement, schemaVersion)
 	if err != nil {
 		return true, nil, err
 	}
+	var sheetID *int
+	if sheetRevision != nil {
+		sheetID = &sheetRevision.SheetUID
+		mi.Payload.SheetRevision = int32(sheetRevision.Revision)
+	}
 
 	migrationID, schema, err := executeMigration(ctx, driverCtx, store, dbFactory, stateCfg, task, statement, sheetID, mi)
 	if err != nil {
@@ -619,6 +703,34 @@ func runMigration(ctx context.Context, driverCtx context.Context, store *store.S
 	return postMigration(ctx, store, activityManager, license, task, mi, migrationID, schema)
 }
 
+// getSheetRevision gets the revision of the task sheet pinned when the issue is approved.
+// The task executes the statement of the revision, and the revision is recorded in the change history.
+// The latest revision is used if the revision isn't pinned, e.g. the issue was approved before the revisions are pinned.
+func getSheetRevision(ctx context.Context, stores *store.Store, sheetID, revision int) (*store.SheetRevisionMessage, error) {
+	if revision == 0 {
+		sheetRevision, err := stores.GetLatestSheetRevision(ctx, sheetID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the latest revision of sheet %d", sheetID)
+		}
+		if sheetRevision == nil {
+			return nil, errors.Errorf("sheet %d has no revision", sheetID)
+		}
+		return sheetRevision, nil
+	}
+	sheetRevision, err := stores.GetSheetRevision(ctx, &store.FindSheetRevisionMessage{
+		SheetUID: sheetID,
+		Revision: &revision,
+		LoadFull: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get revision %d of sheet %d", revision, sheetID)
+	}
+	if sheetRevision == nil {
+		return nil, errors.Errorf("revision %d of sheet %d not found", revision, sheetID)
+	}
+	return sheetRevision, nil
+}
+
 // Writes back the latest schema to the repository after migration.
 // Returns the commit id on success.
 func writeBackLatestSchema(
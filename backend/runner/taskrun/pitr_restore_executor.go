@@ -18,12 +18,13 @@ import (
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
 	"github.com/bytebase/bytebase/backend/component/state"
+	"github.com/bytebase/bytebase/backend/component/storagefactory"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/plugin/db"
 	"github.com/bytebase/bytebase/backend/plugin/db/mysql"
 	"github.com/bytebase/bytebase/backend/plugin/db/pg"
 	"github.com/bytebase/bytebase/backend/plugin/db/util"
-	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	"github.com/bytebase/bytebase/backend/plugin/storage"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
 	"github.com/bytebase/bytebase/backend/runner/schemasync"
 	"github.com/bytebase/bytebase/backend/store"
@@ -31,25 +32,25 @@ import (
 )
 
 // NewPITRRestoreExecutor creates a PITR restore task executor.
-func NewPITRRestoreExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, schemaSyncer *schemasync.Syncer, stateCfg *state.State, profile config.Profile) Executor {
+func NewPITRRestoreExecutor(store *store.Store, dbFactory *dbfactory.DBFactory, storageFactory *storagefactory.StorageFactory, schemaSyncer *schemasync.Syncer, stateCfg *state.State, profile config.Profile) Executor {
 	return &PITRRestoreExecutor{
-		store:        store,
-		dbFactory:    dbFactory,
-		s3Client:     s3Client,
-		schemaSyncer: schemaSyncer,
-		stateCfg:     stateCfg,
-		profile:      profile,
+		store:          store,
+		dbFactory:      dbFactory,
+		storageFactory: storageFactory,
+		schemaSyncer:   schemaSyncer,
+		stateCfg:       stateCfg,
+		profile:        profile,
 	}
 }
 
 // PITRRestoreExecutor is the PITR restore task executor.
 type PITRRestoreExecutor struct {
-	store        *store.Store
-	dbFactory    *dbfactory.DBFactory
-	s3Client     *bbs3.Client
-	schemaSyncer *schemasync.Syncer
-	stateCfg     *state.State
-	profile      config.Profile
+	store          *store.Store
+	dbFactory      *dbfactory.DBFactory
+	storageFactory *storagefactory.StorageFactory
+	schemaSyncer   *schemasync.Syncer
+	stateCfg       *state.State
+	profile        config.Profile
 }
 
 // RunOnce will run the PITR restore task executor once.
@@ -75,15 +76,15 @@ func (exec *PITRRestoreExecutor) RunOnce(ctx context.Context, _ context.Context,
 
 	if payload.BackupID != nil {
 		// Restore Backup
-		resultPayload, err := exec.doBackupRestore(ctx, exec.store, exec.dbFactory, exec.s3Client, exec.schemaSyncer, exec.profile, task, payload)
+		resultPayload, err := exec.doBackupRestore(ctx, exec.store, exec.dbFactory, exec.storageFactory, exec.schemaSyncer, exec.profile, task, payload)
 		return true, resultPayload, err
 	}
 
-	resultPayload, err := exec.doPITRRestore(ctx, exec.dbFactory, exec.s3Client, exec.profile, task, payload)
+	resultPayload, err := exec.doPITRRestore(ctx, exec.dbFactory, exec.storageFactory, exec.profile, task, payload)
 	return true, resultPayload, err
 }
 
-func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, schemaSyncer *schemasync.Syncer, profile config.Profile, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
+func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, storageFactory *storagefactory.StorageFactory, schemaSyncer *schemasync.Syncer, profile config.Profile, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
 	instance, err := stores.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to find database for the backup")
@@ -112,7 +113,7 @@ func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *st
 			if err != nil {
 				return nil, err
 			}
-			return exec.doRestoreInPlacePostgres(ctx, stores, dbFactory, profile, issue, task, payload)
+			return exec.doRestoreInPlacePostgres(ctx, stores, dbFactory, storageFactory, profile, issue, task, payload)
 		}
 		return nil, errors.Errorf("we only support backup restore replace for PostgreSQL now")
 	}
@@ -141,7 +142,7 @@ func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *st
 	)
 
 	// Restore the database to the target database.
-	if err := exec.restoreDatabase(ctx, dbFactory, s3Client, profile, targetInstance, targetDatabase, backup); err != nil {
+	if err := exec.restoreDatabase(ctx, dbFactory, storageFactory, profile, targetInstance, targetDatabase, backup); err != nil {
 		return nil, err
 	}
 	// TODO(zp): This should be done in the same transaction as restoreDatabase to guarantee consistency.
@@ -180,7 +181,7 @@ func (exec *PITRRestoreExecutor) doBackupRestore(ctx context.Context, stores *st
 	}, nil
 }
 
-func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
+func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *dbfactory.DBFactory, storageFactory *storagefactory.StorageFactory, profile config.Profile, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
 	instance, err := exec.store.GetInstanceV2(ctx, &store.FindInstanceMessage{UID: &task.InstanceID})
 	if err != nil {
 		return nil, err
@@ -231,14 +232,18 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 		return nil, errors.Errorf("[internal] cast driver to mysql.Driver failed")
 	}
 
+	binlogStorage, err := storageFactory.GetBinlogStorage(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
 	slog.Debug("Downloading all binlog files")
-	if err := mysqlSourceDriver.FetchAllBinlogFiles(ctx, true /* downloadLatestBinlogFile */, s3Client); err != nil {
+	if err := mysqlSourceDriver.FetchAllBinlogFiles(ctx, true /* downloadLatestBinlogFile */, binlogStorage); err != nil {
 		return nil, err
 	}
 
 	targetTs := *payload.PointInTimeTs
 	slog.Debug("Getting latest backup before or equal to targetTs", slog.Int64("targetTs", targetTs))
-	backup, targetBinlogInfo, err := mysqlSourceDriver.GetLatestBackupBeforeOrEqualTs(ctx, backupList, targetTs, s3Client)
+	backup, targetBinlogInfo, err := mysqlSourceDriver.GetLatestBackupBeforeOrEqualTs(ctx, backupList, targetTs, binlogStorage)
 	if err != nil {
 		targetTsHuman := time.Unix(targetTs, 0).Format(time.RFC822)
 		slog.Error("Failed to get backup before or equal to time",
@@ -252,14 +257,16 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 	slog.Debug("Got latest backup before or equal to targetTs", slog.String("backup", backup.Name))
 
 	backupAbsPathLocal := backuprun.GetBackupAbsFilePath(profile.DataDir, backup.DatabaseUID, backup.Name)
-	if backup.StorageBackend == api.BackupStorageBackendS3 {
-		if err := downloadBackupFileFromCloud(ctx, s3Client, backup.Path, backupAbsPathLocal); err != nil {
-			return nil, errors.Wrapf(err, "failed to download backup %q from S3", backup.Path)
+	if backup.StorageBackend != api.BackupStorageBackendLocal {
+		if err := downloadBackupFileFromCloud(ctx, storageFactory, backup, backupAbsPathLocal); err != nil {
+			return nil, err
 		}
 		defer os.Remove(backupAbsPathLocal)
-		replayBinlogPathList, err := downloadBinlogFilesFromCloud(ctx, s3Client, startBinlogInfo, *targetBinlogInfo, binlogDir)
+	}
+	if binlogStorage != nil {
+		replayBinlogPathList, err := downloadBinlogFilesFromCloud(ctx, binlogStorage, startBinlogInfo, *targetBinlogInfo, binlogDir)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to download binlog files from %s to %s from S3", startBinlogInfo.FileName, targetBinlogInfo.FileName)
+			return nil, errors.Wrapf(err, "failed to download binlog files from %s to %s from the cloud storage", startBinlogInfo.FileName, targetBinlogInfo.FileName)
 		}
 		defer func() {
 			for _, binlogPath := range replayBinlogPathList {
@@ -330,7 +337,7 @@ func (exec *PITRRestoreExecutor) doPITRRestore(ctx context.Context, dbFactory *d
 	}, nil
 }
 
-func downloadBinlogFilesFromCloud(ctx context.Context, client *bbs3.Client, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) ([]string, error) {
+func downloadBinlogFilesFromCloud(ctx context.Context, binlogStorage storage.Storage, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) ([]string, error) {
 	replayBinlogPathList, err := mysql.GetBinlogReplayList(startBinlogInfo, targetBinlogInfo, binlogDir)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get binlog replay list in directory %s", binlogDir)
@@ -338,14 +345,14 @@ func downloadBinlogFilesFromCloud(ctx context.Context, client *bbs3.Client, star
 	for _, binlogFilePath := range replayBinlogPathList {
 		// Use path.Join to compose a path on cloud which always uses / as the separator.
 		filePathOnCloud := path.Join(common.GetBinlogRelativeDir(binlogDir), filepath.Base(binlogFilePath))
-		if err := client.DownloadFileFromCloud(ctx, binlogFilePath, filePathOnCloud); err != nil {
+		if err := storage.DownloadFile(ctx, binlogStorage, filePathOnCloud, binlogFilePath); err != nil {
 			return nil, errors.Wrapf(err, "failed to download binlog file %s from the cloud storage", binlogFilePath)
 		}
 	}
 	return replayBinlogPathList, nil
 }
 
-func (*PITRRestoreExecutor) doRestoreInPlacePostgres(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, profile config.Profile, issue *store.IssueMessage, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
+func (*PITRRestoreExecutor) doRestoreInPlacePostgres(ctx context.Context, stores *store.Store, dbFactory *dbfactory.DBFactory, storageFactory *storagefactory.StorageFactory, profile config.Profile, issue *store.IssueMessage, task *store.TaskMessage, payload api.TaskDatabasePITRRestorePayload) (*api.TaskRunResultPayload, error) {
 	if payload.BackupID == nil {
 		return nil, errors.Errorf("PITR for Postgres is not implemented")
 	}
@@ -358,6 +365,12 @@ func (*PITRRestoreExecutor) doRestoreInPlacePostgres(ctx context.Context, stores
 		return nil, errors.Errorf("backup with ID %d not found", *payload.BackupID)
 	}
 	backupFileName := backuprun.GetBackupAbsFilePath(profile.DataDir, backup.DatabaseUID, backup.Name)
+	if backup.StorageBackend != api.BackupStorageBackendLocal {
+		if err := downloadBackupFileFromCloud(ctx, storageFactory, backup, backupFileName); err != nil {
+			return nil, err
+		}
+		defer os.Remove(backupFileName)
+	}
 	backupFile, err := os.Open(backupFileName)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to open backup file %q", backupFileName)
@@ -462,7 +475,7 @@ func (exec *PITRRestoreExecutor) updateProgress(ctx context.Context, driver *mys
 }
 
 // restoreDatabase will restore the database to the instance from the backup.
-func (*PITRRestoreExecutor) restoreDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, s3Client *bbs3.Client, profile config.Profile, instance *store.InstanceMessage, database *store.DatabaseMessage, backup *store.BackupMessage) error {
+func (*PITRRestoreExecutor) restoreDatabase(ctx context.Context, dbFactory *dbfactory.DBFactory, storageFactory *storagefactory.StorageFactory, profile config.Profile, instance *store.InstanceMessage, database *store.DatabaseMessage, backup *store.BackupMessage) error {
 	driver, err := dbFactory.GetAdminDatabaseDriver(ctx, instance, database)
 	if err != nil {
 		return err
@@ -471,9 +484,9 @@ func (*PITRRestoreExecutor) restoreDatabase(ctx context.Context, dbFactory *dbfa
 
 	backupAbsPathLocal := filepath.Join(profile.DataDir, backup.Path)
 
-	if backup.StorageBackend == api.BackupStorageBackendS3 {
-		if err := downloadBackupFileFromCloud(ctx, s3Client, backup.Path, backupAbsPathLocal); err != nil {
-			return errors.Wrapf(err, "failed to download backup %q from S3", backup.Path)
+	if backup.StorageBackend != api.BackupStorageBackendLocal {
+		if err := downloadBackupFileFromCloud(ctx, storageFactory, backup, backupAbsPathLocal); err != nil {
+			return err
 		}
 		defer os.Remove(backupAbsPathLocal)
 	}
@@ -491,17 +504,19 @@ func (*PITRRestoreExecutor) restoreDatabase(ctx context.Context, dbFactory *dbfa
 	return nil
 }
 
-func downloadBackupFileFromCloud(ctx context.Context, s3Client *bbs3.Client, backupPath, backupAbsPathLocal string) error {
-	slog.Debug("Downloading backup file from s3 bucket.", slog.String("path", backupPath))
-	backupFileDownload, err := os.Create(backupAbsPathLocal)
+func downloadBackupFileFromCloud(ctx context.Context, storageFactory *storagefactory.StorageFactory, backup *store.BackupMessage, backupAbsPathLocal string) error {
+	backupStorage, err := storageFactory.GetBackupStorage(ctx, backup)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create local backup file %q for downloading from s3 bucket", backupAbsPathLocal)
+		return errors.Wrapf(err, "failed to get storage of backup %q", backup.Name)
+	}
+	if err := os.MkdirAll(filepath.Dir(backupAbsPathLocal), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to create directory for local backup file %q", backupAbsPathLocal)
 	}
-	defer backupFileDownload.Close()
-	if _, err := s3Client.DownloadObject(ctx, backupPath, backupFileDownload); err != nil {
-		return errors.Wrapf(err, "failed to download backup file %q from s3 bucket", backupPath)
+	slog.Debug("Downloading backup file from the storage.", slog.String("storageBackend", string(backup.StorageBackend)), slog.String("path", backup.Path))
+	if err := storage.DownloadFile(ctx, backupStorage, backup.Path, backupAbsPathLocal); err != nil {
+		return errors.Wrapf(err, "failed to download backup %q from the %s storage", backup.Path, backup.StorageBackend)
 	}
-	slog.Debug("Successfully downloaded backup file from s3 bucket.")
+	slog.Debug("Successfully downloaded backup file from the storage.")
 	return nil
 }
 
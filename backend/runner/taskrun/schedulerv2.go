@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +32,9 @@ type SchedulerV2 struct {
 	stateCfg        *state.State
 	activityManager *activity.Manager
 	executorMap     map[api.TaskType]Executor
+
+	// lastClearOrphanedTs is the last time the task runs executed by dead replicas were cleared in high-availability mode.
+	lastClearOrphanedTs time.Time
 }
 
 // NewSchedulerV2 will create a new scheduler.
@@ -85,6 +89,14 @@ func (s *SchedulerV2) runOnce(ctx context.Context) {
 		}
 	}()
 
+	// A replica is only considered dead after the replica timeout, so there is no need to check more frequently.
+	if s.stateCfg.HA && time.Since(s.lastClearOrphanedTs) >= state.ReplicaTimeout {
+		s.lastClearOrphanedTs = time.Now()
+		if err := s.ClearRunningTaskRuns(ctx); err != nil {
+			slog.Error("failed to clear task runs executed by dead replicas", log.BBError(err))
+		}
+	}
+
 	if err := s.scheduleAutoRolloutTasks(ctx); err != nil {
 		slog.Error("failed to schedule auto rollout tasks", log.BBError(err))
 	}
@@ -236,8 +248,10 @@ func (s *SchedulerV2) schedulePendingTaskRuns(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to list pending tasks")
 	}
+	// The canary wave holds are computed once per pipeline per tick, keyed by the pipeline ID.
+	canaryWaveHolds := map[int]map[int]string{}
 	for _, taskRun := range taskRuns {
-		if err := s.schedulePendingTaskRun(ctx, taskRun); err != nil {
+		if err := s.schedulePendingTaskRun(ctx, taskRun, canaryWaveHolds); err != nil {
 			slog.Error("failed to schedule pending task run", log.BBError(err))
 		}
 	}
@@ -245,7 +259,7 @@ func (s *SchedulerV2) schedulePendingTaskRuns(ctx context.Context) error {
 	return nil
 }
 
-func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store.TaskRunMessage) error {
+func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store.TaskRunMessage, canaryWaveHolds map[int]map[int]string) error {
 	task, err := s.store.GetTaskV2ByID(ctx, taskRun.TaskUID)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get task")
@@ -259,13 +273,7 @@ func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store
 			return errors.Wrapf(err, "failed to get blocking task %v", blockingTaskUID)
 		}
 
-		skipped := struct {
-			Skipped bool `json:"skipped"`
-		}{}
-		if err := json.Unmarshal([]byte(blockingTask.Payload), &skipped); err != nil {
-			return errors.Wrapf(err, "failed to unmarshal payload")
-		}
-		if skipped.Skipped {
+		if blockingTask.Skipped {
 			continue
 		}
 
@@ -274,12 +282,212 @@ func (s *SchedulerV2) schedulePendingTaskRun(ctx context.Context, taskRun *store
 		}
 	}
 
+	held, err := s.holdByRolloutWindow(ctx, taskRun, task)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check rollout window of task run %v", taskRun.ID)
+	}
+	if held {
+		return nil
+	}
+	held, err = s.holdByCanaryWave(ctx, taskRun, task, canaryWaveHolds)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check canary wave of task run %v", taskRun.ID)
+	}
+	if held {
+		return nil
+	}
+
+	// In high-availability mode, the replica acquiring the lease is the only one executing the task run.
+	acquired, err := s.stateCfg.TryAcquireLease(ctx, store.TaskRunLeaseResource(taskRun.ID), task.InstanceID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to acquire lease of task run %v", taskRun.ID)
+	}
+	if !acquired {
+		return nil
+	}
+
+	// Only claim the task run if it is still PENDING.
+	// The lease is released once a task run finishes, so another replica may have claimed and finished it since we listed it.
+	pending := api.TaskRunPending
+	patch := &store.TaskRunStatusPatch{
+		ID:             taskRun.ID,
+		UpdaterID:      api.SystemBotID,
+		Status:         api.TaskRunRunning,
+		ExpectedStatus: &pending,
+	}
+	// Clear the reason why the task run was held.
+	if taskRun.ResultProto.GetDetail() != "" {
+		result := ""
+		patch.Result = &result
+	}
+	claimed, err := s.store.UpdateTaskRunStatus(ctx, patch)
+	if err != nil {
+		s.stateCfg.ReleaseLease(ctx, store.TaskRunLeaseResource(taskRun.ID))
+		return errors.Wrapf(err, "failed to update task run status to running")
+	}
+	if claimed == nil {
+		// The task run has already been claimed.
+		s.stateCfg.ReleaseLease(ctx, store.TaskRunLeaseResource(taskRun.ID))
+	}
+	return nil
+}
+
+// holdByRolloutWindow returns true if the pending task run is held by the rollout window policy of the stage environment.
+// The reason is written to the task run detail so that the users know why the task run doesn't start.
+func (s *SchedulerV2) holdByRolloutWindow(ctx context.Context, taskRun *store.TaskRunMessage, task *store.TaskMessage) (bool, error) {
+	stages, err := s.store.ListStageV2(ctx, task.PipelineID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list stages")
+	}
+	var stage *store.StageMessage
+	for _, st := range stages {
+		if st.ID == task.StageID {
+			stage = st
+			break
+		}
+	}
+	if stage == nil {
+		return false, errors.Errorf("stage %d not found", task.StageID)
+	}
+	policy, err := s.store.GetRolloutWindowPolicy(ctx, stage.EnvironmentID)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get rollout window policy for environment %d", stage.EnvironmentID)
+	}
+	// The task runs created by the system bot, e.g. the auto rollout, never bypass the policy.
+	if taskRun.CreatorID != api.SystemBotID && len(policy.BypassRoles) > 0 {
+		creator, err := s.store.GetUserByID(ctx, taskRun.CreatorID)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get user %d", taskRun.CreatorID)
+		}
+		if creator != nil && utils.IsRolloutWindowBypassed(policy, creator.Role) {
+			return false, nil
+		}
+	}
+	reason, err := utils.CheckRolloutWindow(policy, time.Now())
+	if err != nil {
+		return false, err
+	}
+	if reason == "" {
+		return false, nil
+	}
+	if err := s.holdTaskRun(ctx, taskRun, reason); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// holdByCanaryWave returns true if the pending task run is held because its stage is a canary wave
+// and the previous wave is not finished, has failed too many tasks, or is still soaking.
+// The hold reasons of the pipeline stages are cached in canaryWaveHolds for the current tick.
+func (s *SchedulerV2) holdByCanaryWave(ctx context.Context, taskRun *store.TaskRunMessage, task *store.TaskMessage, canaryWaveHolds map[int]map[int]string) (bool, error) {
+	holds, ok := canaryWaveHolds[task.PipelineID]
+	if !ok {
+		var err error
+		holds, err = s.getCanaryWaveHolds(ctx, task.PipelineID)
+		if err != nil {
+			return false, err
+		}
+		canaryWaveHolds[task.PipelineID] = holds
+	}
+	reason := holds[task.StageID]
+	if reason == "" {
+		return false, nil
+	}
+	if err := s.holdTaskRun(ctx, taskRun, reason); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getCanaryWaveHolds returns the hold reasons of the canary wave stages in the pipeline, keyed by the stage ID.
+// The stages that are not held are absent.
+func (s *SchedulerV2) getCanaryWaveHolds(ctx context.Context, pipelineID int) (map[int]string, error) {
+	stages, err := s.store.ListStageV2(ctx, pipelineID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list stages")
+	}
+	holds := map[int]string{}
+	for i, stage := range stages {
+		wave := stage.Payload.GetCanaryWave()
+		if wave.GetIndex() == 0 || i == 0 {
+			continue
+		}
+		reason, err := s.getCanaryWaveHoldReason(ctx, wave, stages[i-1])
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			holds[stage.ID] = reason
+		}
+	}
+	return holds, nil
+}
+
+// getCanaryWaveHoldReason returns the reason why the wave cannot start after the previous stage, or empty if it can start.
+func (s *SchedulerV2) getCanaryWaveHoldReason(ctx context.Context, wave *storepb.CanaryWave, prevStage *store.StageMessage) (string, error) {
+	prevTasks, err := s.store.ListTasks(ctx, &api.TaskFind{StageID: &prevStage.ID})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list tasks of stage %d", prevStage.ID)
+	}
+	failed := 0
+	for _, prevTask := range prevTasks {
+		if prevTask.Skipped {
+			continue
+		}
+		switch prevTask.LatestTaskRunStatus {
+		case api.TaskRunDone, api.TaskRunSkipped:
+		case api.TaskRunFailed, api.TaskRunCanceled:
+			failed++
+		default:
+			return fmt.Sprintf("Waiting for the previous wave %q to finish", prevStage.Name), nil
+		}
+	}
+	if failed > int(wave.MaxFailedTasks) {
+		return fmt.Sprintf("Rollout is halted because %d tasks failed in the previous wave %q, exceeding the limit of %d", failed, prevStage.Name, wave.MaxFailedTasks), nil
+	}
+
+	if soak := wave.GetSoakDuration().AsDuration(); soak > 0 {
+		prevTaskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{StageUID: &prevStage.ID})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to list task runs of stage %d", prevStage.ID)
+		}
+		var finishedTs int64
+		for _, prevTaskRun := range prevTaskRuns {
+			if prevTaskRun.UpdatedTs > finishedTs {
+				finishedTs = prevTaskRun.UpdatedTs
+			}
+		}
+		if soakEnd := time.Unix(finishedTs, 0).Add(soak); time.Now().Before(soakEnd) {
+			return fmt.Sprintf("Soaking the previous wave %q until %s", prevStage.Name, soakEnd.UTC().Format(time.RFC3339)), nil
+		}
+	}
+	return "", nil
+}
+
+// holdTaskRun keeps the task run pending and writes the reason to the task run detail
+// so that the users know why the task run doesn't start.
+func (s *SchedulerV2) holdTaskRun(ctx context.Context, taskRun *store.TaskRunMessage, reason string) error {
+	if taskRun.ResultProto.GetDetail() == reason {
+		return nil
+	}
+	resultBytes, err := protojson.Marshal(&storepb.TaskRunResult{
+		Detail: reason,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal task run result")
+	}
+	result := string(resultBytes)
+	// Only hold the task run if it is still PENDING.
+	// Another replica may have claimed it since we listed it, and we must not reset it back to PENDING.
+	pending := api.TaskRunPending
 	if _, err := s.store.UpdateTaskRunStatus(ctx, &store.TaskRunStatusPatch{
-		ID:        taskRun.ID,
-		UpdaterID: api.SystemBotID,
-		Status:    api.TaskRunRunning,
+		ID:             taskRun.ID,
+		UpdaterID:      api.SystemBotID,
+		Status:         api.TaskRunPending,
+		ExpectedStatus: &pending,
+		Result:         &result,
 	}); err != nil {
-		return errors.Wrapf(err, "failed to update task run status to running")
+		return errors.Wrapf(err, "failed to update task run result")
 	}
 	return nil
 }
@@ -292,6 +500,14 @@ func (s *SchedulerV2) scheduleRunningTaskRuns(ctx context.Context) error {
 		return errors.Wrapf(err, "failed to list pending tasks")
 	}
 
+	heldLeases, err := s.stateCfg.ListHeldLeases(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list held leases")
+	}
+	if s.stateCfg.HA {
+		s.reconcileStoppedTaskRuns(ctx, taskRuns, heldLeases)
+	}
+
 	// Find the minimum task ID for each database.
 	// We only run the first (i.e. which has the minimum task ID) task for each database.
 	minTaskIDForDatabase := map[int]int{}
@@ -316,6 +532,10 @@ func (s *SchedulerV2) scheduleRunningTaskRuns(ctx context.Context) error {
 		if _, ok := s.stateCfg.RunningTaskRuns.Load(taskRun.ID); ok {
 			continue
 		}
+		// Skip the task run if it is executed by another replica.
+		if s.stateCfg.HA && !heldLeases[store.TaskRunLeaseResource(taskRun.ID)] {
+			continue
+		}
 		task, err := s.store.GetTaskV2ByID(ctx, taskRun.TaskUID)
 		if err != nil {
 			slog.Error("failed to get task", slog.Int("task id", taskRun.TaskUID), log.BBError(err))
@@ -355,6 +575,26 @@ func (s *SchedulerV2) scheduleRunningTaskRuns(ctx context.Context) error {
 	return nil
 }
 
+// reconcileStoppedTaskRuns cancels the executions and releases the leases of the task runs held by the replica but no longer RUNNING.
+// In high-availability mode, the task run may be canceled by the API served by another replica.
+func (s *SchedulerV2) reconcileStoppedTaskRuns(ctx context.Context, runningTaskRuns []*store.TaskRunMessage, heldLeases map[string]bool) {
+	running := make(map[string]bool)
+	for _, taskRun := range runningTaskRuns {
+		running[store.TaskRunLeaseResource(taskRun.ID)] = true
+	}
+	s.stateCfg.RunningTaskRunsCancelFunc.Range(func(key, value any) bool {
+		if !running[store.TaskRunLeaseResource(key.(int))] {
+			value.(context.CancelFunc)()
+		}
+		return true
+	})
+	for resource := range heldLeases {
+		if strings.HasPrefix(resource, store.TaskRunLeasePrefix) && !running[resource] {
+			s.stateCfg.ReleaseLease(ctx, resource)
+		}
+	}
+}
+
 func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRunMessage, task *store.TaskMessage, executor Executor) {
 	defer func() {
 		s.stateCfg.RunningTaskRuns.Delete(taskRun.ID)
@@ -378,6 +618,8 @@ func (s *SchedulerV2) runTaskRunOnce(ctx context.Context, taskRun *store.TaskRun
 		)
 		return
 	}
+	// The task run is finished, release the lease after updating its status.
+	defer s.stateCfg.ReleaseLease(ctx, store.TaskRunLeaseResource(taskRun.ID))
 
 	if done && err != nil && errors.Is(err, context.Canceled) {
 		slog.Warn("task run is canceled",
@@ -694,7 +936,19 @@ func (s *SchedulerV2) createActivityForTaskRunStatusUpdate(ctx context.Context,
 // When Bytebase is restarted, the task scheduler will re-schedule those RUNNING tasks, which should be CANCELED instead.
 // So we change their status to CANCELED before starting the scheduler.
 // And corresponding taskRuns are also changed to CANCELED.
+// In high-availability mode, only the taskRuns without leases held by alive replicas are changed to CANCELED,
+// which are the ones executed by dead replicas.
 func (s *SchedulerV2) ClearRunningTaskRuns(ctx context.Context) error {
+	if s.stateCfg.HA {
+		taskRunIDs, err := s.store.CancelOrphanedTaskRuns(ctx, state.AliveSinceTs(), api.SystemBotID)
+		if err != nil {
+			return err
+		}
+		if len(taskRunIDs) > 0 {
+			slog.Info("Canceled task runs executed by dead replicas", slog.Any("taskRuns", taskRunIDs))
+		}
+		return nil
+	}
 	runningTaskRuns, err := s.store.ListTaskRunsV2(ctx, &store.FindTaskRunMessage{
 		Status: &[]api.TaskRunStatus{api.TaskRunRunning},
 	})
@@ -43,12 +43,12 @@ func (exec *SchemaUpdateGhostSyncExecutor) RunOnce(ctx context.Context, _ contex
 	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
 		return true, nil, errors.Wrap(err, "invalid database schema update gh-ost sync payload")
 	}
-	statement, err := exec.store.GetSheetStatementByID(ctx, payload.SheetID)
+	sheetRevision, err := getSheetRevision(ctx, exec.store, payload.SheetID, payload.SheetRevision)
 	if err != nil {
 		return true, nil, err
 	}
 
-	return exec.runGhostMigration(ctx, exec.store, task, statement)
+	return exec.runGhostMigration(ctx, exec.store, task, sheetRevision.Statement)
 }
 
 type sharedGhostState struct {
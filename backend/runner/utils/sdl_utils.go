@@ -40,13 +40,23 @@ func ComputeDatabaseSchemaDiff(ctx context.Context, instance *store.InstanceMess
 		engine = parser.Postgres
 	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
 		engine = parser.MySQL
+	case db.MSSQL:
+		engine = parser.MSSQL
+	case db.Snowflake:
+		engine = parser.Snowflake
 	default:
 		return "", errors.Errorf("unsupported database engine %q", instance.Engine)
 	}
 
-	sdlFormat, err := transform.SchemaTransform(engine, schema.String())
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to transform SDL format")
+	sdlFormat := schema.String()
+	switch engine {
+	case parser.MSSQL, parser.Snowflake:
+		// The differs of MSSQL and Snowflake accept the dumped schema directly.
+	default:
+		sdlFormat, err = transform.SchemaTransform(engine, sdlFormat)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to transform SDL format")
+		}
 	}
 	diff, err := differ.SchemaDiff(engine, sdlFormat, newSchema, store.IgnoreDatabaseAndTableCaseSensitive(instance))
 	if err != nil {
@@ -0,0 +1,96 @@
+// Package webhookrun is the runner retrying the failed webhook deliveries.
+package webhookrun
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/activity"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+const (
+	webhookRunnerInterval = 10 * time.Second
+	// webhookDeliveryRetention is how long the succeeded and dead-lettered deliveries are kept.
+	webhookDeliveryRetention     = 30 * 24 * time.Hour
+	webhookDeliveryPurgeInterval = 1 * time.Hour
+)
+
+// NewRunner creates a new runner instance.
+func NewRunner(store *store.Store, activityManager *activity.Manager) *Runner {
+	return &Runner{
+		store:           store,
+		activityManager: activityManager,
+	}
+}
+
+// Runner is the runner retrying the pending webhook deliveries when they are due.
+type Runner struct {
+	store           *store.Store
+	activityManager *activity.Manager
+}
+
+// Run runs the runner.
+func (r *Runner) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(webhookRunnerInterval)
+	defer ticker.Stop()
+	purgeTicker := time.NewTicker(webhookDeliveryPurgeInterval)
+	defer purgeTicker.Stop()
+	defer wg.Done()
+	slog.Debug(fmt.Sprintf("Webhook runner started and will run every %v", webhookRunnerInterval))
+	for {
+		select {
+		case <-ticker.C:
+			r.retryDeliveries(ctx)
+		case <-purgeTicker.C:
+			r.purgeDeliveries(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Runner) retryDeliveries(ctx context.Context) {
+	pending := store.WebhookDeliveryPending
+	now := time.Now().Unix()
+	deliveries, err := r.store.ListWebhookDeliveries(ctx, &store.FindWebhookDeliveryMessage{
+		Status:              &pending,
+		NextAttemptBeforeTs: &now,
+	})
+	if err != nil {
+		slog.Error("Failed to list pending webhook deliveries", log.BBError(err))
+		return
+	}
+	for _, delivery := range deliveries {
+		r.retryDelivery(ctx, delivery.UID)
+	}
+}
+
+func (r *Runner) retryDelivery(ctx context.Context, deliveryUID int) {
+	delivery, err := r.activityManager.RetryWebhookDelivery(ctx, deliveryUID)
+	if err != nil {
+		slog.Error("Failed to retry webhook delivery", slog.Int("delivery", deliveryUID), log.BBError(err))
+		return
+	}
+	if delivery != nil && delivery.Status == store.WebhookDeliveryDeadLetter {
+		slog.Warn("Webhook delivery is dead-lettered",
+			slog.Int("delivery", delivery.UID),
+			slog.Int("attempts", delivery.AttemptCount),
+			slog.String("error", delivery.LastError))
+	}
+}
+
+func (r *Runner) purgeDeliveries(ctx context.Context) {
+	count, err := r.store.DeleteFinishedWebhookDeliveries(ctx, time.Now().Add(-webhookDeliveryRetention).Unix())
+	if err != nil {
+		slog.Error("Failed to purge webhook deliveries", log.BBError(err))
+		return
+	}
+	if count > 0 {
+		slog.Debug("Purged webhook deliveries", slog.Int64("count", count))
+	}
+}
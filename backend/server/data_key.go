@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	"github.com/bytebase/bytebase/backend/common/log"
+	"github.com/bytebase/bytebase/backend/component/config"
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+	"github.com/bytebase/bytebase/backend/resources/postgres"
+	"github.com/bytebase/bytebase/backend/store"
+)
+
+// initSecretEnvelope sets up the envelope encryption of the secrets if the key provider is configured.
+func initSecretEnvelope(ctx context.Context, storeInstance *store.Store, kmsConfig *kms.Config) error {
+	if kmsConfig.Provider == "" {
+		return nil
+	}
+	keyProvider, err := kms.New(ctx, kmsConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to create key provider")
+	}
+	if err := storeInstance.InitSecretEnvelope(ctx, kmsConfig.Provider, keyProvider); err != nil {
+		return errors.Wrap(err, "failed to init the encryption of the secrets")
+	}
+	return nil
+}
+
+// RotateDataKey connects to the metadata database, re-encrypts all the secrets with a new data key wrapped by the configured key provider,
+// and returns the number of the re-encrypted secrets.
+// If the previous key provider is specified, the data keys wrapped by it are re-wrapped by the configured key provider first.
+// The servers sharing the metadata database keep running, see store.RotateDataKey.
+func RotateDataKey(ctx context.Context, profile config.Profile, previousKMS *kms.Config) (int, error) {
+	if profile.KMS.Provider == "" {
+		return 0, errors.New("key provider is not configured")
+	}
+	pgBinDir, err := postgres.Install(profile.ResourceDir)
+	if err != nil {
+		return 0, err
+	}
+	var metaDB *store.MetadataDB
+	if profile.UseEmbedDB() {
+		pgDataDir := common.GetPostgresDataDir(profile.DataDir, profile.DemoName)
+		metaDB = store.NewMetadataDBWithEmbedPg(profile.PgUser, pgDataDir, pgBinDir, profile.DemoName, profile.Mode)
+	} else {
+		metaDB = store.NewMetadataDBWithExternalPg(profile.PgURL, pgBinDir, profile.DemoName, profile.Mode)
+	}
+	defer func() {
+		if err := metaDB.Close(); err != nil {
+			slog.Error("failed to close metadb", log.BBError(err))
+		}
+	}()
+	storeDB, err := metaDB.Connect(profile.DatastorePort, false /* readonly */, profile.Version)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot connect metadb")
+	}
+	if err := storeDB.Open(ctx); err != nil {
+		return 0, errors.Wrap(err, "cannot open metadb")
+	}
+	storeInstance := store.New(storeDB)
+	defer storeInstance.Close(ctx)
+
+	if previousKMS.Provider != "" {
+		previousKeyProvider, err := kms.New(ctx, previousKMS)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to create previous key provider")
+		}
+		keyProvider, err := kms.New(ctx, &profile.KMS)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to create key provider")
+		}
+		count, err := storeInstance.RewrapDataKeys(ctx, previousKMS.Provider, previousKeyProvider, profile.KMS.Provider, keyProvider)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to re-wrap data keys")
+		}
+		slog.Info(fmt.Sprintf("Re-wrapped %d data keys with the %s key provider", count, profile.KMS.Provider))
+	}
+	if err := initSecretEnvelope(ctx, storeInstance, &profile.KMS); err != nil {
+		return 0, err
+	}
+	return storeInstance.RotateDataKey(ctx)
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/component/exportjob"
+)
+
+// registerExportJobRoutes registers the endpoint downloading the export files of the export jobs.
+// The download is authorized by the token in the download link instead of the credentials of the user,
+// so that the link can be opened in the browser or by other tools.
+func (s *Server) registerExportJobRoutes(g *echo.Group) {
+	g.GET("/:job", func(c echo.Context) error {
+		err := s.exportJobManager.Download(c.Request().Context(), c.Param("job"), c.QueryParam("token"), func(job *exportjob.Job) io.Writer {
+			c.Response().Header().Set(echo.HeaderContentType, "application/octet-stream")
+			c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", job.FileName()))
+			c.Response().WriteHeader(http.StatusOK)
+			return c.Response()
+		})
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(err, exportjob.ErrJobNotFound), errors.Is(err, exportjob.ErrInvalidToken):
+			return echo.NewHTTPError(http.StatusNotFound, "Export file not found or the download link has expired")
+		case c.Response().Committed:
+			// The content is partially written, the client sees a truncated download.
+			return err
+		default:
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to download export file").SetInternal(err)
+		}
+	})
+}
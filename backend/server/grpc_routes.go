@@ -14,6 +14,8 @@ import (
 	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/exportjob"
+	"github.com/bytebase/bytebase/backend/component/sqlsession"
 	"github.com/bytebase/bytebase/backend/component/state"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
@@ -41,6 +43,8 @@ func configureGrpcRouters(
 	backupRunner *backuprun.Runner,
 	relayRunner *relay.Runner,
 	planCheckScheduler *plancheck.Scheduler,
+	sqlSessionManager *sqlsession.Manager,
+	exportJobManager *exportjob.Manager,
 	postCreateUser v1.CreateUserFunc,
 	secret string,
 	errorRecordRing *api.ErrorRecordRing,
@@ -73,7 +77,7 @@ func configureGrpcRouters(
 	v1pb.RegisterIdentityProviderServiceServer(grpcServer, v1.NewIdentityProviderService(stores, licenseService))
 	v1pb.RegisterSettingServiceServer(grpcServer, v1.NewSettingService(stores, profile, licenseService, stateCfg))
 	v1pb.RegisterAnomalyServiceServer(grpcServer, v1.NewAnomalyService(stores))
-	v1pb.RegisterSQLServiceServer(grpcServer, v1.NewSQLService(stores, schemaSyncer, dbFactory, activityManager, licenseService))
+	v1pb.RegisterSQLServiceServer(grpcServer, v1.NewSQLService(stores, schemaSyncer, dbFactory, activityManager, licenseService, sqlSessionManager, exportJobManager))
 	v1pb.RegisterExternalVersionControlServiceServer(grpcServer, v1.NewExternalVersionControlService(stores))
 	v1pb.RegisterRiskServiceServer(grpcServer, v1.NewRiskService(stores, licenseService))
 	issueService := v1.NewIssueService(stores, activityManager, relayRunner, stateCfg, licenseService, metricReporter)
@@ -81,6 +85,7 @@ func configureGrpcRouters(
 	rolloutService := v1.NewRolloutService(stores, licenseService, dbFactory, planCheckScheduler, stateCfg, activityManager)
 	v1pb.RegisterRolloutServiceServer(grpcServer, rolloutService)
 	v1pb.RegisterRoleServiceServer(grpcServer, v1.NewRoleService(stores, licenseService))
+	v1pb.RegisterGroupServiceServer(grpcServer, v1.NewGroupService(stores, licenseService))
 	v1pb.RegisterSheetServiceServer(grpcServer, v1.NewSheetService(stores, licenseService))
 	v1pb.RegisterSchemaDesignServiceServer(grpcServer, v1.NewSchemaDesignService(stores, licenseService))
 	v1pb.RegisterCelServiceServer(grpcServer, v1.NewCelService())
@@ -141,6 +146,9 @@ func configureGrpcRouters(
 	if err := v1pb.RegisterRoleServiceHandler(ctx, mux, grpcConn); err != nil {
 		return nil, nil, err
 	}
+	if err := v1pb.RegisterGroupServiceHandler(ctx, mux, grpcConn); err != nil {
+		return nil, nil, err
+	}
 	if err := v1pb.RegisterSheetServiceHandler(ctx, mux, grpcConn); err != nil {
 		return nil, nil, err
 	}
@@ -27,10 +27,11 @@ func configureEchoRouters(e *echo.Echo, grpcServer *grpc.Server, mux *grpcRuntim
 	e.HidePort = true
 	e.Use(recoverMiddleware)
 	grpcSkipper := func(c echo.Context) bool {
-		// Skip grpc and webhook calls.
+		// Skip grpc and webhook calls, and the export file downloads which may take longer than the timeout.
 		return strings.HasPrefix(c.Request().URL.Path, "/bytebase.v1.") ||
 			strings.HasPrefix(c.Request().URL.Path, "/v1:adminExecute") ||
-			strings.HasPrefix(c.Request().URL.Path, webhookAPIPrefix)
+			strings.HasPrefix(c.Request().URL.Path, webhookAPIPrefix) ||
+			strings.HasPrefix(c.Request().URL.Path, exportPrefix+"/")
 	}
 	e.Use(middleware.TimeoutWithConfig(middleware.TimeoutConfig{
 		Skipper: grpcSkipper,
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/bytebase/bytebase/backend/api/v1"
+	"github.com/bytebase/bytebase/backend/plugin/idp/saml"
+	"github.com/bytebase/bytebase/backend/store"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// samlRequestTTL is the time for the users to sign in on the SAML identity provider.
+const samlRequestTTL = 10 * time.Minute
+
+// samlCallbackTemplate hands the SAML response over to the frontend sign-in page through the session storage,
+// so that the response is only posted to the sign-in API and never appears in any URL, logs or browser history.
+var samlCallbackTemplate = template.Must(template.New("saml-callback").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Signing in</title></head>
+<body>
+<script>
+window.sessionStorage.setItem("bb.saml.response", JSON.stringify({{.Response}}));
+window.location.replace({{.CallbackURL}});
+</script>
+</body>
+</html>
+`))
+
+type samlCallbackResponse struct {
+	IdentityProvider string `json:"idp"`
+	SAMLResponse     string `json:"samlResponse"`
+	RelayState       string `json:"relayState,omitempty"`
+}
+
+func (s *Server) registerSAMLRoutes(g *echo.Group) {
+	// The service provider metadata for registering Bytebase on the identity provider.
+	g.GET("/:idp/metadata", func(c echo.Context) error {
+		samlIDP, err := s.getSAMLIdentityProvider(c.Request().Context(), c.Param("idp"))
+		if err != nil {
+			return err
+		}
+		metadata, err := samlIDP.Metadata()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate SAML metadata").SetInternal(err)
+		}
+		return c.Blob(http.StatusOK, "application/samlmetadata+xml", metadata)
+	})
+
+	// The single sign-on redirects the users to the identity provider with a signed authentication request.
+	g.GET("/:idp/sso", func(c echo.Context) error {
+		samlIDP, err := s.getSAMLIdentityProvider(c.Request().Context(), c.Param("idp"))
+		if err != nil {
+			return err
+		}
+		requestID, err := saml.NewRequestID()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate SAML request ID").SetInternal(err)
+		}
+		redirectURL, err := samlIDP.AuthnRequestURL(requestID, c.QueryParam("RelayState"), time.Now())
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create SAML authentication request").SetInternal(err)
+		}
+		// The requests are stored in the database as the response may be posted to another replica.
+		if err := s.store.CreateSAMLRequest(c.Request().Context(), requestID, c.Param("idp"), time.Now().Add(samlRequestTTL)); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save SAML authentication request").SetInternal(err)
+		}
+		return c.Redirect(http.StatusFound, redirectURL)
+	})
+
+	// The assertion consumer service validates the SAML response posted by the identity provider,
+	// and passes it to the frontend to sign in.
+	g.POST("/:idp/acs", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		samlIDP, err := s.getSAMLIdentityProvider(ctx, c.Param("idp"))
+		if err != nil {
+			return err
+		}
+		samlResponse := c.FormValue("SAMLResponse")
+		if samlResponse == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "Missing SAML response")
+		}
+		// The request ID is consumed when signing in, so only check it's issued here.
+		if _, err := samlIDP.UserInfo(samlResponse, func(requestID string) (bool, error) {
+			return s.store.ExistsSAMLRequest(ctx, requestID, c.Param("idp"))
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid SAML response: %v", err)).SetInternal(err)
+		}
+
+		setting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
+		}
+		var page bytes.Buffer
+		if err := samlCallbackTemplate.Execute(&page, struct {
+			Response    samlCallbackResponse
+			CallbackURL string
+		}{
+			Response: samlCallbackResponse{
+				IdentityProvider: c.Param("idp"),
+				SAMLResponse:     samlResponse,
+				RelayState:       c.FormValue("RelayState"),
+			},
+			CallbackURL: fmt.Sprintf("%s/saml/callback", setting.ExternalUrl),
+		}); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to render SAML callback page").SetInternal(err)
+		}
+		c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+		return c.HTMLBlob(http.StatusOK, page.Bytes())
+	})
+}
+
+func (s *Server) getSAMLIdentityProvider(ctx context.Context, identityProviderID string) (*saml.IdentityProvider, error) {
+	identityProvider, err := s.store.GetIdentityProvider(ctx, &store.FindIdentityProviderMessage{
+		ResourceID: &identityProviderID,
+	})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get identity provider").SetInternal(err)
+	}
+	if identityProvider == nil || identityProvider.Deleted || identityProvider.Type != storepb.IdentityProviderType_SAML {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("SAML identity provider %q not found", identityProviderID))
+	}
+	setting, err := s.store.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get workspace setting").SetInternal(err)
+	}
+	if setting.ExternalUrl == "" {
+		return nil, echo.NewHTTPError(http.StatusPreconditionFailed, "External URL is not set")
+	}
+	samlIDP, err := v1.NewSAMLIdentityProvider(setting.ExternalUrl, identityProviderID, identityProvider.Config.GetSamlConfig())
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to create SAML identity provider").SetInternal(err)
+	}
+	return samlIDP, nil
+}
@@ -22,23 +22,28 @@ import (
 
 	"github.com/bytebase/bytebase/backend/api/auth"
 	"github.com/bytebase/bytebase/backend/api/gitops"
+	"github.com/bytebase/bytebase/backend/api/scim"
 	v1 "github.com/bytebase/bytebase/backend/api/v1"
 	"github.com/bytebase/bytebase/backend/common"
 	"github.com/bytebase/bytebase/backend/common/log"
 	"github.com/bytebase/bytebase/backend/component/activity"
 	"github.com/bytebase/bytebase/backend/component/config"
 	"github.com/bytebase/bytebase/backend/component/dbfactory"
+	"github.com/bytebase/bytebase/backend/component/exportjob"
+	"github.com/bytebase/bytebase/backend/component/sqlsession"
 	"github.com/bytebase/bytebase/backend/component/state"
+	"github.com/bytebase/bytebase/backend/component/storagefactory"
 	enterpriseAPI "github.com/bytebase/bytebase/backend/enterprise/api"
 	enterpriseService "github.com/bytebase/bytebase/backend/enterprise/service"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	"github.com/bytebase/bytebase/backend/migrator"
-	bbs3 "github.com/bytebase/bytebase/backend/plugin/storage/s3"
 	"github.com/bytebase/bytebase/backend/resources/mongoutil"
 	"github.com/bytebase/bytebase/backend/resources/mysqlutil"
 	"github.com/bytebase/bytebase/backend/resources/postgres"
 	"github.com/bytebase/bytebase/backend/runner/approval"
 	"github.com/bytebase/bytebase/backend/runner/backuprun"
+	"github.com/bytebase/bytebase/backend/runner/datakeyrun"
+	"github.com/bytebase/bytebase/backend/runner/heartbeat"
 	"github.com/bytebase/bytebase/backend/runner/mail"
 	"github.com/bytebase/bytebase/backend/runner/metricreport"
 	"github.com/bytebase/bytebase/backend/runner/plancheck"
@@ -47,6 +52,7 @@ import (
 	"github.com/bytebase/bytebase/backend/runner/schemasync"
 	"github.com/bytebase/bytebase/backend/runner/slowquerysync"
 	"github.com/bytebase/bytebase/backend/runner/taskrun"
+	"github.com/bytebase/bytebase/backend/runner/webhookrun"
 	"github.com/bytebase/bytebase/backend/store"
 	_ "github.com/bytebase/bytebase/docs/openapi" // initial the swagger doc
 
@@ -81,6 +87,34 @@ import (
 	// Register risingwave driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/db/risingwave"
 
+	// Register local storage.
+	_ "github.com/bytebase/bytebase/backend/plugin/storage/local"
+	// Register S3 storage.
+	_ "github.com/bytebase/bytebase/backend/plugin/storage/s3"
+	// Register GCS storage.
+	_ "github.com/bytebase/bytebase/backend/plugin/storage/gcs"
+	// Register Azure Blob storage.
+	_ "github.com/bytebase/bytebase/backend/plugin/storage/azblob"
+
+	// Register local key provider.
+	_ "github.com/bytebase/bytebase/backend/plugin/kms/local"
+	// Register Vault key provider.
+	_ "github.com/bytebase/bytebase/backend/plugin/kms/vault"
+	// Register AWS KMS key provider.
+	_ "github.com/bytebase/bytebase/backend/plugin/kms/aws"
+	// Register GCP KMS key provider.
+	_ "github.com/bytebase/bytebase/backend/plugin/kms/gcp"
+
+	// Register Vault secret manager.
+	_ "github.com/bytebase/bytebase/backend/plugin/secret/vault"
+	// Register AWS Secrets Manager.
+	_ "github.com/bytebase/bytebase/backend/plugin/secret/aws"
+
+	// Register AWS RDS IAM authentication.
+	_ "github.com/bytebase/bytebase/backend/plugin/iamauth/aws"
+	// Register Cloud SQL IAM authentication.
+	_ "github.com/bytebase/bytebase/backend/plugin/iamauth/gcp"
+
 	// Register fake advisor.
 	_ "github.com/bytebase/bytebase/backend/plugin/advisor/fake"
 	// Register mysql advisor.
@@ -100,6 +134,10 @@ import (
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/differ/pg"
 	// Register oracle differ driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/differ/plsql"
+	// Register mssql differ driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/differ/tsql"
+	// Register snowflake differ driver.
+	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/differ/snowflake"
 	// Register mysql edit driver.
 	_ "github.com/bytebase/bytebase/backend/plugin/parser/sql/edit/mysql"
 	// Register postgres edit driver.
@@ -113,6 +151,12 @@ import (
 const (
 	// internalAPIPrefix is the API prefix for Bytebase internal, used by the UX.
 	internalAPIPrefix = "/api"
+	// samlPrefix is the prefix of the SAML service provider endpoints.
+	samlPrefix = "/saml"
+	// scimAPIPrefix is the API prefix for the SCIM 2.0 provisioning.
+	scimAPIPrefix = "/scim/v2"
+	// exportPrefix is the prefix of the endpoint downloading the export files.
+	exportPrefix = exportjob.DownloadPath
 	// webhookAPIPrefix is the API prefix for Bytebase webhook.
 	webhookAPIPrefix       = "/hook"
 	maxStacksize           = 1024 * 10240
@@ -132,9 +176,14 @@ type Server struct {
 	rollbackRunner     *rollbackrun.Runner
 	approvalRunner     *approval.Runner
 	relayRunner        *relay.Runner
+	heartbeatRunner    *heartbeat.Runner
+	webhookRunner      *webhookrun.Runner
+	dataKeyRunner      *datakeyrun.Runner
 	runnerWG           sync.WaitGroup
 
-	activityManager *activity.Manager
+	activityManager   *activity.Manager
+	sqlSessionManager *sqlsession.Manager
+	exportJobManager  *exportjob.Manager
 
 	licenseService enterpriseAPI.LicenseService
 
@@ -144,6 +193,7 @@ type Server struct {
 	metaDB          *store.MetadataDB
 	store           *store.Store
 	dbFactory       *dbfactory.DBFactory
+	storageFactory  *storagefactory.StorageFactory
 	startedTs       int64
 	secret          string
 	errorRecordRing api.ErrorRecordRing
@@ -159,8 +209,6 @@ type Server struct {
 	// Postgres utility binaries
 	pgBinDir string
 
-	s3Client *bbs3.Client
-
 	// stateCfg is the shared in-momory state within the server.
 	stateCfg *state.State
 
@@ -187,6 +235,7 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	slog.Info(fmt.Sprintf("backupBucket=%s", profile.BackupBucket))
 	slog.Info(fmt.Sprintf("backupRegion=%s", profile.BackupRegion))
 	slog.Info(fmt.Sprintf("backupCredentialFile=%s", profile.BackupCredentialFile))
+	slog.Info(fmt.Sprintf("kmsProvider=%s", profile.KMS.Provider))
 	slog.Info("-----Config END-------")
 
 	serverStarted := false
@@ -257,6 +306,10 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		return nil, errors.Wrap(err, "cannot open metadb")
 	}
 	storeInstance := store.New(storeDB)
+	if profile.HA {
+		// The replicas don't invalidate the in-memory caches of each other.
+		storeInstance.BypassCache()
+	}
 	if profile.Readonly {
 		slog.Info("Database is opened in readonly mode. Skip migration and demo data setup.")
 	} else {
@@ -266,7 +319,11 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	}
 	s.store = storeInstance
 
-	s.stateCfg, err = state.New()
+	if err := initSecretEnvelope(ctx, storeInstance, &profile.KMS); err != nil {
+		return nil, err
+	}
+
+	s.stateCfg, err = state.New(s.store, profile.HA)
 	if err != nil {
 		return nil, err
 	}
@@ -287,7 +344,8 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		return nil, errors.Wrap(err, "failed to init config")
 	}
 	s.secret = secret
-	s.activityManager = activity.NewManager(storeInstance)
+	s.activityManager = activity.NewManager(storeInstance, s.stateCfg)
+	s.sqlSessionManager = sqlsession.NewManager(storeInstance)
 	s.dbFactory = dbfactory.New(s.mysqlBinDir, s.mongoBinDir, s.pgBinDir, profile.DataDir, s.secret)
 
 	// Configure echo server.
@@ -298,27 +356,25 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	gatewayModifier := auth.GatewayResponseModifier{ExternalURL: externalURL, TokenDuration: tokenDuration}
 	mux := grpcRuntime.NewServeMux(grpcRuntime.WithForwardResponseOption(gatewayModifier.Modify))
 
-	if profile.BackupBucket != "" {
-		credentials, err := bbs3.GetCredentialsFromFile(ctx, profile.BackupCredentialFile)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get credentials from file")
-		}
-		s3Client, err := bbs3.NewClient(ctx, profile.BackupRegion, profile.BackupBucket, credentials)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to create AWS S3 client")
-		}
-		s.s3Client = s3Client
+	s.storageFactory = storagefactory.New(storeInstance, &s.profile)
+	// Fail fast if the default backup storage is misconfigured.
+	if _, err := s.storageFactory.GetStorage(ctx, s.storageFactory.GetDefaultBackupStorage()); err != nil {
+		return nil, errors.Wrap(err, "failed to create the default backup storage")
 	}
+	s.exportJobManager = exportjob.NewManager(s.store, s.storageFactory, s.secret)
 
 	s.metricReporter = metricreport.NewReporter(s.store, s.licenseService, &s.profile, false)
 	s.schemaSyncer = schemasync.NewSyncer(storeInstance, s.dbFactory, s.stateCfg, profile, s.licenseService)
 	if !profile.Readonly {
 		s.slowQuerySyncer = slowquerysync.NewSyncer(storeInstance, s.dbFactory, s.stateCfg, profile)
-		s.backupRunner = backuprun.NewRunner(storeInstance, s.dbFactory, s.s3Client, s.stateCfg, &profile)
+		s.backupRunner = backuprun.NewRunner(storeInstance, s.dbFactory, s.storageFactory, s.stateCfg, &profile)
 		s.rollbackRunner = rollbackrun.NewRunner(&profile, storeInstance, s.dbFactory, s.stateCfg)
 		s.mailSender = mail.NewSender(s.store, s.stateCfg)
 		s.relayRunner = relay.NewRunner(storeInstance, s.activityManager, s.stateCfg)
 		s.approvalRunner = approval.NewRunner(storeInstance, s.dbFactory, s.stateCfg, s.activityManager, s.relayRunner, s.licenseService)
+		s.heartbeatRunner = heartbeat.NewRunner(storeInstance, s.stateCfg)
+		s.webhookRunner = webhookrun.NewRunner(storeInstance, s.activityManager)
+		s.dataKeyRunner = datakeyrun.NewRunner(storeInstance, s.stateCfg)
 
 		s.taskSchedulerV2 = taskrun.NewSchedulerV2(storeInstance, s.stateCfg, s.activityManager)
 		s.taskSchedulerV2.Register(api.TaskGeneral, taskrun.NewDefaultExecutor())
@@ -327,10 +383,10 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdate, taskrun.NewSchemaUpdateExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, s.schemaSyncer, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdateSDL, taskrun.NewSchemaUpdateSDLExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, s.schemaSyncer, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseDataUpdate, taskrun.NewDataUpdateExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, profile))
-		s.taskSchedulerV2.Register(api.TaskDatabaseBackup, taskrun.NewDatabaseBackupExecutor(storeInstance, s.dbFactory, s.s3Client, profile))
+		s.taskSchedulerV2.Register(api.TaskDatabaseBackup, taskrun.NewDatabaseBackupExecutor(storeInstance, s.dbFactory, s.storageFactory, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdateGhostSync, taskrun.NewSchemaUpdateGhostSyncExecutor(storeInstance, s.stateCfg, s.secret))
 		s.taskSchedulerV2.Register(api.TaskDatabaseSchemaUpdateGhostCutover, taskrun.NewSchemaUpdateGhostCutoverExecutor(storeInstance, s.dbFactory, s.activityManager, s.licenseService, s.stateCfg, s.schemaSyncer, profile))
-		s.taskSchedulerV2.Register(api.TaskDatabaseRestorePITRRestore, taskrun.NewPITRRestoreExecutor(storeInstance, s.dbFactory, s.s3Client, s.schemaSyncer, s.stateCfg, profile))
+		s.taskSchedulerV2.Register(api.TaskDatabaseRestorePITRRestore, taskrun.NewPITRRestoreExecutor(storeInstance, s.dbFactory, s.storageFactory, s.schemaSyncer, s.stateCfg, profile))
 		s.taskSchedulerV2.Register(api.TaskDatabaseRestorePITRCutover, taskrun.NewPITRCutoverExecutor(storeInstance, s.dbFactory, s.schemaSyncer, s.backupRunner, s.activityManager, profile))
 
 		s.planCheckScheduler = plancheck.NewScheduler(storeInstance, s.licenseService, s.stateCfg)
@@ -346,6 +402,8 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		s.planCheckScheduler.Register(store.PlanCheckDatabasePITRMySQL, pitrMySQLExecutor)
 		statementReportExecutor := plancheck.NewStatementReportExecutor(storeInstance, s.dbFactory)
 		s.planCheckScheduler.Register(store.PlanCheckDatabaseStatementSummaryReport, statementReportExecutor)
+		statementLockImpactExecutor := plancheck.NewStatementLockImpactExecutor(storeInstance, s.dbFactory)
+		s.planCheckScheduler.Register(store.PlanCheckDatabaseStatementLockImpact, statementLockImpactExecutor)
 
 		// Metric reporter
 		s.initMetricReporter()
@@ -398,7 +456,7 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 		}
 		return nil
 	}
-	rolloutService, issueService, err := configureGrpcRouters(ctx, mux, s.grpcServer, s.store, s.dbFactory, s.licenseService, &s.profile, s.metricReporter, s.stateCfg, s.schemaSyncer, s.activityManager, s.backupRunner, s.relayRunner, s.planCheckScheduler, postCreateUser, s.secret, &s.errorRecordRing, tokenDuration)
+	rolloutService, issueService, err := configureGrpcRouters(ctx, mux, s.grpcServer, s.store, s.dbFactory, s.licenseService, &s.profile, s.metricReporter, s.stateCfg, s.schemaSyncer, s.activityManager, s.backupRunner, s.relayRunner, s.planCheckScheduler, s.sqlSessionManager, s.exportJobManager, postCreateUser, s.secret, &s.errorRecordRing, tokenDuration)
 	if err != nil {
 		return nil, err
 	}
@@ -409,6 +467,13 @@ func NewServer(ctx context.Context, profile config.Profile) (*Server, error) {
 	gitOpsService.RegisterWebhookRoutes(webhookGroup)
 	apiGroup := s.e.Group(internalAPIPrefix)
 	s.registerDatabaseRoutes(apiGroup)
+	samlGroup := s.e.Group(samlPrefix)
+	s.registerSAMLRoutes(samlGroup)
+	scimGroup := s.e.Group(scimAPIPrefix)
+	scimService := scim.NewService(s.store, s.licenseService)
+	scimService.RegisterRoutes(scimGroup)
+	exportGroup := s.e.Group(exportPrefix)
+	s.registerExportJobRoutes(exportGroup)
 
 	reflection.Register(s.grpcServer)
 
@@ -421,6 +486,14 @@ func (s *Server) Run(ctx context.Context, port int) error {
 	ctx, cancel := context.WithCancel(ctx)
 	s.cancel = cancel
 	if !s.profile.Readonly {
+		if s.profile.HA {
+			// Send the first heartbeat before starting the schedulers so that the leases acquired by them are not reclaimed.
+			if err := s.heartbeatRunner.Beat(ctx); err != nil {
+				return errors.Wrap(err, "failed to send the first heartbeat")
+			}
+			s.runnerWG.Add(1)
+			go s.heartbeatRunner.Run(ctx, &s.runnerWG)
+		}
 		// runnerWG waits for all goroutines to complete.
 		if err := s.taskSchedulerV2.ClearRunningTaskRuns(ctx); err != nil {
 			return errors.Wrap(err, "failed to clear existing RUNNING tasks before starting the task scheduler")
@@ -441,6 +514,10 @@ func (s *Server) Run(ctx context.Context, port int) error {
 		go s.approvalRunner.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
 		go s.relayRunner.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
+		go s.webhookRunner.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
+		go s.dataKeyRunner.Run(ctx, &s.runnerWG)
 
 		s.runnerWG.Add(1)
 		go s.metricReporter.Run(ctx, &s.runnerWG)
@@ -448,6 +525,11 @@ func (s *Server) Run(ctx context.Context, port int) error {
 		s.runnerWG.Add(1)
 		go s.planCheckScheduler.Run(ctx, &s.runnerWG)
 	}
+	// SQL Editor sessions and export jobs are served by the read-only replicas as well.
+	s.runnerWG.Add(1)
+	go s.sqlSessionManager.Run(ctx, &s.runnerWG)
+	s.runnerWG.Add(1)
+	go s.exportJobManager.Run(ctx, &s.runnerWG)
 
 	listen, err := net.Listen("tcp", fmt.Sprintf(":%d", port+1))
 	if err != nil {
@@ -0,0 +1,249 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+)
+
+// AccessTokenMessage is the message for a scoped personal access token.
+type AccessTokenMessage struct {
+	// PrincipalUID is the unique ID of the user owning the token.
+	PrincipalUID int
+	// Title is the title of the token.
+	Title string
+	// ProjectList is the list of project resource IDs the token is restricted to.
+	// Empty means all projects.
+	ProjectList []string
+	// PermissionList is the list of permissions the token is restricted to, such as "rollout:run".
+	// Empty means all permissions.
+	PermissionList []string
+	// ExpireTs is the expiration time of the token in unix seconds.
+	ExpireTs int64
+
+	// Output only fields.
+	//
+	// UID is the unique ID of the token.
+	UID int
+	// CreatedTs is the creation time of the token in unix seconds.
+	CreatedTs int64
+	// LastUsedTs is the time the token was last used in unix seconds, zero if never used.
+	LastUsedTs int64
+	// Revoked is true if the token has been revoked.
+	Revoked bool
+}
+
+// FindAccessTokenMessage is the message for finding access tokens.
+type FindAccessTokenMessage struct {
+	UID          *int
+	PrincipalUID *int
+	ShowRevoked  bool
+}
+
+// CreateAccessToken creates a new access token.
+func (s *Store) CreateAccessToken(ctx context.Context, create *AccessTokenMessage, creatorUID int) (*AccessTokenMessage, error) {
+	query := `
+		INSERT INTO access_token (
+			creator_id,
+			updater_id,
+			principal_id,
+			title,
+			project_list,
+			permission_list,
+			expire_ts
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_ts
+	`
+	projectList := create.ProjectList
+	if projectList == nil {
+		projectList = []string{}
+	}
+	permissionList := create.PermissionList
+	if permissionList == nil {
+		permissionList = []string{}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	accessToken := &AccessTokenMessage{
+		PrincipalUID:   create.PrincipalUID,
+		Title:          create.Title,
+		ProjectList:    projectList,
+		PermissionList: permissionList,
+		ExpireTs:       create.ExpireTs,
+	}
+	if err := tx.QueryRowContext(ctx, query,
+		creatorUID,
+		creatorUID,
+		create.PrincipalUID,
+		create.Title,
+		projectList,
+		permissionList,
+		create.ExpireTs,
+	).Scan(
+		&accessToken.UID,
+		&accessToken.CreatedTs,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, errors.Wrapf(err, "failed to query row")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+
+	return accessToken, nil
+}
+
+// GetAccessToken gets an access token.
+func (s *Store) GetAccessToken(ctx context.Context, find *FindAccessTokenMessage) (*AccessTokenMessage, error) {
+	accessTokens, err := s.ListAccessTokens(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(accessTokens) == 0 {
+		return nil, nil
+	}
+	if len(accessTokens) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d access tokens with filter %+v, expect 1", len(accessTokens), find)}
+	}
+	return accessTokens[0], nil
+}
+
+// ListAccessTokens lists access tokens.
+func (s *Store) ListAccessTokens(ctx context.Context, find *FindAccessTokenMessage) ([]*AccessTokenMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.PrincipalUID; v != nil {
+		where, args = append(where, fmt.Sprintf("principal_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if !find.ShowRevoked {
+		where, args = append(where, fmt.Sprintf("row_status = $%d", len(args)+1)), append(args, api.Normal)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			row_status,
+			principal_id,
+			created_ts,
+			title,
+			project_list,
+			permission_list,
+			expire_ts,
+			last_used_ts
+		FROM access_token
+		WHERE %s
+		ORDER BY id`, strings.Join(where, " AND "))
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query access tokens")
+	}
+	defer rows.Close()
+
+	var accessTokens []*AccessTokenMessage
+	for rows.Next() {
+		var accessToken AccessTokenMessage
+		var rowStatus string
+		var projectList, permissionList pgtype.TextArray
+		if err := rows.Scan(
+			&accessToken.UID,
+			&rowStatus,
+			&accessToken.PrincipalUID,
+			&accessToken.CreatedTs,
+			&accessToken.Title,
+			&projectList,
+			&permissionList,
+			&accessToken.ExpireTs,
+			&accessToken.LastUsedTs,
+		); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan access token")
+		}
+		if err := projectList.AssignTo(&accessToken.ProjectList); err != nil {
+			return nil, err
+		}
+		if err := permissionList.AssignTo(&accessToken.PermissionList); err != nil {
+			return nil, err
+		}
+		accessToken.Revoked = api.RowStatus(rowStatus) == api.Archived
+		accessTokens = append(accessTokens, &accessToken)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate access tokens")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+
+	return accessTokens, nil
+}
+
+// RevokeAccessToken revokes an access token.
+func (s *Store) RevokeAccessToken(ctx context.Context, uid int, updaterUID int) error {
+	query := `
+		UPDATE access_token
+		SET row_status = $1, updater_id = $2
+		WHERE id = $3
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, api.Archived, updaterUID, uid)
+	if err != nil {
+		return errors.Wrapf(err, "failed to revoke access token %d", uid)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return &common.Error{Code: common.NotFound, Err: errors.Errorf("access token not found: %d", uid)}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// UpdateAccessTokenLastUsedTs updates the last used time of an access token.
+func (s *Store) UpdateAccessTokenLastUsedTs(ctx context.Context, uid int, lastUsedTs int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE access_token SET last_used_ts = $1 WHERE id = $2`, lastUsedTs, uid); err != nil {
+		return errors.Wrapf(err, "failed to update last used time of access token %d", uid)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	return nil
+}
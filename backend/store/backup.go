@@ -136,6 +136,8 @@ type BackupMessage struct {
 	MigrationHistoryVersion string
 	// Path is the path of the backup file.
 	Path string
+	// Payload is the payload of the backup.
+	Payload api.BackupPayload
 
 	// Output only fields.
 	//
@@ -149,8 +151,6 @@ type BackupMessage struct {
 	RowStatus api.RowStatus
 	// DatabaseUID is the UID of the database.
 	DatabaseUID int
-	// Payload is the payload of the backup.
-	Payload api.BackupPayload
 }
 
 // SLogBackupArray is a helper to format []*BackupMessage.
@@ -343,6 +343,10 @@ func (s *Store) CreateBackupV2(ctx context.Context, create *BackupMessage, datab
 		return nil, errors.Wrapf(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
+	payload, err := json.Marshal(create.Payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal backup payload")
+	}
 	query := `
 		INSERT INTO backup (
 			creator_id,
@@ -354,12 +358,13 @@ func (s *Store) CreateBackupV2(ctx context.Context, create *BackupMessage, datab
 			storage_backend,
 			migration_history_version,
 			path,
-			comment
+			comment,
+			payload
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING id, row_status, name, storage_backend, migration_history_version, path, created_ts, updated_ts, status, type, comment, database_id
 	`
-	var backup BackupMessage
+	backup := BackupMessage{Payload: create.Payload}
 	if err := tx.QueryRowContext(ctx, query,
 		principalUID,
 		principalUID,
@@ -371,6 +376,7 @@ func (s *Store) CreateBackupV2(ctx context.Context, create *BackupMessage, datab
 		create.MigrationHistoryVersion,
 		create.Path,
 		create.Comment,
+		payload,
 	).Scan(
 		&backup.UID,
 		&backup.RowStatus,
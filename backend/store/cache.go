@@ -0,0 +1,67 @@
+package store
+
+import (
+	"sync"
+)
+
+// storeCache is an in-memory cache of the store, which can be bypassed.
+// The replicas in high-availability mode don't invalidate the caches of each other,
+// so the caches are bypassed there and every replica reads the latest data, e.g. a deactivated user or an updated IAM policy, from the database.
+type storeCache struct {
+	sync.Map
+	bypass bool
+}
+
+// Load returns the value stored in the cache for a key, or false if the cache is bypassed.
+func (c *storeCache) Load(key any) (any, bool) {
+	if c.bypass {
+		return nil, false
+	}
+	return c.Map.Load(key)
+}
+
+// Store sets the value for a key unless the cache is bypassed.
+func (c *storeCache) Store(key, value any) {
+	if c.bypass {
+		return
+	}
+	c.Map.Store(key, value)
+}
+
+// BypassCache makes the store always read from the database instead of the in-memory caches.
+// It must be called before the store is used.
+func (s *Store) BypassCache() {
+	s.bypassCache = true
+	for _, c := range []*storeCache{
+		&s.userIDCache,
+		&s.environmentCache,
+		&s.environmentIDCache,
+		&s.instanceCache,
+		&s.instanceIDCache,
+		&s.databaseCache,
+		&s.databaseIDCache,
+		&s.projectCache,
+		&s.projectIDCache,
+		&s.projectPolicyCache,
+		&s.projectIDPolicyCache,
+		&s.policyCache,
+		&s.issueCache,
+		&s.issueByPipelineCache,
+		&s.pipelineCache,
+		&s.settingCache,
+		&s.idpCache,
+		&s.projectIDDeploymentConfigCache,
+		&s.risksCache,
+		&s.databaseGroupCache,
+		&s.databaseGroupIDCache,
+		&s.schemaGroupCache,
+		&s.vcsIDCache,
+	} {
+		c.bypass = true
+	}
+}
+
+// clear removes all the entries from the cache.
+func (c *storeCache) clear() {
+	c.Map = sync.Map{}
+}
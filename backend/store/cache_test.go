@@ -0,0 +1,29 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreCacheBypass(t *testing.T) {
+	a := require.New(t)
+	s := &Store{}
+	s.userIDCache.Store(1, "u1")
+	v, ok := s.userIDCache.Load(1)
+	a.True(ok)
+	a.Equal("u1", v)
+
+	s.BypassCache()
+	_, ok = s.userIDCache.Load(1)
+	a.False(ok)
+	s.userIDCache.Store(2, "u2")
+	_, ok = s.userIDCache.Load(2)
+	a.False(ok)
+
+	// Clearing the cache keeps it bypassed.
+	s.userIDCache.clear()
+	s.userIDCache.Store(3, "u3")
+	_, ok = s.userIDCache.Load(3)
+	a.False(ok)
+}
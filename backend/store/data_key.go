@@ -0,0 +1,469 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/kms"
+)
+
+// secretField is a field storing a secret, which is encrypted with the envelope if the key provider is configured.
+type secretField struct {
+	table string
+	// keyColumn is the column identifying the row.
+	keyColumn string
+	// column is the column of the secret, or the JSONB column containing the secret.
+	column string
+	// jsonPath is the path of the secret in the JSONB column.
+	jsonPath []string
+	// filter is the optional condition of the rows storing the secret.
+	filter string
+}
+
+// The credential and encryption key files of the backup storages are kept in the data directory
+// and only referenced by name in the backup plan policies, so they are not stored in the metadata database.
+var secretFields = []*secretField{
+	{table: "data_source", keyColumn: "id", column: "password"},
+	{table: "data_source", keyColumn: "id", column: "ssl_key"},
+	{table: "data_source", keyColumn: "id", column: "ssl_cert"},
+	{table: "data_source", keyColumn: "id", column: "ssl_ca"},
+	{table: "data_source", keyColumn: "id", column: "options", jsonPath: []string{"sshObfuscatedPassword"}},
+	{table: "data_source", keyColumn: "id", column: "options", jsonPath: []string{"sshObfuscatedPrivateKey"}},
+	{table: "data_source", keyColumn: "id", column: "options", jsonPath: []string{"externalSecret", "obfuscatedToken"}},
+	{table: "data_source", keyColumn: "id", column: "options", jsonPath: []string{"externalSecret", "obfuscatedAppRoleSecretId"}},
+	{table: "repository", keyColumn: "id", column: "access_token"},
+	{table: "repository", keyColumn: "id", column: "refresh_token"},
+	{table: "vcs", keyColumn: "id", column: "secret"},
+	{table: "project_webhook", keyColumn: "id", column: "signing_secret"},
+	{table: "idp", keyColumn: "id", column: "config", jsonPath: []string{"clientSecret"}, filter: "type IN ('OAUTH2', 'OIDC')"},
+	{table: "idp", keyColumn: "id", column: "config", jsonPath: []string{"bindPassword"}, filter: "type = 'LDAP'"},
+	{table: "idp", keyColumn: "id", column: "config", jsonPath: []string{"spPrivateKey"}, filter: "type = 'SAML'"},
+	{table: "setting", keyColumn: "name", column: "value", filter: fmt.Sprintf("name = '%s'", api.SettingPluginOpenAIKey)},
+	{table: "setting", keyColumn: "name", column: "value", filter: fmt.Sprintf("name = '%s'", api.SettingWorkspaceMailDelivery)},
+	{table: "setting", keyColumn: "name", column: "value", filter: fmt.Sprintf("name = '%s'", api.SettingSCIMToken)},
+}
+
+// secretSettings are the settings whose values are secrets.
+// The mail delivery setting is encrypted as a whole for the SMTP password in it.
+var secretSettings = map[api.SettingName]bool{
+	api.SettingPluginOpenAIKey:       true,
+	api.SettingWorkspaceMailDelivery: true,
+	api.SettingSCIMToken:             true,
+}
+
+type dataKeyMessage struct {
+	id         int
+	provider   kms.Provider
+	wrappedKey string
+	active     bool
+	// deactivatedTs is when the data key was replaced by another active data key.
+	deactivatedTs int64
+}
+
+// InitSecretEnvelope sets up the envelope encryption of the secrets with the key provider.
+// The active data key wrapped by the key provider is created if there is none.
+// Secrets written before are encrypted in the background by ReencryptSecrets.
+func (s *Store) InitSecretEnvelope(ctx context.Context, provider kms.Provider, keyProvider kms.KeyProvider) error {
+	dataKeys, err := s.listDataKeys(ctx)
+	if err != nil {
+		return err
+	}
+	envelope := kms.NewEnvelope(keyProvider)
+	for _, dataKey := range dataKeys {
+		if err := addDataKey(ctx, envelope, provider, dataKey); err != nil {
+			return err
+		}
+	}
+	if envelope.ActiveDataKeyID() == 0 {
+		dataKey, err := s.createActiveDataKey(ctx, provider, envelope)
+		if err != nil {
+			return err
+		}
+		if err := addDataKey(ctx, envelope, provider, dataKey); err != nil {
+			return err
+		}
+	}
+
+	s.keyProvider = keyProvider
+	s.keyProviderType = provider
+	s.secretEnvelope = envelope
+	return nil
+}
+
+// HasSecretEnvelope returns whether the secrets are encrypted with the key provider.
+func (s *Store) HasSecretEnvelope() bool {
+	return s.secretEnvelope != nil
+}
+
+// RefreshDataKeys adds the data keys created by other replicas or the rotate-key command to the envelope,
+// and switches to the active data key in the metadata database.
+func (s *Store) RefreshDataKeys(ctx context.Context) error {
+	if s.secretEnvelope == nil {
+		return nil
+	}
+	s.dataKeyMu.Lock()
+	defer s.dataKeyMu.Unlock()
+
+	dataKeys, err := s.listDataKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for _, dataKey := range dataKeys {
+		if s.secretEnvelope.HasDataKey(dataKey.id) {
+			if dataKey.active {
+				if err := s.secretEnvelope.SetActiveDataKey(dataKey.id); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := addDataKey(ctx, s.secretEnvelope, s.keyProviderType, dataKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RotateDataKey generates a new active data key and re-encrypts all the secrets with it.
+// The secrets stored before the key provider was configured are encrypted as well.
+// The servers keep running during the rotation: they switch to the new data key on RefreshDataKeys,
+// and the secrets they write with the old data key in the meantime are re-encrypted by ReencryptSecrets later.
+// The old data keys are deleted by DeleteUnusedDataKeys once no secrets are encrypted with them.
+// It returns the number of the re-encrypted secrets.
+func (s *Store) RotateDataKey(ctx context.Context) (int, error) {
+	if s.secretEnvelope == nil {
+		return 0, errors.New("key provider is not configured")
+	}
+	wrappedKey, err := s.secretEnvelope.GenerateDataKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE data_key SET active = FALSE, deactivated_ts = extract(epoch from now())
+		WHERE active
+	`); err != nil {
+		return 0, errors.Wrap(err, "failed to deactivate data key")
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO data_key (provider, wrapped_key, active)
+		VALUES ($1, $2, TRUE)
+	`, s.keyProviderType, base64.StdEncoding.EncodeToString(wrappedKey)); err != nil {
+		return 0, errors.Wrap(err, "failed to create data key")
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	if err := s.RefreshDataKeys(ctx); err != nil {
+		return 0, err
+	}
+	return s.ReencryptSecrets(ctx)
+}
+
+// ReencryptSecrets encrypts the secrets which are not encrypted with the active data key, including the ones stored in plaintext.
+// Each secret is only updated if it is unchanged since it was read, so the concurrent updates by other replicas are kept.
+// It returns the number of the re-encrypted secrets.
+func (s *Store) ReencryptSecrets(ctx context.Context) (int, error) {
+	if s.secretEnvelope == nil {
+		return 0, errors.New("key provider is not configured")
+	}
+	activeID := s.secretEnvelope.ActiveDataKeyID()
+	count := 0
+	for _, field := range secretFields {
+		n, err := s.reencryptSecretField(ctx, field, activeID)
+		if err != nil {
+			return count, errors.Wrapf(err, "failed to re-encrypt %s.%s", field.table, field.column)
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// DeleteUnusedDataKeys deletes the inactive data keys deactivated before the timestamp which no secrets are encrypted with.
+// The timestamp leaves time for the replicas to switch to the active data key,
+// so that no secrets are encrypted with the deleted data keys afterwards.
+// It returns the number of the deleted data keys.
+func (s *Store) DeleteUnusedDataKeys(ctx context.Context, deactivatedBeforeTs int64) (int, error) {
+	dataKeys, err := s.listDataKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, dataKey := range dataKeys {
+		if dataKey.active || dataKey.deactivatedTs >= deactivatedBeforeTs {
+			continue
+		}
+		used, err := s.isDataKeyUsed(ctx, dataKey.id)
+		if err != nil {
+			return count, err
+		}
+		if used {
+			continue
+		}
+		if _, err := s.db.db.ExecContext(ctx, `DELETE FROM data_key WHERE id = $1 AND NOT active`, dataKey.id); err != nil {
+			return count, errors.Wrapf(err, "failed to delete data key %d", dataKey.id)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// RewrapDataKeys re-wraps the data keys wrapped by the previous key provider with the key provider,
+// so that the key provider can be switched without re-encrypting the secrets.
+// It must be called before InitSecretEnvelope with the key provider.
+// It returns the number of the re-wrapped data keys.
+func (s *Store) RewrapDataKeys(ctx context.Context, previousProvider kms.Provider, previousKeyProvider kms.KeyProvider, provider kms.Provider, keyProvider kms.KeyProvider) (int, error) {
+	dataKeys, err := s.listDataKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, dataKey := range dataKeys {
+		if dataKey.provider != previousProvider {
+			continue
+		}
+		wrappedKey, err := base64.StdEncoding.DecodeString(dataKey.wrappedKey)
+		if err != nil {
+			return count, errors.Wrapf(err, "failed to decode data key %d", dataKey.id)
+		}
+		plainKey, err := previousKeyProvider.Unwrap(ctx, wrappedKey)
+		if err != nil {
+			return count, errors.Wrapf(err, "failed to unwrap data key %d with the %s key provider", dataKey.id, previousProvider)
+		}
+		rewrappedKey, err := keyProvider.Wrap(ctx, plainKey)
+		if err != nil {
+			return count, errors.Wrapf(err, "failed to wrap data key %d with the %s key provider", dataKey.id, provider)
+		}
+		if _, err := s.db.db.ExecContext(ctx, `
+			UPDATE data_key SET provider = $1, wrapped_key = $2
+			WHERE id = $3 AND provider = $4
+		`, provider, base64.StdEncoding.EncodeToString(rewrappedKey), dataKey.id, previousProvider); err != nil {
+			return count, errors.Wrapf(err, "failed to update data key %d", dataKey.id)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// createActiveDataKey creates the active data key.
+// In HA mode, another replica may have created it concurrently, and the one created by that replica is returned.
+func (s *Store) createActiveDataKey(ctx context.Context, provider kms.Provider, envelope *kms.Envelope) (*dataKeyMessage, error) {
+	wrappedKey, err := envelope.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dataKey := &dataKeyMessage{provider: provider, wrappedKey: base64.StdEncoding.EncodeToString(wrappedKey), active: true}
+	if err := s.db.db.QueryRowContext(ctx, `
+		INSERT INTO data_key (provider, wrapped_key, active)
+		VALUES ($1, $2, TRUE)
+		ON CONFLICT (active) WHERE active DO NOTHING
+		RETURNING id
+	`, dataKey.provider, dataKey.wrappedKey).Scan(&dataKey.id); err != nil {
+		if err != sql.ErrNoRows {
+			return nil, errors.Wrap(err, "failed to create data key")
+		}
+		dataKeys, err := s.listDataKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, dataKey := range dataKeys {
+			if dataKey.active {
+				return dataKey, nil
+			}
+		}
+		return nil, errors.New("failed to find the active data key")
+	}
+	return dataKey, nil
+}
+
+// getSecretFieldValue returns the expression of the secret and the condition of the rows storing it.
+func getSecretFieldValue(field *secretField) (string, string) {
+	value := field.column
+	if len(field.jsonPath) > 0 {
+		value = fmt.Sprintf("COALESCE(%s#>>'{%s}', '')", field.column, strings.Join(field.jsonPath, ","))
+	}
+	where := fmt.Sprintf("%s <> ''", value)
+	if field.filter != "" {
+		where = fmt.Sprintf("%s AND %s", where, field.filter)
+	}
+	return value, where
+}
+
+func (s *Store) reencryptSecretField(ctx context.Context, field *secretField, activeID int) (int, error) {
+	value, where := getSecretFieldValue(field)
+	rows, err := s.db.db.QueryContext(ctx, fmt.Sprintf(`SELECT %s::TEXT, %s FROM %s WHERE %s AND %s NOT LIKE $1`, field.keyColumn, value, field.table, where, value), kms.EncryptedPrefix(activeID)+"%")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	secrets := make(map[string]string)
+	for rows.Next() {
+		var key, secret string
+		if err := rows.Scan(&key, &secret); err != nil {
+			return 0, err
+		}
+		secrets[key] = secret
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	set := fmt.Sprintf("%s = $1", field.column)
+	if len(field.jsonPath) > 0 {
+		set = fmt.Sprintf("%s = jsonb_set(%s, '{%s}', to_jsonb($1::TEXT))", field.column, field.column, strings.Join(field.jsonPath, ","))
+	}
+	count := 0
+	for key, secret := range secrets {
+		plaintext, err := s.decryptSecret(ctx, secret)
+		if err != nil {
+			return count, err
+		}
+		encrypted, err := s.encryptSecret(plaintext)
+		if err != nil {
+			return count, err
+		}
+		result, err := s.db.db.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET %s WHERE %s::TEXT = $2 AND %s = $3`, field.table, set, field.keyColumn, value), encrypted, key, secret)
+		if err != nil {
+			return count, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return count, err
+		}
+		count += int(n)
+	}
+	return count, nil
+}
+
+// isDataKeyUsed returns whether any secret is encrypted with the data key.
+func (s *Store) isDataKeyUsed(ctx context.Context, id int) (bool, error) {
+	for _, field := range secretFields {
+		value, where := getSecretFieldValue(field)
+		var used bool
+		if err := s.db.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE %s AND %s LIKE $1)`, field.table, where, value), kms.EncryptedPrefix(id)+"%").Scan(&used); err != nil {
+			return false, errors.Wrapf(err, "failed to check %s.%s", field.table, field.column)
+		}
+		if used {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *Store) listDataKeys(ctx context.Context) ([]*dataKeyMessage, error) {
+	rows, err := s.db.db.QueryContext(ctx, `
+		SELECT
+			id,
+			provider,
+			wrapped_key,
+			active,
+			deactivated_ts
+		FROM data_key
+		ORDER BY id`,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list data keys")
+	}
+	defer rows.Close()
+	var dataKeys []*dataKeyMessage
+	for rows.Next() {
+		var dataKey dataKeyMessage
+		if err := rows.Scan(
+			&dataKey.id,
+			&dataKey.provider,
+			&dataKey.wrappedKey,
+			&dataKey.active,
+			&dataKey.deactivatedTs,
+		); err != nil {
+			return nil, err
+		}
+		dataKeys = append(dataKeys, &dataKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return dataKeys, nil
+}
+
+func addDataKey(ctx context.Context, envelope *kms.Envelope, provider kms.Provider, dataKey *dataKeyMessage) error {
+	if dataKey.provider != provider {
+		return errors.Errorf("data key %d is wrapped by the %s key provider, but the %s key provider is configured, run the rotate-key command with the --previous-kms-* flags to switch the key provider", dataKey.id, dataKey.provider, provider)
+	}
+	wrappedKey, err := base64.StdEncoding.DecodeString(dataKey.wrappedKey)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decode data key %d", dataKey.id)
+	}
+	return envelope.AddDataKey(ctx, dataKey.id, wrappedKey, dataKey.active)
+}
+
+// encryptSecret encrypts the secret with the envelope, or returns the secret as is if the key provider is not configured.
+func (s *Store) encryptSecret(secret string) (string, error) {
+	if s.secretEnvelope == nil {
+		return secret, nil
+	}
+	encrypted, err := s.secretEnvelope.Encrypt(secret)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encrypt secret")
+	}
+	return encrypted, nil
+}
+
+// encryptSecrets encrypts the secrets in place.
+func (s *Store) encryptSecrets(secrets ...*string) error {
+	for _, secret := range secrets {
+		encrypted, err := s.encryptSecret(*secret)
+		if err != nil {
+			return err
+		}
+		*secret = encrypted
+	}
+	return nil
+}
+
+// decryptSecret decrypts the secret encrypted with the envelope.
+// The secret stored before the key provider was configured is returned as is.
+// The data keys are refreshed if the secret is encrypted with a data key created by another replica.
+func (s *Store) decryptSecret(ctx context.Context, secret string) (string, error) {
+	if !kms.IsEncrypted(secret) {
+		return secret, nil
+	}
+	if s.secretEnvelope == nil {
+		return "", errors.New("secret is encrypted but the key provider is not configured")
+	}
+	plaintext, err := s.secretEnvelope.Decrypt(secret)
+	if errors.Is(err, kms.ErrUnknownDataKey) {
+		if err := s.RefreshDataKeys(ctx); err != nil {
+			return "", errors.Wrap(err, "failed to refresh data keys")
+		}
+		plaintext, err = s.secretEnvelope.Decrypt(secret)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt secret")
+	}
+	return plaintext, nil
+}
+
+// decryptSecrets decrypts the secrets in place.
+func (s *Store) decryptSecrets(ctx context.Context, secrets ...*string) error {
+	for _, secret := range secrets {
+		plaintext, err := s.decryptSecret(ctx, *secret)
+		if err != nil {
+			return err
+		}
+		*secret = plaintext
+	}
+	return nil
+}
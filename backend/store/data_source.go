@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -35,6 +36,10 @@ type DataSourceMessage struct {
 	SSHUser                 string
 	SSHObfuscatedPassword   string
 	SSHObfuscatedPrivateKey string
+	// ExternalSecret is the external secret manager storing the credential of the data source.
+	ExternalSecret *storepb.DataSourceExternalSecret
+	// IAMAuthentication is the IAM database authentication generating the short-lived token as the password.
+	IAMAuthentication *storepb.DataSourceIAMAuthentication
 	// (deprecated) Output only.
 	UID int
 }
@@ -61,6 +66,8 @@ func (m *DataSourceMessage) Copy() *DataSourceMessage {
 		SSHUser:                 m.SSHUser,
 		SSHObfuscatedPassword:   m.SSHObfuscatedPassword,
 		SSHObfuscatedPrivateKey: m.SSHObfuscatedPrivateKey,
+		ExternalSecret:          m.ExternalSecret,
+		IAMAuthentication:       m.IAMAuthentication,
 		UID:                     m.UID,
 	}
 }
@@ -91,9 +98,13 @@ type UpdateDataSourceMessage struct {
 	SSHUser                 *string
 	SSHObfuscatedPassword   *string
 	SSHObfuscatedPrivateKey *string
+	ExternalSecret          *storepb.DataSourceExternalSecret
+	RemoveExternalSecret    bool
+	IAMAuthentication       *storepb.DataSourceIAMAuthentication
+	RemoveIAMAuthentication bool
 }
 
-func (*Store) listDataSourceV2(ctx context.Context, tx *Tx, instanceID string) ([]*DataSourceMessage, error) {
+func (s *Store) listDataSourceV2(ctx context.Context, tx *Tx, instanceID string) ([]*DataSourceMessage, error) {
 	var dataSourceMessages []*DataSourceMessage
 	rows, err := tx.QueryContext(ctx, `
 		SELECT
@@ -151,6 +162,23 @@ func (*Store) listDataSourceV2(ctx context.Context, tx *Tx, instanceID string) (
 		dataSourceMessage.SSHUser = dataSourceOptions.SshUser
 		dataSourceMessage.SSHObfuscatedPassword = dataSourceOptions.SshObfuscatedPassword
 		dataSourceMessage.SSHObfuscatedPrivateKey = dataSourceOptions.SshObfuscatedPrivateKey
+		dataSourceMessage.ExternalSecret = dataSourceOptions.ExternalSecret
+		dataSourceMessage.IAMAuthentication = dataSourceOptions.IamAuthentication
+		if err := s.decryptSecrets(ctx,
+			&dataSourceMessage.ObfuscatedPassword,
+			&dataSourceMessage.ObfuscatedSslKey,
+			&dataSourceMessage.ObfuscatedSslCert,
+			&dataSourceMessage.ObfuscatedSslCa,
+			&dataSourceMessage.SSHObfuscatedPassword,
+			&dataSourceMessage.SSHObfuscatedPrivateKey,
+		); err != nil {
+			return nil, err
+		}
+		if externalSecret := dataSourceMessage.ExternalSecret; externalSecret != nil {
+			if err := s.decryptSecrets(ctx, &externalSecret.ObfuscatedToken, &externalSecret.ObfuscatedAppRoleSecretId); err != nil {
+				return nil, err
+			}
+		}
 
 		dataSourceMessages = append(dataSourceMessages, &dataSourceMessage)
 	}
@@ -222,16 +250,32 @@ func (s *Store) UpdateDataSourceV2(ctx context.Context, patch *UpdateDataSourceM
 		set, args = append(set, fmt.Sprintf("username = $%d", len(args)+1)), append(args, *v)
 	}
 	if v := patch.ObfuscatedPassword; v != nil {
-		set, args = append(set, fmt.Sprintf("password = $%d", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return err
+		}
+		set, args = append(set, fmt.Sprintf("password = $%d", len(args)+1)), append(args, encrypted)
 	}
 	if v := patch.ObfuscatedSslKey; v != nil {
-		set, args = append(set, fmt.Sprintf("ssl_key = $%d", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return err
+		}
+		set, args = append(set, fmt.Sprintf("ssl_key = $%d", len(args)+1)), append(args, encrypted)
 	}
 	if v := patch.ObfuscatedSslCert; v != nil {
-		set, args = append(set, fmt.Sprintf("ssl_cert = $%d", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return err
+		}
+		set, args = append(set, fmt.Sprintf("ssl_cert = $%d", len(args)+1)), append(args, encrypted)
 	}
 	if v := patch.ObfuscatedSslCa; v != nil {
-		set, args = append(set, fmt.Sprintf("ssl_ca = $%d", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return err
+		}
+		set, args = append(set, fmt.Sprintf("ssl_ca = $%d", len(args)+1)), append(args, encrypted)
 	}
 	if v := patch.Host; v != nil {
 		set, args = append(set, fmt.Sprintf("host = $%d", len(args)+1)), append(args, *v)
@@ -268,10 +312,40 @@ func (s *Store) UpdateDataSourceV2(ctx context.Context, patch *UpdateDataSourceM
 		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('sshUser', to_jsonb($%d::TEXT))", len(args)+1)), append(args, *v)
 	}
 	if v := patch.SSHObfuscatedPassword; v != nil {
-		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('sshObfuscatedPassword', to_jsonb($%d::TEXT))", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return err
+		}
+		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('sshObfuscatedPassword', to_jsonb($%d::TEXT))", len(args)+1)), append(args, encrypted)
 	}
 	if v := patch.SSHObfuscatedPrivateKey; v != nil {
-		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('sshObfuscatedPrivateKey', to_jsonb($%d::TEXT))", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return err
+		}
+		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('sshObfuscatedPrivateKey', to_jsonb($%d::TEXT))", len(args)+1)), append(args, encrypted)
+	}
+	if v := patch.ExternalSecret; v != nil {
+		externalSecret, err := s.encryptExternalSecret(v)
+		if err != nil {
+			return err
+		}
+		protoBytes, err := protojson.Marshal(externalSecret)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal external secret")
+		}
+		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('externalSecret', $%d::JSONB)", len(args)+1)), append(args, protoBytes)
+	} else if patch.RemoveExternalSecret {
+		optionSet = append(optionSet, "jsonb_build_object('externalSecret', NULL)")
+	}
+	if v := patch.IAMAuthentication; v != nil {
+		protoBytes, err := protojson.Marshal(v)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal IAM authentication")
+		}
+		optionSet, args = append(optionSet, fmt.Sprintf("jsonb_build_object('iamAuthentication', $%d::JSONB)", len(args)+1)), append(args, protoBytes)
+	} else if patch.RemoveIAMAuthentication {
+		optionSet = append(optionSet, "jsonb_build_object('iamAuthentication', NULL)")
 	}
 	if len(optionSet) != 0 {
 		set = append(set, fmt.Sprintf(`options = options || %s`, strings.Join(optionSet, "||")))
@@ -309,7 +383,11 @@ func (s *Store) UpdateDataSourceV2(ctx context.Context, patch *UpdateDataSourceM
 	return nil
 }
 
-func (*Store) addDataSourceToInstanceImplV2(ctx context.Context, tx *Tx, instanceUID, creatorID int, dataSource *DataSourceMessage) error {
+func (s *Store) addDataSourceToInstanceImplV2(ctx context.Context, tx *Tx, instanceUID, creatorID int, dataSource *DataSourceMessage) error {
+	password, sslKey, sslCert, sslCa, sshPassword, sshPrivateKey := dataSource.ObfuscatedPassword, dataSource.ObfuscatedSslKey, dataSource.ObfuscatedSslCert, dataSource.ObfuscatedSslCa, dataSource.SSHObfuscatedPassword, dataSource.SSHObfuscatedPrivateKey
+	if err := s.encryptSecrets(&password, &sslKey, &sslCert, &sslCa, &sshPassword, &sshPrivateKey); err != nil {
+		return err
+	}
 	// We flatten the data source fields in DataSourceMessage, so we need to compose them in store layer before INSERT.
 	dataSourceOptions := storepb.DataSourceOptions{
 		Srv:                     dataSource.SRV,
@@ -319,8 +397,16 @@ func (*Store) addDataSourceToInstanceImplV2(ctx context.Context, tx *Tx, instanc
 		SshHost:                 dataSource.SSHHost,
 		SshPort:                 dataSource.SSHPort,
 		SshUser:                 dataSource.SSHUser,
-		SshObfuscatedPassword:   dataSource.SSHObfuscatedPassword,
-		SshObfuscatedPrivateKey: dataSource.SSHObfuscatedPrivateKey,
+		SshObfuscatedPassword:   sshPassword,
+		SshObfuscatedPrivateKey: sshPrivateKey,
+		IamAuthentication:       dataSource.IAMAuthentication,
+	}
+	if dataSource.ExternalSecret != nil {
+		externalSecret, err := s.encryptExternalSecret(dataSource.ExternalSecret)
+		if err != nil {
+			return err
+		}
+		dataSourceOptions.ExternalSecret = externalSecret
 	}
 	protoBytes, err := protojson.Marshal(&dataSourceOptions)
 	if err != nil {
@@ -346,8 +432,8 @@ func (*Store) addDataSourceToInstanceImplV2(ctx context.Context, tx *Tx, instanc
 		)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`, creatorID, creatorID, instanceUID, dataSource.ID,
-		dataSource.Type, dataSource.Username, dataSource.ObfuscatedPassword, dataSource.ObfuscatedSslKey,
-		dataSource.ObfuscatedSslCert, dataSource.ObfuscatedSslCa, dataSource.Host, dataSource.Port,
+		dataSource.Type, dataSource.Username, password, sslKey,
+		sslCert, sslCa, dataSource.Host, dataSource.Port,
 		protoBytes, dataSource.Database,
 	); err != nil {
 		return err
@@ -356,6 +442,18 @@ func (*Store) addDataSourceToInstanceImplV2(ctx context.Context, tx *Tx, instanc
 	return nil
 }
 
+// encryptExternalSecret returns a copy of the external secret with the secrets encrypted.
+func (s *Store) encryptExternalSecret(externalSecret *storepb.DataSourceExternalSecret) (*storepb.DataSourceExternalSecret, error) {
+	encrypted, ok := proto.Clone(externalSecret).(*storepb.DataSourceExternalSecret)
+	if !ok {
+		return nil, errors.New("failed to clone external secret")
+	}
+	if err := s.encryptSecrets(&encrypted.ObfuscatedToken, &encrypted.ObfuscatedAppRoleSecretId); err != nil {
+		return nil, err
+	}
+	return encrypted, nil
+}
+
 // clearDataSourceImpl deletes dataSources by instance id and database id.
 func (*Store) clearDataSourceImpl(ctx context.Context, tx *Tx, instanceID int) error {
 	if _, err := tx.ExecContext(ctx, `DELETE FROM data_source WHERE instance_id = $1`, instanceID); err != nil {
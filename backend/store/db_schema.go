@@ -124,6 +124,21 @@ func (s *DBSchema) CompactText() (string, error) {
 	return buf.String(), nil
 }
 
+// FindTable finds the table by name.
+func (s *DBSchema) FindTable(schemaName string, tableName string) *storepb.TableMetadata {
+	for _, schema := range s.Metadata.Schemas {
+		if schema.Name != schemaName {
+			continue
+		}
+		for _, table := range schema.Tables {
+			if table.Name == tableName {
+				return table
+			}
+		}
+	}
+	return nil
+}
+
 // FindIndex finds the index by name.
 func (s *DBSchema) FindIndex(schemaName string, tableName string, indexName string) *storepb.IndexMetadata {
 	for _, schema := range s.Metadata.Schemas {
@@ -151,7 +166,7 @@ func (s *Store) GetDBSchema(ctx context.Context, databaseID int) (*DBSchema, err
 		instanceCount++
 		return true
 	})
-	if s.dbSchemaCache.MaxCost() != 1_000_000 || instanceCount <= 10 {
+	if !s.bypassCache && (s.dbSchemaCache.MaxCost() != 1_000_000 || instanceCount <= 10) {
 		if dbSchema, ok := s.dbSchemaCache.Get(databaseID); ok {
 			return dbSchema.(*DBSchema), nil
 		}
@@ -49,6 +49,7 @@ func (s *Schedule) toAPIDeploymentSchedule() *api.DeploymentSchedule {
 			Name: d.Name,
 			Spec: &api.DeploymentSpec{
 				Selector: d.Spec.Selector.toAPILabelSelector(),
+				Waves:    d.Spec.Waves.toAPIDeploymentWaves(),
 			},
 		})
 	}
@@ -66,6 +67,29 @@ type Deployment struct {
 // DeploymentSpec is the message for deployment specification.
 type DeploymentSpec struct {
 	Selector *LabelSelector `json:"selector"`
+	// Waves splits the matched databases into canary waves. The matched databases roll out in a single stage if nil.
+	Waves *DeploymentWaves `json:"waves,omitempty"`
+}
+
+// DeploymentWaves is the message for the canary waves of a deployment.
+type DeploymentWaves struct {
+	// Percentages is the cumulative percentages of the matched databases rolled out in each wave, such as [1, 10, 100].
+	Percentages []int `json:"percentages"`
+	// MaxFailedTasks is the maximum number of failed tasks in a wave before the following waves are halted.
+	MaxFailedTasks int `json:"maxFailedTasks"`
+	// SoakSeconds is the time to wait after the tasks of a wave finish before the next wave starts.
+	SoakSeconds int64 `json:"soakSeconds"`
+}
+
+func (w *DeploymentWaves) toAPIDeploymentWaves() *api.DeploymentWaves {
+	if w == nil {
+		return nil
+	}
+	return &api.DeploymentWaves{
+		Percentages:    w.Percentages,
+		MaxFailedTasks: w.MaxFailedTasks,
+		SoakSeconds:    w.SoakSeconds,
+	}
 }
 
 // LabelSelector is the message for label selector.
@@ -83,6 +107,10 @@ func (ls *LabelSelector) toAPILabelSelector() *api.LabelSelector {
 			operatorTp = api.InOperatorType
 		case ExistsOperatorType:
 			operatorTp = api.ExistsOperatorType
+		case NotInOperatorType:
+			operatorTp = api.NotInOperatorType
+		case DoesNotExistOperatorType:
+			operatorTp = api.DoesNotExistOperatorType
 		}
 		labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, &api.LabelSelectorRequirement{
 			Key:      r.Key,
@@ -94,8 +122,7 @@ func (ls *LabelSelector) toAPILabelSelector() *api.LabelSelector {
 }
 
 // OperatorType is the type of label selector requirement operator.
-// Valid operators are In, Exists.
-// Note: NotIn and DoesNotExist are not supported initially.
+// Valid operators are In, Exists, NotIn and DoesNotExist.
 type OperatorType string
 
 const (
@@ -103,6 +130,10 @@ const (
 	InOperatorType OperatorType = "In"
 	// ExistsOperatorType is the operator type for Exists.
 	ExistsOperatorType OperatorType = "Exists"
+	// NotInOperatorType is the operator type for NotIn.
+	NotInOperatorType OperatorType = "NotIn"
+	// DoesNotExistOperatorType is the operator type for DoesNotExist.
+	DoesNotExistOperatorType OperatorType = "DoesNotExist"
 )
 
 // LabelSelectorRequirement is the message for label selector.
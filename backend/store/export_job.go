@@ -0,0 +1,290 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+)
+
+// ExportJobState is the state of an export job.
+type ExportJobState string
+
+const (
+	// ExportJobRunning is the state of a job writing the export file.
+	ExportJobRunning ExportJobState = "RUNNING"
+	// ExportJobDone is the state of a job whose export file can be downloaded.
+	ExportJobDone ExportJobState = "DONE"
+	// ExportJobFailed is the state of a failed or interrupted job.
+	ExportJobFailed ExportJobState = "FAILED"
+	// ExportJobCanceled is the state of a canceled job.
+	ExportJobCanceled ExportJobState = "CANCELED"
+)
+
+// ExportJobMessage is the message for an export job.
+type ExportJobMessage struct {
+	ID                 string
+	CreatorUID         int
+	InstanceID         string
+	ConnectionDatabase string
+	Statement          string
+	// Format and Compression are the names of the v1 API enums.
+	Format      string
+	Compression string
+	// Path is the path of the export file in the default backup storage.
+	Path      string
+	State     ExportJobState
+	Error     string
+	RowCount  int64
+	ByteCount int64
+
+	// Output only fields.
+	CreatedTs int64
+	// UpdatedTs is refreshed by the progress reports of the running job.
+	UpdatedTs  int64
+	FinishedTs int64
+}
+
+// FindExportJobMessage is the message for finding export jobs.
+type FindExportJobMessage struct {
+	ID         *string
+	CreatorUID *int
+	State      *ExportJobState
+}
+
+// UpdateExportJobMessage is the message for updating an export job.
+type UpdateExportJobMessage struct {
+	ID string
+	// ExpectedState makes the update conditional, the job is only updated if it's in the state.
+	ExpectedState *ExportJobState
+
+	State      *ExportJobState
+	Error      *string
+	RowCount   *int64
+	ByteCount  *int64
+	FinishedTs *int64
+}
+
+// CreateExportJob creates an export job.
+func (s *Store) CreateExportJob(ctx context.Context, create *ExportJobMessage) (*ExportJobMessage, error) {
+	query := `
+		INSERT INTO export_job (
+			id,
+			creator_id,
+			instance_id,
+			connection_database,
+			statement,
+			format,
+			compression,
+			path,
+			state
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_ts, updated_ts
+	`
+	job := &ExportJobMessage{
+		ID:                 create.ID,
+		CreatorUID:         create.CreatorUID,
+		InstanceID:         create.InstanceID,
+		ConnectionDatabase: create.ConnectionDatabase,
+		Statement:          create.Statement,
+		Format:             create.Format,
+		Compression:        create.Compression,
+		Path:               create.Path,
+		State:              ExportJobRunning,
+	}
+	if err := s.db.db.QueryRowContext(ctx, query,
+		create.ID,
+		create.CreatorUID,
+		create.InstanceID,
+		create.ConnectionDatabase,
+		create.Statement,
+		create.Format,
+		create.Compression,
+		create.Path,
+		ExportJobRunning,
+	).Scan(
+		&job.CreatedTs,
+		&job.UpdatedTs,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, errors.Wrapf(err, "failed to create export job")
+	}
+	return job, nil
+}
+
+// GetExportJob gets an export job.
+func (s *Store) GetExportJob(ctx context.Context, find *FindExportJobMessage) (*ExportJobMessage, error) {
+	jobs, err := s.ListExportJobs(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	if len(jobs) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d export jobs with filter %+v, expect 1", len(jobs), find)}
+	}
+	return jobs[0], nil
+}
+
+// ListExportJobs lists export jobs, the latest first.
+func (s *Store) ListExportJobs(ctx context.Context, find *FindExportJobMessage) ([]*ExportJobMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.CreatorUID; v != nil {
+		where, args = append(where, fmt.Sprintf("creator_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.State; v != nil {
+		where, args = append(where, fmt.Sprintf("state = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := s.db.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			%s
+		FROM export_job
+		WHERE %s
+		ORDER BY created_ts DESC`, exportJobColumns, strings.Join(where, " AND ")),
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query export jobs")
+	}
+	defer rows.Close()
+
+	var jobs []*ExportJobMessage
+	for rows.Next() {
+		job, err := scanExportJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate export jobs")
+	}
+	return jobs, nil
+}
+
+// UpdateExportJob updates an export job.
+// It returns nil if the job is not in the expected state.
+func (s *Store) UpdateExportJob(ctx context.Context, update *UpdateExportJobMessage) (*ExportJobMessage, error) {
+	set, args := []string{}, []any{}
+	if v := update.State; v != nil {
+		set, args = append(set, fmt.Sprintf("state = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.Error; v != nil {
+		set, args = append(set, fmt.Sprintf("error = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.RowCount; v != nil {
+		set, args = append(set, fmt.Sprintf("row_count = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.ByteCount; v != nil {
+		set, args = append(set, fmt.Sprintf("byte_count = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.FinishedTs; v != nil {
+		set, args = append(set, fmt.Sprintf("finished_ts = $%d", len(args)+1)), append(args, *v)
+	}
+	if len(set) == 0 {
+		// The progress report without changes still refreshes updated_ts.
+		set = append(set, "updated_ts = extract(epoch from now())")
+	}
+	where, args := []string{fmt.Sprintf("id = $%d", len(args)+1)}, append(args, update.ID)
+	if v := update.ExpectedState; v != nil {
+		where, args = append(where, fmt.Sprintf("state = $%d", len(args)+1)), append(args, *v)
+	}
+
+	row := s.db.db.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE export_job
+		SET %s
+		WHERE %s
+		RETURNING
+			%s
+	`, strings.Join(set, ", "), strings.Join(where, " AND "), exportJobColumns),
+		args...,
+	)
+	job, err := scanExportJob(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// FailInterruptedExportJobs fails the running export jobs without progress reports since the timestamp,
+// whose replicas have been stopped or lost the connection to the metadata database.
+func (s *Store) FailInterruptedExportJobs(ctx context.Context, updatedBeforeTs int64, message string) (int64, error) {
+	result, err := s.db.db.ExecContext(ctx, `
+		UPDATE export_job
+		SET state = $1, error = $2, finished_ts = extract(epoch from now())
+		WHERE state = $3 AND updated_ts < $4
+	`, ExportJobFailed, message, ExportJobRunning, updatedBeforeTs)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to fail interrupted export jobs")
+	}
+	return result.RowsAffected()
+}
+
+// DeleteFinishedExportJobs deletes the export jobs finished before the timestamp.
+func (s *Store) DeleteFinishedExportJobs(ctx context.Context, finishedBeforeTs int64) (int64, error) {
+	result, err := s.db.db.ExecContext(ctx, `
+		DELETE FROM export_job WHERE state <> $1 AND finished_ts < $2
+	`, ExportJobRunning, finishedBeforeTs)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete finished export jobs")
+	}
+	return result.RowsAffected()
+}
+
+const exportJobColumns = `id,
+			creator_id,
+			instance_id,
+			connection_database,
+			statement,
+			format,
+			compression,
+			path,
+			state,
+			error,
+			row_count,
+			byte_count,
+			created_ts,
+			updated_ts,
+			finished_ts`
+
+func scanExportJob(scanner interface{ Scan(dest ...any) error }) (*ExportJobMessage, error) {
+	var job ExportJobMessage
+	if err := scanner.Scan(
+		&job.ID,
+		&job.CreatorUID,
+		&job.InstanceID,
+		&job.ConnectionDatabase,
+		&job.Statement,
+		&job.Format,
+		&job.Compression,
+		&job.Path,
+		&job.State,
+		&job.Error,
+		&job.RowCount,
+		&job.ByteCount,
+		&job.CreatedTs,
+		&job.UpdatedTs,
+		&job.FinishedTs,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, errors.Wrapf(err, "failed to scan export job")
+	}
+	return &job, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/bytebase/bytebase/backend/common"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
@@ -25,7 +26,15 @@ type IdentityProviderMessage struct {
 	Deleted bool
 }
 
-func getConfigBytes(config *storepb.IdentityProviderConfig) ([]byte, error) {
+// getConfigBytes marshals the config with the secrets in it encrypted.
+func (s *Store) getConfigBytes(config *storepb.IdentityProviderConfig) ([]byte, error) {
+	config, ok := proto.Clone(config).(*storepb.IdentityProviderConfig)
+	if !ok {
+		return nil, errors.Errorf("failed to clone identity provider config")
+	}
+	if err := s.encryptSecrets(getIdentityProviderConfigSecrets(config)...); err != nil {
+		return nil, err
+	}
 	if v := config.GetOauth2Config(); v != nil {
 		configBytes, err := protojson.Marshal(v)
 		return configBytes, err
@@ -35,11 +44,28 @@ func getConfigBytes(config *storepb.IdentityProviderConfig) ([]byte, error) {
 	} else if v := config.GetLdapConfig(); v != nil {
 		configBytes, err := protojson.Marshal(v)
 		return configBytes, err
+	} else if v := config.GetSamlConfig(); v != nil {
+		configBytes, err := protojson.Marshal(v)
+		return configBytes, err
 	} else {
 		return nil, errors.Errorf("unexpected provider type")
 	}
 }
 
+// getIdentityProviderConfigSecrets returns the secrets in the config.
+func getIdentityProviderConfigSecrets(config *storepb.IdentityProviderConfig) []*string {
+	if v := config.GetOauth2Config(); v != nil {
+		return []*string{&v.ClientSecret}
+	} else if v := config.GetOidcConfig(); v != nil {
+		return []*string{&v.ClientSecret}
+	} else if v := config.GetLdapConfig(); v != nil {
+		return []*string{&v.BindPassword}
+	} else if v := config.GetSamlConfig(); v != nil {
+		return []*string{&v.SpPrivateKey}
+	}
+	return nil
+}
+
 // FindIdentityProviderMessage is the message for finding identity providers.
 type FindIdentityProviderMessage struct {
 	// We should only set either UID or ResourceID.
@@ -74,7 +100,7 @@ func (s *Store) CreateIdentityProvider(ctx context.Context, create *IdentityProv
 		Type:       create.Type,
 		Config:     create.Config,
 	}
-	configBytes, err := getConfigBytes(identityProvider.Config)
+	configBytes, err := s.getConfigBytes(identityProvider.Config)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal identity provider config")
 	}
@@ -188,7 +214,7 @@ func (s *Store) UpdateIdentityProvider(ctx context.Context, patch *UpdateIdentit
 	return identityProvider, nil
 }
 
-func (*Store) updateIdentityProviderImpl(ctx context.Context, tx *Tx, patch *UpdateIdentityProviderMessage) (*IdentityProviderMessage, error) {
+func (s *Store) updateIdentityProviderImpl(ctx context.Context, tx *Tx, patch *UpdateIdentityProviderMessage) (*IdentityProviderMessage, error) {
 	set, args := []string{}, []any{}
 	if v := patch.Title; v != nil {
 		set, args = append(set, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
@@ -197,7 +223,7 @@ func (*Store) updateIdentityProviderImpl(ctx context.Context, tx *Tx, patch *Upd
 		set, args = append(set, fmt.Sprintf("domain = $%d", len(args)+1)), append(args, *v)
 	}
 	if v := patch.Config; v != nil {
-		configBytes, err := getConfigBytes(v)
+		configBytes, err := s.getConfigBytes(v)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to marshal identity provider config")
 		}
@@ -247,11 +273,14 @@ func (*Store) updateIdentityProviderImpl(ctx context.Context, tx *Tx, patch *Upd
 
 	identityProvider.Type = convertIdentityProviderType(identityProviderType)
 	identityProvider.Config = convertIdentityProviderConfigString(identityProvider.Type, identityProviderConfig)
+	if err := s.decryptSecrets(ctx, getIdentityProviderConfigSecrets(identityProvider.Config)...); err != nil {
+		return nil, err
+	}
 	identityProvider.Deleted = convertRowStatusToDeleted(rowStatus)
 	return identityProvider, nil
 }
 
-func (*Store) listIdentityProvidersImpl(ctx context.Context, tx *Tx, find *FindIdentityProviderMessage) ([]*IdentityProviderMessage, error) {
+func (s *Store) listIdentityProvidersImpl(ctx context.Context, tx *Tx, find *FindIdentityProviderMessage) ([]*IdentityProviderMessage, error) {
 	where, args := []string{"TRUE"}, []any{}
 	if v := find.ResourceID; v != nil {
 		where, args = append(where, fmt.Sprintf("resource_id = $%d", len(args)+1)), append(args, *v)
@@ -300,6 +329,9 @@ func (*Store) listIdentityProvidersImpl(ctx context.Context, tx *Tx, find *FindI
 		}
 		identityProviderMessage.Type = convertIdentityProviderType(identityProviderType)
 		identityProviderMessage.Config = convertIdentityProviderConfigString(identityProviderMessage.Type, identityProviderConfig)
+		if err := s.decryptSecrets(ctx, getIdentityProviderConfigSecrets(identityProviderMessage.Config)...); err != nil {
+			return nil, err
+		}
 		identityProviderMessage.Deleted = convertRowStatusToDeleted(rowStatus)
 		identityProviderMessages = append(identityProviderMessages, &identityProviderMessage)
 	}
@@ -317,6 +349,8 @@ func convertIdentityProviderType(identityProviderType string) storepb.IdentityPr
 		return storepb.IdentityProviderType_OIDC
 	} else if identityProviderType == "LDAP" {
 		return storepb.IdentityProviderType_LDAP
+	} else if identityProviderType == "SAML" {
+		return storepb.IdentityProviderType_SAML
 	}
 	return storepb.IdentityProviderType_IDENTITY_PROVIDER_TYPE_UNSPECIFIED
 }
@@ -350,6 +384,15 @@ func convertIdentityProviderConfigString(identityProviderType storepb.IdentityPr
 		identityProviderConfig.Config = &storepb.IdentityProviderConfig_LdapConfig{
 			LdapConfig: &formattedConfig,
 		}
+	} else if identityProviderType == storepb.IdentityProviderType_SAML {
+		var formattedConfig storepb.SAMLIdentityProviderConfig
+		decoder := protojson.UnmarshalOptions{DiscardUnknown: true}
+		if err := decoder.Unmarshal([]byte(config), &formattedConfig); err != nil {
+			return nil
+		}
+		identityProviderConfig.Config = &storepb.IdentityProviderConfig_SamlConfig{
+			SamlConfig: &formattedConfig,
+		}
 	}
 	return identityProviderConfig
 }
@@ -0,0 +1,297 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// leaseAdvisoryLockNamespace is the namespace of the advisory locks for serializing the lease acquisitions on the same instance.
+const leaseAdvisoryLockNamespace = 20231018
+
+// TaskRunLeasePrefix is the prefix of the lease resources of task runs.
+const TaskRunLeasePrefix = "task_run/"
+
+// DataKeyLeaseResource is the lease resource of re-encrypting the secrets with the active data key.
+const DataKeyLeaseResource = "data_key"
+
+// LeaseMessage is the message for a lease of a background job held by a replica.
+type LeaseMessage struct {
+	// Resource is the unique name of the leased job, e.g. "task_run/101".
+	Resource string
+	// Holder is the ID of the replica holding the lease.
+	Holder string
+	// InstanceUID is the UID of the instance the job connects to, zero if the job does not connect to any instance.
+	InstanceUID int
+}
+
+// FindLeaseMessage is the message for finding leases.
+type FindLeaseMessage struct {
+	Holder *string
+	// AliveSinceTs filters the leases held by replicas with heartbeats no earlier than the timestamp.
+	AliveSinceTs *int64
+}
+
+// TaskRunLeaseResource returns the lease resource of the task run.
+func TaskRunLeaseResource(taskRunUID int) string {
+	return fmt.Sprintf("%s%d", TaskRunLeasePrefix, taskRunUID)
+}
+
+// PlanCheckRunLeaseResource returns the lease resource of the plan check run.
+func PlanCheckRunLeaseResource(planCheckRunUID int) string {
+	return fmt.Sprintf("plan_check_run/%d", planCheckRunUID)
+}
+
+// BackupDatabaseLeaseResource returns the lease resource of the automatic backup for the database.
+func BackupDatabaseLeaseResource(databaseUID int) string {
+	return fmt.Sprintf("backup_database/%d", databaseUID)
+}
+
+// ApprovalFindingLeaseResource returns the lease resource of finding the approval template for the issue.
+func ApprovalFindingLeaseResource(issueUID int) string {
+	return fmt.Sprintf("approval_finding/%d", issueUID)
+}
+
+// WebhookDeliveryLeaseResource returns the lease resource of retrying the webhook delivery.
+func WebhookDeliveryLeaseResource(deliveryUID int) string {
+	return fmt.Sprintf("webhook_delivery/%d", deliveryUID)
+}
+
+// UpsertReplicaHeartbeat records the heartbeat of the replica.
+func (s *Store) UpsertReplicaHeartbeat(ctx context.Context, replicaID string) error {
+	query := `
+		INSERT INTO replica_heartbeat (replica_id, heartbeat_ts)
+		VALUES ($1, extract(epoch from now()))
+		ON CONFLICT (replica_id) DO UPDATE SET heartbeat_ts = EXCLUDED.heartbeat_ts
+	`
+	if _, err := s.db.db.ExecContext(ctx, query, replicaID); err != nil {
+		return errors.Wrapf(err, "failed to upsert heartbeat of replica %q", replicaID)
+	}
+	return nil
+}
+
+// DeleteReplica deletes the heartbeat of the replica and all leases held by it.
+func (s *Store) DeleteReplica(ctx context.Context, replicaID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM lease WHERE holder = $1`, replicaID); err != nil {
+		return errors.Wrapf(err, "failed to delete leases of replica %q", replicaID)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM replica_heartbeat WHERE replica_id = $1`, replicaID); err != nil {
+		return errors.Wrapf(err, "failed to delete heartbeat of replica %q", replicaID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// DeleteDeadReplicas deletes the replicas without heartbeats since aliveSinceTs, and reclaims the leases held by them.
+// It returns the reclaimed leases.
+func (s *Store) DeleteDeadReplicas(ctx context.Context, aliveSinceTs int64) ([]*LeaseMessage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM replica_heartbeat WHERE heartbeat_ts < $1`, aliveSinceTs); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete dead replicas")
+	}
+	rows, err := tx.QueryContext(ctx, `
+		DELETE FROM lease
+		WHERE holder NOT IN (SELECT replica_id FROM replica_heartbeat)
+		RETURNING resource, holder, instance_id
+	`)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to reclaim leases of dead replicas")
+	}
+	defer rows.Close()
+	var leases []*LeaseMessage
+	for rows.Next() {
+		lease := &LeaseMessage{}
+		var instanceUID sql.NullInt32
+		if err := rows.Scan(&lease.Resource, &lease.Holder, &instanceUID); err != nil {
+			return nil, err
+		}
+		lease.InstanceUID = int(instanceUID.Int32)
+		leases = append(leases, lease)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return leases, nil
+}
+
+// TryAcquireLease tries to acquire the lease for lease.Holder.
+// The lease is acquired if it is free, already held by lease.Holder or held by a replica without heartbeats since aliveSinceTs.
+// If lease.InstanceUID is not zero, the lease is not acquired when there are already instanceLimit leases on the instance held by alive replicas.
+func (s *Store) TryAcquireLease(ctx context.Context, lease *LeaseMessage, aliveSinceTs int64, instanceLimit int) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var instanceUID sql.NullInt32
+	if lease.InstanceUID != 0 {
+		instanceUID = sql.NullInt32{Int32: int32(lease.InstanceUID), Valid: true}
+		// Serialize the acquisitions on the same instance so that the limit holds across replicas.
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1, $2)`, leaseAdvisoryLockNamespace, lease.InstanceUID); err != nil {
+			return false, errors.Wrapf(err, "failed to lock instance %d", lease.InstanceUID)
+		}
+	}
+
+	var holder string
+	// Skip the lease locked by another replica, which is either acquiring or reclaiming it.
+	err = tx.QueryRowContext(ctx, `SELECT holder FROM lease WHERE resource = $1 FOR UPDATE SKIP LOCKED`, lease.Resource).Scan(&holder)
+	exists := true
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return false, errors.Wrapf(err, "failed to get lease %q", lease.Resource)
+		}
+		exists = false
+	}
+	if exists {
+		if holder == lease.Holder {
+			return true, nil
+		}
+		var alive bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM replica_heartbeat WHERE replica_id = $1 AND heartbeat_ts >= $2)`, holder, aliveSinceTs).Scan(&alive); err != nil {
+			return false, errors.Wrapf(err, "failed to get heartbeat of replica %q", holder)
+		}
+		if alive {
+			return false, nil
+		}
+	}
+
+	if lease.InstanceUID != 0 {
+		var count int
+		if err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(1) FROM lease
+			WHERE instance_id = $1 AND resource != $2 AND holder IN (SELECT replica_id FROM replica_heartbeat WHERE heartbeat_ts >= $3)
+		`, lease.InstanceUID, lease.Resource, aliveSinceTs).Scan(&count); err != nil {
+			return false, errors.Wrapf(err, "failed to count leases on instance %d", lease.InstanceUID)
+		}
+		if count >= instanceLimit {
+			return false, nil
+		}
+	}
+
+	if exists {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE lease SET holder = $1, instance_id = $2, created_ts = extract(epoch from now())
+			WHERE resource = $3
+		`, lease.Holder, instanceUID, lease.Resource); err != nil {
+			return false, errors.Wrapf(err, "failed to reclaim lease %q", lease.Resource)
+		}
+	} else {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO lease (resource, holder, instance_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (resource) DO NOTHING
+		`, lease.Resource, lease.Holder, instanceUID)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to create lease %q", lease.Resource)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		// The lease is held by another replica.
+		if affected == 0 {
+			return false, nil
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return true, nil
+}
+
+// ReleaseLease releases the lease held by the holder.
+func (s *Store) ReleaseLease(ctx context.Context, resource, holder string) error {
+	if _, err := s.db.db.ExecContext(ctx, `DELETE FROM lease WHERE resource = $1 AND holder = $2`, resource, holder); err != nil {
+		return errors.Wrapf(err, "failed to release lease %q", resource)
+	}
+	return nil
+}
+
+// ListLeases lists leases.
+func (s *Store) ListLeases(ctx context.Context, find *FindLeaseMessage) ([]*LeaseMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.Holder; v != nil {
+		where, args = append(where, fmt.Sprintf("holder = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.AliveSinceTs; v != nil {
+		where, args = append(where, fmt.Sprintf("holder IN (SELECT replica_id FROM replica_heartbeat WHERE heartbeat_ts >= $%d)", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := s.db.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT resource, holder, instance_id
+		FROM lease
+		WHERE %s
+	`, strings.Join(where, " AND ")), args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list leases")
+	}
+	defer rows.Close()
+	var leases []*LeaseMessage
+	for rows.Next() {
+		lease := &LeaseMessage{}
+		var instanceUID sql.NullInt32
+		if err := rows.Scan(&lease.Resource, &lease.Holder, &instanceUID); err != nil {
+			return nil, err
+		}
+		lease.InstanceUID = int(instanceUID.Int32)
+		leases = append(leases, lease)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+// CancelOrphanedTaskRuns changes the RUNNING task runs without leases held by replicas with heartbeats since aliveSinceTs to CANCELED.
+// It returns the UIDs of the canceled task runs.
+func (s *Store) CancelOrphanedTaskRuns(ctx context.Context, aliveSinceTs int64, updaterID int) ([]int, error) {
+	rows, err := s.db.db.QueryContext(ctx, `
+		UPDATE task_run
+		SET status = 'CANCELED', updater_id = $1
+		WHERE status = 'RUNNING' AND NOT EXISTS (
+			SELECT 1 FROM lease
+			JOIN replica_heartbeat ON lease.holder = replica_heartbeat.replica_id
+			WHERE lease.resource = $2::TEXT || task_run.id AND replica_heartbeat.heartbeat_ts >= $3
+		)
+		RETURNING id
+	`, updaterID, TaskRunLeasePrefix, aliveSinceTs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to cancel orphaned task runs")
+	}
+	defer rows.Close()
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -25,6 +25,8 @@ const (
 	PlanCheckDatabaseStatementType PlanCheckRunType = "bb.plan-check.database.statement.type"
 	// PlanCheckDatabaseStatementSummaryReport is the plan check type for statement summary report.
 	PlanCheckDatabaseStatementSummaryReport PlanCheckRunType = "bb.plan-check.database.statement.summary.report"
+	// PlanCheckDatabaseStatementLockImpact is the plan check type for statement lock impact.
+	PlanCheckDatabaseStatementLockImpact PlanCheckRunType = "bb.plan-check.database.statement.lock-impact"
 	// PlanCheckDatabaseConnect is the plan check type for database connection.
 	PlanCheckDatabaseConnect PlanCheckRunType = "bb.plan-check.database.connect"
 	// PlanCheckDatabaseGhostSync is the plan check type for the gh-ost sync task.
@@ -65,6 +67,7 @@ type PlanCheckRunMessage struct {
 
 // FindPlanCheckRunMessage is the message for finding plan check runs.
 type FindPlanCheckRunMessage struct {
+	UID     *int
 	PlanUID *int64
 
 	Status *[]PlanCheckRunStatus
@@ -128,6 +131,10 @@ func (s *Store) CreatePlanCheckRuns(ctx context.Context, creates ...*PlanCheckRu
 // ListPlanCheckRuns returns a list of plan check runs based on find.
 func (s *Store) ListPlanCheckRuns(ctx context.Context, find *FindPlanCheckRunMessage) ([]*PlanCheckRunMessage, error) {
 	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where = append(where, fmt.Sprintf("plan_check_run.id = $%d", len(args)+1))
+		args = append(args, *v)
+	}
 	if v := find.PlanUID; v != nil {
 		where = append(where, fmt.Sprintf("plan_check_run.plan_id = $%d", len(args)+1))
 		args = append(args, *v)
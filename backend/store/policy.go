@@ -118,6 +118,32 @@ func (s *Store) GetMaskingRulePolicy(ctx context.Context) (*storepb.MaskingRuleP
 	return p, nil
 }
 
+// GetRolloutWindowPolicy will get the rollout window policy for an environment.
+func (s *Store) GetRolloutWindowPolicy(ctx context.Context, environmentID int) (*storepb.RolloutWindowPolicy, error) {
+	resourceType := api.PolicyResourceTypeEnvironment
+	pType := api.PolicyTypeRolloutWindow
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &environmentID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The policy doesn't block any rollout if it is not enforced.
+	if policy == nil || !policy.Enforce {
+		return &storepb.RolloutWindowPolicy{}, nil
+	}
+
+	p := new(storepb.RolloutWindowPolicy)
+	if err := protojson.Unmarshal([]byte(policy.Payload), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
 // GetMaskingPolicyByDatabaseUID gets the masking policy for a database.
 func (s *Store) GetMaskingPolicyByDatabaseUID(ctx context.Context, databaseUID int) (*storepb.MaskingPolicy, error) {
 	resourceType := api.PolicyResourceTypeDatabase
@@ -168,6 +194,31 @@ func (s *Store) GetMaskingExceptionPolicyByProjectUID(ctx context.Context, proje
 	return p, nil
 }
 
+// GetRedisKeyAccessPolicyByProjectUID gets the Redis key access policy for a project.
+func (s *Store) GetRedisKeyAccessPolicyByProjectUID(ctx context.Context, projectUID int) (*storepb.RedisKeyAccessPolicy, error) {
+	resourceType := api.PolicyResourceTypeProject
+	pType := api.PolicyTypeRedisKeyAccess
+	policy, err := s.GetPolicyV2(ctx, &FindPolicyMessage{
+		ResourceType: &resourceType,
+		ResourceUID:  &projectUID,
+		Type:         &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if policy == nil {
+		return &storepb.RedisKeyAccessPolicy{}, nil
+	}
+
+	p := new(storepb.RedisKeyAccessPolicy)
+	if err := protojson.Unmarshal([]byte(policy.Payload), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
 // PolicyMessage is the mssage for policy.
 type PolicyMessage struct {
 	ResourceUID       int
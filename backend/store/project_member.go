@@ -26,6 +26,9 @@ func (m *IAMPolicyMessage) String() string {
 		for _, member := range binding.Members {
 			members = append(members, member.Email)
 		}
+		for _, group := range binding.Groups {
+			members = append(members, fmt.Sprintf("group:%s", group.Email))
+		}
 		if binding.Condition == nil {
 			binding.Condition = &expr.Expr{}
 		}
@@ -67,17 +70,57 @@ func (m *IAMPolicyMessage) sort() error {
 		sort.Slice(binding.Members, func(i, j int) bool {
 			return binding.Members[i].ID < binding.Members[j].ID
 		})
+		sort.Slice(binding.Groups, func(i, j int) bool {
+			return binding.Groups[i].UID < binding.Groups[j].UID
+		})
 	}
 	return nil
 }
 
 // PolicyBinding is the IAM policy binding of a project.
 type PolicyBinding struct {
-	Role      api.Role
-	Members   []*UserMessage
+	Role    api.Role
+	Members []*UserMessage
+	// Groups are the user groups granted the role, all the group members have the role.
+	Groups    []*UserGroupMessage
 	Condition *expr.Expr
 }
 
+// HasMember returns whether the user is granted the role of the binding, directly or by the groups.
+func (b *PolicyBinding) HasMember(userUID int) bool {
+	for _, member := range b.Members {
+		if member.ID == userUID {
+			return true
+		}
+	}
+	for _, group := range b.Groups {
+		for _, memberUID := range group.MemberUIDs {
+			if memberUID == userUID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetPolicyBindingUsers returns the users granted the role of the binding, including the group members.
+func (s *Store) GetPolicyBindingUsers(ctx context.Context, binding *PolicyBinding) ([]*UserMessage, error) {
+	users := binding.Members
+	for _, group := range binding.Groups {
+		for _, memberUID := range group.MemberUIDs {
+			user, err := s.GetUserByID(ctx, memberUID)
+			if err != nil {
+				return nil, err
+			}
+			if user == nil || user.MemberDeleted {
+				continue
+			}
+			users = append(users, user)
+		}
+	}
+	return filterUser(users), nil
+}
+
 // GetProjectPolicyMessage is the message to get project policy.
 type GetProjectPolicyMessage struct {
 	ProjectID *string
@@ -97,8 +140,12 @@ type ProjectMemberMessage struct {
 func (s *Store) GetProjectUsingRole(ctx context.Context, role api.Role) (bool, string, error) {
 	query := `
 		SELECT project.resource_id
-		FROM project_member, project
-		WHERE project_member.role = $1 AND project_member.project_id = project.id
+		FROM (
+			SELECT project_id, role FROM project_member
+			UNION ALL
+			SELECT project_id, role FROM project_group_member
+		) AS member, project
+		WHERE member.role = $1 AND member.project_id = project.id
 		LIMIT 1
 	`
 	var project string
@@ -239,13 +286,30 @@ func (s *Store) getProjectPolicyImpl(ctx context.Context, tx *Tx, find *GetProje
 		return nil, err
 	}
 
-	projectPolicy := &IAMPolicyMessage{}
-	for key, userUIDs := range roleMap {
+	groupRoleMap, err := getProjectGroupRolesImpl(ctx, tx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	bindingMap := map[roleConditionMapKey]*PolicyBinding{}
+	getBinding := func(key roleConditionMapKey) (*PolicyBinding, error) {
+		if binding, ok := bindingMap[key]; ok {
+			return binding, nil
+		}
 		var condition expr.Expr
 		if err := protojson.Unmarshal([]byte(key.condition), &condition); err != nil {
 			return nil, err
 		}
 		binding := &PolicyBinding{Role: key.role, Condition: &condition}
+		bindingMap[key] = binding
+		return binding, nil
+	}
+	projectPolicy := &IAMPolicyMessage{}
+	for key, userUIDs := range roleMap {
+		binding, err := getBinding(key)
+		if err != nil {
+			return nil, err
+		}
 		for _, userUID := range userUIDs {
 			user, err := s.GetUserByID(ctx, userUID)
 			if err != nil {
@@ -253,6 +317,25 @@ func (s *Store) getProjectPolicyImpl(ctx context.Context, tx *Tx, find *GetProje
 			}
 			binding.Members = append(binding.Members, user)
 		}
+	}
+	for key, groupUIDs := range groupRoleMap {
+		binding, err := getBinding(key)
+		if err != nil {
+			return nil, err
+		}
+		for _, groupUID := range groupUIDs {
+			groupUID := groupUID
+			group, err := s.GetUserGroup(ctx, &FindUserGroupMessage{UID: &groupUID})
+			if err != nil {
+				return nil, err
+			}
+			if group == nil {
+				continue
+			}
+			binding.Groups = append(binding.Groups, group)
+		}
+	}
+	for _, binding := range bindingMap {
 		projectPolicy.Bindings = append(projectPolicy.Bindings, binding)
 	}
 	if err := projectPolicy.sort(); err != nil {
@@ -261,6 +344,51 @@ func (s *Store) getProjectPolicyImpl(ctx context.Context, tx *Tx, find *GetProje
 	return projectPolicy, nil
 }
 
+// getProjectGroupRolesImpl returns the group IDs granted each of the role and condition in the project.
+func getProjectGroupRolesImpl(ctx context.Context, tx *Tx, find *GetProjectPolicyMessage) (map[roleConditionMapKey][]int, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.ProjectID; v != nil {
+		where, args = append(where, fmt.Sprintf("project.resource_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("project.id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+			SELECT
+				project_group_member.group_id,
+				project_group_member.role,
+				project_group_member.condition
+			FROM project_group_member
+			LEFT JOIN project ON project_group_member.project_id = project.id
+			WHERE `+strings.Join(where, " AND "),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	roleMap := map[roleConditionMapKey][]int{}
+	for rows.Next() {
+		var role api.Role
+		var rawCondition string
+		var groupUID int
+		if err := rows.Scan(
+			&groupUID,
+			&role,
+			&rawCondition,
+		); err != nil {
+			return nil, err
+		}
+		key := roleConditionMapKey{role: role, condition: rawCondition}
+		roleMap[key] = append(roleMap[key], groupUID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return roleMap, nil
+}
+
 func (s *Store) setProjectIAMPolicyImpl(ctx context.Context, tx *Tx, set *IAMPolicyMessage, creatorUID int, projectUID int) error {
 	if set == nil {
 		return errors.Errorf("SetProjectPolicy must set IAMPolicyMessage")
@@ -284,8 +412,8 @@ func (s *Store) setProjectIAMPolicyImpl(ctx context.Context, tx *Tx, set *IAMPol
 	}
 
 	if len(inserts.Bindings) > 0 {
-		args := []any{}
-		var placeholders []string
+		args, groupArgs := []any{}, []any{}
+		var placeholders, groupPlaceholders []string
 		for _, binding := range inserts.Bindings {
 			rawCondition, err := formatCondition(binding.Condition)
 			if err != nil {
@@ -303,17 +431,43 @@ func (s *Store) setProjectIAMPolicyImpl(ctx context.Context, tx *Tx, set *IAMPol
 					rawCondition,
 				)
 			}
+			for _, group := range binding.Groups {
+				groupPlaceholders = append(groupPlaceholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", len(groupArgs)+1, len(groupArgs)+2, len(groupArgs)+3, len(groupArgs)+4, len(groupArgs)+5, len(groupArgs)+6))
+				groupArgs = append(groupArgs,
+					creatorUID,   // creator_id
+					creatorUID,   // updater_id
+					projectUID,   // project_id
+					binding.Role, // role
+					group.UID,    // group_id
+					rawCondition,
+				)
+			}
 		}
-		query := fmt.Sprintf(`INSERT INTO project_member (
-			creator_id,
-			updater_id,
-			project_id,
-			role,
-			principal_id,
-			condition
-		) VALUES %s`, strings.Join(placeholders, ", "))
-		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
-			return err
+		if len(placeholders) > 0 {
+			query := fmt.Sprintf(`INSERT INTO project_member (
+				creator_id,
+				updater_id,
+				project_id,
+				role,
+				principal_id,
+				condition
+			) VALUES %s`, strings.Join(placeholders, ", "))
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+		}
+		if len(groupPlaceholders) > 0 {
+			query := fmt.Sprintf(`INSERT INTO project_group_member (
+				creator_id,
+				updater_id,
+				project_id,
+				role,
+				group_id,
+				condition
+			) VALUES %s`, strings.Join(groupPlaceholders, ", "))
+			if _, err := tx.ExecContext(ctx, query, groupArgs...); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
@@ -324,7 +478,9 @@ func (*Store) deleteProjectIAMPolicyImpl(ctx context.Context, tx *Tx, projectUID
 		return nil
 	}
 	where, args := []string{}, []any{}
+	groupWhere, groupArgs := []string{}, []any{}
 	args = append(args, projectUID)
+	groupArgs = append(groupArgs, projectUID)
 	for _, binding := range deletes.Bindings {
 		rawCondition, err := formatCondition(binding.Condition)
 		if err != nil {
@@ -334,10 +490,22 @@ func (*Store) deleteProjectIAMPolicyImpl(ctx context.Context, tx *Tx, projectUID
 			where = append(where, fmt.Sprintf("(project_member.principal_id = $%d AND project_member.role = $%d AND project_member.condition = $%d)", len(args)+1, len(args)+2, len(args)+3))
 			args = append(args, member.ID, binding.Role, rawCondition)
 		}
+		for _, group := range binding.Groups {
+			groupWhere = append(groupWhere, fmt.Sprintf("(project_group_member.group_id = $%d AND project_group_member.role = $%d AND project_group_member.condition = $%d)", len(groupArgs)+1, len(groupArgs)+2, len(groupArgs)+3))
+			groupArgs = append(groupArgs, group.UID, binding.Role, rawCondition)
+		}
 	}
-	query := fmt.Sprintf(`DELETE FROM project_member WHERE project_member.project_id = $1 AND (%s)`, strings.Join(where, " OR "))
-	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
-		return err
+	if len(where) > 0 {
+		query := fmt.Sprintf(`DELETE FROM project_member WHERE project_member.project_id = $1 AND (%s)`, strings.Join(where, " OR "))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+	if len(groupWhere) > 0 {
+		query := fmt.Sprintf(`DELETE FROM project_group_member WHERE project_group_member.project_id = $1 AND (%s)`, strings.Join(groupWhere, " OR "))
+		if _, err := tx.ExecContext(ctx, query, groupArgs...); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -345,106 +513,106 @@ func (*Store) deleteProjectIAMPolicyImpl(ctx context.Context, tx *Tx, projectUID
 // GetIAMPolicyDiff returns the diff between old and new policy (remove and add).
 // TODO(d): make minimal diff.
 func GetIAMPolicyDiff(oldPolicy *IAMPolicyMessage, newPolicy *IAMPolicyMessage) (*IAMPolicyMessage, *IAMPolicyMessage, error) {
-	oldMap, newMap := make(map[roleConditionMapKey][]*UserMessage), make(map[roleConditionMapKey][]*UserMessage)
+	oldMap, err := getPolicyBindingMap(oldPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+	newMap, err := getPolicyBindingMap(newPolicy)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	for _, binding := range oldPolicy.Bindings {
-		str, err := formatCondition(binding.Condition)
-		if err != nil {
-			return nil, nil, err
-		}
-		key := roleConditionMapKey{role: binding.Role, condition: str}
-		if oldMap[key] != nil {
-			oldMap[key] = filterUser(append(oldMap[key], binding.Members...))
-		} else {
-			oldMap[key] = binding.Members
-		}
+	// Delete member that no longer exists.
+	remove, err := getPolicyBindingDiff(oldMap, newMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Create member if not exist in old policy.
+	add, err := getPolicyBindingDiff(newMap, oldMap)
+	if err != nil {
+		return nil, nil, err
 	}
-	for _, binding := range newPolicy.Bindings {
+	if err := remove.sort(); err != nil {
+		return nil, nil, err
+	}
+	if err := add.sort(); err != nil {
+		return nil, nil, err
+	}
+
+	return remove, add, nil
+}
+
+// getPolicyBindingMap merges the bindings with the same role and condition.
+func getPolicyBindingMap(policy *IAMPolicyMessage) (map[roleConditionMapKey]*PolicyBinding, error) {
+	bindingMap := make(map[roleConditionMapKey]*PolicyBinding)
+	for _, binding := range policy.Bindings {
 		str, err := formatCondition(binding.Condition)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		key := roleConditionMapKey{role: binding.Role, condition: str}
-		if newMap[key] != nil {
-			newMap[key] = filterUser(append(newMap[key], binding.Members...))
+		if existing, ok := bindingMap[key]; ok {
+			existing.Members = filterUser(append(existing.Members, binding.Members...))
+			existing.Groups = filterGroup(append(existing.Groups, binding.Groups...))
 		} else {
-			newMap[key] = binding.Members
+			bindingMap[key] = &PolicyBinding{
+				Role:    binding.Role,
+				Members: binding.Members,
+				Groups:  binding.Groups,
+			}
 		}
 	}
+	return bindingMap, nil
+}
 
-	remove, add := &IAMPolicyMessage{}, &IAMPolicyMessage{}
-	// Delete member that no longer exists.
-	for key, oldMembers := range oldMap {
-		newMembers, ok := newMap[key]
+// getPolicyBindingDiff returns the members and groups in the source bindings but not in the target bindings.
+func getPolicyBindingDiff(sourceMap, targetMap map[roleConditionMapKey]*PolicyBinding) (*IAMPolicyMessage, error) {
+	diff := &IAMPolicyMessage{}
+	for key, source := range sourceMap {
 		var condition expr.Expr
 		if err := protojson.Unmarshal([]byte(key.condition), &condition); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
+		target, ok := targetMap[key]
 		if !ok {
-			remove.Bindings = append(remove.Bindings, &PolicyBinding{
+			diff.Bindings = append(diff.Bindings, &PolicyBinding{
 				Role:      key.role,
 				Condition: &condition,
-				Members:   oldMembers,
+				Members:   source.Members,
+				Groups:    source.Groups,
 			})
-		} else {
-			// Reconcile members.
-			oldUserMap, newUserMap := make(map[int]*UserMessage), make(map[int]*UserMessage)
-			for _, oldMember := range oldMembers {
-				oldUserMap[oldMember.ID] = oldMember
-			}
-			for _, newMember := range newMembers {
-				newUserMap[newMember.ID] = newMember
-			}
-			var removeMembers, addMembers []*UserMessage
-			for oldID, oldMember := range oldUserMap {
-				if _, ok := newUserMap[oldID]; !ok {
-					removeMembers = append(removeMembers, oldMember)
-				}
-			}
-			for newID, newMember := range newUserMap {
-				if _, ok := oldUserMap[newID]; !ok {
-					addMembers = append(addMembers, newMember)
-				}
-			}
-			if len(removeMembers) > 0 {
-				remove.Bindings = append(remove.Bindings, &PolicyBinding{
-					Role:      key.role,
-					Condition: &condition,
-					Members:   removeMembers,
-				})
-			}
-			if len(addMembers) > 0 {
-				add.Bindings = append(add.Bindings, &PolicyBinding{
-					Role:      key.role,
-					Condition: &condition,
-					Members:   addMembers,
-				})
+			continue
+		}
+		// Reconcile members.
+		targetUserMap, targetGroupMap := make(map[int]bool), make(map[int]bool)
+		for _, member := range target.Members {
+			targetUserMap[member.ID] = true
+		}
+		for _, group := range target.Groups {
+			targetGroupMap[group.UID] = true
+		}
+		var members []*UserMessage
+		var groups []*UserGroupMessage
+		for _, member := range filterUser(source.Members) {
+			if !targetUserMap[member.ID] {
+				members = append(members, member)
 			}
 		}
-	}
-
-	// Create member if not exist in old policy.
-	for key, members := range newMap {
-		if _, ok := oldMap[key]; !ok {
-			var condition expr.Expr
-			if err := protojson.Unmarshal([]byte(key.condition), &condition); err != nil {
-				return nil, nil, err
+		for _, group := range filterGroup(source.Groups) {
+			if !targetGroupMap[group.UID] {
+				groups = append(groups, group)
 			}
-			add.Bindings = append(add.Bindings, &PolicyBinding{
+		}
+		if len(members) > 0 || len(groups) > 0 {
+			diff.Bindings = append(diff.Bindings, &PolicyBinding{
 				Role:      key.role,
 				Condition: &condition,
 				Members:   members,
+				Groups:    groups,
 			})
 		}
 	}
-	if err := remove.sort(); err != nil {
-		return nil, nil, err
-	}
-	if err := add.sort(); err != nil {
-		return nil, nil, err
-	}
-
-	return remove, add, nil
+	return diff, nil
 }
 
 func filterUser(list []*UserMessage) []*UserMessage {
@@ -457,3 +625,14 @@ func filterUser(list []*UserMessage) []*UserMessage {
 	}
 	return result
 }
+
+func filterGroup(list []*UserGroupMessage) []*UserGroupMessage {
+	result, f := []*UserGroupMessage{}, make(map[int]bool, len(list))
+	for _, group := range list {
+		if f[group.UID] {
+			continue
+		}
+		result, f[group.UID] = append(result, group), true
+	}
+	return result
+}
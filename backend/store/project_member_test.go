@@ -234,6 +234,42 @@ func TestGetIAMPolicyDiff(t *testing.T) {
 				},
 			},
 		},
+		{
+			oldPolicy: &IAMPolicyMessage{
+				Bindings: []*PolicyBinding{
+					{
+						Role:    api.Owner,
+						Members: []*UserMessage{{ID: 1}},
+						Groups:  []*UserGroupMessage{{UID: 101, Email: "dba@example.com"}},
+					},
+				},
+			},
+			newPolicy: &IAMPolicyMessage{
+				Bindings: []*PolicyBinding{
+					{
+						Role:    api.Owner,
+						Members: []*UserMessage{{ID: 1}},
+						Groups:  []*UserGroupMessage{{UID: 102, Email: "developer@example.com"}},
+					},
+				},
+			},
+			remove: &IAMPolicyMessage{
+				Bindings: []*PolicyBinding{
+					{
+						Role:   api.Owner,
+						Groups: []*UserGroupMessage{{UID: 101, Email: "dba@example.com"}},
+					},
+				},
+			},
+			add: &IAMPolicyMessage{
+				Bindings: []*PolicyBinding{
+					{
+						Role:   api.Owner,
+						Groups: []*UserGroupMessage{{UID: 102, Email: "developer@example.com"}},
+					},
+				},
+			},
+		},
 	}
 
 	for i, tc := range testCases {
@@ -243,3 +279,14 @@ func TestGetIAMPolicyDiff(t *testing.T) {
 		require.Equal(t, tc.add.String(), add.String(), fmt.Sprintf("%d", i))
 	}
 }
+
+func TestPolicyBindingHasMember(t *testing.T) {
+	binding := &PolicyBinding{
+		Role:    api.Developer,
+		Members: []*UserMessage{{ID: 1}},
+		Groups:  []*UserGroupMessage{{UID: 101, MemberUIDs: []int{2, 3}}},
+	}
+	require.True(t, binding.HasMember(1))
+	require.True(t, binding.HasMember(3))
+	require.False(t, binding.HasMember(4))
+}
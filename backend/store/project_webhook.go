@@ -23,6 +23,8 @@ type ProjectWebhookMessage struct {
 	URL string
 	// ActivityList is the list of activities that the webhook is interested in.
 	ActivityList []string
+	// SigningSecret is the secret to sign the requests of the custom webhook.
+	SigningSecret string
 	// Output only fields.
 	//
 	// ID is the unique identifier of the project webhook.
@@ -38,6 +40,8 @@ type UpdateProjectWebhookMessage struct {
 	URL *string
 	// ActivityList is the list of activities that the webhook is interested in.
 	ActivityList []string
+	// SigningSecret is the secret to sign the requests of the custom webhook.
+	SigningSecret *string
 }
 
 // FindProjectWebhookMessage is the message for finding project webhooks,
@@ -59,13 +63,18 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 			type,
 			name,
 			url,
-			activity_list
+			activity_list,
+			signing_secret
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, project_id, type, name, url, activity_list
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, project_id, type, name, url, activity_list, signing_secret
 	`
 	var projectWebhook ProjectWebhookMessage
 	var txtArray pgtype.TextArray
+	signingSecret, err := s.encryptSecret(create.SigningSecret)
+	if err != nil {
+		return nil, err
+	}
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -80,6 +89,7 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		create.Title,
 		create.URL,
 		create.ActivityList,
+		signingSecret,
 	).Scan(
 		&projectWebhook.ID,
 		&projectWebhook.ProjectID,
@@ -87,6 +97,7 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		&projectWebhook.Title,
 		&projectWebhook.URL,
 		&txtArray,
+		&projectWebhook.SigningSecret,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
@@ -96,6 +107,9 @@ func (s *Store) CreateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 		return nil, err
 	}
+	if err := s.decryptSecrets(ctx, &projectWebhook.SigningSecret); err != nil {
+		return nil, err
+	}
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
 	}
@@ -165,6 +179,13 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if v := update.ActivityList; v != nil {
 		set, args = append(set, fmt.Sprintf("activity_list = $%d", len(args)+1)), append(args, v)
 	}
+	if v := update.SigningSecret; v != nil {
+		signingSecret, err := s.encryptSecret(*v)
+		if err != nil {
+			return nil, err
+		}
+		set, args = append(set, fmt.Sprintf("signing_secret = $%d", len(args)+1)), append(args, signingSecret)
+	}
 
 	args = append(args, projectWebhookID)
 
@@ -175,7 +196,7 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	UPDATE project_webhook
 	SET `+strings.Join(set, ", ")+`
 	WHERE id = $%d
-	RETURNING id, project_id, type, name, url, activity_list
+	RETURNING id, project_id, type, name, url, activity_list, signing_secret
 `, len(args)),
 		args...,
 	).Scan(
@@ -185,6 +206,7 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 		&projectWebhook.Title,
 		&projectWebhook.URL,
 		&txtArray,
+		&projectWebhook.SigningSecret,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("project hook ID not found: %d", projectWebhookID)}
@@ -194,6 +216,9 @@ func (s *Store) UpdateProjectWebhookV2(ctx context.Context, principalUID int, pr
 	if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 		return nil, err
 	}
+	if err := s.decryptSecrets(ctx, &projectWebhook.SigningSecret); err != nil {
+		return nil, err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
@@ -222,7 +247,7 @@ func (s *Store) DeleteProjectWebhookV2(ctx context.Context, projectResourceID st
 	return nil
 }
 
-func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindProjectWebhookMessage) ([]*ProjectWebhookMessage, error) {
+func (s *Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindProjectWebhookMessage) ([]*ProjectWebhookMessage, error) {
 	// Build WHERE clause.
 	where, args := []string{"TRUE"}, []any{}
 	if v := find.ID; v != nil {
@@ -241,7 +266,8 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 			type,
 			name,
 			url,
-			activity_list
+			activity_list,
+			signing_secret
 		FROM project_webhook
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -262,6 +288,7 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 			&projectWebhook.Title,
 			&projectWebhook.URL,
 			&txtArray,
+			&projectWebhook.SigningSecret,
 		); err != nil {
 			return nil, err
 		}
@@ -269,6 +296,9 @@ func (*Store) findProjectWebhookImplV2(ctx context.Context, tx *Tx, find *FindPr
 		if err := txtArray.AssignTo(&projectWebhook.ActivityList); err != nil {
 			return nil, err
 		}
+		if err := s.decryptSecrets(ctx, &projectWebhook.SigningSecret); err != nil {
+			return nil, err
+		}
 
 		if v := find.ActivityType; v != nil {
 			for _, activity := range projectWebhook.ActivityList {
@@ -194,6 +194,11 @@ func (s *Store) createRepositoryImplV2(ctx context.Context, tx *Tx, create *Repo
 		return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("cannot found project %s", create.ProjectResourceID)}
 	}
 
+	accessToken, refreshToken := create.AccessToken, create.RefreshToken
+	if err := s.encryptSecrets(&accessToken, &refreshToken); err != nil {
+		return nil, err
+	}
+
 	repository := RepositoryMessage{
 		ProjectResourceID: project.ResourceID,
 	}
@@ -244,9 +249,9 @@ func (s *Store) createRepositoryImplV2(ctx context.Context, tx *Tx, create *Repo
 		create.WebhookURLHost,
 		create.WebhookEndpointID,
 		create.WebhookSecretToken,
-		create.AccessToken,
+		accessToken,
 		create.ExpiresTs,
-		create.RefreshToken,
+		refreshToken,
 	).Scan(
 		&repository.UID,
 		&repository.VCSUID,
@@ -273,10 +278,13 @@ func (s *Store) createRepositoryImplV2(ctx context.Context, tx *Tx, create *Repo
 		}
 		return nil, err
 	}
+	if err := s.decryptSecrets(ctx, &repository.AccessToken, &repository.RefreshToken); err != nil {
+		return nil, err
+	}
 	return &repository, nil
 }
 
-func (*Store) listRepositoryImplV2(ctx context.Context, tx *Tx, find *FindRepositoryMessage) ([]*RepositoryMessage, error) {
+func (s *Store) listRepositoryImplV2(ctx context.Context, tx *Tx, find *FindRepositoryMessage) ([]*RepositoryMessage, error) {
 	// Build WHERE clause.
 	where, args := []string{"TRUE"}, []any{}
 	if v := find.UID; v != nil {
@@ -355,6 +363,9 @@ func (*Store) listRepositoryImplV2(ctx context.Context, tx *Tx, find *FindReposi
 		); err != nil {
 			return nil, err
 		}
+		if err := s.decryptSecrets(ctx, &repository.AccessToken, &repository.RefreshToken); err != nil {
+			return nil, err
+		}
 
 		repoRawList = append(repoRawList, &repository)
 	}
@@ -367,7 +378,7 @@ func (*Store) listRepositoryImplV2(ctx context.Context, tx *Tx, find *FindReposi
 
 // patchRepositoryImpl updates a repository by ID. Returns the new state of the repository after update.
 // Returns ENOTFOUND if repository does not exist.
-func (*Store) patchRepositoryImplV2(ctx context.Context, tx *Tx, patch *PatchRepositoryMessage, updaterID int) (*RepositoryMessage, error) {
+func (s *Store) patchRepositoryImplV2(ctx context.Context, tx *Tx, patch *PatchRepositoryMessage, updaterID int) (*RepositoryMessage, error) {
 	// Build UPDATE clause.
 	set, args := []string{"updater_id = $1"}, []any{updaterID}
 	if v := patch.BranchFilter; v != nil {
@@ -386,13 +397,21 @@ func (*Store) patchRepositoryImplV2(ctx context.Context, tx *Tx, patch *PatchRep
 		set, args = append(set, fmt.Sprintf("sheet_path_template = $%d", len(args)+1)), append(args, *v)
 	}
 	if v := patch.AccessToken; v != nil {
-		set, args = append(set, fmt.Sprintf("access_token = $%d", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return nil, err
+		}
+		set, args = append(set, fmt.Sprintf("access_token = $%d", len(args)+1)), append(args, encrypted)
 	}
 	if v := patch.ExpiresTs; v != nil {
 		set, args = append(set, fmt.Sprintf("expires_ts = $%d", len(args)+1)), append(args, *v)
 	}
 	if v := patch.RefreshToken; v != nil {
-		set, args = append(set, fmt.Sprintf("refresh_token = $%d", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return nil, err
+		}
+		set, args = append(set, fmt.Sprintf("refresh_token = $%d", len(args)+1)), append(args, encrypted)
 	}
 	if v := patch.EnableSQLReviewCI; v != nil {
 		set, args = append(set, fmt.Sprintf("enable_sql_review_ci = $%d", len(args)+1)), append(args, *v)
@@ -466,6 +485,9 @@ func (*Store) patchRepositoryImplV2(ctx context.Context, tx *Tx, patch *PatchRep
 		}
 		return nil, err
 	}
+	if err := s.decryptSecrets(ctx, &repository.AccessToken, &repository.RefreshToken); err != nil {
+		return nil, err
+	}
 	return &repository, nil
 }
 
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CreateSAMLRequest records the SAML authentication request sent to the identity provider.
+// The expired requests are purged at the same time.
+func (s *Store) CreateSAMLRequest(ctx context.Context, requestID, identityProviderID string, expireTime time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM saml_request WHERE expires_ts <= extract(epoch from now())
+	`); err != nil {
+		return errors.Wrapf(err, "failed to purge expired SAML requests")
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO saml_request (id, idp_id, expires_ts) VALUES ($1, $2, $3)
+	`, requestID, identityProviderID, expireTime.Unix()); err != nil {
+		return errors.Wrapf(err, "failed to create SAML request")
+	}
+	return tx.Commit()
+}
+
+// ExistsSAMLRequest returns true if the unexpired SAML authentication request was sent to the identity provider.
+func (s *Store) ExistsSAMLRequest(ctx context.Context, requestID, identityProviderID string) (bool, error) {
+	var exists bool
+	if err := s.db.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM saml_request WHERE id = $1 AND idp_id = $2 AND expires_ts > extract(epoch from now())
+		)
+	`, requestID, identityProviderID).Scan(&exists); err != nil {
+		return false, errors.Wrapf(err, "failed to get SAML request")
+	}
+	return exists, nil
+}
+
+// ConsumeSAMLRequest deletes the SAML authentication request, and returns true if it was unexpired and sent to the identity provider.
+// Each request can only be consumed once even if multiple replicas consume it concurrently.
+func (s *Store) ConsumeSAMLRequest(ctx context.Context, requestID, identityProviderID string) (bool, error) {
+	var expiresTs int64
+	if err := s.db.db.QueryRowContext(ctx, `
+		DELETE FROM saml_request WHERE id = $1 AND idp_id = $2
+		RETURNING expires_ts
+	`, requestID, identityProviderID).Scan(&expiresTs); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to consume SAML request")
+	}
+	return time.Now().Before(time.Unix(expiresTs, 0)), nil
+}
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SCIMUserMessage is the message for a user managed by the SCIM provisioning clients.
+type SCIMUserMessage struct {
+	PrincipalUID int
+	// ExternalID is the identifier of the user in the provisioning client.
+	ExternalID string
+}
+
+// FindSCIMUserMessage is the message for finding the users managed by the SCIM provisioning clients.
+type FindSCIMUserMessage struct {
+	PrincipalUID *int
+	ExternalID   *string
+}
+
+// GetSCIMUser gets a user managed by the SCIM provisioning clients.
+func (s *Store) GetSCIMUser(ctx context.Context, find *FindSCIMUserMessage) (*SCIMUserMessage, error) {
+	users, err := s.ListSCIMUsers(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	return users[0], nil
+}
+
+// ListSCIMUsers lists the users managed by the SCIM provisioning clients.
+func (s *Store) ListSCIMUsers(ctx context.Context, find *FindSCIMUserMessage) ([]*SCIMUserMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.PrincipalUID; v != nil {
+		where, args = append(where, fmt.Sprintf("principal_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ExternalID; v != nil {
+		where, args = append(where, fmt.Sprintf("external_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := s.db.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT
+			principal_id,
+			external_id
+		FROM scim_user
+		WHERE %s
+		ORDER BY principal_id`, strings.Join(where, " AND ")),
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list SCIM users")
+	}
+	defer rows.Close()
+
+	var users []*SCIMUserMessage
+	for rows.Next() {
+		user := &SCIMUserMessage{}
+		if err := rows.Scan(&user.PrincipalUID, &user.ExternalID); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan SCIM user")
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate SCIM users")
+	}
+	return users, nil
+}
+
+// UpsertSCIMUser marks the user as managed by the SCIM provisioning clients with the external ID.
+func (s *Store) UpsertSCIMUser(ctx context.Context, upsert *SCIMUserMessage) error {
+	if _, err := s.db.db.ExecContext(ctx, `
+		INSERT INTO scim_user (principal_id, external_id) VALUES ($1, $2)
+		ON CONFLICT (principal_id) DO UPDATE SET external_id = EXCLUDED.external_id
+	`, upsert.PrincipalUID, upsert.ExternalID); err != nil {
+		return errors.Wrapf(err, "failed to upsert SCIM user %d", upsert.PrincipalUID)
+	}
+	return nil
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
-	"sync"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -14,7 +13,6 @@ import (
 	"github.com/bytebase/bytebase/backend/common"
 	api "github.com/bytebase/bytebase/backend/legacyapi"
 	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
-	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
 )
 
 // FindSettingMessage is the message for finding setting.
@@ -38,23 +36,6 @@ type SettingMessage struct {
 	CreatedTs   int64
 }
 
-var (
-	// Currently, we do not persist the algorithm setting, in order to use it conveniently, we hard code it here.
-	// TODO(zp): remove the following hard code when we persist the algorithm setting.
-	mockAlgorithmSetting = &v1pb.MaskingAlgorithmSetting{
-		Algorithms: []*v1pb.MaskingAlgorithmSetting_MaskingAlgorithm{
-			{
-				Id:    "substitution",
-				Title: "Substitution algorithm",
-			},
-			{
-				Id:    "hash-md5",
-				Title: "MD5 encryption",
-			},
-		},
-	}
-)
-
 // GetWorkspaceGeneralSetting gets the workspace general setting payload.
 func (s *Store) GetWorkspaceGeneralSetting(ctx context.Context) (*storepb.WorkspaceProfileSetting, error) {
 	settingName := api.SettingWorkspaceProfile
@@ -171,9 +152,49 @@ func (s *Store) GetDataClassificationSetting(ctx context.Context) (*storepb.Data
 	return payload, nil
 }
 
+// GetMaskingAlgorithmSetting gets the masking algorithm setting.
+func (s *Store) GetMaskingAlgorithmSetting(ctx context.Context) (*storepb.MaskingAlgorithmSetting, error) {
+	settingName := api.SettingMaskingAlgorithms
+	setting, err := s.GetSettingV2(ctx, &FindSettingMessage{
+		Name: &settingName,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get setting %s", settingName)
+	}
+	if setting == nil {
+		return &storepb.MaskingAlgorithmSetting{}, nil
+	}
+
+	payload := new(storepb.MaskingAlgorithmSetting)
+	if err := protojson.Unmarshal([]byte(setting.Value), payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// GetSemanticTypesSetting gets the semantic types setting.
+func (s *Store) GetSemanticTypesSetting(ctx context.Context) (*storepb.SemanticTypesSetting, error) {
+	settingName := api.SettingSemanticTypes
+	setting, err := s.GetSettingV2(ctx, &FindSettingMessage{
+		Name: &settingName,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get setting %s", settingName)
+	}
+	if setting == nil {
+		return &storepb.SemanticTypesSetting{}, nil
+	}
+
+	payload := new(storepb.SemanticTypesSetting)
+	if err := protojson.Unmarshal([]byte(setting.Value), payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
 // DeleteCache deletes the cache.
 func (s *Store) DeleteCache() {
-	s.settingCache = sync.Map{}
+	s.settingCache.clear()
 }
 
 // GetSettingV2 returns the setting by name.
@@ -184,27 +205,13 @@ func (s *Store) GetSettingV2(ctx context.Context, find *FindSettingMessage) (*Se
 		}
 	}
 
-	// TODO(zp): remove the following hard code when we persist the algorithm setting.
-	if find.Name != nil && *find.Name == api.SettingMaskingAlgorithms {
-		value, err := protojson.Marshal(mockAlgorithmSetting)
-		if err != nil {
-			return nil, err
-		}
-		setting := &SettingMessage{
-			Name:        api.SettingMaskingAlgorithms,
-			Value:       string(value),
-			Description: "",
-		}
-		return setting, nil
-	}
-
 	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	settings, err := listSettingV2Impl(ctx, tx, find)
+	settings, err := s.listSettingV2Impl(ctx, tx, find)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list setting")
 	}
@@ -228,7 +235,7 @@ func (s *Store) ListSettingV2(ctx context.Context, find *FindSettingMessage) ([]
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
-	settings, err := listSettingV2Impl(ctx, tx, find)
+	settings, err := s.listSettingV2Impl(ctx, tx, find)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list setting")
 	}
@@ -236,18 +243,6 @@ func (s *Store) ListSettingV2(ctx context.Context, find *FindSettingMessage) ([]
 		return nil, errors.Wrap(err, "failed to commit transaction")
 	}
 
-	// TODO(zp): remove the following hard code when we persist the algorithm setting.
-	value, err := protojson.Marshal(mockAlgorithmSetting)
-	if err != nil {
-		return nil, err
-	}
-	setting := &SettingMessage{
-		Name:        api.SettingMaskingAlgorithms,
-		Value:       string(value),
-		Description: "",
-	}
-	settings = append(settings, setting)
-
 	for _, setting := range settings {
 		s.settingCache.Store(setting.Name, setting)
 	}
@@ -260,9 +255,18 @@ func (s *Store) UpsertSettingV2(ctx context.Context, update *SetSettingMessage,
 		return nil, errors.New("cannot update masking algorithm setting")
 	}
 
+	value := update.Value
+	if secretSettings[update.Name] {
+		encrypted, err := s.encryptSecret(value)
+		if err != nil {
+			return nil, err
+		}
+		value = encrypted
+	}
+
 	fields := []string{"creator_id", "updater_id", "name", "value"}
 	updateFields := []string{"value = EXCLUDED.value", "updater_id = EXCLUDED.updater_id"}
-	valuePlaceholders, args := []string{"$1", "$2", "$3", "$4"}, []any{principalUID, principalUID, update.Name, update.Value}
+	valuePlaceholders, args := []string{"$1", "$2", "$3", "$4"}, []any{principalUID, principalUID, update.Name, value}
 
 	if v := update.Description; v != nil {
 		fields = append(fields, "description")
@@ -293,6 +297,11 @@ func (s *Store) UpsertSettingV2(ctx context.Context, update *SetSettingMessage,
 		}
 		return nil, err
 	}
+	if secretSettings[setting.Name] {
+		if err := s.decryptSecrets(ctx, &setting.Value); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrap(err, "failed to commit transaction")
@@ -303,11 +312,6 @@ func (s *Store) UpsertSettingV2(ctx context.Context, update *SetSettingMessage,
 
 // CreateSettingIfNotExistV2 creates a new setting only if the named setting doesn't exist.
 func (s *Store) CreateSettingIfNotExistV2(ctx context.Context, create *SettingMessage, principalUID int) (*SettingMessage, bool, error) {
-	// TODO(zp): remove the following hard code when we persist the algorithm setting.
-	if create.Name == api.SettingMaskingAlgorithms {
-		return nil, false, errors.New("cannot create masking algorithm setting")
-	}
-
 	if setting, ok := s.settingCache.Load(create.Name); ok {
 		return setting.(*SettingMessage), false, nil
 	}
@@ -317,7 +321,7 @@ func (s *Store) CreateSettingIfNotExistV2(ctx context.Context, create *SettingMe
 		return nil, false, errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
-	settings, err := listSettingV2Impl(ctx, tx, &FindSettingMessage{Name: &create.Name})
+	settings, err := s.listSettingV2Impl(ctx, tx, &FindSettingMessage{Name: &create.Name})
 	if err != nil {
 		return nil, false, errors.Wrap(err, "failed to list settings")
 	}
@@ -329,8 +333,16 @@ func (s *Store) CreateSettingIfNotExistV2(ctx context.Context, create *SettingMe
 		return settings[0], false, nil
 	}
 
+	value := create.Value
+	if secretSettings[create.Name] {
+		encrypted, err := s.encryptSecret(value)
+		if err != nil {
+			return nil, false, err
+		}
+		value = encrypted
+	}
 	fields := []string{"creator_id", "updater_id", "name", "value", "description"}
-	valuesPlaceholders, args := []string{"$1", "$2", "$3", "$4", "$5"}, []any{principalUID, principalUID, create.Name, create.Value, create.Description}
+	valuesPlaceholders, args := []string{"$1", "$2", "$3", "$4", "$5"}, []any{principalUID, principalUID, create.Name, value, create.Description}
 
 	query := `INSERT INTO setting (` + strings.Join(fields, ",") + `)
 		VALUES (` + strings.Join(valuesPlaceholders, ",") + `)
@@ -344,6 +356,11 @@ func (s *Store) CreateSettingIfNotExistV2(ctx context.Context, create *SettingMe
 	); err != nil {
 		return nil, false, err
 	}
+	if secretSettings[setting.Name] {
+		if err := s.decryptSecrets(ctx, &setting.Value); err != nil {
+			return nil, false, err
+		}
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, false, errors.Wrap(err, "failed to commit transaction")
@@ -354,11 +371,6 @@ func (s *Store) CreateSettingIfNotExistV2(ctx context.Context, create *SettingMe
 
 // DeleteSettingV2 deletes a setting by the name.
 func (s *Store) DeleteSettingV2(ctx context.Context, name api.SettingName) error {
-	// TODO(zp): remove the following hard code when we persist the algorithm setting.
-	if name == api.SettingMaskingAlgorithms {
-		return errors.New("cannot delete masking algorithm setting")
-	}
-
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
@@ -377,7 +389,7 @@ func (s *Store) DeleteSettingV2(ctx context.Context, name api.SettingName) error
 	return nil
 }
 
-func listSettingV2Impl(ctx context.Context, tx *Tx, find *FindSettingMessage) ([]*SettingMessage, error) {
+func (s *Store) listSettingV2Impl(ctx context.Context, tx *Tx, find *FindSettingMessage) ([]*SettingMessage, error) {
 	where, args := []string{"TRUE"}, []any{}
 	if v := find.Name; v != nil {
 		where, args = append(where, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
@@ -406,6 +418,11 @@ func listSettingV2Impl(ctx context.Context, tx *Tx, find *FindSettingMessage) ([
 		); err != nil {
 			return nil, err
 		}
+		if secretSettings[settingMessage.Name] {
+			if err := s.decryptSecrets(ctx, &settingMessage.Value); err != nil {
+				return nil, err
+			}
+		}
 		settingMessages = append(settingMessages, &settingMessage)
 	}
 	if err := rows.Err(); err != nil {
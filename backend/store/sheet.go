@@ -133,8 +133,10 @@ type PatchSheetMessage struct {
 
 // GetSheetStatementByID gets the statement of a sheet by ID.
 func (s *Store) GetSheetStatementByID(ctx context.Context, id int) (string, error) {
-	if statement, ok := s.sheetStatementCache.Get(id); ok {
-		return statement.(string), nil
+	if !s.bypassCache {
+		if statement, ok := s.sheetStatementCache.Get(id); ok {
+			return statement.(string), nil
+		}
 	}
 
 	sheets, err := s.ListSheets(ctx, &FindSheetMessage{UID: &id, LoadFull: true}, SystemBotID)
@@ -370,6 +372,9 @@ func (s *Store) CreateSheet(ctx context.Context, create *SheetMessage) (*SheetMe
 		}
 		return nil, err
 	}
+	if err := createSheetRevisionImpl(ctx, tx, create.UID, create.CreatorID, create.Statement); err != nil {
+		return nil, err
+	}
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
 	}
@@ -386,11 +391,18 @@ func (s *Store) PatchSheet(ctx context.Context, patch *PatchSheetMessage) (*Shee
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to begin transaction")
 	}
+	defer tx.Rollback()
 
 	sheet, err := patchSheetImpl(ctx, tx, patch)
 	if err != nil {
 		return nil, err
 	}
+	if v := patch.Statement; v != nil {
+		// The sheet row is locked by the update, so the revisions are appended sequentially.
+		if err := createSheetRevisionImpl(ctx, tx, patch.UID, patch.UpdaterID, *v); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
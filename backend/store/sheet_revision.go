@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+)
+
+// SheetRevisionMessage is the message for an immutable revision of the sheet statement.
+type SheetRevisionMessage struct {
+	SheetUID int
+	// Revision is the sequence number of the revision in the sheet, starting from 1.
+	Revision  int
+	CreatorID int
+	Statement string
+
+	// Output only fields
+	UID         int
+	Size        int64
+	CreatedTime time.Time
+}
+
+// FindSheetRevisionMessage is the message for finding sheet revisions.
+type FindSheetRevisionMessage struct {
+	SheetUID int
+	Revision *int
+
+	// LoadFull is used if we want to load the full statement.
+	LoadFull bool
+	Limit    *int
+	Offset   *int
+}
+
+// GetSheetRevision gets a sheet revision.
+func (s *Store) GetSheetRevision(ctx context.Context, find *FindSheetRevisionMessage) (*SheetRevisionMessage, error) {
+	revisions, err := s.ListSheetRevisions(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+	if len(revisions) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d sheet revisions with filter %+v, expect 1", len(revisions), find)}
+	}
+	return revisions[0], nil
+}
+
+// GetLatestSheetRevision gets the latest revision of the sheet with the full statement.
+// It returns nil if the sheet has no revision.
+func (s *Store) GetLatestSheetRevision(ctx context.Context, sheetUID int) (*SheetRevisionMessage, error) {
+	limit := 1
+	return s.GetSheetRevision(ctx, &FindSheetRevisionMessage{
+		SheetUID: sheetUID,
+		LoadFull: true,
+		Limit:    &limit,
+	})
+}
+
+// ListSheetRevisions lists the revisions of a sheet, the latest first.
+func (s *Store) ListSheetRevisions(ctx context.Context, find *FindSheetRevisionMessage) ([]*SheetRevisionMessage, error) {
+	where, args := []string{"sheet_id = $1"}, []any{find.SheetUID}
+	if v := find.Revision; v != nil {
+		where, args = append(where, fmt.Sprintf("revision = $%d", len(args)+1)), append(args, *v)
+	}
+	statementField := fmt.Sprintf("LEFT(statement, %d)", common.MaxSheetSize)
+	if find.LoadFull {
+		statementField = "statement"
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			sheet_id,
+			revision,
+			creator_id,
+			created_ts,
+			%s,
+			LENGTH(statement)
+		FROM sheet_revision
+		WHERE %s
+		ORDER BY revision DESC
+	`, statementField, strings.Join(where, " AND "))
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+	if v := find.Offset; v != nil {
+		query += fmt.Sprintf(" OFFSET %d", *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query")
+	}
+	defer rows.Close()
+
+	var revisions []*SheetRevisionMessage
+	for rows.Next() {
+		var revision SheetRevisionMessage
+		var createdTs int64
+		if err := rows.Scan(
+			&revision.UID,
+			&revision.SheetUID,
+			&revision.Revision,
+			&revision.CreatorID,
+			&createdTs,
+			&revision.Statement,
+			&revision.Size,
+		); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan")
+		}
+		revision.CreatedTime = time.Unix(createdTs, 0)
+		revisions = append(revisions, &revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to scan")
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return revisions, nil
+}
+
+// PinTaskSheetRevisions pins the latest revisions of the task sheets in the pipeline to the task payloads,
+// so that the tasks execute the statements approved in the issue even if the sheets are edited afterwards.
+func (s *Store) PinTaskSheetRevisions(ctx context.Context, pipelineUID int) error {
+	if _, err := s.db.db.ExecContext(ctx, `
+		UPDATE task
+		SET payload = task.payload || jsonb_build_object('sheetRevision', latest.revision)
+		FROM (
+			SELECT sheet_id, MAX(revision) AS revision
+			FROM sheet_revision
+			WHERE sheet_id IN (
+				SELECT (payload->>'sheetId')::INT FROM task WHERE pipeline_id = $1 AND payload->>'sheetId' IS NOT NULL
+			)
+			GROUP BY sheet_id
+		) AS latest
+		WHERE task.pipeline_id = $1 AND (task.payload->>'sheetId')::INT = latest.sheet_id
+	`, pipelineUID); err != nil {
+		return errors.Wrapf(err, "failed to pin sheet revisions of pipeline %d", pipelineUID)
+	}
+	return nil
+}
+
+// createSheetRevisionImpl appends a revision to the sheet if the statement differs from the latest revision.
+// The caller must hold the row lock of the sheet, so that the revisions of a sheet are created sequentially.
+func createSheetRevisionImpl(ctx context.Context, tx *Tx, sheetUID, creatorID int, statement string) error {
+	var latestRevision int
+	var unchanged bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT revision, statement = $2
+		FROM sheet_revision
+		WHERE sheet_id = $1
+		ORDER BY revision DESC
+		LIMIT 1
+	`, sheetUID, statement).Scan(&latestRevision, &unchanged); err != nil && err != sql.ErrNoRows {
+		return errors.Wrapf(err, "failed to get the latest revision of sheet %d", sheetUID)
+	}
+	if unchanged {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO sheet_revision (
+			sheet_id,
+			creator_id,
+			revision,
+			statement
+		)
+		VALUES ($1, $2, $3, $4)
+	`, sheetUID, creatorID, latestRevision+1, statement); err != nil {
+		return errors.Wrapf(err, "failed to create revision of sheet %d", sheetUID)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// SlowQuerySnapshotMessage is the snapshot of the cumulative query statistics of an instance.
+// It is used by the engines which only expose the statistics since the statement is cached, e.g. SQL Server and Oracle.
+type SlowQuerySnapshotMessage struct {
+	InstanceUID int
+	// LogDate is the date whose slow query statistics are the deltas from the baseline.
+	LogDate time.Time
+	// Baseline is the cumulative statistics at the beginning of the log date of the statements executed on the log date, keyed by the database name.
+	Baseline map[string]*storepb.SlowQueryStatistics
+	// Latest is the cumulative statistics at the last sync, keyed by the database name.
+	Latest map[string]*storepb.SlowQueryStatistics
+
+	// Output only fields
+	UpdatedTime time.Time
+}
+
+// GetSlowQuerySnapshot gets the slow query snapshot of the instance.
+// It returns nil if the instance has no snapshot.
+func (s *Store) GetSlowQuerySnapshot(ctx context.Context, instanceUID int) (*SlowQuerySnapshotMessage, error) {
+	snapshot := &SlowQuerySnapshotMessage{
+		InstanceUID: instanceUID,
+	}
+	var updatedTs int64
+	var logDate int
+	var baseline, latest []byte
+	if err := s.db.db.QueryRowContext(ctx, `
+		SELECT
+			updated_ts,
+			log_date_ts,
+			baseline,
+			latest
+		FROM slow_query_snapshot
+		WHERE instance_id = $1
+	`, instanceUID).Scan(
+		&updatedTs,
+		&logDate,
+		&baseline,
+		&latest,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get slow query snapshot")
+	}
+	snapshot.UpdatedTime = time.Unix(updatedTs, 0)
+	logDateTime, err := time.Parse("20060102", strconv.Itoa(logDate))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse log date %d", logDate)
+	}
+	snapshot.LogDate = logDateTime
+	if snapshot.Baseline, err = unmarshalSlowQueryStatisticsMap(baseline); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal baseline")
+	}
+	if snapshot.Latest, err = unmarshalSlowQueryStatisticsMap(latest); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal latest")
+	}
+	return snapshot, nil
+}
+
+// UpsertSlowQuerySnapshot upserts the slow query snapshot of the instance.
+func (s *Store) UpsertSlowQuerySnapshot(ctx context.Context, snapshot *SlowQuerySnapshotMessage) error {
+	logDate, err := strconv.Atoi(snapshot.LogDate.Format("20060102"))
+	if err != nil {
+		return err
+	}
+	baseline, err := marshalSlowQueryStatisticsMap(snapshot.Baseline)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal baseline")
+	}
+	latest, err := marshalSlowQueryStatisticsMap(snapshot.Latest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal latest")
+	}
+	if _, err := s.db.db.ExecContext(ctx, `
+		INSERT INTO slow_query_snapshot (
+			instance_id,
+			log_date_ts,
+			baseline,
+			latest
+		) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (instance_id) DO UPDATE SET
+			updated_ts = extract(epoch from now()),
+			log_date_ts = EXCLUDED.log_date_ts,
+			baseline = EXCLUDED.baseline,
+			latest = EXCLUDED.latest
+	`, snapshot.InstanceUID, logDate, baseline, latest); err != nil {
+		return errors.Wrapf(err, "failed to upsert slow query snapshot")
+	}
+	return nil
+}
+
+// DeleteOutdatedSlowQuerySnapshot deletes the slow query snapshots not updated since the earliest time.
+func (s *Store) DeleteOutdatedSlowQuerySnapshot(ctx context.Context, earliest time.Time) error {
+	if _, err := s.db.db.ExecContext(ctx, `
+		DELETE FROM slow_query_snapshot
+		WHERE updated_ts < $1
+	`, earliest.Unix()); err != nil {
+		return errors.Wrapf(err, "failed to delete outdated slow query snapshots")
+	}
+	return nil
+}
+
+func marshalSlowQueryStatisticsMap(m map[string]*storepb.SlowQueryStatistics) ([]byte, error) {
+	raw := make(map[string]json.RawMessage)
+	for database, statistics := range m {
+		bytes, err := protojson.Marshal(statistics)
+		if err != nil {
+			return nil, err
+		}
+		raw[database] = bytes
+	}
+	return json.Marshal(raw)
+}
+
+func unmarshalSlowQueryStatisticsMap(bytes []byte) (map[string]*storepb.SlowQueryStatistics, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return nil, err
+	}
+	m := make(map[string]*storepb.SlowQueryStatistics)
+	for database, bytes := range raw {
+		statistics := &storepb.SlowQueryStatistics{}
+		if err := protojsonUnmarshaler.Unmarshal(bytes, statistics); err != nil {
+			return nil, err
+		}
+		m[database] = statistics
+	}
+	return m, nil
+}
@@ -5,6 +5,11 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
 )
 
 // StageMessage is the message for stage.
@@ -13,6 +18,7 @@ type StageMessage struct {
 	EnvironmentID int
 	PipelineID    int
 	TaskList      []*TaskMessage
+	Payload       *storepb.StagePayload
 
 	// Output only.
 	ID int
@@ -40,15 +46,24 @@ func (s *Store) CreateStageV2(ctx context.Context, stagesCreate []*StageMessage,
 	var valueStr []string
 	var values []any
 	for i, create := range stagesCreate {
+		payload := create.Payload
+		if payload == nil {
+			payload = &storepb.StagePayload{}
+		}
+		payloadBytes, err := protojson.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal stage payload")
+		}
 		values = append(values,
 			creatorID,
 			creatorID,
 			create.PipelineID,
 			create.EnvironmentID,
 			create.Name,
+			payloadBytes,
 		)
-		const count = 5
-		valueStr = append(valueStr, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", i*count+1, i*count+2, i*count+3, i*count+4, i*count+5))
+		const count = 6
+		valueStr = append(valueStr, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d)", i*count+1, i*count+2, i*count+3, i*count+4, i*count+5, i*count+6))
 	}
 
 	query := fmt.Sprintf(`
@@ -58,9 +73,10 @@ func (s *Store) CreateStageV2(ctx context.Context, stagesCreate []*StageMessage,
 	  		updater_id,
 	  		pipeline_id,
 	  		environment_id,
-	  		name
+	  		name,
+	  		payload
 	  	) VALUES %s
-	  	RETURNING id, pipeline_id, environment_id, name
+	  	RETURNING id, pipeline_id, environment_id, name, payload
     ) SELECT * FROM inserted ORDER BY id ASC
     `, strings.Join(valueStr, ","))
 	rows, err := tx.QueryContext(ctx, query, values...)
@@ -72,14 +88,21 @@ func (s *Store) CreateStageV2(ctx context.Context, stagesCreate []*StageMessage,
 	var stages []*StageMessage
 	for rows.Next() {
 		var stage StageMessage
+		var payload []byte
 		if err := rows.Scan(
 			&stage.ID,
 			&stage.PipelineID,
 			&stage.EnvironmentID,
 			&stage.Name,
+			&payload,
 		); err != nil {
 			return nil, err
 		}
+		stagePayload := &storepb.StagePayload{}
+		if err := protojson.Unmarshal(payload, stagePayload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal stage payload")
+		}
+		stage.Payload = stagePayload
 		stages = append(stages, &stage)
 	}
 	if err := rows.Err(); err != nil {
@@ -109,7 +132,8 @@ func (s *Store) ListStageV2(ctx context.Context, pipelineUID int) ([]*StageMessa
 			stage.id,
 			stage.pipeline_id,
 			stage.environment_id,
-			stage.name
+			stage.name,
+			stage.payload
 		FROM stage
 		WHERE %s ORDER BY id ASC`, strings.Join(where, " AND ")),
 		args...,
@@ -122,14 +146,21 @@ func (s *Store) ListStageV2(ctx context.Context, pipelineUID int) ([]*StageMessa
 	var stages []*StageMessage
 	for rows.Next() {
 		var stage StageMessage
+		var payload []byte
 		if err := rows.Scan(
 			&stage.ID,
 			&stage.PipelineID,
 			&stage.EnvironmentID,
 			&stage.Name,
+			&payload,
 		); err != nil {
 			return nil, err
 		}
+		stagePayload := &storepb.StagePayload{}
+		if err := protojson.Unmarshal(payload, stagePayload); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal stage payload")
+		}
+		stage.Payload = stagePayload
 
 		stages = append(stages, &stage)
 	}
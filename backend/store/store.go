@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 
 	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/plugin/kms"
 )
 
 var (
@@ -23,32 +24,41 @@ var (
 type Store struct {
 	db *DB
 
-	userIDCache                    sync.Map         // map[int]*UserMessage
-	environmentCache               sync.Map         // map[string]*EnvironmentMessage
-	environmentIDCache             sync.Map         // map[int]*EnvironmentMessage
-	instanceCache                  sync.Map         // map[string]*InstanceMessage
-	instanceIDCache                sync.Map         // map[int]*InstanceMessage
-	databaseCache                  sync.Map         // map[string]*DatabaseMessage
-	databaseIDCache                sync.Map         // map[int]*DatabaseMessage
-	projectCache                   sync.Map         // map[string]*ProjectMessage
-	projectIDCache                 sync.Map         // map[int]*ProjectMessage
-	projectPolicyCache             sync.Map         // map[string]*IAMPolicyMessage
-	projectIDPolicyCache           sync.Map         // map[int]*IAMPolicyMessage
-	policyCache                    sync.Map         // map[string]*PolicyMessage
-	issueCache                     sync.Map         // map[int]*IssueMessage
-	issueByPipelineCache           sync.Map         // map[int]*IssueMessage
-	pipelineCache                  sync.Map         // map[int]*PipelineMessage
+	userIDCache                    storeCache       // map[int]*UserMessage
+	environmentCache               storeCache       // map[string]*EnvironmentMessage
+	environmentIDCache             storeCache       // map[int]*EnvironmentMessage
+	instanceCache                  storeCache       // map[string]*InstanceMessage
+	instanceIDCache                storeCache       // map[int]*InstanceMessage
+	databaseCache                  storeCache       // map[string]*DatabaseMessage
+	databaseIDCache                storeCache       // map[int]*DatabaseMessage
+	projectCache                   storeCache       // map[string]*ProjectMessage
+	projectIDCache                 storeCache       // map[int]*ProjectMessage
+	projectPolicyCache             storeCache       // map[string]*IAMPolicyMessage
+	projectIDPolicyCache           storeCache       // map[int]*IAMPolicyMessage
+	policyCache                    storeCache       // map[string]*PolicyMessage
+	issueCache                     storeCache       // map[int]*IssueMessage
+	issueByPipelineCache           storeCache       // map[int]*IssueMessage
+	pipelineCache                  storeCache       // map[int]*PipelineMessage
 	dbSchemaCache                  *ristretto.Cache // map[int]*DBSchema
-	settingCache                   sync.Map         // map[string]*SettingMessage
-	idpCache                       sync.Map         // map[string]*IdentityProvider
-	projectIDDeploymentConfigCache sync.Map         // map[int]*DeploymentConfigMessage
-	risksCache                     sync.Map         // []*RiskMessage, use 0 as the key
-	databaseGroupCache             sync.Map         // map[string]*DatabaseGroupMessage
-	databaseGroupIDCache           sync.Map         // map[int]*DatabaseGroupMessage
-	schemaGroupCache               sync.Map         // map[string]*SchemaGroupMessage
+	settingCache                   storeCache       // map[string]*SettingMessage
+	idpCache                       storeCache       // map[string]*IdentityProvider
+	projectIDDeploymentConfigCache storeCache       // map[int]*DeploymentConfigMessage
+	risksCache                     storeCache       // []*RiskMessage, use 0 as the key
+	databaseGroupCache             storeCache       // map[string]*DatabaseGroupMessage
+	databaseGroupIDCache           storeCache       // map[int]*DatabaseGroupMessage
+	schemaGroupCache               storeCache       // map[string]*SchemaGroupMessage
 	// sheetStatementCache caches the statement of a sheet.
 	sheetStatementCache *ristretto.Cache // map[sheetUID]sheetStatementString
-	vcsIDCache          sync.Map         // map[int]*ExternalVersionControlMessage
+	vcsIDCache          storeCache       // map[int]*ExternalVersionControlMessage
+	// bypassCache is set in high-availability mode, see BypassCache.
+	bypassCache bool
+
+	// The secrets are encrypted with secretEnvelope if the key provider is configured.
+	keyProvider     kms.KeyProvider
+	keyProviderType kms.Provider
+	secretEnvelope  *kms.Envelope
+	// dataKeyMu serializes refreshing the data keys of secretEnvelope.
+	dataKeyMu sync.Mutex
 }
 
 // New creates a new instance of Store.
@@ -45,6 +45,8 @@ type TaskMessage struct {
 	Statement string
 
 	LatestTaskRunStatus api.TaskRunStatus
+	// Skipped is true if the task is skipped by the users.
+	Skipped bool
 }
 
 // GetTaskV2ByID gets a task by ID.
@@ -231,6 +233,7 @@ func (s *Store) ListTasks(ctx context.Context, find *api.TaskFind) ([]*TaskMessa
 			COALESCE(latest_task_run.status, 'NOT_STARTED') AS latest_task_run_status,
 			task.type,
 			task.payload,
+			COALESCE((task.payload->>'skipped')::BOOLEAN, FALSE) AS skipped,
 			task.earliest_allowed_ts,
 			(SELECT ARRAY_AGG (task_dag.from_task_id) FROM task_dag WHERE task_dag.to_task_id = task.id) blocked_by
 		FROM task
@@ -270,6 +273,7 @@ func (s *Store) ListTasks(ctx context.Context, find *api.TaskFind) ([]*TaskMessa
 			&task.LatestTaskRunStatus,
 			&task.Type,
 			&task.Payload,
+			&task.Skipped,
 			&task.EarliestAllowedTs,
 			&blockedBy,
 		); err != nil {
@@ -305,6 +309,8 @@ func (s *Store) UpdateTaskV2(ctx context.Context, patch *api.TaskPatch) (*TaskMe
 	var payloadSet []string
 	if v := patch.SheetID; v != nil {
 		payloadSet, args = append(payloadSet, fmt.Sprintf(`jsonb_build_object('sheetId', to_jsonb($%d::INT))`, len(args)+1)), append(args, *v)
+		// The revision of the new sheet is pinned when the issue is approved again.
+		payloadSet = append(payloadSet, `jsonb_build_object('sheetRevision', 0)`)
 	}
 	if v := patch.SchemaVersion; v != nil {
 		payloadSet, args = append(payloadSet, fmt.Sprintf(`jsonb_build_object('schemaVersion', to_jsonb($%d::TEXT))`, len(args)+1)), append(args, *v)
@@ -69,6 +69,10 @@ type TaskRunStatusPatch struct {
 	Status api.TaskRunStatus
 	Code   *common.Code
 	Result *string
+
+	// ExpectedStatus makes the patch conditional on the current status of the task run.
+	// If the status doesn't match, e.g. another replica has claimed the task run, the patch is skipped and nil is returned.
+	ExpectedStatus *api.TaskRunStatus
 }
 
 // ListTaskRunsV2 lists task runs.
@@ -177,6 +181,7 @@ func (s *Store) ListTaskRunsV2(ctx context.Context, find *FindTaskRunMessage) ([
 }
 
 // UpdateTaskRunStatus updates task run status.
+// It returns nil if the patch has an expected status which doesn't match the current one.
 func (s *Store) UpdateTaskRunStatus(ctx context.Context, patch *TaskRunStatusPatch) (*TaskRunMessage, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -328,6 +333,7 @@ func (*Store) createTaskRunImpl(ctx context.Context, tx *Tx, create *TaskRunMess
 }
 
 // patchTaskRunStatusImpl updates a taskRun status. Returns the new state of the taskRun after update.
+// It returns nil if the patch has an expected status which doesn't match the current one.
 func (*Store) patchTaskRunStatusImpl(ctx context.Context, tx *Tx, patch *TaskRunStatusPatch) (*TaskRunMessage, error) {
 	set, args := []string{"updater_id = $1"}, []any{patch.UpdaterID}
 	set, args = append(set, "status = $2"), append(args, patch.Status)
@@ -345,6 +351,9 @@ func (*Store) patchTaskRunStatusImpl(ctx context.Context, tx *Tx, patch *TaskRun
 	// Build WHERE clause.
 	where := []string{"TRUE"}
 	where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, patch.ID)
+	if v := patch.ExpectedStatus; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
 
 	var taskRun TaskRunMessage
 	if err := tx.QueryRowContext(ctx, `
@@ -367,6 +376,9 @@ func (*Store) patchTaskRunStatusImpl(ctx context.Context, tx *Tx, patch *TaskRun
 		&taskRun.Result,
 	); err != nil {
 		if err == sql.ErrNoRows {
+			if patch.ExpectedStatus != nil {
+				return nil, nil
+			}
 			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("project ID not found: %d", patch.ID)}
 		}
 		return nil, err
@@ -0,0 +1,415 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgtype"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+)
+
+// UserGroupMessage is the message for a user group.
+type UserGroupMessage struct {
+	// Name is the display name of the group, which is unique in the workspace.
+	Name string
+	// ExternalID is the identifier of the group in the provisioning client.
+	ExternalID string
+	// Email is the identifier of the group in the IAM bindings, e.g. group:dba@example.com.
+	// The groups provisioned by the SCIM clients may have no email.
+	Email       string
+	Description string
+	// IdentityProviderID is the resource ID of the identity provider whose users sync the group membership on login.
+	IdentityProviderID string
+	// IdentityProviderGroup is the identifier of the group in the identity provider, e.g. the DN of the LDAP group.
+	IdentityProviderGroup string
+	// MemberUIDs is the list of unique IDs of the group members.
+	MemberUIDs []int
+
+	// Output only fields.
+	//
+	// UID is the unique ID of the group.
+	UID        int
+	CreatorUID int
+	CreatedTs  int64
+	UpdatedTs  int64
+}
+
+// FindUserGroupMessage is the message for finding user groups.
+type FindUserGroupMessage struct {
+	UID        *int
+	Name       *string
+	ExternalID *string
+	Email      *string
+	MemberUID  *int
+	// IdentityProviderID finds the groups linked to the identity provider.
+	IdentityProviderID *string
+}
+
+// UpdateUserGroupMessage is the message for updating a user group.
+type UpdateUserGroupMessage struct {
+	Name                  *string
+	ExternalID            *string
+	Email                 *string
+	Description           *string
+	IdentityProviderID    *string
+	IdentityProviderGroup *string
+	// MemberUIDs replaces the members of the group if set.
+	MemberUIDs *[]int
+	// AddMemberUIDs and RemoveMemberUIDs add and remove the members without touching the others,
+	// so that the concurrent updates of different members don't overwrite each other.
+	AddMemberUIDs    []int
+	RemoveMemberUIDs []int
+}
+
+// GetUserGroup gets a user group.
+func (s *Store) GetUserGroup(ctx context.Context, find *FindUserGroupMessage) (*UserGroupMessage, error) {
+	userGroups, err := s.ListUserGroups(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(userGroups) == 0 {
+		return nil, nil
+	}
+	if len(userGroups) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d user groups with filter %+v, expect 1", len(userGroups), find)}
+	}
+	return userGroups[0], nil
+}
+
+// ListUserGroups lists user groups.
+func (s *Store) ListUserGroups(ctx context.Context, find *FindUserGroupMessage) ([]*UserGroupMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("user_group.id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Name; v != nil {
+		where, args = append(where, fmt.Sprintf("user_group.name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ExternalID; v != nil {
+		where, args = append(where, fmt.Sprintf("user_group.external_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Email; v != nil {
+		where, args = append(where, fmt.Sprintf("user_group.email = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.IdentityProviderID; v != nil {
+		where, args = append(where, fmt.Sprintf("user_group.idp_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.MemberUID; v != nil {
+		where, args = append(where, fmt.Sprintf("EXISTS (SELECT 1 FROM user_group_member WHERE user_group_member.group_id = user_group.id AND user_group_member.principal_id = $%d)", len(args)+1)), append(args, *v)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			user_group.id,
+			user_group.creator_id,
+			user_group.created_ts,
+			user_group.updated_ts,
+			user_group.name,
+			user_group.external_id,
+			user_group.email,
+			user_group.description,
+			user_group.idp_id,
+			user_group.idp_group,
+			ARRAY(
+				SELECT user_group_member.principal_id
+				FROM user_group_member
+				WHERE user_group_member.group_id = user_group.id
+				ORDER BY user_group_member.principal_id
+			)
+		FROM user_group
+		WHERE %s
+		ORDER BY user_group.id`, strings.Join(where, " AND "))
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query user groups")
+	}
+	defer rows.Close()
+
+	var userGroups []*UserGroupMessage
+	for rows.Next() {
+		var userGroup UserGroupMessage
+		var memberUIDs pgtype.Int4Array
+		if err := rows.Scan(
+			&userGroup.UID,
+			&userGroup.CreatorUID,
+			&userGroup.CreatedTs,
+			&userGroup.UpdatedTs,
+			&userGroup.Name,
+			&userGroup.ExternalID,
+			&userGroup.Email,
+			&userGroup.Description,
+			&userGroup.IdentityProviderID,
+			&userGroup.IdentityProviderGroup,
+			&memberUIDs,
+		); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan user group")
+		}
+		if err := memberUIDs.AssignTo(&userGroup.MemberUIDs); err != nil {
+			return nil, err
+		}
+		userGroups = append(userGroups, &userGroup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate user groups")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+
+	return userGroups, nil
+}
+
+// CreateUserGroup creates a user group with its members.
+func (s *Store) CreateUserGroup(ctx context.Context, create *UserGroupMessage, creatorUID int) (*UserGroupMessage, error) {
+	query := `
+		INSERT INTO user_group (
+			creator_id,
+			updater_id,
+			name,
+			external_id,
+			email,
+			description,
+			idp_id,
+			idp_group
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_ts, updated_ts
+	`
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	userGroup := &UserGroupMessage{
+		Name:        create.Name,
+		ExternalID:  create.ExternalID,
+		Email:       create.Email,
+		Description: create.Description,
+		CreatorUID:  creatorUID,
+
+		IdentityProviderID:    create.IdentityProviderID,
+		IdentityProviderGroup: create.IdentityProviderGroup,
+	}
+	if err := tx.QueryRowContext(ctx, query,
+		creatorUID,
+		creatorUID,
+		create.Name,
+		create.ExternalID,
+		create.Email,
+		create.Description,
+		create.IdentityProviderID,
+		create.IdentityProviderGroup,
+	).Scan(
+		&userGroup.UID,
+		&userGroup.CreatedTs,
+		&userGroup.UpdatedTs,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, errors.Wrapf(err, "failed to query row")
+	}
+	memberUIDs, err := setUserGroupMembersImpl(ctx, tx, userGroup.UID, create.MemberUIDs)
+	if err != nil {
+		return nil, err
+	}
+	userGroup.MemberUIDs = memberUIDs
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+
+	return userGroup, nil
+}
+
+// UpdateUserGroup updates a user group.
+func (s *Store) UpdateUserGroup(ctx context.Context, uid int, patch *UpdateUserGroupMessage, updaterUID int) (*UserGroupMessage, error) {
+	set, args := []string{"updater_id = $1"}, []any{updaterUID}
+	if v := patch.Name; v != nil {
+		set, args = append(set, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.ExternalID; v != nil {
+		set, args = append(set, fmt.Sprintf("external_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.Email; v != nil {
+		set, args = append(set, fmt.Sprintf("email = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.Description; v != nil {
+		set, args = append(set, fmt.Sprintf("description = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.IdentityProviderID; v != nil {
+		set, args = append(set, fmt.Sprintf("idp_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := patch.IdentityProviderGroup; v != nil {
+		set, args = append(set, fmt.Sprintf("idp_group = $%d", len(args)+1)), append(args, *v)
+	}
+	args = append(args, uid)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE user_group
+		SET %s
+		WHERE id = $%d
+	`, strings.Join(set, ", "), len(args)), args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update user group %d", uid)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("user group not found: %d", uid)}
+	}
+	if v := patch.MemberUIDs; v != nil {
+		if _, err := setUserGroupMembersImpl(ctx, tx, uid, *v); err != nil {
+			return nil, err
+		}
+	}
+	if v := patch.RemoveMemberUIDs; len(v) > 0 {
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM user_group_member WHERE group_id = $1 AND principal_id = ANY(CAST($2 AS INTEGER[]))
+		`, uid, v); err != nil {
+			return nil, errors.Wrapf(err, "failed to remove members from user group %d", uid)
+		}
+	}
+	if v := patch.AddMemberUIDs; len(v) > 0 {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO user_group_member (group_id, principal_id)
+			SELECT DISTINCT $1::INTEGER, unnest(CAST($2 AS INTEGER[]))
+			ON CONFLICT DO NOTHING
+		`, uid, v); err != nil {
+			return nil, errors.Wrapf(err, "failed to add members to user group %d", uid)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	// The project IAM policies contain the group members.
+	s.projectIDPolicyCache.clear()
+	s.projectPolicyCache.clear()
+
+	return s.GetUserGroup(ctx, &FindUserGroupMessage{UID: &uid})
+}
+
+// DeleteUserGroup deletes a user group and its memberships.
+func (s *Store) DeleteUserGroup(ctx context.Context, uid int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM user_group WHERE id = $1`, uid)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete user group %d", uid)
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return &common.Error{Code: common.NotFound, Err: errors.Errorf("user group not found: %d", uid)}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	s.projectIDPolicyCache.clear()
+	s.projectPolicyCache.clear()
+	return nil
+}
+
+// SyncUserGroupMemberships syncs the group membership of the user from the identity provider on login.
+// The user is added to the groups, and removed from the other groups that the identity provider has added the user to.
+// The memberships added by the others are never removed.
+func (s *Store) SyncUserGroupMemberships(ctx context.Context, identityProviderID string, principalUID int, groupUIDs []int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	removed, err := tx.ExecContext(ctx, `
+		DELETE FROM user_group_member
+		WHERE principal_id = $1 AND idp_id = $2 AND group_id != ALL(CAST($3 AS INTEGER[]))
+	`, principalUID, identityProviderID, groupUIDs)
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove user %d from groups", principalUID)
+	}
+	added, err := tx.ExecContext(ctx, `
+		INSERT INTO user_group_member (group_id, principal_id, idp_id)
+		SELECT DISTINCT unnest(CAST($1 AS INTEGER[])), $2::INTEGER, $3::TEXT
+		ON CONFLICT DO NOTHING
+	`, groupUIDs, principalUID, identityProviderID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add user %d to groups", principalUID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrapf(err, "failed to commit transaction")
+	}
+	removedRows, _ := removed.RowsAffected()
+	addedRows, _ := added.RowsAffected()
+	if removedRows > 0 || addedRows > 0 {
+		s.projectIDPolicyCache.clear()
+		s.projectPolicyCache.clear()
+	}
+	return nil
+}
+
+// setUserGroupMembersImpl replaces the members of the group and returns the sorted unique member IDs.
+// The existing members are kept as is, so that who added them is retained.
+func setUserGroupMembersImpl(ctx context.Context, tx *Tx, groupUID int, memberUIDs []int) ([]int, error) {
+	if memberUIDs == nil {
+		memberUIDs = []int{}
+	}
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM user_group_member WHERE group_id = $1 AND principal_id != ALL(CAST($2 AS INTEGER[]))
+	`, groupUID, memberUIDs); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete members of user group %d", groupUID)
+	}
+	if len(memberUIDs) == 0 {
+		return []int{}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO user_group_member (group_id, principal_id)
+		SELECT DISTINCT $1::INTEGER, unnest(CAST($2 AS INTEGER[]))
+		ON CONFLICT DO NOTHING
+	`, groupUID, memberUIDs); err != nil {
+		return nil, errors.Wrapf(err, "failed to add members to user group %d", groupUID)
+	}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT principal_id FROM user_group_member WHERE group_id = $1 ORDER BY principal_id
+	`, groupUID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list members of user group %d", groupUID)
+	}
+	defer rows.Close()
+	var members []int
+	for rows.Next() {
+		var member int
+		if err := rows.Scan(&member); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
@@ -108,6 +108,10 @@ func (s *Store) CreateExternalVersionControlV2(ctx context.Context, principalUID
 	}
 	defer tx.Rollback()
 
+	secret, err := s.encryptSecret(create.Secret)
+	if err != nil {
+		return nil, err
+	}
 	query := `
 		INSERT INTO vcs (
 			creator_id,
@@ -131,7 +135,7 @@ func (s *Store) CreateExternalVersionControlV2(ctx context.Context, principalUID
 		create.InstanceURL,
 		create.APIURL,
 		create.ApplicationID,
-		create.Secret,
+		secret,
 	).Scan(
 		&externalVersionControl.ID,
 		&externalVersionControl.Name,
@@ -146,6 +150,9 @@ func (s *Store) CreateExternalVersionControlV2(ctx context.Context, principalUID
 		}
 		return nil, err
 	}
+	if err := s.decryptSecrets(ctx, &externalVersionControl.Secret); err != nil {
+		return nil, err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
@@ -166,7 +173,11 @@ func (s *Store) UpdateExternalVersionControlV2(ctx context.Context, principalUID
 		set, args = append(set, fmt.Sprintf("application_id = $%d", len(args)+1)), append(args, *v)
 	}
 	if v := update.Secret; v != nil {
-		set, args = append(set, fmt.Sprintf("secret = $%d", len(args)+1)), append(args, *v)
+		encrypted, err := s.encryptSecret(*v)
+		if err != nil {
+			return nil, err
+		}
+		set, args = append(set, fmt.Sprintf("secret = $%d", len(args)+1)), append(args, encrypted)
 	}
 	args = append(args, externalVersionControlUID)
 
@@ -199,6 +210,9 @@ func (s *Store) UpdateExternalVersionControlV2(ctx context.Context, principalUID
 		}
 		return nil, err
 	}
+	if err := s.decryptSecrets(ctx, &externalVersionControl.Secret); err != nil {
+		return nil, err
+	}
 
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrapf(err, "failed to commit transaction")
@@ -227,7 +241,7 @@ func (s *Store) DeleteExternalVersionControlV2(ctx context.Context, externalVers
 	return nil
 }
 
-func (*Store) findExternalVersionControlsImplV2(ctx context.Context, tx *Tx, find *findExternalVersionControlMessage) ([]*ExternalVersionControlMessage, error) {
+func (s *Store) findExternalVersionControlsImplV2(ctx context.Context, tx *Tx, find *findExternalVersionControlMessage) ([]*ExternalVersionControlMessage, error) {
 	where, args := []string{"TRUE"}, []any{}
 	if v := find.id; v != nil {
 		// Build WHERE clause.
@@ -265,6 +279,9 @@ func (*Store) findExternalVersionControlsImplV2(ctx context.Context, tx *Tx, fin
 		); err != nil {
 			return nil, err
 		}
+		if err := s.decryptSecrets(ctx, &externalVersionControl.Secret); err != nil {
+			return nil, err
+		}
 		externalVersionControls = append(externalVersionControls, &externalVersionControl)
 	}
 	if err := rows.Err(); err != nil {
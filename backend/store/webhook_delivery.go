@@ -0,0 +1,302 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/backend/common"
+)
+
+// WebhookDeliveryStatus is the status of a webhook delivery.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending is the status of a delivery waiting for the next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "PENDING"
+	// WebhookDeliverySucceeded is the status of a delivery accepted by the receiver.
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	// WebhookDeliveryDeadLetter is the status of a delivery which has failed the maximum attempts.
+	WebhookDeliveryDeadLetter WebhookDeliveryStatus = "DEAD_LETTER"
+)
+
+// WebhookDeliveryMessage is the message for a delivery of a project webhook.
+type WebhookDeliveryMessage struct {
+	// ProjectWebhookUID is the unique ID of the project webhook.
+	ProjectWebhookUID int
+	// ActivityType is the type of the activity triggering the delivery.
+	ActivityType string
+	// Payload is the JSON encoded webhook context, excluding the URL and the signing secret.
+	Payload string
+	Status  WebhookDeliveryStatus
+	// AttemptCount is the number of attempts made.
+	AttemptCount int
+	// NextAttemptTs is the time of the next attempt in unix seconds for pending deliveries.
+	NextAttemptTs int64
+	// LastAttemptTs is the time of the last attempt in unix seconds, zero if never attempted.
+	LastAttemptTs int64
+	// LastError is the error of the last attempt, empty if the last attempt succeeded.
+	LastError string
+
+	// Output only fields.
+	//
+	// UID is the unique ID of the delivery.
+	UID       int
+	CreatedTs int64
+}
+
+// FindWebhookDeliveryMessage is the message for finding webhook deliveries.
+type FindWebhookDeliveryMessage struct {
+	UID               *int
+	ProjectWebhookUID *int
+	Status            *WebhookDeliveryStatus
+	// NextAttemptBeforeTs filters the deliveries whose next attempt is due no later than the timestamp.
+	NextAttemptBeforeTs *int64
+	Limit               *int
+	Offset              *int
+}
+
+// UpdateWebhookDeliveryMessage is the message for updating a webhook delivery.
+type UpdateWebhookDeliveryMessage struct {
+	UID           int
+	Status        *WebhookDeliveryStatus
+	AttemptCount  *int
+	NextAttemptTs *int64
+	LastAttemptTs *int64
+	LastError     *string
+}
+
+// CreateWebhookDelivery creates a webhook delivery.
+func (s *Store) CreateWebhookDelivery(ctx context.Context, create *WebhookDeliveryMessage) (*WebhookDeliveryMessage, error) {
+	query := `
+		INSERT INTO webhook_delivery (
+			project_webhook_id,
+			activity_type,
+			status,
+			payload,
+			next_attempt_ts
+		)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_ts
+	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	delivery := &WebhookDeliveryMessage{
+		ProjectWebhookUID: create.ProjectWebhookUID,
+		ActivityType:      create.ActivityType,
+		Payload:           create.Payload,
+		Status:            WebhookDeliveryPending,
+		NextAttemptTs:     create.NextAttemptTs,
+	}
+	if err := tx.QueryRowContext(ctx, query,
+		create.ProjectWebhookUID,
+		create.ActivityType,
+		WebhookDeliveryPending,
+		create.Payload,
+		create.NextAttemptTs,
+	).Scan(
+		&delivery.UID,
+		&delivery.CreatedTs,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, errors.Wrapf(err, "failed to query row")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+
+	return delivery, nil
+}
+
+// GetWebhookDelivery gets a webhook delivery.
+func (s *Store) GetWebhookDelivery(ctx context.Context, find *FindWebhookDeliveryMessage) (*WebhookDeliveryMessage, error) {
+	deliveries, err := s.ListWebhookDeliveries(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	if len(deliveries) > 1 {
+		return nil, &common.Error{Code: common.Conflict, Err: errors.Errorf("found %d webhook deliveries with filter %+v, expect 1", len(deliveries), find)}
+	}
+	return deliveries[0], nil
+}
+
+// ListWebhookDeliveries lists webhook deliveries, the latest first.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, find *FindWebhookDeliveryMessage) ([]*WebhookDeliveryMessage, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if v := find.UID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ProjectWebhookUID; v != nil {
+		where, args = append(where, fmt.Sprintf("project_webhook_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.NextAttemptBeforeTs; v != nil {
+		where, args = append(where, fmt.Sprintf("next_attempt_ts <= $%d", len(args)+1)), append(args, *v)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			created_ts,
+			project_webhook_id,
+			activity_type,
+			status,
+			payload,
+			attempt_count,
+			next_attempt_ts,
+			last_attempt_ts,
+			last_error
+		FROM webhook_delivery
+		WHERE %s
+		ORDER BY id DESC`, strings.Join(where, " AND "))
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" LIMIT %d", *v)
+	}
+	if v := find.Offset; v != nil {
+		query += fmt.Sprintf(" OFFSET %d", *v)
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query webhook deliveries")
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDeliveryMessage
+	for rows.Next() {
+		var delivery WebhookDeliveryMessage
+		if err := rows.Scan(
+			&delivery.UID,
+			&delivery.CreatedTs,
+			&delivery.ProjectWebhookUID,
+			&delivery.ActivityType,
+			&delivery.Status,
+			&delivery.Payload,
+			&delivery.AttemptCount,
+			&delivery.NextAttemptTs,
+			&delivery.LastAttemptTs,
+			&delivery.LastError,
+		); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan webhook delivery")
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to iterate webhook deliveries")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery updates a webhook delivery.
+func (s *Store) UpdateWebhookDelivery(ctx context.Context, update *UpdateWebhookDeliveryMessage) (*WebhookDeliveryMessage, error) {
+	set, args := []string{}, []any{}
+	if v := update.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.AttemptCount; v != nil {
+		set, args = append(set, fmt.Sprintf("attempt_count = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.NextAttemptTs; v != nil {
+		set, args = append(set, fmt.Sprintf("next_attempt_ts = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.LastAttemptTs; v != nil {
+		set, args = append(set, fmt.Sprintf("last_attempt_ts = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := update.LastError; v != nil {
+		set, args = append(set, fmt.Sprintf("last_error = $%d", len(args)+1)), append(args, *v)
+	}
+	if len(set) == 0 {
+		return nil, errors.New("no update field provided")
+	}
+	args = append(args, update.UID)
+
+	query := fmt.Sprintf(`
+		UPDATE webhook_delivery
+		SET %s
+		WHERE id = $%d
+		RETURNING
+			id,
+			created_ts,
+			project_webhook_id,
+			activity_type,
+			status,
+			payload,
+			attempt_count,
+			next_attempt_ts,
+			last_attempt_ts,
+			last_error
+	`, strings.Join(set, ", "), len(args))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	var delivery WebhookDeliveryMessage
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(
+		&delivery.UID,
+		&delivery.CreatedTs,
+		&delivery.ProjectWebhookUID,
+		&delivery.ActivityType,
+		&delivery.Status,
+		&delivery.Payload,
+		&delivery.AttemptCount,
+		&delivery.NextAttemptTs,
+		&delivery.LastAttemptTs,
+		&delivery.LastError,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("webhook delivery not found: %d", update.UID)}
+		}
+		return nil, errors.Wrapf(err, "failed to update webhook delivery %d", update.UID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrapf(err, "failed to commit transaction")
+	}
+	return &delivery, nil
+}
+
+// DeleteFinishedWebhookDeliveries deletes the succeeded and dead-lettered webhook deliveries last updated before the time.
+// It returns the number of the deleted deliveries.
+func (s *Store) DeleteFinishedWebhookDeliveries(ctx context.Context, updatedBeforeTs int64) (int64, error) {
+	result, err := s.db.db.ExecContext(ctx, `
+		DELETE FROM webhook_delivery
+		WHERE status IN ($1, $2) AND updated_ts < $3
+	`, WebhookDeliverySucceeded, WebhookDeliveryDeadLetter, updatedBeforeTs)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to delete webhook deliveries")
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get the number of deleted webhook deliveries")
+	}
+	return count, nil
+}
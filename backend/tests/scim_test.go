@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/backend/api/scim"
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	"github.com/bytebase/bytebase/backend/tests/fake"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+const scimTestToken = "scim-test-token-0123456789abcdefghijklmnopqrstuvwxyz"
+
+func TestSCIMUser(t *testing.T) {
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	ctx, err := ctl.StartServerWithExternalPg(ctx, &config{
+		dataDir:            t.TempDir(),
+		vcsProviderCreator: fake.NewGitLab,
+	})
+	a.NoError(err)
+	defer ctl.Close(ctx)
+
+	err = ctl.setLicense(ctx)
+	a.NoError(err)
+	_, err = ctl.settingServiceClient.SetSetting(ctx, &v1pb.SetSettingRequest{
+		Setting: &v1pb.Setting{
+			Name: fmt.Sprintf("settings/%s", api.SettingSCIMToken),
+			Value: &v1pb.Value{
+				Value: &v1pb.Value_StringValue{StringValue: scimTestToken},
+			},
+		},
+	})
+	a.NoError(err)
+
+	// Only the token is accepted, not the hash of the token stored in the setting.
+	code, _, err := ctl.scimRequest(http.MethodGet, "/Users", "", nil)
+	a.NoError(err)
+	a.Equal(http.StatusUnauthorized, code)
+	code, _, err = ctl.scimRequest(http.MethodGet, "/Users", scim.HashToken(scimTestToken), nil)
+	a.NoError(err)
+	a.Equal(http.StatusUnauthorized, code)
+	code, _, err = ctl.scimRequest(http.MethodGet, "/Users", "wrong-"+scimTestToken, nil)
+	a.NoError(err)
+	a.Equal(http.StatusUnauthorized, code)
+	code, _, err = ctl.scimRequest(http.MethodGet, "/Users", scimTestToken, nil)
+	a.NoError(err)
+	a.Equal(http.StatusOK, code)
+
+	// Create, patch and deactivate a user.
+	code, body, err := ctl.scimRequest(http.MethodPost, "/Users", scimTestToken, map[string]any{
+		"schemas":    []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+		"userName":   "scim-user@example.com",
+		"externalId": "scim-user",
+		"name":       map[string]any{"givenName": "SCIM", "familyName": "User"},
+	})
+	a.NoError(err)
+	a.Equal(http.StatusCreated, code, body)
+	a.Equal("SCIM User", body["displayName"])
+	a.Equal(true, body["active"])
+	userID, ok := body["id"].(string)
+	a.True(ok)
+
+	code, body, err = ctl.scimRequest(http.MethodPatch, "/Users/"+userID, scimTestToken, map[string]any{
+		"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		"Operations": []map[string]any{
+			{"op": "replace", "path": "displayName", "value": "Renamed User"},
+		},
+	})
+	a.NoError(err)
+	a.Equal(http.StatusOK, code, body)
+	a.Equal("Renamed User", body["displayName"])
+
+	code, body, err = ctl.scimRequest(http.MethodPatch, "/Users/"+userID, scimTestToken, map[string]any{
+		"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		"Operations": []map[string]any{
+			{"op": "replace", "value": map[string]any{"active": "False"}},
+		},
+	})
+	a.NoError(err)
+	a.Equal(http.StatusOK, code, body)
+	a.Equal(false, body["active"])
+
+	code, _, err = ctl.scimRequest(http.MethodDelete, "/Users/"+userID, scimTestToken, nil)
+	a.NoError(err)
+	a.Equal(http.StatusNoContent, code)
+	code, body, err = ctl.scimRequest(http.MethodGet, "/Users/"+userID, scimTestToken, nil)
+	a.NoError(err)
+	a.Equal(http.StatusOK, code, body)
+	a.Equal(false, body["active"])
+
+	// The users not provisioned by the SCIM clients, including the workspace owner, cannot be modified.
+	developer, err := ctl.authServiceClient.CreateUser(ctx, &v1pb.CreateUserRequest{
+		User: &v1pb.User{
+			Email:    "developer@example.com",
+			Password: "1024",
+			Title:    "developer",
+			UserType: v1pb.UserType_USER,
+		},
+	})
+	a.NoError(err)
+	users, err := ctl.authServiceClient.ListUsers(ctx, &v1pb.ListUsersRequest{})
+	a.NoError(err)
+	var ownerID string
+	for _, user := range users.Users {
+		if user.Email == "demo@example.com" {
+			ownerID = strings.TrimPrefix(user.Name, "users/")
+		}
+	}
+	a.NotEmpty(ownerID)
+	for _, id := range []string{ownerID, strings.TrimPrefix(developer.Name, "users/")} {
+		code, _, err = ctl.scimRequest(http.MethodPatch, "/Users/"+id, scimTestToken, map[string]any{
+			"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+			"Operations": []map[string]any{
+				{"op": "replace", "path": "active", "value": false},
+			},
+		})
+		a.NoError(err)
+		a.Equal(http.StatusNotFound, code, id)
+		code, _, err = ctl.scimRequest(http.MethodDelete, "/Users/"+id, scimTestToken, nil)
+		a.NoError(err)
+		a.Equal(http.StatusNotFound, code, id)
+	}
+	// The workspace owner cannot be linked to the SCIM clients by the email.
+	code, _, err = ctl.scimRequest(http.MethodPost, "/Users", scimTestToken, map[string]any{
+		"schemas":    []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+		"userName":   "demo@example.com",
+		"externalId": "owner",
+		"active":     false,
+	})
+	a.NoError(err)
+	a.Equal(http.StatusConflict, code)
+	owner, err := ctl.authServiceClient.GetUser(ctx, &v1pb.GetUserRequest{Name: "users/" + ownerID})
+	a.NoError(err)
+	a.Equal(v1pb.State_ACTIVE, owner.State)
+}
+
+// scimRequest sends the request to the SCIM APIs with the bearer token, and returns the status code and the response body.
+func (ctl *controller) scimRequest(method, path, token string, body any) (int, map[string]any, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, errors.Wrap(err, "failed to marshal request body")
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/scim/v2%s", ctl.rootURL, path), reader)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "fail to create a new %s request(%q)", method, path)
+	}
+	req.Header.Set("Content-Type", "application/scim+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := ctl.client.Do(req)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "fail to send a %s request(%q)", method, path)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to read http response body")
+	}
+	result := map[string]any{}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &result); err != nil {
+			return 0, nil, errors.Wrapf(err, "failed to unmarshal response body %q", string(b))
+		}
+	}
+	return resp.StatusCode, result, nil
+}
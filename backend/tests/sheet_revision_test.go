@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/bytebase/bytebase/backend/tests/fake"
+	v1pb "github.com/bytebase/bytebase/proto/generated-go/v1"
+)
+
+func TestSheetRevision(t *testing.T) {
+	t.Parallel()
+	a := require.New(t)
+	ctx := context.Background()
+	ctl := &controller{}
+	ctx, err := ctl.StartServerWithExternalPg(ctx, &config{
+		dataDir:            t.TempDir(),
+		vcsProviderCreator: fake.NewGitLab,
+	})
+	a.NoError(err)
+	defer ctl.Close(ctx)
+
+	statement1 := "CREATE TABLE t1(id INT);\n"
+	statement2 := "CREATE TABLE t1(id INT);\nCREATE TABLE t2(id INT);\n"
+	sheet, err := ctl.sheetServiceClient.CreateSheet(ctx, &v1pb.CreateSheetRequest{
+		Parent: ctl.project.Name,
+		Sheet: &v1pb.Sheet{
+			Title:      "sheetRevision",
+			Content:    []byte(statement1),
+			Visibility: v1pb.Sheet_VISIBILITY_PROJECT,
+			Source:     v1pb.Sheet_SOURCE_BYTEBASE_ARTIFACT,
+			Type:       v1pb.Sheet_TYPE_SQL,
+		},
+	})
+	a.NoError(err)
+	revisionName := func(revision int) string {
+		return fmt.Sprintf("%s/revisions/%d", sheet.Name, revision)
+	}
+	updateContent := func(content string) {
+		_, err := ctl.sheetServiceClient.UpdateSheet(ctx, &v1pb.UpdateSheetRequest{
+			Sheet: &v1pb.Sheet{
+				Name:    sheet.Name,
+				Content: []byte(content),
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content"}},
+		})
+		a.NoError(err)
+	}
+	listRevisionNames := func() []string {
+		resp, err := ctl.sheetServiceClient.ListSheetRevisions(ctx, &v1pb.ListSheetRevisionsRequest{Parent: sheet.Name})
+		a.NoError(err)
+		var names []string
+		for _, revision := range resp.Revisions {
+			names = append(names, revision.Name)
+		}
+		return names
+	}
+
+	// Saving the same content doesn't create a revision.
+	updateContent(statement1)
+	a.Equal([]string{revisionName(1)}, listRevisionNames())
+
+	updateContent(statement2)
+	a.Equal([]string{revisionName(2), revisionName(1)}, listRevisionNames())
+	diff, err := ctl.sheetServiceClient.DiffSheetRevisions(ctx, &v1pb.DiffSheetRevisionsRequest{
+		Name:         sheet.Name,
+		BaseRevision: revisionName(1),
+	})
+	a.NoError(err)
+	a.Contains(diff.Diff, "+CREATE TABLE t2(id INT);\n")
+
+	// Restoring a revision appends its content as a new revision.
+	restored, err := ctl.sheetServiceClient.RestoreSheetRevision(ctx, &v1pb.RestoreSheetRevisionRequest{Name: revisionName(1)})
+	a.NoError(err)
+	a.Equal(statement1, string(restored.Content))
+	a.Equal([]string{revisionName(3), revisionName(2), revisionName(1)}, listRevisionNames())
+	revision, err := ctl.sheetServiceClient.GetSheetRevision(ctx, &v1pb.GetSheetRevisionRequest{Name: revisionName(3)})
+	a.NoError(err)
+	a.Equal(statement1, string(revision.Content))
+	revision, err = ctl.sheetServiceClient.GetSheetRevision(ctx, &v1pb.GetSheetRevisionRequest{Name: revisionName(2)})
+	a.NoError(err)
+	a.Equal(statement2, string(revision.Content))
+
+	// Restoring the content of the latest revision doesn't create a revision.
+	_, err = ctl.sheetServiceClient.RestoreSheetRevision(ctx, &v1pb.RestoreSheetRevisionRequest{Name: revisionName(1)})
+	a.NoError(err)
+	a.Equal([]string{revisionName(3), revisionName(2), revisionName(1)}, listRevisionNames())
+	got, err := ctl.sheetServiceClient.GetSheet(ctx, &v1pb.GetSheetRequest{Name: sheet.Name})
+	a.NoError(err)
+	a.Equal(statement1, string(got.Content))
+
+	_, err = ctl.sheetServiceClient.RestoreSheetRevision(ctx, &v1pb.RestoreSheetRevisionRequest{Name: revisionName(4)})
+	a.Error(err)
+}
@@ -0,0 +1,253 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+// maxRolloutWindowSearch is the maximum time range to search for the next rollout window start.
+const maxRolloutWindowSearch = 5 * 366 * 24 * time.Hour
+
+// ValidateRolloutWindowPolicy validates the cron expressions, time zones and periods of the rollout window policy.
+func ValidateRolloutWindowPolicy(policy *storepb.RolloutWindowPolicy) error {
+	for _, window := range policy.AllowedWindows {
+		if _, err := parseRolloutWindow(window); err != nil {
+			return err
+		}
+	}
+	for _, period := range policy.FreezePeriods {
+		if period.StartTime == nil || period.EndTime == nil {
+			return errors.Errorf("freeze period %q must have start time and end time set", period.Title)
+		}
+		if !period.StartTime.AsTime().Before(period.EndTime.AsTime()) {
+			return errors.Errorf("freeze period %q must start before it ends", period.Title)
+		}
+	}
+	for _, role := range policy.BypassRoles {
+		switch api.Role(role) {
+		case api.Owner, api.DBA, api.Developer:
+		default:
+			return errors.Errorf("invalid bypass role %q", role)
+		}
+	}
+	return nil
+}
+
+// IsRolloutWindowBypassed returns true if the role can roll out regardless of the rollout window policy.
+func IsRolloutWindowBypassed(policy *storepb.RolloutWindowPolicy, role api.Role) bool {
+	for _, bypassRole := range policy.BypassRoles {
+		if api.Role(bypassRole) == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckRolloutWindow checks if the tasks are allowed to roll out at the time by the rollout window policy.
+// It returns the reason if the tasks are not allowed to roll out, or empty otherwise.
+func CheckRolloutWindow(policy *storepb.RolloutWindowPolicy, now time.Time) (string, error) {
+	for _, period := range policy.FreezePeriods {
+		start, end := period.StartTime.AsTime(), period.EndTime.AsTime()
+		if !now.Before(start) && now.Before(end) {
+			return fmt.Sprintf("Rollout is frozen by %q until %s", period.Title, end.UTC().Format(time.RFC3339)), nil
+		}
+	}
+	if len(policy.AllowedWindows) == 0 {
+		return "", nil
+	}
+
+	var nextStart time.Time
+	for _, window := range policy.AllowedWindows {
+		w, err := parseRolloutWindow(window)
+		if err != nil {
+			return "", err
+		}
+		if w.contains(now) {
+			return "", nil
+		}
+		if start, ok := w.schedule.next(now.In(w.location)); ok && (nextStart.IsZero() || start.Before(nextStart)) {
+			nextStart = start
+		}
+	}
+	if nextStart.IsZero() {
+		return "Rollout is outside the allowed windows", nil
+	}
+	return fmt.Sprintf("Rollout is outside the allowed windows, the next window starts at %s", nextStart.UTC().Format(time.RFC3339)), nil
+}
+
+type rolloutWindow struct {
+	schedule *cronSchedule
+	duration time.Duration
+	location *time.Location
+}
+
+func parseRolloutWindow(window *storepb.RolloutWindowPolicy_Window) (*rolloutWindow, error) {
+	schedule, err := parseCronSchedule(window.Cron)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", window.Cron)
+	}
+	if window.Duration == nil || window.Duration.AsDuration() <= 0 {
+		return nil, errors.Errorf("the duration of window %q must be positive", window.Cron)
+	}
+	location := time.UTC
+	if window.TimeZone != "" {
+		location, err = time.LoadLocation(window.TimeZone)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid time zone %q", window.TimeZone)
+		}
+	}
+	return &rolloutWindow{
+		schedule: schedule,
+		duration: window.Duration.AsDuration(),
+		location: location,
+	}, nil
+}
+
+// contains returns true if a window started within the duration before the time.
+func (w *rolloutWindow) contains(now time.Time) bool {
+	// The window [start, start+duration) contains now if start is in (now-duration, now].
+	start, ok := w.schedule.next(now.Add(-w.duration).Add(time.Nanosecond).In(w.location))
+	return ok && !start.After(now)
+}
+
+// cronSchedule is the parsed cron expression in the format of "minute hour day-of-month month day-of-week".
+type cronSchedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	// The day matches either day-of-month or day-of-week if both are restricted, which is the same as the cron.
+	dayOfMonthStar bool
+	dayOfWeekStar  bool
+}
+
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("expect 5 fields but got %d", len(fields))
+	}
+	minute, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, err
+	}
+	hour, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, err
+	}
+	dayOfMonth, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, err
+	}
+	month, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, err
+	}
+	dayOfWeek, err := parseCronField(fields[4], 0, 7)
+	if err != nil {
+		return nil, err
+	}
+	// Both 0 and 7 are Sunday.
+	if dayOfWeek&(1<<7) != 0 {
+		dayOfWeek |= 1
+	}
+	return &cronSchedule{
+		minute:         minute,
+		hour:           hour,
+		dayOfMonth:     dayOfMonth,
+		month:          month,
+		dayOfWeek:      dayOfWeek,
+		dayOfMonthStar: strings.HasPrefix(fields[2], "*"),
+		dayOfWeekStar:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses the comma separated list of "*", "n", "n-m" with an optional "/step" into a bit set.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangeExpr, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			s, err := strconv.Atoi(item[i+1:])
+			if err != nil || s <= 0 {
+				return 0, errors.Errorf("invalid step in %q", item)
+			}
+			rangeExpr, step = item[:i], s
+		}
+		start, end := min, max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			bounds := strings.SplitN(rangeExpr, "-", 2)
+			s, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, errors.Errorf("invalid range %q", rangeExpr)
+			}
+			e, err := strconv.Atoi(bounds[1])
+			if err != nil {
+				return 0, errors.Errorf("invalid range %q", rangeExpr)
+			}
+			start, end = s, e
+		default:
+			v, err := strconv.Atoi(rangeExpr)
+			if err != nil {
+				return 0, errors.Errorf("invalid value %q", rangeExpr)
+			}
+			start, end = v, v
+			if step != 1 {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, errors.Errorf("%q is out of range [%d, %d]", item, min, max)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *cronSchedule) matchDay(t time.Time) bool {
+	domMatch := c.dayOfMonth&(1<<uint(t.Day())) != 0
+	dowMatch := c.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if c.dayOfMonthStar || c.dayOfWeekStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// next returns the earliest matching minute at or after the time, in the location of the time.
+func (c *cronSchedule) next(t time.Time) (time.Time, bool) {
+	if truncated := t.Truncate(time.Minute); truncated.Before(t) {
+		t = truncated.Add(time.Minute)
+	}
+	limit := t.Add(maxRolloutWindowSearch)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	api "github.com/bytebase/bytebase/backend/legacyapi"
+	storepb "github.com/bytebase/bytebase/proto/generated-go/store"
+)
+
+func TestCheckRolloutWindow(t *testing.T) {
+	policy := &storepb.RolloutWindowPolicy{
+		// Tuesday to Thursday 02:00-05:00 UTC.
+		AllowedWindows: []*storepb.RolloutWindowPolicy_Window{
+			{Cron: "0 2 * * 2-4", Duration: durationpb.New(3 * time.Hour)},
+		},
+		FreezePeriods: []*storepb.RolloutWindowPolicy_FreezePeriod{
+			{
+				Title:     "Black Friday",
+				StartTime: timestamppb.New(time.Date(2023, 11, 22, 0, 0, 0, 0, time.UTC)),
+				EndTime:   timestamppb.New(time.Date(2023, 11, 28, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		BypassRoles: []string{string(api.Owner)},
+	}
+	require.NoError(t, ValidateRolloutWindowPolicy(policy))
+
+	tests := []struct {
+		now  time.Time
+		want string
+	}{
+		{
+			// Tuesday 02:00.
+			now:  time.Date(2023, 11, 14, 2, 0, 0, 0, time.UTC),
+			want: "",
+		},
+		{
+			// Thursday 04:59.
+			now:  time.Date(2023, 11, 16, 4, 59, 59, 0, time.UTC),
+			want: "",
+		},
+		{
+			// Thursday 05:00.
+			now:  time.Date(2023, 11, 16, 5, 0, 0, 0, time.UTC),
+			want: "Rollout is outside the allowed windows, the next window starts at 2023-11-21T02:00:00Z",
+		},
+		{
+			// Monday 03:00.
+			now:  time.Date(2023, 11, 13, 3, 0, 0, 0, time.UTC),
+			want: "Rollout is outside the allowed windows, the next window starts at 2023-11-14T02:00:00Z",
+		},
+		{
+			// Wednesday 03:00 in the freeze period.
+			now:  time.Date(2023, 11, 22, 3, 0, 0, 0, time.UTC),
+			want: `Rollout is frozen by "Black Friday" until 2023-11-28T00:00:00Z`,
+		},
+		{
+			// Tuesday 02:30 right after the freeze period.
+			now:  time.Date(2023, 11, 28, 2, 30, 0, 0, time.UTC),
+			want: "",
+		},
+	}
+	for _, test := range tests {
+		got, err := CheckRolloutWindow(policy, test.now)
+		require.NoError(t, err)
+		require.Equal(t, test.want, got, test.now)
+	}
+
+	require.True(t, IsRolloutWindowBypassed(policy, api.Owner))
+	require.False(t, IsRolloutWindowBypassed(policy, api.DBA))
+}
+
+func TestCheckRolloutWindowTimeZone(t *testing.T) {
+	policy := &storepb.RolloutWindowPolicy{
+		// Every day 22:00-02:00 in New York, which spans midnight.
+		AllowedWindows: []*storepb.RolloutWindowPolicy_Window{
+			{Cron: "0 22 * * *", Duration: durationpb.New(4 * time.Hour), TimeZone: "America/New_York"},
+		},
+	}
+	require.NoError(t, ValidateRolloutWindowPolicy(policy))
+
+	// 01:00 in New York (EST).
+	got, err := CheckRolloutWindow(policy, time.Date(2023, 12, 1, 6, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "", got)
+	// 12:00 in New York (EST).
+	got, err = CheckRolloutWindow(policy, time.Date(2023, 12, 1, 17, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, "Rollout is outside the allowed windows, the next window starts at 2023-12-02T03:00:00Z", got)
+}
+
+func TestValidateRolloutWindowPolicy(t *testing.T) {
+	tests := []struct {
+		window  *storepb.RolloutWindowPolicy_Window
+		wantErr bool
+	}{
+		{window: &storepb.RolloutWindowPolicy_Window{Cron: "*/15 9-17 1,15 * 1-5", Duration: durationpb.New(time.Minute)}},
+		{window: &storepb.RolloutWindowPolicy_Window{Cron: "0 2 * * 7", Duration: durationpb.New(time.Hour)}},
+		{window: &storepb.RolloutWindowPolicy_Window{Cron: "0 2 * *", Duration: durationpb.New(time.Hour)}, wantErr: true},
+		{window: &storepb.RolloutWindowPolicy_Window{Cron: "60 2 * * *", Duration: durationpb.New(time.Hour)}, wantErr: true},
+		{window: &storepb.RolloutWindowPolicy_Window{Cron: "0 5-2 * * *", Duration: durationpb.New(time.Hour)}, wantErr: true},
+		{window: &storepb.RolloutWindowPolicy_Window{Cron: "0 2 * * *"}, wantErr: true},
+		{window: &storepb.RolloutWindowPolicy_Window{Cron: "0 2 * * *", Duration: durationpb.New(time.Hour), TimeZone: "Mars/Olympus"}, wantErr: true},
+	}
+	for _, test := range tests {
+		err := ValidateRolloutWindowPolicy(&storepb.RolloutWindowPolicy{
+			AllowedWindows: []*storepb.RolloutWindowPolicy_Window{test.window},
+		})
+		if test.wantErr {
+			require.Error(t, err, test.window.Cron)
+		} else {
+			require.NoError(t, err, test.window.Cron)
+		}
+	}
+}
@@ -241,6 +241,20 @@ func isMatchExpression(labels map[string]string, expression *api.LabelSelectorRe
 	case api.ExistsOperatorType:
 		_, ok := labels[expression.Key]
 		return ok
+	case api.NotInOperatorType:
+		value, ok := labels[expression.Key]
+		if !ok {
+			return true
+		}
+		for _, exprValue := range expression.Values {
+			if exprValue == value {
+				return false
+			}
+		}
+		return true
+	case api.DoesNotExistOperatorType:
+		_, ok := labels[expression.Key]
+		return !ok
 	default:
 		return false
 	}
@@ -314,6 +328,32 @@ func GetDatabaseMatrixFromDeploymentSchedule(schedule *api.DeploymentSchedule, d
 	return matrix, nil
 }
 
+// SplitDatabasesIntoWaves splits the databases into waves by the cumulative percentages.
+// The first wave has at least one database, and empty waves are skipped.
+func SplitDatabasesIntoWaves(databases []*store.DatabaseMessage, percentages []int) [][]*store.DatabaseMessage {
+	if len(percentages) == 0 {
+		return [][]*store.DatabaseMessage{databases}
+	}
+	var waves [][]*store.DatabaseMessage
+	start := 0
+	for _, percentage := range percentages {
+		// Round up so that a small percentage still rolls out to one database.
+		end := (len(databases)*percentage + 99) / 100
+		if end > len(databases) {
+			end = len(databases)
+		}
+		if end <= start {
+			continue
+		}
+		waves = append(waves, databases[start:end])
+		start = end
+	}
+	if start < len(databases) {
+		waves = append(waves, databases[start:])
+	}
+	return waves
+}
+
 // RefreshToken is a token refresher that stores the latest access token configuration to repository.
 func RefreshToken(ctx context.Context, s *store.Store, webURL string) common.TokenRefresher {
 	return func(token, refreshToken string, expiresTs int64) error {
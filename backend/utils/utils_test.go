@@ -268,6 +268,50 @@ func TestGetDatabaseMatrixFromDeploymentSchedule(t *testing.T) {
 				{dbs[5], dbs[6]},
 			},
 		},
+		{
+			"NotIn and DoesNotExist operators",
+			&api.DeploymentSchedule{
+				Deployments: []*api.Deployment{
+					{
+						Spec: &api.DeploymentSpec{
+							Selector: &api.LabelSelector{
+								MatchExpressions: []*api.LabelSelectorRequirement{
+									{
+										Key:      "bb.location",
+										Operator: "In",
+										Values:   []string{"earth", "us"},
+									},
+									{
+										Key:      "bb.tenant",
+										Operator: "DoesNotExist",
+									},
+								},
+							},
+						},
+					},
+					{
+						Spec: &api.DeploymentSpec{
+							Selector: &api.LabelSelector{
+								MatchExpressions: []*api.LabelSelectorRequirement{
+									{
+										Key:      "bb.location",
+										Operator: "NotIn",
+										Values:   []string{"earth"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			[]*store.DatabaseMessage{
+				dbs[0], dbs[1], dbs[2], dbs[3], dbs[4], dbs[5],
+			},
+			[][]*store.DatabaseMessage{
+				{dbs[3], dbs[4], dbs[5]},
+				{dbs[0], dbs[2]},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -276,6 +320,48 @@ func TestGetDatabaseMatrixFromDeploymentSchedule(t *testing.T) {
 	}
 }
 
+func TestSplitDatabasesIntoWaves(t *testing.T) {
+	var dbs []*store.DatabaseMessage
+	for i := 0; i < 50; i++ {
+		dbs = append(dbs, &store.DatabaseMessage{UID: i})
+	}
+
+	tests := []struct {
+		name         string
+		databaseList []*store.DatabaseMessage
+		percentages  []int
+		want         []int
+	}{
+		{
+			name:         "1%, 10%, 100%",
+			databaseList: dbs,
+			percentages:  []int{1, 10, 100},
+			want:         []int{1, 4, 45},
+		},
+		{
+			name:         "empty waves are skipped",
+			databaseList: dbs[:3],
+			percentages:  []int{1, 10, 50, 100},
+			want:         []int{1, 1, 1},
+		},
+		{
+			name:         "no waves",
+			databaseList: dbs[:3],
+			percentages:  nil,
+			want:         []int{3},
+		},
+	}
+
+	for _, test := range tests {
+		waves := SplitDatabasesIntoWaves(test.databaseList, test.percentages)
+		var got []int
+		for _, wave := range waves {
+			got = append(got, len(wave))
+		}
+		require.Equal(t, test.want, got, test.name)
+	}
+}
+
 func TestMergeTaskCreateLists(t *testing.T) {
 	tests := []struct {
 		name               string
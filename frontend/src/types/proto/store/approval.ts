@@ -137,7 +137,14 @@ export interface ApprovalNode {
     | undefined;
   /** Format: roles/{role} */
   role?: string | undefined;
-  externalNodeId?: string | undefined;
+  externalNodeId?:
+    | string
+    | undefined;
+  /**
+   * The node can be approved by any member of the user group.
+   * Format: groups/{email}
+   */
+  group?: string | undefined;
 }
 
 /**
@@ -652,7 +659,7 @@ export const ApprovalStep = {
 };
 
 function createBaseApprovalNode(): ApprovalNode {
-  return { type: 0, groupValue: undefined, role: undefined, externalNodeId: undefined };
+  return { type: 0, groupValue: undefined, role: undefined, externalNodeId: undefined, group: undefined };
 }
 
 export const ApprovalNode = {
@@ -669,6 +676,9 @@ export const ApprovalNode = {
     if (message.externalNodeId !== undefined) {
       writer.uint32(34).string(message.externalNodeId);
     }
+    if (message.group !== undefined) {
+      writer.uint32(42).string(message.group);
+    }
     return writer;
   },
 
@@ -707,6 +717,13 @@ export const ApprovalNode = {
 
           message.externalNodeId = reader.string();
           continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.group = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -722,6 +739,7 @@ export const ApprovalNode = {
       groupValue: isSet(object.groupValue) ? approvalNode_GroupValueFromJSON(object.groupValue) : undefined,
       role: isSet(object.role) ? String(object.role) : undefined,
       externalNodeId: isSet(object.externalNodeId) ? String(object.externalNodeId) : undefined,
+      group: isSet(object.group) ? String(object.group) : undefined,
     };
   },
 
@@ -734,6 +752,7 @@ export const ApprovalNode = {
         : undefined);
     message.role !== undefined && (obj.role = message.role);
     message.externalNodeId !== undefined && (obj.externalNodeId = message.externalNodeId);
+    message.group !== undefined && (obj.group = message.group);
     return obj;
   },
 
@@ -747,6 +766,7 @@ export const ApprovalNode = {
     message.groupValue = object.groupValue ?? undefined;
     message.role = object.role ?? undefined;
     message.externalNodeId = object.externalNodeId ?? undefined;
+    message.group = object.group ?? undefined;
     return message;
   },
 };
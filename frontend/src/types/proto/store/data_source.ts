@@ -24,6 +24,187 @@ export interface DataSourceOptions {
   sshObfuscatedPassword: string;
   /** The private key to login the server. If it's empty string, we will use the system default private key from os.Getenv("SSH_AUTH_SOCK"). */
   sshObfuscatedPrivateKey: string;
+  /**
+   * The external secret manager storing the credential of the data source.
+   * If it's set, the credential is fetched from the secret manager at connection time instead of using the password.
+   */
+  externalSecret:
+    | DataSourceExternalSecret
+    | undefined;
+  /**
+   * The IAM database authentication of the data source.
+   * If it's set, a short-lived authentication token generated from the cloud credentials of the Bytebase server is used as the password.
+   * MySQL sends the token in cleartext, so the SSL CA, e.g. the AWS RDS global bundle or the Cloud SQL server CA, is required to verify the server.
+   */
+  iamAuthentication: DataSourceIAMAuthentication | undefined;
+}
+
+export interface DataSourceExternalSecret {
+  secretType: DataSourceExternalSecret_SecretType;
+  /** The auth type of Vault. AWS Secrets Manager uses the AWS credentials of the Bytebase server. */
+  authType: DataSourceExternalSecret_AuthType;
+  /**
+   * The URL of the Vault server, e.g. https://vault.example.com:8200.
+   * For AWS Secrets Manager, it's the optional custom endpoint.
+   */
+  url: string;
+  /** The Vault token for the TOKEN auth type. */
+  obfuscatedToken: string;
+  /** The role ID for the APP_ROLE auth type. */
+  appRoleId: string;
+  /** The secret ID for the APP_ROLE auth type. */
+  obfuscatedAppRoleSecretId: string;
+  /** The mount path of the Vault secrets engine, e.g. "secret" for KV and "database" for the database secrets engine. */
+  engineName: string;
+  /** The path of the secret in the Vault KV secrets engine, or the name or ARN of the secret in AWS Secrets Manager. */
+  secretName: string;
+  /**
+   * The key of the password in the secret.
+   * If it's empty for AWS Secrets Manager, the whole secret string is used as the password.
+   */
+  passwordKeyName: string;
+  /** The key of the username in the secret. If it's empty, the username of the data source is used. */
+  usernameKeyName: string;
+  /** The role of the Vault database secrets engine generating the dynamic credentials. */
+  databaseRole: string;
+  /** The region of AWS Secrets Manager. If it's empty, the region of the AWS config of the Bytebase server is used. */
+  region: string;
+}
+
+export enum DataSourceExternalSecret_SecretType {
+  SECRET_TYPE_UNSPECIFIED = 0,
+  /** VAULT_KV_V2 - The static secret stored in the HashiCorp Vault KV secrets engine version 2. */
+  VAULT_KV_V2 = 1,
+  /** VAULT_DATABASE - The dynamic credential generated by the role of the HashiCorp Vault database secrets engine. */
+  VAULT_DATABASE = 2,
+  /** AWS_SECRETS_MANAGER - The secret stored in AWS Secrets Manager. */
+  AWS_SECRETS_MANAGER = 3,
+  UNRECOGNIZED = -1,
+}
+
+export function dataSourceExternalSecret_SecretTypeFromJSON(object: any): DataSourceExternalSecret_SecretType {
+  switch (object) {
+    case 0:
+    case "SECRET_TYPE_UNSPECIFIED":
+      return DataSourceExternalSecret_SecretType.SECRET_TYPE_UNSPECIFIED;
+    case 1:
+    case "VAULT_KV_V2":
+      return DataSourceExternalSecret_SecretType.VAULT_KV_V2;
+    case 2:
+    case "VAULT_DATABASE":
+      return DataSourceExternalSecret_SecretType.VAULT_DATABASE;
+    case 3:
+    case "AWS_SECRETS_MANAGER":
+      return DataSourceExternalSecret_SecretType.AWS_SECRETS_MANAGER;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return DataSourceExternalSecret_SecretType.UNRECOGNIZED;
+  }
+}
+
+export function dataSourceExternalSecret_SecretTypeToJSON(object: DataSourceExternalSecret_SecretType): string {
+  switch (object) {
+    case DataSourceExternalSecret_SecretType.SECRET_TYPE_UNSPECIFIED:
+      return "SECRET_TYPE_UNSPECIFIED";
+    case DataSourceExternalSecret_SecretType.VAULT_KV_V2:
+      return "VAULT_KV_V2";
+    case DataSourceExternalSecret_SecretType.VAULT_DATABASE:
+      return "VAULT_DATABASE";
+    case DataSourceExternalSecret_SecretType.AWS_SECRETS_MANAGER:
+      return "AWS_SECRETS_MANAGER";
+    case DataSourceExternalSecret_SecretType.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export enum DataSourceExternalSecret_AuthType {
+  AUTH_TYPE_UNSPECIFIED = 0,
+  /** TOKEN - Authenticate to Vault with the token. */
+  TOKEN = 1,
+  /** APP_ROLE - Authenticate to Vault with the AppRole role ID and secret ID. */
+  APP_ROLE = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function dataSourceExternalSecret_AuthTypeFromJSON(object: any): DataSourceExternalSecret_AuthType {
+  switch (object) {
+    case 0:
+    case "AUTH_TYPE_UNSPECIFIED":
+      return DataSourceExternalSecret_AuthType.AUTH_TYPE_UNSPECIFIED;
+    case 1:
+    case "TOKEN":
+      return DataSourceExternalSecret_AuthType.TOKEN;
+    case 2:
+    case "APP_ROLE":
+      return DataSourceExternalSecret_AuthType.APP_ROLE;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return DataSourceExternalSecret_AuthType.UNRECOGNIZED;
+  }
+}
+
+export function dataSourceExternalSecret_AuthTypeToJSON(object: DataSourceExternalSecret_AuthType): string {
+  switch (object) {
+    case DataSourceExternalSecret_AuthType.AUTH_TYPE_UNSPECIFIED:
+      return "AUTH_TYPE_UNSPECIFIED";
+    case DataSourceExternalSecret_AuthType.TOKEN:
+      return "TOKEN";
+    case DataSourceExternalSecret_AuthType.APP_ROLE:
+      return "APP_ROLE";
+    case DataSourceExternalSecret_AuthType.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface DataSourceIAMAuthentication {
+  type: DataSourceIAMAuthentication_Type;
+  /** The region of the AWS RDS instance. If it's empty, the region of the AWS config of the Bytebase server is used. */
+  region: string;
+}
+
+export enum DataSourceIAMAuthentication_Type {
+  TYPE_UNSPECIFIED = 0,
+  /** AWS_RDS - The AWS RDS and Aurora IAM database authentication. The token is valid for 15 minutes. */
+  AWS_RDS = 1,
+  /** GCP_CLOUD_SQL - The Google Cloud SQL IAM database authentication. The token is the OAuth 2.0 access token valid for 1 hour. */
+  GCP_CLOUD_SQL = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function dataSourceIAMAuthentication_TypeFromJSON(object: any): DataSourceIAMAuthentication_Type {
+  switch (object) {
+    case 0:
+    case "TYPE_UNSPECIFIED":
+      return DataSourceIAMAuthentication_Type.TYPE_UNSPECIFIED;
+    case 1:
+    case "AWS_RDS":
+      return DataSourceIAMAuthentication_Type.AWS_RDS;
+    case 2:
+    case "GCP_CLOUD_SQL":
+      return DataSourceIAMAuthentication_Type.GCP_CLOUD_SQL;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return DataSourceIAMAuthentication_Type.UNRECOGNIZED;
+  }
+}
+
+export function dataSourceIAMAuthentication_TypeToJSON(object: DataSourceIAMAuthentication_Type): string {
+  switch (object) {
+    case DataSourceIAMAuthentication_Type.TYPE_UNSPECIFIED:
+      return "TYPE_UNSPECIFIED";
+    case DataSourceIAMAuthentication_Type.AWS_RDS:
+      return "AWS_RDS";
+    case DataSourceIAMAuthentication_Type.GCP_CLOUD_SQL:
+      return "GCP_CLOUD_SQL";
+    case DataSourceIAMAuthentication_Type.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
 }
 
 function createBaseDataSourceOptions(): DataSourceOptions {
@@ -37,6 +218,8 @@ function createBaseDataSourceOptions(): DataSourceOptions {
     sshUser: "",
     sshObfuscatedPassword: "",
     sshObfuscatedPrivateKey: "",
+    externalSecret: undefined,
+    iamAuthentication: undefined,
   };
 }
 
@@ -69,6 +252,12 @@ export const DataSourceOptions = {
     if (message.sshObfuscatedPrivateKey !== "") {
       writer.uint32(74).string(message.sshObfuscatedPrivateKey);
     }
+    if (message.externalSecret !== undefined) {
+      DataSourceExternalSecret.encode(message.externalSecret, writer.uint32(82).fork()).ldelim();
+    }
+    if (message.iamAuthentication !== undefined) {
+      DataSourceIAMAuthentication.encode(message.iamAuthentication, writer.uint32(90).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -142,6 +331,20 @@ export const DataSourceOptions = {
 
           message.sshObfuscatedPrivateKey = reader.string();
           continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.externalSecret = DataSourceExternalSecret.decode(reader, reader.uint32());
+          continue;
+        case 11:
+          if (tag !== 90) {
+            break;
+          }
+
+          message.iamAuthentication = DataSourceIAMAuthentication.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -162,6 +365,12 @@ export const DataSourceOptions = {
       sshUser: isSet(object.sshUser) ? String(object.sshUser) : "",
       sshObfuscatedPassword: isSet(object.sshObfuscatedPassword) ? String(object.sshObfuscatedPassword) : "",
       sshObfuscatedPrivateKey: isSet(object.sshObfuscatedPrivateKey) ? String(object.sshObfuscatedPrivateKey) : "",
+      externalSecret: isSet(object.externalSecret)
+        ? DataSourceExternalSecret.fromJSON(object.externalSecret)
+        : undefined,
+      iamAuthentication: isSet(object.iamAuthentication)
+        ? DataSourceIAMAuthentication.fromJSON(object.iamAuthentication)
+        : undefined,
     };
   },
 
@@ -176,6 +385,12 @@ export const DataSourceOptions = {
     message.sshUser !== undefined && (obj.sshUser = message.sshUser);
     message.sshObfuscatedPassword !== undefined && (obj.sshObfuscatedPassword = message.sshObfuscatedPassword);
     message.sshObfuscatedPrivateKey !== undefined && (obj.sshObfuscatedPrivateKey = message.sshObfuscatedPrivateKey);
+    message.externalSecret !== undefined && (obj.externalSecret = message.externalSecret
+      ? DataSourceExternalSecret.toJSON(message.externalSecret)
+      : undefined);
+    message.iamAuthentication !== undefined && (obj.iamAuthentication = message.iamAuthentication
+      ? DataSourceIAMAuthentication.toJSON(message.iamAuthentication)
+      : undefined);
     return obj;
   },
 
@@ -194,6 +409,301 @@ export const DataSourceOptions = {
     message.sshUser = object.sshUser ?? "";
     message.sshObfuscatedPassword = object.sshObfuscatedPassword ?? "";
     message.sshObfuscatedPrivateKey = object.sshObfuscatedPrivateKey ?? "";
+    message.externalSecret = (object.externalSecret !== undefined && object.externalSecret !== null)
+      ? DataSourceExternalSecret.fromPartial(object.externalSecret)
+      : undefined;
+    message.iamAuthentication = (object.iamAuthentication !== undefined && object.iamAuthentication !== null)
+      ? DataSourceIAMAuthentication.fromPartial(object.iamAuthentication)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseDataSourceExternalSecret(): DataSourceExternalSecret {
+  return {
+    secretType: 0,
+    authType: 0,
+    url: "",
+    obfuscatedToken: "",
+    appRoleId: "",
+    obfuscatedAppRoleSecretId: "",
+    engineName: "",
+    secretName: "",
+    passwordKeyName: "",
+    usernameKeyName: "",
+    databaseRole: "",
+    region: "",
+  };
+}
+
+export const DataSourceExternalSecret = {
+  encode(message: DataSourceExternalSecret, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.secretType !== 0) {
+      writer.uint32(8).int32(message.secretType);
+    }
+    if (message.authType !== 0) {
+      writer.uint32(16).int32(message.authType);
+    }
+    if (message.url !== "") {
+      writer.uint32(26).string(message.url);
+    }
+    if (message.obfuscatedToken !== "") {
+      writer.uint32(34).string(message.obfuscatedToken);
+    }
+    if (message.appRoleId !== "") {
+      writer.uint32(42).string(message.appRoleId);
+    }
+    if (message.obfuscatedAppRoleSecretId !== "") {
+      writer.uint32(50).string(message.obfuscatedAppRoleSecretId);
+    }
+    if (message.engineName !== "") {
+      writer.uint32(58).string(message.engineName);
+    }
+    if (message.secretName !== "") {
+      writer.uint32(66).string(message.secretName);
+    }
+    if (message.passwordKeyName !== "") {
+      writer.uint32(74).string(message.passwordKeyName);
+    }
+    if (message.usernameKeyName !== "") {
+      writer.uint32(82).string(message.usernameKeyName);
+    }
+    if (message.databaseRole !== "") {
+      writer.uint32(90).string(message.databaseRole);
+    }
+    if (message.region !== "") {
+      writer.uint32(98).string(message.region);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DataSourceExternalSecret {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDataSourceExternalSecret();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.secretType = reader.int32() as any;
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.authType = reader.int32() as any;
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.url = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.obfuscatedToken = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.appRoleId = reader.string();
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.obfuscatedAppRoleSecretId = reader.string();
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.engineName = reader.string();
+          continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.secretName = reader.string();
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.passwordKeyName = reader.string();
+          continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.usernameKeyName = reader.string();
+          continue;
+        case 11:
+          if (tag !== 90) {
+            break;
+          }
+
+          message.databaseRole = reader.string();
+          continue;
+        case 12:
+          if (tag !== 98) {
+            break;
+          }
+
+          message.region = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DataSourceExternalSecret {
+    return {
+      secretType: isSet(object.secretType) ? dataSourceExternalSecret_SecretTypeFromJSON(object.secretType) : 0,
+      authType: isSet(object.authType) ? dataSourceExternalSecret_AuthTypeFromJSON(object.authType) : 0,
+      url: isSet(object.url) ? String(object.url) : "",
+      obfuscatedToken: isSet(object.obfuscatedToken) ? String(object.obfuscatedToken) : "",
+      appRoleId: isSet(object.appRoleId) ? String(object.appRoleId) : "",
+      obfuscatedAppRoleSecretId: isSet(object.obfuscatedAppRoleSecretId)
+        ? String(object.obfuscatedAppRoleSecretId)
+        : "",
+      engineName: isSet(object.engineName) ? String(object.engineName) : "",
+      secretName: isSet(object.secretName) ? String(object.secretName) : "",
+      passwordKeyName: isSet(object.passwordKeyName) ? String(object.passwordKeyName) : "",
+      usernameKeyName: isSet(object.usernameKeyName) ? String(object.usernameKeyName) : "",
+      databaseRole: isSet(object.databaseRole) ? String(object.databaseRole) : "",
+      region: isSet(object.region) ? String(object.region) : "",
+    };
+  },
+
+  toJSON(message: DataSourceExternalSecret): unknown {
+    const obj: any = {};
+    message.secretType !== undefined &&
+      (obj.secretType = dataSourceExternalSecret_SecretTypeToJSON(message.secretType));
+    message.authType !== undefined && (obj.authType = dataSourceExternalSecret_AuthTypeToJSON(message.authType));
+    message.url !== undefined && (obj.url = message.url);
+    message.obfuscatedToken !== undefined && (obj.obfuscatedToken = message.obfuscatedToken);
+    message.appRoleId !== undefined && (obj.appRoleId = message.appRoleId);
+    message.obfuscatedAppRoleSecretId !== undefined &&
+      (obj.obfuscatedAppRoleSecretId = message.obfuscatedAppRoleSecretId);
+    message.engineName !== undefined && (obj.engineName = message.engineName);
+    message.secretName !== undefined && (obj.secretName = message.secretName);
+    message.passwordKeyName !== undefined && (obj.passwordKeyName = message.passwordKeyName);
+    message.usernameKeyName !== undefined && (obj.usernameKeyName = message.usernameKeyName);
+    message.databaseRole !== undefined && (obj.databaseRole = message.databaseRole);
+    message.region !== undefined && (obj.region = message.region);
+    return obj;
+  },
+
+  create(base?: DeepPartial<DataSourceExternalSecret>): DataSourceExternalSecret {
+    return DataSourceExternalSecret.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<DataSourceExternalSecret>): DataSourceExternalSecret {
+    const message = createBaseDataSourceExternalSecret();
+    message.secretType = object.secretType ?? 0;
+    message.authType = object.authType ?? 0;
+    message.url = object.url ?? "";
+    message.obfuscatedToken = object.obfuscatedToken ?? "";
+    message.appRoleId = object.appRoleId ?? "";
+    message.obfuscatedAppRoleSecretId = object.obfuscatedAppRoleSecretId ?? "";
+    message.engineName = object.engineName ?? "";
+    message.secretName = object.secretName ?? "";
+    message.passwordKeyName = object.passwordKeyName ?? "";
+    message.usernameKeyName = object.usernameKeyName ?? "";
+    message.databaseRole = object.databaseRole ?? "";
+    message.region = object.region ?? "";
+    return message;
+  },
+};
+
+function createBaseDataSourceIAMAuthentication(): DataSourceIAMAuthentication {
+  return { type: 0, region: "" };
+}
+
+export const DataSourceIAMAuthentication = {
+  encode(message: DataSourceIAMAuthentication, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.type !== 0) {
+      writer.uint32(8).int32(message.type);
+    }
+    if (message.region !== "") {
+      writer.uint32(18).string(message.region);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DataSourceIAMAuthentication {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDataSourceIAMAuthentication();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.type = reader.int32() as any;
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.region = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DataSourceIAMAuthentication {
+    return {
+      type: isSet(object.type) ? dataSourceIAMAuthentication_TypeFromJSON(object.type) : 0,
+      region: isSet(object.region) ? String(object.region) : "",
+    };
+  },
+
+  toJSON(message: DataSourceIAMAuthentication): unknown {
+    const obj: any = {};
+    message.type !== undefined && (obj.type = dataSourceIAMAuthentication_TypeToJSON(message.type));
+    message.region !== undefined && (obj.region = message.region);
+    return obj;
+  },
+
+  create(base?: DeepPartial<DataSourceIAMAuthentication>): DataSourceIAMAuthentication {
+    return DataSourceIAMAuthentication.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<DataSourceIAMAuthentication>): DataSourceIAMAuthentication {
+    const message = createBaseDataSourceIAMAuthentication();
+    message.type = object.type ?? 0;
+    message.region = object.region ?? "";
     return message;
   },
 };
@@ -8,6 +8,7 @@ export enum IdentityProviderType {
   OAUTH2 = 1,
   OIDC = 2,
   LDAP = 3,
+  SAML = 4,
   UNRECOGNIZED = -1,
 }
 
@@ -25,6 +26,9 @@ export function identityProviderTypeFromJSON(object: any): IdentityProviderType
     case 3:
     case "LDAP":
       return IdentityProviderType.LDAP;
+    case 4:
+    case "SAML":
+      return IdentityProviderType.SAML;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -42,6 +46,8 @@ export function identityProviderTypeToJSON(object: IdentityProviderType): string
       return "OIDC";
     case IdentityProviderType.LDAP:
       return "LDAP";
+    case IdentityProviderType.SAML:
+      return "SAML";
     case IdentityProviderType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -99,6 +105,7 @@ export interface IdentityProviderConfig {
   oauth2Config?: OAuth2IdentityProviderConfig | undefined;
   oidcConfig?: OIDCIdentityProviderConfig | undefined;
   ldapConfig?: LDAPIdentityProviderConfig | undefined;
+  samlConfig?: SAMLIdentityProviderConfig | undefined;
 }
 
 /** OAuth2IdentityProviderConfig is the structure for OAuth2 identity provider config. */
@@ -163,6 +170,46 @@ export interface LDAPIdentityProviderConfig {
   fieldMapping: FieldMapping | undefined;
 }
 
+/** SAMLIdentityProviderConfig is the structure for SAML 2.0 identity provider config. */
+export interface SAMLIdentityProviderConfig {
+  /**
+   * EntityID is the entity ID of the identity provider, which is expected to
+   * be the issuer of the SAML responses.
+   */
+  entityId: string;
+  /**
+   * SSOURL is the single sign-on URL of the identity provider that receives
+   * the authentication requests with the HTTP-Redirect binding.
+   */
+  ssoUrl: string;
+  /**
+   * Certificate is the PEM-encoded X.509 certificate that the identity provider
+   * signs the SAML responses with.
+   */
+  certificate: string;
+  /**
+   * SPCertificate is the PEM-encoded X.509 certificate of Bytebase as the
+   * service provider, which is published in the service provider metadata.
+   */
+  spCertificate: string;
+  /**
+   * SPPrivateKey is the PEM-encoded private key of the service provider
+   * certificate, which is used to sign the authentication requests.
+   */
+  spPrivateKey: string;
+  /**
+   * NameIDFormat is the name identifier format requested from the identity
+   * provider, e.g. "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress".
+   * When not set, the unspecified format will be used.
+   */
+  nameIdFormat: string;
+  /**
+   * FieldMapping is the mapping of the assertion attributes returned by the
+   * identity provider. The subject name identifier is available as "NameID".
+   */
+  fieldMapping: FieldMapping | undefined;
+}
+
 /**
  * FieldMapping saves the field names from user info API of identity provider.
  * As we save all raw json string of user info response data into `principal.idp_user_info`,
@@ -182,6 +229,12 @@ export interface FieldMapping {
   email: string;
   /** Phone is the field name of primary phone in 3rd-party idp user info. Optional. */
   phone: string;
+  /**
+   * Groups is the field name of the group list in 3rd-party idp user info, e.g. the groups claim of OIDC or the memberOf attribute of LDAP. Optional.
+   * On login, the user is added to the user groups linked to the identity provider whose identity provider group is in the list,
+   * and removed from the other groups that the identity provider has added the user to.
+   */
+  groups: string;
 }
 
 export interface IdentityProviderUserInfo {
@@ -193,10 +246,12 @@ export interface IdentityProviderUserInfo {
   email: string;
   /** Phone is the value of primary phone in 3rd-party idp user info. */
   phone: string;
+  /** Groups is the value of the group list in 3rd-party idp user info. */
+  groups: string[];
 }
 
 function createBaseIdentityProviderConfig(): IdentityProviderConfig {
-  return { oauth2Config: undefined, oidcConfig: undefined, ldapConfig: undefined };
+  return { oauth2Config: undefined, oidcConfig: undefined, ldapConfig: undefined, samlConfig: undefined };
 }
 
 export const IdentityProviderConfig = {
@@ -210,6 +265,9 @@ export const IdentityProviderConfig = {
     if (message.ldapConfig !== undefined) {
       LDAPIdentityProviderConfig.encode(message.ldapConfig, writer.uint32(26).fork()).ldelim();
     }
+    if (message.samlConfig !== undefined) {
+      SAMLIdentityProviderConfig.encode(message.samlConfig, writer.uint32(34).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -241,6 +299,13 @@ export const IdentityProviderConfig = {
 
           message.ldapConfig = LDAPIdentityProviderConfig.decode(reader, reader.uint32());
           continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.samlConfig = SAMLIdentityProviderConfig.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -255,6 +320,7 @@ export const IdentityProviderConfig = {
       oauth2Config: isSet(object.oauth2Config) ? OAuth2IdentityProviderConfig.fromJSON(object.oauth2Config) : undefined,
       oidcConfig: isSet(object.oidcConfig) ? OIDCIdentityProviderConfig.fromJSON(object.oidcConfig) : undefined,
       ldapConfig: isSet(object.ldapConfig) ? LDAPIdentityProviderConfig.fromJSON(object.ldapConfig) : undefined,
+      samlConfig: isSet(object.samlConfig) ? SAMLIdentityProviderConfig.fromJSON(object.samlConfig) : undefined,
     };
   },
 
@@ -266,6 +332,8 @@ export const IdentityProviderConfig = {
       (obj.oidcConfig = message.oidcConfig ? OIDCIdentityProviderConfig.toJSON(message.oidcConfig) : undefined);
     message.ldapConfig !== undefined &&
       (obj.ldapConfig = message.ldapConfig ? LDAPIdentityProviderConfig.toJSON(message.ldapConfig) : undefined);
+    message.samlConfig !== undefined &&
+      (obj.samlConfig = message.samlConfig ? SAMLIdentityProviderConfig.toJSON(message.samlConfig) : undefined);
     return obj;
   },
 
@@ -284,6 +352,9 @@ export const IdentityProviderConfig = {
     message.ldapConfig = (object.ldapConfig !== undefined && object.ldapConfig !== null)
       ? LDAPIdentityProviderConfig.fromPartial(object.ldapConfig)
       : undefined;
+    message.samlConfig = (object.samlConfig !== undefined && object.samlConfig !== null)
+      ? SAMLIdentityProviderConfig.fromPartial(object.samlConfig)
+      : undefined;
     return message;
   },
 };
@@ -768,8 +839,155 @@ export const LDAPIdentityProviderConfig = {
   },
 };
 
+function createBaseSAMLIdentityProviderConfig(): SAMLIdentityProviderConfig {
+  return {
+    entityId: "",
+    ssoUrl: "",
+    certificate: "",
+    spCertificate: "",
+    spPrivateKey: "",
+    nameIdFormat: "",
+    fieldMapping: undefined,
+  };
+}
+
+export const SAMLIdentityProviderConfig = {
+  encode(message: SAMLIdentityProviderConfig, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.entityId !== "") {
+      writer.uint32(10).string(message.entityId);
+    }
+    if (message.ssoUrl !== "") {
+      writer.uint32(18).string(message.ssoUrl);
+    }
+    if (message.certificate !== "") {
+      writer.uint32(26).string(message.certificate);
+    }
+    if (message.spCertificate !== "") {
+      writer.uint32(34).string(message.spCertificate);
+    }
+    if (message.spPrivateKey !== "") {
+      writer.uint32(42).string(message.spPrivateKey);
+    }
+    if (message.nameIdFormat !== "") {
+      writer.uint32(50).string(message.nameIdFormat);
+    }
+    if (message.fieldMapping !== undefined) {
+      FieldMapping.encode(message.fieldMapping, writer.uint32(58).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SAMLIdentityProviderConfig {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSAMLIdentityProviderConfig();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.entityId = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.ssoUrl = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.certificate = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.spCertificate = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.spPrivateKey = reader.string();
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.nameIdFormat = reader.string();
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.fieldMapping = FieldMapping.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SAMLIdentityProviderConfig {
+    return {
+      entityId: isSet(object.entityId) ? String(object.entityId) : "",
+      ssoUrl: isSet(object.ssoUrl) ? String(object.ssoUrl) : "",
+      certificate: isSet(object.certificate) ? String(object.certificate) : "",
+      spCertificate: isSet(object.spCertificate) ? String(object.spCertificate) : "",
+      spPrivateKey: isSet(object.spPrivateKey) ? String(object.spPrivateKey) : "",
+      nameIdFormat: isSet(object.nameIdFormat) ? String(object.nameIdFormat) : "",
+      fieldMapping: isSet(object.fieldMapping) ? FieldMapping.fromJSON(object.fieldMapping) : undefined,
+    };
+  },
+
+  toJSON(message: SAMLIdentityProviderConfig): unknown {
+    const obj: any = {};
+    message.entityId !== undefined && (obj.entityId = message.entityId);
+    message.ssoUrl !== undefined && (obj.ssoUrl = message.ssoUrl);
+    message.certificate !== undefined && (obj.certificate = message.certificate);
+    message.spCertificate !== undefined && (obj.spCertificate = message.spCertificate);
+    message.spPrivateKey !== undefined && (obj.spPrivateKey = message.spPrivateKey);
+    message.nameIdFormat !== undefined && (obj.nameIdFormat = message.nameIdFormat);
+    message.fieldMapping !== undefined &&
+      (obj.fieldMapping = message.fieldMapping ? FieldMapping.toJSON(message.fieldMapping) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<SAMLIdentityProviderConfig>): SAMLIdentityProviderConfig {
+    return SAMLIdentityProviderConfig.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<SAMLIdentityProviderConfig>): SAMLIdentityProviderConfig {
+    const message = createBaseSAMLIdentityProviderConfig();
+    message.entityId = object.entityId ?? "";
+    message.ssoUrl = object.ssoUrl ?? "";
+    message.certificate = object.certificate ?? "";
+    message.spCertificate = object.spCertificate ?? "";
+    message.spPrivateKey = object.spPrivateKey ?? "";
+    message.nameIdFormat = object.nameIdFormat ?? "";
+    message.fieldMapping = (object.fieldMapping !== undefined && object.fieldMapping !== null)
+      ? FieldMapping.fromPartial(object.fieldMapping)
+      : undefined;
+    return message;
+  },
+};
+
 function createBaseFieldMapping(): FieldMapping {
-  return { identifier: "", displayName: "", email: "", phone: "" };
+  return { identifier: "", displayName: "", email: "", phone: "", groups: "" };
 }
 
 export const FieldMapping = {
@@ -786,6 +1004,9 @@ export const FieldMapping = {
     if (message.phone !== "") {
       writer.uint32(34).string(message.phone);
     }
+    if (message.groups !== "") {
+      writer.uint32(42).string(message.groups);
+    }
     return writer;
   },
 
@@ -824,6 +1045,13 @@ export const FieldMapping = {
 
           message.phone = reader.string();
           continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.groups = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -839,6 +1067,7 @@ export const FieldMapping = {
       displayName: isSet(object.displayName) ? String(object.displayName) : "",
       email: isSet(object.email) ? String(object.email) : "",
       phone: isSet(object.phone) ? String(object.phone) : "",
+      groups: isSet(object.groups) ? String(object.groups) : "",
     };
   },
 
@@ -848,6 +1077,7 @@ export const FieldMapping = {
     message.displayName !== undefined && (obj.displayName = message.displayName);
     message.email !== undefined && (obj.email = message.email);
     message.phone !== undefined && (obj.phone = message.phone);
+    message.groups !== undefined && (obj.groups = message.groups);
     return obj;
   },
 
@@ -861,12 +1091,13 @@ export const FieldMapping = {
     message.displayName = object.displayName ?? "";
     message.email = object.email ?? "";
     message.phone = object.phone ?? "";
+    message.groups = object.groups ?? "";
     return message;
   },
 };
 
 function createBaseIdentityProviderUserInfo(): IdentityProviderUserInfo {
-  return { identifier: "", displayName: "", email: "", phone: "" };
+  return { identifier: "", displayName: "", email: "", phone: "", groups: [] };
 }
 
 export const IdentityProviderUserInfo = {
@@ -883,6 +1114,9 @@ export const IdentityProviderUserInfo = {
     if (message.phone !== "") {
       writer.uint32(34).string(message.phone);
     }
+    for (const v of message.groups) {
+      writer.uint32(42).string(v!);
+    }
     return writer;
   },
 
@@ -921,6 +1155,13 @@ export const IdentityProviderUserInfo = {
 
           message.phone = reader.string();
           continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.groups.push(reader.string());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -936,6 +1177,7 @@ export const IdentityProviderUserInfo = {
       displayName: isSet(object.displayName) ? String(object.displayName) : "",
       email: isSet(object.email) ? String(object.email) : "",
       phone: isSet(object.phone) ? String(object.phone) : "",
+      groups: Array.isArray(object?.groups) ? object.groups.map((e: any) => String(e)) : [],
     };
   },
 
@@ -945,6 +1187,11 @@ export const IdentityProviderUserInfo = {
     message.displayName !== undefined && (obj.displayName = message.displayName);
     message.email !== undefined && (obj.email = message.email);
     message.phone !== undefined && (obj.phone = message.phone);
+    if (message.groups) {
+      obj.groups = message.groups.map((e) => e);
+    } else {
+      obj.groups = [];
+    }
     return obj;
   },
 
@@ -958,6 +1205,7 @@ export const IdentityProviderUserInfo = {
     message.displayName = object.displayName ?? "";
     message.email = object.email ?? "";
     message.phone = object.phone ?? "";
+    message.groups = object.groups?.map((e) => e) || [];
     return message;
   },
 };
@@ -6,7 +6,11 @@ export const protobufPackage = "bytebase.store";
 
 export interface InstanceChangeHistoryPayload {
   pushEvent: PushEvent | undefined;
-  changedResources: ChangedResources | undefined;
+  changedResources:
+    | ChangedResources
+    | undefined;
+  /** The revision of the sheet executed by the change. */
+  sheetRevision: number;
 }
 
 export interface ChangedResources {
@@ -28,7 +32,7 @@ export interface ChangedResourceTable {
 }
 
 function createBaseInstanceChangeHistoryPayload(): InstanceChangeHistoryPayload {
-  return { pushEvent: undefined, changedResources: undefined };
+  return { pushEvent: undefined, changedResources: undefined, sheetRevision: 0 };
 }
 
 export const InstanceChangeHistoryPayload = {
@@ -39,6 +43,9 @@ export const InstanceChangeHistoryPayload = {
     if (message.changedResources !== undefined) {
       ChangedResources.encode(message.changedResources, writer.uint32(18).fork()).ldelim();
     }
+    if (message.sheetRevision !== 0) {
+      writer.uint32(24).int32(message.sheetRevision);
+    }
     return writer;
   },
 
@@ -63,6 +70,13 @@ export const InstanceChangeHistoryPayload = {
 
           message.changedResources = ChangedResources.decode(reader, reader.uint32());
           continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.sheetRevision = reader.int32();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -76,6 +90,7 @@ export const InstanceChangeHistoryPayload = {
     return {
       pushEvent: isSet(object.pushEvent) ? PushEvent.fromJSON(object.pushEvent) : undefined,
       changedResources: isSet(object.changedResources) ? ChangedResources.fromJSON(object.changedResources) : undefined,
+      sheetRevision: isSet(object.sheetRevision) ? Number(object.sheetRevision) : 0,
     };
   },
 
@@ -85,6 +100,7 @@ export const InstanceChangeHistoryPayload = {
       (obj.pushEvent = message.pushEvent ? PushEvent.toJSON(message.pushEvent) : undefined);
     message.changedResources !== undefined &&
       (obj.changedResources = message.changedResources ? ChangedResources.toJSON(message.changedResources) : undefined);
+    message.sheetRevision !== undefined && (obj.sheetRevision = Math.round(message.sheetRevision));
     return obj;
   },
 
@@ -100,6 +116,7 @@ export const InstanceChangeHistoryPayload = {
     message.changedResources = (object.changedResources !== undefined && object.changedResources !== null)
       ? ChangedResources.fromPartial(object.changedResources)
       : undefined;
+    message.sheetRevision = object.sheetRevision ?? 0;
     return message;
   },
 };
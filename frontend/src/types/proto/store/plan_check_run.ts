@@ -1,6 +1,7 @@
 /* eslint-disable */
 import Long from "long";
 import _m0 from "protobufjs/minimal";
+import { Duration } from "../google/protobuf/duration";
 import { ChangedResources } from "./instance_change_history";
 
 export const protobufPackage = "bytebase.store";
@@ -71,6 +72,7 @@ export interface PlanCheckRunResult_Result {
   code: number;
   sqlSummaryReport?: PlanCheckRunResult_Result_SqlSummaryReport | undefined;
   sqlReviewReport?: PlanCheckRunResult_Result_SqlReviewReport | undefined;
+  lockImpactReport?: PlanCheckRunResult_Result_LockImpactReport | undefined;
 }
 
 export enum PlanCheckRunResult_Result_Status {
@@ -134,6 +136,109 @@ export interface PlanCheckRunResult_Result_SqlReviewReport {
   code: number;
 }
 
+/** LockImpactReport is the lock impact of a PostgreSQL DDL statement. */
+export interface PlanCheckRunResult_Result_LockImpactReport {
+  line: number;
+  /** statement_type is the type of the statement, such as ALTER_TABLE. */
+  statementType: string;
+  /** The table locked by the statement, in the format of "schema"."table". */
+  table: string;
+  /** lock_level is the strongest table lock taken by the statement. */
+  lockLevel: PlanCheckRunResult_Result_LockImpactReport_LockLevel;
+  /** table_rewrite is whether the statement rewrites the whole table and its indexes. */
+  tableRewrite: boolean;
+  /** validation_scan is whether the statement scans the whole table to validate the constraints. */
+  validationScan: boolean;
+  /** The synced row count and data size of the table. */
+  rowCount: number;
+  dataSize: number;
+  /** estimated_duration is the estimated duration of holding the lock, based on the synced table size. */
+  estimatedDuration: Duration | undefined;
+}
+
+/**
+ * LockLevel is the PostgreSQL table-level lock mode.
+ * See https://www.postgresql.org/docs/current/explicit-locking.html.
+ */
+export enum PlanCheckRunResult_Result_LockImpactReport_LockLevel {
+  LOCK_LEVEL_UNSPECIFIED = 0,
+  ACCESS_SHARE = 1,
+  ROW_SHARE = 2,
+  ROW_EXCLUSIVE = 3,
+  SHARE_UPDATE_EXCLUSIVE = 4,
+  SHARE = 5,
+  SHARE_ROW_EXCLUSIVE = 6,
+  EXCLUSIVE = 7,
+  ACCESS_EXCLUSIVE = 8,
+  UNRECOGNIZED = -1,
+}
+
+export function planCheckRunResult_Result_LockImpactReport_LockLevelFromJSON(
+  object: any,
+): PlanCheckRunResult_Result_LockImpactReport_LockLevel {
+  switch (object) {
+    case 0:
+    case "LOCK_LEVEL_UNSPECIFIED":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.LOCK_LEVEL_UNSPECIFIED;
+    case 1:
+    case "ACCESS_SHARE":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.ACCESS_SHARE;
+    case 2:
+    case "ROW_SHARE":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.ROW_SHARE;
+    case 3:
+    case "ROW_EXCLUSIVE":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.ROW_EXCLUSIVE;
+    case 4:
+    case "SHARE_UPDATE_EXCLUSIVE":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.SHARE_UPDATE_EXCLUSIVE;
+    case 5:
+    case "SHARE":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.SHARE;
+    case 6:
+    case "SHARE_ROW_EXCLUSIVE":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.SHARE_ROW_EXCLUSIVE;
+    case 7:
+    case "EXCLUSIVE":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.EXCLUSIVE;
+    case 8:
+    case "ACCESS_EXCLUSIVE":
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.ACCESS_EXCLUSIVE;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return PlanCheckRunResult_Result_LockImpactReport_LockLevel.UNRECOGNIZED;
+  }
+}
+
+export function planCheckRunResult_Result_LockImpactReport_LockLevelToJSON(
+  object: PlanCheckRunResult_Result_LockImpactReport_LockLevel,
+): string {
+  switch (object) {
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.LOCK_LEVEL_UNSPECIFIED:
+      return "LOCK_LEVEL_UNSPECIFIED";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.ACCESS_SHARE:
+      return "ACCESS_SHARE";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.ROW_SHARE:
+      return "ROW_SHARE";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.ROW_EXCLUSIVE:
+      return "ROW_EXCLUSIVE";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.SHARE_UPDATE_EXCLUSIVE:
+      return "SHARE_UPDATE_EXCLUSIVE";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.SHARE:
+      return "SHARE";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.SHARE_ROW_EXCLUSIVE:
+      return "SHARE_ROW_EXCLUSIVE";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.EXCLUSIVE:
+      return "EXCLUSIVE";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.ACCESS_EXCLUSIVE:
+      return "ACCESS_EXCLUSIVE";
+    case PlanCheckRunResult_Result_LockImpactReport_LockLevel.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 function createBasePlanCheckRunConfig(): PlanCheckRunConfig {
   return { sheetUid: 0, changeDatabaseType: 0, instanceUid: 0, databaseName: "", databaseGroupUid: undefined };
 }
@@ -325,7 +430,15 @@ export const PlanCheckRunResult = {
 };
 
 function createBasePlanCheckRunResult_Result(): PlanCheckRunResult_Result {
-  return { status: 0, title: "", content: "", code: 0, sqlSummaryReport: undefined, sqlReviewReport: undefined };
+  return {
+    status: 0,
+    title: "",
+    content: "",
+    code: 0,
+    sqlSummaryReport: undefined,
+    sqlReviewReport: undefined,
+    lockImpactReport: undefined,
+  };
 }
 
 export const PlanCheckRunResult_Result = {
@@ -348,6 +461,9 @@ export const PlanCheckRunResult_Result = {
     if (message.sqlReviewReport !== undefined) {
       PlanCheckRunResult_Result_SqlReviewReport.encode(message.sqlReviewReport, writer.uint32(50).fork()).ldelim();
     }
+    if (message.lockImpactReport !== undefined) {
+      PlanCheckRunResult_Result_LockImpactReport.encode(message.lockImpactReport, writer.uint32(58).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -400,6 +516,13 @@ export const PlanCheckRunResult_Result = {
 
           message.sqlReviewReport = PlanCheckRunResult_Result_SqlReviewReport.decode(reader, reader.uint32());
           continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.lockImpactReport = PlanCheckRunResult_Result_LockImpactReport.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -421,6 +544,9 @@ export const PlanCheckRunResult_Result = {
       sqlReviewReport: isSet(object.sqlReviewReport)
         ? PlanCheckRunResult_Result_SqlReviewReport.fromJSON(object.sqlReviewReport)
         : undefined,
+      lockImpactReport: isSet(object.lockImpactReport)
+        ? PlanCheckRunResult_Result_LockImpactReport.fromJSON(object.lockImpactReport)
+        : undefined,
     };
   },
 
@@ -436,6 +562,9 @@ export const PlanCheckRunResult_Result = {
     message.sqlReviewReport !== undefined && (obj.sqlReviewReport = message.sqlReviewReport
       ? PlanCheckRunResult_Result_SqlReviewReport.toJSON(message.sqlReviewReport)
       : undefined);
+    message.lockImpactReport !== undefined && (obj.lockImpactReport = message.lockImpactReport
+      ? PlanCheckRunResult_Result_LockImpactReport.toJSON(message.lockImpactReport)
+      : undefined);
     return obj;
   },
 
@@ -455,6 +584,9 @@ export const PlanCheckRunResult_Result = {
     message.sqlReviewReport = (object.sqlReviewReport !== undefined && object.sqlReviewReport !== null)
       ? PlanCheckRunResult_Result_SqlReviewReport.fromPartial(object.sqlReviewReport)
       : undefined;
+    message.lockImpactReport = (object.lockImpactReport !== undefined && object.lockImpactReport !== null)
+      ? PlanCheckRunResult_Result_LockImpactReport.fromPartial(object.lockImpactReport)
+      : undefined;
     return message;
   },
 };
@@ -664,6 +796,186 @@ export const PlanCheckRunResult_Result_SqlReviewReport = {
   },
 };
 
+function createBasePlanCheckRunResult_Result_LockImpactReport(): PlanCheckRunResult_Result_LockImpactReport {
+  return {
+    line: 0,
+    statementType: "",
+    table: "",
+    lockLevel: 0,
+    tableRewrite: false,
+    validationScan: false,
+    rowCount: 0,
+    dataSize: 0,
+    estimatedDuration: undefined,
+  };
+}
+
+export const PlanCheckRunResult_Result_LockImpactReport = {
+  encode(message: PlanCheckRunResult_Result_LockImpactReport, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.line !== 0) {
+      writer.uint32(8).int64(message.line);
+    }
+    if (message.statementType !== "") {
+      writer.uint32(18).string(message.statementType);
+    }
+    if (message.table !== "") {
+      writer.uint32(26).string(message.table);
+    }
+    if (message.lockLevel !== 0) {
+      writer.uint32(32).int32(message.lockLevel);
+    }
+    if (message.tableRewrite === true) {
+      writer.uint32(40).bool(message.tableRewrite);
+    }
+    if (message.validationScan === true) {
+      writer.uint32(48).bool(message.validationScan);
+    }
+    if (message.rowCount !== 0) {
+      writer.uint32(56).int64(message.rowCount);
+    }
+    if (message.dataSize !== 0) {
+      writer.uint32(64).int64(message.dataSize);
+    }
+    if (message.estimatedDuration !== undefined) {
+      Duration.encode(message.estimatedDuration, writer.uint32(74).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PlanCheckRunResult_Result_LockImpactReport {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlanCheckRunResult_Result_LockImpactReport();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.line = longToNumber(reader.int64() as Long);
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.statementType = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.table = reader.string();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.lockLevel = reader.int32() as any;
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.tableRewrite = reader.bool();
+          continue;
+        case 6:
+          if (tag !== 48) {
+            break;
+          }
+
+          message.validationScan = reader.bool();
+          continue;
+        case 7:
+          if (tag !== 56) {
+            break;
+          }
+
+          message.rowCount = longToNumber(reader.int64() as Long);
+          continue;
+        case 8:
+          if (tag !== 64) {
+            break;
+          }
+
+          message.dataSize = longToNumber(reader.int64() as Long);
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.estimatedDuration = Duration.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlanCheckRunResult_Result_LockImpactReport {
+    return {
+      line: isSet(object.line) ? Number(object.line) : 0,
+      statementType: isSet(object.statementType) ? String(object.statementType) : "",
+      table: isSet(object.table) ? String(object.table) : "",
+      lockLevel: isSet(object.lockLevel)
+        ? planCheckRunResult_Result_LockImpactReport_LockLevelFromJSON(object.lockLevel)
+        : 0,
+      tableRewrite: isSet(object.tableRewrite) ? Boolean(object.tableRewrite) : false,
+      validationScan: isSet(object.validationScan) ? Boolean(object.validationScan) : false,
+      rowCount: isSet(object.rowCount) ? Number(object.rowCount) : 0,
+      dataSize: isSet(object.dataSize) ? Number(object.dataSize) : 0,
+      estimatedDuration: isSet(object.estimatedDuration) ? Duration.fromJSON(object.estimatedDuration) : undefined,
+    };
+  },
+
+  toJSON(message: PlanCheckRunResult_Result_LockImpactReport): unknown {
+    const obj: any = {};
+    message.line !== undefined && (obj.line = Math.round(message.line));
+    message.statementType !== undefined && (obj.statementType = message.statementType);
+    message.table !== undefined && (obj.table = message.table);
+    message.lockLevel !== undefined &&
+      (obj.lockLevel = planCheckRunResult_Result_LockImpactReport_LockLevelToJSON(message.lockLevel));
+    message.tableRewrite !== undefined && (obj.tableRewrite = message.tableRewrite);
+    message.validationScan !== undefined && (obj.validationScan = message.validationScan);
+    message.rowCount !== undefined && (obj.rowCount = Math.round(message.rowCount));
+    message.dataSize !== undefined && (obj.dataSize = Math.round(message.dataSize));
+    message.estimatedDuration !== undefined &&
+      (obj.estimatedDuration = message.estimatedDuration ? Duration.toJSON(message.estimatedDuration) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<PlanCheckRunResult_Result_LockImpactReport>): PlanCheckRunResult_Result_LockImpactReport {
+    return PlanCheckRunResult_Result_LockImpactReport.fromPartial(base ?? {});
+  },
+
+  fromPartial(
+    object: DeepPartial<PlanCheckRunResult_Result_LockImpactReport>,
+  ): PlanCheckRunResult_Result_LockImpactReport {
+    const message = createBasePlanCheckRunResult_Result_LockImpactReport();
+    message.line = object.line ?? 0;
+    message.statementType = object.statementType ?? "";
+    message.table = object.table ?? "";
+    message.lockLevel = object.lockLevel ?? 0;
+    message.tableRewrite = object.tableRewrite ?? false;
+    message.validationScan = object.validationScan ?? false;
+    message.rowCount = object.rowCount ?? 0;
+    message.dataSize = object.dataSize ?? 0;
+    message.estimatedDuration = (object.estimatedDuration !== undefined && object.estimatedDuration !== null)
+      ? Duration.fromPartial(object.estimatedDuration)
+      : undefined;
+    return message;
+  },
+};
+
 declare const self: any | undefined;
 declare const window: any | undefined;
 declare const global: any | undefined;
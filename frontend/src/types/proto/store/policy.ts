@@ -1,5 +1,7 @@
 /* eslint-disable */
 import _m0 from "protobufjs/minimal";
+import { Duration } from "../google/protobuf/duration";
+import { Timestamp } from "../google/protobuf/timestamp";
 import { Expr } from "../google/type/expr";
 import { MaskingLevel, maskingLevelFromJSON, maskingLevelToJSON } from "./common";
 
@@ -58,6 +60,7 @@ export interface MaskingExceptionPolicy_MaskingException {
    * Member is the principal who bind to this exception policy instance.
    *
    * * `user:{emailid}`: An email address that represents a specific Bytebase account. For example, `alice@example.com`.
+   * * `group:{emailid}`: An email address that represents a user group. For example, `dba@example.com`.
    */
   member: string;
   /** The condition that is associated with this exception policy instance. */
@@ -118,6 +121,51 @@ export interface MaskingRulePolicy_MaskingRule {
   maskingLevel: MaskingLevel;
 }
 
+/** RolloutWindowPolicy is the policy restricting when the tasks of an environment are allowed to roll out. */
+export interface RolloutWindowPolicy {
+  /**
+   * The recurring windows in which the tasks are allowed to roll out.
+   * The tasks are allowed to roll out at any time if empty.
+   */
+  allowedWindows: RolloutWindowPolicy_Window[];
+  /** The periods in which the tasks are not allowed to roll out, such as a holiday code freeze. */
+  freezePeriods: RolloutWindowPolicy_FreezePeriod[];
+  /** The workspace roles of the task run creators who can roll out at any time, such as "OWNER" and "DBA". */
+  bypassRoles: string[];
+}
+
+export interface RolloutWindowPolicy_Window {
+  /**
+   * The cron expression of the window start in the format of "minute hour day-of-month month day-of-week".
+   * For example, "0 2 * * 2-4" starts the window at 02:00 from Tuesday to Thursday.
+   */
+  cron: string;
+  /** The duration of the window. */
+  duration:
+    | Duration
+    | undefined;
+  /**
+   * The IANA time zone of the cron expression, such as "America/New_York".
+   * UTC is used if empty.
+   */
+  timeZone: string;
+}
+
+export interface RolloutWindowPolicy_FreezePeriod {
+  title: string;
+  startTime: Date | undefined;
+  endTime: Date | undefined;
+}
+
+/** RedisKeyAccessPolicy is the policy restricting the keys accessed by the Redis commands in the SQL Editor of a project. */
+export interface RedisKeyAccessPolicy {
+  /**
+   * The glob-style key patterns in the same syntax as the KEYS command, such as "user:*".
+   * The commands can only access the keys matching one of the patterns, and the keys are not restricted if empty.
+   */
+  keyPatterns: string[];
+}
+
 function createBaseIamPolicy(): IamPolicy {
   return { bindings: [] };
 }
@@ -741,6 +789,336 @@ export const MaskingRulePolicy_MaskingRule = {
   },
 };
 
+function createBaseRolloutWindowPolicy(): RolloutWindowPolicy {
+  return { allowedWindows: [], freezePeriods: [], bypassRoles: [] };
+}
+
+export const RolloutWindowPolicy = {
+  encode(message: RolloutWindowPolicy, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.allowedWindows) {
+      RolloutWindowPolicy_Window.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    for (const v of message.freezePeriods) {
+      RolloutWindowPolicy_FreezePeriod.encode(v!, writer.uint32(18).fork()).ldelim();
+    }
+    for (const v of message.bypassRoles) {
+      writer.uint32(26).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RolloutWindowPolicy {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRolloutWindowPolicy();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.allowedWindows.push(RolloutWindowPolicy_Window.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.freezePeriods.push(RolloutWindowPolicy_FreezePeriod.decode(reader, reader.uint32()));
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.bypassRoles.push(reader.string());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RolloutWindowPolicy {
+    return {
+      allowedWindows: Array.isArray(object?.allowedWindows)
+        ? object.allowedWindows.map((e: any) => RolloutWindowPolicy_Window.fromJSON(e))
+        : [],
+      freezePeriods: Array.isArray(object?.freezePeriods)
+        ? object.freezePeriods.map((e: any) => RolloutWindowPolicy_FreezePeriod.fromJSON(e))
+        : [],
+      bypassRoles: Array.isArray(object?.bypassRoles) ? object.bypassRoles.map((e: any) => String(e)) : [],
+    };
+  },
+
+  toJSON(message: RolloutWindowPolicy): unknown {
+    const obj: any = {};
+    if (message.allowedWindows) {
+      obj.allowedWindows = message.allowedWindows.map((e) => e ? RolloutWindowPolicy_Window.toJSON(e) : undefined);
+    } else {
+      obj.allowedWindows = [];
+    }
+    if (message.freezePeriods) {
+      obj.freezePeriods = message.freezePeriods.map((e) => e ? RolloutWindowPolicy_FreezePeriod.toJSON(e) : undefined);
+    } else {
+      obj.freezePeriods = [];
+    }
+    if (message.bypassRoles) {
+      obj.bypassRoles = message.bypassRoles.map((e) => e);
+    } else {
+      obj.bypassRoles = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RolloutWindowPolicy>): RolloutWindowPolicy {
+    return RolloutWindowPolicy.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RolloutWindowPolicy>): RolloutWindowPolicy {
+    const message = createBaseRolloutWindowPolicy();
+    message.allowedWindows = object.allowedWindows?.map((e) => RolloutWindowPolicy_Window.fromPartial(e)) || [];
+    message.freezePeriods = object.freezePeriods?.map((e) => RolloutWindowPolicy_FreezePeriod.fromPartial(e)) || [];
+    message.bypassRoles = object.bypassRoles?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseRolloutWindowPolicy_Window(): RolloutWindowPolicy_Window {
+  return { cron: "", duration: undefined, timeZone: "" };
+}
+
+export const RolloutWindowPolicy_Window = {
+  encode(message: RolloutWindowPolicy_Window, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.cron !== "") {
+      writer.uint32(10).string(message.cron);
+    }
+    if (message.duration !== undefined) {
+      Duration.encode(message.duration, writer.uint32(18).fork()).ldelim();
+    }
+    if (message.timeZone !== "") {
+      writer.uint32(26).string(message.timeZone);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RolloutWindowPolicy_Window {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRolloutWindowPolicy_Window();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.cron = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.duration = Duration.decode(reader, reader.uint32());
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.timeZone = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RolloutWindowPolicy_Window {
+    return {
+      cron: isSet(object.cron) ? String(object.cron) : "",
+      duration: isSet(object.duration) ? Duration.fromJSON(object.duration) : undefined,
+      timeZone: isSet(object.timeZone) ? String(object.timeZone) : "",
+    };
+  },
+
+  toJSON(message: RolloutWindowPolicy_Window): unknown {
+    const obj: any = {};
+    message.cron !== undefined && (obj.cron = message.cron);
+    message.duration !== undefined && (obj.duration = message.duration ? Duration.toJSON(message.duration) : undefined);
+    message.timeZone !== undefined && (obj.timeZone = message.timeZone);
+    return obj;
+  },
+
+  create(base?: DeepPartial<RolloutWindowPolicy_Window>): RolloutWindowPolicy_Window {
+    return RolloutWindowPolicy_Window.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RolloutWindowPolicy_Window>): RolloutWindowPolicy_Window {
+    const message = createBaseRolloutWindowPolicy_Window();
+    message.cron = object.cron ?? "";
+    message.duration = (object.duration !== undefined && object.duration !== null)
+      ? Duration.fromPartial(object.duration)
+      : undefined;
+    message.timeZone = object.timeZone ?? "";
+    return message;
+  },
+};
+
+function createBaseRolloutWindowPolicy_FreezePeriod(): RolloutWindowPolicy_FreezePeriod {
+  return { title: "", startTime: undefined, endTime: undefined };
+}
+
+export const RolloutWindowPolicy_FreezePeriod = {
+  encode(message: RolloutWindowPolicy_FreezePeriod, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.title !== "") {
+      writer.uint32(10).string(message.title);
+    }
+    if (message.startTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.startTime), writer.uint32(18).fork()).ldelim();
+    }
+    if (message.endTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.endTime), writer.uint32(26).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RolloutWindowPolicy_FreezePeriod {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRolloutWindowPolicy_FreezePeriod();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.startTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.endTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RolloutWindowPolicy_FreezePeriod {
+    return {
+      title: isSet(object.title) ? String(object.title) : "",
+      startTime: isSet(object.startTime) ? fromJsonTimestamp(object.startTime) : undefined,
+      endTime: isSet(object.endTime) ? fromJsonTimestamp(object.endTime) : undefined,
+    };
+  },
+
+  toJSON(message: RolloutWindowPolicy_FreezePeriod): unknown {
+    const obj: any = {};
+    message.title !== undefined && (obj.title = message.title);
+    message.startTime !== undefined && (obj.startTime = message.startTime.toISOString());
+    message.endTime !== undefined && (obj.endTime = message.endTime.toISOString());
+    return obj;
+  },
+
+  create(base?: DeepPartial<RolloutWindowPolicy_FreezePeriod>): RolloutWindowPolicy_FreezePeriod {
+    return RolloutWindowPolicy_FreezePeriod.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RolloutWindowPolicy_FreezePeriod>): RolloutWindowPolicy_FreezePeriod {
+    const message = createBaseRolloutWindowPolicy_FreezePeriod();
+    message.title = object.title ?? "";
+    message.startTime = object.startTime ?? undefined;
+    message.endTime = object.endTime ?? undefined;
+    return message;
+  },
+};
+
+function createBaseRedisKeyAccessPolicy(): RedisKeyAccessPolicy {
+  return { keyPatterns: [] };
+}
+
+export const RedisKeyAccessPolicy = {
+  encode(message: RedisKeyAccessPolicy, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.keyPatterns) {
+      writer.uint32(10).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RedisKeyAccessPolicy {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRedisKeyAccessPolicy();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.keyPatterns.push(reader.string());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RedisKeyAccessPolicy {
+    return { keyPatterns: Array.isArray(object?.keyPatterns) ? object.keyPatterns.map((e: any) => String(e)) : [] };
+  },
+
+  toJSON(message: RedisKeyAccessPolicy): unknown {
+    const obj: any = {};
+    if (message.keyPatterns) {
+      obj.keyPatterns = message.keyPatterns.map((e) => e);
+    } else {
+      obj.keyPatterns = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RedisKeyAccessPolicy>): RedisKeyAccessPolicy {
+    return RedisKeyAccessPolicy.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RedisKeyAccessPolicy>): RedisKeyAccessPolicy {
+    const message = createBaseRedisKeyAccessPolicy();
+    message.keyPatterns = object.keyPatterns?.map((e) => e) || [];
+    return message;
+  },
+};
+
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
 
 export type DeepPartial<T> = T extends Builtin ? T
@@ -748,6 +1126,28 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = date.getTime() / 1_000;
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = (t.seconds || 0) * 1_000;
+  millis += (t.nanos || 0) / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
@@ -320,6 +320,214 @@ export interface SemanticTypesSetting_SemanticType {
   fullMaskAlgorithmId: string;
 }
 
+export interface MaskingAlgorithmSetting {
+  /** algorithms is the list of masking algorithms. */
+  algorithms: MaskingAlgorithmSetting_Algorithm[];
+}
+
+export interface MaskingAlgorithmSetting_Algorithm {
+  /** id is the uuid for masking algorithm. */
+  id: string;
+  /** title is the title for masking algorithm. */
+  title: string;
+  /** description is the description for masking algorithm. */
+  description: string;
+  fullMask?: MaskingAlgorithmSetting_Algorithm_FullMask | undefined;
+  rangeMask?: MaskingAlgorithmSetting_Algorithm_RangeMask | undefined;
+  hashMask?: MaskingAlgorithmSetting_Algorithm_HashMask | undefined;
+  innerOuterMask?: MaskingAlgorithmSetting_Algorithm_InnerOuterMask | undefined;
+  generalizationMask?: MaskingAlgorithmSetting_Algorithm_GeneralizationMask | undefined;
+}
+
+export interface MaskingAlgorithmSetting_Algorithm_FullMask {
+  /**
+   * substitution is the string used to replace the original value, the
+   * max length of the string is 16 bytes.
+   */
+  substitution: string;
+}
+
+export interface MaskingAlgorithmSetting_Algorithm_RangeMask {
+  /**
+   * We store it as a repeated field to face the fact that the original value may have multiple parts should be masked.
+   * But frontend can be started with a single rule easily.
+   */
+  slices: MaskingAlgorithmSetting_Algorithm_RangeMask_Slice[];
+}
+
+export interface MaskingAlgorithmSetting_Algorithm_RangeMask_Slice {
+  /** start is the start index of the original value, start from 0 and should be less than end. */
+  start: number;
+  /** end is the end index of the original value. */
+  end: number;
+  /** substitution is the string used to replace the OriginalValue[start:end). */
+  substitution: string;
+}
+
+export interface MaskingAlgorithmSetting_Algorithm_HashMask {
+  type: MaskingAlgorithmSetting_Algorithm_HashMask_HashType;
+  /** salt is the salt value to generate a different hash that with the word alone. */
+  salt: string;
+}
+
+export enum MaskingAlgorithmSetting_Algorithm_HashMask_HashType {
+  HASH_TYPE_UNSPECIFIED = 0,
+  MD5 = 1,
+  SHA256 = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function maskingAlgorithmSetting_Algorithm_HashMask_HashTypeFromJSON(
+  object: any,
+): MaskingAlgorithmSetting_Algorithm_HashMask_HashType {
+  switch (object) {
+    case 0:
+    case "HASH_TYPE_UNSPECIFIED":
+      return MaskingAlgorithmSetting_Algorithm_HashMask_HashType.HASH_TYPE_UNSPECIFIED;
+    case 1:
+    case "MD5":
+      return MaskingAlgorithmSetting_Algorithm_HashMask_HashType.MD5;
+    case 2:
+    case "SHA256":
+      return MaskingAlgorithmSetting_Algorithm_HashMask_HashType.SHA256;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return MaskingAlgorithmSetting_Algorithm_HashMask_HashType.UNRECOGNIZED;
+  }
+}
+
+export function maskingAlgorithmSetting_Algorithm_HashMask_HashTypeToJSON(
+  object: MaskingAlgorithmSetting_Algorithm_HashMask_HashType,
+): string {
+  switch (object) {
+    case MaskingAlgorithmSetting_Algorithm_HashMask_HashType.HASH_TYPE_UNSPECIFIED:
+      return "HASH_TYPE_UNSPECIFIED";
+    case MaskingAlgorithmSetting_Algorithm_HashMask_HashType.MD5:
+      return "MD5";
+    case MaskingAlgorithmSetting_Algorithm_HashMask_HashType.SHA256:
+      return "SHA256";
+    case MaskingAlgorithmSetting_Algorithm_HashMask_HashType.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface MaskingAlgorithmSetting_Algorithm_InnerOuterMask {
+  prefixLen: number;
+  suffixLen: number;
+  type: MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType;
+  /** substitution is the character used to replace each masked character. */
+  substitution: string;
+}
+
+export enum MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType {
+  MASK_TYPE_UNSPECIFIED = 0,
+  /** INNER - INNER masks the value between the prefix and the suffix. */
+  INNER = 1,
+  /** OUTER - OUTER masks the prefix and the suffix, and keeps the value between them. */
+  OUTER = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function maskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskTypeFromJSON(
+  object: any,
+): MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType {
+  switch (object) {
+    case 0:
+    case "MASK_TYPE_UNSPECIFIED":
+      return MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType.MASK_TYPE_UNSPECIFIED;
+    case 1:
+    case "INNER":
+      return MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType.INNER;
+    case 2:
+    case "OUTER":
+      return MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType.OUTER;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType.UNRECOGNIZED;
+  }
+}
+
+export function maskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskTypeToJSON(
+  object: MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType,
+): string {
+  switch (object) {
+    case MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType.MASK_TYPE_UNSPECIFIED:
+      return "MASK_TYPE_UNSPECIFIED";
+    case MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType.INNER:
+      return "INNER";
+    case MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType.OUTER:
+      return "OUTER";
+    case MaskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskType.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface MaskingAlgorithmSetting_Algorithm_GeneralizationMask {
+  /** bucket_size is the width of the range a number falls into, e.g. 10 generalizes 27 into [20, 30). */
+  bucketSize: number;
+  /** time_unit is the unit a date or timestamp value is truncated to. */
+  timeUnit: MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit;
+}
+
+export enum MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit {
+  TIME_UNIT_UNSPECIFIED = 0,
+  YEAR = 1,
+  MONTH = 2,
+  DAY = 3,
+  HOUR = 4,
+  UNRECOGNIZED = -1,
+}
+
+export function maskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnitFromJSON(
+  object: any,
+): MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit {
+  switch (object) {
+    case 0:
+    case "TIME_UNIT_UNSPECIFIED":
+      return MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.TIME_UNIT_UNSPECIFIED;
+    case 1:
+    case "YEAR":
+      return MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.YEAR;
+    case 2:
+    case "MONTH":
+      return MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.MONTH;
+    case 3:
+    case "DAY":
+      return MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.DAY;
+    case 4:
+    case "HOUR":
+      return MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.HOUR;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.UNRECOGNIZED;
+  }
+}
+
+export function maskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnitToJSON(
+  object: MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit,
+): string {
+  switch (object) {
+    case MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.TIME_UNIT_UNSPECIFIED:
+      return "TIME_UNIT_UNSPECIFIED";
+    case MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.YEAR:
+      return "YEAR";
+    case MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.MONTH:
+      return "MONTH";
+    case MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.DAY:
+      return "DAY";
+    case MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.HOUR:
+      return "HOUR";
+    case MaskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnit.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 function createBaseWorkspaceProfileSetting(): WorkspaceProfileSetting {
   return {
     externalUrl: "",
@@ -2184,6 +2392,739 @@ export const SemanticTypesSetting_SemanticType = {
   },
 };
 
+function createBaseMaskingAlgorithmSetting(): MaskingAlgorithmSetting {
+  return { algorithms: [] };
+}
+
+export const MaskingAlgorithmSetting = {
+  encode(message: MaskingAlgorithmSetting, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.algorithms) {
+      MaskingAlgorithmSetting_Algorithm.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): MaskingAlgorithmSetting {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaskingAlgorithmSetting();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.algorithms.push(MaskingAlgorithmSetting_Algorithm.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MaskingAlgorithmSetting {
+    return {
+      algorithms: Array.isArray(object?.algorithms)
+        ? object.algorithms.map((e: any) => MaskingAlgorithmSetting_Algorithm.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: MaskingAlgorithmSetting): unknown {
+    const obj: any = {};
+    if (message.algorithms) {
+      obj.algorithms = message.algorithms.map((e) => e ? MaskingAlgorithmSetting_Algorithm.toJSON(e) : undefined);
+    } else {
+      obj.algorithms = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<MaskingAlgorithmSetting>): MaskingAlgorithmSetting {
+    return MaskingAlgorithmSetting.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<MaskingAlgorithmSetting>): MaskingAlgorithmSetting {
+    const message = createBaseMaskingAlgorithmSetting();
+    message.algorithms = object.algorithms?.map((e) => MaskingAlgorithmSetting_Algorithm.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseMaskingAlgorithmSetting_Algorithm(): MaskingAlgorithmSetting_Algorithm {
+  return {
+    id: "",
+    title: "",
+    description: "",
+    fullMask: undefined,
+    rangeMask: undefined,
+    hashMask: undefined,
+    innerOuterMask: undefined,
+    generalizationMask: undefined,
+  };
+}
+
+export const MaskingAlgorithmSetting_Algorithm = {
+  encode(message: MaskingAlgorithmSetting_Algorithm, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.title !== "") {
+      writer.uint32(18).string(message.title);
+    }
+    if (message.description !== "") {
+      writer.uint32(26).string(message.description);
+    }
+    if (message.fullMask !== undefined) {
+      MaskingAlgorithmSetting_Algorithm_FullMask.encode(message.fullMask, writer.uint32(34).fork()).ldelim();
+    }
+    if (message.rangeMask !== undefined) {
+      MaskingAlgorithmSetting_Algorithm_RangeMask.encode(message.rangeMask, writer.uint32(42).fork()).ldelim();
+    }
+    if (message.hashMask !== undefined) {
+      MaskingAlgorithmSetting_Algorithm_HashMask.encode(message.hashMask, writer.uint32(50).fork()).ldelim();
+    }
+    if (message.innerOuterMask !== undefined) {
+      MaskingAlgorithmSetting_Algorithm_InnerOuterMask.encode(message.innerOuterMask, writer.uint32(58).fork())
+        .ldelim();
+    }
+    if (message.generalizationMask !== undefined) {
+      MaskingAlgorithmSetting_Algorithm_GeneralizationMask.encode(message.generalizationMask, writer.uint32(66).fork())
+        .ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): MaskingAlgorithmSetting_Algorithm {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaskingAlgorithmSetting_Algorithm();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.description = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.fullMask = MaskingAlgorithmSetting_Algorithm_FullMask.decode(reader, reader.uint32());
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.rangeMask = MaskingAlgorithmSetting_Algorithm_RangeMask.decode(reader, reader.uint32());
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.hashMask = MaskingAlgorithmSetting_Algorithm_HashMask.decode(reader, reader.uint32());
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.innerOuterMask = MaskingAlgorithmSetting_Algorithm_InnerOuterMask.decode(reader, reader.uint32());
+          continue;
+        case 8:
+          if (tag !== 66) {
+            break;
+          }
+
+          message.generalizationMask = MaskingAlgorithmSetting_Algorithm_GeneralizationMask.decode(
+            reader,
+            reader.uint32(),
+          );
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MaskingAlgorithmSetting_Algorithm {
+    return {
+      id: isSet(object.id) ? String(object.id) : "",
+      title: isSet(object.title) ? String(object.title) : "",
+      description: isSet(object.description) ? String(object.description) : "",
+      fullMask: isSet(object.fullMask)
+        ? MaskingAlgorithmSetting_Algorithm_FullMask.fromJSON(object.fullMask)
+        : undefined,
+      rangeMask: isSet(object.rangeMask)
+        ? MaskingAlgorithmSetting_Algorithm_RangeMask.fromJSON(object.rangeMask)
+        : undefined,
+      hashMask: isSet(object.hashMask)
+        ? MaskingAlgorithmSetting_Algorithm_HashMask.fromJSON(object.hashMask)
+        : undefined,
+      innerOuterMask: isSet(object.innerOuterMask)
+        ? MaskingAlgorithmSetting_Algorithm_InnerOuterMask.fromJSON(object.innerOuterMask)
+        : undefined,
+      generalizationMask: isSet(object.generalizationMask)
+        ? MaskingAlgorithmSetting_Algorithm_GeneralizationMask.fromJSON(object.generalizationMask)
+        : undefined,
+    };
+  },
+
+  toJSON(message: MaskingAlgorithmSetting_Algorithm): unknown {
+    const obj: any = {};
+    message.id !== undefined && (obj.id = message.id);
+    message.title !== undefined && (obj.title = message.title);
+    message.description !== undefined && (obj.description = message.description);
+    message.fullMask !== undefined && (obj.fullMask = message.fullMask
+      ? MaskingAlgorithmSetting_Algorithm_FullMask.toJSON(message.fullMask)
+      : undefined);
+    message.rangeMask !== undefined && (obj.rangeMask = message.rangeMask
+      ? MaskingAlgorithmSetting_Algorithm_RangeMask.toJSON(message.rangeMask)
+      : undefined);
+    message.hashMask !== undefined && (obj.hashMask = message.hashMask
+      ? MaskingAlgorithmSetting_Algorithm_HashMask.toJSON(message.hashMask)
+      : undefined);
+    message.innerOuterMask !== undefined && (obj.innerOuterMask = message.innerOuterMask
+      ? MaskingAlgorithmSetting_Algorithm_InnerOuterMask.toJSON(message.innerOuterMask)
+      : undefined);
+    message.generalizationMask !== undefined && (obj.generalizationMask = message.generalizationMask
+      ? MaskingAlgorithmSetting_Algorithm_GeneralizationMask.toJSON(message.generalizationMask)
+      : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<MaskingAlgorithmSetting_Algorithm>): MaskingAlgorithmSetting_Algorithm {
+    return MaskingAlgorithmSetting_Algorithm.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<MaskingAlgorithmSetting_Algorithm>): MaskingAlgorithmSetting_Algorithm {
+    const message = createBaseMaskingAlgorithmSetting_Algorithm();
+    message.id = object.id ?? "";
+    message.title = object.title ?? "";
+    message.description = object.description ?? "";
+    message.fullMask = (object.fullMask !== undefined && object.fullMask !== null)
+      ? MaskingAlgorithmSetting_Algorithm_FullMask.fromPartial(object.fullMask)
+      : undefined;
+    message.rangeMask = (object.rangeMask !== undefined && object.rangeMask !== null)
+      ? MaskingAlgorithmSetting_Algorithm_RangeMask.fromPartial(object.rangeMask)
+      : undefined;
+    message.hashMask = (object.hashMask !== undefined && object.hashMask !== null)
+      ? MaskingAlgorithmSetting_Algorithm_HashMask.fromPartial(object.hashMask)
+      : undefined;
+    message.innerOuterMask = (object.innerOuterMask !== undefined && object.innerOuterMask !== null)
+      ? MaskingAlgorithmSetting_Algorithm_InnerOuterMask.fromPartial(object.innerOuterMask)
+      : undefined;
+    message.generalizationMask = (object.generalizationMask !== undefined && object.generalizationMask !== null)
+      ? MaskingAlgorithmSetting_Algorithm_GeneralizationMask.fromPartial(object.generalizationMask)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseMaskingAlgorithmSetting_Algorithm_FullMask(): MaskingAlgorithmSetting_Algorithm_FullMask {
+  return { substitution: "" };
+}
+
+export const MaskingAlgorithmSetting_Algorithm_FullMask = {
+  encode(message: MaskingAlgorithmSetting_Algorithm_FullMask, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.substitution !== "") {
+      writer.uint32(10).string(message.substitution);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): MaskingAlgorithmSetting_Algorithm_FullMask {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_FullMask();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.substitution = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MaskingAlgorithmSetting_Algorithm_FullMask {
+    return { substitution: isSet(object.substitution) ? String(object.substitution) : "" };
+  },
+
+  toJSON(message: MaskingAlgorithmSetting_Algorithm_FullMask): unknown {
+    const obj: any = {};
+    message.substitution !== undefined && (obj.substitution = message.substitution);
+    return obj;
+  },
+
+  create(base?: DeepPartial<MaskingAlgorithmSetting_Algorithm_FullMask>): MaskingAlgorithmSetting_Algorithm_FullMask {
+    return MaskingAlgorithmSetting_Algorithm_FullMask.fromPartial(base ?? {});
+  },
+
+  fromPartial(
+    object: DeepPartial<MaskingAlgorithmSetting_Algorithm_FullMask>,
+  ): MaskingAlgorithmSetting_Algorithm_FullMask {
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_FullMask();
+    message.substitution = object.substitution ?? "";
+    return message;
+  },
+};
+
+function createBaseMaskingAlgorithmSetting_Algorithm_RangeMask(): MaskingAlgorithmSetting_Algorithm_RangeMask {
+  return { slices: [] };
+}
+
+export const MaskingAlgorithmSetting_Algorithm_RangeMask = {
+  encode(message: MaskingAlgorithmSetting_Algorithm_RangeMask, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.slices) {
+      MaskingAlgorithmSetting_Algorithm_RangeMask_Slice.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): MaskingAlgorithmSetting_Algorithm_RangeMask {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_RangeMask();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.slices.push(MaskingAlgorithmSetting_Algorithm_RangeMask_Slice.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MaskingAlgorithmSetting_Algorithm_RangeMask {
+    return {
+      slices: Array.isArray(object?.slices)
+        ? object.slices.map((e: any) => MaskingAlgorithmSetting_Algorithm_RangeMask_Slice.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: MaskingAlgorithmSetting_Algorithm_RangeMask): unknown {
+    const obj: any = {};
+    if (message.slices) {
+      obj.slices = message.slices.map((e) =>
+        e ? MaskingAlgorithmSetting_Algorithm_RangeMask_Slice.toJSON(e) : undefined
+      );
+    } else {
+      obj.slices = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<MaskingAlgorithmSetting_Algorithm_RangeMask>): MaskingAlgorithmSetting_Algorithm_RangeMask {
+    return MaskingAlgorithmSetting_Algorithm_RangeMask.fromPartial(base ?? {});
+  },
+
+  fromPartial(
+    object: DeepPartial<MaskingAlgorithmSetting_Algorithm_RangeMask>,
+  ): MaskingAlgorithmSetting_Algorithm_RangeMask {
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_RangeMask();
+    message.slices = object.slices?.map((e) => MaskingAlgorithmSetting_Algorithm_RangeMask_Slice.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseMaskingAlgorithmSetting_Algorithm_RangeMask_Slice(): MaskingAlgorithmSetting_Algorithm_RangeMask_Slice {
+  return { start: 0, end: 0, substitution: "" };
+}
+
+export const MaskingAlgorithmSetting_Algorithm_RangeMask_Slice = {
+  encode(
+    message: MaskingAlgorithmSetting_Algorithm_RangeMask_Slice,
+    writer: _m0.Writer = _m0.Writer.create(),
+  ): _m0.Writer {
+    if (message.start !== 0) {
+      writer.uint32(8).int32(message.start);
+    }
+    if (message.end !== 0) {
+      writer.uint32(16).int32(message.end);
+    }
+    if (message.substitution !== "") {
+      writer.uint32(26).string(message.substitution);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): MaskingAlgorithmSetting_Algorithm_RangeMask_Slice {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_RangeMask_Slice();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.start = reader.int32();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.end = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.substitution = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MaskingAlgorithmSetting_Algorithm_RangeMask_Slice {
+    return {
+      start: isSet(object.start) ? Number(object.start) : 0,
+      end: isSet(object.end) ? Number(object.end) : 0,
+      substitution: isSet(object.substitution) ? String(object.substitution) : "",
+    };
+  },
+
+  toJSON(message: MaskingAlgorithmSetting_Algorithm_RangeMask_Slice): unknown {
+    const obj: any = {};
+    message.start !== undefined && (obj.start = Math.round(message.start));
+    message.end !== undefined && (obj.end = Math.round(message.end));
+    message.substitution !== undefined && (obj.substitution = message.substitution);
+    return obj;
+  },
+
+  create(
+    base?: DeepPartial<MaskingAlgorithmSetting_Algorithm_RangeMask_Slice>,
+  ): MaskingAlgorithmSetting_Algorithm_RangeMask_Slice {
+    return MaskingAlgorithmSetting_Algorithm_RangeMask_Slice.fromPartial(base ?? {});
+  },
+
+  fromPartial(
+    object: DeepPartial<MaskingAlgorithmSetting_Algorithm_RangeMask_Slice>,
+  ): MaskingAlgorithmSetting_Algorithm_RangeMask_Slice {
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_RangeMask_Slice();
+    message.start = object.start ?? 0;
+    message.end = object.end ?? 0;
+    message.substitution = object.substitution ?? "";
+    return message;
+  },
+};
+
+function createBaseMaskingAlgorithmSetting_Algorithm_HashMask(): MaskingAlgorithmSetting_Algorithm_HashMask {
+  return { type: 0, salt: "" };
+}
+
+export const MaskingAlgorithmSetting_Algorithm_HashMask = {
+  encode(message: MaskingAlgorithmSetting_Algorithm_HashMask, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.type !== 0) {
+      writer.uint32(8).int32(message.type);
+    }
+    if (message.salt !== "") {
+      writer.uint32(18).string(message.salt);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): MaskingAlgorithmSetting_Algorithm_HashMask {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_HashMask();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.type = reader.int32() as any;
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.salt = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MaskingAlgorithmSetting_Algorithm_HashMask {
+    return {
+      type: isSet(object.type) ? maskingAlgorithmSetting_Algorithm_HashMask_HashTypeFromJSON(object.type) : 0,
+      salt: isSet(object.salt) ? String(object.salt) : "",
+    };
+  },
+
+  toJSON(message: MaskingAlgorithmSetting_Algorithm_HashMask): unknown {
+    const obj: any = {};
+    message.type !== undefined && (obj.type = maskingAlgorithmSetting_Algorithm_HashMask_HashTypeToJSON(message.type));
+    message.salt !== undefined && (obj.salt = message.salt);
+    return obj;
+  },
+
+  create(base?: DeepPartial<MaskingAlgorithmSetting_Algorithm_HashMask>): MaskingAlgorithmSetting_Algorithm_HashMask {
+    return MaskingAlgorithmSetting_Algorithm_HashMask.fromPartial(base ?? {});
+  },
+
+  fromPartial(
+    object: DeepPartial<MaskingAlgorithmSetting_Algorithm_HashMask>,
+  ): MaskingAlgorithmSetting_Algorithm_HashMask {
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_HashMask();
+    message.type = object.type ?? 0;
+    message.salt = object.salt ?? "";
+    return message;
+  },
+};
+
+function createBaseMaskingAlgorithmSetting_Algorithm_InnerOuterMask(): MaskingAlgorithmSetting_Algorithm_InnerOuterMask {
+  return { prefixLen: 0, suffixLen: 0, type: 0, substitution: "" };
+}
+
+export const MaskingAlgorithmSetting_Algorithm_InnerOuterMask = {
+  encode(
+    message: MaskingAlgorithmSetting_Algorithm_InnerOuterMask,
+    writer: _m0.Writer = _m0.Writer.create(),
+  ): _m0.Writer {
+    if (message.prefixLen !== 0) {
+      writer.uint32(8).int32(message.prefixLen);
+    }
+    if (message.suffixLen !== 0) {
+      writer.uint32(16).int32(message.suffixLen);
+    }
+    if (message.type !== 0) {
+      writer.uint32(24).int32(message.type);
+    }
+    if (message.substitution !== "") {
+      writer.uint32(34).string(message.substitution);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): MaskingAlgorithmSetting_Algorithm_InnerOuterMask {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_InnerOuterMask();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.prefixLen = reader.int32();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.suffixLen = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 24) {
+            break;
+          }
+
+          message.type = reader.int32() as any;
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.substitution = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MaskingAlgorithmSetting_Algorithm_InnerOuterMask {
+    return {
+      prefixLen: isSet(object.prefixLen) ? Number(object.prefixLen) : 0,
+      suffixLen: isSet(object.suffixLen) ? Number(object.suffixLen) : 0,
+      type: isSet(object.type) ? maskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskTypeFromJSON(object.type) : 0,
+      substitution: isSet(object.substitution) ? String(object.substitution) : "",
+    };
+  },
+
+  toJSON(message: MaskingAlgorithmSetting_Algorithm_InnerOuterMask): unknown {
+    const obj: any = {};
+    message.prefixLen !== undefined && (obj.prefixLen = Math.round(message.prefixLen));
+    message.suffixLen !== undefined && (obj.suffixLen = Math.round(message.suffixLen));
+    message.type !== undefined &&
+      (obj.type = maskingAlgorithmSetting_Algorithm_InnerOuterMask_MaskTypeToJSON(message.type));
+    message.substitution !== undefined && (obj.substitution = message.substitution);
+    return obj;
+  },
+
+  create(
+    base?: DeepPartial<MaskingAlgorithmSetting_Algorithm_InnerOuterMask>,
+  ): MaskingAlgorithmSetting_Algorithm_InnerOuterMask {
+    return MaskingAlgorithmSetting_Algorithm_InnerOuterMask.fromPartial(base ?? {});
+  },
+
+  fromPartial(
+    object: DeepPartial<MaskingAlgorithmSetting_Algorithm_InnerOuterMask>,
+  ): MaskingAlgorithmSetting_Algorithm_InnerOuterMask {
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_InnerOuterMask();
+    message.prefixLen = object.prefixLen ?? 0;
+    message.suffixLen = object.suffixLen ?? 0;
+    message.type = object.type ?? 0;
+    message.substitution = object.substitution ?? "";
+    return message;
+  },
+};
+
+function createBaseMaskingAlgorithmSetting_Algorithm_GeneralizationMask(): MaskingAlgorithmSetting_Algorithm_GeneralizationMask {
+  return { bucketSize: 0, timeUnit: 0 };
+}
+
+export const MaskingAlgorithmSetting_Algorithm_GeneralizationMask = {
+  encode(
+    message: MaskingAlgorithmSetting_Algorithm_GeneralizationMask,
+    writer: _m0.Writer = _m0.Writer.create(),
+  ): _m0.Writer {
+    if (message.bucketSize !== 0) {
+      writer.uint32(9).double(message.bucketSize);
+    }
+    if (message.timeUnit !== 0) {
+      writer.uint32(16).int32(message.timeUnit);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): MaskingAlgorithmSetting_Algorithm_GeneralizationMask {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_GeneralizationMask();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 9) {
+            break;
+          }
+
+          message.bucketSize = reader.double();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.timeUnit = reader.int32() as any;
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MaskingAlgorithmSetting_Algorithm_GeneralizationMask {
+    return {
+      bucketSize: isSet(object.bucketSize) ? Number(object.bucketSize) : 0,
+      timeUnit: isSet(object.timeUnit)
+        ? maskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnitFromJSON(object.timeUnit)
+        : 0,
+    };
+  },
+
+  toJSON(message: MaskingAlgorithmSetting_Algorithm_GeneralizationMask): unknown {
+    const obj: any = {};
+    message.bucketSize !== undefined && (obj.bucketSize = message.bucketSize);
+    message.timeUnit !== undefined &&
+      (obj.timeUnit = maskingAlgorithmSetting_Algorithm_GeneralizationMask_TimeUnitToJSON(message.timeUnit));
+    return obj;
+  },
+
+  create(
+    base?: DeepPartial<MaskingAlgorithmSetting_Algorithm_GeneralizationMask>,
+  ): MaskingAlgorithmSetting_Algorithm_GeneralizationMask {
+    return MaskingAlgorithmSetting_Algorithm_GeneralizationMask.fromPartial(base ?? {});
+  },
+
+  fromPartial(
+    object: DeepPartial<MaskingAlgorithmSetting_Algorithm_GeneralizationMask>,
+  ): MaskingAlgorithmSetting_Algorithm_GeneralizationMask {
+    const message = createBaseMaskingAlgorithmSetting_Algorithm_GeneralizationMask();
+    message.bucketSize = object.bucketSize ?? 0;
+    message.timeUnit = object.timeUnit ?? 0;
+    return message;
+  },
+};
+
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
 
 export type DeepPartial<T> = T extends Builtin ? T
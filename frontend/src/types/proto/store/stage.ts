@@ -0,0 +1,179 @@
+/* eslint-disable */
+import _m0 from "protobufjs/minimal";
+import { Duration } from "../google/protobuf/duration";
+
+export const protobufPackage = "bytebase.store";
+
+export interface StagePayload {
+  /**
+   * The canary wave of the stage.
+   * It's set if the stage is one of the waves the matched databases of a deployment are split into.
+   */
+  canaryWave: CanaryWave | undefined;
+}
+
+export interface CanaryWave {
+  /** The zero-based index of the wave in the deployment. */
+  index: number;
+  /** The wave is halted if more than max_failed_tasks tasks fail in the previous wave. */
+  maxFailedTasks: number;
+  /** The time to wait after the tasks of the previous wave finish before the tasks of the wave start. */
+  soakDuration: Duration | undefined;
+}
+
+function createBaseStagePayload(): StagePayload {
+  return { canaryWave: undefined };
+}
+
+export const StagePayload = {
+  encode(message: StagePayload, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.canaryWave !== undefined) {
+      CanaryWave.encode(message.canaryWave, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): StagePayload {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseStagePayload();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.canaryWave = CanaryWave.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): StagePayload {
+    return { canaryWave: isSet(object.canaryWave) ? CanaryWave.fromJSON(object.canaryWave) : undefined };
+  },
+
+  toJSON(message: StagePayload): unknown {
+    const obj: any = {};
+    message.canaryWave !== undefined &&
+      (obj.canaryWave = message.canaryWave ? CanaryWave.toJSON(message.canaryWave) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<StagePayload>): StagePayload {
+    return StagePayload.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<StagePayload>): StagePayload {
+    const message = createBaseStagePayload();
+    message.canaryWave = (object.canaryWave !== undefined && object.canaryWave !== null)
+      ? CanaryWave.fromPartial(object.canaryWave)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseCanaryWave(): CanaryWave {
+  return { index: 0, maxFailedTasks: 0, soakDuration: undefined };
+}
+
+export const CanaryWave = {
+  encode(message: CanaryWave, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.index !== 0) {
+      writer.uint32(8).int32(message.index);
+    }
+    if (message.maxFailedTasks !== 0) {
+      writer.uint32(16).int32(message.maxFailedTasks);
+    }
+    if (message.soakDuration !== undefined) {
+      Duration.encode(message.soakDuration, writer.uint32(26).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CanaryWave {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCanaryWave();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.index = reader.int32();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.maxFailedTasks = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.soakDuration = Duration.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CanaryWave {
+    return {
+      index: isSet(object.index) ? Number(object.index) : 0,
+      maxFailedTasks: isSet(object.maxFailedTasks) ? Number(object.maxFailedTasks) : 0,
+      soakDuration: isSet(object.soakDuration) ? Duration.fromJSON(object.soakDuration) : undefined,
+    };
+  },
+
+  toJSON(message: CanaryWave): unknown {
+    const obj: any = {};
+    message.index !== undefined && (obj.index = Math.round(message.index));
+    message.maxFailedTasks !== undefined && (obj.maxFailedTasks = Math.round(message.maxFailedTasks));
+    message.soakDuration !== undefined &&
+      (obj.soakDuration = message.soakDuration ? Duration.toJSON(message.soakDuration) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<CanaryWave>): CanaryWave {
+    return CanaryWave.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<CanaryWave>): CanaryWave {
+    const message = createBaseCanaryWave();
+    message.index = object.index ?? 0;
+    message.maxFailedTasks = object.maxFailedTasks ?? 0;
+    message.soakDuration = (object.soakDuration !== undefined && object.soakDuration !== null)
+      ? Duration.fromPartial(object.soakDuration)
+      : undefined;
+    return message;
+  },
+};
+
+type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
+
+export type DeepPartial<T> = T extends Builtin ? T
+  : T extends Array<infer U> ? Array<DeepPartial<U>> : T extends ReadonlyArray<infer U> ? ReadonlyArray<DeepPartial<U>>
+  : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
+  : Partial<T>;
+
+function isSet(value: any): boolean {
+  return value !== null && value !== undefined;
+}
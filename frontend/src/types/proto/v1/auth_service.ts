@@ -2,6 +2,7 @@
 import _m0 from "protobufjs/minimal";
 import { Empty } from "../google/protobuf/empty";
 import { FieldMask } from "../google/protobuf/field_mask";
+import { Timestamp } from "../google/protobuf/timestamp";
 import { State, stateFromJSON, stateToJSON } from "./common";
 
 export const protobufPackage = "bytebase.v1";
@@ -182,6 +183,39 @@ export interface UndeleteUserRequest {
   name: string;
 }
 
+export interface CreateAccessTokenRequest {
+  /**
+   * The user owning the access token.
+   * Format: users/{user}
+   */
+  parent: string;
+  /** The access token to create. */
+  accessToken: AccessToken | undefined;
+}
+
+export interface ListAccessTokensRequest {
+  /**
+   * The user owning the access tokens.
+   * Format: users/{user}
+   */
+  parent: string;
+  /** Show revoked access tokens if specified. */
+  showRevoked: boolean;
+}
+
+export interface ListAccessTokensResponse {
+  /** The access tokens of the user. */
+  accessTokens: AccessToken[];
+}
+
+export interface RevokeAccessTokenRequest {
+  /**
+   * The name of the access token to revoke.
+   * Format: users/{user}/accessTokens/{access_token}
+   */
+  name: string;
+}
+
 export interface LoginRequest {
   email: string;
   password: string;
@@ -211,6 +245,7 @@ export interface LoginRequest {
 export interface IdentityProviderContext {
   oauth2Context?: OAuth2IdentityProviderContext | undefined;
   oidcContext?: OIDCIdentityProviderContext | undefined;
+  samlContext?: SAMLIdentityProviderContext | undefined;
 }
 
 export interface OAuth2IdentityProviderContext {
@@ -220,6 +255,11 @@ export interface OAuth2IdentityProviderContext {
 export interface OIDCIdentityProviderContext {
 }
 
+export interface SAMLIdentityProviderContext {
+  /** The base64-encoded SAML response posted to the assertion consumer service. */
+  samlResponse: string;
+}
+
 export interface LoginResponse {
   token: string;
   mfaTempToken?: string | undefined;
@@ -255,6 +295,39 @@ export interface User {
   phone: string;
 }
 
+/** AccessToken is a personal access token restricted to a set of projects and permissions. */
+export interface AccessToken {
+  /**
+   * The name of the access token.
+   * Format: users/{user}/accessTokens/{access_token}. {access_token} is a system-generated unique ID.
+   */
+  name: string;
+  title: string;
+  /**
+   * The projects the access token is restricted to.
+   * Format: projects/{project}
+   * Empty means all projects the user can access.
+   */
+  projects: string[];
+  /**
+   * The permissions the access token is restricted to, in the format of {resource}:{action}.
+   * The resource is derived from the service name and the action from the method name,
+   * for example, "rollout:run" for RolloutService.BatchRunTasks and "sql:query" for SQLService.Query.
+   * The action can be "*" to grant all actions of the resource.
+   * Empty means all permissions of the user.
+   */
+  permissions: string[];
+  expireTime: Date | undefined;
+  createTime:
+    | Date
+    | undefined;
+  /** The last time the access token was used, unset if it has never been used. */
+  lastUsedTime: Date | undefined;
+  revoked: boolean;
+  /** The token is only returned when the access token is created. */
+  token: string;
+}
+
 function createBaseGetUserRequest(): GetUserRequest {
   return { name: "" };
 }
@@ -754,6 +827,271 @@ export const UndeleteUserRequest = {
   },
 };
 
+function createBaseCreateAccessTokenRequest(): CreateAccessTokenRequest {
+  return { parent: "", accessToken: undefined };
+}
+
+export const CreateAccessTokenRequest = {
+  encode(message: CreateAccessTokenRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    if (message.accessToken !== undefined) {
+      AccessToken.encode(message.accessToken, writer.uint32(18).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CreateAccessTokenRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateAccessTokenRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.accessToken = AccessToken.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateAccessTokenRequest {
+    return {
+      parent: isSet(object.parent) ? String(object.parent) : "",
+      accessToken: isSet(object.accessToken) ? AccessToken.fromJSON(object.accessToken) : undefined,
+    };
+  },
+
+  toJSON(message: CreateAccessTokenRequest): unknown {
+    const obj: any = {};
+    message.parent !== undefined && (obj.parent = message.parent);
+    message.accessToken !== undefined &&
+      (obj.accessToken = message.accessToken ? AccessToken.toJSON(message.accessToken) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateAccessTokenRequest>): CreateAccessTokenRequest {
+    return CreateAccessTokenRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<CreateAccessTokenRequest>): CreateAccessTokenRequest {
+    const message = createBaseCreateAccessTokenRequest();
+    message.parent = object.parent ?? "";
+    message.accessToken = (object.accessToken !== undefined && object.accessToken !== null)
+      ? AccessToken.fromPartial(object.accessToken)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseListAccessTokensRequest(): ListAccessTokensRequest {
+  return { parent: "", showRevoked: false };
+}
+
+export const ListAccessTokensRequest = {
+  encode(message: ListAccessTokensRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    if (message.showRevoked === true) {
+      writer.uint32(16).bool(message.showRevoked);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListAccessTokensRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListAccessTokensRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.showRevoked = reader.bool();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListAccessTokensRequest {
+    return {
+      parent: isSet(object.parent) ? String(object.parent) : "",
+      showRevoked: isSet(object.showRevoked) ? Boolean(object.showRevoked) : false,
+    };
+  },
+
+  toJSON(message: ListAccessTokensRequest): unknown {
+    const obj: any = {};
+    message.parent !== undefined && (obj.parent = message.parent);
+    message.showRevoked !== undefined && (obj.showRevoked = message.showRevoked);
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListAccessTokensRequest>): ListAccessTokensRequest {
+    return ListAccessTokensRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListAccessTokensRequest>): ListAccessTokensRequest {
+    const message = createBaseListAccessTokensRequest();
+    message.parent = object.parent ?? "";
+    message.showRevoked = object.showRevoked ?? false;
+    return message;
+  },
+};
+
+function createBaseListAccessTokensResponse(): ListAccessTokensResponse {
+  return { accessTokens: [] };
+}
+
+export const ListAccessTokensResponse = {
+  encode(message: ListAccessTokensResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.accessTokens) {
+      AccessToken.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListAccessTokensResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListAccessTokensResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.accessTokens.push(AccessToken.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListAccessTokensResponse {
+    return {
+      accessTokens: Array.isArray(object?.accessTokens)
+        ? object.accessTokens.map((e: any) => AccessToken.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: ListAccessTokensResponse): unknown {
+    const obj: any = {};
+    if (message.accessTokens) {
+      obj.accessTokens = message.accessTokens.map((e) => e ? AccessToken.toJSON(e) : undefined);
+    } else {
+      obj.accessTokens = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListAccessTokensResponse>): ListAccessTokensResponse {
+    return ListAccessTokensResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListAccessTokensResponse>): ListAccessTokensResponse {
+    const message = createBaseListAccessTokensResponse();
+    message.accessTokens = object.accessTokens?.map((e) => AccessToken.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseRevokeAccessTokenRequest(): RevokeAccessTokenRequest {
+  return { name: "" };
+}
+
+export const RevokeAccessTokenRequest = {
+  encode(message: RevokeAccessTokenRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RevokeAccessTokenRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRevokeAccessTokenRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RevokeAccessTokenRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: RevokeAccessTokenRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<RevokeAccessTokenRequest>): RevokeAccessTokenRequest {
+    return RevokeAccessTokenRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RevokeAccessTokenRequest>): RevokeAccessTokenRequest {
+    const message = createBaseRevokeAccessTokenRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
 function createBaseLoginRequest(): LoginRequest {
   return {
     email: "",
@@ -916,7 +1254,7 @@ export const LoginRequest = {
 };
 
 function createBaseIdentityProviderContext(): IdentityProviderContext {
-  return { oauth2Context: undefined, oidcContext: undefined };
+  return { oauth2Context: undefined, oidcContext: undefined, samlContext: undefined };
 }
 
 export const IdentityProviderContext = {
@@ -927,6 +1265,9 @@ export const IdentityProviderContext = {
     if (message.oidcContext !== undefined) {
       OIDCIdentityProviderContext.encode(message.oidcContext, writer.uint32(18).fork()).ldelim();
     }
+    if (message.samlContext !== undefined) {
+      SAMLIdentityProviderContext.encode(message.samlContext, writer.uint32(26).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -951,6 +1292,13 @@ export const IdentityProviderContext = {
 
           message.oidcContext = OIDCIdentityProviderContext.decode(reader, reader.uint32());
           continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.samlContext = SAMLIdentityProviderContext.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -966,6 +1314,7 @@ export const IdentityProviderContext = {
         ? OAuth2IdentityProviderContext.fromJSON(object.oauth2Context)
         : undefined,
       oidcContext: isSet(object.oidcContext) ? OIDCIdentityProviderContext.fromJSON(object.oidcContext) : undefined,
+      samlContext: isSet(object.samlContext) ? SAMLIdentityProviderContext.fromJSON(object.samlContext) : undefined,
     };
   },
 
@@ -976,6 +1325,8 @@ export const IdentityProviderContext = {
       : undefined);
     message.oidcContext !== undefined &&
       (obj.oidcContext = message.oidcContext ? OIDCIdentityProviderContext.toJSON(message.oidcContext) : undefined);
+    message.samlContext !== undefined &&
+      (obj.samlContext = message.samlContext ? SAMLIdentityProviderContext.toJSON(message.samlContext) : undefined);
     return obj;
   },
 
@@ -991,6 +1342,9 @@ export const IdentityProviderContext = {
     message.oidcContext = (object.oidcContext !== undefined && object.oidcContext !== null)
       ? OIDCIdentityProviderContext.fromPartial(object.oidcContext)
       : undefined;
+    message.samlContext = (object.samlContext !== undefined && object.samlContext !== null)
+      ? SAMLIdentityProviderContext.fromPartial(object.samlContext)
+      : undefined;
     return message;
   },
 };
@@ -1095,25 +1449,22 @@ export const OIDCIdentityProviderContext = {
   },
 };
 
-function createBaseLoginResponse(): LoginResponse {
-  return { token: "", mfaTempToken: undefined };
+function createBaseSAMLIdentityProviderContext(): SAMLIdentityProviderContext {
+  return { samlResponse: "" };
 }
 
-export const LoginResponse = {
-  encode(message: LoginResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
-    if (message.token !== "") {
-      writer.uint32(10).string(message.token);
-    }
-    if (message.mfaTempToken !== undefined) {
-      writer.uint32(18).string(message.mfaTempToken);
+export const SAMLIdentityProviderContext = {
+  encode(message: SAMLIdentityProviderContext, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.samlResponse !== "") {
+      writer.uint32(10).string(message.samlResponse);
     }
     return writer;
   },
 
-  decode(input: _m0.Reader | Uint8Array, length?: number): LoginResponse {
+  decode(input: _m0.Reader | Uint8Array, length?: number): SAMLIdentityProviderContext {
     const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseLoginResponse();
+    const message = createBaseSAMLIdentityProviderContext();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -1122,14 +1473,7 @@ export const LoginResponse = {
             break;
           }
 
-          message.token = reader.string();
-          continue;
-        case 2:
-          if (tag !== 18) {
-            break;
-          }
-
-          message.mfaTempToken = reader.string();
+          message.samlResponse = reader.string();
           continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
@@ -1140,34 +1484,100 @@ export const LoginResponse = {
     return message;
   },
 
-  fromJSON(object: any): LoginResponse {
-    return {
-      token: isSet(object.token) ? String(object.token) : "",
-      mfaTempToken: isSet(object.mfaTempToken) ? String(object.mfaTempToken) : undefined,
-    };
+  fromJSON(object: any): SAMLIdentityProviderContext {
+    return { samlResponse: isSet(object.samlResponse) ? String(object.samlResponse) : "" };
   },
 
-  toJSON(message: LoginResponse): unknown {
+  toJSON(message: SAMLIdentityProviderContext): unknown {
     const obj: any = {};
-    message.token !== undefined && (obj.token = message.token);
-    message.mfaTempToken !== undefined && (obj.mfaTempToken = message.mfaTempToken);
+    message.samlResponse !== undefined && (obj.samlResponse = message.samlResponse);
     return obj;
   },
 
-  create(base?: DeepPartial<LoginResponse>): LoginResponse {
-    return LoginResponse.fromPartial(base ?? {});
+  create(base?: DeepPartial<SAMLIdentityProviderContext>): SAMLIdentityProviderContext {
+    return SAMLIdentityProviderContext.fromPartial(base ?? {});
   },
 
-  fromPartial(object: DeepPartial<LoginResponse>): LoginResponse {
-    const message = createBaseLoginResponse();
-    message.token = object.token ?? "";
-    message.mfaTempToken = object.mfaTempToken ?? undefined;
+  fromPartial(object: DeepPartial<SAMLIdentityProviderContext>): SAMLIdentityProviderContext {
+    const message = createBaseSAMLIdentityProviderContext();
+    message.samlResponse = object.samlResponse ?? "";
     return message;
   },
 };
 
-function createBaseLogoutRequest(): LogoutRequest {
-  return {};
+function createBaseLoginResponse(): LoginResponse {
+  return { token: "", mfaTempToken: undefined };
+}
+
+export const LoginResponse = {
+  encode(message: LoginResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.token !== "") {
+      writer.uint32(10).string(message.token);
+    }
+    if (message.mfaTempToken !== undefined) {
+      writer.uint32(18).string(message.mfaTempToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): LoginResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseLoginResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.token = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.mfaTempToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): LoginResponse {
+    return {
+      token: isSet(object.token) ? String(object.token) : "",
+      mfaTempToken: isSet(object.mfaTempToken) ? String(object.mfaTempToken) : undefined,
+    };
+  },
+
+  toJSON(message: LoginResponse): unknown {
+    const obj: any = {};
+    message.token !== undefined && (obj.token = message.token);
+    message.mfaTempToken !== undefined && (obj.mfaTempToken = message.mfaTempToken);
+    return obj;
+  },
+
+  create(base?: DeepPartial<LoginResponse>): LoginResponse {
+    return LoginResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<LoginResponse>): LoginResponse {
+    const message = createBaseLoginResponse();
+    message.token = object.token ?? "";
+    message.mfaTempToken = object.mfaTempToken ?? undefined;
+    return message;
+  },
+};
+
+function createBaseLogoutRequest(): LogoutRequest {
+  return {};
 }
 
 export const LogoutRequest = {
@@ -1428,6 +1838,186 @@ export const User = {
   },
 };
 
+function createBaseAccessToken(): AccessToken {
+  return {
+    name: "",
+    title: "",
+    projects: [],
+    permissions: [],
+    expireTime: undefined,
+    createTime: undefined,
+    lastUsedTime: undefined,
+    revoked: false,
+    token: "",
+  };
+}
+
+export const AccessToken = {
+  encode(message: AccessToken, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.title !== "") {
+      writer.uint32(18).string(message.title);
+    }
+    for (const v of message.projects) {
+      writer.uint32(26).string(v!);
+    }
+    for (const v of message.permissions) {
+      writer.uint32(34).string(v!);
+    }
+    if (message.expireTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.expireTime), writer.uint32(42).fork()).ldelim();
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(50).fork()).ldelim();
+    }
+    if (message.lastUsedTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.lastUsedTime), writer.uint32(58).fork()).ldelim();
+    }
+    if (message.revoked === true) {
+      writer.uint32(64).bool(message.revoked);
+    }
+    if (message.token !== "") {
+      writer.uint32(74).string(message.token);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): AccessToken {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAccessToken();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.projects.push(reader.string());
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.permissions.push(reader.string());
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.expireTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.lastUsedTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 8:
+          if (tag !== 64) {
+            break;
+          }
+
+          message.revoked = reader.bool();
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.token = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): AccessToken {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      title: isSet(object.title) ? String(object.title) : "",
+      projects: Array.isArray(object?.projects) ? object.projects.map((e: any) => String(e)) : [],
+      permissions: Array.isArray(object?.permissions) ? object.permissions.map((e: any) => String(e)) : [],
+      expireTime: isSet(object.expireTime) ? fromJsonTimestamp(object.expireTime) : undefined,
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      lastUsedTime: isSet(object.lastUsedTime) ? fromJsonTimestamp(object.lastUsedTime) : undefined,
+      revoked: isSet(object.revoked) ? Boolean(object.revoked) : false,
+      token: isSet(object.token) ? String(object.token) : "",
+    };
+  },
+
+  toJSON(message: AccessToken): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.title !== undefined && (obj.title = message.title);
+    if (message.projects) {
+      obj.projects = message.projects.map((e) => e);
+    } else {
+      obj.projects = [];
+    }
+    if (message.permissions) {
+      obj.permissions = message.permissions.map((e) => e);
+    } else {
+      obj.permissions = [];
+    }
+    message.expireTime !== undefined && (obj.expireTime = message.expireTime.toISOString());
+    message.createTime !== undefined && (obj.createTime = message.createTime.toISOString());
+    message.lastUsedTime !== undefined && (obj.lastUsedTime = message.lastUsedTime.toISOString());
+    message.revoked !== undefined && (obj.revoked = message.revoked);
+    message.token !== undefined && (obj.token = message.token);
+    return obj;
+  },
+
+  create(base?: DeepPartial<AccessToken>): AccessToken {
+    return AccessToken.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<AccessToken>): AccessToken {
+    const message = createBaseAccessToken();
+    message.name = object.name ?? "";
+    message.title = object.title ?? "";
+    message.projects = object.projects?.map((e) => e) || [];
+    message.permissions = object.permissions?.map((e) => e) || [];
+    message.expireTime = object.expireTime ?? undefined;
+    message.createTime = object.createTime ?? undefined;
+    message.lastUsedTime = object.lastUsedTime ?? undefined;
+    message.revoked = object.revoked ?? false;
+    message.token = object.token ?? "";
+    return message;
+  },
+};
+
 export type AuthServiceDefinition = typeof AuthServiceDefinition;
 export const AuthServiceDefinition = {
   name: "AuthService",
@@ -1629,6 +2219,209 @@ export const AuthServiceDefinition = {
         },
       },
     },
+    createAccessToken: {
+      name: "CreateAccessToken",
+      requestType: CreateAccessTokenRequest,
+      requestStream: false,
+      responseType: AccessToken,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [
+            new Uint8Array([
+              19,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              44,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              95,
+              116,
+              111,
+              107,
+              101,
+              110,
+            ]),
+          ],
+          578365826: [
+            new Uint8Array([
+              49,
+              58,
+              12,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              95,
+              116,
+              111,
+              107,
+              101,
+              110,
+              34,
+              33,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              117,
+              115,
+              101,
+              114,
+              115,
+              47,
+              42,
+              125,
+              47,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              84,
+              111,
+              107,
+              101,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    listAccessTokens: {
+      name: "ListAccessTokens",
+      requestType: ListAccessTokensRequest,
+      requestStream: false,
+      responseType: ListAccessTokensResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([6, 112, 97, 114, 101, 110, 116])],
+          578365826: [
+            new Uint8Array([
+              35,
+              18,
+              33,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              117,
+              115,
+              101,
+              114,
+              115,
+              47,
+              42,
+              125,
+              47,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              84,
+              111,
+              107,
+              101,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    revokeAccessToken: {
+      name: "RevokeAccessToken",
+      requestType: RevokeAccessTokenRequest,
+      requestStream: false,
+      responseType: AccessToken,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              45,
+              58,
+              1,
+              42,
+              34,
+              40,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              117,
+              115,
+              101,
+              114,
+              115,
+              47,
+              42,
+              47,
+              97,
+              99,
+              99,
+              101,
+              115,
+              115,
+              84,
+              111,
+              107,
+              101,
+              110,
+              115,
+              47,
+              42,
+              125,
+              58,
+              114,
+              101,
+              118,
+              111,
+              107,
+              101,
+            ]),
+          ],
+        },
+      },
+    },
     login: {
       name: "Login",
       requestType: LoginRequest,
@@ -1689,6 +2482,28 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = date.getTime() / 1_000;
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = (t.seconds || 0) * 1_000;
+  millis += (t.nanos || 0) / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
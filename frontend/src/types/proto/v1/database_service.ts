@@ -1111,7 +1111,14 @@ export interface ChangeHistory {
   /** Format: projects/{project}/issues/{issue} */
   issue: string;
   pushEvent: PushEvent | undefined;
-  changedResources: ChangedResources | undefined;
+  changedResources:
+    | ChangedResources
+    | undefined;
+  /**
+   * The sheet revision executed by the change.
+   * Format: projects/{project}/sheets/{sheet}/revisions/{revision}
+   */
+  sheetRevision: string;
 }
 
 export enum ChangeHistory_Source {
@@ -6447,6 +6454,7 @@ function createBaseChangeHistory(): ChangeHistory {
     issue: "",
     pushEvent: undefined,
     changedResources: undefined,
+    sheetRevision: "",
   };
 }
 
@@ -6509,6 +6517,9 @@ export const ChangeHistory = {
     if (message.changedResources !== undefined) {
       ChangedResources.encode(message.changedResources, writer.uint32(154).fork()).ldelim();
     }
+    if (message.sheetRevision !== "") {
+      writer.uint32(162).string(message.sheetRevision);
+    }
     return writer;
   },
 
@@ -6652,6 +6663,13 @@ export const ChangeHistory = {
 
           message.changedResources = ChangedResources.decode(reader, reader.uint32());
           continue;
+        case 20:
+          if (tag !== 162) {
+            break;
+          }
+
+          message.sheetRevision = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -6682,6 +6700,7 @@ export const ChangeHistory = {
       issue: isSet(object.issue) ? String(object.issue) : "",
       pushEvent: isSet(object.pushEvent) ? PushEvent.fromJSON(object.pushEvent) : undefined,
       changedResources: isSet(object.changedResources) ? ChangedResources.fromJSON(object.changedResources) : undefined,
+      sheetRevision: isSet(object.sheetRevision) ? String(object.sheetRevision) : "",
     };
   },
 
@@ -6709,6 +6728,7 @@ export const ChangeHistory = {
       (obj.pushEvent = message.pushEvent ? PushEvent.toJSON(message.pushEvent) : undefined);
     message.changedResources !== undefined &&
       (obj.changedResources = message.changedResources ? ChangedResources.toJSON(message.changedResources) : undefined);
+    message.sheetRevision !== undefined && (obj.sheetRevision = message.sheetRevision);
     return obj;
   },
 
@@ -6743,6 +6763,7 @@ export const ChangeHistory = {
     message.changedResources = (object.changedResources !== undefined && object.changedResources !== null)
       ? ChangedResources.fromPartial(object.changedResources)
       : undefined;
+    message.sheetRevision = object.sheetRevision ?? "";
     return message;
   },
 };
@@ -11,6 +11,7 @@ export enum IdentityProviderType {
   OAUTH2 = 1,
   OIDC = 2,
   LDAP = 3,
+  SAML = 4,
   UNRECOGNIZED = -1,
 }
 
@@ -28,6 +29,9 @@ export function identityProviderTypeFromJSON(object: any): IdentityProviderType
     case 3:
     case "LDAP":
       return IdentityProviderType.LDAP;
+    case 4:
+    case "SAML":
+      return IdentityProviderType.SAML;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -45,6 +49,8 @@ export function identityProviderTypeToJSON(object: IdentityProviderType): string
       return "OIDC";
     case IdentityProviderType.LDAP:
       return "LDAP";
+    case IdentityProviderType.SAML:
+      return "SAML";
     case IdentityProviderType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -181,6 +187,7 @@ export interface TestIdentityProviderRequest {
   /** The identity provider to test connection including uncreated. */
   identityProvider: IdentityProvider | undefined;
   oauth2Context?: OAuth2IdentityProviderTestRequestContext | undefined;
+  samlContext?: SAMLIdentityProviderTestRequestContext | undefined;
 }
 
 export interface OAuth2IdentityProviderTestRequestContext {
@@ -188,6 +195,11 @@ export interface OAuth2IdentityProviderTestRequestContext {
   code: string;
 }
 
+export interface SAMLIdentityProviderTestRequestContext {
+  /** The base64-encoded SAML response posted to the assertion consumer service. */
+  samlResponse: string;
+}
+
 export interface TestIdentityProviderResponse {
 }
 
@@ -210,6 +222,7 @@ export interface IdentityProviderConfig {
   oauth2Config?: OAuth2IdentityProviderConfig | undefined;
   oidcConfig?: OIDCIdentityProviderConfig | undefined;
   ldapConfig?: LDAPIdentityProviderConfig | undefined;
+  samlConfig?: SAMLIdentityProviderConfig | undefined;
 }
 
 /** OAuth2IdentityProviderConfig is the structure for OAuth2 identity provider config. */
@@ -275,6 +288,46 @@ export interface LDAPIdentityProviderConfig {
   fieldMapping: FieldMapping | undefined;
 }
 
+/** SAMLIdentityProviderConfig is the structure for SAML 2.0 identity provider config. */
+export interface SAMLIdentityProviderConfig {
+  /**
+   * EntityID is the entity ID of the identity provider, which is expected to
+   * be the issuer of the SAML responses.
+   */
+  entityId: string;
+  /**
+   * SSOURL is the single sign-on URL of the identity provider that receives
+   * the authentication requests with the HTTP-Redirect binding.
+   */
+  ssoUrl: string;
+  /**
+   * Certificate is the PEM-encoded X.509 certificate that the identity provider
+   * signs the SAML responses with.
+   */
+  certificate: string;
+  /**
+   * SPCertificate is the PEM-encoded X.509 certificate of Bytebase as the
+   * service provider, which is published in the service provider metadata.
+   */
+  spCertificate: string;
+  /**
+   * SPPrivateKey is the PEM-encoded private key of the service provider
+   * certificate, which is used to sign the authentication requests.
+   */
+  spPrivateKey: string;
+  /**
+   * NameIDFormat is the name identifier format requested from the identity
+   * provider, e.g. "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress".
+   * When not set, the unspecified format will be used.
+   */
+  nameIdFormat: string;
+  /**
+   * FieldMapping is the mapping of the assertion attributes returned by the
+   * identity provider. The subject name identifier is available as "NameID".
+   */
+  fieldMapping: FieldMapping | undefined;
+}
+
 /**
  * FieldMapping saves the field names from user info API of identity provider.
  * As we save all raw json string of user info response data into `principal.idp_user_info`,
@@ -294,6 +347,12 @@ export interface FieldMapping {
   email: string;
   /** Phone is the field name of primary phone in 3rd-party idp user info. */
   phone: string;
+  /**
+   * Groups is the field name of the group list in 3rd-party idp user info, e.g. the groups claim of OIDC or the memberOf attribute of LDAP. Optional.
+   * On login, the user is added to the user groups linked to the identity provider whose identity provider group is in the list,
+   * and removed from the other groups that the identity provider has added the user to.
+   */
+  groups: string;
 }
 
 function createBaseGetIdentityProviderRequest(): GetIdentityProviderRequest {
@@ -774,7 +833,7 @@ export const UndeleteIdentityProviderRequest = {
 };
 
 function createBaseTestIdentityProviderRequest(): TestIdentityProviderRequest {
-  return { identityProvider: undefined, oauth2Context: undefined };
+  return { identityProvider: undefined, oauth2Context: undefined, samlContext: undefined };
 }
 
 export const TestIdentityProviderRequest = {
@@ -785,6 +844,9 @@ export const TestIdentityProviderRequest = {
     if (message.oauth2Context !== undefined) {
       OAuth2IdentityProviderTestRequestContext.encode(message.oauth2Context, writer.uint32(18).fork()).ldelim();
     }
+    if (message.samlContext !== undefined) {
+      SAMLIdentityProviderTestRequestContext.encode(message.samlContext, writer.uint32(26).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -809,6 +871,13 @@ export const TestIdentityProviderRequest = {
 
           message.oauth2Context = OAuth2IdentityProviderTestRequestContext.decode(reader, reader.uint32());
           continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.samlContext = SAMLIdentityProviderTestRequestContext.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -824,6 +893,9 @@ export const TestIdentityProviderRequest = {
       oauth2Context: isSet(object.oauth2Context)
         ? OAuth2IdentityProviderTestRequestContext.fromJSON(object.oauth2Context)
         : undefined,
+      samlContext: isSet(object.samlContext)
+        ? SAMLIdentityProviderTestRequestContext.fromJSON(object.samlContext)
+        : undefined,
     };
   },
 
@@ -834,6 +906,9 @@ export const TestIdentityProviderRequest = {
     message.oauth2Context !== undefined && (obj.oauth2Context = message.oauth2Context
       ? OAuth2IdentityProviderTestRequestContext.toJSON(message.oauth2Context)
       : undefined);
+    message.samlContext !== undefined && (obj.samlContext = message.samlContext
+      ? SAMLIdentityProviderTestRequestContext.toJSON(message.samlContext)
+      : undefined);
     return obj;
   },
 
@@ -849,6 +924,9 @@ export const TestIdentityProviderRequest = {
     message.oauth2Context = (object.oauth2Context !== undefined && object.oauth2Context !== null)
       ? OAuth2IdentityProviderTestRequestContext.fromPartial(object.oauth2Context)
       : undefined;
+    message.samlContext = (object.samlContext !== undefined && object.samlContext !== null)
+      ? SAMLIdentityProviderTestRequestContext.fromPartial(object.samlContext)
+      : undefined;
     return message;
   },
 };
@@ -909,6 +987,62 @@ export const OAuth2IdentityProviderTestRequestContext = {
   },
 };
 
+function createBaseSAMLIdentityProviderTestRequestContext(): SAMLIdentityProviderTestRequestContext {
+  return { samlResponse: "" };
+}
+
+export const SAMLIdentityProviderTestRequestContext = {
+  encode(message: SAMLIdentityProviderTestRequestContext, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.samlResponse !== "") {
+      writer.uint32(10).string(message.samlResponse);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SAMLIdentityProviderTestRequestContext {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSAMLIdentityProviderTestRequestContext();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.samlResponse = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SAMLIdentityProviderTestRequestContext {
+    return { samlResponse: isSet(object.samlResponse) ? String(object.samlResponse) : "" };
+  },
+
+  toJSON(message: SAMLIdentityProviderTestRequestContext): unknown {
+    const obj: any = {};
+    message.samlResponse !== undefined && (obj.samlResponse = message.samlResponse);
+    return obj;
+  },
+
+  create(base?: DeepPartial<SAMLIdentityProviderTestRequestContext>): SAMLIdentityProviderTestRequestContext {
+    return SAMLIdentityProviderTestRequestContext.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<SAMLIdentityProviderTestRequestContext>): SAMLIdentityProviderTestRequestContext {
+    const message = createBaseSAMLIdentityProviderTestRequestContext();
+    message.samlResponse = object.samlResponse ?? "";
+    return message;
+  },
+};
+
 function createBaseTestIdentityProviderResponse(): TestIdentityProviderResponse {
   return {};
 }
@@ -1093,7 +1227,7 @@ export const IdentityProvider = {
 };
 
 function createBaseIdentityProviderConfig(): IdentityProviderConfig {
-  return { oauth2Config: undefined, oidcConfig: undefined, ldapConfig: undefined };
+  return { oauth2Config: undefined, oidcConfig: undefined, ldapConfig: undefined, samlConfig: undefined };
 }
 
 export const IdentityProviderConfig = {
@@ -1107,6 +1241,9 @@ export const IdentityProviderConfig = {
     if (message.ldapConfig !== undefined) {
       LDAPIdentityProviderConfig.encode(message.ldapConfig, writer.uint32(26).fork()).ldelim();
     }
+    if (message.samlConfig !== undefined) {
+      SAMLIdentityProviderConfig.encode(message.samlConfig, writer.uint32(34).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -1138,6 +1275,13 @@ export const IdentityProviderConfig = {
 
           message.ldapConfig = LDAPIdentityProviderConfig.decode(reader, reader.uint32());
           continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.samlConfig = SAMLIdentityProviderConfig.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1152,6 +1296,7 @@ export const IdentityProviderConfig = {
       oauth2Config: isSet(object.oauth2Config) ? OAuth2IdentityProviderConfig.fromJSON(object.oauth2Config) : undefined,
       oidcConfig: isSet(object.oidcConfig) ? OIDCIdentityProviderConfig.fromJSON(object.oidcConfig) : undefined,
       ldapConfig: isSet(object.ldapConfig) ? LDAPIdentityProviderConfig.fromJSON(object.ldapConfig) : undefined,
+      samlConfig: isSet(object.samlConfig) ? SAMLIdentityProviderConfig.fromJSON(object.samlConfig) : undefined,
     };
   },
 
@@ -1163,6 +1308,8 @@ export const IdentityProviderConfig = {
       (obj.oidcConfig = message.oidcConfig ? OIDCIdentityProviderConfig.toJSON(message.oidcConfig) : undefined);
     message.ldapConfig !== undefined &&
       (obj.ldapConfig = message.ldapConfig ? LDAPIdentityProviderConfig.toJSON(message.ldapConfig) : undefined);
+    message.samlConfig !== undefined &&
+      (obj.samlConfig = message.samlConfig ? SAMLIdentityProviderConfig.toJSON(message.samlConfig) : undefined);
     return obj;
   },
 
@@ -1181,6 +1328,9 @@ export const IdentityProviderConfig = {
     message.ldapConfig = (object.ldapConfig !== undefined && object.ldapConfig !== null)
       ? LDAPIdentityProviderConfig.fromPartial(object.ldapConfig)
       : undefined;
+    message.samlConfig = (object.samlConfig !== undefined && object.samlConfig !== null)
+      ? SAMLIdentityProviderConfig.fromPartial(object.samlConfig)
+      : undefined;
     return message;
   },
 };
@@ -1690,8 +1840,155 @@ export const LDAPIdentityProviderConfig = {
   },
 };
 
+function createBaseSAMLIdentityProviderConfig(): SAMLIdentityProviderConfig {
+  return {
+    entityId: "",
+    ssoUrl: "",
+    certificate: "",
+    spCertificate: "",
+    spPrivateKey: "",
+    nameIdFormat: "",
+    fieldMapping: undefined,
+  };
+}
+
+export const SAMLIdentityProviderConfig = {
+  encode(message: SAMLIdentityProviderConfig, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.entityId !== "") {
+      writer.uint32(10).string(message.entityId);
+    }
+    if (message.ssoUrl !== "") {
+      writer.uint32(18).string(message.ssoUrl);
+    }
+    if (message.certificate !== "") {
+      writer.uint32(26).string(message.certificate);
+    }
+    if (message.spCertificate !== "") {
+      writer.uint32(34).string(message.spCertificate);
+    }
+    if (message.spPrivateKey !== "") {
+      writer.uint32(42).string(message.spPrivateKey);
+    }
+    if (message.nameIdFormat !== "") {
+      writer.uint32(50).string(message.nameIdFormat);
+    }
+    if (message.fieldMapping !== undefined) {
+      FieldMapping.encode(message.fieldMapping, writer.uint32(58).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SAMLIdentityProviderConfig {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSAMLIdentityProviderConfig();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.entityId = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.ssoUrl = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.certificate = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.spCertificate = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.spPrivateKey = reader.string();
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.nameIdFormat = reader.string();
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.fieldMapping = FieldMapping.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SAMLIdentityProviderConfig {
+    return {
+      entityId: isSet(object.entityId) ? String(object.entityId) : "",
+      ssoUrl: isSet(object.ssoUrl) ? String(object.ssoUrl) : "",
+      certificate: isSet(object.certificate) ? String(object.certificate) : "",
+      spCertificate: isSet(object.spCertificate) ? String(object.spCertificate) : "",
+      spPrivateKey: isSet(object.spPrivateKey) ? String(object.spPrivateKey) : "",
+      nameIdFormat: isSet(object.nameIdFormat) ? String(object.nameIdFormat) : "",
+      fieldMapping: isSet(object.fieldMapping) ? FieldMapping.fromJSON(object.fieldMapping) : undefined,
+    };
+  },
+
+  toJSON(message: SAMLIdentityProviderConfig): unknown {
+    const obj: any = {};
+    message.entityId !== undefined && (obj.entityId = message.entityId);
+    message.ssoUrl !== undefined && (obj.ssoUrl = message.ssoUrl);
+    message.certificate !== undefined && (obj.certificate = message.certificate);
+    message.spCertificate !== undefined && (obj.spCertificate = message.spCertificate);
+    message.spPrivateKey !== undefined && (obj.spPrivateKey = message.spPrivateKey);
+    message.nameIdFormat !== undefined && (obj.nameIdFormat = message.nameIdFormat);
+    message.fieldMapping !== undefined &&
+      (obj.fieldMapping = message.fieldMapping ? FieldMapping.toJSON(message.fieldMapping) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<SAMLIdentityProviderConfig>): SAMLIdentityProviderConfig {
+    return SAMLIdentityProviderConfig.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<SAMLIdentityProviderConfig>): SAMLIdentityProviderConfig {
+    const message = createBaseSAMLIdentityProviderConfig();
+    message.entityId = object.entityId ?? "";
+    message.ssoUrl = object.ssoUrl ?? "";
+    message.certificate = object.certificate ?? "";
+    message.spCertificate = object.spCertificate ?? "";
+    message.spPrivateKey = object.spPrivateKey ?? "";
+    message.nameIdFormat = object.nameIdFormat ?? "";
+    message.fieldMapping = (object.fieldMapping !== undefined && object.fieldMapping !== null)
+      ? FieldMapping.fromPartial(object.fieldMapping)
+      : undefined;
+    return message;
+  },
+};
+
 function createBaseFieldMapping(): FieldMapping {
-  return { identifier: "", displayName: "", email: "", phone: "" };
+  return { identifier: "", displayName: "", email: "", phone: "", groups: "" };
 }
 
 export const FieldMapping = {
@@ -1708,6 +2005,9 @@ export const FieldMapping = {
     if (message.phone !== "") {
       writer.uint32(34).string(message.phone);
     }
+    if (message.groups !== "") {
+      writer.uint32(42).string(message.groups);
+    }
     return writer;
   },
 
@@ -1746,6 +2046,13 @@ export const FieldMapping = {
 
           message.phone = reader.string();
           continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.groups = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1761,6 +2068,7 @@ export const FieldMapping = {
       displayName: isSet(object.displayName) ? String(object.displayName) : "",
       email: isSet(object.email) ? String(object.email) : "",
       phone: isSet(object.phone) ? String(object.phone) : "",
+      groups: isSet(object.groups) ? String(object.groups) : "",
     };
   },
 
@@ -1770,6 +2078,7 @@ export const FieldMapping = {
     message.displayName !== undefined && (obj.displayName = message.displayName);
     message.email !== undefined && (obj.email = message.email);
     message.phone !== undefined && (obj.phone = message.phone);
+    message.groups !== undefined && (obj.groups = message.groups);
     return obj;
   },
 
@@ -1783,6 +2092,7 @@ export const FieldMapping = {
     message.displayName = object.displayName ?? "";
     message.email = object.email ?? "";
     message.phone = object.phone ?? "";
+    message.groups = object.groups ?? "";
     return message;
   },
 };
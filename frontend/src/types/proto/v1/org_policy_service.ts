@@ -3,7 +3,9 @@ import _m0 from "protobufjs/minimal";
 import { Duration } from "../google/protobuf/duration";
 import { Empty } from "../google/protobuf/empty";
 import { FieldMask } from "../google/protobuf/field_mask";
+import { Timestamp } from "../google/protobuf/timestamp";
 import { Expr } from "../google/type/expr";
+import { UserRole, userRoleFromJSON, userRoleToJSON } from "./auth_service";
 import { Engine, engineFromJSON, engineToJSON, MaskingLevel, maskingLevelFromJSON, maskingLevelToJSON } from "./common";
 import { DeploymentType, deploymentTypeFromJSON, deploymentTypeToJSON } from "./deployment";
 import { IamPolicy } from "./iam_policy";
@@ -21,6 +23,8 @@ export enum PolicyType {
   DISABLE_COPY_DATA = 8,
   MASKING_RULE = 9,
   MASKING_EXCEPTION = 10,
+  ROLLOUT_WINDOW = 11,
+  REDIS_KEY_ACCESS = 12,
   UNRECOGNIZED = -1,
 }
 
@@ -56,6 +60,12 @@ export function policyTypeFromJSON(object: any): PolicyType {
     case 10:
     case "MASKING_EXCEPTION":
       return PolicyType.MASKING_EXCEPTION;
+    case 11:
+    case "ROLLOUT_WINDOW":
+      return PolicyType.ROLLOUT_WINDOW;
+    case 12:
+    case "REDIS_KEY_ACCESS":
+      return PolicyType.REDIS_KEY_ACCESS;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -85,6 +95,10 @@ export function policyTypeToJSON(object: PolicyType): string {
       return "MASKING_RULE";
     case PolicyType.MASKING_EXCEPTION:
       return "MASKING_EXCEPTION";
+    case PolicyType.ROLLOUT_WINDOW:
+      return "ROLLOUT_WINDOW";
+    case PolicyType.REDIS_KEY_ACCESS:
+      return "REDIS_KEY_ACCESS";
     case PolicyType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -436,6 +450,8 @@ export interface Policy {
   disableCopyDataPolicy?: DisableCopyDataPolicy | undefined;
   maskingRulePolicy?: MaskingRulePolicy | undefined;
   maskingExceptionPolicy?: MaskingExceptionPolicy | undefined;
+  rolloutWindowPolicy?: RolloutWindowPolicy | undefined;
+  redisKeyAccessPolicy?: RedisKeyAccessPolicy | undefined;
   enforce: boolean;
   /** The resource type for the policy. */
   resourceType: PolicyResourceType;
@@ -456,7 +472,90 @@ export interface DeploymentApprovalStrategy {
 
 export interface BackupPlanPolicy {
   schedule: BackupPlanSchedule;
-  retentionDuration: Duration | undefined;
+  retentionDuration:
+    | Duration
+    | undefined;
+  /**
+   * The storage the backups are written to.
+   * The backups are written to the default storage of the server if not set.
+   */
+  storage: BackupStorage | undefined;
+}
+
+export interface BackupStorage {
+  type: BackupStorage_Type;
+  /** The bucket of S3 and GCS, or the container of Azure Blob Storage. */
+  bucket: string;
+  /** The path prefix of the backup files in the bucket. */
+  prefix: string;
+  /** The region of S3. */
+  region: string;
+  /** The custom endpoint URL of an S3-compatible service, or the service URL of Azure Blob Storage. */
+  endpoint: string;
+  /**
+   * The name of the credential file in the backup-credential directory of the Bytebase data directory.
+   * It is the shared credentials file for S3, the service account key file for GCS,
+   * and the file containing the connection string for Azure Blob Storage.
+   * The default credentials of the environment are used for S3 and GCS if empty.
+   */
+  credentialFile: string;
+  /**
+   * The name of the file in the backup-credential directory of the Bytebase data directory containing the base64 encoded 256-bit key
+   * to encrypt the backup files on the client side. The backup files are not encrypted if empty.
+   */
+  encryptionKeyFile: string;
+}
+
+export enum BackupStorage_Type {
+  TYPE_UNSPECIFIED = 0,
+  LOCAL = 1,
+  /** S3 - AWS S3 or an S3-compatible service such as MinIO. */
+  S3 = 2,
+  GCS = 3,
+  AZURE_BLOB = 4,
+  UNRECOGNIZED = -1,
+}
+
+export function backupStorage_TypeFromJSON(object: any): BackupStorage_Type {
+  switch (object) {
+    case 0:
+    case "TYPE_UNSPECIFIED":
+      return BackupStorage_Type.TYPE_UNSPECIFIED;
+    case 1:
+    case "LOCAL":
+      return BackupStorage_Type.LOCAL;
+    case 2:
+    case "S3":
+      return BackupStorage_Type.S3;
+    case 3:
+    case "GCS":
+      return BackupStorage_Type.GCS;
+    case 4:
+    case "AZURE_BLOB":
+      return BackupStorage_Type.AZURE_BLOB;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return BackupStorage_Type.UNRECOGNIZED;
+  }
+}
+
+export function backupStorage_TypeToJSON(object: BackupStorage_Type): string {
+  switch (object) {
+    case BackupStorage_Type.TYPE_UNSPECIFIED:
+      return "TYPE_UNSPECIFIED";
+    case BackupStorage_Type.LOCAL:
+      return "LOCAL";
+    case BackupStorage_Type.S3:
+      return "S3";
+    case BackupStorage_Type.GCS:
+      return "GCS";
+    case BackupStorage_Type.AZURE_BLOB:
+      return "AZURE_BLOB";
+    case BackupStorage_Type.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
 }
 
 export interface SlowQueryPolicy {
@@ -467,6 +566,54 @@ export interface DisableCopyDataPolicy {
   active: boolean;
 }
 
+/**
+ * RolloutWindowPolicy is the policy restricting when the tasks of an environment are allowed to roll out.
+ * The pending task runs are held outside the allowed windows and during the freeze periods.
+ */
+export interface RolloutWindowPolicy {
+  /**
+   * The recurring windows in which the tasks are allowed to roll out.
+   * The tasks are allowed to roll out at any time if empty.
+   */
+  allowedWindows: RolloutWindowPolicy_Window[];
+  /** The periods in which the tasks are not allowed to roll out, such as a holiday code freeze. */
+  freezePeriods: RolloutWindowPolicy_FreezePeriod[];
+  /** The workspace roles of the task run creators who can roll out at any time. */
+  bypassRoles: UserRole[];
+}
+
+export interface RolloutWindowPolicy_Window {
+  /**
+   * The cron expression of the window start in the format of "minute hour day-of-month month day-of-week".
+   * For example, "0 2 * * 2-4" starts the window at 02:00 from Tuesday to Thursday.
+   */
+  cron: string;
+  /** The duration of the window. */
+  duration:
+    | Duration
+    | undefined;
+  /**
+   * The IANA time zone of the cron expression, such as "America/New_York".
+   * UTC is used if empty.
+   */
+  timeZone: string;
+}
+
+export interface RolloutWindowPolicy_FreezePeriod {
+  title: string;
+  startTime: Date | undefined;
+  endTime: Date | undefined;
+}
+
+/** RedisKeyAccessPolicy is the policy restricting the keys accessed by the Redis commands in the SQL Editor of a project. */
+export interface RedisKeyAccessPolicy {
+  /**
+   * The glob-style key patterns in the same syntax as the KEYS command, such as "user:*".
+   * The commands can only access the keys matching one of the patterns, and the keys are not restricted if empty.
+   */
+  keyPatterns: string[];
+}
+
 export interface MaskingPolicy {
   maskData: MaskData[];
 }
@@ -505,6 +652,7 @@ export interface MaskingExceptionPolicy_MaskingException {
    * Member is the principal who bind to this exception policy instance.
    *
    * * `user:{emailid}`: An email address that represents a specific Bytebase account. For example, `alice@example.com`.
+   * * `group:{emailid}`: An email address that represents a user group. For example, `dba@example.com`.
    */
   member: string;
   /** The condition that is associated with this exception policy instance. */
@@ -1050,6 +1198,8 @@ function createBasePolicy(): Policy {
     disableCopyDataPolicy: undefined,
     maskingRulePolicy: undefined,
     maskingExceptionPolicy: undefined,
+    rolloutWindowPolicy: undefined,
+    redisKeyAccessPolicy: undefined,
     enforce: false,
     resourceType: 0,
     resourceUid: "",
@@ -1097,6 +1247,12 @@ export const Policy = {
     if (message.maskingExceptionPolicy !== undefined) {
       MaskingExceptionPolicy.encode(message.maskingExceptionPolicy, writer.uint32(146).fork()).ldelim();
     }
+    if (message.rolloutWindowPolicy !== undefined) {
+      RolloutWindowPolicy.encode(message.rolloutWindowPolicy, writer.uint32(154).fork()).ldelim();
+    }
+    if (message.redisKeyAccessPolicy !== undefined) {
+      RedisKeyAccessPolicy.encode(message.redisKeyAccessPolicy, writer.uint32(162).fork()).ldelim();
+    }
     if (message.enforce === true) {
       writer.uint32(104).bool(message.enforce);
     }
@@ -1207,6 +1363,20 @@ export const Policy = {
 
           message.maskingExceptionPolicy = MaskingExceptionPolicy.decode(reader, reader.uint32());
           continue;
+        case 19:
+          if (tag !== 154) {
+            break;
+          }
+
+          message.rolloutWindowPolicy = RolloutWindowPolicy.decode(reader, reader.uint32());
+          continue;
+        case 20:
+          if (tag !== 162) {
+            break;
+          }
+
+          message.redisKeyAccessPolicy = RedisKeyAccessPolicy.decode(reader, reader.uint32());
+          continue;
         case 13:
           if (tag !== 104) {
             break;
@@ -1260,6 +1430,12 @@ export const Policy = {
       maskingExceptionPolicy: isSet(object.maskingExceptionPolicy)
         ? MaskingExceptionPolicy.fromJSON(object.maskingExceptionPolicy)
         : undefined,
+      rolloutWindowPolicy: isSet(object.rolloutWindowPolicy)
+        ? RolloutWindowPolicy.fromJSON(object.rolloutWindowPolicy)
+        : undefined,
+      redisKeyAccessPolicy: isSet(object.redisKeyAccessPolicy)
+        ? RedisKeyAccessPolicy.fromJSON(object.redisKeyAccessPolicy)
+        : undefined,
       enforce: isSet(object.enforce) ? Boolean(object.enforce) : false,
       resourceType: isSet(object.resourceType) ? policyResourceTypeFromJSON(object.resourceType) : 0,
       resourceUid: isSet(object.resourceUid) ? String(object.resourceUid) : "",
@@ -1294,6 +1470,12 @@ export const Policy = {
     message.maskingExceptionPolicy !== undefined && (obj.maskingExceptionPolicy = message.maskingExceptionPolicy
       ? MaskingExceptionPolicy.toJSON(message.maskingExceptionPolicy)
       : undefined);
+    message.rolloutWindowPolicy !== undefined && (obj.rolloutWindowPolicy = message.rolloutWindowPolicy
+      ? RolloutWindowPolicy.toJSON(message.rolloutWindowPolicy)
+      : undefined);
+    message.redisKeyAccessPolicy !== undefined && (obj.redisKeyAccessPolicy = message.redisKeyAccessPolicy
+      ? RedisKeyAccessPolicy.toJSON(message.redisKeyAccessPolicy)
+      : undefined);
     message.enforce !== undefined && (obj.enforce = message.enforce);
     message.resourceType !== undefined && (obj.resourceType = policyResourceTypeToJSON(message.resourceType));
     message.resourceUid !== undefined && (obj.resourceUid = message.resourceUid);
@@ -1340,6 +1522,12 @@ export const Policy = {
       (object.maskingExceptionPolicy !== undefined && object.maskingExceptionPolicy !== null)
         ? MaskingExceptionPolicy.fromPartial(object.maskingExceptionPolicy)
         : undefined;
+    message.rolloutWindowPolicy = (object.rolloutWindowPolicy !== undefined && object.rolloutWindowPolicy !== null)
+      ? RolloutWindowPolicy.fromPartial(object.rolloutWindowPolicy)
+      : undefined;
+    message.redisKeyAccessPolicy = (object.redisKeyAccessPolicy !== undefined && object.redisKeyAccessPolicy !== null)
+      ? RedisKeyAccessPolicy.fromPartial(object.redisKeyAccessPolicy)
+      : undefined;
     message.enforce = object.enforce ?? false;
     message.resourceType = object.resourceType ?? 0;
     message.resourceUid = object.resourceUid ?? "";
@@ -1512,7 +1700,7 @@ export const DeploymentApprovalStrategy = {
 };
 
 function createBaseBackupPlanPolicy(): BackupPlanPolicy {
-  return { schedule: 0, retentionDuration: undefined };
+  return { schedule: 0, retentionDuration: undefined, storage: undefined };
 }
 
 export const BackupPlanPolicy = {
@@ -1523,6 +1711,9 @@ export const BackupPlanPolicy = {
     if (message.retentionDuration !== undefined) {
       Duration.encode(message.retentionDuration, writer.uint32(18).fork()).ldelim();
     }
+    if (message.storage !== undefined) {
+      BackupStorage.encode(message.storage, writer.uint32(26).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -1547,6 +1738,13 @@ export const BackupPlanPolicy = {
 
           message.retentionDuration = Duration.decode(reader, reader.uint32());
           continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.storage = BackupStorage.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1560,6 +1758,7 @@ export const BackupPlanPolicy = {
     return {
       schedule: isSet(object.schedule) ? backupPlanScheduleFromJSON(object.schedule) : 0,
       retentionDuration: isSet(object.retentionDuration) ? Duration.fromJSON(object.retentionDuration) : undefined,
+      storage: isSet(object.storage) ? BackupStorage.fromJSON(object.storage) : undefined,
     };
   },
 
@@ -1568,6 +1767,8 @@ export const BackupPlanPolicy = {
     message.schedule !== undefined && (obj.schedule = backupPlanScheduleToJSON(message.schedule));
     message.retentionDuration !== undefined &&
       (obj.retentionDuration = message.retentionDuration ? Duration.toJSON(message.retentionDuration) : undefined);
+    message.storage !== undefined &&
+      (obj.storage = message.storage ? BackupStorage.toJSON(message.storage) : undefined);
     return obj;
   },
 
@@ -1581,6 +1782,145 @@ export const BackupPlanPolicy = {
     message.retentionDuration = (object.retentionDuration !== undefined && object.retentionDuration !== null)
       ? Duration.fromPartial(object.retentionDuration)
       : undefined;
+    message.storage = (object.storage !== undefined && object.storage !== null)
+      ? BackupStorage.fromPartial(object.storage)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseBackupStorage(): BackupStorage {
+  return { type: 0, bucket: "", prefix: "", region: "", endpoint: "", credentialFile: "", encryptionKeyFile: "" };
+}
+
+export const BackupStorage = {
+  encode(message: BackupStorage, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.type !== 0) {
+      writer.uint32(8).int32(message.type);
+    }
+    if (message.bucket !== "") {
+      writer.uint32(18).string(message.bucket);
+    }
+    if (message.prefix !== "") {
+      writer.uint32(26).string(message.prefix);
+    }
+    if (message.region !== "") {
+      writer.uint32(34).string(message.region);
+    }
+    if (message.endpoint !== "") {
+      writer.uint32(42).string(message.endpoint);
+    }
+    if (message.credentialFile !== "") {
+      writer.uint32(50).string(message.credentialFile);
+    }
+    if (message.encryptionKeyFile !== "") {
+      writer.uint32(58).string(message.encryptionKeyFile);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): BackupStorage {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseBackupStorage();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.type = reader.int32() as any;
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.bucket = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.prefix = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.region = reader.string();
+          continue;
+        case 5:
+          if (tag !== 42) {
+            break;
+          }
+
+          message.endpoint = reader.string();
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.credentialFile = reader.string();
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.encryptionKeyFile = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): BackupStorage {
+    return {
+      type: isSet(object.type) ? backupStorage_TypeFromJSON(object.type) : 0,
+      bucket: isSet(object.bucket) ? String(object.bucket) : "",
+      prefix: isSet(object.prefix) ? String(object.prefix) : "",
+      region: isSet(object.region) ? String(object.region) : "",
+      endpoint: isSet(object.endpoint) ? String(object.endpoint) : "",
+      credentialFile: isSet(object.credentialFile) ? String(object.credentialFile) : "",
+      encryptionKeyFile: isSet(object.encryptionKeyFile) ? String(object.encryptionKeyFile) : "",
+    };
+  },
+
+  toJSON(message: BackupStorage): unknown {
+    const obj: any = {};
+    message.type !== undefined && (obj.type = backupStorage_TypeToJSON(message.type));
+    message.bucket !== undefined && (obj.bucket = message.bucket);
+    message.prefix !== undefined && (obj.prefix = message.prefix);
+    message.region !== undefined && (obj.region = message.region);
+    message.endpoint !== undefined && (obj.endpoint = message.endpoint);
+    message.credentialFile !== undefined && (obj.credentialFile = message.credentialFile);
+    message.encryptionKeyFile !== undefined && (obj.encryptionKeyFile = message.encryptionKeyFile);
+    return obj;
+  },
+
+  create(base?: DeepPartial<BackupStorage>): BackupStorage {
+    return BackupStorage.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<BackupStorage>): BackupStorage {
+    const message = createBaseBackupStorage();
+    message.type = object.type ?? 0;
+    message.bucket = object.bucket ?? "";
+    message.prefix = object.prefix ?? "";
+    message.region = object.region ?? "";
+    message.endpoint = object.endpoint ?? "";
+    message.credentialFile = object.credentialFile ?? "";
+    message.encryptionKeyFile = object.encryptionKeyFile ?? "";
     return message;
   },
 };
@@ -1697,6 +2037,348 @@ export const DisableCopyDataPolicy = {
   },
 };
 
+function createBaseRolloutWindowPolicy(): RolloutWindowPolicy {
+  return { allowedWindows: [], freezePeriods: [], bypassRoles: [] };
+}
+
+export const RolloutWindowPolicy = {
+  encode(message: RolloutWindowPolicy, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.allowedWindows) {
+      RolloutWindowPolicy_Window.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    for (const v of message.freezePeriods) {
+      RolloutWindowPolicy_FreezePeriod.encode(v!, writer.uint32(18).fork()).ldelim();
+    }
+    writer.uint32(26).fork();
+    for (const v of message.bypassRoles) {
+      writer.int32(v);
+    }
+    writer.ldelim();
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RolloutWindowPolicy {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRolloutWindowPolicy();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.allowedWindows.push(RolloutWindowPolicy_Window.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.freezePeriods.push(RolloutWindowPolicy_FreezePeriod.decode(reader, reader.uint32()));
+          continue;
+        case 3:
+          if (tag === 24) {
+            message.bypassRoles.push(reader.int32() as any);
+
+            continue;
+          }
+
+          if (tag === 26) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.bypassRoles.push(reader.int32() as any);
+            }
+
+            continue;
+          }
+
+          break;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RolloutWindowPolicy {
+    return {
+      allowedWindows: Array.isArray(object?.allowedWindows)
+        ? object.allowedWindows.map((e: any) => RolloutWindowPolicy_Window.fromJSON(e))
+        : [],
+      freezePeriods: Array.isArray(object?.freezePeriods)
+        ? object.freezePeriods.map((e: any) => RolloutWindowPolicy_FreezePeriod.fromJSON(e))
+        : [],
+      bypassRoles: Array.isArray(object?.bypassRoles) ? object.bypassRoles.map((e: any) => userRoleFromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: RolloutWindowPolicy): unknown {
+    const obj: any = {};
+    if (message.allowedWindows) {
+      obj.allowedWindows = message.allowedWindows.map((e) => e ? RolloutWindowPolicy_Window.toJSON(e) : undefined);
+    } else {
+      obj.allowedWindows = [];
+    }
+    if (message.freezePeriods) {
+      obj.freezePeriods = message.freezePeriods.map((e) => e ? RolloutWindowPolicy_FreezePeriod.toJSON(e) : undefined);
+    } else {
+      obj.freezePeriods = [];
+    }
+    if (message.bypassRoles) {
+      obj.bypassRoles = message.bypassRoles.map((e) => userRoleToJSON(e));
+    } else {
+      obj.bypassRoles = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RolloutWindowPolicy>): RolloutWindowPolicy {
+    return RolloutWindowPolicy.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RolloutWindowPolicy>): RolloutWindowPolicy {
+    const message = createBaseRolloutWindowPolicy();
+    message.allowedWindows = object.allowedWindows?.map((e) => RolloutWindowPolicy_Window.fromPartial(e)) || [];
+    message.freezePeriods = object.freezePeriods?.map((e) => RolloutWindowPolicy_FreezePeriod.fromPartial(e)) || [];
+    message.bypassRoles = object.bypassRoles?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseRolloutWindowPolicy_Window(): RolloutWindowPolicy_Window {
+  return { cron: "", duration: undefined, timeZone: "" };
+}
+
+export const RolloutWindowPolicy_Window = {
+  encode(message: RolloutWindowPolicy_Window, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.cron !== "") {
+      writer.uint32(10).string(message.cron);
+    }
+    if (message.duration !== undefined) {
+      Duration.encode(message.duration, writer.uint32(18).fork()).ldelim();
+    }
+    if (message.timeZone !== "") {
+      writer.uint32(26).string(message.timeZone);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RolloutWindowPolicy_Window {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRolloutWindowPolicy_Window();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.cron = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.duration = Duration.decode(reader, reader.uint32());
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.timeZone = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RolloutWindowPolicy_Window {
+    return {
+      cron: isSet(object.cron) ? String(object.cron) : "",
+      duration: isSet(object.duration) ? Duration.fromJSON(object.duration) : undefined,
+      timeZone: isSet(object.timeZone) ? String(object.timeZone) : "",
+    };
+  },
+
+  toJSON(message: RolloutWindowPolicy_Window): unknown {
+    const obj: any = {};
+    message.cron !== undefined && (obj.cron = message.cron);
+    message.duration !== undefined && (obj.duration = message.duration ? Duration.toJSON(message.duration) : undefined);
+    message.timeZone !== undefined && (obj.timeZone = message.timeZone);
+    return obj;
+  },
+
+  create(base?: DeepPartial<RolloutWindowPolicy_Window>): RolloutWindowPolicy_Window {
+    return RolloutWindowPolicy_Window.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RolloutWindowPolicy_Window>): RolloutWindowPolicy_Window {
+    const message = createBaseRolloutWindowPolicy_Window();
+    message.cron = object.cron ?? "";
+    message.duration = (object.duration !== undefined && object.duration !== null)
+      ? Duration.fromPartial(object.duration)
+      : undefined;
+    message.timeZone = object.timeZone ?? "";
+    return message;
+  },
+};
+
+function createBaseRolloutWindowPolicy_FreezePeriod(): RolloutWindowPolicy_FreezePeriod {
+  return { title: "", startTime: undefined, endTime: undefined };
+}
+
+export const RolloutWindowPolicy_FreezePeriod = {
+  encode(message: RolloutWindowPolicy_FreezePeriod, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.title !== "") {
+      writer.uint32(10).string(message.title);
+    }
+    if (message.startTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.startTime), writer.uint32(18).fork()).ldelim();
+    }
+    if (message.endTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.endTime), writer.uint32(26).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RolloutWindowPolicy_FreezePeriod {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRolloutWindowPolicy_FreezePeriod();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.startTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.endTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RolloutWindowPolicy_FreezePeriod {
+    return {
+      title: isSet(object.title) ? String(object.title) : "",
+      startTime: isSet(object.startTime) ? fromJsonTimestamp(object.startTime) : undefined,
+      endTime: isSet(object.endTime) ? fromJsonTimestamp(object.endTime) : undefined,
+    };
+  },
+
+  toJSON(message: RolloutWindowPolicy_FreezePeriod): unknown {
+    const obj: any = {};
+    message.title !== undefined && (obj.title = message.title);
+    message.startTime !== undefined && (obj.startTime = message.startTime.toISOString());
+    message.endTime !== undefined && (obj.endTime = message.endTime.toISOString());
+    return obj;
+  },
+
+  create(base?: DeepPartial<RolloutWindowPolicy_FreezePeriod>): RolloutWindowPolicy_FreezePeriod {
+    return RolloutWindowPolicy_FreezePeriod.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RolloutWindowPolicy_FreezePeriod>): RolloutWindowPolicy_FreezePeriod {
+    const message = createBaseRolloutWindowPolicy_FreezePeriod();
+    message.title = object.title ?? "";
+    message.startTime = object.startTime ?? undefined;
+    message.endTime = object.endTime ?? undefined;
+    return message;
+  },
+};
+
+function createBaseRedisKeyAccessPolicy(): RedisKeyAccessPolicy {
+  return { keyPatterns: [] };
+}
+
+export const RedisKeyAccessPolicy = {
+  encode(message: RedisKeyAccessPolicy, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.keyPatterns) {
+      writer.uint32(10).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RedisKeyAccessPolicy {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRedisKeyAccessPolicy();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.keyPatterns.push(reader.string());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RedisKeyAccessPolicy {
+    return { keyPatterns: Array.isArray(object?.keyPatterns) ? object.keyPatterns.map((e: any) => String(e)) : [] };
+  },
+
+  toJSON(message: RedisKeyAccessPolicy): unknown {
+    const obj: any = {};
+    if (message.keyPatterns) {
+      obj.keyPatterns = message.keyPatterns.map((e) => e);
+    } else {
+      obj.keyPatterns = [];
+    }
+    return obj;
+  },
+
+  create(base?: DeepPartial<RedisKeyAccessPolicy>): RedisKeyAccessPolicy {
+    return RedisKeyAccessPolicy.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RedisKeyAccessPolicy>): RedisKeyAccessPolicy {
+    const message = createBaseRedisKeyAccessPolicy();
+    message.keyPatterns = object.keyPatterns?.map((e) => e) || [];
+    return message;
+  },
+};
+
 function createBaseMaskingPolicy(): MaskingPolicy {
   return { maskData: [] };
 }
@@ -3484,6 +4166,28 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = date.getTime() / 1_000;
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = (t.seconds || 0) * 1_000;
+  millis += (t.nanos || 0) / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
@@ -1,7 +1,9 @@
 /* eslint-disable */
 import _m0 from "protobufjs/minimal";
+import { Duration } from "../google/protobuf/duration";
 import { Empty } from "../google/protobuf/empty";
 import { FieldMask } from "../google/protobuf/field_mask";
+import { Timestamp } from "../google/protobuf/timestamp";
 import { Expr } from "../google/type/expr";
 import { State, stateFromJSON, stateToJSON } from "./common";
 import { ProjectGitOpsInfo } from "./externalvs_service";
@@ -211,6 +213,10 @@ export enum OperatorType {
   OPERATOR_TYPE_IN = 1,
   /** OPERATOR_TYPE_EXISTS - The operator is "Exists". */
   OPERATOR_TYPE_EXISTS = 2,
+  /** OPERATOR_TYPE_NOT_IN - The operator is "NotIn". */
+  OPERATOR_TYPE_NOT_IN = 3,
+  /** OPERATOR_TYPE_DOES_NOT_EXIST - The operator is "DoesNotExist". */
+  OPERATOR_TYPE_DOES_NOT_EXIST = 4,
   UNRECOGNIZED = -1,
 }
 
@@ -225,6 +231,12 @@ export function operatorTypeFromJSON(object: any): OperatorType {
     case 2:
     case "OPERATOR_TYPE_EXISTS":
       return OperatorType.OPERATOR_TYPE_EXISTS;
+    case 3:
+    case "OPERATOR_TYPE_NOT_IN":
+      return OperatorType.OPERATOR_TYPE_NOT_IN;
+    case 4:
+    case "OPERATOR_TYPE_DOES_NOT_EXIST":
+      return OperatorType.OPERATOR_TYPE_DOES_NOT_EXIST;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -240,6 +252,10 @@ export function operatorTypeToJSON(object: OperatorType): string {
       return "OPERATOR_TYPE_IN";
     case OperatorType.OPERATOR_TYPE_EXISTS:
       return "OPERATOR_TYPE_EXISTS";
+    case OperatorType.OPERATOR_TYPE_NOT_IN:
+      return "OPERATOR_TYPE_NOT_IN";
+    case OperatorType.OPERATOR_TYPE_DOES_NOT_EXIST:
+      return "OPERATOR_TYPE_DOES_NOT_EXIST";
     case OperatorType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -598,6 +614,124 @@ export interface TestWebhookResponse {
   error: string;
 }
 
+export interface ListWebhookDeliveriesRequest {
+  /**
+   * The webhook which owns the deliveries.
+   * Format: projects/{project}/webhooks/{webhook}
+   */
+  parent: string;
+  /**
+   * The maximum number of deliveries to return. The service may return fewer than this value.
+   * If unspecified, at most 10 deliveries will be returned.
+   * The maximum value is 1000; values above 1000 will be coerced to 1000.
+   */
+  pageSize: number;
+  /**
+   * A page token, received from a previous `ListWebhookDeliveries` call.
+   * Provide this to retrieve the subsequent page.
+   *
+   * When paginating, all other parameters provided to `ListWebhookDeliveries` must match
+   * the call that provided the page token.
+   */
+  pageToken: string;
+}
+
+export interface ListWebhookDeliveriesResponse {
+  /** The deliveries of the webhook, the latest first. */
+  deliveries: WebhookDelivery[];
+  /**
+   * A token, which can be sent as `page_token` to retrieve the next page.
+   * If this field is omitted, there are no subsequent pages.
+   */
+  nextPageToken: string;
+}
+
+export interface RedeliverWebhookDeliveryRequest {
+  /**
+   * The name of the delivery to redeliver.
+   * Format: projects/{project}/webhooks/{webhook}/deliveries/{delivery}
+   */
+  name: string;
+}
+
+/**
+ * WebhookDelivery is a delivery of an activity to a webhook.
+ * Failed deliveries are retried with exponential backoff, and dead-lettered after the maximum attempts.
+ */
+export interface WebhookDelivery {
+  /**
+   * The name of the delivery.
+   * Format: projects/{project}/webhooks/{webhook}/deliveries/{delivery}
+   */
+  name: string;
+  /** The activity type triggering the delivery. */
+  activityType: Activity_Type;
+  /** The title of the delivered message. */
+  title: string;
+  status: WebhookDelivery_Status;
+  /** The number of attempts made. */
+  attemptCount: number;
+  /** The error of the last attempt, empty if the last attempt succeeded. */
+  lastError: string;
+  createTime:
+    | Date
+    | undefined;
+  /** The time of the last attempt, unset if never attempted. */
+  lastAttemptTime:
+    | Date
+    | undefined;
+  /** The time of the next attempt, only set for pending deliveries. */
+  nextAttemptTime: Date | undefined;
+}
+
+export enum WebhookDelivery_Status {
+  STATUS_UNSPECIFIED = 0,
+  /** PENDING - The delivery is waiting for the next attempt. */
+  PENDING = 1,
+  /** SUCCEEDED - The delivery has been accepted by the receiver. */
+  SUCCEEDED = 2,
+  /** DEAD_LETTER - The delivery has failed the maximum attempts and won't be retried automatically. */
+  DEAD_LETTER = 3,
+  UNRECOGNIZED = -1,
+}
+
+export function webhookDelivery_StatusFromJSON(object: any): WebhookDelivery_Status {
+  switch (object) {
+    case 0:
+    case "STATUS_UNSPECIFIED":
+      return WebhookDelivery_Status.STATUS_UNSPECIFIED;
+    case 1:
+    case "PENDING":
+      return WebhookDelivery_Status.PENDING;
+    case 2:
+    case "SUCCEEDED":
+      return WebhookDelivery_Status.SUCCEEDED;
+    case 3:
+    case "DEAD_LETTER":
+      return WebhookDelivery_Status.DEAD_LETTER;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return WebhookDelivery_Status.UNRECOGNIZED;
+  }
+}
+
+export function webhookDelivery_StatusToJSON(object: WebhookDelivery_Status): string {
+  switch (object) {
+    case WebhookDelivery_Status.STATUS_UNSPECIFIED:
+      return "STATUS_UNSPECIFIED";
+    case WebhookDelivery_Status.PENDING:
+      return "PENDING";
+    case WebhookDelivery_Status.SUCCEEDED:
+      return "SUCCEEDED";
+    case WebhookDelivery_Status.DEAD_LETTER:
+      return "DEAD_LETTER";
+    case WebhookDelivery_Status.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export interface Webhook {
   /**
    * name is the name of the webhook, generated by the server.
@@ -622,6 +756,14 @@ export interface Webhook {
    * - TYPE_ISSUE_COMMENT_CREAT
    */
   notificationTypes: Activity_Type[];
+  /**
+   * signing_secret is the secret to sign the requests of the custom webhook.
+   * If set, the Unix timestamp in seconds is sent in the X-Bytebase-Timestamp header, and the HMAC-SHA256 signature
+   * of "{timestamp}.{request body}" is sent in the X-Bytebase-Signature-256 header in the format of "sha256={hex digest}".
+   * Receivers should reject the requests with stale timestamps to prevent replays.
+   * It is never returned.
+   */
+  signingSecret: string;
 }
 
 export enum Webhook_Type {
@@ -715,7 +857,26 @@ export interface ScheduleDeployment {
 }
 
 export interface DeploymentSpec {
-  labelSelector: LabelSelector | undefined;
+  labelSelector:
+    | LabelSelector
+    | undefined;
+  /**
+   * The canary waves the matched databases are split into.
+   * The matched databases roll out in a single stage if not set.
+   */
+  waves: DeploymentWaves | undefined;
+}
+
+export interface DeploymentWaves {
+  /**
+   * The cumulative percentages of the matched databases rolled out in each wave, such as [1, 10, 100].
+   * The percentages must be increasing and end with 100.
+   */
+  percentages: number[];
+  /** The following waves are halted if more than max_failed_tasks tasks fail in a wave. */
+  maxFailedTasks: number;
+  /** The time to wait after the tasks of a wave finish before the next wave starts. */
+  soakDuration: Duration | undefined;
 }
 
 export interface LabelSelector {
@@ -3167,19 +3328,382 @@ export const TestWebhookResponse = {
     return writer;
   },
 
-  decode(input: _m0.Reader | Uint8Array, length?: number): TestWebhookResponse {
-    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
-    let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseTestWebhookResponse();
-    while (reader.pos < end) {
-      const tag = reader.uint32();
-      switch (tag >>> 3) {
-        case 1:
-          if (tag !== 10) {
+  decode(input: _m0.Reader | Uint8Array, length?: number): TestWebhookResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseTestWebhookResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): TestWebhookResponse {
+    return { error: isSet(object.error) ? String(object.error) : "" };
+  },
+
+  toJSON(message: TestWebhookResponse): unknown {
+    const obj: any = {};
+    message.error !== undefined && (obj.error = message.error);
+    return obj;
+  },
+
+  create(base?: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
+    return TestWebhookResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
+    const message = createBaseTestWebhookResponse();
+    message.error = object.error ?? "";
+    return message;
+  },
+};
+
+function createBaseListWebhookDeliveriesRequest(): ListWebhookDeliveriesRequest {
+  return { parent: "", pageSize: 0, pageToken: "" };
+}
+
+export const ListWebhookDeliveriesRequest = {
+  encode(message: ListWebhookDeliveriesRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    if (message.pageSize !== 0) {
+      writer.uint32(16).int32(message.pageSize);
+    }
+    if (message.pageToken !== "") {
+      writer.uint32(26).string(message.pageToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListWebhookDeliveriesRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListWebhookDeliveriesRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.pageSize = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.pageToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListWebhookDeliveriesRequest {
+    return {
+      parent: isSet(object.parent) ? String(object.parent) : "",
+      pageSize: isSet(object.pageSize) ? Number(object.pageSize) : 0,
+      pageToken: isSet(object.pageToken) ? String(object.pageToken) : "",
+    };
+  },
+
+  toJSON(message: ListWebhookDeliveriesRequest): unknown {
+    const obj: any = {};
+    message.parent !== undefined && (obj.parent = message.parent);
+    message.pageSize !== undefined && (obj.pageSize = Math.round(message.pageSize));
+    message.pageToken !== undefined && (obj.pageToken = message.pageToken);
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListWebhookDeliveriesRequest>): ListWebhookDeliveriesRequest {
+    return ListWebhookDeliveriesRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListWebhookDeliveriesRequest>): ListWebhookDeliveriesRequest {
+    const message = createBaseListWebhookDeliveriesRequest();
+    message.parent = object.parent ?? "";
+    message.pageSize = object.pageSize ?? 0;
+    message.pageToken = object.pageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseListWebhookDeliveriesResponse(): ListWebhookDeliveriesResponse {
+  return { deliveries: [], nextPageToken: "" };
+}
+
+export const ListWebhookDeliveriesResponse = {
+  encode(message: ListWebhookDeliveriesResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.deliveries) {
+      WebhookDelivery.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    if (message.nextPageToken !== "") {
+      writer.uint32(18).string(message.nextPageToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListWebhookDeliveriesResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListWebhookDeliveriesResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.deliveries.push(WebhookDelivery.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.nextPageToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListWebhookDeliveriesResponse {
+    return {
+      deliveries: Array.isArray(object?.deliveries)
+        ? object.deliveries.map((e: any) => WebhookDelivery.fromJSON(e))
+        : [],
+      nextPageToken: isSet(object.nextPageToken) ? String(object.nextPageToken) : "",
+    };
+  },
+
+  toJSON(message: ListWebhookDeliveriesResponse): unknown {
+    const obj: any = {};
+    if (message.deliveries) {
+      obj.deliveries = message.deliveries.map((e) => e ? WebhookDelivery.toJSON(e) : undefined);
+    } else {
+      obj.deliveries = [];
+    }
+    message.nextPageToken !== undefined && (obj.nextPageToken = message.nextPageToken);
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListWebhookDeliveriesResponse>): ListWebhookDeliveriesResponse {
+    return ListWebhookDeliveriesResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListWebhookDeliveriesResponse>): ListWebhookDeliveriesResponse {
+    const message = createBaseListWebhookDeliveriesResponse();
+    message.deliveries = object.deliveries?.map((e) => WebhookDelivery.fromPartial(e)) || [];
+    message.nextPageToken = object.nextPageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseRedeliverWebhookDeliveryRequest(): RedeliverWebhookDeliveryRequest {
+  return { name: "" };
+}
+
+export const RedeliverWebhookDeliveryRequest = {
+  encode(message: RedeliverWebhookDeliveryRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RedeliverWebhookDeliveryRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRedeliverWebhookDeliveryRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RedeliverWebhookDeliveryRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: RedeliverWebhookDeliveryRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<RedeliverWebhookDeliveryRequest>): RedeliverWebhookDeliveryRequest {
+    return RedeliverWebhookDeliveryRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RedeliverWebhookDeliveryRequest>): RedeliverWebhookDeliveryRequest {
+    const message = createBaseRedeliverWebhookDeliveryRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseWebhookDelivery(): WebhookDelivery {
+  return {
+    name: "",
+    activityType: 0,
+    title: "",
+    status: 0,
+    attemptCount: 0,
+    lastError: "",
+    createTime: undefined,
+    lastAttemptTime: undefined,
+    nextAttemptTime: undefined,
+  };
+}
+
+export const WebhookDelivery = {
+  encode(message: WebhookDelivery, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.activityType !== 0) {
+      writer.uint32(16).int32(message.activityType);
+    }
+    if (message.title !== "") {
+      writer.uint32(26).string(message.title);
+    }
+    if (message.status !== 0) {
+      writer.uint32(32).int32(message.status);
+    }
+    if (message.attemptCount !== 0) {
+      writer.uint32(40).int32(message.attemptCount);
+    }
+    if (message.lastError !== "") {
+      writer.uint32(50).string(message.lastError);
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(58).fork()).ldelim();
+    }
+    if (message.lastAttemptTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.lastAttemptTime), writer.uint32(66).fork()).ldelim();
+    }
+    if (message.nextAttemptTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.nextAttemptTime), writer.uint32(74).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): WebhookDelivery {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWebhookDelivery();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.activityType = reader.int32() as any;
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.title = reader.string();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.status = reader.int32() as any;
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.attemptCount = reader.int32();
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.lastError = reader.string();
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 8:
+          if (tag !== 66) {
             break;
           }
 
-          message.error = reader.string();
+          message.lastAttemptTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.nextAttemptTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
           continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
@@ -3190,29 +3714,55 @@ export const TestWebhookResponse = {
     return message;
   },
 
-  fromJSON(object: any): TestWebhookResponse {
-    return { error: isSet(object.error) ? String(object.error) : "" };
+  fromJSON(object: any): WebhookDelivery {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      activityType: isSet(object.activityType) ? activity_TypeFromJSON(object.activityType) : 0,
+      title: isSet(object.title) ? String(object.title) : "",
+      status: isSet(object.status) ? webhookDelivery_StatusFromJSON(object.status) : 0,
+      attemptCount: isSet(object.attemptCount) ? Number(object.attemptCount) : 0,
+      lastError: isSet(object.lastError) ? String(object.lastError) : "",
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      lastAttemptTime: isSet(object.lastAttemptTime) ? fromJsonTimestamp(object.lastAttemptTime) : undefined,
+      nextAttemptTime: isSet(object.nextAttemptTime) ? fromJsonTimestamp(object.nextAttemptTime) : undefined,
+    };
   },
 
-  toJSON(message: TestWebhookResponse): unknown {
+  toJSON(message: WebhookDelivery): unknown {
     const obj: any = {};
-    message.error !== undefined && (obj.error = message.error);
+    message.name !== undefined && (obj.name = message.name);
+    message.activityType !== undefined && (obj.activityType = activity_TypeToJSON(message.activityType));
+    message.title !== undefined && (obj.title = message.title);
+    message.status !== undefined && (obj.status = webhookDelivery_StatusToJSON(message.status));
+    message.attemptCount !== undefined && (obj.attemptCount = Math.round(message.attemptCount));
+    message.lastError !== undefined && (obj.lastError = message.lastError);
+    message.createTime !== undefined && (obj.createTime = message.createTime.toISOString());
+    message.lastAttemptTime !== undefined && (obj.lastAttemptTime = message.lastAttemptTime.toISOString());
+    message.nextAttemptTime !== undefined && (obj.nextAttemptTime = message.nextAttemptTime.toISOString());
     return obj;
   },
 
-  create(base?: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
-    return TestWebhookResponse.fromPartial(base ?? {});
+  create(base?: DeepPartial<WebhookDelivery>): WebhookDelivery {
+    return WebhookDelivery.fromPartial(base ?? {});
   },
 
-  fromPartial(object: DeepPartial<TestWebhookResponse>): TestWebhookResponse {
-    const message = createBaseTestWebhookResponse();
-    message.error = object.error ?? "";
+  fromPartial(object: DeepPartial<WebhookDelivery>): WebhookDelivery {
+    const message = createBaseWebhookDelivery();
+    message.name = object.name ?? "";
+    message.activityType = object.activityType ?? 0;
+    message.title = object.title ?? "";
+    message.status = object.status ?? 0;
+    message.attemptCount = object.attemptCount ?? 0;
+    message.lastError = object.lastError ?? "";
+    message.createTime = object.createTime ?? undefined;
+    message.lastAttemptTime = object.lastAttemptTime ?? undefined;
+    message.nextAttemptTime = object.nextAttemptTime ?? undefined;
     return message;
   },
 };
 
 function createBaseWebhook(): Webhook {
-  return { name: "", type: 0, title: "", url: "", notificationTypes: [] };
+  return { name: "", type: 0, title: "", url: "", notificationTypes: [], signingSecret: "" };
 }
 
 export const Webhook = {
@@ -3234,6 +3784,9 @@ export const Webhook = {
       writer.int32(v);
     }
     writer.ldelim();
+    if (message.signingSecret !== "") {
+      writer.uint32(50).string(message.signingSecret);
+    }
     return writer;
   },
 
@@ -3289,6 +3842,13 @@ export const Webhook = {
           }
 
           break;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.signingSecret = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -3307,6 +3867,7 @@ export const Webhook = {
       notificationTypes: Array.isArray(object?.notificationTypes)
         ? object.notificationTypes.map((e: any) => activity_TypeFromJSON(e))
         : [],
+      signingSecret: isSet(object.signingSecret) ? String(object.signingSecret) : "",
     };
   },
 
@@ -3321,6 +3882,7 @@ export const Webhook = {
     } else {
       obj.notificationTypes = [];
     }
+    message.signingSecret !== undefined && (obj.signingSecret = message.signingSecret);
     return obj;
   },
 
@@ -3335,6 +3897,7 @@ export const Webhook = {
     message.title = object.title ?? "";
     message.url = object.url ?? "";
     message.notificationTypes = object.notificationTypes?.map((e) => e) || [];
+    message.signingSecret = object.signingSecret ?? "";
     return message;
   },
 };
@@ -3563,7 +4126,7 @@ export const ScheduleDeployment = {
 };
 
 function createBaseDeploymentSpec(): DeploymentSpec {
-  return { labelSelector: undefined };
+  return { labelSelector: undefined, waves: undefined };
 }
 
 export const DeploymentSpec = {
@@ -3571,6 +4134,9 @@ export const DeploymentSpec = {
     if (message.labelSelector !== undefined) {
       LabelSelector.encode(message.labelSelector, writer.uint32(10).fork()).ldelim();
     }
+    if (message.waves !== undefined) {
+      DeploymentWaves.encode(message.waves, writer.uint32(18).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -3588,6 +4154,13 @@ export const DeploymentSpec = {
 
           message.labelSelector = LabelSelector.decode(reader, reader.uint32());
           continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.waves = DeploymentWaves.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -3598,13 +4171,17 @@ export const DeploymentSpec = {
   },
 
   fromJSON(object: any): DeploymentSpec {
-    return { labelSelector: isSet(object.labelSelector) ? LabelSelector.fromJSON(object.labelSelector) : undefined };
+    return {
+      labelSelector: isSet(object.labelSelector) ? LabelSelector.fromJSON(object.labelSelector) : undefined,
+      waves: isSet(object.waves) ? DeploymentWaves.fromJSON(object.waves) : undefined,
+    };
   },
 
   toJSON(message: DeploymentSpec): unknown {
     const obj: any = {};
     message.labelSelector !== undefined &&
       (obj.labelSelector = message.labelSelector ? LabelSelector.toJSON(message.labelSelector) : undefined);
+    message.waves !== undefined && (obj.waves = message.waves ? DeploymentWaves.toJSON(message.waves) : undefined);
     return obj;
   },
 
@@ -3617,6 +4194,112 @@ export const DeploymentSpec = {
     message.labelSelector = (object.labelSelector !== undefined && object.labelSelector !== null)
       ? LabelSelector.fromPartial(object.labelSelector)
       : undefined;
+    message.waves = (object.waves !== undefined && object.waves !== null)
+      ? DeploymentWaves.fromPartial(object.waves)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseDeploymentWaves(): DeploymentWaves {
+  return { percentages: [], maxFailedTasks: 0, soakDuration: undefined };
+}
+
+export const DeploymentWaves = {
+  encode(message: DeploymentWaves, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    writer.uint32(10).fork();
+    for (const v of message.percentages) {
+      writer.int32(v);
+    }
+    writer.ldelim();
+    if (message.maxFailedTasks !== 0) {
+      writer.uint32(16).int32(message.maxFailedTasks);
+    }
+    if (message.soakDuration !== undefined) {
+      Duration.encode(message.soakDuration, writer.uint32(26).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeploymentWaves {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeploymentWaves();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag === 8) {
+            message.percentages.push(reader.int32());
+
+            continue;
+          }
+
+          if (tag === 10) {
+            const end2 = reader.uint32() + reader.pos;
+            while (reader.pos < end2) {
+              message.percentages.push(reader.int32());
+            }
+
+            continue;
+          }
+
+          break;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.maxFailedTasks = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.soakDuration = Duration.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DeploymentWaves {
+    return {
+      percentages: Array.isArray(object?.percentages) ? object.percentages.map((e: any) => Number(e)) : [],
+      maxFailedTasks: isSet(object.maxFailedTasks) ? Number(object.maxFailedTasks) : 0,
+      soakDuration: isSet(object.soakDuration) ? Duration.fromJSON(object.soakDuration) : undefined,
+    };
+  },
+
+  toJSON(message: DeploymentWaves): unknown {
+    const obj: any = {};
+    if (message.percentages) {
+      obj.percentages = message.percentages.map((e) => Math.round(e));
+    } else {
+      obj.percentages = [];
+    }
+    message.maxFailedTasks !== undefined && (obj.maxFailedTasks = Math.round(message.maxFailedTasks));
+    message.soakDuration !== undefined &&
+      (obj.soakDuration = message.soakDuration ? Duration.toJSON(message.soakDuration) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<DeploymentWaves>): DeploymentWaves {
+    return DeploymentWaves.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<DeploymentWaves>): DeploymentWaves {
+    const message = createBaseDeploymentWaves();
+    message.percentages = object.percentages?.map((e) => e) || [];
+    message.maxFailedTasks = object.maxFailedTasks ?? 0;
+    message.soakDuration = (object.soakDuration !== undefined && object.soakDuration !== null)
+      ? Duration.fromPartial(object.soakDuration)
+      : undefined;
     return message;
   },
 };
@@ -6320,6 +7003,147 @@ export const ProjectServiceDefinition = {
         },
       },
     },
+    listWebhookDeliveries: {
+      name: "ListWebhookDeliveries",
+      requestType: ListWebhookDeliveriesRequest,
+      requestStream: false,
+      responseType: ListWebhookDeliveriesResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([6, 112, 97, 114, 101, 110, 116])],
+          578365826: [
+            new Uint8Array([
+              47,
+              18,
+              45,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              119,
+              101,
+              98,
+              104,
+              111,
+              111,
+              107,
+              115,
+              47,
+              42,
+              125,
+              47,
+              100,
+              101,
+              108,
+              105,
+              118,
+              101,
+              114,
+              105,
+              101,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    redeliverWebhookDelivery: {
+      name: "RedeliverWebhookDelivery",
+      requestType: RedeliverWebhookDeliveryRequest,
+      requestStream: false,
+      responseType: WebhookDelivery,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              60,
+              58,
+              1,
+              42,
+              34,
+              55,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              119,
+              101,
+              98,
+              104,
+              111,
+              111,
+              107,
+              115,
+              47,
+              42,
+              47,
+              100,
+              101,
+              108,
+              105,
+              118,
+              101,
+              114,
+              105,
+              101,
+              115,
+              47,
+              42,
+              125,
+              58,
+              114,
+              101,
+              100,
+              101,
+              108,
+              105,
+              118,
+              101,
+              114,
+            ]),
+          ],
+        },
+      },
+    },
     updateProjectGitOpsInfo: {
       name: "UpdateProjectGitOpsInfo",
       requestType: UpdateProjectGitOpsInfoRequest,
@@ -7543,6 +8367,28 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = date.getTime() / 1_000;
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = (t.seconds || 0) * 1_000;
+  millis += (t.nanos || 0) / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
 function isSet(value: any): boolean {
   return value !== null && value !== undefined;
 }
@@ -1,6 +1,7 @@
 /* eslint-disable */
 import Long from "long";
 import _m0 from "protobufjs/minimal";
+import { Duration } from "../google/protobuf/duration";
 import { FieldMask } from "../google/protobuf/field_mask";
 import { Timestamp } from "../google/protobuf/timestamp";
 import { ChangedResources } from "./database_service";
@@ -396,6 +397,7 @@ export enum PlanCheckRun_Type {
   DATABASE_CONNECT = 6,
   DATABASE_GHOST_SYNC = 7,
   DATABASE_PITR_MYSQL = 8,
+  DATABASE_STATEMENT_LOCK_IMPACT = 9,
   UNRECOGNIZED = -1,
 }
 
@@ -428,6 +430,9 @@ export function planCheckRun_TypeFromJSON(object: any): PlanCheckRun_Type {
     case 8:
     case "DATABASE_PITR_MYSQL":
       return PlanCheckRun_Type.DATABASE_PITR_MYSQL;
+    case 9:
+    case "DATABASE_STATEMENT_LOCK_IMPACT":
+      return PlanCheckRun_Type.DATABASE_STATEMENT_LOCK_IMPACT;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -455,6 +460,8 @@ export function planCheckRun_TypeToJSON(object: PlanCheckRun_Type): string {
       return "DATABASE_GHOST_SYNC";
     case PlanCheckRun_Type.DATABASE_PITR_MYSQL:
       return "DATABASE_PITR_MYSQL";
+    case PlanCheckRun_Type.DATABASE_STATEMENT_LOCK_IMPACT:
+      return "DATABASE_STATEMENT_LOCK_IMPACT";
     case PlanCheckRun_Type.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -519,6 +526,7 @@ export interface PlanCheckRun_Result {
   code: number;
   sqlSummaryReport?: PlanCheckRun_Result_SqlSummaryReport | undefined;
   sqlReviewReport?: PlanCheckRun_Result_SqlReviewReport | undefined;
+  lockImpactReport?: PlanCheckRun_Result_LockImpactReport | undefined;
 }
 
 export enum PlanCheckRun_Result_Status {
@@ -582,6 +590,109 @@ export interface PlanCheckRun_Result_SqlReviewReport {
   code: number;
 }
 
+/** LockImpactReport is the lock impact of a PostgreSQL DDL statement. */
+export interface PlanCheckRun_Result_LockImpactReport {
+  line: number;
+  /** statement_type is the type of the statement, such as ALTER_TABLE. */
+  statementType: string;
+  /** The table locked by the statement, in the format of "schema"."table". */
+  table: string;
+  /** lock_level is the strongest table lock taken by the statement. */
+  lockLevel: PlanCheckRun_Result_LockImpactReport_LockLevel;
+  /** table_rewrite is whether the statement rewrites the whole table and its indexes. */
+  tableRewrite: boolean;
+  /** validation_scan is whether the statement scans the whole table to validate the constraints. */
+  validationScan: boolean;
+  /** The synced row count and data size of the table. */
+  rowCount: number;
+  dataSize: number;
+  /** estimated_duration is the estimated duration of holding the lock, based on the synced table size. */
+  estimatedDuration: Duration | undefined;
+}
+
+/**
+ * LockLevel is the PostgreSQL table-level lock mode.
+ * See https://www.postgresql.org/docs/current/explicit-locking.html.
+ */
+export enum PlanCheckRun_Result_LockImpactReport_LockLevel {
+  LOCK_LEVEL_UNSPECIFIED = 0,
+  ACCESS_SHARE = 1,
+  ROW_SHARE = 2,
+  ROW_EXCLUSIVE = 3,
+  SHARE_UPDATE_EXCLUSIVE = 4,
+  SHARE = 5,
+  SHARE_ROW_EXCLUSIVE = 6,
+  EXCLUSIVE = 7,
+  ACCESS_EXCLUSIVE = 8,
+  UNRECOGNIZED = -1,
+}
+
+export function planCheckRun_Result_LockImpactReport_LockLevelFromJSON(
+  object: any,
+): PlanCheckRun_Result_LockImpactReport_LockLevel {
+  switch (object) {
+    case 0:
+    case "LOCK_LEVEL_UNSPECIFIED":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.LOCK_LEVEL_UNSPECIFIED;
+    case 1:
+    case "ACCESS_SHARE":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.ACCESS_SHARE;
+    case 2:
+    case "ROW_SHARE":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.ROW_SHARE;
+    case 3:
+    case "ROW_EXCLUSIVE":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.ROW_EXCLUSIVE;
+    case 4:
+    case "SHARE_UPDATE_EXCLUSIVE":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.SHARE_UPDATE_EXCLUSIVE;
+    case 5:
+    case "SHARE":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.SHARE;
+    case 6:
+    case "SHARE_ROW_EXCLUSIVE":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.SHARE_ROW_EXCLUSIVE;
+    case 7:
+    case "EXCLUSIVE":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.EXCLUSIVE;
+    case 8:
+    case "ACCESS_EXCLUSIVE":
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.ACCESS_EXCLUSIVE;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return PlanCheckRun_Result_LockImpactReport_LockLevel.UNRECOGNIZED;
+  }
+}
+
+export function planCheckRun_Result_LockImpactReport_LockLevelToJSON(
+  object: PlanCheckRun_Result_LockImpactReport_LockLevel,
+): string {
+  switch (object) {
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.LOCK_LEVEL_UNSPECIFIED:
+      return "LOCK_LEVEL_UNSPECIFIED";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.ACCESS_SHARE:
+      return "ACCESS_SHARE";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.ROW_SHARE:
+      return "ROW_SHARE";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.ROW_EXCLUSIVE:
+      return "ROW_EXCLUSIVE";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.SHARE_UPDATE_EXCLUSIVE:
+      return "SHARE_UPDATE_EXCLUSIVE";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.SHARE:
+      return "SHARE";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.SHARE_ROW_EXCLUSIVE:
+      return "SHARE_ROW_EXCLUSIVE";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.EXCLUSIVE:
+      return "EXCLUSIVE";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.ACCESS_EXCLUSIVE:
+      return "ACCESS_EXCLUSIVE";
+    case PlanCheckRun_Result_LockImpactReport_LockLevel.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export interface GetRolloutRequest {
   /**
    * The name of the rollout to retrieve.
@@ -671,6 +782,11 @@ export interface Stage {
   environment: string;
   title: string;
   tasks: Task[];
+  /**
+   * The reason why the tasks of the stage are not allowed to roll out now by the rollout window policy of the environment.
+   * Empty if the tasks are allowed to roll out now.
+   */
+  rolloutWindowWarning: string;
 }
 
 export interface Task {
@@ -3161,7 +3277,15 @@ export const PlanCheckRun = {
 };
 
 function createBasePlanCheckRun_Result(): PlanCheckRun_Result {
-  return { status: 0, title: "", content: "", code: 0, sqlSummaryReport: undefined, sqlReviewReport: undefined };
+  return {
+    status: 0,
+    title: "",
+    content: "",
+    code: 0,
+    sqlSummaryReport: undefined,
+    sqlReviewReport: undefined,
+    lockImpactReport: undefined,
+  };
 }
 
 export const PlanCheckRun_Result = {
@@ -3184,6 +3308,9 @@ export const PlanCheckRun_Result = {
     if (message.sqlReviewReport !== undefined) {
       PlanCheckRun_Result_SqlReviewReport.encode(message.sqlReviewReport, writer.uint32(50).fork()).ldelim();
     }
+    if (message.lockImpactReport !== undefined) {
+      PlanCheckRun_Result_LockImpactReport.encode(message.lockImpactReport, writer.uint32(58).fork()).ldelim();
+    }
     return writer;
   },
 
@@ -3236,6 +3363,13 @@ export const PlanCheckRun_Result = {
 
           message.sqlReviewReport = PlanCheckRun_Result_SqlReviewReport.decode(reader, reader.uint32());
           continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.lockImpactReport = PlanCheckRun_Result_LockImpactReport.decode(reader, reader.uint32());
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -3257,6 +3391,9 @@ export const PlanCheckRun_Result = {
       sqlReviewReport: isSet(object.sqlReviewReport)
         ? PlanCheckRun_Result_SqlReviewReport.fromJSON(object.sqlReviewReport)
         : undefined,
+      lockImpactReport: isSet(object.lockImpactReport)
+        ? PlanCheckRun_Result_LockImpactReport.fromJSON(object.lockImpactReport)
+        : undefined,
     };
   },
 
@@ -3272,6 +3409,9 @@ export const PlanCheckRun_Result = {
     message.sqlReviewReport !== undefined && (obj.sqlReviewReport = message.sqlReviewReport
       ? PlanCheckRun_Result_SqlReviewReport.toJSON(message.sqlReviewReport)
       : undefined);
+    message.lockImpactReport !== undefined && (obj.lockImpactReport = message.lockImpactReport
+      ? PlanCheckRun_Result_LockImpactReport.toJSON(message.lockImpactReport)
+      : undefined);
     return obj;
   },
 
@@ -3291,6 +3431,9 @@ export const PlanCheckRun_Result = {
     message.sqlReviewReport = (object.sqlReviewReport !== undefined && object.sqlReviewReport !== null)
       ? PlanCheckRun_Result_SqlReviewReport.fromPartial(object.sqlReviewReport)
       : undefined;
+    message.lockImpactReport = (object.lockImpactReport !== undefined && object.lockImpactReport !== null)
+      ? PlanCheckRun_Result_LockImpactReport.fromPartial(object.lockImpactReport)
+      : undefined;
     return message;
   },
 };
@@ -3496,6 +3639,182 @@ export const PlanCheckRun_Result_SqlReviewReport = {
   },
 };
 
+function createBasePlanCheckRun_Result_LockImpactReport(): PlanCheckRun_Result_LockImpactReport {
+  return {
+    line: 0,
+    statementType: "",
+    table: "",
+    lockLevel: 0,
+    tableRewrite: false,
+    validationScan: false,
+    rowCount: 0,
+    dataSize: 0,
+    estimatedDuration: undefined,
+  };
+}
+
+export const PlanCheckRun_Result_LockImpactReport = {
+  encode(message: PlanCheckRun_Result_LockImpactReport, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.line !== 0) {
+      writer.uint32(8).int64(message.line);
+    }
+    if (message.statementType !== "") {
+      writer.uint32(18).string(message.statementType);
+    }
+    if (message.table !== "") {
+      writer.uint32(26).string(message.table);
+    }
+    if (message.lockLevel !== 0) {
+      writer.uint32(32).int32(message.lockLevel);
+    }
+    if (message.tableRewrite === true) {
+      writer.uint32(40).bool(message.tableRewrite);
+    }
+    if (message.validationScan === true) {
+      writer.uint32(48).bool(message.validationScan);
+    }
+    if (message.rowCount !== 0) {
+      writer.uint32(56).int64(message.rowCount);
+    }
+    if (message.dataSize !== 0) {
+      writer.uint32(64).int64(message.dataSize);
+    }
+    if (message.estimatedDuration !== undefined) {
+      Duration.encode(message.estimatedDuration, writer.uint32(74).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): PlanCheckRun_Result_LockImpactReport {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlanCheckRun_Result_LockImpactReport();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 8) {
+            break;
+          }
+
+          message.line = longToNumber(reader.int64() as Long);
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.statementType = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.table = reader.string();
+          continue;
+        case 4:
+          if (tag !== 32) {
+            break;
+          }
+
+          message.lockLevel = reader.int32() as any;
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.tableRewrite = reader.bool();
+          continue;
+        case 6:
+          if (tag !== 48) {
+            break;
+          }
+
+          message.validationScan = reader.bool();
+          continue;
+        case 7:
+          if (tag !== 56) {
+            break;
+          }
+
+          message.rowCount = longToNumber(reader.int64() as Long);
+          continue;
+        case 8:
+          if (tag !== 64) {
+            break;
+          }
+
+          message.dataSize = longToNumber(reader.int64() as Long);
+          continue;
+        case 9:
+          if (tag !== 74) {
+            break;
+          }
+
+          message.estimatedDuration = Duration.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlanCheckRun_Result_LockImpactReport {
+    return {
+      line: isSet(object.line) ? Number(object.line) : 0,
+      statementType: isSet(object.statementType) ? String(object.statementType) : "",
+      table: isSet(object.table) ? String(object.table) : "",
+      lockLevel: isSet(object.lockLevel) ? planCheckRun_Result_LockImpactReport_LockLevelFromJSON(object.lockLevel) : 0,
+      tableRewrite: isSet(object.tableRewrite) ? Boolean(object.tableRewrite) : false,
+      validationScan: isSet(object.validationScan) ? Boolean(object.validationScan) : false,
+      rowCount: isSet(object.rowCount) ? Number(object.rowCount) : 0,
+      dataSize: isSet(object.dataSize) ? Number(object.dataSize) : 0,
+      estimatedDuration: isSet(object.estimatedDuration) ? Duration.fromJSON(object.estimatedDuration) : undefined,
+    };
+  },
+
+  toJSON(message: PlanCheckRun_Result_LockImpactReport): unknown {
+    const obj: any = {};
+    message.line !== undefined && (obj.line = Math.round(message.line));
+    message.statementType !== undefined && (obj.statementType = message.statementType);
+    message.table !== undefined && (obj.table = message.table);
+    message.lockLevel !== undefined &&
+      (obj.lockLevel = planCheckRun_Result_LockImpactReport_LockLevelToJSON(message.lockLevel));
+    message.tableRewrite !== undefined && (obj.tableRewrite = message.tableRewrite);
+    message.validationScan !== undefined && (obj.validationScan = message.validationScan);
+    message.rowCount !== undefined && (obj.rowCount = Math.round(message.rowCount));
+    message.dataSize !== undefined && (obj.dataSize = Math.round(message.dataSize));
+    message.estimatedDuration !== undefined &&
+      (obj.estimatedDuration = message.estimatedDuration ? Duration.toJSON(message.estimatedDuration) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<PlanCheckRun_Result_LockImpactReport>): PlanCheckRun_Result_LockImpactReport {
+    return PlanCheckRun_Result_LockImpactReport.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<PlanCheckRun_Result_LockImpactReport>): PlanCheckRun_Result_LockImpactReport {
+    const message = createBasePlanCheckRun_Result_LockImpactReport();
+    message.line = object.line ?? 0;
+    message.statementType = object.statementType ?? "";
+    message.table = object.table ?? "";
+    message.lockLevel = object.lockLevel ?? 0;
+    message.tableRewrite = object.tableRewrite ?? false;
+    message.validationScan = object.validationScan ?? false;
+    message.rowCount = object.rowCount ?? 0;
+    message.dataSize = object.dataSize ?? 0;
+    message.estimatedDuration = (object.estimatedDuration !== undefined && object.estimatedDuration !== null)
+      ? Duration.fromPartial(object.estimatedDuration)
+      : undefined;
+    return message;
+  },
+};
+
 function createBaseGetRolloutRequest(): GetRolloutRequest {
   return { name: "" };
 }
@@ -3968,7 +4287,7 @@ export const Rollout = {
 };
 
 function createBaseStage(): Stage {
-  return { name: "", uid: "", environment: "", title: "", tasks: [] };
+  return { name: "", uid: "", environment: "", title: "", tasks: [], rolloutWindowWarning: "" };
 }
 
 export const Stage = {
@@ -3988,6 +4307,9 @@ export const Stage = {
     for (const v of message.tasks) {
       Task.encode(v!, writer.uint32(42).fork()).ldelim();
     }
+    if (message.rolloutWindowWarning !== "") {
+      writer.uint32(50).string(message.rolloutWindowWarning);
+    }
     return writer;
   },
 
@@ -4033,6 +4355,13 @@ export const Stage = {
 
           message.tasks.push(Task.decode(reader, reader.uint32()));
           continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.rolloutWindowWarning = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -4049,6 +4378,7 @@ export const Stage = {
       environment: isSet(object.environment) ? String(object.environment) : "",
       title: isSet(object.title) ? String(object.title) : "",
       tasks: Array.isArray(object?.tasks) ? object.tasks.map((e: any) => Task.fromJSON(e)) : [],
+      rolloutWindowWarning: isSet(object.rolloutWindowWarning) ? String(object.rolloutWindowWarning) : "",
     };
   },
 
@@ -4063,6 +4393,7 @@ export const Stage = {
     } else {
       obj.tasks = [];
     }
+    message.rolloutWindowWarning !== undefined && (obj.rolloutWindowWarning = message.rolloutWindowWarning);
     return obj;
   },
 
@@ -4077,6 +4408,7 @@ export const Stage = {
     message.environment = object.environment ?? "";
     message.title = object.title ?? "";
     message.tasks = object.tasks?.map((e) => Task.fromPartial(e)) || [];
+    message.rolloutWindowWarning = object.rolloutWindowWarning ?? "";
     return message;
   },
 };
@@ -332,6 +332,103 @@ export function sheet_TypeToJSON(object: Sheet_Type): string {
   }
 }
 
+export interface ListSheetRevisionsRequest {
+  /**
+   * The sheet which owns the revisions.
+   * Format: projects/{project}/sheets/{sheet}
+   */
+  parent: string;
+  /**
+   * The maximum number of revisions to return. The service may return fewer than this value.
+   * If unspecified, at most 10 revisions will be returned.
+   * The maximum value is 1000; values above 1000 will be coerced to 1000.
+   */
+  pageSize: number;
+  /**
+   * A page token, received from a previous `ListSheetRevisions` call.
+   * Provide this to retrieve the subsequent page.
+   *
+   * When paginating, all other parameters provided to `ListSheetRevisions` must match
+   * the call that provided the page token.
+   */
+  pageToken: string;
+}
+
+export interface ListSheetRevisionsResponse {
+  /**
+   * The revisions of the sheet, the latest first.
+   * The content of the revisions is cut off, use GetSheetRevision to retrieve the full content.
+   */
+  revisions: SheetRevision[];
+  /**
+   * A token, which can be sent as `page_token` to retrieve the next page.
+   * If this field is omitted, there are no subsequent pages.
+   */
+  nextPageToken: string;
+}
+
+export interface GetSheetRevisionRequest {
+  /**
+   * The name of the revision to retrieve.
+   * Format: projects/{project}/sheets/{sheet}/revisions/{revision}
+   */
+  name: string;
+}
+
+export interface DiffSheetRevisionsRequest {
+  /**
+   * The name of the sheet.
+   * Format: projects/{project}/sheets/{sheet}
+   */
+  name: string;
+  /**
+   * The revision to diff from.
+   * Format: projects/{project}/sheets/{sheet}/revisions/{revision}
+   */
+  baseRevision: string;
+  /**
+   * The revision to diff to. If it's empty, the latest revision is used.
+   * Format: projects/{project}/sheets/{sheet}/revisions/{revision}
+   */
+  targetRevision: string;
+}
+
+export interface DiffSheetRevisionsResponse {
+  /**
+   * The unified diff from the base revision to the target revision.
+   * It's empty if the contents are identical.
+   */
+  diff: string;
+}
+
+export interface RestoreSheetRevisionRequest {
+  /**
+   * The name of the revision to restore.
+   * Format: projects/{project}/sheets/{sheet}/revisions/{revision}
+   */
+  name: string;
+}
+
+export interface SheetRevision {
+  /**
+   * The name of the revision.
+   * Format: projects/{project}/sheets/{sheet}/revisions/{revision}
+   */
+  name: string;
+  /**
+   * The creator of the revision, who made the change.
+   * Format: users/{email}
+   */
+  creator: string;
+  createTime:
+    | Date
+    | undefined;
+  /** The content of the sheet at the revision. */
+  content: Uint8Array;
+  /** content_size is the full size of the content, may not match the size of the `content` field. */
+  contentSize: number;
+}
+
 function createBaseCreateSheetRequest(): CreateSheetRequest {
   return { parent: "", sheet: undefined };
 }
@@ -1233,198 +1330,720 @@ export const Sheet = {
   },
 };
 
-export type SheetServiceDefinition = typeof SheetServiceDefinition;
-export const SheetServiceDefinition = {
-  name: "SheetService",
-  fullName: "bytebase.v1.SheetService",
-  methods: {
-    createSheet: {
-      name: "CreateSheet",
-      requestType: CreateSheetRequest,
-      requestStream: false,
-      responseType: Sheet,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          8410: [new Uint8Array([12, 112, 97, 114, 101, 110, 116, 44, 115, 104, 101, 101, 116])],
-          578365826: [
-            new Uint8Array([
-              39,
-              58,
-              5,
-              115,
-              104,
-              101,
-              101,
-              116,
-              34,
-              30,
-              47,
-              118,
-              49,
-              47,
-              123,
-              112,
-              97,
-              114,
-              101,
-              110,
-              116,
-              61,
-              112,
-              114,
-              111,
-              106,
-              101,
-              99,
-              116,
-              115,
-              47,
-              42,
-              125,
-              47,
-              115,
-              104,
-              101,
-              101,
-              116,
-              115,
-            ]),
-          ],
-        },
-      },
-    },
-    getSheet: {
-      name: "GetSheet",
-      requestType: GetSheetRequest,
-      requestStream: false,
-      responseType: Sheet,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          8410: [new Uint8Array([4, 110, 97, 109, 101])],
-          578365826: [
-            new Uint8Array([
-              32,
-              18,
-              30,
-              47,
-              118,
-              49,
-              47,
-              123,
-              110,
-              97,
-              109,
-              101,
-              61,
-              112,
-              114,
-              111,
-              106,
-              101,
-              99,
-              116,
-              115,
-              47,
-              42,
-              47,
-              115,
-              104,
-              101,
-              101,
-              116,
-              115,
-              47,
-              42,
-              125,
-            ]),
-          ],
-        },
-      },
-    },
-    searchSheets: {
-      name: "SearchSheets",
-      requestType: SearchSheetsRequest,
-      requestStream: false,
-      responseType: SearchSheetsResponse,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          578365826: [
-            new Uint8Array([
-              39,
-              18,
-              37,
-              47,
-              118,
-              49,
-              47,
-              123,
-              112,
-              97,
-              114,
-              101,
-              110,
-              116,
-              61,
-              112,
-              114,
-              111,
-              106,
-              101,
-              99,
-              116,
-              115,
-              47,
-              42,
-              125,
-              47,
-              115,
-              104,
-              101,
-              101,
-              116,
-              115,
-              58,
-              115,
-              101,
-              97,
-              114,
-              99,
-              104,
-            ]),
-          ],
-        },
-      },
-    },
-    updateSheet: {
-      name: "UpdateSheet",
-      requestType: UpdateSheetRequest,
-      requestStream: false,
-      responseType: Sheet,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          8410: [new Uint8Array([17, 115, 104, 101, 101, 116, 44, 117, 112, 100, 97, 116, 101, 95, 109, 97, 115, 107])],
-          578365826: [
-            new Uint8Array([
-              45,
-              58,
-              5,
-              115,
-              104,
-              101,
-              101,
-              116,
-              50,
-              36,
-              47,
-              118,
-              49,
-              47,
-              123,
-              115,
+function createBaseListSheetRevisionsRequest(): ListSheetRevisionsRequest {
+  return { parent: "", pageSize: 0, pageToken: "" };
+}
+
+export const ListSheetRevisionsRequest = {
+  encode(message: ListSheetRevisionsRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.parent !== "") {
+      writer.uint32(10).string(message.parent);
+    }
+    if (message.pageSize !== 0) {
+      writer.uint32(16).int32(message.pageSize);
+    }
+    if (message.pageToken !== "") {
+      writer.uint32(26).string(message.pageToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListSheetRevisionsRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListSheetRevisionsRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.parent = reader.string();
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.pageSize = reader.int32();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.pageToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListSheetRevisionsRequest {
+    return {
+      parent: isSet(object.parent) ? String(object.parent) : "",
+      pageSize: isSet(object.pageSize) ? Number(object.pageSize) : 0,
+      pageToken: isSet(object.pageToken) ? String(object.pageToken) : "",
+    };
+  },
+
+  toJSON(message: ListSheetRevisionsRequest): unknown {
+    const obj: any = {};
+    message.parent !== undefined && (obj.parent = message.parent);
+    message.pageSize !== undefined && (obj.pageSize = Math.round(message.pageSize));
+    message.pageToken !== undefined && (obj.pageToken = message.pageToken);
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListSheetRevisionsRequest>): ListSheetRevisionsRequest {
+    return ListSheetRevisionsRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListSheetRevisionsRequest>): ListSheetRevisionsRequest {
+    const message = createBaseListSheetRevisionsRequest();
+    message.parent = object.parent ?? "";
+    message.pageSize = object.pageSize ?? 0;
+    message.pageToken = object.pageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseListSheetRevisionsResponse(): ListSheetRevisionsResponse {
+  return { revisions: [], nextPageToken: "" };
+}
+
+export const ListSheetRevisionsResponse = {
+  encode(message: ListSheetRevisionsResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    for (const v of message.revisions) {
+      SheetRevision.encode(v!, writer.uint32(10).fork()).ldelim();
+    }
+    if (message.nextPageToken !== "") {
+      writer.uint32(18).string(message.nextPageToken);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ListSheetRevisionsResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListSheetRevisionsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.revisions.push(SheetRevision.decode(reader, reader.uint32()));
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.nextPageToken = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListSheetRevisionsResponse {
+    return {
+      revisions: Array.isArray(object?.revisions) ? object.revisions.map((e: any) => SheetRevision.fromJSON(e)) : [],
+      nextPageToken: isSet(object.nextPageToken) ? String(object.nextPageToken) : "",
+    };
+  },
+
+  toJSON(message: ListSheetRevisionsResponse): unknown {
+    const obj: any = {};
+    if (message.revisions) {
+      obj.revisions = message.revisions.map((e) => e ? SheetRevision.toJSON(e) : undefined);
+    } else {
+      obj.revisions = [];
+    }
+    message.nextPageToken !== undefined && (obj.nextPageToken = message.nextPageToken);
+    return obj;
+  },
+
+  create(base?: DeepPartial<ListSheetRevisionsResponse>): ListSheetRevisionsResponse {
+    return ListSheetRevisionsResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ListSheetRevisionsResponse>): ListSheetRevisionsResponse {
+    const message = createBaseListSheetRevisionsResponse();
+    message.revisions = object.revisions?.map((e) => SheetRevision.fromPartial(e)) || [];
+    message.nextPageToken = object.nextPageToken ?? "";
+    return message;
+  },
+};
+
+function createBaseGetSheetRevisionRequest(): GetSheetRevisionRequest {
+  return { name: "" };
+}
+
+export const GetSheetRevisionRequest = {
+  encode(message: GetSheetRevisionRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetSheetRevisionRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetSheetRevisionRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetSheetRevisionRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: GetSheetRevisionRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetSheetRevisionRequest>): GetSheetRevisionRequest {
+    return GetSheetRevisionRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<GetSheetRevisionRequest>): GetSheetRevisionRequest {
+    const message = createBaseGetSheetRevisionRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseDiffSheetRevisionsRequest(): DiffSheetRevisionsRequest {
+  return { name: "", baseRevision: "", targetRevision: "" };
+}
+
+export const DiffSheetRevisionsRequest = {
+  encode(message: DiffSheetRevisionsRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.baseRevision !== "") {
+      writer.uint32(18).string(message.baseRevision);
+    }
+    if (message.targetRevision !== "") {
+      writer.uint32(26).string(message.targetRevision);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DiffSheetRevisionsRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDiffSheetRevisionsRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.baseRevision = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.targetRevision = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DiffSheetRevisionsRequest {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      baseRevision: isSet(object.baseRevision) ? String(object.baseRevision) : "",
+      targetRevision: isSet(object.targetRevision) ? String(object.targetRevision) : "",
+    };
+  },
+
+  toJSON(message: DiffSheetRevisionsRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.baseRevision !== undefined && (obj.baseRevision = message.baseRevision);
+    message.targetRevision !== undefined && (obj.targetRevision = message.targetRevision);
+    return obj;
+  },
+
+  create(base?: DeepPartial<DiffSheetRevisionsRequest>): DiffSheetRevisionsRequest {
+    return DiffSheetRevisionsRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<DiffSheetRevisionsRequest>): DiffSheetRevisionsRequest {
+    const message = createBaseDiffSheetRevisionsRequest();
+    message.name = object.name ?? "";
+    message.baseRevision = object.baseRevision ?? "";
+    message.targetRevision = object.targetRevision ?? "";
+    return message;
+  },
+};
+
+function createBaseDiffSheetRevisionsResponse(): DiffSheetRevisionsResponse {
+  return { diff: "" };
+}
+
+export const DiffSheetRevisionsResponse = {
+  encode(message: DiffSheetRevisionsResponse, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.diff !== "") {
+      writer.uint32(10).string(message.diff);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DiffSheetRevisionsResponse {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDiffSheetRevisionsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.diff = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DiffSheetRevisionsResponse {
+    return { diff: isSet(object.diff) ? String(object.diff) : "" };
+  },
+
+  toJSON(message: DiffSheetRevisionsResponse): unknown {
+    const obj: any = {};
+    message.diff !== undefined && (obj.diff = message.diff);
+    return obj;
+  },
+
+  create(base?: DeepPartial<DiffSheetRevisionsResponse>): DiffSheetRevisionsResponse {
+    return DiffSheetRevisionsResponse.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<DiffSheetRevisionsResponse>): DiffSheetRevisionsResponse {
+    const message = createBaseDiffSheetRevisionsResponse();
+    message.diff = object.diff ?? "";
+    return message;
+  },
+};
+
+function createBaseRestoreSheetRevisionRequest(): RestoreSheetRevisionRequest {
+  return { name: "" };
+}
+
+export const RestoreSheetRevisionRequest = {
+  encode(message: RestoreSheetRevisionRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RestoreSheetRevisionRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRestoreSheetRevisionRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RestoreSheetRevisionRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: RestoreSheetRevisionRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<RestoreSheetRevisionRequest>): RestoreSheetRevisionRequest {
+    return RestoreSheetRevisionRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RestoreSheetRevisionRequest>): RestoreSheetRevisionRequest {
+    const message = createBaseRestoreSheetRevisionRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseSheetRevision(): SheetRevision {
+  return { name: "", creator: "", createTime: undefined, content: new Uint8Array(0), contentSize: 0 };
+}
+
+export const SheetRevision = {
+  encode(message: SheetRevision, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.creator !== "") {
+      writer.uint32(18).string(message.creator);
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(26).fork()).ldelim();
+    }
+    if (message.content.length !== 0) {
+      writer.uint32(34).bytes(message.content);
+    }
+    if (message.contentSize !== 0) {
+      writer.uint32(40).int64(message.contentSize);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): SheetRevision {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSheetRevision();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.creator = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.content = reader.bytes();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.contentSize = longToNumber(reader.int64() as Long);
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SheetRevision {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      creator: isSet(object.creator) ? String(object.creator) : "",
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      content: isSet(object.content) ? bytesFromBase64(object.content) : new Uint8Array(0),
+      contentSize: isSet(object.contentSize) ? Number(object.contentSize) : 0,
+    };
+  },
+
+  toJSON(message: SheetRevision): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.creator !== undefined && (obj.creator = message.creator);
+    message.createTime !== undefined && (obj.createTime = message.createTime.toISOString());
+    message.content !== undefined &&
+      (obj.content = base64FromBytes(message.content !== undefined ? message.content : new Uint8Array(0)));
+    message.contentSize !== undefined && (obj.contentSize = Math.round(message.contentSize));
+    return obj;
+  },
+
+  create(base?: DeepPartial<SheetRevision>): SheetRevision {
+    return SheetRevision.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<SheetRevision>): SheetRevision {
+    const message = createBaseSheetRevision();
+    message.name = object.name ?? "";
+    message.creator = object.creator ?? "";
+    message.createTime = object.createTime ?? undefined;
+    message.content = object.content ?? new Uint8Array(0);
+    message.contentSize = object.contentSize ?? 0;
+    return message;
+  },
+};
+
+export type SheetServiceDefinition = typeof SheetServiceDefinition;
+export const SheetServiceDefinition = {
+  name: "SheetService",
+  fullName: "bytebase.v1.SheetService",
+  methods: {
+    createSheet: {
+      name: "CreateSheet",
+      requestType: CreateSheetRequest,
+      requestStream: false,
+      responseType: Sheet,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([12, 112, 97, 114, 101, 110, 116, 44, 115, 104, 101, 101, 116])],
+          578365826: [
+            new Uint8Array([
+              39,
+              58,
+              5,
+              115,
+              104,
+              101,
+              101,
+              116,
+              34,
+              30,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              125,
+              47,
+              115,
+              104,
+              101,
+              101,
+              116,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    getSheet: {
+      name: "GetSheet",
+      requestType: GetSheetRequest,
+      requestStream: false,
+      responseType: Sheet,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              32,
+              18,
+              30,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              115,
+              104,
+              101,
+              101,
+              116,
+              115,
+              47,
+              42,
+              125,
+            ]),
+          ],
+        },
+      },
+    },
+    searchSheets: {
+      name: "SearchSheets",
+      requestType: SearchSheetsRequest,
+      requestStream: false,
+      responseType: SearchSheetsResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              39,
+              18,
+              37,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              125,
+              47,
+              115,
+              104,
+              101,
+              101,
+              116,
+              115,
+              58,
+              115,
+              101,
+              97,
+              114,
+              99,
+              104,
+            ]),
+          ],
+        },
+      },
+    },
+    updateSheet: {
+      name: "UpdateSheet",
+      requestType: UpdateSheetRequest,
+      requestStream: false,
+      responseType: Sheet,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([17, 115, 104, 101, 101, 116, 44, 117, 112, 100, 97, 116, 101, 95, 109, 97, 115, 107])],
+          578365826: [
+            new Uint8Array([
+              45,
+              58,
+              5,
+              115,
+              104,
+              101,
+              101,
+              116,
+              50,
+              36,
+              47,
+              118,
+              49,
+              47,
+              123,
+              115,
               104,
               101,
               101,
@@ -1669,6 +2288,268 @@ export const SheetServiceDefinition = {
         },
       },
     },
+    listSheetRevisions: {
+      name: "ListSheetRevisions",
+      requestType: ListSheetRevisionsRequest,
+      requestStream: false,
+      responseType: ListSheetRevisionsResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([6, 112, 97, 114, 101, 110, 116])],
+          578365826: [
+            new Uint8Array([
+              44,
+              18,
+              42,
+              47,
+              118,
+              49,
+              47,
+              123,
+              112,
+              97,
+              114,
+              101,
+              110,
+              116,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              115,
+              104,
+              101,
+              101,
+              116,
+              115,
+              47,
+              42,
+              125,
+              47,
+              114,
+              101,
+              118,
+              105,
+              115,
+              105,
+              111,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    getSheetRevision: {
+      name: "GetSheetRevision",
+      requestType: GetSheetRevisionRequest,
+      requestStream: false,
+      responseType: SheetRevision,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              44,
+              18,
+              42,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              115,
+              104,
+              101,
+              101,
+              116,
+              115,
+              47,
+              42,
+              47,
+              114,
+              101,
+              118,
+              105,
+              115,
+              105,
+              111,
+              110,
+              115,
+              47,
+              42,
+              125,
+            ]),
+          ],
+        },
+      },
+    },
+    /** DiffSheetRevisions returns the unified diff between two revisions of a sheet. */
+    diffSheetRevisions: {
+      name: "DiffSheetRevisions",
+      requestType: DiffSheetRevisionsRequest,
+      requestStream: false,
+      responseType: DiffSheetRevisionsResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              46,
+              18,
+              44,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              115,
+              104,
+              101,
+              101,
+              116,
+              115,
+              47,
+              42,
+              125,
+              58,
+              100,
+              105,
+              102,
+              102,
+              82,
+              101,
+              118,
+              105,
+              115,
+              105,
+              111,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    /**
+     * RestoreSheetRevision restores the content of the sheet to a revision.
+     * The restored content is appended as a new revision, so the history is never rewritten.
+     */
+    restoreSheetRevision: {
+      name: "RestoreSheetRevision",
+      requestType: RestoreSheetRevisionRequest,
+      requestStream: false,
+      responseType: Sheet,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              55,
+              58,
+              1,
+              42,
+              34,
+              50,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              112,
+              114,
+              111,
+              106,
+              101,
+              99,
+              116,
+              115,
+              47,
+              42,
+              47,
+              115,
+              104,
+              101,
+              101,
+              116,
+              115,
+              47,
+              42,
+              47,
+              114,
+              101,
+              118,
+              105,
+              115,
+              105,
+              111,
+              110,
+              115,
+              47,
+              42,
+              125,
+              58,
+              114,
+              101,
+              115,
+              116,
+              111,
+              114,
+              101,
+            ]),
+          ],
+        },
+      },
+    },
   },
 } as const;
 
@@ -2,12 +2,54 @@
 import Long from "long";
 import _m0 from "protobufjs/minimal";
 import { Duration } from "../google/protobuf/duration";
+import { Empty } from "../google/protobuf/empty";
 import { NullValue, nullValueFromJSON, nullValueToJSON, Value } from "../google/protobuf/struct";
+import { Timestamp } from "../google/protobuf/timestamp";
 import { Engine, engineFromJSON, engineToJSON, ExportFormat, exportFormatFromJSON, exportFormatToJSON } from "./common";
 import { DatabaseMetadata } from "./database_service";
 
 export const protobufPackage = "bytebase.v1";
 
+export enum ExportCompression {
+  /** EXPORT_COMPRESSION_UNSPECIFIED - The export file is not compressed. */
+  EXPORT_COMPRESSION_UNSPECIFIED = 0,
+  GZIP = 1,
+  ZIP = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function exportCompressionFromJSON(object: any): ExportCompression {
+  switch (object) {
+    case 0:
+    case "EXPORT_COMPRESSION_UNSPECIFIED":
+      return ExportCompression.EXPORT_COMPRESSION_UNSPECIFIED;
+    case 1:
+    case "GZIP":
+      return ExportCompression.GZIP;
+    case 2:
+    case "ZIP":
+      return ExportCompression.ZIP;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return ExportCompression.UNRECOGNIZED;
+  }
+}
+
+export function exportCompressionToJSON(object: ExportCompression): string {
+  switch (object) {
+    case ExportCompression.EXPORT_COMPRESSION_UNSPECIFIED:
+      return "EXPORT_COMPRESSION_UNSPECIFIED";
+    case ExportCompression.GZIP:
+      return "GZIP";
+    case ExportCompression.ZIP:
+      return "ZIP";
+    case ExportCompression.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export interface DifferPreviewRequest {
   engine: Engine;
   oldSchema: string;
@@ -35,7 +77,16 @@ export interface AdminExecuteRequest {
   /** The maximum number of rows to return. */
   limit: number;
   /** The timeout for the request. */
-  timeout: Duration | undefined;
+  timeout:
+    | Duration
+    | undefined;
+  /**
+   * The session to execute the statement in.
+   * The statement runs on the connection pinned by the session, so that it can run in the open transaction of the session.
+   * The name and connection_database must match the session.
+   * Format: sessions/{session}
+   */
+  session: string;
 }
 
 export interface AdminExecuteResponse {
@@ -90,7 +141,16 @@ export interface QueryRequest {
   /** The maximum number of rows to return. */
   limit: number;
   /** The timeout for the request. */
-  timeout: Duration | undefined;
+  timeout:
+    | Duration
+    | undefined;
+  /**
+   * The session to run the query in.
+   * The query runs on the connection pinned by the session, so that it sees the uncommitted changes of the open transaction of the session.
+   * The name and connection_database must match the session.
+   * Format: sessions/{session}
+   */
+  session: string;
 }
 
 export interface QueryResponse {
@@ -239,6 +299,243 @@ export interface CheckResponse {
   advices: Advice[];
 }
 
+/**
+ * Session is a SQL Editor session pinning a database connection across requests.
+ * The session is closed after it has been idle for the idle timeout, or the client disconnects from AdminExecute,
+ * and the open transaction of the session is rolled back.
+ */
+export interface Session {
+  /**
+   * The name of the session.
+   * Format: sessions/{session}
+   */
+  name: string;
+  /**
+   * The instance name of the session.
+   * Format: instances/{instance}
+   */
+  instance: string;
+  /**
+   * The connection database name of the session.
+   * For PostgreSQL, it's required.
+   */
+  connectionDatabase: string;
+  /**
+   * The duration after which the idle session is closed.
+   * The default is 10 minutes and the maximum is 1 hour.
+   */
+  idleTimeout: Duration | undefined;
+  transactionState: Session_TransactionState;
+  createTime:
+    | Date
+    | undefined;
+  /** The time the last statement ran in the session. */
+  lastActiveTime: Date | undefined;
+}
+
+export enum Session_TransactionState {
+  TRANSACTION_STATE_UNSPECIFIED = 0,
+  /** AUTOCOMMIT - No transaction is open, the statements are committed automatically. */
+  AUTOCOMMIT = 1,
+  /** IN_TRANSACTION - A transaction is open, it must be committed or rolled back explicitly. */
+  IN_TRANSACTION = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function session_TransactionStateFromJSON(object: any): Session_TransactionState {
+  switch (object) {
+    case 0:
+    case "TRANSACTION_STATE_UNSPECIFIED":
+      return Session_TransactionState.TRANSACTION_STATE_UNSPECIFIED;
+    case 1:
+    case "AUTOCOMMIT":
+      return Session_TransactionState.AUTOCOMMIT;
+    case 2:
+    case "IN_TRANSACTION":
+      return Session_TransactionState.IN_TRANSACTION;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return Session_TransactionState.UNRECOGNIZED;
+  }
+}
+
+export function session_TransactionStateToJSON(object: Session_TransactionState): string {
+  switch (object) {
+    case Session_TransactionState.TRANSACTION_STATE_UNSPECIFIED:
+      return "TRANSACTION_STATE_UNSPECIFIED";
+    case Session_TransactionState.AUTOCOMMIT:
+      return "AUTOCOMMIT";
+    case Session_TransactionState.IN_TRANSACTION:
+      return "IN_TRANSACTION";
+    case Session_TransactionState.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface CreateSessionRequest {
+  /** The session to create. */
+  session: Session | undefined;
+}
+
+export interface GetSessionRequest {
+  /**
+   * The name of the session.
+   * Format: sessions/{session}
+   */
+  name: string;
+}
+
+export interface DeleteSessionRequest {
+  /**
+   * The name of the session to close, the open transaction of the session is rolled back.
+   * Format: sessions/{session}
+   */
+  name: string;
+}
+
+export interface BeginTransactionRequest {
+  /**
+   * The name of the session.
+   * Format: sessions/{session}
+   */
+  name: string;
+}
+
+export interface CommitTransactionRequest {
+  /**
+   * The name of the session.
+   * Format: sessions/{session}
+   */
+  name: string;
+}
+
+export interface RollbackTransactionRequest {
+  /**
+   * The name of the session.
+   * Format: sessions/{session}
+   */
+  name: string;
+}
+
+/**
+ * ExportJob is a long-running export of a query result.
+ * The rows are streamed from the database into the export file in the storage, so that the export isn't limited by the memory of the server.
+ * The export file is deleted after the download link expires.
+ */
+export interface ExportJob {
+  /**
+   * The name of the export job.
+   * Format: exportJobs/{exportJob}
+   */
+  name: string;
+  /**
+   * The instance name to execute the query against.
+   * Format: instances/{instance}
+   */
+  instance: string;
+  /** The connection database name to execute the query against. */
+  connectionDatabase: string;
+  /** The SQL statement to execute. */
+  statement: string;
+  /** The export format. */
+  format: ExportFormat;
+  /** The compression of the export file. */
+  compression: ExportCompression;
+  state: ExportJob_State;
+  /** The number of rows exported so far. */
+  rowCount: number;
+  /** The number of bytes written to the export file so far. */
+  byteCount: number;
+  /** The error of the failed export job. */
+  error: string;
+  createTime: Date | undefined;
+  finishTime:
+    | Date
+    | undefined;
+  /** The link to download the export file without authentication, only set when the export job is done. */
+  downloadUrl: string;
+  /** The time the download link expires. */
+  expireTime: Date | undefined;
+}
+
+export enum ExportJob_State {
+  STATE_UNSPECIFIED = 0,
+  RUNNING = 1,
+  DONE = 2,
+  FAILED = 3,
+  CANCELED = 4,
+  UNRECOGNIZED = -1,
+}
+
+export function exportJob_StateFromJSON(object: any): ExportJob_State {
+  switch (object) {
+    case 0:
+    case "STATE_UNSPECIFIED":
+      return ExportJob_State.STATE_UNSPECIFIED;
+    case 1:
+    case "RUNNING":
+      return ExportJob_State.RUNNING;
+    case 2:
+    case "DONE":
+      return ExportJob_State.DONE;
+    case 3:
+    case "FAILED":
+      return ExportJob_State.FAILED;
+    case 4:
+    case "CANCELED":
+      return ExportJob_State.CANCELED;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return ExportJob_State.UNRECOGNIZED;
+  }
+}
+
+export function exportJob_StateToJSON(object: ExportJob_State): string {
+  switch (object) {
+    case ExportJob_State.STATE_UNSPECIFIED:
+      return "STATE_UNSPECIFIED";
+    case ExportJob_State.RUNNING:
+      return "RUNNING";
+    case ExportJob_State.DONE:
+      return "DONE";
+    case ExportJob_State.FAILED:
+      return "FAILED";
+    case ExportJob_State.CANCELED:
+      return "CANCELED";
+    case ExportJob_State.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export interface CreateExportJobRequest {
+  /** The export to run in the background. The format is required. */
+  export:
+    | ExportRequest
+    | undefined;
+  /** The compression of the export file. */
+  compression: ExportCompression;
+}
+
+export interface GetExportJobRequest {
+  /**
+   * The name of the export job.
+   * Format: exportJobs/{exportJob}
+   */
+  name: string;
+}
+
+export interface CancelExportJobRequest {
+  /**
+   * The name of the export job to cancel, the partially written export file is deleted.
+   * Format: exportJobs/{exportJob}
+   */
+  name: string;
+}
+
 function createBaseDifferPreviewRequest(): DifferPreviewRequest {
   return { engine: 0, oldSchema: "", newMetadata: undefined };
 }
@@ -383,7 +680,7 @@ export const DifferPreviewResponse = {
 };
 
 function createBaseAdminExecuteRequest(): AdminExecuteRequest {
-  return { name: "", connectionDatabase: "", statement: "", limit: 0, timeout: undefined };
+  return { name: "", connectionDatabase: "", statement: "", limit: 0, timeout: undefined, session: "" };
 }
 
 export const AdminExecuteRequest = {
@@ -403,6 +700,9 @@ export const AdminExecuteRequest = {
     if (message.timeout !== undefined) {
       Duration.encode(message.timeout, writer.uint32(42).fork()).ldelim();
     }
+    if (message.session !== "") {
+      writer.uint32(50).string(message.session);
+    }
     return writer;
   },
 
@@ -448,6 +748,13 @@ export const AdminExecuteRequest = {
 
           message.timeout = Duration.decode(reader, reader.uint32());
           continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.session = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -464,6 +771,7 @@ export const AdminExecuteRequest = {
       statement: isSet(object.statement) ? String(object.statement) : "",
       limit: isSet(object.limit) ? Number(object.limit) : 0,
       timeout: isSet(object.timeout) ? Duration.fromJSON(object.timeout) : undefined,
+      session: isSet(object.session) ? String(object.session) : "",
     };
   },
 
@@ -474,6 +782,7 @@ export const AdminExecuteRequest = {
     message.statement !== undefined && (obj.statement = message.statement);
     message.limit !== undefined && (obj.limit = Math.round(message.limit));
     message.timeout !== undefined && (obj.timeout = message.timeout ? Duration.toJSON(message.timeout) : undefined);
+    message.session !== undefined && (obj.session = message.session);
     return obj;
   },
 
@@ -490,6 +799,7 @@ export const AdminExecuteRequest = {
     message.timeout = (object.timeout !== undefined && object.timeout !== null)
       ? Duration.fromPartial(object.timeout)
       : undefined;
+    message.session = object.session ?? "";
     return message;
   },
 };
@@ -735,7 +1045,7 @@ export const ExportResponse = {
 };
 
 function createBaseQueryRequest(): QueryRequest {
-  return { name: "", connectionDatabase: "", statement: "", limit: 0, timeout: undefined };
+  return { name: "", connectionDatabase: "", statement: "", limit: 0, timeout: undefined, session: "" };
 }
 
 export const QueryRequest = {
@@ -755,6 +1065,9 @@ export const QueryRequest = {
     if (message.timeout !== undefined) {
       Duration.encode(message.timeout, writer.uint32(42).fork()).ldelim();
     }
+    if (message.session !== "") {
+      writer.uint32(50).string(message.session);
+    }
     return writer;
   },
 
@@ -800,6 +1113,13 @@ export const QueryRequest = {
 
           message.timeout = Duration.decode(reader, reader.uint32());
           continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.session = reader.string();
+          continue;
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -816,6 +1136,7 @@ export const QueryRequest = {
       statement: isSet(object.statement) ? String(object.statement) : "",
       limit: isSet(object.limit) ? Number(object.limit) : 0,
       timeout: isSet(object.timeout) ? Duration.fromJSON(object.timeout) : undefined,
+      session: isSet(object.session) ? String(object.session) : "",
     };
   },
 
@@ -826,6 +1147,7 @@ export const QueryRequest = {
     message.statement !== undefined && (obj.statement = message.statement);
     message.limit !== undefined && (obj.limit = Math.round(message.limit));
     message.timeout !== undefined && (obj.timeout = message.timeout ? Duration.toJSON(message.timeout) : undefined);
+    message.session !== undefined && (obj.session = message.session);
     return obj;
   },
 
@@ -842,6 +1164,7 @@ export const QueryRequest = {
     message.timeout = (object.timeout !== undefined && object.timeout !== null)
       ? Duration.fromPartial(object.timeout)
       : undefined;
+    message.session = object.session ?? "";
     return message;
   },
 };
@@ -1826,190 +2149,1510 @@ export const CheckResponse = {
   },
 };
 
-export type SQLServiceDefinition = typeof SQLServiceDefinition;
-export const SQLServiceDefinition = {
-  name: "SQLService",
-  fullName: "bytebase.v1.SQLService",
-  methods: {
-    pretty: {
-      name: "Pretty",
-      requestType: PrettyRequest,
-      requestStream: false,
-      responseType: PrettyResponse,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          578365826: [
-            new Uint8Array([19, 58, 1, 42, 34, 14, 47, 118, 49, 47, 115, 113, 108, 47, 112, 114, 101, 116, 116, 121]),
-          ],
-        },
-      },
-    },
-    query: {
-      name: "Query",
-      requestType: QueryRequest,
-      requestStream: false,
-      responseType: QueryResponse,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          578365826: [
-            new Uint8Array([
-              33,
-              58,
-              1,
-              42,
-              34,
-              28,
-              47,
-              118,
-              49,
-              47,
-              123,
-              110,
-              97,
-              109,
-              101,
-              61,
-              105,
-              110,
-              115,
-              116,
-              97,
-              110,
-              99,
-              101,
-              115,
-              47,
-              42,
-              125,
-              58,
-              113,
-              117,
-              101,
-              114,
-              121,
-            ]),
-          ],
-        },
-      },
-    },
-    export: {
-      name: "Export",
-      requestType: ExportRequest,
-      requestStream: false,
-      responseType: ExportResponse,
-      responseStream: false,
-      options: {
-        _unknownFields: {
-          578365826: [
-            new Uint8Array([
-              34,
-              58,
-              1,
-              42,
-              34,
-              29,
-              47,
-              118,
-              49,
-              47,
-              123,
-              110,
-              97,
-              109,
-              101,
-              61,
-              105,
-              110,
-              115,
-              116,
-              97,
-              110,
-              99,
-              101,
-              115,
-              47,
-              42,
-              125,
+function createBaseSession(): Session {
+  return {
+    name: "",
+    instance: "",
+    connectionDatabase: "",
+    idleTimeout: undefined,
+    transactionState: 0,
+    createTime: undefined,
+    lastActiveTime: undefined,
+  };
+}
+
+export const Session = {
+  encode(message: Session, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.instance !== "") {
+      writer.uint32(18).string(message.instance);
+    }
+    if (message.connectionDatabase !== "") {
+      writer.uint32(26).string(message.connectionDatabase);
+    }
+    if (message.idleTimeout !== undefined) {
+      Duration.encode(message.idleTimeout, writer.uint32(34).fork()).ldelim();
+    }
+    if (message.transactionState !== 0) {
+      writer.uint32(40).int32(message.transactionState);
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(50).fork()).ldelim();
+    }
+    if (message.lastActiveTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.lastActiveTime), writer.uint32(58).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): Session {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSession();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.instance = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.connectionDatabase = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.idleTimeout = Duration.decode(reader, reader.uint32());
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.transactionState = reader.int32() as any;
+          continue;
+        case 6:
+          if (tag !== 50) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 7:
+          if (tag !== 58) {
+            break;
+          }
+
+          message.lastActiveTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Session {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      instance: isSet(object.instance) ? String(object.instance) : "",
+      connectionDatabase: isSet(object.connectionDatabase) ? String(object.connectionDatabase) : "",
+      idleTimeout: isSet(object.idleTimeout) ? Duration.fromJSON(object.idleTimeout) : undefined,
+      transactionState: isSet(object.transactionState) ? session_TransactionStateFromJSON(object.transactionState) : 0,
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      lastActiveTime: isSet(object.lastActiveTime) ? fromJsonTimestamp(object.lastActiveTime) : undefined,
+    };
+  },
+
+  toJSON(message: Session): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.instance !== undefined && (obj.instance = message.instance);
+    message.connectionDatabase !== undefined && (obj.connectionDatabase = message.connectionDatabase);
+    message.idleTimeout !== undefined &&
+      (obj.idleTimeout = message.idleTimeout ? Duration.toJSON(message.idleTimeout) : undefined);
+    message.transactionState !== undefined &&
+      (obj.transactionState = session_TransactionStateToJSON(message.transactionState));
+    message.createTime !== undefined && (obj.createTime = message.createTime.toISOString());
+    message.lastActiveTime !== undefined && (obj.lastActiveTime = message.lastActiveTime.toISOString());
+    return obj;
+  },
+
+  create(base?: DeepPartial<Session>): Session {
+    return Session.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<Session>): Session {
+    const message = createBaseSession();
+    message.name = object.name ?? "";
+    message.instance = object.instance ?? "";
+    message.connectionDatabase = object.connectionDatabase ?? "";
+    message.idleTimeout = (object.idleTimeout !== undefined && object.idleTimeout !== null)
+      ? Duration.fromPartial(object.idleTimeout)
+      : undefined;
+    message.transactionState = object.transactionState ?? 0;
+    message.createTime = object.createTime ?? undefined;
+    message.lastActiveTime = object.lastActiveTime ?? undefined;
+    return message;
+  },
+};
+
+function createBaseCreateSessionRequest(): CreateSessionRequest {
+  return { session: undefined };
+}
+
+export const CreateSessionRequest = {
+  encode(message: CreateSessionRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.session !== undefined) {
+      Session.encode(message.session, writer.uint32(10).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CreateSessionRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateSessionRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.session = Session.decode(reader, reader.uint32());
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateSessionRequest {
+    return { session: isSet(object.session) ? Session.fromJSON(object.session) : undefined };
+  },
+
+  toJSON(message: CreateSessionRequest): unknown {
+    const obj: any = {};
+    message.session !== undefined && (obj.session = message.session ? Session.toJSON(message.session) : undefined);
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateSessionRequest>): CreateSessionRequest {
+    return CreateSessionRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<CreateSessionRequest>): CreateSessionRequest {
+    const message = createBaseCreateSessionRequest();
+    message.session = (object.session !== undefined && object.session !== null)
+      ? Session.fromPartial(object.session)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseGetSessionRequest(): GetSessionRequest {
+  return { name: "" };
+}
+
+export const GetSessionRequest = {
+  encode(message: GetSessionRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetSessionRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetSessionRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetSessionRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: GetSessionRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetSessionRequest>): GetSessionRequest {
+    return GetSessionRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<GetSessionRequest>): GetSessionRequest {
+    const message = createBaseGetSessionRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseDeleteSessionRequest(): DeleteSessionRequest {
+  return { name: "" };
+}
+
+export const DeleteSessionRequest = {
+  encode(message: DeleteSessionRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): DeleteSessionRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteSessionRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DeleteSessionRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: DeleteSessionRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<DeleteSessionRequest>): DeleteSessionRequest {
+    return DeleteSessionRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<DeleteSessionRequest>): DeleteSessionRequest {
+    const message = createBaseDeleteSessionRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseBeginTransactionRequest(): BeginTransactionRequest {
+  return { name: "" };
+}
+
+export const BeginTransactionRequest = {
+  encode(message: BeginTransactionRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): BeginTransactionRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseBeginTransactionRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): BeginTransactionRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: BeginTransactionRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<BeginTransactionRequest>): BeginTransactionRequest {
+    return BeginTransactionRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<BeginTransactionRequest>): BeginTransactionRequest {
+    const message = createBaseBeginTransactionRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseCommitTransactionRequest(): CommitTransactionRequest {
+  return { name: "" };
+}
+
+export const CommitTransactionRequest = {
+  encode(message: CommitTransactionRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CommitTransactionRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCommitTransactionRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CommitTransactionRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: CommitTransactionRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<CommitTransactionRequest>): CommitTransactionRequest {
+    return CommitTransactionRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<CommitTransactionRequest>): CommitTransactionRequest {
+    const message = createBaseCommitTransactionRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseRollbackTransactionRequest(): RollbackTransactionRequest {
+  return { name: "" };
+}
+
+export const RollbackTransactionRequest = {
+  encode(message: RollbackTransactionRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): RollbackTransactionRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRollbackTransactionRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RollbackTransactionRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: RollbackTransactionRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<RollbackTransactionRequest>): RollbackTransactionRequest {
+    return RollbackTransactionRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<RollbackTransactionRequest>): RollbackTransactionRequest {
+    const message = createBaseRollbackTransactionRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseExportJob(): ExportJob {
+  return {
+    name: "",
+    instance: "",
+    connectionDatabase: "",
+    statement: "",
+    format: 0,
+    compression: 0,
+    state: 0,
+    rowCount: 0,
+    byteCount: 0,
+    error: "",
+    createTime: undefined,
+    finishTime: undefined,
+    downloadUrl: "",
+    expireTime: undefined,
+  };
+}
+
+export const ExportJob = {
+  encode(message: ExportJob, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.instance !== "") {
+      writer.uint32(18).string(message.instance);
+    }
+    if (message.connectionDatabase !== "") {
+      writer.uint32(26).string(message.connectionDatabase);
+    }
+    if (message.statement !== "") {
+      writer.uint32(34).string(message.statement);
+    }
+    if (message.format !== 0) {
+      writer.uint32(40).int32(message.format);
+    }
+    if (message.compression !== 0) {
+      writer.uint32(48).int32(message.compression);
+    }
+    if (message.state !== 0) {
+      writer.uint32(56).int32(message.state);
+    }
+    if (message.rowCount !== 0) {
+      writer.uint32(64).int64(message.rowCount);
+    }
+    if (message.byteCount !== 0) {
+      writer.uint32(72).int64(message.byteCount);
+    }
+    if (message.error !== "") {
+      writer.uint32(82).string(message.error);
+    }
+    if (message.createTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.createTime), writer.uint32(90).fork()).ldelim();
+    }
+    if (message.finishTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.finishTime), writer.uint32(98).fork()).ldelim();
+    }
+    if (message.downloadUrl !== "") {
+      writer.uint32(106).string(message.downloadUrl);
+    }
+    if (message.expireTime !== undefined) {
+      Timestamp.encode(toTimestamp(message.expireTime), writer.uint32(114).fork()).ldelim();
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): ExportJob {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseExportJob();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        case 2:
+          if (tag !== 18) {
+            break;
+          }
+
+          message.instance = reader.string();
+          continue;
+        case 3:
+          if (tag !== 26) {
+            break;
+          }
+
+          message.connectionDatabase = reader.string();
+          continue;
+        case 4:
+          if (tag !== 34) {
+            break;
+          }
+
+          message.statement = reader.string();
+          continue;
+        case 5:
+          if (tag !== 40) {
+            break;
+          }
+
+          message.format = reader.int32() as any;
+          continue;
+        case 6:
+          if (tag !== 48) {
+            break;
+          }
+
+          message.compression = reader.int32() as any;
+          continue;
+        case 7:
+          if (tag !== 56) {
+            break;
+          }
+
+          message.state = reader.int32() as any;
+          continue;
+        case 8:
+          if (tag !== 64) {
+            break;
+          }
+
+          message.rowCount = longToNumber(reader.int64() as Long);
+          continue;
+        case 9:
+          if (tag !== 72) {
+            break;
+          }
+
+          message.byteCount = longToNumber(reader.int64() as Long);
+          continue;
+        case 10:
+          if (tag !== 82) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+        case 11:
+          if (tag !== 90) {
+            break;
+          }
+
+          message.createTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 12:
+          if (tag !== 98) {
+            break;
+          }
+
+          message.finishTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+        case 13:
+          if (tag !== 106) {
+            break;
+          }
+
+          message.downloadUrl = reader.string();
+          continue;
+        case 14:
+          if (tag !== 114) {
+            break;
+          }
+
+          message.expireTime = fromTimestamp(Timestamp.decode(reader, reader.uint32()));
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ExportJob {
+    return {
+      name: isSet(object.name) ? String(object.name) : "",
+      instance: isSet(object.instance) ? String(object.instance) : "",
+      connectionDatabase: isSet(object.connectionDatabase) ? String(object.connectionDatabase) : "",
+      statement: isSet(object.statement) ? String(object.statement) : "",
+      format: isSet(object.format) ? exportFormatFromJSON(object.format) : 0,
+      compression: isSet(object.compression) ? exportCompressionFromJSON(object.compression) : 0,
+      state: isSet(object.state) ? exportJob_StateFromJSON(object.state) : 0,
+      rowCount: isSet(object.rowCount) ? Number(object.rowCount) : 0,
+      byteCount: isSet(object.byteCount) ? Number(object.byteCount) : 0,
+      error: isSet(object.error) ? String(object.error) : "",
+      createTime: isSet(object.createTime) ? fromJsonTimestamp(object.createTime) : undefined,
+      finishTime: isSet(object.finishTime) ? fromJsonTimestamp(object.finishTime) : undefined,
+      downloadUrl: isSet(object.downloadUrl) ? String(object.downloadUrl) : "",
+      expireTime: isSet(object.expireTime) ? fromJsonTimestamp(object.expireTime) : undefined,
+    };
+  },
+
+  toJSON(message: ExportJob): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    message.instance !== undefined && (obj.instance = message.instance);
+    message.connectionDatabase !== undefined && (obj.connectionDatabase = message.connectionDatabase);
+    message.statement !== undefined && (obj.statement = message.statement);
+    message.format !== undefined && (obj.format = exportFormatToJSON(message.format));
+    message.compression !== undefined && (obj.compression = exportCompressionToJSON(message.compression));
+    message.state !== undefined && (obj.state = exportJob_StateToJSON(message.state));
+    message.rowCount !== undefined && (obj.rowCount = Math.round(message.rowCount));
+    message.byteCount !== undefined && (obj.byteCount = Math.round(message.byteCount));
+    message.error !== undefined && (obj.error = message.error);
+    message.createTime !== undefined && (obj.createTime = message.createTime.toISOString());
+    message.finishTime !== undefined && (obj.finishTime = message.finishTime.toISOString());
+    message.downloadUrl !== undefined && (obj.downloadUrl = message.downloadUrl);
+    message.expireTime !== undefined && (obj.expireTime = message.expireTime.toISOString());
+    return obj;
+  },
+
+  create(base?: DeepPartial<ExportJob>): ExportJob {
+    return ExportJob.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<ExportJob>): ExportJob {
+    const message = createBaseExportJob();
+    message.name = object.name ?? "";
+    message.instance = object.instance ?? "";
+    message.connectionDatabase = object.connectionDatabase ?? "";
+    message.statement = object.statement ?? "";
+    message.format = object.format ?? 0;
+    message.compression = object.compression ?? 0;
+    message.state = object.state ?? 0;
+    message.rowCount = object.rowCount ?? 0;
+    message.byteCount = object.byteCount ?? 0;
+    message.error = object.error ?? "";
+    message.createTime = object.createTime ?? undefined;
+    message.finishTime = object.finishTime ?? undefined;
+    message.downloadUrl = object.downloadUrl ?? "";
+    message.expireTime = object.expireTime ?? undefined;
+    return message;
+  },
+};
+
+function createBaseCreateExportJobRequest(): CreateExportJobRequest {
+  return { export: undefined, compression: 0 };
+}
+
+export const CreateExportJobRequest = {
+  encode(message: CreateExportJobRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.export !== undefined) {
+      ExportRequest.encode(message.export, writer.uint32(10).fork()).ldelim();
+    }
+    if (message.compression !== 0) {
+      writer.uint32(16).int32(message.compression);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CreateExportJobRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateExportJobRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.export = ExportRequest.decode(reader, reader.uint32());
+          continue;
+        case 2:
+          if (tag !== 16) {
+            break;
+          }
+
+          message.compression = reader.int32() as any;
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateExportJobRequest {
+    return {
+      export: isSet(object.export) ? ExportRequest.fromJSON(object.export) : undefined,
+      compression: isSet(object.compression) ? exportCompressionFromJSON(object.compression) : 0,
+    };
+  },
+
+  toJSON(message: CreateExportJobRequest): unknown {
+    const obj: any = {};
+    message.export !== undefined && (obj.export = message.export ? ExportRequest.toJSON(message.export) : undefined);
+    message.compression !== undefined && (obj.compression = exportCompressionToJSON(message.compression));
+    return obj;
+  },
+
+  create(base?: DeepPartial<CreateExportJobRequest>): CreateExportJobRequest {
+    return CreateExportJobRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<CreateExportJobRequest>): CreateExportJobRequest {
+    const message = createBaseCreateExportJobRequest();
+    message.export = (object.export !== undefined && object.export !== null)
+      ? ExportRequest.fromPartial(object.export)
+      : undefined;
+    message.compression = object.compression ?? 0;
+    return message;
+  },
+};
+
+function createBaseGetExportJobRequest(): GetExportJobRequest {
+  return { name: "" };
+}
+
+export const GetExportJobRequest = {
+  encode(message: GetExportJobRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): GetExportJobRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetExportJobRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetExportJobRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: GetExportJobRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<GetExportJobRequest>): GetExportJobRequest {
+    return GetExportJobRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<GetExportJobRequest>): GetExportJobRequest {
+    const message = createBaseGetExportJobRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseCancelExportJobRequest(): CancelExportJobRequest {
+  return { name: "" };
+}
+
+export const CancelExportJobRequest = {
+  encode(message: CancelExportJobRequest, writer: _m0.Writer = _m0.Writer.create()): _m0.Writer {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: _m0.Reader | Uint8Array, length?: number): CancelExportJobRequest {
+    const reader = input instanceof _m0.Reader ? input : _m0.Reader.create(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCancelExportJobRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1:
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skipType(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CancelExportJobRequest {
+    return { name: isSet(object.name) ? String(object.name) : "" };
+  },
+
+  toJSON(message: CancelExportJobRequest): unknown {
+    const obj: any = {};
+    message.name !== undefined && (obj.name = message.name);
+    return obj;
+  },
+
+  create(base?: DeepPartial<CancelExportJobRequest>): CancelExportJobRequest {
+    return CancelExportJobRequest.fromPartial(base ?? {});
+  },
+
+  fromPartial(object: DeepPartial<CancelExportJobRequest>): CancelExportJobRequest {
+    const message = createBaseCancelExportJobRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+export type SQLServiceDefinition = typeof SQLServiceDefinition;
+export const SQLServiceDefinition = {
+  name: "SQLService",
+  fullName: "bytebase.v1.SQLService",
+  methods: {
+    pretty: {
+      name: "Pretty",
+      requestType: PrettyRequest,
+      requestStream: false,
+      responseType: PrettyResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([19, 58, 1, 42, 34, 14, 47, 118, 49, 47, 115, 113, 108, 47, 112, 114, 101, 116, 116, 121]),
+          ],
+        },
+      },
+    },
+    query: {
+      name: "Query",
+      requestType: QueryRequest,
+      requestStream: false,
+      responseType: QueryResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              33,
+              58,
+              1,
+              42,
+              34,
+              28,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              105,
+              110,
+              115,
+              116,
+              97,
+              110,
+              99,
+              101,
+              115,
+              47,
+              42,
+              125,
+              58,
+              113,
+              117,
+              101,
+              114,
+              121,
+            ]),
+          ],
+        },
+      },
+    },
+    export: {
+      name: "Export",
+      requestType: ExportRequest,
+      requestStream: false,
+      responseType: ExportResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              34,
+              58,
+              1,
+              42,
+              34,
+              29,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              105,
+              110,
+              115,
+              116,
+              97,
+              110,
+              99,
+              101,
+              115,
+              47,
+              42,
+              125,
+              58,
+              101,
+              120,
+              112,
+              111,
+              114,
+              116,
+            ]),
+          ],
+        },
+      },
+    },
+    adminExecute: {
+      name: "AdminExecute",
+      requestType: AdminExecuteRequest,
+      requestStream: true,
+      responseType: AdminExecuteResponse,
+      responseStream: true,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([18, 18, 16, 47, 118, 49, 58, 97, 100, 109, 105, 110, 69, 120, 101, 99, 117, 116, 101]),
+          ],
+        },
+      },
+    },
+    differPreview: {
+      name: "DifferPreview",
+      requestType: DifferPreviewRequest,
+      requestStream: false,
+      responseType: DifferPreviewResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              26,
+              58,
+              1,
+              42,
+              34,
+              21,
+              47,
+              118,
+              49,
+              47,
+              115,
+              113,
+              108,
+              47,
+              100,
+              105,
+              102,
+              102,
+              101,
+              114,
+              80,
+              114,
+              101,
+              118,
+              105,
+              101,
+              119,
+            ]),
+          ],
+        },
+      },
+    },
+    check: {
+      name: "Check",
+      requestType: CheckRequest,
+      requestStream: false,
+      responseType: CheckResponse,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([18, 58, 1, 42, 34, 13, 47, 118, 49, 47, 115, 113, 108, 47, 99, 104, 101, 99, 107]),
+          ],
+        },
+      },
+    },
+    createSession: {
+      name: "CreateSession",
+      requestType: CreateSessionRequest,
+      requestStream: false,
+      responseType: Session,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              23,
+              58,
+              7,
+              115,
+              101,
+              115,
+              115,
+              105,
+              111,
+              110,
+              34,
+              12,
+              47,
+              118,
+              49,
+              47,
+              115,
+              101,
+              115,
+              115,
+              105,
+              111,
+              110,
+              115,
+            ]),
+          ],
+        },
+      },
+    },
+    getSession: {
+      name: "GetSession",
+      requestType: GetSessionRequest,
+      requestStream: false,
+      responseType: Session,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              23,
+              18,
+              21,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              115,
+              101,
+              115,
+              115,
+              105,
+              111,
+              110,
+              115,
+              47,
+              42,
+              125,
+            ]),
+          ],
+        },
+      },
+    },
+    deleteSession: {
+      name: "DeleteSession",
+      requestType: DeleteSessionRequest,
+      requestStream: false,
+      responseType: Empty,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              23,
+              42,
+              21,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              115,
+              101,
+              115,
+              115,
+              105,
+              111,
+              110,
+              115,
+              47,
+              42,
+              125,
+            ]),
+          ],
+        },
+      },
+    },
+    beginTransaction: {
+      name: "BeginTransaction",
+      requestType: BeginTransactionRequest,
+      requestStream: false,
+      responseType: Session,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([
+              43,
               58,
+              1,
+              42,
+              34,
+              38,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
               101,
-              120,
-              112,
+              61,
+              115,
+              101,
+              115,
+              115,
+              105,
               111,
+              110,
+              115,
+              47,
+              42,
+              125,
+              58,
+              98,
+              101,
+              103,
+              105,
+              110,
+              84,
               114,
+              97,
+              110,
+              115,
+              97,
+              99,
               116,
+              105,
+              111,
+              110,
             ]),
           ],
         },
       },
     },
-    adminExecute: {
-      name: "AdminExecute",
-      requestType: AdminExecuteRequest,
-      requestStream: true,
-      responseType: AdminExecuteResponse,
-      responseStream: true,
+    commitTransaction: {
+      name: "CommitTransaction",
+      requestType: CommitTransactionRequest,
+      requestStream: false,
+      responseType: Session,
+      responseStream: false,
       options: {
         _unknownFields: {
           578365826: [
-            new Uint8Array([18, 18, 16, 47, 118, 49, 58, 97, 100, 109, 105, 110, 69, 120, 101, 99, 117, 116, 101]),
+            new Uint8Array([
+              44,
+              58,
+              1,
+              42,
+              34,
+              39,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              115,
+              101,
+              115,
+              115,
+              105,
+              111,
+              110,
+              115,
+              47,
+              42,
+              125,
+              58,
+              99,
+              111,
+              109,
+              109,
+              105,
+              116,
+              84,
+              114,
+              97,
+              110,
+              115,
+              97,
+              99,
+              116,
+              105,
+              111,
+              110,
+            ]),
           ],
         },
       },
     },
-    differPreview: {
-      name: "DifferPreview",
-      requestType: DifferPreviewRequest,
+    rollbackTransaction: {
+      name: "RollbackTransaction",
+      requestType: RollbackTransactionRequest,
       requestStream: false,
-      responseType: DifferPreviewResponse,
+      responseType: Session,
       responseStream: false,
       options: {
         _unknownFields: {
           578365826: [
             new Uint8Array([
-              26,
+              46,
               58,
               1,
               42,
               34,
-              21,
+              41,
               47,
               118,
               49,
               47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
               115,
-              113,
-              108,
-              47,
-              100,
-              105,
-              102,
-              102,
               101,
+              115,
+              115,
+              105,
+              111,
+              110,
+              115,
+              47,
+              42,
+              125,
+              58,
               114,
-              80,
+              111,
+              108,
+              108,
+              98,
+              97,
+              99,
+              107,
+              84,
               114,
-              101,
-              118,
+              97,
+              110,
+              115,
+              97,
+              99,
+              116,
               105,
+              111,
+              110,
+            ]),
+          ],
+        },
+      },
+    },
+    createExportJob: {
+      name: "CreateExportJob",
+      requestType: CreateExportJobRequest,
+      requestStream: false,
+      responseType: ExportJob,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          578365826: [
+            new Uint8Array([19, 58, 1, 42, 34, 14, 47, 118, 49, 47, 101, 120, 112, 111, 114, 116, 74, 111, 98, 115]),
+          ],
+        },
+      },
+    },
+    getExportJob: {
+      name: "GetExportJob",
+      requestType: GetExportJobRequest,
+      requestStream: false,
+      responseType: ExportJob,
+      responseStream: false,
+      options: {
+        _unknownFields: {
+          8410: [new Uint8Array([4, 110, 97, 109, 101])],
+          578365826: [
+            new Uint8Array([
+              25,
+              18,
+              23,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
               101,
-              119,
+              61,
+              101,
+              120,
+              112,
+              111,
+              114,
+              116,
+              74,
+              111,
+              98,
+              115,
+              47,
+              42,
+              125,
             ]),
           ],
         },
       },
     },
-    check: {
-      name: "Check",
-      requestType: CheckRequest,
+    cancelExportJob: {
+      name: "CancelExportJob",
+      requestType: CancelExportJobRequest,
       requestStream: false,
-      responseType: CheckResponse,
+      responseType: ExportJob,
       responseStream: false,
       options: {
         _unknownFields: {
           578365826: [
-            new Uint8Array([18, 58, 1, 42, 34, 13, 47, 118, 49, 47, 115, 113, 108, 47, 99, 104, 101, 99, 107]),
+            new Uint8Array([
+              35,
+              58,
+              1,
+              42,
+              34,
+              30,
+              47,
+              118,
+              49,
+              47,
+              123,
+              110,
+              97,
+              109,
+              101,
+              61,
+              101,
+              120,
+              112,
+              111,
+              114,
+              116,
+              74,
+              111,
+              98,
+              115,
+              47,
+              42,
+              125,
+              58,
+              99,
+              97,
+              110,
+              99,
+              101,
+              108,
+            ]),
           ],
         },
       },
@@ -2068,6 +3711,28 @@ export type DeepPartial<T> = T extends Builtin ? T
   : T extends {} ? { [K in keyof T]?: DeepPartial<T[K]> }
   : Partial<T>;
 
+function toTimestamp(date: Date): Timestamp {
+  const seconds = date.getTime() / 1_000;
+  const nanos = (date.getTime() % 1_000) * 1_000_000;
+  return { seconds, nanos };
+}
+
+function fromTimestamp(t: Timestamp): Date {
+  let millis = (t.seconds || 0) * 1_000;
+  millis += (t.nanos || 0) / 1_000_000;
+  return new Date(millis);
+}
+
+function fromJsonTimestamp(o: any): Date {
+  if (o instanceof Date) {
+    return o;
+  } else if (typeof o === "string") {
+    return new Date(o);
+  } else {
+    return fromTimestamp(Timestamp.fromJSON(o));
+  }
+}
+
 function longToNumber(long: Long): number {
   if (long.gt(Number.MAX_SAFE_INTEGER)) {
     throw new tsProtoGlobalThis.Error("Value is larger than Number.MAX_SAFE_INTEGER");
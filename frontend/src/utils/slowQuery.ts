@@ -6,6 +6,10 @@ import { semverCompare } from "./util";
 export const InstanceListSupportSlowQuery: [EngineType, string][] = [
   ["MYSQL", "5.7"],
   ["POSTGRES", "0"],
+  ["MSSQL", "0"],
+  ["ORACLE", "0"],
+  ["MONGODB", "0"],
+  ["CLICKHOUSE", "0"],
 ];
 
 export const instanceSupportSlowQuery = (instance: Instance) => {
@@ -22,6 +26,10 @@ export const instanceSupportSlowQuery = (instance: Instance) => {
 export const InstanceV1ListSupportSlowQuery: [Engine, string][] = [
   [Engine.MYSQL, "5.7"],
   [Engine.POSTGRES, "0"],
+  [Engine.MSSQL, "0"],
+  [Engine.ORACLE, "0"],
+  [Engine.MONGODB, "0"],
+  [Engine.CLICKHOUSE, "0"],
 ];
 
 export const instanceV1SupportSlowQuery = (instance: InstanceV1) => {
@@ -40,21 +48,26 @@ export const instanceV1SupportSlowQuery = (instance: InstanceV1) => {
 export const slowQueryTypeOfInstance = (instance: Instance) => {
   if (!instanceSupportSlowQuery(instance)) return undefined;
   const { engine } = instance;
-  if (engine === "MYSQL") return "INSTANCE";
   if (engine === "POSTGRES") return "DATABASE";
-  return undefined;
+  return "INSTANCE";
 };
 
 export const instanceHasSlowQueryDetail = (instance: Instance) => {
   const { engine } = instance;
-  if (engine === "MYSQL") return true;
+  if (engine === "MYSQL" || engine === "MONGODB" || engine === "CLICKHOUSE")
+    return true;
 
   return false;
 };
 
 export const instanceV1HasSlowQueryDetail = (instance: InstanceV1) => {
   const { engine } = instance;
-  if (engine === Engine.MYSQL) return true;
+  if (
+    engine === Engine.MYSQL ||
+    engine === Engine.MONGODB ||
+    engine === Engine.CLICKHOUSE
+  )
+    return true;
 
   return false;
 };
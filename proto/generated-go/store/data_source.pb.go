@@ -20,6 +20,163 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DataSourceExternalSecret_SecretType int32
+
+const (
+	DataSourceExternalSecret_SECRET_TYPE_UNSPECIFIED DataSourceExternalSecret_SecretType = 0
+	// The static secret stored in the HashiCorp Vault KV secrets engine version 2.
+	DataSourceExternalSecret_VAULT_KV_V2 DataSourceExternalSecret_SecretType = 1
+	// The dynamic credential generated by the role of the HashiCorp Vault database secrets engine.
+	DataSourceExternalSecret_VAULT_DATABASE DataSourceExternalSecret_SecretType = 2
+	// The secret stored in AWS Secrets Manager.
+	DataSourceExternalSecret_AWS_SECRETS_MANAGER DataSourceExternalSecret_SecretType = 3
+)
+
+// Enum value maps for DataSourceExternalSecret_SecretType.
+var (
+	DataSourceExternalSecret_SecretType_name = map[int32]string{
+		0: "SECRET_TYPE_UNSPECIFIED",
+		1: "VAULT_KV_V2",
+		2: "VAULT_DATABASE",
+		3: "AWS_SECRETS_MANAGER",
+	}
+	DataSourceExternalSecret_SecretType_value = map[string]int32{
+		"SECRET_TYPE_UNSPECIFIED": 0,
+		"VAULT_KV_V2":             1,
+		"VAULT_DATABASE":          2,
+		"AWS_SECRETS_MANAGER":     3,
+	}
+)
+
+func (x DataSourceExternalSecret_SecretType) Enum() *DataSourceExternalSecret_SecretType {
+	p := new(DataSourceExternalSecret_SecretType)
+	*p = x
+	return p
+}
+
+func (x DataSourceExternalSecret_SecretType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataSourceExternalSecret_SecretType) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_data_source_proto_enumTypes[0].Descriptor()
+}
+
+func (DataSourceExternalSecret_SecretType) Type() protoreflect.EnumType {
+	return &file_store_data_source_proto_enumTypes[0]
+}
+
+func (x DataSourceExternalSecret_SecretType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataSourceExternalSecret_SecretType.Descriptor instead.
+func (DataSourceExternalSecret_SecretType) EnumDescriptor() ([]byte, []int) {
+	return file_store_data_source_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type DataSourceExternalSecret_AuthType int32
+
+const (
+	DataSourceExternalSecret_AUTH_TYPE_UNSPECIFIED DataSourceExternalSecret_AuthType = 0
+	// Authenticate to Vault with the token.
+	DataSourceExternalSecret_TOKEN DataSourceExternalSecret_AuthType = 1
+	// Authenticate to Vault with the AppRole role ID and secret ID.
+	DataSourceExternalSecret_APP_ROLE DataSourceExternalSecret_AuthType = 2
+)
+
+// Enum value maps for DataSourceExternalSecret_AuthType.
+var (
+	DataSourceExternalSecret_AuthType_name = map[int32]string{
+		0: "AUTH_TYPE_UNSPECIFIED",
+		1: "TOKEN",
+		2: "APP_ROLE",
+	}
+	DataSourceExternalSecret_AuthType_value = map[string]int32{
+		"AUTH_TYPE_UNSPECIFIED": 0,
+		"TOKEN":                 1,
+		"APP_ROLE":              2,
+	}
+)
+
+func (x DataSourceExternalSecret_AuthType) Enum() *DataSourceExternalSecret_AuthType {
+	p := new(DataSourceExternalSecret_AuthType)
+	*p = x
+	return p
+}
+
+func (x DataSourceExternalSecret_AuthType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataSourceExternalSecret_AuthType) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_data_source_proto_enumTypes[1].Descriptor()
+}
+
+func (DataSourceExternalSecret_AuthType) Type() protoreflect.EnumType {
+	return &file_store_data_source_proto_enumTypes[1]
+}
+
+func (x DataSourceExternalSecret_AuthType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataSourceExternalSecret_AuthType.Descriptor instead.
+func (DataSourceExternalSecret_AuthType) EnumDescriptor() ([]byte, []int) {
+	return file_store_data_source_proto_rawDescGZIP(), []int{1, 1}
+}
+
+type DataSourceIAMAuthentication_Type int32
+
+const (
+	DataSourceIAMAuthentication_TYPE_UNSPECIFIED DataSourceIAMAuthentication_Type = 0
+	// The AWS RDS and Aurora IAM database authentication. The token is valid for 15 minutes.
+	DataSourceIAMAuthentication_AWS_RDS DataSourceIAMAuthentication_Type = 1
+	// The Google Cloud SQL IAM database authentication. The token is the OAuth 2.0 access token valid for 1 hour.
+	DataSourceIAMAuthentication_GCP_CLOUD_SQL DataSourceIAMAuthentication_Type = 2
+)
+
+// Enum value maps for DataSourceIAMAuthentication_Type.
+var (
+	DataSourceIAMAuthentication_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "AWS_RDS",
+		2: "GCP_CLOUD_SQL",
+	}
+	DataSourceIAMAuthentication_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"AWS_RDS":          1,
+		"GCP_CLOUD_SQL":    2,
+	}
+)
+
+func (x DataSourceIAMAuthentication_Type) Enum() *DataSourceIAMAuthentication_Type {
+	p := new(DataSourceIAMAuthentication_Type)
+	*p = x
+	return p
+}
+
+func (x DataSourceIAMAuthentication_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataSourceIAMAuthentication_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_data_source_proto_enumTypes[2].Descriptor()
+}
+
+func (DataSourceIAMAuthentication_Type) Type() protoreflect.EnumType {
+	return &file_store_data_source_proto_enumTypes[2]
+}
+
+func (x DataSourceIAMAuthentication_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataSourceIAMAuthentication_Type.Descriptor instead.
+func (DataSourceIAMAuthentication_Type) EnumDescriptor() ([]byte, []int) {
+	return file_store_data_source_proto_rawDescGZIP(), []int{2, 0}
+}
+
 type DataSourceOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -43,6 +200,13 @@ type DataSourceOptions struct {
 	SshObfuscatedPassword string `protobuf:"bytes,8,opt,name=ssh_obfuscated_password,json=sshObfuscatedPassword,proto3" json:"ssh_obfuscated_password,omitempty"`
 	// The private key to login the server. If it's empty string, we will use the system default private key from os.Getenv("SSH_AUTH_SOCK").
 	SshObfuscatedPrivateKey string `protobuf:"bytes,9,opt,name=ssh_obfuscated_private_key,json=sshObfuscatedPrivateKey,proto3" json:"ssh_obfuscated_private_key,omitempty"`
+	// The external secret manager storing the credential of the data source.
+	// If it's set, the credential is fetched from the secret manager at connection time instead of using the password.
+	ExternalSecret *DataSourceExternalSecret `protobuf:"bytes,10,opt,name=external_secret,json=externalSecret,proto3" json:"external_secret,omitempty"`
+	// The IAM database authentication of the data source.
+	// If it's set, a short-lived authentication token generated from the cloud credentials of the Bytebase server is used as the password.
+	// MySQL sends the token in cleartext, so the SSL CA, e.g. the AWS RDS global bundle or the Cloud SQL server CA, is required to verify the server.
+	IamAuthentication *DataSourceIAMAuthentication `protobuf:"bytes,11,opt,name=iam_authentication,json=iamAuthentication,proto3" json:"iam_authentication,omitempty"`
 }
 
 func (x *DataSourceOptions) Reset() {
@@ -140,12 +304,230 @@ func (x *DataSourceOptions) GetSshObfuscatedPrivateKey() string {
 	return ""
 }
 
+func (x *DataSourceOptions) GetExternalSecret() *DataSourceExternalSecret {
+	if x != nil {
+		return x.ExternalSecret
+	}
+	return nil
+}
+
+func (x *DataSourceOptions) GetIamAuthentication() *DataSourceIAMAuthentication {
+	if x != nil {
+		return x.IamAuthentication
+	}
+	return nil
+}
+
+type DataSourceExternalSecret struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SecretType DataSourceExternalSecret_SecretType `protobuf:"varint,1,opt,name=secret_type,json=secretType,proto3,enum=bytebase.store.DataSourceExternalSecret_SecretType" json:"secret_type,omitempty"`
+	// The auth type of Vault. AWS Secrets Manager uses the AWS credentials of the Bytebase server.
+	AuthType DataSourceExternalSecret_AuthType `protobuf:"varint,2,opt,name=auth_type,json=authType,proto3,enum=bytebase.store.DataSourceExternalSecret_AuthType" json:"auth_type,omitempty"`
+	// The URL of the Vault server, e.g. https://vault.example.com:8200.
+	// For AWS Secrets Manager, it's the optional custom endpoint.
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// The Vault token for the TOKEN auth type.
+	ObfuscatedToken string `protobuf:"bytes,4,opt,name=obfuscated_token,json=obfuscatedToken,proto3" json:"obfuscated_token,omitempty"`
+	// The role ID for the APP_ROLE auth type.
+	AppRoleId string `protobuf:"bytes,5,opt,name=app_role_id,json=appRoleId,proto3" json:"app_role_id,omitempty"`
+	// The secret ID for the APP_ROLE auth type.
+	ObfuscatedAppRoleSecretId string `protobuf:"bytes,6,opt,name=obfuscated_app_role_secret_id,json=obfuscatedAppRoleSecretId,proto3" json:"obfuscated_app_role_secret_id,omitempty"`
+	// The mount path of the Vault secrets engine, e.g. "secret" for KV and "database" for the database secrets engine.
+	EngineName string `protobuf:"bytes,7,opt,name=engine_name,json=engineName,proto3" json:"engine_name,omitempty"`
+	// The path of the secret in the Vault KV secrets engine, or the name or ARN of the secret in AWS Secrets Manager.
+	SecretName string `protobuf:"bytes,8,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`
+	// The key of the password in the secret.
+	// If it's empty for AWS Secrets Manager, the whole secret string is used as the password.
+	PasswordKeyName string `protobuf:"bytes,9,opt,name=password_key_name,json=passwordKeyName,proto3" json:"password_key_name,omitempty"`
+	// The key of the username in the secret. If it's empty, the username of the data source is used.
+	UsernameKeyName string `protobuf:"bytes,10,opt,name=username_key_name,json=usernameKeyName,proto3" json:"username_key_name,omitempty"`
+	// The role of the Vault database secrets engine generating the dynamic credentials.
+	DatabaseRole string `protobuf:"bytes,11,opt,name=database_role,json=databaseRole,proto3" json:"database_role,omitempty"`
+	// The region of AWS Secrets Manager. If it's empty, the region of the AWS config of the Bytebase server is used.
+	Region string `protobuf:"bytes,12,opt,name=region,proto3" json:"region,omitempty"`
+}
+
+func (x *DataSourceExternalSecret) Reset() {
+	*x = DataSourceExternalSecret{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_data_source_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataSourceExternalSecret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataSourceExternalSecret) ProtoMessage() {}
+
+func (x *DataSourceExternalSecret) ProtoReflect() protoreflect.Message {
+	mi := &file_store_data_source_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataSourceExternalSecret.ProtoReflect.Descriptor instead.
+func (*DataSourceExternalSecret) Descriptor() ([]byte, []int) {
+	return file_store_data_source_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DataSourceExternalSecret) GetSecretType() DataSourceExternalSecret_SecretType {
+	if x != nil {
+		return x.SecretType
+	}
+	return DataSourceExternalSecret_SECRET_TYPE_UNSPECIFIED
+}
+
+func (x *DataSourceExternalSecret) GetAuthType() DataSourceExternalSecret_AuthType {
+	if x != nil {
+		return x.AuthType
+	}
+	return DataSourceExternalSecret_AUTH_TYPE_UNSPECIFIED
+}
+
+func (x *DataSourceExternalSecret) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetObfuscatedToken() string {
+	if x != nil {
+		return x.ObfuscatedToken
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetAppRoleId() string {
+	if x != nil {
+		return x.AppRoleId
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetObfuscatedAppRoleSecretId() string {
+	if x != nil {
+		return x.ObfuscatedAppRoleSecretId
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetEngineName() string {
+	if x != nil {
+		return x.EngineName
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetPasswordKeyName() string {
+	if x != nil {
+		return x.PasswordKeyName
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetUsernameKeyName() string {
+	if x != nil {
+		return x.UsernameKeyName
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetDatabaseRole() string {
+	if x != nil {
+		return x.DatabaseRole
+	}
+	return ""
+}
+
+func (x *DataSourceExternalSecret) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type DataSourceIAMAuthentication struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type DataSourceIAMAuthentication_Type `protobuf:"varint,1,opt,name=type,proto3,enum=bytebase.store.DataSourceIAMAuthentication_Type" json:"type,omitempty"`
+	// The region of the AWS RDS instance. If it's empty, the region of the AWS config of the Bytebase server is used.
+	Region string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+}
+
+func (x *DataSourceIAMAuthentication) Reset() {
+	*x = DataSourceIAMAuthentication{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_data_source_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataSourceIAMAuthentication) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataSourceIAMAuthentication) ProtoMessage() {}
+
+func (x *DataSourceIAMAuthentication) ProtoReflect() protoreflect.Message {
+	mi := &file_store_data_source_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataSourceIAMAuthentication.ProtoReflect.Descriptor instead.
+func (*DataSourceIAMAuthentication) Descriptor() ([]byte, []int) {
+	return file_store_data_source_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DataSourceIAMAuthentication) GetType() DataSourceIAMAuthentication_Type {
+	if x != nil {
+		return x.Type
+	}
+	return DataSourceIAMAuthentication_TYPE_UNSPECIFIED
+}
+
+func (x *DataSourceIAMAuthentication) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
 var File_store_data_source_proto protoreflect.FileDescriptor
 
 var file_store_data_source_proto_rawDesc = []byte{
 	0x0a, 0x17, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x6f, 0x75,
 	0x72, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x62,
-	0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0xd9, 0x02, 0x0a, 0x11, 0x44, 0x61,
+	0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0x88, 0x04, 0x0a, 0x11, 0x44, 0x61,
 	0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
 	0x10, 0x0a, 0x03, 0x73, 0x72, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x73, 0x72,
 	0x76, 0x12, 0x37, 0x0a, 0x17, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
@@ -167,9 +549,77 @@ var file_store_data_source_proto_rawDesc = []byte{
 	0x6f, 0x62, 0x66, 0x75, 0x73, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61,
 	0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x73, 0x73,
 	0x68, 0x4f, 0x62, 0x66, 0x75, 0x73, 0x63, 0x61, 0x74, 0x65, 0x64, 0x50, 0x72, 0x69, 0x76, 0x61,
-	0x74, 0x65, 0x4b, 0x65, 0x79, 0x42, 0x14, 0x5a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x64, 0x2d, 0x67, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x51, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x0e, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x5a, 0x0a, 0x12, 0x69, 0x61, 0x6d, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x49, 0x41, 0x4d, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x11, 0x69, 0x61, 0x6d, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xdf, 0x05, 0x0a, 0x18, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x12, 0x54, 0x0a, 0x0b, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73,
+	0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x4e, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x62, 0x79, 0x74,
+	0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x61,
+	0x75, 0x74, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x62, 0x66,
+	0x75, 0x73, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x6f, 0x62, 0x66, 0x75, 0x73, 0x63, 0x61, 0x74, 0x65, 0x64, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1e, 0x0a, 0x0b, 0x61, 0x70, 0x70, 0x5f, 0x72, 0x6f, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x70, 0x70, 0x52, 0x6f,
+	0x6c, 0x65, 0x49, 0x64, 0x12, 0x40, 0x0a, 0x1d, 0x6f, 0x62, 0x66, 0x75, 0x73, 0x63, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x70, 0x70, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x6f, 0x62, 0x66,
+	0x75, 0x73, 0x63, 0x61, 0x74, 0x65, 0x64, 0x41, 0x70, 0x70, 0x52, 0x6f, 0x6c, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x4b, 0x65, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x4b, 0x65, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x6f, 0x6c,
+	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x22, 0x67, 0x0a,
+	0x0a, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x53,
+	0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x56, 0x41, 0x55, 0x4c,
+	0x54, 0x5f, 0x4b, 0x56, 0x5f, 0x56, 0x32, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x56, 0x41, 0x55,
+	0x4c, 0x54, 0x5f, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x10, 0x02, 0x12, 0x17, 0x0a,
+	0x13, 0x41, 0x57, 0x53, 0x5f, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x53, 0x5f, 0x4d, 0x41, 0x4e,
+	0x41, 0x47, 0x45, 0x52, 0x10, 0x03, 0x22, 0x3e, 0x0a, 0x08, 0x41, 0x75, 0x74, 0x68, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a,
+	0x05, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x50, 0x50, 0x5f,
+	0x52, 0x4f, 0x4c, 0x45, 0x10, 0x02, 0x22, 0xb9, 0x01, 0x0a, 0x1b, 0x44, 0x61, 0x74, 0x61, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x41, 0x4d, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x49, 0x41, 0x4d, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x67, 0x69, 0x6f, 0x6e, 0x22, 0x3c, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x57, 0x53, 0x5f, 0x52, 0x44, 0x53, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x47, 0x43, 0x50, 0x5f, 0x43, 0x4c, 0x4f, 0x55, 0x44, 0x5f, 0x53, 0x51, 0x4c,
+	0x10, 0x02, 0x42, 0x14, 0x5a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2d,
+	0x67, 0x6f, 0x2f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -184,16 +634,27 @@ func file_store_data_source_proto_rawDescGZIP() []byte {
 	return file_store_data_source_proto_rawDescData
 }
 
-var file_store_data_source_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_store_data_source_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_store_data_source_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_store_data_source_proto_goTypes = []interface{}{
-	(*DataSourceOptions)(nil), // 0: bytebase.store.DataSourceOptions
+	(DataSourceExternalSecret_SecretType)(0), // 0: bytebase.store.DataSourceExternalSecret.SecretType
+	(DataSourceExternalSecret_AuthType)(0),   // 1: bytebase.store.DataSourceExternalSecret.AuthType
+	(DataSourceIAMAuthentication_Type)(0),    // 2: bytebase.store.DataSourceIAMAuthentication.Type
+	(*DataSourceOptions)(nil),                // 3: bytebase.store.DataSourceOptions
+	(*DataSourceExternalSecret)(nil),         // 4: bytebase.store.DataSourceExternalSecret
+	(*DataSourceIAMAuthentication)(nil),      // 5: bytebase.store.DataSourceIAMAuthentication
 }
 var file_store_data_source_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	4, // 0: bytebase.store.DataSourceOptions.external_secret:type_name -> bytebase.store.DataSourceExternalSecret
+	5, // 1: bytebase.store.DataSourceOptions.iam_authentication:type_name -> bytebase.store.DataSourceIAMAuthentication
+	0, // 2: bytebase.store.DataSourceExternalSecret.secret_type:type_name -> bytebase.store.DataSourceExternalSecret.SecretType
+	1, // 3: bytebase.store.DataSourceExternalSecret.auth_type:type_name -> bytebase.store.DataSourceExternalSecret.AuthType
+	2, // 4: bytebase.store.DataSourceIAMAuthentication.type:type_name -> bytebase.store.DataSourceIAMAuthentication.Type
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_store_data_source_proto_init() }
@@ -214,19 +675,44 @@ func file_store_data_source_proto_init() {
 				return nil
 			}
 		}
+		file_store_data_source_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataSourceExternalSecret); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_data_source_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataSourceIAMAuthentication); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_store_data_source_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      3,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_store_data_source_proto_goTypes,
 		DependencyIndexes: file_store_data_source_proto_depIdxs,
+		EnumInfos:         file_store_data_source_proto_enumTypes,
 		MessageInfos:      file_store_data_source_proto_msgTypes,
 	}.Build()
 	File_store_data_source_proto = out.File
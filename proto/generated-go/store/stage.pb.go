@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: store/stage.proto
+
+package store
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StagePayload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The canary wave of the stage.
+	// It's set if the stage is one of the waves the matched databases of a deployment are split into.
+	CanaryWave *CanaryWave `protobuf:"bytes,1,opt,name=canary_wave,json=canaryWave,proto3" json:"canary_wave,omitempty"`
+}
+
+func (x *StagePayload) Reset() {
+	*x = StagePayload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_stage_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StagePayload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StagePayload) ProtoMessage() {}
+
+func (x *StagePayload) ProtoReflect() protoreflect.Message {
+	mi := &file_store_stage_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StagePayload.ProtoReflect.Descriptor instead.
+func (*StagePayload) Descriptor() ([]byte, []int) {
+	return file_store_stage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StagePayload) GetCanaryWave() *CanaryWave {
+	if x != nil {
+		return x.CanaryWave
+	}
+	return nil
+}
+
+type CanaryWave struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The zero-based index of the wave in the deployment.
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// The wave is halted if more than max_failed_tasks tasks fail in the previous wave.
+	MaxFailedTasks int32 `protobuf:"varint,2,opt,name=max_failed_tasks,json=maxFailedTasks,proto3" json:"max_failed_tasks,omitempty"`
+	// The time to wait after the tasks of the previous wave finish before the tasks of the wave start.
+	SoakDuration *durationpb.Duration `protobuf:"bytes,3,opt,name=soak_duration,json=soakDuration,proto3" json:"soak_duration,omitempty"`
+}
+
+func (x *CanaryWave) Reset() {
+	*x = CanaryWave{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_store_stage_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CanaryWave) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CanaryWave) ProtoMessage() {}
+
+func (x *CanaryWave) ProtoReflect() protoreflect.Message {
+	mi := &file_store_stage_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CanaryWave.ProtoReflect.Descriptor instead.
+func (*CanaryWave) Descriptor() ([]byte, []int) {
+	return file_store_stage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CanaryWave) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CanaryWave) GetMaxFailedTasks() int32 {
+	if x != nil {
+		return x.MaxFailedTasks
+	}
+	return 0
+}
+
+func (x *CanaryWave) GetSoakDuration() *durationpb.Duration {
+	if x != nil {
+		return x.SoakDuration
+	}
+	return nil
+}
+
+var File_store_stage_proto protoreflect.FileDescriptor
+
+var file_store_stage_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x4b, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x67, 0x65, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x12, 0x3b, 0x0a, 0x0b, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x77, 0x61,
+	0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62,
+	0x61, 0x73, 0x65, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79,
+	0x57, 0x61, 0x76, 0x65, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x57, 0x61, 0x76, 0x65,
+	0x22, 0x8c, 0x01, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x61, 0x72, 0x79, 0x57, 0x61, 0x76, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0e, 0x6d, 0x61, 0x78, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12,
+	0x3e, 0x0a, 0x0d, 0x73, 0x6f, 0x61, 0x6b, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x73, 0x6f, 0x61, 0x6b, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x14, 0x5a, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2d, 0x67, 0x6f, 0x2f,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_store_stage_proto_rawDescOnce sync.Once
+	file_store_stage_proto_rawDescData = file_store_stage_proto_rawDesc
+)
+
+func file_store_stage_proto_rawDescGZIP() []byte {
+	file_store_stage_proto_rawDescOnce.Do(func() {
+		file_store_stage_proto_rawDescData = protoimpl.X.CompressGZIP(file_store_stage_proto_rawDescData)
+	})
+	return file_store_stage_proto_rawDescData
+}
+
+var file_store_stage_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_store_stage_proto_goTypes = []interface{}{
+	(*StagePayload)(nil),        // 0: bytebase.store.StagePayload
+	(*CanaryWave)(nil),          // 1: bytebase.store.CanaryWave
+	(*durationpb.Duration)(nil), // 2: google.protobuf.Duration
+}
+var file_store_stage_proto_depIdxs = []int32{
+	1, // 0: bytebase.store.StagePayload.canary_wave:type_name -> bytebase.store.CanaryWave
+	2, // 1: bytebase.store.CanaryWave.soak_duration:type_name -> google.protobuf.Duration
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_store_stage_proto_init() }
+func file_store_stage_proto_init() {
+	if File_store_stage_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_store_stage_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StagePayload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_store_stage_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CanaryWave); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_store_stage_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_store_stage_proto_goTypes,
+		DependencyIndexes: file_store_stage_proto_depIdxs,
+		MessageInfos:      file_store_stage_proto_msgTypes,
+	}.Build()
+	File_store_stage_proto = out.File
+	file_store_stage_proto_rawDesc = nil
+	file_store_stage_proto_goTypes = nil
+	file_store_stage_proto_depIdxs = nil
+}
@@ -232,6 +232,161 @@ func (Announcement_AlertLevel) EnumDescriptor() ([]byte, []int) {
 	return file_v1_setting_service_proto_rawDescGZIP(), []int{11, 0}
 }
 
+type MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType int32
+
+const (
+	MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HASH_TYPE_UNSPECIFIED MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType = 0
+	MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_MD5                   MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType = 1
+	MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_SHA256                MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType = 2
+)
+
+// Enum value maps for MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType.
+var (
+	MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType_name = map[int32]string{
+		0: "HASH_TYPE_UNSPECIFIED",
+		1: "MD5",
+		2: "SHA256",
+	}
+	MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType_value = map[string]int32{
+		"HASH_TYPE_UNSPECIFIED": 0,
+		"MD5":                   1,
+		"SHA256":                2,
+	}
+)
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType) Enum() *MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType {
+	p := new(MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType)
+	*p = x
+	return p
+}
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_setting_service_proto_enumTypes[4].Descriptor()
+}
+
+func (MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType) Type() protoreflect.EnumType {
+	return &file_v1_setting_service_proto_enumTypes[4]
+}
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType.Descriptor instead.
+func (MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType) EnumDescriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 2, 0}
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType int32
+
+const (
+	MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MASK_TYPE_UNSPECIFIED MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType = 0
+	// INNER masks the value between the prefix and the suffix.
+	MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_INNER MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType = 1
+	// OUTER masks the prefix and the suffix, and keeps the value between them.
+	MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_OUTER MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType = 2
+)
+
+// Enum value maps for MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType.
+var (
+	MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType_name = map[int32]string{
+		0: "MASK_TYPE_UNSPECIFIED",
+		1: "INNER",
+		2: "OUTER",
+	}
+	MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType_value = map[string]int32{
+		"MASK_TYPE_UNSPECIFIED": 0,
+		"INNER":                 1,
+		"OUTER":                 2,
+	}
+)
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType) Enum() *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType {
+	p := new(MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType)
+	*p = x
+	return p
+}
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_setting_service_proto_enumTypes[5].Descriptor()
+}
+
+func (MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType) Type() protoreflect.EnumType {
+	return &file_v1_setting_service_proto_enumTypes[5]
+}
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType.Descriptor instead.
+func (MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType) EnumDescriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 3, 0}
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit int32
+
+const (
+	MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TIME_UNIT_UNSPECIFIED MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit = 0
+	MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_YEAR                  MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit = 1
+	MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_MONTH                 MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit = 2
+	MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_DAY                   MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit = 3
+	MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_HOUR                  MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit = 4
+)
+
+// Enum value maps for MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit.
+var (
+	MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit_name = map[int32]string{
+		0: "TIME_UNIT_UNSPECIFIED",
+		1: "YEAR",
+		2: "MONTH",
+		3: "DAY",
+		4: "HOUR",
+	}
+	MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit_value = map[string]int32{
+		"TIME_UNIT_UNSPECIFIED": 0,
+		"YEAR":                  1,
+		"MONTH":                 2,
+		"DAY":                   3,
+		"HOUR":                  4,
+	}
+)
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit) Enum() *MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit {
+	p := new(MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit)
+	*p = x
+	return p
+}
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_setting_service_proto_enumTypes[6].Descriptor()
+}
+
+func (MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit) Type() protoreflect.EnumType {
+	return &file_v1_setting_service_proto_enumTypes[6]
+}
+
+func (x MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit.Descriptor instead.
+func (MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit) EnumDescriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 4, 0}
+}
+
 type ListSettingsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1574,6 +1729,7 @@ type MaskingAlgorithmSetting struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// algorithms is the list of masking algorithms.
 	Algorithms []*MaskingAlgorithmSetting_MaskingAlgorithm `protobuf:"bytes,1,rep,name=algorithms,proto3" json:"algorithms,omitempty"`
 }
 
@@ -2298,12 +2454,19 @@ type MaskingAlgorithmSetting_MaskingAlgorithm struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// id is the uuid for semantic type.
+	// id is the uuid for masking algorithm.
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// the title of the masking algorithm, it should not be empty.
+	// title is the title for masking algorithm.
 	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	// the description of the masking algorithm, it can be empty.
+	// description is the description for masking algorithm.
 	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Types that are assignable to Mask:
+	//	*MaskingAlgorithmSetting_MaskingAlgorithm_FullMask_
+	//	*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_
+	//	*MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_
+	//	*MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_
+	//	*MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_
+	Mask isMaskingAlgorithmSetting_MaskingAlgorithm_Mask `protobuf_oneof:"mask"`
 }
 
 func (x *MaskingAlgorithmSetting_MaskingAlgorithm) Reset() {
@@ -2359,6 +2522,436 @@ func (x *MaskingAlgorithmSetting_MaskingAlgorithm) GetDescription() string {
 	return ""
 }
 
+func (m *MaskingAlgorithmSetting_MaskingAlgorithm) GetMask() isMaskingAlgorithmSetting_MaskingAlgorithm_Mask {
+	if m != nil {
+		return m.Mask
+	}
+	return nil
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm) GetFullMask() *MaskingAlgorithmSetting_MaskingAlgorithm_FullMask {
+	if x, ok := x.GetMask().(*MaskingAlgorithmSetting_MaskingAlgorithm_FullMask_); ok {
+		return x.FullMask
+	}
+	return nil
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm) GetRangeMask() *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask {
+	if x, ok := x.GetMask().(*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_); ok {
+		return x.RangeMask
+	}
+	return nil
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm) GetHashMask() *MaskingAlgorithmSetting_MaskingAlgorithm_HashMask {
+	if x, ok := x.GetMask().(*MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_); ok {
+		return x.HashMask
+	}
+	return nil
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm) GetInnerOuterMask() *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask {
+	if x, ok := x.GetMask().(*MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_); ok {
+		return x.InnerOuterMask
+	}
+	return nil
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm) GetGeneralizationMask() *MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask {
+	if x, ok := x.GetMask().(*MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_); ok {
+		return x.GeneralizationMask
+	}
+	return nil
+}
+
+type isMaskingAlgorithmSetting_MaskingAlgorithm_Mask interface {
+	isMaskingAlgorithmSetting_MaskingAlgorithm_Mask()
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_FullMask_ struct {
+	FullMask *MaskingAlgorithmSetting_MaskingAlgorithm_FullMask `protobuf:"bytes,4,opt,name=full_mask,json=fullMask,proto3,oneof"`
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_ struct {
+	RangeMask *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask `protobuf:"bytes,5,opt,name=range_mask,json=rangeMask,proto3,oneof"`
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_ struct {
+	HashMask *MaskingAlgorithmSetting_MaskingAlgorithm_HashMask `protobuf:"bytes,6,opt,name=hash_mask,json=hashMask,proto3,oneof"`
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_ struct {
+	InnerOuterMask *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask `protobuf:"bytes,7,opt,name=inner_outer_mask,json=innerOuterMask,proto3,oneof"`
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_ struct {
+	GeneralizationMask *MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask `protobuf:"bytes,8,opt,name=generalization_mask,json=generalizationMask,proto3,oneof"`
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_FullMask_) isMaskingAlgorithmSetting_MaskingAlgorithm_Mask() {
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_) isMaskingAlgorithmSetting_MaskingAlgorithm_Mask() {
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_) isMaskingAlgorithmSetting_MaskingAlgorithm_Mask() {
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_) isMaskingAlgorithmSetting_MaskingAlgorithm_Mask() {
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_) isMaskingAlgorithmSetting_MaskingAlgorithm_Mask() {
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_FullMask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// substitution is the string used to replace the original value, the
+	// max length of the string is 16 bytes.
+	Substitution string `protobuf:"bytes,1,opt,name=substitution,proto3" json:"substitution,omitempty"`
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_FullMask) Reset() {
+	*x = MaskingAlgorithmSetting_MaskingAlgorithm_FullMask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_setting_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_FullMask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_FullMask) ProtoMessage() {}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_FullMask) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_setting_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_FullMask.ProtoReflect.Descriptor instead.
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_FullMask) Descriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 0}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_FullMask) GetSubstitution() string {
+	if x != nil {
+		return x.Substitution
+	}
+	return ""
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// We store it as a repeated field to face the fact that the original value may have multiple parts should be masked.
+	// But frontend can be started with a single rule easily.
+	Slices []*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice `protobuf:"bytes,1,rep,name=slices,proto3" json:"slices,omitempty"`
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask) Reset() {
+	*x = MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_setting_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask) ProtoMessage() {}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_setting_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask.ProtoReflect.Descriptor instead.
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask) Descriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 1}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask) GetSlices() []*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice {
+	if x != nil {
+		return x.Slices
+	}
+	return nil
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_HashMask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType `protobuf:"varint,1,opt,name=type,proto3,enum=bytebase.v1.MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType" json:"type,omitempty"`
+	// salt is the salt value to generate a different hash that with the word alone.
+	Salt string `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"`
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_HashMask) Reset() {
+	*x = MaskingAlgorithmSetting_MaskingAlgorithm_HashMask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_setting_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_HashMask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_HashMask) ProtoMessage() {}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_HashMask) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_setting_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_HashMask.ProtoReflect.Descriptor instead.
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_HashMask) Descriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 2}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_HashMask) GetType() MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType {
+	if x != nil {
+		return x.Type
+	}
+	return MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HASH_TYPE_UNSPECIFIED
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_HashMask) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PrefixLen int32                                                            `protobuf:"varint,1,opt,name=prefix_len,json=prefixLen,proto3" json:"prefix_len,omitempty"`
+	SuffixLen int32                                                            `protobuf:"varint,2,opt,name=suffix_len,json=suffixLen,proto3" json:"suffix_len,omitempty"`
+	Type      MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType `protobuf:"varint,3,opt,name=type,proto3,enum=bytebase.v1.MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType" json:"type,omitempty"`
+	// substitution is the character used to replace each masked character.
+	Substitution string `protobuf:"bytes,4,opt,name=substitution,proto3" json:"substitution,omitempty"`
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) Reset() {
+	*x = MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_setting_service_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) ProtoMessage() {}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_setting_service_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask.ProtoReflect.Descriptor instead.
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) Descriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 3}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) GetPrefixLen() int32 {
+	if x != nil {
+		return x.PrefixLen
+	}
+	return 0
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) GetSuffixLen() int32 {
+	if x != nil {
+		return x.SuffixLen
+	}
+	return 0
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) GetType() MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType {
+	if x != nil {
+		return x.Type
+	}
+	return MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MASK_TYPE_UNSPECIFIED
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask) GetSubstitution() string {
+	if x != nil {
+		return x.Substitution
+	}
+	return ""
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// bucket_size is the width of the range a number falls into, e.g. 10 generalizes 27 into [20, 30).
+	BucketSize float64 `protobuf:"fixed64,1,opt,name=bucket_size,json=bucketSize,proto3" json:"bucket_size,omitempty"`
+	// time_unit is the unit a date or timestamp value is truncated to.
+	TimeUnit MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit `protobuf:"varint,2,opt,name=time_unit,json=timeUnit,proto3,enum=bytebase.v1.MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit" json:"time_unit,omitempty"`
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask) Reset() {
+	*x = MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_setting_service_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask) ProtoMessage() {}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_setting_service_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask.ProtoReflect.Descriptor instead.
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask) Descriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 4}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask) GetBucketSize() float64 {
+	if x != nil {
+		return x.BucketSize
+	}
+	return 0
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask) GetTimeUnit() MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit {
+	if x != nil {
+		return x.TimeUnit
+	}
+	return MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TIME_UNIT_UNSPECIFIED
+}
+
+type MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// start is the start index of the original value, start from 0 and should be less than end.
+	Start int32 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	// end is the end index of the original value.
+	End int32 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	// substitution is the string used to replace the OriginalValue[start:end).
+	Substitution string `protobuf:"bytes,3,opt,name=substitution,proto3" json:"substitution,omitempty"`
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice) Reset() {
+	*x = MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_v1_setting_service_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice) ProtoMessage() {}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_setting_service_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice.ProtoReflect.Descriptor instead.
+func (*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice) Descriptor() ([]byte, []int) {
+	return file_v1_setting_service_proto_rawDescGZIP(), []int{18, 0, 1, 0}
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice) GetSubstitution() string {
+	if x != nil {
+		return x.Substitution
+	}
+	return ""
+}
+
 var File_v1_setting_service_proto protoreflect.FileDescriptor
 
 var file_v1_setting_service_proto_rawDesc = []byte{
@@ -2751,20 +3344,113 @@ var file_v1_setting_service_proto_rawDesc = []byte{
 	0x69, 0x74, 0x68, 0x6d, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x16, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6d,
 	0x61, 0x73, 0x6b, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x5f, 0x69, 0x64,
 	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x66, 0x75, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x6b,
-	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x49, 0x64, 0x22, 0xcc, 0x01, 0x0a, 0x17,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x49, 0x64, 0x22, 0x9c, 0x0d, 0x0a, 0x17,
 	0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
 	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x55, 0x0a, 0x0a, 0x61, 0x6c, 0x67, 0x6f, 0x72,
 	0x69, 0x74, 0x68, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x62, 0x79,
 	0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e,
 	0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
 	0x67, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
-	0x68, 0x6d, 0x52, 0x0a, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x73, 0x1a, 0x5a,
-	0x0a, 0x10, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
-	0x68, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0xdc, 0x02, 0x0a, 0x0e, 0x53,
+	0x68, 0x6d, 0x52, 0x0a, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x73, 0x1a, 0xa9,
+	0x0c, 0x0a, 0x10, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69,
+	0x74, 0x68, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5d, 0x0a, 0x09, 0x66,
+	0x75, 0x6c, 0x6c, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e,
+	0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73,
+	0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x46, 0x75, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x6b, 0x48, 0x00,
+	0x52, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x60, 0x0a, 0x0a, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3f,
+	0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73,
+	0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x48,
+	0x00, 0x52, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x5d, 0x0a, 0x09,
+	0x68, 0x61, 0x73, 0x68, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x3e, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61,
+	0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x61, 0x73, 0x6b, 0x48,
+	0x00, 0x52, 0x08, 0x68, 0x61, 0x73, 0x68, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x70, 0x0a, 0x10, 0x69,
+	0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x61, 0x73, 0x6b,
+	0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x49, 0x6e, 0x6e,
+	0x65, 0x72, 0x4f, 0x75, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x73, 0x6b, 0x48, 0x00, 0x52, 0x0e, 0x69,
+	0x6e, 0x6e, 0x65, 0x72, 0x4f, 0x75, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x7b, 0x0a,
+	0x13, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6d, 0x61, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x48, 0x2e, 0x62, 0x79, 0x74,
+	0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x61, 0x73, 0x6b, 0x48, 0x00, 0x52, 0x12, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x73, 0x6b, 0x1a, 0x2e, 0x0a, 0x08, 0x46, 0x75,
+	0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x74, 0x69,
+	0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x69, 0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xbf, 0x01, 0x0a, 0x09, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x5d, 0x0a, 0x06, 0x73, 0x6c, 0x69, 0x63,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x45, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62,
+	0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d,
+	0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x2e, 0x53, 0x6c, 0x69, 0x63, 0x65, 0x52,
+	0x06, 0x73, 0x6c, 0x69, 0x63, 0x65, 0x73, 0x1a, 0x53, 0x0a, 0x05, 0x53, 0x6c, 0x69, 0x63, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73,
+	0x74, 0x69, 0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x75, 0x62, 0x73, 0x74, 0x69, 0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xb7, 0x01, 0x0a,
+	0x08, 0x48, 0x61, 0x73, 0x68, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x5b, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x47, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61,
+	0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x61,
+	0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x48,
+	0x61, 0x73, 0x68, 0x4d, 0x61, 0x73, 0x6b, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x22, 0x3a, 0x0a, 0x08, 0x48, 0x61,
+	0x73, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x48, 0x41, 0x53, 0x48, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x35, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48,
+	0x41, 0x32, 0x35, 0x36, 0x10, 0x02, 0x1a, 0x92, 0x02, 0x0a, 0x0e, 0x49, 0x6e, 0x6e, 0x65, 0x72,
+	0x4f, 0x75, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x4c, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x66, 0x66,
+	0x69, 0x78, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x75,
+	0x66, 0x66, 0x69, 0x78, 0x4c, 0x65, 0x6e, 0x12, 0x61, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x4d, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72,
+	0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x61, 0x73, 0x6b,
+	0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x49, 0x6e, 0x6e,
+	0x65, 0x72, 0x4f, 0x75, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x73, 0x6b, 0x2e, 0x4d, 0x61, 0x73, 0x6b,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x75,
+	0x62, 0x73, 0x74, 0x69, 0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x74, 0x69, 0x74, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3b,
+	0x0a, 0x08, 0x4d, 0x61, 0x73, 0x6b, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x4d, 0x41,
+	0x53, 0x4b, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x4e, 0x4e, 0x45, 0x52, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x4f, 0x55, 0x54, 0x45, 0x52, 0x10, 0x02, 0x1a, 0xf4, 0x01, 0x0a, 0x12,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61,
+	0x73, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x6e, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x6e, 0x69, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x51, 0x2e, 0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x61, 0x73,
+	0x6b, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x2e, 0x47, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x61, 0x73, 0x6b,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x55,
+	0x6e, 0x69, 0x74, 0x22, 0x4d, 0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x12,
+	0x19, 0x0a, 0x15, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x59, 0x45,
+	0x41, 0x52, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x4f, 0x4e, 0x54, 0x48, 0x10, 0x02, 0x12,
+	0x07, 0x0a, 0x03, 0x44, 0x41, 0x59, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x4f, 0x55, 0x52,
+	0x10, 0x04, 0x42, 0x06, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x32, 0xdc, 0x02, 0x0a, 0x0e, 0x53,
 	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6c, 0x0a,
 	0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x20, 0x2e,
 	0x62, 0x79, 0x74, 0x65, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
@@ -2803,108 +3489,126 @@ func file_v1_setting_service_proto_rawDescGZIP() []byte {
 	return file_v1_setting_service_proto_rawDescData
 }
 
-var file_v1_setting_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_v1_setting_service_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_v1_setting_service_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_v1_setting_service_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_v1_setting_service_proto_goTypes = []interface{}{
-	(SMTPMailDeliverySettingValue_Encryption)(0),                     // 0: bytebase.v1.SMTPMailDeliverySettingValue.Encryption
-	(SMTPMailDeliverySettingValue_Authentication)(0),                 // 1: bytebase.v1.SMTPMailDeliverySettingValue.Authentication
-	(AppIMSetting_IMType)(0),                                         // 2: bytebase.v1.AppIMSetting.IMType
-	(Announcement_AlertLevel)(0),                                     // 3: bytebase.v1.Announcement.AlertLevel
-	(*ListSettingsRequest)(nil),                                      // 4: bytebase.v1.ListSettingsRequest
-	(*ListSettingsResponse)(nil),                                     // 5: bytebase.v1.ListSettingsResponse
-	(*GetSettingRequest)(nil),                                        // 6: bytebase.v1.GetSettingRequest
-	(*GetSettingResponse)(nil),                                       // 7: bytebase.v1.GetSettingResponse
-	(*SetSettingRequest)(nil),                                        // 8: bytebase.v1.SetSettingRequest
-	(*Setting)(nil),                                                  // 9: bytebase.v1.Setting
-	(*Value)(nil),                                                    // 10: bytebase.v1.Value
-	(*SMTPMailDeliverySettingValue)(nil),                             // 11: bytebase.v1.SMTPMailDeliverySettingValue
-	(*AppIMSetting)(nil),                                             // 12: bytebase.v1.AppIMSetting
-	(*AgentPluginSetting)(nil),                                       // 13: bytebase.v1.AgentPluginSetting
-	(*WorkspaceProfileSetting)(nil),                                  // 14: bytebase.v1.WorkspaceProfileSetting
-	(*Announcement)(nil),                                             // 15: bytebase.v1.Announcement
-	(*WorkspaceApprovalSetting)(nil),                                 // 16: bytebase.v1.WorkspaceApprovalSetting
-	(*ExternalApprovalSetting)(nil),                                  // 17: bytebase.v1.ExternalApprovalSetting
-	(*SchemaTemplateSetting)(nil),                                    // 18: bytebase.v1.SchemaTemplateSetting
-	(*WorkspaceTrialSetting)(nil),                                    // 19: bytebase.v1.WorkspaceTrialSetting
-	(*DataClassificationSetting)(nil),                                // 20: bytebase.v1.DataClassificationSetting
-	(*SemanticTypesSetting)(nil),                                     // 21: bytebase.v1.SemanticTypesSetting
-	(*MaskingAlgorithmSetting)(nil),                                  // 22: bytebase.v1.MaskingAlgorithmSetting
-	(*AppIMSetting_ExternalApproval)(nil),                            // 23: bytebase.v1.AppIMSetting.ExternalApproval
-	(*WorkspaceApprovalSetting_Rule)(nil),                            // 24: bytebase.v1.WorkspaceApprovalSetting.Rule
-	(*ExternalApprovalSetting_Node)(nil),                             // 25: bytebase.v1.ExternalApprovalSetting.Node
-	(*SchemaTemplateSetting_FieldTemplate)(nil),                      // 26: bytebase.v1.SchemaTemplateSetting.FieldTemplate
-	(*SchemaTemplateSetting_ColumnType)(nil),                         // 27: bytebase.v1.SchemaTemplateSetting.ColumnType
-	(*SchemaTemplateSetting_TableTemplate)(nil),                      // 28: bytebase.v1.SchemaTemplateSetting.TableTemplate
-	(*DataClassificationSetting_DataClassificationConfig)(nil),       // 29: bytebase.v1.DataClassificationSetting.DataClassificationConfig
-	(*DataClassificationSetting_DataClassificationConfig_Level)(nil), // 30: bytebase.v1.DataClassificationSetting.DataClassificationConfig.Level
-	(*DataClassificationSetting_DataClassificationConfig_DataClassification)(nil), // 31: bytebase.v1.DataClassificationSetting.DataClassificationConfig.DataClassification
-	nil, // 32: bytebase.v1.DataClassificationSetting.DataClassificationConfig.ClassificationEntry
-	(*SemanticTypesSetting_SemanticType)(nil),        // 33: bytebase.v1.SemanticTypesSetting.SemanticType
-	(*MaskingAlgorithmSetting_MaskingAlgorithm)(nil), // 34: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm
-	(*durationpb.Duration)(nil),                      // 35: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil),                    // 36: google.protobuf.Timestamp
-	(PlanType)(0),                                    // 37: bytebase.v1.PlanType
-	(*ApprovalTemplate)(nil),                         // 38: bytebase.v1.ApprovalTemplate
-	(*expr.Expr)(nil),                                // 39: google.type.Expr
-	(Engine)(0),                                      // 40: bytebase.v1.Engine
-	(*ColumnMetadata)(nil),                           // 41: bytebase.v1.ColumnMetadata
-	(*TableMetadata)(nil),                            // 42: bytebase.v1.TableMetadata
+	(SMTPMailDeliverySettingValue_Encryption)(0),                                  // 0: bytebase.v1.SMTPMailDeliverySettingValue.Encryption
+	(SMTPMailDeliverySettingValue_Authentication)(0),                              // 1: bytebase.v1.SMTPMailDeliverySettingValue.Authentication
+	(AppIMSetting_IMType)(0),                                                      // 2: bytebase.v1.AppIMSetting.IMType
+	(Announcement_AlertLevel)(0),                                                  // 3: bytebase.v1.Announcement.AlertLevel
+	(MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_HashType)(0),               // 4: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.HashMask.HashType
+	(MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_MaskType)(0),         // 5: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.InnerOuterMask.MaskType
+	(MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_TimeUnit)(0),     // 6: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.GeneralizationMask.TimeUnit
+	(*ListSettingsRequest)(nil),                                                   // 7: bytebase.v1.ListSettingsRequest
+	(*ListSettingsResponse)(nil),                                                  // 8: bytebase.v1.ListSettingsResponse
+	(*GetSettingRequest)(nil),                                                     // 9: bytebase.v1.GetSettingRequest
+	(*GetSettingResponse)(nil),                                                    // 10: bytebase.v1.GetSettingResponse
+	(*SetSettingRequest)(nil),                                                     // 11: bytebase.v1.SetSettingRequest
+	(*Setting)(nil),                                                               // 12: bytebase.v1.Setting
+	(*Value)(nil),                                                                 // 13: bytebase.v1.Value
+	(*SMTPMailDeliverySettingValue)(nil),                                          // 14: bytebase.v1.SMTPMailDeliverySettingValue
+	(*AppIMSetting)(nil),                                                          // 15: bytebase.v1.AppIMSetting
+	(*AgentPluginSetting)(nil),                                                    // 16: bytebase.v1.AgentPluginSetting
+	(*WorkspaceProfileSetting)(nil),                                               // 17: bytebase.v1.WorkspaceProfileSetting
+	(*Announcement)(nil),                                                          // 18: bytebase.v1.Announcement
+	(*WorkspaceApprovalSetting)(nil),                                              // 19: bytebase.v1.WorkspaceApprovalSetting
+	(*ExternalApprovalSetting)(nil),                                               // 20: bytebase.v1.ExternalApprovalSetting
+	(*SchemaTemplateSetting)(nil),                                                 // 21: bytebase.v1.SchemaTemplateSetting
+	(*WorkspaceTrialSetting)(nil),                                                 // 22: bytebase.v1.WorkspaceTrialSetting
+	(*DataClassificationSetting)(nil),                                             // 23: bytebase.v1.DataClassificationSetting
+	(*SemanticTypesSetting)(nil),                                                  // 24: bytebase.v1.SemanticTypesSetting
+	(*MaskingAlgorithmSetting)(nil),                                               // 25: bytebase.v1.MaskingAlgorithmSetting
+	(*AppIMSetting_ExternalApproval)(nil),                                         // 26: bytebase.v1.AppIMSetting.ExternalApproval
+	(*WorkspaceApprovalSetting_Rule)(nil),                                         // 27: bytebase.v1.WorkspaceApprovalSetting.Rule
+	(*ExternalApprovalSetting_Node)(nil),                                          // 28: bytebase.v1.ExternalApprovalSetting.Node
+	(*SchemaTemplateSetting_FieldTemplate)(nil),                                   // 29: bytebase.v1.SchemaTemplateSetting.FieldTemplate
+	(*SchemaTemplateSetting_ColumnType)(nil),                                      // 30: bytebase.v1.SchemaTemplateSetting.ColumnType
+	(*SchemaTemplateSetting_TableTemplate)(nil),                                   // 31: bytebase.v1.SchemaTemplateSetting.TableTemplate
+	(*DataClassificationSetting_DataClassificationConfig)(nil),                    // 32: bytebase.v1.DataClassificationSetting.DataClassificationConfig
+	(*DataClassificationSetting_DataClassificationConfig_Level)(nil),              // 33: bytebase.v1.DataClassificationSetting.DataClassificationConfig.Level
+	(*DataClassificationSetting_DataClassificationConfig_DataClassification)(nil), // 34: bytebase.v1.DataClassificationSetting.DataClassificationConfig.DataClassification
+	nil, // 35: bytebase.v1.DataClassificationSetting.DataClassificationConfig.ClassificationEntry
+	(*SemanticTypesSetting_SemanticType)(nil),                           // 36: bytebase.v1.SemanticTypesSetting.SemanticType
+	(*MaskingAlgorithmSetting_MaskingAlgorithm)(nil),                    // 37: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm
+	(*MaskingAlgorithmSetting_MaskingAlgorithm_FullMask)(nil),           // 38: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.FullMask
+	(*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask)(nil),          // 39: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.RangeMask
+	(*MaskingAlgorithmSetting_MaskingAlgorithm_HashMask)(nil),           // 40: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.HashMask
+	(*MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask)(nil),     // 41: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.InnerOuterMask
+	(*MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask)(nil), // 42: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.GeneralizationMask
+	(*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice)(nil),    // 43: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.RangeMask.Slice
+	(*durationpb.Duration)(nil),                                         // 44: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),                                       // 45: google.protobuf.Timestamp
+	(PlanType)(0),                                                       // 46: bytebase.v1.PlanType
+	(*ApprovalTemplate)(nil),                                            // 47: bytebase.v1.ApprovalTemplate
+	(*expr.Expr)(nil),                                                   // 48: google.type.Expr
+	(Engine)(0),                                                         // 49: bytebase.v1.Engine
+	(*ColumnMetadata)(nil),                                              // 50: bytebase.v1.ColumnMetadata
+	(*TableMetadata)(nil),                                               // 51: bytebase.v1.TableMetadata
 }
 var file_v1_setting_service_proto_depIdxs = []int32{
-	9,  // 0: bytebase.v1.ListSettingsResponse.settings:type_name -> bytebase.v1.Setting
-	9,  // 1: bytebase.v1.GetSettingResponse.setting:type_name -> bytebase.v1.Setting
-	9,  // 2: bytebase.v1.SetSettingRequest.setting:type_name -> bytebase.v1.Setting
-	10, // 3: bytebase.v1.Setting.value:type_name -> bytebase.v1.Value
-	11, // 4: bytebase.v1.Value.smtp_mail_delivery_setting_value:type_name -> bytebase.v1.SMTPMailDeliverySettingValue
-	12, // 5: bytebase.v1.Value.app_im_setting_value:type_name -> bytebase.v1.AppIMSetting
-	13, // 6: bytebase.v1.Value.agent_plugin_setting_value:type_name -> bytebase.v1.AgentPluginSetting
-	14, // 7: bytebase.v1.Value.workspace_profile_setting_value:type_name -> bytebase.v1.WorkspaceProfileSetting
-	16, // 8: bytebase.v1.Value.workspace_approval_setting_value:type_name -> bytebase.v1.WorkspaceApprovalSetting
-	19, // 9: bytebase.v1.Value.workspace_trial_setting_value:type_name -> bytebase.v1.WorkspaceTrialSetting
-	17, // 10: bytebase.v1.Value.external_approval_setting_value:type_name -> bytebase.v1.ExternalApprovalSetting
-	18, // 11: bytebase.v1.Value.schema_template_setting_value:type_name -> bytebase.v1.SchemaTemplateSetting
-	20, // 12: bytebase.v1.Value.data_classification_setting_value:type_name -> bytebase.v1.DataClassificationSetting
-	21, // 13: bytebase.v1.Value.semantic_types_setting_value:type_name -> bytebase.v1.SemanticTypesSetting
-	22, // 14: bytebase.v1.Value.masking_algorithm_setting_value:type_name -> bytebase.v1.MaskingAlgorithmSetting
+	12, // 0: bytebase.v1.ListSettingsResponse.settings:type_name -> bytebase.v1.Setting
+	12, // 1: bytebase.v1.GetSettingResponse.setting:type_name -> bytebase.v1.Setting
+	12, // 2: bytebase.v1.SetSettingRequest.setting:type_name -> bytebase.v1.Setting
+	13, // 3: bytebase.v1.Setting.value:type_name -> bytebase.v1.Value
+	14, // 4: bytebase.v1.Value.smtp_mail_delivery_setting_value:type_name -> bytebase.v1.SMTPMailDeliverySettingValue
+	15, // 5: bytebase.v1.Value.app_im_setting_value:type_name -> bytebase.v1.AppIMSetting
+	16, // 6: bytebase.v1.Value.agent_plugin_setting_value:type_name -> bytebase.v1.AgentPluginSetting
+	17, // 7: bytebase.v1.Value.workspace_profile_setting_value:type_name -> bytebase.v1.WorkspaceProfileSetting
+	19, // 8: bytebase.v1.Value.workspace_approval_setting_value:type_name -> bytebase.v1.WorkspaceApprovalSetting
+	22, // 9: bytebase.v1.Value.workspace_trial_setting_value:type_name -> bytebase.v1.WorkspaceTrialSetting
+	20, // 10: bytebase.v1.Value.external_approval_setting_value:type_name -> bytebase.v1.ExternalApprovalSetting
+	21, // 11: bytebase.v1.Value.schema_template_setting_value:type_name -> bytebase.v1.SchemaTemplateSetting
+	23, // 12: bytebase.v1.Value.data_classification_setting_value:type_name -> bytebase.v1.DataClassificationSetting
+	24, // 13: bytebase.v1.Value.semantic_types_setting_value:type_name -> bytebase.v1.SemanticTypesSetting
+	25, // 14: bytebase.v1.Value.masking_algorithm_setting_value:type_name -> bytebase.v1.MaskingAlgorithmSetting
 	0,  // 15: bytebase.v1.SMTPMailDeliverySettingValue.encryption:type_name -> bytebase.v1.SMTPMailDeliverySettingValue.Encryption
 	1,  // 16: bytebase.v1.SMTPMailDeliverySettingValue.authentication:type_name -> bytebase.v1.SMTPMailDeliverySettingValue.Authentication
 	2,  // 17: bytebase.v1.AppIMSetting.im_type:type_name -> bytebase.v1.AppIMSetting.IMType
-	23, // 18: bytebase.v1.AppIMSetting.external_approval:type_name -> bytebase.v1.AppIMSetting.ExternalApproval
-	35, // 19: bytebase.v1.WorkspaceProfileSetting.token_duration:type_name -> google.protobuf.Duration
-	15, // 20: bytebase.v1.WorkspaceProfileSetting.announcement:type_name -> bytebase.v1.Announcement
+	26, // 18: bytebase.v1.AppIMSetting.external_approval:type_name -> bytebase.v1.AppIMSetting.ExternalApproval
+	44, // 19: bytebase.v1.WorkspaceProfileSetting.token_duration:type_name -> google.protobuf.Duration
+	18, // 20: bytebase.v1.WorkspaceProfileSetting.announcement:type_name -> bytebase.v1.Announcement
 	3,  // 21: bytebase.v1.Announcement.level:type_name -> bytebase.v1.Announcement.AlertLevel
-	24, // 22: bytebase.v1.WorkspaceApprovalSetting.rules:type_name -> bytebase.v1.WorkspaceApprovalSetting.Rule
-	25, // 23: bytebase.v1.ExternalApprovalSetting.nodes:type_name -> bytebase.v1.ExternalApprovalSetting.Node
-	26, // 24: bytebase.v1.SchemaTemplateSetting.field_templates:type_name -> bytebase.v1.SchemaTemplateSetting.FieldTemplate
-	27, // 25: bytebase.v1.SchemaTemplateSetting.column_types:type_name -> bytebase.v1.SchemaTemplateSetting.ColumnType
-	28, // 26: bytebase.v1.SchemaTemplateSetting.table_templates:type_name -> bytebase.v1.SchemaTemplateSetting.TableTemplate
-	36, // 27: bytebase.v1.WorkspaceTrialSetting.expire_time:type_name -> google.protobuf.Timestamp
-	36, // 28: bytebase.v1.WorkspaceTrialSetting.issued_time:type_name -> google.protobuf.Timestamp
-	37, // 29: bytebase.v1.WorkspaceTrialSetting.plan:type_name -> bytebase.v1.PlanType
-	29, // 30: bytebase.v1.DataClassificationSetting.configs:type_name -> bytebase.v1.DataClassificationSetting.DataClassificationConfig
-	33, // 31: bytebase.v1.SemanticTypesSetting.types:type_name -> bytebase.v1.SemanticTypesSetting.SemanticType
-	34, // 32: bytebase.v1.MaskingAlgorithmSetting.algorithms:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm
-	38, // 33: bytebase.v1.WorkspaceApprovalSetting.Rule.template:type_name -> bytebase.v1.ApprovalTemplate
-	39, // 34: bytebase.v1.WorkspaceApprovalSetting.Rule.condition:type_name -> google.type.Expr
-	40, // 35: bytebase.v1.SchemaTemplateSetting.FieldTemplate.engine:type_name -> bytebase.v1.Engine
-	41, // 36: bytebase.v1.SchemaTemplateSetting.FieldTemplate.column:type_name -> bytebase.v1.ColumnMetadata
-	40, // 37: bytebase.v1.SchemaTemplateSetting.ColumnType.engine:type_name -> bytebase.v1.Engine
-	40, // 38: bytebase.v1.SchemaTemplateSetting.TableTemplate.engine:type_name -> bytebase.v1.Engine
-	42, // 39: bytebase.v1.SchemaTemplateSetting.TableTemplate.table:type_name -> bytebase.v1.TableMetadata
-	30, // 40: bytebase.v1.DataClassificationSetting.DataClassificationConfig.levels:type_name -> bytebase.v1.DataClassificationSetting.DataClassificationConfig.Level
-	32, // 41: bytebase.v1.DataClassificationSetting.DataClassificationConfig.classification:type_name -> bytebase.v1.DataClassificationSetting.DataClassificationConfig.ClassificationEntry
-	31, // 42: bytebase.v1.DataClassificationSetting.DataClassificationConfig.ClassificationEntry.value:type_name -> bytebase.v1.DataClassificationSetting.DataClassificationConfig.DataClassification
-	4,  // 43: bytebase.v1.SettingService.ListSettings:input_type -> bytebase.v1.ListSettingsRequest
-	6,  // 44: bytebase.v1.SettingService.GetSetting:input_type -> bytebase.v1.GetSettingRequest
-	8,  // 45: bytebase.v1.SettingService.SetSetting:input_type -> bytebase.v1.SetSettingRequest
-	5,  // 46: bytebase.v1.SettingService.ListSettings:output_type -> bytebase.v1.ListSettingsResponse
-	9,  // 47: bytebase.v1.SettingService.GetSetting:output_type -> bytebase.v1.Setting
-	9,  // 48: bytebase.v1.SettingService.SetSetting:output_type -> bytebase.v1.Setting
-	46, // [46:49] is the sub-list for method output_type
-	43, // [43:46] is the sub-list for method input_type
-	43, // [43:43] is the sub-list for extension type_name
-	43, // [43:43] is the sub-list for extension extendee
-	0,  // [0:43] is the sub-list for field type_name
+	27, // 22: bytebase.v1.WorkspaceApprovalSetting.rules:type_name -> bytebase.v1.WorkspaceApprovalSetting.Rule
+	28, // 23: bytebase.v1.ExternalApprovalSetting.nodes:type_name -> bytebase.v1.ExternalApprovalSetting.Node
+	29, // 24: bytebase.v1.SchemaTemplateSetting.field_templates:type_name -> bytebase.v1.SchemaTemplateSetting.FieldTemplate
+	30, // 25: bytebase.v1.SchemaTemplateSetting.column_types:type_name -> bytebase.v1.SchemaTemplateSetting.ColumnType
+	31, // 26: bytebase.v1.SchemaTemplateSetting.table_templates:type_name -> bytebase.v1.SchemaTemplateSetting.TableTemplate
+	45, // 27: bytebase.v1.WorkspaceTrialSetting.expire_time:type_name -> google.protobuf.Timestamp
+	45, // 28: bytebase.v1.WorkspaceTrialSetting.issued_time:type_name -> google.protobuf.Timestamp
+	46, // 29: bytebase.v1.WorkspaceTrialSetting.plan:type_name -> bytebase.v1.PlanType
+	32, // 30: bytebase.v1.DataClassificationSetting.configs:type_name -> bytebase.v1.DataClassificationSetting.DataClassificationConfig
+	36, // 31: bytebase.v1.SemanticTypesSetting.types:type_name -> bytebase.v1.SemanticTypesSetting.SemanticType
+	37, // 32: bytebase.v1.MaskingAlgorithmSetting.algorithms:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm
+	47, // 33: bytebase.v1.WorkspaceApprovalSetting.Rule.template:type_name -> bytebase.v1.ApprovalTemplate
+	48, // 34: bytebase.v1.WorkspaceApprovalSetting.Rule.condition:type_name -> google.type.Expr
+	49, // 35: bytebase.v1.SchemaTemplateSetting.FieldTemplate.engine:type_name -> bytebase.v1.Engine
+	50, // 36: bytebase.v1.SchemaTemplateSetting.FieldTemplate.column:type_name -> bytebase.v1.ColumnMetadata
+	49, // 37: bytebase.v1.SchemaTemplateSetting.ColumnType.engine:type_name -> bytebase.v1.Engine
+	49, // 38: bytebase.v1.SchemaTemplateSetting.TableTemplate.engine:type_name -> bytebase.v1.Engine
+	51, // 39: bytebase.v1.SchemaTemplateSetting.TableTemplate.table:type_name -> bytebase.v1.TableMetadata
+	33, // 40: bytebase.v1.DataClassificationSetting.DataClassificationConfig.levels:type_name -> bytebase.v1.DataClassificationSetting.DataClassificationConfig.Level
+	35, // 41: bytebase.v1.DataClassificationSetting.DataClassificationConfig.classification:type_name -> bytebase.v1.DataClassificationSetting.DataClassificationConfig.ClassificationEntry
+	34, // 42: bytebase.v1.DataClassificationSetting.DataClassificationConfig.ClassificationEntry.value:type_name -> bytebase.v1.DataClassificationSetting.DataClassificationConfig.DataClassification
+	38, // 43: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.full_mask:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.FullMask
+	39, // 44: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.range_mask:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.RangeMask
+	40, // 45: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.hash_mask:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.HashMask
+	41, // 46: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.inner_outer_mask:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.InnerOuterMask
+	42, // 47: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.generalization_mask:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.GeneralizationMask
+	43, // 48: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.RangeMask.slices:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.RangeMask.Slice
+	4,  // 49: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.HashMask.type:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.HashMask.HashType
+	5,  // 50: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.InnerOuterMask.type:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.InnerOuterMask.MaskType
+	6,  // 51: bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.GeneralizationMask.time_unit:type_name -> bytebase.v1.MaskingAlgorithmSetting.MaskingAlgorithm.GeneralizationMask.TimeUnit
+	7,  // 52: bytebase.v1.SettingService.ListSettings:input_type -> bytebase.v1.ListSettingsRequest
+	9,  // 53: bytebase.v1.SettingService.GetSetting:input_type -> bytebase.v1.GetSettingRequest
+	11, // 54: bytebase.v1.SettingService.SetSetting:input_type -> bytebase.v1.SetSettingRequest
+	8,  // 55: bytebase.v1.SettingService.ListSettings:output_type -> bytebase.v1.ListSettingsResponse
+	12, // 56: bytebase.v1.SettingService.GetSetting:output_type -> bytebase.v1.Setting
+	12, // 57: bytebase.v1.SettingService.SetSetting:output_type -> bytebase.v1.Setting
+	55, // [55:58] is the sub-list for method output_type
+	52, // [52:55] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_v1_setting_service_proto_init() }
@@ -3277,6 +3981,78 @@ func file_v1_setting_service_proto_init() {
 				return nil
 			}
 		}
+		file_v1_setting_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaskingAlgorithmSetting_MaskingAlgorithm_FullMask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_setting_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_setting_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaskingAlgorithmSetting_MaskingAlgorithm_HashMask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_setting_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_setting_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_v1_setting_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_Slice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_v1_setting_service_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*Value_StringValue)(nil),
@@ -3294,13 +4070,20 @@ func file_v1_setting_service_proto_init() {
 	}
 	file_v1_setting_service_proto_msgTypes[7].OneofWrappers = []interface{}{}
 	file_v1_setting_service_proto_msgTypes[27].OneofWrappers = []interface{}{}
+	file_v1_setting_service_proto_msgTypes[30].OneofWrappers = []interface{}{
+		(*MaskingAlgorithmSetting_MaskingAlgorithm_FullMask_)(nil),
+		(*MaskingAlgorithmSetting_MaskingAlgorithm_RangeMask_)(nil),
+		(*MaskingAlgorithmSetting_MaskingAlgorithm_HashMask_)(nil),
+		(*MaskingAlgorithmSetting_MaskingAlgorithm_InnerOuterMask_)(nil),
+		(*MaskingAlgorithmSetting_MaskingAlgorithm_GeneralizationMask_)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_v1_setting_service_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   31,
+			NumEnums:      7,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
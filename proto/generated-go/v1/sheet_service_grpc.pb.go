@@ -27,6 +27,10 @@ const (
 	SheetService_UpdateSheetOrganizer_FullMethodName = "/bytebase.v1.SheetService/UpdateSheetOrganizer"
 	SheetService_DeleteSheet_FullMethodName          = "/bytebase.v1.SheetService/DeleteSheet"
 	SheetService_SyncSheets_FullMethodName           = "/bytebase.v1.SheetService/SyncSheets"
+	SheetService_ListSheetRevisions_FullMethodName   = "/bytebase.v1.SheetService/ListSheetRevisions"
+	SheetService_GetSheetRevision_FullMethodName     = "/bytebase.v1.SheetService/GetSheetRevision"
+	SheetService_DiffSheetRevisions_FullMethodName   = "/bytebase.v1.SheetService/DiffSheetRevisions"
+	SheetService_RestoreSheetRevision_FullMethodName = "/bytebase.v1.SheetService/RestoreSheetRevision"
 )
 
 // SheetServiceClient is the client API for SheetService service.
@@ -40,6 +44,13 @@ type SheetServiceClient interface {
 	UpdateSheetOrganizer(ctx context.Context, in *UpdateSheetOrganizerRequest, opts ...grpc.CallOption) (*SheetOrganizer, error)
 	DeleteSheet(ctx context.Context, in *DeleteSheetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SyncSheets(ctx context.Context, in *SyncSheetsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSheetRevisions(ctx context.Context, in *ListSheetRevisionsRequest, opts ...grpc.CallOption) (*ListSheetRevisionsResponse, error)
+	GetSheetRevision(ctx context.Context, in *GetSheetRevisionRequest, opts ...grpc.CallOption) (*SheetRevision, error)
+	// DiffSheetRevisions returns the unified diff between two revisions of a sheet.
+	DiffSheetRevisions(ctx context.Context, in *DiffSheetRevisionsRequest, opts ...grpc.CallOption) (*DiffSheetRevisionsResponse, error)
+	// RestoreSheetRevision restores the content of the sheet to a revision.
+	// The restored content is appended as a new revision, so the history is never rewritten.
+	RestoreSheetRevision(ctx context.Context, in *RestoreSheetRevisionRequest, opts ...grpc.CallOption) (*Sheet, error)
 }
 
 type sheetServiceClient struct {
@@ -113,6 +124,42 @@ func (c *sheetServiceClient) SyncSheets(ctx context.Context, in *SyncSheetsReque
 	return out, nil
 }
 
+func (c *sheetServiceClient) ListSheetRevisions(ctx context.Context, in *ListSheetRevisionsRequest, opts ...grpc.CallOption) (*ListSheetRevisionsResponse, error) {
+	out := new(ListSheetRevisionsResponse)
+	err := c.cc.Invoke(ctx, SheetService_ListSheetRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheetServiceClient) GetSheetRevision(ctx context.Context, in *GetSheetRevisionRequest, opts ...grpc.CallOption) (*SheetRevision, error) {
+	out := new(SheetRevision)
+	err := c.cc.Invoke(ctx, SheetService_GetSheetRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheetServiceClient) DiffSheetRevisions(ctx context.Context, in *DiffSheetRevisionsRequest, opts ...grpc.CallOption) (*DiffSheetRevisionsResponse, error) {
+	out := new(DiffSheetRevisionsResponse)
+	err := c.cc.Invoke(ctx, SheetService_DiffSheetRevisions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sheetServiceClient) RestoreSheetRevision(ctx context.Context, in *RestoreSheetRevisionRequest, opts ...grpc.CallOption) (*Sheet, error) {
+	out := new(Sheet)
+	err := c.cc.Invoke(ctx, SheetService_RestoreSheetRevision_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SheetServiceServer is the server API for SheetService service.
 // All implementations must embed UnimplementedSheetServiceServer
 // for forward compatibility
@@ -124,6 +171,13 @@ type SheetServiceServer interface {
 	UpdateSheetOrganizer(context.Context, *UpdateSheetOrganizerRequest) (*SheetOrganizer, error)
 	DeleteSheet(context.Context, *DeleteSheetRequest) (*emptypb.Empty, error)
 	SyncSheets(context.Context, *SyncSheetsRequest) (*emptypb.Empty, error)
+	ListSheetRevisions(context.Context, *ListSheetRevisionsRequest) (*ListSheetRevisionsResponse, error)
+	GetSheetRevision(context.Context, *GetSheetRevisionRequest) (*SheetRevision, error)
+	// DiffSheetRevisions returns the unified diff between two revisions of a sheet.
+	DiffSheetRevisions(context.Context, *DiffSheetRevisionsRequest) (*DiffSheetRevisionsResponse, error)
+	// RestoreSheetRevision restores the content of the sheet to a revision.
+	// The restored content is appended as a new revision, so the history is never rewritten.
+	RestoreSheetRevision(context.Context, *RestoreSheetRevisionRequest) (*Sheet, error)
 	mustEmbedUnimplementedSheetServiceServer()
 }
 
@@ -152,6 +206,18 @@ func (UnimplementedSheetServiceServer) DeleteSheet(context.Context, *DeleteSheet
 func (UnimplementedSheetServiceServer) SyncSheets(context.Context, *SyncSheetsRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SyncSheets not implemented")
 }
+func (UnimplementedSheetServiceServer) ListSheetRevisions(context.Context, *ListSheetRevisionsRequest) (*ListSheetRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSheetRevisions not implemented")
+}
+func (UnimplementedSheetServiceServer) GetSheetRevision(context.Context, *GetSheetRevisionRequest) (*SheetRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSheetRevision not implemented")
+}
+func (UnimplementedSheetServiceServer) DiffSheetRevisions(context.Context, *DiffSheetRevisionsRequest) (*DiffSheetRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffSheetRevisions not implemented")
+}
+func (UnimplementedSheetServiceServer) RestoreSheetRevision(context.Context, *RestoreSheetRevisionRequest) (*Sheet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSheetRevision not implemented")
+}
 func (UnimplementedSheetServiceServer) mustEmbedUnimplementedSheetServiceServer() {}
 
 // UnsafeSheetServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -291,6 +357,78 @@ func _SheetService_SyncSheets_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SheetService_ListSheetRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSheetRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheetServiceServer).ListSheetRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SheetService_ListSheetRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheetServiceServer).ListSheetRevisions(ctx, req.(*ListSheetRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheetService_GetSheetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSheetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheetServiceServer).GetSheetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SheetService_GetSheetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheetServiceServer).GetSheetRevision(ctx, req.(*GetSheetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheetService_DiffSheetRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffSheetRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheetServiceServer).DiffSheetRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SheetService_DiffSheetRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheetServiceServer).DiffSheetRevisions(ctx, req.(*DiffSheetRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SheetService_RestoreSheetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSheetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SheetServiceServer).RestoreSheetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SheetService_RestoreSheetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SheetServiceServer).RestoreSheetRevision(ctx, req.(*RestoreSheetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SheetService_ServiceDesc is the grpc.ServiceDesc for SheetService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -326,6 +464,22 @@ var SheetService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SyncSheets",
 			Handler:    _SheetService_SyncSheets_Handler,
 		},
+		{
+			MethodName: "ListSheetRevisions",
+			Handler:    _SheetService_ListSheetRevisions_Handler,
+		},
+		{
+			MethodName: "GetSheetRevision",
+			Handler:    _SheetService_GetSheetRevision_Handler,
+		},
+		{
+			MethodName: "DiffSheetRevisions",
+			Handler:    _SheetService_DiffSheetRevisions_Handler,
+		},
+		{
+			MethodName: "RestoreSheetRevision",
+			Handler:    _SheetService_RestoreSheetRevision_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "v1/sheet_service.proto",
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -19,12 +20,21 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SQLService_Pretty_FullMethodName        = "/bytebase.v1.SQLService/Pretty"
-	SQLService_Query_FullMethodName         = "/bytebase.v1.SQLService/Query"
-	SQLService_Export_FullMethodName        = "/bytebase.v1.SQLService/Export"
-	SQLService_AdminExecute_FullMethodName  = "/bytebase.v1.SQLService/AdminExecute"
-	SQLService_DifferPreview_FullMethodName = "/bytebase.v1.SQLService/DifferPreview"
-	SQLService_Check_FullMethodName         = "/bytebase.v1.SQLService/Check"
+	SQLService_Pretty_FullMethodName              = "/bytebase.v1.SQLService/Pretty"
+	SQLService_Query_FullMethodName               = "/bytebase.v1.SQLService/Query"
+	SQLService_Export_FullMethodName              = "/bytebase.v1.SQLService/Export"
+	SQLService_AdminExecute_FullMethodName        = "/bytebase.v1.SQLService/AdminExecute"
+	SQLService_DifferPreview_FullMethodName       = "/bytebase.v1.SQLService/DifferPreview"
+	SQLService_Check_FullMethodName               = "/bytebase.v1.SQLService/Check"
+	SQLService_CreateSession_FullMethodName       = "/bytebase.v1.SQLService/CreateSession"
+	SQLService_GetSession_FullMethodName          = "/bytebase.v1.SQLService/GetSession"
+	SQLService_DeleteSession_FullMethodName       = "/bytebase.v1.SQLService/DeleteSession"
+	SQLService_BeginTransaction_FullMethodName    = "/bytebase.v1.SQLService/BeginTransaction"
+	SQLService_CommitTransaction_FullMethodName   = "/bytebase.v1.SQLService/CommitTransaction"
+	SQLService_RollbackTransaction_FullMethodName = "/bytebase.v1.SQLService/RollbackTransaction"
+	SQLService_CreateExportJob_FullMethodName     = "/bytebase.v1.SQLService/CreateExportJob"
+	SQLService_GetExportJob_FullMethodName        = "/bytebase.v1.SQLService/GetExportJob"
+	SQLService_CancelExportJob_FullMethodName     = "/bytebase.v1.SQLService/CancelExportJob"
 )
 
 // SQLServiceClient is the client API for SQLService service.
@@ -37,6 +47,15 @@ type SQLServiceClient interface {
 	AdminExecute(ctx context.Context, opts ...grpc.CallOption) (SQLService_AdminExecuteClient, error)
 	DifferPreview(ctx context.Context, in *DifferPreviewRequest, opts ...grpc.CallOption) (*DifferPreviewResponse, error)
 	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error)
+	DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	BeginTransaction(ctx context.Context, in *BeginTransactionRequest, opts ...grpc.CallOption) (*Session, error)
+	CommitTransaction(ctx context.Context, in *CommitTransactionRequest, opts ...grpc.CallOption) (*Session, error)
+	RollbackTransaction(ctx context.Context, in *RollbackTransactionRequest, opts ...grpc.CallOption) (*Session, error)
+	CreateExportJob(ctx context.Context, in *CreateExportJobRequest, opts ...grpc.CallOption) (*ExportJob, error)
+	GetExportJob(ctx context.Context, in *GetExportJobRequest, opts ...grpc.CallOption) (*ExportJob, error)
+	CancelExportJob(ctx context.Context, in *CancelExportJobRequest, opts ...grpc.CallOption) (*ExportJob, error)
 }
 
 type sQLServiceClient struct {
@@ -123,6 +142,87 @@ func (c *sQLServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...
 	return out, nil
 }
 
+func (c *sQLServiceClient) CreateSession(ctx context.Context, in *CreateSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, SQLService_CreateSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQLServiceClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, SQLService_GetSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQLServiceClient) DeleteSession(ctx context.Context, in *DeleteSessionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SQLService_DeleteSession_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQLServiceClient) BeginTransaction(ctx context.Context, in *BeginTransactionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, SQLService_BeginTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQLServiceClient) CommitTransaction(ctx context.Context, in *CommitTransactionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, SQLService_CommitTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQLServiceClient) RollbackTransaction(ctx context.Context, in *RollbackTransactionRequest, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, SQLService_RollbackTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQLServiceClient) CreateExportJob(ctx context.Context, in *CreateExportJobRequest, opts ...grpc.CallOption) (*ExportJob, error) {
+	out := new(ExportJob)
+	err := c.cc.Invoke(ctx, SQLService_CreateExportJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQLServiceClient) GetExportJob(ctx context.Context, in *GetExportJobRequest, opts ...grpc.CallOption) (*ExportJob, error) {
+	out := new(ExportJob)
+	err := c.cc.Invoke(ctx, SQLService_GetExportJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sQLServiceClient) CancelExportJob(ctx context.Context, in *CancelExportJobRequest, opts ...grpc.CallOption) (*ExportJob, error) {
+	out := new(ExportJob)
+	err := c.cc.Invoke(ctx, SQLService_CancelExportJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SQLServiceServer is the server API for SQLService service.
 // All implementations must embed UnimplementedSQLServiceServer
 // for forward compatibility
@@ -133,6 +233,15 @@ type SQLServiceServer interface {
 	AdminExecute(SQLService_AdminExecuteServer) error
 	DifferPreview(context.Context, *DifferPreviewRequest) (*DifferPreviewResponse, error)
 	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	CreateSession(context.Context, *CreateSessionRequest) (*Session, error)
+	GetSession(context.Context, *GetSessionRequest) (*Session, error)
+	DeleteSession(context.Context, *DeleteSessionRequest) (*emptypb.Empty, error)
+	BeginTransaction(context.Context, *BeginTransactionRequest) (*Session, error)
+	CommitTransaction(context.Context, *CommitTransactionRequest) (*Session, error)
+	RollbackTransaction(context.Context, *RollbackTransactionRequest) (*Session, error)
+	CreateExportJob(context.Context, *CreateExportJobRequest) (*ExportJob, error)
+	GetExportJob(context.Context, *GetExportJobRequest) (*ExportJob, error)
+	CancelExportJob(context.Context, *CancelExportJobRequest) (*ExportJob, error)
 	mustEmbedUnimplementedSQLServiceServer()
 }
 
@@ -158,6 +267,33 @@ func (UnimplementedSQLServiceServer) DifferPreview(context.Context, *DifferPrevi
 func (UnimplementedSQLServiceServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
+func (UnimplementedSQLServiceServer) CreateSession(context.Context, *CreateSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSession not implemented")
+}
+func (UnimplementedSQLServiceServer) GetSession(context.Context, *GetSessionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedSQLServiceServer) DeleteSession(context.Context, *DeleteSessionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
+}
+func (UnimplementedSQLServiceServer) BeginTransaction(context.Context, *BeginTransactionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginTransaction not implemented")
+}
+func (UnimplementedSQLServiceServer) CommitTransaction(context.Context, *CommitTransactionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitTransaction not implemented")
+}
+func (UnimplementedSQLServiceServer) RollbackTransaction(context.Context, *RollbackTransactionRequest) (*Session, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackTransaction not implemented")
+}
+func (UnimplementedSQLServiceServer) CreateExportJob(context.Context, *CreateExportJobRequest) (*ExportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExportJob not implemented")
+}
+func (UnimplementedSQLServiceServer) GetExportJob(context.Context, *GetExportJobRequest) (*ExportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExportJob not implemented")
+}
+func (UnimplementedSQLServiceServer) CancelExportJob(context.Context, *CancelExportJobRequest) (*ExportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelExportJob not implemented")
+}
 func (UnimplementedSQLServiceServer) mustEmbedUnimplementedSQLServiceServer() {}
 
 // UnsafeSQLServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -287,6 +423,168 @@ func _SQLService_Check_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SQLService_CreateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).CreateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_CreateSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).CreateSession(ctx, req.(*CreateSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQLService_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_GetSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).GetSession(ctx, req.(*GetSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQLService_DeleteSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).DeleteSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_DeleteSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).DeleteSession(ctx, req.(*DeleteSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQLService_BeginTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).BeginTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_BeginTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).BeginTransaction(ctx, req.(*BeginTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQLService_CommitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).CommitTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_CommitTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).CommitTransaction(ctx, req.(*CommitTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQLService_RollbackTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).RollbackTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_RollbackTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).RollbackTransaction(ctx, req.(*RollbackTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQLService_CreateExportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).CreateExportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_CreateExportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).CreateExportJob(ctx, req.(*CreateExportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQLService_GetExportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).GetExportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_GetExportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).GetExportJob(ctx, req.(*GetExportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SQLService_CancelExportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelExportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SQLServiceServer).CancelExportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SQLService_CancelExportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SQLServiceServer).CancelExportJob(ctx, req.(*CancelExportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SQLService_ServiceDesc is the grpc.ServiceDesc for SQLService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -314,6 +612,42 @@ var SQLService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Check",
 			Handler:    _SQLService_Check_Handler,
 		},
+		{
+			MethodName: "CreateSession",
+			Handler:    _SQLService_CreateSession_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _SQLService_GetSession_Handler,
+		},
+		{
+			MethodName: "DeleteSession",
+			Handler:    _SQLService_DeleteSession_Handler,
+		},
+		{
+			MethodName: "BeginTransaction",
+			Handler:    _SQLService_BeginTransaction_Handler,
+		},
+		{
+			MethodName: "CommitTransaction",
+			Handler:    _SQLService_CommitTransaction_Handler,
+		},
+		{
+			MethodName: "RollbackTransaction",
+			Handler:    _SQLService_RollbackTransaction_Handler,
+		},
+		{
+			MethodName: "CreateExportJob",
+			Handler:    _SQLService_CreateExportJob_Handler,
+		},
+		{
+			MethodName: "GetExportJob",
+			Handler:    _SQLService_GetExportJob_Handler,
+		},
+		{
+			MethodName: "CancelExportJob",
+			Handler:    _SQLService_CancelExportJob_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{